@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package client // import "go.opentelemetry.io/collector/client"
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// Standard AuthData attribute names. Authenticators exposing these claims should use
+// these names and types, so that consumers can read them with the typed accessors
+// independently of the authenticator in use.
+const (
+	// AuthAttributeSubject is the string identifying the authenticated principal.
+	AuthAttributeSubject = "subject"
+	// AuthAttributeIssuer is the string identifying the issuer of the credentials.
+	AuthAttributeIssuer = "issuer"
+	// AuthAttributeScopes is the []string of scopes granted to the principal.
+	AuthAttributeScopes = "scopes"
+	// AuthAttributeTenant is the string identifying the tenant of the principal.
+	AuthAttributeTenant = "tenant"
+)
+
+// StandardClaims contains the claims commonly provided by authenticators.
+type StandardClaims struct {
+	Subject string
+	Issuer  string
+	Scopes  []string
+	Tenant  string
+}
+
+// NewAuthData returns an AuthData exposing the non-empty standard claims under their
+// standard attribute names, along with the given additional attributes. The standard
+// claims take precedence over additional attributes with the same name.
+func NewAuthData(claims StandardClaims, attributes map[string]any) AuthData {
+	attrs := make(map[string]any, len(attributes)+4)
+	for k, v := range attributes {
+		attrs[k] = v
+	}
+	if claims.Subject != "" {
+		attrs[AuthAttributeSubject] = claims.Subject
+	}
+	if claims.Issuer != "" {
+		attrs[AuthAttributeIssuer] = claims.Issuer
+	}
+	if len(claims.Scopes) > 0 {
+		attrs[AuthAttributeScopes] = claims.Scopes
+	}
+	if claims.Tenant != "" {
+		attrs[AuthAttributeTenant] = claims.Tenant
+	}
+	return &authData{attrs: attrs}
+}
+
+// NewContextWithAuth returns a context carrying the client.Info of the given context, with its Auth
+// set to the given AuthData. Server authenticators typically return it from Authenticate, e.g. along
+// with NewAuthData, so that the receivers and the downstream components can read the identity of
+// the authenticated client.
+func NewContextWithAuth(ctx context.Context, ad AuthData) context.Context {
+	cl := FromContext(ctx)
+	cl.Auth = ad
+	return NewContext(ctx, cl)
+}
+
+type authData struct {
+	attrs map[string]any
+}
+
+func (ad *authData) GetAttribute(name string) any {
+	return ad.attrs[name]
+}
+
+func (ad *authData) GetAttributeNames() []string {
+	names := make([]string, 0, len(ad.attrs))
+	for name := range ad.attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Subject returns the AuthAttributeSubject claim of the AuthData, if present and a string.
+func Subject(ad AuthData) (string, bool) {
+	return stringAttribute(ad, AuthAttributeSubject)
+}
+
+// Issuer returns the AuthAttributeIssuer claim of the AuthData, if present and a string.
+func Issuer(ad AuthData) (string, bool) {
+	return stringAttribute(ad, AuthAttributeIssuer)
+}
+
+// Tenant returns the AuthAttributeTenant claim of the AuthData, if present and a string.
+func Tenant(ad AuthData) (string, bool) {
+	return stringAttribute(ad, AuthAttributeTenant)
+}
+
+// Scopes returns the AuthAttributeScopes claim of the AuthData. Besides a []string, the claim
+// may be a []any of strings, or a space-delimited string as used by OAuth 2.0.
+func Scopes(ad AuthData) ([]string, bool) {
+	if ad == nil {
+		return nil, false
+	}
+	switch v := ad.GetAttribute(AuthAttributeScopes).(type) {
+	case []string:
+		return v, true
+	case string:
+		return strings.Fields(v), true
+	case []any:
+		scopes := make([]string, 0, len(v))
+		for _, s := range v {
+			str, ok := s.(string)
+			if !ok {
+				return nil, false
+			}
+			scopes = append(scopes, str)
+		}
+		return scopes, true
+	}
+	return nil, false
+}
+
+func stringAttribute(ad AuthData, name string) (string, bool) {
+	if ad == nil {
+		return "", false
+	}
+	v, ok := ad.GetAttribute(name).(string)
+	return v, ok
+}
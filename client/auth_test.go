@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAuthData(t *testing.T) {
+	ad := NewAuthData(StandardClaims{
+		Subject: "user",
+		Issuer:  "https://issuer.example.org",
+		Scopes:  []string{"read", "write"},
+		Tenant:  "acme",
+	}, map[string]any{
+		"membership":         []string{"dev"},
+		AuthAttributeSubject: "overridden",
+	})
+	assert.Equal(t, []string{"issuer", "membership", "scopes", "subject", "tenant"}, ad.GetAttributeNames())
+	assert.Equal(t, []string{"dev"}, ad.GetAttribute("membership"))
+
+	subject, ok := Subject(ad)
+	assert.True(t, ok)
+	assert.Equal(t, "user", subject)
+	issuer, ok := Issuer(ad)
+	assert.True(t, ok)
+	assert.Equal(t, "https://issuer.example.org", issuer)
+	tenant, ok := Tenant(ad)
+	assert.True(t, ok)
+	assert.Equal(t, "acme", tenant)
+	scopes, ok := Scopes(ad)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"read", "write"}, scopes)
+
+	// Empty claims are not set.
+	ad = NewAuthData(StandardClaims{Subject: "user"}, nil)
+	assert.Equal(t, []string{"subject"}, ad.GetAttributeNames())
+	_, ok = Tenant(ad)
+	assert.False(t, ok)
+	_, ok = Scopes(ad)
+	assert.False(t, ok)
+}
+
+func TestClaimsAccessors(t *testing.T) {
+	_, ok := Subject(nil)
+	assert.False(t, ok)
+	_, ok = Scopes(nil)
+	assert.False(t, ok)
+
+	ad := NewAuthData(StandardClaims{}, map[string]any{
+		AuthAttributeSubject: 42,
+		AuthAttributeScopes:  "read  write",
+	})
+	_, ok = Subject(ad)
+	assert.False(t, ok)
+	scopes, ok := Scopes(ad)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"read", "write"}, scopes)
+
+	scopes, ok = Scopes(NewAuthData(StandardClaims{}, map[string]any{AuthAttributeScopes: []any{"read"}}))
+	assert.True(t, ok)
+	assert.Equal(t, []string{"read"}, scopes)
+	_, ok = Scopes(NewAuthData(StandardClaims{}, map[string]any{AuthAttributeScopes: []any{1}}))
+	assert.False(t, ok)
+}
+
+func TestNewContextWithAuth(t *testing.T) {
+	addr := &net.IPAddr{IP: net.IPv4(1, 2, 3, 4)}
+	ctx := NewContext(context.Background(), Info{Addr: addr})
+	ctx = NewContextWithAuth(ctx, NewAuthData(StandardClaims{Tenant: "acme"}, nil))
+
+	cl := FromContext(ctx)
+	assert.Equal(t, addr, cl.Addr)
+	tenant, ok := Tenant(cl.Auth)
+	assert.True(t, ok)
+	assert.Equal(t, "acme", tenant)
+}
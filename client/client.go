@@ -28,6 +28,10 @@
 // and storing a new client.Info into the context that it passes down. The
 // attribute names should be documented with their return types and considered
 // part of the public API for the authenticator.
+// Authenticators exposing a subject, issuer, scopes or tenant should do so
+// under the standard attribute names, for instance by using client.NewAuthData,
+// so that consumers can read them with the client.Subject, client.Issuer,
+// client.Scopes and client.Tenant accessors.
 //
 // # Consumers
 //
@@ -42,6 +46,8 @@
 //
 // - rate limit client calls based on IP addresses
 //
+// - authorize clients based on the subject of their TLS certificate
+//
 // Processors and exporters relying on the existence of data from the
 // client.Info, especially client.AuthData, should clearly document this as part
 // of the component's README file. The expected pattern for consuming data is to
@@ -89,7 +95,8 @@ type ctxKey struct{}
 type Info struct {
 	// Addr for the client connecting to this collector. Available in a
 	// best-effort basis, and generally reliable for receivers making use of
-	// confighttp.ToServer and configgrpc.ToServerOption.
+	// confighttp.ToServer and configgrpc.ToServerOption, and for the UDP receivers
+	// populating it with confignet.WithPacketContext.
 	Addr net.Addr
 
 	// Auth information from the incoming request as provided by
@@ -100,8 +107,36 @@ type Info struct {
 	// Metadata is the request metadata from the client connecting to this connector.
 	// Experimental: *NOTE* this structure is subject to change or removal in the future.
 	Metadata Metadata
+
+	// PeerCertificates is the verified certificate chain presented by the client
+	// when the receiver terminates mutual TLS, starting with the client's own
+	// certificate. Empty when the client did not present a verified certificate.
+	// Experimental: *NOTE* this structure is subject to change or removal in the future.
+	PeerCertificates []PeerCertificate
+
+	// Protocol is the protocol negotiated by the client, one of ProtocolHTTP1,
+	// ProtocolHTTP2, ProtocolGRPC or ProtocolUDP. Empty when unknown.
+	// Experimental: *NOTE* this structure is subject to change or removal in the future.
+	Protocol string
+
+	// TLSVersion is the TLS version negotiated by the client, e.g. "1.3", as
+	// returned by TLSVersionName. Empty when the connection does not use TLS.
+	// Experimental: *NOTE* this structure is subject to change or removal in the future.
+	TLSVersion string
 }
 
+// Protocols negotiated by the clients, set in Info.Protocol.
+const (
+	// ProtocolHTTP1 is used for the HTTP/1.x requests.
+	ProtocolHTTP1 = "http/1.1"
+	// ProtocolHTTP2 is used for the HTTP/2 requests, other than gRPC.
+	ProtocolHTTP2 = "h2"
+	// ProtocolGRPC is used for the gRPC requests.
+	ProtocolGRPC = "grpc"
+	// ProtocolUDP is used for the datagrams received over UDP.
+	ProtocolUDP = "udp"
+)
+
 // Metadata is an immutable map, meant to contain request metadata.
 type Metadata struct {
 	data map[string][]string
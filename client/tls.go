@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package client // import "go.opentelemetry.io/collector/client"
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"time"
+)
+
+// PeerCertificate contains the details of a certificate presented by a client.
+type PeerCertificate struct {
+	// Subject is the distinguished name of the certificate subject, e.g. "CN=client,O=example".
+	Subject string
+
+	// Issuer is the distinguished name of the certificate issuer.
+	Issuer string
+
+	// DNSNames, EmailAddresses, IPAddresses and URIs are the subject alternative names.
+	DNSNames       []string
+	EmailAddresses []string
+	IPAddresses    []string
+	URIs           []string
+
+	// FingerprintSHA256 is the hex-encoded SHA-256 digest of the DER-encoded certificate.
+	FingerprintSHA256 string
+
+	// NotBefore and NotAfter bound the validity period of the certificate.
+	NotBefore time.Time
+	NotAfter  time.Time
+
+	// Certificate is the parsed certificate, for consumers needing other fields.
+	Certificate *x509.Certificate
+}
+
+// NewPeerCertificate returns the details of the given certificate.
+func NewPeerCertificate(cert *x509.Certificate) PeerCertificate {
+	fingerprint := sha256.Sum256(cert.Raw)
+	pc := PeerCertificate{
+		Subject:           cert.Subject.String(),
+		Issuer:            cert.Issuer.String(),
+		DNSNames:          cert.DNSNames,
+		EmailAddresses:    cert.EmailAddresses,
+		FingerprintSHA256: hex.EncodeToString(fingerprint[:]),
+		NotBefore:         cert.NotBefore,
+		NotAfter:          cert.NotAfter,
+		Certificate:       cert,
+	}
+	for _, ip := range cert.IPAddresses {
+		pc.IPAddresses = append(pc.IPAddresses, ip.String())
+	}
+	for _, uri := range cert.URIs {
+		pc.URIs = append(pc.URIs, uri.String())
+	}
+	return pc
+}
+
+// PeerCertificatesFromTLS returns the details of the verified certificate chain presented
+// by the client of the given TLS connection, or nil if the client certificate was not verified.
+func PeerCertificatesFromTLS(state *tls.ConnectionState) []PeerCertificate {
+	if state == nil || len(state.VerifiedChains) == 0 {
+		return nil
+	}
+	chain := state.VerifiedChains[0]
+	certs := make([]PeerCertificate, 0, len(chain))
+	for _, cert := range chain {
+		certs = append(certs, NewPeerCertificate(cert))
+	}
+	return certs
+}
+
+// TLSVersionName returns the name of a TLS version of the crypto/tls package, e.g. "1.3" for
+// tls.VersionTLS13, or an empty string for an unknown version.
+func TLSVersionName(version uint16) string {
+	switch version {
+	case tls.VersionTLS10:
+		return "1.0"
+	case tls.VersionTLS11:
+		return "1.1"
+	case tls.VersionTLS12:
+		return "1.2"
+	case tls.VersionTLS13:
+		return "1.3"
+	}
+	return ""
+}
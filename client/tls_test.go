@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"math/big"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCertificate(t *testing.T) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	spiffe, err := url.Parse("spiffe://example.org/collector")
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:   big.NewInt(1),
+		Subject:        pkix.Name{CommonName: "client", Organization: []string{"example"}},
+		NotBefore:      time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		DNSNames:       []string{"client.example.org"},
+		EmailAddresses: []string{"client@example.org"},
+		IPAddresses:    []net.IP{net.IPv4(127, 0, 0, 1)},
+		URIs:           []*url.URL{spiffe},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func TestNewPeerCertificate(t *testing.T) {
+	cert := newTestCertificate(t)
+	fingerprint := sha256.Sum256(cert.Raw)
+
+	pc := NewPeerCertificate(cert)
+	assert.Equal(t, "CN=client,O=example", pc.Subject)
+	assert.Equal(t, "CN=client,O=example", pc.Issuer)
+	assert.Equal(t, []string{"client.example.org"}, pc.DNSNames)
+	assert.Equal(t, []string{"client@example.org"}, pc.EmailAddresses)
+	assert.Equal(t, []string{"127.0.0.1"}, pc.IPAddresses)
+	assert.Equal(t, []string{"spiffe://example.org/collector"}, pc.URIs)
+	assert.Equal(t, hex.EncodeToString(fingerprint[:]), pc.FingerprintSHA256)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), pc.NotAfter)
+	assert.Same(t, cert, pc.Certificate)
+}
+
+func TestPeerCertificatesFromTLS(t *testing.T) {
+	assert.Nil(t, PeerCertificatesFromTLS(nil))
+
+	cert := newTestCertificate(t)
+	// Certificates that were not verified are ignored.
+	assert.Nil(t, PeerCertificatesFromTLS(&tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}))
+
+	certs := PeerCertificatesFromTLS(&tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{cert},
+		VerifiedChains:   [][]*x509.Certificate{{cert, cert}},
+	})
+	require.Len(t, certs, 2)
+	assert.Equal(t, "CN=client,O=example", certs[0].Subject)
+}
+
+func TestTLSVersionName(t *testing.T) {
+	assert.Equal(t, "1.0", TLSVersionName(tls.VersionTLS10))
+	assert.Equal(t, "1.1", TLSVersionName(tls.VersionTLS11))
+	assert.Equal(t, "1.2", TLSVersionName(tls.VersionTLS12))
+	assert.Equal(t, "1.3", TLSVersionName(tls.VersionTLS13))
+	assert.Empty(t, TLSVersionName(0))
+}
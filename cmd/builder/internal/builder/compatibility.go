@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package builder // import "go.opentelemetry.io/collector/cmd/builder/internal/builder"
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+const (
+	// APICompatibilityWarn logs the components requiring API modules incompatible with the distribution. It is the default.
+	APICompatibilityWarn = "warn"
+	// APICompatibilityStrict refuses to build a distribution with components requiring incompatible API modules.
+	APICompatibilityStrict = "strict"
+	// APICompatibilityNone disables the API compatibility check.
+	APICompatibilityNone = "none"
+)
+
+// ErrIncompatibleAPI indicates a component requiring API modules incompatible with the distribution
+var ErrIncompatibleAPI = errors.New("incompatible component API")
+
+// apiModules are the modules defining the API between the components and the collector core.
+var apiModules = []string{
+	coreModule + "/component",
+	coreModule + "/pdata",
+}
+
+func validateAPICompatibilityMode(mode string) error {
+	switch mode {
+	case "", APICompatibilityWarn, APICompatibilityStrict, APICompatibilityNone:
+		return nil
+	}
+	return fmt.Errorf("invalid api_compatibility %q: must be one of %q, %q or %q",
+		mode, APICompatibilityWarn, APICompatibilityStrict, APICompatibilityNone)
+}
+
+// apiIncompatibility is a component requiring a version of an API module incompatible with the distribution.
+type apiIncompatibility struct {
+	// Component is the listed component, at its selected version.
+	Component moduleVersion
+	// Requires is the API module, at the version required by the component.
+	Requires moduleVersion
+	// Expected is the version of the API module used by the core of the distribution.
+	Expected string
+	// Suggested is a version of the component built for the core of the distribution, if any was found.
+	Suggested string
+}
+
+func (i apiIncompatibility) String() string {
+	msg := fmt.Sprintf("%s requires %s, but the distribution uses %s", i.Component, i.Requires, i.Expected)
+	if i.Suggested != "" {
+		return msg + fmt.Sprintf(": use %s %s instead", i.Component.Path, i.Suggested)
+	}
+	return msg + fmt.Sprintf(": use a version of %s built for %s %s", i.Component.Path, i.Requires.Path, i.Expected)
+}
+
+// checkAPICompatibility checks that the listed components require the same major and minor versions
+// of the API modules as the core of the distribution. Depending on the api_compatibility mode, the
+// incompatible components are logged or fail the build.
+func checkAPICompatibility(cfg Config, selected []moduleVersion, graph []moduleRequirement) error {
+	if cfg.Distribution.APICompatibility == APICompatibilityNone {
+		return nil
+	}
+
+	issues := findAPIIncompatibilities(cfg.Distribution.OtelColVersion, componentModules(cfg), selected, graph)
+	var errs error
+	for _, issue := range issues {
+		issue.Suggested = suggestVersion(cfg.Distribution.OtelColVersion, listVersions(cfg, issue.Component.Path))
+		if cfg.Distribution.APICompatibility == APICompatibilityStrict {
+			errs = multierr.Append(errs, fmt.Errorf("%w: %s", ErrIncompatibleAPI, issue))
+			continue
+		}
+		cfg.Logger.Warn("Component built for a different collector API, compilation may fail. Set dist::api_compatibility to \"strict\" to refuse such components.",
+			zap.String("component", issue.Component.String()),
+			zap.String("requires", issue.Requires.String()),
+			zap.String("expected", issue.Expected),
+			zap.String("suggested", issue.Suggested))
+	}
+	return errs
+}
+
+// componentModules returns the paths of the modules of the listed components.
+func componentModules(cfg Config) []string {
+	var paths []string
+	for _, mods := range [][]Module{cfg.Connectors, cfg.Extensions, cfg.Receivers, cfg.Exporters, cfg.Processors} {
+		for _, mod := range mods {
+			if fields := strings.Fields(mod.GoMod); len(fields) > 0 {
+				paths = append(paths, fields[0])
+			}
+		}
+	}
+	return paths
+}
+
+// findAPIIncompatibilities returns the requirements of the components on API modules that do not match
+// the versions required by the core of the distribution, i.e. the root collector module at otelColVersion.
+func findAPIIncompatibilities(otelColVersion string, components []string, selected []moduleVersion, graph []moduleRequirement) []apiIncompatibility {
+	core := moduleVersion{Path: coreModule, Version: "v" + strings.TrimPrefix(otelColVersion, "v")}
+	expected := map[string]string{coreModule + "/component": core.Version}
+	for _, req := range graph {
+		if req.From == core && isAPIModule(req.To.Path) {
+			expected[req.To.Path] = req.To.Version
+		}
+	}
+
+	versions := make(map[string]string, len(selected))
+	for _, mod := range selected {
+		versions[mod.Path] = mod.Version
+	}
+
+	var issues []apiIncompatibility
+	for _, path := range components {
+		component := moduleVersion{Path: path, Version: versions[path]}
+		for _, req := range graph {
+			if req.From != component || !isAPIModule(req.To.Path) {
+				continue
+			}
+			if want, ok := expected[req.To.Path]; ok && !compatibleVersions(req.To.Version, want) {
+				issues = append(issues, apiIncompatibility{Component: component, Requires: req.To, Expected: want})
+			}
+		}
+	}
+	return issues
+}
+
+func isAPIModule(path string) bool {
+	for _, mod := range apiModules {
+		if path == mod {
+			return true
+		}
+	}
+	return false
+}
+
+// compatibleVersions reports whether two versions of an API module have the same major and minor versions,
+// and the same pre-release, as pre-releases of the stable modules, e.g. pdata v1.0.0-rcv0014, break the API.
+func compatibleVersions(a, b string) bool {
+	minorA, okA := minorVersion(a)
+	minorB, okB := minorVersion(b)
+	if !okA || !okB || minorA != minorB {
+		return false
+	}
+	_, preA, _ := strings.Cut(a, "-")
+	_, preB, _ := strings.Cut(b, "-")
+	return preA == preB
+}
+
+// listVersions returns the known versions of a module, in ascending order. Errors are ignored, as the
+// versions are only used to suggest a compatible version.
+func listVersions(cfg Config, path string) []string {
+	// #nosec G204 -- cfg.Distribution.Go is trusted to be a safe path
+	cmd := exec.Command(cfg.Distribution.Go, "list", "-m", "-versions", path)
+	cmd.Dir = cfg.Distribution.OutputPath
+	out, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+	fields := strings.Fields(string(out))
+	if len(fields) < 2 {
+		return nil
+	}
+	return fields[1:]
+}
+
+// suggestVersion returns the latest of the versions with the same major and minor versions as otelColVersion,
+// which is the version of the components built for the core of the distribution when they are released with
+// the collector, or an empty string if there is no such version.
+func suggestVersion(otelColVersion string, versions []string) string {
+	want, ok := minorVersion("v" + strings.TrimPrefix(otelColVersion, "v"))
+	if !ok {
+		return ""
+	}
+	for i := len(versions) - 1; i >= 0; i-- {
+		if got, ok := minorVersion(versions[i]); ok && got == want && !strings.Contains(versions[i], "-") {
+			return versions[i]
+		}
+	}
+	return ""
+}
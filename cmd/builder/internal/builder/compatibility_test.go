@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+var (
+	compatibilitySelected = parseModuleList([]byte(`example.com/distribution
+example.com/oldreceiver v0.84.0
+example.com/newexporter v0.85.2
+go.opentelemetry.io/collector v0.85.0
+go.opentelemetry.io/collector/component v0.85.0
+go.opentelemetry.io/collector/pdata v1.0.0-rcv0014
+`))
+	compatibilityGraph = parseModuleGraph([]byte(`example.com/distribution example.com/oldreceiver@v0.84.0
+example.com/distribution example.com/newexporter@v0.85.2
+example.com/distribution go.opentelemetry.io/collector@v0.85.0
+example.com/oldreceiver@v0.84.0 go.opentelemetry.io/collector/component@v0.84.0
+example.com/oldreceiver@v0.84.0 go.opentelemetry.io/collector/pdata@v1.0.0-rcv0013
+example.com/newexporter@v0.85.2 go.opentelemetry.io/collector/component@v0.85.0
+example.com/newexporter@v0.85.2 go.opentelemetry.io/collector/pdata@v1.0.0-rcv0014
+go.opentelemetry.io/collector@v0.85.0 go.opentelemetry.io/collector/component@v0.85.0
+go.opentelemetry.io/collector@v0.85.0 go.opentelemetry.io/collector/pdata@v1.0.0-rcv0014
+`))
+)
+
+func TestFindAPIIncompatibilities(t *testing.T) {
+	issues := findAPIIncompatibilities("0.85.0", []string{"example.com/oldreceiver", "example.com/newexporter"}, compatibilitySelected, compatibilityGraph)
+	require.Len(t, issues, 2)
+	assert.Equal(t, "example.com/oldreceiver@v0.84.0 requires go.opentelemetry.io/collector/component@v0.84.0, but the distribution uses v0.85.0: "+
+		"use a version of example.com/oldreceiver built for go.opentelemetry.io/collector/component v0.85.0", issues[0].String())
+	issues[1].Suggested = "v0.85.0"
+	assert.Equal(t, "example.com/oldreceiver@v0.84.0 requires go.opentelemetry.io/collector/pdata@v1.0.0-rcv0013, but the distribution uses v1.0.0-rcv0014: "+
+		"use example.com/oldreceiver v0.85.0 instead", issues[1].String())
+
+	assert.Empty(t, findAPIIncompatibilities("0.85.0", []string{"example.com/newexporter"}, compatibilitySelected, compatibilityGraph))
+}
+
+func TestCheckAPICompatibility(t *testing.T) {
+	newConfig := func(mode string) (Config, *observer.ObservedLogs) {
+		core, logs := observer.New(zapcore.WarnLevel)
+		return Config{
+			Logger:       zap.New(core),
+			Distribution: Distribution{OtelColVersion: "0.85.0", Go: "invalid/go/binary/path", APICompatibility: mode},
+			Receivers:    []Module{{GoMod: "example.com/oldreceiver v0.84.0"}},
+		}, logs
+	}
+
+	cfg, logs := newConfig(APICompatibilityStrict)
+	err := checkAPICompatibility(cfg, compatibilitySelected, compatibilityGraph)
+	assert.ErrorIs(t, err, ErrIncompatibleAPI)
+	assert.ErrorContains(t, err, "example.com/oldreceiver@v0.84.0 requires go.opentelemetry.io/collector/component@v0.84.0")
+	assert.ErrorContains(t, err, "example.com/oldreceiver@v0.84.0 requires go.opentelemetry.io/collector/pdata@v1.0.0-rcv0013")
+	assert.Zero(t, logs.Len())
+
+	cfg, logs = newConfig("")
+	assert.NoError(t, checkAPICompatibility(cfg, compatibilitySelected, compatibilityGraph))
+	require.Equal(t, 2, logs.Len())
+	assert.Equal(t, "example.com/oldreceiver@v0.84.0", logs.All()[0].ContextMap()["component"])
+
+	cfg, logs = newConfig(APICompatibilityNone)
+	assert.NoError(t, checkAPICompatibility(cfg, compatibilitySelected, compatibilityGraph))
+	assert.Zero(t, logs.Len())
+}
+
+func TestCompatibleVersions(t *testing.T) {
+	assert.True(t, compatibleVersions("v0.85.0", "v0.85.2"))
+	assert.False(t, compatibleVersions("v0.84.0", "v0.85.0"))
+	assert.True(t, compatibleVersions("v1.0.0-rcv0014", "v1.0.0-rcv0014"))
+	assert.False(t, compatibleVersions("v1.0.0-rcv0013", "v1.0.0-rcv0014"))
+	assert.False(t, compatibleVersions("invalid", "v0.85.0"))
+}
+
+func TestSuggestVersion(t *testing.T) {
+	versions := []string{"v0.84.0", "v0.85.0", "v0.85.1", "v0.86.0-rc1", "v0.86.0"}
+	assert.Equal(t, "v0.85.1", suggestVersion("0.85.0", versions))
+	assert.Equal(t, "v0.86.0", suggestVersion("0.86.0", versions))
+	assert.Equal(t, "", suggestVersion("0.87.0", versions))
+	assert.Equal(t, "", suggestVersion("invalid", versions))
+}
+
+func TestValidateAPICompatibilityMode(t *testing.T) {
+	for _, mode := range []string{"", APICompatibilityWarn, APICompatibilityStrict, APICompatibilityNone} {
+		assert.NoError(t, validateAPICompatibilityMode(mode))
+	}
+	assert.EqualError(t, validateAPICompatibilityMode("invalid"), `invalid api_compatibility "invalid": must be one of "warn", "strict" or "none"`)
+
+	cfg := Config{Distribution: Distribution{APICompatibility: "invalid"}}
+	assert.Error(t, cfg.Validate())
+}
@@ -20,6 +20,9 @@ const defaultOtelColVersion = "0.85.0"
 // ErrInvalidGoMod indicates an invalid gomod
 var ErrInvalidGoMod = errors.New("invalid gomod specification for module")
 
+// ErrInvalidFeatureGate indicates an invalid feature gate specification
+var ErrInvalidFeatureGate = errors.New("invalid feature gate specification")
+
 // Config holds the builder's configuration
 type Config struct {
 	Logger          *zap.Logger
@@ -28,14 +31,16 @@ type Config struct {
 	SkipGetModules  bool   `mapstructure:"-"`
 	LDFlags         string `mapstructure:"-"`
 
-	Distribution Distribution `mapstructure:"dist"`
-	Exporters    []Module     `mapstructure:"exporters"`
-	Extensions   []Module     `mapstructure:"extensions"`
-	Receivers    []Module     `mapstructure:"receivers"`
-	Processors   []Module     `mapstructure:"processors"`
-	Connectors   []Module     `mapstructure:"connectors"`
-	Replaces     []string     `mapstructure:"replaces"`
-	Excludes     []string     `mapstructure:"excludes"`
+	Distribution Distribution  `mapstructure:"dist"`
+	Exporters    []Module      `mapstructure:"exporters"`
+	Extensions   []Module      `mapstructure:"extensions"`
+	Receivers    []Module      `mapstructure:"receivers"`
+	Processors   []Module      `mapstructure:"processors"`
+	Connectors   []Module      `mapstructure:"connectors"`
+	Replaces     []string      `mapstructure:"replaces"`
+	Excludes     []string      `mapstructure:"excludes"`
+	FeatureGates []FeatureGate `mapstructure:"feature_gates"`
+	Hooks        []Hook        `mapstructure:"hooks"`
 }
 
 // Distribution holds the parameters for the final binary
@@ -49,6 +54,8 @@ type Distribution struct {
 	Version          string `mapstructure:"version"`
 	BuildTags        string `mapstructure:"build_tags"`
 	DebugCompilation bool   `mapstructure:"debug_compilation"`
+	APICompatibility string `mapstructure:"api_compatibility"`
+	Profile          string `mapstructure:"profile"`
 }
 
 // Module represents a receiver, exporter, processor or extension for the distribution
@@ -59,6 +66,20 @@ type Module struct {
 	Path   string `mapstructure:"path"`   // an optional path to the local version of this module
 }
 
+// FeatureGate represents the default value of a feature gate in the distribution
+type FeatureGate struct {
+	ID      string `mapstructure:"id"`      // the id of the feature gate
+	Enabled bool   `mapstructure:"enabled"` // whether the feature gate is enabled by default in the distribution
+	Locked  bool   `mapstructure:"locked"`  // if true, the feature gate can not be changed at runtime
+}
+
+// Hook represents a package with functions called by the generated main at startup and shutdown
+type Hook struct {
+	Module        `mapstructure:",squash"`
+	BeforeStart   string `mapstructure:"before_start"`   // the function called with the collector settings before the collector runs
+	AfterShutdown string `mapstructure:"after_shutdown"` // the function called once the collector is shut down
+}
+
 // NewDefaultConfig creates a new config, with default values
 func NewDefaultConfig() Config {
 	log, err := zap.NewDevelopment()
@@ -89,6 +110,16 @@ func (c *Config) Validate() error {
 		validateModules(c.Exporters),
 		validateModules(c.Processors),
 		validateModules(c.Connectors),
+		validateModulePaths(c.Extensions),
+		validateModulePaths(c.Receivers),
+		validateModulePaths(c.Exporters),
+		validateModulePaths(c.Processors),
+		validateModulePaths(c.Connectors),
+		validateReplaces(c.Replaces, c.Distribution.OutputPath),
+		validateAPICompatibilityMode(c.Distribution.APICompatibility),
+		validateProfile(c.Distribution.Profile),
+		validateFeatureGates(c.FeatureGates),
+		validateHooks(c.Hooks),
 	)
 }
 
@@ -137,6 +168,11 @@ func (c *Config) ParseModules() error {
 		return err
 	}
 
+	c.Hooks, err = parseHooks(c.Hooks)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -149,6 +185,21 @@ func validateModules(mods []Module) error {
 	return nil
 }
 
+func validateFeatureGates(gates []FeatureGate) error {
+	var errs error
+	seen := map[string]bool{}
+	for _, gate := range gates {
+		switch {
+		case gate.ID == "":
+			errs = multierr.Append(errs, fmt.Errorf("%w: the id is required", ErrInvalidFeatureGate))
+		case seen[gate.ID]:
+			errs = multierr.Append(errs, fmt.Errorf("feature gate %q: %w: duplicated id", gate.ID, ErrInvalidFeatureGate))
+		}
+		seen[gate.ID] = true
+	}
+	return errs
+}
+
 func parseModules(mods []Module) ([]Module, error) {
 	var parsedModules []Module
 	for _, mod := range mods {
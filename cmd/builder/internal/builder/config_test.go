@@ -192,3 +192,19 @@ func TestDebugOptionSetConfig(t *testing.T) {
 	assert.NoError(t, cfg.Validate())
 	assert.True(t, cfg.Distribution.DebugCompilation)
 }
+
+func TestInvalidFeatureGates(t *testing.T) {
+	cfg := Config{
+		FeatureGates: []FeatureGate{
+			{ID: "foo", Enabled: true},
+			{Enabled: true},
+			{ID: "foo", Locked: true},
+		},
+	}
+	err := cfg.Validate()
+	assert.ErrorIs(t, err, ErrInvalidFeatureGate)
+	assert.EqualError(t, err, `invalid feature gate specification: the id is required; feature gate "foo": invalid feature gate specification: duplicated id`)
+
+	cfg.FeatureGates = cfg.FeatureGates[:1]
+	assert.NoError(t, cfg.Validate())
+}
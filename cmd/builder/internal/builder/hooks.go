@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package builder // import "go.opentelemetry.io/collector/cmd/builder/internal/builder"
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+
+	"go.uber.org/multierr"
+)
+
+// ErrInvalidHook indicates an invalid hook specification
+var ErrInvalidHook = errors.New("invalid hook specification")
+
+// validateHooks checks that the hooks are Go modules with at least one function to call,
+// and that the functions are exported identifiers.
+func validateHooks(hooks []Hook) error {
+	var errs error
+	for _, hook := range hooks {
+		if hook.GoMod == "" {
+			errs = multierr.Append(errs, fmt.Errorf("%w: the gomod is required", ErrInvalidHook))
+			continue
+		}
+		if hook.Path != "" {
+			if err := checkLocalModule(hook.Path); err != nil {
+				errs = multierr.Append(errs, fmt.Errorf("hook %q: %w", hook.GoMod, err))
+			}
+		}
+		if hook.BeforeStart == "" && hook.AfterShutdown == "" {
+			errs = multierr.Append(errs, fmt.Errorf("hook %q: %w: one of before_start or after_shutdown is required", hook.GoMod, ErrInvalidHook))
+		}
+		for _, fn := range []string{hook.BeforeStart, hook.AfterShutdown} {
+			if fn != "" && (!token.IsIdentifier(fn) || !token.IsExported(fn)) {
+				errs = multierr.Append(errs, fmt.Errorf("hook %q: %w: %q is not an exported function name", hook.GoMod, ErrInvalidHook, fn))
+			}
+		}
+	}
+	return errs
+}
+
+func parseHooks(hooks []Hook) ([]Hook, error) {
+	mods := make([]Module, 0, len(hooks))
+	for _, hook := range hooks {
+		mods = append(mods, hook.Module)
+	}
+	mods, err := parseModules(mods)
+	if err != nil {
+		return hooks, err
+	}
+
+	parsedHooks := make([]Hook, 0, len(hooks))
+	for i, hook := range hooks {
+		hook.Module = mods[i]
+		parsedHooks = append(parsedHooks, hook)
+	}
+	return parsedHooks, nil
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateHooks(t *testing.T) {
+	tests := []struct {
+		name string
+		hook Hook
+		err  string
+	}{
+		{
+			name: "valid",
+			hook: Hook{Module: Module{GoMod: "example.com/hook v1.0.0"}, BeforeStart: "Start", AfterShutdown: "Stop"},
+		},
+		{
+			name: "missing gomod",
+			hook: Hook{BeforeStart: "Start"},
+			err:  "invalid hook specification: the gomod is required",
+		},
+		{
+			name: "missing functions",
+			hook: Hook{Module: Module{GoMod: "example.com/hook v1.0.0"}},
+			err:  `hook "example.com/hook v1.0.0": invalid hook specification: one of before_start or after_shutdown is required`,
+		},
+		{
+			name: "unexported function",
+			hook: Hook{Module: Module{GoMod: "example.com/hook v1.0.0"}, BeforeStart: "start"},
+			err:  `hook "example.com/hook v1.0.0": invalid hook specification: "start" is not an exported function name`,
+		},
+		{
+			name: "invalid function",
+			hook: Hook{Module: Module{GoMod: "example.com/hook v1.0.0"}, AfterShutdown: "Stop()"},
+			err:  `hook "example.com/hook v1.0.0": invalid hook specification: "Stop()" is not an exported function name`,
+		},
+		{
+			name: "invalid path",
+			hook: Hook{Module: Module{GoMod: "example.com/hook v1.0.0", Path: filepath.Join(t.TempDir(), "missing")}, BeforeStart: "Start"},
+			err:  "does not exist",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateHooks([]Hook{tt.hook})
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestValidateHooksError(t *testing.T) {
+	cfg := Config{Hooks: []Hook{{Module: Module{GoMod: "example.com/hook v1.0.0"}}}}
+	assert.ErrorIs(t, cfg.Validate(), ErrInvalidHook)
+}
+
+func TestParseHooks(t *testing.T) {
+	cfg := Config{Hooks: []Hook{{
+		Module:      Module{GoMod: "example.com/org/license v1.0.0"},
+		BeforeStart: "Check",
+	}}}
+	require.NoError(t, cfg.ParseModules())
+	assert.Equal(t, []Hook{{
+		Module:      Module{Name: "license", GoMod: "example.com/org/license v1.0.0", Import: "example.com/org/license"},
+		BeforeStart: "Check",
+	}}, cfg.Hooks)
+}
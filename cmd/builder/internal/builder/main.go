@@ -59,6 +59,9 @@ func Generate(cfg Config) error {
 		mainWindowsTemplate,
 		componentsTemplate,
 		componentsTestTemplate,
+		featureGatesTemplate,
+		hooksTemplate,
+		manifestTemplate,
 		goModTemplate,
 	} {
 		if err := processAndWrite(cfg, tmpl, tmpl.Name(), cfg); err != nil {
@@ -66,6 +69,11 @@ func Generate(cfg Config) error {
 		}
 	}
 
+	// the manifest is updated with the resolved versions once the modules are retrieved
+	if err := writeManifest(cfg, nil); err != nil {
+		return fmt.Errorf("failed to generate the components manifest: %w", err)
+	}
+
 	cfg.Logger.Info("Sources created", zap.String("path", cfg.Distribution.OutputPath))
 	return nil
 }
@@ -90,8 +98,8 @@ func Compile(cfg Config) error {
 		ldflags += " " + cfg.LDFlags
 	}
 	args = append(args, "-ldflags="+ldflags)
-	if cfg.Distribution.BuildTags != "" {
-		args = append(args, "-tags", cfg.Distribution.BuildTags)
+	if tags := buildTags(cfg); tags != "" {
+		args = append(args, "-tags", tags)
 	}
 	// #nosec G204 -- cfg.Distribution.Go is trusted to be a safe path and the caller is  assumed to have carried out necessary input validation
 	cmd := exec.Command(cfg.Distribution.Go, args...)
@@ -100,6 +108,7 @@ func Compile(cfg Config) error {
 		return fmt.Errorf("failed to compile the OpenTelemetry Collector distribution: %w. Output:\n%s", err, out)
 	}
 	cfg.Logger.Info("Compiled", zap.String("binary", fmt.Sprintf("%s/%s", cfg.Distribution.OutputPath, cfg.Distribution.Name)))
+	addManifestStability(cfg)
 
 	return nil
 }
@@ -118,6 +127,10 @@ func GetModules(cfg Config) error {
 		return fmt.Errorf("failed to update go.mod: %w. Output:\n%s", err, out)
 	}
 
+	if err := validateModuleGraph(cfg); err != nil {
+		return err
+	}
+
 	cfg.Logger.Info("Getting go modules")
 	// basic retry if error from go mod command (in case of transient network error). This could be improved
 	// retry 3 times with 5 second spacing interval
@@ -133,7 +146,7 @@ func GetModules(cfg Config) error {
 			time.Sleep(5 * time.Second)
 			continue
 		}
-		return nil
+		return updateManifest(cfg)
 	}
 	return fmt.Errorf("failed to download go modules: %s", failReason)
 }
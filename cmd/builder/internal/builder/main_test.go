@@ -4,6 +4,7 @@
 package builder
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -35,6 +36,45 @@ func TestGenerateInvalidOutputPath(t *testing.T) {
 	require.Contains(t, err.Error(), "failed to create output path")
 }
 
+func TestGenerateFeatureGates(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.Distribution.OutputPath = t.TempDir()
+	cfg.FeatureGates = []FeatureGate{
+		{ID: "gate.enabled", Enabled: true},
+		{ID: "gate.locked", Locked: true},
+	}
+	require.NoError(t, Generate(cfg))
+
+	out, err := os.ReadFile(filepath.Join(cfg.Distribution.OutputPath, "featuregates.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(out), `errs = append(errs, reg.SetFromSource("gate.enabled", true, featuregate.SourceDistribution))`)
+	assert.Contains(t, string(out), `errs = append(errs, reg.SetFromSource("gate.locked", false, featuregate.SourceDistribution))`)
+	assert.Contains(t, string(out), `errs = append(errs, reg.Lock("gate.locked"))`)
+	assert.NotContains(t, string(out), `reg.Lock("gate.enabled")`)
+}
+
+func TestGenerateHooks(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.Distribution.OutputPath = t.TempDir()
+	cfg.Hooks = []Hook{
+		{Module: Module{Name: "license", GoMod: "example.com/license v1.0.0", Import: "example.com/license"}, BeforeStart: "Check"},
+		{Module: Module{Name: "audit", GoMod: "example.com/audit v1.0.0", Import: "example.com/audit"}, BeforeStart: "Start", AfterShutdown: "Flush"},
+	}
+	require.NoError(t, Generate(cfg))
+
+	out, err := os.ReadFile(filepath.Join(cfg.Distribution.OutputPath, "hooks.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(out), `license "example.com/license"`)
+	assert.Contains(t, string(out), `if err := license.Check(set); err != nil {`)
+	assert.Contains(t, string(out), `if err := audit.Start(set); err != nil {`)
+	assert.Contains(t, string(out), `errs = append(errs, audit.Flush())`)
+	assert.NotContains(t, string(out), `license.Flush`)
+
+	out, err = os.ReadFile(filepath.Join(cfg.Distribution.OutputPath, "go.mod"))
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "example.com/license v1.0.0")
+}
+
 func TestSkipGenerate(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		t.Skip("skipping the test on Windows, see https://github.com/open-telemetry/opentelemetry-collector/issues/5403")
@@ -61,27 +101,42 @@ func TestGenerateAndCompile(t *testing.T) {
 	workspaceDir := filepath.Dir(filepath.Dir(filepath.Dir(filepath.Dir(filepath.Dir(thisFile)))))
 	replaces := []string{fmt.Sprintf("go.opentelemetry.io/collector => %s", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/component => %s/component", workspaceDir),
+		fmt.Sprintf("go.opentelemetry.io/collector/config/configauth => %s/config/configauth", workspaceDir),
+		fmt.Sprintf("go.opentelemetry.io/collector/config/configcompression => %s/config/configcompression", workspaceDir),
+		fmt.Sprintf("go.opentelemetry.io/collector/config/configgrpc => %s/config/configgrpc", workspaceDir),
+		fmt.Sprintf("go.opentelemetry.io/collector/config/confighttp => %s/config/confighttp", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/config/confignet => %s/config/confignet", workspaceDir),
+		fmt.Sprintf("go.opentelemetry.io/collector/config/configopaque => %s/config/configopaque", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/config/configtelemetry => %s/config/configtelemetry", workspaceDir),
+		fmt.Sprintf("go.opentelemetry.io/collector/config/configtls => %s/config/configtls", workspaceDir),
+		fmt.Sprintf("go.opentelemetry.io/collector/config/internal => %s/config/internal", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/confmap => %s/confmap", workspaceDir),
-		fmt.Sprintf("go.opentelemetry.io/collector/consumer => %s/consumer", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/connector => %s/connector", workspaceDir),
+		fmt.Sprintf("go.opentelemetry.io/collector/connector/bufferconnector => %s/connector/bufferconnector", workspaceDir),
+		fmt.Sprintf("go.opentelemetry.io/collector/connector/forwardconnector => %s/connector/forwardconnector", workspaceDir),
+		fmt.Sprintf("go.opentelemetry.io/collector/connector/routingconnector => %s/connector/routingconnector", workspaceDir),
+		fmt.Sprintf("go.opentelemetry.io/collector/consumer => %s/consumer", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/exporter => %s/exporter", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/exporter/debugexporter => %s/exporter/debugexporter", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/exporter/loggingexporter => %s/exporter/loggingexporter", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/exporter/otlpexporter => %s/exporter/otlpexporter", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/exporter/otlphttpexporter => %s/exporter/otlphttpexporter", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/extension => %s/extension", workspaceDir),
+		fmt.Sprintf("go.opentelemetry.io/collector/extension/auth => %s/extension/auth", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/extension/ballastextension => %s/extension/ballastextension", workspaceDir),
+		fmt.Sprintf("go.opentelemetry.io/collector/extension/encoding => %s/extension/encoding", workspaceDir),
+		fmt.Sprintf("go.opentelemetry.io/collector/extension/memorytunerextension => %s/extension/memorytunerextension", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/extension/zpagesextension => %s/extension/zpagesextension", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/featuregate => %s/featuregate", workspaceDir),
+		fmt.Sprintf("go.opentelemetry.io/collector/pdata => %s/pdata", workspaceDir),
+		fmt.Sprintf("go.opentelemetry.io/collector/pdata/codec => %s/pdata/codec", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/processor => %s/processor", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/processor/batchprocessor => %s/processor/batchprocessor", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/processor/memorylimiterprocessor => %s/processor/memorylimiterprocessor", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/receiver => %s/receiver", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/receiver/otlpreceiver => %s/receiver/otlpreceiver", workspaceDir),
-		fmt.Sprintf("go.opentelemetry.io/collector/pdata => %s/pdata", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/semconv => %s/semconv", workspaceDir),
+		fmt.Sprintf("go.opentelemetry.io/collector/semconv/schema => %s/semconv/schema", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/service => %s/service", workspaceDir),
 	}
 
@@ -118,6 +173,68 @@ func TestGenerateAndCompile(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			testCase: "Feature Gates Compilation",
+			cfgBuilder: func(t *testing.T) Config {
+				cfg := NewDefaultConfig()
+				cfg.Distribution.OutputPath = t.TempDir()
+				cfg.Replaces = append(cfg.Replaces, replaces...)
+				cfg.FeatureGates = []FeatureGate{{ID: "telemetry.useOtelForInternalMetrics", Enabled: true, Locked: true}}
+				return cfg
+			},
+		},
+		{
+			testCase: "Lite Profile Compilation",
+			cfgBuilder: func(t *testing.T) Config {
+				cfg := NewDefaultConfig()
+				cfg.Distribution.OutputPath = t.TempDir()
+				cfg.Replaces = append(cfg.Replaces, replaces...)
+				cfg.Distribution.Profile = ProfileLite
+				return cfg
+			},
+		},
+		{
+			testCase: "Components Compilation",
+			cfgBuilder: func(t *testing.T) Config {
+				cfg := NewDefaultConfig()
+				cfg.Distribution.OutputPath = t.TempDir()
+				cfg.Replaces = append(cfg.Replaces, replaces...)
+				cfg.Distribution.Name = "otelcol-components"
+				cfg.Receivers = []Module{{GoMod: "go.opentelemetry.io/collector/receiver/otlpreceiver v0.85.0"}}
+				cfg.Exporters = []Module{{GoMod: "go.opentelemetry.io/collector/exporter/debugexporter v0.85.0"}}
+				require.NoError(t, cfg.ParseModules())
+				return cfg
+			},
+		},
+		{
+			testCase: "Hooks Compilation",
+			cfgBuilder: func(t *testing.T) Config {
+				cfg := NewDefaultConfig()
+				cfg.Distribution.OutputPath = t.TempDir()
+				cfg.Replaces = append(cfg.Replaces, replaces...)
+				hookDir := t.TempDir()
+				require.NoError(t, os.WriteFile(filepath.Join(hookDir, "go.mod"), []byte("module example.com/hook\n\ngo 1.20\n"), 0600))
+				require.NoError(t, os.WriteFile(filepath.Join(hookDir, "hook.go"), []byte(`package hook
+
+import "go.opentelemetry.io/collector/otelcol"
+
+func Start(set *otelcol.CollectorSettings) error {
+	set.DisableGracefulShutdown = true
+	return nil
+}
+
+func Stop() error {
+	return nil
+}
+`), 0600))
+				cfg.Hooks = []Hook{{
+					Module:        Module{Name: "hook", GoMod: "example.com/hook v0.0.0", Import: "example.com/hook", Path: hookDir},
+					BeforeStart:   "Start",
+					AfterShutdown: "Stop",
+				}}
+				return cfg
+			},
+		},
 	}
 
 	for _, tt := range testCases {
@@ -126,6 +243,15 @@ func TestGenerateAndCompile(t *testing.T) {
 			assert.NoError(t, cfg.Validate())
 			assert.NoError(t, cfg.SetGoPath())
 			require.NoError(t, GenerateAndCompile(cfg))
+
+			// The manifest includes the stability levels reported by the compiled distribution.
+			data, err := os.ReadFile(filepath.Join(cfg.Distribution.OutputPath, manifestFile))
+			require.NoError(t, err)
+			var m manifest
+			require.NoError(t, json.Unmarshal(data, &m))
+			for _, c := range m.Components {
+				assert.NotEmpty(t, c.Stability, c.Import)
+			}
 		})
 	}
 
@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package builder // import "go.opentelemetry.io/collector/cmd/builder/internal/builder"
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// manifestFile is the name of the components manifest, written in the output path and embedded in the binary.
+const manifestFile = "manifest.json"
+
+// manifest lists the modules the components are built from, see otelcol.Manifest for the format.
+type manifest struct {
+	Profile    string              `json:"profile"`
+	BuildTags  string              `json:"build_tags,omitempty"`
+	Components []manifestComponent `json:"components"`
+}
+
+type manifestComponent struct {
+	Kind    string `json:"kind"`
+	Import  string `json:"import"`
+	Module  string `json:"module"`
+	Version string `json:"version"`
+	Sum     string `json:"sum,omitempty"`
+	// Stability is only known from the factories, it is read from the compiled distribution.
+	Stability map[string]string `json:"stability,omitempty"`
+}
+
+// listedModule is a module as reported by "go list -m -json".
+type listedModule struct {
+	Path    string
+	Version string
+	Sum     string
+	Replace *listedModule
+}
+
+// writeManifest writes the components manifest, with the versions and checksums of the resolved modules.
+// The version declared in the configuration is used for the modules that are not resolved.
+func writeManifest(cfg Config, resolved map[string]listedModule) error {
+	m := manifest{Profile: profile(cfg), BuildTags: buildTags(cfg), Components: []manifestComponent{}}
+	for _, kind := range []struct {
+		name string
+		mods []Module
+	}{
+		{"connector", cfg.Connectors},
+		{"extension", cfg.Extensions},
+		{"receiver", cfg.Receivers},
+		{"exporter", cfg.Exporters},
+		{"processor", cfg.Processors},
+	} {
+		for _, mod := range kind.mods {
+			fields := strings.Fields(mod.GoMod)
+			if len(fields) == 0 {
+				continue
+			}
+			c := manifestComponent{Kind: kind.name, Import: mod.Import, Module: fields[0]}
+			if len(fields) > 1 {
+				c.Version = fields[1]
+			}
+			if lm, ok := resolved[c.Module]; ok {
+				c.Version, c.Sum = lm.Version, lm.Sum
+				// the checksum of a module replaced by another one is the one of the replacement,
+				// while a module replaced by a local directory has none
+				if lm.Replace != nil {
+					c.Sum = lm.Replace.Sum
+					if lm.Replace.Version != "" {
+						c.Version = lm.Replace.Version
+					}
+				}
+			}
+			m.Components = append(m.Components, c)
+		}
+	}
+
+	return saveManifest(cfg, m)
+}
+
+func saveManifest(cfg Config, m manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Clean(filepath.Join(cfg.Distribution.OutputPath, manifestFile)), append(data, '\n'), 0600)
+}
+
+// addManifestStability rewrites the components manifest with the stability levels of the components,
+// as reported by the "components --manifest" command of the compiled distribution. The manifest is left
+// unchanged if the distribution is compiled for another platform or does not support that command.
+func addManifestStability(cfg Config) {
+	if cfg.Distribution.Name == "" {
+		return
+	}
+	goos, goarch := os.Getenv("GOOS"), os.Getenv("GOARCH")
+	if (goos != "" && goos != runtime.GOOS) || (goarch != "" && goarch != runtime.GOARCH) {
+		cfg.Logger.Info("The distribution is compiled for another platform, the components manifest does not include the stability levels")
+		return
+	}
+
+	// #nosec G204 -- the binary is the distribution just compiled
+	cmd := exec.Command(filepath.Join(cfg.Distribution.OutputPath, cfg.Distribution.Name), "components", "--manifest")
+	out, err := cmd.Output()
+	if err != nil {
+		cfg.Logger.Warn("Failed to read the stability levels of the components, the components manifest does not include them",
+			zap.Error(err), zap.ByteString("output", stderr(err)))
+		return
+	}
+	var m manifest
+	if err = json.Unmarshal(out, &m); err != nil {
+		cfg.Logger.Warn("Invalid components manifest reported by the distribution", zap.Error(err))
+		return
+	}
+	if err = saveManifest(cfg, m); err != nil {
+		cfg.Logger.Warn("Failed to write the components manifest", zap.Error(err))
+		return
+	}
+	cfg.Logger.Info("Components manifest updated with the stability levels", zap.String("path", filepath.Join(cfg.Distribution.OutputPath, manifestFile)))
+}
+
+// updateManifest rewrites the components manifest with the versions and checksums of the modules
+// selected by the go.mod file of the distribution.
+func updateManifest(cfg Config) error {
+	var paths []string
+	seen := map[string]bool{}
+	for _, mods := range [][]Module{cfg.Connectors, cfg.Extensions, cfg.Receivers, cfg.Exporters, cfg.Processors} {
+		for _, mod := range mods {
+			if fields := strings.Fields(mod.GoMod); len(fields) > 0 && !seen[fields[0]] {
+				seen[fields[0]] = true
+				paths = append(paths, fields[0])
+			}
+		}
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+
+	// #nosec G204 -- cfg.Distribution.Go is trusted to be a safe path
+	cmd := exec.Command(cfg.Distribution.Go, append([]string{"list", "-m", "-json"}, paths...)...)
+	cmd.Dir = cfg.Distribution.OutputPath
+	out, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("failed to list the component modules: %w. Output:\n%s", err, stderr(err))
+	}
+	resolved, err := parseListedModules(out)
+	if err != nil {
+		return fmt.Errorf("failed to list the component modules: %w", err)
+	}
+
+	if err = writeManifest(cfg, resolved); err != nil {
+		return fmt.Errorf("failed to generate the components manifest: %w", err)
+	}
+	cfg.Logger.Info("Components manifest updated", zap.String("path", filepath.Join(cfg.Distribution.OutputPath, manifestFile)))
+	return nil
+}
+
+func parseListedModules(out []byte) (map[string]listedModule, error) {
+	resolved := map[string]listedModule{}
+	dec := json.NewDecoder(bytes.NewReader(out))
+	for {
+		var lm listedModule
+		if err := dec.Decode(&lm); errors.Is(err, io.EOF) {
+			return resolved, nil
+		} else if err != nil {
+			return nil, err
+		}
+		resolved[lm.Path] = lm
+	}
+}
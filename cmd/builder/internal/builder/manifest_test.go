@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestWriteManifest(t *testing.T) {
+	cfg := Config{
+		Distribution: Distribution{OutputPath: t.TempDir()},
+		Receivers: []Module{{
+			GoMod:  "go.opentelemetry.io/collector/receiver/otlpreceiver v0.85.0",
+			Import: "go.opentelemetry.io/collector/receiver/otlpreceiver",
+		}},
+		Exporters: []Module{
+			{
+				GoMod:  "github.com/org/repo v1.2.0",
+				Import: "github.com/org/repo/exporter/myexporter",
+			},
+			{
+				GoMod:  "github.com/org/local v0.1.0",
+				Import: "github.com/org/local",
+			},
+		},
+	}
+
+	require.NoError(t, writeManifest(cfg, nil))
+	data, err := os.ReadFile(filepath.Join(cfg.Distribution.OutputPath, manifestFile))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"profile": "full", "components": [
+		{"kind": "receiver", "import": "go.opentelemetry.io/collector/receiver/otlpreceiver", "module": "go.opentelemetry.io/collector/receiver/otlpreceiver", "version": "v0.85.0"},
+		{"kind": "exporter", "import": "github.com/org/repo/exporter/myexporter", "module": "github.com/org/repo", "version": "v1.2.0"},
+		{"kind": "exporter", "import": "github.com/org/local", "module": "github.com/org/local", "version": "v0.1.0"}
+	]}`, string(data))
+
+	resolved, err := parseListedModules([]byte(`{
+	"Path": "go.opentelemetry.io/collector/receiver/otlpreceiver",
+	"Version": "v0.85.0",
+	"Sum": "h1:otlp="
+}
+{
+	"Path": "github.com/org/repo",
+	"Version": "v1.2.0",
+	"Replace": {"Path": "github.com/fork/repo", "Version": "v1.2.1", "Sum": "h1:fork="}
+}
+{
+	"Path": "github.com/org/local",
+	"Version": "v0.1.0",
+	"Replace": {"Path": "../local"}
+}`))
+	require.NoError(t, err)
+	require.NoError(t, writeManifest(cfg, resolved))
+	data, err = os.ReadFile(filepath.Join(cfg.Distribution.OutputPath, manifestFile))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"profile": "full", "components": [
+		{"kind": "receiver", "import": "go.opentelemetry.io/collector/receiver/otlpreceiver", "module": "go.opentelemetry.io/collector/receiver/otlpreceiver", "version": "v0.85.0", "sum": "h1:otlp="},
+		{"kind": "exporter", "import": "github.com/org/repo/exporter/myexporter", "module": "github.com/org/repo", "version": "v1.2.1", "sum": "h1:fork="},
+		{"kind": "exporter", "import": "github.com/org/local", "module": "github.com/org/local", "version": "v0.1.0"}
+	]}`, string(data))
+
+	_, err = parseListedModules([]byte("{"))
+	assert.Error(t, err)
+}
+
+func TestAddManifestStability(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake distribution is a shell script")
+	}
+	t.Setenv("GOOS", "")
+	t.Setenv("GOARCH", "")
+	cfg := Config{
+		Logger:       zap.NewNop(),
+		Distribution: Distribution{OutputPath: t.TempDir(), Name: "otelcol-test"},
+		Receivers: []Module{{
+			GoMod:  "go.opentelemetry.io/collector/receiver/otlpreceiver v0.85.0",
+			Import: "go.opentelemetry.io/collector/receiver/otlpreceiver",
+		}},
+	}
+	require.NoError(t, writeManifest(cfg, nil))
+
+	// The distribution does not support the command: the manifest is left unchanged.
+	binary := filepath.Join(cfg.Distribution.OutputPath, cfg.Distribution.Name)
+	require.NoError(t, os.WriteFile(binary, []byte("#!/bin/sh\necho 'unknown flag: --manifest' >&2\nexit 1\n"), 0700)) // #nosec G306
+	addManifestStability(cfg)
+	data, err := os.ReadFile(filepath.Join(cfg.Distribution.OutputPath, manifestFile))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"profile": "full", "components": [
+		{"kind": "receiver", "import": "go.opentelemetry.io/collector/receiver/otlpreceiver", "module": "go.opentelemetry.io/collector/receiver/otlpreceiver", "version": "v0.85.0"}
+	]}`, string(data))
+
+	require.NoError(t, os.WriteFile(binary, []byte(`#!/bin/sh
+[ "$1 $2" = "components --manifest" ] || exit 1
+cat <<EOF
+{"profile": "full", "components": [
+  {"kind": "receiver", "import": "go.opentelemetry.io/collector/receiver/otlpreceiver", "module": "go.opentelemetry.io/collector/receiver/otlpreceiver", "version": "v0.85.0",
+   "stability": {"logs": "Beta", "metrics": "Stable", "traces": "Stable"}}
+]}
+EOF
+`), 0700)) // #nosec G306
+	addManifestStability(cfg)
+	data, err = os.ReadFile(filepath.Join(cfg.Distribution.OutputPath, manifestFile))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"profile": "full", "components": [
+		{"kind": "receiver", "import": "go.opentelemetry.io/collector/receiver/otlpreceiver", "module": "go.opentelemetry.io/collector/receiver/otlpreceiver", "version": "v0.85.0",
+		 "stability": {"logs": "Beta", "metrics": "Stable", "traces": "Stable"}}
+	]}`, string(data))
+}
@@ -0,0 +1,245 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package builder // import "go.opentelemetry.io/collector/cmd/builder/internal/builder"
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.uber.org/multierr"
+)
+
+const coreModule = "go.opentelemetry.io/collector"
+
+// ErrInvalidReplace indicates a replace directive that cannot be used
+var ErrInvalidReplace = errors.New("invalid replace directive")
+
+// ErrIncompatibleModules indicates that the module graph resolves to core modules incompatible with the distribution
+var ErrIncompatibleModules = errors.New("incompatible collector modules")
+
+// validateModulePaths checks that the local paths of the modules point to Go modules.
+func validateModulePaths(mods []Module) error {
+	var errs error
+	for _, mod := range mods {
+		if mod.Path == "" {
+			continue
+		}
+		if err := checkLocalModule(mod.Path); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("module %q: %w", mod.GoMod, err))
+		}
+	}
+	return errs
+}
+
+// validateReplaces checks that the replace directives are well-formed, and that the local paths
+// they point to are Go modules. Relative paths are resolved against outputPath, where the go.mod
+// using them is generated.
+func validateReplaces(replaces []string, outputPath string) error {
+	var errs error
+	for _, replace := range replaces {
+		parts := strings.Split(replace, "=>")
+		if len(parts) != 2 || len(strings.Fields(parts[0])) == 0 || len(strings.Fields(parts[0])) > 2 {
+			errs = multierr.Append(errs, fmt.Errorf("%q: %w: expected \"module [version] => replacement [version]\"", replace, ErrInvalidReplace))
+			continue
+		}
+		target := strings.Fields(parts[1])
+		switch {
+		case len(target) == 0 || len(target) > 2:
+			errs = multierr.Append(errs, fmt.Errorf("%q: %w: expected \"module [version] => replacement [version]\"", replace, ErrInvalidReplace))
+		case len(target) == 1 && isLocalPath(target[0]):
+			path := target[0]
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(outputPath, path)
+			}
+			if err := checkLocalModule(path); err != nil {
+				errs = multierr.Append(errs, fmt.Errorf("%q: %w: %w", replace, ErrInvalidReplace, err))
+			}
+		case len(target) == 1:
+			errs = multierr.Append(errs, fmt.Errorf("%q: %w: a version is required when replacing with a module path", replace, ErrInvalidReplace))
+		}
+	}
+	return errs
+}
+
+// isLocalPath reports whether the replacement is a file path rather than a module path, following the go.mod rules.
+func isLocalPath(path string) bool {
+	return filepath.IsAbs(path) || strings.HasPrefix(path, "./") || strings.HasPrefix(path, "../") ||
+		strings.HasPrefix(path, `.\`) || strings.HasPrefix(path, `..\`)
+}
+
+func checkLocalModule(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("local path %q does not exist", path)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("local path %q is not a directory", path)
+	}
+	if _, err = os.Stat(filepath.Join(path, "go.mod")); err != nil {
+		return fmt.Errorf("local path %q does not contain a go.mod file", path)
+	}
+	return nil
+}
+
+// moduleVersion is a module at a given version, as reported by "go mod graph" and "go list -m".
+type moduleVersion struct {
+	Path    string
+	Version string
+}
+
+func (m moduleVersion) String() string {
+	if m.Version == "" {
+		return m.Path
+	}
+	return m.Path + "@" + m.Version
+}
+
+// moduleRequirement is an edge of the module graph: From requires To.
+type moduleRequirement struct {
+	From moduleVersion
+	To   moduleVersion
+}
+
+// validateModuleGraph resolves the module graph of the generated distribution, and checks that the
+// core collector modules it selects are compatible with the collector version of the distribution.
+func validateModuleGraph(cfg Config) error {
+	cfg.Logger.Info("Validating module graph")
+
+	// #nosec G204 -- cfg.Distribution.Go is trusted to be a safe path
+	cmd := exec.Command(cfg.Distribution.Go, "list", "-m", "-f", "{{.Path}} {{.Version}}{{with .Replace}} {{.Path}} {{.Version}}{{end}}", "all")
+	cmd.Dir = cfg.Distribution.OutputPath
+	out, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("failed to resolve the module graph: %w. Output:\n%s", err, stderr(err))
+	}
+	selected := parseModuleList(out)
+
+	// #nosec G204 -- cfg.Distribution.Go is trusted to be a safe path
+	cmd = exec.Command(cfg.Distribution.Go, "mod", "graph")
+	cmd.Dir = cfg.Distribution.OutputPath
+	if out, err = cmd.Output(); err != nil {
+		return fmt.Errorf("failed to resolve the module graph: %w. Output:\n%s", err, stderr(err))
+	}
+
+	graph := parseModuleGraph(out)
+	if err = checkCoreModules(cfg.Distribution.OtelColVersion, selected, graph); err != nil {
+		return err
+	}
+	return checkAPICompatibility(cfg, selected, graph)
+}
+
+func stderr(err error) []byte {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Stderr
+	}
+	return nil
+}
+
+// parseModuleList parses the output of "go list -m", with the path and version of the replacement,
+// if any, following the ones of the module. The modules replaced by a local path have no version.
+func parseModuleList(out []byte) []moduleVersion {
+	var mods []moduleVersion
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		switch len(fields) {
+		case 1:
+			mods = append(mods, moduleVersion{Path: fields[0]})
+		case 2:
+			mods = append(mods, moduleVersion{Path: fields[0], Version: fields[1]})
+		case 3:
+			mods = append(mods, moduleVersion{Path: fields[0]})
+		case 4:
+			mods = append(mods, moduleVersion{Path: fields[0], Version: fields[3]})
+		}
+	}
+	return mods
+}
+
+func parseModuleGraph(out []byte) []moduleRequirement {
+	var reqs []moduleRequirement
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		reqs = append(reqs, moduleRequirement{From: parseModuleVersion(fields[0]), To: parseModuleVersion(fields[1])})
+	}
+	return reqs
+}
+
+func parseModuleVersion(s string) moduleVersion {
+	path, version, _ := strings.Cut(s, "@")
+	return moduleVersion{Path: path, Version: version}
+}
+
+// checkCoreModules checks that the selected core collector modules released along with the collector,
+// i.e. the ones with a v0 version, have the same minor version as otelColVersion. Modules with a stable
+// version, e.g. pdata, are versioned independently and are not checked.
+func checkCoreModules(otelColVersion string, selected []moduleVersion, graph []moduleRequirement) error {
+	want, ok := minorVersion("v" + strings.TrimPrefix(otelColVersion, "v"))
+	if !ok {
+		return nil
+	}
+
+	var errs error
+	for _, mod := range selected {
+		if !isCoreModule(mod.Path) || !strings.HasPrefix(mod.Version, "v0.") {
+			continue
+		}
+		got, ok := minorVersion(mod.Version)
+		if !ok || got == want {
+			continue
+		}
+		errs = multierr.Append(errs, fmt.Errorf("%w: %s is selected, but the distribution is built with otelcol_version %s; "+
+			"it is required by %s: use versions of these components built for collector %s.x, or set otelcol_version to %s",
+			ErrIncompatibleModules, mod, otelColVersion, strings.Join(requiredBy(mod, graph), ", "),
+			strings.TrimPrefix(want, "v"), strings.TrimPrefix(mod.Version, "v")))
+	}
+	return errs
+}
+
+// requiredBy returns the modules requiring mod, other than the core collector modules
+// unless only core modules require it.
+func requiredBy(mod moduleVersion, graph []moduleRequirement) []string {
+	var core, others []string
+	for _, req := range graph {
+		if req.To != mod {
+			continue
+		}
+		// The main module, i.e. the distribution itself, is the only one without a version.
+		if isCoreModule(req.From.Path) && req.From.Version != "" {
+			core = append(core, req.From.String())
+		} else {
+			others = append(others, req.From.String())
+		}
+	}
+	if len(others) == 0 {
+		others = core
+	}
+	sort.Strings(others)
+	return others
+}
+
+func isCoreModule(path string) bool {
+	return path == coreModule || strings.HasPrefix(path, coreModule+"/")
+}
+
+// minorVersion returns the "vX.Y" prefix of a semantic version.
+func minorVersion(version string) (string, bool) {
+	parts := strings.SplitN(strings.TrimPrefix(version, "v"), ".", 3)
+	if len(parts) < 3 || parts[0] == "" || parts[1] == "" {
+		return "", false
+	}
+	return "v" + parts[0] + "." + parts[1], true
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateModulePaths(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/mod\n"), 0600))
+
+	assert.NoError(t, validateModulePaths([]Module{
+		{GoMod: "example.com/remote v1.0.0"},
+		{GoMod: "example.com/mod v1.0.0", Path: dir},
+	}))
+
+	err := validateModulePaths([]Module{{GoMod: "example.com/mod v1.0.0", Path: filepath.Join(dir, "missing")}})
+	assert.ErrorContains(t, err, `module "example.com/mod v1.0.0": local path`)
+	assert.ErrorContains(t, err, "does not exist")
+
+	err = validateModulePaths([]Module{{GoMod: "example.com/mod v1.0.0", Path: t.TempDir()}})
+	assert.ErrorContains(t, err, "does not contain a go.mod file")
+}
+
+func TestValidateReplaces(t *testing.T) {
+	outputPath := t.TempDir()
+	local := filepath.Join(outputPath, "local")
+	require.NoError(t, os.Mkdir(local, 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "go.mod"), []byte("module example.com/local\n"), 0600))
+
+	tests := []struct {
+		replace string
+		err     string
+	}{
+		{replace: "example.com/a => example.com/b v1.0.0"},
+		{replace: "example.com/a v1.0.0 => example.com/b v1.0.1"},
+		{replace: "example.com/a => ./local"},
+		{replace: "example.com/a => " + local},
+		{replace: "example.com/a", err: `expected "module [version] => replacement [version]"`},
+		{replace: "example.com/a =>", err: `expected "module [version] => replacement [version]"`},
+		{replace: "example.com/a => example.com/b", err: "a version is required when replacing with a module path"},
+		{replace: "example.com/a => ./missing", err: "does not exist"},
+		{replace: "example.com/a => ../" + filepath.Base(outputPath), err: "does not contain a go.mod file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.replace, func(t *testing.T) {
+			err := validateReplaces([]string{tt.replace}, outputPath)
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrInvalidReplace)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestCheckCoreModules(t *testing.T) {
+	graph := parseModuleGraph([]byte(`example.com/distribution go.opentelemetry.io/collector@v0.85.0
+example.com/distribution example.com/receiver@v1.2.0
+example.com/distribution go.opentelemetry.io/collector/pdata@v1.0.0-rcv0014
+example.com/receiver@v1.2.0 go.opentelemetry.io/collector/component@v0.86.0
+go.opentelemetry.io/collector@v0.85.0 go.opentelemetry.io/collector/component@v0.85.0
+go.opentelemetry.io/collector/component@v0.86.0 go.opentelemetry.io/collector/confmap@v0.86.0
+`))
+
+	selected := parseModuleList([]byte(`example.com/distribution
+example.com/receiver v1.2.0
+go.opentelemetry.io/collector v0.85.0
+go.opentelemetry.io/collector/component v0.86.0
+go.opentelemetry.io/collector/confmap v0.86.0
+go.opentelemetry.io/collector/pdata v1.0.0-rcv0014
+go.opentelemetry.io/collector/service v0.0.0-00010101000000-000000000000 ../service
+go.opentelemetry.io/collector/semconv v0.84.0 go.opentelemetry.io/collector/semconv v0.85.0
+`))
+
+	err := checkCoreModules("0.85.0", selected, graph)
+	assert.ErrorIs(t, err, ErrIncompatibleModules)
+	assert.EqualError(t, err, "incompatible collector modules: go.opentelemetry.io/collector/component@v0.86.0 is selected, "+
+		"but the distribution is built with otelcol_version 0.85.0; it is required by example.com/receiver@v1.2.0: "+
+		"use versions of these components built for collector 0.85.x, or set otelcol_version to 0.86.0; "+
+		"incompatible collector modules: go.opentelemetry.io/collector/confmap@v0.86.0 is selected, "+
+		"but the distribution is built with otelcol_version 0.85.0; it is required by go.opentelemetry.io/collector/component@v0.86.0: "+
+		"use versions of these components built for collector 0.85.x, or set otelcol_version to 0.86.0")
+
+	assert.NoError(t, checkCoreModules("0.86.1", selected[:2], graph))
+	assert.NoError(t, checkCoreModules("0.85.0", selected[:3], graph))
+	assert.NoError(t, checkCoreModules("invalid", selected, graph))
+}
+
+func TestMinorVersion(t *testing.T) {
+	v, ok := minorVersion("v0.85.1")
+	assert.True(t, ok)
+	assert.Equal(t, "v0.85", v)
+	v, ok = minorVersion("v1.0.0-rcv0014")
+	assert.True(t, ok)
+	assert.Equal(t, "v1.0", v)
+	_, ok = minorVersion("v0.85")
+	assert.False(t, ok)
+}
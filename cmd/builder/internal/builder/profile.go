@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package builder // import "go.opentelemetry.io/collector/cmd/builder/internal/builder"
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	// ProfileFull builds the distribution with all the optional subsystems of the collector core. It is the default.
+	ProfileFull = "full"
+	// ProfileLite strips the optional subsystems of the collector core, see liteBuildTags, to produce smaller binaries.
+	ProfileLite = "lite"
+)
+
+// liteBuildTags are the build tags excluding the optional subsystems of the collector core in the lite profile:
+// the OpenCensus bridge, the Prometheus exporter of the internal metrics, the service zPages and the OTLP/JSON
+// encoding of the OTLP receiver.
+var liteBuildTags = []string{
+	"otelcol_noopencensus",
+	"otelcol_noprometheus",
+	"otelcol_nozpages",
+	"otelcol_nojson",
+}
+
+func validateProfile(profile string) error {
+	switch profile {
+	case "", ProfileFull, ProfileLite:
+		return nil
+	}
+	return fmt.Errorf("invalid profile %q: must be one of %q or %q", profile, ProfileFull, ProfileLite)
+}
+
+// profile returns the build profile of the distribution.
+func profile(cfg Config) string {
+	if cfg.Distribution.Profile == "" {
+		return ProfileFull
+	}
+	return cfg.Distribution.Profile
+}
+
+// buildTags returns the comma-separated build tags of the distribution: the ones from the configuration,
+// separated by commas or spaces, followed by the ones of the build profile.
+func buildTags(cfg Config) string {
+	tags := strings.FieldsFunc(cfg.Distribution.BuildTags, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	if profile(cfg) == ProfileLite {
+		for _, tag := range liteBuildTags {
+			if !contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return strings.Join(tags, ",")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
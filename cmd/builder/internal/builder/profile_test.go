@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateProfile(t *testing.T) {
+	for _, p := range []string{"", ProfileFull, ProfileLite} {
+		assert.NoError(t, validateProfile(p))
+	}
+	assert.EqualError(t, validateProfile("invalid"), `invalid profile "invalid": must be one of "full" or "lite"`)
+
+	cfg := Config{Distribution: Distribution{Profile: "invalid"}}
+	assert.Error(t, cfg.Validate())
+}
+
+func TestBuildTags(t *testing.T) {
+	tests := []struct {
+		name      string
+		profile   string
+		buildTags string
+		want      string
+	}{
+		{
+			name: "default profile",
+		},
+		{
+			name:      "full profile",
+			profile:   ProfileFull,
+			buildTags: "custom other",
+			want:      "custom,other",
+		},
+		{
+			name:    "lite profile",
+			profile: ProfileLite,
+			want:    "otelcol_noopencensus,otelcol_noprometheus,otelcol_nozpages,otelcol_nojson",
+		},
+		{
+			name:      "lite profile with build tags",
+			profile:   ProfileLite,
+			buildTags: "custom, otelcol_nojson",
+			want:      "custom,otelcol_nojson,otelcol_noopencensus,otelcol_noprometheus,otelcol_nozpages",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{Distribution: Distribution{Profile: tt.profile, BuildTags: tt.buildTags}}
+			assert.Equal(t, tt.want, buildTags(cfg))
+		})
+	}
+}
@@ -29,6 +29,18 @@ var (
 	mainWindowsBytes    []byte
 	mainWindowsTemplate = parseTemplate("main_windows.go", mainWindowsBytes)
 
+	//go:embed templates/featuregates.go.tmpl
+	featureGatesBytes    []byte
+	featureGatesTemplate = parseTemplate("featuregates.go", featureGatesBytes)
+
+	//go:embed templates/hooks.go.tmpl
+	hooksBytes    []byte
+	hooksTemplate = parseTemplate("hooks.go", hooksBytes)
+
+	//go:embed templates/manifest.go.tmpl
+	manifestBytes    []byte
+	manifestTemplate = parseTemplate("manifest.go", manifestBytes)
+
 	//go:embed templates/go.mod.tmpl
 	goModBytes    []byte
 	goModTemplate = parseTemplate("go.mod", goModBytes)
@@ -164,6 +164,8 @@ func applyCfgFromFile(flags *flag.FlagSet, cfgFromFile builder.Config) {
 	cfg.Connectors = cfgFromFile.Connectors
 	cfg.Replaces = cfgFromFile.Replaces
 	cfg.Excludes = cfgFromFile.Excludes
+	cfg.FeatureGates = cfgFromFile.FeatureGates
+	cfg.Hooks = cfgFromFile.Hooks
 
 	if !flags.Changed(skipGenerateFlag) && cfgFromFile.SkipGenerate {
 		cfg.SkipGenerate = cfgFromFile.SkipGenerate
@@ -196,4 +198,6 @@ func applyCfgFromFile(flags *flag.FlagSet, cfgFromFile builder.Config) {
 		cfg.Distribution.Module = cfgFromFile.Distribution.Module
 	}
 	cfg.Distribution.DebugCompilation = cfgFromFile.Distribution.DebugCompilation
+	cfg.Distribution.APICompatibility = cfgFromFile.Distribution.APICompatibility
+	cfg.Distribution.Profile = cfgFromFile.Distribution.Profile
 }
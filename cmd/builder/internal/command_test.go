@@ -65,6 +65,7 @@ func Test_applyCfgFromFile(t *testing.T) {
 		Version:          "testVersion",
 		BuildTags:        "",
 		DebugCompilation: true,
+		Profile:          "lite",
 	}
 	testStringTable := []string{"A", "B", "C"}
 	testModule := builder.Module{
@@ -73,6 +74,16 @@ func Test_applyCfgFromFile(t *testing.T) {
 		Import: "testImport",
 		Path:   "testPath",
 	}
+	testFeatureGate := builder.FeatureGate{
+		ID:      "testFeatureGate",
+		Enabled: true,
+		Locked:  true,
+	}
+	testHook := builder.Hook{
+		Module:        testModule,
+		BeforeStart:   "Start",
+		AfterShutdown: "Stop",
+	}
 	type args struct {
 		flags       *flag.FlagSet
 		cfgFromFile builder.Config
@@ -95,6 +106,8 @@ func Test_applyCfgFromFile(t *testing.T) {
 					Receivers:    []builder.Module{testModule},
 					Exporters:    []builder.Module{testModule},
 					Replaces:     testStringTable,
+					FeatureGates: []builder.FeatureGate{testFeatureGate},
+					Hooks:        []builder.Hook{testHook},
 				},
 			},
 			want: builder.Config{
@@ -105,6 +118,8 @@ func Test_applyCfgFromFile(t *testing.T) {
 				Receivers:    []builder.Module{testModule},
 				Exporters:    []builder.Module{testModule},
 				Replaces:     testStringTable,
+				FeatureGates: []builder.FeatureGate{testFeatureGate},
+				Hooks:        []builder.Hook{testHook},
 			},
 			wantErr: false,
 		},
@@ -233,6 +248,7 @@ func Test_applyCfgFromFile(t *testing.T) {
 			assert.Equal(t, tt.want.Receivers, cfg.Receivers)
 			assert.Equal(t, tt.want.Processors, cfg.Processors)
 			assert.Equal(t, tt.want.Replaces, cfg.Replaces)
+			assert.Equal(t, tt.want.FeatureGates, cfg.FeatureGates)
 		})
 	}
 }
@@ -0,0 +1,212 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+var (
+	//go:embed templates/telemetry.go.tmpl
+	telemetryBytes    []byte
+	telemetryTemplate = parseTemplate("telemetry.go", telemetryBytes)
+
+	//go:embed templates/documentation.md.tmpl
+	documentationBytes    []byte
+	documentationTemplate = parseTemplate("documentation.md", documentationBytes)
+)
+
+// attributeType describes how the values of an attribute type are handled in Go.
+type attributeType struct {
+	GoType      string
+	Constructor string
+	DocType     string
+}
+
+var attributeTypes = map[string]attributeType{
+	"string": {GoType: "string", Constructor: "attribute.String", DocType: "Str"},
+	"int":    {GoType: "int64", Constructor: "attribute.Int64", DocType: "Int"},
+	"double": {GoType: "float64", Constructor: "attribute.Float64", DocType: "Double"},
+	"bool":   {GoType: "bool", Constructor: "attribute.Bool", DocType: "Bool"},
+}
+
+func parseTemplate(name string, bytes []byte) *template.Template {
+	return template.Must(template.New(name).Funcs(template.FuncMap{
+		"goName":        goName,
+		"paramName":     paramName,
+		"attributeSets": func(td templateData) []attributeSet { return attributeSets(td.metadata) },
+		"instrument":    metric.instrument,
+		"goValueType":   metric.goValueType,
+		"metricType":    metric.metricType,
+		"valueType":     metric.valueType,
+		"isAsync":       metric.isAsync,
+		"attributeType": func(a attribute) attributeType { return attributeTypes[a.Type] },
+		"hasAsync":      func(td templateData) bool { return td.hasAsync() },
+		"hasAttributes": func(td templateData) bool { return td.hasAttributes() },
+		"sortedMetrics": func(td templateData) []string { return sortedKeys(td.Telemetry.Metrics) },
+		"sortedAttrs":   func(td templateData) []string { return sortedKeys(td.Attributes) },
+		"join":          strings.Join,
+	}).Parse(string(bytes)))
+}
+
+// templateData is the data of the templates.
+type templateData struct {
+	metadata
+	// Package is the name of the generated telemetry package.
+	Package string
+}
+
+func generateFile(tmpl *template.Template, md metadata, pkg string, path string) error {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, templateData{metadata: md, Package: pkg}); err != nil {
+		return fmt.Errorf("failed executing template %v: %w", tmpl.Name(), err)
+	}
+	out := buf.Bytes()
+	if filepath.Ext(path) == ".go" {
+		formatted, err := format.Source(out)
+		if err != nil {
+			return fmt.Errorf("failed formatting %v: %w", path, err)
+		}
+		out = formatted
+	}
+	return os.WriteFile(path, out, 0600)
+}
+
+// goName returns the exported Go name of a metric, attribute or attribute value,
+// e.g. ProcessorBatchBatchSendSize for processor/batch/batch_send_size.
+func goName(name string) string {
+	var sb strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		sb.WriteString(upperFirstChar(part))
+	}
+	return sb.String()
+}
+
+// paramName returns the name of the parameter of an attribute.
+func paramName(name string) string {
+	n := goName(name)
+	n = strings.ToLower(n[:1]) + n[1:]
+	if token.IsKeyword(n) {
+		return n + "Value"
+	}
+	return n
+}
+
+func upperFirstChar(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// attributeSet is a list of attributes used by some metrics.
+type attributeSet struct {
+	Name       string
+	Attributes []string
+	Metrics    []string
+}
+
+// attributeSets returns the distinct lists of attributes of the metrics.
+func attributeSets(md metadata) []attributeSet {
+	var sets []attributeSet
+	index := map[string]int{}
+	for _, name := range sortedKeys(md.Telemetry.Metrics) {
+		attrs := md.Telemetry.Metrics[name].Attributes
+		if len(attrs) == 0 {
+			continue
+		}
+		key := strings.Join(attrs, ",")
+		i, ok := index[key]
+		if !ok {
+			i = len(sets)
+			index[key] = i
+			sets = append(sets, attributeSet{Name: goName(strings.Join(attrs, "_")), Attributes: attrs})
+		}
+		sets[i].Metrics = append(sets[i].Metrics, name)
+	}
+	return sets
+}
+
+func (md metadata) hasAsync() bool {
+	for _, m := range md.Telemetry.Metrics {
+		if m.isAsync() {
+			return true
+		}
+	}
+	return false
+}
+
+func (md metadata) hasAttributes() bool {
+	for _, m := range md.Telemetry.Metrics {
+		if len(m.Attributes) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (m metric) isAsync() bool {
+	return m.Gauge != nil || (m.Sum != nil && m.Sum.Async)
+}
+
+// valueType returns the value type of the metric, Int64 or Float64.
+func (m metric) valueType() string {
+	var vt string
+	switch {
+	case m.Sum != nil:
+		vt = m.Sum.ValueType
+	case m.Gauge != nil:
+		vt = m.Gauge.ValueType
+	case m.Histogram != nil:
+		vt = m.Histogram.ValueType
+	}
+	if vt == "double" {
+		return "Float64"
+	}
+	return "Int64"
+}
+
+func (m metric) goValueType() string {
+	return strings.ToLower(m.valueType())
+}
+
+// instrument returns the name of the OpenTelemetry instrument type of the metric, e.g. Int64Counter.
+func (m metric) instrument() string {
+	switch {
+	case m.Gauge != nil:
+		return m.valueType() + "ObservableGauge"
+	case m.Histogram != nil:
+		return m.valueType() + "Histogram"
+	}
+	kind := "Counter"
+	if !m.Sum.Monotonic {
+		kind = "UpDownCounter"
+	}
+	if m.Sum.Async {
+		return m.valueType() + "Observable" + kind
+	}
+	return m.valueType() + kind
+}
+
+// metricType returns the type of the metric in the documentation.
+func (m metric) metricType() string {
+	switch {
+	case m.Gauge != nil:
+		return "Gauge"
+	case m.Histogram != nil:
+		return "Histogram"
+	}
+	return "Sum"
+}
@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// metadata is the content of a metadata.yaml file, describing the telemetry of a component.
+type metadata struct {
+	// Type of the component, only used in the documentation.
+	Type string `yaml:"type"`
+	// ScopeName is the instrumentation scope name of the telemetry.
+	ScopeName string `yaml:"scope_name"`
+	// Attributes are the attributes which can be used by the metrics.
+	Attributes map[string]attribute `yaml:"attributes"`
+	// Telemetry is the internal telemetry of the component.
+	Telemetry telemetry `yaml:"telemetry"`
+}
+
+type attribute struct {
+	// Description of the attribute.
+	Description string `yaml:"description"`
+	// Type of the attribute values: string, int, double or bool.
+	Type string `yaml:"type"`
+	// Enum is the list of the possible values of a string attribute, if restricted.
+	Enum []string `yaml:"enum"`
+}
+
+type telemetry struct {
+	// Metrics by metric name.
+	Metrics map[string]metric `yaml:"metrics"`
+}
+
+type metric struct {
+	// Description of the metric.
+	Description string `yaml:"description"`
+	// Unit of the metric, following the UCUM conventions.
+	Unit string `yaml:"unit"`
+	// Exactly one of Sum, Gauge and Histogram is set.
+	Sum       *sum       `yaml:"sum"`
+	Gauge     *gauge     `yaml:"gauge"`
+	Histogram *histogram `yaml:"histogram"`
+	// Attributes are the names of the attributes of the metric.
+	Attributes []string `yaml:"attributes"`
+}
+
+type sum struct {
+	// ValueType is int or double.
+	ValueType string `yaml:"value_type"`
+	// Monotonic sums can only increase.
+	Monotonic bool `yaml:"monotonic"`
+	// Async sums are observed by a callback when the telemetry is collected.
+	Async bool `yaml:"async"`
+}
+
+// gauge is always observed by a callback when the telemetry is collected.
+type gauge struct {
+	// ValueType is int or double.
+	ValueType string `yaml:"value_type"`
+}
+
+type histogram struct {
+	// ValueType is int or double.
+	ValueType string `yaml:"value_type"`
+}
+
+func loadMetadata(path string) (metadata, error) {
+	buf, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return metadata{}, err
+	}
+	var md metadata
+	dec := yaml.NewDecoder(bytes.NewReader(buf))
+	dec.KnownFields(true)
+	if err = dec.Decode(&md); err != nil {
+		return metadata{}, err
+	}
+	return md, md.validate()
+}
+
+func (md metadata) validate() error {
+	var errs error
+	if md.ScopeName == "" {
+		errs = errors.Join(errs, errors.New("missing scope_name"))
+	}
+	for _, name := range sortedKeys(md.Attributes) {
+		errs = errors.Join(errs, md.Attributes[name].validate(name))
+	}
+	if len(md.Telemetry.Metrics) == 0 {
+		errs = errors.Join(errs, errors.New("no telemetry metrics defined"))
+	}
+	fields := map[string]string{}
+	for _, name := range sortedKeys(md.Telemetry.Metrics) {
+		errs = errors.Join(errs, md.Telemetry.Metrics[name].validate(name, md.Attributes))
+		if other, ok := fields[goName(name)]; ok {
+			errs = errors.Join(errs, fmt.Errorf("metrics %q and %q have the same Go name %q", other, name, goName(name)))
+		}
+		fields[goName(name)] = name
+	}
+	return errs
+}
+
+func (a attribute) validate(name string) error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("attribute %q: "+format, append([]any{name}, args...)...))
+	}
+	if a.Description == "" {
+		fail("missing description")
+	}
+	if _, ok := attributeTypes[a.Type]; !ok {
+		fail("invalid type %q", a.Type)
+	}
+	if len(a.Enum) > 0 && a.Type != "string" {
+		fail("enum is only supported by the string attributes")
+	}
+	return errors.Join(errs...)
+}
+
+func (m metric) validate(name string, attrs map[string]attribute) error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("metric %q: "+format, append([]any{name}, args...)...))
+	}
+	if m.Description == "" {
+		fail("missing description")
+	}
+	if m.Unit == "" {
+		fail("missing unit")
+	}
+	count := 0
+	var valueType string
+	if m.Sum != nil {
+		count++
+		valueType = m.Sum.ValueType
+	}
+	if m.Gauge != nil {
+		count++
+		valueType = m.Gauge.ValueType
+	}
+	if m.Histogram != nil {
+		count++
+		valueType = m.Histogram.ValueType
+	}
+	if count != 1 {
+		fail("exactly one of sum, gauge and histogram must be set")
+	} else if valueType != "int" && valueType != "double" {
+		fail("invalid value_type %q", valueType)
+	}
+	seen := map[string]bool{}
+	for _, attr := range m.Attributes {
+		if _, ok := attrs[attr]; !ok {
+			fail("undefined attribute %q", attr)
+		}
+		if seen[attr] {
+			fail("duplicate attribute %q", attr)
+		}
+		seen[attr] = true
+	}
+	return errors.Join(errs...)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
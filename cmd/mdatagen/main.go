@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	output := flag.String("output", "", "directory of the generated telemetry package, defaults to internal/metadata next to the metadata file")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-output dir] metadata.yaml\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(flag.Arg(0), *output); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run generates the telemetry package and the documentation of the telemetry defined in the metadata file.
+func run(ymlPath, outputDir string) error {
+	md, err := loadMetadata(ymlPath)
+	if err != nil {
+		return fmt.Errorf("failed loading %v: %w", ymlPath, err)
+	}
+	ymlDir := filepath.Dir(ymlPath)
+	if outputDir == "" {
+		outputDir = filepath.Join(ymlDir, "internal", "metadata")
+	}
+	if err = os.MkdirAll(outputDir, 0700); err != nil {
+		return err
+	}
+	if err = generateFile(telemetryTemplate, md, filepath.Base(outputDir), filepath.Join(outputDir, "generated_telemetry.go")); err != nil {
+		return err
+	}
+	return generateFile(documentationTemplate, md, filepath.Base(outputDir), filepath.Join(ymlDir, "documentation.md"))
+}
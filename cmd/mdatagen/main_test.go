@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMetadata(t *testing.T) {
+	md, err := loadMetadata(filepath.Join("testdata", "metadata.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "go.opentelemetry.io/collector/cmd/mdatagen/sample", md.ScopeName)
+	assert.Len(t, md.Telemetry.Metrics, 4)
+	assert.Equal(t, []string{"open", "closed"}, md.Attributes["state"].Enum)
+}
+
+func TestLoadMetadata_Invalid(t *testing.T) {
+	_, err := loadMetadata(filepath.Join("testdata", "invalid.yaml"))
+	require.Error(t, err)
+	for _, msg := range []string{
+		"missing scope_name",
+		`attribute "state": missing description`,
+		`attribute "state": invalid type "list"`,
+		`metric "receiver/sample/both": exactly one of sum, gauge and histogram must be set`,
+		`metric "receiver/sample/requests": missing description`,
+		`metric "receiver/sample/requests": invalid value_type "long"`,
+		`metric "receiver/sample/requests": undefined attribute "receiver"`,
+	} {
+		assert.ErrorContains(t, err, msg)
+	}
+
+	_, err = loadMetadata(filepath.Join("testdata", "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestGoName(t *testing.T) {
+	assert.Equal(t, "ProcessorBatchBatchSendSize", goName("processor/batch/batch_send_size"))
+	assert.Equal(t, "ReceiverAcceptedSpans", goName("receiver/accepted_spans"))
+	assert.Equal(t, "HttpServerDuration", goName("http.server.duration"))
+	assert.Equal(t, "receiver", paramName("receiver"))
+	assert.Equal(t, "typeValue", paramName("type"))
+}
+
+// copyMetadata copies the test metadata file to a temporary directory, returning its path.
+func copyMetadata(t *testing.T) string {
+	dir := t.TempDir()
+	ymlPath := filepath.Join(dir, "metadata.yaml")
+	buf, err := os.ReadFile(filepath.Join("testdata", "metadata.yaml"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(ymlPath, buf, 0600))
+	return ymlPath
+}
+
+func TestRun(t *testing.T) {
+	ymlPath := copyMetadata(t)
+	dir := filepath.Dir(ymlPath)
+	require.NoError(t, run(ymlPath, ""))
+
+	generated, err := os.ReadFile(filepath.Join(dir, "internal", "metadata", "generated_telemetry.go"))
+	require.NoError(t, err)
+	for _, expected := range []string{
+		"package metadata",
+		`const ScopeName = "go.opentelemetry.io/collector/cmd/mdatagen/sample"`,
+		`AttributeStateOpen   = "open"`,
+		"ReceiverSampleRequests metric.Int64Counter",
+		"ReceiverSampleRequestDuration metric.Float64Histogram",
+		"ReceiverSampleConnections        metric.Int64ObservableUpDownCounter",
+		"ReceiverSampleMemoryRatio        metric.Float64ObservableGauge",
+		"func WithReceiverSampleConnectionsCallback(cb func() int64, opts ...metric.ObserveOption) TelemetryBuilderOption {",
+		"func WithReceiverSampleMemoryRatioCallback(cb func() float64, opts ...metric.ObserveOption) TelemetryBuilderOption {",
+		"func ReceiverTypeRetriedAttributeSet(receiver string, typeValue string, retried bool) metric.MeasurementOption {",
+		`attribute.Bool("retried", retried),`,
+		"func ReceiverStateAttributeSet(receiver string, state string) metric.MeasurementOption {",
+		"func ReceiverAttributeSet(receiver string) metric.MeasurementOption {",
+	} {
+		assert.Contains(t, string(generated), expected)
+	}
+
+	doc, err := os.ReadFile(filepath.Join(dir, "documentation.md"))
+	require.NoError(t, err)
+	for _, expected := range []string{
+		"# sample",
+		"### receiver/sample/connections",
+		"| 1 | Sum | Int | false |",
+		"| ms | Histogram | Double |",
+		"| state | The state of the connection. | open, closed |",
+		"| retried | Whether the request was retried. | Any Bool |",
+	} {
+		assert.Contains(t, string(doc), expected)
+	}
+}
+
+func TestRun_Output(t *testing.T) {
+	ymlPath := copyMetadata(t)
+	output := filepath.Join(t.TempDir(), "sampletelemetry")
+	require.NoError(t, run(ymlPath, output))
+	generated, err := os.ReadFile(filepath.Join(output, "generated_telemetry.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(generated), "package sampletelemetry")
+	assert.FileExists(t, filepath.Join(filepath.Dir(ymlPath), "documentation.md"))
+}
@@ -4,7 +4,9 @@ package main
 
 import (
 	"go.opentelemetry.io/collector/connector"
+	bufferconnector "go.opentelemetry.io/collector/connector/bufferconnector"
 	forwardconnector "go.opentelemetry.io/collector/connector/forwardconnector"
+	routingconnector "go.opentelemetry.io/collector/connector/routingconnector"
 	"go.opentelemetry.io/collector/exporter"
 	debugexporter "go.opentelemetry.io/collector/exporter/debugexporter"
 	loggingexporter "go.opentelemetry.io/collector/exporter/loggingexporter"
@@ -12,6 +14,7 @@ import (
 	otlphttpexporter "go.opentelemetry.io/collector/exporter/otlphttpexporter"
 	"go.opentelemetry.io/collector/extension"
 	ballastextension "go.opentelemetry.io/collector/extension/ballastextension"
+	memorytunerextension "go.opentelemetry.io/collector/extension/memorytunerextension"
 	zpagesextension "go.opentelemetry.io/collector/extension/zpagesextension"
 	"go.opentelemetry.io/collector/otelcol"
 	"go.opentelemetry.io/collector/processor"
@@ -27,6 +30,7 @@ func components() (otelcol.Factories, error) {
 
 	factories.Extensions, err = extension.MakeFactoryMap(
 		ballastextension.NewFactory(),
+		memorytunerextension.NewFactory(),
 		zpagesextension.NewFactory(),
 	)
 	if err != nil {
@@ -59,7 +63,9 @@ func components() (otelcol.Factories, error) {
 	}
 
 	factories.Connectors, err = connector.MakeFactoryMap(
+		bufferconnector.NewFactory(),
 		forwardconnector.NewFactory(),
+		routingconnector.NewFactory(),
 	)
 	if err != nil {
 		return otelcol.Factories{}, err
@@ -9,7 +9,9 @@ require (
 	go.opentelemetry.io/collector v0.85.0
 	go.opentelemetry.io/collector/component v0.85.0
 	go.opentelemetry.io/collector/connector v0.85.0
+	go.opentelemetry.io/collector/connector/bufferconnector v0.85.0
 	go.opentelemetry.io/collector/connector/forwardconnector v0.85.0
+	go.opentelemetry.io/collector/connector/routingconnector v0.85.0
 	go.opentelemetry.io/collector/exporter v0.85.0
 	go.opentelemetry.io/collector/exporter/debugexporter v0.85.0
 	go.opentelemetry.io/collector/exporter/loggingexporter v0.85.0
@@ -17,6 +19,7 @@ require (
 	go.opentelemetry.io/collector/exporter/otlphttpexporter v0.85.0
 	go.opentelemetry.io/collector/extension v0.85.0
 	go.opentelemetry.io/collector/extension/ballastextension v0.85.0
+	go.opentelemetry.io/collector/extension/memorytunerextension v0.85.0
 	go.opentelemetry.io/collector/extension/zpagesextension v0.85.0
 	go.opentelemetry.io/collector/processor v0.85.0
 	go.opentelemetry.io/collector/processor/batchprocessor v0.85.0
@@ -87,6 +90,7 @@ require (
 	go.opentelemetry.io/collector/confmap v0.85.0 // indirect
 	go.opentelemetry.io/collector/consumer v0.85.0 // indirect
 	go.opentelemetry.io/collector/extension/auth v0.85.0 // indirect
+	go.opentelemetry.io/collector/extension/encoding v0.85.0 // indirect
 	go.opentelemetry.io/collector/featuregate v1.0.0-rcv0014 // indirect
 	go.opentelemetry.io/collector/pdata v1.0.0-rcv0014 // indirect
 	go.opentelemetry.io/collector/semconv v0.85.0 // indirect
@@ -120,6 +124,7 @@ require (
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20230711160842-782d3b101e98 // indirect
 	google.golang.org/grpc v1.58.1 // indirect
 	google.golang.org/protobuf v1.31.0 // indirect
+	gopkg.in/natefinch/lumberjack.v2 v2.2.1 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -152,8 +157,12 @@ replace go.opentelemetry.io/collector/consumer => ../../consumer
 
 replace go.opentelemetry.io/collector/connector => ../../connector
 
+replace go.opentelemetry.io/collector/connector/bufferconnector => ../../connector/bufferconnector
+
 replace go.opentelemetry.io/collector/connector/forwardconnector => ../../connector/forwardconnector
 
+replace go.opentelemetry.io/collector/connector/routingconnector => ../../connector/routingconnector
+
 replace go.opentelemetry.io/collector/exporter => ../../exporter
 
 replace go.opentelemetry.io/collector/exporter/debugexporter => ../../exporter/debugexporter
@@ -170,6 +179,10 @@ replace go.opentelemetry.io/collector/extension/auth => ../../extension/auth
 
 replace go.opentelemetry.io/collector/extension/ballastextension => ../../extension/ballastextension
 
+replace go.opentelemetry.io/collector/extension/encoding => ../../extension/encoding
+
+replace go.opentelemetry.io/collector/extension/memorytunerextension => ../../extension/memorytunerextension
+
 replace go.opentelemetry.io/collector/extension/zpagesextension => ../../extension/zpagesextension
 
 replace go.opentelemetry.io/collector/featuregate => ../../featuregate
@@ -4,7 +4,9 @@
 package main
 
 import (
+	"fmt"
 	"log"
+	"os"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/otelcol"
@@ -16,21 +18,40 @@ func main() {
 		log.Fatalf("failed to build components: %v", err)
 	}
 
+	if err := setFeatureGates(); err != nil {
+		log.Fatalf("failed to set the feature gates of the distribution: %v", err)
+	}
+
 	info := component.BuildInfo{
 		Command:     "otelcorecol",
 		Description: "Local OpenTelemetry Collector binary, testing only.",
 		Version:     "0.85.0-dev",
 	}
 
-	if err := run(otelcol.CollectorSettings{BuildInfo: info, Factories: factories}); err != nil {
-		log.Fatal(err)
+	manifest, err := otelcol.ParseManifest(manifestJSON)
+	if err != nil {
+		log.Fatalf("failed to parse the components manifest: %v", err)
+	}
+
+	set := otelcol.CollectorSettings{BuildInfo: info, Factories: factories, Manifest: manifest}
+	if err := beforeStart(&set); err != nil {
+		log.Fatalf("failed to run the startup hooks: %v", err)
+	}
+
+	runErr := run(set)
+	if err := afterShutdown(); err != nil {
+		log.Printf("failed to run the shutdown hooks: %v", err)
+	}
+	if runErr != nil {
+		log.Print(runErr)
+		os.Exit(otelcol.ExitCode(runErr))
 	}
 }
 
 func runInteractive(params otelcol.CollectorSettings) error {
 	cmd := otelcol.NewCommand(params)
 	if err := cmd.Execute(); err != nil {
-		log.Fatalf("collector server run finished with error: %v", err)
+		return fmt.Errorf("collector server run finished with error: %w", err)
 	}
 
 	return nil
@@ -6,6 +6,7 @@ package component // import "go.opentelemetry.io/collector/component"
 import (
 	"context"
 	"errors"
+	"fmt"
 )
 
 var (
@@ -96,6 +97,40 @@ const (
 	KindConnector
 )
 
+func (k Kind) String() string {
+	switch k {
+	case KindReceiver:
+		return "receiver"
+	case KindProcessor:
+		return "processor"
+	case KindExporter:
+		return "exporter"
+	case KindExtension:
+		return "extension"
+	case KindConnector:
+		return "connector"
+	}
+	return ""
+}
+
+// StartError is returned when a component fails to start, identifying the failing component.
+type StartError struct {
+	// Kind is the kind of the component.
+	Kind Kind
+	// ID is the ID of the component.
+	ID ID
+	// Err is the error returned by the Start method of the component.
+	Err error
+}
+
+func (e *StartError) Error() string {
+	return fmt.Sprintf("failed to start %s %q: %v", e.Kind, e.ID, e.Err)
+}
+
+func (e *StartError) Unwrap() error {
+	return e.Err
+}
+
 // StabilityLevel represents the stability level of the component created by the factory.
 // The stability level is used to determine if the component should be used in production
 // or not. For more details see:
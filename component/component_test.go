@@ -4,11 +4,28 @@
 package component
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
+func TestKindString(t *testing.T) {
+	assert.EqualValues(t, "receiver", KindReceiver.String())
+	assert.EqualValues(t, "processor", KindProcessor.String())
+	assert.EqualValues(t, "exporter", KindExporter.String())
+	assert.EqualValues(t, "extension", KindExtension.String())
+	assert.EqualValues(t, "connector", KindConnector.String())
+	assert.EqualValues(t, "", Kind(100).String())
+}
+
+func TestStartError(t *testing.T) {
+	errBind := errors.New("address already in use")
+	err := &StartError{Kind: KindReceiver, ID: NewIDWithName("otlp", "1"), Err: errBind}
+	assert.EqualError(t, err, `failed to start receiver "otlp/1": address already in use`)
+	assert.ErrorIs(t, err, errBind)
+}
+
 func TestStabilityLevelString(t *testing.T) {
 	assert.EqualValues(t, "Undefined", StabilityLevelUndefined.String())
 	assert.EqualValues(t, "Unmaintained", StabilityLevelUnmaintained.String())
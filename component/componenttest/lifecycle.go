@@ -0,0 +1,234 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package componenttest // import "go.opentelemetry.io/collector/component/componenttest"
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// leakCheckTimeout is the time given to the background operations of a component to complete
+// after Shutdown returned, before reporting them as leaked.
+const leakCheckTimeout = 5 * time.Second
+
+// CreateFunc creates a new instance of the component under test, typically by calling
+// one of the Create* functions of its factory.
+type CreateFunc func() (component.Component, error)
+
+// LifecycleOption configures CheckLifecycle.
+type LifecycleOption func(*lifecycleSettings)
+
+type lifecycleSettings struct {
+	host          component.Host
+	startErrHost  component.Host
+	skipLeakCheck bool
+}
+
+// WithHost sets the host the component is started with. By default, the component
+// is started with a host that has no factories, extensions or exporters.
+func WithHost(host component.Host) LifecycleOption {
+	return func(s *lifecycleSettings) {
+		s.host = host
+	}
+}
+
+// WithStartErrorHost sets a host with which the component is expected to fail to start,
+// e.g. because it references an extension that is not available. The component must
+// still shut down cleanly after the failed Start.
+func WithStartErrorHost(host component.Host) LifecycleOption {
+	return func(s *lifecycleSettings) {
+		s.startErrHost = host
+	}
+}
+
+// WithoutLeakCheck disables the goroutine and file descriptor leak checks, for components
+// relying on resources that are intentionally shared across instances.
+func WithoutLeakCheck() LifecycleOption {
+	return func(s *lifecycleSettings) {
+		s.skipLeakCheck = true
+	}
+}
+
+// CheckLifecycle verifies that the components created by create honor the contract of
+// component.Component:
+//   - a component is started and shut down without error;
+//   - Shutdown is safe to call without Start having been called;
+//   - Shutdown is safe to call more than once;
+//   - a component can be shut down after a failed Start (see WithStartErrorHost);
+//   - a new instance can be started once the previous one was shut down;
+//   - no goroutines or file descriptors are left behind once Shutdown returned.
+//
+// Each check runs as a sub-test on a new instance. As the leak checks observe the whole
+// process, tests calling CheckLifecycle must not run in parallel with other tests.
+func CheckLifecycle(t *testing.T, create CreateFunc, options ...LifecycleOption) {
+	set := lifecycleSettings{}
+	for _, op := range options {
+		op(&set)
+	}
+
+	t.Run("start_shutdown", func(t *testing.T) {
+		set.run(t, func(t *testing.T, host *lifecycleHost) {
+			comp := createComponent(t, create)
+			require.NoError(t, comp.Start(context.Background(), host))
+			require.NoError(t, comp.Shutdown(context.Background()))
+		})
+	})
+
+	t.Run("shutdown_before_start", func(t *testing.T) {
+		set.run(t, func(t *testing.T, _ *lifecycleHost) {
+			comp := createComponent(t, create)
+			require.NoError(t, comp.Shutdown(context.Background()))
+		})
+	})
+
+	t.Run("double_shutdown", func(t *testing.T) {
+		set.run(t, func(t *testing.T, host *lifecycleHost) {
+			comp := createComponent(t, create)
+			require.NoError(t, comp.Start(context.Background(), host))
+			require.NoError(t, comp.Shutdown(context.Background()))
+			require.NoError(t, comp.Shutdown(context.Background()))
+		})
+	})
+
+	t.Run("start_error", func(t *testing.T) {
+		if set.startErrHost == nil {
+			t.Skip("no host to make the component fail to start, see WithStartErrorHost")
+		}
+		set.run(t, func(t *testing.T, _ *lifecycleHost) {
+			host := newLifecycleHost(set.startErrHost)
+			comp := createComponent(t, create)
+			require.Error(t, comp.Start(context.Background(), host))
+			require.NoError(t, comp.Shutdown(context.Background()))
+		})
+	})
+
+	t.Run("restart", func(t *testing.T) {
+		set.run(t, func(t *testing.T, host *lifecycleHost) {
+			for i := 0; i < 2; i++ {
+				comp := createComponent(t, create)
+				require.NoError(t, comp.Start(context.Background(), host))
+				require.NoError(t, comp.Shutdown(context.Background()))
+			}
+		})
+	})
+}
+
+// run executes fn with a fresh lifecycleHost, then checks that the component did not report
+// a fatal error and did not leak any goroutine or file descriptor.
+func (s *lifecycleSettings) run(t *testing.T, fn func(*testing.T, *lifecycleHost)) {
+	before := takeResourceSnapshot()
+	host := newLifecycleHost(s.host)
+	fn(t, host)
+	require.NoError(t, host.fatalError(), "component reported a fatal error")
+	if !s.skipLeakCheck {
+		require.NoError(t, waitForNoLeaks(before, leakCheckTimeout))
+	}
+}
+
+func createComponent(t *testing.T, create CreateFunc) component.Component {
+	comp, err := create()
+	require.NoError(t, err)
+	require.NotNil(t, comp)
+	return comp
+}
+
+// lifecycleHost is a component.Host answering the capability queries of the component
+// with the wrapped host, and recording the fatal errors reported by the component.
+type lifecycleHost struct {
+	host component.Host
+
+	mu       sync.Mutex
+	fatalErr error
+}
+
+func newLifecycleHost(host component.Host) *lifecycleHost {
+	if host == nil {
+		host = NewNopHost()
+	}
+	return &lifecycleHost{host: host}
+}
+
+func (h *lifecycleHost) ReportFatalError(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.fatalErr == nil {
+		h.fatalErr = err
+	}
+	h.host.ReportFatalError(err)
+}
+
+func (h *lifecycleHost) fatalError() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.fatalErr
+}
+
+func (h *lifecycleHost) GetFactory(kind component.Kind, componentType component.Type) component.Factory {
+	return h.host.GetFactory(kind, componentType)
+}
+
+func (h *lifecycleHost) GetExtensions() map[component.ID]component.Component {
+	return h.host.GetExtensions()
+}
+
+func (h *lifecycleHost) GetExporters() map[component.DataType]map[component.ID]component.Component {
+	return h.host.GetExporters() // nolint:staticcheck
+}
+
+// resourceSnapshot is the number of goroutines and open file descriptors of the process.
+// A negative number of file descriptors means they can not be counted on this platform.
+type resourceSnapshot struct {
+	goroutines int
+	fds        int
+}
+
+func takeResourceSnapshot() resourceSnapshot {
+	return resourceSnapshot{
+		goroutines: runtime.NumGoroutine(),
+		fds:        countOpenFDs(),
+	}
+}
+
+// countOpenFDs returns the number of file descriptors opened by the process, or -1 if
+// they can not be listed.
+func countOpenFDs() int {
+	entries, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		return -1
+	}
+	// Reading the directory opens one more descriptor, listed in the entries.
+	return len(entries) - 1
+}
+
+// waitForNoLeaks waits up to timeout for the resources of the process to get back to
+// the before snapshot, as goroutines may still be exiting when Shutdown returns.
+func waitForNoLeaks(before resourceSnapshot, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		err := before.compare(takeResourceSnapshot())
+		if err == nil || time.Now().After(deadline) {
+			return err
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func (s resourceSnapshot) compare(after resourceSnapshot) error {
+	if after.goroutines > s.goroutines {
+		return fmt.Errorf("%d goroutine(s) leaked after Shutdown", after.goroutines-s.goroutines)
+	}
+	if s.fds >= 0 && after.fds > s.fds {
+		return fmt.Errorf("%d file descriptor(s) leaked after Shutdown", after.fds-s.fds)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package componenttest
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// backgroundComponent runs a goroutine and keeps a file open between Start and Shutdown.
+type backgroundComponent struct {
+	done chan struct{}
+	file *os.File
+}
+
+func (c *backgroundComponent) Start(_ context.Context, host component.Host) error {
+	if host.GetExtensions() == nil {
+		return errors.New("no extensions")
+	}
+	f, err := os.Open(os.DevNull)
+	if err != nil {
+		return err
+	}
+	c.file = f
+	done := make(chan struct{})
+	c.done = done
+	go func() {
+		<-done
+	}()
+	return nil
+}
+
+func (c *backgroundComponent) Shutdown(context.Context) error {
+	if c.done == nil {
+		return nil
+	}
+	close(c.done)
+	c.done = nil
+	return c.file.Close()
+}
+
+type extensionsHost struct {
+	component.Host
+}
+
+func (extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return map[component.ID]component.Component{}
+}
+
+func TestCheckLifecycle(t *testing.T) {
+	CheckLifecycle(t, func() (component.Component, error) {
+		return &backgroundComponent{}, nil
+	}, WithHost(extensionsHost{Host: NewNopHost()}), WithStartErrorHost(NewNopHost()))
+}
+
+func TestLifecycleHostFatalError(t *testing.T) {
+	host := newLifecycleHost(nil)
+	assert.Nil(t, host.GetExtensions())
+	assert.NoError(t, host.fatalError())
+	host.ReportFatalError(errors.New("first"))
+	host.ReportFatalError(errors.New("second"))
+	assert.EqualError(t, host.fatalError(), "first")
+}
+
+func TestWaitForNoLeaksGoroutine(t *testing.T) {
+	before := takeResourceSnapshot()
+	done := make(chan struct{})
+	go func() {
+		<-done
+	}()
+	assert.EqualError(t, waitForNoLeaks(before, 50*time.Millisecond), "1 goroutine(s) leaked after Shutdown")
+	close(done)
+	assert.NoError(t, waitForNoLeaks(before, leakCheckTimeout))
+}
+
+func TestWaitForNoLeaksFileDescriptor(t *testing.T) {
+	before := takeResourceSnapshot()
+	if before.fds < 0 {
+		t.Skip("file descriptors can not be counted on this platform")
+	}
+	f, err := os.Open(os.DevNull)
+	require.NoError(t, err)
+	assert.EqualError(t, waitForNoLeaks(before, 50*time.Millisecond), "1 file descriptor(s) leaked after Shutdown")
+	require.NoError(t, f.Close())
+	assert.NoError(t, waitForNoLeaks(before, leakCheckTimeout))
+}
@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package componenttest // import "go.opentelemetry.io/collector/component/componenttest"
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// Telemetry records in memory the spans and the OpenTelemetry metrics of the components under test,
+// including the instruments they define, so they can be checked, e.g.:
+//
+//	tel := componenttest.NewTelemetry()
+//	defer tel.Shutdown(context.Background())
+//	set := receivertest.NewNopCreateSettings()
+//	set.TelemetrySettings = tel.NewTelemetrySettings()
+//	...
+//	err := tel.CheckSum("receiver_accepted_spans", 10, attribute.String("receiver", "otlp"), attribute.String("transport", "grpc"))
+type Telemetry struct {
+	// Reader collects the metrics recorded with the MeterProvider of the telemetry settings.
+	Reader *sdkmetric.ManualReader
+	// SpanRecorder records the spans ended with the TracerProvider of the telemetry settings.
+	SpanRecorder *tracetest.SpanRecorder
+
+	meterProvider  *sdkmetric.MeterProvider
+	tracerProvider *sdktrace.TracerProvider
+}
+
+// TelemetryOption configures the Telemetry returned by NewTelemetry.
+type TelemetryOption func(*telemetryOptions)
+
+type telemetryOptions struct {
+	readers []sdkmetric.Reader
+}
+
+// WithMetricReader adds a reader to the MeterProvider, e.g. a Prometheus exporter.
+func WithMetricReader(reader sdkmetric.Reader) TelemetryOption {
+	return func(o *telemetryOptions) {
+		o.readers = append(o.readers, reader)
+	}
+}
+
+// NewTelemetry returns a Telemetry. Shutdown must be called at the end of the test.
+func NewTelemetry(opts ...TelemetryOption) *Telemetry {
+	o := telemetryOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	tel := &Telemetry{
+		Reader:       sdkmetric.NewManualReader(),
+		SpanRecorder: new(tracetest.SpanRecorder),
+	}
+	mpOpts := []sdkmetric.Option{sdkmetric.WithResource(resource.Empty()), sdkmetric.WithReader(tel.Reader)}
+	for _, r := range o.readers {
+		mpOpts = append(mpOpts, sdkmetric.WithReader(r))
+	}
+	tel.meterProvider = sdkmetric.NewMeterProvider(mpOpts...)
+	tel.tracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(tel.SpanRecorder))
+	return tel
+}
+
+// NewTelemetrySettings returns telemetry settings recording the spans and metrics at the normal level,
+// with a nop logger.
+func (tel *Telemetry) NewTelemetrySettings() component.TelemetrySettings {
+	return component.TelemetrySettings{
+		Logger:         zap.NewNop(),
+		TracerProvider: tel.tracerProvider,
+		MeterProvider:  tel.meterProvider,
+		MetricsLevel:   configtelemetry.LevelNormal,
+		Resource:       pcommon.NewResource(),
+	}
+}
+
+// GetMetric returns the metric with the given name collected from the Reader.
+func (tel *Telemetry) GetMetric(name string) (metricdata.Metrics, error) {
+	var rm metricdata.ResourceMetrics
+	if err := tel.Reader.Collect(context.Background(), &rm); err != nil {
+		return metricdata.Metrics{}, err
+	}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == name {
+				return m, nil
+			}
+		}
+	}
+	return metricdata.Metrics{}, fmt.Errorf("metric '%s' not found", name)
+}
+
+// CheckSum checks that the value of the counter, or up-down counter, with the given name and attributes
+// matches the given value.
+func (tel *Telemetry) CheckSum(name string, value float64, attrs ...attribute.KeyValue) error {
+	m, err := tel.GetMetric(name)
+	if err != nil {
+		return err
+	}
+	set := attribute.NewSet(attrs...)
+	switch data := m.Data.(type) {
+	case metricdata.Sum[int64]:
+		return checkValue(name, set, value, data.DataPoints)
+	case metricdata.Sum[float64]:
+		return checkValue(name, set, value, data.DataPoints)
+	}
+	return fmt.Errorf("metric '%s' is a %T, not a sum", name, m.Data)
+}
+
+// CheckGauge checks that the value of the gauge with the given name and attributes matches the given value.
+func (tel *Telemetry) CheckGauge(name string, value float64, attrs ...attribute.KeyValue) error {
+	m, err := tel.GetMetric(name)
+	if err != nil {
+		return err
+	}
+	set := attribute.NewSet(attrs...)
+	switch data := m.Data.(type) {
+	case metricdata.Gauge[int64]:
+		return checkValue(name, set, value, data.DataPoints)
+	case metricdata.Gauge[float64]:
+		return checkValue(name, set, value, data.DataPoints)
+	}
+	return fmt.Errorf("metric '%s' is a %T, not a gauge", name, m.Data)
+}
+
+// CheckHistogram checks that the count and the sum of the values recorded by the histogram with the given
+// name and attributes match the given ones.
+func (tel *Telemetry) CheckHistogram(name string, count uint64, sum float64, attrs ...attribute.KeyValue) error {
+	m, err := tel.GetMetric(name)
+	if err != nil {
+		return err
+	}
+	set := attribute.NewSet(attrs...)
+	switch data := m.Data.(type) {
+	case metricdata.Histogram[int64]:
+		return checkHistogram(name, set, count, sum, data.DataPoints)
+	case metricdata.Histogram[float64]:
+		return checkHistogram(name, set, count, sum, data.DataPoints)
+	}
+	return fmt.Errorf("metric '%s' is a %T, not a histogram", name, m.Data)
+}
+
+// Shutdown shuts down the MeterProvider and the TracerProvider.
+func (tel *Telemetry) Shutdown(ctx context.Context) error {
+	return multierr.Combine(tel.meterProvider.Shutdown(ctx), tel.tracerProvider.Shutdown(ctx))
+}
+
+func checkValue[N int64 | float64](name string, set attribute.Set, value float64, dps []metricdata.DataPoint[N]) error {
+	for _, dp := range dps {
+		if !dp.Attributes.Equals(&set) {
+			continue
+		}
+		if math.Abs(float64(dp.Value)-value) > 0.0001 {
+			return fmt.Errorf("values for metric '%s' did not match, expected '%f' got '%f'", name, value, float64(dp.Value))
+		}
+		return nil
+	}
+	return errNoTimeSeries(name, set)
+}
+
+func checkHistogram[N int64 | float64](name string, set attribute.Set, count uint64, sum float64, dps []metricdata.HistogramDataPoint[N]) error {
+	for _, dp := range dps {
+		if !dp.Attributes.Equals(&set) {
+			continue
+		}
+		if dp.Count != count || math.Abs(float64(dp.Sum)-sum) > 0.0001 {
+			return fmt.Errorf("values for metric '%s' did not match, expected count '%d' and sum '%f' got '%d' and '%f'", name, count, sum, dp.Count, float64(dp.Sum))
+		}
+		return nil
+	}
+	return errNoTimeSeries(name, set)
+}
+
+func errNoTimeSeries(name string, set attribute.Set) error {
+	return fmt.Errorf("metric '%s' doesn't have a timeseries with the given attributes: %s", name, set.Encoded(attribute.DefaultEncoder()))
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package componenttest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"go.opentelemetry.io/collector/config/configtelemetry"
+)
+
+func TestTelemetry(t *testing.T) {
+	extraReader := sdkmetric.NewManualReader()
+	tel := NewTelemetry(WithMetricReader(extraReader))
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+
+	set := tel.NewTelemetrySettings()
+	assert.Equal(t, configtelemetry.LevelNormal, set.MetricsLevel)
+
+	meter := set.MeterProvider.Meter("componenttest")
+	attrs := []attribute.KeyValue{attribute.String("receiver", "custom")}
+	counter, err := meter.Int64Counter("custom_requests")
+	require.NoError(t, err)
+	counter.Add(context.Background(), 3, metric.WithAttributes(attrs...))
+	counter.Add(context.Background(), 4, metric.WithAttributes(attrs...))
+	upDown, err := meter.Float64UpDownCounter("custom_queue_size")
+	require.NoError(t, err)
+	upDown.Add(context.Background(), 2.5)
+	_, err = meter.Int64ObservableGauge("custom_connections", metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+		o.Observe(5, metric.WithAttributes(attrs...))
+		return nil
+	}))
+	require.NoError(t, err)
+	histogram, err := meter.Float64Histogram("custom_latency")
+	require.NoError(t, err)
+	histogram.Record(context.Background(), 1.5)
+	histogram.Record(context.Background(), 2.5)
+
+	_, span := set.TracerProvider.Tracer("componenttest").Start(context.Background(), "operation")
+	span.End()
+
+	assert.NoError(t, tel.CheckSum("custom_requests", 7, attrs...))
+	assert.EqualError(t, tel.CheckSum("custom_requests", 8, attrs...), "values for metric 'custom_requests' did not match, expected '8.000000' got '7.000000'")
+	assert.EqualError(t, tel.CheckSum("custom_requests", 7), "metric 'custom_requests' doesn't have a timeseries with the given attributes: ")
+	assert.NoError(t, tel.CheckSum("custom_queue_size", 2.5))
+	assert.NoError(t, tel.CheckGauge("custom_connections", 5, attrs...))
+	assert.EqualError(t, tel.CheckGauge("custom_requests", 7, attrs...), "metric 'custom_requests' is a metricdata.Sum[int64], not a gauge")
+	assert.NoError(t, tel.CheckHistogram("custom_latency", 2, 4))
+	assert.Error(t, tel.CheckHistogram("custom_latency", 1, 4))
+	assert.EqualError(t, tel.CheckSum("unknown", 0), "metric 'unknown' not found")
+
+	require.Len(t, tel.SpanRecorder.Ended(), 1)
+	assert.Equal(t, "operation", tel.SpanRecorder.Ended()[0].Name())
+
+	// The additional readers receive the metrics as well.
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, extraReader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	assert.Len(t, rm.ScopeMetrics[0].Metrics, 4)
+}
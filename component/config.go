@@ -4,7 +4,9 @@
 package component // import "go.opentelemetry.io/collector/component"
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
 
 	"go.uber.org/multierr"
 
@@ -41,49 +43,131 @@ type ConfigValidator interface {
 	Validate() error
 }
 
+// ValidationError is an error returned by the validation of a configuration, carrying the path
+// of the invalid value in the configuration, e.g. "protocols::grpc::endpoint".
+type ValidationError struct {
+	// Path is the path of the invalid value, with the keys separated by confmap.KeyDelimiter.
+	Path string
+	// Err is the error returned by the validation of the value.
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Err.Error()
+	}
+	return e.Path + ": " + e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// NewValidationError returns err with each of its errors located at path. The path of the errors
+// that are already a ValidationError is appended to path.
+func NewValidationError(path string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var errs error
+	for _, e := range multierr.Errors(err) {
+		if ve, ok := e.(*ValidationError); ok { //nolint:errorlint
+			errs = multierr.Append(errs, &ValidationError{Path: joinPath(path, ve.Path), Err: ve.Err})
+			continue
+		}
+		errs = multierr.Append(errs, &ValidationError{Path: path, Err: e})
+	}
+	return errs
+}
+
 // ValidateConfig validates a config, by doing this:
 //   - Call Validate on the config itself if the config implements ConfigValidator.
+//   - Validate each of the fields, slice elements and map entries of the config, recursively.
+//
+// The errors of the fields, elements and entries are returned as ValidationError, with their path
+// built from the mapstructure tags of the fields.
 func ValidateConfig(cfg Config) error {
-	return validate(reflect.ValueOf(cfg))
+	return validate(reflect.ValueOf(cfg), "")
 }
 
-func validate(v reflect.Value) error {
+func validate(v reflect.Value, path string) error {
 	// Validate the value itself.
 	switch v.Kind() {
 	case reflect.Invalid:
 		return nil
 	case reflect.Ptr:
-		return validate(v.Elem())
+		return validate(v.Elem(), path)
 	case reflect.Struct:
 		var errs error
-		errs = multierr.Append(errs, callValidateIfPossible(v))
+		errs = multierr.Append(errs, validateAt(v, path))
 		// Reflect on the pointed data and check each of its fields.
 		for i := 0; i < v.NumField(); i++ {
-			if !v.Type().Field(i).IsExported() {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
 				continue
 			}
-			errs = multierr.Append(errs, validate(v.Field(i)))
+			errs = multierr.Append(errs, validate(v.Field(i), joinPath(path, fieldKey(field))))
 		}
 		return errs
 	case reflect.Slice, reflect.Array:
 		var errs error
-		errs = multierr.Append(errs, callValidateIfPossible(v))
+		errs = multierr.Append(errs, validateAt(v, path))
 		// Reflect on the pointed data and check each of its fields.
 		for i := 0; i < v.Len(); i++ {
-			errs = multierr.Append(errs, validate(v.Index(i)))
+			errs = multierr.Append(errs, validate(v.Index(i), joinPath(path, fmt.Sprint(i))))
 		}
 		return errs
 	case reflect.Map:
 		var errs error
-		errs = multierr.Append(errs, callValidateIfPossible(v))
+		errs = multierr.Append(errs, validateAt(v, path))
 		iter := v.MapRange()
 		for iter.Next() {
-			errs = multierr.Append(errs, validate(iter.Key()))
-			errs = multierr.Append(errs, validate(iter.Value()))
+			keyPath := joinPath(path, fmt.Sprint(reflect.Indirect(iter.Key()).Interface()))
+			errs = multierr.Append(errs, validate(iter.Key(), keyPath))
+			errs = multierr.Append(errs, validate(iter.Value(), keyPath))
 		}
 		return errs
 	default:
-		return callValidateIfPossible(v)
+		return validateAt(v, path)
+	}
+}
+
+// validateAt calls Validate on v if possible, locating the returned errors at path.
+// Errors of the top-level value are returned as-is.
+func validateAt(v reflect.Value, path string) error {
+	err := callValidateIfPossible(v)
+	if path == "" {
+		return err
+	}
+	return NewValidationError(path, err)
+}
+
+// fieldKey returns the configuration key of a struct field, as decoded by confmap.
+// Squashed and embedded fields share the key of their parent.
+func fieldKey(field reflect.StructField) string {
+	tag := strings.Split(field.Tag.Get("mapstructure"), ",")
+	for _, opt := range tag[1:] {
+		if opt == "squash" {
+			return ""
+		}
+	}
+	if name := tag[0]; name != "" && name != "-" {
+		return name
+	}
+	if field.Anonymous {
+		return ""
+	}
+	return strings.ToLower(field.Name)
+}
+
+func joinPath(prefix, key string) string {
+	switch {
+	case prefix == "":
+		return key
+	case key == "":
+		return prefix
+	default:
+		return prefix + confmap.KeyDelimiter + key
 	}
 }
 
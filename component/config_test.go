@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/multierr"
 )
 
 type configChildStruct struct {
@@ -113,84 +114,139 @@ func TestValidateConfig(t *testing.T) {
 		{
 			name:     "child struct",
 			cfg:      configChildStruct{Child: errConfig{err: errors.New("child struct")}},
-			expected: errors.New("child struct"),
+			expected: &ValidationError{Path: "child", Err: errors.New("child struct")},
 		},
 		{
 			name:     "pointer child struct",
 			cfg:      &configChildStruct{Child: errConfig{err: errors.New("pointer child struct")}},
-			expected: errors.New("pointer child struct"),
+			expected: &ValidationError{Path: "child", Err: errors.New("pointer child struct")},
 		},
 		{
 			name:     "child struct pointer",
 			cfg:      &configChildStruct{ChildPtr: &errConfig{err: errors.New("child struct pointer")}},
-			expected: errors.New("child struct pointer"),
+			expected: &ValidationError{Path: "childptr", Err: errors.New("child struct pointer")},
 		},
 		{
 			name:     "child slice",
 			cfg:      configChildSlice{Child: []errConfig{{}, {err: errors.New("child slice")}}},
-			expected: errors.New("child slice"),
+			expected: &ValidationError{Path: "child::1", Err: errors.New("child slice")},
 		},
 		{
 			name:     "pointer child slice",
 			cfg:      &configChildSlice{Child: []errConfig{{}, {err: errors.New("pointer child slice")}}},
-			expected: errors.New("pointer child slice"),
+			expected: &ValidationError{Path: "child::1", Err: errors.New("pointer child slice")},
 		},
 		{
 			name:     "child slice pointer",
 			cfg:      &configChildSlice{ChildPtr: []*errConfig{{}, {err: errors.New("child slice pointer")}}},
-			expected: errors.New("child slice pointer"),
+			expected: &ValidationError{Path: "childptr::1", Err: errors.New("child slice pointer")},
 		},
 		{
 			name:     "child map value",
 			cfg:      configChildMapValue{Child: map[string]errConfig{"test": {err: errors.New("child map")}}},
-			expected: errors.New("child map"),
+			expected: &ValidationError{Path: "child::test", Err: errors.New("child map")},
 		},
 		{
 			name:     "pointer child map value",
 			cfg:      &configChildMapValue{Child: map[string]errConfig{"test": {err: errors.New("pointer child map")}}},
-			expected: errors.New("pointer child map"),
+			expected: &ValidationError{Path: "child::test", Err: errors.New("pointer child map")},
 		},
 		{
 			name:     "child map value pointer",
 			cfg:      &configChildMapValue{ChildPtr: map[string]*errConfig{"test": {err: errors.New("child map pointer")}}},
-			expected: errors.New("child map pointer"),
+			expected: &ValidationError{Path: "childptr::test", Err: errors.New("child map pointer")},
 		},
 		{
 			name:     "child map key",
 			cfg:      configChildMapKey{Child: map[errType]string{"child map key": ""}},
-			expected: errors.New("child map key"),
+			expected: &ValidationError{Path: "child::child map key", Err: errors.New("child map key")},
 		},
 		{
 			name:     "pointer child map key",
 			cfg:      &configChildMapKey{Child: map[errType]string{"pointer child map key": ""}},
-			expected: errors.New("pointer child map key"),
+			expected: &ValidationError{Path: "child::pointer child map key", Err: errors.New("pointer child map key")},
 		},
 		{
 			name:     "child map key pointer",
 			cfg:      &configChildMapKey{ChildPtr: map[*errType]string{newErrType("child map key pointer"): ""}},
-			expected: errors.New("child map key pointer"),
+			expected: &ValidationError{Path: "childptr::child map key pointer", Err: errors.New("child map key pointer")},
 		},
 		{
 			name:     "child type",
 			cfg:      configChildTypeDef{Child: "child type"},
-			expected: errors.New("child type"),
+			expected: &ValidationError{Path: "child", Err: errors.New("child type")},
 		},
 		{
 			name:     "pointer child type",
 			cfg:      &configChildTypeDef{Child: "pointer child type"},
-			expected: errors.New("pointer child type"),
+			expected: &ValidationError{Path: "child", Err: errors.New("pointer child type")},
 		},
 		{
 			name:     "child type pointer",
 			cfg:      &configChildTypeDef{ChildPtr: newErrType("child type pointer")},
-			expected: errors.New("child type pointer"),
+			expected: &ValidationError{Path: "childptr", Err: errors.New("child type pointer")},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validate(reflect.ValueOf(tt.cfg))
+			err := validate(reflect.ValueOf(tt.cfg), "")
 			assert.Equal(t, tt.expected, err)
 		})
 	}
 }
+
+type configTagged struct {
+	Squashed configEmbedded `mapstructure:",squash"`
+	EmbeddedConfig
+	Endpoint  errType     `mapstructure:"endpoint"`
+	Protocols []errConfig `mapstructure:"protocols"`
+	Untagged  errType
+}
+
+type configEmbedded struct {
+	Name errType `mapstructure:"name"`
+}
+
+type EmbeddedConfig struct {
+	Name errType `mapstructure:"name"`
+}
+
+func TestValidateConfigPaths(t *testing.T) {
+	cfg := &configTagged{
+		Squashed:       configEmbedded{Name: "squashed"},
+		EmbeddedConfig: EmbeddedConfig{Name: "embedded"},
+		Endpoint:       "endpoint",
+		Protocols:      []errConfig{{err: errors.New("protocol")}},
+		Untagged:       "untagged",
+	}
+	err := ValidateConfig(cfg)
+	assert.Equal(t, []error{
+		&ValidationError{Path: "name", Err: errors.New("squashed")},
+		&ValidationError{Path: "name", Err: errors.New("embedded")},
+		&ValidationError{Path: "endpoint", Err: errors.New("endpoint")},
+		&ValidationError{Path: "protocols::0", Err: errors.New("protocol")},
+		&ValidationError{Path: "untagged", Err: errors.New("untagged")},
+	}, multierr.Errors(err))
+	assert.EqualError(t, err, "name: squashed; name: embedded; endpoint: endpoint; protocols::0: protocol; untagged: untagged")
+}
+
+func TestNewValidationError(t *testing.T) {
+	assert.NoError(t, NewValidationError("receivers::otlp", nil))
+
+	errPlain := errors.New("plain")
+	err := NewValidationError("receivers::otlp", multierr.Combine(
+		errPlain,
+		&ValidationError{Path: "protocols::grpc", Err: errors.New("nested")},
+	))
+	assert.Equal(t, []error{
+		&ValidationError{Path: "receivers::otlp", Err: errPlain},
+		&ValidationError{Path: "receivers::otlp::protocols::grpc", Err: errors.New("nested")},
+	}, multierr.Errors(err))
+	assert.ErrorIs(t, err, errPlain)
+
+	var ve *ValidationError
+	assert.ErrorAs(t, err, &ve)
+	assert.Equal(t, "receivers::otlp", ve.Path)
+	assert.EqualError(t, &ValidationError{Err: errPlain}, "plain")
+}
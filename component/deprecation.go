@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package component // import "go.opentelemetry.io/collector/component"
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Deprecation describes the deprecation of a component, as declared by its factory.
+type Deprecation struct {
+	// Since is the version in which the component was deprecated, e.g. "v0.85.0".
+	Since string
+	// RemovalVersion is the version in which the component is expected to be removed.
+	RemovalVersion string
+	// Replacement describes what to use instead of the component, e.g. "the otlphttp exporter".
+	Replacement string
+}
+
+// deprecatedFactory is implemented by the factories of the component kinds that support
+// declaring a deprecation, e.g. through receiver.WithDeprecation.
+type deprecatedFactory interface {
+	Deprecation() *Deprecation
+}
+
+// FactoryDeprecation returns the deprecation declared by the factory, or nil if the
+// component it creates is not deprecated.
+func FactoryDeprecation(f Factory) *Deprecation {
+	if df, ok := f.(deprecatedFactory); ok {
+		return df.Deprecation()
+	}
+	return nil
+}
+
+// DeprecatedField is a deprecated configuration field that is set in a configuration.
+type DeprecatedField struct {
+	// Path is the path of the field in the configuration, e.g. "protocols::grpc::max_concurrent_streams".
+	Path string
+	// Replacement is the value of the `deprecated` tag of the field, describing what to use instead.
+	Replacement string
+}
+
+// DeprecatedFields returns the fields of cfg that are deprecated and set to a value different from the
+// one of defaultCfg, the default configuration created by the factory, so that the deprecated fields
+// only set by the factory are not reported. The fields missing from defaultCfg, e.g. in a slice element
+// or a map entry it does not have, are reported if set to a non-zero value. defaultCfg may be nil.
+//
+// A field is deprecated by tagging it with `deprecated:"<replacement>"`, e.g.:
+//
+//	MaxConns int `mapstructure:"max_conns" deprecated:"use max_connections instead"`
+func DeprecatedFields(cfg Config, defaultCfg Config) []DeprecatedField {
+	return deprecatedFields(reflect.ValueOf(cfg), reflect.ValueOf(defaultCfg), "")
+}
+
+// deprecatedFields walks v along with def, the matching value of the default configuration, which is
+// invalid if the default configuration has no such value.
+func deprecatedFields(v reflect.Value, def reflect.Value, path string) []DeprecatedField {
+	if def.IsValid() && def.Type() != v.Type() {
+		def = reflect.Value{}
+	}
+	var fields []DeprecatedField
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			var defElem reflect.Value
+			if def.IsValid() && !def.IsNil() {
+				defElem = def.Elem()
+			}
+			fields = deprecatedFields(v.Elem(), defElem, path)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			var defField reflect.Value
+			if def.IsValid() {
+				defField = def.Field(i)
+			}
+			fieldPath := joinPath(path, fieldKey(field))
+			if replacement, ok := field.Tag.Lookup("deprecated"); ok && isSet(v.Field(i), defField) {
+				fields = append(fields, DeprecatedField{Path: fieldPath, Replacement: replacement})
+			}
+			fields = append(fields, deprecatedFields(v.Field(i), defField, fieldPath)...)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			var defElem reflect.Value
+			if def.IsValid() && i < def.Len() {
+				defElem = def.Index(i)
+			}
+			fields = append(fields, deprecatedFields(v.Index(i), defElem, joinPath(path, fmt.Sprint(i)))...)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			var defValue reflect.Value
+			if def.IsValid() && !def.IsNil() {
+				defValue = def.MapIndex(iter.Key())
+			}
+			keyPath := joinPath(path, fmt.Sprint(reflect.Indirect(iter.Key()).Interface()))
+			fields = append(fields, deprecatedFields(iter.Value(), defValue, keyPath)...)
+		}
+	}
+	return fields
+}
+
+// isSet returns whether v is set to a value different from def, or to a non-zero value if def is invalid.
+func isSet(v reflect.Value, def reflect.Value) bool {
+	if !def.IsValid() {
+		return !v.IsZero()
+	}
+	return !reflect.DeepEqual(v.Interface(), def.Interface())
+}
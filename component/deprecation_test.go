@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type deprecatedTestFactory struct {
+	Factory
+	deprecation *Deprecation
+}
+
+func (f deprecatedTestFactory) Deprecation() *Deprecation {
+	return f.deprecation
+}
+
+func TestFactoryDeprecation(t *testing.T) {
+	assert.Nil(t, FactoryDeprecation(nil))
+	assert.Nil(t, FactoryDeprecation(deprecatedTestFactory{}))
+
+	deprecation := &Deprecation{Since: "v0.85.0", RemovalVersion: "v0.90.0", Replacement: "the new receiver"}
+	assert.Equal(t, deprecation, FactoryDeprecation(deprecatedTestFactory{deprecation: deprecation}))
+}
+
+type deprecatedFieldsConfig struct {
+	Endpoint string                           `mapstructure:"endpoint"`
+	Port     int                              `mapstructure:"port" deprecated:"use endpoint instead"`
+	Host     string                           `mapstructure:"host" deprecated:"use endpoint instead"`
+	Protocol *deprecatedFieldsProtocolConfig  `mapstructure:"protocol"`
+	Servers  []deprecatedFieldsProtocolConfig `mapstructure:"servers"`
+	Named    map[string]any                   `mapstructure:"named"`
+}
+
+type deprecatedFieldsProtocolConfig struct {
+	MaxConns int `mapstructure:"max_conns" deprecated:"use max_connections instead"`
+}
+
+func TestDeprecatedFields(t *testing.T) {
+	assert.Empty(t, DeprecatedFields(nil, nil))
+	assert.Empty(t, DeprecatedFields(&deprecatedFieldsConfig{Endpoint: "localhost:4317"}, nil))
+
+	cfg := &deprecatedFieldsConfig{
+		Port:     4317,
+		Protocol: &deprecatedFieldsProtocolConfig{MaxConns: 10},
+		Servers:  []deprecatedFieldsProtocolConfig{{}, {MaxConns: 5}},
+		Named:    map[string]any{"grpc": deprecatedFieldsProtocolConfig{MaxConns: 1}},
+	}
+	assert.Equal(t, []DeprecatedField{
+		{Path: "port", Replacement: "use endpoint instead"},
+		{Path: "protocol::max_conns", Replacement: "use max_connections instead"},
+		{Path: "servers::1::max_conns", Replacement: "use max_connections instead"},
+		{Path: "named::grpc::max_conns", Replacement: "use max_connections instead"},
+	}, DeprecatedFields(cfg, nil))
+}
+
+func TestDeprecatedFieldsDefaultConfig(t *testing.T) {
+	defaultCfg := &deprecatedFieldsConfig{
+		Port:     4317,
+		Protocol: &deprecatedFieldsProtocolConfig{MaxConns: 10},
+		Servers:  []deprecatedFieldsProtocolConfig{{MaxConns: 5}},
+		Named:    map[string]any{"grpc": deprecatedFieldsProtocolConfig{MaxConns: 1}},
+	}
+	// The deprecated fields set by the factory are not reported.
+	assert.Empty(t, DeprecatedFields(&deprecatedFieldsConfig{
+		Port:     4317,
+		Protocol: &deprecatedFieldsProtocolConfig{MaxConns: 10},
+		Servers:  []deprecatedFieldsProtocolConfig{{MaxConns: 5}},
+		Named:    map[string]any{"grpc": deprecatedFieldsProtocolConfig{MaxConns: 1}},
+	}, defaultCfg))
+
+	cfg := &deprecatedFieldsConfig{
+		Port:     4317,
+		Host:     "localhost",
+		Protocol: &deprecatedFieldsProtocolConfig{MaxConns: 20},
+		Servers:  []deprecatedFieldsProtocolConfig{{MaxConns: 5}, {MaxConns: 5}},
+		Named:    map[string]any{"grpc": deprecatedFieldsProtocolConfig{MaxConns: 1}, "http": deprecatedFieldsProtocolConfig{MaxConns: 1}},
+	}
+	assert.Equal(t, []DeprecatedField{
+		{Path: "host", Replacement: "use endpoint instead"},
+		{Path: "protocol::max_conns", Replacement: "use max_connections instead"},
+		{Path: "servers::1::max_conns", Replacement: "use max_connections instead"},
+		{Path: "named::http::max_conns", Replacement: "use max_connections instead"},
+	}, DeprecatedFields(cfg, defaultCfg))
+}
@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package component // import "go.opentelemetry.io/collector/component"
+
+import (
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+// FeatureGatesConfig can be embedded in a component Config to let users override, for a single
+// component instance, the feature gates registered in the namespace of the component type.
+// The keys are the gate names relative to the namespace, e.g. "foo" for "receiver.otlp.foo".
+type FeatureGatesConfig struct {
+	FeatureGates map[string]bool `mapstructure:"feature_gates"`
+}
+
+// GetFeatureGates returns the instance-level feature gate overrides.
+func (c FeatureGatesConfig) GetFeatureGates() map[string]bool {
+	return c.FeatureGates
+}
+
+type featureGatesConfig interface {
+	GetFeatureGates() map[string]bool
+}
+
+// FeatureGatesNamespace returns the namespace of the feature gates owned by the components
+// of the given kind and type, e.g. "receiver.otlp". Component authors register their gates with it.
+func FeatureGatesNamespace(kind Kind, typ Type) *featuregate.Namespace {
+	return featuregate.GlobalRegistry().Namespace(kind.String() + "." + string(typ))
+}
+
+// FeatureGates returns the feature gates of the component instance identified by kind and id,
+// typically called with the ID from the CreateSettings and the Config passed to the factory.
+// If cfg embeds FeatureGatesConfig, its overrides apply to this instance only.
+func FeatureGates(kind Kind, id ID, cfg Config) (*featuregate.Namespace, error) {
+	return featureGates(FeatureGatesNamespace(kind, id.Type()), cfg)
+}
+
+func featureGates(ns *featuregate.Namespace, cfg Config) (*featuregate.Namespace, error) {
+	fgc, ok := cfg.(featureGatesConfig)
+	if !ok || len(fgc.GetFeatureGates()) == 0 {
+		return ns, nil
+	}
+	return ns.WithOverrides(fgc.GetFeatureGates())
+}
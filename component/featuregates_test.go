@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+func TestFeatureGatesNamespace(t *testing.T) {
+	assert.Equal(t, "receiver.otlp", FeatureGatesNamespace(KindReceiver, "otlp").Prefix())
+	assert.Equal(t, "exporter.otlp", FeatureGatesNamespace(KindExporter, "otlp").Prefix())
+}
+
+func TestFeatureGates(t *testing.T) {
+	ns, err := FeatureGates(KindProcessor, NewIDWithName("batch", "1"), struct{}{})
+	require.NoError(t, err)
+	assert.Equal(t, "processor.batch", ns.Prefix())
+
+	reg := featuregate.NewRegistry()
+	ns = reg.Namespace("receiver.foo")
+	ns.MustRegister("bar", featuregate.StageAlpha)
+
+	type config struct {
+		FeatureGatesConfig `mapstructure:",squash"`
+	}
+	instance, err := featureGates(ns, &config{})
+	require.NoError(t, err)
+	assert.False(t, instance.IsEnabled("bar"))
+
+	instance, err = featureGates(ns, &config{FeatureGatesConfig{FeatureGates: map[string]bool{"bar": true}}})
+	require.NoError(t, err)
+	assert.True(t, instance.IsEnabled("bar"))
+	assert.False(t, ns.IsEnabled("bar"))
+
+	_, err = featureGates(ns, &config{FeatureGatesConfig{FeatureGates: map[string]bool{"unknown": true}}})
+	assert.Error(t, err)
+}
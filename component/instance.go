@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package component // import "go.opentelemetry.io/collector/component"
+
+import (
+	"sort"
+	"strings"
+)
+
+// InstanceID identifies an instance of a component created by the collector. The same component
+// ID may result in several instances: receivers and exporters have one instance per data type,
+// shared by all the pipelines of that data type, while processors have one instance per pipeline.
+type InstanceID struct {
+	// ID is the ID of the component in the configuration.
+	ID ID
+	// Kind is the kind of the component.
+	Kind Kind
+	// PipelineIDs are the IDs of the pipelines sharing the instance.
+	PipelineIDs map[ID]struct{}
+}
+
+// NewInstanceID returns the InstanceID of the instance of the component with the given ID
+// that is used by the given pipelines.
+func NewInstanceID(id ID, kind Kind, pipelineIDs ...ID) InstanceID {
+	instanceID := InstanceID{ID: id, Kind: kind, PipelineIDs: make(map[ID]struct{}, len(pipelineIDs))}
+	for _, pipelineID := range pipelineIDs {
+		instanceID.PipelineIDs[pipelineID] = struct{}{}
+	}
+	return instanceID
+}
+
+// SortedPipelineIDs returns the IDs of the pipelines sharing the instance, sorted.
+func (id InstanceID) SortedPipelineIDs() []ID {
+	pipelineIDs := make([]ID, 0, len(id.PipelineIDs))
+	for pipelineID := range id.PipelineIDs {
+		pipelineIDs = append(pipelineIDs, pipelineID)
+	}
+	sort.Slice(pipelineIDs, func(i, j int) bool { return pipelineIDs[i].String() < pipelineIDs[j].String() })
+	return pipelineIDs
+}
+
+// String returns the instance ID in the form "<kind>/<id>[<pipeline>,...]", e.g. "receiver/otlp[traces,traces/2]".
+func (id InstanceID) String() string {
+	pipelines := make([]string, 0, len(id.PipelineIDs))
+	for _, pipelineID := range id.SortedPipelineIDs() {
+		pipelines = append(pipelines, pipelineID.String())
+	}
+	return id.Kind.String() + "/" + id.ID.String() + "[" + strings.Join(pipelines, ",") + "]"
+}
@@ -0,0 +1,24 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstanceID(t *testing.T) {
+	traces := NewID("traces")
+	traces2 := NewIDWithName("traces", "2")
+
+	id := NewInstanceID(NewID("otlp"), KindReceiver, traces2, traces, traces)
+	assert.Equal(t, map[ID]struct{}{traces: {}, traces2: {}}, id.PipelineIDs)
+	assert.Equal(t, []ID{traces, traces2}, id.SortedPipelineIDs())
+	assert.Equal(t, "receiver/otlp[traces,traces/2]", id.String())
+
+	ext := NewInstanceID(NewIDWithName("health_check", "1"), KindExtension)
+	assert.Empty(t, ext.SortedPipelineIDs())
+	assert.Equal(t, "extension/health_check/1[]", ext.String())
+}
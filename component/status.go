@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package component // import "go.opentelemetry.io/collector/component"
+
+// Status is the status of a component instance, as reported by the service running it.
+type Status int
+
+const (
+	// StatusNone is the status of an instance not started yet.
+	StatusNone Status = iota
+	// StatusStarting is reported when the instance starts.
+	StatusStarting
+	// StatusOK is reported once the instance is started.
+	StatusOK
+	// StatusStartError is reported when the instance fails to start.
+	StatusStartError
+	// StatusFatalError is reported when the instance reports a fatal error with Host.ReportFatalError.
+	StatusFatalError
+	// StatusStopping is reported when the instance is shut down.
+	StatusStopping
+	// StatusStopped is reported once the instance is shut down.
+	StatusStopped
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusStarting:
+		return "Starting"
+	case StatusOK:
+		return "OK"
+	case StatusStartError:
+		return "StartError"
+	case StatusFatalError:
+		return "FatalError"
+	case StatusStopping:
+		return "Stopping"
+	case StatusStopped:
+		return "Stopped"
+	}
+	return "None"
+}
+
+// StatusEvent reports a change of the status of a component instance.
+type StatusEvent struct {
+	// InstanceID identifies the component instance.
+	InstanceID InstanceID
+	// Status is the new status of the instance.
+	Status Status
+	// Err is the error of the StatusStartError and StatusFatalError statuses, and the error
+	// returned by the shutdown of the instance with the StatusStopped status, if any.
+	Err error
+}
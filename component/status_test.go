@@ -0,0 +1,21 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusString(t *testing.T) {
+	assert.Equal(t, "None", StatusNone.String())
+	assert.Equal(t, "Starting", StatusStarting.String())
+	assert.Equal(t, "OK", StatusOK.String())
+	assert.Equal(t, "StartError", StatusStartError.String())
+	assert.Equal(t, "FatalError", StatusFatalError.String())
+	assert.Equal(t, "Stopping", StatusStopping.String())
+	assert.Equal(t, "Stopped", StatusStopped.String())
+	assert.Equal(t, "None", Status(100).String())
+}
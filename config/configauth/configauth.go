@@ -18,36 +18,100 @@ var (
 	errAuthenticatorNotFound = errors.New("authenticator not found")
 	errNotClient             = errors.New("requested authenticator is not a client authenticator")
 	errNotServer             = errors.New("requested authenticator is not a server authenticator")
+	errChained               = errors.New("several authenticators are configured, use GetClientAuthenticators")
 )
 
 // Authentication defines the auth settings for the receiver.
 type Authentication struct {
 	// AuthenticatorID specifies the name of the extension to use in order to authenticate the incoming data point.
 	AuthenticatorID component.ID `mapstructure:"authenticator"`
+
+	// AuthenticatorIDs specifies the names of the extensions applied, in this order, to authenticate the
+	// outgoing requests, e.g. to obtain an OAuth token and then sign the request. On incoming requests, the
+	// extensions are tried in this order and the first one accepting the request authenticates it, e.g. to
+	// accept both the old and the new credentials during a migration. It cannot be used along with AuthenticatorID.
+	AuthenticatorIDs []component.ID `mapstructure:"authenticators"`
+}
+
+// Validate checks if the Authentication configuration is valid.
+func (a Authentication) Validate() error {
+	if a.AuthenticatorID != (component.ID{}) && len(a.AuthenticatorIDs) > 0 {
+		return errors.New("only one of authenticator and authenticators can be set")
+	}
+	return nil
 }
 
 // GetServerAuthenticator attempts to select the appropriate auth.Server from the list of extensions,
 // based on the requested extension name. If an authenticator is not found, an error is returned.
+// When several authenticators are configured, the returned auth.Server tries them in order and the first one
+// accepting the request authenticates it, see AuthenticatorFromContext.
 func (a Authentication) GetServerAuthenticator(extensions map[component.ID]component.Component) (auth.Server, error) {
-	if ext, found := extensions[a.AuthenticatorID]; found {
+	switch len(a.AuthenticatorIDs) {
+	case 0:
+		return getServerAuthenticator(extensions, a.AuthenticatorID)
+	case 1:
+		return getServerAuthenticator(extensions, a.AuthenticatorIDs[0])
+	}
+	servers := make([]auth.Server, 0, len(a.AuthenticatorIDs))
+	for _, id := range a.AuthenticatorIDs {
+		server, err := getServerAuthenticator(extensions, id)
+		if err != nil {
+			return nil, err
+		}
+		servers = append(servers, server)
+	}
+	return newFallbackServer(a.AuthenticatorIDs, servers), nil
+}
+
+func getServerAuthenticator(extensions map[component.ID]component.Component, id component.ID) (auth.Server, error) {
+	if ext, found := extensions[id]; found {
 		if server, ok := ext.(auth.Server); ok {
 			return server, nil
 		}
 		return nil, errNotServer
 	}
-
-	return nil, fmt.Errorf("failed to resolve authenticator %q: %w", a.AuthenticatorID, errAuthenticatorNotFound)
+	return nil, fmt.Errorf("failed to resolve authenticator %q: %w", id, errAuthenticatorNotFound)
 }
 
 // GetClientAuthenticator attempts to select the appropriate auth.Client from the list of extensions,
 // based on the component id of the extension. If an authenticator is not found, an error is returned.
+// An error is also returned if several authenticators are configured, see GetClientAuthenticators.
 // This should be only used by HTTP clients.
 func (a Authentication) GetClientAuthenticator(extensions map[component.ID]component.Component) (auth.Client, error) {
-	if ext, found := extensions[a.AuthenticatorID]; found {
+	switch len(a.AuthenticatorIDs) {
+	case 0:
+		return getClientAuthenticator(extensions, a.AuthenticatorID)
+	case 1:
+		return getClientAuthenticator(extensions, a.AuthenticatorIDs[0])
+	default:
+		return nil, errChained
+	}
+}
+
+// GetClientAuthenticators returns the auth.Client of each configured authenticator, in the configured order.
+// Clients must apply them in that order to the outgoing requests.
+func (a Authentication) GetClientAuthenticators(extensions map[component.ID]component.Component) ([]auth.Client, error) {
+	ids := a.AuthenticatorIDs
+	if len(ids) == 0 {
+		ids = []component.ID{a.AuthenticatorID}
+	}
+	clients := make([]auth.Client, 0, len(ids))
+	for _, id := range ids {
+		client, err := getClientAuthenticator(extensions, id)
+		if err != nil {
+			return nil, err
+		}
+		clients = append(clients, client)
+	}
+	return clients, nil
+}
+
+func getClientAuthenticator(extensions map[component.ID]component.Component, id component.ID) (auth.Client, error) {
+	if ext, found := extensions[id]; found {
 		if client, ok := ext.(auth.Client); ok {
 			return client, nil
 		}
 		return nil, errNotClient
 	}
-	return nil, fmt.Errorf("failed to resolve authenticator %q: %w", a.AuthenticatorID, errAuthenticatorNotFound)
+	return nil, fmt.Errorf("failed to resolve authenticator %q: %w", id, errAuthenticatorNotFound)
 }
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
@@ -113,3 +114,67 @@ func TestGetClientFails(t *testing.T) {
 	assert.ErrorIs(t, err, errAuthenticatorNotFound)
 	assert.Nil(t, authenticator)
 }
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Authentication{AuthenticatorID: component.NewID("a")}.Validate())
+	assert.NoError(t, Authentication{AuthenticatorIDs: []component.ID{component.NewID("a"), component.NewID("b")}}.Validate())
+	assert.EqualError(t, Authentication{
+		AuthenticatorID:  component.NewID("a"),
+		AuthenticatorIDs: []component.ID{component.NewID("b")},
+	}.Validate(), "only one of authenticator and authenticators can be set")
+}
+
+func TestGetServerChained(t *testing.T) {
+	ext := map[component.ID]component.Component{
+		component.NewID("a"):      auth.NewServer(),
+		component.NewID("b"):      auth.NewServer(),
+		component.NewID("client"): auth.NewClient(),
+	}
+	authenticator, err := (&Authentication{AuthenticatorIDs: []component.ID{component.NewID("a"), component.NewID("b")}}).GetServerAuthenticator(ext)
+	assert.NoError(t, err)
+	assert.NotNil(t, authenticator)
+
+	authenticator, err = (&Authentication{AuthenticatorIDs: []component.ID{component.NewID("b")}}).GetServerAuthenticator(ext)
+	assert.NoError(t, err)
+	assert.Same(t, ext[component.NewID("b")], authenticator)
+
+	_, err = (&Authentication{AuthenticatorIDs: []component.ID{component.NewID("a"), component.NewID("client")}}).GetServerAuthenticator(ext)
+	assert.ErrorIs(t, err, errNotServer)
+
+	_, err = (&Authentication{AuthenticatorIDs: []component.ID{component.NewID("a"), component.NewID("missing")}}).GetServerAuthenticator(ext)
+	assert.ErrorIs(t, err, errAuthenticatorNotFound)
+}
+
+func TestGetClients(t *testing.T) {
+	a, b := auth.NewClient(), auth.NewClient()
+	ext := map[component.ID]component.Component{
+		component.NewID("a"):      a,
+		component.NewID("b"):      b,
+		component.NewID("server"): auth.NewServer(),
+	}
+
+	clients, err := (&Authentication{AuthenticatorID: component.NewID("a")}).GetClientAuthenticators(ext)
+	assert.NoError(t, err)
+	require.Len(t, clients, 1)
+	assert.Same(t, a, clients[0])
+
+	cfg := &Authentication{AuthenticatorIDs: []component.ID{component.NewID("b"), component.NewID("a")}}
+	clients, err = cfg.GetClientAuthenticators(ext)
+	assert.NoError(t, err)
+	require.Len(t, clients, 2)
+	assert.Same(t, b, clients[0])
+	assert.Same(t, a, clients[1])
+
+	_, err = cfg.GetClientAuthenticator(ext)
+	assert.ErrorIs(t, err, errChained)
+
+	client, err := (&Authentication{AuthenticatorIDs: []component.ID{component.NewID("b")}}).GetClientAuthenticator(ext)
+	assert.NoError(t, err)
+	assert.Same(t, b, client)
+
+	_, err = (&Authentication{AuthenticatorIDs: []component.ID{component.NewID("a"), component.NewID("server")}}).GetClientAuthenticators(ext)
+	assert.ErrorIs(t, err, errNotClient)
+
+	_, err = (&Authentication{AuthenticatorIDs: []component.ID{component.NewID("a"), component.NewID("missing")}}).GetClientAuthenticators(ext)
+	assert.ErrorIs(t, err, errAuthenticatorNotFound)
+}
@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configauth // import "go.opentelemetry.io/collector/config/configauth"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/auth"
+)
+
+type authenticatorKey struct{}
+
+// AuthenticatorFromContext returns the ID of the authenticator which accepted the incoming request, when several
+// server authenticators are configured. The second return value is false when no such ID is in the context.
+func AuthenticatorFromContext(ctx context.Context) (component.ID, bool) {
+	id, ok := ctx.Value(authenticatorKey{}).(component.ID)
+	return id, ok
+}
+
+// newFallbackServer returns an auth.Server authenticating the requests with the first of the servers accepting
+// them, in order. The servers are extensions started and shut down by the service, not by the returned server.
+func newFallbackServer(ids []component.ID, servers []auth.Server) auth.Server {
+	return auth.NewServer(auth.WithServerAuthenticate(func(ctx context.Context, headers map[string][]string) (context.Context, error) {
+		var errs []error
+		for i, server := range servers {
+			authCtx, err := server.Authenticate(ctx, headers)
+			if err == nil {
+				return context.WithValue(authCtx, authenticatorKey{}, ids[i]), nil
+			}
+			errs = append(errs, fmt.Errorf("authenticator %q: %w", ids[i], err))
+			if ctx.Err() != nil {
+				break
+			}
+		}
+		return ctx, errors.Join(errs...)
+	}))
+}
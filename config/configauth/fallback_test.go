@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configauth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/auth"
+)
+
+type ctxKey struct{}
+
+// headerServer accepts the requests with the given value of the "authorization" header.
+func headerServer(value string) auth.Server {
+	return auth.NewServer(auth.WithServerAuthenticate(func(ctx context.Context, headers map[string][]string) (context.Context, error) {
+		if len(headers["authorization"]) == 0 || headers["authorization"][0] != value {
+			return ctx, errors.New("invalid credentials")
+		}
+		return context.WithValue(ctx, ctxKey{}, value), nil
+	}))
+}
+
+func TestFallbackServer(t *testing.T) {
+	cfg := &Authentication{AuthenticatorIDs: []component.ID{component.NewID("apikey"), component.NewID("oidc")}}
+	server, err := cfg.GetServerAuthenticator(map[component.ID]component.Component{
+		component.NewID("apikey"): headerServer("old-key"),
+		component.NewID("oidc"):   headerServer("new-token"),
+	})
+	require.NoError(t, err)
+
+	_, ok := AuthenticatorFromContext(context.Background())
+	assert.False(t, ok)
+
+	ctx, err := server.Authenticate(context.Background(), map[string][]string{"authorization": {"old-key"}})
+	require.NoError(t, err)
+	id, ok := AuthenticatorFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, component.NewID("apikey"), id)
+	assert.Equal(t, "old-key", ctx.Value(ctxKey{}))
+
+	ctx, err = server.Authenticate(context.Background(), map[string][]string{"authorization": {"new-token"}})
+	require.NoError(t, err)
+	id, ok = AuthenticatorFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, component.NewID("oidc"), id)
+	assert.Equal(t, "new-token", ctx.Value(ctxKey{}))
+
+	_, err = server.Authenticate(context.Background(), map[string][]string{"authorization": {"unknown"}})
+	assert.EqualError(t, err, "authenticator \"apikey\": invalid credentials\nauthenticator \"oidc\": invalid credentials")
+}
+
+func TestFallbackServerCanceled(t *testing.T) {
+	calls := 0
+	canceling := auth.NewServer(auth.WithServerAuthenticate(func(ctx context.Context, _ map[string][]string) (context.Context, error) {
+		calls++
+		return ctx, ctx.Err()
+	}))
+	server := newFallbackServer([]component.ID{component.NewID("a"), component.NewID("b")}, []auth.Server{canceling, canceling})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := server.Authenticate(ctx, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configcompression // import "go.opentelemetry.io/collector/config/configcompression"
+
+import (
+	"errors"
+)
+
+// minRatioCheckSize is the size of the decompressed payload from which MaxRatio is enforced:
+// small payloads may legitimately be very compressible, and a ratio measured on the first
+// bytes of a stream is not meaningful.
+const minRatioCheckSize = 64 * 1024
+
+// ErrDecompressionLimitExceeded is returned when a payload exceeds the DecompressionLimits.
+var ErrDecompressionLimitExceeded = errors.New("decompressed payload exceeds the decompression limits")
+
+// DecompressionLimits defines the limits applied by a server to the decompression of the payloads
+// it receives, so that a small malicious payload cannot expand to gigabytes in memory.
+type DecompressionLimits struct {
+	// MaxDecompressedSize is the maximum size, in bytes, of a decompressed payload. No limit if 0.
+	MaxDecompressedSize int64 `mapstructure:"max_decompressed_size"`
+
+	// MaxRatio is the maximum ratio between the decompressed and the compressed size of a payload. No limit if 0.
+	MaxRatio int64 `mapstructure:"max_ratio"`
+}
+
+// Validate checks if the DecompressionLimits configuration is valid.
+func (dl *DecompressionLimits) Validate() error {
+	if dl.MaxDecompressedSize < 0 {
+		return errors.New("max_decompressed_size must not be negative")
+	}
+	if dl.MaxRatio < 0 {
+		return errors.New("max_ratio must not be negative")
+	}
+	return nil
+}
+
+// Enabled returns true if any limit is set.
+func (dl *DecompressionLimits) Enabled() bool {
+	return dl.MaxDecompressedSize > 0 || dl.MaxRatio > 0
+}
+
+// Check returns ErrDecompressionLimitExceeded if compressedSize bytes decompressed into decompressedSize
+// bytes exceed the limits. It can be called while the payload is being decompressed, with the sizes
+// read so far.
+func (dl *DecompressionLimits) Check(compressedSize, decompressedSize int64) error {
+	if dl.MaxDecompressedSize > 0 && decompressedSize > dl.MaxDecompressedSize {
+		return ErrDecompressionLimitExceeded
+	}
+	if dl.MaxRatio > 0 && decompressedSize >= minRatioCheckSize && decompressedSize > dl.MaxRatio*compressedSize {
+		return ErrDecompressionLimitExceeded
+	}
+	return nil
+}
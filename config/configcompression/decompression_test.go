@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configcompression
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecompressionLimitsValidate(t *testing.T) {
+	assert.NoError(t, (&DecompressionLimits{}).Validate())
+	assert.NoError(t, (&DecompressionLimits{MaxDecompressedSize: 1024, MaxRatio: 10}).Validate())
+	assert.EqualError(t, (&DecompressionLimits{MaxDecompressedSize: -1}).Validate(), "max_decompressed_size must not be negative")
+	assert.EqualError(t, (&DecompressionLimits{MaxRatio: -1}).Validate(), "max_ratio must not be negative")
+}
+
+func TestDecompressionLimitsCheck(t *testing.T) {
+	tests := []struct {
+		name         string
+		limits       DecompressionLimits
+		compressed   int64
+		decompressed int64
+		exceeded     bool
+	}{
+		{
+			name:         "no_limits",
+			compressed:   1024,
+			decompressed: 1 << 30,
+		},
+		{
+			name:         "below_max_size",
+			limits:       DecompressionLimits{MaxDecompressedSize: 1 << 20},
+			compressed:   1024,
+			decompressed: 1 << 20,
+		},
+		{
+			name:         "above_max_size",
+			limits:       DecompressionLimits{MaxDecompressedSize: 1 << 20},
+			compressed:   1 << 20,
+			decompressed: 1<<20 + 1,
+			exceeded:     true,
+		},
+		{
+			name:         "below_max_ratio",
+			limits:       DecompressionLimits{MaxRatio: 100},
+			compressed:   1024,
+			decompressed: 100 * 1024,
+		},
+		{
+			name:         "above_max_ratio",
+			limits:       DecompressionLimits{MaxRatio: 100},
+			compressed:   1024,
+			decompressed: 100*1024 + 1,
+			exceeded:     true,
+		},
+		{
+			name:         "small_payload_above_max_ratio",
+			limits:       DecompressionLimits{MaxRatio: 100},
+			compressed:   10,
+			decompressed: 10 * 1024,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.limits.Check(tt.compressed, tt.decompressed)
+			if tt.exceeded {
+				assert.ErrorIs(t, err, ErrDecompressionLimitExceeded)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
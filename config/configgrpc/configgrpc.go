@@ -16,14 +16,17 @@ import (
 	"github.com/mostynb/go-grpc-compression/nonclobbering/zstd"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.opentelemetry.io/otel"
+	"go.uber.org/multierr"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
@@ -51,7 +54,8 @@ type KeepaliveClientConfig struct {
 type GRPCClientSettings struct {
 	// The target to which the exporter is going to send traces or metrics,
 	// using the gRPC protocol. The valid syntax is described at
-	// https://github.com/grpc/grpc/blob/master/doc/naming.md.
+	// https://github.com/grpc/grpc/blob/master/doc/naming.md. The "unix://" and "npipe://" schemes
+	// connect to a Unix domain socket or a Windows named pipe, see confignet.ParseLocalEndpoint.
 	Endpoint string `mapstructure:"endpoint"`
 
 	// The compression key for supported compression types within collector.
@@ -79,6 +83,11 @@ type GRPCClientSettings struct {
 	// The headers associated with gRPC requests.
 	Headers map[string]configopaque.String `mapstructure:"headers"`
 
+	// HeadersFromContext lists the keys of the client.Metadata, taken from the context of each
+	// outgoing request, that are sent as gRPC metadata. The metadata is only available when the
+	// receiver is configured with `include_metadata`.
+	HeadersFromContext []string `mapstructure:"headers_from_context"`
+
 	// Sets the balancer in grpclb_policy to discover the servers. Default is pick_first.
 	// https://github.com/grpc/grpc-go/blob/master/examples/features/load_balancing/README.md
 	BalancerName string `mapstructure:"balancer_name"`
@@ -118,7 +127,8 @@ type KeepaliveEnforcementPolicy struct {
 
 // GRPCServerSettings defines common settings for a gRPC server configuration.
 type GRPCServerSettings struct {
-	// Server net.Addr config. For transport only "tcp" and "unix" are valid options.
+	// Server net.Addr config. For transport only "tcp" and "unix" are valid options. An endpoint with the
+	// "unix://" scheme listens on a Unix domain socket, see confignet.ParseLocalEndpoint.
 	NetAddr confignet.NetAddr `mapstructure:",squash"`
 
 	// Configures the protocol to use TLS.
@@ -128,10 +138,17 @@ type GRPCServerSettings struct {
 	// MaxRecvMsgSizeMiB sets the maximum size (in MiB) of messages accepted by the server.
 	MaxRecvMsgSizeMiB uint64 `mapstructure:"max_recv_msg_size_mib"`
 
+	// DecompressionLimits bounds the size of the messages accepted by the server once decompressed.
+	DecompressionLimits configcompression.DecompressionLimits `mapstructure:"decompression_limits"`
+
 	// MaxConcurrentStreams sets the limit on the number of concurrent streams to each ServerTransport.
 	// It has effect only for streaming RPCs.
 	MaxConcurrentStreams uint32 `mapstructure:"max_concurrent_streams"`
 
+	// RateLimits limits the rate of the messages and of the bytes received from each client.
+	// The default value is nil, which doesn't limit the clients.
+	RateLimits *RateLimitSettings `mapstructure:"rate_limits"`
+
 	// ReadBufferSize for gRPC server. See grpc.ReadBufferSize.
 	// (https://godoc.org/google.golang.org/grpc#ReadBufferSize).
 	ReadBufferSize int `mapstructure:"read_buffer_size"`
@@ -149,8 +166,52 @@ type GRPCServerSettings struct {
 	// Include propagates the incoming connection's metadata to downstream consumers.
 	// Experimental: *NOTE* this option is subject to change or removal in the future.
 	IncludeMetadata bool `mapstructure:"include_metadata"`
+
+	// MetadataRules, if not empty, lists the only metadata keys added to the client metadata when IncludeMetadata
+	// is enabled, allowing to rename them or to give them a default value.
+	// Experimental: *NOTE* this option is subject to change or removal in the future.
+	MetadataRules []MetadataRule `mapstructure:"metadata_rules"`
+
+	// ProxyProtocol configures support for the PROXY protocol, to obtain the address of
+	// the original client when the receiver is behind an L4 load balancer.
+	ProxyProtocol *confignet.ProxyProtocolSettings `mapstructure:"proxy_protocol"`
+}
+
+// MetadataRule adds a key of the metadata of the incoming RPCs to the client metadata.
+type MetadataRule struct {
+	// Key is the metadata key, matched case-insensitively.
+	Key string `mapstructure:"key"`
+
+	// Rename is the key in the client metadata. If not set, Key is used.
+	Rename string `mapstructure:"rename"`
+
+	// Default is the value added to the client metadata if the RPC doesn't have the key.
+	// If not set, the key is omitted.
+	Default string `mapstructure:"default"`
+}
+
+// Validate checks that the rule has a key.
+func (mr *MetadataRule) Validate() error {
+	if mr.Key == "" {
+		return errors.New("metadata rule key must not be empty")
+	}
+	return nil
+}
+
+func toInternalMetadataRules(rules []MetadataRule) []internal.MetadataRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	internalRules := make([]internal.MetadataRule, len(rules))
+	for i, rule := range rules {
+		internalRules[i] = internal.MetadataRule{Key: rule.Key, Rename: rule.Rename, Default: rule.Default}
+	}
+	return internalRules
 }
 
+// localTarget is the target of the connections to a local socket, setting the authority of the RPCs to localhost.
+const localTarget = "passthrough:///localhost"
+
 // SanitizedEndpoint strips the prefix of either http:// or https:// from configgrpc.GRPCClientSettings.Endpoint.
 func (gcs *GRPCClientSettings) SanitizedEndpoint() string {
 	switch {
@@ -181,6 +242,13 @@ func (gcs *GRPCClientSettings) ToClientConn(ctx context.Context, host component.
 		return nil, err
 	}
 	opts = append(opts, extraOpts...)
+	if la, ok := confignet.ParseLocalEndpoint(gcs.Endpoint); ok {
+		// The connections are dialed to the local socket, whatever the target.
+		opts = append(opts, grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return la.DialContext(ctx)
+		}))
+		return grpc.DialContext(ctx, localTarget, opts...)
+	}
 	return grpc.DialContext(ctx, gcs.SanitizedEndpoint(), opts...)
 }
 
@@ -228,16 +296,27 @@ func (gcs *GRPCClientSettings) toDialOptions(host component.Host, settings compo
 			return nil, errors.New("no extensions configuration available")
 		}
 
-		grpcAuthenticator, cerr := gcs.Auth.GetClientAuthenticator(host.GetExtensions())
+		authenticators, cerr := gcs.Auth.GetClientAuthenticators(host.GetExtensions())
 		if cerr != nil {
 			return nil, cerr
 		}
 
-		perRPCCredentials, perr := grpcAuthenticator.PerRPCCredentials()
-		if perr != nil {
-			return nil, err
+		// The credentials are applied in order, the metadata returned by the last ones
+		// overriding the metadata with the same keys returned by the first ones.
+		var refreshers []auth.ClientRefresher
+		for _, grpcAuthenticator := range authenticators {
+			perRPCCredentials, perr := grpcAuthenticator.PerRPCCredentials()
+			if perr != nil {
+				return nil, perr
+			}
+			opts = append(opts, grpc.WithPerRPCCredentials(perRPCCredentials))
+			if refresher, ok := grpcAuthenticator.(auth.ClientRefresher); ok {
+				refreshers = append(refreshers, refresher)
+			}
+		}
+		if len(refreshers) > 0 {
+			opts = append(opts, grpc.WithChainUnaryInterceptor(refreshUnaryClientInterceptor(refreshers)))
 		}
-		opts = append(opts, grpc.WithPerRPCCredentials(perRPCCredentials))
 	}
 
 	if gcs.BalancerName != "" {
@@ -262,20 +341,83 @@ func (gcs *GRPCClientSettings) toDialOptions(host component.Host, settings compo
 	opts = append(opts, grpc.WithUnaryInterceptor(otelgrpc.UnaryClientInterceptor(otelOpts...)))
 	opts = append(opts, grpc.WithStreamInterceptor(otelgrpc.StreamClientInterceptor(otelOpts...)))
 
+	if len(gcs.HeadersFromContext) > 0 {
+		opts = append(opts, grpc.WithChainUnaryInterceptor(headersFromContextUnaryInterceptor(gcs.HeadersFromContext)))
+		opts = append(opts, grpc.WithChainStreamInterceptor(headersFromContextStreamInterceptor(gcs.HeadersFromContext)))
+	}
+
 	return opts, nil
 }
 
+// contextWithHeadersFromClient appends the values of the given client.Metadata keys to the outgoing gRPC metadata.
+func contextWithHeadersFromClient(ctx context.Context, keys []string) context.Context {
+	cl := client.FromContext(ctx)
+	for _, key := range keys {
+		for _, v := range cl.Metadata.Get(key) {
+			ctx = metadata.AppendToOutgoingContext(ctx, key, v)
+		}
+	}
+	return ctx
+}
+
+func headersFromContextUnaryInterceptor(keys []string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(contextWithHeadersFromClient(ctx, keys), method, req, reply, cc, opts...)
+	}
+}
+
+func headersFromContextStreamInterceptor(keys []string) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(contextWithHeadersFromClient(ctx, keys), desc, cc, method, opts...)
+	}
+}
+
+// refreshUnaryClientInterceptor refreshes the credentials of the client authenticators, and retries the RPC once,
+// when the server rejects an RPC with the Unauthenticated code.
+func refreshUnaryClientInterceptor(refreshers []auth.ClientRefresher) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if status.Code(err) != codes.Unauthenticated {
+			return err
+		}
+		refreshed := false
+		for _, refresher := range refreshers {
+			if refresher.RefreshCredentials(ctx) == nil {
+				refreshed = true
+			}
+		}
+		if !refreshed {
+			return err
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
 func validateBalancerName(balancerName string) bool {
 	return balancer.Get(balancerName) != nil
 }
 
 // ToListener returns the net.Listener constructed from the settings.
 func (gss *GRPCServerSettings) ToListener() (net.Listener, error) {
-	return gss.NetAddr.Listen()
+	var listener net.Listener
+	var err error
+	if la, ok := confignet.ParseLocalEndpoint(gss.NetAddr.Endpoint); ok {
+		listener, err = la.Listen()
+	} else {
+		listener, err = gss.NetAddr.Listen()
+	}
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := gss.ProxyProtocol.WrapListener(listener)
+	if err != nil {
+		return nil, multierr.Append(err, listener.Close())
+	}
+	return wrapped, nil
 }
 
 func (gss *GRPCServerSettings) ToServer(host component.Host, settings component.TelemetrySettings, extraOpts ...grpc.ServerOption) (*grpc.Server, error) {
-	opts, err := gss.toServerOption(host, settings)
+	opts, err := gss.toServerOption(host, settings, extraOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -283,7 +425,7 @@ func (gss *GRPCServerSettings) ToServer(host component.Host, settings component.
 	return grpc.NewServer(opts...), nil
 }
 
-func (gss *GRPCServerSettings) toServerOption(host component.Host, settings component.TelemetrySettings) ([]grpc.ServerOption, error) {
+func (gss *GRPCServerSettings) toServerOption(host component.Host, settings component.TelemetrySettings, extraOpts ...grpc.ServerOption) ([]grpc.ServerOption, error) {
 	switch gss.NetAddr.Transport {
 	case "tcp", "tcp4", "tcp6", "udp", "udp4", "udp6":
 		internal.WarnOnUnspecifiedHost(settings.Logger, gss.NetAddr.Endpoint)
@@ -291,13 +433,19 @@ func (gss *GRPCServerSettings) toServerOption(host component.Host, settings comp
 
 	var opts []grpc.ServerOption
 
+	creds := insecure.NewCredentials()
 	if gss.TLSSetting != nil {
 		tlsCfg, err := gss.TLSSetting.LoadTLSConfig()
 		if err != nil {
 			return nil, err
 		}
-		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsCfg)))
+		creds = credentials.NewTLS(tlsCfg)
 	}
+	connMetrics, err := newConnectionMetrics(settings.MeterProvider, componentIDAttributes(extraOpts))
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, grpc.Creds(connMetrics.wrapCredentials(creds)))
 
 	if gss.MaxRecvMsgSizeMiB > 0 {
 		opts = append(opts, grpc.MaxRecvMsgSize(int(gss.MaxRecvMsgSizeMiB*1024*1024)))
@@ -360,6 +508,29 @@ func (gss *GRPCServerSettings) toServerOption(host component.Host, settings comp
 		})
 	}
 
+	if gss.RateLimits != nil {
+		if gss.RateLimits.PerAuthIdentity != nil && gss.Auth == nil {
+			return nil, errors.New("rate_limits::per_auth_identity requires auth to be configured")
+		}
+		limiter, err := newRateLimiter(*gss.RateLimits, settings.MeterProvider, componentIDAttributes(extraOpts))
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.StatsHandler(limiter))
+		uInterceptors = append(uInterceptors, limiter.unaryServerInterceptor)
+		sInterceptors = append(sInterceptors, limiter.streamServerInterceptor)
+	}
+
+	if gss.DecompressionLimits.Enabled() {
+		limiter, err := newDecompressionLimiter(gss.DecompressionLimits, settings.MeterProvider)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.StatsHandler(limiter))
+		uInterceptors = append(uInterceptors, decompressionUnaryServerInterceptor)
+		sInterceptors = append(sInterceptors, decompressionStreamServerInterceptor)
+	}
+
 	otelOpts := []otelgrpc.Option{
 		otelgrpc.WithTracerProvider(settings.TracerProvider),
 		otelgrpc.WithMeterProvider(settings.MeterProvider),
@@ -371,8 +542,9 @@ func (gss *GRPCServerSettings) toServerOption(host component.Host, settings comp
 	uInterceptors = append(uInterceptors, otelgrpc.UnaryServerInterceptor(otelOpts...))
 	sInterceptors = append(sInterceptors, otelgrpc.StreamServerInterceptor(otelOpts...))
 
-	uInterceptors = append(uInterceptors, enhanceWithClientInformation(gss.IncludeMetadata))
-	sInterceptors = append(sInterceptors, enhanceStreamWithClientInformation(gss.IncludeMetadata))
+	metadataRules := toInternalMetadataRules(gss.MetadataRules)
+	uInterceptors = append(uInterceptors, enhanceWithClientInformation(gss.IncludeMetadata, metadataRules))
+	sInterceptors = append(sInterceptors, enhanceStreamWithClientInformation(gss.IncludeMetadata, metadataRules))
 
 	opts = append(opts, grpc.ChainUnaryInterceptor(uInterceptors...), grpc.ChainStreamInterceptor(sInterceptors...))
 
@@ -395,24 +567,29 @@ func getGRPCCompressionName(compressionType configcompression.CompressionType) (
 
 // enhanceWithClientInformation intercepts the incoming RPC, replacing the incoming context with one that includes
 // a client.Info, potentially with the peer's address.
-func enhanceWithClientInformation(includeMetadata bool) func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+func enhanceWithClientInformation(includeMetadata bool, metadataRules []internal.MetadataRule) func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
 	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
-		return handler(contextWithClient(ctx, includeMetadata), req)
+		return handler(contextWithClient(ctx, includeMetadata, metadataRules), req)
 	}
 }
 
-func enhanceStreamWithClientInformation(includeMetadata bool) func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+func enhanceStreamWithClientInformation(includeMetadata bool, metadataRules []internal.MetadataRule) func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
 	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-		return handler(srv, wrapServerStream(contextWithClient(ss.Context(), includeMetadata), ss))
+		return handler(srv, wrapServerStream(contextWithClient(ss.Context(), includeMetadata, metadataRules), ss))
 	}
 }
 
-// contextWithClient attempts to add the peer address to the client.Info from the context. When no
-// client.Info exists in the context, one is created.
-func contextWithClient(ctx context.Context, includeMetadata bool) context.Context {
+// contextWithClient attempts to add the peer address, protocol, TLS version and verified certificates to the client.Info from
+// the context. When no client.Info exists in the context, one is created.
+func contextWithClient(ctx context.Context, includeMetadata bool, metadataRules []internal.MetadataRule) context.Context {
 	cl := client.FromContext(ctx)
 	if p, ok := peer.FromContext(ctx); ok {
 		cl.Addr = p.Addr
+		cl.Protocol = client.ProtocolGRPC
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			cl.PeerCertificates = client.PeerCertificatesFromTLS(&tlsInfo.State)
+			cl.TLSVersion = client.TLSVersionName(tlsInfo.State.Version)
+		}
 	}
 	if includeMetadata {
 		if md, ok := metadata.FromIncomingContext(ctx); ok {
@@ -420,6 +597,9 @@ func contextWithClient(ctx context.Context, includeMetadata bool) context.Contex
 			if len(md[client.MetadataHostName]) == 0 && len(md[":authority"]) > 0 {
 				copiedMD[client.MetadataHostName] = md[":authority"]
 			}
+			if len(metadataRules) > 0 {
+				copiedMD = internal.MapMetadata(copiedMD, metadataRules)
+			}
 			cl.Metadata = client.NewMetadata(copiedMD)
 		}
 	}
@@ -5,6 +5,9 @@ package configgrpc
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"net"
 	"os"
@@ -19,8 +22,11 @@ import (
 	"go.uber.org/zap/zaptest/observer"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
@@ -30,6 +36,7 @@ import (
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/config/internal"
 	"go.opentelemetry.io/collector/extension/auth"
 	"go.opentelemetry.io/collector/extension/auth/authtest"
 	"go.opentelemetry.io/collector/obsreport/obsreporttest"
@@ -181,7 +188,7 @@ func TestDefaultGrpcServerSettings(t *testing.T) {
 	}
 	opts, err := gss.toServerOption(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
 	assert.NoError(t, err)
-	assert.Len(t, opts, 2)
+	assert.Len(t, opts, 3)
 }
 
 func TestAllGrpcServerSettingsExceptAuth(t *testing.T) {
@@ -691,10 +698,11 @@ func TestReceiveOnUnixDomainSocket(t *testing.T) {
 
 func TestContextWithClient(t *testing.T) {
 	testCases := []struct {
-		desc       string
-		input      context.Context
-		doMetadata bool
-		expected   client.Info
+		desc          string
+		input         context.Context
+		doMetadata    bool
+		metadataRules []internal.MetadataRule
+		expected      client.Info
 	}{
 		{
 			desc:     "no peer information, empty client",
@@ -725,6 +733,7 @@ func TestContextWithClient(t *testing.T) {
 				Addr: &net.IPAddr{
 					IP: net.IPv4(1, 2, 3, 4),
 				},
+				Protocol: client.ProtocolGRPC,
 			},
 		},
 		{
@@ -742,6 +751,7 @@ func TestContextWithClient(t *testing.T) {
 				Addr: &net.IPAddr{
 					IP: net.IPv4(1, 2, 3, 5),
 				},
+				Protocol: client.ProtocolGRPC,
 			},
 		},
 		{
@@ -784,10 +794,26 @@ func TestContextWithClient(t *testing.T) {
 				Metadata: client.NewMetadata(map[string][]string{"test-metadata-key": {"test-value"}, ":authority": {"localhost:55443"}, "Host": {"localhost:55443"}}),
 			},
 		},
+		{
+			desc: "existing client with metadata and metadata rules",
+			input: metadata.NewIncomingContext(
+				client.NewContext(context.Background(), client.Info{}),
+				metadata.Pairs("x-tenant-id", "acme", "x-secret", "s3cr3t", ":authority", "localhost:55443"),
+			),
+			doMetadata: true,
+			metadataRules: []internal.MetadataRule{
+				{Key: "x-tenant-id", Rename: "tenant"},
+				{Key: "x-region", Default: "eu"},
+				{Key: "host"},
+			},
+			expected: client.Info{
+				Metadata: client.NewMetadata(map[string][]string{"tenant": {"acme"}, "x-region": {"eu"}, "host": {"localhost:55443"}}),
+			},
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
-			cl := client.FromContext(contextWithClient(tC.input, tC.doMetadata))
+			cl := client.FromContext(contextWithClient(tC.input, tC.doMetadata, tC.metadataRules))
 			assert.Equal(t, tC.expected, cl)
 		})
 	}
@@ -810,7 +836,7 @@ func TestStreamInterceptorEnhancesClient(t *testing.T) {
 	}
 
 	// test
-	err := enhanceStreamWithClientInformation(false)(nil, stream, nil, handler)
+	err := enhanceStreamWithClientInformation(false, nil)(nil, stream, nil, handler)
 
 	// verify
 	assert.NoError(t, err)
@@ -1093,3 +1119,222 @@ type mockHost struct {
 func (nh *mockHost) GetExtensions() map[component.ID]component.Component {
 	return nh.ext
 }
+
+func TestContextWithClientPeerCertificates(t *testing.T) {
+	pemData, err := os.ReadFile(filepath.Join("testdata", "client.crt"))
+	require.NoError(t, err)
+	block, _ := pem.Decode(pemData)
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.IPAddr{IP: net.IPv4(1, 2, 3, 4)},
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{
+			Version:          tls.VersionTLS13,
+			PeerCertificates: []*x509.Certificate{cert},
+			VerifiedChains:   [][]*x509.Certificate{{cert}},
+		}},
+	})
+	cl := client.FromContext(contextWithClient(ctx, false, nil))
+	require.Len(t, cl.PeerCertificates, 1)
+	assert.Equal(t, client.NewPeerCertificate(cert), cl.PeerCertificates[0])
+	assert.Equal(t, "1.3", cl.TLSVersion)
+
+	// Peers without TLS do not have certificates.
+	ctx = peer.NewContext(context.Background(), &peer.Peer{Addr: &net.IPAddr{IP: net.IPv4(1, 2, 3, 4)}})
+	assert.Empty(t, client.FromContext(contextWithClient(ctx, false, nil)).PeerCertificates)
+}
+
+func TestHeadersFromContextInterceptors(t *testing.T) {
+	ctx := client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{
+			"X-Tenant-Id": {"tenant-1", "tenant-2"},
+			"x-other":     {"other"},
+		}),
+	})
+	keys := []string{"x-tenant-id", "x-missing"}
+	verify := func(ctx context.Context) {
+		md, ok := metadata.FromOutgoingContext(ctx)
+		require.True(t, ok)
+		assert.Equal(t, []string{"tenant-1", "tenant-2"}, md.Get("x-tenant-id"))
+		assert.Empty(t, md.Get("x-other"))
+		assert.Empty(t, md.Get("x-missing"))
+	}
+
+	err := headersFromContextUnaryInterceptor(keys)(ctx, "method", nil, nil, nil,
+		func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+			verify(ctx)
+			return nil
+		})
+	assert.NoError(t, err)
+
+	_, err = headersFromContextStreamInterceptor(keys)(ctx, nil, nil, "method",
+		func(ctx context.Context, _ *grpc.StreamDesc, _ *grpc.ClientConn, _ string, _ ...grpc.CallOption) (grpc.ClientStream, error) {
+			verify(ctx)
+			return nil, nil
+		})
+	assert.NoError(t, err)
+}
+
+func TestRefreshUnaryClientInterceptor(t *testing.T) {
+	errUnauthenticated := status.Error(codes.Unauthenticated, "expired token")
+	tests := []struct {
+		name          string
+		refreshErr    error
+		firstErr      error
+		expectedErr   error
+		expectedCalls int
+	}{
+		{
+			name:          "accepted",
+			expectedCalls: 1,
+		},
+		{
+			name:          "refreshed",
+			firstErr:      errUnauthenticated,
+			expectedCalls: 2,
+		},
+		{
+			name:          "refresh_failed",
+			firstErr:      errUnauthenticated,
+			refreshErr:    errors.New("refresh failed"),
+			expectedErr:   errUnauthenticated,
+			expectedCalls: 1,
+		},
+		{
+			name:          "other_error",
+			firstErr:      status.Error(codes.Unavailable, "unavailable"),
+			expectedErr:   status.Error(codes.Unavailable, "unavailable"),
+			expectedCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refreshed := 0
+			refresher := auth.NewClient(auth.WithClientRefreshCredentials(func(context.Context) error {
+				if tt.refreshErr != nil {
+					return tt.refreshErr
+				}
+				refreshed++
+				return nil
+			})).(auth.ClientRefresher)
+
+			calls := 0
+			err := refreshUnaryClientInterceptor([]auth.ClientRefresher{refresher})(context.Background(), "method", nil, nil, nil,
+				func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+					calls++
+					if calls == 1 {
+						return tt.firstErr
+					}
+					return nil
+				})
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedCalls, calls)
+			assert.Equal(t, tt.expectedCalls-1, refreshed)
+		})
+	}
+}
+
+func TestChainedClientAuthenticators(t *testing.T) {
+	gss := &GRPCServerSettings{
+		NetAddr: confignet.NetAddr{Endpoint: "localhost:0", Transport: "tcp"},
+	}
+	ln, err := gss.ToListener()
+	require.NoError(t, err)
+	srv, err := gss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	mock := &grpcTraceServer{}
+	ptraceotlp.RegisterGRPCServer(srv, mock)
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	t.Cleanup(srv.Stop)
+
+	newAuthenticator := func(md map[string]string) auth.Client {
+		return auth.NewClient(auth.WithClientPerRPCCredentials(func() (credentials.PerRPCCredentials, error) {
+			return &staticPerRPCCredentials{md: md}, nil
+		}))
+	}
+	host := &mockHost{
+		ext: map[component.ID]component.Component{
+			component.NewID("token"):  newAuthenticator(map[string]string{"authorization": "Bearer token", "x-override": "token"}),
+			component.NewID("header"): newAuthenticator(map[string]string{"x-api-key": "key", "x-override": "header"}),
+		},
+	}
+	gcs := &GRPCClientSettings{
+		Endpoint:   ln.Addr().String(),
+		TLSSetting: configtls.TLSClientSetting{Insecure: true},
+		Auth: &configauth.Authentication{
+			AuthenticatorIDs: []component.ID{component.NewID("token"), component.NewID("header")},
+		},
+	}
+	grpcClientConn, err := gcs.ToClientConn(context.Background(), host, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, grpcClientConn.Close()) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_, err = ptraceotlp.NewGRPCClient(grpcClientConn).Export(ctx, ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+	require.NoError(t, err)
+
+	md, ok := metadata.FromIncomingContext(mock.recordedContext)
+	require.True(t, ok)
+	assert.Equal(t, []string{"Bearer token"}, md.Get("authorization"))
+	assert.Equal(t, []string{"key"}, md.Get("x-api-key"))
+	assert.Equal(t, []string{"header"}, md.Get("x-override"))
+}
+
+type staticPerRPCCredentials struct {
+	md map[string]string
+}
+
+func (c *staticPerRPCCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return c.md, nil
+}
+
+func (c *staticPerRPCCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+func TestMetadataRuleValidate(t *testing.T) {
+	assert.NoError(t, (&MetadataRule{Key: "x-tenant-id", Rename: "tenant"}).Validate())
+	assert.EqualError(t, (&MetadataRule{Rename: "tenant"}).Validate(), "metadata rule key must not be empty")
+}
+
+func TestUnixSocketEndpoint(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix domain sockets are not supported on Windows by all the versions")
+	}
+	endpoint := "unix://" + filepath.Join(t.TempDir(), "otelcol.sock")
+	gss := &GRPCServerSettings{
+		NetAddr: confignet.NetAddr{Endpoint: endpoint, Transport: "tcp"},
+	}
+	ln, err := gss.ToListener()
+	require.NoError(t, err)
+	srv, err := gss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	mock := &grpcTraceServer{}
+	ptraceotlp.RegisterGRPCServer(srv, mock)
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	t.Cleanup(srv.Stop)
+
+	gcs := &GRPCClientSettings{
+		Endpoint:   endpoint,
+		TLSSetting: configtls.TLSClientSetting{Insecure: true},
+	}
+	grpcClientConn, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, grpcClientConn.Close()) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_, err = ptraceotlp.NewGRPCClient(grpcClientConn).Export(ctx, ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+	require.NoError(t, err)
+
+	md, ok := metadata.FromIncomingContext(mock.recordedContext)
+	require.True(t, ok)
+	assert.Equal(t, []string{"localhost"}, md.Get(":authority"))
+}
@@ -0,0 +1,251 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configgrpc // import "go.opentelemetry.io/collector/config/configgrpc"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Reasons a connection of a gRPC server was closed, reported as the "reason" attribute of the
+// rpc.server.connections_closed metric.
+const (
+	// closeReasonKeepaliveEnforcement is used when the server closed the connection because the client
+	// pinged more frequently than allowed by the keepalive enforcement policy.
+	closeReasonKeepaliveEnforcement = "keepalive_enforcement"
+	// closeReasonMaxAge is used when the connection reached the MaxConnectionAge.
+	closeReasonMaxAge = "max_age"
+	// closeReasonMaxIdle is used when the connection was idle for longer than the MaxConnectionIdle.
+	closeReasonMaxIdle = "max_idle"
+	// closeReasonGoAway is used when the server sent a GOAWAY for any other reason, e.g. when shutting down.
+	closeReasonGoAway = "goaway"
+	// closeReasonServer is used when the server closed the connection without a GOAWAY, e.g. when the
+	// client did not acknowledge a keepalive ping in time.
+	closeReasonServer = "server_closed"
+	// closeReasonClient is used when the client closed the connection.
+	closeReasonClient = "client_closed"
+	// closeReasonNetworkError is used when the connection failed because of a network error.
+	closeReasonNetworkError = "network_error"
+)
+
+const (
+	http2FrameHeaderLen = 9
+	http2FrameGoAway    = 0x7
+	http2ErrCodeNo      = 0x0
+	// http2ErrCodeEnhanceYourCalm is the error code sent by the server when enforcing the keepalive policy.
+	http2ErrCodeEnhanceYourCalm = 0xb
+	// maxGoAwayPayloadLen bounds the part of the GOAWAY payload kept to classify it: the last stream ID
+	// and the error code, followed by the debug data set by the gRPC server.
+	maxGoAwayPayloadLen = 8 + 64
+)
+
+// WithComponentID returns a grpc.ServerOption, to pass to GRPCServerSettings.ToServer, setting the ID
+// of the component running the server. The ID is recorded as the "receiver" attribute of the connection
+// and rate limiting metrics of the server.
+func WithComponentID(id component.ID) grpc.ServerOption {
+	return componentIDOption{id: id}
+}
+
+type componentIDOption struct {
+	grpc.EmptyServerOption
+	id component.ID
+}
+
+// componentIDAttributes returns the attributes of the metrics of the server set by WithComponentID.
+func componentIDAttributes(extraOpts []grpc.ServerOption) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	for _, opt := range extraOpts {
+		if idOpt, ok := opt.(componentIDOption); ok {
+			attrs = append(attrs, attribute.String("receiver", idOpt.id.String()))
+		}
+	}
+	return attrs
+}
+
+// connectionMetrics records why the connections accepted by a gRPC server were closed. The gRPC server
+// doesn't report it, so the connections are wrapped once the handshake completed to look at the GOAWAY
+// frames written by the server, and at the way the connection ended otherwise.
+type connectionMetrics struct {
+	closed metric.Int64Counter
+	attrs  []attribute.KeyValue
+}
+
+func newConnectionMetrics(mp metric.MeterProvider, attrs []attribute.KeyValue) (*connectionMetrics, error) {
+	if mp == nil {
+		mp = noop.NewMeterProvider()
+	}
+	closed, err := mp.Meter(scopeName).Int64Counter(
+		"rpc.server.connections_closed",
+		metric.WithDescription("Number of connections closed by the server or the client, by reason"),
+		metric.WithUnit("1"))
+	if err != nil {
+		return nil, err
+	}
+	return &connectionMetrics{closed: closed, attrs: attrs}, nil
+}
+
+func (cm *connectionMetrics) record(reason string) {
+	attrs := append([]attribute.KeyValue{attribute.String("reason", reason)}, cm.attrs...)
+	cm.closed.Add(context.Background(), 1, metric.WithAttributes(attrs...))
+}
+
+// wrapCredentials returns credentials wrapping the connections returned by the given credentials.
+func (cm *connectionMetrics) wrapCredentials(creds credentials.TransportCredentials) credentials.TransportCredentials {
+	return &connectionMetricsCredentials{TransportCredentials: creds, metrics: cm}
+}
+
+type connectionMetricsCredentials struct {
+	credentials.TransportCredentials
+	metrics *connectionMetrics
+}
+
+func (c *connectionMetricsCredentials) ServerHandshake(rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	conn, authInfo, err := c.TransportCredentials.ServerHandshake(rawConn)
+	if err != nil {
+		return conn, authInfo, err
+	}
+	return &metricsConn{Conn: conn, metrics: c.metrics}, authInfo, nil
+}
+
+func (c *connectionMetricsCredentials) Clone() credentials.TransportCredentials {
+	return &connectionMetricsCredentials{TransportCredentials: c.TransportCredentials.Clone(), metrics: c.metrics}
+}
+
+// metricsConn is a server connection recording why it was closed.
+type metricsConn struct {
+	net.Conn
+	metrics *connectionMetrics
+
+	mu        sync.Mutex
+	frames    goAwayParser
+	readErr   error
+	closeOnce sync.Once
+}
+
+func (c *metricsConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if err != nil {
+		c.mu.Lock()
+		if c.readErr == nil {
+			c.readErr = err
+		}
+		c.mu.Unlock()
+	}
+	return n, err
+}
+
+func (c *metricsConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	c.mu.Lock()
+	c.frames.write(b[:n])
+	c.mu.Unlock()
+	return n, err
+}
+
+func (c *metricsConn) Close() error {
+	c.closeOnce.Do(func() {
+		c.mu.Lock()
+		reason := c.closeReason()
+		c.mu.Unlock()
+		c.metrics.record(reason)
+	})
+	return c.Conn.Close()
+}
+
+// closeReason returns why the connection is being closed, the server always closing the connection
+// once done with it, even if it was closed by the client.
+func (c *metricsConn) closeReason() string {
+	switch {
+	case c.frames.reason != "":
+		return c.frames.reason
+	case c.readErr == nil:
+		return closeReasonServer
+	case errors.Is(c.readErr, io.EOF):
+		return closeReasonClient
+	default:
+		return closeReasonNetworkError
+	}
+}
+
+// goAwayParser follows the HTTP/2 frames written by the server to find the first GOAWAY frame.
+// The server sends a first GOAWAY when draining a connection, followed by a second one once the
+// client acknowledged it, so only the first one is kept.
+type goAwayParser struct {
+	header    [http2FrameHeaderLen]byte
+	headerLen int
+	remaining int
+	goAway    bool
+	payload   []byte
+	reason    string
+}
+
+func (p *goAwayParser) write(b []byte) {
+	for len(b) > 0 {
+		if p.headerLen < http2FrameHeaderLen {
+			n := copy(p.header[p.headerLen:], b)
+			p.headerLen += n
+			b = b[n:]
+			if p.headerLen < http2FrameHeaderLen {
+				return
+			}
+			p.remaining = int(p.header[0])<<16 | int(p.header[1])<<8 | int(p.header[2])
+			p.goAway = p.header[3] == http2FrameGoAway && p.reason == ""
+			p.payload = p.payload[:0]
+			if p.remaining == 0 {
+				p.frameDone()
+			}
+			continue
+		}
+		n := len(b)
+		if n > p.remaining {
+			n = p.remaining
+		}
+		if p.goAway && len(p.payload) < maxGoAwayPayloadLen {
+			keep := n
+			if keep > maxGoAwayPayloadLen-len(p.payload) {
+				keep = maxGoAwayPayloadLen - len(p.payload)
+			}
+			p.payload = append(p.payload, b[:keep]...)
+		}
+		p.remaining -= n
+		b = b[n:]
+		if p.remaining == 0 {
+			p.frameDone()
+		}
+	}
+}
+
+func (p *goAwayParser) frameDone() {
+	if p.goAway && len(p.payload) >= 8 {
+		p.reason = goAwayReason(binary.BigEndian.Uint32(p.payload[4:8]), string(p.payload[8:]))
+	}
+	p.headerLen = 0
+	p.goAway = false
+}
+
+// goAwayReason classifies a GOAWAY frame from its error code and the debug data set by the gRPC server.
+func goAwayReason(code uint32, debugData string) string {
+	switch {
+	case code == http2ErrCodeEnhanceYourCalm && debugData == "too_many_pings":
+		return closeReasonKeepaliveEnforcement
+	case code == http2ErrCodeNo && debugData == "max_age":
+		return closeReasonMaxAge
+	case code == http2ErrCodeNo && debugData == "max_idle":
+		return closeReasonMaxIdle
+	default:
+		return closeReasonGoAway
+	}
+}
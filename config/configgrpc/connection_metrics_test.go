@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configgrpc
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"golang.org/x/net/http2"
+	"google.golang.org/grpc"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+func TestGoAwayParser(t *testing.T) {
+	tests := []struct {
+		name      string
+		code      http2.ErrCode
+		debugData string
+		reason    string
+	}{
+		{name: "too_many_pings", code: http2.ErrCodeEnhanceYourCalm, debugData: "too_many_pings", reason: closeReasonKeepaliveEnforcement},
+		{name: "max_age", code: http2.ErrCodeNo, debugData: "max_age", reason: closeReasonMaxAge},
+		{name: "max_idle", code: http2.ErrCodeNo, debugData: "max_idle", reason: closeReasonMaxIdle},
+		{name: "graceful_stop", code: http2.ErrCodeNo, debugData: "graceful_stop", reason: closeReasonGoAway},
+		{name: "protocol_error", code: http2.ErrCodeProtocol, reason: closeReasonGoAway},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			framer := http2.NewFramer(&buf, nil)
+			require.NoError(t, framer.WriteSettings(http2.Setting{ID: http2.SettingMaxFrameSize, Val: 16384}))
+			require.NoError(t, framer.WriteData(1, false, bytes.Repeat([]byte("a"), 1000)))
+			require.NoError(t, framer.WriteGoAway(1, tt.code, []byte(tt.debugData)))
+			require.NoError(t, framer.WritePing(false, [8]byte{}))
+			// Only the first GOAWAY is used.
+			require.NoError(t, framer.WriteGoAway(1, http2.ErrCodeNo, []byte("graceful_stop")))
+
+			// The frames are written in chunks not aligned on the frame boundaries.
+			var p goAwayParser
+			data := buf.Bytes()
+			for len(data) > 0 {
+				n := 7
+				if n > len(data) {
+					n = len(data)
+				}
+				p.write(data[:n])
+				data = data[n:]
+			}
+			assert.Equal(t, tt.reason, p.reason)
+		})
+	}
+}
+
+func TestConnectionMetrics(t *testing.T) {
+	tests := []struct {
+		name      string
+		keepalive *KeepaliveServerConfig
+		close     func(srv *grpc.Server, conn *grpc.ClientConn)
+		reason    string
+	}{
+		{
+			name: "max_age",
+			keepalive: &KeepaliveServerConfig{
+				ServerParameters: &KeepaliveServerParameters{
+					MaxConnectionAge:      100 * time.Millisecond,
+					MaxConnectionAgeGrace: 100 * time.Millisecond,
+				},
+			},
+			close:  func(*grpc.Server, *grpc.ClientConn) {},
+			reason: closeReasonMaxAge,
+		},
+		{
+			name: "client_closed",
+			close: func(_ *grpc.Server, conn *grpc.ClientConn) {
+				assert.NoError(t, conn.Close())
+			},
+			reason: closeReasonClient,
+		},
+		{
+			name: "graceful_stop",
+			close: func(srv *grpc.Server, _ *grpc.ClientConn) {
+				srv.GracefulStop()
+			},
+			reason: closeReasonGoAway,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := sdkmetric.NewManualReader()
+			set := componenttest.NewNopTelemetrySettings()
+			set.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+			gss := &GRPCServerSettings{
+				NetAddr:   confignet.NetAddr{Endpoint: "localhost:0", Transport: "tcp"},
+				Keepalive: tt.keepalive,
+			}
+			ln, err := gss.ToListener()
+			require.NoError(t, err)
+			srv, err := gss.ToServer(componenttest.NewNopHost(), set, WithComponentID(component.NewID("otlp")))
+			require.NoError(t, err)
+			ptraceotlp.RegisterGRPCServer(srv, &grpcTraceServer{})
+			go func() {
+				_ = srv.Serve(ln)
+			}()
+			t.Cleanup(srv.Stop)
+
+			gcs := &GRPCClientSettings{
+				Endpoint:   ln.Addr().String(),
+				TLSSetting: configtls.TLSClientSetting{Insecure: true},
+			}
+			grpcClientConn, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = grpcClientConn.Close() })
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_, err = ptraceotlp.NewGRPCClient(grpcClientConn).Export(ctx, ptraceotlp.NewExportRequestFromTraces(ptrace.NewTraces()), grpc.WaitForReady(true))
+			require.NoError(t, err)
+
+			tt.close(srv, grpcClientConn)
+			assert.Eventually(t, func() bool {
+				var rm metricdata.ResourceMetrics
+				require.NoError(t, reader.Collect(context.Background(), &rm))
+				return connectionsClosed(rm, tt.reason, "otlp") > 0
+			}, 5*time.Second, 10*time.Millisecond)
+		})
+	}
+}
+
+func connectionsClosed(rm metricdata.ResourceMetrics, reason string, receiver string) int64 {
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if sum, ok := m.Data.(metricdata.Sum[int64]); ok && m.Name == "rpc.server.connections_closed" {
+				for _, dp := range sum.DataPoints {
+					if dp.Attributes.Equals(attributeSet(reason, receiver)) {
+						return dp.Value
+					}
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func attributeSet(reason string, receiver string) *attribute.Set {
+	set := attribute.NewSet(attribute.String("reason", reason), attribute.String("receiver", receiver))
+	return &set
+}
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configgrpc // import "go.opentelemetry.io/collector/config/configgrpc"
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/config/configcompression"
+)
+
+const scopeName = "go.opentelemetry.io/collector/config/configgrpc"
+
+type decompressionStateKey struct{}
+
+// decompressionState records whether a message of the RPC exceeded the decompression limits.
+type decompressionState struct {
+	exceeded atomic.Bool
+}
+
+// decompressionLimiter is a stats.Handler checking the size of the messages received once decompressed,
+// reported by the gRPC server after decompressing them. The messages exceeding the limits are then
+// rejected by the interceptors, before being handled. The memory used to decompress a message is
+// bounded by the maximum size of the messages accepted by the server, see GRPCServerSettings.MaxRecvMsgSizeMiB.
+type decompressionLimiter struct {
+	limits   configcompression.DecompressionLimits
+	exceeded metric.Int64Counter
+}
+
+func newDecompressionLimiter(limits configcompression.DecompressionLimits, mp metric.MeterProvider) (*decompressionLimiter, error) {
+	if mp == nil {
+		mp = noop.NewMeterProvider()
+	}
+	exceeded, err := mp.Meter(scopeName).Int64Counter(
+		"rpc.server.decompression_limit_exceeded",
+		metric.WithDescription("Number of messages rejected because their decompressed size exceeded the decompression limits"),
+		metric.WithUnit("1"))
+	if err != nil {
+		return nil, err
+	}
+	return &decompressionLimiter{limits: limits, exceeded: exceeded}, nil
+}
+
+func (dl *decompressionLimiter) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return context.WithValue(ctx, decompressionStateKey{}, &decompressionState{})
+}
+
+func (dl *decompressionLimiter) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	in, ok := s.(*stats.InPayload)
+	if !ok {
+		return
+	}
+	state, ok := ctx.Value(decompressionStateKey{}).(*decompressionState)
+	if !ok {
+		return
+	}
+	if dl.limits.Check(int64(in.CompressedLength), int64(in.Length)) != nil {
+		state.exceeded.Store(true)
+		dl.exceeded.Add(ctx, 1)
+	}
+}
+
+func (dl *decompressionLimiter) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (dl *decompressionLimiter) HandleConn(context.Context, stats.ConnStats) {}
+
+// checkDecompression returns an error if a message received by the RPC exceeded the decompression limits.
+func checkDecompression(ctx context.Context) error {
+	if state, ok := ctx.Value(decompressionStateKey{}).(*decompressionState); ok && state.exceeded.Load() {
+		return status.Error(codes.ResourceExhausted, configcompression.ErrDecompressionLimitExceeded.Error())
+	}
+	return nil
+}
+
+func decompressionUnaryServerInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := checkDecompression(ctx); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func decompressionStreamServerInterceptor(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &decompressionServerStream{ServerStream: ss})
+}
+
+// decompressionServerStream fails to receive the messages exceeding the decompression limits.
+type decompressionServerStream struct {
+	grpc.ServerStream
+}
+
+func (s *decompressionServerStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return checkDecompression(s.Context())
+}
@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configgrpc
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+func TestDecompressionLimits(t *testing.T) {
+	tests := []struct {
+		name     string
+		limits   configcompression.DecompressionLimits
+		exceeded bool
+	}{
+		{
+			name:   "within_limits",
+			limits: configcompression.DecompressionLimits{MaxDecompressedSize: 4 * 1024 * 1024, MaxRatio: 10000},
+		},
+		{
+			name:     "max_decompressed_size",
+			limits:   configcompression.DecompressionLimits{MaxDecompressedSize: 1024 * 1024},
+			exceeded: true,
+		},
+		{
+			name:     "max_ratio",
+			limits:   configcompression.DecompressionLimits{MaxRatio: 10},
+			exceeded: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := sdkmetric.NewManualReader()
+			set := componenttest.NewNopTelemetrySettings()
+			set.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+			gss := &GRPCServerSettings{
+				NetAddr:             confignet.NetAddr{Endpoint: "localhost:0", Transport: "tcp"},
+				DecompressionLimits: tt.limits,
+			}
+			ln, err := gss.ToListener()
+			require.NoError(t, err)
+			srv, err := gss.ToServer(componenttest.NewNopHost(), set)
+			require.NoError(t, err)
+			ptraceotlp.RegisterGRPCServer(srv, &grpcTraceServer{})
+			go func() {
+				_ = srv.Serve(ln)
+			}()
+			t.Cleanup(srv.Stop)
+
+			gcs := &GRPCClientSettings{
+				Endpoint:    ln.Addr().String(),
+				Compression: configcompression.Gzip,
+				TLSSetting:  configtls.TLSClientSetting{Insecure: true},
+			}
+			grpcClientConn, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+			t.Cleanup(func() { assert.NoError(t, grpcClientConn.Close()) })
+
+			// A payload of 2MiB compressing to a few KiB.
+			td := ptrace.NewTraces()
+			td.ResourceSpans().AppendEmpty().Resource().Attributes().PutStr("payload", strings.Repeat("a", 2*1024*1024))
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_, err = ptraceotlp.NewGRPCClient(grpcClientConn).Export(ctx, ptraceotlp.NewExportRequestFromTraces(td), grpc.WaitForReady(true))
+
+			var rm metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+			if !tt.exceeded {
+				assert.NoError(t, err)
+				assert.Zero(t, decompressionLimitExceeded(rm))
+				return
+			}
+			assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+			assert.Contains(t, err.Error(), configcompression.ErrDecompressionLimitExceeded.Error())
+			assert.Equal(t, int64(1), decompressionLimitExceeded(rm))
+		})
+	}
+}
+
+func TestDecompressionStreamServerInterceptor(t *testing.T) {
+	state := &decompressionState{}
+	ctx := context.WithValue(context.Background(), decompressionStateKey{}, state)
+	handler := func(_ any, stream grpc.ServerStream) error {
+		return stream.RecvMsg(nil)
+	}
+	stream := &mockServerStream{ctx: ctx, ServerStream: &recvServerStream{}}
+
+	assert.NoError(t, decompressionStreamServerInterceptor(nil, stream, &grpc.StreamServerInfo{}, handler))
+
+	state.exceeded.Store(true)
+	err := decompressionStreamServerInterceptor(nil, stream, &grpc.StreamServerInfo{}, handler)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+type recvServerStream struct {
+	grpc.ServerStream
+}
+
+func (recvServerStream) RecvMsg(any) error {
+	return nil
+}
+
+func decompressionLimitExceeded(rm metricdata.ResourceMetrics) int64 {
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if sum, ok := m.Data.(metricdata.Sum[int64]); ok && m.Name == "rpc.server.decompression_limit_exceeded" {
+				var total int64
+				for _, dp := range sum.DataPoints {
+					total += dp.Value
+				}
+				return total
+			}
+		}
+	}
+	return 0
+}
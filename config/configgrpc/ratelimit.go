@@ -0,0 +1,311 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configgrpc // import "go.opentelemetry.io/collector/config/configgrpc"
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"go.opentelemetry.io/collector/client"
+)
+
+const (
+	// rateLimitClientIP is the "limit" attribute of the throttled messages exceeding the rate limit of their client IP.
+	rateLimitClientIP = "client_ip"
+	// rateLimitAuthIdentity is the "limit" attribute of the throttled messages exceeding the rate limit of their
+	// authenticated identity.
+	rateLimitAuthIdentity = "auth_identity"
+
+	// retryAfterKey is the trailer metadata key telling the throttled clients how many seconds to wait before retrying.
+	retryAfterKey = "retry-after"
+
+	// rateLimitSweepInterval is the interval the rate limiters forget the idle clients at.
+	rateLimitSweepInterval = time.Minute
+)
+
+// RateLimit limits the rate of the messages and of the bytes received from a client.
+type RateLimit struct {
+	// RequestsPerSecond is the number of messages per second accepted from a client, each message of a stream
+	// counting as a request. Zero means no limit.
+	RequestsPerSecond float64 `mapstructure:"requests_per_second"`
+
+	// BytesPerSecond is the number of bytes per second accepted from a client, counting the size of the messages
+	// once decompressed. A client is allowed to exceed it by a single message, the following messages being
+	// throttled until the excess is paid back. Zero means no limit.
+	BytesPerSecond float64 `mapstructure:"bytes_per_second"`
+}
+
+// Validate checks that the rates are not negative.
+func (rl *RateLimit) Validate() error {
+	if rl.RequestsPerSecond < 0 {
+		return errors.New("requests_per_second must not be negative")
+	}
+	if rl.BytesPerSecond < 0 {
+		return errors.New("bytes_per_second must not be negative")
+	}
+	return nil
+}
+
+// RateLimitSettings defines the rate limits applied by a server to its clients. The messages exceeding them
+// are rejected with the RESOURCE_EXHAUSTED status, carrying the time to wait before retrying as a RetryInfo
+// detail and as the "retry-after" trailer metadata, in seconds.
+type RateLimitSettings struct {
+	// PerClientIP limits the messages received from each client IP address.
+	PerClientIP *RateLimit `mapstructure:"per_client_ip"`
+
+	// PerAuthIdentity limits the messages received from each authenticated subject, see client.Subject.
+	// It requires an authenticator setting the subject, the messages without one not being limited.
+	PerAuthIdentity *RateLimit `mapstructure:"per_auth_identity"`
+}
+
+type rateLimitStateKey struct{}
+
+// rateLimitState records the size of the last message received by the RPC.
+type rateLimitState struct {
+	lastMsgSize atomic.Int64
+}
+
+// rateLimiter is a stats.Handler recording the size of the messages received once decompressed, checked
+// by the interceptors against the rate limits of the client before the messages are handled.
+type rateLimiter struct {
+	perClientIP     *clientRateLimiter
+	perAuthIdentity *clientRateLimiter
+	throttled       metric.Int64Counter
+	attrs           []attribute.KeyValue
+	now             func() time.Time
+}
+
+func newRateLimiter(settings RateLimitSettings, mp metric.MeterProvider, attrs []attribute.KeyValue) (*rateLimiter, error) {
+	if mp == nil {
+		mp = noop.NewMeterProvider()
+	}
+	throttled, err := mp.Meter(scopeName).Int64Counter(
+		"rpc.server.throttled_requests",
+		metric.WithDescription("Number of messages rejected because their client exceeded the rate limits, by limit"),
+		metric.WithUnit("1"))
+	if err != nil {
+		return nil, err
+	}
+	rl := &rateLimiter{throttled: throttled, attrs: attrs, now: time.Now}
+	if settings.PerClientIP != nil {
+		rl.perClientIP = newClientRateLimiter(*settings.PerClientIP)
+	}
+	if settings.PerAuthIdentity != nil {
+		rl.perAuthIdentity = newClientRateLimiter(*settings.PerAuthIdentity)
+	}
+	return rl, nil
+}
+
+func (rl *rateLimiter) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return context.WithValue(ctx, rateLimitStateKey{}, &rateLimitState{})
+}
+
+func (rl *rateLimiter) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	in, ok := s.(*stats.InPayload)
+	if !ok {
+		return
+	}
+	if state, ok := ctx.Value(rateLimitStateKey{}).(*rateLimitState); ok {
+		state.lastMsgSize.Store(int64(in.Length))
+	}
+}
+
+func (rl *rateLimiter) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (rl *rateLimiter) HandleConn(context.Context, stats.ConnStats) {}
+
+// check returns an error if the last message received by the RPC exceeds the rate limits of its client.
+// It must be called once the client.Info of the context was set by the authenticator.
+func (rl *rateLimiter) check(ctx context.Context) error {
+	var size int64
+	if state, ok := ctx.Value(rateLimitStateKey{}).(*rateLimitState); ok {
+		size = state.lastMsgSize.Load()
+	}
+	now := rl.now()
+	if rl.perClientIP != nil {
+		if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+			if wait := rl.perClientIP.allow(clientIP(p.Addr), size, now); wait > 0 {
+				return rl.throttle(ctx, rateLimitClientIP, wait)
+			}
+		}
+	}
+	if rl.perAuthIdentity != nil {
+		if subject, ok := client.Subject(client.FromContext(ctx).Auth); ok {
+			if wait := rl.perAuthIdentity.allow(subject, size, now); wait > 0 {
+				return rl.throttle(ctx, rateLimitAuthIdentity, wait)
+			}
+		}
+	}
+	return nil
+}
+
+func (rl *rateLimiter) throttle(ctx context.Context, limit string, wait time.Duration) error {
+	attrs := append([]attribute.KeyValue{attribute.String("limit", limit)}, rl.attrs...)
+	rl.throttled.Add(ctx, 1, metric.WithAttributes(attrs...))
+
+	retryAfter := int64(math.Ceil(wait.Seconds()))
+	_ = grpc.SetTrailer(ctx, metadata.Pairs(retryAfterKey, strconv.FormatInt(retryAfter, 10)))
+	st := status.New(codes.ResourceExhausted, "rate limit exceeded, retry after "+wait.Round(time.Millisecond).String())
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(wait)}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+func (rl *rateLimiter) unaryServerInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := rl.check(ctx); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (rl *rateLimiter) streamServerInterceptor(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &rateLimitServerStream{ServerStream: ss, limiter: rl})
+}
+
+// rateLimitServerStream fails to receive the messages exceeding the rate limits of the client.
+type rateLimitServerStream struct {
+	grpc.ServerStream
+	limiter *rateLimiter
+}
+
+func (s *rateLimitServerStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.limiter.check(s.Context())
+}
+
+// clientIP returns the IP address of the client, without the port.
+func clientIP(addr net.Addr) string {
+	if tcpAddr, ok := addr.(*net.TCPAddr); ok {
+		return tcpAddr.IP.String()
+	}
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	return host
+}
+
+// clientRateLimiter applies a RateLimit to each client, with a token bucket per client and per rate.
+// The buckets of the idle clients are forgotten once full again.
+type clientRateLimiter struct {
+	limit RateLimit
+
+	mu        sync.Mutex
+	clients   map[string]*clientBuckets
+	lastSweep time.Time
+}
+
+type clientBuckets struct {
+	requests tokenBucket
+	bytes    tokenBucket
+}
+
+func newClientRateLimiter(limit RateLimit) *clientRateLimiter {
+	return &clientRateLimiter{limit: limit, clients: make(map[string]*clientBuckets)}
+}
+
+// allow takes a message of the given size from the buckets of the client, returning zero if the message is
+// accepted, and otherwise the time to wait before the client is allowed to send it.
+func (crl *clientRateLimiter) allow(key string, size int64, now time.Time) time.Duration {
+	crl.mu.Lock()
+	defer crl.mu.Unlock()
+	if now.Sub(crl.lastSweep) >= rateLimitSweepInterval {
+		crl.sweep(now)
+	}
+
+	cb, ok := crl.clients[key]
+	if !ok {
+		// The buckets of a new client are full, allowing it a burst of one second worth of messages.
+		cb = &clientBuckets{
+			requests: tokenBucket{tokens: crl.requestsCapacity(), last: now},
+			bytes:    tokenBucket{tokens: crl.limit.BytesPerSecond, last: now},
+		}
+		crl.clients[key] = cb
+	}
+	cb.requests.refill(now, crl.limit.RequestsPerSecond, crl.requestsCapacity())
+	cb.bytes.refill(now, crl.limit.BytesPerSecond, crl.limit.BytesPerSecond)
+
+	var wait time.Duration
+	if crl.limit.RequestsPerSecond > 0 && cb.requests.tokens < 1 {
+		wait = rateWait(1-cb.requests.tokens, crl.limit.RequestsPerSecond)
+	}
+	if crl.limit.BytesPerSecond > 0 && cb.bytes.tokens <= 0 {
+		if bytesWait := rateWait(-cb.bytes.tokens, crl.limit.BytesPerSecond); bytesWait > wait {
+			wait = bytesWait
+		}
+	}
+	if wait > 0 {
+		return wait
+	}
+	if crl.limit.RequestsPerSecond > 0 {
+		cb.requests.tokens--
+	}
+	if crl.limit.BytesPerSecond > 0 {
+		cb.bytes.tokens -= float64(size)
+	}
+	return 0
+}
+
+// requestsCapacity returns the capacity of the requests buckets, at least one request for the rates below one
+// request per second.
+func (crl *clientRateLimiter) requestsCapacity() float64 {
+	return math.Max(crl.limit.RequestsPerSecond, 1)
+}
+
+// sweep forgets the clients whose buckets are full, the same as new clients.
+func (crl *clientRateLimiter) sweep(now time.Time) {
+	crl.lastSweep = now
+	for key, cb := range crl.clients {
+		cb.requests.refill(now, crl.limit.RequestsPerSecond, crl.requestsCapacity())
+		cb.bytes.refill(now, crl.limit.BytesPerSecond, crl.limit.BytesPerSecond)
+		if cb.requests.tokens >= crl.requestsCapacity() && cb.bytes.tokens >= crl.limit.BytesPerSecond {
+			delete(crl.clients, key)
+		}
+	}
+}
+
+// tokenBucket holds up to one second worth of tokens, the tokens possibly going below zero.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func (tb *tokenBucket) refill(now time.Time, rate, capacity float64) {
+	if elapsed := now.Sub(tb.last); elapsed > 0 {
+		tb.tokens = math.Min(capacity, tb.tokens+elapsed.Seconds()*rate)
+		tb.last = now
+	}
+}
+
+// rateWait returns the time to refill the given number of tokens, at least a millisecond.
+func rateWait(tokens, rate float64) time.Duration {
+	wait := time.Duration(tokens / rate * float64(time.Second))
+	if wait < time.Millisecond {
+		return time.Millisecond
+	}
+	return wait
+}
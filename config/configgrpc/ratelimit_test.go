@@ -0,0 +1,261 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configgrpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/extension/auth"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+func TestRateLimitValidate(t *testing.T) {
+	assert.NoError(t, (&RateLimit{RequestsPerSecond: 10, BytesPerSecond: 1024}).Validate())
+	assert.NoError(t, (&RateLimit{}).Validate())
+	assert.EqualError(t, (&RateLimit{RequestsPerSecond: -1}).Validate(), "requests_per_second must not be negative")
+	assert.EqualError(t, (&RateLimit{BytesPerSecond: -1}).Validate(), "bytes_per_second must not be negative")
+}
+
+func TestClientRateLimiterRequests(t *testing.T) {
+	crl := newClientRateLimiter(RateLimit{RequestsPerSecond: 2})
+	now := time.Now()
+
+	// A burst of one second worth of requests is allowed.
+	assert.Zero(t, crl.allow("a", 100, now))
+	assert.Zero(t, crl.allow("a", 100, now))
+	assert.Equal(t, 500*time.Millisecond, crl.allow("a", 100, now))
+	// The clients have their own buckets.
+	assert.Zero(t, crl.allow("b", 100, now))
+
+	now = now.Add(250 * time.Millisecond)
+	assert.Equal(t, 250*time.Millisecond, crl.allow("a", 100, now))
+	now = now.Add(250 * time.Millisecond)
+	assert.Zero(t, crl.allow("a", 100, now))
+	assert.Equal(t, 500*time.Millisecond, crl.allow("a", 100, now))
+}
+
+func TestClientRateLimiterLowRate(t *testing.T) {
+	crl := newClientRateLimiter(RateLimit{RequestsPerSecond: 0.5})
+	now := time.Now()
+
+	assert.Zero(t, crl.allow("a", 0, now))
+	assert.Equal(t, 2*time.Second, crl.allow("a", 0, now))
+	assert.Zero(t, crl.allow("a", 0, now.Add(2*time.Second)))
+}
+
+func TestClientRateLimiterBytes(t *testing.T) {
+	crl := newClientRateLimiter(RateLimit{BytesPerSecond: 1000})
+	now := time.Now()
+
+	// A message larger than the rate is accepted, the following ones waiting for the excess to be paid back.
+	assert.Zero(t, crl.allow("a", 3000, now))
+	assert.Equal(t, 2*time.Second, crl.allow("a", 10, now))
+	now = now.Add(time.Second)
+	assert.Equal(t, time.Second, crl.allow("a", 10, now))
+	now = now.Add(1500 * time.Millisecond)
+	assert.Zero(t, crl.allow("a", 10, now))
+	// The requests are not limited.
+	assert.Zero(t, crl.allow("a", 10, now))
+}
+
+func TestClientRateLimiterSweep(t *testing.T) {
+	crl := newClientRateLimiter(RateLimit{RequestsPerSecond: 1, BytesPerSecond: 1000})
+	now := time.Now()
+
+	assert.Zero(t, crl.allow("idle", 10, now))
+	assert.Zero(t, crl.allow("busy", 100000, now))
+	assert.Len(t, crl.clients, 2)
+
+	// The idle client is forgotten, the busy one still having to pay back its excess.
+	now = now.Add(rateLimitSweepInterval)
+	assert.Equal(t, 39*time.Second, crl.allow("busy", 10, now))
+	assert.Len(t, crl.clients, 1)
+	assert.Contains(t, crl.clients, "busy")
+}
+
+func TestClientIP(t *testing.T) {
+	assert.Equal(t, "127.0.0.1", clientIP(&net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 4317}))
+	assert.Equal(t, "::1", clientIP(&net.TCPAddr{IP: net.IPv6loopback, Port: 4317}))
+	assert.Equal(t, "/tmp/sock", clientIP(&net.UnixAddr{Name: "/tmp/sock", Net: "unix"}))
+}
+
+func TestRateLimitsPerClientIP(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	set := componenttest.NewNopTelemetrySettings()
+	set.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	gss := &GRPCServerSettings{
+		NetAddr: confignet.NetAddr{Endpoint: "localhost:0", Transport: "tcp"},
+		RateLimits: &RateLimitSettings{
+			PerClientIP: &RateLimit{RequestsPerSecond: 0.1},
+		},
+	}
+	exportFn := startRateLimitedServer(t, gss, set, nil)
+
+	var trailer metadata.MD
+	require.NoError(t, exportFn(context.Background()))
+	err := exportFn(context.Background(), grpc.Trailer(&trailer))
+	assertThrottled(t, err, trailer, 10*time.Second)
+	assert.Equal(t, int64(1), throttledRequests(t, reader, rateLimitClientIP))
+}
+
+func TestRateLimitsPerAuthIdentity(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	set := componenttest.NewNopTelemetrySettings()
+	set.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	gss := &GRPCServerSettings{
+		NetAddr: confignet.NetAddr{Endpoint: "localhost:0", Transport: "tcp"},
+		Auth:    &configauth.Authentication{AuthenticatorID: component.NewID("mock")},
+		RateLimits: &RateLimitSettings{
+			PerAuthIdentity: &RateLimit{RequestsPerSecond: 1},
+		},
+	}
+	authFunc := func(ctx context.Context, headers map[string][]string) (context.Context, error) {
+		cl := client.FromContext(ctx)
+		if subjects := headers["subject"]; len(subjects) > 0 {
+			cl.Auth = client.NewAuthData(client.StandardClaims{Subject: subjects[0]}, nil)
+		}
+		return client.NewContext(ctx, cl), nil
+	}
+	host := &mockHost{
+		ext: map[component.ID]component.Component{
+			component.NewID("mock"): auth.NewServer(auth.WithServerAuthenticate(authFunc)),
+		},
+	}
+	exportFn := startRateLimitedServer(t, gss, set, host)
+
+	alice := metadata.AppendToOutgoingContext(context.Background(), "subject", "alice")
+	bob := metadata.AppendToOutgoingContext(context.Background(), "subject", "bob")
+	require.NoError(t, exportFn(alice))
+	require.NoError(t, exportFn(bob))
+	var trailer metadata.MD
+	err := exportFn(alice, grpc.Trailer(&trailer))
+	assertThrottled(t, err, trailer, time.Second)
+
+	// The RPCs without subject are not limited.
+	for i := 0; i < 3; i++ {
+		require.NoError(t, exportFn(context.Background()))
+	}
+	assert.Equal(t, int64(1), throttledRequests(t, reader, rateLimitAuthIdentity))
+}
+
+func TestRateLimitsPerAuthIdentityRequiresAuth(t *testing.T) {
+	gss := &GRPCServerSettings{
+		NetAddr: confignet.NetAddr{Endpoint: "localhost:0", Transport: "tcp"},
+		RateLimits: &RateLimitSettings{
+			PerAuthIdentity: &RateLimit{RequestsPerSecond: 1},
+		},
+	}
+	_, err := gss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	assert.EqualError(t, err, "rate_limits::per_auth_identity requires auth to be configured")
+}
+
+func TestRateLimitStreamServerInterceptor(t *testing.T) {
+	rl, err := newRateLimiter(RateLimitSettings{PerClientIP: &RateLimit{BytesPerSecond: 100}}, nil, nil)
+	require.NoError(t, err)
+	state := &rateLimitState{}
+	ctx := context.WithValue(context.Background(), rateLimitStateKey{}, state)
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 4317}})
+	handler := func(_ any, stream grpc.ServerStream) error {
+		for i := 0; i < 2; i++ {
+			if err := stream.RecvMsg(nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	stream := &mockServerStream{ctx: ctx, ServerStream: &recvServerStream{}}
+
+	// Each message of the stream is counted, the first one exceeding the rate.
+	state.lastMsgSize.Store(120)
+	err = rl.streamServerInterceptor(nil, stream, &grpc.StreamServerInfo{}, handler)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+// startRateLimitedServer starts a trace server with the given settings, returning a function exporting
+// a small payload to it.
+func startRateLimitedServer(t *testing.T, gss *GRPCServerSettings, set component.TelemetrySettings, host component.Host) func(ctx context.Context, opts ...grpc.CallOption) error {
+	if host == nil {
+		host = componenttest.NewNopHost()
+	}
+	ln, err := gss.ToListener()
+	require.NoError(t, err)
+	srv, err := gss.ToServer(host, set)
+	require.NoError(t, err)
+	ptraceotlp.RegisterGRPCServer(srv, &grpcTraceServer{})
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	t.Cleanup(srv.Stop)
+
+	gcs := &GRPCClientSettings{
+		Endpoint:   ln.Addr().String(),
+		TLSSetting: configtls.TLSClientSetting{Insecure: true},
+	}
+	grpcClientConn, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, grpcClientConn.Close()) })
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().Resource().Attributes().PutStr("key", "value")
+	return func(ctx context.Context, opts ...grpc.CallOption) error {
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		_, err := ptraceotlp.NewGRPCClient(grpcClientConn).Export(ctx, ptraceotlp.NewExportRequestFromTraces(td), append(opts, grpc.WaitForReady(true))...)
+		return err
+	}
+}
+
+func assertThrottled(t *testing.T, err error, trailer metadata.MD, maxWait time.Duration) {
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	require.Len(t, st.Details(), 1)
+	retryInfo, ok := st.Details()[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	assert.Greater(t, retryInfo.RetryDelay.AsDuration(), time.Duration(0))
+	assert.LessOrEqual(t, retryInfo.RetryDelay.AsDuration(), maxWait)
+	require.Len(t, trailer.Get(retryAfterKey), 1)
+	assert.NotEqual(t, "0", trailer.Get(retryAfterKey)[0])
+}
+
+func throttledRequests(t *testing.T, reader sdkmetric.Reader, limit string) int64 {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	var total int64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if !ok || m.Name != "rpc.server.throttled_requests" {
+				continue
+			}
+			for _, dp := range sum.DataPoints {
+				if v, _ := dp.Attributes.Value("limit"); v.AsString() == limit {
+					total += dp.Value
+				}
+			}
+		}
+	}
+	return total
+}
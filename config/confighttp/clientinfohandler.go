@@ -5,10 +5,12 @@ package confighttp // import "go.opentelemetry.io/collector/config/confighttp"
 
 import (
 	"context"
+	"errors"
 	"net"
 	"net/http"
 
 	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/config/internal"
 )
 
 var _ http.Handler = (*clientInfoHandler)(nil)
@@ -19,30 +21,78 @@ type clientInfoHandler struct {
 
 	// include client metadata or not
 	includeMetadata bool
+
+	// metadataRules, if not empty, selects and renames the headers included in the client metadata
+	metadataRules []internal.MetadataRule
+}
+
+// MetadataRule adds a header of the incoming requests to the client metadata.
+type MetadataRule struct {
+	// Key is the name of the header, matched case-insensitively.
+	Key string `mapstructure:"key"`
+
+	// Rename is the key of the header in the client metadata. If not set, Key is used.
+	Rename string `mapstructure:"rename"`
+
+	// Default is the value added to the client metadata if the request doesn't have the header.
+	// If not set, the key is omitted.
+	Default string `mapstructure:"default"`
+}
+
+// Validate checks that the rule has a key.
+func (mr *MetadataRule) Validate() error {
+	if mr.Key == "" {
+		return errors.New("metadata rule key must not be empty")
+	}
+	return nil
+}
+
+func toInternalMetadataRules(rules []MetadataRule) []internal.MetadataRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	internalRules := make([]internal.MetadataRule, len(rules))
+	for i, rule := range rules {
+		internalRules[i] = internal.MetadataRule{Key: rule.Key, Rename: rule.Rename, Default: rule.Default}
+	}
+	return internalRules
 }
 
 // ServeHTTP intercepts incoming HTTP requests, replacing the request's context with one that contains
 // a client.Info containing the client's IP address.
 func (h *clientInfoHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	req = req.WithContext(contextWithClient(req, h.includeMetadata))
+	req = req.WithContext(contextWithClient(req, h.includeMetadata, h.metadataRules))
 	h.next.ServeHTTP(w, req)
 }
 
-// contextWithClient attempts to add the client IP address to the client.Info from the context. When no
-// client.Info exists in the context, one is created.
-func contextWithClient(req *http.Request, includeMetadata bool) context.Context {
+// contextWithClient attempts to add the client IP address, protocol, TLS version and verified certificates to the client.Info
+// from the context. When no client.Info exists in the context, one is created.
+func contextWithClient(req *http.Request, includeMetadata bool, metadataRules []internal.MetadataRule) context.Context {
 	cl := client.FromContext(req.Context())
 
 	ip := parseIP(req.RemoteAddr)
 	if ip != nil {
 		cl.Addr = ip
 	}
+	switch req.ProtoMajor {
+	case 1:
+		cl.Protocol = client.ProtocolHTTP1
+	case 2:
+		cl.Protocol = client.ProtocolHTTP2
+	}
+	if req.TLS != nil {
+		cl.PeerCertificates = client.PeerCertificatesFromTLS(req.TLS)
+		cl.TLSVersion = client.TLSVersionName(req.TLS.Version)
+	}
 
 	if includeMetadata {
 		md := req.Header.Clone()
 		if len(md.Get(client.MetadataHostName)) == 0 && req.Host != "" {
 			md.Add(client.MetadataHostName, req.Host)
 		}
+		if len(metadataRules) > 0 {
+			md = internal.MapMetadata(md, metadataRules)
+		}
 
 		cl.Metadata = client.NewMetadata(md)
 	}
@@ -9,15 +9,21 @@ import (
 	"bytes"
 	"compress/gzip"
 	"compress/zlib"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
 
+	"github.com/golang/snappy"
 	"github.com/klauspost/compress/zstd"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
 
 	"go.opentelemetry.io/collector/config/configcompression"
 )
 
+const scopeName = "go.opentelemetry.io/collector/config/confighttp"
+
 type compressRoundTripper struct {
 	rt              http.RoundTripper
 	compressionType configcompression.CompressionType
@@ -69,13 +75,15 @@ type decompressor struct {
 	errHandler func(w http.ResponseWriter, r *http.Request, errorMsg string, statusCode int)
 	base       http.Handler
 	decoders   map[string]func(body io.ReadCloser) (io.ReadCloser, error)
+	limiter    *decompressionLimiter
 }
 
 // httpContentDecompressor offloads the task of handling compressed HTTP requests
 // by identifying the compression format in the "Content-Encoding" header and re-writing
 // request body so that the handlers further in the chain can work on decompressed data.
-// It supports gzip and deflate/zlib compression.
-func httpContentDecompressor(h http.Handler, eh func(w http.ResponseWriter, r *http.Request, errorMsg string, statusCode int), decoders map[string]func(body io.ReadCloser) (io.ReadCloser, error)) http.Handler {
+// It supports gzip, zstd, snappy and deflate/zlib compression. If limiter is not nil, the decompressed bodies
+// are bounded by its limits.
+func httpContentDecompressor(h http.Handler, eh func(w http.ResponseWriter, r *http.Request, errorMsg string, statusCode int), decoders map[string]func(body io.ReadCloser) (io.ReadCloser, error), limiter *decompressionLimiter) http.Handler {
 	errHandler := defaultErrorHandler
 	if eh != nil {
 		errHandler = eh
@@ -84,6 +92,7 @@ func httpContentDecompressor(h http.Handler, eh func(w http.ResponseWriter, r *h
 	d := &decompressor{
 		errHandler: errHandler,
 		base:       h,
+		limiter:    limiter,
 		decoders: map[string]func(body io.ReadCloser) (io.ReadCloser, error){
 			"": func(body io.ReadCloser) (io.ReadCloser, error) {
 				// Not a compressed payload. Nothing to do.
@@ -110,6 +119,10 @@ func httpContentDecompressor(h http.Handler, eh func(w http.ResponseWriter, r *h
 				}
 				return zr.IOReadCloser(), nil
 			},
+			"snappy": func(body io.ReadCloser) (io.ReadCloser, error) {
+				// The framing format is used, like by the snappy compression of the client.
+				return io.NopCloser(snappy.NewReader(body)), nil
+			},
 			"zlib": func(body io.ReadCloser) (io.ReadCloser, error) {
 				zr, err := zlib.NewReader(body)
 				if err != nil {
@@ -153,7 +166,72 @@ func (d *decompressor) newBodyReader(r *http.Request) (io.ReadCloser, error) {
 	if !ok {
 		return nil, fmt.Errorf("unsupported %s: %s", headerContentEncoding, encoding)
 	}
-	return decoder(r.Body)
+	if d.limiter == nil {
+		return decoder(r.Body)
+	}
+	compressed := &countingReadCloser{ReadCloser: r.Body}
+	body, err := decoder(compressed)
+	if body == nil || err != nil {
+		return body, err
+	}
+	return &limitedReadCloser{ReadCloser: body, ctx: r.Context(), compressed: compressed, limiter: d.limiter}, nil
+}
+
+// decompressionLimiter enforces the decompression limits on the request bodies.
+type decompressionLimiter struct {
+	limits   configcompression.DecompressionLimits
+	exceeded metric.Int64Counter
+}
+
+func newDecompressionLimiter(limits configcompression.DecompressionLimits, mp metric.MeterProvider) (*decompressionLimiter, error) {
+	if mp == nil {
+		mp = noop.NewMeterProvider()
+	}
+	exceeded, err := mp.Meter(scopeName).Int64Counter(
+		"http.server.decompression_limit_exceeded",
+		metric.WithDescription("Number of requests rejected because their decompressed body exceeded the decompression limits"),
+		metric.WithUnit("1"))
+	if err != nil {
+		return nil, err
+	}
+	return &decompressionLimiter{limits: limits, exceeded: exceeded}, nil
+}
+
+// countingReadCloser counts the bytes read from the compressed body.
+type countingReadCloser struct {
+	io.ReadCloser
+	n int64
+}
+
+func (c *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// limitedReadCloser fails with configcompression.ErrDecompressionLimitExceeded once the decompressed
+// body exceeds the decompression limits.
+type limitedReadCloser struct {
+	io.ReadCloser
+	ctx          context.Context
+	compressed   *countingReadCloser
+	decompressed int64
+	limiter      *decompressionLimiter
+	err          error
+}
+
+func (l *limitedReadCloser) Read(p []byte) (int, error) {
+	if l.err != nil {
+		return 0, l.err
+	}
+	n, err := l.ReadCloser.Read(p)
+	l.decompressed += int64(n)
+	if lerr := l.limiter.limits.Check(l.compressed.n, l.decompressed); lerr != nil {
+		l.err = lerr
+		l.limiter.exceeded.Add(l.ctx, 1)
+		return 0, lerr
+	}
+	return n, err
 }
 
 // defaultErrorHandler writes the error message in plain text.
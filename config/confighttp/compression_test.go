@@ -7,6 +7,8 @@ import (
 	"bytes"
 	"compress/gzip"
 	"compress/zlib"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -18,6 +20,8 @@ import (
 	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configcompression"
@@ -133,7 +137,7 @@ func TestHTTPCustomDecompression(t *testing.T) {
 			return io.NopCloser(strings.NewReader("decompressed body")), nil
 		},
 	}
-	srv := httptest.NewServer(httpContentDecompressor(handler, defaultErrorHandler, decoders))
+	srv := httptest.NewServer(httpContentDecompressor(handler, defaultErrorHandler, decoders, nil))
 
 	t.Cleanup(srv.Close)
 
@@ -190,6 +194,12 @@ func TestHTTPContentDecompressionHandler(t *testing.T) {
 			reqBody:  compressZstd(t, testBody),
 			respCode: http.StatusOK,
 		},
+		{
+			name:     "ValidSnappy",
+			encoding: "snappy",
+			reqBody:  compressSnappy(t, testBody),
+			respCode: http.StatusOK,
+		},
 		{
 			name:     "InvalidDeflate",
 			encoding: "deflate",
@@ -218,6 +228,13 @@ func TestHTTPContentDecompressionHandler(t *testing.T) {
 			respCode: http.StatusBadRequest,
 			respBody: "invalid input: magic number mismatch",
 		},
+		{
+			name:     "InvalidSnappy",
+			encoding: "snappy",
+			reqBody:  bytes.NewBuffer(testBody),
+			respCode: http.StatusBadRequest,
+			respBody: "snappy: corrupt input",
+		},
 		{
 			name:     "UnsupportedCompression",
 			encoding: "nosuchcompression",
@@ -239,7 +256,7 @@ func TestHTTPContentDecompressionHandler(t *testing.T) {
 				require.NoError(t, err, "failed to read request body: %v", err)
 				assert.EqualValues(t, testBody, string(body))
 				w.WriteHeader(http.StatusOK)
-			}), defaultErrorHandler, noDecoders))
+			}), defaultErrorHandler, noDecoders, nil))
 			t.Cleanup(srv.Close)
 
 			req, err := http.NewRequest(http.MethodGet, srv.URL, tt.reqBody)
@@ -260,6 +277,101 @@ func TestHTTPContentDecompressionHandler(t *testing.T) {
 	}
 }
 
+func TestHTTPDecompressionLimits(t *testing.T) {
+	testBody := []byte("uncompressed_text")
+	bomb := make([]byte, 10*1024*1024)
+	tests := []struct {
+		name     string
+		limits   configcompression.DecompressionLimits
+		encoding string
+		reqBody  *bytes.Buffer
+		exceeded bool
+	}{
+		{
+			name:     "no_limits",
+			encoding: "gzip",
+			reqBody:  compressGzip(t, bomb),
+		},
+		{
+			name:     "max_decompressed_size",
+			limits:   configcompression.DecompressionLimits{MaxDecompressedSize: 1024 * 1024},
+			encoding: "gzip",
+			reqBody:  compressGzip(t, bomb),
+			exceeded: true,
+		},
+		{
+			name:     "max_ratio",
+			limits:   configcompression.DecompressionLimits{MaxRatio: 100},
+			encoding: "zstd",
+			reqBody:  compressZstd(t, bomb),
+			exceeded: true,
+		},
+		{
+			name:     "within_limits",
+			limits:   configcompression.DecompressionLimits{MaxDecompressedSize: int64(len(testBody)), MaxRatio: 100},
+			encoding: "zlib",
+			reqBody:  compressZlib(t, testBody),
+		},
+		{
+			name:    "uncompressed",
+			limits:  configcompression.DecompressionLimits{MaxDecompressedSize: 1024 * 1024},
+			reqBody: bytes.NewBuffer(bomb),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := sdkmetric.NewManualReader()
+			set := componenttest.NewNopTelemetrySettings()
+			set.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+			hss := &HTTPServerSettings{Endpoint: "localhost:0", DecompressionLimits: tt.limits}
+			s, err := hss.ToServer(componenttest.NewNopHost(), set, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, err := io.Copy(io.Discard, r.Body)
+				if errors.Is(err, configcompression.ErrDecompressionLimitExceeded) {
+					w.WriteHeader(http.StatusRequestEntityTooLarge)
+					return
+				}
+				assert.NoError(t, err)
+				w.WriteHeader(http.StatusOK)
+			}))
+			require.NoError(t, err)
+			srv := httptest.NewServer(s.Handler)
+			t.Cleanup(srv.Close)
+
+			req, err := http.NewRequest(http.MethodPost, srv.URL, tt.reqBody)
+			require.NoError(t, err)
+			req.Header.Set("Content-Encoding", tt.encoding)
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+
+			var rm metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+			if !tt.exceeded {
+				assert.Equal(t, http.StatusOK, res.StatusCode)
+				assert.Zero(t, decompressionLimitExceeded(rm))
+				return
+			}
+			assert.Equal(t, http.StatusRequestEntityTooLarge, res.StatusCode)
+			assert.Equal(t, int64(1), decompressionLimitExceeded(rm))
+		})
+	}
+}
+
+func decompressionLimitExceeded(rm metricdata.ResourceMetrics) int64 {
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if sum, ok := m.Data.(metricdata.Sum[int64]); ok && m.Name == "http.server.decompression_limit_exceeded" {
+				var total int64
+				for _, dp := range sum.DataPoints {
+					total += dp.Value
+				}
+				return total
+			}
+		}
+	}
+	return 0
+}
+
 func TestHTTPContentCompressionRequestWithNilBody(t *testing.T) {
 	compressedGzipBody := compressGzip(t, []byte{})
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
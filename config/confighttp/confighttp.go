@@ -4,8 +4,10 @@
 package confighttp // import "go.opentelemetry.io/collector/config/confighttp"
 
 import (
+	"context"
 	"crypto/tls"
 	"errors"
+	"fmt"
 	"io"
 	"net"
 	"net/http"
@@ -14,11 +16,15 @@ import (
 	"github.com/rs/cors"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/otel"
+	"go.uber.org/multierr"
 	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/config/internal"
@@ -29,7 +35,9 @@ const headerContentEncoding = "Content-Encoding"
 
 // HTTPClientSettings defines settings for creating an HTTP client.
 type HTTPClientSettings struct {
-	// The target URL to send data to (e.g.: http://some.url:9411/v1/traces).
+	// The target URL to send data to (e.g.: http://some.url:9411/v1/traces). The "unix://" and "npipe://"
+	// schemes send the requests to a Unix domain socket or a Windows named pipe, see confignet.ParseLocalEndpoint,
+	// the path of the requests following the path of the socket (e.g.: unix:///var/run/otelcol.sock/v1/traces).
 	Endpoint string `mapstructure:"endpoint"`
 
 	// TLSSetting struct exposes TLS client configuration.
@@ -49,6 +57,11 @@ type HTTPClientSettings struct {
 	// Header values are opaque since they may be sensitive.
 	Headers map[string]configopaque.String `mapstructure:"headers"`
 
+	// HeadersFromContext lists the keys of the client.Metadata, taken from the context of each
+	// outgoing request, that are set as HTTP request headers. The metadata is only available when
+	// the receiver is configured with `include_metadata`.
+	HeadersFromContext []string `mapstructure:"headers_from_context"`
+
 	// Custom Round Tripper to allow for individual components to intercept HTTP requests
 	CustomRoundTripper func(next http.RoundTripper) (http.RoundTripper, error)
 
@@ -75,6 +88,16 @@ type HTTPClientSettings struct {
 	// There's an already set value, and we want to override it only if an explicit value provided
 	IdleConnTimeout *time.Duration `mapstructure:"idle_conn_timeout"`
 
+	// MaxConnLifetime is the maximum amount of time the connections are used for new requests. Once it elapsed,
+	// the requests are sent on new connections, e.g. to be balanced over the backends behind a load balancer,
+	// and the previous connections are closed once their requests completed. No limit if zero.
+	MaxConnLifetime time.Duration `mapstructure:"max_conn_lifetime"`
+
+	// HTTP2PriorKnowledge, if true, sends the requests to the plaintext (http://) endpoints with HTTP/2 without
+	// upgrade (h2c), multiplexing the concurrent requests over a single connection per host. The server must
+	// support h2c. The requests to the TLS endpoints negotiate HTTP/2 as usual.
+	HTTP2PriorKnowledge bool `mapstructure:"http2_prior_knowledge"`
+
 	// DisableKeepAlives, if true, disables HTTP keep-alives and will only use the connection to the server
 	// for a single HTTP request.
 	//
@@ -105,54 +128,43 @@ func (hcs *HTTPClientSettings) ToClient(host component.Host, settings component.
 	if err != nil {
 		return nil, err
 	}
-	transport := http.DefaultTransport.(*http.Transport).Clone()
-	if tlsCfg != nil {
-		transport.TLSClientConfig = tlsCfg
-	}
-	if hcs.ReadBufferSize > 0 {
-		transport.ReadBufferSize = hcs.ReadBufferSize
-	}
-	if hcs.WriteBufferSize > 0 {
-		transport.WriteBufferSize = hcs.WriteBufferSize
-	}
-
-	if hcs.MaxIdleConns != nil {
-		transport.MaxIdleConns = *hcs.MaxIdleConns
-	}
-
-	if hcs.MaxIdleConnsPerHost != nil {
-		transport.MaxIdleConnsPerHost = *hcs.MaxIdleConnsPerHost
+	newTransport := func() idleClosingRoundTripper {
+		return hcs.newTransport(tlsCfg)
 	}
-
-	if hcs.MaxConnsPerHost != nil {
-		transport.MaxConnsPerHost = *hcs.MaxConnsPerHost
+	clientTransport := (http.RoundTripper)(newTransport())
+	if hcs.MaxConnLifetime > 0 {
+		clientTransport = newLifetimeRoundTripper(newTransport, hcs.MaxConnLifetime)
 	}
-
-	if hcs.IdleConnTimeout != nil {
-		transport.IdleConnTimeout = *hcs.IdleConnTimeout
+	if la, ok := confignet.ParseLocalEndpoint(hcs.Endpoint); ok {
+		clientTransport = newLocalRoundTripper(clientTransport, hcs.Endpoint, la)
 	}
 
-	transport.DisableKeepAlives = hcs.DisableKeepAlives
-
-	clientTransport := (http.RoundTripper)(transport)
-
 	// The Auth RoundTripper should always be the innermost to ensure that
 	// request signing-based auth mechanisms operate after compression
 	// and header middleware modifies the request
+	var refreshers []auth.ClientRefresher
 	if hcs.Auth != nil {
 		ext := host.GetExtensions()
 		if ext == nil {
 			return nil, errors.New("extensions configuration not found")
 		}
 
-		httpCustomAuthRoundTripper, aerr := hcs.Auth.GetClientAuthenticator(ext)
+		authenticators, aerr := hcs.Auth.GetClientAuthenticators(ext)
 		if aerr != nil {
 			return nil, aerr
 		}
 
-		clientTransport, err = httpCustomAuthRoundTripper.RoundTripper(clientTransport)
-		if err != nil {
-			return nil, err
+		// The first authenticator must be the outermost RoundTripper to be applied first to the request.
+		for i := len(authenticators) - 1; i >= 0; i-- {
+			clientTransport, err = authenticators[i].RoundTripper(clientTransport)
+			if err != nil {
+				return nil, err
+			}
+		}
+		for _, authenticator := range authenticators {
+			if refresher, ok := authenticator.(auth.ClientRefresher); ok {
+				refreshers = append(refreshers, refresher)
+			}
 		}
 	}
 
@@ -163,6 +175,13 @@ func (hcs *HTTPClientSettings) ToClient(host component.Host, settings component.
 		}
 	}
 
+	if len(hcs.HeadersFromContext) > 0 {
+		clientTransport = &headersFromContextRoundTripper{
+			transport: clientTransport,
+			keys:      hcs.HeadersFromContext,
+		}
+	}
+
 	// Compress the body using specified compression methods if non-empty string is provided.
 	// Supporting gzip, zlib, deflate, snappy, and zstd; none is treated as uncompressed.
 	if configcompression.IsCompressed(hcs.Compression) {
@@ -172,6 +191,14 @@ func (hcs *HTTPClientSettings) ToClient(host component.Host, settings component.
 		}
 	}
 
+	// The requests are retried with their original body, before compression.
+	if len(refreshers) > 0 {
+		clientTransport = &refreshRoundTripper{
+			transport:  clientTransport,
+			refreshers: refreshers,
+		}
+	}
+
 	// wrapping http transport with otelhttp transport to enable otel instrumentation
 	if settings.TracerProvider != nil && settings.MeterProvider != nil {
 		clientTransport = otelhttp.NewTransport(
@@ -195,6 +222,52 @@ func (hcs *HTTPClientSettings) ToClient(host component.Host, settings component.
 	}, nil
 }
 
+// newTransport creates the transport sending the requests. A new transport is created, instead of cloning a
+// transport, each time the connections are rotated: cloning a transport which negotiated HTTP/2 would keep the
+// HTTP/2 protocol in the TLS configuration of the clone.
+func (hcs *HTTPClientSettings) newTransport(tlsCfg *tls.Config) idleClosingRoundTripper {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsCfg != nil {
+		transport.TLSClientConfig = tlsCfg.Clone()
+	}
+	if hcs.ReadBufferSize > 0 {
+		transport.ReadBufferSize = hcs.ReadBufferSize
+	}
+	if hcs.WriteBufferSize > 0 {
+		transport.WriteBufferSize = hcs.WriteBufferSize
+	}
+
+	if hcs.MaxIdleConns != nil {
+		transport.MaxIdleConns = *hcs.MaxIdleConns
+	}
+
+	if hcs.MaxIdleConnsPerHost != nil {
+		transport.MaxIdleConnsPerHost = *hcs.MaxIdleConnsPerHost
+	}
+
+	if hcs.MaxConnsPerHost != nil {
+		transport.MaxConnsPerHost = *hcs.MaxConnsPerHost
+	}
+
+	if hcs.IdleConnTimeout != nil {
+		transport.IdleConnTimeout = *hcs.IdleConnTimeout
+	}
+
+	transport.DisableKeepAlives = hcs.DisableKeepAlives
+
+	if la, ok := confignet.ParseLocalEndpoint(hcs.Endpoint); ok {
+		// All the requests are sent to the local socket, whatever their host.
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return la.DialContext(ctx)
+		}
+	}
+
+	if hcs.HTTP2PriorKnowledge {
+		return newH2CRoundTripper(transport)
+	}
+	return transport
+}
+
 // Custom RoundTripper that adds headers.
 type headerRoundTripper struct {
 	transport http.RoundTripper
@@ -210,9 +283,31 @@ func (interceptor *headerRoundTripper) RoundTrip(req *http.Request) (*http.Respo
 	return interceptor.transport.RoundTrip(req)
 }
 
+// Custom RoundTripper that sets headers from the client.Metadata of the request context.
+type headersFromContextRoundTripper struct {
+	transport http.RoundTripper
+	keys      []string
+}
+
+// RoundTrip is a custom RoundTripper that sets headers from the client.Metadata of the request context.
+func (interceptor *headersFromContextRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	cl := client.FromContext(req.Context())
+	for _, key := range interceptor.keys {
+		if vals := cl.Metadata.Get(key); len(vals) > 0 {
+			req.Header.Del(key)
+			for _, v := range vals {
+				req.Header.Add(key, v)
+			}
+		}
+	}
+	// Send the request to next transport.
+	return interceptor.transport.RoundTrip(req)
+}
+
 // HTTPServerSettings defines settings for creating an HTTP server.
 type HTTPServerSettings struct {
-	// Endpoint configures the listening address for the server.
+	// Endpoint configures the listening address for the server. The "unix://" scheme listens on a Unix domain
+	// socket (e.g.: unix:///var/run/otelcol.sock).
 	Endpoint string `mapstructure:"endpoint"`
 
 	// TLSSetting struct exposes TLS client configuration.
@@ -224,25 +319,57 @@ type HTTPServerSettings struct {
 	// Auth for this receiver
 	Auth *configauth.Authentication `mapstructure:"auth"`
 
-	// MaxRequestBodySize sets the maximum request body size in bytes
+	// MaxRequestBodySize sets the maximum request body size in bytes, as received. The requests declaring
+	// a larger Content-Length are rejected with a 413 status before their body is read, reading a larger
+	// body fails with an *http.MaxBytesError.
 	MaxRequestBodySize int64 `mapstructure:"max_request_body_size"`
 
+	// DecompressionLimits bounds the size of the decompressed request bodies. Unlike MaxRequestBodySize,
+	// which applies to the body as received, these limits apply to the body once decompressed.
+	DecompressionLimits configcompression.DecompressionLimits `mapstructure:"decompression_limits"`
+
 	// IncludeMetadata propagates the client metadata from the incoming requests to the downstream consumers
 	// Experimental: *NOTE* this option is subject to change or removal in the future.
 	IncludeMetadata bool `mapstructure:"include_metadata"`
 
+	// MetadataRules, if not empty, lists the only headers added to the client metadata when IncludeMetadata
+	// is enabled, allowing to rename them or to give them a default value.
+	// Experimental: *NOTE* this option is subject to change or removal in the future.
+	MetadataRules []MetadataRule `mapstructure:"metadata_rules"`
+
 	// Additional headers attached to each HTTP response sent to the client.
 	// Header values are opaque since they may be sensitive.
 	ResponseHeaders map[string]configopaque.String `mapstructure:"response_headers"`
+
+	// ProxyProtocol configures support for the PROXY protocol, to obtain the address of
+	// the original client when the receiver is behind an L4 load balancer.
+	ProxyProtocol *confignet.ProxyProtocolSettings `mapstructure:"proxy_protocol"`
+
+	// H2C, if true, accepts HTTP/2 without TLS (h2c) on the plaintext connections, both with prior knowledge
+	// and with an upgrade from HTTP/1.1. HTTP/2 is always accepted on the TLS connections.
+	H2C bool `mapstructure:"h2c"`
 }
 
 // ToListener creates a net.Listener.
 func (hss *HTTPServerSettings) ToListener() (net.Listener, error) {
-	listener, err := net.Listen("tcp", hss.Endpoint)
+	var listener net.Listener
+	var err error
+	if la, ok := confignet.ParseLocalEndpoint(hss.Endpoint); ok {
+		listener, err = la.Listen()
+	} else {
+		listener, err = net.Listen("tcp", hss.Endpoint)
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	// The PROXY protocol header is sent before the TLS handshake.
+	wrapped, err := hss.ProxyProtocol.WrapListener(listener)
+	if err != nil {
+		return nil, multierr.Append(err, listener.Close())
+	}
+	listener = wrapped
+
 	if hss.TLSSetting != nil {
 		var tlsCfg *tls.Config
 		tlsCfg, err = hss.TLSSetting.LoadTLSConfig()
@@ -294,10 +421,17 @@ func (hss *HTTPServerSettings) ToServer(host component.Host, settings component.
 		o(serverOpts)
 	}
 
-	handler = httpContentDecompressor(handler, serverOpts.errHandler, serverOpts.decoders)
+	var limiter *decompressionLimiter
+	if hss.DecompressionLimits.Enabled() {
+		var err error
+		if limiter, err = newDecompressionLimiter(hss.DecompressionLimits, settings.MeterProvider); err != nil {
+			return nil, err
+		}
+	}
+	handler = httpContentDecompressor(handler, serverOpts.errHandler, serverOpts.decoders, limiter)
 
 	if hss.MaxRequestBodySize > 0 {
-		handler = maxRequestBodySizeInterceptor(handler, hss.MaxRequestBodySize)
+		handler = maxRequestBodySizeInterceptor(handler, serverOpts.errHandler, hss.MaxRequestBodySize)
 	}
 
 	if hss.Auth != nil {
@@ -341,11 +475,23 @@ func (hss *HTTPServerSettings) ToServer(host component.Host, settings component.
 	handler = &clientInfoHandler{
 		next:            handler,
 		includeMetadata: hss.IncludeMetadata,
+		metadataRules:   toInternalMetadataRules(hss.MetadataRules),
 	}
 
-	return &http.Server{
-		Handler: handler,
-	}, nil
+	if !hss.H2C {
+		return &http.Server{
+			Handler: handler,
+		}, nil
+	}
+	// Configuring the server with the HTTP/2 server allows to gracefully shut down the h2c connections.
+	h2s := &http2.Server{}
+	srv := &http.Server{
+		Handler: h2c.NewHandler(handler, h2s),
+	}
+	if err := http2.ConfigureServer(srv, h2s); err != nil {
+		return nil, err
+	}
+	return srv, nil
 }
 
 func responseHeadersHandler(handler http.Handler, headers map[string]configopaque.String) http.Handler {
@@ -394,8 +540,16 @@ func authInterceptor(next http.Handler, server auth.Server) http.Handler {
 	})
 }
 
-func maxRequestBodySizeInterceptor(next http.Handler, maxRecvSize int64) http.Handler {
+func maxRequestBodySizeInterceptor(next http.Handler, eh func(w http.ResponseWriter, r *http.Request, errorMsg string, statusCode int), maxRecvSize int64) http.Handler {
+	errHandler := defaultErrorHandler
+	if eh != nil {
+		errHandler = eh
+	}
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > maxRecvSize {
+			errHandler(w, r, fmt.Sprintf("request body of %d bytes exceeds the maximum of %d bytes", r.ContentLength, maxRecvSize), http.StatusRequestEntityTooLarge)
+			return
+		}
 		r.Body = http.MaxBytesReader(w, r.Body, maxRecvSize)
 		next.ServeHTTP(w, r)
 	})
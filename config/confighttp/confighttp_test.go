@@ -4,7 +4,11 @@
 package confighttp
 
 import (
+	"bufio"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"io"
@@ -12,7 +16,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -26,9 +32,11 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/config/internal"
 	"go.opentelemetry.io/collector/extension/auth"
 	"go.opentelemetry.io/collector/extension/auth/authtest"
 )
@@ -989,12 +997,40 @@ func TestHttpClientHeaders(t *testing.T) {
 	}
 }
 
+func TestHttpClientHeadersFromContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, []string{"tenant-1"}, r.Header.Values("X-Tenant-Id"))
+		assert.Empty(t, r.Header.Values("X-Other"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	setting := HTTPClientSettings{
+		Endpoint:           server.URL,
+		HeadersFromContext: []string{"X-Tenant-Id", "X-Missing"},
+	}
+	cl, err := setting.ToClient(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	ctx := client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{
+			"x-tenant-id": {"tenant-1"},
+			"x-other":     {"other"},
+		}),
+	})
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, setting.Endpoint, nil)
+	require.NoError(t, err)
+	resp, err := cl.Do(req)
+	require.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+}
+
 func TestContextWithClient(t *testing.T) {
 	testCases := []struct {
-		desc       string
-		input      *http.Request
-		doMetadata bool
-		expected   client.Info
+		desc          string
+		input         *http.Request
+		doMetadata    bool
+		metadataRules []internal.MetadataRule
+		expected      client.Info
 	}{
 		{
 			desc:     "request without client IP or headers",
@@ -1012,6 +1048,15 @@ func TestContextWithClient(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "request with protocol",
+			input: &http.Request{
+				ProtoMajor: 2,
+			},
+			expected: client.Info{
+				Protocol: client.ProtocolHTTP2,
+			},
+		},
 		{
 			desc: "request with client headers, no metadata processing",
 			input: &http.Request{
@@ -1041,10 +1086,34 @@ func TestContextWithClient(t *testing.T) {
 				Metadata: client.NewMetadata(map[string][]string{"x-test-header": {"test-value"}, "Host": {"localhost:55443"}}),
 			},
 		},
+		{
+			desc: "request with client headers and metadata rules",
+			input: &http.Request{
+				Header: map[string][]string{"X-Tenant-Id": {"acme"}, "X-Secret": {"s3cr3t"}},
+				Host:   "localhost:55443",
+			},
+			doMetadata: true,
+			metadataRules: []internal.MetadataRule{
+				{Key: "x-tenant-id", Rename: "tenant"},
+				{Key: "x-region", Default: "eu"},
+				{Key: "host"},
+			},
+			expected: client.Info{
+				Metadata: client.NewMetadata(map[string][]string{"tenant": {"acme"}, "x-region": {"eu"}, "host": {"localhost:55443"}}),
+			},
+		},
+		{
+			desc: "request with client headers and metadata rules, no metadata processing",
+			input: &http.Request{
+				Header: map[string][]string{"X-Tenant-Id": {"acme"}},
+			},
+			metadataRules: []internal.MetadataRule{{Key: "x-tenant-id"}},
+			expected:      client.Info{},
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
-			ctx := contextWithClient(tC.input, tC.doMetadata)
+			ctx := contextWithClient(tC.input, tC.doMetadata, tC.metadataRules)
 			assert.Equal(t, tC.expected, client.FromContext(ctx))
 		})
 	}
@@ -1189,6 +1258,38 @@ func TestServerWithDecoder(t *testing.T) {
 
 }
 
+func TestServerMaxRequestBodySize(t *testing.T) {
+	hss := HTTPServerSettings{
+		Endpoint:           "localhost:0",
+		MaxRequestBodySize: 4,
+	}
+	handlerCalled := false
+	srv, err := hss.ToServer(
+		componenttest.NewNopHost(),
+		componenttest.NewNopTelemetrySettings(),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handlerCalled = true
+			_, err := io.ReadAll(r.Body)
+			var maxBytesErr *http.MaxBytesError
+			assert.ErrorAs(t, err, &maxBytesErr)
+		}),
+	)
+	require.NoError(t, err)
+
+	// The declared Content-Length exceeds the limit: the request is rejected before calling the handler.
+	response := httptest.NewRecorder()
+	srv.Handler.ServeHTTP(response, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("too large")))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, response.Code)
+	assert.Equal(t, "request body of 9 bytes exceeds the maximum of 4 bytes\n", response.Body.String())
+	assert.False(t, handlerCalled)
+
+	// The body of unknown length is limited while it is read.
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("too large"))
+	req.ContentLength = -1
+	srv.Handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.True(t, handlerCalled)
+}
+
 type mockHost struct {
 	component.Host
 	ext map[component.ID]component.Component
@@ -1303,3 +1404,146 @@ func BenchmarkHttpRequest(b *testing.B) {
 		})
 	}
 }
+
+func TestContextWithClientPeerCertificates(t *testing.T) {
+	pemData, err := os.ReadFile(filepath.Join("testdata", "client.crt"))
+	require.NoError(t, err)
+	block, _ := pem.Decode(pemData)
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+
+	req := &http.Request{
+		RemoteAddr: "1.2.3.4:33455",
+		ProtoMajor: 1,
+		TLS: &tls.ConnectionState{
+			Version:          tls.VersionTLS12,
+			PeerCertificates: []*x509.Certificate{cert},
+			VerifiedChains:   [][]*x509.Certificate{{cert}},
+		},
+	}
+	cl := client.FromContext(contextWithClient(req, false, nil))
+	require.Len(t, cl.PeerCertificates, 1)
+	assert.Equal(t, client.NewPeerCertificate(cert), cl.PeerCertificates[0])
+	assert.Equal(t, client.ProtocolHTTP1, cl.Protocol)
+	assert.Equal(t, "1.2", cl.TLSVersion)
+}
+
+func TestHttpServerProxyProtocol(t *testing.T) {
+	hss := &HTTPServerSettings{
+		Endpoint: "localhost:0",
+		ProxyProtocol: &confignet.ProxyProtocolSettings{
+			Enabled:        true,
+			TrustedSources: []string{"127.0.0.0/8", "::1/128"},
+		},
+	}
+	ln, err := hss.ToListener()
+	require.NoError(t, err)
+
+	addrCh := make(chan string, 1)
+	s, err := hss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings(),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			addrCh <- client.FromContext(r.Context()).Addr.String()
+			w.WriteHeader(http.StatusOK)
+		}))
+	require.NoError(t, err)
+	go func() {
+		_ = s.Serve(ln)
+	}()
+	defer func() { assert.NoError(t, s.Close()) }()
+
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("PROXY TCP4 192.168.0.1 192.168.0.11 56324 443\r\nGET / HTTP/1.1\r\nHost: localhost\r\n\r\n"))
+	require.NoError(t, err)
+
+	resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+	require.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "192.168.0.1", <-addrCh)
+}
+
+func TestChainedClientAuthenticators(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, []string{"first", "second"}, r.Header.Values("X-Auth-Order"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	newAuthenticator := func(name string) auth.Client {
+		return auth.NewClient(auth.WithClientRoundTripper(func(base http.RoundTripper) (http.RoundTripper, error) {
+			return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				req.Header.Add("X-Auth-Order", name)
+				return base.RoundTrip(req)
+			}), nil
+		}))
+	}
+	host := &mockHost{
+		ext: map[component.ID]component.Component{
+			component.NewID("first"):  newAuthenticator("first"),
+			component.NewID("second"): newAuthenticator("second"),
+		},
+	}
+	hcs := HTTPClientSettings{
+		Endpoint: server.URL,
+		Auth: &configauth.Authentication{
+			AuthenticatorIDs: []component.ID{component.NewID("first"), component.NewID("second")},
+		},
+	}
+	cl, err := hcs.ToClient(host, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	resp, err := cl.Get(server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NoError(t, resp.Body.Close())
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestMetadataRuleValidate(t *testing.T) {
+	assert.NoError(t, (&MetadataRule{Key: "x-tenant-id", Rename: "tenant"}).Validate())
+	assert.EqualError(t, (&MetadataRule{Rename: "tenant"}).Validate(), "metadata rule key must not be empty")
+}
+
+func TestHttpServerMetadataRules(t *testing.T) {
+	hss := &HTTPServerSettings{
+		Endpoint:        "localhost:0",
+		IncludeMetadata: true,
+		MetadataRules:   []MetadataRule{{Key: "X-Tenant-Id", Rename: "tenant"}, {Key: "x-env", Default: "prod"}},
+	}
+	ln, err := hss.ToListener()
+	require.NoError(t, err)
+
+	mdCh := make(chan client.Metadata, 1)
+	s, err := hss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings(),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mdCh <- client.FromContext(r.Context()).Metadata
+			w.WriteHeader(http.StatusOK)
+		}))
+	require.NoError(t, err)
+	go func() {
+		_ = s.Serve(ln)
+	}()
+	t.Cleanup(func() { assert.NoError(t, s.Close()) })
+
+	req, err := http.NewRequest(http.MethodGet, "http://"+ln.Addr().String(), nil)
+	require.NoError(t, err)
+	req.Header.Set("x-tenant-id", "acme")
+	req.Header.Set("x-secret", "s3cr3t")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	md := <-mdCh
+	assert.Equal(t, []string{"acme"}, md.Get("tenant"))
+	assert.Equal(t, []string{"prod"}, md.Get("x-env"))
+	assert.Nil(t, md.Get("x-secret"))
+	assert.Nil(t, md.Get("x-tenant-id"))
+}
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confighttp // import "go.opentelemetry.io/collector/config/confighttp"
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+
+	"golang.org/x/net/http2"
+)
+
+// h2cRoundTripper sends the plaintext requests with HTTP/2 with prior knowledge (h2c), and the other requests
+// with the next transport.
+type h2cRoundTripper struct {
+	h2c  *http2.Transport
+	next *http.Transport
+}
+
+func newH2CRoundTripper(next *http.Transport) *h2cRoundTripper {
+	return &h2cRoundTripper{
+		h2c: &http2.Transport{
+			// The plaintext requests are sent on connections dialed without TLS.
+			AllowHTTP: true,
+			DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+				return next.DialContext(ctx, network, addr)
+			},
+			DisableCompression: next.DisableCompression,
+		},
+		next: next,
+	}
+}
+
+func (rt *h2cRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Scheme == "http" {
+		return rt.h2c.RoundTrip(req)
+	}
+	return rt.next.RoundTrip(req)
+}
+
+func (rt *h2cRoundTripper) CloseIdleConnections() {
+	rt.h2c.CloseIdleConnections()
+	rt.next.CloseIdleConnections()
+}
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confighttp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/internal/testutil"
+)
+
+// startProtoServer starts a server replying with the protocol of the requests, and counts its connections.
+func startProtoServer(t *testing.T, h2c bool) (string, *connCounter) {
+	hss := &HTTPServerSettings{Endpoint: testutil.GetAvailableLocalAddress(t), H2C: h2c}
+	ln, err := hss.ToListener()
+	require.NoError(t, err)
+	srv, err := hss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings(),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, r.Proto)
+		}))
+	require.NoError(t, err)
+	counter := &connCounter{}
+	srv.ConnState = counter.connState
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	t.Cleanup(func() { assert.NoError(t, srv.Shutdown(context.Background())) })
+	return "http://" + hss.Endpoint, counter
+}
+
+func getProto(client *http.Client, url string) (string, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.Proto != string(body) {
+		return "", errors.New("the client and the server protocols differ")
+	}
+	return resp.Proto, nil
+}
+
+func TestHTTP2PriorKnowledge(t *testing.T) {
+	url, counter := startProtoServer(t, true)
+	hcs := &HTTPClientSettings{Endpoint: url, HTTP2PriorKnowledge: true}
+	client, err := hcs.ToClient(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			proto, err := getProto(client, url)
+			assert.NoError(t, err)
+			assert.Equal(t, "HTTP/2.0", proto)
+		}()
+	}
+	wg.Wait()
+	// The concurrent requests are multiplexed over a single connection.
+	opened, _ := counter.counts()
+	assert.Equal(t, 1, opened)
+}
+
+func TestHTTP2PriorKnowledgeMaxConnLifetime(t *testing.T) {
+	url, counter := startProtoServer(t, true)
+	hcs := &HTTPClientSettings{Endpoint: url, HTTP2PriorKnowledge: true, MaxConnLifetime: 50 * time.Millisecond}
+	client, err := hcs.ToClient(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	proto, err := getProto(client, url)
+	require.NoError(t, err)
+	assert.Equal(t, "HTTP/2.0", proto)
+	time.Sleep(100 * time.Millisecond)
+	proto, err = getProto(client, url)
+	require.NoError(t, err)
+	assert.Equal(t, "HTTP/2.0", proto)
+	// The h2c connections are hijacked from the server, their closing is not reported.
+	opened, _ := counter.counts()
+	assert.Equal(t, 2, opened)
+}
+
+func TestH2CServerHTTP1(t *testing.T) {
+	url, _ := startProtoServer(t, true)
+	client, err := (&HTTPClientSettings{Endpoint: url}).ToClient(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	proto, err := getProto(client, url)
+	require.NoError(t, err)
+	assert.Equal(t, "HTTP/1.1", proto)
+}
+
+func TestHTTP2PriorKnowledgeWithoutH2CServer(t *testing.T) {
+	url, _ := startProtoServer(t, false)
+	hcs := &HTTPClientSettings{Endpoint: url, HTTP2PriorKnowledge: true}
+	client, err := hcs.ToClient(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	_, err = getProto(client, url)
+	assert.Error(t, err)
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confighttp // import "go.opentelemetry.io/collector/config/confighttp"
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// lifetimeRoundTripper sends the requests with a transport replaced by a new one, opening new connections, when
+// the maximum lifetime of its connections elapsed. The idle connections of a replaced transport are closed once
+// the requests it was sending completed.
+type lifetimeRoundTripper struct {
+	newTransport func() idleClosingRoundTripper
+	maxLifetime  time.Duration
+
+	mu      sync.Mutex
+	current *transportGeneration
+}
+
+// idleClosingRoundTripper is a transport whose idle connections can be closed, e.g. http.Transport.
+type idleClosingRoundTripper interface {
+	http.RoundTripper
+	CloseIdleConnections()
+}
+
+// transportGeneration is a transport and the requests it is sending, guarded by lifetimeRoundTripper.mu.
+type transportGeneration struct {
+	transport idleClosingRoundTripper
+	expiry    time.Time
+	inFlight  int
+	retired   bool
+}
+
+func newLifetimeRoundTripper(newTransport func() idleClosingRoundTripper, maxLifetime time.Duration) *lifetimeRoundTripper {
+	rt := &lifetimeRoundTripper{newTransport: newTransport, maxLifetime: maxLifetime}
+	rt.current = rt.newGeneration()
+	return rt
+}
+
+func (rt *lifetimeRoundTripper) newGeneration() *transportGeneration {
+	return &transportGeneration{transport: rt.newTransport(), expiry: time.Now().Add(rt.maxLifetime)}
+}
+
+func (rt *lifetimeRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	gen := rt.acquire()
+	resp, err := gen.transport.RoundTrip(req)
+	if err != nil {
+		rt.release(gen)
+		return nil, err
+	}
+	// The connection is only returned to the idle connections once the response body is consumed.
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: func() { rt.release(gen) }}
+	return resp, nil
+}
+
+// acquire returns the transport to send a request with, replacing the current one if it expired.
+func (rt *lifetimeRoundTripper) acquire() *transportGeneration {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	if time.Now().After(rt.current.expiry) {
+		rt.current.retired = true
+		if rt.current.inFlight == 0 {
+			rt.current.transport.CloseIdleConnections()
+		}
+		rt.current = rt.newGeneration()
+	}
+	rt.current.inFlight++
+	return rt.current
+}
+
+func (rt *lifetimeRoundTripper) release(gen *transportGeneration) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	gen.inFlight--
+	if gen.retired && gen.inFlight == 0 {
+		gen.transport.CloseIdleConnections()
+	}
+}
+
+// CloseIdleConnections closes the idle connections of the current transport, see http.Client.CloseIdleConnections.
+func (rt *lifetimeRoundTripper) CloseIdleConnections() {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	rt.current.transport.CloseIdleConnections()
+}
+
+// releasingBody releases the request once the response body is closed.
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confighttp
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+// connCounter counts the connections opened and closed by a server.
+type connCounter struct {
+	mu     sync.Mutex
+	opened int
+	closed int
+}
+
+func (c *connCounter) connState(_ net.Conn, state http.ConnState) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch state {
+	case http.StateNew:
+		c.opened++
+	case http.StateClosed:
+		c.closed++
+	}
+}
+
+func (c *connCounter) counts() (int, int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.opened, c.closed
+}
+
+func newCountingServer(t *testing.T) (*httptest.Server, *connCounter) {
+	counter := &connCounter{}
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	srv.Config.ConnState = counter.connState
+	srv.Start()
+	t.Cleanup(srv.Close)
+	return srv, counter
+}
+
+func sendRequest(t *testing.T, client *http.Client, url string) {
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	_, err = io.Copy(io.Discard, resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+}
+
+func TestMaxConnLifetime(t *testing.T) {
+	srv, counter := newCountingServer(t)
+	hcs := &HTTPClientSettings{Endpoint: srv.URL, MaxConnLifetime: 50 * time.Millisecond}
+	client, err := hcs.ToClient(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	sendRequest(t, client, srv.URL)
+	sendRequest(t, client, srv.URL)
+	opened, _ := counter.counts()
+	assert.Equal(t, 1, opened)
+
+	time.Sleep(100 * time.Millisecond)
+	sendRequest(t, client, srv.URL)
+	assert.Eventually(t, func() bool {
+		opened, closed := counter.counts()
+		return opened == 2 && closed == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestMaxConnLifetimeInFlight(t *testing.T) {
+	srv, counter := newCountingServer(t)
+	rt := newLifetimeRoundTripper(func() idleClosingRoundTripper {
+		return http.DefaultTransport.(*http.Transport).Clone()
+	}, 50*time.Millisecond)
+	client := &http.Client{Transport: rt}
+
+	// The connection is not closed while the response is being read.
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	time.Sleep(100 * time.Millisecond)
+	sendRequest(t, client, srv.URL)
+	opened, closed := counter.counts()
+	assert.Equal(t, 2, opened)
+	assert.Equal(t, 0, closed)
+
+	require.NoError(t, resp.Body.Close())
+	assert.Eventually(t, func() bool {
+		_, closed := counter.counts()
+		return closed == 1
+	}, time.Second, 10*time.Millisecond)
+
+	client.CloseIdleConnections()
+	assert.Eventually(t, func() bool {
+		_, closed := counter.counts()
+		return closed == 2
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestNoMaxConnLifetime(t *testing.T) {
+	srv, counter := newCountingServer(t)
+	hcs := &HTTPClientSettings{Endpoint: srv.URL}
+	client, err := hcs.ToClient(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	sendRequest(t, client, srv.URL)
+	time.Sleep(20 * time.Millisecond)
+	sendRequest(t, client, srv.URL)
+	opened, closed := counter.counts()
+	assert.Equal(t, 1, opened)
+	assert.Equal(t, 0, closed)
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confighttp // import "go.opentelemetry.io/collector/config/confighttp"
+
+import (
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/collector/config/confignet"
+)
+
+// localHost is the host of the requests sent to a local socket.
+const localHost = "localhost"
+
+// localRoundTripper rewrites the requests to a "unix://" or "npipe://" endpoint, e.g.
+// unix:///var/run/otelcol.sock/v1/traces, into plain HTTP requests, e.g. http://localhost/v1/traces,
+// sent by the transport on the connections dialed to the local socket.
+type localRoundTripper struct {
+	transport http.RoundTripper
+	scheme    string
+	// socketPath is the path of the endpoint, preceding the path of the requests.
+	socketPath string
+}
+
+func newLocalRoundTripper(transport http.RoundTripper, endpoint string, la confignet.LocalAddr) *localRoundTripper {
+	return &localRoundTripper{
+		transport:  transport,
+		scheme:     la.Scheme,
+		socketPath: strings.TrimSuffix(strings.TrimPrefix(endpoint, la.Scheme+"://"), "/"),
+	}
+}
+
+func (rt *localRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Scheme != rt.scheme {
+		return rt.transport.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	req.URL.Scheme = "http"
+	req.URL.Host = localHost
+	req.URL.Path = strings.TrimPrefix(req.URL.Path, rt.socketPath)
+	req.URL.RawPath = ""
+	if req.URL.Path == "" {
+		req.URL.Path = "/"
+	}
+	req.Host = localHost
+	return rt.transport.RoundTrip(req)
+}
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confighttp
+
+import (
+	"net/http"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func TestUnixSocketEndpoint(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix domain sockets are not supported on Windows by all the versions")
+	}
+	endpoint := "unix://" + filepath.Join(t.TempDir(), "otelcol.sock")
+	hss := HTTPServerSettings{Endpoint: endpoint, H2C: true}
+	ln, err := hss.ToListener()
+	require.NoError(t, err)
+	srv, err := hss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		assert.Equal(t, "localhost", r.Host)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	require.NoError(t, err)
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	defer func() {
+		assert.NoError(t, srv.Close())
+	}()
+
+	for _, h2c := range []bool{false, true} {
+		hcs := HTTPClientSettings{Endpoint: endpoint, HTTP2PriorKnowledge: h2c}
+		client, err := hcs.ToClient(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+		require.NoError(t, err)
+		resp, err := client.Post(endpoint+"/v1/traces", "text/plain", strings.NewReader("payload"))
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	}
+}
+
+func TestNamedPipeEndpointListen(t *testing.T) {
+	hss := HTTPServerSettings{Endpoint: "npipe:////./pipe/otelcol"}
+	_, err := hss.ToListener()
+	assert.Error(t, err)
+}
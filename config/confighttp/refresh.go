@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confighttp // import "go.opentelemetry.io/collector/config/confighttp"
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"go.opentelemetry.io/collector/extension/auth"
+)
+
+// refreshRoundTripper refreshes the credentials of the client authenticators, and retries the request once,
+// when the server rejects a request with the 401 Unauthorized status. The requests whose body cannot be
+// obtained again, see http.Request.GetBody, are not retried.
+type refreshRoundTripper struct {
+	transport  http.RoundTripper
+	refreshers []auth.ClientRefresher
+}
+
+func (rt *refreshRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := rt.transport.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return resp, nil
+	}
+	if !rt.refresh(req.Context()) {
+		return resp, nil
+	}
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, berr := req.GetBody()
+		if berr != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	return rt.transport.RoundTrip(retry)
+}
+
+// refresh refreshes the credentials of the authenticators, returning whether any was refreshed.
+func (rt *refreshRoundTripper) refresh(ctx context.Context) bool {
+	refreshed := false
+	for _, refresher := range rt.refreshers {
+		if refresher.RefreshCredentials(ctx) == nil {
+			refreshed = true
+		}
+	}
+	return refreshed
+}
@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confighttp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/extension/auth"
+)
+
+// tokenAuthenticator sets the current token in the Authorization header, and obtains a new one when refreshed.
+type tokenAuthenticator struct {
+	token      int
+	refreshErr error
+}
+
+func (ta *tokenAuthenticator) client() auth.Client {
+	return auth.NewClient(
+		auth.WithClientRoundTripper(func(base http.RoundTripper) (http.RoundTripper, error) {
+			return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				req = req.Clone(req.Context())
+				req.Header.Set("Authorization", strconv.Itoa(ta.token))
+				return base.RoundTrip(req)
+			}), nil
+		}),
+		auth.WithClientRefreshCredentials(func(context.Context) error {
+			if ta.refreshErr != nil {
+				return ta.refreshErr
+			}
+			ta.token++
+			return nil
+		}),
+	)
+}
+
+func TestRefreshRoundTripper(t *testing.T) {
+	tests := []struct {
+		name           string
+		refreshErr     error
+		compression    configcompression.CompressionType
+		expectedStatus int
+		expectedCalls  int
+	}{
+		{
+			name:           "refreshed",
+			expectedStatus: http.StatusOK,
+			expectedCalls:  2,
+		},
+		{
+			name:           "refreshed_compressed",
+			compression:    configcompression.Gzip,
+			expectedStatus: http.StatusOK,
+			expectedCalls:  2,
+		},
+		{
+			name:           "refresh_failed",
+			refreshErr:     errors.New("refresh failed"),
+			expectedStatus: http.StatusUnauthorized,
+			expectedCalls:  1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if r.Header.Get("Authorization") != "1" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				if tt.compression == "" {
+					body, err := io.ReadAll(r.Body)
+					assert.NoError(t, err)
+					assert.Equal(t, "payload", string(body))
+				}
+			}))
+			defer srv.Close()
+
+			authenticator := &tokenAuthenticator{refreshErr: tt.refreshErr}
+			hcs := HTTPClientSettings{
+				Endpoint:    srv.URL,
+				Compression: tt.compression,
+				Auth:        &configauth.Authentication{AuthenticatorID: component.NewID("token")},
+			}
+			host := &mockHost{ext: map[component.ID]component.Component{component.NewID("token"): authenticator.client()}}
+			client, err := hcs.ToClient(host, componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+
+			resp, err := client.Post(srv.URL, "text/plain", strings.NewReader("payload"))
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			assert.Equal(t, tt.expectedCalls, calls)
+		})
+	}
+}
+
+func TestRefreshRoundTripperBodyNotReplayable(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	authenticator := &tokenAuthenticator{}
+	rt := &refreshRoundTripper{transport: http.DefaultTransport, refreshers: []auth.ClientRefresher{authenticator.client().(auth.ClientRefresher)}}
+	req, err := http.NewRequest(http.MethodPost, srv.URL, io.NopCloser(strings.NewReader("payload")))
+	require.NoError(t, err)
+	resp, err := rt.RoundTrip(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 0, authenticator.token)
+}
@@ -2,7 +2,10 @@ module go.opentelemetry.io/collector/config/confignet
 
 go 1.20
 
-require github.com/stretchr/testify v1.8.4
+require (
+	github.com/stretchr/testify v1.8.4
+	golang.org/x/sys v0.12.0
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confignet // import "go.opentelemetry.io/collector/config/confignet"
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+)
+
+// Schemes of the endpoints of the local sockets, see ParseLocalEndpoint.
+const (
+	// SchemeUnix is the scheme of the Unix domain socket endpoints, e.g. "unix:///var/run/otelcol.sock".
+	SchemeUnix = "unix"
+	// SchemeNamedPipe is the scheme of the Windows named pipe endpoints, e.g. "npipe:////./pipe/otelcol".
+	SchemeNamedPipe = "npipe"
+)
+
+var (
+	errNamedPipeListen       = errors.New("npipe endpoints can only be used by clients")
+	errNamedPipeNotSupported = errors.New("npipe endpoints are only supported on Windows")
+)
+
+// LocalAddr is the address of a local socket, a Unix domain socket or a Windows named pipe, configured as
+// an endpoint with the "unix://" or "npipe://" scheme, avoiding the TCP loopback between the collectors
+// running on the same host.
+type LocalAddr struct {
+	// Scheme is SchemeUnix or SchemeNamedPipe.
+	Scheme string
+	// Path is the path of the Unix domain socket, e.g. "/var/run/otelcol.sock", or of the named pipe,
+	// e.g. `\\.\pipe\otelcol`.
+	Path string
+}
+
+// ParseLocalEndpoint returns the LocalAddr of an endpoint with the "unix://" or "npipe://" scheme, and
+// false for the other endpoints. As in Docker, the slashes of the named pipe endpoints are converted to
+// backslashes, "npipe:////./pipe/otelcol" being the named pipe `\\.\pipe\otelcol`.
+func ParseLocalEndpoint(endpoint string) (LocalAddr, bool) {
+	if path, ok := strings.CutPrefix(endpoint, SchemeUnix+"://"); ok && path != "" {
+		return LocalAddr{Scheme: SchemeUnix, Path: path}, true
+	}
+	if path, ok := strings.CutPrefix(endpoint, SchemeNamedPipe+"://"); ok && path != "" {
+		return LocalAddr{Scheme: SchemeNamedPipe, Path: strings.ReplaceAll(path, "/", `\`)}, true
+	}
+	return LocalAddr{}, false
+}
+
+// DialContext connects to the local socket.
+func (la LocalAddr) DialContext(ctx context.Context) (net.Conn, error) {
+	if la.Scheme == SchemeNamedPipe {
+		return dialPipe(ctx, la.Path)
+	}
+	var d net.Dialer
+	return d.DialContext(ctx, "unix", la.Path)
+}
+
+// Listen listens on the local socket. The named pipes can only be dialed.
+func (la LocalAddr) Listen() (net.Listener, error) {
+	if la.Scheme == SchemeNamedPipe {
+		return nil, errNamedPipeListen
+	}
+	return net.Listen("unix", la.Path)
+}
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confignet
+
+import (
+	"context"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLocalEndpoint(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		expected LocalAddr
+		ok       bool
+	}{
+		{endpoint: "unix:///var/run/otelcol.sock", expected: LocalAddr{Scheme: SchemeUnix, Path: "/var/run/otelcol.sock"}, ok: true},
+		{endpoint: "unix://relative.sock", expected: LocalAddr{Scheme: SchemeUnix, Path: "relative.sock"}, ok: true},
+		{endpoint: "npipe:////./pipe/otelcol", expected: LocalAddr{Scheme: SchemeNamedPipe, Path: `\\.\pipe\otelcol`}, ok: true},
+		{endpoint: "unix://"},
+		{endpoint: "localhost:4317"},
+		{endpoint: "http://localhost:4318"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			la, ok := ParseLocalEndpoint(tt.endpoint)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, la)
+		})
+	}
+}
+
+func TestLocalAddrUnix(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix domain sockets are not supported on Windows by all the versions")
+	}
+	la, ok := ParseLocalEndpoint("unix://" + filepath.Join(t.TempDir(), "otelcol.sock"))
+	require.True(t, ok)
+	ln, err := la.Listen()
+	require.NoError(t, err)
+	done := make(chan bool, 1)
+
+	go func() {
+		conn, errGo := ln.Accept()
+		assert.NoError(t, errGo)
+		buf := make([]byte, 10)
+		var numChr int
+		numChr, errGo = conn.Read(buf)
+		assert.NoError(t, errGo)
+		assert.Equal(t, "test", string(buf[:numChr]))
+		assert.NoError(t, conn.Close())
+		done <- true
+	}()
+
+	conn, err := la.DialContext(context.Background())
+	require.NoError(t, err)
+	_, err = conn.Write([]byte("test"))
+	assert.NoError(t, err)
+	assert.NoError(t, conn.Close())
+	<-done
+	assert.NoError(t, ln.Close())
+}
+
+func TestLocalAddrNamedPipe(t *testing.T) {
+	la := LocalAddr{Scheme: SchemeNamedPipe, Path: `\\.\pipe\otelcol-missing`}
+	_, err := la.Listen()
+	assert.ErrorIs(t, err, errNamedPipeListen)
+
+	_, err = la.DialContext(context.Background())
+	if runtime.GOOS != "windows" {
+		assert.ErrorIs(t, err, errNamedPipeNotSupported)
+	} else {
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,15 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package confignet // import "go.opentelemetry.io/collector/config/confignet"
+
+import (
+	"context"
+	"net"
+)
+
+func dialPipe(_ context.Context, _ string) (net.Conn, error) {
+	return nil, errNamedPipeNotSupported
+}
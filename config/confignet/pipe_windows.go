@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows
+
+package confignet // import "go.opentelemetry.io/collector/config/confignet"
+
+import (
+	"context"
+	"net"
+	"os"
+	"time"
+)
+
+// dialPipe opens the client end of the named pipe.
+func dialPipe(ctx context.Context, path string) (net.Conn, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &pipeConn{File: f, addr: pipeAddr(path)}, nil
+}
+
+// pipeConn is a net.Conn reading from and writing to a named pipe.
+type pipeConn struct {
+	*os.File
+	addr pipeAddr
+}
+
+func (c *pipeConn) LocalAddr() net.Addr {
+	return c.addr
+}
+
+func (c *pipeConn) RemoteAddr() net.Addr {
+	return c.addr
+}
+
+// SetDeadline is a no-op when the named pipe does not support deadlines, the connection being then
+// only interrupted by Close.
+func (c *pipeConn) SetDeadline(t time.Time) error {
+	_ = c.File.SetDeadline(t)
+	return nil
+}
+
+func (c *pipeConn) SetReadDeadline(t time.Time) error {
+	_ = c.File.SetReadDeadline(t)
+	return nil
+}
+
+func (c *pipeConn) SetWriteDeadline(t time.Time) error {
+	_ = c.File.SetWriteDeadline(t)
+	return nil
+}
+
+// pipeAddr is the net.Addr of a named pipe.
+type pipeAddr string
+
+func (a pipeAddr) Network() string {
+	return SchemeNamedPipe
+}
+
+func (a pipeAddr) String() string {
+	return string(a)
+}
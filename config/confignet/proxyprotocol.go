@@ -0,0 +1,276 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confignet // import "go.opentelemetry.io/collector/config/confignet"
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// proxyHeaderTimeout bounds the time spent waiting for the PROXY protocol header of a connection.
+const proxyHeaderTimeout = 10 * time.Second
+
+var (
+	proxyV1Prefix    = []byte("PROXY ")
+	proxyV2Signature = []byte("\r\n\r\n\x00\r\nQUIT\n")
+
+	errProxyHeader = errors.New("invalid PROXY protocol header")
+)
+
+// ProxyProtocolSettings configures support for the PROXY protocol (v1 and v2) on a listener,
+// used by L4 load balancers to pass on the address of the client they proxy the connection for.
+// See https://www.haproxy.org/download/2.8/doc/proxy-protocol.txt.
+type ProxyProtocolSettings struct {
+	// Enabled, if true, reads the PROXY protocol header of connections coming from a trusted source,
+	// so that the address of the original client is reported as the remote address of the connection.
+	Enabled bool `mapstructure:"enabled"`
+
+	// TrustedSources lists the CIDR blocks, e.g. "10.0.0.0/8", of the load balancers allowed to send
+	// a PROXY protocol header. Connections from other sources are handled as if the PROXY protocol was
+	// disabled, so that clients can not spoof their address. Required when Enabled is true.
+	TrustedSources []string `mapstructure:"trusted_sources"`
+}
+
+// Validate checks if the ProxyProtocolSettings configuration is valid.
+func (ps *ProxyProtocolSettings) Validate() error {
+	if !ps.Enabled {
+		return nil
+	}
+	if len(ps.TrustedSources) == 0 {
+		return errors.New("proxy_protocol: trusted_sources must be set when the PROXY protocol is enabled")
+	}
+	_, err := ps.trustedNetworks()
+	return err
+}
+
+func (ps *ProxyProtocolSettings) trustedNetworks() ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(ps.TrustedSources))
+	for _, cidr := range ps.TrustedSources {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("proxy_protocol: invalid trusted source %q: %w", cidr, err)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// WrapListener returns a net.Listener whose connections report the client address sent in the
+// PROXY protocol header by trusted sources. The listener is returned as-is if the PROXY protocol
+// is not enabled.
+func (ps *ProxyProtocolSettings) WrapListener(l net.Listener) (net.Listener, error) {
+	if ps == nil || !ps.Enabled {
+		return l, nil
+	}
+	if err := ps.Validate(); err != nil {
+		return nil, err
+	}
+	networks, err := ps.trustedNetworks()
+	if err != nil {
+		return nil, err
+	}
+	return &proxyListener{Listener: l, trusted: networks}, nil
+}
+
+type proxyListener struct {
+	net.Listener
+	trusted []*net.IPNet
+}
+
+func (l *proxyListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	if !l.isTrusted(conn.RemoteAddr()) {
+		return conn, nil
+	}
+	// The header is read on first use of the connection, so that a slow client does not block Accept.
+	return &proxyConn{Conn: conn, reader: bufio.NewReader(conn)}, nil
+}
+
+func (l *proxyListener) isTrusted(addr net.Addr) bool {
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	if !ok {
+		return false
+	}
+	for _, network := range l.trusted {
+		if network.Contains(tcpAddr.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+type proxyConn struct {
+	net.Conn
+	reader *bufio.Reader
+
+	once       sync.Once
+	err        error
+	remoteAddr net.Addr
+	localAddr  net.Addr
+}
+
+func (c *proxyConn) readHeader() {
+	c.once.Do(func() {
+		if err := c.Conn.SetReadDeadline(time.Now().Add(proxyHeaderTimeout)); err != nil {
+			c.err = err
+			return
+		}
+		c.remoteAddr, c.localAddr, c.err = readProxyHeader(c.reader)
+		if err := c.Conn.SetReadDeadline(time.Time{}); err != nil && c.err == nil {
+			c.err = err
+		}
+	})
+}
+
+func (c *proxyConn) Read(b []byte) (int, error) {
+	c.readHeader()
+	if c.err != nil {
+		return 0, c.err
+	}
+	return c.reader.Read(b)
+}
+
+func (c *proxyConn) RemoteAddr() net.Addr {
+	c.readHeader()
+	if c.remoteAddr != nil {
+		return c.remoteAddr
+	}
+	return c.Conn.RemoteAddr()
+}
+
+func (c *proxyConn) LocalAddr() net.Addr {
+	c.readHeader()
+	if c.localAddr != nil {
+		return c.localAddr
+	}
+	return c.Conn.LocalAddr()
+}
+
+// readProxyHeader consumes the PROXY protocol header, if any, returning the source and destination
+// addresses it carries. Nil addresses are returned if there is no header, or if it does not carry
+// addresses, e.g. for health checks sent by the load balancer itself.
+func readProxyHeader(r *bufio.Reader) (net.Addr, net.Addr, error) {
+	first, err := r.Peek(1)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	switch first[0] {
+	case proxyV1Prefix[0]:
+		if prefix, err := r.Peek(len(proxyV1Prefix)); err == nil && bytes.Equal(prefix, proxyV1Prefix) {
+			return readProxyHeaderV1(r)
+		}
+	case proxyV2Signature[0]:
+		if prefix, err := r.Peek(len(proxyV2Signature)); err == nil && bytes.Equal(prefix, proxyV2Signature) {
+			return readProxyHeaderV2(r)
+		}
+	}
+	return nil, nil, nil
+}
+
+// readProxyHeaderV1 reads a header such as "PROXY TCP4 192.168.0.1 192.168.0.11 56324 443\r\n".
+func readProxyHeaderV1(r *bufio.Reader) (net.Addr, net.Addr, error) {
+	// The header is at most 107 bytes long, including the CRLF.
+	var line []byte
+	for len(line) < 107 {
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, nil, err
+		}
+		line = append(line, b)
+		if b == '\n' {
+			break
+		}
+	}
+	if !bytes.HasSuffix(line, []byte("\r\n")) {
+		return nil, nil, errProxyHeader
+	}
+	fields := strings.Fields(string(line[:len(line)-2]))
+	if len(fields) >= 2 && fields[1] == "UNKNOWN" {
+		return nil, nil, nil
+	}
+	if len(fields) != 6 || (fields[1] != "TCP4" && fields[1] != "TCP6") {
+		return nil, nil, errProxyHeader
+	}
+	src, err := parseTCPAddr(fields[2], fields[4])
+	if err != nil {
+		return nil, nil, err
+	}
+	dst, err := parseTCPAddr(fields[3], fields[5])
+	if err != nil {
+		return nil, nil, err
+	}
+	return src, dst, nil
+}
+
+func parseTCPAddr(host, port string) (*net.TCPAddr, error) {
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return nil, errProxyHeader
+	}
+	p, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return nil, errProxyHeader
+	}
+	return &net.TCPAddr{IP: ip, Port: int(p)}, nil
+}
+
+// readProxyHeaderV2 reads the binary header of the version 2 of the protocol.
+func readProxyHeaderV2(r *bufio.Reader) (net.Addr, net.Addr, error) {
+	var fixed [16]byte
+	if _, err := io.ReadFull(r, fixed[:]); err != nil {
+		return nil, nil, err
+	}
+	verCmd, family := fixed[12], fixed[13]
+	length := int(binary.BigEndian.Uint16(fixed[14:16]))
+	if verCmd>>4 != 2 {
+		return nil, nil, errProxyHeader
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, nil, err
+	}
+	switch verCmd & 0x0F {
+	case 0x0: // LOCAL: the connection was established by the proxy itself.
+		return nil, nil, nil
+	case 0x1: // PROXY
+	default:
+		return nil, nil, errProxyHeader
+	}
+
+	var ipLen int
+	switch family >> 4 {
+	case 0x1: // AF_INET
+		ipLen = net.IPv4len
+	case 0x2: // AF_INET6
+		ipLen = net.IPv6len
+	default: // AF_UNSPEC, AF_UNIX: the addresses are ignored.
+		return nil, nil, nil
+	}
+	if len(payload) < 2*ipLen+4 {
+		return nil, nil, errProxyHeader
+	}
+	srcIP := net.IP(payload[:ipLen])
+	dstIP := net.IP(payload[ipLen : 2*ipLen])
+	srcPort := int(binary.BigEndian.Uint16(payload[2*ipLen:]))
+	dstPort := int(binary.BigEndian.Uint16(payload[2*ipLen+2:]))
+	if family&0x0F == 0x2 { // DGRAM
+		return &net.UDPAddr{IP: srcIP, Port: srcPort}, &net.UDPAddr{IP: dstIP, Port: dstPort}, nil
+	}
+	return &net.TCPAddr{IP: srcIP, Port: srcPort}, &net.TCPAddr{IP: dstIP, Port: dstPort}, nil
+}
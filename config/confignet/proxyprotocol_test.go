@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confignet
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProxyProtocolSettingsValidate(t *testing.T) {
+	assert.NoError(t, (&ProxyProtocolSettings{}).Validate())
+	assert.NoError(t, (&ProxyProtocolSettings{Enabled: true, TrustedSources: []string{"10.0.0.0/8", "::1/128"}}).Validate())
+	assert.Error(t, (&ProxyProtocolSettings{Enabled: true}).Validate())
+	assert.Error(t, (&ProxyProtocolSettings{Enabled: true, TrustedSources: []string{"10.0.0.1"}}).Validate())
+}
+
+func proxyV2Header(cmd byte, family byte, payload []byte) []byte {
+	header := append([]byte{}, proxyV2Signature...)
+	header = append(header, 0x20|cmd, family)
+	header = binary.BigEndian.AppendUint16(header, uint16(len(payload)))
+	return append(header, payload...)
+}
+
+func TestReadProxyHeader(t *testing.T) {
+	ipv4Payload := []byte{192, 168, 0, 1, 192, 168, 0, 11, 0xDC, 0x04, 0x01, 0xBB}
+	ipv6Payload := append(append(net.ParseIP("2001:db8::1").To16(), net.ParseIP("2001:db8::2").To16()...), 0xDC, 0x04, 0x01, 0xBB)
+	for _, tt := range []struct {
+		name     string
+		input    []byte
+		src      net.Addr
+		dst      net.Addr
+		wantErr  bool
+		leftover string
+	}{
+		{
+			name:     "no header",
+			input:    []byte("GET / HTTP/1.1\r\n"),
+			leftover: "GET / HTTP/1.1\r\n",
+		},
+		{
+			name:     "v1 TCP4",
+			input:    []byte("PROXY TCP4 192.168.0.1 192.168.0.11 56324 443\r\ndata"),
+			src:      &net.TCPAddr{IP: net.ParseIP("192.168.0.1"), Port: 56324},
+			dst:      &net.TCPAddr{IP: net.ParseIP("192.168.0.11"), Port: 443},
+			leftover: "data",
+		},
+		{
+			name:     "v1 TCP6",
+			input:    []byte("PROXY TCP6 2001:db8::1 2001:db8::2 56324 443\r\ndata"),
+			src:      &net.TCPAddr{IP: net.ParseIP("2001:db8::1"), Port: 56324},
+			dst:      &net.TCPAddr{IP: net.ParseIP("2001:db8::2"), Port: 443},
+			leftover: "data",
+		},
+		{
+			name:     "v1 UNKNOWN",
+			input:    []byte("PROXY UNKNOWN\r\ndata"),
+			leftover: "data",
+		},
+		{
+			name:    "v1 invalid address",
+			input:   []byte("PROXY TCP4 invalid 192.168.0.11 56324 443\r\n"),
+			wantErr: true,
+		},
+		{
+			name:    "v1 missing CRLF",
+			input:   []byte("PROXY TCP4 192.168.0.1 192.168.0.11 56324 443\n"),
+			wantErr: true,
+		},
+		{
+			name:     "v2 TCP4",
+			input:    append(proxyV2Header(0x1, 0x11, ipv4Payload), []byte("data")...),
+			src:      &net.TCPAddr{IP: net.IP{192, 168, 0, 1}, Port: 56324},
+			dst:      &net.TCPAddr{IP: net.IP{192, 168, 0, 11}, Port: 443},
+			leftover: "data",
+		},
+		{
+			name:     "v2 TCP6 with TLVs",
+			input:    append(proxyV2Header(0x1, 0x21, append(ipv6Payload, 0x04, 0x00, 0x01, 0x00)), []byte("data")...),
+			src:      &net.TCPAddr{IP: net.ParseIP("2001:db8::1"), Port: 56324},
+			dst:      &net.TCPAddr{IP: net.ParseIP("2001:db8::2"), Port: 443},
+			leftover: "data",
+		},
+		{
+			name:     "v2 LOCAL",
+			input:    append(proxyV2Header(0x0, 0x00, nil), []byte("data")...),
+			leftover: "data",
+		},
+		{
+			name:    "v2 truncated addresses",
+			input:   proxyV2Header(0x1, 0x11, ipv4Payload[:4]),
+			wantErr: true,
+		},
+		{
+			name:    "v2 invalid command",
+			input:   proxyV2Header(0x2, 0x11, ipv4Payload),
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := bufio.NewReader(bytes.NewReader(tt.input))
+			src, dst, err := readProxyHeader(r)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.src, src)
+			assert.Equal(t, tt.dst, dst)
+			leftover, err := io.ReadAll(r)
+			require.NoError(t, err)
+			assert.Equal(t, tt.leftover, string(leftover))
+		})
+	}
+}
+
+func TestProxyProtocolListener(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		trustedSources []string
+		wantAddr       string
+		wantData       string
+	}{
+		{
+			name:           "trusted source",
+			trustedSources: []string{"127.0.0.0/8"},
+			wantAddr:       "192.168.0.1:56324",
+			wantData:       "test",
+		},
+		{
+			name:           "untrusted source",
+			trustedSources: []string{"10.0.0.0/8"},
+			wantData:       "PROXY TCP4 192.168.0.1 192.168.0.11 56324 443\r\ntest",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ps := &ProxyProtocolSettings{Enabled: true, TrustedSources: tt.trustedSources}
+			inner, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			ln, err := ps.WrapListener(inner)
+			require.NoError(t, err)
+			defer ln.Close()
+
+			go func() {
+				conn, errDial := net.Dial("tcp", ln.Addr().String())
+				if !assert.NoError(t, errDial) {
+					return
+				}
+				_, errDial = conn.Write([]byte("PROXY TCP4 192.168.0.1 192.168.0.11 56324 443\r\ntest"))
+				assert.NoError(t, errDial)
+				assert.NoError(t, conn.Close())
+			}()
+
+			conn, err := ln.Accept()
+			require.NoError(t, err)
+			defer conn.Close()
+			if tt.wantAddr != "" {
+				assert.Equal(t, tt.wantAddr, conn.RemoteAddr().String())
+			} else {
+				assert.Contains(t, conn.RemoteAddr().String(), "127.0.0.1:")
+			}
+			data, err := io.ReadAll(conn)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantData, string(data))
+		})
+	}
+}
+
+func TestProxyProtocolWrapListenerDisabled(t *testing.T) {
+	inner, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer inner.Close()
+
+	var ps *ProxyProtocolSettings
+	ln, err := ps.WrapListener(inner)
+	require.NoError(t, err)
+	assert.Same(t, inner, ln)
+
+	_, err = (&ProxyProtocolSettings{Enabled: true}).WrapListener(inner)
+	assert.Error(t, err)
+}
@@ -0,0 +1,16 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !linux && !darwin && !freebsd
+
+package confignet // import "go.opentelemetry.io/collector/config/confignet"
+
+import (
+	"syscall"
+)
+
+const reusePortSupported = false
+
+func reusePortControl(_, _ string, _ syscall.RawConn) error {
+	return errReusePortNotSupported
+}
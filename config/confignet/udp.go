@@ -0,0 +1,213 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confignet // import "go.opentelemetry.io/collector/config/confignet"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+)
+
+const (
+	defaultUDPTransport  = "udp"
+	defaultMaxPacketSize = 65535
+)
+
+var errReusePortNotSupported = errors.New("reuse_port is not supported on this platform")
+
+// UDPServerSettings defines the settings of a server receiving datagrams over UDP,
+// e.g. for the statsd or syslog receivers.
+type UDPServerSettings struct {
+	// Endpoint configures the listening address, in the form "host:port".
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Transport to use, "udp" (default), "udp4" (IPv4-only) or "udp6" (IPv6-only).
+	Transport string `mapstructure:"transport"`
+
+	// ReadBufferSize is the size in bytes of the receive buffer of the socket (SO_RCVBUF).
+	// The system default is used if zero.
+	ReadBufferSize int `mapstructure:"read_buffer_size"`
+
+	// MaxPacketSize is the maximum size in bytes of a datagram, the larger datagrams are dropped.
+	// Defaults to 65535 if zero.
+	MaxPacketSize int `mapstructure:"max_packet_size"`
+
+	// NumWorkers is the number of goroutines reading and handling the datagrams concurrently.
+	// Defaults to 1 if zero.
+	NumWorkers int `mapstructure:"num_workers"`
+
+	// ReusePort, if true, opens a socket per worker bound to the same address with SO_REUSEPORT,
+	// so that the kernel balances the datagrams across the workers. Only supported on Linux,
+	// macOS and FreeBSD.
+	ReusePort bool `mapstructure:"reuse_port"`
+}
+
+// Validate checks if the UDPServerSettings configuration is valid.
+func (us *UDPServerSettings) Validate() error {
+	switch us.Transport {
+	case "", "udp", "udp4", "udp6":
+	default:
+		return fmt.Errorf("transport must be one of \"udp\", \"udp4\" or \"udp6\", got %q", us.Transport)
+	}
+	if us.ReadBufferSize < 0 {
+		return errors.New("read_buffer_size must not be negative")
+	}
+	if us.MaxPacketSize < 0 || us.MaxPacketSize > defaultMaxPacketSize {
+		return fmt.Errorf("max_packet_size must be between 0 and %d", defaultMaxPacketSize)
+	}
+	if us.NumWorkers < 0 {
+		return errors.New("num_workers must not be negative")
+	}
+	if us.ReusePort && !reusePortSupported {
+		return errReusePortNotSupported
+	}
+	return nil
+}
+
+// PacketHandler handles a datagram, with the context returned by the function set with WithPacketContext.
+// The payload is only valid until the handler returns.
+type PacketHandler func(ctx context.Context, payload []byte)
+
+// UDPServer receives datagrams and passes them to a PacketHandler.
+type UDPServer struct {
+	conns         []net.PacketConn
+	numWorkers    int
+	maxPacketSize int
+	packetContext func(ctx context.Context, addr net.Addr) context.Context
+}
+
+// udpServerOptions has options that change the behavior of the UDPServer
+// returned by UDPServerSettings.ToServer().
+type udpServerOptions struct {
+	packetContext func(ctx context.Context, addr net.Addr) context.Context
+}
+
+// UDPServerOption is an option to change the behavior of the UDPServer
+// returned by UDPServerSettings.ToServer().
+type UDPServerOption func(opts *udpServerOptions)
+
+// WithPacketContext sets the function deriving the context passed to the PacketHandler from the
+// address of the sender of each datagram, e.g. to report it in client.Info:
+//
+//	confignet.WithPacketContext(func(ctx context.Context, addr net.Addr) context.Context {
+//		return client.NewContext(ctx, client.Info{Addr: addr, Protocol: client.ProtocolUDP})
+//	})
+func WithPacketContext(fn func(ctx context.Context, addr net.Addr) context.Context) UDPServerOption {
+	return func(opts *udpServerOptions) {
+		opts.packetContext = fn
+	}
+}
+
+// ToServer opens the sockets of a UDPServer listening on the configured address.
+func (us *UDPServerSettings) ToServer(opts ...UDPServerOption) (*UDPServer, error) {
+	if err := us.Validate(); err != nil {
+		return nil, err
+	}
+	serverOpts := &udpServerOptions{}
+	for _, o := range opts {
+		o(serverOpts)
+	}
+	transport := us.Transport
+	if transport == "" {
+		transport = defaultUDPTransport
+	}
+	srv := &UDPServer{
+		numWorkers:    us.NumWorkers,
+		maxPacketSize: us.MaxPacketSize,
+		packetContext: serverOpts.packetContext,
+	}
+	if srv.numWorkers == 0 {
+		srv.numWorkers = 1
+	}
+	if srv.maxPacketSize == 0 {
+		srv.maxPacketSize = defaultMaxPacketSize
+	}
+
+	numConns := 1
+	lc := net.ListenConfig{}
+	if us.ReusePort {
+		numConns = srv.numWorkers
+		lc.Control = reusePortControl
+	}
+	endpoint := us.Endpoint
+	for i := 0; i < numConns; i++ {
+		conn, err := lc.ListenPacket(context.Background(), transport, endpoint)
+		if err == nil && us.ReadBufferSize > 0 {
+			err = conn.(*net.UDPConn).SetReadBuffer(us.ReadBufferSize)
+		}
+		if err != nil {
+			if conn != nil {
+				_ = conn.Close()
+			}
+			_ = srv.Close()
+			return nil, err
+		}
+		srv.conns = append(srv.conns, conn)
+		// The other sockets are bound to the port of the first socket, chosen by the system if 0.
+		endpoint = conn.LocalAddr().String()
+	}
+	return srv, nil
+}
+
+// Addr returns the address the server is listening on.
+func (s *UDPServer) Addr() net.Addr {
+	return s.conns[0].LocalAddr()
+}
+
+// Serve reads the datagrams with the configured number of workers, calling handler for each of them.
+// It blocks until the server is closed.
+func (s *UDPServer) Serve(handler PacketHandler) error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs error
+	)
+	for i := 0; i < s.numWorkers; i++ {
+		conn := s.conns[i%len(s.conns)]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.serveConn(conn, handler); err != nil {
+				mu.Lock()
+				errs = errors.Join(errs, err)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+func (s *UDPServer) serveConn(conn net.PacketConn, handler PacketHandler) error {
+	// One more byte detects the datagrams larger than the maximum size, truncated by the read.
+	buf := make([]byte, s.maxPacketSize+1)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		if n > s.maxPacketSize {
+			continue
+		}
+		ctx := context.Background()
+		if s.packetContext != nil {
+			ctx = s.packetContext(ctx, addr)
+		}
+		handler(ctx, buf[:n])
+	}
+}
+
+// Close closes the sockets of the server, stopping Serve once the datagrams being handled are handled.
+func (s *UDPServer) Close() error {
+	var errs error
+	for _, conn := range s.conns {
+		errs = errors.Join(errs, conn.Close())
+	}
+	return errs
+}
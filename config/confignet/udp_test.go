@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confignet
+
+import (
+	"context"
+	"net"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type senderKey struct{}
+
+type packet struct {
+	payload string
+	sender  net.Addr
+}
+
+func startUDPServer(t *testing.T, us UDPServerSettings) (*UDPServer, <-chan packet) {
+	srv, err := us.ToServer(WithPacketContext(func(ctx context.Context, addr net.Addr) context.Context {
+		return context.WithValue(ctx, senderKey{}, addr)
+	}))
+	require.NoError(t, err)
+	packets := make(chan packet, 10)
+	done := make(chan error)
+	go func() {
+		done <- srv.Serve(func(ctx context.Context, payload []byte) {
+			sender, _ := ctx.Value(senderKey{}).(net.Addr)
+			packets <- packet{payload: string(payload), sender: sender}
+		})
+	}()
+	t.Cleanup(func() {
+		require.NoError(t, srv.Close())
+		assert.NoError(t, <-done)
+	})
+	return srv, packets
+}
+
+func sendUDP(t *testing.T, addr net.Addr, payload []byte) net.Addr {
+	conn, err := net.Dial("udp", addr.String())
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write(payload)
+	require.NoError(t, err)
+	return conn.LocalAddr()
+}
+
+func receive(t *testing.T, packets <-chan packet) packet {
+	select {
+	case p := <-packets:
+		return p
+	case <-time.After(5 * time.Second):
+		t.Fatal("no datagram received")
+		return packet{}
+	}
+}
+
+func TestUDPServer(t *testing.T) {
+	srv, packets := startUDPServer(t, UDPServerSettings{
+		Endpoint:       "127.0.0.1:0",
+		ReadBufferSize: 1 << 16,
+		MaxPacketSize:  16,
+		NumWorkers:     2,
+	})
+
+	from := sendUDP(t, srv.Addr(), []byte("foo:1|c"))
+	p := receive(t, packets)
+	assert.Equal(t, "foo:1|c", p.payload)
+	assert.Equal(t, from.String(), p.sender.String())
+
+	// The datagrams larger than the maximum size are dropped.
+	sendUDP(t, srv.Addr(), make([]byte, 17))
+	sendUDP(t, srv.Addr(), []byte("bar:2|c"))
+	assert.Equal(t, "bar:2|c", receive(t, packets).payload)
+}
+
+func TestUDPServerReusePort(t *testing.T) {
+	if !reusePortSupported {
+		t.Skip("SO_REUSEPORT is not supported on " + runtime.GOOS)
+	}
+	srv, packets := startUDPServer(t, UDPServerSettings{
+		Endpoint:   "127.0.0.1:0",
+		NumWorkers: 3,
+		ReusePort:  true,
+	})
+	require.Len(t, srv.conns, 3)
+	for _, conn := range srv.conns {
+		assert.Equal(t, srv.Addr().String(), conn.LocalAddr().String())
+	}
+
+	for i := 0; i < 5; i++ {
+		sendUDP(t, srv.Addr(), []byte("foo:1|c"))
+		assert.Equal(t, "foo:1|c", receive(t, packets).payload)
+	}
+}
+
+func TestUDPServerListenError(t *testing.T) {
+	us := UDPServerSettings{Endpoint: "localhost:invalid"}
+	_, err := us.ToServer()
+	assert.Error(t, err)
+}
+
+func TestUDPServerSettingsValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings UDPServerSettings
+		err      string
+	}{
+		{name: "default", settings: UDPServerSettings{Endpoint: "localhost:8125"}},
+		{name: "udp6", settings: UDPServerSettings{Transport: "udp6", NumWorkers: 4}},
+		{name: "transport", settings: UDPServerSettings{Transport: "tcp"}, err: `transport must be one of "udp", "udp4" or "udp6", got "tcp"`},
+		{name: "read buffer", settings: UDPServerSettings{ReadBufferSize: -1}, err: "read_buffer_size must not be negative"},
+		{name: "max packet size", settings: UDPServerSettings{MaxPacketSize: 70000}, err: "max_packet_size must be between 0 and 65535"},
+		{name: "workers", settings: UDPServerSettings{NumWorkers: -1}, err: "num_workers must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.settings.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
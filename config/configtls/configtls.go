@@ -6,7 +6,9 @@ package configtls // import "go.opentelemetry.io/collector/config/configtls"
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sync"
@@ -57,6 +59,19 @@ type TLSSetting struct {
 	// ReloadInterval specifies the duration after which the certificate will be reloaded
 	// If not set, it will never be reloaded (optional)
 	ReloadInterval time.Duration `mapstructure:"reload_interval"`
+
+	// KeyLogFile is the path of the file the TLS master secrets are appended to, in the NSS key log
+	// format, allowing tools like Wireshark to decrypt the connections. It compromises the security
+	// of the connections and must only be used for debugging. (optional)
+	KeyLogFile string `mapstructure:"key_log_file"`
+
+	// CurvePreferences sets the elliptic curves used in the key exchange, in order of preference.
+	// If not set, refer to crypto/tls for defaults. (optional)
+	CurvePreferences []string `mapstructure:"curve_preferences"`
+
+	// StrictMode only accepts TLS 1.3, whose cipher suites are all modern AEAD ciphers.
+	// It cannot be combined with a min_version or max_version other than "1.3". (optional)
+	StrictMode bool `mapstructure:"strict_mode"`
 }
 
 // TLSClientSetting contains TLS configurations that are specific to client
@@ -147,9 +162,32 @@ func (r *certReloader) GetCertificate() (*tls.Certificate, error) {
 	return r.cert, nil
 }
 
+// Validate checks that the TLS versions and curves are supported, and consistent with the strict mode.
+func (c TLSSetting) Validate() error {
+	minTLS, err := convertVersion(c.MinVersion, defaultMinTLSVersion)
+	if err != nil {
+		return fmt.Errorf("invalid TLS min_version: %w", err)
+	}
+	maxTLS, err := convertVersion(c.MaxVersion, defaultMaxTLSVersion)
+	if err != nil {
+		return fmt.Errorf("invalid TLS max_version: %w", err)
+	}
+	if c.StrictMode && (c.MinVersion != "" && minTLS != tls.VersionTLS13 || c.MaxVersion != "" && maxTLS != tls.VersionTLS13) {
+		return errors.New("TLS strict_mode only accepts TLS 1.3, min_version and max_version must be empty or \"1.3\"")
+	}
+	if _, err = convertCurves(c.CurvePreferences); err != nil {
+		return fmt.Errorf("invalid TLS curve_preferences: %w", err)
+	}
+	return nil
+}
+
 // loadTLSConfig loads TLS certificates and returns a tls.Config.
 // This will set the RootCAs and Certificates of a tls.Config.
 func (c TLSSetting) loadTLSConfig() (*tls.Config, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
 	certPool, err := c.loadCACertPool()
 	if err != nil {
 		return nil, err
@@ -175,6 +213,21 @@ func (c TLSSetting) loadTLSConfig() (*tls.Config, error) {
 	if err != nil {
 		return nil, fmt.Errorf("invalid TLS max_version: %w", err)
 	}
+	if c.StrictMode {
+		minTLS, maxTLS = tls.VersionTLS13, tls.VersionTLS13
+	}
+	curves, err := convertCurves(c.CurvePreferences)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TLS curve_preferences: %w", err)
+	}
+
+	var keyLogWriter io.Writer
+	if c.KeyLogFile != "" {
+		keyLogWriter, err = os.OpenFile(filepath.Clean(c.KeyLogFile), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open TLS key_log_file: %w", err)
+		}
+	}
 
 	return &tls.Config{
 		RootCAs:              certPool,
@@ -182,6 +235,8 @@ func (c TLSSetting) loadTLSConfig() (*tls.Config, error) {
 		GetClientCertificate: getClientCertificate,
 		MinVersion:           minTLS,
 		MaxVersion:           maxTLS,
+		CurvePreferences:     curves,
+		KeyLogWriter:         keyLogWriter,
 	}, nil
 }
 
@@ -355,3 +410,22 @@ var tlsVersions = map[string]uint16{
 	"1.2": tls.VersionTLS12,
 	"1.3": tls.VersionTLS13,
 }
+
+func convertCurves(names []string) ([]tls.CurveID, error) {
+	var curves []tls.CurveID
+	for _, name := range names {
+		curve, ok := tlsCurves[name]
+		if !ok {
+			return nil, fmt.Errorf("unsupported curve: %q", name)
+		}
+		curves = append(curves, curve)
+	}
+	return curves, nil
+}
+
+var tlsCurves = map[string]tls.CurveID{
+	"X25519": tls.X25519,
+	"P256":   tls.CurveP256,
+	"P384":   tls.CurveP384,
+	"P521":   tls.CurveP521,
+}
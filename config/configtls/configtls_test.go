@@ -627,3 +627,77 @@ func TestMinMaxTLSVersions(t *testing.T) {
 		})
 	}
 }
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		setting  TLSSetting
+		errorTxt string
+	}{
+		{name: "default", setting: TLSSetting{}},
+		{name: "strict mode", setting: TLSSetting{StrictMode: true}},
+		{name: "strict mode with TLS 1.3", setting: TLSSetting{StrictMode: true, MinVersion: "1.3", MaxVersion: "1.3"}},
+		{name: "curves", setting: TLSSetting{CurvePreferences: []string{"X25519", "P256", "P384", "P521"}}},
+		{
+			name:     "strict mode with TLS 1.2",
+			setting:  TLSSetting{StrictMode: true, MinVersion: "1.2"},
+			errorTxt: `TLS strict_mode only accepts TLS 1.3, min_version and max_version must be empty or "1.3"`,
+		},
+		{
+			name:     "strict mode with max TLS 1.2",
+			setting:  TLSSetting{StrictMode: true, MaxVersion: "1.2"},
+			errorTxt: `TLS strict_mode only accepts TLS 1.3, min_version and max_version must be empty or "1.3"`,
+		},
+		{
+			name:     "invalid min version",
+			setting:  TLSSetting{MinVersion: "asd"},
+			errorTxt: `invalid TLS min_version: unsupported TLS version: "asd"`,
+		},
+		{
+			name:     "invalid curve",
+			setting:  TLSSetting{CurvePreferences: []string{"X25519", "P224"}},
+			errorTxt: `invalid TLS curve_preferences: unsupported curve: "P224"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.setting.Validate()
+			if test.errorTxt == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.errorTxt)
+			}
+		})
+	}
+}
+
+func TestStrictModeAndCurves(t *testing.T) {
+	setting := TLSSetting{
+		StrictMode:       true,
+		CurvePreferences: []string{"X25519", "P256"},
+	}
+	config, err := setting.loadTLSConfig()
+	require.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS13), config.MinVersion)
+	assert.Equal(t, uint16(tls.VersionTLS13), config.MaxVersion)
+	assert.Equal(t, []tls.CurveID{tls.X25519, tls.CurveP256}, config.CurvePreferences)
+
+	_, err = TLSSetting{StrictMode: true, MinVersion: "1.2"}.loadTLSConfig()
+	assert.Error(t, err)
+}
+
+func TestKeyLogFile(t *testing.T) {
+	keyLogFile := filepath.Join(t.TempDir(), "keys.log")
+	config, err := TLSSetting{KeyLogFile: keyLogFile}.loadTLSConfig()
+	require.NoError(t, err)
+	require.NotNil(t, config.KeyLogWriter)
+	t.Cleanup(func() { assert.NoError(t, config.KeyLogWriter.(io.Closer).Close()) })
+
+	info, err := os.Stat(keyLogFile)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	_, err = TLSSetting{KeyLogFile: filepath.Join(t.TempDir(), "missing", "keys.log")}.loadTLSConfig()
+	assert.ErrorContains(t, err, "failed to open TLS key_log_file")
+}
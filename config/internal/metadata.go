@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/config/internal"
+
+import "strings"
+
+// MetadataRule maps a key of the metadata of the incoming requests to a key of the client.Metadata.
+type MetadataRule struct {
+	// Key is the key of the request metadata, matched case-insensitively.
+	Key string
+	// Rename is the key of the client.Metadata, Key if empty.
+	Rename string
+	// Default is the value used if the request has no value for Key, the key is omitted if empty.
+	Default string
+}
+
+// MapMetadata returns the metadata allowed by the rules, under their renamed keys.
+// The values of the rules mapping to the same key are appended in the order of the rules.
+func MapMetadata(md map[string][]string, rules []MetadataRule) map[string][]string {
+	mapped := make(map[string][]string, len(rules))
+	for _, rule := range rules {
+		vals := lookup(md, rule.Key)
+		if len(vals) == 0 && rule.Default != "" {
+			vals = []string{rule.Default}
+		}
+		if len(vals) == 0 {
+			continue
+		}
+		key := rule.Rename
+		if key == "" {
+			key = rule.Key
+		}
+		mapped[key] = append(mapped[key], vals...)
+	}
+	return mapped
+}
+
+func lookup(md map[string][]string, key string) []string {
+	if vals, ok := md[key]; ok {
+		return vals
+	}
+	for k, vals := range md {
+		if strings.EqualFold(k, key) {
+			return vals
+		}
+	}
+	return nil
+}
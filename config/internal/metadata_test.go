@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapMetadata(t *testing.T) {
+	md := map[string][]string{
+		"X-Tenant-Id": {"acme"},
+		"x-region":    {"eu", "us"},
+		"X-Secret":    {"s3cr3t"},
+	}
+	rules := []MetadataRule{
+		{Key: "x-tenant-id", Rename: "tenant"},
+		{Key: "X-Region"},
+		{Key: "x-env", Default: "prod"},
+		{Key: "x-missing"},
+	}
+	assert.Equal(t, map[string][]string{
+		"tenant":   {"acme"},
+		"X-Region": {"eu", "us"},
+		"x-env":    {"prod"},
+	}, MapMetadata(md, rules))
+
+	assert.Equal(t, map[string][]string{
+		"source": {"acme", "eu", "us"},
+	}, MapMetadata(md, []MetadataRule{
+		{Key: "x-tenant-id", Rename: "source"},
+		{Key: "x-region", Rename: "source"},
+	}))
+
+	assert.Empty(t, MapMetadata(md, nil))
+}
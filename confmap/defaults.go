@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confmap // import "go.opentelemetry.io/collector/confmap"
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// defaultTagName is the name of the struct tag holding the default value of a field, e.g. `default:"5s"`.
+const defaultTagName = "default"
+
+// ApplyDefaults sets the fields of the struct pointed to by cfg, and of its nested structs, to the default
+// value given by their `default` struct tag. Only the fields holding their zero value are set, so that
+// ApplyDefaults can be used to complete a default configuration partially built by hand.
+//
+// The default values are decoded the same way as the values of a Conf, e.g. `default:"5s"` for a
+// time.Duration or `default:"a,b"` for a []string. This allows simple components to derive their
+// default configuration from the struct tags:
+//
+//	func createDefaultConfig() component.Config {
+//		cfg := &Config{}
+//		if err := confmap.ApplyDefaults(cfg); err != nil {
+//			panic(err)
+//		}
+//		return cfg
+//	}
+func ApplyDefaults(cfg any) error {
+	val := reflect.ValueOf(cfg)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("expected a non-nil pointer to a struct, got %T", cfg)
+	}
+	return applyDefaults(val.Elem(), nil)
+}
+
+// applyDefaultsHookFunc returns a DecodeHookFuncValue that sets the fields of the structs absent from the
+// configuration to the default value given by their `default` struct tag.
+func applyDefaultsHookFunc() mapstructure.DecodeHookFuncValue {
+	return func(from reflect.Value, to reflect.Value) (any, error) {
+		if to.Kind() != reflect.Struct || !to.CanSet() {
+			return from.Interface(), nil
+		}
+		fromMap, ok := from.Interface().(map[string]any)
+		if !ok {
+			return from.Interface(), nil
+		}
+		if err := applyDefaults(to, fromMap); err != nil {
+			return nil, err
+		}
+		return from.Interface(), nil
+	}
+}
+
+// applyDefaults sets the zero fields of the given struct that are not set in the configuration to their
+// default value, recursively.
+func applyDefaults(to reflect.Value, conf map[string]any) error {
+	toType := to.Type()
+	for i := 0; i < toType.NumField(); i++ {
+		field := toType.Field(i)
+		fieldVal := to.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tagParts := strings.Split(field.Tag.Get("mapstructure"), ",")
+		name := tagParts[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		squash := false
+		for _, opt := range tagParts[1:] {
+			squash = squash || opt == "squash"
+		}
+
+		// The fields of squashed structs are set from the same configuration.
+		if squash && fieldVal.Kind() == reflect.Struct {
+			if err := applyDefaults(fieldVal, conf); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// The fields present in the configuration are set by the decoder.
+		if v, ok := conf[name]; ok && v != nil {
+			continue
+		}
+
+		defaultValue, hasDefault := field.Tag.Lookup(defaultTagName)
+		switch {
+		case hasDefault:
+			if !fieldVal.IsZero() {
+				continue
+			}
+			if err := decodeDefault(defaultValue, fieldVal); err != nil {
+				return fmt.Errorf("invalid default value %q for %q: %w", defaultValue, name, err)
+			}
+		case fieldVal.Kind() == reflect.Struct:
+			if err := applyDefaults(fieldVal, nil); err != nil {
+				return err
+			}
+		case fieldVal.Kind() == reflect.Ptr && !fieldVal.IsNil() && fieldVal.Elem().Kind() == reflect.Struct:
+			if err := applyDefaults(fieldVal.Elem(), nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// decodeDefault decodes the default value given by a struct tag into the field,
+// using the same hooks as decodeConfig for the scalar values.
+func decodeDefault(defaultValue string, field reflect.Value) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           field.Addr().Interface(),
+		WeaklyTypedInput: true,
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			mapstructure.StringToSliceHookFunc(","),
+			mapstructure.StringToTimeDurationHookFunc(),
+			mapstructure.TextUnmarshallerHookFunc(),
+		),
+	})
+	if err != nil {
+		return err
+	}
+	return decoder.Decode(defaultValue)
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confmap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type defaultsEmbedded struct {
+	Endpoint string `mapstructure:"endpoint" default:"localhost:4317"`
+}
+
+type defaultsNested struct {
+	Enabled  bool          `mapstructure:"enabled" default:"true"`
+	Interval time.Duration `mapstructure:"interval" default:"5s"`
+}
+
+type defaultsConfig struct {
+	Embedded  defaultsEmbedded `mapstructure:",squash"`
+	Timeout   time.Duration    `mapstructure:"timeout" default:"10s"`
+	Size      int              `mapstructure:"size" default:"512"`
+	Ratio     float64          `mapstructure:"ratio" default:"0.5"`
+	Keys      []string         `mapstructure:"keys" default:"a,b"`
+	Limit     *int             `mapstructure:"limit" default:"10"`
+	NoDefault string           `mapstructure:"no_default"`
+	Nested    defaultsNested   `mapstructure:"nested"`
+	NestedPtr *defaultsNested  `mapstructure:"nested_ptr"`
+	Ignored   string           `mapstructure:"-" default:"ignored"`
+}
+
+func TestApplyDefaults(t *testing.T) {
+	cfg := &defaultsConfig{Size: 1024, NestedPtr: &defaultsNested{}}
+	require.NoError(t, ApplyDefaults(cfg))
+
+	limit := 10
+	assert.Equal(t, &defaultsConfig{
+		Embedded: defaultsEmbedded{Endpoint: "localhost:4317"},
+		Timeout:  10 * time.Second,
+		// Fields already set are kept.
+		Size:      1024,
+		Ratio:     0.5,
+		Keys:      []string{"a", "b"},
+		Limit:     &limit,
+		Nested:    defaultsNested{Enabled: true, Interval: 5 * time.Second},
+		NestedPtr: &defaultsNested{Enabled: true, Interval: 5 * time.Second},
+	}, cfg)
+}
+
+func TestApplyDefaultsInvalid(t *testing.T) {
+	assert.EqualError(t, ApplyDefaults(defaultsConfig{}), "expected a non-nil pointer to a struct, got confmap.defaultsConfig")
+
+	type invalidConfig struct {
+		Timeout time.Duration `mapstructure:"timeout" default:"forever"`
+	}
+	assert.ErrorContains(t, ApplyDefaults(&invalidConfig{}), `invalid default value "forever" for "timeout"`)
+}
+
+func TestUnmarshalDefaults(t *testing.T) {
+	conf := NewFromStringMap(map[string]any{
+		"endpoint": "otelcol:4317",
+		"size":     2048,
+		"keys":     nil,
+		"nested": map[string]any{
+			"enabled": false,
+		},
+		"nested_ptr": map[string]any{
+			"interval": "1s",
+		},
+	})
+	cfg := &defaultsConfig{}
+	require.NoError(t, conf.Unmarshal(cfg, WithErrorUnused()))
+
+	limit := 10
+	assert.Equal(t, &defaultsConfig{
+		Embedded:  defaultsEmbedded{Endpoint: "otelcol:4317"},
+		Timeout:   10 * time.Second,
+		Size:      2048,
+		Ratio:     0.5,
+		Keys:      []string{"a", "b"},
+		Limit:     &limit,
+		Nested:    defaultsNested{Enabled: false, Interval: 5 * time.Second},
+		NestedPtr: &defaultsNested{Enabled: true, Interval: time.Second},
+	}, cfg)
+}
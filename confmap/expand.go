@@ -5,6 +5,7 @@ package confmap // import "go.opentelemetry.io/collector/confmap"
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
@@ -139,7 +140,8 @@ func toString(strURI string, input any) (string, error) {
 }
 
 func (mr *Resolver) expandURI(ctx context.Context, uri string) (any, bool, error) {
-	lURI, err := newLocation(uri[2 : len(uri)-1])
+	uriContent, hint := splitTypeHint(uri[2 : len(uri)-1])
+	lURI, err := newLocation(uriContent)
 	if err != nil {
 		return nil, false, err
 	}
@@ -152,7 +154,94 @@ func (mr *Resolver) expandURI(ctx context.Context, uri string) (any, bool, error
 	}
 	mr.closers = append(mr.closers, ret.Close)
 	val, err := ret.AsRaw()
-	return val, true, err
+	if err != nil || hint == "" {
+		return val, true, err
+	}
+	if val, err = castValue(val, hint); err != nil {
+		return nil, false, fmt.Errorf("expanding %v: %w", uri, err)
+	}
+	return val, true, nil
+}
+
+// Type hints that can be appended to an embedded uri, e.g. "${env:PORT|int}", to cast the retrieved value.
+const (
+	typeHintInt   = "int"
+	typeHintFloat = "float"
+	typeHintBool  = "bool"
+	typeHintJSON  = "json"
+)
+
+// splitTypeHint splits the type hint, if any, from the content of an embedded uri.
+// A suffix that is not a supported type hint is left as part of the uri.
+func splitTypeHint(uriContent string) (string, string) {
+	idx := strings.LastIndexByte(uriContent, '|')
+	if idx < 0 {
+		return uriContent, ""
+	}
+	switch hint := uriContent[idx+1:]; hint {
+	case typeHintInt, typeHintFloat, typeHintBool, typeHintJSON:
+		return uriContent[:idx], hint
+	default:
+		return uriContent, ""
+	}
+}
+
+// castValue casts the value retrieved for an embedded uri to the type given by the hint.
+// Values already of the requested type are returned as is, strings are parsed.
+func castValue(val any, hint string) (any, error) {
+	switch hint {
+	case typeHintInt:
+		switch v := val.(type) {
+		case int, int32, int64:
+			return v, nil
+		case string:
+			i, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+			if err == nil {
+				return int(i), nil
+			}
+		}
+	case typeHintFloat:
+		switch v := val.(type) {
+		case float32, float64:
+			return v, nil
+		case int:
+			return float64(v), nil
+		case int32:
+			return float64(v), nil
+		case int64:
+			return float64(v), nil
+		case string:
+			f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err == nil {
+				return f, nil
+			}
+		}
+	case typeHintBool:
+		switch v := val.(type) {
+		case bool:
+			return v, nil
+		case string:
+			b, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err == nil {
+				return b, nil
+			}
+		}
+	case typeHintJSON:
+		s, ok := val.(string)
+		if !ok {
+			// The providers already decode the values, the JSON documents being valid YAML ones.
+			return val, nil
+		}
+		var v any
+		if err := json.Unmarshal([]byte(s), &v); err != nil {
+			return nil, fmt.Errorf("cannot cast %q to %s: %w", s, hint, err)
+		}
+		return v, nil
+	}
+	if s, ok := val.(string); ok {
+		return nil, fmt.Errorf("cannot cast %q to %s", s, hint)
+	}
+	return nil, fmt.Errorf("cannot cast %v(%T) to %s", val, val, hint)
 }
 
 type location struct {
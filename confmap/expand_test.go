@@ -459,3 +459,79 @@ func TestResolverExpandStringValueInvalidReturnValue(t *testing.T) {
 	_, err = resolver.Resolve(context.Background())
 	assert.EqualError(t, err, `expanding ${test:PORT}, expected convertable to string value type, got ['ӛ']([]interface {})`)
 }
+
+func TestResolverExpandTypeHints(t *testing.T) {
+	envs := map[string]any{
+		"PORT":    "4317",
+		"RATIO":   "0.5",
+		"ENABLED": "true",
+		"HEADERS": `{"key": "value"}`,
+		"INT":     1234,
+		"PIPE":    "a|b",
+	}
+	envProvider := newFakeProvider("env", func(_ context.Context, uri string, _ WatcherFunc) (*Retrieved, error) {
+		return NewRetrieved(envs[uri[4:]])
+	})
+
+	tests := []struct {
+		name   string
+		input  string
+		output any
+	}{
+		{name: "int", input: "${env:PORT|int}", output: 4317},
+		{name: "int_already_typed", input: "${env:INT|int}", output: 1234},
+		{name: "float", input: "${env:RATIO|float}", output: 0.5},
+		{name: "float_from_int", input: "${env:INT|float}", output: float64(1234)},
+		{name: "bool", input: "${env:ENABLED|bool}", output: true},
+		{name: "json", input: "${env:HEADERS|json}", output: map[string]any{"key": "value"}},
+		{name: "embedded", input: "localhost:${env:PORT|int}", output: "localhost:4317"},
+		{name: "not_a_hint", input: "${test:a|b}", output: "a|b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newFakeProvider("input", func(context.Context, string, WatcherFunc) (*Retrieved, error) {
+				return NewRetrieved(map[string]any{"test": tt.input})
+			})
+			testProvider := newFakeProvider("test", func(_ context.Context, uri string, _ WatcherFunc) (*Retrieved, error) {
+				return NewRetrieved(uri[5:])
+			})
+
+			resolver, err := NewResolver(ResolverSettings{URIs: []string{"input:"}, Providers: makeMapProvidersMap(provider, envProvider, testProvider), Converters: nil})
+			require.NoError(t, err)
+
+			cfgMap, err := resolver.Resolve(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, map[string]any{"test": tt.output}, cfgMap.ToStringMap())
+		})
+	}
+}
+
+func TestResolverExpandTypeHintsInvalid(t *testing.T) {
+	envProvider := newFakeProvider("env", func(_ context.Context, uri string, _ WatcherFunc) (*Retrieved, error) {
+		return NewRetrieved(map[string]any{"PORT": "http", "LIST": []any{1}, "HEADERS": "{"}[uri[4:]])
+	})
+
+	tests := []struct {
+		input string
+		err   string
+	}{
+		{input: "${env:PORT|int}", err: `expanding ${env:PORT|int}: cannot cast "http" to int`},
+		{input: "${env:PORT|float}", err: `expanding ${env:PORT|float}: cannot cast "http" to float`},
+		{input: "${env:PORT|bool}", err: `expanding ${env:PORT|bool}: cannot cast "http" to bool`},
+		{input: "${env:LIST|int}", err: `expanding ${env:LIST|int}: cannot cast [1]([]interface {}) to int`},
+		{input: "${env:HEADERS|json}", err: `expanding ${env:HEADERS|json}: cannot cast "{" to json: unexpected end of JSON input`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			provider := newFakeProvider("input", func(context.Context, string, WatcherFunc) (*Retrieved, error) {
+				return NewRetrieved(map[string]any{"test": tt.input})
+			})
+
+			resolver, err := NewResolver(ResolverSettings{URIs: []string{"input:"}, Providers: makeMapProvidersMap(provider, envProvider), Converters: nil})
+			require.NoError(t, err)
+
+			_, err = resolver.Resolve(context.Background())
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
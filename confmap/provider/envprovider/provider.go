@@ -21,6 +21,11 @@ type provider struct{}
 //
 // This Provider supports "env" scheme, and can be called with a selector:
 // `env:NAME_OF_ENVIRONMENT_VARIABLE`
+//
+// Similar to the shell parameter expansion, the name can be followed by a default value used when
+// the environment variable is unset or empty, `env:NAME_OF_ENVIRONMENT_VARIABLE:-default`, or by an
+// error message making the environment variable required, `env:NAME_OF_ENVIRONMENT_VARIABLE:?message`.
+// The default value is parsed as YAML, like the value of the environment variable.
 func New() confmap.Provider {
 	return &provider{}
 }
@@ -30,7 +35,33 @@ func (emp *provider) Retrieve(_ context.Context, uri string, _ confmap.WatcherFu
 		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, schemeName)
 	}
 
-	return internal.NewRetrievedFromYAML([]byte(os.Getenv(uri[len(schemeName)+1:])))
+	val, err := lookupEnv(uri[len(schemeName)+1:])
+	if err != nil {
+		return nil, err
+	}
+	return internal.NewRetrievedFromYAML([]byte(val))
+}
+
+// lookupEnv returns the value of the environment variable named by the selector, applying the
+// ":-default" or ":?message" operator following the name, if any, when the variable is unset or empty.
+func lookupEnv(selector string) (string, error) {
+	name, op, arg := selector, "", ""
+	if idx := strings.Index(selector, ":"); idx >= 0 && idx+1 < len(selector) {
+		switch selector[idx+1] {
+		case '-', '?':
+			name, op, arg = selector[:idx], selector[idx:idx+2], selector[idx+2:]
+		}
+	}
+	if val := os.Getenv(name); val != "" || op == "" {
+		return val, nil
+	}
+	if op == ":-" {
+		return arg, nil
+	}
+	if arg == "" {
+		arg = "not set or empty"
+	}
+	return "", fmt.Errorf("environment variable %q is required: %s", name, arg)
 }
 
 func (*provider) Scheme() string {
@@ -68,3 +68,59 @@ func TestEnv(t *testing.T) {
 
 	assert.NoError(t, env.Shutdown(context.Background()))
 }
+
+func TestEnvDefault(t *testing.T) {
+	const envName = "default-value"
+	env := New()
+
+	ret, err := env.Retrieve(context.Background(), envSchemePrefix+envName+":-localhost:4317", nil)
+	require.NoError(t, err)
+	raw, err := ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, "localhost:4317", raw)
+
+	// The default value is parsed as YAML.
+	ret, err = env.Retrieve(context.Background(), envSchemePrefix+envName+":-4317", nil)
+	require.NoError(t, err)
+	raw, err = ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, 4317, raw)
+
+	// An empty variable is replaced by the default value as well.
+	t.Setenv(envName, "")
+	ret, err = env.Retrieve(context.Background(), envSchemePrefix+envName+":-true", nil)
+	require.NoError(t, err)
+	raw, err = ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, true, raw)
+
+	t.Setenv(envName, "4318")
+	ret, err = env.Retrieve(context.Background(), envSchemePrefix+envName+":-4317", nil)
+	require.NoError(t, err)
+	raw, err = ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, 4318, raw)
+
+	assert.NoError(t, env.Shutdown(context.Background()))
+}
+
+func TestEnvRequired(t *testing.T) {
+	const envName = "required-value"
+	env := New()
+
+	_, err := env.Retrieve(context.Background(), envSchemePrefix+envName+":?the endpoint must be set", nil)
+	assert.EqualError(t, err, `environment variable "required-value" is required: the endpoint must be set`)
+
+	t.Setenv(envName, "")
+	_, err = env.Retrieve(context.Background(), envSchemePrefix+envName+":?", nil)
+	assert.EqualError(t, err, `environment variable "required-value" is required: not set or empty`)
+
+	t.Setenv(envName, "localhost:4317")
+	ret, err := env.Retrieve(context.Background(), envSchemePrefix+envName+":?the endpoint must be set", nil)
+	require.NoError(t, err)
+	raw, err := ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, "localhost:4317", raw)
+
+	assert.NoError(t, env.Shutdown(context.Background()))
+}
@@ -16,3 +16,12 @@ import (
 func New() confmap.Provider {
 	return configurablehttpprovider.New(configurablehttpprovider.HTTPScheme)
 }
+
+// Settings configures the timeout, the retries and the headers of the requests sent by the provider.
+type Settings = configurablehttpprovider.Settings
+
+// NewWithSettings returns a new confmap.Provider that reads the configuration from a http server, sending the
+// requests as configured by the Settings.
+func NewWithSettings(set Settings) confmap.Provider {
+	return configurablehttpprovider.NewWithSettings(configurablehttpprovider.HTTPScheme, set)
+}
@@ -16,3 +16,9 @@ func TestSupportedScheme(t *testing.T) {
 	assert.Equal(t, "http", fp.Scheme())
 	require.NoError(t, fp.Shutdown(context.Background()))
 }
+
+func TestSupportedSchemeWithSettings(t *testing.T) {
+	fp := NewWithSettings(Settings{MaxRetries: 3})
+	assert.Equal(t, "http", fp.Scheme())
+	require.NoError(t, fp.Shutdown(context.Background()))
+}
@@ -17,3 +17,13 @@ import (
 func New() confmap.Provider {
 	return configurablehttpprovider.New(configurablehttpprovider.HTTPSScheme)
 }
+
+// Settings configures the timeout, the retries, the headers and the TLS settings of the requests
+// sent by the provider.
+type Settings = configurablehttpprovider.Settings
+
+// NewWithSettings returns a new confmap.Provider that reads the configuration from a https server, sending the
+// requests as configured by the Settings.
+func NewWithSettings(set Settings) confmap.Provider {
+	return configurablehttpprovider.NewWithSettings(configurablehttpprovider.HTTPSScheme, set)
+}
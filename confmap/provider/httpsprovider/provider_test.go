@@ -13,3 +13,8 @@ func TestSupportedScheme(t *testing.T) {
 	fp := New()
 	assert.Equal(t, "https", fp.Scheme())
 }
+
+func TestSupportedSchemeWithSettings(t *testing.T) {
+	fp := NewWithSettings(Settings{MaxRetries: 3})
+	assert.Equal(t, "https", fp.Scheme())
+}
@@ -7,12 +7,14 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/provider/internal"
@@ -25,10 +27,70 @@ const (
 	HTTPSScheme SchemeType = "https"
 )
 
+const (
+	defaultInitialBackoff = time.Second
+	defaultMaxBackoff     = 30 * time.Second
+)
+
+// Settings configures the requests sent by the provider to the http server.
+type Settings struct {
+	// Timeout is the time limit of each request, including reading the response body. No timeout if zero.
+	Timeout time.Duration
+
+	// Headers are added to each request, e.g. an "Authorization" header.
+	Headers map[string]string
+
+	// BearerTokenFile is the path of a file with a bearer token sent in the "Authorization" header of each
+	// request. The file is read before each request, so that the token can be rotated.
+	BearerTokenFile string
+
+	// PollInterval is the time between two requests checking whether the configuration changed, the
+	// service being notified to reload the configuration once it did. No polling if zero.
+	PollInterval time.Duration
+
+	// MaxRetries is the number of times a request failing with a network error or with a
+	// 429 or 5xx status code is retried. No retry if zero.
+	MaxRetries int
+
+	// InitialBackoff is the wait before the first retry, doubled after each retry. Defaults to 1s if zero.
+	InitialBackoff time.Duration
+
+	// MaxBackoff is the upper bound of the wait between two retries. Defaults to 30s if zero.
+	MaxBackoff time.Duration
+
+	// CAFile is the path of a PEM file with CA certificates to trust in addition to the system ones.
+	// Only supported by the https scheme.
+	CAFile string
+
+	// CertFile and KeyFile are the paths of the PEM client certificate and key for mTLS.
+	// Only supported by the https scheme.
+	CertFile string
+	KeyFile  string
+
+	// InsecureSkipVerify disables the verification of the server certificate.
+	// Only supported by the https scheme.
+	InsecureSkipVerify bool
+}
+
+func (set Settings) validate(scheme SchemeType) error {
+	if set.Timeout < 0 || set.InitialBackoff < 0 || set.MaxBackoff < 0 || set.PollInterval < 0 {
+		return errors.New("timeout, backoff and poll interval durations must not be negative")
+	}
+	if set.MaxRetries < 0 {
+		return errors.New("max retries must not be negative")
+	}
+	if (set.CertFile == "") != (set.KeyFile == "") {
+		return errors.New("client certificate and key files must be set together")
+	}
+	if scheme == HTTPScheme && (set.CAFile != "" || set.CertFile != "" || set.InsecureSkipVerify) {
+		return fmt.Errorf("tls settings are not supported by the %q scheme", scheme)
+	}
+	return nil
+}
+
 type provider struct {
-	scheme             SchemeType
-	caCertPath         string // Used for tests
-	insecureSkipVerify bool   // Used for tests
+	scheme SchemeType
+	set    Settings
 }
 
 // New returns a new provider that reads the configuration from http server using the configured transport mechanism
@@ -39,14 +101,22 @@ type provider struct {
 // One example for https-uri: https://localhost:3333/getConfig
 // This is used by the http and https external implementations.
 func New(scheme SchemeType) confmap.Provider {
-	return &provider{scheme: scheme}
+	return NewWithSettings(scheme, Settings{})
+}
+
+// NewWithSettings returns a new provider like New, sending the requests as configured by the Settings.
+func NewWithSettings(scheme SchemeType, set Settings) confmap.Provider {
+	return &provider{scheme: scheme, set: set}
 }
 
 // Create the client based on the type of scheme that was selected.
 func (fmp *provider) createClient() (*http.Client, error) {
+	if err := fmp.set.validate(fmp.scheme); err != nil {
+		return nil, err
+	}
 	switch fmp.scheme {
 	case HTTPScheme:
-		return &http.Client{}, nil
+		return &http.Client{Timeout: fmp.set.Timeout}, nil
 	case HTTPSScheme:
 		pool, err := x509.SystemCertPool()
 
@@ -54,32 +124,40 @@ func (fmp *provider) createClient() (*http.Client, error) {
 			return nil, fmt.Errorf("unable to create a cert pool: %w", err)
 		}
 
-		if fmp.caCertPath != "" {
-			cert, err := os.ReadFile(filepath.Clean(fmp.caCertPath))
+		if fmp.set.CAFile != "" {
+			cert, err := os.ReadFile(filepath.Clean(fmp.set.CAFile))
 
 			if err != nil {
-				return nil, fmt.Errorf("unable to read CA from %q URI: %w", fmp.caCertPath, err)
+				return nil, fmt.Errorf("unable to read CA from %q URI: %w", fmp.set.CAFile, err)
 			}
 
 			if ok := pool.AppendCertsFromPEM(cert); !ok {
-				return nil, fmt.Errorf("unable to add CA from uri: %s into the cert pool", fmp.caCertPath)
+				return nil, fmt.Errorf("unable to add CA from uri: %s into the cert pool", fmp.set.CAFile)
+			}
+		}
+
+		tlsCfg := &tls.Config{
+			InsecureSkipVerify: fmp.set.InsecureSkipVerify,
+			RootCAs:            pool,
+		}
+		if fmp.set.CertFile != "" {
+			cert, err := tls.LoadX509KeyPair(fmp.set.CertFile, fmp.set.KeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("unable to load the client certificate: %w", err)
 			}
+			tlsCfg.Certificates = []tls.Certificate{cert}
 		}
 
 		return &http.Client{
-			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{
-					InsecureSkipVerify: fmp.insecureSkipVerify,
-					RootCAs:            pool,
-				},
-			},
+			Timeout:   fmp.set.Timeout,
+			Transport: &http.Transport{TLSClientConfig: tlsCfg},
 		}, nil
 	default:
 		return nil, fmt.Errorf("invalid scheme type: %s", fmp.scheme)
 	}
 }
 
-func (fmp *provider) Retrieve(_ context.Context, uri string, _ confmap.WatcherFunc) (*confmap.Retrieved, error) {
+func (fmp *provider) Retrieve(ctx context.Context, uri string, watcher confmap.WatcherFunc) (*confmap.Retrieved, error) {
 
 	if !strings.HasPrefix(uri, string(fmp.scheme)+":") {
 		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, string(fmp.scheme))
@@ -91,25 +169,72 @@ func (fmp *provider) Retrieve(_ context.Context, uri string, _ confmap.WatcherFu
 		return nil, fmt.Errorf("unable to configure http transport layer: %w", err)
 	}
 
+	backoff := fmp.set.InitialBackoff
+	if backoff == 0 {
+		backoff = defaultInitialBackoff
+	}
+	maxBackoff := fmp.set.MaxBackoff
+	if maxBackoff == 0 {
+		maxBackoff = defaultMaxBackoff
+	}
+	for attempt := 0; ; attempt++ {
+		body, retryable, err := fmp.get(ctx, client, uri)
+		if err == nil {
+			return internal.NewPolledRetrievedFromYAML(body, fmp.set.PollInterval, watcher, func(ctx context.Context) ([]byte, error) {
+				body, _, err := fmp.get(ctx, client, uri)
+				return body, err
+			})
+		}
+		if !retryable || attempt >= fmp.set.MaxRetries {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %w", err, ctx.Err())
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// get sends a HTTP GET request and returns the response body, or the error and whether the request can be retried.
+func (fmp *provider) get(ctx context.Context, client *http.Client, uri string) ([]byte, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to create the HTTP GET request for uri %q: %w", uri, err)
+	}
+	for k, v := range fmp.set.Headers {
+		req.Header.Set(k, v)
+	}
+	if fmp.set.BearerTokenFile != "" {
+		token, err := os.ReadFile(filepath.Clean(fmp.set.BearerTokenFile))
+		if err != nil {
+			return nil, false, fmt.Errorf("unable to read the bearer token from %q: %w", fmp.set.BearerTokenFile, err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
 	// send a HTTP GET request
-	resp, err := client.Get(uri)
+	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("unable to download the file via HTTP GET for uri %q: %w ", uri, err)
+		return nil, ctx.Err() == nil, fmt.Errorf("unable to download the file via HTTP GET for uri %q: %w ", uri, err)
 	}
 	defer resp.Body.Close()
 
 	// check the HTTP status code
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to load resource from uri %q. status code: %d", uri, resp.StatusCode)
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		return nil, retryable, fmt.Errorf("failed to load resource from uri %q. status code: %d", uri, resp.StatusCode)
 	}
 
 	// read the response body
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("fail to read the response body from uri %q: %w", uri, err)
+		return nil, ctx.Err() == nil, fmt.Errorf("fail to read the response body from uri %q: %w", uri, err)
 	}
-
-	return internal.NewRetrievedFromYAML(body)
+	return body, false, nil
 }
 
 func (fmp *provider) Scheme() string {
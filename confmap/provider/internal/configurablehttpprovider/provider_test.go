@@ -17,12 +17,15 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"path/filepath"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 )
 
@@ -215,9 +218,9 @@ func TestFunctionalityDownloadFileHTTPS(t *testing.T) {
 			tsURL, err := url.Parse(ts.URL)
 			require.NoError(t, err)
 			if tt.useCertificate {
-				fp.caCertPath = tt.certPath
+				fp.set.CAFile = tt.certPath
 			}
-			fp.insecureSkipVerify = tt.skipHostnameValidation
+			fp.set.InsecureSkipVerify = tt.skipHostnameValidation
 			_, err = fp.Retrieve(context.Background(), fmt.Sprintf("https://%s:%s", tt.hostName, tsURL.Port()), nil)
 			if tt.shouldError {
 				assert.Error(t, err)
@@ -302,3 +305,189 @@ func TestInvalidTransport(t *testing.T) {
 	_, err := fp.Retrieve(context.Background(), "foo://..", nil)
 	assert.Error(t, err)
 }
+
+func TestRetrieveWithHeaders(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		answerGet(w, r)
+	}))
+	defer ts.Close()
+
+	_, err := New(HTTPScheme).Retrieve(context.Background(), ts.URL, nil)
+	assert.Error(t, err)
+
+	fp := NewWithSettings(HTTPScheme, Settings{Headers: map[string]string{"Authorization": "Bearer token"}})
+	_, err = fp.Retrieve(context.Background(), ts.URL, nil)
+	assert.NoError(t, err)
+}
+
+func TestRetrieveWithBearerTokenFile(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer rotated" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		answerGet(w, r)
+	}))
+	defer ts.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("token\n"), 0600))
+	fp := NewWithSettings(HTTPScheme, Settings{BearerTokenFile: tokenFile})
+	_, err := fp.Retrieve(context.Background(), ts.URL, nil)
+	assert.Error(t, err)
+
+	require.NoError(t, os.WriteFile(tokenFile, []byte("rotated\n"), 0600))
+	_, err = fp.Retrieve(context.Background(), ts.URL, nil)
+	assert.NoError(t, err)
+
+	fp = NewWithSettings(HTTPScheme, Settings{BearerTokenFile: filepath.Join(t.TempDir(), "nonexistent")})
+	_, err = fp.Retrieve(context.Background(), ts.URL, nil)
+	assert.Error(t, err)
+}
+
+func TestRetrieveWithPolling(t *testing.T) {
+	var content atomic.Value
+	content.Store("key: value")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(content.Load().(string)))
+	}))
+	defer ts.Close()
+
+	events := make(chan *confmap.ChangeEvent, 1)
+	fp := NewWithSettings(HTTPScheme, Settings{PollInterval: time.Millisecond})
+	ret, err := fp.Retrieve(context.Background(), ts.URL, func(event *confmap.ChangeEvent) {
+		events <- event
+	})
+	require.NoError(t, err)
+	retMap, err := ret.AsConf()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"key": "value"}, retMap.ToStringMap())
+
+	content.Store("key: other")
+	select {
+	case event := <-events:
+		assert.NoError(t, event.Error)
+	case <-time.After(10 * time.Second):
+		t.Fatal("the watcher was not called")
+	}
+	assert.NoError(t, ret.Close(context.Background()))
+}
+
+func TestRetrieveWithRetries(t *testing.T) {
+	var attempts atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch attempts.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			answerGet(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	fp := NewWithSettings(HTTPScheme, Settings{MaxRetries: 1, InitialBackoff: time.Millisecond})
+	_, err := fp.Retrieve(context.Background(), ts.URL, nil)
+	assert.Error(t, err)
+	assert.EqualValues(t, 2, attempts.Load())
+
+	attempts.Store(0)
+	fp = NewWithSettings(HTTPScheme, Settings{MaxRetries: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+	_, err = fp.Retrieve(context.Background(), ts.URL, nil)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, attempts.Load())
+}
+
+func TestRetrieveNoRetryOnClientError(t *testing.T) {
+	var attempts atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	fp := NewWithSettings(HTTPScheme, Settings{MaxRetries: 3, InitialBackoff: time.Millisecond})
+	_, err := fp.Retrieve(context.Background(), ts.URL, nil)
+	assert.Error(t, err)
+	assert.EqualValues(t, 1, attempts.Load())
+}
+
+func TestRetrieveCanceledDuringBackoff(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	fp := NewWithSettings(HTTPScheme, Settings{MaxRetries: 10, InitialBackoff: time.Hour})
+	_, err := fp.Retrieve(ctx, ts.URL, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRetrieveWithTimeout(t *testing.T) {
+	done := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer ts.Close()
+	defer close(done)
+
+	fp := NewWithSettings(HTTPScheme, Settings{Timeout: 10 * time.Millisecond})
+	_, err := fp.Retrieve(context.Background(), ts.URL, nil)
+	assert.Error(t, err)
+}
+
+func TestInvalidSettings(t *testing.T) {
+	tests := []struct {
+		name   string
+		scheme SchemeType
+		set    Settings
+	}{
+		{name: "negative timeout", scheme: HTTPSScheme, set: Settings{Timeout: -time.Second}},
+		{name: "negative retries", scheme: HTTPSScheme, set: Settings{MaxRetries: -1}},
+		{name: "negative poll interval", scheme: HTTPSScheme, set: Settings{PollInterval: -time.Second}},
+		{name: "cert without key", scheme: HTTPSScheme, set: Settings{CertFile: "cert.pem"}},
+		{name: "tls with http", scheme: HTTPScheme, set: Settings{CAFile: "ca.pem"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fp := NewWithSettings(tt.scheme, tt.set)
+			_, err := fp.Retrieve(context.Background(), string(tt.scheme)+"://localhost", nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRetrieveWithClientCertificate(t *testing.T) {
+	certPath, keyPath, err := generateCertificate("localhost")
+	require.NoError(t, err)
+	defer os.Remove(certPath)
+	defer os.Remove(keyPath)
+
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	require.NoError(t, err)
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(answerGet))
+	ts.TLS = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAnyClientCert,
+	}
+	ts.StartTLS()
+	defer ts.Close()
+
+	tsURL, err := url.Parse(ts.URL)
+	require.NoError(t, err)
+	uri := fmt.Sprintf("https://localhost:%s", tsURL.Port())
+
+	_, err = NewWithSettings(HTTPSScheme, Settings{CAFile: certPath}).Retrieve(context.Background(), uri, nil)
+	assert.Error(t, err)
+
+	fp := NewWithSettings(HTTPSScheme, Settings{CAFile: certPath, CertFile: certPath, KeyFile: keyPath})
+	_, err = fp.Retrieve(context.Background(), uri, nil)
+	assert.NoError(t, err)
+}
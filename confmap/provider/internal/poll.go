@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/confmap/provider/internal"
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// NewPolledRetrievedFromYAML returns a new Retrieved instance like NewRetrievedFromYAML, and calls the watcher once
+// the bytes returned by get, called every interval, differ from the yaml bytes. The polling stops when the
+// Retrieved is closed. The errors returned by get are ignored, the bytes being retrieved again at the next interval.
+// The watcher is not called if the interval is not positive.
+func NewPolledRetrievedFromYAML(yamlBytes []byte, interval time.Duration, watcher confmap.WatcherFunc, get func(context.Context) ([]byte, error)) (*confmap.Retrieved, error) {
+	if interval <= 0 || watcher == nil {
+		return NewRetrievedFromYAML(yamlBytes)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	ret, err := NewRetrievedFromYAML(yamlBytes, confmap.WithRetrievedClose(func(context.Context) error {
+		cancel()
+		<-done
+		return nil
+	}))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	go func() {
+		changed := poll(ctx, yamlBytes, interval, get)
+		// The watcher is called once the polling is done, as it may close the Retrieved.
+		close(done)
+		if changed {
+			watcher(&confmap.ChangeEvent{})
+		}
+	}()
+	return ret, nil
+}
+
+// poll returns true once the bytes returned by get differ from the given ones, or false once the context is done.
+func poll(ctx context.Context, yamlBytes []byte, interval time.Duration, get func(context.Context) ([]byte, error)) bool {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+		body, err := get(ctx)
+		if err == nil && !bytes.Equal(body, yamlBytes) {
+			return true
+		}
+	}
+}
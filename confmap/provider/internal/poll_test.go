@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+func TestNewPolledRetrievedFromYAML(t *testing.T) {
+	var calls atomic.Int32
+	get := func(context.Context) ([]byte, error) {
+		switch calls.Add(1) {
+		case 1:
+			return []byte("key: value"), nil
+		case 2:
+			return nil, errors.New("unavailable")
+		default:
+			return []byte("key: other"), nil
+		}
+	}
+	events := make(chan *confmap.ChangeEvent, 1)
+	ret, err := NewPolledRetrievedFromYAML([]byte("key: value"), time.Millisecond, func(event *confmap.ChangeEvent) {
+		events <- event
+	}, get)
+	require.NoError(t, err)
+	retMap, err := ret.AsConf()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"key": "value"}, retMap.ToStringMap())
+
+	// The unchanged bytes and the errors do not call the watcher.
+	select {
+	case event := <-events:
+		assert.NoError(t, event.Error)
+	case <-time.After(10 * time.Second):
+		t.Fatal("the watcher was not called")
+	}
+	assert.EqualValues(t, 3, calls.Load())
+	assert.NoError(t, ret.Close(context.Background()))
+}
+
+func TestNewPolledRetrievedFromYAMLClose(t *testing.T) {
+	var calls atomic.Int32
+	ret, err := NewPolledRetrievedFromYAML([]byte("key: value"), time.Millisecond, func(*confmap.ChangeEvent) {
+		t.Error("unexpected change event")
+	}, func(context.Context) ([]byte, error) {
+		calls.Add(1)
+		return []byte("key: value"), nil
+	})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool { return calls.Load() > 1 }, 10*time.Second, time.Millisecond)
+	assert.NoError(t, ret.Close(context.Background()))
+
+	stopped := calls.Load()
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, stopped, calls.Load())
+}
+
+func TestNewPolledRetrievedFromYAMLDisabled(t *testing.T) {
+	ret, err := NewPolledRetrievedFromYAML([]byte("key: value"), 0, func(*confmap.ChangeEvent) {
+		t.Error("unexpected change event")
+	}, func(context.Context) ([]byte, error) {
+		t.Error("unexpected poll")
+		return nil, nil
+	})
+	require.NoError(t, err)
+	assert.NoError(t, ret.Close(context.Background()))
+
+	_, err = NewPolledRetrievedFromYAML([]byte("[invalid:,"), time.Millisecond, func(*confmap.ChangeEvent) {}, nil)
+	assert.Error(t, err)
+}
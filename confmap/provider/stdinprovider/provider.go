@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package stdinprovider // import "go.opentelemetry.io/collector/confmap/provider/stdinprovider"
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/provider/internal"
+)
+
+const schemeName = "stdin"
+
+type provider struct {
+	reader io.Reader
+
+	once    sync.Once
+	content []byte
+	err     error
+}
+
+// New returns a new confmap.Provider that reads the configuration from the standard input.
+//
+// This Provider supports "stdin" scheme, and can be called with a "uri" that follows:
+//
+//	stdin-uri = "stdin:"
+//
+// The standard input is read until EOF the first time the configuration is retrieved, and the
+// same content is returned by the following calls, e.g. when the configuration is reloaded.
+//
+// Examples:
+// `cat config.yaml | otelcorecol --config=stdin:`
+func New() confmap.Provider {
+	return newWithReader(os.Stdin)
+}
+
+func newWithReader(reader io.Reader) *provider {
+	return &provider{reader: reader}
+}
+
+func (sp *provider) Retrieve(_ context.Context, uri string, _ confmap.WatcherFunc) (*confmap.Retrieved, error) {
+	if uri != schemeName+":" {
+		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, schemeName)
+	}
+
+	sp.once.Do(func() {
+		sp.content, sp.err = io.ReadAll(sp.reader)
+	})
+	if sp.err != nil {
+		return nil, fmt.Errorf("unable to read the standard input: %w", sp.err)
+	}
+
+	return internal.NewRetrievedFromYAML(sp.content)
+}
+
+func (*provider) Scheme() string {
+	return schemeName
+}
+
+func (*provider) Shutdown(context.Context) error {
+	return nil
+}
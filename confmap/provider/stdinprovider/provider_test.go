@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package stdinprovider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestValidateProviderScheme(t *testing.T) {
+	assert.NoError(t, confmaptest.ValidateProviderScheme(New()))
+}
+
+func TestUnsupportedScheme(t *testing.T) {
+	sp := newWithReader(strings.NewReader("processors::batch::timeout: 2s"))
+	_, err := sp.Retrieve(context.Background(), "file:config.yaml", nil)
+	assert.Error(t, err)
+	_, err = sp.Retrieve(context.Background(), "stdin:config.yaml", nil)
+	assert.Error(t, err)
+	assert.NoError(t, sp.Shutdown(context.Background()))
+}
+
+func TestInvalidYAML(t *testing.T) {
+	sp := newWithReader(strings.NewReader("[invalid,"))
+	_, err := sp.Retrieve(context.Background(), "stdin:", nil)
+	assert.Error(t, err)
+	assert.NoError(t, sp.Shutdown(context.Background()))
+}
+
+func TestReadError(t *testing.T) {
+	sp := newWithReader(iotest.ErrReader(errors.New("read failed")))
+	_, err := sp.Retrieve(context.Background(), "stdin:", nil)
+	assert.ErrorContains(t, err, "read failed")
+	assert.NoError(t, sp.Shutdown(context.Background()))
+}
+
+func TestEmpty(t *testing.T) {
+	sp := newWithReader(strings.NewReader(""))
+	ret, err := sp.Retrieve(context.Background(), "stdin:", nil)
+	require.NoError(t, err)
+	retMap, err := ret.AsConf()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{}, retMap.ToStringMap())
+	assert.NoError(t, sp.Shutdown(context.Background()))
+}
+
+func TestRetrieveTwice(t *testing.T) {
+	sp := newWithReader(strings.NewReader("processors:\n  batch:\n    timeout: 2s\n"))
+	expected := map[string]any{
+		"processors": map[string]any{
+			"batch": map[string]any{
+				"timeout": "2s",
+			},
+		},
+	}
+	for i := 0; i < 2; i++ {
+		ret, err := sp.Retrieve(context.Background(), "stdin:", nil)
+		require.NoError(t, err)
+		retMap, err := ret.AsConf()
+		require.NoError(t, err)
+		assert.Equal(t, expected, retMap.ToStringMap())
+	}
+	assert.NoError(t, sp.Shutdown(context.Background()))
+}
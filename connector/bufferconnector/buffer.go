@@ -0,0 +1,314 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bufferconnector // import "go.opentelemetry.io/collector/connector/bufferconnector"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	readIndexKey  = "ri"
+	writeIndexKey = "wi"
+)
+
+var (
+	errBufferFull         = errors.New("buffer is full")
+	errNoStorageClient    = errors.New("no storage client extension found")
+	errWrongExtensionType = errors.New("requested extension is not a storage extension")
+)
+
+// replayFunc unmarshals a spooled batch and sends it to the next consumer,
+// returning the number of items of the batch.
+type replayFunc func(ctx context.Context, data []byte) (int, error)
+
+// buffer spools the batches received from the exporter pipeline to the storage extension,
+// and replays them in order into the receiver pipeline.
+//
+// Write index is the index of the next batch to be spooled, read index is the index of
+// the next batch to be replayed. A batch is deleted from the storage only once it is replayed,
+// the batches left when the collector stops are replayed after a restart.
+type buffer struct {
+	logger *zap.Logger
+	id     component.ID
+	signal component.DataType
+	cfg    *Config
+	replay replayFunc
+
+	client     storage.Client
+	mu         sync.Mutex
+	readIndex  uint64
+	writeIndex uint64
+
+	// spooled is notified when a batch is spooled.
+	spooled    chan struct{}
+	cancelFunc context.CancelFunc
+	stopWG     sync.WaitGroup
+}
+
+func newBuffer(set connector.CreateSettings, cfg *Config, signal component.DataType, replay replayFunc) *buffer {
+	return &buffer{
+		logger:  set.Logger,
+		id:      set.ID,
+		signal:  signal,
+		cfg:     cfg,
+		replay:  replay,
+		spooled: make(chan struct{}, 1),
+	}
+}
+
+func (b *buffer) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// Start gets the storage client, and starts replaying the batches left in the storage.
+func (b *buffer) Start(ctx context.Context, host component.Host) error {
+	client, err := toStorageClient(ctx, *b.cfg.StorageID, host, b.id, b.signal)
+	if err != nil {
+		return err
+	}
+	b.client = client
+
+	readIndex, writeIndex, err := b.loadIndexes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read the buffer indexes: %w", err)
+	}
+	b.readIndex = readIndex
+	b.writeIndex = writeIndex
+	if writeIndex > readIndex {
+		b.logger.Info("Replaying spooled batches", zap.Uint64("numberOfBatches", writeIndex-readIndex))
+	}
+
+	var replayCtx context.Context
+	replayCtx, b.cancelFunc = context.WithCancel(context.Background())
+	b.stopWG.Add(1)
+	go b.replayLoop(replayCtx)
+	return nil
+}
+
+// Shutdown stops replaying, the batches not replayed yet are kept in the storage.
+func (b *buffer) Shutdown(ctx context.Context) error {
+	if b.cancelFunc != nil {
+		b.cancelFunc()
+	}
+	b.stopWG.Wait()
+	if b.client == nil {
+		return nil
+	}
+	return b.client.Close(ctx)
+}
+
+// spool writes the batch to the storage, to be replayed.
+func (b *buffer) spool(ctx context.Context, data []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.cfg.QueueSize > 0 && b.writeIndex-b.readIndex >= uint64(b.cfg.QueueSize) {
+		return errBufferFull
+	}
+	if err := b.client.Batch(ctx,
+		storage.SetOperation(itemKey(b.writeIndex), data),
+		storage.SetOperation(writeIndexKey, indexToBytes(b.writeIndex+1)),
+	); err != nil {
+		return err
+	}
+	b.writeIndex++
+
+	select {
+	case b.spooled <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (b *buffer) replayLoop(ctx context.Context) {
+	defer b.stopWG.Done()
+	for {
+		index, data, ok, err := b.next(ctx)
+		if err != nil {
+			b.logger.Error("Failed to read a spooled batch, retrying", zap.Error(err))
+			if !wait(ctx, b.cfg.RetryInterval) {
+				return
+			}
+			continue
+		}
+		if !ok {
+			select {
+			case <-b.spooled:
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		var items int
+		if data != nil {
+			items, err = b.replay(ctx, data)
+		}
+		if err != nil && !consumererror.IsPermanent(err) {
+			if ctx.Err() != nil {
+				return
+			}
+			b.logger.Warn("Failed to replay a spooled batch, retrying", zap.Error(err))
+			if !wait(ctx, b.cfg.RetryInterval) {
+				return
+			}
+			continue
+		}
+		if err != nil {
+			b.logger.Error("Dropping a spooled batch", zap.Error(err))
+		}
+
+		if err = b.advance(ctx, index); err != nil {
+			b.logger.Error("Failed to delete a replayed batch", zap.Error(err))
+		}
+		if b.cfg.MaxItemsPerSecond > 0 && items > 0 {
+			if !wait(ctx, time.Duration(items)*time.Second/time.Duration(b.cfg.MaxItemsPerSecond)) {
+				return
+			}
+		}
+	}
+}
+
+// next returns the next batch to be replayed, if any. The data is nil if the batch is missing in the storage.
+func (b *buffer) next(ctx context.Context) (uint64, []byte, bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.readIndex >= b.writeIndex {
+		return 0, nil, false, nil
+	}
+	data, err := b.client.Get(ctx, itemKey(b.readIndex))
+	if err != nil {
+		return 0, nil, false, err
+	}
+	return b.readIndex, data, true, nil
+}
+
+// advance deletes the replayed batch from the storage, and moves to the next one.
+func (b *buffer) advance(ctx context.Context, index uint64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.readIndex = index + 1
+	return b.client.Batch(ctx,
+		storage.DeleteOperation(itemKey(index)),
+		storage.SetOperation(readIndexKey, indexToBytes(b.readIndex)),
+	)
+}
+
+func (b *buffer) loadIndexes(ctx context.Context) (uint64, uint64, error) {
+	readOp := storage.GetOperation(readIndexKey)
+	writeOp := storage.GetOperation(writeIndexKey)
+	if err := b.client.Batch(ctx, readOp, writeOp); err != nil {
+		return 0, 0, err
+	}
+	readIndex, err := bytesToIndex(readOp.Value)
+	if err != nil {
+		return 0, 0, err
+	}
+	writeIndex, err := bytesToIndex(writeOp.Value)
+	if err != nil {
+		return 0, 0, err
+	}
+	if readIndex > writeIndex {
+		return 0, 0, fmt.Errorf("read index %d is after write index %d", readIndex, writeIndex)
+	}
+	return readIndex, writeIndex, nil
+}
+
+type tracesBuffer struct {
+	*buffer
+	marshaler ptrace.ProtoMarshaler
+}
+
+func (c *tracesBuffer) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	data, err := c.marshaler.MarshalTraces(td)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return c.spool(ctx, data)
+}
+
+type metricsBuffer struct {
+	*buffer
+	marshaler pmetric.ProtoMarshaler
+}
+
+func (c *metricsBuffer) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	data, err := c.marshaler.MarshalMetrics(md)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return c.spool(ctx, data)
+}
+
+type logsBuffer struct {
+	*buffer
+	marshaler plog.ProtoMarshaler
+}
+
+func (c *logsBuffer) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	data, err := c.marshaler.MarshalLogs(ld)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return c.spool(ctx, data)
+}
+
+func toStorageClient(ctx context.Context, storageID component.ID, host component.Host, ownerID component.ID, signal component.DataType) (storage.Client, error) {
+	ext, found := host.GetExtensions()[storageID]
+	if !found {
+		return nil, errNoStorageClient
+	}
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, errWrongExtensionType
+	}
+	return storageExt.GetClient(ctx, component.KindConnector, ownerID, string(signal))
+}
+
+// wait waits for d, returning false if ctx is done before.
+func wait(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func itemKey(index uint64) string {
+	return strconv.FormatUint(index, 10)
+}
+
+func indexToBytes(index uint64) []byte {
+	return binary.LittleEndian.AppendUint64(nil, index)
+}
+
+// bytesToIndex decodes an index, a missing index is 0.
+func bytesToIndex(b []byte) (uint64, error) {
+	if b == nil {
+		return 0, nil
+	}
+	if len(b) != 8 {
+		return 0, fmt.Errorf("invalid index of %d bytes", len(b))
+	}
+	return binary.LittleEndian.Uint64(b), nil
+}
@@ -0,0 +1,277 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bufferconnector
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var storageID = component.NewID("file_storage")
+
+type mockStorageExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	mu      sync.Mutex
+	clients map[string]*mockStorageClient
+}
+
+func newMockStorageExtension() *mockStorageExtension {
+	return &mockStorageExtension{clients: map[string]*mockStorageClient{}}
+}
+
+func (m *mockStorageExtension) GetClient(_ context.Context, kind component.Kind, id component.ID, name string) (storage.Client, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := kind.String() + "/" + id.String() + "/" + name
+	if _, ok := m.clients[key]; !ok {
+		m.clients[key] = &mockStorageClient{st: map[string][]byte{}}
+	}
+	return m.clients[key], nil
+}
+
+type mockStorageClient struct {
+	mu sync.Mutex
+	st map[string][]byte
+}
+
+func (m *mockStorageClient) Get(_ context.Context, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.st[key], nil
+}
+
+func (m *mockStorageClient) Set(_ context.Context, key string, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.st[key] = value
+	return nil
+}
+
+func (m *mockStorageClient) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.st, key)
+	return nil
+}
+
+func (m *mockStorageClient) Batch(_ context.Context, ops ...storage.Operation) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			op.Value = m.st[op.Key]
+		case storage.Set:
+			m.st[op.Key] = op.Value
+		case storage.Delete:
+			delete(m.st, op.Key)
+		}
+	}
+	return nil
+}
+
+func (m *mockStorageClient) Close(context.Context) error {
+	return nil
+}
+
+func (m *mockStorageClient) len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.st)
+}
+
+type mockHost struct {
+	component.Host
+	ext map[component.ID]component.Component
+}
+
+func (h *mockHost) GetExtensions() map[component.ID]component.Component {
+	return h.ext
+}
+
+func newMockHost(ext component.Component) component.Host {
+	return &mockHost{Host: componenttest.NewNopHost(), ext: map[component.ID]component.Component{storageID: ext}}
+}
+
+func newTestConfig() *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.StorageID = &storageID
+	cfg.RetryInterval = 10 * time.Millisecond
+	return cfg
+}
+
+func newTraces(spans int) ptrace.Traces {
+	td := ptrace.NewTraces()
+	ss := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+	for i := 0; i < spans; i++ {
+		ss.Spans().AppendEmpty().SetName("span")
+	}
+	return td
+}
+
+func TestBuffer(t *testing.T) {
+	f := NewFactory()
+	cfg := newTestConfig()
+	ctx := context.Background()
+	set := connectortest.NewNopCreateSettings()
+	host := newMockHost(newMockStorageExtension())
+
+	tracesSink := new(consumertest.TracesSink)
+	tracesToTraces, err := f.CreateTracesToTraces(ctx, set, cfg, tracesSink)
+	require.NoError(t, err)
+	assert.Equal(t, consumer.Capabilities{MutatesData: false}, tracesToTraces.Capabilities())
+
+	metricsSink := new(consumertest.MetricsSink)
+	metricsToMetrics, err := f.CreateMetricsToMetrics(ctx, set, cfg, metricsSink)
+	require.NoError(t, err)
+
+	logsSink := new(consumertest.LogsSink)
+	logsToLogs, err := f.CreateLogsToLogs(ctx, set, cfg, logsSink)
+	require.NoError(t, err)
+
+	require.NoError(t, tracesToTraces.Start(ctx, host))
+	require.NoError(t, metricsToMetrics.Start(ctx, host))
+	require.NoError(t, logsToLogs.Start(ctx, host))
+
+	require.NoError(t, tracesToTraces.ConsumeTraces(ctx, newTraces(2)))
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+	require.NoError(t, metricsToMetrics.ConsumeMetrics(ctx, md))
+	require.NoError(t, metricsToMetrics.ConsumeMetrics(ctx, md))
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	require.NoError(t, logsToLogs.ConsumeLogs(ctx, ld))
+	require.NoError(t, logsToLogs.ConsumeLogs(ctx, ld))
+	require.NoError(t, logsToLogs.ConsumeLogs(ctx, ld))
+
+	assert.Eventually(t, func() bool {
+		return tracesSink.SpanCount() == 2 && len(metricsSink.AllMetrics()) == 2 && len(logsSink.AllLogs()) == 3
+	}, time.Second, 5*time.Millisecond)
+
+	assert.NoError(t, tracesToTraces.Shutdown(ctx))
+	assert.NoError(t, metricsToMetrics.Shutdown(ctx))
+	assert.NoError(t, logsToLogs.Shutdown(ctx))
+	assert.Equal(t, md, metricsSink.AllMetrics()[0])
+	assert.Equal(t, ld, logsSink.AllLogs()[0])
+}
+
+func TestBufferReplayAfterRestart(t *testing.T) {
+	f := NewFactory()
+	cfg := newTestConfig()
+	ctx := context.Background()
+	set := connectortest.NewNopCreateSettings()
+	host := newMockHost(newMockStorageExtension())
+
+	// The batches are kept in the storage while the next consumer fails.
+	failing, err := f.CreateTracesToTraces(ctx, set, cfg, consumertest.NewErr(errors.New("unavailable")))
+	require.NoError(t, err)
+	require.NoError(t, failing.Start(ctx, host))
+	require.NoError(t, failing.ConsumeTraces(ctx, newTraces(1)))
+	require.NoError(t, failing.ConsumeTraces(ctx, newTraces(2)))
+	require.NoError(t, failing.Shutdown(ctx))
+
+	sink := new(consumertest.TracesSink)
+	tracesToTraces, err := f.CreateTracesToTraces(ctx, set, cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, tracesToTraces.Start(ctx, host))
+	assert.Eventually(t, func() bool {
+		return len(sink.AllTraces()) == 2
+	}, time.Second, 5*time.Millisecond)
+	require.NoError(t, tracesToTraces.Shutdown(ctx))
+
+	// The batches are replayed in order.
+	assert.Equal(t, 1, sink.AllTraces()[0].SpanCount())
+	assert.Equal(t, 2, sink.AllTraces()[1].SpanCount())
+}
+
+func TestBufferFull(t *testing.T) {
+	f := NewFactory()
+	cfg := newTestConfig()
+	cfg.QueueSize = 1
+	ctx := context.Background()
+
+	tracesToTraces, err := f.CreateTracesToTraces(ctx, connectortest.NewNopCreateSettings(), cfg, consumertest.NewErr(errors.New("unavailable")))
+	require.NoError(t, err)
+	require.NoError(t, tracesToTraces.Start(ctx, newMockHost(newMockStorageExtension())))
+	require.NoError(t, tracesToTraces.ConsumeTraces(ctx, newTraces(1)))
+	assert.ErrorIs(t, tracesToTraces.ConsumeTraces(ctx, newTraces(1)), errBufferFull)
+	require.NoError(t, tracesToTraces.Shutdown(ctx))
+}
+
+func TestBufferPermanentError(t *testing.T) {
+	f := NewFactory()
+	cfg := newTestConfig()
+	ctx := context.Background()
+	ext := newMockStorageExtension()
+	set := connectortest.NewNopCreateSettings()
+
+	tracesToTraces, err := f.CreateTracesToTraces(ctx, set, cfg, consumertest.NewErr(consumererror.NewPermanent(errors.New("invalid"))))
+	require.NoError(t, err)
+	require.NoError(t, tracesToTraces.Start(ctx, newMockHost(ext)))
+	require.NoError(t, tracesToTraces.ConsumeTraces(ctx, newTraces(1)))
+
+	client, err := ext.GetClient(ctx, component.KindConnector, set.ID, string(component.DataTypeTraces))
+	require.NoError(t, err)
+	// The batch is dropped, only the indexes are left.
+	assert.Eventually(t, func() bool {
+		return client.(*mockStorageClient).len() == 2
+	}, time.Second, 5*time.Millisecond)
+	require.NoError(t, tracesToTraces.Shutdown(ctx))
+}
+
+func TestBufferMaxItemsPerSecond(t *testing.T) {
+	f := NewFactory()
+	cfg := newTestConfig()
+	cfg.MaxItemsPerSecond = 100
+	ctx := context.Background()
+
+	sink := new(consumertest.TracesSink)
+	tracesToTraces, err := f.CreateTracesToTraces(ctx, connectortest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, tracesToTraces.Start(ctx, newMockHost(newMockStorageExtension())))
+
+	start := time.Now()
+	require.NoError(t, tracesToTraces.ConsumeTraces(ctx, newTraces(10)))
+	require.NoError(t, tracesToTraces.ConsumeTraces(ctx, newTraces(10)))
+	assert.Eventually(t, func() bool {
+		return sink.SpanCount() == 20
+	}, time.Second, 5*time.Millisecond)
+	// The second batch is replayed once the 10 spans of the first one are allowed.
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+	require.NoError(t, tracesToTraces.Shutdown(ctx))
+}
+
+func TestBufferStartError(t *testing.T) {
+	f := NewFactory()
+	ctx := context.Background()
+	tracesToTraces, err := f.CreateTracesToTraces(ctx, connectortest.NewNopCreateSettings(), newTestConfig(), consumertest.NewNop())
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, tracesToTraces.Start(ctx, componenttest.NewNopHost()), errNoStorageClient)
+	assert.ErrorIs(t, tracesToTraces.Start(ctx, newMockHost(struct {
+		component.StartFunc
+		component.ShutdownFunc
+	}{})), errWrongExtensionType)
+	assert.NoError(t, tracesToTraces.Shutdown(ctx))
+}
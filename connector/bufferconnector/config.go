@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bufferconnector // import "go.opentelemetry.io/collector/connector/bufferconnector"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Config defines configuration for the buffer connector.
+type Config struct {
+	// StorageID is the storage extension spooling the data until it is replayed.
+	StorageID *component.ID `mapstructure:"storage"`
+
+	// QueueSize is the maximum number of batches spooled, 0 means no limit.
+	// The data is rejected while the buffer is full.
+	QueueSize int `mapstructure:"queue_size"`
+
+	// MaxItemsPerSecond is the maximum number of spans, data points or log records
+	// replayed per second, 0 means no limit.
+	MaxItemsPerSecond int `mapstructure:"max_items_per_second"`
+
+	// RetryInterval is the time to wait before replaying again a batch failing to be consumed.
+	RetryInterval time.Duration `mapstructure:"retry_interval"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the connector configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.StorageID == nil {
+		return errors.New("storage must be set")
+	}
+	if cfg.QueueSize < 0 {
+		return errors.New("queue_size must not be negative")
+	}
+	if cfg.MaxItemsPerSecond < 0 {
+		return errors.New("max_items_per_second must not be negative")
+	}
+	if cfg.RetryInterval <= 0 {
+		return errors.New("retry_interval must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bufferconnector
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(confmap.New(), cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(cm, cfg))
+	storageID := component.NewID("file_storage")
+	assert.Equal(t,
+		&Config{
+			StorageID:         &storageID,
+			QueueSize:         500,
+			MaxItemsPerSecond: 1000,
+			RetryInterval:     time.Second,
+		}, cfg)
+}
+
+func TestConfigValidate(t *testing.T) {
+	storageID := component.NewID("file_storage")
+	tests := []struct {
+		name        string
+		cfg         *Config
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			cfg:  &Config{StorageID: &storageID, RetryInterval: time.Second},
+		},
+		{
+			name:        "no_storage",
+			cfg:         createDefaultConfig().(*Config),
+			expectedErr: "storage must be set",
+		},
+		{
+			name:        "negative_queue_size",
+			cfg:         &Config{StorageID: &storageID, QueueSize: -1, RetryInterval: time.Second},
+			expectedErr: "queue_size must not be negative",
+		},
+		{
+			name:        "negative_max_items_per_second",
+			cfg:         &Config{StorageID: &storageID, MaxItemsPerSecond: -1, RetryInterval: time.Second},
+			expectedErr: "max_items_per_second must not be negative",
+		},
+		{
+			name:        "retry_interval",
+			cfg:         &Config{StorageID: &storageID},
+			expectedErr: "retry_interval must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,6 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package bufferconnector spools signals from one pipeline to a storage extension,
+// and replays them into another pipeline.
+package bufferconnector // import "go.opentelemetry.io/collector/connector/bufferconnector"
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bufferconnector // import "go.opentelemetry.io/collector/connector/bufferconnector"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	// The value of connector "type" in configuration.
+	typeStr = "buffer"
+
+	defaultQueueSize     = 10000
+	defaultRetryInterval = 5 * time.Second
+)
+
+// NewFactory returns a connector.Factory.
+func NewFactory() connector.Factory {
+	return connector.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		connector.WithTracesToTraces(createTracesToTraces, component.StabilityLevelDevelopment),
+		connector.WithMetricsToMetrics(createMetricsToMetrics, component.StabilityLevelDevelopment),
+		connector.WithLogsToLogs(createLogsToLogs, component.StabilityLevelDevelopment),
+	)
+}
+
+// createDefaultConfig creates the default configuration.
+func createDefaultConfig() component.Config {
+	return &Config{
+		QueueSize:     defaultQueueSize,
+		RetryInterval: defaultRetryInterval,
+	}
+}
+
+// createTracesToTraces creates a traces to traces connector based on provided config.
+func createTracesToTraces(
+	_ context.Context,
+	set connector.CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Traces,
+) (connector.Traces, error) {
+	unmarshaler := &ptrace.ProtoUnmarshaler{}
+	return &tracesBuffer{
+		buffer: newBuffer(set, cfg.(*Config), component.DataTypeTraces, func(ctx context.Context, data []byte) (int, error) {
+			td, err := unmarshaler.UnmarshalTraces(data)
+			if err != nil {
+				return 0, err
+			}
+			return td.SpanCount(), nextConsumer.ConsumeTraces(ctx, td)
+		}),
+	}, nil
+}
+
+// createMetricsToMetrics creates a metrics to metrics connector based on provided config.
+func createMetricsToMetrics(
+	_ context.Context,
+	set connector.CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Metrics,
+) (connector.Metrics, error) {
+	unmarshaler := &pmetric.ProtoUnmarshaler{}
+	return &metricsBuffer{
+		buffer: newBuffer(set, cfg.(*Config), component.DataTypeMetrics, func(ctx context.Context, data []byte) (int, error) {
+			md, err := unmarshaler.UnmarshalMetrics(data)
+			if err != nil {
+				return 0, err
+			}
+			return md.DataPointCount(), nextConsumer.ConsumeMetrics(ctx, md)
+		}),
+	}, nil
+}
+
+// createLogsToLogs creates a logs to logs connector based on provided config.
+func createLogsToLogs(
+	_ context.Context,
+	set connector.CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Logs,
+) (connector.Logs, error) {
+	unmarshaler := &plog.ProtoUnmarshaler{}
+	return &logsBuffer{
+		buffer: newBuffer(set, cfg.(*Config), component.DataTypeLogs, func(ctx context.Context, data []byte) (int, error) {
+			ld, err := unmarshaler.UnmarshalLogs(data)
+			if err != nil {
+				return 0, err
+			}
+			return ld.LogRecordCount(), nextConsumer.ConsumeLogs(ctx, ld)
+		}),
+	}, nil
+}
@@ -85,6 +85,10 @@ type CreateSettings struct {
 	// ID returns the ID of the component that will be created.
 	ID component.ID
 
+	// InstanceID identifies the instance that will be created, among the instances created for ID.
+	// It can be used to attribute the telemetry of the component to the instance.
+	InstanceID component.InstanceID
+
 	component.TelemetrySettings
 
 	// BuildInfo can be used by components for informational purposes
@@ -320,6 +324,8 @@ type factory struct {
 	logsToTracesStabilityLevel  component.StabilityLevel
 	logsToMetricsStabilityLevel component.StabilityLevel
 	logsToLogsStabilityLevel    component.StabilityLevel
+
+	deprecation *component.Deprecation
 }
 
 // Type returns the type of component.
@@ -437,6 +443,19 @@ func (f factory) LogsToLogsStability() component.StabilityLevel {
 	return f.logsToLogsStabilityLevel
 }
 
+// Deprecation returns the deprecation of the connector, or nil if it is not deprecated.
+func (f *factory) Deprecation() *component.Deprecation {
+	return f.deprecation
+}
+
+// WithDeprecation marks the connector as deprecated. A warning is logged when a deprecated connector is
+// used in the configuration.
+func WithDeprecation(deprecation component.Deprecation) FactoryOption {
+	return factoryOptionFunc(func(o *factory) {
+		o.deprecation = &deprecation
+	})
+}
+
 // NewFactory returns a Factory.
 func NewFactory(cfgType component.Type, createDefaultConfig component.CreateDefaultConfigFunc, options ...FactoryOption) Factory {
 	f := &factory{
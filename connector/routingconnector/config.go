@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routingconnector // import "go.opentelemetry.io/collector/connector/routingconnector"
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	// ContextResource matches the attributes of the resource.
+	ContextResource = "resource"
+	// ContextRecord matches the attributes of the span, of the metric data point or of the log record.
+	ContextRecord = "record"
+)
+
+// Config defines configuration for the routing connector.
+type Config struct {
+	// DefaultPipelines are the pipelines the data not matched by any route is routed to.
+	// The data not matched by any route is dropped if empty.
+	DefaultPipelines []component.ID `mapstructure:"default_pipelines"`
+
+	// MatchOnce routes the data to the first matching route only, instead of all the matching routes.
+	MatchOnce bool `mapstructure:"match_once"`
+
+	// Table is the list of the routes, evaluated in order.
+	Table []RoutingTableItem `mapstructure:"table"`
+}
+
+// RoutingTableItem defines a route.
+type RoutingTableItem struct {
+	// Name is the name of the route in the telemetry of the connector. Defaults to "route_<index>".
+	Name string `mapstructure:"name"`
+
+	// Conditions must all match for the data to be routed to the pipelines of the route.
+	Conditions []Condition `mapstructure:"conditions"`
+
+	// Pipelines are the pipelines the matching data is routed to.
+	Pipelines []component.ID `mapstructure:"pipelines"`
+}
+
+// Condition matches the value of an attribute, converted to a string. Exactly one of Exact,
+// Prefix or Regexp must be set.
+type Condition struct {
+	// Context is whether the attribute is an attribute of the resource, "resource",
+	// or of the span, metric data point or log record, "record".
+	Context string `mapstructure:"context"`
+
+	// Attribute is the key of the attribute.
+	Attribute string `mapstructure:"attribute"`
+
+	// Exact matches the values equal to it.
+	Exact string `mapstructure:"exact"`
+
+	// Prefix matches the values starting with it.
+	Prefix string `mapstructure:"prefix"`
+
+	// Regexp matches the values matching the regular expression.
+	Regexp string `mapstructure:"regexp"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the connector configuration is valid
+func (cfg *Config) Validate() error {
+	if len(cfg.Table) == 0 {
+		return errors.New("table must not be empty")
+	}
+	names := make(map[string]struct{}, len(cfg.Table))
+	for i, item := range cfg.Table {
+		name := item.routeName(i)
+		if _, ok := names[name]; ok {
+			return fmt.Errorf("table: duplicate route name %q", name)
+		}
+		names[name] = struct{}{}
+		if len(item.Pipelines) == 0 {
+			return fmt.Errorf("table: route %q: pipelines must not be empty", name)
+		}
+		if len(item.Conditions) == 0 {
+			return fmt.Errorf("table: route %q: conditions must not be empty", name)
+		}
+		for _, cond := range item.Conditions {
+			if err := cond.validate(); err != nil {
+				return fmt.Errorf("table: route %q: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (item RoutingTableItem) routeName(index int) string {
+	if item.Name != "" {
+		return item.Name
+	}
+	return fmt.Sprintf("route_%d", index)
+}
+
+func (cond Condition) validate() error {
+	if cond.Context != ContextResource && cond.Context != ContextRecord {
+		return fmt.Errorf("context must be one of %q or %q, got %q", ContextResource, ContextRecord, cond.Context)
+	}
+	if cond.Attribute == "" {
+		return errors.New("attribute must not be empty")
+	}
+	set := 0
+	for _, match := range []string{cond.Exact, cond.Prefix, cond.Regexp} {
+		if match != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return fmt.Errorf("exactly one of exact, prefix or regexp must be set for the attribute %q", cond.Attribute)
+	}
+	if cond.Regexp != "" {
+		if _, err := regexp.Compile(cond.Regexp); err != nil {
+			return fmt.Errorf("invalid regexp for the attribute %q: %w", cond.Attribute, err)
+		}
+	}
+	return nil
+}
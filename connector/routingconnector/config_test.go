@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routingconnector
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(confmap.New(), cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(cm, cfg))
+	assert.Equal(t,
+		&Config{
+			DefaultPipelines: []component.ID{component.NewIDWithName("traces", "default")},
+			MatchOnce:        true,
+			Table: []RoutingTableItem{
+				{
+					Name: "checkout",
+					Conditions: []Condition{
+						{Context: ContextResource, Attribute: "service.name", Exact: "checkout"},
+					},
+					Pipelines: []component.ID{component.NewIDWithName("traces", "checkout")},
+				},
+				{
+					Conditions: []Condition{
+						{Context: ContextRecord, Attribute: "http.route", Prefix: "/api/"},
+						{Context: ContextResource, Attribute: "deployment.environment", Regexp: "^prod(-.*)?$"},
+					},
+					Pipelines: []component.ID{component.NewIDWithName("traces", "api"), component.NewIDWithName("traces", "archive")},
+				},
+			},
+		}, cfg)
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestConfigValidate(t *testing.T) {
+	pipelines := []component.ID{component.NewID("traces")}
+	condition := Condition{Context: ContextResource, Attribute: "service.name", Exact: "checkout"}
+	tests := []struct {
+		name        string
+		cfg         *Config
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			cfg:  &Config{Table: []RoutingTableItem{{Conditions: []Condition{condition}, Pipelines: pipelines}}},
+		},
+		{
+			name:        "empty_table",
+			cfg:         createDefaultConfig().(*Config),
+			expectedErr: "table must not be empty",
+		},
+		{
+			name: "duplicate_name",
+			cfg: &Config{Table: []RoutingTableItem{
+				{Conditions: []Condition{condition}, Pipelines: pipelines},
+				{Name: "route_0", Conditions: []Condition{condition}, Pipelines: pipelines},
+			}},
+			expectedErr: `table: duplicate route name "route_0"`,
+		},
+		{
+			name:        "no_pipelines",
+			cfg:         &Config{Table: []RoutingTableItem{{Name: "checkout", Conditions: []Condition{condition}}}},
+			expectedErr: `table: route "checkout": pipelines must not be empty`,
+		},
+		{
+			name:        "no_conditions",
+			cfg:         &Config{Table: []RoutingTableItem{{Pipelines: pipelines}}},
+			expectedErr: `table: route "route_0": conditions must not be empty`,
+		},
+		{
+			name: "invalid_context",
+			cfg: &Config{Table: []RoutingTableItem{{
+				Conditions: []Condition{{Context: "span", Attribute: "service.name", Exact: "checkout"}},
+				Pipelines:  pipelines,
+			}}},
+			expectedErr: `table: route "route_0": context must be one of "resource" or "record", got "span"`,
+		},
+		{
+			name: "no_attribute",
+			cfg: &Config{Table: []RoutingTableItem{{
+				Conditions: []Condition{{Context: ContextRecord, Exact: "checkout"}},
+				Pipelines:  pipelines,
+			}}},
+			expectedErr: `table: route "route_0": attribute must not be empty`,
+		},
+		{
+			name: "no_match",
+			cfg: &Config{Table: []RoutingTableItem{{
+				Conditions: []Condition{{Context: ContextRecord, Attribute: "service.name"}},
+				Pipelines:  pipelines,
+			}}},
+			expectedErr: `table: route "route_0": exactly one of exact, prefix or regexp must be set for the attribute "service.name"`,
+		},
+		{
+			name: "several_matches",
+			cfg: &Config{Table: []RoutingTableItem{{
+				Conditions: []Condition{{Context: ContextRecord, Attribute: "service.name", Exact: "checkout", Prefix: "check"}},
+				Pipelines:  pipelines,
+			}}},
+			expectedErr: `table: route "route_0": exactly one of exact, prefix or regexp must be set for the attribute "service.name"`,
+		},
+		{
+			name: "invalid_regexp",
+			cfg: &Config{Table: []RoutingTableItem{{
+				Conditions: []Condition{{Context: ContextRecord, Attribute: "service.name", Regexp: "("}},
+				Pipelines:  pipelines,
+			}}},
+			expectedErr: "table: route \"route_0\": invalid regexp for the attribute \"service.name\": error parsing regexp: missing closing ): `(`",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,6 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package routingconnector routes signals to the pipelines of the first or of all the routes
+// whose attribute conditions match.
+package routingconnector // import "go.opentelemetry.io/collector/connector/routingconnector"
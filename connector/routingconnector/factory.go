@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routingconnector // import "go.opentelemetry.io/collector/connector/routingconnector"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+const (
+	// The value of connector "type" in configuration.
+	typeStr = "routing"
+)
+
+// NewFactory returns a connector.Factory.
+func NewFactory() connector.Factory {
+	return connector.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		connector.WithTracesToTraces(createTracesToTraces, component.StabilityLevelDevelopment),
+		connector.WithMetricsToMetrics(createMetricsToMetrics, component.StabilityLevelDevelopment),
+		connector.WithLogsToLogs(createLogsToLogs, component.StabilityLevelDevelopment),
+	)
+}
+
+// createDefaultConfig creates the default configuration.
+func createDefaultConfig() component.Config {
+	return &Config{}
+}
+
+// createTracesToTraces creates a traces to traces connector based on provided config.
+func createTracesToTraces(
+	_ context.Context,
+	set connector.CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Traces,
+) (connector.Traces, error) {
+	table, telemetry, err := newRouting(set, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	consumers, err := routeConsumers(table, nextConsumer.(connector.TracesRouter).Consumer)
+	if err != nil {
+		return nil, err
+	}
+	return &tracesRouter{table: table, telemetry: telemetry, consumers: consumers}, nil
+}
+
+// createMetricsToMetrics creates a metrics to metrics connector based on provided config.
+func createMetricsToMetrics(
+	_ context.Context,
+	set connector.CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Metrics,
+) (connector.Metrics, error) {
+	table, telemetry, err := newRouting(set, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	consumers, err := routeConsumers(table, nextConsumer.(connector.MetricsRouter).Consumer)
+	if err != nil {
+		return nil, err
+	}
+	return &metricsRouter{table: table, telemetry: telemetry, consumers: consumers}, nil
+}
+
+// createLogsToLogs creates a logs to logs connector based on provided config.
+func createLogsToLogs(
+	_ context.Context,
+	set connector.CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Logs,
+) (connector.Logs, error) {
+	table, telemetry, err := newRouting(set, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	consumers, err := routeConsumers(table, nextConsumer.(connector.LogsRouter).Consumer)
+	if err != nil {
+		return nil, err
+	}
+	return &logsRouter{table: table, telemetry: telemetry, consumers: consumers}, nil
+}
+
+func newRouting(set connector.CreateSettings, cfg *Config) (*routingTable, *routingTelemetry, error) {
+	table := newRoutingTable(cfg)
+	telemetry, err := newRoutingTelemetry(set.ID, table)
+	if err != nil {
+		return nil, nil, err
+	}
+	return table, telemetry, nil
+}
+
+// routeConsumers returns the consumers of the pipelines of each route, the consumer of a route
+// without pipelines being the zero value.
+func routeConsumers[C any](table *routingTable, consumerOf func(...component.ID) (C, error)) ([]C, error) {
+	consumers := make([]C, len(table.routes))
+	for i, r := range table.routes {
+		if len(r.pipelines) == 0 {
+			continue
+		}
+		cons, err := consumerOf(r.pipelines...)
+		if err != nil {
+			return nil, fmt.Errorf("route %q: %w", r.name, err)
+		}
+		consumers[i] = cons
+	}
+	return consumers, nil
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routingconnector // import "go.opentelemetry.io/collector/connector/routingconnector"
+
+import (
+	"context"
+
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+type logsRouter struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	table     *routingTable
+	telemetry *routingTelemetry
+	// consumers are the consumers of the pipelines of each route, nil for the default route without pipelines.
+	consumers []consumer.Logs
+}
+
+func (r *logsRouter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (r *logsRouter) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	routed := make(map[int]plog.Logs)
+	output := func(route int) plog.ResourceLogs {
+		out, ok := routed[route]
+		if !ok {
+			out = plog.NewLogs()
+			routed[route] = out
+		}
+		return out.ResourceLogs().AppendEmpty()
+	}
+
+	records := newRecordRoutes(r.table)
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		resource := rl.Resource().Attributes()
+		if !r.table.recordConditions {
+			for _, route := range r.table.match(resource, emptyMap) {
+				rl.CopyTo(output(route))
+			}
+			continue
+		}
+
+		records.reset()
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			logRecords := rl.ScopeLogs().At(j).LogRecords()
+			for k := 0; k < logRecords.Len(); k++ {
+				records.add(resource, logRecords.At(k).Attributes())
+			}
+		}
+		for route, used := range records.used {
+			if !used {
+				continue
+			}
+			dest := output(route)
+			rl.CopyTo(dest)
+			keep := records.keep(route)
+			dest.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+				sl.LogRecords().RemoveIf(func(plog.LogRecord) bool { return !keep() })
+				return sl.LogRecords().Len() == 0
+			})
+		}
+	}
+
+	var errs error
+	for route := range r.table.routes {
+		out, ok := routed[route]
+		if !ok {
+			continue
+		}
+		if r.consumers[route] == nil {
+			r.telemetry.record(route, out.LogRecordCount(), true)
+			continue
+		}
+		err := r.consumers[route].ConsumeLogs(ctx, out)
+		r.telemetry.record(route, out.LogRecordCount(), err != nil)
+		errs = multierr.Append(errs, err)
+	}
+	return errs
+}
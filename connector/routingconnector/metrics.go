@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routingconnector // import "go.opentelemetry.io/collector/connector/routingconnector"
+
+import (
+	"context"
+
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+type metricsRouter struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	table     *routingTable
+	telemetry *routingTelemetry
+	// consumers are the consumers of the pipelines of each route, nil for the default route without pipelines.
+	consumers []consumer.Metrics
+}
+
+func (r *metricsRouter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (r *metricsRouter) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	routed := make(map[int]pmetric.Metrics)
+	output := func(route int) pmetric.ResourceMetrics {
+		out, ok := routed[route]
+		if !ok {
+			out = pmetric.NewMetrics()
+			routed[route] = out
+		}
+		return out.ResourceMetrics().AppendEmpty()
+	}
+
+	records := newRecordRoutes(r.table)
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		resource := rm.Resource().Attributes()
+		if !r.table.recordConditions {
+			for _, route := range r.table.match(resource, emptyMap) {
+				rm.CopyTo(output(route))
+			}
+			continue
+		}
+
+		// The records of the metrics are their data points.
+		records.reset()
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			metrics := rm.ScopeMetrics().At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				rangeDataPointAttributes(metrics.At(k), func(attrs pcommon.Map) {
+					records.add(resource, attrs)
+				})
+			}
+		}
+		for route, used := range records.used {
+			if !used {
+				continue
+			}
+			dest := output(route)
+			rm.CopyTo(dest)
+			keep := records.keep(route)
+			dest.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+				sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+					return !removeDataPointsIf(m, func() bool { return !keep() })
+				})
+				return sm.Metrics().Len() == 0
+			})
+		}
+	}
+
+	var errs error
+	for route := range r.table.routes {
+		out, ok := routed[route]
+		if !ok {
+			continue
+		}
+		if r.consumers[route] == nil {
+			r.telemetry.record(route, out.DataPointCount(), true)
+			continue
+		}
+		err := r.consumers[route].ConsumeMetrics(ctx, out)
+		r.telemetry.record(route, out.DataPointCount(), err != nil)
+		errs = multierr.Append(errs, err)
+	}
+	return errs
+}
+
+// rangeDataPointAttributes calls f with the attributes of each data point of the metric, in order.
+func rangeDataPointAttributes(m pmetric.Metric, f func(pcommon.Map)) {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		for i := 0; i < m.Gauge().DataPoints().Len(); i++ {
+			f(m.Gauge().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		for i := 0; i < m.Sum().DataPoints().Len(); i++ {
+			f(m.Sum().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		for i := 0; i < m.Histogram().DataPoints().Len(); i++ {
+			f(m.Histogram().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		for i := 0; i < m.ExponentialHistogram().DataPoints().Len(); i++ {
+			f(m.ExponentialHistogram().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		for i := 0; i < m.Summary().DataPoints().Len(); i++ {
+			f(m.Summary().DataPoints().At(i).Attributes())
+		}
+	}
+}
+
+// removeDataPointsIf removes the data points of the metric for which remove returns true, in order,
+// and returns whether data points are left.
+func removeDataPointsIf(m pmetric.Metric, remove func() bool) bool {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		m.Gauge().DataPoints().RemoveIf(func(pmetric.NumberDataPoint) bool { return remove() })
+		return m.Gauge().DataPoints().Len() > 0
+	case pmetric.MetricTypeSum:
+		m.Sum().DataPoints().RemoveIf(func(pmetric.NumberDataPoint) bool { return remove() })
+		return m.Sum().DataPoints().Len() > 0
+	case pmetric.MetricTypeHistogram:
+		m.Histogram().DataPoints().RemoveIf(func(pmetric.HistogramDataPoint) bool { return remove() })
+		return m.Histogram().DataPoints().Len() > 0
+	case pmetric.MetricTypeExponentialHistogram:
+		m.ExponentialHistogram().DataPoints().RemoveIf(func(pmetric.ExponentialHistogramDataPoint) bool { return remove() })
+		return m.ExponentialHistogram().DataPoints().Len() > 0
+	case pmetric.MetricTypeSummary:
+		m.Summary().DataPoints().RemoveIf(func(pmetric.SummaryDataPoint) bool { return remove() })
+		return m.Summary().DataPoints().Len() > 0
+	}
+	return false
+}
@@ -0,0 +1,273 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routingconnector
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	checkoutID = component.NewIDWithName("traces", "checkout")
+	apiID      = component.NewIDWithName("traces", "api")
+	defaultID  = component.NewIDWithName("traces", "default")
+)
+
+func checkoutRoute() RoutingTableItem {
+	return RoutingTableItem{
+		Name:       "checkout",
+		Conditions: []Condition{{Context: ContextResource, Attribute: "service.name", Exact: "checkout"}},
+		Pipelines:  []component.ID{checkoutID},
+	}
+}
+
+func apiRoute() RoutingTableItem {
+	return RoutingTableItem{
+		Name:       "api",
+		Conditions: []Condition{{Context: ContextRecord, Attribute: "http.route", Prefix: "/api/"}},
+		Pipelines:  []component.ID{apiID},
+	}
+}
+
+func newTraces(resources map[string][]string) ptrace.Traces {
+	td := ptrace.NewTraces()
+	for _, service := range []string{"checkout", "cart"} {
+		routes, ok := resources[service]
+		if !ok {
+			continue
+		}
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("service.name", service)
+		spans := rs.ScopeSpans().AppendEmpty().Spans()
+		for _, route := range routes {
+			span := spans.AppendEmpty()
+			span.SetName(route)
+			span.Attributes().PutStr("http.route", route)
+		}
+	}
+	return td
+}
+
+func spanNames(td ptrace.Traces) map[string][]string {
+	names := map[string][]string{}
+	td.RangeSpans(func(rs ptrace.ResourceSpans, _ ptrace.ScopeSpans, span ptrace.Span) bool {
+		service, _ := rs.Resource().Attributes().Get("service.name")
+		names[service.Str()] = append(names[service.Str()], span.Name())
+		return true
+	})
+	return names
+}
+
+func createTracesRouter(t *testing.T, cfg *Config, sinks map[component.ID]*consumertest.TracesSink) connector.Traces {
+	var opts []connectortest.TracesRouterOption
+	for id, sink := range sinks {
+		opts = append(opts, connectortest.WithTracesSink(id, sink))
+	}
+	require.NoError(t, component.ValidateConfig(cfg))
+	conn, err := NewFactory().CreateTracesToTraces(context.Background(), connectortest.NewNopCreateSettings(), cfg, connectortest.NewTracesRouter(opts...).(consumer.Traces))
+	require.NoError(t, err)
+	return conn
+}
+
+func TestTracesResourceRouting(t *testing.T) {
+	checkout, def := new(consumertest.TracesSink), new(consumertest.TracesSink)
+	conn := createTracesRouter(t, &Config{
+		DefaultPipelines: []component.ID{defaultID},
+		Table:            []RoutingTableItem{checkoutRoute()},
+	}, map[component.ID]*consumertest.TracesSink{checkoutID: checkout, defaultID: def})
+
+	require.NoError(t, conn.ConsumeTraces(context.Background(), newTraces(map[string][]string{
+		"checkout": {"/api/pay", "/health"},
+		"cart":     {"/api/add"},
+	})))
+	require.Len(t, checkout.AllTraces(), 1)
+	assert.Equal(t, map[string][]string{"checkout": {"/api/pay", "/health"}}, spanNames(checkout.AllTraces()[0]))
+	require.Len(t, def.AllTraces(), 1)
+	assert.Equal(t, map[string][]string{"cart": {"/api/add"}}, spanNames(def.AllTraces()[0]))
+
+	// The data only matching some routes is not sent to the others.
+	require.NoError(t, conn.ConsumeTraces(context.Background(), newTraces(map[string][]string{"cart": {"/api/add"}})))
+	assert.Len(t, checkout.AllTraces(), 1)
+	assert.Len(t, def.AllTraces(), 2)
+}
+
+func TestTracesRecordRouting(t *testing.T) {
+	checkout, api, def := new(consumertest.TracesSink), new(consumertest.TracesSink), new(consumertest.TracesSink)
+	sinks := map[component.ID]*consumertest.TracesSink{checkoutID: checkout, apiID: api, defaultID: def}
+	conn := createTracesRouter(t, &Config{
+		DefaultPipelines: []component.ID{defaultID},
+		Table:            []RoutingTableItem{checkoutRoute(), apiRoute()},
+	}, sinks)
+
+	td := newTraces(map[string][]string{
+		"checkout": {"/api/pay", "/health"},
+		"cart":     {"/api/add", "/health"},
+	})
+	require.NoError(t, conn.ConsumeTraces(context.Background(), td))
+	// The data is routed to all the matching routes.
+	assert.Equal(t, map[string][]string{"checkout": {"/api/pay", "/health"}}, spanNames(checkout.AllTraces()[0]))
+	assert.Equal(t, map[string][]string{"checkout": {"/api/pay"}, "cart": {"/api/add"}}, spanNames(api.AllTraces()[0]))
+	assert.Equal(t, map[string][]string{"cart": {"/health"}}, spanNames(def.AllTraces()[0]))
+	// The routed data is a copy.
+	assert.Equal(t, 4, td.SpanCount())
+
+	// With match_once, the data is only routed to the first matching route.
+	for _, sink := range sinks {
+		sink.Reset()
+	}
+	conn = createTracesRouter(t, &Config{
+		DefaultPipelines: []component.ID{defaultID},
+		MatchOnce:        true,
+		Table:            []RoutingTableItem{checkoutRoute(), apiRoute()},
+	}, sinks)
+	require.NoError(t, conn.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, map[string][]string{"checkout": {"/api/pay", "/health"}}, spanNames(checkout.AllTraces()[0]))
+	assert.Equal(t, map[string][]string{"cart": {"/api/add"}}, spanNames(api.AllTraces()[0]))
+	assert.Equal(t, map[string][]string{"cart": {"/health"}}, spanNames(def.AllTraces()[0]))
+}
+
+func TestTracesRoutingTelemetry(t *testing.T) {
+	checkout := new(consumertest.TracesSink)
+	cfg := &Config{Table: []RoutingTableItem{checkoutRoute(), apiRoute()}}
+	set := connectortest.NewNopCreateSettings()
+	set.ID = component.NewIDWithName(typeStr, "telemetry")
+	router := testTracesRouter{
+		checkoutID: checkout,
+		apiID:      consumertest.NewErr(errors.New("unavailable")),
+	}
+	conn, err := NewFactory().CreateTracesToTraces(context.Background(), set, cfg, router)
+	require.NoError(t, err)
+
+	assert.Error(t, conn.ConsumeTraces(context.Background(), newTraces(map[string][]string{
+		"checkout": {"/health"},
+		"cart":     {"/api/add", "/health", "/metrics"},
+	})))
+	assert.Len(t, checkout.AllTraces(), 1)
+
+	// The data of the failed route and the data not matching any route, without default pipelines, is dropped.
+	assertItems(t, set.ID, "routed_items", "checkout", 1)
+	assertItems(t, set.ID, "dropped_items", "api", 1)
+	assertItems(t, set.ID, "dropped_items", defaultRouteName, 2)
+}
+
+// testTracesRouter is a connector.TracesRouter of a single pipeline by route.
+type testTracesRouter map[component.ID]consumer.Traces
+
+func (r testTracesRouter) Consumer(ids ...component.ID) (consumer.Traces, error) {
+	if len(ids) != 1 {
+		return nil, errors.New("a single pipeline is supported")
+	}
+	return r[ids[0]], nil
+}
+
+func (r testTracesRouter) PipelineIDs() []component.ID {
+	var ids []component.ID
+	for id := range r {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (r testTracesRouter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{}
+}
+
+func (r testTracesRouter) ConsumeTraces(context.Context, ptrace.Traces) error {
+	return errors.New("the router must not be used as a consumer")
+}
+
+func assertItems(t *testing.T, id component.ID, metric, route string, expected int64) {
+	rows, err := view.RetrieveData("connector/" + typeStr + "/" + metric)
+	require.NoError(t, err)
+	for _, row := range rows {
+		if hasTag(row.Tags, connectorTagKey, id.String()) && hasTag(row.Tags, routeTagKey, route) {
+			assert.Equal(t, expected, int64(row.Data.(*view.SumData).Value), "%s of the route %q", metric, route)
+			return
+		}
+	}
+	t.Errorf("no %s for the route %q", metric, route)
+}
+
+func hasTag(tags []tag.Tag, key tag.Key, value string) bool {
+	for _, tg := range tags {
+		if tg.Key == key && tg.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestMetricsRecordRouting(t *testing.T) {
+	api, def := new(consumertest.MetricsSink), new(consumertest.MetricsSink)
+	conn, err := NewFactory().CreateMetricsToMetrics(context.Background(), connectortest.NewNopCreateSettings(), &Config{
+		DefaultPipelines: []component.ID{defaultID},
+		Table:            []RoutingTableItem{apiRoute()},
+	}, connectortest.NewMetricsRouter(
+		connectortest.WithMetricsSink(apiID, api),
+		connectortest.WithMetricsSink(defaultID, def),
+	).(consumer.Metrics))
+	require.NoError(t, err)
+
+	md := pmetric.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	sum := metrics.AppendEmpty()
+	sum.SetName("requests")
+	sum.SetEmptySum().DataPoints().AppendEmpty().Attributes().PutStr("http.route", "/api/pay")
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("latency")
+	histogram.SetEmptyHistogram().DataPoints().AppendEmpty().Attributes().PutStr("http.route", "/health")
+
+	require.NoError(t, conn.ConsumeMetrics(context.Background(), md))
+	require.Len(t, api.AllMetrics(), 1)
+	assert.Equal(t, 1, api.AllMetrics()[0].DataPointCount())
+	assert.Equal(t, 1, api.AllMetrics()[0].MetricCount())
+	assert.Equal(t, "requests", api.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Name())
+	require.Len(t, def.AllMetrics(), 1)
+	assert.Equal(t, 1, def.AllMetrics()[0].DataPointCount())
+	assert.Equal(t, "latency", def.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Name())
+}
+
+func TestLogsRecordRouting(t *testing.T) {
+	api := new(consumertest.LogsSink)
+	conn, err := NewFactory().CreateLogsToLogs(context.Background(), connectortest.NewNopCreateSettings(), &Config{
+		Table: []RoutingTableItem{apiRoute()},
+	}, connectortest.NewLogsRouter(connectortest.WithLogsSink(apiID, api)).(consumer.Logs))
+	require.NoError(t, err)
+
+	ld := plog.NewLogs()
+	logRecords := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, route := range []string{"/api/pay", "/health", "/api/add"} {
+		lr := logRecords.AppendEmpty()
+		lr.Body().SetStr(route)
+		lr.Attributes().PutStr("http.route", route)
+	}
+	require.NoError(t, conn.ConsumeLogs(context.Background(), ld))
+	require.Len(t, api.AllLogs(), 1)
+	routed := api.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 2, routed.Len())
+	assert.Equal(t, "/api/pay", routed.At(0).Body().Str())
+	assert.Equal(t, "/api/add", routed.At(1).Body().Str())
+}
+
+func TestCreateUnknownPipeline(t *testing.T) {
+	_, err := NewFactory().CreateLogsToLogs(context.Background(), connectortest.NewNopCreateSettings(), &Config{
+		Table: []RoutingTableItem{apiRoute()},
+	}, connectortest.NewLogsRouter(connectortest.WithNopLogs(defaultID)).(consumer.Logs))
+	assert.ErrorContains(t, err, `route "api"`)
+}
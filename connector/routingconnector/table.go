@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routingconnector // import "go.opentelemetry.io/collector/connector/routingconnector"
+
+import (
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const defaultRouteName = "default"
+
+// matcher is a compiled condition.
+type matcher struct {
+	record    bool
+	attribute string
+	match     func(string) bool
+}
+
+func newMatcher(cond Condition) matcher {
+	m := matcher{record: cond.Context == ContextRecord, attribute: cond.Attribute}
+	switch {
+	case cond.Exact != "":
+		m.match = func(v string) bool { return v == cond.Exact }
+	case cond.Prefix != "":
+		m.match = func(v string) bool { return strings.HasPrefix(v, cond.Prefix) }
+	default:
+		// The regular expression is checked by Config.Validate.
+		re := regexp.MustCompile(cond.Regexp)
+		m.match = re.MatchString
+	}
+	return m
+}
+
+func (m matcher) matches(resource, record pcommon.Map) bool {
+	attrs := resource
+	if m.record {
+		attrs = record
+	}
+	v, ok := attrs.Get(m.attribute)
+	return ok && m.match(v.AsString())
+}
+
+type route struct {
+	name      string
+	matchers  []matcher
+	pipelines []component.ID
+}
+
+// routingTable evaluates the routes of the data. The routes are identified by their index in routes,
+// the default route being the last one.
+type routingTable struct {
+	routes    []route
+	matchOnce bool
+	// recordConditions is whether some conditions match the attributes of the records, which must then be
+	// routed one by one instead of routing whole resources.
+	recordConditions bool
+}
+
+func newRoutingTable(cfg *Config) *routingTable {
+	t := &routingTable{matchOnce: cfg.MatchOnce}
+	for i, item := range cfg.Table {
+		r := route{name: item.routeName(i), pipelines: item.Pipelines}
+		for _, cond := range item.Conditions {
+			r.matchers = append(r.matchers, newMatcher(cond))
+			t.recordConditions = t.recordConditions || cond.Context == ContextRecord
+		}
+		t.routes = append(t.routes, r)
+	}
+	t.routes = append(t.routes, route{name: defaultRouteName, pipelines: cfg.DefaultPipelines})
+	return t
+}
+
+func (t *routingTable) defaultRoute() int {
+	return len(t.routes) - 1
+}
+
+// match returns the routes of the data with the given resource and record attributes, in order.
+func (t *routingTable) match(resource, record pcommon.Map) []int {
+	var matched []int
+	for i, r := range t.routes[:t.defaultRoute()] {
+		if r.matches(resource, record) {
+			matched = append(matched, i)
+			if t.matchOnce {
+				break
+			}
+		}
+	}
+	if len(matched) == 0 {
+		return []int{t.defaultRoute()}
+	}
+	return matched
+}
+
+func (r route) matches(resource, record pcommon.Map) bool {
+	for _, m := range r.matchers {
+		if !m.matches(resource, record) {
+			return false
+		}
+	}
+	return true
+}
+
+// recordRoutes accumulates the routes of the records of a resource, in the order they are visited.
+type recordRoutes struct {
+	table   *routingTable
+	records [][]int
+	// used are the routes of at least one record.
+	used []bool
+}
+
+func newRecordRoutes(table *routingTable) *recordRoutes {
+	return &recordRoutes{table: table, used: make([]bool, len(table.routes))}
+}
+
+func (rr *recordRoutes) add(resource, record pcommon.Map) {
+	routes := rr.table.match(resource, record)
+	rr.records = append(rr.records, routes)
+	for _, r := range routes {
+		rr.used[r] = true
+	}
+}
+
+// keep returns a function telling whether the next record, in the order they were added, is routed
+// to the route.
+func (rr *recordRoutes) keep(route int) func() bool {
+	next := 0
+	return func() bool {
+		routes := rr.records[next]
+		next++
+		for _, r := range routes {
+			if r == route {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func (rr *recordRoutes) reset() {
+	rr.records = rr.records[:0]
+	for i := range rr.used {
+		rr.used[i] = false
+	}
+}
+
+var emptyMap = pcommon.NewMap()
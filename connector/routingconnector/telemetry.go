@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routingconnector // import "go.opentelemetry.io/collector/connector/routingconnector"
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	connectorTagKey  = tag.MustNewKey("connector")
+	routeTagKey      = tag.MustNewKey("route")
+	statRoutedItems  = stats.Int64("routed_items", "Number of spans, metric data points or log records routed to the pipelines of the route", stats.UnitDimensionless)
+	statDroppedItems = stats.Int64("dropped_items", "Number of spans, metric data points or log records matching the route and not routed to its pipelines", stats.UnitDimensionless)
+)
+
+func init() {
+	// TODO: Find a way to handle the error.
+	_ = view.Register(metricViews()...)
+}
+
+// metricViews returns the metrics views of the routing connector.
+func metricViews() []*view.View {
+	tagKeys := []tag.Key{connectorTagKey, routeTagKey}
+	var views []*view.View
+	for _, measure := range []*stats.Int64Measure{statRoutedItems, statDroppedItems} {
+		views = append(views, &view.View{
+			Name:        "connector/" + typeStr + "/" + measure.Name(),
+			Measure:     measure,
+			Description: measure.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.Sum(),
+		})
+	}
+	return views
+}
+
+// routingTelemetry records the number of items routed and dropped by route.
+type routingTelemetry struct {
+	// routeCtxs are the contexts tagged with the connector and the route, by route.
+	routeCtxs []context.Context
+}
+
+func newRoutingTelemetry(id component.ID, table *routingTable) (*routingTelemetry, error) {
+	rt := &routingTelemetry{}
+	for _, r := range table.routes {
+		ctx, err := tag.New(context.Background(), tag.Insert(connectorTagKey, id.String()), tag.Insert(routeTagKey, r.name))
+		if err != nil {
+			return nil, err
+		}
+		rt.routeCtxs = append(rt.routeCtxs, ctx)
+	}
+	return rt, nil
+}
+
+func (rt *routingTelemetry) record(route int, items int, dropped bool) {
+	measure := statRoutedItems
+	if dropped {
+		measure = statDroppedItems
+	}
+	stats.Record(rt.routeCtxs[route], measure.M(int64(items)))
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routingconnector // import "go.opentelemetry.io/collector/connector/routingconnector"
+
+import (
+	"context"
+
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+type tracesRouter struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	table     *routingTable
+	telemetry *routingTelemetry
+	// consumers are the consumers of the pipelines of each route, nil for the default route without pipelines.
+	consumers []consumer.Traces
+}
+
+func (r *tracesRouter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (r *tracesRouter) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	routed := make(map[int]ptrace.Traces)
+	output := func(route int) ptrace.ResourceSpans {
+		out, ok := routed[route]
+		if !ok {
+			out = ptrace.NewTraces()
+			routed[route] = out
+		}
+		return out.ResourceSpans().AppendEmpty()
+	}
+
+	records := newRecordRoutes(r.table)
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		resource := rs.Resource().Attributes()
+		if !r.table.recordConditions {
+			for _, route := range r.table.match(resource, emptyMap) {
+				rs.CopyTo(output(route))
+			}
+			continue
+		}
+
+		records.reset()
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			spans := rs.ScopeSpans().At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				records.add(resource, spans.At(k).Attributes())
+			}
+		}
+		for route, used := range records.used {
+			if !used {
+				continue
+			}
+			dest := output(route)
+			rs.CopyTo(dest)
+			keep := records.keep(route)
+			dest.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+				ss.Spans().RemoveIf(func(ptrace.Span) bool { return !keep() })
+				return ss.Spans().Len() == 0
+			})
+		}
+	}
+
+	var errs error
+	for route := range r.table.routes {
+		out, ok := routed[route]
+		if !ok {
+			continue
+		}
+		if r.consumers[route] == nil {
+			r.telemetry.record(route, out.SpanCount(), true)
+			continue
+		}
+		err := r.consumers[route].ConsumeTraces(ctx, out)
+		r.telemetry.record(route, out.SpanCount(), err != nil)
+		errs = multierr.Append(errs, err)
+	}
+	return errs
+}
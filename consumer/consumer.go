@@ -21,10 +21,29 @@ type baseConsumer interface {
 	Capabilities() Capabilities
 }
 
+// BackpressureReporter is implemented by the consumers able to report that they are congested, e.g. because
+// the components downstream of them do not keep up with the data. The receivers can check it with
+// UnderBackpressure to shed load before the consumers block or fail.
+type BackpressureReporter interface {
+	// Backpressure returns whether the consumer is congested. It is called concurrently with the
+	// Consume* functions, and must be cheap.
+	Backpressure() bool
+}
+
+// UnderBackpressure returns whether the consumer reports backpressure,
+// false if it does not implement BackpressureReporter.
+func UnderBackpressure(c any) bool {
+	if br, ok := c.(BackpressureReporter); ok {
+		return br.Backpressure()
+	}
+	return false
+}
+
 var errNilFunc = errors.New("nil consumer func")
 
 type baseImpl struct {
 	capabilities Capabilities
+	backpressure func() bool
 }
 
 // Option to construct new consumers.
@@ -38,11 +57,24 @@ func WithCapabilities(capabilities Capabilities) Option {
 	}
 }
 
+// WithBackpressure sets the function reporting whether the consumer is congested, see BackpressureReporter.
+// The consumers created without it never report backpressure.
+func WithBackpressure(backpressure func() bool) Option {
+	return func(o *baseImpl) {
+		o.backpressure = backpressure
+	}
+}
+
 // Capabilities implementation of the base
 func (bs baseImpl) Capabilities() Capabilities {
 	return bs.capabilities
 }
 
+// Backpressure implementation of the base
+func (bs baseImpl) Backpressure() bool {
+	return bs.backpressure != nil && bs.backpressure()
+}
+
 func newBaseImpl(options ...Option) *baseImpl {
 	bs := &baseImpl{
 		capabilities: Capabilities{MutatesData: false},
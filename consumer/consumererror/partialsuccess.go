@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror // import "go.opentelemetry.io/collector/consumer/consumererror"
+
+import (
+	"errors"
+
+	"go.uber.org/multierr"
+)
+
+// PartialSuccess is an error reporting that only a part of the data was rejected,
+// the other items being accepted. It is permanent if the error it wraps is permanent.
+type PartialSuccess struct {
+	err      error
+	rejected int
+}
+
+// NewPartialSuccess wraps an error to indicate that only the given number of items
+// were rejected: spans, metric data points or log records, depending on the signal of
+// the data. Wrap err with NewPermanent if sending the rejected items again would fail.
+func NewPartialSuccess(err error, rejected int) error {
+	return PartialSuccess{err: err, rejected: rejected}
+}
+
+func (p PartialSuccess) Error() string {
+	return p.err.Error()
+}
+
+// Unwrap returns the wrapped error for functions Is and As in standard package errors.
+func (p PartialSuccess) Unwrap() error {
+	return p.err
+}
+
+// Rejected returns the number of items rejected.
+func (p PartialSuccess) Rejected() int {
+	return p.rejected
+}
+
+// Retryable returns true if sending the rejected items again may succeed, i.e. if
+// the wrapped error is not permanent.
+func (p PartialSuccess) Retryable() bool {
+	return !IsPermanent(p.err)
+}
+
+// AsPartialSuccess returns the PartialSuccess wrapped in err, and false if err does not
+// report a partial success.
+func AsPartialSuccess(err error) (PartialSuccess, bool) {
+	var p PartialSuccess
+	if err == nil {
+		return p, false
+	}
+	return p, errors.As(err, &p)
+}
+
+// CombinePartialSuccess combines the errors returned by the consumers the same data was
+// passed to. If all the non-nil errors report a partial success, the combined error reports
+// a partial success rejecting the largest number of items rejected by one of the consumers,
+// otherwise the combined error reports that the whole data was rejected.
+func CombinePartialSuccess(errs ...error) error {
+	var last error
+	// unwrapped are the errors without their partial success, not to report a partial success
+	// when the whole data is rejected, nor several partial successes.
+	unwrapped := make([]error, 0, len(errs))
+	partial := true
+	rejected := 0
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		last = err
+		p, ok := AsPartialSuccess(err)
+		if !ok {
+			partial = false
+			unwrapped = append(unwrapped, err)
+			continue
+		}
+		unwrapped = append(unwrapped, p.err)
+		if p.rejected > rejected {
+			rejected = p.rejected
+		}
+	}
+	switch {
+	case len(unwrapped) == 0:
+		return nil
+	case len(unwrapped) == 1:
+		return last
+	case !partial:
+		return multierr.Combine(unwrapped...)
+	default:
+		return NewPartialSuccess(multierr.Combine(unwrapped...), rejected)
+	}
+}
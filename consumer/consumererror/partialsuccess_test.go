@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartialSuccess(t *testing.T) {
+	_, ok := AsPartialSuccess(nil)
+	assert.False(t, ok)
+	_, ok = AsPartialSuccess(errors.New("testError"))
+	assert.False(t, ok)
+
+	err := fmt.Errorf("wrapped: %w", NewPartialSuccess(errors.New("testError"), 3))
+	p, ok := AsPartialSuccess(err)
+	require.True(t, ok)
+	assert.Equal(t, 3, p.Rejected())
+	assert.True(t, p.Retryable())
+	assert.False(t, IsPermanent(err))
+	assert.Equal(t, "wrapped: testError", err.Error())
+
+	err = NewPartialSuccess(NewPermanent(testErrorType{"testError"}), 2)
+	p, ok = AsPartialSuccess(err)
+	require.True(t, ok)
+	assert.False(t, p.Retryable())
+	assert.True(t, IsPermanent(err))
+	target := testErrorType{}
+	require.True(t, errors.As(err, &target))
+	assert.Equal(t, "testError", target.s)
+}
+
+func TestCombinePartialSuccess(t *testing.T) {
+	assert.NoError(t, CombinePartialSuccess())
+	assert.NoError(t, CombinePartialSuccess(nil, nil))
+
+	single := NewPartialSuccess(errors.New("single"), 2)
+	assert.Equal(t, single, CombinePartialSuccess(nil, single))
+
+	err := CombinePartialSuccess(NewPartialSuccess(errors.New("first"), 2), nil, NewPartialSuccess(NewPermanent(errors.New("second")), 5))
+	p, ok := AsPartialSuccess(err)
+	require.True(t, ok)
+	assert.Equal(t, 5, p.Rejected())
+	assert.False(t, p.Retryable())
+	assert.Contains(t, err.Error(), "first")
+	assert.Contains(t, err.Error(), "second")
+
+	// A consumer rejecting all the data rejects the whole data.
+	err = CombinePartialSuccess(NewPartialSuccess(errors.New("first"), 2), errors.New("second"))
+	assert.Error(t, err)
+	_, ok = AsPartialSuccess(err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror // import "go.opentelemetry.io/collector/consumer/consumererror"
+
+import "errors"
+
+// shutdown is an error returned when the data was refused because the
+// pipeline is shutting down.
+type shutdown struct {
+	err error
+}
+
+// NewShutdown wraps an error to indicate that the data was refused because the
+// pipeline is shutting down, e.g. when sending to a stopped queue. This data is
+// reported separately from the data refused because of a failure.
+func NewShutdown(err error) error {
+	return shutdown{err: err}
+}
+
+func (s shutdown) Error() string {
+	return "Pipeline shutting down: " + s.err.Error()
+}
+
+// Unwrap returns the wrapped error for functions Is and As in standard package errors.
+func (s shutdown) Unwrap() error {
+	return s.err
+}
+
+// IsShutdown checks if an error was wrapped with the NewShutdown function, which
+// is used to indicate that the data was refused because the pipeline is shutting down.
+func IsShutdown(err error) bool {
+	if err == nil {
+		return false
+	}
+	return errors.As(err, &shutdown{})
+}
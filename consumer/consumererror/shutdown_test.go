@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsShutdown(t *testing.T) {
+	var err error
+	assert.False(t, IsShutdown(err))
+
+	err = errors.New("testError")
+	assert.False(t, IsShutdown(err))
+
+	err = NewShutdown(err)
+	assert.True(t, IsShutdown(err))
+	assert.False(t, IsPermanent(err))
+
+	err = fmt.Errorf("%w", err)
+	assert.True(t, IsShutdown(err))
+}
+
+func TestShutdown_Unwrap(t *testing.T) {
+	var err error = testErrorType{"testError"}
+	shutdownErr := NewShutdown(err)
+	require.True(t, IsShutdown(shutdownErr))
+
+	target := testErrorType{}
+	require.True(t, errors.As(shutdownErr, &target))
+	require.Equal(t, err, target)
+}
@@ -48,3 +48,18 @@ func TestConsumeLogs_ReturnError(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, want, cp.ConsumeLogs(context.Background(), plog.NewLogs()))
 }
+
+func TestWithBackpressureLogs(t *testing.T) {
+	cp, err := NewLogs(func(context.Context, plog.Logs) error { return nil })
+	assert.NoError(t, err)
+	assert.False(t, UnderBackpressure(cp))
+
+	congested := true
+	cp, err = NewLogs(
+		func(context.Context, plog.Logs) error { return nil },
+		WithBackpressure(func() bool { return congested }))
+	assert.NoError(t, err)
+	assert.True(t, UnderBackpressure(cp))
+	congested = false
+	assert.False(t, UnderBackpressure(cp))
+}
@@ -48,3 +48,18 @@ func TestConsumeMetrics_ReturnError(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, want, cp.ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
 }
+
+func TestWithBackpressureMetrics(t *testing.T) {
+	cp, err := NewMetrics(func(context.Context, pmetric.Metrics) error { return nil })
+	assert.NoError(t, err)
+	assert.False(t, UnderBackpressure(cp))
+
+	congested := true
+	cp, err = NewMetrics(
+		func(context.Context, pmetric.Metrics) error { return nil },
+		WithBackpressure(func() bool { return congested }))
+	assert.NoError(t, err)
+	assert.True(t, UnderBackpressure(cp))
+	congested = false
+	assert.False(t, UnderBackpressure(cp))
+}
@@ -48,3 +48,18 @@ func TestConsumeTraces_ReturnError(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, want, cp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
 }
+
+func TestWithBackpressureTraces(t *testing.T) {
+	cp, err := NewTraces(func(context.Context, ptrace.Traces) error { return nil })
+	assert.NoError(t, err)
+	assert.False(t, UnderBackpressure(cp))
+
+	congested := true
+	cp, err = NewTraces(
+		func(context.Context, ptrace.Traces) error { return nil },
+		WithBackpressure(func() bool { return congested }))
+	assert.NoError(t, err)
+	assert.True(t, UnderBackpressure(cp))
+	congested = false
+	assert.False(t, UnderBackpressure(cp))
+}
@@ -36,6 +36,10 @@ type CreateSettings struct {
 	// ID returns the ID of the component that will be created.
 	ID component.ID
 
+	// InstanceID identifies the instance that will be created, among the instances created for ID.
+	// It can be used to attribute the telemetry of the component to the instance.
+	InstanceID component.InstanceID
+
 	component.TelemetrySettings
 
 	// BuildInfo can be used by components for informational purposes
@@ -133,6 +137,7 @@ type factory struct {
 	metricsStabilityLevel component.StabilityLevel
 	CreateLogsFunc
 	logsStabilityLevel component.StabilityLevel
+	deprecation        *component.Deprecation
 }
 
 func (f *factory) Type() component.Type {
@@ -177,6 +182,19 @@ func WithLogs(createLogs CreateLogsFunc, sl component.StabilityLevel) FactoryOpt
 	})
 }
 
+// Deprecation returns the deprecation of the exporter, or nil if it is not deprecated.
+func (f *factory) Deprecation() *component.Deprecation {
+	return f.deprecation
+}
+
+// WithDeprecation marks the exporter as deprecated. A warning is logged when a deprecated exporter is
+// used in the configuration.
+func WithDeprecation(deprecation component.Deprecation) FactoryOption {
+	return factoryOptionFunc(func(o *factory) {
+		o.deprecation = &deprecation
+	})
+}
+
 // NewFactory returns a Factory.
 func NewFactory(cfgType component.Type, createDefaultConfig component.CreateDefaultConfigFunc, options ...FactoryOption) Factory {
 	f := &factory{
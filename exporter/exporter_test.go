@@ -56,6 +56,15 @@ func TestNewFactoryWithOptions(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestNewFactoryWithDeprecation(t *testing.T) {
+	factory := NewFactory("test", func() component.Config { return nil })
+	assert.Nil(t, component.FactoryDeprecation(factory))
+
+	deprecation := component.Deprecation{Since: "v0.85.0", Replacement: "the new exporter"}
+	factory = NewFactory("test", func() component.Config { return nil }, WithDeprecation(deprecation))
+	assert.Equal(t, &deprecation, component.FactoryDeprecation(factory))
+}
+
 func TestMakeFactoryMap(t *testing.T) {
 	type testCase struct {
 		name string
@@ -0,0 +1,317 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/metric/metricdata"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+)
+
+// BatcherSettings defines the configuration of the batching of the requests by an exporter. The requests are merged
+// into batches, and the oversized ones split, after the sending queue, so the requests restored from the persistent
+// queue are batched as well. Sending a request waits for the batch it was merged into to be sent, so the batches
+// merge at most sending_queue::num_consumers requests when the sending queue is enabled.
+type BatcherSettings struct {
+	// Enabled indicates whether the requests are batched.
+	Enabled bool `mapstructure:"enabled"`
+	// FlushTimeout is the time after which a batch is sent regardless of its size.
+	FlushTimeout time.Duration `mapstructure:"flush_timeout"`
+	// MinSizeItems is the number of items (spans, metric data points or log records) which triggers the sending of
+	// a batch, before FlushTimeout. 0 sends every request as soon as it is received, only splitting the oversized ones.
+	MinSizeItems int `mapstructure:"min_size_items"`
+	// MaxSizeItems is the maximum number of items of a batch, the larger requests being split. 0 means no limit.
+	MaxSizeItems int `mapstructure:"max_size_items"`
+	// MetadataKeys are the client metadata keys the requests are batched by: only the requests with the same values
+	// for these keys are merged, the batches being sent with the client metadata of their first request.
+	// The receivers must include the client metadata, e.g. with "include_metadata: true".
+	MetadataKeys []string `mapstructure:"metadata_keys"`
+}
+
+// NewDefaultBatcherSettings returns the default settings for BatcherSettings.
+func NewDefaultBatcherSettings() BatcherSettings {
+	return BatcherSettings{
+		Enabled:      false,
+		FlushTimeout: 200 * time.Millisecond,
+		MinSizeItems: 8192,
+	}
+}
+
+// Validate checks if the BatcherSettings configuration is valid.
+func (cfg *BatcherSettings) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.FlushTimeout <= 0 {
+		return errors.New("batcher flush_timeout must be positive")
+	}
+	if cfg.MinSizeItems < 0 {
+		return errors.New("batcher min_size_items must not be negative")
+	}
+	if cfg.MaxSizeItems < 0 {
+		return errors.New("batcher max_size_items must not be negative")
+	}
+	if cfg.MaxSizeItems != 0 && cfg.MaxSizeItems < cfg.MinSizeItems {
+		return errors.New("batcher max_size_items must be greater than or equal to min_size_items")
+	}
+	return nil
+}
+
+// BatchMergeSplitFunc merges the request req into the optional request optReq, nil if there is no batch yet, and
+// splits the result into requests of at most maxItems items, 0 meaning no limit. All the returned requests but the
+// last one must have maxItems items. The given requests can be modified.
+// This API is at the early stage of development and may change without backward compatibility
+// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
+type BatchMergeSplitFunc func(ctx context.Context, maxItems int, optReq Request, req Request) ([]Request, error)
+
+// BatcherOption apply changes to the batching of the requests.
+type BatcherOption func(*batchSender)
+
+// WithRequestBatchFuncs sets the function merging and splitting the requests of the new exporter helpers
+// New[Traces|Metrics|Logs]RequestExporter, which is required to batch them. The data of the other exporters
+// is merged and split by the exporter helper.
+// This API is at the early stage of development and may change without backward compatibility
+// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
+func WithRequestBatchFuncs(mergeSplit BatchMergeSplitFunc) BatcherOption {
+	return func(bs *batchSender) {
+		bs.mergeSplitFunc = func(ctx context.Context, maxItems int, optReq internal.Request, req internal.Request) ([]internal.Request, error) {
+			var opt Request
+			if optReq != nil {
+				opt = optReq.(*request).Request
+			}
+			reqs, err := mergeSplit(ctx, maxItems, opt, req.(*request).Request)
+			if err != nil {
+				return nil, err
+			}
+			res := make([]internal.Request, 0, len(reqs))
+			for _, r := range reqs {
+				res = append(res, newRequest(ctx, r))
+			}
+			return res, nil
+		}
+	}
+}
+
+// errNoRequestBatchFuncs is returned by the new request exporter helpers batching the requests without WithRequestBatchFuncs.
+var errNoRequestBatchFuncs = errors.New("WithRequestBatchFuncs must be set to batch the requests of the new request exporters")
+
+// WithBatcher enables the batching of the requests after the sending queue.
+// The default BatcherSettings is to send the requests as they are received.
+// The exporters batching the pdata set the MutatesData capability, the data being modified when merged and split.
+// The new exporter helpers New[Traces|Metrics|Logs]RequestExporter return an error if WithRequestBatchFuncs is not set.
+func WithBatcher(config BatcherSettings, opts ...BatcherOption) Option {
+	return func(o *baseExporter) {
+		if !config.Enabled {
+			return
+		}
+		bs := newBatchSender(o.set.ID.String(), config, o.sampledLogger)
+		for _, opt := range opts {
+			opt(bs)
+		}
+		switch {
+		case !o.requestExporter:
+			bs.mergeSplitFunc = mergeSplitPdataRequests
+			o.consumerOptions = append(o.consumerOptions, consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+		case bs.mergeSplitFunc == nil:
+			o.optionsErr = multierr.Append(o.optionsErr, errNoRequestBatchFuncs)
+			return
+		}
+		o.batchSender = bs
+	}
+}
+
+const (
+	// batchTriggerKey is the label of the batcher metrics telling why the batches were sent.
+	batchTriggerKey = "trigger"
+	// batchTriggerSize is the trigger of the batches sent because they reached min_size_items, or were split.
+	batchTriggerSize = "size"
+	// batchTriggerTimeout is the trigger of the batches sent after flush_timeout.
+	batchTriggerTimeout = "timeout"
+	// batchTriggerShutdown is the trigger of the batches sent on shutdown.
+	batchTriggerShutdown = "shutdown"
+)
+
+// batch is a batch being filled, the senders of its requests waiting for it to be sent.
+type batch struct {
+	ctx   context.Context
+	req   internal.Request
+	timer *time.Timer
+	done  chan struct{}
+	err   error
+}
+
+// batchSender merges the requests into batches and splits the oversized ones before sending them.
+type batchSender struct {
+	baseRequestSender
+	cfg            BatcherSettings
+	fullName       string
+	logger         *zap.Logger
+	mergeSplitFunc func(ctx context.Context, maxItems int, optReq internal.Request, req internal.Request) ([]internal.Request, error)
+
+	mu      sync.Mutex
+	batches map[string]*batch
+	stopped bool
+}
+
+func newBatchSender(fullName string, cfg BatcherSettings, logger *zap.Logger) *batchSender {
+	return &batchSender{
+		cfg:      cfg,
+		fullName: fullName,
+		logger:   logger,
+		batches:  map[string]*batch{},
+	}
+}
+
+// send merges the request into the batch of its client metadata, and waits for all the batches holding its items
+// to be sent, returning their errors.
+func (bs *batchSender) send(req internal.Request) error {
+	bs.mu.Lock()
+	if bs.stopped {
+		bs.mu.Unlock()
+		return bs.nextSender.send(req)
+	}
+
+	key := bs.batchKey(req.Context())
+	b := bs.batches[key]
+	var optReq internal.Request
+	if b != nil {
+		optReq = b.req
+	}
+	reqs, err := bs.mergeSplitFunc(req.Context(), bs.cfg.MaxSizeItems, optReq, req)
+	if err != nil {
+		bs.mu.Unlock()
+		bs.logger.Error("Failed to batch the request, sending it alone.", zap.Error(err))
+		return bs.nextSender.send(req)
+	}
+
+	waits := make([]*batch, 0, len(reqs))
+	var full []*batch
+	for i, r := range reqs {
+		if b == nil {
+			b = bs.newBatch(key, req.Context())
+		}
+		r.SetContext(b.ctx)
+		b.req = r
+		waits = append(waits, b)
+		// Only the last request can have less than max_size_items items.
+		if i < len(reqs)-1 || r.Count() >= bs.cfg.MinSizeItems {
+			bs.detach(key, b)
+			full = append(full, b)
+		}
+		b = nil
+	}
+	bs.mu.Unlock()
+
+	for _, fb := range full {
+		bs.export(fb, batchTriggerSize)
+	}
+	var errs error
+	for _, w := range waits {
+		<-w.done
+		errs = multierr.Append(errs, w.err)
+	}
+	return errs
+}
+
+// newBatch starts a batch of the given client metadata, sent after flush_timeout unless filled before.
+// Must be called with the lock held.
+func (bs *batchSender) newBatch(key string, ctx context.Context) *batch {
+	b := &batch{ctx: ctx, done: make(chan struct{})}
+	bs.batches[key] = b
+	b.timer = time.AfterFunc(bs.cfg.FlushTimeout, func() {
+		bs.mu.Lock()
+		if bs.batches[key] != b {
+			bs.mu.Unlock()
+			return
+		}
+		delete(bs.batches, key)
+		bs.mu.Unlock()
+		bs.export(b, batchTriggerTimeout)
+	})
+	return b
+}
+
+// detach removes the batch from the batches being filled. Must be called with the lock held.
+func (bs *batchSender) detach(key string, b *batch) {
+	b.timer.Stop()
+	if bs.batches[key] == b {
+		delete(bs.batches, key)
+	}
+}
+
+// export sends the batch and wakes up the senders of its requests.
+func (bs *batchSender) export(b *batch, trigger string) {
+	b.err = bs.nextSender.send(b.req)
+	bs.recordBatch(trigger, b.req.Count())
+	close(b.done)
+}
+
+func (bs *batchSender) recordBatch(trigger string, items int) {
+	labelValues := []metricdata.LabelValue{metricdata.NewLabelValue(bs.fullName), metricdata.NewLabelValue(trigger)}
+	if entry, err := globalInstruments.batchesSent.GetEntry(labelValues...); err == nil {
+		entry.Inc(1)
+	}
+	if entry, err := globalInstruments.batchSentItems.GetEntry(labelValues...); err == nil {
+		entry.Inc(int64(items))
+	}
+}
+
+// batchKey returns the key of the batches of the client metadata of the context.
+func (bs *batchSender) batchKey(ctx context.Context) string {
+	if len(bs.cfg.MetadataKeys) == 0 {
+		return ""
+	}
+	info := client.FromContext(ctx)
+	var sb strings.Builder
+	for _, k := range bs.cfg.MetadataKeys {
+		for _, v := range info.Metadata.Get(k) {
+			sb.WriteString(v)
+			sb.WriteByte(0)
+		}
+		sb.WriteByte(1)
+	}
+	return sb.String()
+}
+
+// shutdown sends the batches being filled. The requests received after are sent as they are.
+func (bs *batchSender) shutdown() {
+	bs.mu.Lock()
+	bs.stopped = true
+	batches := make([]*batch, 0, len(bs.batches))
+	for key, b := range bs.batches {
+		bs.detach(key, b)
+		batches = append(batches, b)
+	}
+	bs.mu.Unlock()
+	for _, b := range batches {
+		bs.export(b, batchTriggerShutdown)
+	}
+}
+
+// mergeSplitPdataRequests merges and splits the requests of the exporters of pdata, which are of the same signal.
+func mergeSplitPdataRequests(ctx context.Context, maxItems int, optReq internal.Request, req internal.Request) ([]internal.Request, error) {
+	switch r := req.(type) {
+	case *logsRequest:
+		opt, _ := optReq.(*logsRequest)
+		return mergeSplitLogs(ctx, maxItems, opt, r), nil
+	case *metricsRequest:
+		opt, _ := optReq.(*metricsRequest)
+		return mergeSplitMetrics(ctx, maxItems, opt, r), nil
+	case *tracesRequest:
+		opt, _ := optReq.(*tracesRequest)
+		return mergeSplitTraces(ctx, maxItems, opt, r), nil
+	}
+	return nil, fmt.Errorf("unsupported request type %T", req)
+}
@@ -0,0 +1,278 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/tag"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestBatcherSettingsValidate(t *testing.T) {
+	cfg := NewDefaultBatcherSettings()
+	assert.NoError(t, cfg.Validate())
+	cfg.Enabled = true
+	assert.NoError(t, cfg.Validate())
+
+	cfg.FlushTimeout = 0
+	assert.EqualError(t, cfg.Validate(), "batcher flush_timeout must be positive")
+	cfg.FlushTimeout = time.Second
+	cfg.MinSizeItems = -1
+	assert.EqualError(t, cfg.Validate(), "batcher min_size_items must not be negative")
+	cfg.MinSizeItems = 10
+	cfg.MaxSizeItems = -1
+	assert.EqualError(t, cfg.Validate(), "batcher max_size_items must not be negative")
+	cfg.MaxSizeItems = 5
+	assert.EqualError(t, cfg.Validate(), "batcher max_size_items must be greater than or equal to min_size_items")
+	cfg.MaxSizeItems = 10
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestBatchSenderMergesRequests(t *testing.T) {
+	cfg := BatcherSettings{Enabled: true, FlushTimeout: time.Minute, MinSizeItems: 4}
+	set, le, sink := newBatchLogsExporter(t, "merge", cfg)
+
+	consumeConcurrently(t, le, context.Background(), context.Background())
+	assert.Equal(t, []int{4}, sink.sizes())
+	checkBatchMetrics(t, set.ID, batchTriggerSize, 1, 4)
+	assert.True(t, le.Capabilities().MutatesData)
+}
+
+func TestBatchSenderFlushTimeout(t *testing.T) {
+	cfg := BatcherSettings{Enabled: true, FlushTimeout: 50 * time.Millisecond, MinSizeItems: 10}
+	set, le, sink := newBatchLogsExporter(t, "timeout", cfg)
+
+	start := time.Now()
+	require.NoError(t, le.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)))
+	assert.GreaterOrEqual(t, time.Since(start), cfg.FlushTimeout)
+	assert.Equal(t, []int{1}, sink.sizes())
+	checkBatchMetrics(t, set.ID, batchTriggerTimeout, 1, 1)
+}
+
+func TestBatchSenderSplitsRequests(t *testing.T) {
+	cfg := BatcherSettings{Enabled: true, FlushTimeout: time.Minute, MaxSizeItems: 3}
+	set, le, sink := newBatchLogsExporter(t, "split", cfg)
+
+	require.NoError(t, le.ConsumeLogs(context.Background(), testdata.GenerateLogs(7)))
+	assert.Equal(t, []int{3, 3, 1}, sink.sizes())
+	checkBatchMetrics(t, set.ID, batchTriggerSize, 3, 7)
+}
+
+func TestBatchSenderMetadataKeys(t *testing.T) {
+	cfg := BatcherSettings{Enabled: true, FlushTimeout: 500 * time.Millisecond, MinSizeItems: 4, MetadataKeys: []string{"tenant"}}
+	_, le, sink := newBatchLogsExporter(t, "metadata", cfg)
+
+	tenantCtx := func(tenant string) context.Context {
+		return client.NewContext(context.Background(), client.Info{
+			Metadata: client.NewMetadata(map[string][]string{"tenant": {tenant}}),
+		})
+	}
+	consumeConcurrently(t, le, tenantCtx("a"), tenantCtx("b"), tenantCtx("a"))
+
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	assert.ElementsMatch(t, []string{"a", "b"}, sink.tenants)
+	for i, tenant := range sink.tenants {
+		assert.Equal(t, map[string]int{"a": 4, "b": 2}[tenant], sink.batches[i])
+	}
+}
+
+func TestBatchSenderError(t *testing.T) {
+	want := errors.New("export failed")
+	cfg := BatcherSettings{Enabled: true, FlushTimeout: time.Minute, MinSizeItems: 4}
+	set := exportertest.NewNopCreateSettings()
+	set.ID = component.NewIDWithName("test", "batch-error")
+	le, err := NewLogsExporter(context.Background(), set, &fakeLogsExporterConfig, func(context.Context, plog.Logs) error {
+		return want
+	}, WithBatcher(cfg))
+	require.NoError(t, err)
+	require.NoError(t, le.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, le.Shutdown(context.Background())) })
+
+	// Every request of the batch gets its error.
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			errs <- le.ConsumeLogs(context.Background(), testdata.GenerateLogs(2))
+		}()
+	}
+	for i := 0; i < 2; i++ {
+		assert.ErrorIs(t, <-errs, want)
+	}
+}
+
+func TestBatchSenderShutdown(t *testing.T) {
+	cfg := BatcherSettings{Enabled: true, FlushTimeout: time.Hour, MinSizeItems: 10}
+	set, le, sink := newBatchLogsExporter(t, "shutdown", cfg)
+
+	done := make(chan error)
+	go func() {
+		done <- le.ConsumeLogs(context.Background(), testdata.GenerateLogs(1))
+	}()
+	bs := le.(*logsExporter).batchSender.(*batchSender)
+	assert.Eventually(t, func() bool {
+		bs.mu.Lock()
+		defer bs.mu.Unlock()
+		return len(bs.batches) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, le.Shutdown(context.Background()))
+	require.NoError(t, <-done)
+	assert.Equal(t, []int{1}, sink.sizes())
+	checkBatchMetrics(t, set.ID, batchTriggerShutdown, 1, 1)
+
+	// The requests are sent as they are once stopped.
+	require.NoError(t, le.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)))
+	assert.Equal(t, []int{1, 1}, sink.sizes())
+}
+
+func TestBatchSenderAfterQueue(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 2
+	cfg := BatcherSettings{Enabled: true, FlushTimeout: time.Minute, MinSizeItems: 4}
+	_, le, sink := newBatchLogsExporter(t, "queue", cfg, WithQueue(qCfg))
+
+	require.NoError(t, le.ConsumeLogs(context.Background(), testdata.GenerateLogs(2)))
+	require.NoError(t, le.ConsumeLogs(context.Background(), testdata.GenerateLogs(2)))
+	assert.Eventually(t, func() bool {
+		return len(sink.sizes()) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []int{4}, sink.sizes())
+}
+
+func TestBatchSenderRequestExporter(t *testing.T) {
+	cfg := BatcherSettings{Enabled: true, FlushTimeout: time.Minute, MinSizeItems: 4}
+	set := exportertest.NewNopCreateSettings()
+	set.ID = component.NewIDWithName("test", "batch-request")
+	le, err := NewLogsRequestExporter(context.Background(), set, &fakeRequestConverter{}, WithBatcher(cfg))
+	assert.Nil(t, le)
+	assert.ErrorIs(t, err, errNoRequestBatchFuncs)
+	_, err = NewTracesRequestExporter(context.Background(), set, &fakeRequestConverter{}, WithBatcher(cfg))
+	assert.ErrorIs(t, err, errNoRequestBatchFuncs)
+	_, err = NewMetricsRequestExporter(context.Background(), set, &fakeRequestConverter{}, WithBatcher(cfg))
+	assert.ErrorIs(t, err, errNoRequestBatchFuncs)
+
+	mergeSplit := func(_ context.Context, _ int, optReq Request, req Request) ([]Request, error) {
+		merged := req.(fakeRequest)
+		if optReq != nil {
+			merged.items += optReq.(fakeRequest).items
+		}
+		return []Request{merged}, nil
+	}
+	le, err = NewLogsRequestExporter(context.Background(), set, &fakeRequestConverter{},
+		WithBatcher(cfg, WithRequestBatchFuncs(mergeSplit)))
+	require.NoError(t, err)
+	require.NoError(t, le.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, le.Shutdown(context.Background())) })
+
+	consumeConcurrently(t, le, context.Background(), context.Background())
+	checkBatchMetrics(t, set.ID, batchTriggerSize, 1, 4)
+	assert.False(t, le.Capabilities().MutatesData)
+}
+
+func TestMergeSplitPdataRequests(t *testing.T) {
+	reqs, err := mergeSplitPdataRequests(context.Background(), 3,
+		newMetricsRequest(context.Background(), testdata.GenerateMetrics(2), nil),
+		newMetricsRequest(context.Background(), testdata.GenerateMetrics(3), nil))
+	require.NoError(t, err)
+	assertSplit(t, reqs, 3, testdata.GenerateMetrics(5).DataPointCount())
+
+	reqs, err = mergeSplitPdataRequests(context.Background(), 2, nil,
+		newTracesRequest(context.Background(), testdata.GenerateTraces(5), nil))
+	require.NoError(t, err)
+	assertSplit(t, reqs, 2, 5)
+
+	reqs, err = mergeSplitPdataRequests(context.Background(), 0,
+		newLogsRequest(context.Background(), testdata.GenerateLogs(5), nil),
+		newLogsRequest(context.Background(), testdata.GenerateLogs(6), nil))
+	require.NoError(t, err)
+	assertSplit(t, reqs, 11, 11)
+
+	_, err = mergeSplitPdataRequests(context.Background(), 0, nil, newRequest(context.Background(), fakeRequest{}))
+	assert.Error(t, err)
+}
+
+func assertSplit(t *testing.T, reqs []internal.Request, maxItems int, total int) {
+	count := 0
+	for i, req := range reqs {
+		if i < len(reqs)-1 {
+			assert.Equal(t, maxItems, req.Count())
+		} else {
+			assert.LessOrEqual(t, req.Count(), maxItems)
+		}
+		count += req.Count()
+	}
+	assert.Equal(t, total, count)
+}
+
+// batchLogsSink records the size and the tenant metadata of the batches of logs it received.
+type batchLogsSink struct {
+	mu      sync.Mutex
+	batches []int
+	tenants []string
+}
+
+func (s *batchLogsSink) push(ctx context.Context, ld plog.Logs) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batches = append(s.batches, ld.LogRecordCount())
+	tenant := ""
+	if values := client.FromContext(ctx).Metadata.Get("tenant"); len(values) > 0 {
+		tenant = values[0]
+	}
+	s.tenants = append(s.tenants, tenant)
+	return nil
+}
+
+func (s *batchLogsSink) sizes() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]int(nil), s.batches...)
+}
+
+func newBatchLogsExporter(t *testing.T, name string, cfg BatcherSettings, opts ...Option) (exporter.CreateSettings, exporter.Logs, *batchLogsSink) {
+	sink := &batchLogsSink{}
+	set := exportertest.NewNopCreateSettings()
+	set.ID = component.NewIDWithName("test", "batch-"+name)
+	le, err := NewLogsExporter(context.Background(), set, &fakeLogsExporterConfig, sink.push, append(opts, WithBatcher(cfg))...)
+	require.NoError(t, err)
+	require.NoError(t, le.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, le.Shutdown(context.Background())) })
+	return set, le, sink
+}
+
+// consumeConcurrently sends 2 log records with every context concurrently, and waits for all the sends to return.
+func consumeConcurrently(t *testing.T, le exporter.Logs, ctxs ...context.Context) {
+	var wg sync.WaitGroup
+	for _, ctx := range ctxs {
+		wg.Add(1)
+		go func(ctx context.Context) {
+			defer wg.Done()
+			assert.NoError(t, le.ConsumeLogs(ctx, testdata.GenerateLogs(2)))
+		}(ctx)
+	}
+	wg.Wait()
+}
+
+func checkBatchMetrics(t *testing.T, id component.ID, trigger string, batches int64, items int64) {
+	triggerTag, _ := tag.NewKey(batchTriggerKey)
+	tags := []tag.Tag{{Key: exporterTag, Value: id.String()}, {Key: triggerTag, Value: trigger}}
+	checkValueForGlobalManager(t, tags, batches, "exporter/batches_sent")
+	checkValueForGlobalManager(t, tags, items, "exporter/batch_sent_items")
+}
@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// CircuitBreakerSettings defines the configuration of the circuit breaker of an exporter, which stops sending
+// data to a degraded backend once too many attempts failed, instead of retrying them. While the circuit breaker
+// is open, the batches wait in the exporter, holding back the sending queue, until a few probe batches succeed.
+type CircuitBreakerSettings struct {
+	// Enabled indicates whether the circuit breaker is enabled. It is only used if the retries are enabled.
+	Enabled bool `mapstructure:"enabled"`
+	// FailureRateThreshold is the ratio of failed attempts, among the last WindowSize attempts, opening the circuit breaker.
+	FailureRateThreshold float64 `mapstructure:"failure_rate_threshold"`
+	// WindowSize is the number of the last attempts the failure rate is computed on. The circuit breaker
+	// cannot open before WindowSize attempts were made.
+	WindowSize int `mapstructure:"window_size"`
+	// OpenDuration is the time the circuit breaker stays open before letting probe batches through.
+	OpenDuration time.Duration `mapstructure:"open_duration"`
+	// HalfOpenProbes is the number of probe batches which must succeed to close the circuit breaker.
+	// A failed probe opens the circuit breaker again.
+	HalfOpenProbes int `mapstructure:"half_open_probes"`
+}
+
+// NewDefaultCircuitBreakerSettings returns the default settings for CircuitBreakerSettings.
+func NewDefaultCircuitBreakerSettings() CircuitBreakerSettings {
+	return CircuitBreakerSettings{
+		Enabled:              false,
+		FailureRateThreshold: 0.5,
+		WindowSize:           20,
+		OpenDuration:         30 * time.Second,
+		HalfOpenProbes:       3,
+	}
+}
+
+// Validate checks if the CircuitBreakerSettings configuration is valid.
+func (cfg *CircuitBreakerSettings) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.FailureRateThreshold <= 0 || cfg.FailureRateThreshold > 1 {
+		return errors.New("circuit breaker failure_rate_threshold must be in the range (0, 1]")
+	}
+	if cfg.WindowSize <= 0 {
+		return errors.New("circuit breaker window_size must be positive")
+	}
+	if cfg.OpenDuration <= 0 {
+		return errors.New("circuit breaker open_duration must be positive")
+	}
+	if cfg.HalfOpenProbes <= 0 {
+		return errors.New("circuit breaker half_open_probes must be positive")
+	}
+	return nil
+}
+
+// CircuitBreakerState is the state of the circuit breaker of an exporter.
+type CircuitBreakerState int
+
+const (
+	// CircuitBreakerClosed means the batches are sent.
+	CircuitBreakerClosed CircuitBreakerState = iota
+	// CircuitBreakerOpen means the batches wait for the circuit breaker to let probe batches through.
+	CircuitBreakerOpen
+	// CircuitBreakerHalfOpen means the probe batches are sent, the other batches waiting for their outcome.
+	CircuitBreakerHalfOpen
+)
+
+// String returns the string representation of the CircuitBreakerState.
+func (s CircuitBreakerState) String() string {
+	switch s {
+	case CircuitBreakerClosed:
+		return "closed"
+	case CircuitBreakerOpen:
+		return "open"
+	case CircuitBreakerHalfOpen:
+		return "half_open"
+	}
+	return ""
+}
+
+var errCircuitBreakerOpen = errors.New("circuit breaker is open")
+
+// circuitBreaker tracks the outcome of the last attempts to send data to the backend.
+type circuitBreaker struct {
+	cfg CircuitBreakerSettings
+	// onClose is called when the circuit breaker closes, to send the waiting batches.
+	onClose func()
+
+	mu    sync.Mutex
+	state CircuitBreakerState
+	// outcomes is the ring buffer of the outcomes of the last attempts, true for a failure.
+	outcomes []bool
+	next     int
+	attempts int
+	failures int
+	// openUntil is the time the circuit breaker lets the probe batches through.
+	openUntil time.Time
+	// probes and succeededProbes are the number of probe batches let through and succeeded while half-open.
+	probes          int
+	succeededProbes int
+}
+
+func newCircuitBreaker(cfg CircuitBreakerSettings, onClose func()) *circuitBreaker {
+	return &circuitBreaker{
+		cfg:      cfg,
+		onClose:  onClose,
+		outcomes: make([]bool, cfg.WindowSize),
+	}
+}
+
+// allow returns whether a batch can be sent now, and otherwise the time to wait until before asking again.
+func (cb *circuitBreaker) allow(now time.Time) (bool, time.Time) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if cb.state == CircuitBreakerOpen {
+		if now.Before(cb.openUntil) {
+			return false, cb.openUntil
+		}
+		cb.state = CircuitBreakerHalfOpen
+		cb.probes = 0
+		cb.succeededProbes = 0
+	}
+	if cb.state == CircuitBreakerHalfOpen {
+		if cb.probes >= cb.cfg.HalfOpenProbes {
+			// Wait for the outcome of the probes: the waiting batches are sent once the circuit breaker closes,
+			// or wait for another open duration if a probe fails.
+			return false, now.Add(cb.cfg.OpenDuration)
+		}
+		cb.probes++
+	}
+	return true, time.Time{}
+}
+
+// record records the outcome of an attempt allowed by allow.
+func (cb *circuitBreaker) record(now time.Time, failed bool) {
+	cb.mu.Lock()
+	closed := false
+	switch cb.state {
+	case CircuitBreakerClosed:
+		if cb.attempts == len(cb.outcomes) {
+			if cb.outcomes[cb.next] {
+				cb.failures--
+			}
+		} else {
+			cb.attempts++
+		}
+		cb.outcomes[cb.next] = failed
+		cb.next = (cb.next + 1) % len(cb.outcomes)
+		if failed {
+			cb.failures++
+		}
+		if cb.attempts == len(cb.outcomes) && float64(cb.failures) >= cb.cfg.FailureRateThreshold*float64(cb.attempts) {
+			cb.open(now)
+		}
+	case CircuitBreakerHalfOpen:
+		if failed {
+			cb.open(now)
+			break
+		}
+		cb.succeededProbes++
+		if cb.succeededProbes >= cb.cfg.HalfOpenProbes {
+			cb.state = CircuitBreakerClosed
+			cb.attempts, cb.failures, cb.next = 0, 0, 0
+			closed = true
+		}
+	}
+	cb.mu.Unlock()
+
+	if closed && cb.onClose != nil {
+		cb.onClose()
+	}
+}
+
+func (cb *circuitBreaker) open(now time.Time) {
+	cb.state = CircuitBreakerOpen
+	cb.openUntil = now.Add(cb.cfg.OpenDuration)
+}
+
+func (cb *circuitBreaker) currentState() CircuitBreakerState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.state
+}
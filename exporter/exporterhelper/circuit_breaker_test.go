@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+)
+
+func TestCircuitBreakerSettingsValidate(t *testing.T) {
+	cfg := NewDefaultCircuitBreakerSettings()
+	assert.NoError(t, cfg.Validate())
+	cfg.Enabled = true
+	assert.NoError(t, cfg.Validate())
+
+	cfg.FailureRateThreshold = 1.5
+	assert.EqualError(t, cfg.Validate(), "circuit breaker failure_rate_threshold must be in the range (0, 1]")
+	cfg = NewDefaultCircuitBreakerSettings()
+	cfg.Enabled = true
+	cfg.WindowSize = 0
+	assert.EqualError(t, cfg.Validate(), "circuit breaker window_size must be positive")
+	cfg = NewDefaultCircuitBreakerSettings()
+	cfg.Enabled = true
+	cfg.OpenDuration = 0
+	assert.EqualError(t, cfg.Validate(), "circuit breaker open_duration must be positive")
+	cfg = NewDefaultCircuitBreakerSettings()
+	cfg.Enabled = true
+	cfg.HalfOpenProbes = 0
+	assert.EqualError(t, cfg.Validate(), "circuit breaker half_open_probes must be positive")
+
+	// The settings of a disabled circuit breaker are not validated.
+	cfg.Enabled = false
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestCircuitBreakerStates(t *testing.T) {
+	closed := 0
+	cb := newCircuitBreaker(CircuitBreakerSettings{
+		Enabled:              true,
+		FailureRateThreshold: 0.5,
+		WindowSize:           4,
+		OpenDuration:         time.Minute,
+		HalfOpenProbes:       2,
+	}, func() { closed++ })
+	now := time.Now()
+
+	// The circuit breaker does not open before the window is full.
+	for _, failed := range []bool{true, false, false} {
+		ok, _ := cb.allow(now)
+		require.True(t, ok)
+		cb.record(now, failed)
+	}
+	assert.Equal(t, CircuitBreakerClosed, cb.currentState())
+
+	// The oldest outcomes leave the window: 2 failures out of the last 4 attempts open it.
+	cb.record(now, false)
+	assert.Equal(t, CircuitBreakerClosed, cb.currentState())
+	cb.record(now, true)
+	assert.Equal(t, CircuitBreakerClosed, cb.currentState())
+	cb.record(now, true)
+	assert.Equal(t, CircuitBreakerOpen, cb.currentState())
+
+	ok, retryAt := cb.allow(now.Add(time.Second))
+	assert.False(t, ok)
+	assert.Equal(t, now.Add(time.Minute), retryAt)
+
+	// Once the open duration elapsed, the probes are let through, and a failed probe opens it again.
+	now = now.Add(time.Minute)
+	ok, _ = cb.allow(now)
+	require.True(t, ok)
+	assert.Equal(t, CircuitBreakerHalfOpen, cb.currentState())
+	cb.record(now, true)
+	assert.Equal(t, CircuitBreakerOpen, cb.currentState())
+
+	now = now.Add(time.Minute)
+	for i := 0; i < 2; i++ {
+		ok, _ = cb.allow(now)
+		require.True(t, ok)
+	}
+	// The other batches wait for the outcome of the probes.
+	ok, _ = cb.allow(now)
+	assert.False(t, ok)
+	cb.record(now, false)
+	assert.Equal(t, CircuitBreakerHalfOpen, cb.currentState())
+	assert.Zero(t, closed)
+	cb.record(now, false)
+	assert.Equal(t, CircuitBreakerClosed, cb.currentState())
+	assert.Equal(t, 1, closed)
+
+	// The window starts over once closed.
+	ok, _ = cb.allow(now)
+	require.True(t, ok)
+	cb.record(now, true)
+	assert.Equal(t, CircuitBreakerClosed, cb.currentState())
+}
+
+func TestRetrySenderCircuitBreaker(t *testing.T) {
+	id := component.NewIDWithName("test", "circuit_breaker")
+	rCfg := NewDefaultRetrySettings()
+	rCfg.InitialInterval = time.Millisecond
+	rCfg.RandomizationFactor = 0
+	rCfg.MaxElapsedTime = 0
+	rCfg.CircuitBreaker = CircuitBreakerSettings{
+		Enabled:              true,
+		FailureRateThreshold: 1,
+		WindowSize:           2,
+		OpenDuration:         100 * time.Millisecond,
+		HalfOpenProbes:       1,
+	}
+	rs := newRetrySender(id, rCfg, zap.NewNop(), nil)
+	next := &flakyRequestSender{}
+	next.failing.Store(true)
+	rs.setNextSender(next)
+	t.Cleanup(rs.shutdown)
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- rs.send(newMockRequest(context.Background(), 1, nil)) }()
+
+	// After 2 failed attempts, the request is held instead of being retried.
+	assert.Eventually(t, func() bool {
+		return rs.breaker.currentState() == CircuitBreakerOpen
+	}, time.Second, time.Millisecond)
+	checkValueForGlobalManager(t, []tag.Tag{{Key: exporterTag, Value: id.String()}}, 1, "exporter/circuit_breaker_state")
+	assert.EqualValues(t, 2, next.attempts.Load())
+
+	// The probe succeeds once the backend recovered.
+	next.failing.Store(false)
+	require.NoError(t, <-errCh)
+	assert.EqualValues(t, 3, next.attempts.Load())
+	assert.Equal(t, CircuitBreakerClosed, rs.breaker.currentState())
+	checkValueForGlobalManager(t, []tag.Tag{{Key: exporterTag, Value: id.String()}}, 0, "exporter/circuit_breaker_state")
+
+	// The permanent errors are not failures of the backend.
+	next.err = consumererror.NewPermanent(errors.New("bad data"))
+	next.failing.Store(true)
+	for i := 0; i < 3; i++ {
+		assert.Error(t, rs.send(newMockRequest(context.Background(), 1, nil)))
+	}
+	assert.Equal(t, CircuitBreakerClosed, rs.breaker.currentState())
+}
+
+func TestRetrySenderCircuitBreakerInterruptedOnShutdown(t *testing.T) {
+	rCfg := NewDefaultRetrySettings()
+	rCfg.InitialInterval = time.Millisecond
+	rCfg.CircuitBreaker = CircuitBreakerSettings{
+		Enabled:              true,
+		FailureRateThreshold: 1,
+		WindowSize:           1,
+		OpenDuration:         time.Hour,
+		HalfOpenProbes:       1,
+	}
+	rs := newRetrySender(component.NewID("test"), rCfg, zap.NewNop(), nil)
+	next := &flakyRequestSender{}
+	next.failing.Store(true)
+	rs.setNextSender(next)
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- rs.send(newMockRequest(context.Background(), 1, nil)) }()
+	assert.Eventually(t, func() bool {
+		st, _, lastErr := rs.backoffs.status()
+		return st == 1 && lastErr == errCircuitBreakerOpen.Error()
+	}, time.Second, time.Millisecond)
+
+	rs.shutdown()
+	err := <-errCh
+	assert.True(t, consumererror.IsShutdown(err))
+	assert.ErrorIs(t, err, errCircuitBreakerOpen)
+	assert.EqualValues(t, 1, next.attempts.Load())
+}
+
+// flakyRequestSender fails while failing is set.
+type flakyRequestSender struct {
+	baseRequestSender
+	failing  atomic.Bool
+	attempts atomic.Int64
+	err      error
+}
+
+func (fs *flakyRequestSender) send(internal.Request) error {
+	fs.attempts.Add(1)
+	if !fs.failing.Load() {
+		return nil
+	}
+	if fs.err != nil {
+		return fs.err
+	}
+	return errors.New("transient error")
+}
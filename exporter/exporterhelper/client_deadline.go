@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ClientDeadlineSettings defines how the deadline set by the client on the incoming request, e.g. a gRPC
+// deadline, is carried to the data placed in the sending queue. The queued data is dropped instead of being
+// sent or retried once the deadline expired, the client having already given up on it.
+type ClientDeadlineSettings struct {
+	// Enabled carries the deadline of the incoming request to the queued data.
+	// Data received without a deadline is not affected.
+	Enabled bool `mapstructure:"enabled"`
+	// MinTimeout is the minimum time given to the queued data to be sent, extending the deadline of the clients
+	// setting a shorter one. This prevents the data from being dropped only because of the time spent in the queue.
+	MinTimeout time.Duration `mapstructure:"min_timeout"`
+}
+
+// Validate checks if the ClientDeadlineSettings configuration is valid
+func (dCfg *ClientDeadlineSettings) Validate() error {
+	if dCfg.MinTimeout < 0 {
+		return errors.New("client deadline min_timeout must not be negative")
+	}
+	return nil
+}
+
+// deadline returns the deadline to set on the data queued with the given context of the incoming request,
+// if any.
+func (dCfg *ClientDeadlineSettings) deadline(ctx context.Context, now time.Time) (time.Time, bool) {
+	if !dCfg.Enabled {
+		return time.Time{}, false
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return time.Time{}, false
+	}
+	if minDeadline := now.Add(dCfg.MinTimeout); deadline.Before(minDeadline) {
+		deadline = minDeadline
+	}
+	return deadline, true
+}
+
+// clientDeadlineKey marks the context of the queued data carrying the deadline of the client.
+type clientDeadlineKey struct{}
+
+// contextWithClientDeadline returns the context of the queued data carrying the deadline of the client.
+func contextWithClientDeadline(ctx context.Context, deadline time.Time) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	return context.WithValue(ctx, clientDeadlineKey{}, true), cancel
+}
+
+// hasClientDeadline tells whether the context carries the deadline of the client.
+func hasClientDeadline(ctx context.Context) bool {
+	ok, _ := ctx.Value(clientDeadlineKey{}).(bool)
+	return ok
+}
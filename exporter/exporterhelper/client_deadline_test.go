@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+func TestClientDeadlineSettings_Validate(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.ClientDeadline = ClientDeadlineSettings{Enabled: true, MinTimeout: time.Second}
+	assert.NoError(t, qCfg.Validate())
+
+	qCfg.ClientDeadline.MinTimeout = -time.Second
+	assert.EqualError(t, qCfg.Validate(), "client deadline min_timeout must not be negative")
+
+	storageID := component.NewID("storage")
+	qCfg.ClientDeadline.MinTimeout = 0
+	qCfg.StorageID = &storageID
+	assert.EqualError(t, qCfg.Validate(), "client deadline is not supported by the persistent queue")
+}
+
+func TestClientDeadlineSettings_Deadline(t *testing.T) {
+	now := time.Now()
+	ctx, cancel := context.WithDeadline(context.Background(), now.Add(time.Second))
+	t.Cleanup(cancel)
+
+	tests := []struct {
+		name     string
+		cfg      ClientDeadlineSettings
+		ctx      context.Context
+		deadline time.Time
+		ok       bool
+	}{
+		{
+			name: "disabled",
+			cfg:  ClientDeadlineSettings{},
+			ctx:  ctx,
+		},
+		{
+			name: "no_deadline",
+			cfg:  ClientDeadlineSettings{Enabled: true},
+			ctx:  context.Background(),
+		},
+		{
+			name:     "client_deadline",
+			cfg:      ClientDeadlineSettings{Enabled: true, MinTimeout: 100 * time.Millisecond},
+			ctx:      ctx,
+			deadline: now.Add(time.Second),
+			ok:       true,
+		},
+		{
+			name:     "min_timeout",
+			cfg:      ClientDeadlineSettings{Enabled: true, MinTimeout: time.Minute},
+			ctx:      ctx,
+			deadline: now.Add(time.Minute),
+			ok:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deadline, ok := tt.cfg.deadline(tt.ctx, now)
+			assert.Equal(t, tt.ok, ok)
+			assert.True(t, tt.deadline.Equal(deadline))
+		})
+	}
+}
+
+func TestQueuedRetry_ClientDeadlineExpired(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	qCfg.ClientDeadline = ClientDeadlineSettings{Enabled: true}
+	rCfg := NewDefaultRetrySettings()
+	be, err := newBaseExporter(defaultSettings, "", false, nil, nil, newObservabilityConsumerSender, WithRetry(rCfg), WithQueue(qCfg))
+	require.NoError(t, err)
+	ocs := be.obsrepSender.(*observabilityConsumerSender)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	t.Cleanup(cancel)
+	mockR := newMockRequest(ctx, 2, nil)
+	ocs.run(func() {
+		// This is asynchronous so it should just enqueue, no errors expected.
+		require.NoError(t, be.send(mockR))
+	})
+	ocs.awaitAsyncProcessing()
+
+	// The client already gave up on the data, so it is not sent.
+	mockR.checkNumRequests(t, 0)
+	ocs.checkSendItemsCount(t, 0)
+	ocs.checkDroppedItemsCount(t, 2)
+}
+
+func TestQueuedRetry_ClientDeadlineMinTimeout(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	qCfg.ClientDeadline = ClientDeadlineSettings{Enabled: true, MinTimeout: time.Minute}
+	be, err := newBaseExporter(defaultSettings, "", false, nil, nil, newObservabilityConsumerSender, WithQueue(qCfg))
+	require.NoError(t, err)
+	ocs := be.obsrepSender.(*observabilityConsumerSender)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	t.Cleanup(cancel)
+	mockR := newMockRequest(ctx, 2, nil)
+	ocs.run(func() {
+		// This is asynchronous so it should just enqueue, no errors expected.
+		require.NoError(t, be.send(mockR))
+	})
+	ocs.awaitAsyncProcessing()
+
+	mockR.checkNumRequests(t, 1)
+	ocs.checkSendItemsCount(t, 2)
+	ocs.checkDroppedItemsCount(t, 0)
+}
+
+func TestTimeoutSender_ClientDeadline(t *testing.T) {
+	ts := &timeoutSender{cfg: NewDefaultTimeoutSettings()}
+
+	// Without client_deadline, the expired requests are still passed to the exporter, which reports the error.
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	t.Cleanup(cancel)
+	mockR := newMockRequest(ctx, 2, nil)
+	err := ts.send(mockR)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.False(t, consumererror.IsPermanent(err))
+	mockR.checkNumRequests(t, 1)
+
+	ctx, cancel = contextWithClientDeadline(context.Background(), time.Now().Add(-time.Second))
+	t.Cleanup(cancel)
+	assert.True(t, hasClientDeadline(ctx))
+	mockR = newMockRequest(ctx, 2, nil)
+	err = ts.send(mockR)
+	assert.True(t, consumererror.IsPermanent(err))
+	mockR.checkNumRequests(t, 0)
+}
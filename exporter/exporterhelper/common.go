@@ -119,15 +119,35 @@ func WithQueue(config QueueSettings) Option {
 			if config.StorageID == nil {
 				queue = internal.NewBoundedMemoryQueue(config.QueueSize, config.NumConsumers)
 			} else {
-				queue = internal.NewPersistentQueue(config.QueueSize, config.NumConsumers, *config.StorageID, o.marshaler, o.unmarshaler)
+				queue = internal.NewPersistentQueue(config.QueueSize, config.NumConsumers, *config.StorageID, o.marshaler, o.unmarshaler,
+					internal.PersistentQueueSettings{
+						MaxBytes:           uint64(config.MaxBytes),
+						CompactionInterval: config.CompactionInterval,
+					})
 			}
 		}
 		qs := newQueueSender(o.set.ID, o.signal, queue, o.sampledLogger)
+		if queue != nil && config.Tenant.MetadataKey != "" {
+			qs.tenants = newTenantQuotas(o.set.ID.String(), config.Tenant)
+		}
+		qs.clientDeadline = config.ClientDeadline
+		qs.overflowPolicy = config.OverflowPolicy
+		qs.numConsumers = config.NumConsumers
 		o.queueSender = qs
 		o.setOnTemporaryFailure(qs.onTemporaryFailure)
 	}
 }
 
+// WithTemporality enables the conversion of the aggregation temporality of the metrics before they are sent.
+// The default TemporalitySettings is to send the metrics unchanged. This option only applies to the metrics exporters.
+func WithTemporality(config TemporalitySettings) Option {
+	return func(o *baseExporter) {
+		if config.Temporality != "" {
+			o.temporality = newTemporalityConverter(config)
+		}
+	}
+}
+
 // WithCapabilities overrides the default Capabilities() function for a Consumer.
 // The default is non-mutable data.
 // TODO: Verify if we can change the default to be mutable as we do for processors.
@@ -155,6 +175,7 @@ type baseExporter struct {
 	// The data is handled by each sender in the respective order starting from the queueSender.
 	// Most of the senders are optional, and initialized with a no-op path-through sender.
 	queueSender   requestSender
+	batchSender   requestSender
 	obsrepSender  requestSender
 	retrySender   requestSender
 	timeoutSender *timeoutSender // timeoutSender is always initialized.
@@ -162,7 +183,13 @@ type baseExporter struct {
 	// onTemporaryFailure is a function that is called when the retrySender is unable to send data to the next consumer.
 	onTemporaryFailure onRequestHandlingFinishedFunc
 
+	// temporality converts the temporality of the metrics before they are sent, nil if disabled.
+	temporality *temporalityConverter
+
 	consumerOptions []consumer.Option
+
+	// optionsErr is the error of the invalid options, returned by the exporter helper.
+	optionsErr error
 }
 
 // TODO: requestExporter, marshaler, and unmarshaler arguments can be removed when the old exporter helpers will be updated to call the new ones.
@@ -181,6 +208,7 @@ func newBaseExporter(set exporter.CreateSettings, signal component.DataType, req
 		signal:          signal,
 
 		queueSender:   &baseRequestSender{},
+		batchSender:   &baseRequestSender{},
 		obsrepSender:  osf(obsrep),
 		retrySender:   &baseRequestSender{},
 		timeoutSender: &timeoutSender{cfg: NewDefaultTimeoutSettings()},
@@ -193,6 +221,9 @@ func newBaseExporter(set exporter.CreateSettings, signal component.DataType, req
 	for _, op := range options {
 		op(be)
 	}
+	if be.optionsErr != nil {
+		return nil, be.optionsErr
+	}
 	be.connectSenders()
 
 	return be, nil
@@ -205,7 +236,8 @@ func (be *baseExporter) send(req internal.Request) error {
 
 // connectSenders connects the senders in the predefined order.
 func (be *baseExporter) connectSenders() {
-	be.queueSender.setNextSender(be.obsrepSender)
+	be.queueSender.setNextSender(be.batchSender)
+	be.batchSender.setNextSender(be.obsrepSender)
 	be.obsrepSender.setNextSender(be.retrySender)
 	be.retrySender.setNextSender(be.timeoutSender)
 }
@@ -220,6 +252,16 @@ func (be *baseExporter) Start(ctx context.Context, host component.Host) error {
 	return be.queueSender.start(ctx, host, be.set)
 }
 
+// Flush retries immediately the requests waiting for their retry backoff to expire,
+// instead of waiting for the backoff. The requests in the sending queue are already
+// sent as soon as a consumer is available.
+func (be *baseExporter) Flush(context.Context) error {
+	if rs, ok := be.retrySender.(*retrySender); ok {
+		rs.backoffs.nudge()
+	}
+	return nil
+}
+
 func (be *baseExporter) Shutdown(ctx context.Context) error {
 	// First shutdown the retry sender, so it can push any pending requests to back the queue.
 	be.retrySender.shutdown()
@@ -227,6 +269,9 @@ func (be *baseExporter) Shutdown(ctx context.Context) error {
 	// Then shutdown the queue sender.
 	be.queueSender.shutdown()
 
+	// Then send the batches being filled, once the queue is drained.
+	be.batchSender.shutdown()
+
 	// Last shutdown the wrapped exporter itself.
 	return be.ShutdownFunc.Shutdown(ctx)
 }
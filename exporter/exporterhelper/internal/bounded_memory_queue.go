@@ -15,7 +15,7 @@ import (
 
 // boundedMemoryQueue implements a producer-consumer exchange similar to a ring buffer queue,
 // where the queue is bounded and if it fills up due to slow consumers, the new items written by
-// the producer are dropped.
+// the producer are dropped, or the oldest items are evicted if an OnEvicted callback is set.
 type boundedMemoryQueue struct {
 	stopWG       sync.WaitGroup
 	size         *atomic.Uint32
@@ -23,6 +23,7 @@ type boundedMemoryQueue struct {
 	items        chan Request
 	capacity     uint32
 	numConsumers int
+	onEvicted    func(item Request)
 }
 
 // NewBoundedMemoryQueue constructs the new queue of specified capacity, and with an optional
@@ -40,6 +41,7 @@ func NewBoundedMemoryQueue(capacity int, numConsumers int) ProducerConsumerQueue
 // StartConsumers starts a given number of goroutines consuming items from the queue
 // and passing them into the consumer callback.
 func (q *boundedMemoryQueue) Start(_ context.Context, _ component.Host, set QueueSettings) error {
+	q.onEvicted = set.OnEvicted
 	var startWG sync.WaitGroup
 	for i := 0; i < q.numConsumers; i++ {
 		q.stopWG.Add(1)
@@ -57,27 +59,44 @@ func (q *boundedMemoryQueue) Start(_ context.Context, _ component.Host, set Queu
 	return nil
 }
 
-// Produce is used by the producer to submit new item to the queue. Returns false in case of queue overflow.
+// Produce is used by the producer to submit new item to the queue. Returns false in case of queue overflow,
+// unless the oldest items are evicted to make room for the new item.
 func (q *boundedMemoryQueue) Produce(item Request) bool {
-	if q.stopped.Load() {
-		return false
-	}
+	for {
+		if q.stopped.Load() {
+			return false
+		}
 
-	// we might have two concurrent backing queues at the moment
-	// their combined size is stored in q.size, and their combined capacity
-	// should match the capacity of the new queue
-	if q.size.Load() >= q.capacity {
-		return false
+		// we might have two concurrent backing queues at the moment
+		// their combined size is stored in q.size, and their combined capacity
+		// should match the capacity of the new queue
+		if q.size.Load() < q.capacity {
+			q.size.Add(1)
+			select {
+			case q.items <- item:
+				return true
+			default:
+				// should not happen, as overflows should have been captured earlier
+				q.size.Add(^uint32(0))
+			}
+		}
+
+		if q.onEvicted == nil {
+			return false
+		}
+		q.evictOldest()
 	}
+}
 
-	q.size.Add(1)
+// evictOldest removes the oldest item of the queue, unless a consumer took it meanwhile.
+func (q *boundedMemoryQueue) evictOldest() {
 	select {
-	case q.items <- item:
-		return true
+	case item, ok := <-q.items:
+		if ok {
+			q.size.Add(^uint32(0))
+			q.onEvicted(item)
+		}
 	default:
-		// should not happen, as overflows should have been captured earlier
-		q.size.Add(^uint32(0))
-		return false
 	}
 }
 
@@ -105,6 +105,29 @@ func TestBoundedQueue(t *testing.T) {
 	})
 }
 
+func TestBoundedQueueDropOldest(t *testing.T) {
+	// No consumer, the items stay in the queue.
+	q := NewBoundedMemoryQueue(2, 0)
+	var evicted []string
+	set := newNopQueueSettings(func(Request) {})
+	set.OnEvicted = func(item Request) {
+		evicted = append(evicted, item.(stringRequest).str)
+	}
+	require.NoError(t, q.Start(context.Background(), componenttest.NewNopHost(), set))
+
+	assert.True(t, q.Produce(newStringRequest("a")))
+	assert.True(t, q.Produce(newStringRequest("b")))
+	assert.Empty(t, evicted)
+	assert.True(t, q.Produce(newStringRequest("c")))
+	assert.True(t, q.Produce(newStringRequest("d")))
+	assert.Equal(t, []string{"a", "b"}, evicted)
+	assert.Equal(t, 2, q.Size())
+
+	q.Stop()
+	assert.False(t, q.Produce(newStringRequest("x")), "cannot push to closed queue")
+	assert.Equal(t, []string{"a", "b"}, evicted)
+}
+
 // In this test we run a queue with many items and a slow consumer.
 // When the queue is stopped, the remaining items should be processed.
 // Due to the way q.Stop() waits for all consumers to finish, the
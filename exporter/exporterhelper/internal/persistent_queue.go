@@ -8,6 +8,9 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
+
+	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension/experimental/storage"
@@ -22,17 +25,54 @@ var (
 	errWrongExtensionType = errors.New("requested extension is not a storage extension")
 )
 
+// RestoreStats holds the statistics of the restore of the items found in the storage of a persistent queue
+// when it started, e.g. after a restart of the collector.
+type RestoreStats struct {
+	// RestoredItems is the number of items found in the storage, including the items which were being
+	// dispatched when the queue stopped.
+	RestoredItems uint64
+	// CorruptedItems is the number of restored items which could not be read and were skipped so far.
+	CorruptedItems uint64
+	// Duration is the time spent restoring the items when the queue started.
+	Duration time.Duration
+}
+
+// RestoringQueue is implemented by the queues restoring their items when they start.
+type RestoringQueue interface {
+	// RestoreStats returns the statistics of the restore of the items when the queue started.
+	RestoreStats() RestoreStats
+	// ReplayBacklog returns the number of restored items which were not dispatched yet.
+	ReplayBacklog() int
+}
+
+// BlockingQueue is implemented by the queues able to wait for room when they are full.
+type BlockingQueue interface {
+	// ProduceContext adds an item to the queue, waiting for room while the queue is full until the context
+	// is done or the queue is stopped. Returns false if the item wasn't added to the queue.
+	ProduceContext(ctx context.Context, item Request) bool
+}
+
+// PersistentQueueSettings defines the limits and the maintenance of the storage of a persistent queue.
+type PersistentQueueSettings struct {
+	// MaxBytes is the maximum total size of the items waiting to be dispatched, unlimited if zero.
+	MaxBytes uint64
+	// CompactionInterval is the interval between the compactions of the storage, disabled if zero.
+	CompactionInterval time.Duration
+}
+
 // persistentQueue holds the queue backed by file storage
 type persistentQueue struct {
-	stopWG       sync.WaitGroup
-	stopOnce     sync.Once
-	stopChan     chan struct{}
-	storageID    component.ID
-	storage      *persistentContiguousStorage
-	capacity     uint64
-	numConsumers int
-	marshaler    RequestMarshaler
-	unmarshaler  RequestUnmarshaler
+	stopWG             sync.WaitGroup
+	stopOnce           sync.Once
+	stopChan           chan struct{}
+	storageID          component.ID
+	storage            *persistentContiguousStorage
+	capacity           uint64
+	maxBytes           uint64
+	compactionInterval time.Duration
+	numConsumers       int
+	marshaler          RequestMarshaler
+	unmarshaler        RequestUnmarshaler
 }
 
 // buildPersistentStorageName returns a name that is constructed out of queue name and signal type. This is done
@@ -43,14 +83,16 @@ func buildPersistentStorageName(name string, signal component.DataType) string {
 
 // NewPersistentQueue creates a new queue backed by file storage; name and signal must be a unique combination that identifies the queue storage
 func NewPersistentQueue(capacity int, numConsumers int, storageID component.ID, marshaler RequestMarshaler,
-	unmarshaler RequestUnmarshaler) ProducerConsumerQueue {
+	unmarshaler RequestUnmarshaler, set PersistentQueueSettings) ProducerConsumerQueue {
 	return &persistentQueue{
-		capacity:     uint64(capacity),
-		numConsumers: numConsumers,
-		storageID:    storageID,
-		marshaler:    marshaler,
-		unmarshaler:  unmarshaler,
-		stopChan:     make(chan struct{}),
+		capacity:           uint64(capacity),
+		maxBytes:           set.MaxBytes,
+		compactionInterval: set.CompactionInterval,
+		numConsumers:       numConsumers,
+		storageID:          storageID,
+		marshaler:          marshaler,
+		unmarshaler:        unmarshaler,
+		stopChan:           make(chan struct{}),
 	}
 }
 
@@ -61,7 +103,11 @@ func (pq *persistentQueue) Start(ctx context.Context, host component.Host, set Q
 		return err
 	}
 	storageName := buildPersistentStorageName(set.ID.Name(), set.DataType)
-	pq.storage = newPersistentContiguousStorage(ctx, storageName, storageClient, set.Logger, pq.capacity, pq.marshaler, pq.unmarshaler)
+	pq.storage = newPersistentContiguousStorage(ctx, storageName, storageClient, set.Logger, pq.capacity, pq.maxBytes, pq.marshaler, pq.unmarshaler)
+	// Set once the items found in the storage are restored, so that they are not evicted by each other.
+	pq.storage.mu.Lock()
+	pq.storage.onEvicted = set.OnEvicted
+	pq.storage.mu.Unlock()
 	for i := 0; i < pq.numConsumers; i++ {
 		pq.stopWG.Add(1)
 		go func() {
@@ -76,12 +122,50 @@ func (pq *persistentQueue) Start(ctx context.Context, host component.Host, set Q
 			}
 		}()
 	}
+	if pq.compactionInterval > 0 {
+		if _, ok := storageClient.(storage.Compactor); ok {
+			pq.stopWG.Add(1)
+			go pq.compactPeriodically(set)
+		} else {
+			set.Logger.Warn("The storage extension does not support compaction, ignoring the compaction interval",
+				zap.String(zapQueueNameKey, storageName))
+		}
+	}
 	return nil
 }
 
+// compactPeriodically compacts the storage at the compaction interval until the queue is stopped.
+func (pq *persistentQueue) compactPeriodically(set QueueSettings) {
+	defer pq.stopWG.Done()
+	ticker := time.NewTicker(pq.compactionInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			start := time.Now()
+			if err := pq.storage.compact(context.Background()); err != nil {
+				set.Logger.Warn("Failed compacting the persistent queue storage",
+					zap.String(zapQueueNameKey, pq.storage.queueName), zap.Error(err))
+				continue
+			}
+			set.Logger.Debug("Compacted the persistent queue storage",
+				zap.String(zapQueueNameKey, pq.storage.queueName), zap.Duration(zapDuration, time.Since(start)))
+		case <-pq.stopChan:
+			return
+		}
+	}
+}
+
 // Produce adds an item to the queue and returns true if it was accepted
 func (pq *persistentQueue) Produce(item Request) bool {
-	err := pq.storage.put(item)
+	err := pq.storage.put(context.Background(), item, false)
+	return err == nil
+}
+
+// ProduceContext adds an item to the queue, waiting for room while the queue is full until the context
+// is done or the queue is stopped, and returns true if it was accepted.
+func (pq *persistentQueue) ProduceContext(ctx context.Context, item Request) bool {
+	err := pq.storage.put(ctx, item, true)
 	return err == nil
 }
 
@@ -108,6 +192,16 @@ func (pq *persistentQueue) IsPersistent() bool {
 	return true
 }
 
+// RestoreStats returns the statistics of the restore of the items found in the storage when the queue started.
+func (pq *persistentQueue) RestoreStats() RestoreStats {
+	return pq.storage.restoreStats()
+}
+
+// ReplayBacklog returns the number of items found in the storage when the queue started which were not dispatched yet.
+func (pq *persistentQueue) ReplayBacklog() int {
+	return int(pq.storage.replayBacklog())
+}
+
 func toStorageClient(ctx context.Context, storageID component.ID, host component.Host, ownerID component.ID, signal component.DataType) (storage.Client, error) {
 	extension, err := getStorageExtension(host.GetExtensions(), storageID)
 	if err != nil {
@@ -34,7 +34,7 @@ func (nh *mockHost) GetExtensions() map[component.ID]component.Component {
 // createTestQueue creates and starts a fake queue with the given capacity and number of consumers.
 func createTestQueue(t *testing.T, capacity, numConsumers int, callback func(item Request)) ProducerConsumerQueue {
 	pq := NewPersistentQueue(capacity, numConsumers, component.ID{}, newFakeTracesRequestMarshalerFunc(),
-		newFakeTracesRequestUnmarshalerFunc())
+		newFakeTracesRequestUnmarshalerFunc(), PersistentQueueSettings{})
 	host := &mockHost{ext: map[component.ID]component.Component{
 		{}: NewMockStorageExtension(nil),
 	}}
@@ -47,7 +47,7 @@ func createTestQueue(t *testing.T, capacity, numConsumers int, callback func(ite
 func TestPersistentQueue_Capacity(t *testing.T) {
 	for i := 0; i < 100; i++ {
 		pq := NewPersistentQueue(5, 1, component.ID{}, newFakeTracesRequestMarshalerFunc(),
-			newFakeTracesRequestUnmarshalerFunc())
+			newFakeTracesRequestUnmarshalerFunc(), PersistentQueueSettings{})
 		host := &mockHost{ext: map[component.ID]component.Component{
 			{}: NewMockStorageExtension(nil),
 		}}
@@ -180,6 +180,56 @@ func TestPersistentQueue_ConsumersProducers(t *testing.T) {
 	}
 }
 
+type compactingStorageClient struct {
+	storage.Client
+	compactions *atomic.Int32
+}
+
+func (c compactingStorageClient) Compact(context.Context) error {
+	c.compactions.Add(1)
+	return nil
+}
+
+type compactingStorageExtension struct {
+	storage.Extension
+	compactions *atomic.Int32
+}
+
+func (e compactingStorageExtension) GetClient(ctx context.Context, kind component.Kind, id component.ID, name string) (storage.Client, error) {
+	client, err := e.Extension.GetClient(ctx, kind, id, name)
+	return compactingStorageClient{Client: client, compactions: e.compactions}, err
+}
+
+func TestPersistentQueue_Compaction(t *testing.T) {
+	compactions := &atomic.Int32{}
+	pq := NewPersistentQueue(10, 1, component.ID{}, newFakeTracesRequestMarshalerFunc(),
+		newFakeTracesRequestUnmarshalerFunc(), PersistentQueueSettings{CompactionInterval: time.Millisecond})
+	host := &mockHost{ext: map[component.ID]component.Component{
+		{}: compactingStorageExtension{Extension: NewMockStorageExtension(nil), compactions: compactions},
+	}}
+	require.NoError(t, pq.Start(context.Background(), host, newNopQueueSettings(func(item Request) {})))
+	assert.Eventually(t, func() bool {
+		return compactions.Load() >= 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The storage is not compacted once the queue is stopped.
+	pq.Stop()
+	stopped := compactions.Load()
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, stopped, compactions.Load())
+}
+
+func TestPersistentQueue_CompactionNotSupported(t *testing.T) {
+	pq := NewPersistentQueue(10, 1, component.ID{}, newFakeTracesRequestMarshalerFunc(),
+		newFakeTracesRequestUnmarshalerFunc(), PersistentQueueSettings{CompactionInterval: time.Millisecond})
+	host := &mockHost{ext: map[component.ID]component.Component{
+		{}: NewMockStorageExtension(nil),
+	}}
+	require.NoError(t, pq.Start(context.Background(), host, newNopQueueSettings(func(item Request) {})))
+	t.Cleanup(pq.Stop)
+	assert.ErrorIs(t, pq.(*persistentQueue).storage.compact(context.Background()), errCompactionNotSupported)
+}
+
 func newTraces(numTraces int, numSpans int) ptrace.Traces {
 	traces := ptrace.NewTraces()
 	batch := traces.ResourceSpans().AppendEmpty()
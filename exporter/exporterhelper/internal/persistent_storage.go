@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -49,6 +50,8 @@ type persistentContiguousStorage struct {
 	stopChan chan struct{}
 	stopOnce sync.Once
 	capacity uint64
+	// maxBytes is the maximum total size of the items waiting to be dispatched, unlimited if zero.
+	maxBytes uint64
 
 	reqChan chan Request
 
@@ -56,8 +59,22 @@ type persistentContiguousStorage struct {
 	readIndex                itemIndex
 	writeIndex               itemIndex
 	currentlyDispatchedItems []itemIndex
+	// queueBytes is the total size of the items waiting to be dispatched.
+	queueBytes uint64
+	// onEvicted, if set, makes put evict the oldest items to make room for a new item instead of rejecting it.
+	onEvicted func(item Request)
+	// roomChan is closed, and replaced, when room is made in the queue or it is stopped.
+	roomChan chan struct{}
+	stopped  bool
+	// restoreEnd is the write index once the items found at startup were restored,
+	// the items before it being the restored items.
+	restoreEnd itemIndex
 
 	itemsCount *atomic.Uint64
+
+	restoredItems   uint64
+	corruptedItems  *atomic.Uint64
+	restoreDuration time.Duration
 }
 
 type itemIndex uint64
@@ -67,40 +84,58 @@ const (
 	zapQueueNameKey  = "queueName"
 	zapErrorCount    = "errorCount"
 	zapNumberOfItems = "numberOfItems"
+	zapCorrupted     = "corruptedItems"
+	zapDuration      = "duration"
+	zapBytes         = "bytes"
 
 	readIndexKey                = "ri"
 	writeIndexKey               = "wi"
 	currentlyDispatchedItemsKey = "di"
+	queueBytesKey               = "qb"
 )
 
 var (
-	errMaxCapacityReached   = errors.New("max capacity reached")
-	errValueNotSet          = errors.New("value not set")
-	errKeyNotPresentInBatch = errors.New("key was not present in get batchStruct")
+	errMaxCapacityReached     = errors.New("max capacity reached")
+	errItemTooLarge           = errors.New("item larger than the max bytes of the queue")
+	errQueueStopped           = errors.New("queue is stopped")
+	errValueNotSet            = errors.New("value not set")
+	errKeyNotPresentInBatch   = errors.New("key was not present in get batchStruct")
+	errCompactionNotSupported = errors.New("storage client does not support compaction")
 )
 
 // newPersistentContiguousStorage creates a new file-storage extension backed queue;
 // queueName parameter must be a unique value that identifies the queue.
 func newPersistentContiguousStorage(ctx context.Context, queueName string, client storage.Client,
-	logger *zap.Logger, capacity uint64, marshaler RequestMarshaler, unmarshaler RequestUnmarshaler) *persistentContiguousStorage {
+	logger *zap.Logger, capacity uint64, maxBytes uint64, marshaler RequestMarshaler, unmarshaler RequestUnmarshaler) *persistentContiguousStorage {
 	pcs := &persistentContiguousStorage{
-		logger:      logger,
-		client:      client,
-		queueName:   queueName,
-		unmarshaler: unmarshaler,
-		marshaler:   marshaler,
-		capacity:    capacity,
-		putChan:     make(chan struct{}, capacity),
-		reqChan:     make(chan Request),
-		stopChan:    make(chan struct{}),
-		itemsCount:  &atomic.Uint64{},
+		logger:         logger,
+		client:         client,
+		queueName:      queueName,
+		unmarshaler:    unmarshaler,
+		marshaler:      marshaler,
+		capacity:       capacity,
+		maxBytes:       maxBytes,
+		putChan:        make(chan struct{}, capacity),
+		reqChan:        make(chan Request),
+		stopChan:       make(chan struct{}),
+		roomChan:       make(chan struct{}),
+		itemsCount:     &atomic.Uint64{},
+		corruptedItems: &atomic.Uint64{},
 	}
 
+	start := time.Now()
 	initPersistentContiguousStorage(ctx, pcs)
+	pcs.restoredItems = pcs.size()
 	notDispatchedReqs := pcs.retrieveNotDispatchedReqs(context.Background())
+	pcs.restoredItems += uint64(len(notDispatchedReqs))
 
 	// Make sure the leftover requests are handled
 	pcs.enqueueNotDispatchedReqs(notDispatchedReqs)
+	pcs.mu.Lock()
+	pcs.restoreEnd = pcs.writeIndex
+	pcs.mu.Unlock()
+	pcs.restoreDuration = time.Since(start)
+	pcs.logRestore()
 
 	// Ensure the communication channel has the same size as the queue
 	// We might already have items here from requeueing non-dispatched requests
@@ -117,7 +152,7 @@ func newPersistentContiguousStorage(ctx context.Context, queueName string, clien
 func initPersistentContiguousStorage(ctx context.Context, pcs *persistentContiguousStorage) {
 	var writeIndex itemIndex
 	var readIndex itemIndex
-	batch, err := newBatch(pcs).get(readIndexKey, writeIndexKey).execute(ctx)
+	batch, err := newBatch(pcs).get(readIndexKey, writeIndexKey, queueBytesKey).execute(ctx)
 
 	if err == nil {
 		readIndex, err = batch.getItemIndexResult(readIndexKey)
@@ -140,6 +175,9 @@ func initPersistentContiguousStorage(ctx context.Context, pcs *persistentContigu
 	} else {
 		pcs.readIndex = readIndex
 		pcs.writeIndex = writeIndex
+		// The size of the items is not known if the queue was stored by an older version, it is
+		// then only accounted for the new items.
+		pcs.queueBytes, _ = batch.getUint64Result(queueBytesKey)
 	}
 
 	pcs.itemsCount.Store(uint64(pcs.writeIndex - pcs.readIndex))
@@ -149,7 +187,7 @@ func (pcs *persistentContiguousStorage) enqueueNotDispatchedReqs(reqs []Request)
 	if len(reqs) > 0 {
 		errCount := 0
 		for _, req := range reqs {
-			if req == nil || pcs.put(req) != nil {
+			if req == nil || pcs.put(context.Background(), req, false) != nil {
 				errCount++
 			}
 		}
@@ -167,6 +205,40 @@ func (pcs *persistentContiguousStorage) enqueueNotDispatchedReqs(reqs []Request)
 	}
 }
 
+// logRestore logs a summary of the restore of the items found in the storage at startup.
+func (pcs *persistentContiguousStorage) logRestore() {
+	fields := []zap.Field{
+		zap.String(zapQueueNameKey, pcs.queueName),
+		zap.Uint64(zapNumberOfItems, pcs.restoredItems),
+		zap.Uint64(zapCorrupted, pcs.corruptedItems.Load()),
+		zap.Duration(zapDuration, pcs.restoreDuration),
+	}
+	if pcs.restoredItems == 0 {
+		pcs.logger.Debug("No items restored from the persistent queue", fields...)
+		return
+	}
+	pcs.logger.Info("Restored items from the persistent queue, replaying them", fields...)
+}
+
+// restoreStats returns the statistics of the restore of the items found in the storage at startup.
+func (pcs *persistentContiguousStorage) restoreStats() RestoreStats {
+	return RestoreStats{
+		RestoredItems:  pcs.restoredItems,
+		CorruptedItems: pcs.corruptedItems.Load(),
+		Duration:       pcs.restoreDuration,
+	}
+}
+
+// replayBacklog returns the number of restored items which were not dispatched yet.
+func (pcs *persistentContiguousStorage) replayBacklog() uint64 {
+	pcs.mu.Lock()
+	defer pcs.mu.Unlock()
+	if pcs.readIndex >= pcs.restoreEnd {
+		return 0
+	}
+	return uint64(pcs.restoreEnd - pcs.readIndex)
+}
+
 // loop is the main loop that handles fetching items from the persistent buffer
 func (pcs *persistentContiguousStorage) loop() {
 	for {
@@ -195,6 +267,10 @@ func (pcs *persistentContiguousStorage) size() uint64 {
 func (pcs *persistentContiguousStorage) stop() {
 	pcs.logger.Debug("Stopping persistentContiguousStorage", zap.String(zapQueueNameKey, pcs.queueName))
 	pcs.stopOnce.Do(func() {
+		pcs.mu.Lock()
+		pcs.stopped = true
+		pcs.notifyRoom()
+		pcs.mu.Unlock()
 		close(pcs.stopChan)
 		if err := pcs.client.Close(context.Background()); err != nil {
 			pcs.logger.Warn("failed to close client", zap.Error(err))
@@ -202,34 +278,156 @@ func (pcs *persistentContiguousStorage) stop() {
 	})
 }
 
-// put marshals the request and puts it into the persistent queue
-func (pcs *persistentContiguousStorage) put(req Request) error {
+// put marshals the request and puts it into the persistent queue. If the queue is full, the oldest items
+// are evicted if onEvicted is set, otherwise it waits for room if wait is true, until the context is done
+// or the queue is stopped.
+func (pcs *persistentContiguousStorage) put(ctx context.Context, req Request, wait bool) error {
 	// Nil requests are ignored
 	if req == nil {
 		return nil
 	}
 
+	value, marshalErr := pcs.marshaler(req)
+	if marshalErr != nil {
+		// The item is skipped when it is read.
+		pcs.logger.Debug("Failed marshaling item, skipping it", zap.String(zapQueueNameKey, pcs.queueName), zap.Error(marshalErr))
+	}
+	itemBytes := uint64(len(value))
+
+	// The evicted items are passed to onEvicted once the lock is released.
+	var evicted []Request
+	defer func() {
+		for _, item := range evicted {
+			pcs.onEvicted(item)
+		}
+	}()
 	pcs.mu.Lock()
 	defer pcs.mu.Unlock()
 
-	if pcs.size() >= pcs.capacity {
-		pcs.logger.Warn("Maximum queue capacity reached", zap.String(zapQueueNameKey, pcs.queueName))
-		return errMaxCapacityReached
+	if pcs.maxBytes > 0 && itemBytes > pcs.maxBytes {
+		pcs.logger.Warn("Item larger than the maximum queue size in bytes",
+			zap.String(zapQueueNameKey, pcs.queueName), zap.Uint64(zapBytes, itemBytes))
+		return errItemTooLarge
+	}
+
+	for !pcs.hasRoom(itemBytes) {
+		if pcs.onEvicted != nil {
+			if item, ok := pcs.evictOldest(); ok {
+				if item != nil {
+					evicted = append(evicted, item)
+				}
+				continue
+			}
+		}
+		if !wait {
+			pcs.logger.Warn("Maximum queue capacity reached", zap.String(zapQueueNameKey, pcs.queueName))
+			return errMaxCapacityReached
+		}
+		if pcs.stopped {
+			return errQueueStopped
+		}
+		roomChan := pcs.roomChan
+		pcs.mu.Unlock()
+		select {
+		case <-roomChan:
+		case <-ctx.Done():
+			pcs.mu.Lock()
+			return ctx.Err()
+		}
+		pcs.mu.Lock()
 	}
 
 	itemKey := pcs.itemKey(pcs.writeIndex)
 	pcs.writeIndex++
 	pcs.itemsCount.Store(uint64(pcs.writeIndex - pcs.readIndex))
+	pcs.queueBytes += itemBytes
 
-	ctx := context.Background()
-	_, err := newBatch(pcs).setItemIndex(writeIndexKey, pcs.writeIndex).setRequest(itemKey, req).execute(ctx)
+	batch := newBatch(pcs).setItemIndex(writeIndexKey, pcs.writeIndex).setUint64(queueBytesKey, pcs.queueBytes)
+	if marshalErr == nil {
+		batch.setBytes(itemKey, value)
+	}
+	_, err := batch.execute(context.Background())
 
-	// Inform the loop that there's some data to process
-	pcs.putChan <- struct{}{}
+	// Inform the loop that there's some data to process. The loop may already be informed of
+	// more items than the queue holds if items were evicted.
+	select {
+	case pcs.putChan <- struct{}{}:
+	default:
+	}
 
 	return err
 }
 
+// hasRoom returns whether an item of the given size can be added to the queue.
+func (pcs *persistentContiguousStorage) hasRoom(itemBytes uint64) bool {
+	return pcs.size() < pcs.capacity && (pcs.maxBytes == 0 || pcs.queueBytes+itemBytes <= pcs.maxBytes)
+}
+
+// notifyRoom wakes up the puts waiting for room in the queue.
+func (pcs *persistentContiguousStorage) notifyRoom() {
+	close(pcs.roomChan)
+	pcs.roomChan = make(chan struct{})
+}
+
+// evictOldest removes the oldest item waiting to be dispatched from the storage and returns it, nil if it
+// could not be read. It returns false if there is no item to evict.
+func (pcs *persistentContiguousStorage) evictOldest() (Request, bool) {
+	if pcs.readIndex == pcs.writeIndex {
+		return nil, false
+	}
+	ctx := context.Background()
+	index := pcs.readIndex
+	itemKey := pcs.itemKey(index)
+
+	var req Request
+	batch, err := newBatch(pcs).get(itemKey).execute(ctx)
+	if err == nil {
+		pcs.readItem(index, batch.getResultSize(itemKey))
+		req, err = batch.getRequestResult(itemKey)
+	} else {
+		pcs.readItem(index, 0)
+	}
+
+	_, delErr := newBatch(pcs).
+		setItemIndex(readIndexKey, pcs.readIndex).
+		setUint64(queueBytesKey, pcs.queueBytes).
+		delete(itemKey).
+		execute(ctx)
+	if delErr != nil {
+		pcs.logger.Error("Error deleting evicted item from queue",
+			zap.String(zapQueueNameKey, pcs.queueName), zap.Error(delErr))
+	}
+
+	if err != nil {
+		pcs.logger.Debug("Evicted item could not be read",
+			zap.String(zapQueueNameKey, pcs.queueName), zap.String(zapKey, itemKey), zap.Error(err))
+		return nil, true
+	}
+	return req, true
+}
+
+// readItem removes the item at the given index, of the given size, from the items waiting to be dispatched.
+func (pcs *persistentContiguousStorage) readItem(index itemIndex, itemBytes uint64) {
+	pcs.readIndex = index + 1
+	pcs.itemsCount.Store(uint64(pcs.writeIndex - pcs.readIndex))
+	if itemBytes > pcs.queueBytes || pcs.readIndex == pcs.writeIndex {
+		// The size of the items stored by an older version, or which could not be read, is not known,
+		// no bytes are left once the queue is empty.
+		itemBytes = pcs.queueBytes
+	}
+	pcs.queueBytes -= itemBytes
+	pcs.notifyRoom()
+}
+
+// compact reclaims the space left by the deleted items in the storage, if supported by the storage client.
+func (pcs *persistentContiguousStorage) compact(ctx context.Context) error {
+	compactor, ok := pcs.client.(storage.Compactor)
+	if !ok {
+		return errCompactionNotSupported
+	}
+	return compactor.Compact(ctx)
+}
+
 // getNextItem pulls the next available item from the persistent storage; if none is found, returns (nil, false)
 func (pcs *persistentContiguousStorage) getNextItem(ctx context.Context) (Request, bool) {
 	pcs.mu.Lock()
@@ -237,20 +435,28 @@ func (pcs *persistentContiguousStorage) getNextItem(ctx context.Context) (Reques
 
 	if pcs.readIndex != pcs.writeIndex {
 		index := pcs.readIndex
-		// Increase here, so even if errors happen below, it always iterates
-		pcs.readIndex++
-		pcs.itemsCount.Store(uint64(pcs.writeIndex - pcs.readIndex))
+		var req Request
+		batch, err := newBatch(pcs).get(pcs.itemKey(index)).execute(ctx)
 
+		// Move the read index here, so even if errors happen below, it always iterates
+		if err == nil {
+			pcs.readItem(index, batch.getResultSize(pcs.itemKey(index)))
+		} else {
+			pcs.readItem(index, 0)
+		}
 		pcs.updateReadIndex(ctx)
 		pcs.itemDispatchingStart(ctx, index)
 
-		var req Request
-		batch, err := newBatch(pcs).get(pcs.itemKey(index)).execute(ctx)
 		if err == nil {
 			req, err = batch.getRequestResult(pcs.itemKey(index))
 		}
 
 		if err != nil || req == nil {
+			if index < pcs.restoreEnd {
+				pcs.corruptedItems.Add(1)
+				pcs.logger.Warn("Skipping restored item which could not be read",
+					zap.String(zapQueueNameKey, pcs.queueName), zap.String(zapKey, pcs.itemKey(index)), zap.Error(err))
+			}
 			// We need to make sure that currently dispatched items list is cleaned
 			if err := pcs.itemDispatchingFinish(ctx, index); err != nil {
 				pcs.logger.Error("Error deleting item from queue",
@@ -331,10 +537,12 @@ func (pcs *persistentContiguousStorage) retrieveNotDispatchedReqs(ctx context.Co
 		req, err := retrieveBatch.getRequestResult(key)
 		// If error happened or item is nil, it will be efficiently ignored
 		if err != nil {
+			pcs.corruptedItems.Add(1)
 			pcs.logger.Warn("Failed unmarshalling item",
 				zap.String(zapQueueNameKey, pcs.queueName), zap.String(zapKey, key), zap.Error(err))
 		} else {
 			if req == nil {
+				pcs.corruptedItems.Add(1)
 				pcs.logger.Debug("Item value could not be retrieved",
 					zap.String(zapQueueNameKey, pcs.queueName), zap.String(zapKey, key), zap.Error(err))
 			} else {
@@ -400,6 +608,7 @@ func (pcs *persistentContiguousStorage) itemDispatchingFinish(ctx context.Contex
 func (pcs *persistentContiguousStorage) updateReadIndex(ctx context.Context) {
 	_, err := newBatch(pcs).
 		setItemIndex(readIndexKey, pcs.readIndex).
+		setUint64(queueBytesKey, pcs.queueBytes).
 		execute(ctx)
 
 	if err != nil {
@@ -91,6 +91,16 @@ func (bof *batchStruct) getResult(key string, unmarshal func([]byte) (any, error
 	return unmarshal(op.Value)
 }
 
+// getResultSize returns the size in bytes of the value retrieved by a Get operation for a given key.
+// It should be called after execute.
+func (bof *batchStruct) getResultSize(key string) uint64 {
+	op := bof.getOperations[key]
+	if op == nil {
+		return 0
+	}
+	return uint64(len(op.Value))
+}
+
 // getRequestResult returns the result of a Get operation as a request
 // If the value cannot be retrieved, it returns an error
 func (bof *batchStruct) getRequestResult(key string) (Request, error) {
@@ -120,6 +130,13 @@ func (bof *batchStruct) getItemIndexResult(key string) (itemIndex, error) {
 	return itemIndexIf.(itemIndex), nil
 }
 
+// getUint64Result returns the result of a Get operation as an uint64
+// If the value cannot be retrieved, it returns an error
+func (bof *batchStruct) getUint64Result(key string) (uint64, error) {
+	val, err := bof.getItemIndexResult(key)
+	return uint64(val), err
+}
+
 // getItemIndexArrayResult returns the result of a Get operation as a itemIndexArray
 // It may return nil value
 func (bof *batchStruct) getItemIndexArrayResult(key string) ([]itemIndex, error) {
@@ -145,6 +162,17 @@ func (bof *batchStruct) setItemIndex(key string, value itemIndex) *batchStruct {
 	return bof.set(key, value, itemIndexToBytes)
 }
 
+// setUint64 adds Set operation over a given uint64 to the batch
+func (bof *batchStruct) setUint64(key string, value uint64) *batchStruct {
+	return bof.set(key, value, itemIndexToBytes)
+}
+
+// setBytes adds Set operation over an already marshaled value to the batch
+func (bof *batchStruct) setBytes(key string, value []byte) *batchStruct {
+	bof.operations = append(bof.operations, storage.SetOperation(key, value))
+	return bof
+}
+
 // setItemIndexArray adds Set operation over a given itemIndex array to the batch
 func (bof *batchStruct) setItemIndexArray(key string, value []itemIndex) *batchStruct {
 	return bof.set(key, value, itemIndexArrayToBytes)
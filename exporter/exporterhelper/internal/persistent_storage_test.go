@@ -31,7 +31,7 @@ func createTestClient(extension storage.Extension) storage.Client {
 }
 
 func createTestPersistentStorageWithLoggingAndCapacity(client storage.Client, logger *zap.Logger, capacity uint64) *persistentContiguousStorage {
-	return newPersistentContiguousStorage(context.Background(), "foo", client, logger, capacity,
+	return newPersistentContiguousStorage(context.Background(), "foo", client, logger, capacity, 0,
 		newFakeTracesRequestMarshalerFunc(), newFakeTracesRequestUnmarshalerFunc())
 }
 
@@ -153,7 +153,7 @@ func TestPersistentStorage_CorruptedData(t *testing.T) {
 
 			// Put some items, make sure they are loaded and shutdown the storage...
 			for i := 0; i < 3; i++ {
-				err := ps.put(req)
+				err := ps.put(context.Background(), req, false)
 				require.NoError(t, err)
 			}
 			require.Eventually(t, func() bool {
@@ -194,6 +194,41 @@ func TestPersistentStorage_CorruptedData(t *testing.T) {
 	}
 }
 
+func TestPersistentStorage_RestoreStats(t *testing.T) {
+	req := newFakeTracesRequest(newTraces(5, 10))
+
+	ext := NewMockStorageExtension(nil)
+	client := createTestClient(ext)
+	ps := createTestPersistentStorage(client)
+	assert.Equal(t, uint64(0), ps.restoreStats().RestoredItems)
+	assert.Equal(t, uint64(0), ps.restoreStats().CorruptedItems)
+	assert.Equal(t, uint64(0), ps.replayBacklog())
+
+	// Item 0 is being dispatched, items 1 and 2 are in the queue when the storage stops.
+	for i := 0; i < 3; i++ {
+		require.NoError(t, ps.put(context.Background(), req, false))
+	}
+	requireCurrentlyDispatchedItemsEqual(t, ps, []itemIndex{0})
+	ps.stop()
+	require.NoError(t, client.Set(context.Background(), "1", []byte{0, 1, 2}))
+
+	// Item 0 is requeued as item 3, item 1 is skipped and item 2 is dispatched.
+	newPs := createTestPersistentStorage(client)
+	require.Eventually(t, func() bool {
+		return newPs.restoreStats().CorruptedItems == 1 && newPs.replayBacklog() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, uint64(3), newPs.restoreStats().RestoredItems)
+
+	<-newPs.get()
+	<-newPs.get()
+	assert.Equal(t, uint64(0), newPs.replayBacklog())
+	assert.Equal(t, uint64(1), newPs.restoreStats().CorruptedItems)
+
+	// New items are not part of the replay backlog.
+	require.NoError(t, newPs.put(context.Background(), req, false))
+	assert.Equal(t, uint64(0), newPs.replayBacklog())
+}
+
 func TestPersistentStorage_CurrentlyProcessedItems(t *testing.T) {
 	traces := newTraces(5, 10)
 	req := newFakeTracesRequest(traces)
@@ -203,7 +238,7 @@ func TestPersistentStorage_CurrentlyProcessedItems(t *testing.T) {
 	ps := createTestPersistentStorage(client)
 
 	for i := 0; i < 5; i++ {
-		err := ps.put(req)
+		err := ps.put(context.Background(), req, false)
 		require.NoError(t, err)
 	}
 
@@ -271,14 +306,14 @@ func TestPersistentStorage_StartWithNonDispatched(t *testing.T) {
 
 	// Put in items up to capacity
 	for i := 0; i < int(capacity); i++ {
-		err := ps.put(req)
+		err := ps.put(context.Background(), req, false)
 		require.NoError(t, err)
 	}
 
 	// get one item out, but don't mark it as processed
 	<-ps.get()
 	// put one more item in
-	err := ps.put(req)
+	err := ps.put(context.Background(), req, false)
 	require.NoError(t, err)
 
 	require.Eventually(t, func() bool {
@@ -307,9 +342,9 @@ func TestPersistentStorage_RepeatPutCloseReadClose(t *testing.T) {
 		require.Equal(t, uint64(0), ps.size())
 
 		// Put two elements
-		err := ps.put(req)
+		err := ps.put(context.Background(), req, false)
 		require.NoError(t, err)
-		err = ps.put(req)
+		err = ps.put(context.Background(), req, false)
 		require.NoError(t, err)
 
 		err = ext.Shutdown(context.Background())
@@ -350,7 +385,7 @@ func TestPersistentStorage_EmptyRequest(t *testing.T) {
 
 	require.Equal(t, uint64(0), ps.size())
 
-	err := ps.put(nil)
+	err := ps.put(context.Background(), nil, false)
 	require.NoError(t, err)
 
 	require.Equal(t, uint64(0), ps.size())
@@ -390,7 +425,7 @@ func BenchmarkPersistentStorage_TraceSpans(b *testing.B) {
 			bb.ResetTimer()
 
 			for i := 0; i < bb.N; i++ {
-				err := ps.put(req)
+				err := ps.put(context.Background(), req, false)
 				require.NoError(bb, err)
 			}
 
@@ -464,7 +499,7 @@ func TestPersistentStorage_StorageFull(t *testing.T) {
 	// Put enough items in to fill the underlying storage
 	reqCount := 0
 	for {
-		err = ps.put(req)
+		err = ps.put(context.Background(), req, false)
 		if errors.Is(err, syscall.ENOSPC) {
 			break
 		}
@@ -477,7 +512,7 @@ func TestPersistentStorage_StorageFull(t *testing.T) {
 	client.SetMaxSizeInBytes(newMaxSize)
 
 	// Try to put an item in, should fail
-	err = ps.put(req)
+	err = ps.put(context.Background(), req, false)
 	require.Error(t, err)
 
 	// Take out all the items
@@ -488,7 +523,7 @@ func TestPersistentStorage_StorageFull(t *testing.T) {
 
 	// We should be able to put a new item in
 	// However, this will fail if deleting items fails with full storage
-	err = ps.put(req)
+	err = ps.put(context.Background(), req, false)
 	require.NoError(t, err)
 }
 
@@ -712,3 +747,147 @@ func (m *fakeStorageClientWithErrors) Reset() {
 	defer m.mux.Unlock()
 	m.nextErrorIndex = 0
 }
+
+func createTestPersistentStorageWithMaxBytes(client storage.Client, capacity uint64, maxBytes uint64) *persistentContiguousStorage {
+	return newPersistentContiguousStorage(context.Background(), "foo", client, zap.NewNop(), capacity, maxBytes,
+		newFakeTracesRequestMarshalerFunc(), newFakeTracesRequestUnmarshalerFunc())
+}
+
+func requestBytes(t *testing.T, req Request) uint64 {
+	marshaled, err := newFakeTracesRequestMarshalerFunc()(req)
+	require.NoError(t, err)
+	return uint64(len(marshaled))
+}
+
+func queueBytes(pcs *persistentContiguousStorage) uint64 {
+	pcs.mu.Lock()
+	defer pcs.mu.Unlock()
+	return pcs.queueBytes
+}
+
+func TestPersistentStorage_MaxBytes(t *testing.T) {
+	req := newFakeTracesRequest(newTraces(5, 10))
+	itemBytes := requestBytes(t, req)
+	ps := createTestPersistentStorageWithMaxBytes(createTestClient(NewMockStorageExtension(nil)), 100, 2*itemBytes+itemBytes/2)
+	t.Cleanup(ps.stop)
+
+	// The first item is read by the loop, waiting for a consumer.
+	require.NoError(t, ps.put(context.Background(), req, false))
+	assert.Eventually(t, func() bool {
+		return ps.size() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, uint64(0), queueBytes(ps))
+
+	require.NoError(t, ps.put(context.Background(), req, false))
+	require.NoError(t, ps.put(context.Background(), req, false))
+	assert.Equal(t, 2*itemBytes, queueBytes(ps))
+	assert.ErrorIs(t, ps.put(context.Background(), req, false), errMaxCapacityReached)
+	assert.Equal(t, uint64(2), ps.size())
+
+	// An item larger than the max bytes never fits in the queue.
+	assert.ErrorIs(t, ps.put(context.Background(), newFakeTracesRequest(newTraces(20, 10)), false), errItemTooLarge)
+
+	// Dispatching an item makes room for a new one.
+	(<-ps.get()).OnProcessingFinished()
+	assert.Eventually(t, func() bool {
+		return queueBytes(ps) == itemBytes
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, ps.put(context.Background(), req, false))
+}
+
+func TestPersistentStorage_QueueBytesRestored(t *testing.T) {
+	req := newFakeTracesRequest(newTraces(5, 10))
+	itemBytes := requestBytes(t, req)
+	client := createTestClient(NewMockStorageExtension(nil))
+	ps := createTestPersistentStorageWithMaxBytes(client, 100, 10*itemBytes)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, ps.put(context.Background(), req, false))
+	}
+	// The item read by the loop is not accounted until it is requeued on restart.
+	assert.Eventually(t, func() bool {
+		return queueBytes(ps) == 2*itemBytes
+	}, 5*time.Second, 10*time.Millisecond)
+	ps.stop()
+
+	newPs := createTestPersistentStorageWithMaxBytes(client, 100, 10*itemBytes)
+	t.Cleanup(newPs.stop)
+	assert.Eventually(t, func() bool {
+		return queueBytes(newPs) == 2*itemBytes
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, uint64(3), newPs.restoreStats().RestoredItems)
+}
+
+func TestPersistentStorage_DropOldest(t *testing.T) {
+	ps := createTestPersistentStorageWithMaxBytes(createTestClient(NewMockStorageExtension(nil)), 2, 0)
+	t.Cleanup(ps.stop)
+	var evicted []Request
+	ps.mu.Lock()
+	ps.onEvicted = func(item Request) {
+		evicted = append(evicted, item)
+	}
+	ps.mu.Unlock()
+
+	// The first item is read by the loop, waiting for a consumer.
+	require.NoError(t, ps.put(context.Background(), newFakeTracesRequest(newTraces(1, 1)), false))
+	assert.Eventually(t, func() bool {
+		return ps.size() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	for i := 2; i <= 5; i++ {
+		require.NoError(t, ps.put(context.Background(), newFakeTracesRequest(newTraces(1, i)), false))
+	}
+	assert.Equal(t, uint64(2), ps.size())
+	require.Len(t, evicted, 2)
+	assert.Equal(t, 2, evicted[0].(*fakeTracesRequest).td.SpanCount())
+	assert.Equal(t, 3, evicted[1].(*fakeTracesRequest).td.SpanCount())
+
+	// The items are dispatched in order, the evicted ones being skipped.
+	for _, spans := range []int{1, 4, 5} {
+		req := <-ps.get()
+		assert.Equal(t, spans, req.(*fakeTracesRequest).td.SpanCount())
+		req.OnProcessingFinished()
+	}
+}
+
+func TestPersistentStorage_BlockUntilRoom(t *testing.T) {
+	req := newFakeTracesRequest(newTraces(1, 1))
+	ps := createTestPersistentStorageWithMaxBytes(createTestClient(NewMockStorageExtension(nil)), 1, 0)
+	t.Cleanup(ps.stop)
+
+	// The first item is read by the loop, waiting for a consumer.
+	require.NoError(t, ps.put(context.Background(), req, true))
+	assert.Eventually(t, func() bool {
+		return ps.size() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, ps.put(context.Background(), req, true))
+
+	// Not waiting for room.
+	assert.ErrorIs(t, ps.put(context.Background(), req, false), errMaxCapacityReached)
+
+	// Waiting for room until the context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, ps.put(ctx, req, true), context.DeadlineExceeded)
+
+	// Waiting for room until an item is dispatched.
+	putErr := make(chan error)
+	go func() {
+		putErr <- ps.put(context.Background(), req, true)
+	}()
+	select {
+	case err := <-putErr:
+		t.Fatalf("put returned before room was made: %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+	(<-ps.get()).OnProcessingFinished()
+	require.NoError(t, <-putErr)
+
+	// Waiting for room until the queue is stopped.
+	go func() {
+		putErr <- ps.put(context.Background(), req, true)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	ps.stop()
+	assert.ErrorIs(t, <-putErr, errQueueStopped)
+}
@@ -16,6 +16,9 @@ type QueueSettings struct {
 	exporter.CreateSettings
 	DataType component.DataType
 	Callback func(item Request)
+	// OnEvicted, if set, makes the memory queue evict its oldest item to accept a new item when it is full,
+	// instead of rejecting the new item. It is called with each evicted item.
+	OnEvicted func(item Request)
 }
 
 // ProducerConsumerQueue defines a producer-consumer exchange which can be backed by e.g. the memory-based ring buffer queue
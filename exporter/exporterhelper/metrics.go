@@ -64,6 +64,10 @@ func (req *metricsRequest) Count() int {
 	return req.md.DataPointCount()
 }
 
+func (req *metricsRequest) byteSize() int {
+	return metricsMarshaler.MetricsSize(req.md)
+}
+
 type metricsExporter struct {
 	*baseExporter
 	consumer.Metrics
@@ -96,6 +100,7 @@ func NewMetricsExporter(
 	}
 
 	mc, err := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		md = be.convertTemporality(md)
 		req := newMetricsRequest(ctx, md, pusher)
 		serr := be.send(req)
 		if errors.Is(serr, errSendingQueueIsFull) {
@@ -141,6 +146,7 @@ func NewMetricsRequestExporter(
 	}
 
 	mc, err := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		md = be.convertTemporality(md)
 		req, cErr := converter.RequestFromMetrics(ctx, md)
 		if cErr != nil {
 			set.Logger.Error("Failed to convert metrics. Dropping data.",
@@ -33,6 +33,18 @@ type instruments struct {
 	failedToEnqueueTraceSpans   *metric.Int64Cumulative
 	failedToEnqueueMetricPoints *metric.Int64Cumulative
 	failedToEnqueueLogRecords   *metric.Int64Cumulative
+	tenantQueueItems            *metric.Int64DerivedGauge
+	tenantQueueBytes            *metric.Int64DerivedGauge
+	tenantEnqueueRefusedItems   *metric.Int64Cumulative
+	queueRestoredItems          *metric.Int64DerivedCumulative
+	queueRestoreCorruptedItems  *metric.Int64DerivedCumulative
+	queueRestoreDuration        *metric.Int64DerivedGauge
+	queueReplayBacklog          *metric.Int64DerivedGauge
+	queueEvictedBatches         *metric.Int64Cumulative
+	queueEvictedItems           *metric.Int64Cumulative
+	circuitBreakerState         *metric.Int64DerivedGauge
+	batchesSent                 *metric.Int64Cumulative
+	batchSentItems              *metric.Int64Cumulative
 }
 
 func newInstruments(registry *metric.Registry) *instruments {
@@ -69,6 +81,78 @@ func newInstruments(registry *metric.Registry) *instruments {
 		metric.WithLabelKeys(obsmetrics.ExporterKey),
 		metric.WithUnit(metricdata.UnitDimensionless))
 
+	insts.tenantQueueItems, _ = registry.AddInt64DerivedGauge(
+		obsmetrics.ExporterKey+"/tenant_queue_items",
+		metric.WithDescription("Current number of items in the sending queue for a tenant"),
+		metric.WithLabelKeys(obsmetrics.ExporterKey, tenantKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
+	insts.tenantQueueBytes, _ = registry.AddInt64DerivedGauge(
+		obsmetrics.ExporterKey+"/tenant_queue_bytes",
+		metric.WithDescription("Current size of the data in the sending queue for a tenant"),
+		metric.WithLabelKeys(obsmetrics.ExporterKey, tenantKey),
+		metric.WithUnit(metricdata.UnitBytes))
+
+	insts.tenantEnqueueRefusedItems, _ = registry.AddInt64Cumulative(
+		obsmetrics.ExporterKey+"/tenant_enqueue_refused_items",
+		metric.WithDescription("Number of items refused by the sending queue because the tenant exceeded its quota."),
+		metric.WithLabelKeys(obsmetrics.ExporterKey, tenantKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
+	insts.queueRestoredItems, _ = registry.AddInt64DerivedCumulative(
+		obsmetrics.ExporterKey+"/queue_restored_items",
+		metric.WithDescription("Number of items restored from the persistent queue storage at startup (in batches)"),
+		metric.WithLabelKeys(obsmetrics.ExporterKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
+	insts.queueRestoreCorruptedItems, _ = registry.AddInt64DerivedCumulative(
+		obsmetrics.ExporterKey+"/queue_restore_corrupted_items",
+		metric.WithDescription("Number of items restored from the persistent queue storage which could not be read and were skipped (in batches)"),
+		metric.WithLabelKeys(obsmetrics.ExporterKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
+	insts.queueRestoreDuration, _ = registry.AddInt64DerivedGauge(
+		obsmetrics.ExporterKey+"/queue_restore_duration",
+		metric.WithDescription("Time spent restoring the items of the persistent queue storage at startup"),
+		metric.WithLabelKeys(obsmetrics.ExporterKey),
+		metric.WithUnit(metricdata.UnitMilliseconds))
+
+	insts.queueReplayBacklog, _ = registry.AddInt64DerivedGauge(
+		obsmetrics.ExporterKey+"/queue_replay_backlog",
+		metric.WithDescription("Current number of items restored from the persistent queue storage which were not dispatched yet (in batches)"),
+		metric.WithLabelKeys(obsmetrics.ExporterKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
+	insts.queueEvictedBatches, _ = registry.AddInt64Cumulative(
+		obsmetrics.ExporterKey+"/queue_evicted_batches",
+		metric.WithDescription("Number of batches evicted from the full sending queue by the drop_oldest overflow policy"),
+		metric.WithLabelKeys(obsmetrics.ExporterKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
+	insts.queueEvictedItems, _ = registry.AddInt64Cumulative(
+		obsmetrics.ExporterKey+"/queue_evicted_items",
+		metric.WithDescription("Number of items (spans, metric points or log records) evicted from the full sending queue by the drop_oldest overflow policy"),
+		metric.WithLabelKeys(obsmetrics.ExporterKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
+	insts.circuitBreakerState, _ = registry.AddInt64DerivedGauge(
+		obsmetrics.ExporterKey+"/circuit_breaker_state",
+		metric.WithDescription("Current state of the circuit breaker: 0 closed, 1 open, 2 half-open"),
+		metric.WithLabelKeys(obsmetrics.ExporterKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
+	insts.batchesSent, _ = registry.AddInt64Cumulative(
+		obsmetrics.ExporterKey+"/batches_sent",
+		metric.WithDescription("Number of batches sent by the batcher, by trigger: size, timeout or shutdown"),
+		metric.WithLabelKeys(obsmetrics.ExporterKey, batchTriggerKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
+	insts.batchSentItems, _ = registry.AddInt64Cumulative(
+		obsmetrics.ExporterKey+"/batch_sent_items",
+		metric.WithDescription("Number of items (spans, metric points or log records) in the batches sent by the batcher, by trigger"),
+		metric.WithLabelKeys(obsmetrics.ExporterKey, batchTriggerKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
 	return insts
 }
 
@@ -7,22 +7,31 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
+	"go.opencensus.io/metric"
 	"go.opencensus.io/metric/metricdata"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+	"go.opentelemetry.io/collector/inflight"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 )
 
 const defaultQueueSize = 1000
 
-var errSendingQueueIsFull = errors.New("sending_queue is full")
+var (
+	errSendingQueueIsFull = errors.New("sending_queue is full")
+	// errSendingQueueIsStopped is returned, wrapped as a shutdown error, when the data is sent
+	// after the queue was stopped.
+	errSendingQueueIsStopped = consumererror.NewShutdown(errors.New("sending_queue is stopped"))
+)
 
 // QueueSettings defines configuration for queueing batches before sending to the consumerSender.
 type QueueSettings struct {
@@ -35,8 +44,35 @@ type QueueSettings struct {
 	// StorageID if not empty, enables the persistent storage and uses the component specified
 	// as a storage extension for the persistent queue
 	StorageID *component.ID `mapstructure:"storage"`
+	// Tenant defines the per-tenant quotas of the queue.
+	Tenant TenantQueueSettings `mapstructure:"tenant"`
+	// ClientDeadline defines how the deadline of the incoming requests is carried to the queued data.
+	ClientDeadline ClientDeadlineSettings `mapstructure:"client_deadline"`
+	// OverflowPolicy defines how the data is dropped when the queue is full, reject_new if empty.
+	OverflowPolicy OverflowPolicy `mapstructure:"overflow_policy"`
+	// MaxBytes is the maximum total size, in bytes, of the batches waiting in the persistent queue storage.
+	// Unlimited if zero. Only supported by the persistent queue.
+	MaxBytes int `mapstructure:"max_bytes"`
+	// CompactionInterval is the interval between the compactions of the persistent queue storage, reclaiming
+	// the space left by the sent batches, if supported by the storage extension. Disabled if zero.
+	// Only supported by the persistent queue.
+	CompactionInterval time.Duration `mapstructure:"compaction_interval"`
 }
 
+// OverflowPolicy defines which data is dropped when the sending queue is full.
+type OverflowPolicy string
+
+const (
+	// OverflowPolicyRejectNew rejects the new data, keeping the queued data. This is the default.
+	OverflowPolicyRejectNew OverflowPolicy = "reject_new"
+	// OverflowPolicyDropOldest evicts the oldest queued batches to accept the new data, favoring
+	// fresh data over stale data during long outages.
+	OverflowPolicyDropOldest OverflowPolicy = "drop_oldest"
+	// OverflowPolicyBlock waits for room in the queue, applying backpressure to the pipeline, until
+	// the incoming request is canceled. Only supported by the persistent queue.
+	OverflowPolicyBlock OverflowPolicy = "block"
+)
+
 // NewDefaultQueueSettings returns the default settings for QueueSettings.
 func NewDefaultQueueSettings() QueueSettings {
 	return QueueSettings{
@@ -59,6 +95,47 @@ func (qCfg *QueueSettings) Validate() error {
 		return errors.New("queue size must be positive")
 	}
 
+	if err := qCfg.Tenant.Validate(); err != nil {
+		return err
+	}
+
+	if qCfg.Tenant.MetadataKey != "" && qCfg.StorageID != nil {
+		return errors.New("tenant quotas are not supported by the persistent queue")
+	}
+
+	if err := qCfg.ClientDeadline.Validate(); err != nil {
+		return err
+	}
+
+	if qCfg.ClientDeadline.Enabled && qCfg.StorageID != nil {
+		return errors.New("client deadline is not supported by the persistent queue")
+	}
+
+	switch qCfg.OverflowPolicy {
+	case "", OverflowPolicyRejectNew, OverflowPolicyDropOldest:
+	case OverflowPolicyBlock:
+		if qCfg.StorageID == nil {
+			return errors.New("block overflow policy is only supported by the persistent queue")
+		}
+	default:
+		return fmt.Errorf("overflow policy must be one of %q, %q or %q, got %q",
+			OverflowPolicyRejectNew, OverflowPolicyDropOldest, OverflowPolicyBlock, qCfg.OverflowPolicy)
+	}
+
+	if qCfg.MaxBytes < 0 {
+		return errors.New("max bytes must not be negative")
+	}
+	if qCfg.MaxBytes > 0 && qCfg.StorageID == nil {
+		return errors.New("max bytes is only supported by the persistent queue")
+	}
+
+	if qCfg.CompactionInterval < 0 {
+		return errors.New("compaction interval must not be negative")
+	}
+	if qCfg.CompactionInterval > 0 && qCfg.StorageID == nil {
+		return errors.New("compaction interval is only supported by the persistent queue")
+	}
+
 	return nil
 }
 
@@ -71,6 +148,16 @@ type queueSender struct {
 	traceAttribute   attribute.KeyValue
 	logger           *zap.Logger
 	requeuingEnabled bool
+	tenants          *tenantQuotas
+	clientDeadline   ClientDeadlineSettings
+	inflight         *inflight.Component
+	stopped          atomic.Bool
+	numConsumers     int
+	enqueueTimes     enqueueTimes
+	overflowPolicy   OverflowPolicy
+
+	evictedBatches *metric.Int64CumulativeEntry
+	evictedItems   *metric.Int64CumulativeEntry
 }
 
 func newQueueSender(id component.ID, signal component.DataType, queue internal.ProducerConsumerQueue, logger *zap.Logger) *queueSender {
@@ -96,12 +183,14 @@ func (qs *queueSender) onTemporaryFailure(logger *zap.Logger, req internal.Reque
 		return err
 	}
 
+	qs.enqueueTimes.push(time.Now())
 	if qs.queue.Produce(req) {
 		logger.Error(
 			"Exporting failed. Putting back to the end of the queue.",
 			zap.Error(err),
 		)
 	} else {
+		qs.enqueueTimes.popNewest()
 		logger.Error(
 			"Exporting failed. Queue did not accept requeuing request. Dropping data.",
 			zap.Error(err),
@@ -117,18 +206,31 @@ func (qs *queueSender) start(ctx context.Context, host component.Host, set expor
 		return nil
 	}
 
-	err := qs.queue.Start(ctx, host, internal.QueueSettings{
+	queueSet := internal.QueueSettings{
 		CreateSettings: set,
 		DataType:       qs.signal,
 		Callback: func(item internal.Request) {
+			qs.enqueueTimes.popOldest()
 			_ = qs.nextSender.send(item)
 			item.OnProcessingFinished()
 		},
-	})
+	}
+	if qs.overflowPolicy == OverflowPolicyDropOldest {
+		labelValue := metricdata.NewLabelValue(qs.fullName)
+		qs.evictedBatches, _ = globalInstruments.queueEvictedBatches.GetEntry(labelValue)
+		qs.evictedItems, _ = globalInstruments.queueEvictedItems.GetEntry(labelValue)
+		queueSet.OnEvicted = qs.onEvicted
+	}
+	err := qs.queue.Start(ctx, host, queueSet)
 	if err != nil {
 		return err
 	}
 
+	// The data of the persistent queue is held by the storage, only account for the memory queue.
+	if !qs.queue.IsPersistent() {
+		qs.inflight = inflight.FromHost(host).Component(component.KindExporter, qs.id)
+	}
+
 	// Start reporting queue length metric
 	err = globalInstruments.queueSize.UpsertEntry(func() int64 {
 		return int64(qs.queue.Size())
@@ -143,16 +245,74 @@ func (qs *queueSender) start(ctx context.Context, host component.Host, set expor
 		return fmt.Errorf("failed to create retry queue capacity metric: %w", err)
 	}
 
+	if rq, ok := qs.queue.(internal.RestoringQueue); ok {
+		if err = qs.recordRestoreMetrics(rq); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// onEvicted drops the oldest batch, evicted from the full queue to accept a new one.
+func (qs *queueSender) onEvicted(item internal.Request) {
+	qs.enqueueTimes.popOldest()
+	if qs.evictedBatches != nil {
+		qs.evictedBatches.Inc(1)
+	}
+	if qs.evictedItems != nil {
+		qs.evictedItems.Inc(int64(item.Count()))
+	}
+	qs.logger.Error(
+		"Dropping the oldest data because sending_queue is full. Try increasing queue_size.",
+		zap.Int("dropped_items", item.Count()),
+	)
+	item.OnProcessingFinished()
+}
+
+// recordRestoreMetrics starts reporting the metrics of the restore of the items of the queue.
+func (qs *queueSender) recordRestoreMetrics(rq internal.RestoringQueue) error {
+	err := globalInstruments.queueRestoredItems.UpsertEntry(func() int64 {
+		return int64(rq.RestoreStats().RestoredItems)
+	}, metricdata.NewLabelValue(qs.fullName))
+	if err != nil {
+		return fmt.Errorf("failed to create queue restored items metric: %w", err)
+	}
+	err = globalInstruments.queueRestoreCorruptedItems.UpsertEntry(func() int64 {
+		return int64(rq.RestoreStats().CorruptedItems)
+	}, metricdata.NewLabelValue(qs.fullName))
+	if err != nil {
+		return fmt.Errorf("failed to create queue restore corrupted items metric: %w", err)
+	}
+	err = globalInstruments.queueRestoreDuration.UpsertEntry(func() int64 {
+		return rq.RestoreStats().Duration.Milliseconds()
+	}, metricdata.NewLabelValue(qs.fullName))
+	if err != nil {
+		return fmt.Errorf("failed to create queue restore duration metric: %w", err)
+	}
+	err = globalInstruments.queueReplayBacklog.UpsertEntry(func() int64 {
+		return int64(rq.ReplayBacklog())
+	}, metricdata.NewLabelValue(qs.fullName))
+	if err != nil {
+		return fmt.Errorf("failed to create queue replay backlog metric: %w", err)
+	}
 	return nil
 }
 
 // shutdown is invoked during service shutdown.
 func (qs *queueSender) shutdown() {
 	if qs.queue != nil {
+		qs.stopped.Store(true)
+
 		// Cleanup queue metrics reporting
 		_ = globalInstruments.queueSize.UpsertEntry(func() int64 {
 			return int64(0)
 		}, metricdata.NewLabelValue(qs.fullName))
+		if _, ok := qs.queue.(internal.RestoringQueue); ok {
+			_ = globalInstruments.queueReplayBacklog.UpsertEntry(func() int64 {
+				return int64(0)
+			}, metricdata.NewLabelValue(qs.fullName))
+		}
 
 		// Stop the queued sender, this will drain the queue and will call the retry (which is stopped) that will only
 		// try once every request.
@@ -173,12 +333,66 @@ func (qs *queueSender) send(req internal.Request) error {
 		return err
 	}
 
+	reqCtx := req.Context()
+	span := trace.SpanFromContext(reqCtx)
+	clientDeadline, hasClientDeadline := qs.clientDeadline.deadline(req.Context(), time.Now())
+
 	// Prevent cancellation and deadline to propagate to the context stored in the queue.
 	// The grpc/http based receivers will cancel the request context after this function returns.
 	req.SetContext(noCancellationContext{Context: req.Context()})
+	bytes := 0
+	if (qs.tenants != nil && qs.tenants.sizeBytes()) || qs.inflight != nil {
+		if sizer, ok := req.(byteSizer); ok {
+			bytes = sizer.byteSize()
+		}
+	}
+	var releases []func()
+	if qs.tenants != nil {
+		items := req.Count()
+		tenant, ok := qs.tenants.acquire(qs.tenants.tenant(req.Context()), items, bytes)
+		if !ok {
+			qs.logger.Error(
+				"Dropping data because the tenant exceeded its sending_queue quota.",
+				zap.String("tenant", tenant),
+				zap.Int("dropped_items", items),
+			)
+			qs.tenants.recordRefused(tenant, items)
+			span.AddEvent("Dropped item, tenant exceeded its sending_queue quota.", trace.WithAttributes(qs.traceAttribute))
+			return errTenantQuotaExceeded
+		}
+		releases = append(releases, func() { qs.tenants.release(tenant, items, bytes) })
+	}
+	if qs.inflight != nil {
+		releases = append(releases, qs.inflight.Acquire(int64(bytes)))
+	}
+	if hasClientDeadline {
+		// Only the deadline of the client is carried, the request is still not canceled with the incoming request.
+		ctx, cancel := contextWithClientDeadline(req.Context(), clientDeadline)
+		req.SetContext(ctx)
+		releases = append(releases, cancel)
+	}
+	if len(releases) > 0 {
+		// The tenant quotas, the in-flight accounting and the client deadline are only supported by
+		// the memory queue, which does not set this callback.
+		req.SetOnProcessingFinished(func() {
+			for _, release := range releases {
+				release()
+			}
+		})
+	}
 
-	span := trace.SpanFromContext(req.Context())
-	if !qs.queue.Produce(req) {
+	qs.enqueueTimes.push(time.Now())
+	if !qs.produce(reqCtx, req) {
+		qs.enqueueTimes.popNewest()
+		req.OnProcessingFinished()
+		if qs.stopped.Load() {
+			qs.logger.Error(
+				"Dropping data because sending_queue is stopped.",
+				zap.Int("dropped_items", req.Count()),
+			)
+			span.AddEvent("Dropped item, sending_queue is stopped.", trace.WithAttributes(qs.traceAttribute))
+			return errSendingQueueIsStopped
+		}
 		qs.logger.Error(
 			"Dropping data because sending_queue is full. Try increasing queue_size.",
 			zap.Int("dropped_items", req.Count()),
@@ -191,6 +405,15 @@ func (qs *queueSender) send(req internal.Request) error {
 	return nil
 }
 
+// produce adds the request to the queue, waiting for room with the context of the incoming request
+// if the overflow policy is block.
+func (qs *queueSender) produce(ctx context.Context, req internal.Request) bool {
+	if bq, ok := qs.queue.(internal.BlockingQueue); ok && qs.overflowPolicy == OverflowPolicyBlock {
+		return bq.ProduceContext(ctx, req)
+	}
+	return qs.queue.Produce(req)
+}
+
 type noCancellationContext struct {
 	context.Context
 }
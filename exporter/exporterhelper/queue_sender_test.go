@@ -12,14 +12,17 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/tag"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
 	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/inflight"
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
 func TestQueuedRetry_StopWhileWaiting(t *testing.T) {
@@ -93,6 +96,38 @@ func TestQueuedRetry_DropOnFull(t *testing.T) {
 	require.Error(t, be.send(newMockRequest(context.Background(), 2, nil)))
 }
 
+func TestQueuedRetry_DropOldestOnFull(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 0 // to make every request stay in the queue
+	qCfg.QueueSize = 2
+	qCfg.OverflowPolicy = OverflowPolicyDropOldest
+	be, err := newBaseExporter(defaultSettings, "", false, nil, nil, newObservabilityConsumerSender, WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, be.send(newMockRequest(context.Background(), 2, nil)))
+	}
+	qs := be.queueSender.(*queueSender)
+	assert.Equal(t, 2, qs.queue.Size())
+	assert.Len(t, qs.enqueueTimes.times, 2)
+	checkValueForGlobalManager(t, defaultExporterTags, int64(1), "exporter/queue_evicted_batches")
+	checkValueForGlobalManager(t, defaultExporterTags, int64(2), "exporter/queue_evicted_items")
+}
+
+func TestQueuedRetry_DropOnStopped(t *testing.T) {
+	be, err := newBaseExporter(defaultSettings, "", false, nil, nil, newObservabilityConsumerSender, WithQueue(NewDefaultQueueSettings()))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, be.Shutdown(context.Background()))
+	err = be.send(newMockRequest(context.Background(), 2, nil))
+	assert.True(t, consumererror.IsShutdown(err))
+	assert.False(t, errors.Is(err, errSendingQueueIsFull))
+}
+
 func TestQueuedRetryHappyPath(t *testing.T) {
 	tt, err := obsreporttest.SetupTelemetry(defaultID)
 	require.NoError(t, err)
@@ -149,6 +184,35 @@ func TestQueuedRetry_QueueMetricsReported(t *testing.T) {
 	checkValueForGlobalManager(t, defaultExporterTags, int64(0), "exporter/queue_size")
 }
 
+type restoringQueue struct {
+	internal.ProducerConsumerQueue
+	backlog int
+}
+
+func (rq *restoringQueue) RestoreStats() internal.RestoreStats {
+	return internal.RestoreStats{RestoredItems: 5, CorruptedItems: 2, Duration: 30 * time.Millisecond}
+}
+
+func (rq *restoringQueue) ReplayBacklog() int {
+	return rq.backlog
+}
+
+func TestQueuedRetry_RestoreMetricsReported(t *testing.T) {
+	be, err := newBaseExporter(defaultSettings, "", false, nil, nil, newObservabilityConsumerSender, WithRetry(NewDefaultRetrySettings()), WithQueue(NewDefaultQueueSettings()))
+	require.NoError(t, err)
+	qs := be.queueSender.(*queueSender)
+	qs.queue = &restoringQueue{ProducerConsumerQueue: qs.queue, backlog: 3}
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+
+	checkValueForGlobalManager(t, defaultExporterTags, int64(5), "exporter/queue_restored_items")
+	checkValueForGlobalManager(t, defaultExporterTags, int64(2), "exporter/queue_restore_corrupted_items")
+	checkValueForGlobalManager(t, defaultExporterTags, int64(30), "exporter/queue_restore_duration")
+	checkValueForGlobalManager(t, defaultExporterTags, int64(3), "exporter/queue_replay_backlog")
+
+	assert.NoError(t, be.Shutdown(context.Background()))
+	checkValueForGlobalManager(t, defaultExporterTags, int64(0), "exporter/queue_replay_backlog")
+}
+
 func TestNoCancellationContext(t *testing.T) {
 	deadline := time.Now().Add(1 * time.Second)
 	ctx, cancelFunc := context.WithDeadline(context.Background(), deadline)
@@ -172,6 +236,33 @@ func TestQueueSettings_Validate(t *testing.T) {
 	qCfg.QueueSize = 0
 	assert.EqualError(t, qCfg.Validate(), "queue size must be positive")
 
+	qCfg.QueueSize = defaultQueueSize
+	qCfg.OverflowPolicy = "drop_newest"
+	assert.EqualError(t, qCfg.Validate(), `overflow policy must be one of "reject_new", "drop_oldest" or "block", got "drop_newest"`)
+
+	qCfg.OverflowPolicy = OverflowPolicyBlock
+	assert.EqualError(t, qCfg.Validate(), "block overflow policy is only supported by the persistent queue")
+	qCfg.OverflowPolicy = OverflowPolicyDropOldest
+	assert.NoError(t, qCfg.Validate())
+
+	qCfg.MaxBytes = 1024
+	assert.EqualError(t, qCfg.Validate(), "max bytes is only supported by the persistent queue")
+	qCfg.MaxBytes = 0
+	qCfg.CompactionInterval = time.Minute
+	assert.EqualError(t, qCfg.Validate(), "compaction interval is only supported by the persistent queue")
+
+	storageID := component.NewID("file_storage")
+	qCfg.StorageID = &storageID
+	assert.NoError(t, qCfg.Validate())
+	qCfg.OverflowPolicy = OverflowPolicyBlock
+	qCfg.MaxBytes = 1024
+	assert.NoError(t, qCfg.Validate())
+	qCfg.MaxBytes = -1
+	assert.EqualError(t, qCfg.Validate(), "max bytes must not be negative")
+	qCfg.MaxBytes = 0
+	qCfg.CompactionInterval = -time.Minute
+	assert.EqualError(t, qCfg.Validate(), "compaction interval must not be negative")
+
 	// Confirm Validate doesn't return error with invalid config when feature is disabled
 	qCfg.Enabled = false
 	assert.NoError(t, qCfg.Validate())
@@ -339,6 +430,77 @@ func TestQueuedRetryPersistentEnabled_shutdown_dataIsRequeued(t *testing.T) {
 	}, time.Second, 1*time.Millisecond)
 }
 
+func TestQueuedRetryPersistentDropOldestOnFull(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 0 // to make every request stay in the queue
+	qCfg.QueueSize = 1
+	qCfg.OverflowPolicy = OverflowPolicyDropOldest
+	storageID := component.NewIDWithName("file_storage", "storage")
+	qCfg.StorageID = &storageID
+	set := exportertest.NewNopCreateSettings()
+	set.ID = component.NewIDWithName("test", "persistent_drop_oldest")
+	marshaler := func(internal.Request) ([]byte, error) { return []byte("request"), nil }
+	unmarshaler := func([]byte) (internal.Request, error) { return newMockRequest(context.Background(), 2, nil), nil }
+	be, err := newBaseExporter(set, "", false, marshaler, unmarshaler, newObservabilityConsumerSender, WithQueue(qCfg))
+	require.NoError(t, err)
+	host := &mockHost{ext: map[component.ID]component.Component{
+		storageID: internal.NewMockStorageExtension(nil),
+	}}
+	require.NoError(t, be.Start(context.Background(), host))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	qs := be.queueSender.(*queueSender)
+	// The first request is read from the storage, waiting for a consumer.
+	require.NoError(t, be.send(newMockRequest(context.Background(), 2, nil)))
+	assert.Eventually(t, func() bool {
+		return qs.queue.Size() == 0
+	}, time.Second, time.Millisecond)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, be.send(newMockRequest(context.Background(), 2, nil)))
+	}
+	assert.Equal(t, 1, qs.queue.Size())
+	tags := []tag.Tag{{Key: exporterTag, Value: set.ID.String()}}
+	checkValueForGlobalManager(t, tags, int64(2), "exporter/queue_evicted_batches")
+	checkValueForGlobalManager(t, tags, int64(4), "exporter/queue_evicted_items")
+}
+
+func TestQueuedRetryPersistentBlockOnFull(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 0 // to make every request stay in the queue
+	qCfg.QueueSize = 1
+	qCfg.OverflowPolicy = OverflowPolicyBlock
+	storageID := component.NewIDWithName("file_storage", "storage")
+	qCfg.StorageID = &storageID
+	marshaler := func(internal.Request) ([]byte, error) { return []byte("request"), nil }
+	unmarshaler := func([]byte) (internal.Request, error) { return newMockRequest(context.Background(), 2, nil), nil }
+	be, err := newBaseExporter(defaultSettings, "", false, marshaler, unmarshaler, newObservabilityConsumerSender, WithQueue(qCfg))
+	require.NoError(t, err)
+	host := &mockHost{ext: map[component.ID]component.Component{
+		storageID: internal.NewMockStorageExtension(nil),
+	}}
+	require.NoError(t, be.Start(context.Background(), host))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	qs := be.queueSender.(*queueSender)
+	// The first request is read from the storage, waiting for a consumer.
+	require.NoError(t, be.send(newMockRequest(context.Background(), 2, nil)))
+	assert.Eventually(t, func() bool {
+		return qs.queue.Size() == 0
+	}, time.Second, time.Millisecond)
+	require.NoError(t, be.send(newMockRequest(context.Background(), 2, nil)))
+
+	// The request waits for room until it is canceled.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	assert.ErrorIs(t, be.send(newMockRequest(ctx, 2, nil)), errSendingQueueIsFull)
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+}
+
 type mockHost struct {
 	component.Host
 	ext map[component.ID]component.Component
@@ -347,3 +509,52 @@ type mockHost struct {
 func (nh *mockHost) GetExtensions() map[component.ID]component.Component {
 	return nh.ext
 }
+
+type inFlightHost struct {
+	component.Host
+	tracker *inflight.Tracker
+}
+
+func (h inFlightHost) GetInFlightTracker() *inflight.Tracker {
+	return h.tracker
+}
+
+func TestQueuedRetry_InFlightBytes(t *testing.T) {
+	td := testdata.GenerateTraces(2)
+	tracker := inflight.NewTracker(0)
+	host := inFlightHost{Host: componenttest.NewNopHost(), tracker: tracker}
+
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 0 // to make every request stay in the queue
+	be, err := newBaseExporter(defaultSettings, "", false, nil, nil, newObservabilityConsumerSender, WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), host))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+	require.NoError(t, be.send(newTracesRequest(context.Background(), td, nil)))
+	require.NoError(t, be.send(newTracesRequest(context.Background(), td, nil)))
+	assert.Equal(t, int64(2*tracesMarshaler.TracesSize(td)), tracker.Component(component.KindExporter, defaultSettings.ID).Bytes())
+}
+
+func TestQueuedRetry_InFlightBytesReleased(t *testing.T) {
+	td := testdata.GenerateTraces(2)
+	tracker := inflight.NewTracker(0)
+	host := inFlightHost{Host: componenttest.NewNopHost(), tracker: tracker}
+
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	be, err := newBaseExporter(defaultSettings, "", false, nil, nil, newObservabilityConsumerSender, WithQueue(qCfg))
+	require.NoError(t, err)
+	ocs := be.obsrepSender.(*observabilityConsumerSender)
+	require.NoError(t, be.Start(context.Background(), host))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+	ocs.run(func() {
+		require.NoError(t, be.send(newTracesRequest(context.Background(), td, func(context.Context, ptrace.Traces) error { return nil })))
+	})
+	ocs.awaitAsyncProcessing()
+	assert.Zero(t, tracker.Bytes())
+	assert.NotNil(t, be.queueSender.(*queueSender).inflight)
+}
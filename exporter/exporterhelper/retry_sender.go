@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
+	"go.opencensus.io/metric/metricdata"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
@@ -37,6 +38,8 @@ type RetrySettings struct {
 	// MaxElapsedTime is the maximum amount of time (including retries) spent trying to send a request/batch.
 	// Once this value is reached, the data is discarded.
 	MaxElapsedTime time.Duration `mapstructure:"max_elapsed_time"`
+	// CircuitBreaker stops sending the batches to a degraded backend, see CircuitBreakerSettings.
+	CircuitBreaker CircuitBreakerSettings `mapstructure:"circuit_breaker"`
 }
 
 // NewDefaultRetrySettings returns the default settings for RetrySettings.
@@ -48,6 +51,7 @@ func NewDefaultRetrySettings() RetrySettings {
 		Multiplier:          backoff.DefaultMultiplier,
 		MaxInterval:         30 * time.Second,
 		MaxElapsedTime:      5 * time.Minute,
+		CircuitBreaker:      NewDefaultCircuitBreakerSettings(),
 	}
 }
 
@@ -82,6 +86,9 @@ type retrySender struct {
 	stopCh             chan struct{}
 	logger             *zap.Logger
 	onTemporaryFailure onRequestHandlingFinishedFunc
+	backoffs           backoffs
+	// breaker is nil if the circuit breaker is disabled.
+	breaker *circuitBreaker
 }
 
 func newRetrySender(id component.ID, rCfg RetrySettings, logger *zap.Logger, onTemporaryFailure onRequestHandlingFinishedFunc) *retrySender {
@@ -90,13 +97,21 @@ func newRetrySender(id component.ID, rCfg RetrySettings, logger *zap.Logger, onT
 			return err
 		}
 	}
-	return &retrySender{
+	rs := &retrySender{
 		traceAttribute:     attribute.String(obsmetrics.ExporterKey, id.String()),
 		cfg:                rCfg,
 		stopCh:             make(chan struct{}),
 		logger:             logger,
 		onTemporaryFailure: onTemporaryFailure,
 	}
+	if rCfg.Enabled && rCfg.CircuitBreaker.Enabled {
+		// Send the batches waiting for the circuit breaker as soon as it closes.
+		rs.breaker = newCircuitBreaker(rCfg.CircuitBreaker, rs.backoffs.nudge)
+		_ = globalInstruments.circuitBreakerState.UpsertEntry(func() int64 {
+			return int64(rs.breaker.currentState())
+		}, metricdata.NewLabelValue(id.String()))
+	}
+	return rs
 }
 
 func (rs *retrySender) shutdown() {
@@ -129,13 +144,32 @@ func (rs *retrySender) send(req internal.Request) error {
 	}
 	expBackoff.Reset()
 	span := trace.SpanFromContext(req.Context())
+	// count is the number of items of the request, before only the failed ones are retried.
+	count := req.Count()
 	retryNum := int64(0)
 	for {
+		if rs.breaker != nil {
+			if ok, retryAt := rs.breaker.allow(time.Now()); !ok {
+				// Hold the request instead of sending it to the degraded backend, which holds back the queue.
+				span.AddEvent(
+					"Circuit breaker is open. Will send the request once it lets requests through.",
+					trace.WithAttributes(rs.traceAttribute))
+				if err := rs.wait(req, time.Until(retryAt), errCircuitBreakerOpen); err != nil {
+					return partialFailure(err, req, count)
+				}
+				continue
+			}
+		}
+
 		span.AddEvent(
 			"Sending request.",
 			trace.WithAttributes(rs.traceAttribute, attribute.Int64("retry_num", retryNum)))
 
 		err := rs.nextSender.send(req)
+		if rs.breaker != nil {
+			// The permanent errors are caused by the data, not by the health of the backend.
+			rs.breaker.record(time.Now(), err != nil && !consumererror.IsPermanent(err))
+		}
 		if err == nil {
 			return nil
 		}
@@ -147,7 +181,7 @@ func (rs *retrySender) send(req internal.Request) error {
 				zap.Error(err),
 				zap.Int("dropped_items", req.Count()),
 			)
-			return err
+			return partialFailure(err, req, count)
 		}
 
 		// Give the request a chance to extract signal data to retry if only some data
@@ -158,7 +192,7 @@ func (rs *retrySender) send(req internal.Request) error {
 		if backoffDelay == backoff.Stop {
 			// throw away the batch
 			err = fmt.Errorf("max elapsed time expired %w", err)
-			return rs.onTemporaryFailure(rs.logger, req, err)
+			return partialFailure(rs.onTemporaryFailure(rs.logger, req, err), req, count)
 		}
 
 		throttleErr := throttleRetry{}
@@ -181,17 +215,40 @@ func (rs *retrySender) send(req internal.Request) error {
 		)
 		retryNum++
 
-		// back-off, but get interrupted when shutting down or request is cancelled or timed out.
-		select {
-		case <-req.Context().Done():
-			return fmt.Errorf("Request is cancelled or timed out %w", err)
-		case <-rs.stopCh:
-			return rs.onTemporaryFailure(rs.logger, req, fmt.Errorf("interrupted due to shutdown %w", err))
-		case <-time.After(backoffDelay):
+		if err = rs.wait(req, backoffDelay, err); err != nil {
+			return partialFailure(err, req, count)
 		}
 	}
 }
 
+// partialFailure reports the error of a request whose items were partially sent, only the failed items
+// being retried, as a partial success rejecting the items not sent.
+func partialFailure(err error, req internal.Request, count int) error {
+	if err == nil || req.Count() >= count {
+		return err
+	}
+	if _, ok := consumererror.AsPartialSuccess(err); ok {
+		return err
+	}
+	return consumererror.NewPartialSuccess(err, req.Count())
+}
+
+// wait backs off for the given delay before retrying the request which failed with err, but gets interrupted
+// when shutting down or request is cancelled or timed out. It returns the error to return instead of retrying.
+func (rs *retrySender) wait(req internal.Request, delay time.Duration, err error) error {
+	retried, nudged := rs.backoffs.wait(time.Now().Add(delay), err)
+	defer retried()
+	select {
+	case <-req.Context().Done():
+		return fmt.Errorf("Request is cancelled or timed out %w", err)
+	case <-rs.stopCh:
+		return rs.onTemporaryFailure(rs.logger, req, consumererror.NewShutdown(fmt.Errorf("interrupted due to shutdown %w", err)))
+	case <-time.After(delay):
+	case <-nudged:
+	}
+	return nil
+}
+
 // max returns the larger of x or y.
 func max(x, y time.Duration) time.Duration {
 	if x < y {
@@ -24,6 +24,7 @@ import (
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
 func mockRequestUnmarshaler(mr *mockRequest) internal.RequestUnmarshaler {
@@ -112,6 +113,29 @@ func TestQueuedRetry_OnError(t *testing.T) {
 	ocs.checkDroppedItemsCount(t, 0)
 }
 
+func TestRetry_PartialSuccess(t *testing.T) {
+	rCfg := NewDefaultRetrySettings()
+	rCfg.InitialInterval = 0
+	var calls int
+	te, err := NewTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), &fakeTracesExporterConfig,
+		func(context.Context, ptrace.Traces) error {
+			calls++
+			if calls == 1 {
+				// Only the first span failed to be sent, and is retried.
+				return consumererror.NewTraces(errors.New("some error"), testdata.GenerateTraces(1))
+			}
+			return consumererror.NewPermanent(errors.New("bad data"))
+		}, WithRetry(rCfg))
+	require.NoError(t, err)
+
+	err = te.ConsumeTraces(context.Background(), testdata.GenerateTraces(3))
+	assert.Equal(t, 2, calls)
+	p, ok := consumererror.AsPartialSuccess(err)
+	require.True(t, ok)
+	assert.Equal(t, 1, p.Rejected())
+	assert.False(t, p.Retryable())
+}
+
 func TestQueuedRetry_MaxElapsedTime(t *testing.T) {
 	qCfg := NewDefaultQueueSettings()
 	qCfg.NumConsumers = 1
@@ -218,6 +242,38 @@ func TestQueuedRetry_RetryOnError(t *testing.T) {
 	require.Zero(t, be.queueSender.(*queueSender).queue.Size())
 }
 
+func TestQueuedRetry_Flush(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	rCfg := NewDefaultRetrySettings()
+	rCfg.InitialInterval = time.Hour
+	rCfg.MaxInterval = time.Hour
+	rCfg.MaxElapsedTime = 0
+	be, err := newBaseExporter(defaultSettings, "", false, nil, nil, newObservabilityConsumerSender, WithRetry(rCfg), WithQueue(qCfg))
+	require.NoError(t, err)
+	ocs := be.obsrepSender.(*observabilityConsumerSender)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	mockR := newMockRequest(context.Background(), 2, errors.New("transient error"))
+	ocs.run(func() {
+		// This is asynchronous so it should just enqueue, no errors expected.
+		require.NoError(t, be.send(mockR))
+	})
+	assert.Eventually(t, func() bool {
+		return be.ExporterStatus().RetryingRequests == 1
+	}, time.Second, time.Millisecond)
+
+	// The request is retried without waiting for the backoff.
+	require.NoError(t, be.Flush(context.Background()))
+	ocs.awaitAsyncProcessing()
+	mockR.checkNumRequests(t, 2)
+	ocs.checkSendItemsCount(t, 2)
+	assert.Zero(t, be.ExporterStatus().RetryingRequests)
+}
+
 func TestQueueRetryWithNoQueue(t *testing.T) {
 	rCfg := NewDefaultRetrySettings()
 	rCfg.MaxElapsedTime = time.Nanosecond // fail fast
@@ -237,6 +293,21 @@ func TestQueueRetryWithNoQueue(t *testing.T) {
 	require.NoError(t, be.Shutdown(context.Background()))
 }
 
+func TestQueueRetryWithNoQueue_InterruptedOnShutdown(t *testing.T) {
+	be, err := newBaseExporter(exportertest.NewNopCreateSettings(), component.DataTypeLogs, false, nil, nil, newObservabilityConsumerSender, WithRetry(NewDefaultRetrySettings()))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	ocs := be.obsrepSender.(*observabilityConsumerSender)
+	errCh := make(chan error, 1)
+	ocs.run(func() {
+		go func() { errCh <- be.send(newErrorRequest(context.Background())) }()
+	})
+	require.NoError(t, be.Shutdown(context.Background()))
+	assert.True(t, consumererror.IsShutdown(<-errCh))
+	ocs.awaitAsyncProcessing()
+	ocs.checkDroppedItemsCount(t, 7)
+}
+
 type mockErrorRequest struct {
 	baseRequest
 }
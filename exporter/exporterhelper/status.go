@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer"
+)
+
+// Status is a snapshot of the sending queue and of the retries of an exporter.
+type Status struct {
+	// QueueEnabled is true if the exporter has a sending queue.
+	QueueEnabled bool
+	// Persistent is true if the sending queue is backed by a storage extension.
+	Persistent bool
+	// QueueSize is the number of batches in the sending queue.
+	QueueSize int
+	// QueueCapacity is the maximum number of batches in the sending queue.
+	QueueCapacity int
+	// OldestItemAge is the time spent in the sending queue by the oldest batch, 0 if the queue is empty.
+	// The batches left in a persistent queue by a previous run of the collector are not accounted for.
+	OldestItemAge time.Duration
+	// NumConsumers is the number of consumers from the sending queue.
+	NumConsumers int
+
+	// RetryEnabled is true if the exporter retries the batches failing with a retryable error.
+	RetryEnabled bool
+	// RetryingRequests is the number of batches waiting for their backoff to expire before being retried.
+	RetryingRequests int
+	// NextRetry is the time of the next retry, zero if no batch is waiting to be retried.
+	NextRetry time.Time
+	// LastRetryError is the error of the last failed attempt, empty if no batch is waiting to be retried.
+	LastRetryError string
+	// CircuitBreakerEnabled is true if the exporter stops sending the batches to a degraded backend.
+	CircuitBreakerEnabled bool
+	// CircuitBreakerState is the state of the circuit breaker, closed if it is disabled.
+	CircuitBreakerState CircuitBreakerState
+}
+
+// StatusReporter is implemented by the exporters created with this package,
+// reporting the live status of their sending queue and of their retries.
+type StatusReporter interface {
+	ExporterStatus() Status
+}
+
+var _ StatusReporter = (*baseExporter)(nil)
+
+// ExporterStatus implements the StatusReporter interface.
+func (be *baseExporter) ExporterStatus() Status {
+	var st Status
+	if qs, ok := be.queueSender.(*queueSender); ok && qs.queue != nil {
+		st.QueueEnabled = true
+		st.Persistent = qs.queue.IsPersistent()
+		st.QueueSize = qs.queue.Size()
+		st.QueueCapacity = qs.queue.Capacity()
+		st.OldestItemAge = qs.enqueueTimes.oldestAge(time.Now())
+		st.NumConsumers = qs.numConsumers
+	}
+	if rs, ok := be.retrySender.(*retrySender); ok {
+		st.RetryEnabled = rs.cfg.Enabled
+		st.RetryingRequests, st.NextRetry, st.LastRetryError = rs.backoffs.status()
+		if rs.breaker != nil {
+			st.CircuitBreakerEnabled = true
+			st.CircuitBreakerState = rs.breaker.currentState()
+		}
+	}
+	return st
+}
+
+var _ consumer.BackpressureReporter = (*baseExporter)(nil)
+
+// Backpressure implements the consumer.BackpressureReporter interface,
+// reporting backpressure while the sending queue is full.
+func (be *baseExporter) Backpressure() bool {
+	qs, ok := be.queueSender.(*queueSender)
+	return ok && qs.queue != nil && qs.queue.Size() >= qs.queue.Capacity()
+}
+
+// enqueueTimes is the list of the times the batches in a queue were enqueued, in order.
+type enqueueTimes struct {
+	mu    sync.Mutex
+	times []time.Time
+}
+
+func (et *enqueueTimes) push(t time.Time) {
+	et.mu.Lock()
+	defer et.mu.Unlock()
+	et.times = append(et.times, t)
+}
+
+// popOldest removes the time of the batch being dequeued.
+func (et *enqueueTimes) popOldest() {
+	et.mu.Lock()
+	defer et.mu.Unlock()
+	if len(et.times) > 0 {
+		et.times = et.times[1:]
+	}
+}
+
+// popNewest removes the time of a batch that failed to be enqueued.
+func (et *enqueueTimes) popNewest() {
+	et.mu.Lock()
+	defer et.mu.Unlock()
+	if len(et.times) > 0 {
+		et.times = et.times[:len(et.times)-1]
+	}
+}
+
+func (et *enqueueTimes) oldestAge(now time.Time) time.Duration {
+	et.mu.Lock()
+	defer et.mu.Unlock()
+	if len(et.times) == 0 {
+		return 0
+	}
+	return now.Sub(et.times[0])
+}
+
+// backoffs tracks the batches waiting for their backoff to expire before being retried.
+type backoffs struct {
+	mu      sync.Mutex
+	nextID  uint64
+	waiting map[uint64]time.Time
+	lastErr string
+	// nudgeC is closed to retry the waiting batches immediately.
+	nudgeC chan struct{}
+}
+
+// wait records a batch retried at the given time. It returns the function to call once it is retried,
+// and the channel closed when the batch must be retried immediately.
+func (b *backoffs) wait(retryAt time.Time, err error) (func(), <-chan struct{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.waiting == nil {
+		b.waiting = map[uint64]time.Time{}
+	}
+	if b.nudgeC == nil {
+		b.nudgeC = make(chan struct{})
+	}
+	id := b.nextID
+	b.nextID++
+	b.waiting[id] = retryAt
+	b.lastErr = err.Error()
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.waiting, id)
+	}, b.nudgeC
+}
+
+// nudge retries immediately the batches waiting for their backoff to expire.
+func (b *backoffs) nudge() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.nudgeC != nil {
+		close(b.nudgeC)
+		b.nudgeC = nil
+	}
+}
+
+func (b *backoffs) status() (int, time.Time, string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.waiting) == 0 {
+		return 0, time.Time{}, ""
+	}
+	var next time.Time
+	for _, retryAt := range b.waiting {
+		if next.IsZero() || retryAt.Before(next) {
+			next = retryAt
+		}
+	}
+	return len(b.waiting), next, b.lastErr
+}
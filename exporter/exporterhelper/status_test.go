@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func TestExporterStatusDisabled(t *testing.T) {
+	be, err := newBaseExporter(defaultSettings, "", false, nil, nil, newNoopObsrepSender)
+	require.NoError(t, err)
+	assert.Equal(t, Status{}, be.ExporterStatus())
+}
+
+func TestExporterStatus(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	qCfg.QueueSize = 10
+	rCfg := NewDefaultRetrySettings()
+	rCfg.InitialInterval = time.Hour
+	rCfg.MaxInterval = time.Hour
+	rCfg.MaxElapsedTime = 0
+	rCfg.RandomizationFactor = 0
+	be, err := newBaseExporter(defaultSettings, "", false, nil, nil, newObservabilityConsumerSender, WithRetry(rCfg), WithQueue(qCfg))
+	require.NoError(t, err)
+	ocs := be.obsrepSender.(*observabilityConsumerSender)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+
+	assert.Equal(t, Status{
+		QueueEnabled:  true,
+		QueueCapacity: 10,
+		NumConsumers:  1,
+		RetryEnabled:  true,
+	}, be.ExporterStatus())
+
+	// The first request is waiting to be retried, blocking the only consumer.
+	start := time.Now()
+	ocs.run(func() {
+		require.NoError(t, be.send(newMockRequest(context.Background(), 2, errors.New("transient error"))))
+	})
+	assert.Eventually(t, func() bool {
+		return be.ExporterStatus().RetryingRequests == 1
+	}, time.Second, time.Millisecond)
+	ocs.run(func() {
+		require.NoError(t, be.send(newMockRequest(context.Background(), 2, nil)))
+	})
+	ocs.run(func() {
+		require.NoError(t, be.send(newMockRequest(context.Background(), 2, nil)))
+	})
+
+	st := be.ExporterStatus()
+	assert.Equal(t, 2, st.QueueSize)
+	assert.Greater(t, st.OldestItemAge, time.Duration(0))
+	assert.LessOrEqual(t, st.OldestItemAge, time.Since(start))
+	assert.Equal(t, "transient error", st.LastRetryError)
+	assert.WithinDuration(t, start.Add(time.Hour), st.NextRetry, 10*time.Second)
+
+	require.NoError(t, be.Shutdown(context.Background()))
+	st = be.ExporterStatus()
+	assert.Zero(t, st.QueueSize)
+	assert.Zero(t, st.OldestItemAge)
+	assert.Zero(t, st.RetryingRequests)
+	assert.True(t, st.NextRetry.IsZero())
+}
+
+func TestExporterBackpressure(t *testing.T) {
+	be, err := newBaseExporter(defaultSettings, "", false, nil, nil, newNoopObsrepSender)
+	require.NoError(t, err)
+	assert.False(t, be.Backpressure())
+
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	qCfg.QueueSize = 2
+	rCfg := NewDefaultRetrySettings()
+	rCfg.InitialInterval = time.Hour
+	rCfg.MaxInterval = time.Hour
+	rCfg.MaxElapsedTime = 0
+	be, err = newBaseExporter(defaultSettings, "", false, nil, nil, newObservabilityConsumerSender, WithRetry(rCfg), WithQueue(qCfg))
+	require.NoError(t, err)
+	ocs := be.obsrepSender.(*observabilityConsumerSender)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	assert.False(t, be.Backpressure())
+
+	// The first request is waiting to be retried, blocking the only consumer, until the queue is full.
+	ocs.run(func() {
+		require.NoError(t, be.send(newMockRequest(context.Background(), 2, errors.New("transient error"))))
+	})
+	assert.Eventually(t, func() bool {
+		return be.ExporterStatus().RetryingRequests == 1
+	}, time.Second, time.Millisecond)
+	for i := 0; i < 2; i++ {
+		assert.False(t, be.Backpressure())
+		ocs.run(func() {
+			require.NoError(t, be.send(newMockRequest(context.Background(), 2, nil)))
+		})
+	}
+	assert.True(t, be.Backpressure())
+
+	require.NoError(t, be.Shutdown(context.Background()))
+	assert.False(t, be.Backpressure())
+}
+
+func TestEnqueueTimes(t *testing.T) {
+	et := enqueueTimes{}
+	now := time.Now()
+	assert.Zero(t, et.oldestAge(now))
+
+	et.push(now.Add(-3 * time.Second))
+	et.push(now.Add(-2 * time.Second))
+	et.push(now.Add(-time.Second))
+	assert.Equal(t, 3*time.Second, et.oldestAge(now))
+
+	et.popNewest()
+	et.popOldest()
+	assert.Equal(t, 2*time.Second, et.oldestAge(now))
+	et.popOldest()
+	et.popOldest()
+	assert.Zero(t, et.oldestAge(now))
+}
@@ -0,0 +1,356 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const (
+	// TemporalityCumulative converts the delta sums and histograms to cumulative.
+	TemporalityCumulative = "cumulative"
+	// TemporalityDelta converts the cumulative sums and histograms to delta.
+	TemporalityDelta = "delta"
+)
+
+// TemporalitySettings defines the conversion of the aggregation temporality of the metrics before they are sent,
+// for the backends supporting a single temporality.
+type TemporalitySettings struct {
+	// Temporality is the aggregation temporality of the sums and histograms sent, either "cumulative" or "delta".
+	// The conversion is disabled if empty.
+	Temporality string `mapstructure:"temporality"`
+	// MaxStreams is the maximum number of streams, i.e. data points sharing the same identity, whose state is kept
+	// to convert their temporality. The state of the least recently seen streams is dropped beyond this limit.
+	MaxStreams int `mapstructure:"temporality_max_streams"`
+}
+
+// NewDefaultTemporalitySettings returns the default settings for TemporalitySettings.
+func NewDefaultTemporalitySettings() TemporalitySettings {
+	return TemporalitySettings{
+		MaxStreams: 10000,
+	}
+}
+
+// Validate checks if the TemporalitySettings configuration is valid
+func (tCfg *TemporalitySettings) Validate() error {
+	switch tCfg.Temporality {
+	case "", TemporalityCumulative, TemporalityDelta:
+	default:
+		return fmt.Errorf("temporality must be %q or %q, got %q", TemporalityCumulative, TemporalityDelta, tCfg.Temporality)
+	}
+	if tCfg.Temporality != "" && tCfg.MaxStreams <= 0 {
+		return errors.New("temporality_max_streams must be positive")
+	}
+	return nil
+}
+
+// convertTemporality returns a copy of md with the temporality converted, md if the conversion is disabled.
+// The copy leaves the data shared with the other consumers unchanged.
+func (be *baseExporter) convertTemporality(md pmetric.Metrics) pmetric.Metrics {
+	if be.temporality == nil {
+		return md
+	}
+	converted := pmetric.NewMetrics()
+	md.CopyTo(converted)
+	be.temporality.convert(converted)
+	return converted
+}
+
+// streamState is the last point of a stream, cumulative since start.
+type streamState struct {
+	start     pcommon.Timestamp
+	timestamp pcommon.Timestamp
+
+	valueType   pmetric.NumberDataPointValueType
+	intValue    int64
+	doubleValue float64
+
+	count   uint64
+	sum     float64
+	hasSum  bool
+	buckets []uint64
+	bounds  []float64
+}
+
+type streamEntry struct {
+	key   string
+	state *streamState
+}
+
+// temporalityConverter converts the temporality of the sums and histograms, keeping the state of the
+// most recently seen streams. The exponential histograms are sent unchanged.
+type temporalityConverter struct {
+	toDelta    bool
+	maxStreams int
+
+	mu      sync.Mutex
+	lru     *list.List
+	streams map[string]*list.Element
+}
+
+func newTemporalityConverter(cfg TemporalitySettings) *temporalityConverter {
+	return &temporalityConverter{
+		toDelta:    cfg.Temporality == TemporalityDelta,
+		maxStreams: cfg.MaxStreams,
+		lru:        list.New(),
+		streams:    map[string]*list.Element{},
+	}
+}
+
+// convert converts md in place. The data points that cannot be converted to delta, having no previous
+// point nor a start timestamp, are removed.
+func (tc *temporalityConverter) convert(md pmetric.Metrics) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resourceKey := mapKey(rm.Resource().Attributes())
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			scopeKey := resourceKey + "|" + sm.Scope().Name() + "|" + sm.Scope().Version() + "|" + mapKey(sm.Scope().Attributes())
+			ms := sm.Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				tc.convertMetric(scopeKey, ms.At(k))
+			}
+		}
+	}
+}
+
+func (tc *temporalityConverter) convertMetric(scopeKey string, m pmetric.Metric) {
+	metricKey := scopeKey + "|" + m.Name() + "|" + m.Unit() + "|" + m.Type().String()
+	switch m.Type() {
+	case pmetric.MetricTypeSum:
+		sum := m.Sum()
+		if !tc.needsConversion(sum.AggregationTemporality()) {
+			return
+		}
+		sum.DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+			key := metricKey + "|" + mapKey(dp.Attributes())
+			if tc.toDelta {
+				return !tc.sumToDelta(key, sum.IsMonotonic(), dp)
+			}
+			tc.sumToCumulative(key, dp)
+			return false
+		})
+		sum.SetAggregationTemporality(tc.temporality())
+	case pmetric.MetricTypeHistogram:
+		hist := m.Histogram()
+		if !tc.needsConversion(hist.AggregationTemporality()) {
+			return
+		}
+		hist.DataPoints().RemoveIf(func(dp pmetric.HistogramDataPoint) bool {
+			key := metricKey + "|" + mapKey(dp.Attributes())
+			if tc.toDelta {
+				return !tc.histogramToDelta(key, dp)
+			}
+			tc.histogramToCumulative(key, dp)
+			return false
+		})
+		hist.SetAggregationTemporality(tc.temporality())
+	}
+}
+
+func (tc *temporalityConverter) temporality() pmetric.AggregationTemporality {
+	if tc.toDelta {
+		return pmetric.AggregationTemporalityDelta
+	}
+	return pmetric.AggregationTemporalityCumulative
+}
+
+func (tc *temporalityConverter) needsConversion(temporality pmetric.AggregationTemporality) bool {
+	if tc.toDelta {
+		return temporality == pmetric.AggregationTemporalityCumulative
+	}
+	return temporality == pmetric.AggregationTemporalityDelta
+}
+
+// sumToDelta converts a cumulative point to delta, returning false if the point must be removed.
+func (tc *temporalityConverter) sumToDelta(key string, monotonic bool, dp pmetric.NumberDataPoint) bool {
+	if dp.Flags().NoRecordedValue() {
+		return true
+	}
+	prev := tc.get(key)
+	cur := &streamState{
+		start:       dp.StartTimestamp(),
+		timestamp:   dp.Timestamp(),
+		valueType:   dp.ValueType(),
+		intValue:    dp.IntValue(),
+		doubleValue: dp.DoubleValue(),
+	}
+	tc.put(key, cur)
+	reset := prev == nil || prev.start != dp.StartTimestamp() || prev.timestamp >= dp.Timestamp() || prev.valueType != dp.ValueType() ||
+		monotonic && (dp.IntValue() < prev.intValue || dp.DoubleValue() < prev.doubleValue)
+	if reset {
+		// The value since the start timestamp is a delta, if the start timestamp is known.
+		return dp.StartTimestamp() != 0 && dp.StartTimestamp() < dp.Timestamp()
+	}
+	dp.SetStartTimestamp(prev.timestamp)
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		dp.SetIntValue(dp.IntValue() - prev.intValue)
+	case pmetric.NumberDataPointValueTypeDouble:
+		dp.SetDoubleValue(dp.DoubleValue() - prev.doubleValue)
+	}
+	return true
+}
+
+func (tc *temporalityConverter) sumToCumulative(key string, dp pmetric.NumberDataPoint) {
+	if dp.Flags().NoRecordedValue() {
+		return
+	}
+	state := tc.get(key)
+	if state == nil || state.valueType != dp.ValueType() {
+		state = &streamState{start: dp.StartTimestamp(), valueType: dp.ValueType()}
+		tc.put(key, state)
+	}
+	state.timestamp = dp.Timestamp()
+	dp.SetStartTimestamp(state.start)
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		state.intValue += dp.IntValue()
+		dp.SetIntValue(state.intValue)
+	case pmetric.NumberDataPointValueTypeDouble:
+		state.doubleValue += dp.DoubleValue()
+		dp.SetDoubleValue(state.doubleValue)
+	}
+}
+
+// histogramToDelta converts a cumulative point to delta, returning false if the point must be removed.
+func (tc *temporalityConverter) histogramToDelta(key string, dp pmetric.HistogramDataPoint) bool {
+	if dp.Flags().NoRecordedValue() {
+		return true
+	}
+	prev := tc.get(key)
+	cur := &streamState{
+		start:     dp.StartTimestamp(),
+		timestamp: dp.Timestamp(),
+		count:     dp.Count(),
+		sum:       dp.Sum(),
+		hasSum:    dp.HasSum(),
+		buckets:   dp.BucketCounts().AsRaw(),
+		bounds:    dp.ExplicitBounds().AsRaw(),
+	}
+	tc.put(key, cur)
+	reset := prev == nil || prev.start != dp.StartTimestamp() || prev.timestamp >= dp.Timestamp() ||
+		dp.Count() < prev.count || !equalBuckets(prev, cur)
+	if reset {
+		return dp.StartTimestamp() != 0 && dp.StartTimestamp() < dp.Timestamp()
+	}
+	dp.SetStartTimestamp(prev.timestamp)
+	dp.SetCount(dp.Count() - prev.count)
+	if dp.HasSum() && prev.hasSum {
+		dp.SetSum(dp.Sum() - prev.sum)
+	} else {
+		dp.RemoveSum()
+	}
+	for i := 0; i < dp.BucketCounts().Len(); i++ {
+		dp.BucketCounts().SetAt(i, dp.BucketCounts().At(i)-prev.buckets[i])
+	}
+	// The minimum and maximum of the interval are unknown.
+	dp.RemoveMin()
+	dp.RemoveMax()
+	return true
+}
+
+func (tc *temporalityConverter) histogramToCumulative(key string, dp pmetric.HistogramDataPoint) {
+	if dp.Flags().NoRecordedValue() {
+		return
+	}
+	state := tc.get(key)
+	if state == nil || !equalBounds(state.bounds, dp.ExplicitBounds().AsRaw()) || len(state.buckets) != dp.BucketCounts().Len() {
+		state = &streamState{
+			start:   dp.StartTimestamp(),
+			hasSum:  true,
+			buckets: make([]uint64, dp.BucketCounts().Len()),
+			bounds:  dp.ExplicitBounds().AsRaw(),
+		}
+		tc.put(key, state)
+	}
+	state.timestamp = dp.Timestamp()
+	state.count += dp.Count()
+	state.hasSum = state.hasSum && dp.HasSum()
+	state.sum += dp.Sum()
+	for i := range state.buckets {
+		state.buckets[i] += dp.BucketCounts().At(i)
+	}
+
+	dp.SetStartTimestamp(state.start)
+	dp.SetCount(state.count)
+	if state.hasSum {
+		dp.SetSum(state.sum)
+	} else {
+		dp.RemoveSum()
+	}
+	dp.BucketCounts().FromRaw(state.buckets)
+	// The minimum and maximum since the start are not tracked.
+	dp.RemoveMin()
+	dp.RemoveMax()
+}
+
+// get returns the state of the stream, marking it as the most recently seen.
+func (tc *temporalityConverter) get(key string) *streamState {
+	elem, ok := tc.streams[key]
+	if !ok {
+		return nil
+	}
+	tc.lru.MoveToFront(elem)
+	return elem.Value.(*streamEntry).state
+}
+
+// put sets the state of the stream, dropping the least recently seen stream beyond the limit.
+func (tc *temporalityConverter) put(key string, state *streamState) {
+	if elem, ok := tc.streams[key]; ok {
+		elem.Value.(*streamEntry).state = state
+		tc.lru.MoveToFront(elem)
+		return
+	}
+	tc.streams[key] = tc.lru.PushFront(&streamEntry{key: key, state: state})
+	if tc.lru.Len() > tc.maxStreams {
+		oldest := tc.lru.Back()
+		tc.lru.Remove(oldest)
+		delete(tc.streams, oldest.Value.(*streamEntry).key)
+	}
+}
+
+func equalBuckets(prev, cur *streamState) bool {
+	return len(prev.buckets) == len(cur.buckets) && equalBounds(prev.bounds, cur.bounds)
+}
+
+func equalBounds(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// mapKey returns a string identifying the attributes, independently of their order.
+func mapKey(attrs pcommon.Map) string {
+	keys := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, _ pcommon.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	sort.Strings(keys)
+	var b strings.Builder
+	for _, k := range keys {
+		v, _ := attrs.Get(k)
+		fmt.Fprintf(&b, "%q=%q;", k, v.AsString())
+	}
+	return b.String()
+}
@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestTemporalitySettings_Validate(t *testing.T) {
+	tCfg := NewDefaultTemporalitySettings()
+	assert.NoError(t, tCfg.Validate())
+
+	tCfg.Temporality = TemporalityDelta
+	assert.NoError(t, tCfg.Validate())
+
+	tCfg.Temporality = "gauge"
+	assert.EqualError(t, tCfg.Validate(), `temporality must be "cumulative" or "delta", got "gauge"`)
+
+	tCfg.Temporality = TemporalityCumulative
+	tCfg.MaxStreams = 0
+	assert.EqualError(t, tCfg.Validate(), "temporality_max_streams must be positive")
+}
+
+func newTestSum(temporality pmetric.AggregationTemporality, start, ts pcommon.Timestamp, values ...int64) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "test")
+	m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("requests")
+	sum := m.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(temporality)
+	for i, v := range values {
+		dp := sum.DataPoints().AppendEmpty()
+		dp.Attributes().PutInt("index", int64(i))
+		dp.SetStartTimestamp(start)
+		dp.SetTimestamp(ts)
+		dp.SetIntValue(v)
+	}
+	return md
+}
+
+func newTestHistogram(temporality pmetric.AggregationTemporality, start, ts pcommon.Timestamp, count uint64, sum float64, buckets []uint64) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("latency")
+	hist := m.SetEmptyHistogram()
+	hist.SetAggregationTemporality(temporality)
+	dp := hist.DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetCount(count)
+	dp.SetSum(sum)
+	dp.SetMin(1)
+	dp.SetMax(10)
+	dp.ExplicitBounds().FromRaw([]float64{5})
+	dp.BucketCounts().FromRaw(buckets)
+	return md
+}
+
+func sumPoints(md pmetric.Metrics) pmetric.NumberDataPointSlice {
+	return md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+}
+
+func histogramPoint(md pmetric.Metrics) pmetric.HistogramDataPoint {
+	return md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram().DataPoints().At(0)
+}
+
+func TestTemporalityConverter_SumToDelta(t *testing.T) {
+	tc := newTemporalityConverter(TemporalitySettings{Temporality: TemporalityDelta, MaxStreams: 10})
+
+	// The first points are deltas since their start timestamp.
+	md := newTestSum(pmetric.AggregationTemporalityCumulative, 1, 10, 5, 7)
+	tc.convert(md)
+	m := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, m.Sum().AggregationTemporality())
+	require.Equal(t, 2, sumPoints(md).Len())
+	assert.Equal(t, int64(5), sumPoints(md).At(0).IntValue())
+	assert.Equal(t, pcommon.Timestamp(1), sumPoints(md).At(0).StartTimestamp())
+
+	md = newTestSum(pmetric.AggregationTemporalityCumulative, 1, 20, 8, 10)
+	tc.convert(md)
+	require.Equal(t, 2, sumPoints(md).Len())
+	assert.Equal(t, int64(3), sumPoints(md).At(0).IntValue())
+	assert.Equal(t, int64(3), sumPoints(md).At(1).IntValue())
+	assert.Equal(t, pcommon.Timestamp(10), sumPoints(md).At(0).StartTimestamp())
+	assert.Equal(t, pcommon.Timestamp(20), sumPoints(md).At(0).Timestamp())
+
+	// A decreasing value is a reset, sent as is.
+	md = newTestSum(pmetric.AggregationTemporalityCumulative, 1, 30, 2)
+	tc.convert(md)
+	require.Equal(t, 1, sumPoints(md).Len())
+	assert.Equal(t, int64(2), sumPoints(md).At(0).IntValue())
+	assert.Equal(t, pcommon.Timestamp(1), sumPoints(md).At(0).StartTimestamp())
+
+	// The first points without a start timestamp are dropped.
+	md = newTestSum(pmetric.AggregationTemporalityCumulative, 0, 40, 2, 4)
+	md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).SetName("other")
+	tc.convert(md)
+	assert.Equal(t, 0, sumPoints(md).Len())
+
+	// The delta points are sent unchanged.
+	md = newTestSum(pmetric.AggregationTemporalityDelta, 1, 50, 2)
+	tc.convert(md)
+	assert.Equal(t, int64(2), sumPoints(md).At(0).IntValue())
+}
+
+func TestTemporalityConverter_SumToCumulative(t *testing.T) {
+	tc := newTemporalityConverter(TemporalitySettings{Temporality: TemporalityCumulative, MaxStreams: 10})
+
+	md := newTestSum(pmetric.AggregationTemporalityDelta, 1, 10, 5)
+	tc.convert(md)
+	m := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, m.Sum().AggregationTemporality())
+	assert.Equal(t, int64(5), sumPoints(md).At(0).IntValue())
+
+	md = newTestSum(pmetric.AggregationTemporalityDelta, 10, 20, 3)
+	tc.convert(md)
+	assert.Equal(t, int64(8), sumPoints(md).At(0).IntValue())
+	assert.Equal(t, pcommon.Timestamp(1), sumPoints(md).At(0).StartTimestamp())
+	assert.Equal(t, pcommon.Timestamp(20), sumPoints(md).At(0).Timestamp())
+}
+
+func TestTemporalityConverter_HistogramToDelta(t *testing.T) {
+	tc := newTemporalityConverter(TemporalitySettings{Temporality: TemporalityDelta, MaxStreams: 10})
+
+	md := newTestHistogram(pmetric.AggregationTemporalityCumulative, 1, 10, 3, 12, []uint64{2, 1})
+	tc.convert(md)
+	assert.Equal(t, uint64(3), histogramPoint(md).Count())
+
+	md = newTestHistogram(pmetric.AggregationTemporalityCumulative, 1, 20, 7, 30, []uint64{4, 3})
+	tc.convert(md)
+	dp := histogramPoint(md)
+	assert.Equal(t, pcommon.Timestamp(10), dp.StartTimestamp())
+	assert.Equal(t, uint64(4), dp.Count())
+	assert.Equal(t, float64(18), dp.Sum())
+	assert.Equal(t, []uint64{2, 2}, dp.BucketCounts().AsRaw())
+	assert.False(t, dp.HasMin())
+	assert.False(t, dp.HasMax())
+}
+
+func TestTemporalityConverter_HistogramToCumulative(t *testing.T) {
+	tc := newTemporalityConverter(TemporalitySettings{Temporality: TemporalityCumulative, MaxStreams: 10})
+
+	md := newTestHistogram(pmetric.AggregationTemporalityDelta, 1, 10, 3, 12, []uint64{2, 1})
+	tc.convert(md)
+	md = newTestHistogram(pmetric.AggregationTemporalityDelta, 10, 20, 4, 18, []uint64{2, 2})
+	tc.convert(md)
+	dp := histogramPoint(md)
+	assert.Equal(t, pcommon.Timestamp(1), dp.StartTimestamp())
+	assert.Equal(t, uint64(7), dp.Count())
+	assert.Equal(t, float64(30), dp.Sum())
+	assert.Equal(t, []uint64{4, 3}, dp.BucketCounts().AsRaw())
+}
+
+func TestTemporalityConverter_MaxStreams(t *testing.T) {
+	tc := newTemporalityConverter(TemporalitySettings{Temporality: TemporalityCumulative, MaxStreams: 1})
+
+	tc.convert(newTestSum(pmetric.AggregationTemporalityDelta, 1, 10, 5, 7))
+	assert.Len(t, tc.streams, 1)
+	assert.Equal(t, 1, tc.lru.Len())
+
+	// The state of the first stream was dropped, its cumulative value restarts.
+	md := newTestSum(pmetric.AggregationTemporalityDelta, 10, 20, 3)
+	tc.convert(md)
+	assert.Equal(t, int64(3), sumPoints(md).At(0).IntValue())
+	assert.Equal(t, pcommon.Timestamp(10), sumPoints(md).At(0).StartTimestamp())
+}
+
+func TestMetricsExporter_WithTemporality(t *testing.T) {
+	var received []pmetric.Metrics
+	pusher := func(_ context.Context, md pmetric.Metrics) error {
+		received = append(received, md)
+		return nil
+	}
+	tCfg := NewDefaultTemporalitySettings()
+	tCfg.Temporality = TemporalityCumulative
+	me, err := NewMetricsExporter(context.Background(), exportertest.NewNopCreateSettings(), &fakeMetricsExporterConfig, pusher, WithTemporality(tCfg))
+	require.NoError(t, err)
+
+	md := newTestSum(pmetric.AggregationTemporalityDelta, 1, 10, 5)
+	require.NoError(t, me.ConsumeMetrics(context.Background(), md))
+	require.NoError(t, me.ConsumeMetrics(context.Background(), newTestSum(pmetric.AggregationTemporalityDelta, 10, 20, 3)))
+
+	require.Len(t, received, 2)
+	assert.Equal(t, int64(8), sumPoints(received[1]).At(0).IntValue())
+	// The data consumed is left unchanged.
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().AggregationTemporality())
+}
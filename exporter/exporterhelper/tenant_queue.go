@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.opencensus.io/metric/metricdata"
+
+	"go.opentelemetry.io/collector/client"
+)
+
+const (
+	// tenantKey is the label of the per-tenant queue metrics.
+	tenantKey = "tenant"
+	// overflowTenant is the tenant the data of the tenants above TenantQueueSettings.MaxTenants is accounted to.
+	overflowTenant = "_overflow"
+	// defaultMaxTenants is the default TenantQueueSettings.MaxTenants.
+	defaultMaxTenants = 1000
+)
+
+var errTenantQuotaExceeded = fmt.Errorf("%w for the tenant", errSendingQueueIsFull)
+
+// TenantQueueSettings defines the per-tenant quotas of the sending queue, so that the backlog of a single
+// tenant cannot take over the queue shared by all the tenants.
+type TenantQueueSettings struct {
+	// MetadataKey is the client metadata key identifying the tenant of a request, e.g. "x-tenant-id".
+	// The receivers must be configured to include the client metadata, e.g. with "include_metadata: true".
+	// Requests without this metadata are accounted to a single tenant with an empty name.
+	// Tenant quotas are disabled if empty.
+	MetadataKey string `mapstructure:"metadata_key"`
+	// MaxItems is the maximum number of spans, metric data points or log records queued for a single tenant.
+	// No limit if 0.
+	MaxItems int `mapstructure:"max_items"`
+	// MaxBytes is the maximum size, in bytes of the OTLP protobuf encoding, of the data queued for a single tenant.
+	// No limit if 0.
+	MaxBytes int `mapstructure:"max_bytes"`
+	// MaxTenants is the maximum number of tenants tracked. The tenants are read from the client metadata,
+	// the data of the tenants seen once this number is reached is accounted to a single overflow tenant,
+	// sharing the same quota, so that the memory and the metric series used stay bounded.
+	// Defaults to 1000 if 0.
+	MaxTenants int `mapstructure:"max_tenants"`
+}
+
+// Validate checks if the TenantQueueSettings configuration is valid
+func (tCfg *TenantQueueSettings) Validate() error {
+	if tCfg.MaxItems < 0 || tCfg.MaxBytes < 0 || tCfg.MaxTenants < 0 {
+		return errors.New("tenant quotas must not be negative")
+	}
+	if tCfg.MetadataKey == "" && (tCfg.MaxItems != 0 || tCfg.MaxBytes != 0) {
+		return errors.New("tenant metadata_key must be set to enforce tenant quotas")
+	}
+	return nil
+}
+
+// byteSizer is implemented by the requests able to report the size of their data, to enforce
+// TenantQueueSettings.MaxBytes.
+type byteSizer interface {
+	byteSize() int
+}
+
+type tenantUsage struct {
+	items int64
+	bytes int64
+}
+
+// tenantQuotas tracks the data queued for each tenant.
+type tenantQuotas struct {
+	cfg      TenantQueueSettings
+	fullName string
+
+	mu      sync.Mutex
+	tenants map[string]*tenantUsage
+}
+
+func newTenantQuotas(fullName string, cfg TenantQueueSettings) *tenantQuotas {
+	if cfg.MaxTenants == 0 {
+		cfg.MaxTenants = defaultMaxTenants
+	}
+	return &tenantQuotas{
+		cfg:      cfg,
+		fullName: fullName,
+		tenants:  map[string]*tenantUsage{},
+	}
+}
+
+// tenant returns the tenant of the request, read from the client metadata of its context.
+func (tq *tenantQuotas) tenant(ctx context.Context) string {
+	values := client.FromContext(ctx).Metadata.Get(tq.cfg.MetadataKey)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// sizeBytes returns true if the size of the requests is needed to enforce the quotas.
+func (tq *tenantQuotas) sizeBytes() bool {
+	return tq.cfg.MaxBytes > 0
+}
+
+// acquire reserves the quota of the tenant for the given items and bytes. It returns the tenant
+// the data is accounted to, which is the overflow tenant if too many tenants are tracked, and false,
+// without reserving anything, if that exceeds the quota.
+func (tq *tenantQuotas) acquire(tenant string, items, bytes int) (string, bool) {
+	tq.mu.Lock()
+	defer tq.mu.Unlock()
+	usage, ok := tq.tenants[tenant]
+	if !ok {
+		if len(tq.tenants) >= tq.cfg.MaxTenants {
+			tenant = overflowTenant
+			usage, ok = tq.tenants[tenant]
+		}
+		if !ok {
+			usage = &tenantUsage{}
+			tq.tenants[tenant] = usage
+			tq.registerMetrics(tenant, usage)
+		}
+	}
+	if tq.cfg.MaxItems > 0 && usage.items+int64(items) > int64(tq.cfg.MaxItems) {
+		return tenant, false
+	}
+	if tq.cfg.MaxBytes > 0 && usage.bytes+int64(bytes) > int64(tq.cfg.MaxBytes) {
+		return tenant, false
+	}
+	usage.items += int64(items)
+	usage.bytes += int64(bytes)
+	return tenant, true
+}
+
+// release releases the quota of the tenant reserved by acquire.
+func (tq *tenantQuotas) release(tenant string, items, bytes int) {
+	tq.mu.Lock()
+	defer tq.mu.Unlock()
+	if usage, ok := tq.tenants[tenant]; ok {
+		usage.items -= int64(items)
+		usage.bytes -= int64(bytes)
+	}
+}
+
+func (tq *tenantQuotas) usage(tenant string) (items, bytes int64) {
+	tq.mu.Lock()
+	defer tq.mu.Unlock()
+	if usage, ok := tq.tenants[tenant]; ok {
+		return usage.items, usage.bytes
+	}
+	return 0, 0
+}
+
+// recordRefused records the items refused because the tenant exceeded its quota.
+func (tq *tenantQuotas) recordRefused(tenant string, items int) {
+	entry, err := globalInstruments.tenantEnqueueRefusedItems.GetEntry(metricdata.NewLabelValue(tq.fullName), metricdata.NewLabelValue(tenant))
+	if err == nil {
+		entry.Inc(int64(items))
+	}
+}
+
+func (tq *tenantQuotas) registerMetrics(tenant string, usage *tenantUsage) {
+	labels := []metricdata.LabelValue{metricdata.NewLabelValue(tq.fullName), metricdata.NewLabelValue(tenant)}
+	_ = globalInstruments.tenantQueueItems.UpsertEntry(func() int64 {
+		tq.mu.Lock()
+		defer tq.mu.Unlock()
+		return usage.items
+	}, labels...)
+	_ = globalInstruments.tenantQueueBytes.UpsertEntry(func() int64 {
+		tq.mu.Lock()
+		defer tq.mu.Unlock()
+		return usage.bytes
+	}, labels...)
+}
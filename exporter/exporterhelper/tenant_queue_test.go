@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/tag"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+func tenantContext(tenant string) context.Context {
+	return client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"x-tenant": {tenant}}),
+	})
+}
+
+func tenantTags(tenant string) []tag.Tag {
+	return append(append([]tag.Tag{}, defaultExporterTags...), tag.Tag{Key: tag.MustNewKey(tenantKey), Value: tenant})
+}
+
+func TestTenantQueueSettings_Validate(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.Tenant = TenantQueueSettings{MetadataKey: "x-tenant", MaxItems: 10, MaxBytes: 1024}
+	assert.NoError(t, qCfg.Validate())
+
+	qCfg.Tenant.MaxItems = -1
+	assert.EqualError(t, qCfg.Validate(), "tenant quotas must not be negative")
+
+	qCfg.Tenant = TenantQueueSettings{MetadataKey: "x-tenant", MaxTenants: -1}
+	assert.EqualError(t, qCfg.Validate(), "tenant quotas must not be negative")
+
+	qCfg.Tenant = TenantQueueSettings{MaxItems: 10}
+	assert.EqualError(t, qCfg.Validate(), "tenant metadata_key must be set to enforce tenant quotas")
+
+	storageID := component.NewID("storage")
+	qCfg.Tenant = TenantQueueSettings{MetadataKey: "x-tenant", MaxItems: 10}
+	qCfg.StorageID = &storageID
+	assert.EqualError(t, qCfg.Validate(), "tenant quotas are not supported by the persistent queue")
+}
+
+func TestQueuedRetry_TenantMaxItems(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 0 // to make every request stay in the queue
+	qCfg.Tenant = TenantQueueSettings{MetadataKey: "x-tenant", MaxItems: 5}
+	be, err := newBaseExporter(defaultSettings, "", false, nil, nil, newObservabilityConsumerSender, WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	require.NoError(t, be.send(newMockRequest(tenantContext("items-a"), 2, nil)))
+	require.NoError(t, be.send(newMockRequest(tenantContext("items-a"), 2, nil)))
+	err = be.send(newMockRequest(tenantContext("items-a"), 2, nil))
+	assert.ErrorIs(t, err, errTenantQuotaExceeded)
+	assert.ErrorIs(t, err, errSendingQueueIsFull)
+
+	// The other tenants are not affected.
+	require.NoError(t, be.send(newMockRequest(tenantContext("items-b"), 5, nil)))
+	assert.Equal(t, 3, be.queueSender.(*queueSender).queue.Size())
+
+	checkValueForGlobalManager(t, tenantTags("items-a"), int64(4), "exporter/tenant_queue_items")
+	checkValueForGlobalManager(t, tenantTags("items-b"), int64(5), "exporter/tenant_queue_items")
+	checkValueForGlobalManager(t, tenantTags("items-a"), int64(2), "exporter/tenant_enqueue_refused_items")
+}
+
+func TestQueuedRetry_TenantMaxBytes(t *testing.T) {
+	td := testdata.GenerateTraces(2)
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 0 // to make every request stay in the queue
+	qCfg.Tenant = TenantQueueSettings{MetadataKey: "x-tenant", MaxBytes: tracesMarshaler.TracesSize(td)}
+	be, err := newBaseExporter(defaultSettings, "", false, nil, nil, newObservabilityConsumerSender, WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	require.NoError(t, be.send(newTracesRequest(tenantContext("bytes"), td, nil)))
+	assert.ErrorIs(t, be.send(newTracesRequest(tenantContext("bytes"), td, nil)), errTenantQuotaExceeded)
+	checkValueForGlobalManager(t, tenantTags("bytes"), int64(tracesMarshaler.TracesSize(td)), "exporter/tenant_queue_bytes")
+}
+
+func TestQueuedRetry_TenantQuotaReleased(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	qCfg.Tenant = TenantQueueSettings{MetadataKey: "x-tenant", MaxItems: 2}
+	be, err := newBaseExporter(defaultSettings, "", false, nil, nil, newObservabilityConsumerSender, WithQueue(qCfg))
+	require.NoError(t, err)
+	ocs := be.obsrepSender.(*observabilityConsumerSender)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	for i := 0; i < 3; i++ {
+		ocs.run(func() {
+			require.NoError(t, be.send(newMockRequest(tenantContext("released"), 2, nil)))
+		})
+		ocs.awaitAsyncProcessing()
+	}
+	ocs.checkSendItemsCount(t, 6)
+
+	items, bytes := be.queueSender.(*queueSender).tenants.usage("released")
+	assert.Zero(t, items)
+	assert.Zero(t, bytes)
+}
+
+func TestQueuedRetry_TenantWithoutMetadata(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 0 // to make every request stay in the queue
+	qCfg.Tenant = TenantQueueSettings{MetadataKey: "x-tenant", MaxItems: 2}
+	be, err := newBaseExporter(defaultSettings, "", false, nil, nil, newObservabilityConsumerSender, WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	// The requests without the metadata share the same quota.
+	require.NoError(t, be.send(newMockRequest(context.Background(), 2, nil)))
+	assert.ErrorIs(t, be.send(newMockRequest(context.Background(), 1, nil)), errTenantQuotaExceeded)
+}
+
+func TestQueuedRetry_TenantMaxTenants(t *testing.T) {
+	td := testdata.GenerateTraces(1)
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 0 // to make every request stay in the queue
+	qCfg.Tenant = TenantQueueSettings{MetadataKey: "x-tenant", MaxItems: 2, MaxTenants: 2}
+	be, err := newBaseExporter(defaultSettings, "", false, nil, nil, newObservabilityConsumerSender, WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	require.NoError(t, be.send(newTracesRequest(tenantContext("max-tenants-a"), td, nil)))
+	require.NoError(t, be.send(newTracesRequest(tenantContext("max-tenants-b"), td, nil)))
+	// The tenants above the limit share the quota of the overflow tenant.
+	require.NoError(t, be.send(newTracesRequest(tenantContext("max-tenants-c"), td, nil)))
+	require.NoError(t, be.send(newTracesRequest(tenantContext("max-tenants-d"), td, nil)))
+	assert.ErrorIs(t, be.send(newTracesRequest(tenantContext("max-tenants-e"), td, nil)), errTenantQuotaExceeded)
+	// The tracked tenants keep their own quota.
+	require.NoError(t, be.send(newTracesRequest(tenantContext("max-tenants-a"), td, nil)))
+
+	tenants := be.queueSender.(*queueSender).tenants
+	assert.Len(t, tenants.tenants, 3)
+	items, bytes := tenants.usage(overflowTenant)
+	assert.Equal(t, int64(2), items)
+	// The size of the data is not computed without a bytes quota.
+	assert.Zero(t, bytes)
+}
@@ -5,8 +5,10 @@ package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporte
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
 )
 
@@ -33,6 +35,12 @@ func (ts *timeoutSender) send(req internal.Request) error {
 	// Intentionally don't overwrite the context inside the request, because in case of retries deadline will not be
 	// updated because this deadline most likely is before the next one.
 	ctx := req.Context()
+	// Don't send the data the client already gave up on, when its deadline is carried to the queued data.
+	if hasClientDeadline(ctx) {
+		if err := ctx.Err(); err != nil {
+			return consumererror.NewPermanent(fmt.Errorf("request is cancelled or timed out before being sent: %w", err))
+		}
+	}
 	if ts.cfg.Timeout > 0 {
 		var cancelFunc func()
 		ctx, cancelFunc = context.WithTimeout(req.Context(), ts.cfg.Timeout)
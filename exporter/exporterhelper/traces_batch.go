@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// mergeSplitTraces merges the traces of req into the ones of the optional request optReq, and splits the result into
+// requests of at most maxItems spans, 0 meaning no limit.
+func mergeSplitTraces(ctx context.Context, maxItems int, optReq *tracesRequest, req *tracesRequest) []internal.Request {
+	td := req.td
+	if optReq != nil {
+		td = optReq.td
+		req.td.ResourceSpans().MoveAndAppendTo(td.ResourceSpans())
+	}
+	var reqs []internal.Request
+	for maxItems > 0 && td.SpanCount() > maxItems {
+		reqs = append(reqs, newTracesRequest(ctx, splitTraces(maxItems, td), req.pusher))
+	}
+	return append(reqs, newTracesRequest(ctx, td, req.pusher))
+}
+
+// splitTraces removes spans from the input trace and returns a new trace of the specified size.
+func splitTraces(size int, src ptrace.Traces) ptrace.Traces {
+	if src.SpanCount() <= size {
+		return src
+	}
+	totalCopiedSpans := 0
+	dest := ptrace.NewTraces()
+
+	src.ResourceSpans().RemoveIf(func(srcRs ptrace.ResourceSpans) bool {
+		// If we are done skip everything else.
+		if totalCopiedSpans == size {
+			return false
+		}
+
+		// If it fully fits
+		srcRsSC := resourceSC(srcRs)
+		if (totalCopiedSpans + srcRsSC) <= size {
+			totalCopiedSpans += srcRsSC
+			srcRs.MoveTo(dest.ResourceSpans().AppendEmpty())
+			return true
+		}
+
+		destRs := dest.ResourceSpans().AppendEmpty()
+		srcRs.Resource().CopyTo(destRs.Resource())
+		srcRs.ScopeSpans().RemoveIf(func(srcIls ptrace.ScopeSpans) bool {
+			// If we are done skip everything else.
+			if totalCopiedSpans == size {
+				return false
+			}
+
+			// If possible to move all metrics do that.
+			srcIlsSC := srcIls.Spans().Len()
+			if size-totalCopiedSpans >= srcIlsSC {
+				totalCopiedSpans += srcIlsSC
+				srcIls.MoveTo(destRs.ScopeSpans().AppendEmpty())
+				return true
+			}
+
+			destIls := destRs.ScopeSpans().AppendEmpty()
+			srcIls.Scope().CopyTo(destIls.Scope())
+			srcIls.Spans().RemoveIf(func(srcSpan ptrace.Span) bool {
+				// If we are done skip everything else.
+				if totalCopiedSpans == size {
+					return false
+				}
+				srcSpan.MoveTo(destIls.Spans().AppendEmpty())
+				totalCopiedSpans++
+				return true
+			})
+			return false
+		})
+		return srcRs.ScopeSpans().Len() == 0
+	})
+
+	return dest
+}
+
+// resourceSC calculates the total number of spans in the ptrace.ResourceSpans.
+func resourceSC(rs ptrace.ResourceSpans) (count int) {
+	for k := 0; k < rs.ScopeSpans().Len(); k++ {
+		count += rs.ScopeSpans().At(k).Spans().Len()
+	}
+	return
+}
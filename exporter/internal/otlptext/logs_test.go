@@ -61,6 +61,20 @@ func TestLogsText(t *testing.T) {
 			}(),
 			out: "embedded_maps.out",
 		},
+		{
+			name: "logs_with_event_name",
+			in: func() plog.Logs {
+				ls := plog.NewLogs()
+				l := ls.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+				l.SetTimestamp(pcommon.NewTimestampFromTime(time.Date(2020, 2, 11, 20, 26, 13, 789, time.UTC)))
+				l.SetSeverityNumber(plog.SeverityNumberInfo)
+				l.SetSeverityText("INFO")
+				l.SetEventName("user.login")
+				l.Attributes().PutStr("user.id", "42")
+				return ls
+			}(),
+			out: "event_name.out",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
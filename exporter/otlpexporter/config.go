@@ -4,6 +4,7 @@
 package otlpexporter // import "go.opentelemetry.io/collector/exporter/otlpexporter"
 
 import (
+	"errors"
 	"fmt"
 
 	"go.opentelemetry.io/collector/component"
@@ -18,6 +19,14 @@ type Config struct {
 	exporterhelper.RetrySettings   `mapstructure:"retry_on_failure"`
 
 	configgrpc.GRPCClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// Hedging configures the hedging of the export requests, see HedgingSettings.
+	Hedging HedgingSettings `mapstructure:"hedging"`
+
+	// ConnectionPoolSize is the number of connections the export requests are spread over, in a round-robin
+	// fashion, so that a single HTTP/2 connection does not bottleneck high-throughput pipelines. A single
+	// connection is used when not set.
+	ConnectionPoolSize int `mapstructure:"connection_pool_size"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -27,6 +36,12 @@ func (cfg *Config) Validate() error {
 	if err := cfg.QueueSettings.Validate(); err != nil {
 		return fmt.Errorf("queue settings has invalid configuration: %w", err)
 	}
+	if err := cfg.Hedging.Validate(); err != nil {
+		return fmt.Errorf("hedging settings has invalid configuration: %w", err)
+	}
+	if cfg.ConnectionPoolSize < 0 {
+		return errors.New("connection_pool_size must not be negative")
+	}
 
 	return nil
 }
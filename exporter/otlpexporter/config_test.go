@@ -46,6 +46,7 @@ func TestUnmarshalConfig(t *testing.T) {
 				Multiplier:          1.3,
 				MaxInterval:         1 * time.Minute,
 				MaxElapsedTime:      10 * time.Minute,
+				CircuitBreaker:      exporterhelper.NewDefaultCircuitBreakerSettings(),
 			},
 			QueueSettings: exporterhelper.QueueSettings{
 				Enabled:      true,
@@ -58,8 +59,9 @@ func TestUnmarshalConfig(t *testing.T) {
 					"header1":                "234",
 					"another":                "somevalue",
 				},
-				Endpoint:    "1.2.3.4:1234",
-				Compression: "gzip",
+				HeadersFromContext: []string{"x-tenant-id"},
+				Endpoint:           "1.2.3.4:1234",
+				Compression:        "gzip",
 				TLSSetting: configtls.TLSClientSetting{
 					TLSSetting: configtls.TLSSetting{
 						CAFile: "/var/lib/mycert.pem",
@@ -75,5 +77,17 @@ func TestUnmarshalConfig(t *testing.T) {
 				BalancerName:    "round_robin",
 				Auth:            &configauth.Authentication{AuthenticatorID: component.NewID("nop")},
 			},
+			Hedging: HedgingSettings{
+				Enabled: true,
+				Delay:   50 * time.Millisecond,
+			},
+			ConnectionPoolSize: 4,
 		}, cfg)
 }
+
+func TestValidateConnectionPoolSize(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+	cfg.ConnectionPoolSize = -1
+	assert.EqualError(t, cfg.Validate(), "connection_pool_size must not be negative")
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpexporter // import "go.opentelemetry.io/collector/exporter/otlpexporter"
+
+import (
+	"sync/atomic"
+
+	"go.uber.org/multierr"
+	"google.golang.org/grpc"
+
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+// connPool spreads the export requests over several client connections in a round-robin fashion, each
+// connection being a separate HTTP/2 connection to the backend.
+type connPool struct {
+	conns   []*grpc.ClientConn
+	clients []otlpClients
+	next    atomic.Uint64
+}
+
+// otlpClients are the OTLP clients of a connection.
+type otlpClients struct {
+	traces  ptraceotlp.GRPCClient
+	metrics pmetricotlp.GRPCClient
+	logs    plogotlp.GRPCClient
+}
+
+// newConnPool dials size connections with dial, closing the dialed connections when one fails.
+func newConnPool(size int, dial func() (*grpc.ClientConn, error)) (*connPool, error) {
+	if size < 1 {
+		size = 1
+	}
+	p := &connPool{
+		conns:   make([]*grpc.ClientConn, 0, size),
+		clients: make([]otlpClients, 0, size),
+	}
+	for i := 0; i < size; i++ {
+		conn, err := dial()
+		if err != nil {
+			return nil, multierr.Append(err, p.Close())
+		}
+		p.conns = append(p.conns, conn)
+		p.clients = append(p.clients, otlpClients{
+			traces:  ptraceotlp.NewGRPCClient(conn),
+			metrics: pmetricotlp.NewGRPCClient(conn),
+			logs:    plogotlp.NewGRPCClient(conn),
+		})
+	}
+	return p, nil
+}
+
+// get returns the clients of the next connection.
+func (p *connPool) get() otlpClients {
+	if len(p.clients) == 1 {
+		return p.clients[0]
+	}
+	return p.clients[(p.next.Add(1)-1)%uint64(len(p.clients))]
+}
+
+// Close closes all the connections of the pool.
+func (p *connPool) Close() error {
+	var errs error
+	for _, conn := range p.conns {
+		errs = multierr.Append(errs, conn.Close())
+	}
+	return errs
+}
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpexporter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func dialTestConn() (*grpc.ClientConn, error) {
+	return grpc.Dial("localhost:4317", grpc.WithTransportCredentials(insecure.NewCredentials()))
+}
+
+func TestConnPoolRoundRobin(t *testing.T) {
+	p, err := newConnPool(3, dialTestConn)
+	require.NoError(t, err)
+	require.Len(t, p.conns, 3)
+
+	for i := 0; i < 6; i++ {
+		assert.Equal(t, p.clients[i%3], p.get())
+	}
+	assert.NoError(t, p.Close())
+	for _, conn := range p.conns {
+		assert.Equal(t, connectivity.Shutdown, conn.GetState())
+	}
+}
+
+func TestConnPoolDefaultSize(t *testing.T) {
+	p, err := newConnPool(0, dialTestConn)
+	require.NoError(t, err)
+	assert.Len(t, p.conns, 1)
+	assert.Equal(t, p.clients[0], p.get())
+	assert.NoError(t, p.Close())
+}
+
+func TestConnPoolDialError(t *testing.T) {
+	var dialed []*grpc.ClientConn
+	errDial := errors.New("dial failed")
+	_, err := newConnPool(3, func() (*grpc.ClientConn, error) {
+		if len(dialed) == 2 {
+			return nil, errDial
+		}
+		conn, err := dialTestConn()
+		dialed = append(dialed, conn)
+		return conn, err
+	})
+	assert.ErrorIs(t, err, errDial)
+	for _, conn := range dialed {
+		assert.Equal(t, connectivity.Shutdown, conn.GetState())
+	}
+}
@@ -43,6 +43,7 @@ func createDefaultConfig() component.Config {
 			// We almost read 0 bytes, so no need to tune ReadBufferSize.
 			WriteBufferSize: 512 * 1024,
 		},
+		Hedging: NewDefaultHedgingSettings(),
 	}
 }
 
@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpexporter // import "go.opentelemetry.io/collector/exporter/otlpexporter"
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/otlpexporter/internal/metadata"
+)
+
+// Winners of the hedged requests, reported as the "winner" attribute of the hedged_requests metric.
+const (
+	// hedgeWinnerPrimary is used when the first request completed first.
+	hedgeWinnerPrimary = metadata.AttributeWinnerPrimary
+	// hedgeWinnerHedge is used when the hedged request completed first.
+	hedgeWinnerHedge = metadata.AttributeWinnerHedge
+	// hedgeWinnerNone is used when both requests failed.
+	hedgeWinnerNone = metadata.AttributeWinnerNone
+)
+
+// HedgingSettings configures the hedging of the export requests: when a request did not complete
+// within Delay, a second request sending the same data is issued, and the first one to complete is used.
+type HedgingSettings struct {
+	// Enabled indicates whether the export requests are hedged.
+	Enabled bool `mapstructure:"enabled"`
+	// Delay is the time to wait for a request to complete before issuing the hedged request.
+	Delay time.Duration `mapstructure:"delay"`
+}
+
+// NewDefaultHedgingSettings returns the default settings for HedgingSettings.
+func NewDefaultHedgingSettings() HedgingSettings {
+	return HedgingSettings{
+		Enabled: false,
+		Delay:   100 * time.Millisecond,
+	}
+}
+
+// Validate checks if the HedgingSettings configuration is valid.
+func (hs *HedgingSettings) Validate() error {
+	if hs.Enabled && hs.Delay <= 0 {
+		return errors.New("hedging delay must be positive")
+	}
+	return nil
+}
+
+// hedger sends the export requests, issuing a hedged request when the first one is slow to complete.
+// Both requests go through the same gRPC connection, the round robin balancer sending them to different
+// backend addresses when the endpoint resolves to several addresses.
+type hedger struct {
+	delay            time.Duration
+	telemetryBuilder *metadata.TelemetryBuilder
+	exporterID       string
+}
+
+func newHedger(hs HedgingSettings, id component.ID, mp metric.MeterProvider) (*hedger, error) {
+	if !hs.Enabled {
+		return nil, nil
+	}
+	if mp == nil {
+		mp = noop.NewMeterProvider()
+	}
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(mp)
+	// ignore instrument name error as per workaround in https://github.com/open-telemetry/opentelemetry-collector/issues/8346
+	if err != nil && !errors.Is(err, sdkmetric.ErrInstrumentName) {
+		return nil, err
+	}
+	return &hedger{
+		delay:            hs.Delay,
+		telemetryBuilder: telemetryBuilder,
+		exporterID:       id.String(),
+	}, nil
+}
+
+type hedgeResult struct {
+	hedged bool
+	err    error
+}
+
+// send calls export, and calls it a second time if the first call did not complete within the delay.
+// The result of the first call to complete is returned and the other call is canceled. A call failing
+// with a retryable error did not deliver the data, so the other call is waited for in that case. A call
+// failing with a permanent error completes the request: as defined by OTLP, the backend rejected the
+// data, which the other backends would reject as well.
+func (h *hedger) send(ctx context.Context, export func(context.Context) error) error {
+	if h == nil {
+		return export(ctx)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan hedgeResult, 2)
+	start := func(hedged bool) {
+		go func() {
+			results <- hedgeResult{hedged: hedged, err: export(ctx)}
+		}()
+	}
+	start(false)
+	timer := time.NewTimer(h.delay)
+	defer timer.Stop()
+
+	hedged := false
+	pending := 1
+	var err error
+	for pending > 0 {
+		select {
+		case <-timer.C:
+			hedged = true
+			pending++
+			start(true)
+		case res := <-results:
+			pending--
+			if !hedged {
+				return res.err
+			}
+			if res.err == nil || consumererror.IsPermanent(res.err) {
+				winner := hedgeWinnerPrimary
+				if res.hedged {
+					winner = hedgeWinnerHedge
+				}
+				h.record(ctx, winner)
+				return res.err
+			}
+			err = res.err
+		}
+	}
+	h.record(ctx, hedgeWinnerNone)
+	return err
+}
+
+func (h *hedger) record(ctx context.Context, winner string) {
+	h.telemetryBuilder.ExporterOtlpHedgedRequests.Add(ctx, 1, metadata.WinnerExporterAttributeSet(winner, h.exporterID))
+}
@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpexporter
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+func TestHedgingSettings_Validate(t *testing.T) {
+	hs := NewDefaultHedgingSettings()
+	assert.NoError(t, hs.Validate())
+	hs.Enabled = true
+	assert.NoError(t, hs.Validate())
+	hs.Delay = 0
+	assert.EqualError(t, hs.Validate(), "hedging delay must be positive")
+}
+
+func TestHedger_Disabled(t *testing.T) {
+	h, err := newHedger(NewDefaultHedgingSettings(), component.NewID(typeStr), nil)
+	require.NoError(t, err)
+	assert.Nil(t, h)
+
+	var calls atomic.Int32
+	assert.NoError(t, h.send(context.Background(), func(context.Context) error {
+		calls.Add(1)
+		return nil
+	}))
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+// slowExport returns an export function failing with err after the delay of the given call,
+// or when the call is canceled.
+func slowExport(calls *atomic.Int32, errs []error, delays []time.Duration) func(context.Context) error {
+	return func(ctx context.Context) error {
+		call := calls.Add(1) - 1
+		select {
+		case <-time.After(delays[call]):
+			return errs[call]
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func TestHedger_Send(t *testing.T) {
+	errRetryable := errors.New("unavailable")
+	errPermanent := consumererror.NewPermanent(errors.New("invalid"))
+	tests := []struct {
+		name          string
+		errs          []error
+		delays        []time.Duration
+		expectedErr   error
+		expectedCalls int32
+		winner        string
+	}{
+		{
+			name:          "fast_primary",
+			errs:          []error{nil},
+			delays:        []time.Duration{0},
+			expectedCalls: 1,
+		},
+		{
+			name:          "fast_primary_failure",
+			errs:          []error{errRetryable},
+			delays:        []time.Duration{0},
+			expectedErr:   errRetryable,
+			expectedCalls: 1,
+		},
+		{
+			name:          "hedge_wins",
+			errs:          []error{nil, nil},
+			delays:        []time.Duration{time.Minute, 0},
+			expectedCalls: 2,
+			winner:        hedgeWinnerHedge,
+		},
+		{
+			name:          "primary_wins_after_hedge_failure",
+			errs:          []error{nil, errRetryable},
+			delays:        []time.Duration{200 * time.Millisecond, 0},
+			expectedCalls: 2,
+			winner:        hedgeWinnerPrimary,
+		},
+		{
+			name:          "permanent_error",
+			errs:          []error{nil, errPermanent},
+			delays:        []time.Duration{time.Minute, 0},
+			expectedErr:   errPermanent,
+			expectedCalls: 2,
+			winner:        hedgeWinnerHedge,
+		},
+		{
+			name:          "both_fail",
+			errs:          []error{errRetryable, errRetryable},
+			delays:        []time.Duration{100 * time.Millisecond, 0},
+			expectedErr:   errRetryable,
+			expectedCalls: 2,
+			winner:        hedgeWinnerNone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := sdkmetric.NewManualReader()
+			h, err := newHedger(HedgingSettings{Enabled: true, Delay: 10 * time.Millisecond}, component.NewID(typeStr),
+				sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+			require.NoError(t, err)
+
+			var calls atomic.Int32
+			start := time.Now()
+			err = h.send(context.Background(), slowExport(&calls, tt.errs, tt.delays))
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Less(t, time.Since(start), 10*time.Second)
+			assert.Equal(t, tt.expectedCalls, calls.Load())
+
+			var rm metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+			if tt.winner == "" {
+				assert.Empty(t, rm.ScopeMetrics)
+				return
+			}
+			assert.Equal(t, int64(1), hedgedRequests(rm, tt.winner))
+		})
+	}
+}
+
+func hedgedRequests(rm metricdata.ResourceMetrics, winner string) int64 {
+	expected := attribute.NewSet(attribute.String("winner", winner), attribute.String("exporter", typeStr))
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if sum, ok := m.Data.(metricdata.Sum[int64]); ok && m.Name == "exporter/otlp/hedged_requests" {
+				for _, dp := range sum.DataPoints {
+					if dp.Attributes.Equals(&expected) {
+						return dp.Value
+					}
+				}
+			}
+		}
+	}
+	return 0
+}
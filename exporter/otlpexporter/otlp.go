@@ -12,6 +12,7 @@ import (
 
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/balancer/roundrobin"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
@@ -20,6 +21,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/internal/otlpcompat"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -32,15 +34,13 @@ type baseExporter struct {
 	// Input configuration.
 	config *Config
 
-	// gRPC clients and connection.
-	traceExporter  ptraceotlp.GRPCClient
-	metricExporter pmetricotlp.GRPCClient
-	logExporter    plogotlp.GRPCClient
-	clientConn     *grpc.ClientConn
-	metadata       metadata.MD
-	callOptions    []grpc.CallOption
+	// gRPC clients and connections.
+	clientConns *connPool
+	metadata    metadata.MD
+	callOptions []grpc.CallOption
 
 	settings component.TelemetrySettings
+	hedger   *hedger
 
 	// Default user-agent header.
 	userAgent string
@@ -58,18 +58,27 @@ func newExporter(cfg component.Config, set exporter.CreateSettings) (*baseExport
 	userAgent := fmt.Sprintf("%s/%s (%s/%s)",
 		set.BuildInfo.Description, set.BuildInfo.Version, runtime.GOOS, runtime.GOARCH)
 
-	return &baseExporter{config: oCfg, settings: set.TelemetrySettings, userAgent: userAgent}, nil
+	h, err := newHedger(oCfg.Hedging, set.ID, set.MeterProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	return &baseExporter{config: oCfg, settings: set.TelemetrySettings, hedger: h, userAgent: userAgent}, nil
 }
 
 // start actually creates the gRPC connection. The client construction is deferred till this point as this
 // is the only place we get hold of Extensions which are required to construct auth round tripper.
 func (e *baseExporter) start(ctx context.Context, host component.Host) (err error) {
-	if e.clientConn, err = e.config.GRPCClientSettings.ToClientConn(ctx, host, e.settings, grpc.WithUserAgent(e.userAgent)); err != nil {
+	clientSettings := e.config.GRPCClientSettings
+	if e.hedger != nil && clientSettings.BalancerName == "" {
+		// Send the hedged requests to another backend address than the first requests.
+		clientSettings.BalancerName = roundrobin.Name
+	}
+	if e.clientConns, err = newConnPool(e.config.ConnectionPoolSize, func() (*grpc.ClientConn, error) {
+		return clientSettings.ToClientConn(ctx, host, e.settings, grpc.WithUserAgent(e.userAgent))
+	}); err != nil {
 		return err
 	}
-	e.traceExporter = ptraceotlp.NewGRPCClient(e.clientConn)
-	e.metricExporter = pmetricotlp.NewGRPCClient(e.clientConn)
-	e.logExporter = plogotlp.NewGRPCClient(e.clientConn)
 	headers := map[string]string{}
 	for k, v := range e.config.GRPCClientSettings.Headers {
 		headers[k] = string(v)
@@ -83,49 +92,55 @@ func (e *baseExporter) start(ctx context.Context, host component.Host) (err erro
 }
 
 func (e *baseExporter) shutdown(context.Context) error {
-	if e.clientConn != nil {
-		return e.clientConn.Close()
+	if e.clientConns != nil {
+		return e.clientConns.Close()
 	}
 	return nil
 }
 
 func (e *baseExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
 	req := ptraceotlp.NewExportRequestFromTraces(td)
-	resp, respErr := e.traceExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
-	if err := processError(respErr); err != nil {
-		return err
-	}
-	partialSuccess := resp.PartialSuccess()
-	if !(partialSuccess.ErrorMessage() == "" && partialSuccess.RejectedSpans() == 0) {
-		return consumererror.NewPermanent(fmt.Errorf("OTLP partial success: \"%s\" (%d rejected)", resp.PartialSuccess().ErrorMessage(), resp.PartialSuccess().RejectedSpans()))
-	}
-	return nil
+	return e.hedger.send(ctx, func(ctx context.Context) error {
+		resp, respErr := e.clientConns.get().traces.Export(e.enhanceContext(ctx), req, e.callOptions...)
+		if err := processError(respErr); err != nil {
+			return err
+		}
+		partialSuccess := resp.PartialSuccess()
+		if !(partialSuccess.ErrorMessage() == "" && partialSuccess.RejectedSpans() == 0) {
+			return consumererror.NewPartialSuccess(consumererror.NewPermanent(fmt.Errorf("OTLP partial success: \"%s\" (%d rejected)", resp.PartialSuccess().ErrorMessage(), resp.PartialSuccess().RejectedSpans())), int(resp.PartialSuccess().RejectedSpans()))
+		}
+		return nil
+	})
 }
 
 func (e *baseExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) error {
 	req := pmetricotlp.NewExportRequestFromMetrics(md)
-	resp, respErr := e.metricExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
-	if err := processError(respErr); err != nil {
-		return err
-	}
-	partialSuccess := resp.PartialSuccess()
-	if !(partialSuccess.ErrorMessage() == "" && partialSuccess.RejectedDataPoints() == 0) {
-		return consumererror.NewPermanent(fmt.Errorf("OTLP partial success: \"%s\" (%d rejected)", resp.PartialSuccess().ErrorMessage(), resp.PartialSuccess().RejectedDataPoints()))
-	}
-	return nil
+	return e.hedger.send(ctx, func(ctx context.Context) error {
+		resp, respErr := e.clientConns.get().metrics.Export(e.enhanceContext(ctx), req, e.callOptions...)
+		if err := processError(respErr); err != nil {
+			return err
+		}
+		partialSuccess := resp.PartialSuccess()
+		if !(partialSuccess.ErrorMessage() == "" && partialSuccess.RejectedDataPoints() == 0) {
+			return consumererror.NewPartialSuccess(consumererror.NewPermanent(fmt.Errorf("OTLP partial success: \"%s\" (%d rejected)", resp.PartialSuccess().ErrorMessage(), resp.PartialSuccess().RejectedDataPoints())), int(resp.PartialSuccess().RejectedDataPoints()))
+		}
+		return nil
+	})
 }
 
 func (e *baseExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
-	req := plogotlp.NewExportRequestFromLogs(ld)
-	resp, respErr := e.logExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
-	if err := processError(respErr); err != nil {
-		return err
-	}
-	partialSuccess := resp.PartialSuccess()
-	if !(partialSuccess.ErrorMessage() == "" && partialSuccess.RejectedLogRecords() == 0) {
-		return consumererror.NewPermanent(fmt.Errorf("OTLP partial success: \"%s\" (%d rejected)", resp.PartialSuccess().ErrorMessage(), resp.PartialSuccess().RejectedLogRecords()))
-	}
-	return nil
+	req := plogotlp.NewExportRequestFromLogs(otlpcompat.Logs(ld))
+	return e.hedger.send(ctx, func(ctx context.Context) error {
+		resp, respErr := e.clientConns.get().logs.Export(e.enhanceContext(ctx), req, e.callOptions...)
+		if err := processError(respErr); err != nil {
+			return err
+		}
+		partialSuccess := resp.PartialSuccess()
+		if !(partialSuccess.ErrorMessage() == "" && partialSuccess.RejectedLogRecords() == 0) {
+			return consumererror.NewPartialSuccess(consumererror.NewPermanent(fmt.Errorf("OTLP partial success: \"%s\" (%d rejected)", resp.PartialSuccess().ErrorMessage(), resp.PartialSuccess().RejectedLogRecords())), int(resp.PartialSuccess().RejectedLogRecords()))
+		}
+		return nil
+	})
 }
 
 func (e *baseExporter) enhanceContext(ctx context.Context) context.Context {
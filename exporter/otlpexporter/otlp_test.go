@@ -27,8 +27,11 @@ import (
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/otlpcompat"
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
@@ -311,6 +314,61 @@ func TestSendTraces(t *testing.T) {
 
 	err = exp.ConsumeTraces(context.Background(), td)
 	assert.Error(t, err)
+	partial, ok := consumererror.AsPartialSuccess(err)
+	require.True(t, ok)
+	assert.Equal(t, 1, partial.Rejected())
+	assert.False(t, partial.Retryable())
+}
+
+// countingListener counts the accepted connections.
+type countingListener struct {
+	net.Listener
+	accepted atomic.Int32
+}
+
+func (l *countingListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err == nil {
+		l.accepted.Add(1)
+	}
+	return conn, err
+}
+
+func TestSendTracesUnixSocketConnectionPool(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix domain sockets are not supported on Windows by all the versions")
+	}
+	// Start an OTLP-compatible receiver on a Unix domain socket.
+	socket := filepath.Join(t.TempDir(), "otelcol.sock")
+	unixLn, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	ln := &countingListener{Listener: unixLn}
+	rcv, _ := otlpTracesReceiverOnGRPCServer(ln, false)
+	defer rcv.srv.GracefulStop()
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.QueueSettings.Enabled = false
+	cfg.GRPCClientSettings = configgrpc.GRPCClientSettings{
+		Endpoint: "unix://" + socket,
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+	}
+	cfg.ConnectionPoolSize = 3
+	exp, err := factory.CreateTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, exp.Shutdown(context.Background()))
+	}()
+
+	// The requests are sent in turn on each connection of the pool.
+	for i := 0; i < 3; i++ {
+		require.NoError(t, exp.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	}
+	assert.EqualValues(t, 3, rcv.requestCount.Load())
+	assert.EqualValues(t, 3, ln.accepted.Load())
 }
 
 func TestSendTracesWhenEndpointHasHttpScheme(t *testing.T) {
@@ -772,3 +830,63 @@ func TestSendLogData(t *testing.T) {
 	err = exp.ConsumeLogs(context.Background(), ld)
 	assert.Error(t, err)
 }
+
+func TestSendLogEventName(t *testing.T) {
+	tests := []struct {
+		name            string
+		sendEventName   bool
+		expectEventName string
+		expectAttribute bool
+	}{
+		{
+			name:            "event.name attribute",
+			expectAttribute: true,
+		},
+		{
+			name:            "event_name field",
+			sendEventName:   true,
+			expectEventName: "user.login",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, featuregate.GlobalRegistry().Set(otlpcompat.SendLogEventNameFeatureGate.ID(), tt.sendEventName))
+			defer func() {
+				require.NoError(t, featuregate.GlobalRegistry().Set(otlpcompat.SendLogEventNameFeatureGate.ID(), false))
+			}()
+
+			ln, err := net.Listen("tcp", "localhost:")
+			require.NoError(t, err)
+			rcv := otlpLogsReceiverOnGRPCServer(ln)
+			defer rcv.srv.GracefulStop()
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig().(*Config)
+			cfg.QueueSettings.Enabled = false
+			cfg.GRPCClientSettings = configgrpc.GRPCClientSettings{
+				Endpoint: ln.Addr().String(),
+				TLSSetting: configtls.TLSClientSetting{
+					Insecure: true,
+				},
+			}
+			exp, err := factory.CreateLogsExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+			require.NoError(t, err)
+			require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() {
+				assert.NoError(t, exp.Shutdown(context.Background()))
+			}()
+
+			ld := testdata.GenerateLogs(1)
+			ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).SetEventName("user.login")
+			require.NoError(t, exp.ConsumeLogs(context.Background(), ld))
+			require.Eventually(t, func() bool {
+				return rcv.requestCount.Load() > 0
+			}, 10*time.Second, 5*time.Millisecond)
+
+			lr := rcv.getLastRequest().ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+			assert.Equal(t, tt.expectEventName, lr.EventName())
+			_, ok := lr.Attributes().Get(otlpcompat.EventNameAttribute)
+			assert.Equal(t, tt.expectAttribute, ok)
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/extension/encoding"
 )
 
 // Config defines configuration for OTLP/HTTP exporter.
@@ -25,6 +26,10 @@ type Config struct {
 
 	// The URL to send logs to. If omitted the Endpoint + "/v1/logs" will be used.
 	LogsEndpoint string `mapstructure:"logs_endpoint"`
+
+	// Encoding is the name of the encoding of the exported data, either "otlp_proto", "otlp_json" or
+	// an encoding provided by an encoding extension. If omitted "otlp_proto" will be used.
+	Encoding string `mapstructure:"encoding"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -36,3 +41,11 @@ func (cfg *Config) Validate() error {
 	}
 	return nil
 }
+
+// encoding returns the name of the encoding of the exported data, "otlp_proto" if not set.
+func (cfg *Config) encoding() string {
+	if cfg.Encoding == "" {
+		return encoding.OTLPProto
+	}
+	return cfg.Encoding
+}
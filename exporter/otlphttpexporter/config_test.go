@@ -44,6 +44,7 @@ func TestUnmarshalConfig(t *testing.T) {
 				Multiplier:          1.3,
 				MaxInterval:         1 * time.Minute,
 				MaxElapsedTime:      10 * time.Minute,
+				CircuitBreaker:      exporterhelper.NewDefaultCircuitBreakerSettings(),
 			},
 			QueueSettings: exporterhelper.QueueSettings{
 				Enabled:      true,
@@ -56,7 +57,8 @@ func TestUnmarshalConfig(t *testing.T) {
 					"header1":                "234",
 					"another":                "somevalue",
 				},
-				Endpoint: "https://1.2.3.4:1234",
+				HeadersFromContext: []string{"x-tenant-id"},
+				Endpoint:           "https://1.2.3.4:1234",
 				TLSSetting: configtls.TLSClientSetting{
 					TLSSetting: configtls.TLSSetting{
 						CAFile:   "/var/lib/mycert.pem",
@@ -70,5 +72,6 @@ func TestUnmarshalConfig(t *testing.T) {
 				Timeout:         time.Second * 10,
 				Compression:     "gzip",
 			},
+			Encoding: "otlp_json",
 		}, cfg)
 }
@@ -17,6 +17,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/extension/encoding"
 )
 
 const (
@@ -48,6 +49,7 @@ func createDefaultConfig() component.Config {
 			// We almost read 0 bytes, so no need to tune ReadBufferSize.
 			WriteBufferSize: 512 * 1024,
 		},
+		Encoding: encoding.OTLPProto,
 	}
 }
 
@@ -87,7 +89,7 @@ func createTracesExporter(
 
 	return exporterhelper.NewTracesExporter(ctx, set, cfg,
 		oce.pushTraces,
-		exporterhelper.WithStart(oce.start),
+		exporterhelper.WithStart(oce.startTraces),
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		// explicitly disable since we rely on http.Client timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
@@ -113,7 +115,7 @@ func createMetricsExporter(
 
 	return exporterhelper.NewMetricsExporter(ctx, set, cfg,
 		oce.pushMetrics,
-		exporterhelper.WithStart(oce.start),
+		exporterhelper.WithStart(oce.startMetrics),
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		// explicitly disable since we rely on http.Client timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
@@ -139,7 +141,7 @@ func createLogsExporter(
 
 	return exporterhelper.NewLogsExporter(ctx, set, cfg,
 		oce.pushLogs,
-		exporterhelper.WithStart(oce.start),
+		exporterhelper.WithStart(oce.startLogs),
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		// explicitly disable since we rely on http.Client timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
@@ -23,6 +23,8 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/extension/encoding"
+	"go.opentelemetry.io/collector/internal/otlpcompat"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -38,8 +40,14 @@ type baseExporter struct {
 	tracesURL  string
 	metricsURL string
 	logsURL    string
-	logger     *zap.Logger
-	settings   component.TelemetrySettings
+	// encodings holds the encodings of the host, the marshaler of the signal is resolved from once started.
+	encodings        *encoding.Registry
+	contentType      string
+	tracesMarshaler  ptrace.Marshaler
+	metricsMarshaler pmetric.Marshaler
+	logsMarshaler    plog.Marshaler
+	logger           *zap.Logger
+	settings         component.TelemetrySettings
 	// Default user-agent header.
 	userAgent string
 }
@@ -49,6 +57,10 @@ const (
 	maxHTTPResponseReadBytes = 64 * 1024
 
 	protobufContentType = "application/x-protobuf"
+	jsonContentType     = "application/json"
+	// octetStreamContentType is the content type of the encodings other than the OTLP ones, it can be
+	// overridden with the Content-Type header in the headers setting.
+	octetStreamContentType = "application/octet-stream"
 )
 
 // Create new exporter.
@@ -67,13 +79,25 @@ func newExporter(cfg component.Config, set exporter.CreateSettings) (*baseExport
 
 	// client construction is deferred to start
 	return &baseExporter{
-		config:    oCfg,
-		logger:    set.Logger,
-		userAgent: userAgent,
-		settings:  set.TelemetrySettings,
+		config:      oCfg,
+		contentType: encodingContentType(oCfg.encoding()),
+		logger:      set.Logger,
+		userAgent:   userAgent,
+		settings:    set.TelemetrySettings,
 	}, nil
 }
 
+// encodingContentType returns the content type of the requests encoded with the given encoding.
+func encodingContentType(name string) string {
+	switch name {
+	case encoding.OTLPProto:
+		return protobufContentType
+	case encoding.OTLPJSON:
+		return jsonContentType
+	}
+	return octetStreamContentType
+}
+
 // start actually creates the HTTP client. The client construction is deferred till this point as this
 // is the only place we get hold of Extensions which are required to construct auth round tripper.
 func (e *baseExporter) start(_ context.Context, host component.Host) error {
@@ -82,12 +106,39 @@ func (e *baseExporter) start(_ context.Context, host component.Host) error {
 		return err
 	}
 	e.client = client
-	return nil
+	e.encodings, err = encoding.NewRegistryFromExtensions(host.GetExtensions())
+	return err
+}
+
+func (e *baseExporter) startTraces(ctx context.Context, host component.Host) error {
+	err := e.start(ctx, host)
+	if err != nil {
+		return err
+	}
+	e.tracesMarshaler, err = e.encodings.TracesMarshaler(e.config.encoding())
+	return err
+}
+
+func (e *baseExporter) startMetrics(ctx context.Context, host component.Host) error {
+	err := e.start(ctx, host)
+	if err != nil {
+		return err
+	}
+	e.metricsMarshaler, err = e.encodings.MetricsMarshaler(e.config.encoding())
+	return err
+}
+
+func (e *baseExporter) startLogs(ctx context.Context, host component.Host) error {
+	err := e.start(ctx, host)
+	if err != nil {
+		return err
+	}
+	e.logsMarshaler, err = e.encodings.LogsMarshaler(e.config.encoding())
+	return err
 }
 
 func (e *baseExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
-	tr := ptraceotlp.NewExportRequestFromTraces(td)
-	request, err := tr.MarshalProto()
+	request, err := e.tracesMarshaler.MarshalTraces(td)
 	if err != nil {
 		return consumererror.NewPermanent(err)
 	}
@@ -96,8 +147,7 @@ func (e *baseExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
 }
 
 func (e *baseExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) error {
-	tr := pmetricotlp.NewExportRequestFromMetrics(md)
-	request, err := tr.MarshalProto()
+	request, err := e.metricsMarshaler.MarshalMetrics(md)
 	if err != nil {
 		return consumererror.NewPermanent(err)
 	}
@@ -105,8 +155,7 @@ func (e *baseExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) erro
 }
 
 func (e *baseExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
-	tr := plogotlp.NewExportRequestFromLogs(ld)
-	request, err := tr.MarshalProto()
+	request, err := e.logsMarshaler.MarshalLogs(otlpcompat.Logs(ld))
 	if err != nil {
 		return consumererror.NewPermanent(err)
 	}
@@ -120,7 +169,7 @@ func (e *baseExporter) export(ctx context.Context, url string, request []byte, p
 	if err != nil {
 		return consumererror.NewPermanent(err)
 	}
-	req.Header.Set("Content-Type", protobufContentType)
+	req.Header.Set("Content-Type", e.contentType)
 	req.Header.Set("User-Agent", e.userAgent)
 
 	resp, err := e.client.Do(req)
@@ -270,7 +319,7 @@ func tracesPartialSuccessHandler(protoBytes []byte, contentType string) error {
 	}
 	partialSuccess := exportResponse.PartialSuccess()
 	if !(partialSuccess.ErrorMessage() == "" && partialSuccess.RejectedSpans() == 0) {
-		return consumererror.NewPermanent(fmt.Errorf("OTLP partial success: %s (%d rejected)", partialSuccess.ErrorMessage(), partialSuccess.RejectedSpans()))
+		return consumererror.NewPartialSuccess(consumererror.NewPermanent(fmt.Errorf("OTLP partial success: %s (%d rejected)", partialSuccess.ErrorMessage(), partialSuccess.RejectedSpans())), int(partialSuccess.RejectedSpans()))
 	}
 	return nil
 }
@@ -286,7 +335,7 @@ func metricsPartialSuccessHandler(protoBytes []byte, contentType string) error {
 	}
 	partialSuccess := exportResponse.PartialSuccess()
 	if !(partialSuccess.ErrorMessage() == "" && partialSuccess.RejectedDataPoints() == 0) {
-		return consumererror.NewPermanent(fmt.Errorf("OTLP partial success: %s (%d rejected)", partialSuccess.ErrorMessage(), partialSuccess.RejectedDataPoints()))
+		return consumererror.NewPartialSuccess(consumererror.NewPermanent(fmt.Errorf("OTLP partial success: %s (%d rejected)", partialSuccess.ErrorMessage(), partialSuccess.RejectedDataPoints())), int(partialSuccess.RejectedDataPoints()))
 	}
 	return nil
 }
@@ -302,7 +351,7 @@ func logsPartialSuccessHandler(protoBytes []byte, contentType string) error {
 	}
 	partialSuccess := exportResponse.PartialSuccess()
 	if !(partialSuccess.ErrorMessage() == "" && partialSuccess.RejectedLogRecords() == 0) {
-		return consumererror.NewPermanent(fmt.Errorf("OTLP partial success: %s (%d rejected)", partialSuccess.ErrorMessage(), partialSuccess.RejectedLogRecords()))
+		return consumererror.NewPartialSuccess(consumererror.NewPermanent(fmt.Errorf("OTLP partial success: %s (%d rejected)", partialSuccess.ErrorMessage(), partialSuccess.RejectedLogRecords())), int(partialSuccess.RejectedLogRecords()))
 	}
 	return nil
 }
@@ -34,6 +34,7 @@ import (
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/extension/encoding"
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/internal/testutil"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -711,6 +712,9 @@ func TestPartialSuccess_traces(t *testing.T) {
 	traces := ptrace.NewTraces()
 	err = exp.ConsumeTraces(context.Background(), traces)
 	require.Error(t, err)
+	partial, ok := consumererror.AsPartialSuccess(err)
+	require.True(t, ok)
+	assert.Equal(t, 1, partial.Rejected())
 }
 
 func TestPartialSuccess_metrics(t *testing.T) {
@@ -937,6 +941,104 @@ func TestPartialSuccessUnsupportedContentType(t *testing.T) {
 	}
 }
 
+type encodingExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	encodings map[string]encoding.Encoding
+}
+
+func (e *encodingExtension) Encodings() map[string]encoding.Encoding {
+	return e.encodings
+}
+
+type encodingHost struct {
+	component.Host
+	ext *encodingExtension
+}
+
+func (h encodingHost) GetExtensions() map[component.ID]component.Component {
+	return map[component.ID]component.Component{component.NewID("encoding"): h.ext}
+}
+
+func TestEncoding(t *testing.T) {
+	host := encodingHost{
+		Host: componenttest.NewNopHost(),
+		ext: &encodingExtension{encodings: map[string]encoding.Encoding{
+			"custom": {TracesMarshaler: &ptrace.JSONMarshaler{}},
+		}},
+	}
+	tests := []struct {
+		encoding    string
+		contentType string
+	}{
+		{encoding: "", contentType: "application/x-protobuf"},
+		{encoding: "otlp_proto", contentType: "application/x-protobuf"},
+		{encoding: "otlp_json", contentType: "application/json"},
+		{encoding: "custom", contentType: "application/octet-stream"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.encoding, func(t *testing.T) {
+			var (
+				contentType string
+				body        []byte
+			)
+			srv := createBackend("/v1/traces", func(writer http.ResponseWriter, request *http.Request) {
+				contentType = request.Header.Get("Content-Type")
+				var err error
+				body, err = io.ReadAll(request.Body)
+				assert.NoError(t, err)
+			})
+			defer srv.Close()
+
+			cfg := &Config{
+				TracesEndpoint: fmt.Sprintf("%s/v1/traces", srv.URL),
+				Encoding:       tt.encoding,
+			}
+			exp, err := createTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+			require.NoError(t, err)
+			require.NoError(t, exp.Start(context.Background(), host))
+			t.Cleanup(func() {
+				require.NoError(t, exp.Shutdown(context.Background()))
+			})
+
+			td := testdata.GenerateTraces(1)
+			require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+			assert.Equal(t, tt.contentType, contentType)
+
+			var unmarshaler ptrace.Unmarshaler = &ptrace.ProtoUnmarshaler{}
+			if tt.contentType != "application/x-protobuf" {
+				unmarshaler = &ptrace.JSONUnmarshaler{}
+			}
+			got, err := unmarshaler.UnmarshalTraces(body)
+			require.NoError(t, err)
+			assert.Equal(t, td, got)
+		})
+	}
+}
+
+func TestEncodingStartError(t *testing.T) {
+	host := encodingHost{
+		Host: componenttest.NewNopHost(),
+		ext: &encodingExtension{encodings: map[string]encoding.Encoding{
+			"custom": {TracesMarshaler: &ptrace.JSONMarshaler{}},
+		}},
+	}
+	set := exportertest.NewNopCreateSettings()
+
+	cfg := &Config{TracesEndpoint: "http://localhost:4318/v1/traces", Encoding: "unknown"}
+	exp, err := createTracesExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	assert.ErrorIs(t, exp.Start(context.Background(), host), encoding.ErrUnknownEncoding)
+
+	cfg = &Config{LogsEndpoint: "http://localhost:4318/v1/logs", MetricsEndpoint: "http://localhost:4318/v1/metrics", Encoding: "custom"}
+	logsExp, err := createLogsExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	assert.ErrorIs(t, logsExp.Start(context.Background(), host), encoding.ErrUnsupportedSignal)
+	metricsExp, err := createMetricsExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	assert.ErrorIs(t, metricsExp.Start(context.Background(), host), encoding.ErrUnsupportedSignal)
+}
+
 func createBackend(endpoint string, handler func(writer http.ResponseWriter, request *http.Request)) *httptest.Server {
 	mux := http.NewServeMux()
 	mux.HandleFunc(endpoint, handler)
@@ -4,6 +4,8 @@
 package auth // import "go.opentelemetry.io/collector/extension/auth"
 
 import (
+	"context"
+	"errors"
 	"net/http"
 
 	"google.golang.org/grpc/credentials"
@@ -25,6 +27,18 @@ type Client interface {
 	PerRPCCredentials() (credentials.PerRPCCredentials, error)
 }
 
+var errRefreshNotSupported = errors.New("the authenticator does not support refreshing its credentials")
+
+// ClientRefresher is optionally implemented by a Client able to refresh its credentials on demand, e.g. when
+// the server rejects credentials revoked before their expiration. The HTTP and gRPC clients configured with
+// confighttp and configgrpc refresh the credentials of their authenticators, and retry the request once,
+// when the server rejects a request as unauthenticated.
+type ClientRefresher interface {
+	// RefreshCredentials obtains new credentials, used by the subsequent requests. It returns an error when
+	// the credentials cannot be refreshed, in which case the rejected request is not retried.
+	RefreshCredentials(ctx context.Context) error
+}
+
 // ClientOption represents the possible options for NewServerAuthenticator.
 type ClientOption func(*defaultClient)
 
@@ -48,11 +62,22 @@ func (f ClientPerRPCCredentialsFunc) PerRPCCredentials() (credentials.PerRPCCred
 	return f()
 }
 
+// ClientRefreshCredentialsFunc specifies the function that refreshes the credentials of the client, see ClientRefresher.
+type ClientRefreshCredentialsFunc func(ctx context.Context) error
+
+func (f ClientRefreshCredentialsFunc) RefreshCredentials(ctx context.Context) error {
+	if f == nil {
+		return errRefreshNotSupported
+	}
+	return f(ctx)
+}
+
 type defaultClient struct {
 	component.StartFunc
 	component.ShutdownFunc
 	ClientRoundTripperFunc
 	ClientPerRPCCredentialsFunc
+	ClientRefreshCredentialsFunc
 }
 
 // WithClientStart overrides the default `Start` function for a component.Component.
@@ -87,6 +112,14 @@ func WithClientPerRPCCredentials(perRPCCredentialsFunc ClientPerRPCCredentialsFu
 	}
 }
 
+// WithClientRefreshCredentials provides a `RefreshCredentials` function for this client authenticator.
+// By default, the credentials cannot be refreshed and the rejected requests are not retried.
+func WithClientRefreshCredentials(refreshFunc ClientRefreshCredentialsFunc) ClientOption {
+	return func(o *defaultClient) {
+		o.ClientRefreshCredentialsFunc = refreshFunc
+	}
+}
+
 // NewClient returns a Client configured with the provided options.
 func NewClient(options ...ClientOption) Client {
 	bc := &defaultClient{}
@@ -37,6 +37,12 @@ func TestClientDefaultValues(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("refresh-credentials", func(t *testing.T) {
+		refresher, ok := e.(ClientRefresher)
+		assert.True(t, ok)
+		assert.ErrorIs(t, refresher.RefreshCredentials(context.Background()), errRefreshNotSupported)
+	})
+
 	t.Run("shutdown", func(t *testing.T) {
 		err := e.Shutdown(context.Background())
 		assert.NoError(t, err)
@@ -117,3 +123,20 @@ func TestWithPerRPCCredentials(t *testing.T) {
 	assert.NoError(t, err)
 
 }
+
+func TestWithClientRefreshCredentials(t *testing.T) {
+	refreshed := 0
+	e := NewClient(WithClientRefreshCredentials(func(ctx context.Context) error {
+		refreshed++
+		return nil
+	}))
+
+	// test
+	refresher, ok := e.(ClientRefresher)
+	assert.True(t, ok)
+	err := refresher.RefreshCredentials(context.Background())
+
+	// verify
+	assert.NoError(t, err)
+	assert.Equal(t, 1, refreshed)
+}
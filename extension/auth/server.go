@@ -25,7 +25,9 @@ type Server interface {
 	// The resulting context should contain the authentication data, such as the principal/username, group membership (if available), and the raw
 	// authentication data (if possible). This will allow other components in the pipeline to make decisions based on that data, such as routing based
 	// on tenancy as determined by the group membership, or passing through the authentication data to the next collector/backend.
-	// The context keys to be used are not defined yet.
+	// The authentication data is expected in the client.Info of the resulting context, e.g. as returned by client.NewContextWithAuth,
+	// with the subject, issuer, scopes and tenant of the principal under the standard attribute names of client.NewAuthData, so that
+	// receivers can stamp them onto the received data, see receiverhelper.AuthAttributesSettings.
 	Authenticate(ctx context.Context, headers map[string][]string) (context.Context, error)
 }
 
@@ -0,0 +1,7 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package encoding implements a registry of the encodings used to marshal and
+// unmarshal pdata, allowing receivers and exporters to reference the wire formats
+// by their names, and extensions to provide their own encodings.
+package encoding // import "go.opentelemetry.io/collector/extension/encoding"
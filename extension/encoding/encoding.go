@@ -0,0 +1,32 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package encoding // import "go.opentelemetry.io/collector/extension/encoding"
+
+import (
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Encoding groups the marshalers and unmarshalers of a wire format. An encoding doesn't need to
+// support all the signals, a nil marshaler or unmarshaler meaning the encoding doesn't support it.
+type Encoding struct {
+	TracesMarshaler    ptrace.Marshaler
+	TracesUnmarshaler  ptrace.Unmarshaler
+	MetricsMarshaler   pmetric.Marshaler
+	MetricsUnmarshaler pmetric.Unmarshaler
+	LogsMarshaler      plog.Marshaler
+	LogsUnmarshaler    plog.Unmarshaler
+}
+
+// Extension is an Extension providing encodings. The encodings are registered by their names in the
+// Registry built from the extensions of the host, and can then be referenced by these names from the
+// configuration of the receivers and exporters.
+type Extension interface {
+	extension.Extension
+
+	// Encodings returns the encodings provided by the extension, by name.
+	Encodings() map[string]Encoding
+}
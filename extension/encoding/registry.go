@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package encoding // import "go.opentelemetry.io/collector/extension/encoding"
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	// OTLPProto is the name of the OTLP protobuf encoding, always registered.
+	OTLPProto = "otlp_proto"
+	// OTLPJSON is the name of the OTLP JSON encoding, always registered.
+	OTLPJSON = "otlp_json"
+)
+
+var (
+	// ErrUnknownEncoding is returned when no encoding is registered with the requested name.
+	ErrUnknownEncoding = errors.New("unknown encoding")
+	// ErrUnsupportedSignal is returned when the requested encoding doesn't support the signal.
+	ErrUnsupportedSignal = errors.New("signal not supported by the encoding")
+)
+
+// Registry holds the encodings by name.
+type Registry struct {
+	encodings map[string]Encoding
+}
+
+// NewRegistry returns a Registry holding the OTLP encodings.
+func NewRegistry() *Registry {
+	return &Registry{encodings: map[string]Encoding{
+		OTLPProto: {
+			TracesMarshaler:    &ptrace.ProtoMarshaler{},
+			TracesUnmarshaler:  &ptrace.ProtoUnmarshaler{},
+			MetricsMarshaler:   &pmetric.ProtoMarshaler{},
+			MetricsUnmarshaler: &pmetric.ProtoUnmarshaler{},
+			LogsMarshaler:      &plog.ProtoMarshaler{},
+			LogsUnmarshaler:    &plog.ProtoUnmarshaler{},
+		},
+		OTLPJSON: {
+			TracesMarshaler:    &ptrace.JSONMarshaler{},
+			TracesUnmarshaler:  &ptrace.JSONUnmarshaler{},
+			MetricsMarshaler:   &pmetric.JSONMarshaler{},
+			MetricsUnmarshaler: &pmetric.JSONUnmarshaler{},
+			LogsMarshaler:      &plog.JSONMarshaler{},
+			LogsUnmarshaler:    &plog.JSONUnmarshaler{},
+		},
+	}}
+}
+
+// NewRegistryFromExtensions returns a Registry holding the OTLP encodings, and the encodings provided
+// by the given extensions implementing Extension, typically the extensions of the component.Host.
+func NewRegistryFromExtensions(extensions map[component.ID]component.Component) (*Registry, error) {
+	r := NewRegistry()
+	// Iterate over the extensions in a deterministic order to always report the same conflict.
+	ids := make([]component.ID, 0, len(extensions))
+	for id := range extensions {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+	for _, id := range ids {
+		ext, ok := extensions[id].(Extension)
+		if !ok {
+			continue
+		}
+		for name, enc := range ext.Encodings() {
+			if err := r.Register(name, enc); err != nil {
+				return nil, fmt.Errorf("failed to register the encodings of the extension %q: %w", id, err)
+			}
+		}
+	}
+	return r, nil
+}
+
+// Register registers the encoding with the given name, failing if the name is already used.
+func (r *Registry) Register(name string, enc Encoding) error {
+	if name == "" {
+		return errors.New("encoding name must not be empty")
+	}
+	if _, ok := r.encodings[name]; ok {
+		return fmt.Errorf("encoding %q already registered", name)
+	}
+	r.encodings[name] = enc
+	return nil
+}
+
+// Names returns the sorted names of the registered encodings.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.encodings))
+	for name := range r.encodings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TracesMarshaler returns the traces marshaler of the encoding with the given name.
+func (r *Registry) TracesMarshaler(name string) (ptrace.Marshaler, error) {
+	enc, err := r.lookup(name)
+	if err != nil || enc.TracesMarshaler == nil {
+		return nil, signalError(name, "traces", err)
+	}
+	return enc.TracesMarshaler, nil
+}
+
+// TracesUnmarshaler returns the traces unmarshaler of the encoding with the given name.
+func (r *Registry) TracesUnmarshaler(name string) (ptrace.Unmarshaler, error) {
+	enc, err := r.lookup(name)
+	if err != nil || enc.TracesUnmarshaler == nil {
+		return nil, signalError(name, "traces", err)
+	}
+	return enc.TracesUnmarshaler, nil
+}
+
+// MetricsMarshaler returns the metrics marshaler of the encoding with the given name.
+func (r *Registry) MetricsMarshaler(name string) (pmetric.Marshaler, error) {
+	enc, err := r.lookup(name)
+	if err != nil || enc.MetricsMarshaler == nil {
+		return nil, signalError(name, "metrics", err)
+	}
+	return enc.MetricsMarshaler, nil
+}
+
+// MetricsUnmarshaler returns the metrics unmarshaler of the encoding with the given name.
+func (r *Registry) MetricsUnmarshaler(name string) (pmetric.Unmarshaler, error) {
+	enc, err := r.lookup(name)
+	if err != nil || enc.MetricsUnmarshaler == nil {
+		return nil, signalError(name, "metrics", err)
+	}
+	return enc.MetricsUnmarshaler, nil
+}
+
+// LogsMarshaler returns the logs marshaler of the encoding with the given name.
+func (r *Registry) LogsMarshaler(name string) (plog.Marshaler, error) {
+	enc, err := r.lookup(name)
+	if err != nil || enc.LogsMarshaler == nil {
+		return nil, signalError(name, "logs", err)
+	}
+	return enc.LogsMarshaler, nil
+}
+
+// LogsUnmarshaler returns the logs unmarshaler of the encoding with the given name.
+func (r *Registry) LogsUnmarshaler(name string) (plog.Unmarshaler, error) {
+	enc, err := r.lookup(name)
+	if err != nil || enc.LogsUnmarshaler == nil {
+		return nil, signalError(name, "logs", err)
+	}
+	return enc.LogsUnmarshaler, nil
+}
+
+func (r *Registry) lookup(name string) (Encoding, error) {
+	enc, ok := r.encodings[name]
+	if !ok {
+		return Encoding{}, fmt.Errorf("%w %q, registered encodings are %v", ErrUnknownEncoding, name, r.Names())
+	}
+	return enc, nil
+}
+
+func signalError(name string, signal string, err error) error {
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: %s by %q", ErrUnsupportedSignal, signal, name)
+}
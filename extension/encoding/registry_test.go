@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package encoding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+type testExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	encodings map[string]Encoding
+}
+
+func (e *testExtension) Encodings() map[string]Encoding {
+	return e.encodings
+}
+
+func TestRegistryOTLP(t *testing.T) {
+	r := NewRegistry()
+	assert.Equal(t, []string{OTLPJSON, OTLPProto}, r.Names())
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	for _, name := range r.Names() {
+		m, err := r.TracesMarshaler(name)
+		require.NoError(t, err)
+		u, err := r.TracesUnmarshaler(name)
+		require.NoError(t, err)
+		buf, err := m.MarshalTraces(td)
+		require.NoError(t, err)
+		got, err := u.UnmarshalTraces(buf)
+		require.NoError(t, err)
+		assert.Equal(t, td, got)
+
+		_, err = r.MetricsMarshaler(name)
+		assert.NoError(t, err)
+		_, err = r.MetricsUnmarshaler(name)
+		assert.NoError(t, err)
+		_, err = r.LogsMarshaler(name)
+		assert.NoError(t, err)
+		_, err = r.LogsUnmarshaler(name)
+		assert.NoError(t, err)
+	}
+}
+
+func TestRegistryRegister(t *testing.T) {
+	r := NewRegistry()
+	require.NoError(t, r.Register("custom", Encoding{LogsUnmarshaler: &plog.JSONUnmarshaler{}}))
+	assert.EqualError(t, r.Register("custom", Encoding{}), `encoding "custom" already registered`)
+	assert.EqualError(t, r.Register(OTLPProto, Encoding{}), `encoding "otlp_proto" already registered`)
+	assert.EqualError(t, r.Register("", Encoding{}), "encoding name must not be empty")
+
+	u, err := r.LogsUnmarshaler("custom")
+	require.NoError(t, err)
+	assert.Equal(t, &plog.JSONUnmarshaler{}, u)
+
+	_, err = r.LogsMarshaler("custom")
+	assert.ErrorIs(t, err, ErrUnsupportedSignal)
+	assert.EqualError(t, err, `signal not supported by the encoding: logs by "custom"`)
+	_, err = r.TracesMarshaler("custom")
+	assert.ErrorIs(t, err, ErrUnsupportedSignal)
+	_, err = r.MetricsUnmarshaler("custom")
+	assert.ErrorIs(t, err, ErrUnsupportedSignal)
+
+	_, err = r.TracesUnmarshaler("zipkin")
+	assert.ErrorIs(t, err, ErrUnknownEncoding)
+	assert.EqualError(t, err, `unknown encoding "zipkin", registered encodings are [custom otlp_json otlp_proto]`)
+}
+
+func TestNewRegistryFromExtensions(t *testing.T) {
+	zipkin := Encoding{TracesMarshaler: &ptrace.JSONMarshaler{}, TracesUnmarshaler: &ptrace.JSONUnmarshaler{}}
+	r, err := NewRegistryFromExtensions(map[component.ID]component.Component{
+		component.NewID("zipkinencoding"): &testExtension{encodings: map[string]Encoding{"zipkin": zipkin}},
+		component.NewID("other"):          &testExtension{},
+		component.NewID("nop"):            struct{ component.Component }{},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{OTLPJSON, OTLPProto, "zipkin"}, r.Names())
+	m, err := r.TracesMarshaler("zipkin")
+	require.NoError(t, err)
+	assert.Same(t, zipkin.TracesMarshaler, m)
+	_, err = r.MetricsMarshaler("zipkin")
+	assert.ErrorIs(t, err, ErrUnsupportedSignal)
+
+	_, err = NewRegistryFromExtensions(map[component.ID]component.Component{
+		component.NewID("a"): &testExtension{encodings: map[string]Encoding{"custom": {}}},
+		component.NewID("b"): &testExtension{encodings: map[string]Encoding{"custom": {}}},
+	})
+	assert.EqualError(t, err, `failed to register the encodings of the extension "b": encoding "custom" already registered`)
+
+	_, err = NewRegistryFromExtensions(map[component.ID]component.Component{
+		component.NewID("otlp"): &testExtension{encodings: map[string]Encoding{OTLPJSON: {}}},
+	})
+	assert.EqualError(t, err, `failed to register the encodings of the extension "otlp": encoding "otlp_json" already registered`)
+}
@@ -54,6 +54,14 @@ type Client interface {
 	Close(ctx context.Context) error
 }
 
+// Compactor is an optional interface of the storage clients able to reclaim the space left
+// by the deleted data, e.g. by rewriting their storage file.
+type Compactor interface {
+	// Compact reclaims the space left by the deleted data. The client must remain usable
+	// during and after the compaction.
+	Compact(ctx context.Context) error
+}
+
 type opType int
 
 const (
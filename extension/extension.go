@@ -45,6 +45,10 @@ type CreateSettings struct {
 	// ID returns the ID of the component that will be created.
 	ID component.ID
 
+	// InstanceID identifies the instance that will be created, among the instances created for ID.
+	// It can be used to attribute the telemetry of the component to the instance.
+	InstanceID component.InstanceID
+
 	component.TelemetrySettings
 
 	// BuildInfo can be used by components for informational purposes
@@ -76,6 +80,7 @@ type factory struct {
 	component.CreateDefaultConfigFunc
 	CreateFunc
 	extensionStability component.StabilityLevel
+	deprecation        *component.Deprecation
 }
 
 func (f *factory) Type() component.Type {
@@ -88,18 +93,49 @@ func (f *factory) ExtensionStability() component.StabilityLevel {
 	return f.extensionStability
 }
 
+// Deprecation returns the deprecation of the extension, or nil if it is not deprecated.
+func (f *factory) Deprecation() *component.Deprecation {
+	return f.deprecation
+}
+
+// FactoryOption apply changes to Factory.
+type FactoryOption interface {
+	// applyOption applies the option.
+	applyOption(o *factory)
+}
+
+// factoryOptionFunc is a FactoryOption created through a function.
+type factoryOptionFunc func(*factory)
+
+func (f factoryOptionFunc) applyOption(o *factory) {
+	f(o)
+}
+
+// WithDeprecation marks the extension as deprecated. A warning is logged when a deprecated extension is
+// used in the configuration.
+func WithDeprecation(deprecation component.Deprecation) FactoryOption {
+	return factoryOptionFunc(func(o *factory) {
+		o.deprecation = &deprecation
+	})
+}
+
 // NewFactory returns a new Factory  based on this configuration.
 func NewFactory(
 	cfgType component.Type,
 	createDefaultConfig component.CreateDefaultConfigFunc,
 	createServiceExtension CreateFunc,
-	sl component.StabilityLevel) Factory {
-	return &factory{
+	sl component.StabilityLevel,
+	options ...FactoryOption) Factory {
+	f := &factory{
 		cfgType:                 cfgType,
 		CreateDefaultConfigFunc: createDefaultConfig,
 		CreateFunc:              createServiceExtension,
 		extensionStability:      sl,
 	}
+	for _, opt := range options {
+		opt.applyOption(f)
+	}
+	return f
 }
 
 // MakeFactoryMap takes a list of factories and returns a map with Factory type as keys.
@@ -41,6 +41,15 @@ func TestNewFactory(t *testing.T) {
 	assert.Same(t, nopExtensionInstance, ext)
 }
 
+func TestNewFactoryWithDeprecation(t *testing.T) {
+	factory := NewFactory("test", nil, nil, component.StabilityLevelDeprecated)
+	assert.Nil(t, component.FactoryDeprecation(factory))
+
+	deprecation := component.Deprecation{Since: "v0.85.0", Replacement: "the new extension"}
+	factory = NewFactory("test", nil, nil, component.StabilityLevelDeprecated, WithDeprecation(deprecation))
+	assert.Equal(t, &deprecation, component.FactoryDeprecation(factory))
+}
+
 func TestMakeFactoryMap(t *testing.T) {
 	type testCase struct {
 		name string
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package memorytunerextension // import "go.opentelemetry.io/collector/extension/memorytunerextension"
+
+import (
+	"errors"
+	"time"
+)
+
+// Config has the configuration for the memory tuner extension.
+type Config struct {
+	// LimitMiB is the soft memory limit of the Go runtime, in MiB, see runtime/debug.SetMemoryLimit.
+	// Takes higher priority than LimitPercentage.
+	LimitMiB uint64 `mapstructure:"limit_mib"`
+
+	// LimitPercentage is the soft memory limit of the Go runtime, in % of the total memory
+	// detected from the cgroup or the host.
+	LimitPercentage uint64 `mapstructure:"limit_percentage"`
+
+	// AdaptiveGC lowers GOGC while the heap is close to the memory limit.
+	AdaptiveGC AdaptiveGCConfig `mapstructure:"adaptive_gc"`
+}
+
+// AdaptiveGCConfig has the configuration of the adaptation of GOGC to the memory pressure.
+type AdaptiveGCConfig struct {
+	// Enabled enables the adaptation of GOGC.
+	Enabled bool `mapstructure:"enabled"`
+
+	// CheckInterval is the time between the measurements of the heap.
+	CheckInterval time.Duration `mapstructure:"check_interval"`
+
+	// PressurePercentage is the size of the heap, in % of the memory limit, above which GOGC is lowered.
+	PressurePercentage uint64 `mapstructure:"pressure_percentage"`
+
+	// MinGOGC is the value of GOGC while the heap is above PressurePercentage.
+	MinGOGC int `mapstructure:"min_gogc"`
+}
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.LimitMiB == 0 && (cfg.LimitPercentage == 0 || cfg.LimitPercentage > 100) {
+		return errors.New("limit_percentage is not in range 1 to 100")
+	}
+	if !cfg.AdaptiveGC.Enabled {
+		return nil
+	}
+	if cfg.AdaptiveGC.CheckInterval <= 0 {
+		return errors.New("adaptive_gc::check_interval must be positive")
+	}
+	if cfg.AdaptiveGC.PressurePercentage == 0 || cfg.AdaptiveGC.PressurePercentage > 100 {
+		return errors.New("adaptive_gc::pressure_percentage is not in range 1 to 100")
+	}
+	if cfg.AdaptiveGC.MinGOGC <= 0 {
+		return errors.New("adaptive_gc::min_gogc must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package memorytunerextension
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(confmap.New(), cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(cm, cfg))
+	assert.Equal(t,
+		&Config{
+			LimitMiB:        1024,
+			LimitPercentage: 70,
+			AdaptiveGC: AdaptiveGCConfig{
+				Enabled:            true,
+				CheckInterval:      5 * time.Second,
+				PressurePercentage: 85,
+				MinGOGC:            50,
+			},
+		}, cfg)
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         *Config
+		expectedErr string
+	}{
+		{
+			name: "default",
+			cfg:  createDefaultConfig().(*Config),
+		},
+		{
+			name:        "no_limit",
+			cfg:         &Config{},
+			expectedErr: "limit_percentage is not in range 1 to 100",
+		},
+		{
+			name:        "limit_percentage_out_of_range",
+			cfg:         &Config{LimitPercentage: 200},
+			expectedErr: "limit_percentage is not in range 1 to 100",
+		},
+		{
+			name: "limit_mib_priority",
+			cfg:  &Config{LimitMiB: 512, LimitPercentage: 200},
+		},
+		{
+			name:        "check_interval",
+			cfg:         &Config{LimitMiB: 512, AdaptiveGC: AdaptiveGCConfig{Enabled: true, PressurePercentage: 90, MinGOGC: 25}},
+			expectedErr: "adaptive_gc::check_interval must be positive",
+		},
+		{
+			name:        "pressure_percentage",
+			cfg:         &Config{LimitMiB: 512, AdaptiveGC: AdaptiveGCConfig{Enabled: true, CheckInterval: time.Second, PressurePercentage: 101, MinGOGC: 25}},
+			expectedErr: "adaptive_gc::pressure_percentage is not in range 1 to 100",
+		},
+		{
+			name:        "min_gogc",
+			cfg:         &Config{LimitMiB: 512, AdaptiveGC: AdaptiveGCConfig{Enabled: true, CheckInterval: time.Second, PressurePercentage: 90}},
+			expectedErr: "adaptive_gc::min_gogc must be positive",
+		},
+		{
+			name: "adaptive_gc_disabled",
+			cfg:  &Config{LimitMiB: 512, AdaptiveGC: AdaptiveGCConfig{PressurePercentage: 101}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package memorytunerextension // import "go.opentelemetry.io/collector/extension/memorytunerextension"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/internal/iruntime"
+)
+
+const (
+	// The value of extension "type" in configuration.
+	typeStr = "memory_tuner"
+
+	defaultLimitPercentage    = 80
+	defaultCheckInterval      = time.Second
+	defaultPressurePercentage = 90
+	defaultMinGOGC            = 25
+)
+
+// memHandler returns the total memory of the target host/vm
+var memHandler = iruntime.TotalMemory
+
+// NewFactory creates a factory for the memory tuner extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(typeStr, createDefaultConfig, createExtension, component.StabilityLevelAlpha)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		LimitPercentage: defaultLimitPercentage,
+		AdaptiveGC: AdaptiveGCConfig{
+			CheckInterval:      defaultCheckInterval,
+			PressurePercentage: defaultPressurePercentage,
+			MinGOGC:            defaultMinGOGC,
+		},
+	}
+}
+
+func createExtension(_ context.Context, set extension.CreateSettings, cfg component.Config) (extension.Extension, error) {
+	return newMemoryTuner(cfg.(*Config), set.TelemetrySettings.Logger, memHandler), nil
+}
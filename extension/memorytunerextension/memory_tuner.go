@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package memorytunerextension // import "go.opentelemetry.io/collector/extension/memorytunerextension"
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/internal/gctuner"
+)
+
+const megaBytes = 1024 * 1024
+
+type memoryTuner struct {
+	cfg         *Config
+	logger      *zap.Logger
+	getTotalMem func() (uint64, error)
+	tuner       *gctuner.Tuner
+
+	// readMemStats reads the heap size, replaced in tests.
+	readMemStats func(*runtime.MemStats)
+
+	memoryLimit uint64
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+func newMemoryTuner(cfg *Config, logger *zap.Logger, getTotalMem func() (uint64, error)) *memoryTuner {
+	return &memoryTuner{
+		cfg:          cfg,
+		logger:       logger,
+		getTotalMem:  getTotalMem,
+		tuner:        gctuner.New(logger),
+		readMemStats: runtime.ReadMemStats,
+	}
+}
+
+func (m *memoryTuner) Start(_ context.Context, _ component.Host) error {
+	// absolute value supersedes percentage setting
+	memoryLimit := m.cfg.LimitMiB * megaBytes
+	if memoryLimit == 0 {
+		totalMemory, err := m.getTotalMem()
+		if err != nil {
+			return err
+		}
+		memoryLimit = m.cfg.LimitPercentage * totalMemory / 100
+	}
+	// The limit set explicitly for the process with GOMEMLIMIT takes precedence.
+	var err error
+	if m.memoryLimit, err = m.tuner.Start(memoryLimit); err != nil {
+		return err
+	}
+
+	if m.cfg.AdaptiveGC.Enabled {
+		m.stopCh = make(chan struct{})
+		m.wg.Add(1)
+		go m.monitor()
+	}
+	return nil
+}
+
+func (m *memoryTuner) Shutdown(_ context.Context) error {
+	if m.stopCh != nil {
+		close(m.stopCh)
+		m.wg.Wait()
+		m.stopCh = nil
+	}
+	m.tuner.Stop()
+	return nil
+}
+
+// GetMemoryLimit returns the memory limit of the Go runtime, in bytes. It allows the memory limiter
+// processor to check that its limits are consistent with the one of the Go runtime.
+func (m *memoryTuner) GetMemoryLimit() uint64 {
+	return m.memoryLimit
+}
+
+func (m *memoryTuner) monitor() {
+	defer m.wg.Done()
+	ticker := time.NewTicker(m.cfg.AdaptiveGC.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.checkMemoryPressure()
+		case <-m.stopCh:
+			return
+		}
+	}
+}
+
+// checkMemoryPressure lowers GOGC to make the garbage collection more frequent while the heap is close
+// to the memory limit, and restores it once the heap is back below the threshold.
+func (m *memoryTuner) checkMemoryPressure() {
+	ms := &runtime.MemStats{}
+	m.readMemStats(ms)
+	threshold := m.memoryLimit / 100 * m.cfg.AdaptiveGC.PressurePercentage
+	m.tuner.SetPressure(ms.HeapAlloc >= threshold, m.cfg.AdaptiveGC.MinGOGC)
+}
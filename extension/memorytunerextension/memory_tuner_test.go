@@ -0,0 +1,208 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package memorytunerextension
+
+import (
+	"context"
+	"errors"
+	"math"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/internal/gctuner"
+)
+
+// fakeRuntime records the settings applied to the Go runtime by the memory tuner.
+type fakeRuntime struct {
+	mu          sync.Mutex
+	env         map[string]string
+	memoryLimit int64
+	gcPercent   int
+	heapAlloc   uint64
+}
+
+func newFakeRuntime() *fakeRuntime {
+	return &fakeRuntime{env: map[string]string{}, memoryLimit: math.MaxInt64, gcPercent: 100}
+}
+
+func (r *fakeRuntime) install(m *memoryTuner) {
+	m.tuner.LookupEnv = func(key string) (string, bool) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		v, ok := r.env[key]
+		return v, ok
+	}
+	m.tuner.SetMemoryLimit = func(limit int64) int64 {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		prev := r.memoryLimit
+		if limit >= 0 {
+			r.memoryLimit = limit
+		}
+		return prev
+	}
+	m.tuner.SetGCPercent = func(percent int) int {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		prev := r.gcPercent
+		r.gcPercent = percent
+		return prev
+	}
+	m.readMemStats = func(ms *runtime.MemStats) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		ms.HeapAlloc = r.heapAlloc
+	}
+}
+
+func (r *fakeRuntime) getMemoryLimit() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.memoryLimit
+}
+
+func (r *fakeRuntime) getGCPercent() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.gcPercent
+}
+
+func (r *fakeRuntime) setHeapAlloc(heapAlloc uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.heapAlloc = heapAlloc
+}
+
+func TestMemoryLimit(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      *Config
+		env         map[string]string
+		expectLimit uint64
+		expectSet   bool
+	}{
+		{
+			name:        "test_abs_limit",
+			config:      &Config{LimitMiB: 13},
+			expectLimit: 13 * megaBytes,
+			expectSet:   true,
+		},
+		{
+			name:        "test_abs_limit_priority",
+			config:      &Config{LimitMiB: 13, LimitPercentage: 20},
+			expectLimit: 13 * megaBytes,
+			expectSet:   true,
+		},
+		{
+			name:        "test_limit_in_percentage",
+			config:      &Config{LimitPercentage: 20},
+			expectLimit: 20 * megaBytes,
+			expectSet:   true,
+		},
+		{
+			name:        "test_gomemlimit_env",
+			config:      &Config{LimitMiB: 13},
+			env:         map[string]string{"GOMEMLIMIT": "1GiB"},
+			expectLimit: math.MaxInt64,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := newFakeRuntime()
+			for k, v := range tt.env {
+				rt.env[k] = v
+			}
+			mt := newMemoryTuner(tt.config, zap.NewNop(), mockTotalMem)
+			rt.install(mt)
+
+			assert.NoError(t, mt.Start(context.Background(), componenttest.NewNopHost()))
+			assert.Equal(t, tt.expectLimit, mt.GetMemoryLimit())
+			if tt.expectSet {
+				assert.Equal(t, int64(tt.expectLimit), rt.getMemoryLimit())
+			}
+
+			assert.NoError(t, mt.Shutdown(context.Background()))
+			assert.Equal(t, int64(math.MaxInt64), rt.getMemoryLimit())
+		})
+	}
+}
+
+func TestMemoryLimitTotalMemoryError(t *testing.T) {
+	mt := newMemoryTuner(&Config{LimitPercentage: 20}, zap.NewNop(), func() (uint64, error) {
+		return 0, errors.New("no total memory")
+	})
+	newFakeRuntime().install(mt)
+	assert.EqualError(t, mt.Start(context.Background(), componenttest.NewNopHost()), "no total memory")
+}
+
+func TestAdaptiveGC(t *testing.T) {
+	rt := newFakeRuntime()
+	mt := newMemoryTuner(&Config{
+		LimitMiB: 100,
+		AdaptiveGC: AdaptiveGCConfig{
+			Enabled:            true,
+			CheckInterval:      time.Millisecond,
+			PressurePercentage: 90,
+			MinGOGC:            25,
+		},
+	}, zap.NewNop(), mockTotalMem)
+	rt.install(mt)
+	require.NoError(t, mt.Start(context.Background(), componenttest.NewNopHost()))
+
+	rt.setHeapAlloc(95 * megaBytes)
+	assert.Eventually(t, func() bool { return rt.getGCPercent() == 25 }, 5*time.Second, time.Millisecond)
+
+	rt.setHeapAlloc(50 * megaBytes)
+	assert.Eventually(t, func() bool { return rt.getGCPercent() == 100 }, 5*time.Second, time.Millisecond)
+
+	// GOGC is restored on shutdown if the heap is still under pressure.
+	rt.setHeapAlloc(95 * megaBytes)
+	assert.Eventually(t, func() bool { return rt.getGCPercent() == 25 }, 5*time.Second, time.Millisecond)
+	require.NoError(t, mt.Shutdown(context.Background()))
+	assert.Equal(t, 100, rt.getGCPercent())
+}
+
+func TestAdaptiveGCAlreadyLow(t *testing.T) {
+	rt := newFakeRuntime()
+	rt.gcPercent = 10
+	mt := newMemoryTuner(&Config{
+		LimitMiB:   100,
+		AdaptiveGC: AdaptiveGCConfig{Enabled: true, CheckInterval: time.Hour, PressurePercentage: 90, MinGOGC: 25},
+	}, zap.NewNop(), mockTotalMem)
+	rt.install(mt)
+	require.NoError(t, mt.Start(context.Background(), componenttest.NewNopHost()))
+
+	rt.setHeapAlloc(95 * megaBytes)
+	mt.checkMemoryPressure()
+	assert.Equal(t, 10, rt.getGCPercent())
+	require.NoError(t, mt.Shutdown(context.Background()))
+	assert.Equal(t, 10, rt.getGCPercent())
+}
+
+func TestSingleMemoryTuner(t *testing.T) {
+	rt := newFakeRuntime()
+	first := newMemoryTuner(&Config{LimitMiB: 100}, zap.NewNop(), mockTotalMem)
+	rt.install(first)
+	second := newMemoryTuner(&Config{LimitMiB: 200}, zap.NewNop(), mockTotalMem)
+	rt.install(second)
+
+	require.NoError(t, first.Start(context.Background(), componenttest.NewNopHost()))
+	assert.ErrorIs(t, second.Start(context.Background(), componenttest.NewNopHost()), gctuner.ErrOwned)
+	assert.Equal(t, int64(100*megaBytes), rt.getMemoryLimit())
+	require.NoError(t, second.Shutdown(context.Background()))
+	require.NoError(t, first.Shutdown(context.Background()))
+	assert.Equal(t, int64(math.MaxInt64), rt.getMemoryLimit())
+}
+
+func mockTotalMem() (uint64, error) {
+	return uint64(100 * megaBytes), nil
+}
@@ -49,7 +49,7 @@ func (f *flagValue) Set(s string) error {
 		case '+':
 			id = id[1:]
 		}
-		errs = multierr.Append(errs, f.reg.Set(id, val))
+		errs = multierr.Append(errs, f.reg.SetFromSource(id, val, SourceFlag))
 	}
 	return errs
 }
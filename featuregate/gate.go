@@ -3,7 +3,10 @@
 
 package featuregate // import "go.opentelemetry.io/collector/featuregate"
 
-import "sync/atomic"
+import (
+	"sync"
+	"sync/atomic"
+)
 
 // Gate is an immutable object that is owned by the Registry and represents an individual feature that
 // may be enabled or disabled based on the lifecycle state of the feature and CLI flags specified by the user.
@@ -11,10 +14,18 @@ type Gate struct {
 	id           string
 	description  string
 	referenceURL string
+	owner        string
 	fromVersion  string
 	toVersion    string
+	requires     []string
+	runtime      bool
 	stage        Stage
 	enabled      *atomic.Bool
+	source       atomic.Int32
+	locked       atomic.Bool
+
+	callbacksMu sync.Mutex
+	callbacks   []func(enabled bool)
 }
 
 // ID returns the id of the Gate.
@@ -27,6 +38,16 @@ func (g *Gate) IsEnabled() bool {
 	return g.enabled.Load()
 }
 
+// Source returns where the current enabled value of the Gate comes from.
+func (g *Gate) Source() Source {
+	return Source(g.source.Load())
+}
+
+// IsLocked returns true if the enabled value of the Gate is locked by the distribution, see Registry.Lock.
+func (g *Gate) IsLocked() bool {
+	return g.locked.Load()
+}
+
 // Description returns the description for the Gate.
 func (g *Gate) Description() string {
 	return g.description
@@ -42,6 +63,11 @@ func (g *Gate) ReferenceURL() string {
 	return g.referenceURL
 }
 
+// Owner returns the component or team that owns the Gate.
+func (g *Gate) Owner() string {
+	return g.owner
+}
+
 // FromVersion returns the version information when the Gate's was added.
 func (g *Gate) FromVersion() string {
 	return g.fromVersion
@@ -51,3 +77,24 @@ func (g *Gate) FromVersion() string {
 func (g *Gate) ToVersion() string {
 	return g.toVersion
 }
+
+// Requires returns the ids of the gates which must be enabled when the Gate is enabled.
+func (g *Gate) Requires() []string {
+	return g.requires
+}
+
+// IsRuntimeTogglable returns true if the Gate declared that it can be toggled while the collector
+// is running, see WithRegisterRuntimeToggle and Registry.SetAtRuntime.
+func (g *Gate) IsRuntimeTogglable() bool {
+	return g.runtime
+}
+
+// notify calls all the registered callbacks with the new enabled value.
+func (g *Gate) notify(enabled bool) {
+	g.callbacksMu.Lock()
+	callbacks := append([]func(bool){}, g.callbacks...)
+	g.callbacksMu.Unlock()
+	for _, cb := range callbacks {
+		cb(enabled)
+	}
+}
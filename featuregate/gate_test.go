@@ -19,6 +19,7 @@ func TestGate(t *testing.T) {
 		enabled:      enabled,
 		stage:        StageAlpha,
 		referenceURL: "http://example.com",
+		owner:        "receiver/otlp",
 		fromVersion:  "v0.61.0",
 		toVersion:    "v0.64.0",
 	}
@@ -28,6 +29,7 @@ func TestGate(t *testing.T) {
 	assert.True(t, g.IsEnabled())
 	assert.Equal(t, StageAlpha, g.Stage())
 	assert.Equal(t, "http://example.com", g.ReferenceURL())
+	assert.Equal(t, "receiver/otlp", g.Owner())
 	assert.Equal(t, "v0.61.0", g.FromVersion())
 	assert.Equal(t, "v0.64.0", g.ToVersion())
 }
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package featuregate // import "go.opentelemetry.io/collector/featuregate"
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Namespace gives access to the gates of a Registry whose id starts with a common prefix,
+// for example all the "receiver.otlp.*" gates owned by the OTLP receiver.
+// A Namespace can carry overrides that apply only to the component instance using it.
+type Namespace struct {
+	reg       *Registry
+	prefix    string
+	overrides map[string]bool
+}
+
+// Namespace returns the Namespace of the gates whose id starts with prefix followed by a ".".
+func (r *Registry) Namespace(prefix string) *Namespace {
+	return &Namespace{reg: r, prefix: prefix}
+}
+
+// Prefix returns the prefix of the gate ids in the Namespace.
+func (n *Namespace) Prefix() string {
+	return n.prefix
+}
+
+// ID returns the id of the gate with the given name in the Namespace.
+func (n *Namespace) ID(name string) string {
+	return n.prefix + "." + name
+}
+
+// MustRegister like Register but panics if an invalid name or gate options are provided.
+func (n *Namespace) MustRegister(name string, stage Stage, opts ...RegisterOption) *Gate {
+	g, err := n.Register(name, stage, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// Register a Gate with the given name in the Namespace and return it.
+// Unless set with WithRegisterOwner, the owner of the Gate is the Namespace prefix.
+func (n *Namespace) Register(name string, stage Stage, opts ...RegisterOption) (*Gate, error) {
+	return n.reg.Register(n.ID(name), stage, append([]RegisterOption{WithRegisterOwner(n.prefix)}, opts...)...)
+}
+
+// IsEnabled returns true if the gate with the given name is enabled, taking the overrides
+// of the Namespace into account. Unknown gates are reported as disabled.
+func (n *Namespace) IsEnabled(name string) bool {
+	if enabled, ok := n.overrides[name]; ok {
+		return enabled
+	}
+	v, ok := n.reg.gates.Load(n.ID(name))
+	if !ok {
+		return false
+	}
+	return v.(*Gate).IsEnabled()
+}
+
+// VisitAll visits all the gates of the Namespace in lexicographical order, calling fn for each.
+func (n *Namespace) VisitAll(fn func(*Gate)) {
+	n.reg.VisitAll(func(g *Gate) {
+		if strings.HasPrefix(g.ID(), n.prefix+".") {
+			fn(g)
+		}
+	})
+}
+
+// WithOverrides returns a copy of the Namespace in which the gates named in overrides have the given
+// enabled value, without changing the Registry. The names are relative to the Namespace prefix.
+// Only gates registered in the Namespace and not in the Stable or Deprecated stage can be overridden.
+func (n *Namespace) WithOverrides(overrides map[string]bool) (*Namespace, error) {
+	merged := make(map[string]bool, len(n.overrides)+len(overrides))
+	for name, enabled := range n.overrides {
+		merged[name] = enabled
+	}
+	for name, enabled := range overrides {
+		v, ok := n.reg.gates.Load(n.ID(name))
+		if !ok {
+			return nil, fmt.Errorf("no such feature gate %q", n.ID(name))
+		}
+		if g := v.(*Gate); g.stage == StageStable || g.stage == StageDeprecated {
+			return nil, fmt.Errorf("feature gate %q is %s, can not be overridden", g.id, strings.ToLower(g.stage.String()))
+		}
+		merged[name] = enabled
+	}
+	return &Namespace{reg: n.reg, prefix: n.prefix, overrides: merged}, nil
+}
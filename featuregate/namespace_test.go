@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package featuregate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespace(t *testing.T) {
+	r := NewRegistry()
+	r.MustRegister("receiver.other.foo", StageBeta)
+	ns := r.Namespace("receiver.otlp")
+	assert.Equal(t, "receiver.otlp", ns.Prefix())
+	assert.Equal(t, "receiver.otlp.foo", ns.ID("foo"))
+
+	foo := ns.MustRegister("foo", StageAlpha)
+	assert.Equal(t, "receiver.otlp.foo", foo.ID())
+	assert.Equal(t, "receiver.otlp", foo.Owner())
+	bar, err := ns.Register("bar", StageBeta, WithRegisterOwner("team"))
+	require.NoError(t, err)
+	assert.Equal(t, "team", bar.Owner())
+	assert.Panics(t, func() { ns.MustRegister("foo", StageAlpha) })
+
+	assert.False(t, ns.IsEnabled("foo"))
+	assert.True(t, ns.IsEnabled("bar"))
+	assert.False(t, ns.IsEnabled("unknown"))
+	require.NoError(t, r.Set(foo.ID(), true))
+	assert.True(t, ns.IsEnabled("foo"))
+
+	var ids []string
+	ns.VisitAll(func(g *Gate) {
+		ids = append(ids, g.ID())
+	})
+	assert.Equal(t, []string{"receiver.otlp.bar", "receiver.otlp.foo"}, ids)
+}
+
+func TestNamespaceWithOverrides(t *testing.T) {
+	r := NewRegistry()
+	ns := r.Namespace("receiver.otlp")
+	foo := ns.MustRegister("foo", StageAlpha)
+	ns.MustRegister("bar", StageAlpha)
+	ns.MustRegister("stable", StageStable, WithRegisterToVersion("next"))
+
+	instance, err := ns.WithOverrides(map[string]bool{"foo": true})
+	require.NoError(t, err)
+	assert.True(t, instance.IsEnabled("foo"))
+	assert.False(t, instance.IsEnabled("bar"))
+	// The Registry and the original Namespace are not changed.
+	assert.False(t, foo.IsEnabled())
+	assert.False(t, ns.IsEnabled("foo"))
+
+	// Overrides are merged with the existing ones.
+	instance, err = instance.WithOverrides(map[string]bool{"bar": true})
+	require.NoError(t, err)
+	assert.True(t, instance.IsEnabled("foo"))
+	assert.True(t, instance.IsEnabled("bar"))
+
+	_, err = ns.WithOverrides(map[string]bool{"unknown": true})
+	assert.EqualError(t, err, `no such feature gate "receiver.otlp.unknown"`)
+	_, err = ns.WithOverrides(map[string]bool{"stable": false})
+	assert.EqualError(t, err, `feature gate "receiver.otlp.stable" is stable, can not be overridden`)
+}
@@ -6,8 +6,12 @@ package featuregate // import "go.opentelemetry.io/collector/featuregate"
 import (
 	"fmt"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
+
+	"go.uber.org/multierr"
 )
 
 var globalRegistry = NewRegistry()
@@ -19,6 +23,43 @@ func GlobalRegistry() *Registry {
 
 type Registry struct {
 	gates sync.Map
+
+	mu             sync.Mutex
+	version        string
+	warningHandler func(Warning)
+	// pendingWarnings are the warnings reported before a handler is set, the last one of each Gate.
+	pendingWarnings []Warning
+
+	// runtimeMu serializes the changes made by SetAtRuntime, so that the requirements are checked
+	// against the enabled values they are applied to.
+	runtimeMu sync.Mutex
+}
+
+// Warning describes the explicit use of a Gate that is scheduled for removal, or a Gate still
+// registered past its removal version.
+type Warning struct {
+	// ID is the id of the Gate.
+	ID string
+	// Stage is the Gate's lifecycle stage, either StageStable or StageDeprecated unless Removed.
+	Stage Stage
+	// ToVersion is the Collector release when the Gate is removed.
+	ToVersion string
+	// Removed is set when the Gate is registered although the current version is past ToVersion,
+	// see Registry.SetCurrentVersion.
+	Removed bool
+}
+
+func (w Warning) String() string {
+	if w.Removed {
+		return fmt.Sprintf("Feature gate %q was scheduled for removal in version %v and is still registered by this build. It must be removed.",
+			w.ID, w.ToVersion)
+	}
+	state := "enabled"
+	if w.Stage == StageDeprecated {
+		state = "disabled"
+	}
+	return fmt.Sprintf("Feature gate %q is %s and already %s. It will be removed in version %v and continued use of the gate after version %v will result in an error.",
+		w.ID, strings.ToLower(w.Stage.String()), state, w.ToVersion, w.ToVersion)
 }
 
 // NewRegistry returns a new empty Registry.
@@ -59,6 +100,13 @@ func WithRegisterFromVersion(fromVersion string) RegisterOption {
 	})
 }
 
+// WithRegisterOwner sets the component or team that owns the Gate, e.g. "receiver/otlp".
+func WithRegisterOwner(owner string) RegisterOption {
+	return registerOptionFunc(func(g *Gate) {
+		g.owner = owner
+	})
+}
+
 // WithRegisterToVersion is used to set the Gate "ToVersion".
 // The "ToVersion", if not empty, contains the last Collector release in which you can still use a feature gate.
 // If the feature stage is either "Deprecated" or "Stable", the "ToVersion" is the Collector release when the feature is removed.
@@ -68,6 +116,23 @@ func WithRegisterToVersion(toVersion string) RegisterOption {
 	})
 }
 
+// WithRegisterRequires declares the gates which must be enabled when the Gate is enabled, e.g. a sub-behavior
+// of a feature requiring the gate of that feature. The combinations are checked by Registry.Validate.
+func WithRegisterRequires(ids ...string) RegisterOption {
+	return registerOptionFunc(func(g *Gate) {
+		g.requires = append(g.requires, ids...)
+	})
+}
+
+// WithRegisterRuntimeToggle declares that the Gate can be toggled while the collector is running, with
+// Registry.SetAtRuntime. Only the gates whose value is checked every time it is used, or which react to
+// the changes with a callback registered with Registry.RegisterCallback, should declare it.
+func WithRegisterRuntimeToggle() RegisterOption {
+	return registerOptionFunc(func(g *Gate) {
+		g.runtime = true
+	})
+}
+
 // MustRegister like Register but panics if an invalid ID or gate options are provided.
 func (r *Registry) MustRegister(id string, stage Stage, opts ...RegisterOption) *Gate {
 	g, err := r.Register(id, stage, opts...)
@@ -96,9 +161,20 @@ func (r *Registry) Register(id string, stage Stage, opts ...RegisterOption) (*Ga
 	default:
 		return nil, fmt.Errorf("unknown stage value %q for gate %q", stage, id)
 	}
+	for _, req := range g.requires {
+		if req == id {
+			return nil, fmt.Errorf("feature gate %q can not require itself", id)
+		}
+	}
 	if (g.stage == StageStable || g.stage == StageDeprecated) && g.toVersion == "" {
 		return nil, fmt.Errorf("no removal version set for %v gate %q", g.stage.String(), id)
 	}
+	r.mu.Lock()
+	version := r.version
+	r.mu.Unlock()
+	if err := checkRemoved(g, version); err != nil {
+		return nil, err
+	}
 	if _, loaded := r.gates.LoadOrStore(id, g); loaded {
 		return nil, fmt.Errorf("attempted to add pre-existing gate %q", id)
 	}
@@ -106,27 +182,109 @@ func (r *Registry) Register(id string, stage Stage, opts ...RegisterOption) (*Ga
 }
 
 // Set the enabled valued for a Gate identified by the given id.
+// The Source of the value is recorded as SourceRuntime.
 func (r *Registry) Set(id string, enabled bool) error {
+	return r.SetFromSource(id, enabled, SourceRuntime)
+}
+
+// SetFromSource is like Set but records the given Source for the enabled value.
+func (r *Registry) SetFromSource(id string, enabled bool, source Source) error {
 	v, ok := r.gates.Load(id)
 	if !ok {
 		return fmt.Errorf("no such feature gate %q", id)
 	}
 	g := v.(*Gate)
+	if g.IsLocked() {
+		return fmt.Errorf("feature gate %q is locked by the distribution, can not be modified", id)
+	}
 
 	switch g.stage {
 	case StageStable:
 		if !enabled {
 			return fmt.Errorf("feature gate %q is stable, can not be disabled", id)
 		}
-		fmt.Printf("Feature gate %q is stable and already enabled. It will be removed in version %v and continued use of the gate after version %v will result in an error.\n", id, g.toVersion, g.toVersion)
+		r.warn(Warning{ID: id, Stage: g.stage, ToVersion: g.toVersion})
 	case StageDeprecated:
 		if enabled {
 			return fmt.Errorf("feature gate %q is deprecated, can not be enabled", id)
 		}
-		fmt.Printf("Feature gate %q is deprecated and already disabled. It will be removed in version %v and continued use of the gate after version %v will result in an error.\n", id, g.toVersion, g.toVersion)
+		r.warn(Warning{ID: id, Stage: g.stage, ToVersion: g.toVersion})
 	default:
-		g.enabled.Store(enabled)
+		g.source.Store(int32(source))
+		if g.enabled.Swap(enabled) != enabled {
+			g.notify(enabled)
+		}
+	}
+	return nil
+}
+
+// SetAtRuntime is like Set, for the changes made while the collector is running, e.g. through the control
+// API. Unlike Set, it only changes the gates declared with WithRegisterRuntimeToggle, refuses any change
+// of the Stable and Deprecated gates, and keeps the requirements of the gates satisfied: a Gate can only
+// be enabled if the gates it requires are enabled, and can only be disabled if no enabled Gate requires it.
+func (r *Registry) SetAtRuntime(id string, enabled bool) error {
+	r.runtimeMu.Lock()
+	defer r.runtimeMu.Unlock()
+
+	v, ok := r.gates.Load(id)
+	if !ok {
+		return fmt.Errorf("no such feature gate %q", id)
+	}
+	g := v.(*Gate)
+	if !g.runtime {
+		return fmt.Errorf("feature gate %q can not be toggled at runtime", id)
+	}
+	if g.stage == StageStable || g.stage == StageDeprecated {
+		return fmt.Errorf("feature gate %q is %s, can not be toggled at runtime", id, strings.ToLower(g.stage.String()))
+	}
+	if enabled {
+		for _, req := range g.requires {
+			if rv, ok := r.gates.Load(req); !ok || !rv.(*Gate).IsEnabled() {
+				return fmt.Errorf("feature gate %q requires feature gate %q to be enabled", id, req)
+			}
+		}
+	} else {
+		var errs error
+		r.VisitAll(func(other *Gate) {
+			if !other.IsEnabled() {
+				return
+			}
+			for _, req := range other.requires {
+				if req == id {
+					errs = multierr.Append(errs, fmt.Errorf("feature gate %q is required by the enabled feature gate %q", id, other.id))
+				}
+			}
+		})
+		if errs != nil {
+			return errs
+		}
+	}
+	return r.SetFromSource(id, enabled, SourceRuntime)
+}
+
+// Lock prevents any further change of the enabled value of the Gate identified by the given id.
+// It is meant to be used by distributions to enforce the value of a Gate, once set with SourceDistribution.
+func (r *Registry) Lock(id string) error {
+	v, ok := r.gates.Load(id)
+	if !ok {
+		return fmt.Errorf("no such feature gate %q", id)
+	}
+	v.(*Gate).locked.Store(true)
+	return nil
+}
+
+// RegisterCallback registers a function that is called every time the enabled value of
+// the Gate identified by the given id changes, for example when a gate is toggled at runtime.
+// Callbacks are invoked synchronously by Set, in registration order, so they must not block.
+func (r *Registry) RegisterCallback(id string, fn func(enabled bool)) error {
+	v, ok := r.gates.Load(id)
+	if !ok {
+		return fmt.Errorf("no such feature gate %q", id)
 	}
+	g := v.(*Gate)
+	g.callbacksMu.Lock()
+	defer g.callbacksMu.Unlock()
+	g.callbacks = append(g.callbacks, fn)
 	return nil
 }
 
@@ -144,3 +302,128 @@ func (r *Registry) VisitAll(fn func(*Gate)) {
 		fn(gates[i])
 	}
 }
+
+// Validate checks the combinations of the enabled gates: an error is returned for every enabled Gate
+// requiring a Gate which is not registered or not enabled. It is meant to be called once all the gates
+// are set, e.g. at startup.
+func (r *Registry) Validate() error {
+	var errs error
+	r.VisitAll(func(g *Gate) {
+		if !g.IsEnabled() {
+			return
+		}
+		for _, req := range g.requires {
+			v, ok := r.gates.Load(req)
+			switch {
+			case !ok:
+				errs = multierr.Append(errs, fmt.Errorf("feature gate %q requires unknown feature gate %q", g.id, req))
+			case !v.(*Gate).IsEnabled():
+				errs = multierr.Append(errs, fmt.Errorf("feature gate %q requires feature gate %q to be enabled", g.id, req))
+			}
+		}
+	})
+	return errs
+}
+
+// SetWarningHandler sets the function called when a Stable or Deprecated Gate is explicitly set.
+// The warnings reported while no handler is set, e.g. when the flags are parsed before the logger
+// of the collector is created, are kept and passed to the next handler set.
+func (r *Registry) SetWarningHandler(fn func(Warning)) {
+	r.mu.Lock()
+	r.warningHandler = fn
+	var pending []Warning
+	if fn != nil {
+		pending = r.pendingWarnings
+		r.pendingWarnings = nil
+	}
+	r.mu.Unlock()
+	for _, w := range pending {
+		fn(w)
+	}
+}
+
+func (r *Registry) warn(w Warning) {
+	r.mu.Lock()
+	fn := r.warningHandler
+	if fn == nil {
+		r.addPendingWarning(w)
+	}
+	r.mu.Unlock()
+	if fn != nil {
+		fn(w)
+	}
+}
+
+// addPendingWarning keeps the warning until a handler is set, replacing the previous warning of the same Gate.
+func (r *Registry) addPendingWarning(w Warning) {
+	for i := range r.pendingWarnings {
+		if r.pendingWarnings[i].ID == w.ID && r.pendingWarnings[i].Removed == w.Removed {
+			r.pendingWarnings[i] = w
+			return
+		}
+	}
+	r.pendingWarnings = append(r.pendingWarnings, w)
+}
+
+// SetCurrentVersion sets the Collector release the Registry is used with, e.g. "v0.85.0" or "0.85.0-dev".
+// Once set, Register refuses gates whose "ToVersion" is older than the current version. The gates
+// already registered past their removal version, e.g. when the packages are initialized, are reported
+// as warnings, see SetWarningHandler. Versions that are not in the "[v]MAJOR.MINOR.PATCH" form, such as
+// "latest", disable the check.
+func (r *Registry) SetCurrentVersion(version string) {
+	r.mu.Lock()
+	r.version = version
+	r.mu.Unlock()
+
+	r.VisitAll(func(g *Gate) {
+		if checkRemoved(g, version) != nil {
+			r.warn(Warning{ID: g.id, Stage: g.stage, ToVersion: g.toVersion, Removed: true})
+		}
+	})
+}
+
+// checkRemoved returns an error if the Gate is past its removal version.
+func checkRemoved(g *Gate, version string) error {
+	if g.toVersion == "" {
+		return nil
+	}
+	current, ok := parseVersion(version)
+	if !ok {
+		return nil
+	}
+	removal, ok := parseVersion(g.toVersion)
+	if !ok {
+		return nil
+	}
+	for i := range current {
+		if current[i] != removal[i] {
+			if current[i] > removal[i] {
+				return fmt.Errorf("feature gate %q was removed in version %v, current version is %v", g.id, g.toVersion, version)
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// parseVersion parses a "MAJOR.MINOR.PATCH" version, with or without a leading "v" as in the
+// component.BuildInfo of the collector, ignoring any pre-release or build suffix.
+func parseVersion(v string) ([3]int, bool) {
+	var parsed [3]int
+	v = strings.TrimPrefix(v, "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	parts := strings.Split(v, ".")
+	if len(parts) != 3 {
+		return parsed, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return parsed, false
+		}
+		parsed[i] = n
+	}
+	return parsed, true
+}
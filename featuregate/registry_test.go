@@ -164,3 +164,200 @@ func TestRegisterGateLifecycle(t *testing.T) {
 		})
 	}
 }
+
+func TestRegistryCallback(t *testing.T) {
+	r := NewRegistry()
+	assert.Error(t, r.RegisterCallback("foo", func(bool) {}))
+
+	g := r.MustRegister("foo", StageAlpha)
+	var calls []bool
+	require.NoError(t, r.RegisterCallback(g.ID(), func(enabled bool) {
+		calls = append(calls, enabled)
+	}))
+
+	require.NoError(t, r.Set(g.ID(), true))
+	// Setting the same value again does not trigger callbacks.
+	require.NoError(t, r.Set(g.ID(), true))
+	require.NoError(t, r.Set(g.ID(), false))
+	assert.Equal(t, []bool{true, false}, calls)
+
+	stable := r.MustRegister("stable", StageStable, WithRegisterToVersion("next"))
+	require.NoError(t, r.RegisterCallback(stable.ID(), func(bool) { t.Fail() }))
+	require.NoError(t, r.Set(stable.ID(), true))
+}
+
+func TestRegistryWarningHandler(t *testing.T) {
+	r := NewRegistry()
+	r.MustRegister("stable", StageStable, WithRegisterToVersion("v0.90.0"))
+	r.MustRegister("deprecated", StageDeprecated, WithRegisterToVersion("v0.91.0"))
+	r.MustRegister("alpha", StageAlpha)
+	var warnings []Warning
+	r.SetWarningHandler(func(w Warning) {
+		warnings = append(warnings, w)
+	})
+
+	require.NoError(t, r.Set("stable", true))
+	require.NoError(t, r.Set("deprecated", false))
+	require.NoError(t, r.Set("alpha", true))
+	assert.Equal(t, []Warning{
+		{ID: "stable", Stage: StageStable, ToVersion: "v0.90.0"},
+		{ID: "deprecated", Stage: StageDeprecated, ToVersion: "v0.91.0"},
+	}, warnings)
+	assert.Equal(t, `Feature gate "deprecated" is deprecated and already disabled. It will be removed in version v0.91.0 and continued use of the gate after version v0.91.0 will result in an error.`, warnings[1].String())
+}
+
+func TestRegistryPendingWarnings(t *testing.T) {
+	r := NewRegistry()
+	r.MustRegister("stable", StageStable, WithRegisterToVersion("v0.90.0"))
+	r.MustRegister("deprecated", StageDeprecated, WithRegisterToVersion("v0.91.0"))
+
+	// The warnings reported without handler are passed once to the next handler set.
+	require.NoError(t, r.Set("stable", true))
+	require.NoError(t, r.Set("deprecated", false))
+	require.NoError(t, r.Set("stable", true))
+	var warnings []Warning
+	r.SetWarningHandler(func(w Warning) {
+		warnings = append(warnings, w)
+	})
+	assert.Equal(t, []Warning{
+		{ID: "stable", Stage: StageStable, ToVersion: "v0.90.0"},
+		{ID: "deprecated", Stage: StageDeprecated, ToVersion: "v0.91.0"},
+	}, warnings)
+
+	warnings = nil
+	r.SetWarningHandler(nil)
+	require.NoError(t, r.Set("stable", true))
+	r.SetWarningHandler(func(w Warning) {
+		warnings = append(warnings, w)
+	})
+	assert.Equal(t, []Warning{{ID: "stable", Stage: StageStable, ToVersion: "v0.90.0"}}, warnings)
+}
+
+func TestRegistrySetCurrentVersion(t *testing.T) {
+	r := NewRegistry()
+	r.MustRegister("removed", StageStable, WithRegisterToVersion("v0.84.0"))
+	r.MustRegister("current", StageDeprecated, WithRegisterToVersion("v0.85.0"))
+	r.MustRegister("next", StageStable, WithRegisterToVersion("next"))
+
+	var warnings []Warning
+	r.SetWarningHandler(func(w Warning) {
+		warnings = append(warnings, w)
+	})
+	r.SetCurrentVersion("v0.85.0-dev")
+	assert.Equal(t, []Warning{{ID: "removed", Stage: StageStable, ToVersion: "v0.84.0", Removed: true}}, warnings)
+	assert.Equal(t, `Feature gate "removed" was scheduled for removal in version v0.84.0 and is still registered by this build. It must be removed.`, warnings[0].String())
+
+	_, err := r.Register("old", StageAlpha, WithRegisterToVersion("v0.80.1"))
+	assert.Error(t, err)
+	_, err = r.Register("future", StageAlpha, WithRegisterToVersion("v1.0.0"))
+	assert.NoError(t, err)
+
+	// Versions that can not be parsed disable the check.
+	r.SetCurrentVersion("latest")
+	_, err = r.Register("old", StageAlpha, WithRegisterToVersion("v0.80.1"))
+	assert.NoError(t, err)
+}
+
+func TestRegistrySetCurrentVersionBuildInfo(t *testing.T) {
+	r := NewRegistry()
+	// The version of the component.BuildInfo of the collector has no "v" prefix.
+	r.SetCurrentVersion("0.85.0-dev")
+	_, err := r.Register("old", StageAlpha, WithRegisterToVersion("v0.84.0"))
+	assert.EqualError(t, err, `feature gate "old" was removed in version v0.84.0, current version is 0.85.0-dev`)
+	_, err = r.Register("future", StageAlpha, WithRegisterToVersion("v0.86.0"))
+	assert.NoError(t, err)
+}
+
+func TestRegistrySetFromSource(t *testing.T) {
+	r := NewRegistry()
+	g := r.MustRegister("foo", StageAlpha)
+	stable := r.MustRegister("stable", StageStable, WithRegisterToVersion("next"))
+	assert.Equal(t, SourceDefault, g.Source())
+
+	require.NoError(t, r.SetFromSource(g.ID(), true, SourceConfig))
+	assert.Equal(t, SourceConfig, g.Source())
+	require.NoError(t, NewFlag(r).Set("-foo"))
+	assert.Equal(t, SourceFlag, g.Source())
+	require.NoError(t, r.Set(g.ID(), true))
+	assert.Equal(t, SourceRuntime, g.Source())
+
+	// The value of stable gates can not change, so neither does its source.
+	require.NoError(t, r.SetFromSource(stable.ID(), true, SourceConfig))
+	assert.Equal(t, SourceDefault, stable.Source())
+}
+
+func TestRegistryLock(t *testing.T) {
+	r := NewRegistry()
+	g := r.MustRegister("foo", StageAlpha)
+	assert.False(t, g.IsLocked())
+
+	require.NoError(t, r.SetFromSource(g.ID(), true, SourceDistribution))
+	require.NoError(t, r.Lock(g.ID()))
+	assert.True(t, g.IsLocked())
+	assert.True(t, g.IsEnabled())
+	assert.Equal(t, SourceDistribution, g.Source())
+	assert.Equal(t, "distribution", g.Source().String())
+
+	assert.EqualError(t, r.Set(g.ID(), false), `feature gate "foo" is locked by the distribution, can not be modified`)
+	assert.Error(t, NewFlag(r).Set("-foo"))
+	assert.True(t, g.IsEnabled())
+	assert.Equal(t, SourceDistribution, g.Source())
+
+	assert.EqualError(t, r.Lock("bar"), `no such feature gate "bar"`)
+}
+
+func TestRegistryValidateRequires(t *testing.T) {
+	r := NewRegistry()
+	base := r.MustRegister("base", StageAlpha)
+	sub := r.MustRegister("sub", StageAlpha, WithRegisterRequires("base"))
+	r.MustRegister("orphan", StageBeta, WithRegisterRequires("missing"))
+	assert.Equal(t, []string{"base"}, sub.Requires())
+	assert.Empty(t, base.Requires())
+
+	_, err := r.Register("self", StageAlpha, WithRegisterRequires("self"))
+	assert.EqualError(t, err, `feature gate "self" can not require itself`)
+
+	assert.EqualError(t, r.Validate(), `feature gate "orphan" requires unknown feature gate "missing"`)
+	require.NoError(t, r.Set("orphan", false))
+	require.NoError(t, r.Validate())
+
+	require.NoError(t, r.Set("sub", true))
+	assert.EqualError(t, r.Validate(), `feature gate "sub" requires feature gate "base" to be enabled`)
+	require.NoError(t, r.Set("base", true))
+	assert.NoError(t, r.Validate())
+}
+
+func TestRegistrySetAtRuntime(t *testing.T) {
+	r := NewRegistry()
+	assert.Error(t, r.SetAtRuntime("unknown", true))
+
+	static := r.MustRegister("static", StageAlpha)
+	assert.EqualError(t, r.SetAtRuntime(static.ID(), true), `feature gate "static" can not be toggled at runtime`)
+	assert.False(t, static.IsEnabled())
+
+	stable := r.MustRegister("stable", StageStable, WithRegisterToVersion("v0.100.0"), WithRegisterRuntimeToggle())
+	assert.EqualError(t, r.SetAtRuntime(stable.ID(), true), `feature gate "stable" is stable, can not be toggled at runtime`)
+
+	base := r.MustRegister("base", StageAlpha, WithRegisterRuntimeToggle())
+	sub := r.MustRegister("sub", StageAlpha, WithRegisterRuntimeToggle(), WithRegisterRequires(base.ID()))
+	var calls []bool
+	require.NoError(t, r.RegisterCallback(sub.ID(), func(enabled bool) {
+		calls = append(calls, enabled)
+	}))
+
+	// A gate can only be enabled once the gates it requires are enabled.
+	assert.EqualError(t, r.SetAtRuntime(sub.ID(), true), `feature gate "sub" requires feature gate "base" to be enabled`)
+	require.NoError(t, r.SetAtRuntime(base.ID(), true))
+	require.NoError(t, r.SetAtRuntime(sub.ID(), true))
+	assert.True(t, sub.IsEnabled())
+	assert.Equal(t, SourceRuntime, sub.Source())
+
+	// A gate can only be disabled once the gates requiring it are disabled.
+	assert.EqualError(t, r.SetAtRuntime(base.ID(), false), `feature gate "base" is required by the enabled feature gate "sub"`)
+	require.NoError(t, r.SetAtRuntime(sub.ID(), false))
+	require.NoError(t, r.SetAtRuntime(base.ID(), false))
+	assert.Equal(t, []bool{true, false}, calls)
+
+	require.NoError(t, r.Lock(base.ID()))
+	assert.Error(t, r.SetAtRuntime(base.ID(), true))
+}
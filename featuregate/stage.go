@@ -42,3 +42,36 @@ func (s Stage) String() string {
 	}
 	return "Unknown"
 }
+
+// Source represents where the enabled value of a Gate comes from.
+type Source int8
+
+const (
+	// SourceDefault is used when the Gate has the default value of its Stage.
+	SourceDefault Source = iota
+	// SourceFlag is used when the Gate was set with the --feature-gates command line flag.
+	SourceFlag
+	// SourceConfig is used when the Gate was set in the collector configuration.
+	SourceConfig
+	// SourceRuntime is used when the Gate was set programmatically, for example through the control API.
+	SourceRuntime
+	// SourceDistribution is used when the Gate was set by the distribution, for example with the
+	// feature_gates section of the builder configuration.
+	SourceDistribution
+)
+
+func (s Source) String() string {
+	switch s {
+	case SourceDefault:
+		return "default"
+	case SourceFlag:
+		return "flag"
+	case SourceConfig:
+		return "config"
+	case SourceRuntime:
+		return "runtime"
+	case SourceDistribution:
+		return "distribution"
+	}
+	return "unknown"
+}
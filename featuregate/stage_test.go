@@ -16,3 +16,11 @@ func TestStageString(t *testing.T) {
 	assert.Equal(t, "Deprecated", StageDeprecated.String())
 	assert.Equal(t, "Unknown", Stage(-1).String())
 }
+
+func TestSourceString(t *testing.T) {
+	assert.Equal(t, "default", SourceDefault.String())
+	assert.Equal(t, "flag", SourceFlag.String())
+	assert.Equal(t, "config", SourceConfig.String())
+	assert.Equal(t, "runtime", SourceRuntime.String())
+	assert.Equal(t, "unknown", Source(-1).String())
+}
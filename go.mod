@@ -41,6 +41,7 @@ require (
 	github.com/cenkalti/backoff/v4 v4.2.1 // indirect
 	github.com/cespare/xxhash/v2 v2.2.0 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/fsnotify/fsnotify v1.6.0 // indirect
 	github.com/go-kit/log v0.2.1 // indirect
 	github.com/go-logfmt/logfmt v0.5.1 // indirect
 	github.com/go-logr/logr v1.2.4 // indirect
@@ -72,6 +73,10 @@ require (
 	github.com/tklauser/go-sysconf v0.3.12 // indirect
 	github.com/tklauser/numcpus v0.6.1 // indirect
 	github.com/yusufpapurcu/wmi v1.2.3 // indirect
+	go.opentelemetry.io/collector/config/configauth v0.85.0 // indirect
+	go.opentelemetry.io/collector/config/configopaque v0.85.0 // indirect
+	go.opentelemetry.io/collector/config/configtls v0.85.0 // indirect
+	go.opentelemetry.io/collector/extension/auth v0.85.0 // indirect
 	go.opentelemetry.io/collector/semconv v0.85.0 // indirect
 	go.opentelemetry.io/contrib/propagators/b3 v1.19.0 // indirect
 	go.opentelemetry.io/otel/bridge/opencensus v0.40.0 // indirect
@@ -91,6 +96,7 @@ require (
 	google.golang.org/genproto/googleapis/api v0.0.0-20230711160842-782d3b101e98 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20230711160842-782d3b101e98 // indirect
 	google.golang.org/protobuf v1.31.0 // indirect
+	gopkg.in/natefinch/lumberjack.v2 v2.2.1 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
 )
 
@@ -98,10 +104,16 @@ replace go.opentelemetry.io/collector/component => ./component
 
 replace go.opentelemetry.io/collector/confmap => ./confmap
 
+replace go.opentelemetry.io/collector/config/configauth => ./config/configauth
+
 replace go.opentelemetry.io/collector/config/confignet => ./config/confignet
 
+replace go.opentelemetry.io/collector/config/configopaque => ./config/configopaque
+
 replace go.opentelemetry.io/collector/config/configtelemetry => ./config/configtelemetry
 
+replace go.opentelemetry.io/collector/config/configtls => ./config/configtls
+
 replace go.opentelemetry.io/collector/connector => ./connector
 
 replace go.opentelemetry.io/collector/consumer => ./consumer
@@ -110,6 +122,8 @@ replace go.opentelemetry.io/collector/exporter => ./exporter
 
 replace go.opentelemetry.io/collector/extension => ./extension
 
+replace go.opentelemetry.io/collector/extension/auth => ./extension/auth
+
 replace go.opentelemetry.io/collector/featuregate => ./featuregate
 
 replace go.opentelemetry.io/collector/semconv => ./semconv
@@ -0,0 +1,23 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package inflight // import "go.opentelemetry.io/collector/inflight"
+
+import (
+	"go.opentelemetry.io/collector/component"
+)
+
+// Host is implemented by the component.Host accounting for the data held in flight.
+type Host interface {
+	// GetInFlightTracker returns the Tracker of the host, nil if the accounting is disabled.
+	GetInFlightTracker() *Tracker
+}
+
+// FromHost returns the Tracker of the host, nil if the host doesn't account for the data
+// held in flight or if the accounting is disabled.
+func FromHost(host component.Host) *Tracker {
+	if h, ok := host.(Host); ok {
+		return h.GetInFlightTracker()
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package inflight accounts for the bytes of pdata held in flight by the components of the
+// collector, e.g. by the batchers and the sending queues, across all the pipelines. The
+// accounting is owned by the service, exposed to the components by the host, and used to
+// report the data held by the collector and to refuse data above a global limit.
+package inflight // import "go.opentelemetry.io/collector/inflight"
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Config defines the configuration of the in-flight data accounting of the service.
+type Config struct {
+	// Enabled enables the accounting of the data held by the components.
+	Enabled bool `mapstructure:"enabled"`
+	// LimitMiB is the maximum amount of data, in MiB, held by the components before the
+	// data is refused by the components enforcing it, e.g. the memory_limiter processor.
+	// 0 means no limit.
+	LimitMiB uint64 `mapstructure:"limit_mib"`
+}
+
+// Validate checks if the Config is valid.
+func (cfg *Config) Validate() error {
+	if !cfg.Enabled && cfg.LimitMiB != 0 {
+		return errors.New("limit_mib requires the in-flight accounting to be enabled")
+	}
+	return nil
+}
+
+// Tracker accounts for the bytes held by the components. A nil *Tracker is valid and
+// does not account for anything, allowing the components to use it unconditionally.
+type Tracker struct {
+	limit int64
+	bytes atomic.Int64
+
+	mu         sync.Mutex
+	components map[componentKey]*Component
+	observers  []func(*Component)
+}
+
+type componentKey struct {
+	kind component.Kind
+	id   component.ID
+}
+
+// NewTracker returns a Tracker with the given limit in bytes, 0 meaning no limit.
+func NewTracker(limit int64) *Tracker {
+	return &Tracker{limit: limit, components: map[componentKey]*Component{}}
+}
+
+// Component returns the Component accounting for the bytes held by the component with the
+// given kind and ID, created on the first call. Returns nil if the Tracker is nil.
+func (t *Tracker) Component(kind component.Kind, id component.ID) *Component {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	key := componentKey{kind: kind, id: id}
+	if c, ok := t.components[key]; ok {
+		return c
+	}
+	c := &Component{tracker: t, kind: kind, id: id}
+	t.components[key] = c
+	for _, observer := range t.observers {
+		observer(c)
+	}
+	return c
+}
+
+// Components returns the components accounted by the Tracker, sorted by kind and ID.
+func (t *Tracker) Components() []*Component {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.sortedComponents()
+}
+
+// ObserveComponents calls the function for every component already accounted by the Tracker,
+// and for every component accounted later. It is used to report the bytes of every component.
+func (t *Tracker) ObserveComponents(observer func(*Component)) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, c := range t.sortedComponents() {
+		observer(c)
+	}
+	t.observers = append(t.observers, observer)
+}
+
+func (t *Tracker) sortedComponents() []*Component {
+	components := make([]*Component, 0, len(t.components))
+	for _, c := range t.components {
+		components = append(components, c)
+	}
+	sort.Slice(components, func(i, j int) bool {
+		if components[i].kind != components[j].kind {
+			return components[i].kind < components[j].kind
+		}
+		return components[i].id.String() < components[j].id.String()
+	})
+	return components
+}
+
+// Bytes returns the bytes held by all the components.
+func (t *Tracker) Bytes() int64 {
+	if t == nil {
+		return 0
+	}
+	return t.bytes.Load()
+}
+
+// Limit returns the limit in bytes, 0 meaning no limit.
+func (t *Tracker) Limit() int64 {
+	if t == nil {
+		return 0
+	}
+	return t.limit
+}
+
+// AboveLimit returns whether the bytes held by all the components exceed the limit, meaning
+// that new data should be refused.
+func (t *Tracker) AboveLimit() bool {
+	return t != nil && t.limit > 0 && t.bytes.Load() > t.limit
+}
+
+// Component accounts for the bytes held by a component. A nil *Component is valid and does
+// not account for anything.
+type Component struct {
+	tracker *Tracker
+	kind    component.Kind
+	id      component.ID
+	bytes   atomic.Int64
+}
+
+// Kind returns the kind of the component.
+func (c *Component) Kind() component.Kind {
+	return c.kind
+}
+
+// ID returns the ID of the component.
+func (c *Component) ID() component.ID {
+	return c.id
+}
+
+// Add adds the bytes to the bytes held by the component, negative bytes releasing them.
+func (c *Component) Add(bytes int64) {
+	if c == nil || bytes == 0 {
+		return
+	}
+	c.bytes.Add(bytes)
+	c.tracker.bytes.Add(bytes)
+}
+
+// Acquire adds the bytes to the bytes held by the component, and returns a function releasing
+// them, to call once the data is released. Calling the function more than once has no effect.
+func (c *Component) Acquire(bytes int64) (release func()) {
+	if c == nil || bytes == 0 {
+		return func() {}
+	}
+	c.Add(bytes)
+	var once sync.Once
+	return func() {
+		once.Do(func() { c.Add(-bytes) })
+	}
+}
+
+// Bytes returns the bytes held by the component.
+func (c *Component) Bytes() int64 {
+	if c == nil {
+		return 0
+	}
+	return c.bytes.Load()
+}
@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package inflight
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func TestConfigValidate(t *testing.T) {
+	assert.NoError(t, (&Config{}).Validate())
+	assert.NoError(t, (&Config{Enabled: true, LimitMiB: 100}).Validate())
+	assert.EqualError(t, (&Config{LimitMiB: 100}).Validate(), "limit_mib requires the in-flight accounting to be enabled")
+}
+
+func TestTracker(t *testing.T) {
+	tracker := NewTracker(100)
+	assert.Equal(t, int64(100), tracker.Limit())
+
+	exporter := tracker.Component(component.KindExporter, component.NewID("otlp"))
+	assert.Same(t, exporter, tracker.Component(component.KindExporter, component.NewID("otlp")))
+	batch := tracker.Component(component.KindProcessor, component.NewID("batch"))
+	assert.Equal(t, component.KindProcessor, batch.Kind())
+	assert.Equal(t, component.NewID("batch"), batch.ID())
+
+	release := exporter.Acquire(60)
+	batch.Add(30)
+	assert.Equal(t, int64(60), exporter.Bytes())
+	assert.Equal(t, int64(90), tracker.Bytes())
+	assert.False(t, tracker.AboveLimit())
+
+	batch.Add(20)
+	assert.True(t, tracker.AboveLimit())
+
+	release()
+	release()
+	assert.Equal(t, int64(0), exporter.Bytes())
+	assert.Equal(t, int64(50), tracker.Bytes())
+	assert.False(t, tracker.AboveLimit())
+
+	batch.Add(-50)
+	assert.Equal(t, int64(0), tracker.Bytes())
+
+	assert.Equal(t, []*Component{batch, exporter}, tracker.Components())
+}
+
+func TestTrackerNoLimit(t *testing.T) {
+	tracker := NewTracker(0)
+	tracker.Component(component.KindReceiver, component.NewID("otlp")).Add(1 << 40)
+	assert.False(t, tracker.AboveLimit())
+}
+
+func TestTrackerObserveComponents(t *testing.T) {
+	tracker := NewTracker(0)
+	first := tracker.Component(component.KindExporter, component.NewID("otlp"))
+
+	var observed []*Component
+	tracker.ObserveComponents(func(c *Component) {
+		observed = append(observed, c)
+	})
+	second := tracker.Component(component.KindProcessor, component.NewID("batch"))
+	tracker.Component(component.KindExporter, component.NewID("otlp"))
+	assert.Equal(t, []*Component{first, second}, observed)
+}
+
+func TestNilTracker(t *testing.T) {
+	var tracker *Tracker
+	c := tracker.Component(component.KindExporter, component.NewID("otlp"))
+	assert.Nil(t, c)
+	c.Add(10)
+	c.Acquire(10)()
+	assert.Zero(t, c.Bytes())
+	assert.Zero(t, tracker.Bytes())
+	assert.Zero(t, tracker.Limit())
+	assert.False(t, tracker.AboveLimit())
+	assert.Nil(t, tracker.Components())
+	tracker.ObserveComponents(func(*Component) { t.Fail() })
+}
+
+type trackerHost struct {
+	component.Host
+	tracker *Tracker
+}
+
+func (h trackerHost) GetInFlightTracker() *Tracker {
+	return h.tracker
+}
+
+func TestFromHost(t *testing.T) {
+	assert.Nil(t, FromHost(componenttest.NewNopHost()))
+	tracker := NewTracker(0)
+	assert.Same(t, tracker, FromHost(trackerHost{Host: componenttest.NewNopHost(), tracker: tracker}))
+}
@@ -31,6 +31,7 @@ import (
 	"bufio"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -150,10 +151,38 @@ func isCGroupV2(procPathMountInfo string) (bool, error) {
 }
 
 // MemoryQuotaV2 returns the total memory limit of the process
-// It is a result of cgroupv2 `memory.max`. If the value of
-// `memory.max` was not set (max), the method returns `(-1, false, nil)`.
+// It is a result of cgroupv2 `memory.max`, the lowest one of the cgroup of the process
+// and of its ancestors. If the value of `memory.max` was not set (max) for any of them,
+// the method returns `(-1, false, nil)`.
 func MemoryQuotaV2() (int64, bool, error) {
-	return memoryQuotaV2(_cgroupv2MountPoint, _cgroupv2MemoryMax)
+	return memoryQuotaV2ForProcess(_cgroupv2MountPoint, _procPathCGroup)
+}
+
+// memoryQuotaV2ForProcess returns the lowest `memory.max` of the cgroup of the process, read from
+// procPathCGroup, and of its ancestors up to the cgroupv2 mount point. The cgroup of a process running
+// in a cgroup namespace, e.g. in a container, is the root of the mount point.
+func memoryQuotaV2ForProcess(cgroupv2MountPoint, procPathCGroup string) (int64, bool, error) {
+	dirs := []string{cgroupv2MountPoint}
+	// If the cgroup of the process cannot be read, only the limit of the mount point is used.
+	if subsystems, err := parseCGroupSubsystems(procPathCGroup); err == nil {
+		if cgroup, exists := subsystems[""]; exists {
+			for p := path.Clean(cgroup.Name); p != "/" && p != "."; p = path.Dir(p) {
+				dirs = append(dirs, filepath.Join(cgroupv2MountPoint, p))
+			}
+		}
+	}
+
+	quota, defined := int64(-1), false
+	for _, dir := range dirs {
+		max, maxDefined, err := memoryQuotaV2(dir, _cgroupv2MemoryMax)
+		if err != nil {
+			return -1, false, err
+		}
+		if maxDefined && (!defined || max < quota) {
+			quota, defined = max, true
+		}
+	}
+	return quota, defined, nil
 }
 
 func memoryQuotaV2(cgroupv2MountPoint, cgroupv2MemoryMax string) (int64, bool, error) {
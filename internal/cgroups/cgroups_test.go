@@ -226,3 +226,52 @@ func TestCGroupsMemoryQuotaV2(t *testing.T) {
 		}
 	}
 }
+
+func TestCGroupsMemoryQuotaV2ForProcess(t *testing.T) {
+	testTable := []struct {
+		name            string
+		cgroupPath      string
+		procPathCGroup  string
+		expectedQuota   int64
+		expectedDefined bool
+	}{
+		{
+			name:            "nested",
+			cgroupPath:      filepath.Join(testDataCGroupsPath, "v2", "nested"),
+			procPathCGroup:  filepath.Join(testDataProcPath, "v2", "nested", "cgroup"),
+			expectedQuota:   int64(400000000),
+			expectedDefined: true,
+		},
+		{
+			name:            "root",
+			cgroupPath:      filepath.Join(testDataCGroupsPath, "v2", "memory"),
+			procPathCGroup:  filepath.Join(testDataProcPath, "v2", "cgroupv2", "cgroup"),
+			expectedQuota:   int64(250000000),
+			expectedDefined: true,
+		},
+		{
+			name:            "nonexistent proc cgroup",
+			cgroupPath:      filepath.Join(testDataCGroupsPath, "v2", "memory"),
+			procPathCGroup:  "nonexistent",
+			expectedQuota:   int64(250000000),
+			expectedDefined: true,
+		},
+		{
+			name:            "undefined",
+			cgroupPath:      filepath.Join(testDataCGroupsPath, "v2", "undefined"),
+			procPathCGroup:  filepath.Join(testDataProcPath, "v2", "nested", "cgroup"),
+			expectedQuota:   int64(-1),
+			expectedDefined: false,
+		},
+	}
+
+	for _, tt := range testTable {
+		quota, defined, err := memoryQuotaV2ForProcess(tt.cgroupPath, tt.procPathCGroup)
+		assert.Equal(t, tt.expectedQuota, quota, tt.name)
+		assert.Equal(t, tt.expectedDefined, defined, tt.name)
+		assert.NoError(t, err, tt.name)
+	}
+
+	_, _, err := memoryQuotaV2ForProcess(filepath.Join(testDataCGroupsPath, "v2", "invalid"), "nonexistent")
+	assert.Error(t, err)
+}
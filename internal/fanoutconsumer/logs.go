@@ -14,6 +14,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/pdata/plog"
 )
 
@@ -56,21 +57,38 @@ func (lsc *logsConsumer) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: false}
 }
 
+// Backpressure returns whether any of the consumers wrapped by the current one reports backpressure,
+// as the data is passed to each of them in turn.
+func (lsc *logsConsumer) Backpressure() bool {
+	for _, lc := range lsc.clone {
+		if consumer.UnderBackpressure(lc) {
+			return true
+		}
+	}
+	for _, lc := range lsc.pass {
+		if consumer.UnderBackpressure(lc) {
+			return true
+		}
+	}
+	return false
+}
+
 // ConsumeLogs exports the plog.Logs to all consumers wrapped by the current one.
+// The errors of the consumers are combined with consumererror.CombinePartialSuccess.
 func (lsc *logsConsumer) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
-	var errs error
+	var errs []error
 	// Initially pass to clone exporter to avoid the case where the optimization of sending
 	// the incoming data to a mutating consumer is used that may change the incoming data before
 	// cloning.
 	for _, lc := range lsc.clone {
 		clonedLogs := plog.NewLogs()
 		ld.CopyTo(clonedLogs)
-		errs = multierr.Append(errs, lc.ConsumeLogs(ctx, clonedLogs))
+		errs = append(errs, lc.ConsumeLogs(ctx, clonedLogs))
 	}
 	for _, lc := range lsc.pass {
-		errs = multierr.Append(errs, lc.ConsumeLogs(ctx, ld))
+		errs = append(errs, lc.ConsumeLogs(ctx, ld))
 	}
-	return errs
+	return consumererror.CombinePartialSuccess(errs...)
 }
 
 var _ connector.LogsRouter = (*logsRouter)(nil)
@@ -91,6 +109,11 @@ func NewLogsRouter(cm map[component.ID]consumer.Logs) consumer.Logs {
 	}
 }
 
+// Backpressure returns whether any of the consumers of the pipelines reports backpressure.
+func (r *logsRouter) Backpressure() bool {
+	return consumer.UnderBackpressure(r.Logs)
+}
+
 func (r *logsRouter) PipelineIDs() []component.ID {
 	ids := make([]component.ID, 0, len(r.consumers))
 	for id := range r.consumers {
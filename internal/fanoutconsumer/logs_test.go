@@ -11,10 +11,12 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -180,6 +182,25 @@ func TestLogsWhenErrors(t *testing.T) {
 	assert.EqualValues(t, ld, p3.AllLogs()[1])
 }
 
+func TestLogsPartialSuccess(t *testing.T) {
+	p1 := consumertest.NewErr(consumererror.NewPartialSuccess(errors.New("first"), 1))
+	p2 := consumertest.NewErr(consumererror.NewPartialSuccess(errors.New("second"), 2))
+	p3 := new(consumertest.LogsSink)
+	fc := NewLogs([]consumer.Logs{p1, p2, p3})
+
+	// The data is partially rejected if the consumers only reject a part of it.
+	err := fc.ConsumeLogs(context.Background(), testdata.GenerateLogs(3))
+	p, ok := consumererror.AsPartialSuccess(err)
+	require.True(t, ok)
+	assert.Equal(t, 2, p.Rejected())
+
+	fc = NewLogs([]consumer.Logs{p1, consumertest.NewErr(errors.New("my error")), p3})
+	err = fc.ConsumeLogs(context.Background(), testdata.GenerateLogs(3))
+	assert.Error(t, err)
+	_, ok = consumererror.AsPartialSuccess(err)
+	assert.False(t, ok)
+}
+
 type mutatingLogsSink struct {
 	*consumertest.LogsSink
 }
@@ -324,3 +345,25 @@ func TestLogsRouterGetConsumers(t *testing.T) {
 	assert.Nil(t, fake)
 	assert.Error(t, err)
 }
+
+func TestLogsBackpressure(t *testing.T) {
+	congested := false
+	p1, err := consumer.NewLogs(func(context.Context, plog.Logs) error { return nil },
+		consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}),
+		consumer.WithBackpressure(func() bool { return congested }))
+	assert.NoError(t, err)
+	p2 := new(consumertest.LogsSink)
+
+	fc := NewLogs([]consumer.Logs{p1, p2})
+	assert.False(t, consumer.UnderBackpressure(fc))
+	congested = true
+	assert.True(t, consumer.UnderBackpressure(fc))
+
+	router := NewLogsRouter(map[component.ID]consumer.Logs{
+		component.NewIDWithName("logs", "0"): p1,
+		component.NewIDWithName("logs", "1"): p2,
+	})
+	assert.True(t, consumer.UnderBackpressure(router))
+	congested = false
+	assert.False(t, consumer.UnderBackpressure(router))
+}
@@ -12,6 +12,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 )
 
@@ -54,21 +55,38 @@ func (msc *metricsConsumer) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: false}
 }
 
+// Backpressure returns whether any of the consumers wrapped by the current one reports backpressure,
+// as the data is passed to each of them in turn.
+func (msc *metricsConsumer) Backpressure() bool {
+	for _, mc := range msc.clone {
+		if consumer.UnderBackpressure(mc) {
+			return true
+		}
+	}
+	for _, mc := range msc.pass {
+		if consumer.UnderBackpressure(mc) {
+			return true
+		}
+	}
+	return false
+}
+
 // ConsumeMetrics exports the pmetric.Metrics to all consumers wrapped by the current one.
+// The errors of the consumers are combined with consumererror.CombinePartialSuccess.
 func (msc *metricsConsumer) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
-	var errs error
+	var errs []error
 	// Initially pass to clone exporter to avoid the case where the optimization of sending
 	// the incoming data to a mutating consumer is used that may change the incoming data before
 	// cloning.
 	for _, mc := range msc.clone {
 		clonedMetrics := pmetric.NewMetrics()
 		md.CopyTo(clonedMetrics)
-		errs = multierr.Append(errs, mc.ConsumeMetrics(ctx, clonedMetrics))
+		errs = append(errs, mc.ConsumeMetrics(ctx, clonedMetrics))
 	}
 	for _, mc := range msc.pass {
-		errs = multierr.Append(errs, mc.ConsumeMetrics(ctx, md))
+		errs = append(errs, mc.ConsumeMetrics(ctx, md))
 	}
-	return errs
+	return consumererror.CombinePartialSuccess(errs...)
 }
 
 var _ connector.MetricsRouter = (*metricsRouter)(nil)
@@ -89,6 +107,11 @@ func NewMetricsRouter(cm map[component.ID]consumer.Metrics) consumer.Metrics {
 	}
 }
 
+// Backpressure returns whether any of the consumers of the pipelines reports backpressure.
+func (r *metricsRouter) Backpressure() bool {
+	return consumer.UnderBackpressure(r.Metrics)
+}
+
 func (r *metricsRouter) PipelineIDs() []component.ID {
 	ids := make([]component.ID, 0, len(r.consumers))
 	for id := range r.consumers {
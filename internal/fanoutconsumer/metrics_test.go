@@ -11,10 +11,12 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -180,6 +182,25 @@ func TestMetricsWhenErrors(t *testing.T) {
 	assert.EqualValues(t, md, p3.AllMetrics()[1])
 }
 
+func TestMetricsPartialSuccess(t *testing.T) {
+	p1 := consumertest.NewErr(consumererror.NewPartialSuccess(errors.New("first"), 1))
+	p2 := consumertest.NewErr(consumererror.NewPartialSuccess(errors.New("second"), 2))
+	p3 := new(consumertest.MetricsSink)
+	fc := NewMetrics([]consumer.Metrics{p1, p2, p3})
+
+	// The data is partially rejected if the consumers only reject a part of it.
+	err := fc.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(3))
+	p, ok := consumererror.AsPartialSuccess(err)
+	require.True(t, ok)
+	assert.Equal(t, 2, p.Rejected())
+
+	fc = NewMetrics([]consumer.Metrics{p1, consumertest.NewErr(errors.New("my error")), p3})
+	err = fc.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(3))
+	assert.Error(t, err)
+	_, ok = consumererror.AsPartialSuccess(err)
+	assert.False(t, ok)
+}
+
 type mutatingMetricsSink struct {
 	*consumertest.MetricsSink
 }
@@ -316,3 +337,25 @@ func TestMetricsRouterGetConsumers(t *testing.T) {
 	assert.Nil(t, fake)
 	assert.Error(t, err)
 }
+
+func TestMetricsBackpressure(t *testing.T) {
+	congested := false
+	p1, err := consumer.NewMetrics(func(context.Context, pmetric.Metrics) error { return nil },
+		consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}),
+		consumer.WithBackpressure(func() bool { return congested }))
+	assert.NoError(t, err)
+	p2 := new(consumertest.MetricsSink)
+
+	fc := NewMetrics([]consumer.Metrics{p1, p2})
+	assert.False(t, consumer.UnderBackpressure(fc))
+	congested = true
+	assert.True(t, consumer.UnderBackpressure(fc))
+
+	router := NewMetricsRouter(map[component.ID]consumer.Metrics{
+		component.NewIDWithName("metrics", "0"): p1,
+		component.NewIDWithName("metrics", "1"): p2,
+	})
+	assert.True(t, consumer.UnderBackpressure(router))
+	congested = false
+	assert.False(t, consumer.UnderBackpressure(router))
+}
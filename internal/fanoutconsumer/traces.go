@@ -12,6 +12,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
@@ -54,21 +55,38 @@ func (tsc *tracesConsumer) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: false}
 }
 
+// Backpressure returns whether any of the consumers wrapped by the current one reports backpressure,
+// as the data is passed to each of them in turn.
+func (tsc *tracesConsumer) Backpressure() bool {
+	for _, tc := range tsc.clone {
+		if consumer.UnderBackpressure(tc) {
+			return true
+		}
+	}
+	for _, tc := range tsc.pass {
+		if consumer.UnderBackpressure(tc) {
+			return true
+		}
+	}
+	return false
+}
+
 // ConsumeTraces exports the ptrace.Traces to all consumers wrapped by the current one.
+// The errors of the consumers are combined with consumererror.CombinePartialSuccess.
 func (tsc *tracesConsumer) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
-	var errs error
+	var errs []error
 	// Initially pass to clone exporter to avoid the case where the optimization of sending
 	// the incoming data to a mutating consumer is used that may change the incoming data before
 	// cloning.
 	for _, tc := range tsc.clone {
 		clonedTraces := ptrace.NewTraces()
 		td.CopyTo(clonedTraces)
-		errs = multierr.Append(errs, tc.ConsumeTraces(ctx, clonedTraces))
+		errs = append(errs, tc.ConsumeTraces(ctx, clonedTraces))
 	}
 	for _, tc := range tsc.pass {
-		errs = multierr.Append(errs, tc.ConsumeTraces(ctx, td))
+		errs = append(errs, tc.ConsumeTraces(ctx, td))
 	}
-	return errs
+	return consumererror.CombinePartialSuccess(errs...)
 }
 
 var _ connector.TracesRouter = (*tracesRouter)(nil)
@@ -89,6 +107,11 @@ func NewTracesRouter(cm map[component.ID]consumer.Traces) consumer.Traces {
 	}
 }
 
+// Backpressure returns whether any of the consumers of the pipelines reports backpressure.
+func (r *tracesRouter) Backpressure() bool {
+	return consumer.UnderBackpressure(r.Traces)
+}
+
 func (r *tracesRouter) PipelineIDs() []component.ID {
 	ids := make([]component.ID, 0, len(r.consumers))
 	for id := range r.consumers {
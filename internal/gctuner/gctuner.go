@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package gctuner adjusts the memory limit and GOGC of the Go runtime for the components reclaiming
+// memory without forcing garbage collections. These settings are global to the process, so a single
+// Tuner owns them at a time.
+package gctuner // import "go.opentelemetry.io/collector/internal/gctuner"
+
+import (
+	"errors"
+	"os"
+	"runtime/debug"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+const mibBytes = 1024 * 1024
+
+// ErrOwned is returned by Tuner.Start when the settings of the Go runtime are owned by another Tuner.
+var ErrOwned = errors.New("the memory limit and GOGC of the Go runtime are already tuned by another component")
+
+var (
+	ownerMu sync.Mutex
+	owner   *Tuner
+)
+
+// Tuner sets the memory limit of the Go runtime, lowers GOGC under memory pressure, and restores
+// the previous settings once stopped.
+type Tuner struct {
+	logger *zap.Logger
+
+	// LookupEnv, SetMemoryLimit and SetGCPercent read and change the Go runtime settings, replaced in tests.
+	LookupEnv      func(string) (string, bool)
+	SetMemoryLimit func(int64) int64
+	SetGCPercent   func(int) int
+
+	mu sync.Mutex
+	// prevMemoryLimit is the memory limit to restore on stop, if limitSet.
+	prevMemoryLimit int64
+	limitSet        bool
+	// gcPercent is the GOGC to restore once the memory pressure is gone, if gcLowered.
+	gcPercent int
+	gcLowered bool
+	started   bool
+}
+
+// New returns a Tuner changing the settings of the Go runtime.
+func New(logger *zap.Logger) *Tuner {
+	return &Tuner{
+		logger:         logger,
+		LookupEnv:      os.LookupEnv,
+		SetMemoryLimit: debug.SetMemoryLimit,
+		SetGCPercent:   debug.SetGCPercent,
+	}
+}
+
+// Start takes the ownership of the settings of the Go runtime and sets its memory limit, unless
+// it is set by the GOMEMLIMIT environment variable. It returns the memory limit of the Go runtime,
+// or ErrOwned if another Tuner owns the settings.
+func (t *Tuner) Start(memoryLimit uint64) (uint64, error) {
+	ownerMu.Lock()
+	defer ownerMu.Unlock()
+	if owner != nil && owner != t {
+		return 0, ErrOwned
+	}
+	owner = t
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.started = true
+	if _, ok := t.LookupEnv("GOMEMLIMIT"); ok {
+		// the limit set explicitly for the process takes precedence, a negative value reads it
+		memoryLimit = uint64(t.SetMemoryLimit(-1))
+		t.logger.Info("GOMEMLIMIT is set, the memory limit of the Go runtime is left unchanged",
+			zap.Uint64("limit_mib", memoryLimit/mibBytes))
+		return memoryLimit, nil
+	}
+	if !t.limitSet {
+		t.prevMemoryLimit = t.SetMemoryLimit(int64(memoryLimit))
+		t.limitSet = true
+	} else {
+		t.SetMemoryLimit(int64(memoryLimit))
+	}
+	t.logger.Info("Setting the memory limit of the Go runtime", zap.Uint64("limit_mib", memoryLimit/mibBytes))
+	return memoryLimit, nil
+}
+
+// SetPressure lowers GOGC to minGOGC while under memory pressure, and restores it afterwards.
+func (t *Tuner) SetPressure(underPressure bool, minGOGC int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.started {
+		return
+	}
+	switch {
+	case underPressure && !t.gcLowered:
+		prev := t.SetGCPercent(minGOGC)
+		if prev >= 0 && prev <= minGOGC {
+			// GOGC is already low enough
+			t.SetGCPercent(prev)
+			return
+		}
+		t.gcPercent = prev
+		t.gcLowered = true
+		t.logger.Info("Memory usage under pressure, lowering GOGC", zap.Int("gogc", minGOGC))
+	case !underPressure && t.gcLowered:
+		t.SetGCPercent(t.gcPercent)
+		t.gcLowered = false
+		t.logger.Info("Memory usage back below the pressure threshold, restoring GOGC", zap.Int("gogc", t.gcPercent))
+	}
+}
+
+// Stop restores the settings of the Go runtime changed by the Tuner, and releases their ownership.
+func (t *Tuner) Stop() {
+	ownerMu.Lock()
+	defer ownerMu.Unlock()
+	if owner == t {
+		owner = nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.started = false
+	if t.gcLowered {
+		t.SetGCPercent(t.gcPercent)
+		t.gcLowered = false
+	}
+	if t.limitSet {
+		t.SetMemoryLimit(t.prevMemoryLimit)
+		t.limitSet = false
+	}
+}
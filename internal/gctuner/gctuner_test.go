@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gctuner
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeRuntime struct {
+	env         map[string]string
+	memoryLimit int64
+	gcPercent   int
+}
+
+func newTestTuner(r *fakeRuntime) *Tuner {
+	t := New(zap.NewNop())
+	t.LookupEnv = func(key string) (string, bool) {
+		v, ok := r.env[key]
+		return v, ok
+	}
+	t.SetMemoryLimit = func(limit int64) int64 {
+		prev := r.memoryLimit
+		if limit >= 0 {
+			r.memoryLimit = limit
+		}
+		return prev
+	}
+	t.SetGCPercent = func(percent int) int {
+		prev := r.gcPercent
+		r.gcPercent = percent
+		return prev
+	}
+	return t
+}
+
+func TestTuner(t *testing.T) {
+	r := &fakeRuntime{memoryLimit: math.MaxInt64, gcPercent: 100}
+	tuner := newTestTuner(r)
+
+	// GOGC is only changed once started.
+	tuner.SetPressure(true, 25)
+	assert.Equal(t, 100, r.gcPercent)
+
+	limit, err := tuner.Start(1024 * mibBytes)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1024*mibBytes), limit)
+	assert.Equal(t, int64(1024*mibBytes), r.memoryLimit)
+
+	tuner.SetPressure(true, 25)
+	assert.Equal(t, 25, r.gcPercent)
+	tuner.SetPressure(false, 25)
+	assert.Equal(t, 100, r.gcPercent)
+
+	// GOGC is left unchanged if already lower.
+	r.gcPercent = 10
+	tuner.SetPressure(true, 25)
+	assert.Equal(t, 10, r.gcPercent)
+	r.gcPercent = 100
+
+	tuner.SetPressure(true, 25)
+	tuner.Stop()
+	assert.Equal(t, 100, r.gcPercent)
+	assert.Equal(t, int64(math.MaxInt64), r.memoryLimit)
+}
+
+func TestTunerGOMEMLIMIT(t *testing.T) {
+	r := &fakeRuntime{env: map[string]string{"GOMEMLIMIT": "512MiB"}, memoryLimit: 512 * mibBytes, gcPercent: 100}
+	tuner := newTestTuner(r)
+
+	limit, err := tuner.Start(1024 * mibBytes)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(512*mibBytes), limit)
+	assert.Equal(t, int64(512*mibBytes), r.memoryLimit)
+	tuner.Stop()
+	assert.Equal(t, int64(512*mibBytes), r.memoryLimit)
+}
+
+func TestTunerSingleOwner(t *testing.T) {
+	r := &fakeRuntime{memoryLimit: math.MaxInt64, gcPercent: 100}
+	first := newTestTuner(r)
+	second := newTestTuner(r)
+
+	_, err := first.Start(1024 * mibBytes)
+	require.NoError(t, err)
+	_, err = second.Start(2048 * mibBytes)
+	assert.ErrorIs(t, err, ErrOwned)
+	assert.Equal(t, int64(1024*mibBytes), r.memoryLimit)
+
+	// The settings can be tuned by another Tuner once released.
+	first.Stop()
+	_, err = second.Start(2048 * mibBytes)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2048*mibBytes), r.memoryLimit)
+	second.Stop()
+	assert.Equal(t, int64(math.MaxInt64), r.memoryLimit)
+}
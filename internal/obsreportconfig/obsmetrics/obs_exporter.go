@@ -11,6 +11,8 @@ import (
 const (
 	// ExporterKey used to identify exporters in metrics and traces.
 	ExporterKey = "exporter"
+	// PipelineKey used to identify the pipeline the data sent by exporters comes from.
+	PipelineKey = "pipeline"
 
 	// SentSpansKey used to track spans sent by exporters.
 	SentSpansKey = "sent_spans"
@@ -30,6 +32,7 @@ const (
 
 var (
 	TagKeyExporter, _ = tag.NewKey(ExporterKey)
+	TagKeyPipeline, _ = tag.NewKey(PipelineKey)
 
 	ExporterPrefix                 = ExporterKey + NameSep
 	ExportTraceDataOperationSuffix = NameSep + "traces"
@@ -65,4 +68,16 @@ var (
 		ExporterPrefix+FailedToSendLogRecordsKey,
 		"Number of log records in failed attempts to send to destination.",
 		stats.UnitDimensionless)
+	ExporterDroppedOnShutdownSpans = stats.Int64(
+		ExporterPrefix+DroppedOnShutdownSpansKey,
+		"Number of spans dropped because the exporter was shutting down.",
+		stats.UnitDimensionless)
+	ExporterDroppedOnShutdownMetricPoints = stats.Int64(
+		ExporterPrefix+DroppedOnShutdownMetricPointsKey,
+		"Number of metric points dropped because the exporter was shutting down.",
+		stats.UnitDimensionless)
+	ExporterDroppedOnShutdownLogRecords = stats.Int64(
+		ExporterPrefix+DroppedOnShutdownLogRecordsKey,
+		"Number of log records dropped because the exporter was shutting down.",
+		stats.UnitDimensionless)
 )
@@ -20,6 +20,21 @@ const (
 
 	// DroppedLogRecordsKey is the key used to identify log records dropped by the Collector.
 	DroppedLogRecordsKey = "dropped_log_records"
+
+	// InsertedSpansKey is the key used to identify spans inserted by a processor.
+	InsertedSpansKey = "inserted_spans"
+	// ModifiedSpansKey is the key used to identify spans modified by a processor.
+	ModifiedSpansKey = "modified_spans"
+
+	// InsertedMetricPointsKey is the key used to identify metric points inserted by a processor.
+	InsertedMetricPointsKey = "inserted_metric_points"
+	// ModifiedMetricPointsKey is the key used to identify metric points modified by a processor.
+	ModifiedMetricPointsKey = "modified_metric_points"
+
+	// InsertedLogRecordsKey is the key used to identify log records inserted by a processor.
+	InsertedLogRecordsKey = "inserted_log_records"
+	// ModifiedLogRecordsKey is the key used to identify log records modified by a processor.
+	ModifiedLogRecordsKey = "modified_log_records"
 )
 
 var (
@@ -27,6 +42,10 @@ var (
 
 	ProcessorPrefix = ProcessorKey + NameSep
 
+	ProcessTraceDataOperationSuffix = NameSep + "traces"
+	ProcessMetricsOperationSuffix   = NameSep + "metrics"
+	ProcessLogsOperationSuffix      = NameSep + "logs"
+
 	// Processor metrics. Any count of data items below is in the internal format
 	// of the collector since processors only deal with internal format.
 	ProcessorAcceptedSpans = stats.Int64(
@@ -41,6 +60,14 @@ var (
 		ProcessorPrefix+DroppedSpansKey,
 		"Number of spans that were dropped.",
 		stats.UnitDimensionless)
+	ProcessorInsertedSpans = stats.Int64(
+		ProcessorPrefix+InsertedSpansKey,
+		"Number of spans inserted by the processor.",
+		stats.UnitDimensionless)
+	ProcessorModifiedSpans = stats.Int64(
+		ProcessorPrefix+ModifiedSpansKey,
+		"Number of spans modified by the processor.",
+		stats.UnitDimensionless)
 	ProcessorAcceptedMetricPoints = stats.Int64(
 		ProcessorPrefix+AcceptedMetricPointsKey,
 		"Number of metric points successfully pushed into the next component in the pipeline.",
@@ -53,6 +80,14 @@ var (
 		ProcessorPrefix+DroppedMetricPointsKey,
 		"Number of metric points that were dropped.",
 		stats.UnitDimensionless)
+	ProcessorInsertedMetricPoints = stats.Int64(
+		ProcessorPrefix+InsertedMetricPointsKey,
+		"Number of metric points inserted by the processor.",
+		stats.UnitDimensionless)
+	ProcessorModifiedMetricPoints = stats.Int64(
+		ProcessorPrefix+ModifiedMetricPointsKey,
+		"Number of metric points modified by the processor.",
+		stats.UnitDimensionless)
 	ProcessorAcceptedLogRecords = stats.Int64(
 		ProcessorPrefix+AcceptedLogRecordsKey,
 		"Number of log records successfully pushed into the next component in the pipeline.",
@@ -65,4 +100,12 @@ var (
 		ProcessorPrefix+DroppedLogRecordsKey,
 		"Number of log records that were dropped.",
 		stats.UnitDimensionless)
+	ProcessorInsertedLogRecords = stats.Int64(
+		ProcessorPrefix+InsertedLogRecordsKey,
+		"Number of log records inserted by the processor.",
+		stats.UnitDimensionless)
+	ProcessorModifiedLogRecords = stats.Int64(
+		ProcessorPrefix+ModifiedLogRecordsKey,
+		"Number of log records modified by the processor.",
+		stats.UnitDimensionless)
 )
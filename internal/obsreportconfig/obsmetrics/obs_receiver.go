@@ -15,6 +15,14 @@ const (
 	TransportKey = "transport"
 	// FormatKey used to identify the format of the data received.
 	FormatKey = "format"
+	// ProtocolKey used to identify the protocol negotiated by the client, see client.Info.
+	ProtocolKey = "protocol"
+	// PeerAddressKey used to identify the address of the client, see client.Info.
+	PeerAddressKey = "peer_address"
+	// TLSVersionKey used to identify the TLS version negotiated by the client, see client.Info.
+	TLSVersionKey = "tls_version"
+	// BatchesKey used to identify the number of batches received on a stream.
+	BatchesKey = "batches"
 
 	// AcceptedSpansKey used to identify spans accepted by the Collector.
 	AcceptedSpansKey = "accepted_spans"
@@ -37,11 +45,13 @@ const (
 var (
 	TagKeyReceiver, _  = tag.NewKey(ReceiverKey)
 	TagKeyTransport, _ = tag.NewKey(TransportKey)
+	TagKeyProtocol, _  = tag.NewKey(ProtocolKey)
 
 	ReceiverPrefix                  = ReceiverKey + NameSep
 	ReceiveTraceDataOperationSuffix = NameSep + "TraceDataReceived"
 	ReceiverMetricsOperationSuffix  = NameSep + "MetricsReceived"
 	ReceiverLogsOperationSuffix     = NameSep + "LogsReceived"
+	ReceiverStreamOperationSuffix   = NameSep + "Stream"
 
 	// Receiver metrics. Any count of data items below is in the original format
 	// that they were received, reasoning: reconciliation is easier if measurement
@@ -72,4 +82,16 @@ var (
 		ReceiverPrefix+RefusedLogRecordsKey,
 		"Number of log records that could not be pushed into the pipeline.",
 		stats.UnitDimensionless)
+	ReceiverDroppedOnShutdownSpans = stats.Int64(
+		ReceiverPrefix+DroppedOnShutdownSpansKey,
+		"Number of spans that could not be pushed into the pipeline because it was shutting down.",
+		stats.UnitDimensionless)
+	ReceiverDroppedOnShutdownMetricPoints = stats.Int64(
+		ReceiverPrefix+DroppedOnShutdownMetricPointsKey,
+		"Number of metric points that could not be pushed into the pipeline because it was shutting down.",
+		stats.UnitDimensionless)
+	ReceiverDroppedOnShutdownLogRecords = stats.Int64(
+		ReceiverPrefix+DroppedOnShutdownLogRecordsKey,
+		"Number of log records that could not be pushed into the pipeline because it was shutting down.",
+		stats.UnitDimensionless)
 )
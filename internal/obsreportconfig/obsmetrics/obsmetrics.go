@@ -9,3 +9,14 @@ package obsmetrics // import "go.opentelemetry.io/collector/internal/obsreportco
 const (
 	NameSep = "/"
 )
+
+const (
+	// DroppedOnShutdownSpansKey used to identify spans dropped because the pipeline was shutting down.
+	DroppedOnShutdownSpansKey = "dropped_on_shutdown_spans"
+	// DroppedOnShutdownMetricPointsKey used to identify metric points dropped because the pipeline
+	// was shutting down.
+	DroppedOnShutdownMetricPointsKey = "dropped_on_shutdown_metric_points"
+	// DroppedOnShutdownLogRecordsKey used to identify log records dropped because the pipeline was
+	// shutting down.
+	DroppedOnShutdownLogRecordsKey = "dropped_on_shutdown_log_records"
+)
@@ -4,21 +4,26 @@
 package obsreportconfig // import "go.opentelemetry.io/collector/internal/obsreportconfig"
 
 import (
+	"context"
+
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
 	"go.opencensus.io/tag"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 )
 
 // UseOtelForInternalMetricsfeatureGate is the feature gate that controls whether the collector uses open
-// telemetrySettings for internal metrics.
+// telemetrySettings for internal metrics. It is read when the telemetry of the service and the components
+// is set up, the collector restarts the service when it is toggled at runtime.
 var UseOtelForInternalMetricsfeatureGate = featuregate.GlobalRegistry().MustRegister(
 	"telemetry.useOtelForInternalMetrics",
 	featuregate.StageAlpha,
-	featuregate.WithRegisterDescription("controls whether the collector uses OpenTelemetry for internal metrics"))
+	featuregate.WithRegisterDescription("controls whether the collector uses OpenTelemetry for internal metrics"),
+	featuregate.WithRegisterRuntimeToggle())
 
 // DisableHighCardinalityMetricsfeatureGate is the feature gate that controls whether the collector should enable
 // potentially high cardinality metrics. The gate will be removed when the collector allows for view configuration.
@@ -36,6 +41,38 @@ var UseOtelWithSDKConfigurationForInternalTelemetryFeatureGate = featuregate.Glo
 	featuregate.WithRegisterDescription("controls whether the collector supports extended OpenTelemetry"+
 		"configuration for internal telemetry"))
 
+// ReceiverNetworkAttributesFeatureGate is the feature gate that controls whether the receivers record the
+// network-level attributes of the clients from client.Info: the peer address, protocol and TLS version on the
+// spans, and the protocol on the metrics.
+var ReceiverNetworkAttributesFeatureGate = featuregate.GlobalRegistry().MustRegister(
+	"telemetry.receiverNetworkAttributes",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterDescription("controls whether the receivers record the peer address, protocol and "+
+		"TLS version of the clients on their spans, and the protocol on their metrics"))
+
+// ExporterPipelineAttributeFeatureGate is the feature gate that controls whether the exporters record the ID of the
+// pipeline the data comes from on their sent and failed metrics, to attribute the metrics of the exporters shared by
+// several pipelines.
+var ExporterPipelineAttributeFeatureGate = featuregate.GlobalRegistry().MustRegister(
+	"telemetry.exporterPipelineAttribute",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterDescription("controls whether the exporters record the ID of the pipeline the data "+
+		"comes from on their sent and failed metrics"))
+
+type pipelineIDKey struct{}
+
+// ContextWithPipelineID returns a copy of the context carrying the ID of the pipeline the data comes from,
+// set before the data is passed to the exporters of the pipeline.
+func ContextWithPipelineID(ctx context.Context, pipelineID component.ID) context.Context {
+	return context.WithValue(ctx, pipelineIDKey{}, pipelineID)
+}
+
+// PipelineIDFromContext returns the ID of the pipeline the data comes from, if set in the context.
+func PipelineIDFromContext(ctx context.Context) (component.ID, bool) {
+	pipelineID, ok := ctx.Value(pipelineIDKey{}).(component.ID)
+	return pipelineID, ok
+}
+
 // AllViews returns all the OpenCensus views requires by obsreport package.
 func AllViews(level configtelemetry.Level) []*view.View {
 	if level == configtelemetry.LevelNone {
@@ -60,8 +97,14 @@ func AllViews(level configtelemetry.Level) []*view.View {
 		obsmetrics.ExporterFailedToSendMetricPoints,
 		obsmetrics.ExporterSentLogRecords,
 		obsmetrics.ExporterFailedToSendLogRecords,
+		obsmetrics.ExporterDroppedOnShutdownSpans,
+		obsmetrics.ExporterDroppedOnShutdownMetricPoints,
+		obsmetrics.ExporterDroppedOnShutdownLogRecords,
 	}
 	tagKeys = []tag.Key{obsmetrics.TagKeyExporter}
+	if ExporterPipelineAttributeFeatureGate.IsEnabled() {
+		tagKeys = append(tagKeys, obsmetrics.TagKeyPipeline)
+	}
 	views = append(views, genViews(measures, tagKeys, view.Sum())...)
 
 	errorNumberView := &view.View{
@@ -77,12 +120,18 @@ func AllViews(level configtelemetry.Level) []*view.View {
 		obsmetrics.ProcessorAcceptedSpans,
 		obsmetrics.ProcessorRefusedSpans,
 		obsmetrics.ProcessorDroppedSpans,
+		obsmetrics.ProcessorInsertedSpans,
+		obsmetrics.ProcessorModifiedSpans,
 		obsmetrics.ProcessorAcceptedMetricPoints,
 		obsmetrics.ProcessorRefusedMetricPoints,
 		obsmetrics.ProcessorDroppedMetricPoints,
+		obsmetrics.ProcessorInsertedMetricPoints,
+		obsmetrics.ProcessorModifiedMetricPoints,
 		obsmetrics.ProcessorAcceptedLogRecords,
 		obsmetrics.ProcessorRefusedLogRecords,
 		obsmetrics.ProcessorDroppedLogRecords,
+		obsmetrics.ProcessorInsertedLogRecords,
+		obsmetrics.ProcessorModifiedLogRecords,
 	}
 	tagKeys = []tag.Key{obsmetrics.TagKeyProcessor}
 	views = append(views, genViews(measures, tagKeys, view.Sum())...)
@@ -102,10 +151,16 @@ func receiverViews() []*view.View {
 		obsmetrics.ReceiverRefusedMetricPoints,
 		obsmetrics.ReceiverAcceptedLogRecords,
 		obsmetrics.ReceiverRefusedLogRecords,
+		obsmetrics.ReceiverDroppedOnShutdownSpans,
+		obsmetrics.ReceiverDroppedOnShutdownMetricPoints,
+		obsmetrics.ReceiverDroppedOnShutdownLogRecords,
 	}
 	tagKeys := []tag.Key{
 		obsmetrics.TagKeyReceiver, obsmetrics.TagKeyTransport,
 	}
+	if ReceiverNetworkAttributesFeatureGate.IsEnabled() {
+		tagKeys = append(tagKeys, obsmetrics.TagKeyProtocol)
+	}
 
 	return genViews(measures, tagKeys, view.Sum())
 }
@@ -4,11 +4,18 @@
 package obsreportconfig
 
 import (
+	"context"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/tag"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 )
 
 func TestConfigure(t *testing.T) {
@@ -24,17 +31,17 @@ func TestConfigure(t *testing.T) {
 		{
 			name:         "basic",
 			level:        configtelemetry.LevelBasic,
-			wantViewsLen: 24,
+			wantViewsLen: 36,
 		},
 		{
 			name:         "normal",
 			level:        configtelemetry.LevelNormal,
-			wantViewsLen: 24,
+			wantViewsLen: 36,
 		},
 		{
 			name:         "detailed",
 			level:        configtelemetry.LevelDetailed,
-			wantViewsLen: 24,
+			wantViewsLen: 36,
 		},
 	}
 	for _, tt := range tests {
@@ -43,3 +50,53 @@ func TestConfigure(t *testing.T) {
 		})
 	}
 }
+
+func TestReceiverViewsNetworkAttributes(t *testing.T) {
+	for _, v := range receiverViews() {
+		assert.NotContains(t, v.TagKeys, obsmetrics.TagKeyProtocol)
+	}
+
+	require.NoError(t, featuregate.GlobalRegistry().Set(ReceiverNetworkAttributesFeatureGate.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(ReceiverNetworkAttributesFeatureGate.ID(), false))
+	})
+	views := receiverViews()
+	require.NotEmpty(t, views)
+	for _, v := range views {
+		assert.Contains(t, v.TagKeys, obsmetrics.TagKeyProtocol)
+	}
+}
+
+func TestExporterViewsPipelineAttribute(t *testing.T) {
+	exporterTagKeys := func() [][]tag.Key {
+		var tagKeys [][]tag.Key
+		for _, v := range AllViews(configtelemetry.LevelBasic) {
+			if strings.HasPrefix(v.Name, obsmetrics.ExporterPrefix) && len(v.TagKeys) > 0 {
+				tagKeys = append(tagKeys, v.TagKeys)
+			}
+		}
+		return tagKeys
+	}
+	for _, tagKeys := range exporterTagKeys() {
+		assert.NotContains(t, tagKeys, obsmetrics.TagKeyPipeline)
+	}
+
+	require.NoError(t, featuregate.GlobalRegistry().Set(ExporterPipelineAttributeFeatureGate.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(ExporterPipelineAttributeFeatureGate.ID(), false))
+	})
+	tagKeys := exporterTagKeys()
+	require.NotEmpty(t, tagKeys)
+	for _, keys := range tagKeys {
+		assert.Contains(t, keys, obsmetrics.TagKeyPipeline)
+	}
+}
+
+func TestContextWithPipelineID(t *testing.T) {
+	_, ok := PipelineIDFromContext(context.Background())
+	assert.False(t, ok)
+
+	pipelineID, ok := PipelineIDFromContext(ContextWithPipelineID(context.Background(), component.NewIDWithName("traces", "sampled")))
+	assert.True(t, ok)
+	assert.Equal(t, component.NewIDWithName("traces", "sampled"), pipelineID)
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package otlpcompat keeps the data sent by the OTLP exporters compatible with the backends
+// supporting an older version of the OTLP protocol.
+package otlpcompat // import "go.opentelemetry.io/collector/internal/otlpcompat"
+
+import (
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// SendLogEventNameFeatureGate is the feature gate that controls whether the OTLP exporters send the
+// event name of the log records in the LogRecord event_name field, added by OTLP v1.5.0.
+var SendLogEventNameFeatureGate = featuregate.GlobalRegistry().MustRegister(
+	"exporter.otlp.sendLogEventName",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterDescription("controls whether the OTLP exporters send the event name of the log records "+
+		"in the event_name field, instead of the event.name attribute understood by the backends supporting an older OTLP version"),
+	featuregate.WithRegisterFromVersion("v0.86.0"),
+	featuregate.WithRegisterRuntimeToggle())
+
+// EventNameAttribute is the attribute holding the event name of the log records for the older OTLP versions.
+const EventNameAttribute = "event.name"
+
+// Logs returns the logs to send with OTLP. Unless SendLogEventNameFeatureGate is enabled, the event names
+// are moved to the EventNameAttribute, not overriding an existing attribute, in a copy of ld.
+func Logs(ld plog.Logs) plog.Logs {
+	if SendLogEventNameFeatureGate.IsEnabled() || !hasEventName(ld) {
+		return ld
+	}
+	compat := plog.NewLogs()
+	ld.CopyTo(compat)
+	rls := compat.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				lr := lrs.At(k)
+				if lr.EventName() == "" {
+					continue
+				}
+				if _, ok := lr.Attributes().Get(EventNameAttribute); !ok {
+					lr.Attributes().PutStr(EventNameAttribute, lr.EventName())
+				}
+				lr.SetEventName("")
+			}
+		}
+	}
+	return compat
+}
+
+func hasEventName(ld plog.Logs) bool {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				if lrs.At(k).EventName() != "" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
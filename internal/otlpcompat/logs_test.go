@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpcompat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func newEventLogs() plog.Logs {
+	ld := plog.NewLogs()
+	lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	lrs.AppendEmpty().SetEventName("user.login")
+	lr := lrs.AppendEmpty()
+	lr.SetEventName("user.logout")
+	lr.Attributes().PutStr(EventNameAttribute, "session.end")
+	lrs.AppendEmpty().Body().SetStr("not an event")
+	return ld
+}
+
+func TestLogs(t *testing.T) {
+	ld := newEventLogs()
+	compat := Logs(ld)
+
+	lrs := compat.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	for i := 0; i < lrs.Len(); i++ {
+		assert.Empty(t, lrs.At(i).EventName())
+	}
+	v, ok := lrs.At(0).Attributes().Get(EventNameAttribute)
+	require.True(t, ok)
+	assert.Equal(t, "user.login", v.Str())
+	v, ok = lrs.At(1).Attributes().Get(EventNameAttribute)
+	require.True(t, ok)
+	assert.Equal(t, "session.end", v.Str())
+	assert.Equal(t, 0, lrs.At(2).Attributes().Len())
+
+	// The logs are not modified.
+	assert.Equal(t, "user.login", ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).EventName())
+	assert.Equal(t, 0, ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().Len())
+}
+
+func TestLogsWithoutEventName(t *testing.T) {
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("log")
+	assert.Equal(t, ld, Logs(ld))
+}
+
+func TestLogsSendEventName(t *testing.T) {
+	require.NoError(t, featuregate.GlobalRegistry().Set(SendLogEventNameFeatureGate.ID(), true))
+	defer func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(SendLogEventNameFeatureGate.ID(), false))
+	}()
+	ld := newEventLogs()
+	assert.Equal(t, ld, Logs(ld))
+}
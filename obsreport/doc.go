@@ -20,6 +20,10 @@
 //   - Logs receive operations should use the pair:
 //     StartLogsOp/EndLogsOp
 //
+// Receivers of long-lived streams, carrying many batches on the same connection,
+// should use StartStream once per stream, Stream.RecordBatch for each batch, and
+// Stream.End when the stream is closed.
+//
 // Similar for exporters:
 //
 //   - Traces export operations should use the pair:
@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package obsreport // import "go.opentelemetry.io/collector/obsreport"
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	// maxFailuresPerComponent is the number of failed operations kept for each component.
+	maxFailuresPerComponent = 20
+	// maxFailureErrorLength is the length the error messages of the failed operations are truncated to.
+	maxFailureErrorLength = 512
+)
+
+// Failure is a recent operation in which a component refused, or failed to send, data.
+// The data dropped because the collector was shutting down is not recorded.
+type Failure struct {
+	// Time is the end of the operation.
+	Time time.Time
+	// Kind is the kind of the component, "receiver", "processor", "exporter" or "scraper".
+	Kind string
+	// ID is the ID of the component. The ID of a scraper is prefixed by the ID of its receiver.
+	ID string
+	// DataType is the type of the data.
+	DataType component.DataType
+	// Items is the number of spans, metric points or log records refused or failed to be sent.
+	Items int
+	// Error is the error of the operation, empty if the data was refused without error.
+	Error string
+	// TraceID and SpanID identify the span of the operation, empty if it was not sampled.
+	TraceID string
+	SpanID  string
+}
+
+var failures = newFailureRecorder(maxFailuresPerComponent)
+
+// RecentFailures returns the last failed operations of every component, ordered by component,
+// the most recent operations first.
+func RecentFailures() []Failure {
+	return failures.recent()
+}
+
+type failureKey struct {
+	kind string
+	id   string
+}
+
+// failureRecorder keeps the last failed operations of each component in ring buffers.
+type failureRecorder struct {
+	size  int
+	mu    sync.Mutex
+	rings map[failureKey]*failureRing
+}
+
+type failureRing struct {
+	entries []Failure
+	// next is the index of the entry overwritten by the next failure once the ring is full.
+	next int
+}
+
+func newFailureRecorder(size int) *failureRecorder {
+	return &failureRecorder{size: size, rings: map[failureKey]*failureRing{}}
+}
+
+func (fr *failureRecorder) record(ctx context.Context, kind, id string, dataType component.DataType, items int, err error) {
+	f := Failure{
+		Time:     time.Now(),
+		Kind:     kind,
+		ID:       id,
+		DataType: dataType,
+		Items:    items,
+	}
+	if err != nil {
+		f.Error = err.Error()
+		if len(f.Error) > maxFailureErrorLength {
+			f.Error = f.Error[:maxFailureErrorLength] + "..."
+		}
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		f.TraceID = sc.TraceID().String()
+		f.SpanID = sc.SpanID().String()
+	}
+
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+	key := failureKey{kind: kind, id: id}
+	ring, ok := fr.rings[key]
+	if !ok {
+		ring = &failureRing{entries: make([]Failure, 0, fr.size)}
+		fr.rings[key] = ring
+	}
+	if len(ring.entries) < fr.size {
+		ring.entries = append(ring.entries, f)
+		return
+	}
+	ring.entries[ring.next] = f
+	ring.next = (ring.next + 1) % fr.size
+}
+
+func (fr *failureRecorder) recent() []Failure {
+	fr.mu.Lock()
+	keys := make([]failureKey, 0, len(fr.rings))
+	for key := range fr.rings {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].kind != keys[j].kind {
+			return keys[i].kind < keys[j].kind
+		}
+		return keys[i].id < keys[j].id
+	})
+	var res []Failure
+	for _, key := range keys {
+		ring := fr.rings[key]
+		// The most recent entry is just before next.
+		for i := 1; i <= len(ring.entries); i++ {
+			res = append(res, ring.entries[(ring.next-i+len(ring.entries))%len(ring.entries)])
+		}
+	}
+	fr.mu.Unlock()
+	return res
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package obsreport
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+)
+
+// setFailureRecorder replaces the global failure recorder for the duration of the test.
+func setFailureRecorder(t *testing.T, size int) {
+	prev := failures
+	failures = newFailureRecorder(size)
+	t.Cleanup(func() { failures = prev })
+}
+
+func TestFailureRecorderRing(t *testing.T) {
+	fr := newFailureRecorder(3)
+	for i := 1; i <= 5; i++ {
+		fr.record(context.Background(), "exporter", "otlp", component.DataTypeTraces, i, errors.New("error "+strconv.Itoa(i)))
+	}
+	fr.record(context.Background(), "exporter", "debug", component.DataTypeLogs, 1, nil)
+	fr.record(context.Background(), "receiver", "otlp", component.DataTypeMetrics, 2, errFake)
+
+	recent := fr.recent()
+	require.Len(t, recent, 5)
+	var got []string
+	for _, f := range recent {
+		got = append(got, f.Kind+" "+f.ID+" "+strconv.Itoa(f.Items)+" "+f.Error)
+	}
+	assert.Equal(t, []string{
+		"exporter debug 1 ",
+		"exporter otlp 5 error 5",
+		"exporter otlp 4 error 4",
+		"exporter otlp 3 error 3",
+		"receiver otlp 2 " + errFake.Error(),
+	}, got)
+}
+
+func TestFailureRecorderDetails(t *testing.T) {
+	fr := newFailureRecorder(1)
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SpanID:  trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+	fr.record(ctx, "exporter", "otlp", component.DataTypeTraces, 10, errors.New(strings.Repeat("x", 1000)))
+
+	recent := fr.recent()
+	require.Len(t, recent, 1)
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", recent[0].TraceID)
+	assert.Equal(t, "0102030405060708", recent[0].SpanID)
+	assert.Len(t, recent[0].Error, maxFailureErrorLength+3)
+	assert.False(t, recent[0].Time.IsZero())
+}
+
+func TestRecentFailures(t *testing.T) {
+	setFailureRecorder(t, maxFailuresPerComponent)
+	tt, err := obsreporttest.SetupTelemetry(exporterID)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	exp, err := NewExporter(ExporterSettings{ExporterID: exporterID, ExporterCreateSettings: tt.ToExporterCreateSettings()})
+	require.NoError(t, err)
+	ctx := exp.StartTracesOp(context.Background())
+	exp.EndTracesOp(ctx, 7, errFake)
+	ctx = exp.StartLogsOp(context.Background())
+	exp.EndLogsOp(ctx, 5, nil)
+	ctx = exp.StartMetricsOp(context.Background())
+	exp.EndMetricsOp(ctx, 3, consumererror.NewShutdown(errFake))
+
+	rec, err := NewReceiver(ReceiverSettings{ReceiverID: receiverID, Transport: transport, ReceiverCreateSettings: tt.ToReceiverCreateSettings()})
+	require.NoError(t, err)
+	ctx = rec.StartLogsOp(context.Background())
+	rec.EndLogsOp(ctx, format, 4, errFake)
+	ctx = rec.StartLogsOp(context.Background())
+	rec.EndLogsOp(ctx, format, 2, consumererror.NewShutdown(errFake))
+
+	proc, err := NewProcessor(ProcessorSettings{ProcessorID: processorID, ProcessorCreateSettings: tt.ToProcessorCreateSettings()})
+	require.NoError(t, err)
+	proc.MetricsRefused(context.Background(), 9)
+
+	scraper, err := NewScraper(ScraperSettings{ReceiverID: receiverID, Scraper: scraperID, ReceiverCreateSettings: tt.ToReceiverCreateSettings()})
+	require.NoError(t, err)
+	ctx = scraper.StartMetricsOp(context.Background())
+	scraper.EndMetricsOp(ctx, 6, errFake)
+
+	recent := RecentFailures()
+	require.Len(t, recent, 4)
+	assert.Equal(t, "exporter", recent[0].Kind)
+	assert.Equal(t, exporterID.String(), recent[0].ID)
+	assert.Equal(t, component.DataTypeTraces, recent[0].DataType)
+	assert.Equal(t, 7, recent[0].Items)
+	assert.Equal(t, errFake.Error(), recent[0].Error)
+	// The operations are traced by the test telemetry.
+	assert.NotEmpty(t, recent[0].TraceID)
+
+	assert.Equal(t, "processor", recent[1].Kind)
+	assert.Equal(t, 9, recent[1].Items)
+	assert.Empty(t, recent[1].Error)
+
+	assert.Equal(t, "receiver", recent[2].Kind)
+	assert.Equal(t, component.DataTypeLogs, recent[2].DataType)
+	assert.Equal(t, 4, recent[2].Items)
+
+	assert.Equal(t, "scraper", recent[3].Kind)
+	assert.Equal(t, receiverID.String()+"/"+scraperID.String(), recent[3].ID)
+	assert.Equal(t, 6, recent[3].Items)
+}
@@ -13,20 +13,15 @@ import (
 	"go.opentelemetry.io/otel/metric"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/trace"
-	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/internal/obsreportconfig"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
-)
-
-const (
-	exporterName = "exporter"
-
-	exporterScope = scopeName + nameSep + exporterName
+	"go.opentelemetry.io/collector/obsreport/internal/exportertelemetry"
 )
 
 // Exporter is a helper to add observability to an exporter.
@@ -36,15 +31,13 @@ type Exporter struct {
 	mutators       []tag.Mutator
 	tracer         trace.Tracer
 	logger         *zap.Logger
+	exporterID     string
+	// pipelineAttr records the ID of the pipeline the data comes from on the sent and failed metrics.
+	pipelineAttr bool
 
-	useOtelForMetrics        bool
-	otelAttrs                []attribute.KeyValue
-	sentSpans                metric.Int64Counter
-	failedToSendSpans        metric.Int64Counter
-	sentMetricPoints         metric.Int64Counter
-	failedToSendMetricPoints metric.Int64Counter
-	sentLogRecords           metric.Int64Counter
-	failedToSendLogRecords   metric.Int64Counter
+	useOtelForMetrics bool
+	otelAttrs         metric.MeasurementOption
+	telemetryBuilder  *exportertelemetry.TelemetryBuilder
 }
 
 // ExporterSettings are settings for creating an Exporter.
@@ -65,11 +58,11 @@ func newExporter(cfg ExporterSettings, useOtel bool) (*Exporter, error) {
 		mutators:       []tag.Mutator{tag.Upsert(obsmetrics.TagKeyExporter, cfg.ExporterID.String(), tag.WithTTL(tag.TTLNoPropagation))},
 		tracer:         cfg.ExporterCreateSettings.TracerProvider.Tracer(cfg.ExporterID.String()),
 		logger:         cfg.ExporterCreateSettings.Logger,
+		exporterID:     cfg.ExporterID.String(),
+		pipelineAttr:   obsreportconfig.ExporterPipelineAttributeFeatureGate.IsEnabled(),
 
 		useOtelForMetrics: useOtel,
-		otelAttrs: []attribute.KeyValue{
-			attribute.String(obsmetrics.ExporterKey, cfg.ExporterID.String()),
-		},
+		otelAttrs:         exportertelemetry.ExporterAttributeSet(cfg.ExporterID.String()),
 	}
 
 	// ignore instrument name error as per workaround in https://github.com/open-telemetry/opentelemetry-collector/issues/8346
@@ -87,47 +80,10 @@ func (exp *Exporter) createOtelMetrics(cfg ExporterSettings) error {
 	if !exp.useOtelForMetrics {
 		return nil
 	}
-	meter := cfg.ExporterCreateSettings.MeterProvider.Meter(exporterScope)
-
-	var errors, err error
-
-	exp.sentSpans, err = meter.Int64Counter(
-		obsmetrics.ExporterPrefix+obsmetrics.SentSpansKey,
-		metric.WithDescription("Number of spans successfully sent to destination."),
-		metric.WithUnit("1"))
-	errors = multierr.Append(errors, err)
-
-	exp.failedToSendSpans, err = meter.Int64Counter(
-		obsmetrics.ExporterPrefix+obsmetrics.FailedToSendSpansKey,
-		metric.WithDescription("Number of spans in failed attempts to send to destination."),
-		metric.WithUnit("1"))
-	errors = multierr.Append(errors, err)
 
-	exp.sentMetricPoints, err = meter.Int64Counter(
-		obsmetrics.ExporterPrefix+obsmetrics.SentMetricPointsKey,
-		metric.WithDescription("Number of metric points successfully sent to destination."),
-		metric.WithUnit("1"))
-	errors = multierr.Append(errors, err)
-
-	exp.failedToSendMetricPoints, err = meter.Int64Counter(
-		obsmetrics.ExporterPrefix+obsmetrics.FailedToSendMetricPointsKey,
-		metric.WithDescription("Number of metric points in failed attempts to send to destination."),
-		metric.WithUnit("1"))
-	errors = multierr.Append(errors, err)
-
-	exp.sentLogRecords, err = meter.Int64Counter(
-		obsmetrics.ExporterPrefix+obsmetrics.SentLogRecordsKey,
-		metric.WithDescription("Number of log record successfully sent to destination."),
-		metric.WithUnit("1"))
-	errors = multierr.Append(errors, err)
-
-	exp.failedToSendLogRecords, err = meter.Int64Counter(
-		obsmetrics.ExporterPrefix+obsmetrics.FailedToSendLogRecordsKey,
-		metric.WithDescription("Number of log records in failed attempts to send to destination."),
-		metric.WithUnit("1"))
-	errors = multierr.Append(errors, err)
-
-	return errors
+	var err error
+	exp.telemetryBuilder, err = exportertelemetry.NewTelemetryBuilder(cfg.ExporterCreateSettings.MeterProvider)
+	return err
 }
 
 // StartTracesOp is called at the start of an Export operation.
@@ -139,9 +95,10 @@ func (exp *Exporter) StartTracesOp(ctx context.Context) context.Context {
 
 // EndTracesOp completes the export operation that was started with StartTracesOp.
 func (exp *Exporter) EndTracesOp(ctx context.Context, numSpans int, err error) {
-	numSent, numFailedToSend := toNumItems(numSpans, err)
-	exp.recordMetrics(ctx, component.DataTypeTraces, numSent, numFailedToSend)
-	endSpan(ctx, err, numSent, numFailedToSend, obsmetrics.SentSpansKey, obsmetrics.FailedToSendSpansKey)
+	numSent, numFailedToSend, numDroppedOnShutdown := toNumItems(numSpans, err)
+	exp.recordMetrics(ctx, component.DataTypeTraces, numSent, numFailedToSend, numDroppedOnShutdown)
+	exp.recordFailure(ctx, component.DataTypeTraces, numFailedToSend, err)
+	endSpan(ctx, err, numSent, numFailedToSend, numDroppedOnShutdown, obsmetrics.SentSpansKey, obsmetrics.FailedToSendSpansKey, obsmetrics.DroppedOnShutdownSpansKey)
 }
 
 // StartMetricsOp is called at the start of an Export operation.
@@ -154,9 +111,10 @@ func (exp *Exporter) StartMetricsOp(ctx context.Context) context.Context {
 // EndMetricsOp completes the export operation that was started with
 // StartMetricsOp.
 func (exp *Exporter) EndMetricsOp(ctx context.Context, numMetricPoints int, err error) {
-	numSent, numFailedToSend := toNumItems(numMetricPoints, err)
-	exp.recordMetrics(ctx, component.DataTypeMetrics, numSent, numFailedToSend)
-	endSpan(ctx, err, numSent, numFailedToSend, obsmetrics.SentMetricPointsKey, obsmetrics.FailedToSendMetricPointsKey)
+	numSent, numFailedToSend, numDroppedOnShutdown := toNumItems(numMetricPoints, err)
+	exp.recordMetrics(ctx, component.DataTypeMetrics, numSent, numFailedToSend, numDroppedOnShutdown)
+	exp.recordFailure(ctx, component.DataTypeMetrics, numFailedToSend, err)
+	endSpan(ctx, err, numSent, numFailedToSend, numDroppedOnShutdown, obsmetrics.SentMetricPointsKey, obsmetrics.FailedToSendMetricPointsKey, obsmetrics.DroppedOnShutdownMetricPointsKey)
 }
 
 // StartLogsOp is called at the start of an Export operation.
@@ -168,9 +126,10 @@ func (exp *Exporter) StartLogsOp(ctx context.Context) context.Context {
 
 // EndLogsOp completes the export operation that was started with StartLogsOp.
 func (exp *Exporter) EndLogsOp(ctx context.Context, numLogRecords int, err error) {
-	numSent, numFailedToSend := toNumItems(numLogRecords, err)
-	exp.recordMetrics(ctx, component.DataTypeLogs, numSent, numFailedToSend)
-	endSpan(ctx, err, numSent, numFailedToSend, obsmetrics.SentLogRecordsKey, obsmetrics.FailedToSendLogRecordsKey)
+	numSent, numFailedToSend, numDroppedOnShutdown := toNumItems(numLogRecords, err)
+	exp.recordMetrics(ctx, component.DataTypeLogs, numSent, numFailedToSend, numDroppedOnShutdown)
+	exp.recordFailure(ctx, component.DataTypeLogs, numFailedToSend, err)
+	endSpan(ctx, err, numSent, numFailedToSend, numDroppedOnShutdown, obsmetrics.SentLogRecordsKey, obsmetrics.FailedToSendLogRecordsKey, obsmetrics.DroppedOnShutdownLogRecordsKey)
 }
 
 // startOp creates the span used to trace the operation. Returning
@@ -181,64 +140,97 @@ func (exp *Exporter) startOp(ctx context.Context, operationSuffix string) contex
 	return ctx
 }
 
-func (exp *Exporter) recordMetrics(ctx context.Context, dataType component.DataType, numSent, numFailed int64) {
+func (exp *Exporter) recordMetrics(ctx context.Context, dataType component.DataType, numSent, numFailed, numDroppedOnShutdown int64) {
 	if exp.level == configtelemetry.LevelNone {
 		return
 	}
 	if exp.useOtelForMetrics {
-		exp.recordWithOtel(ctx, dataType, numSent, numFailed)
+		exp.recordWithOtel(ctx, dataType, numSent, numFailed, numDroppedOnShutdown)
 	} else {
-		exp.recordWithOC(ctx, dataType, numSent, numFailed)
+		exp.recordWithOC(ctx, dataType, numSent, numFailed, numDroppedOnShutdown)
 	}
 }
 
-func (exp *Exporter) recordWithOtel(ctx context.Context, dataType component.DataType, sent int64, failed int64) {
-	var sentMeasure, failedMeasure metric.Int64Counter
+func (exp *Exporter) recordWithOtel(ctx context.Context, dataType component.DataType, sent int64, failed int64, droppedOnShutdown int64) {
+	var sentMeasure, failedMeasure, droppedOnShutdownMeasure metric.Int64Counter
 	switch dataType {
 	case component.DataTypeTraces:
-		sentMeasure = exp.sentSpans
-		failedMeasure = exp.failedToSendSpans
+		sentMeasure = exp.telemetryBuilder.ExporterSentSpans
+		failedMeasure = exp.telemetryBuilder.ExporterSendFailedSpans
+		droppedOnShutdownMeasure = exp.telemetryBuilder.ExporterDroppedOnShutdownSpans
 	case component.DataTypeMetrics:
-		sentMeasure = exp.sentMetricPoints
-		failedMeasure = exp.failedToSendMetricPoints
+		sentMeasure = exp.telemetryBuilder.ExporterSentMetricPoints
+		failedMeasure = exp.telemetryBuilder.ExporterSendFailedMetricPoints
+		droppedOnShutdownMeasure = exp.telemetryBuilder.ExporterDroppedOnShutdownMetricPoints
 	case component.DataTypeLogs:
-		sentMeasure = exp.sentLogRecords
-		failedMeasure = exp.failedToSendLogRecords
+		sentMeasure = exp.telemetryBuilder.ExporterSentLogRecords
+		failedMeasure = exp.telemetryBuilder.ExporterSendFailedLogRecords
+		droppedOnShutdownMeasure = exp.telemetryBuilder.ExporterDroppedOnShutdownLogRecords
 	}
 
-	sentMeasure.Add(ctx, sent, metric.WithAttributes(exp.otelAttrs...))
-	failedMeasure.Add(ctx, failed, metric.WithAttributes(exp.otelAttrs...))
+	attrs := exp.otelAttrs
+	if pipelineID, ok := exp.pipelineID(ctx); ok {
+		attrs = metric.WithAttributes(
+			attribute.String(obsmetrics.ExporterKey, exp.exporterID),
+			attribute.String(obsmetrics.PipelineKey, pipelineID.String()))
+	}
+	sentMeasure.Add(ctx, sent, attrs)
+	failedMeasure.Add(ctx, failed, attrs)
+	if droppedOnShutdown > 0 {
+		droppedOnShutdownMeasure.Add(ctx, droppedOnShutdown, attrs)
+	}
 }
 
-func (exp *Exporter) recordWithOC(ctx context.Context, dataType component.DataType, sent int64, failed int64) {
-	var sentMeasure, failedMeasure *stats.Int64Measure
+func (exp *Exporter) recordWithOC(ctx context.Context, dataType component.DataType, sent int64, failed int64, droppedOnShutdown int64) {
+	var sentMeasure, failedMeasure, droppedOnShutdownMeasure *stats.Int64Measure
 	switch dataType {
 	case component.DataTypeTraces:
 		sentMeasure = obsmetrics.ExporterSentSpans
 		failedMeasure = obsmetrics.ExporterFailedToSendSpans
+		droppedOnShutdownMeasure = obsmetrics.ExporterDroppedOnShutdownSpans
 	case component.DataTypeMetrics:
 		sentMeasure = obsmetrics.ExporterSentMetricPoints
 		failedMeasure = obsmetrics.ExporterFailedToSendMetricPoints
+		droppedOnShutdownMeasure = obsmetrics.ExporterDroppedOnShutdownMetricPoints
 	case component.DataTypeLogs:
 		sentMeasure = obsmetrics.ExporterSentLogRecords
 		failedMeasure = obsmetrics.ExporterFailedToSendLogRecords
+		droppedOnShutdownMeasure = obsmetrics.ExporterDroppedOnShutdownLogRecords
 	}
 
+	// The failed items are only recorded when there are some, not to increase the
+	// send_failed_requests count.
+	measurements := []stats.Measurement{sentMeasure.M(sent)}
 	if failed > 0 {
-		_ = stats.RecordWithTags(
-			ctx,
-			exp.mutators,
-			sentMeasure.M(sent),
-			failedMeasure.M(failed))
-	} else {
-		_ = stats.RecordWithTags(
-			ctx,
-			exp.mutators,
-			sentMeasure.M(sent))
+		measurements = append(measurements, failedMeasure.M(failed))
+	}
+	if droppedOnShutdown > 0 {
+		measurements = append(measurements, droppedOnShutdownMeasure.M(droppedOnShutdown))
 	}
+	mutators := exp.mutators
+	if pipelineID, ok := exp.pipelineID(ctx); ok {
+		mutators = append(mutators[:len(mutators):len(mutators)],
+			tag.Upsert(obsmetrics.TagKeyPipeline, pipelineID.String(), tag.WithTTL(tag.TTLNoPropagation)))
+	}
+	_ = stats.RecordWithTags(ctx, mutators, measurements...)
 }
 
-func endSpan(ctx context.Context, err error, numSent, numFailedToSend int64, sentItemsKey, failedToSendItemsKey string) {
+// recordFailure records the failed export operations, but not the data dropped on shutdown.
+func (exp *Exporter) recordFailure(ctx context.Context, dataType component.DataType, numFailedToSend int64, err error) {
+	if err != nil && !consumererror.IsShutdown(err) {
+		failures.record(ctx, component.KindExporter.String(), exp.exporterID, dataType, int(numFailedToSend), err)
+	}
+}
+
+// pipelineID returns the ID of the pipeline the data comes from, if it is recorded on the metrics.
+func (exp *Exporter) pipelineID(ctx context.Context) (component.ID, bool) {
+	if !exp.pipelineAttr {
+		return component.ID{}, false
+	}
+	return obsreportconfig.PipelineIDFromContext(ctx)
+}
+
+func endSpan(ctx context.Context, err error, numSent, numFailedToSend, numDroppedOnShutdown int64, sentItemsKey, failedToSendItemsKey, droppedOnShutdownItemsKey string) {
 	span := trace.SpanFromContext(ctx)
 	// End the span according to errors.
 	if span.IsRecording() {
@@ -246,14 +238,26 @@ func endSpan(ctx context.Context, err error, numSent, numFailedToSend int64, sen
 			attribute.Int64(sentItemsKey, numSent),
 			attribute.Int64(failedToSendItemsKey, numFailedToSend),
 		)
+		if numDroppedOnShutdown > 0 {
+			span.SetAttributes(attribute.Int64(droppedOnShutdownItemsKey, numDroppedOnShutdown))
+		}
 		recordError(span, err)
 	}
 	span.End()
 }
 
-func toNumItems(numExportedItems int, err error) (int64, int64) {
-	if err != nil {
-		return 0, int64(numExportedItems)
+// toNumItems returns the number of items sent, failed to be sent and dropped because the
+// exporter was shutting down.
+func toNumItems(numExportedItems int, err error) (int64, int64, int64) {
+	switch {
+	case err == nil:
+		return int64(numExportedItems), 0, 0
+	case consumererror.IsShutdown(err):
+		return 0, 0, int64(numExportedItems)
+	}
+	// Only the items rejected failed to be sent when the exporter reports a partial success.
+	if p, ok := consumererror.AsPartialSuccess(err); ok && p.Rejected() < numExportedItems {
+		return int64(numExportedItems - p.Rejected()), int64(p.Rejected()), 0
 	}
-	return int64(numExportedItems), 0
+	return 0, int64(numExportedItems), 0
 }
@@ -13,21 +13,17 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
-	"go.uber.org/multierr"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/internal/obsreportconfig"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/obsreport/internal/processortelemetry"
 	"go.opentelemetry.io/collector/processor"
 )
 
-var (
-	processorName  = "processor"
-	processorScope = scopeName + nameSep + processorName
-)
-
 // BuildProcessorCustomMetricName is used to be build a metric name following
 // the standards used in the Collector. The configType should be the same
 // value used to identify the type on the config.
@@ -44,23 +40,17 @@ func BuildProcessorCustomMetricName(configType, metric string) string {
 
 // Processor is a helper to add observability to a processor.
 type Processor struct {
-	level    configtelemetry.Level
-	mutators []tag.Mutator
+	level          configtelemetry.Level
+	processorID    string
+	spanNamePrefix string
+	mutators       []tag.Mutator
+	tracer         trace.Tracer
 
 	logger *zap.Logger
 
 	useOtelForMetrics bool
-	otelAttrs         []attribute.KeyValue
-
-	acceptedSpansCounter        metric.Int64Counter
-	refusedSpansCounter         metric.Int64Counter
-	droppedSpansCounter         metric.Int64Counter
-	acceptedMetricPointsCounter metric.Int64Counter
-	refusedMetricPointsCounter  metric.Int64Counter
-	droppedMetricPointsCounter  metric.Int64Counter
-	acceptedLogRecordsCounter   metric.Int64Counter
-	refusedLogRecordsCounter    metric.Int64Counter
-	droppedLogRecordsCounter    metric.Int64Counter
+	otelAttrs         metric.MeasurementOption
+	telemetryBuilder  *processortelemetry.TelemetryBuilder
 }
 
 // ProcessorSettings are settings for creating a Processor.
@@ -77,12 +67,13 @@ func NewProcessor(cfg ProcessorSettings) (*Processor, error) {
 func newProcessor(cfg ProcessorSettings, useOtel bool) (*Processor, error) {
 	proc := &Processor{
 		level:             cfg.ProcessorCreateSettings.MetricsLevel,
+		processorID:       cfg.ProcessorID.String(),
+		spanNamePrefix:    obsmetrics.ProcessorPrefix + cfg.ProcessorID.String(),
 		mutators:          []tag.Mutator{tag.Upsert(obsmetrics.TagKeyProcessor, cfg.ProcessorID.String(), tag.WithTTL(tag.TTLNoPropagation))},
+		tracer:            cfg.ProcessorCreateSettings.TracerProvider.Tracer(cfg.ProcessorID.String()),
 		logger:            cfg.ProcessorCreateSettings.Logger,
 		useOtelForMetrics: useOtel,
-		otelAttrs: []attribute.KeyValue{
-			attribute.String(obsmetrics.ProcessorKey, cfg.ProcessorID.String()),
-		},
+		otelAttrs:         processortelemetry.ProcessorAttributeSet(cfg.ProcessorID.String()),
 	}
 
 	// ignore instrument name error as per workaround in https://github.com/open-telemetry/opentelemetry-collector/issues/8346
@@ -100,192 +91,282 @@ func (por *Processor) createOtelMetrics(cfg ProcessorSettings) error {
 	if !por.useOtelForMetrics {
 		return nil
 	}
-	meter := cfg.ProcessorCreateSettings.MeterProvider.Meter(processorScope)
-	var errors, err error
 
-	por.acceptedSpansCounter, err = meter.Int64Counter(
-		obsmetrics.ProcessorPrefix+obsmetrics.AcceptedSpansKey,
-		metric.WithDescription("Number of spans successfully pushed into the next component in the pipeline."),
-		metric.WithUnit("1"),
-	)
-	errors = multierr.Append(errors, err)
+	var err error
+	por.telemetryBuilder, err = processortelemetry.NewTelemetryBuilder(cfg.ProcessorCreateSettings.MeterProvider)
+	return err
+}
 
-	por.refusedSpansCounter, err = meter.Int64Counter(
-		obsmetrics.ProcessorPrefix+obsmetrics.RefusedSpansKey,
-		metric.WithDescription("Number of spans that were rejected by the next component in the pipeline."),
-		metric.WithUnit("1"),
-	)
-	errors = multierr.Append(errors, err)
+// StartTracesOp is called when the processor starts processing a batch of traces.
+// The returned context should be used in other calls to the obsreport functions
+// dealing with the same batch.
+func (por *Processor) StartTracesOp(ctx context.Context) context.Context {
+	return por.startOp(ctx, obsmetrics.ProcessTraceDataOperationSuffix)
+}
 
-	por.droppedSpansCounter, err = meter.Int64Counter(
-		obsmetrics.ProcessorPrefix+obsmetrics.DroppedSpansKey,
-		metric.WithDescription("Number of spans that were dropped."),
-		metric.WithUnit("1"),
-	)
-	errors = multierr.Append(errors, err)
+// EndTracesOp completes the processing of the batch of traces started with StartTracesOp,
+// reporting the number of spans inserted, modified and dropped by the processor.
+func (por *Processor) EndTracesOp(processorCtx context.Context, numInserted, numModified, numDropped int, err error) {
+	por.endOp(processorCtx, component.DataTypeTraces, numInserted, numModified, numDropped, err)
+}
 
-	por.acceptedMetricPointsCounter, err = meter.Int64Counter(
-		obsmetrics.ProcessorPrefix+obsmetrics.AcceptedMetricPointsKey,
-		metric.WithDescription("Number of metric points successfully pushed into the next component in the pipeline."),
-		metric.WithUnit("1"),
-	)
-	errors = multierr.Append(errors, err)
+// StartMetricsOp is called when the processor starts processing a batch of metrics.
+// The returned context should be used in other calls to the obsreport functions
+// dealing with the same batch.
+func (por *Processor) StartMetricsOp(ctx context.Context) context.Context {
+	return por.startOp(ctx, obsmetrics.ProcessMetricsOperationSuffix)
+}
 
-	por.refusedMetricPointsCounter, err = meter.Int64Counter(
-		obsmetrics.ProcessorPrefix+obsmetrics.RefusedMetricPointsKey,
-		metric.WithDescription("Number of metric points that were rejected by the next component in the pipeline."),
-		metric.WithUnit("1"),
-	)
-	errors = multierr.Append(errors, err)
+// EndMetricsOp completes the processing of the batch of metrics started with StartMetricsOp,
+// reporting the number of metric points inserted, modified and dropped by the processor.
+func (por *Processor) EndMetricsOp(processorCtx context.Context, numInserted, numModified, numDropped int, err error) {
+	por.endOp(processorCtx, component.DataTypeMetrics, numInserted, numModified, numDropped, err)
+}
 
-	por.droppedMetricPointsCounter, err = meter.Int64Counter(
-		obsmetrics.ProcessorPrefix+obsmetrics.DroppedMetricPointsKey,
-		metric.WithDescription("Number of metric points that were dropped."),
-		metric.WithUnit("1"),
-	)
-	errors = multierr.Append(errors, err)
+// StartLogsOp is called when the processor starts processing a batch of logs.
+// The returned context should be used in other calls to the obsreport functions
+// dealing with the same batch.
+func (por *Processor) StartLogsOp(ctx context.Context) context.Context {
+	return por.startOp(ctx, obsmetrics.ProcessLogsOperationSuffix)
+}
 
-	por.acceptedLogRecordsCounter, err = meter.Int64Counter(
-		obsmetrics.ProcessorPrefix+obsmetrics.AcceptedLogRecordsKey,
-		metric.WithDescription("Number of log records successfully pushed into the next component in the pipeline."),
-		metric.WithUnit("1"),
-	)
-	errors = multierr.Append(errors, err)
+// EndLogsOp completes the processing of the batch of logs started with StartLogsOp,
+// reporting the number of log records inserted, modified and dropped by the processor.
+func (por *Processor) EndLogsOp(processorCtx context.Context, numInserted, numModified, numDropped int, err error) {
+	por.endOp(processorCtx, component.DataTypeLogs, numInserted, numModified, numDropped, err)
+}
 
-	por.refusedLogRecordsCounter, err = meter.Int64Counter(
-		obsmetrics.ProcessorPrefix+obsmetrics.RefusedLogRecordsKey,
-		metric.WithDescription("Number of log records that were rejected by the next component in the pipeline."),
-		metric.WithUnit("1"),
-	)
-	errors = multierr.Append(errors, err)
+func (por *Processor) startOp(ctx context.Context, operationSuffix string) context.Context {
+	ctx, _ = por.tracer.Start(ctx, por.spanNamePrefix+operationSuffix)
+	return ctx
+}
 
-	por.droppedLogRecordsCounter, err = meter.Int64Counter(
-		obsmetrics.ProcessorPrefix+obsmetrics.DroppedLogRecordsKey,
-		metric.WithDescription("Number of log records that were dropped."),
-		metric.WithUnit("1"),
-	)
-	errors = multierr.Append(errors, err)
+// endOp records the observability signals at the end of the processing of a batch.
+func (por *Processor) endOp(processorCtx context.Context, dataType component.DataType, numInserted, numModified, numDropped int, err error) {
+	if por.level != configtelemetry.LevelNone {
+		por.recordData(processorCtx, dataType, itemCounts{
+			inserted: int64(numInserted),
+			modified: int64(numModified),
+			dropped:  int64(numDropped),
+		})
+	}
+	if err != nil {
+		failures.record(processorCtx, component.KindProcessor.String(), por.processorID, dataType, numDropped, err)
+	}
 
-	return errors
+	span := trace.SpanFromContext(processorCtx)
+	if span.IsRecording() {
+		insertedItemsKey, modifiedItemsKey, droppedItemsKey := processorItemsKeys(dataType)
+		span.SetAttributes(
+			attribute.Int64(insertedItemsKey, int64(numInserted)),
+			attribute.Int64(modifiedItemsKey, int64(numModified)),
+			attribute.Int64(droppedItemsKey, int64(numDropped)),
+		)
+		recordError(span, err)
+	}
+	span.End()
 }
 
-func (por *Processor) recordWithOtel(ctx context.Context, dataType component.DataType, accepted, refused, dropped int64) {
-	var acceptedCount, refusedCount, droppedCount metric.Int64Counter
+// processorItemsKeys returns the span attribute keys of the inserted, modified and dropped items of the data type.
+func processorItemsKeys(dataType component.DataType) (string, string, string) {
 	switch dataType {
 	case component.DataTypeTraces:
-		acceptedCount = por.acceptedSpansCounter
-		refusedCount = por.refusedSpansCounter
-		droppedCount = por.droppedSpansCounter
+		return obsmetrics.InsertedSpansKey, obsmetrics.ModifiedSpansKey, obsmetrics.DroppedSpansKey
 	case component.DataTypeMetrics:
-		acceptedCount = por.acceptedMetricPointsCounter
-		refusedCount = por.refusedMetricPointsCounter
-		droppedCount = por.droppedMetricPointsCounter
+		return obsmetrics.InsertedMetricPointsKey, obsmetrics.ModifiedMetricPointsKey, obsmetrics.DroppedMetricPointsKey
 	case component.DataTypeLogs:
-		acceptedCount = por.acceptedLogRecordsCounter
-		refusedCount = por.refusedLogRecordsCounter
-		droppedCount = por.droppedLogRecordsCounter
+		return obsmetrics.InsertedLogRecordsKey, obsmetrics.ModifiedLogRecordsKey, obsmetrics.DroppedLogRecordsKey
 	}
+	return "", "", ""
+}
 
-	acceptedCount.Add(ctx, accepted, metric.WithAttributes(por.otelAttrs...))
-	refusedCount.Add(ctx, refused, metric.WithAttributes(por.otelAttrs...))
-	droppedCount.Add(ctx, dropped, metric.WithAttributes(por.otelAttrs...))
+// itemCounts are the numbers of items of a batch recorded by the processor metrics.
+type itemCounts struct {
+	accepted int64
+	refused  int64
+	dropped  int64
+	inserted int64
+	modified int64
 }
 
-func (por *Processor) recordWithOC(ctx context.Context, dataType component.DataType, accepted, refused, dropped int64) {
-	var acceptedMeasure, refusedMeasure, droppedMeasure *stats.Int64Measure
+func (por *Processor) recordWithOtel(ctx context.Context, dataType component.DataType, counts itemCounts) {
+	var acceptedCount, refusedCount, droppedCount, insertedCount, modifiedCount metric.Int64Counter
+	switch dataType {
+	case component.DataTypeTraces:
+		acceptedCount = por.telemetryBuilder.ProcessorAcceptedSpans
+		refusedCount = por.telemetryBuilder.ProcessorRefusedSpans
+		droppedCount = por.telemetryBuilder.ProcessorDroppedSpans
+		insertedCount = por.telemetryBuilder.ProcessorInsertedSpans
+		modifiedCount = por.telemetryBuilder.ProcessorModifiedSpans
+	case component.DataTypeMetrics:
+		acceptedCount = por.telemetryBuilder.ProcessorAcceptedMetricPoints
+		refusedCount = por.telemetryBuilder.ProcessorRefusedMetricPoints
+		droppedCount = por.telemetryBuilder.ProcessorDroppedMetricPoints
+		insertedCount = por.telemetryBuilder.ProcessorInsertedMetricPoints
+		modifiedCount = por.telemetryBuilder.ProcessorModifiedMetricPoints
+	case component.DataTypeLogs:
+		acceptedCount = por.telemetryBuilder.ProcessorAcceptedLogRecords
+		refusedCount = por.telemetryBuilder.ProcessorRefusedLogRecords
+		droppedCount = por.telemetryBuilder.ProcessorDroppedLogRecords
+		insertedCount = por.telemetryBuilder.ProcessorInsertedLogRecords
+		modifiedCount = por.telemetryBuilder.ProcessorModifiedLogRecords
+	}
+
+	acceptedCount.Add(ctx, counts.accepted, por.otelAttrs)
+	refusedCount.Add(ctx, counts.refused, por.otelAttrs)
+	droppedCount.Add(ctx, counts.dropped, por.otelAttrs)
+	insertedCount.Add(ctx, counts.inserted, por.otelAttrs)
+	modifiedCount.Add(ctx, counts.modified, por.otelAttrs)
+}
+
+func (por *Processor) recordWithOC(ctx context.Context, dataType component.DataType, counts itemCounts) {
+	var acceptedMeasure, refusedMeasure, droppedMeasure, insertedMeasure, modifiedMeasure *stats.Int64Measure
 
 	switch dataType {
 	case component.DataTypeTraces:
 		acceptedMeasure = obsmetrics.ProcessorAcceptedSpans
 		refusedMeasure = obsmetrics.ProcessorRefusedSpans
 		droppedMeasure = obsmetrics.ProcessorDroppedSpans
+		insertedMeasure = obsmetrics.ProcessorInsertedSpans
+		modifiedMeasure = obsmetrics.ProcessorModifiedSpans
 	case component.DataTypeMetrics:
 		acceptedMeasure = obsmetrics.ProcessorAcceptedMetricPoints
 		refusedMeasure = obsmetrics.ProcessorRefusedMetricPoints
 		droppedMeasure = obsmetrics.ProcessorDroppedMetricPoints
+		insertedMeasure = obsmetrics.ProcessorInsertedMetricPoints
+		modifiedMeasure = obsmetrics.ProcessorModifiedMetricPoints
 	case component.DataTypeLogs:
 		acceptedMeasure = obsmetrics.ProcessorAcceptedLogRecords
 		refusedMeasure = obsmetrics.ProcessorRefusedLogRecords
 		droppedMeasure = obsmetrics.ProcessorDroppedLogRecords
+		insertedMeasure = obsmetrics.ProcessorInsertedLogRecords
+		modifiedMeasure = obsmetrics.ProcessorModifiedLogRecords
 	}
 
 	// ignore the error for now; should not happen
 	_ = stats.RecordWithTags(
 		ctx,
 		por.mutators,
-		acceptedMeasure.M(accepted),
-		refusedMeasure.M(refused),
-		droppedMeasure.M(dropped),
+		acceptedMeasure.M(counts.accepted),
+		refusedMeasure.M(counts.refused),
+		droppedMeasure.M(counts.dropped),
+		insertedMeasure.M(counts.inserted),
+		modifiedMeasure.M(counts.modified),
 	)
 }
 
-func (por *Processor) recordData(ctx context.Context, dataType component.DataType, accepted, refused, dropped int64) {
+func (por *Processor) recordData(ctx context.Context, dataType component.DataType, counts itemCounts) {
 	if por.useOtelForMetrics {
-		por.recordWithOtel(ctx, dataType, accepted, refused, dropped)
+		por.recordWithOtel(ctx, dataType, counts)
 	} else {
-		por.recordWithOC(ctx, dataType, accepted, refused, dropped)
+		por.recordWithOC(ctx, dataType, counts)
 	}
 }
 
 // TracesAccepted reports that the trace data was accepted.
 func (por *Processor) TracesAccepted(ctx context.Context, numSpans int) {
 	if por.level != configtelemetry.LevelNone {
-		por.recordData(ctx, component.DataTypeTraces, int64(numSpans), int64(0), int64(0))
+		por.recordData(ctx, component.DataTypeTraces, itemCounts{accepted: int64(numSpans)})
 	}
 }
 
 // TracesRefused reports that the trace data was refused.
 func (por *Processor) TracesRefused(ctx context.Context, numSpans int) {
 	if por.level != configtelemetry.LevelNone {
-		por.recordData(ctx, component.DataTypeTraces, int64(0), int64(numSpans), int64(0))
+		por.recordData(ctx, component.DataTypeTraces, itemCounts{refused: int64(numSpans)})
 	}
+	failures.record(ctx, component.KindProcessor.String(), por.processorID, component.DataTypeTraces, numSpans, nil)
 }
 
 // TracesDropped reports that the trace data was dropped.
 func (por *Processor) TracesDropped(ctx context.Context, numSpans int) {
 	if por.level != configtelemetry.LevelNone {
-		por.recordData(ctx, component.DataTypeTraces, int64(0), int64(0), int64(numSpans))
+		por.recordData(ctx, component.DataTypeTraces, itemCounts{dropped: int64(numSpans)})
+	}
+}
+
+// TracesInserted reports that the trace data was inserted by the processor.
+func (por *Processor) TracesInserted(ctx context.Context, numSpans int) {
+	if por.level != configtelemetry.LevelNone {
+		por.recordData(ctx, component.DataTypeTraces, itemCounts{inserted: int64(numSpans)})
+	}
+}
+
+// TracesModified reports that the trace data was modified by the processor.
+func (por *Processor) TracesModified(ctx context.Context, numSpans int) {
+	if por.level != configtelemetry.LevelNone {
+		por.recordData(ctx, component.DataTypeTraces, itemCounts{modified: int64(numSpans)})
 	}
 }
 
 // MetricsAccepted reports that the metrics were accepted.
 func (por *Processor) MetricsAccepted(ctx context.Context, numPoints int) {
 	if por.level != configtelemetry.LevelNone {
-		por.recordData(ctx, component.DataTypeMetrics, int64(numPoints), int64(0), int64(0))
+		por.recordData(ctx, component.DataTypeMetrics, itemCounts{accepted: int64(numPoints)})
 	}
 }
 
 // MetricsRefused reports that the metrics were refused.
 func (por *Processor) MetricsRefused(ctx context.Context, numPoints int) {
 	if por.level != configtelemetry.LevelNone {
-		por.recordData(ctx, component.DataTypeMetrics, int64(0), int64(numPoints), int64(0))
+		por.recordData(ctx, component.DataTypeMetrics, itemCounts{refused: int64(numPoints)})
 	}
+	failures.record(ctx, component.KindProcessor.String(), por.processorID, component.DataTypeMetrics, numPoints, nil)
 }
 
 // MetricsDropped reports that the metrics were dropped.
 func (por *Processor) MetricsDropped(ctx context.Context, numPoints int) {
 	if por.level != configtelemetry.LevelNone {
-		por.recordData(ctx, component.DataTypeMetrics, int64(0), int64(0), int64(numPoints))
+		por.recordData(ctx, component.DataTypeMetrics, itemCounts{dropped: int64(numPoints)})
+	}
+}
+
+// MetricsInserted reports that the metrics were inserted by the processor.
+func (por *Processor) MetricsInserted(ctx context.Context, numPoints int) {
+	if por.level != configtelemetry.LevelNone {
+		por.recordData(ctx, component.DataTypeMetrics, itemCounts{inserted: int64(numPoints)})
+	}
+}
+
+// MetricsModified reports that the metrics were modified by the processor.
+func (por *Processor) MetricsModified(ctx context.Context, numPoints int) {
+	if por.level != configtelemetry.LevelNone {
+		por.recordData(ctx, component.DataTypeMetrics, itemCounts{modified: int64(numPoints)})
 	}
 }
 
 // LogsAccepted reports that the logs were accepted.
 func (por *Processor) LogsAccepted(ctx context.Context, numRecords int) {
 	if por.level != configtelemetry.LevelNone {
-		por.recordData(ctx, component.DataTypeLogs, int64(numRecords), int64(0), int64(0))
+		por.recordData(ctx, component.DataTypeLogs, itemCounts{accepted: int64(numRecords)})
 	}
 }
 
 // LogsRefused reports that the logs were refused.
 func (por *Processor) LogsRefused(ctx context.Context, numRecords int) {
 	if por.level != configtelemetry.LevelNone {
-		por.recordData(ctx, component.DataTypeLogs, int64(0), int64(numRecords), int64(0))
+		por.recordData(ctx, component.DataTypeLogs, itemCounts{refused: int64(numRecords)})
 	}
+	failures.record(ctx, component.KindProcessor.String(), por.processorID, component.DataTypeLogs, numRecords, nil)
 }
 
 // LogsDropped reports that the logs were dropped.
 func (por *Processor) LogsDropped(ctx context.Context, numRecords int) {
 	if por.level != configtelemetry.LevelNone {
-		por.recordData(ctx, component.DataTypeLogs, int64(0), int64(0), int64(numRecords))
+		por.recordData(ctx, component.DataTypeLogs, itemCounts{dropped: int64(numRecords)})
+	}
+}
+
+// LogsInserted reports that the logs were inserted by the processor.
+func (por *Processor) LogsInserted(ctx context.Context, numRecords int) {
+	if por.level != configtelemetry.LevelNone {
+		por.recordData(ctx, component.DataTypeLogs, itemCounts{inserted: int64(numRecords)})
+	}
+}
+
+// LogsModified reports that the logs were modified by the processor.
+func (por *Processor) LogsModified(ctx context.Context, numRecords int) {
+	if por.level != configtelemetry.LevelNone {
+		por.recordData(ctx, component.DataTypeLogs, itemCounts{modified: int64(numRecords)})
 	}
 }
@@ -13,42 +13,34 @@ import (
 	"go.opentelemetry.io/otel/metric"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/trace"
-	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/internal/obsreportconfig"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/obsreport/internal/receivertelemetry"
 	"go.opentelemetry.io/collector/receiver"
 )
 
-const (
-	receiverName = "receiver"
-
-	receiverScope = scopeName + nameSep + receiverName
-)
-
 // Receiver is a helper to add observability to a receiver.
 type Receiver struct {
 	level          configtelemetry.Level
+	receiverID     string
 	spanNamePrefix string
 	transport      string
 	longLivedCtx   bool
 	mutators       []tag.Mutator
 	tracer         trace.Tracer
-	meter          metric.Meter
 	logger         *zap.Logger
+	// networkAttrs records the network-level attributes of the clients from client.Info.
+	networkAttrs bool
 
 	useOtelForMetrics bool
-	otelAttrs         []attribute.KeyValue
-
-	acceptedSpansCounter        metric.Int64Counter
-	refusedSpansCounter         metric.Int64Counter
-	acceptedMetricPointsCounter metric.Int64Counter
-	refusedMetricPointsCounter  metric.Int64Counter
-	acceptedLogRecordsCounter   metric.Int64Counter
-	refusedLogRecordsCounter    metric.Int64Counter
+	otelAttrs         metric.MeasurementOption
+	telemetryBuilder  *receivertelemetry.TelemetryBuilder
 }
 
 // ReceiverSettings are settings for creating an Receiver.
@@ -60,6 +52,7 @@ type ReceiverSettings struct {
 	// Typically the long lived context is associated to a connection,
 	// eg.: a gRPC stream, for which many batches of data are received in individual
 	// operations without a corresponding new context per operation.
+	// Receivers of streams should rather use Receiver.StartStream.
 	LongLivedCtx           bool
 	ReceiverCreateSettings receiver.CreateSettings
 }
@@ -72,6 +65,7 @@ func NewReceiver(cfg ReceiverSettings) (*Receiver, error) {
 func newReceiver(cfg ReceiverSettings, useOtel bool) (*Receiver, error) {
 	rec := &Receiver{
 		level:          cfg.ReceiverCreateSettings.TelemetrySettings.MetricsLevel,
+		receiverID:     cfg.ReceiverID.String(),
 		spanNamePrefix: obsmetrics.ReceiverPrefix + cfg.ReceiverID.String(),
 		transport:      cfg.Transport,
 		longLivedCtx:   cfg.LongLivedCtx,
@@ -79,85 +73,40 @@ func newReceiver(cfg ReceiverSettings, useOtel bool) (*Receiver, error) {
 			tag.Upsert(obsmetrics.TagKeyReceiver, cfg.ReceiverID.String(), tag.WithTTL(tag.TTLNoPropagation)),
 			tag.Upsert(obsmetrics.TagKeyTransport, cfg.Transport, tag.WithTTL(tag.TTLNoPropagation)),
 		},
-		tracer: cfg.ReceiverCreateSettings.TracerProvider.Tracer(cfg.ReceiverID.String()),
-		meter:  cfg.ReceiverCreateSettings.MeterProvider.Meter(receiverScope),
-		logger: cfg.ReceiverCreateSettings.Logger,
+		tracer:       cfg.ReceiverCreateSettings.TracerProvider.Tracer(cfg.ReceiverID.String()),
+		logger:       cfg.ReceiverCreateSettings.Logger,
+		networkAttrs: obsreportconfig.ReceiverNetworkAttributesFeatureGate.IsEnabled(),
 
 		useOtelForMetrics: useOtel,
-		otelAttrs: []attribute.KeyValue{
-			attribute.String(obsmetrics.ReceiverKey, cfg.ReceiverID.String()),
-			attribute.String(obsmetrics.TransportKey, cfg.Transport),
-		},
+		otelAttrs:         receivertelemetry.ReceiverTransportAttributeSet(cfg.ReceiverID.String(), cfg.Transport),
 	}
 
 	// ignore instrument name error as per workaround in https://github.com/open-telemetry/opentelemetry-collector/issues/8346
-	// if err := rec.createOtelMetrics(); err != nil {
+	// if err := rec.createOtelMetrics(cfg); err != nil {
 	// 	return nil, err
 	// }
-	if err := rec.createOtelMetrics(); err != nil && !errors.Is(err, sdkmetric.ErrInstrumentName) {
+	if err := rec.createOtelMetrics(cfg); err != nil && !errors.Is(err, sdkmetric.ErrInstrumentName) {
 		return nil, err
 	}
 
 	return rec, nil
 }
 
-func (rec *Receiver) createOtelMetrics() error {
+func (rec *Receiver) createOtelMetrics(cfg ReceiverSettings) error {
 	if !rec.useOtelForMetrics {
 		return nil
 	}
 
-	var errors, err error
-
-	rec.acceptedSpansCounter, err = rec.meter.Int64Counter(
-		obsmetrics.ReceiverPrefix+obsmetrics.AcceptedSpansKey,
-		metric.WithDescription("Number of spans successfully pushed into the pipeline."),
-		metric.WithUnit("1"),
-	)
-	errors = multierr.Append(errors, err)
-
-	rec.refusedSpansCounter, err = rec.meter.Int64Counter(
-		obsmetrics.ReceiverPrefix+obsmetrics.RefusedSpansKey,
-		metric.WithDescription("Number of spans that could not be pushed into the pipeline."),
-		metric.WithUnit("1"),
-	)
-	errors = multierr.Append(errors, err)
-
-	rec.acceptedMetricPointsCounter, err = rec.meter.Int64Counter(
-		obsmetrics.ReceiverPrefix+obsmetrics.AcceptedMetricPointsKey,
-		metric.WithDescription("Number of metric points successfully pushed into the pipeline."),
-		metric.WithUnit("1"),
-	)
-	errors = multierr.Append(errors, err)
-
-	rec.refusedMetricPointsCounter, err = rec.meter.Int64Counter(
-		obsmetrics.ReceiverPrefix+obsmetrics.RefusedMetricPointsKey,
-		metric.WithDescription("Number of metric points that could not be pushed into the pipeline."),
-		metric.WithUnit("1"),
-	)
-	errors = multierr.Append(errors, err)
-
-	rec.acceptedLogRecordsCounter, err = rec.meter.Int64Counter(
-		obsmetrics.ReceiverPrefix+obsmetrics.AcceptedLogRecordsKey,
-		metric.WithDescription("Number of log records successfully pushed into the pipeline."),
-		metric.WithUnit("1"),
-	)
-	errors = multierr.Append(errors, err)
-
-	rec.refusedLogRecordsCounter, err = rec.meter.Int64Counter(
-		obsmetrics.ReceiverPrefix+obsmetrics.RefusedLogRecordsKey,
-		metric.WithDescription("Number of log records that could not be pushed into the pipeline."),
-		metric.WithUnit("1"),
-	)
-	errors = multierr.Append(errors, err)
-
-	return errors
+	var err error
+	rec.telemetryBuilder, err = receivertelemetry.NewTelemetryBuilder(cfg.ReceiverCreateSettings.MeterProvider)
+	return err
 }
 
 // StartTracesOp is called when a request is received from a client.
 // The returned context should be used in other calls to the obsreport functions
 // dealing with the same receive operation.
 func (rec *Receiver) StartTracesOp(operationCtx context.Context) context.Context {
-	return rec.startOp(operationCtx, obsmetrics.ReceiveTraceDataOperationSuffix)
+	return rec.startOp(operationCtx, obsmetrics.ReceiveTraceDataOperationSuffix, rec.longLivedCtx)
 }
 
 // EndTracesOp completes the receive operation that was started with
@@ -175,7 +124,7 @@ func (rec *Receiver) EndTracesOp(
 // The returned context should be used in other calls to the obsreport functions
 // dealing with the same receive operation.
 func (rec *Receiver) StartLogsOp(operationCtx context.Context) context.Context {
-	return rec.startOp(operationCtx, obsmetrics.ReceiverLogsOperationSuffix)
+	return rec.startOp(operationCtx, obsmetrics.ReceiverLogsOperationSuffix, rec.longLivedCtx)
 }
 
 // EndLogsOp completes the receive operation that was started with
@@ -193,7 +142,7 @@ func (rec *Receiver) EndLogsOp(
 // The returned context should be used in other calls to the obsreport functions
 // dealing with the same receive operation.
 func (rec *Receiver) StartMetricsOp(operationCtx context.Context) context.Context {
-	return rec.startOp(operationCtx, obsmetrics.ReceiverMetricsOperationSuffix)
+	return rec.startOp(operationCtx, obsmetrics.ReceiverMetricsOperationSuffix, rec.longLivedCtx)
 }
 
 // EndMetricsOp completes the receive operation that was started with
@@ -209,11 +158,11 @@ func (rec *Receiver) EndMetricsOp(
 
 // startOp creates the span used to trace the operation. Returning
 // the updated context with the created span.
-func (rec *Receiver) startOp(receiverCtx context.Context, operationSuffix string) context.Context {
+func (rec *Receiver) startOp(receiverCtx context.Context, operationSuffix string, longLivedCtx bool) context.Context {
 	ctx, _ := tag.New(receiverCtx, rec.mutators...)
 	var span trace.Span
 	spanName := rec.spanNamePrefix + operationSuffix
-	if !rec.longLivedCtx {
+	if !longLivedCtx {
 		ctx, span = rec.tracer.Start(ctx, spanName)
 	} else {
 		// Since the receiverCtx is long lived do not use it to start the span.
@@ -229,9 +178,27 @@ func (rec *Receiver) startOp(receiverCtx context.Context, operationSuffix string
 	if rec.transport != "" {
 		span.SetAttributes(attribute.String(obsmetrics.TransportKey, rec.transport))
 	}
+	if rec.networkAttrs && span.IsRecording() {
+		span.SetAttributes(networkAttributes(client.FromContext(receiverCtx))...)
+	}
 	return ctx
 }
 
+// networkAttributes returns the span attributes of the peer address, protocol and TLS version of the client.
+func networkAttributes(cl client.Info) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	if cl.Addr != nil {
+		attrs = append(attrs, attribute.String(obsmetrics.PeerAddressKey, cl.Addr.String()))
+	}
+	if cl.Protocol != "" {
+		attrs = append(attrs, attribute.String(obsmetrics.ProtocolKey, cl.Protocol))
+	}
+	if cl.TLSVersion != "" {
+		attrs = append(attrs, attribute.String(obsmetrics.TLSVersionKey, cl.TLSVersion))
+	}
+	return attrs
+}
+
 // endOp records the observability signals at the end of an operation.
 func (rec *Receiver) endOp(
 	receiverCtx context.Context,
@@ -242,84 +209,130 @@ func (rec *Receiver) endOp(
 ) {
 	numAccepted := numReceivedItems
 	numRefused := 0
+	numDroppedOnShutdown := 0
 	if err != nil {
 		numAccepted = 0
-		numRefused = numReceivedItems
+		// The data refused because the pipeline is shutting down is counted separately,
+		// not to be mistaken for failures.
+		if consumererror.IsShutdown(err) {
+			numDroppedOnShutdown = numReceivedItems
+		} else {
+			numRefused = numReceivedItems
+			// Only the items rejected are refused when the pipeline reports a partial success.
+			if p, ok := consumererror.AsPartialSuccess(err); ok && p.Rejected() < numReceivedItems {
+				numRefused = p.Rejected()
+				numAccepted = numReceivedItems - numRefused
+			}
+		}
 	}
 
 	span := trace.SpanFromContext(receiverCtx)
 
 	if rec.level != configtelemetry.LevelNone {
-		rec.recordMetrics(receiverCtx, dataType, numAccepted, numRefused)
+		rec.recordMetrics(receiverCtx, dataType, numAccepted, numRefused, numDroppedOnShutdown)
+	}
+	if err != nil && numDroppedOnShutdown == 0 {
+		failures.record(receiverCtx, component.KindReceiver.String(), rec.receiverID, dataType, numRefused, err)
 	}
 
 	// end span according to errors
 	if span.IsRecording() {
-		var acceptedItemsKey, refusedItemsKey string
-		switch dataType {
-		case component.DataTypeTraces:
-			acceptedItemsKey = obsmetrics.AcceptedSpansKey
-			refusedItemsKey = obsmetrics.RefusedSpansKey
-		case component.DataTypeMetrics:
-			acceptedItemsKey = obsmetrics.AcceptedMetricPointsKey
-			refusedItemsKey = obsmetrics.RefusedMetricPointsKey
-		case component.DataTypeLogs:
-			acceptedItemsKey = obsmetrics.AcceptedLogRecordsKey
-			refusedItemsKey = obsmetrics.RefusedLogRecordsKey
-		}
-
+		acceptedItemsKey, refusedItemsKey, droppedOnShutdownItemsKey := itemsKeys(dataType)
 		span.SetAttributes(
 			attribute.String(obsmetrics.FormatKey, format),
 			attribute.Int64(acceptedItemsKey, int64(numAccepted)),
 			attribute.Int64(refusedItemsKey, int64(numRefused)),
 		)
+		if numDroppedOnShutdown > 0 {
+			span.SetAttributes(attribute.Int64(droppedOnShutdownItemsKey, int64(numDroppedOnShutdown)))
+		}
 		recordError(span, err)
 	}
 	span.End()
 }
 
-func (rec *Receiver) recordMetrics(receiverCtx context.Context, dataType component.DataType, numAccepted, numRefused int) {
+// itemsKeys returns the span attribute keys of the accepted, refused and dropped on shutdown items of the data type.
+func itemsKeys(dataType component.DataType) (string, string, string) {
+	switch dataType {
+	case component.DataTypeTraces:
+		return obsmetrics.AcceptedSpansKey, obsmetrics.RefusedSpansKey, obsmetrics.DroppedOnShutdownSpansKey
+	case component.DataTypeMetrics:
+		return obsmetrics.AcceptedMetricPointsKey, obsmetrics.RefusedMetricPointsKey, obsmetrics.DroppedOnShutdownMetricPointsKey
+	case component.DataTypeLogs:
+		return obsmetrics.AcceptedLogRecordsKey, obsmetrics.RefusedLogRecordsKey, obsmetrics.DroppedOnShutdownLogRecordsKey
+	}
+	return "", "", ""
+}
+
+func (rec *Receiver) recordMetrics(receiverCtx context.Context, dataType component.DataType, numAccepted, numRefused, numDroppedOnShutdown int) {
 	if rec.useOtelForMetrics {
-		rec.recordWithOtel(receiverCtx, dataType, numAccepted, numRefused)
+		rec.recordWithOtel(receiverCtx, dataType, numAccepted, numRefused, numDroppedOnShutdown)
 	} else {
-		rec.recordWithOC(receiverCtx, dataType, numAccepted, numRefused)
+		rec.recordWithOC(receiverCtx, dataType, numAccepted, numRefused, numDroppedOnShutdown)
 	}
 }
 
-func (rec *Receiver) recordWithOtel(receiverCtx context.Context, dataType component.DataType, numAccepted, numRefused int) {
-	var acceptedMeasure, refusedMeasure metric.Int64Counter
+func (rec *Receiver) recordWithOtel(receiverCtx context.Context, dataType component.DataType, numAccepted, numRefused, numDroppedOnShutdown int) {
+	var acceptedMeasure, refusedMeasure, droppedOnShutdownMeasure metric.Int64Counter
 	switch dataType {
 	case component.DataTypeTraces:
-		acceptedMeasure = rec.acceptedSpansCounter
-		refusedMeasure = rec.refusedSpansCounter
+		acceptedMeasure = rec.telemetryBuilder.ReceiverAcceptedSpans
+		refusedMeasure = rec.telemetryBuilder.ReceiverRefusedSpans
+		droppedOnShutdownMeasure = rec.telemetryBuilder.ReceiverDroppedOnShutdownSpans
 	case component.DataTypeMetrics:
-		acceptedMeasure = rec.acceptedMetricPointsCounter
-		refusedMeasure = rec.refusedMetricPointsCounter
+		acceptedMeasure = rec.telemetryBuilder.ReceiverAcceptedMetricPoints
+		refusedMeasure = rec.telemetryBuilder.ReceiverRefusedMetricPoints
+		droppedOnShutdownMeasure = rec.telemetryBuilder.ReceiverDroppedOnShutdownMetricPoints
 	case component.DataTypeLogs:
-		acceptedMeasure = rec.acceptedLogRecordsCounter
-		refusedMeasure = rec.refusedLogRecordsCounter
+		acceptedMeasure = rec.telemetryBuilder.ReceiverAcceptedLogRecords
+		refusedMeasure = rec.telemetryBuilder.ReceiverRefusedLogRecords
+		droppedOnShutdownMeasure = rec.telemetryBuilder.ReceiverDroppedOnShutdownLogRecords
 	}
 
-	acceptedMeasure.Add(receiverCtx, int64(numAccepted), metric.WithAttributes(rec.otelAttrs...))
-	refusedMeasure.Add(receiverCtx, int64(numRefused), metric.WithAttributes(rec.otelAttrs...))
+	attrs := rec.otelAttrs
+	if rec.networkAttrs {
+		attrs = metric.WithAttributeSet(attribute.NewSet(
+			attribute.String(obsmetrics.ReceiverKey, rec.receiverID),
+			attribute.String(obsmetrics.TransportKey, rec.transport),
+			attribute.String(obsmetrics.ProtocolKey, client.FromContext(receiverCtx).Protocol),
+		))
+	}
+	acceptedMeasure.Add(receiverCtx, int64(numAccepted), attrs)
+	refusedMeasure.Add(receiverCtx, int64(numRefused), attrs)
+	if numDroppedOnShutdown > 0 {
+		droppedOnShutdownMeasure.Add(receiverCtx, int64(numDroppedOnShutdown), attrs)
+	}
 }
 
-func (rec *Receiver) recordWithOC(receiverCtx context.Context, dataType component.DataType, numAccepted, numRefused int) {
-	var acceptedMeasure, refusedMeasure *stats.Int64Measure
+func (rec *Receiver) recordWithOC(receiverCtx context.Context, dataType component.DataType, numAccepted, numRefused, numDroppedOnShutdown int) {
+	var acceptedMeasure, refusedMeasure, droppedOnShutdownMeasure *stats.Int64Measure
 	switch dataType {
 	case component.DataTypeTraces:
 		acceptedMeasure = obsmetrics.ReceiverAcceptedSpans
 		refusedMeasure = obsmetrics.ReceiverRefusedSpans
+		droppedOnShutdownMeasure = obsmetrics.ReceiverDroppedOnShutdownSpans
 	case component.DataTypeMetrics:
 		acceptedMeasure = obsmetrics.ReceiverAcceptedMetricPoints
 		refusedMeasure = obsmetrics.ReceiverRefusedMetricPoints
+		droppedOnShutdownMeasure = obsmetrics.ReceiverDroppedOnShutdownMetricPoints
 	case component.DataTypeLogs:
 		acceptedMeasure = obsmetrics.ReceiverAcceptedLogRecords
 		refusedMeasure = obsmetrics.ReceiverRefusedLogRecords
+		droppedOnShutdownMeasure = obsmetrics.ReceiverDroppedOnShutdownLogRecords
 	}
 
-	stats.Record(
-		receiverCtx,
+	measurements := []stats.Measurement{
 		acceptedMeasure.M(int64(numAccepted)),
-		refusedMeasure.M(int64(numRefused)))
+		refusedMeasure.M(int64(numRefused)),
+	}
+	if numDroppedOnShutdown > 0 {
+		measurements = append(measurements, droppedOnShutdownMeasure.M(int64(numDroppedOnShutdown)))
+	}
+	if rec.networkAttrs {
+		_ = stats.RecordWithTags(receiverCtx, []tag.Mutator{
+			tag.Upsert(obsmetrics.TagKeyProtocol, client.FromContext(receiverCtx).Protocol, tag.WithTTL(tag.TTLNoPropagation)),
+		}, measurements...)
+		return
+	}
+	stats.Record(receiverCtx, measurements...)
 }
@@ -13,22 +13,17 @@ import (
 	"go.opentelemetry.io/otel/metric"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/trace"
-	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/internal/obsreportconfig"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/obsreport/internal/scrapertelemetry"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/scrapererror"
 )
 
-var (
-	scraperName  = "scraper"
-	scraperScope = scopeName + nameSep + scraperName
-)
-
 // Scraper is a helper to add observability to a scraper.
 type Scraper struct {
 	level      configtelemetry.Level
@@ -39,10 +34,9 @@ type Scraper struct {
 
 	logger *zap.Logger
 
-	useOtelForMetrics    bool
-	otelAttrs            []attribute.KeyValue
-	scrapedMetricsPoints metric.Int64Counter
-	erroredMetricsPoints metric.Int64Counter
+	useOtelForMetrics bool
+	otelAttrs         metric.MeasurementOption
+	telemetryBuilder  *scrapertelemetry.TelemetryBuilder
 }
 
 // ScraperSettings are settings for creating a Scraper.
@@ -69,10 +63,7 @@ func newScraper(cfg ScraperSettings, useOtel bool) (*Scraper, error) {
 
 		logger:            cfg.ReceiverCreateSettings.Logger,
 		useOtelForMetrics: useOtel,
-		otelAttrs: []attribute.KeyValue{
-			attribute.String(obsmetrics.ReceiverKey, cfg.ReceiverID.String()),
-			attribute.String(obsmetrics.ScraperKey, cfg.Scraper.String()),
-		},
+		otelAttrs:         scrapertelemetry.ReceiverScraperAttributeSet(cfg.ReceiverID.String(), cfg.Scraper.String()),
 	}
 
 	// ignore instrument name error as per workaround in https://github.com/open-telemetry/opentelemetry-collector/issues/8346
@@ -90,25 +81,10 @@ func (s *Scraper) createOtelMetrics(cfg ScraperSettings) error {
 	if !s.useOtelForMetrics {
 		return nil
 	}
-	meter := cfg.ReceiverCreateSettings.MeterProvider.Meter(scraperScope)
-
-	var errors, err error
-
-	s.scrapedMetricsPoints, err = meter.Int64Counter(
-		obsmetrics.ScraperPrefix+obsmetrics.ScrapedMetricPointsKey,
-		metric.WithDescription("Number of metric points successfully scraped."),
-		metric.WithUnit("1"),
-	)
-	errors = multierr.Append(errors, err)
 
-	s.erroredMetricsPoints, err = meter.Int64Counter(
-		obsmetrics.ScraperPrefix+obsmetrics.ErroredMetricPointsKey,
-		metric.WithDescription("Number of metric points that were unable to be scraped."),
-		metric.WithUnit("1"),
-	)
-	errors = multierr.Append(errors, err)
-
-	return errors
+	var err error
+	s.telemetryBuilder, err = scrapertelemetry.NewTelemetryBuilder(cfg.ReceiverCreateSettings.MeterProvider)
+	return err
 }
 
 // StartMetricsOp is called when a scrape operation is started. The
@@ -145,6 +121,9 @@ func (s *Scraper) EndMetricsOp(
 	if s.level != configtelemetry.LevelNone {
 		s.recordMetrics(scraperCtx, numScrapedMetrics, numErroredMetrics)
 	}
+	if err != nil {
+		failures.record(scraperCtx, "scraper", s.receiverID.String()+"/"+s.scraper.String(), component.DataTypeMetrics, numErroredMetrics, err)
+	}
 
 	// end span according to errors
 	if span.IsRecording() {
@@ -161,8 +140,8 @@ func (s *Scraper) EndMetricsOp(
 
 func (s *Scraper) recordMetrics(scraperCtx context.Context, numScrapedMetrics, numErroredMetrics int) {
 	if s.useOtelForMetrics {
-		s.scrapedMetricsPoints.Add(scraperCtx, int64(numScrapedMetrics), metric.WithAttributes(s.otelAttrs...))
-		s.erroredMetricsPoints.Add(scraperCtx, int64(numErroredMetrics), metric.WithAttributes(s.otelAttrs...))
+		s.telemetryBuilder.ScraperScrapedMetricPoints.Add(scraperCtx, int64(numScrapedMetrics), s.otelAttrs)
+		s.telemetryBuilder.ScraperErroredMetricPoints.Add(scraperCtx, int64(numErroredMetrics), s.otelAttrs)
 	} else { // OC for metrics
 		stats.Record(
 			scraperCtx,
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package obsreport // import "go.opentelemetry.io/collector/obsreport"
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+)
+
+// Stream records the observability signals of a long-lived stream, like a gRPC stream
+// or a websocket, carrying many batches. It is created by Receiver.StartStream.
+//
+// The stream is traced by a span child of the context of the connection, while each batch
+// is traced by its own span, linked to the stream span. The batches are counted by the
+// receiver metrics, as if they were received by distinct requests, and the stream span
+// records the number of batches and of items received on the stream.
+type Stream struct {
+	rec  *Receiver
+	ctx  context.Context
+	span trace.Span
+
+	mu      sync.Mutex
+	batches int64
+	items   map[component.DataType]*streamItems
+}
+
+type streamItems struct {
+	accepted          int64
+	refused           int64
+	droppedOnShutdown int64
+}
+
+// StartStream is called when a client opens a long-lived stream, streamCtx being the context
+// of the connection. The returned Stream must be ended with Stream.End once the stream is closed.
+func (rec *Receiver) StartStream(streamCtx context.Context) *Stream {
+	ctx, span := rec.tracer.Start(streamCtx, rec.spanNamePrefix+obsmetrics.ReceiverStreamOperationSuffix)
+	if rec.transport != "" {
+		span.SetAttributes(attribute.String(obsmetrics.TransportKey, rec.transport))
+	}
+	return &Stream{
+		rec:   rec,
+		ctx:   ctx,
+		span:  span,
+		items: map[component.DataType]*streamItems{},
+	}
+}
+
+// RecordBatch records a batch of numItems spans, metric points or log records, depending
+// on dataType, received on the stream. It calls consume with the context of the batch,
+// which must be used to push the batch into the pipeline, and returns its error.
+func (s *Stream) RecordBatch(dataType component.DataType, format string, numItems int, consume func(ctx context.Context) error) error {
+	var operationSuffix string
+	switch dataType {
+	case component.DataTypeTraces:
+		operationSuffix = obsmetrics.ReceiveTraceDataOperationSuffix
+	case component.DataTypeMetrics:
+		operationSuffix = obsmetrics.ReceiverMetricsOperationSuffix
+	case component.DataTypeLogs:
+		operationSuffix = obsmetrics.ReceiverLogsOperationSuffix
+	}
+
+	// Since the stream context is long-lived, each batch is traced by its own span linked to the stream span.
+	ctx := s.rec.startOp(s.ctx, operationSuffix, true)
+	err := consume(ctx)
+	s.rec.endOp(ctx, format, numItems, err, dataType)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batches++
+	items, ok := s.items[dataType]
+	if !ok {
+		items = &streamItems{}
+		s.items[dataType] = items
+	}
+	switch {
+	case err == nil:
+		items.accepted += int64(numItems)
+	case consumererror.IsShutdown(err):
+		items.droppedOnShutdown += int64(numItems)
+	default:
+		items.refused += int64(numItems)
+	}
+	return err
+}
+
+// End completes the stream started with Receiver.StartStream, err being the error closing the stream, if any.
+func (s *Stream) End(err error) {
+	if s.span.IsRecording() {
+		s.mu.Lock()
+		s.span.SetAttributes(attribute.Int64(obsmetrics.BatchesKey, s.batches))
+		for dataType, items := range s.items {
+			acceptedItemsKey, refusedItemsKey, droppedOnShutdownItemsKey := itemsKeys(dataType)
+			s.span.SetAttributes(
+				attribute.Int64(acceptedItemsKey, items.accepted),
+				attribute.Int64(refusedItemsKey, items.refused),
+			)
+			if items.droppedOnShutdown > 0 {
+				s.span.SetAttributes(attribute.Int64(droppedOnShutdownItemsKey, items.droppedOnShutdown))
+			}
+		}
+		s.mu.Unlock()
+		recordError(s.span, err)
+	}
+	s.span.End()
+}
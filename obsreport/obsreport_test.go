@@ -6,16 +6,27 @@ package obsreport
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/trace"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
 	"go.opentelemetry.io/collector/receiver/scrapererror"
 )
 
@@ -458,6 +469,159 @@ func TestReceiveWithLongLivedCtx(t *testing.T) {
 	}
 }
 
+func TestReceiveStream(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		connCtx, connSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
+		stream := rec.StartStream(connCtx)
+		params := []testParams{
+			{items: 17, err: nil},
+			{items: 23, err: errFake},
+			{items: 5, err: nil},
+		}
+		for _, p := range params {
+			assert.Equal(t, p.err, stream.RecordBatch(component.DataTypeTraces, format, p.items, func(ctx context.Context) error {
+				// The batch is traced by its own span.
+				assert.NotEqual(t, trace.SpanContextFromContext(connCtx).TraceID(), trace.SpanContextFromContext(ctx).TraceID())
+				return p.err
+			}))
+		}
+		stream.End(nil)
+		connSpan.End()
+
+		spans := tt.SpanRecorder.Ended()
+		require.Equal(t, len(params)+2, len(spans))
+		streamSpan := spans[len(params)]
+		assert.Equal(t, "receiver/"+receiverID.String()+"/Stream", streamSpan.Name())
+		assert.Equal(t, connSpan.SpanContext().SpanID(), streamSpan.Parent().SpanID())
+		require.Contains(t, streamSpan.Attributes(), attribute.KeyValue{Key: obsmetrics.BatchesKey, Value: attribute.Int64Value(3)})
+		require.Contains(t, streamSpan.Attributes(), attribute.KeyValue{Key: obsmetrics.AcceptedSpansKey, Value: attribute.Int64Value(22)})
+		require.Contains(t, streamSpan.Attributes(), attribute.KeyValue{Key: obsmetrics.RefusedSpansKey, Value: attribute.Int64Value(23)})
+		assert.Equal(t, codes.Unset, streamSpan.Status().Code)
+
+		for _, span := range spans[:len(params)] {
+			assert.Equal(t, "receiver/"+receiverID.String()+"/TraceDataReceived", span.Name())
+			assert.False(t, span.Parent().IsValid())
+			require.Equal(t, 1, len(span.Links()))
+			assert.Equal(t, streamSpan.SpanContext().SpanID(), span.Links()[0].SpanContext.SpanID())
+		}
+		require.NoError(t, tt.CheckReceiverTraces(transport, 22, 23))
+	})
+}
+
+func TestReceiveDroppedOnShutdown(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := rec.StartTracesOp(context.Background())
+		rec.EndTracesOp(ctx, format, 7, nil)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOp(ctx, format, 13, consumererror.NewShutdown(errFake))
+		ctx = rec.StartLogsOp(context.Background())
+		rec.EndLogsOp(ctx, format, 5, fmt.Errorf("wrapped: %w", consumererror.NewShutdown(errFake)))
+		ctx = rec.StartMetricsOp(context.Background())
+		rec.EndMetricsOp(ctx, format, 3, consumererror.NewShutdown(errFake))
+
+		spans := tt.SpanRecorder.Ended()
+		require.Equal(t, 4, len(spans))
+		require.Contains(t, spans[1].Attributes(), attribute.KeyValue{Key: obsmetrics.RefusedSpansKey, Value: attribute.Int64Value(0)})
+		require.Contains(t, spans[1].Attributes(), attribute.KeyValue{Key: obsmetrics.DroppedOnShutdownSpansKey, Value: attribute.Int64Value(13)})
+		assert.Equal(t, codes.Error, spans[1].Status().Code)
+
+		require.NoError(t, tt.CheckReceiverTraces(transport, 7, 0))
+		require.NoError(t, tt.CheckReceiverDroppedOnShutdown(transport, component.DataTypeTraces, 13))
+		require.NoError(t, tt.CheckReceiverLogs(transport, 0, 0))
+		require.NoError(t, tt.CheckReceiverDroppedOnShutdown(transport, component.DataTypeLogs, 5))
+		require.NoError(t, tt.CheckReceiverMetrics(transport, 0, 0))
+		require.NoError(t, tt.CheckReceiverDroppedOnShutdown(transport, component.DataTypeMetrics, 3))
+	})
+}
+
+func TestExportDroppedOnShutdown(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := obsrep.StartTracesOp(context.Background())
+		obsrep.EndTracesOp(ctx, 7, nil)
+		ctx = obsrep.StartTracesOp(context.Background())
+		obsrep.EndTracesOp(ctx, 13, consumererror.NewShutdown(errFake))
+		ctx = obsrep.StartLogsOp(context.Background())
+		obsrep.EndLogsOp(ctx, 5, consumererror.NewShutdown(errFake))
+		ctx = obsrep.StartMetricsOp(context.Background())
+		obsrep.EndMetricsOp(ctx, 3, consumererror.NewShutdown(errFake))
+
+		spans := tt.SpanRecorder.Ended()
+		require.Equal(t, 4, len(spans))
+		require.Contains(t, spans[1].Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendSpansKey, Value: attribute.Int64Value(0)})
+		require.Contains(t, spans[1].Attributes(), attribute.KeyValue{Key: obsmetrics.DroppedOnShutdownSpansKey, Value: attribute.Int64Value(13)})
+
+		require.NoError(t, tt.CheckExporterTraces(7, 0))
+		require.NoError(t, tt.CheckExporterDroppedOnShutdown(component.DataTypeTraces, 13))
+		require.NoError(t, tt.CheckExporterDroppedOnShutdown(component.DataTypeLogs, 5))
+		require.NoError(t, tt.CheckExporterDroppedOnShutdown(component.DataTypeMetrics, 3))
+	})
+}
+
+func TestReceivePartialSuccess(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := rec.StartTracesOp(context.Background())
+		rec.EndTracesOp(ctx, format, 10, consumererror.NewPartialSuccess(errFake, 3))
+		// A partial success rejecting more items than received refuses all of them.
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOp(ctx, format, 2, fmt.Errorf("wrapped: %w", consumererror.NewPartialSuccess(errFake, 5)))
+
+		spans := tt.SpanRecorder.Ended()
+		require.Equal(t, 2, len(spans))
+		require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.AcceptedSpansKey, Value: attribute.Int64Value(7)})
+		require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.RefusedSpansKey, Value: attribute.Int64Value(3)})
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+
+		require.NoError(t, tt.CheckReceiverTraces(transport, 7, 5))
+	})
+}
+
+func TestExportPartialSuccess(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := obsrep.StartTracesOp(context.Background())
+		obsrep.EndTracesOp(ctx, 10, consumererror.NewPartialSuccess(consumererror.NewPermanent(errFake), 4))
+
+		spans := tt.SpanRecorder.Ended()
+		require.Equal(t, 1, len(spans))
+		require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.SentSpansKey, Value: attribute.Int64Value(6)})
+		require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendSpansKey, Value: attribute.Int64Value(4)})
+
+		require.NoError(t, tt.CheckExporterTraces(6, 4))
+	})
+}
+
 func TestProcessorTraceData(t *testing.T) {
 	testTelemetry(t, processorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
 		const acceptedSpans = 27
@@ -535,3 +699,184 @@ func TestProcessorLogRecords(t *testing.T) {
 		require.NoError(t, tt.CheckProcessorLogs(acceptedRecords, refusedRecords, droppedRecords))
 	})
 }
+
+func TestProcessTraceDataOp(t *testing.T) {
+	testTelemetry(t, processorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		parentCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
+		defer parentSpan.End()
+
+		por, err := newProcessor(ProcessorSettings{
+			ProcessorID:             processorID,
+			ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := por.StartTracesOp(parentCtx)
+		assert.NotNil(t, ctx)
+		por.EndTracesOp(ctx, 3, 5, 7, nil)
+		ctx = por.StartTracesOp(parentCtx)
+		por.EndTracesOp(ctx, 11, 13, 17, errFake)
+
+		spans := tt.SpanRecorder.Ended()
+		require.Len(t, spans, 2)
+		for _, span := range spans {
+			assert.Equal(t, "processor/"+processorID.String()+"/traces", span.Name())
+			assert.Equal(t, parentSpan.SpanContext().SpanID(), span.Parent().SpanID())
+		}
+		require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.InsertedSpansKey, Value: attribute.Int64Value(3)})
+		require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.ModifiedSpansKey, Value: attribute.Int64Value(5)})
+		require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.DroppedSpansKey, Value: attribute.Int64Value(7)})
+		assert.Equal(t, codes.Unset, spans[0].Status().Code)
+		assert.Equal(t, codes.Error, spans[1].Status().Code)
+		assert.Equal(t, errFake.Error(), spans[1].Status().Description)
+
+		require.NoError(t, tt.CheckProcessorTraces(0, 0, 24))
+		require.NoError(t, tt.CheckProcessorTracesMutations(14, 18))
+	})
+}
+
+func TestProcessMetricsOp(t *testing.T) {
+	testTelemetry(t, processorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		por, err := newProcessor(ProcessorSettings{
+			ProcessorID:             processorID,
+			ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := por.StartMetricsOp(context.Background())
+		por.EndMetricsOp(ctx, 2, 4, 6, nil)
+		por.MetricsInserted(context.Background(), 1)
+		por.MetricsModified(context.Background(), 3)
+
+		spans := tt.SpanRecorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "processor/"+processorID.String()+"/metrics", spans[0].Name())
+		require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.InsertedMetricPointsKey, Value: attribute.Int64Value(2)})
+		require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.ModifiedMetricPointsKey, Value: attribute.Int64Value(4)})
+		require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.DroppedMetricPointsKey, Value: attribute.Int64Value(6)})
+
+		require.NoError(t, tt.CheckProcessorMetrics(0, 0, 6))
+		require.NoError(t, tt.CheckProcessorMetricsMutations(3, 7))
+	})
+}
+
+func TestProcessLogsOp(t *testing.T) {
+	testTelemetry(t, processorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		por, err := newProcessor(ProcessorSettings{
+			ProcessorID:             processorID,
+			ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := por.StartLogsOp(context.Background())
+		por.EndLogsOp(ctx, 8, 0, 1, nil)
+		por.LogsInserted(context.Background(), 2)
+		por.LogsModified(context.Background(), 5)
+
+		spans := tt.SpanRecorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "processor/"+processorID.String()+"/logs", spans[0].Name())
+		require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.InsertedLogRecordsKey, Value: attribute.Int64Value(8)})
+		require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.ModifiedLogRecordsKey, Value: attribute.Int64Value(0)})
+		require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.DroppedLogRecordsKey, Value: attribute.Int64Value(1)})
+
+		require.NoError(t, tt.CheckProcessorLogs(0, 0, 1))
+		require.NoError(t, tt.CheckProcessorLogsMutations(10, 5))
+	})
+}
+
+func TestReceiveNetworkAttributes(t *testing.T) {
+	ctx := client.NewContext(context.Background(), client.Info{
+		Addr:       &net.IPAddr{IP: net.IPv4(1, 2, 3, 4)},
+		Protocol:   client.ProtocolHTTP2,
+		TLSVersion: "1.3",
+	})
+
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+		rec.networkAttrs = true
+
+		rec.EndTracesOp(rec.StartTracesOp(ctx), format, 3, nil)
+		rec.EndTracesOp(rec.StartTracesOp(context.Background()), format, 2, nil)
+
+		spans := tt.SpanRecorder.Ended()
+		require.Len(t, spans, 2)
+		assert.Contains(t, spans[0].Attributes(), attribute.String(obsmetrics.PeerAddressKey, "1.2.3.4"))
+		assert.Contains(t, spans[0].Attributes(), attribute.String(obsmetrics.ProtocolKey, client.ProtocolHTTP2))
+		assert.Contains(t, spans[0].Attributes(), attribute.String(obsmetrics.TLSVersionKey, "1.3"))
+		for _, attr := range spans[1].Attributes() {
+			assert.NotContains(t, []attribute.Key{obsmetrics.PeerAddressKey, obsmetrics.ProtocolKey, obsmetrics.TLSVersionKey}, attr.Key)
+		}
+	})
+
+	t.Run("Metrics", func(t *testing.T) {
+		reader := sdkmetric.NewManualReader()
+		set := receivertest.NewNopCreateSettings()
+		set.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+		set.MetricsLevel = configtelemetry.LevelNormal
+		rec, err := newReceiver(ReceiverSettings{ReceiverID: receiverID, Transport: transport, ReceiverCreateSettings: set}, true)
+		require.NoError(t, err)
+		rec.networkAttrs = true
+
+		rec.EndTracesOp(rec.StartTracesOp(ctx), format, 3, nil)
+
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(context.Background(), &rm))
+		var found bool
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				if m.Name != "receiver/accepted_spans" {
+					continue
+				}
+				dps := m.Data.(metricdata.Sum[int64]).DataPoints
+				require.Len(t, dps, 1)
+				assert.Equal(t, int64(3), dps[0].Value)
+				protocol, ok := dps[0].Attributes.Value(obsmetrics.ProtocolKey)
+				assert.True(t, ok)
+				assert.Equal(t, client.ProtocolHTTP2, protocol.AsString())
+				found = true
+			}
+		}
+		assert.True(t, found)
+	})
+}
+
+func TestExportPipelineAttribute(t *testing.T) {
+	tracesCtx := obsreportconfig.ContextWithPipelineID(context.Background(), component.NewID("traces"))
+	sampledCtx := obsreportconfig.ContextWithPipelineID(context.Background(), component.NewIDWithName("traces", "sampled"))
+
+	reader := sdkmetric.NewManualReader()
+	set := exportertest.NewNopCreateSettings()
+	set.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	set.MetricsLevel = configtelemetry.LevelNormal
+	exp, err := newExporter(ExporterSettings{ExporterID: exporterID, ExporterCreateSettings: set}, true)
+	require.NoError(t, err)
+	exp.pipelineAttr = true
+
+	exp.EndTracesOp(exp.StartTracesOp(tracesCtx), 3, nil)
+	exp.EndTracesOp(exp.StartTracesOp(sampledCtx), 2, nil)
+	exp.EndTracesOp(exp.StartTracesOp(tracesCtx), 4, nil)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	sent := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "exporter/sent_spans" {
+				continue
+			}
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				exporter, _ := dp.Attributes.Value(obsmetrics.ExporterKey)
+				assert.Equal(t, exporterID.String(), exporter.AsString())
+				pipeline, _ := dp.Attributes.Value(obsmetrics.PipelineKey)
+				sent[pipeline.AsString()] = dp.Value
+			}
+		}
+	}
+	assert.Equal(t, map[string]int64{"traces": 7, "traces/sampled": 2}, sent)
+}
@@ -9,12 +9,13 @@ import (
 	ocprom "contrib.go.opencensus.io/exporter/prometheus"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/otel/attribute"
 	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
-	"go.opentelemetry.io/otel/sdk/resource"
-	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
-	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -46,10 +47,14 @@ type TestTelemetry struct {
 	component.TelemetrySettings
 	id           component.ID
 	SpanRecorder *tracetest.SpanRecorder
-	views        []*view.View
+	// Reader collects the OpenTelemetry metrics recorded with the MeterProvider of the TelemetrySettings,
+	// to check them directly, e.g. with metricdatatest.
+	Reader *sdkmetric.ManualReader
+	views  []*view.View
+	logs   *observer.ObservedLogs
 
 	otelPrometheusChecker *prometheusChecker
-	meterProvider         *sdkmetric.MeterProvider
+	telemetry             *componenttest.Telemetry
 	ocExporter            *ocprom.Exporter
 }
 
@@ -113,6 +118,27 @@ func (tts *TestTelemetry) CheckProcessorLogs(acceptedLogRecords, refusedLogRecor
 	return tts.otelPrometheusChecker.checkProcessorLogs(tts.id, acceptedLogRecords, refusedLogRecords, droppedLogRecords)
 }
 
+// CheckProcessorTracesMutations checks that for the current exported values for the items inserted and modified
+// by the processor match given values.
+// When this function is called it is required to also call SetupTelemetry as first thing.
+func (tts *TestTelemetry) CheckProcessorTracesMutations(insertedSpans, modifiedSpans int64) error {
+	return tts.otelPrometheusChecker.checkProcessorTracesMutations(tts.id, insertedSpans, modifiedSpans)
+}
+
+// CheckProcessorMetricsMutations checks that for the current exported values for the items inserted and modified
+// by the processor match given values.
+// When this function is called it is required to also call SetupTelemetry as first thing.
+func (tts *TestTelemetry) CheckProcessorMetricsMutations(insertedMetricPoints, modifiedMetricPoints int64) error {
+	return tts.otelPrometheusChecker.checkProcessorMetricsMutations(tts.id, insertedMetricPoints, modifiedMetricPoints)
+}
+
+// CheckProcessorLogsMutations checks that for the current exported values for the items inserted and modified
+// by the processor match given values.
+// When this function is called it is required to also call SetupTelemetry as first thing.
+func (tts *TestTelemetry) CheckProcessorLogsMutations(insertedLogRecords, modifiedLogRecords int64) error {
+	return tts.otelPrometheusChecker.checkProcessorLogsMutations(tts.id, insertedLogRecords, modifiedLogRecords)
+}
+
 // CheckReceiverTraces checks that for the current exported values for trace receiver metrics match given values.
 // When this function is called it is required to also call SetupTelemetry as first thing.
 func (tts *TestTelemetry) CheckReceiverTraces(protocol string, acceptedSpans, droppedSpans int64) error {
@@ -131,32 +157,59 @@ func (tts *TestTelemetry) CheckReceiverMetrics(protocol string, acceptedMetricPo
 	return tts.otelPrometheusChecker.checkReceiverMetrics(tts.id, protocol, acceptedMetricPoints, droppedMetricPoints)
 }
 
+// CheckReceiverDroppedOnShutdown checks that the current exported value for the items of the given data type
+// dropped by the receiver because the pipeline was shutting down matches the given value.
+// When this function is called it is required to also call SetupTelemetry as first thing.
+func (tts *TestTelemetry) CheckReceiverDroppedOnShutdown(protocol string, dataType component.DataType, droppedItems int64) error {
+	return tts.otelPrometheusChecker.checkReceiverDroppedOnShutdown(tts.id, protocol, dataType, droppedItems)
+}
+
+// CheckExporterDroppedOnShutdown checks that the current exported value for the items of the given data type
+// dropped by the exporter because it was shutting down matches the given value.
+// When this function is called it is required to also call SetupTelemetry as first thing.
+func (tts *TestTelemetry) CheckExporterDroppedOnShutdown(dataType component.DataType, droppedItems int64) error {
+	return tts.otelPrometheusChecker.checkExporterDroppedOnShutdown(tts.id, dataType, droppedItems)
+}
+
+// CheckCounter checks that the current value of the counter with the given name and attributes matches the given
+// value. The counter can be recorded with OpenCensus, if its view is registered, or with the OpenTelemetry
+// MeterProvider of the TelemetrySettings, so the custom instruments of the components can be checked whichever
+// implementation of the metrics is enabled. The name is normalized as a Prometheus name, e.g. the OpenCensus view
+// "processor/batch/timeout_trigger_send" is checked as "processor_batch_timeout_trigger_send".
+// When this function is called it is required to also call SetupTelemetry as first thing.
+func (tts *TestTelemetry) CheckCounter(name string, value int64, attrs ...attribute.KeyValue) error {
+	return tts.otelPrometheusChecker.checkCounter(prometheusName(name), value, attrs)
+}
+
+// CheckGauge checks that the current value of the gauge with the given name and attributes matches the given
+// value, recorded with OpenCensus or OpenTelemetry as with CheckCounter.
+// When this function is called it is required to also call SetupTelemetry as first thing.
+func (tts *TestTelemetry) CheckGauge(name string, value float64, attrs ...attribute.KeyValue) error {
+	return tts.otelPrometheusChecker.checkGauge(prometheusName(name), value, attrs)
+}
+
 // Shutdown unregisters any views and shuts down the SpanRecorder
 func (tts *TestTelemetry) Shutdown(ctx context.Context) error {
 	view.Unregister(tts.views...)
 	view.UnregisterExporter(tts.ocExporter)
-	var errs error
-	errs = multierr.Append(errs, tts.SpanRecorder.Shutdown(ctx))
-	if tts.meterProvider != nil {
-		errs = multierr.Append(errs, tts.meterProvider.Shutdown(ctx))
+	if tts.telemetry == nil {
+		return nil
 	}
-	return errs
+	// Shutting down the TracerProvider shuts down the SpanRecorder.
+	return tts.telemetry.Shutdown(ctx)
 }
 
-// SetupTelemetry does setup the testing environment to check the metrics recorded by receivers, producers or exporters.
+// SetupTelemetry does setup the testing environment to check the metrics, spans and logs recorded by receivers, producers or exporters.
 // The caller must pass the ID of the component that intends to test, so the CreateSettings and Check methods will use.
 // The caller should defer a call to Shutdown the returned TestTelemetry.
 func SetupTelemetry(id component.ID) (TestTelemetry, error) {
-	sr := new(tracetest.SpanRecorder)
-	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	core, logs := observer.New(zapcore.DebugLevel)
 
 	settings := TestTelemetry{
 		TelemetrySettings: componenttest.NewNopTelemetrySettings(),
 		id:                id,
-		SpanRecorder:      sr,
+		logs:              logs,
 	}
-	settings.TelemetrySettings.TracerProvider = tp
-	settings.TelemetrySettings.MetricsLevel = configtelemetry.LevelNormal
 	settings.views = obsreportconfig.AllViews(configtelemetry.LevelNormal)
 	err := view.Register(settings.views...)
 	if err != nil {
@@ -176,11 +229,11 @@ func SetupTelemetry(id component.ID) (TestTelemetry, error) {
 		return settings, err
 	}
 
-	settings.meterProvider = sdkmetric.NewMeterProvider(
-		sdkmetric.WithResource(resource.Empty()),
-		sdkmetric.WithReader(exp),
-	)
-	settings.TelemetrySettings.MeterProvider = settings.meterProvider
+	settings.telemetry = componenttest.NewTelemetry(componenttest.WithMetricReader(exp))
+	settings.TelemetrySettings = settings.telemetry.NewTelemetrySettings()
+	settings.TelemetrySettings.Logger = zap.New(core)
+	settings.SpanRecorder = settings.telemetry.SpanRecorder
+	settings.Reader = settings.telemetry.Reader
 
 	settings.otelPrometheusChecker = &prometheusChecker{promHandler: settings.ocExporter}
 
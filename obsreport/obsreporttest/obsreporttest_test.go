@@ -9,8 +9,15 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 )
@@ -245,3 +252,72 @@ func TestCheckExporterLogsViews(t *testing.T) {
 	assert.Error(t, tt.CheckExporterLogs(0, 0))
 	assert.Error(t, tt.CheckExporterLogs(0, 7))
 }
+
+func TestCheckCustomMetrics(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry(processor)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	// A custom OpenCensus view.
+	ocMeasure := stats.Int64("processor/custom/oc_items", "Items", stats.UnitDimensionless)
+	ocView := &view.View{Name: ocMeasure.Name(), Measure: ocMeasure, Aggregation: view.Sum()}
+	require.NoError(t, view.Register(ocView))
+	t.Cleanup(func() { view.Unregister(ocView) })
+	stats.Record(context.Background(), ocMeasure.M(5), ocMeasure.M(6))
+
+	// Custom OpenTelemetry instruments.
+	meter := tt.MeterProvider.Meter("custom")
+	counter, err := meter.Int64Counter("processor.custom.otel_items")
+	require.NoError(t, err)
+	counter.Add(context.Background(), 4, metric.WithAttributes(attribute.String("processor", processor.String())))
+	gauge, err := meter.Int64UpDownCounter("processor_custom_queue_size")
+	require.NoError(t, err)
+	gauge.Add(context.Background(), 3)
+
+	assert.NoError(t, tt.CheckCounter("processor/custom/oc_items", 11))
+	assert.NoError(t, tt.CheckCounter("processor/custom/otel_items", 4, attribute.String("processor", processor.String())))
+	assert.Error(t, tt.CheckCounter("processor/custom/otel_items", 4))
+	assert.Error(t, tt.CheckCounter("processor/custom/otel_items", 5, attribute.String("processor", processor.String())))
+	assert.Error(t, tt.CheckCounter("processor/custom/unknown", 0))
+
+	// The up-down counters are exported as Prometheus gauges.
+	assert.NoError(t, tt.CheckGauge("processor_custom_queue_size", 3))
+	assert.Error(t, tt.CheckGauge("processor_custom_queue_size", 2))
+	assert.Error(t, tt.CheckGauge("processor/custom/otel_items", 4, attribute.String("processor", processor.String())))
+
+	// The OpenTelemetry metrics can be collected directly.
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, tt.Reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	assert.Len(t, rm.ScopeMetrics[0].Metrics, 2)
+}
+
+func TestCheckExporterLogsOtel(t *testing.T) {
+	require.NoError(t, featuregate.GlobalRegistry().Set(obsreportconfig.UseOtelForInternalMetricsfeatureGate.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(obsreportconfig.UseOtelForInternalMetricsfeatureGate.ID(), false))
+	})
+	tt, err := obsreporttest.SetupTelemetry(exporter)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	obsrep, err := obsreport.NewExporter(obsreport.ExporterSettings{
+		ExporterID:             exporter,
+		ExporterCreateSettings: tt.ToExporterCreateSettings(),
+	})
+	require.NoError(t, err)
+	ctx := obsrep.StartLogsOp(context.Background())
+	obsrep.EndLogsOp(ctx, 7, nil)
+
+	assert.NoError(t, tt.CheckExporterLogs(7, 0))
+	assert.NoError(t, tt.CheckCounter("exporter/sent_log_records", 7, attribute.String("exporter", exporter.String())))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, tt.Reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	var names []string
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		names = append(names, m.Name)
+	}
+	assert.Contains(t, names, "exporter/sent_log_records")
+}
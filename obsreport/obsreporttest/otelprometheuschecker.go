@@ -8,6 +8,7 @@ import (
 	"math"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 
 	io_prometheus_client "github.com/prometheus/client_model/go"
 	"github.com/prometheus/common/expfmt"
@@ -75,6 +76,27 @@ func (pc *prometheusChecker) checkProcessorLogs(processor component.ID, accepted
 		pc.checkCounter("processor_dropped_log_records", droppedLogRecords, processorAttrs))
 }
 
+func (pc *prometheusChecker) checkProcessorTracesMutations(processor component.ID, insertedSpans, modifiedSpans int64) error {
+	processorAttrs := attributesForProcessorMetrics(processor)
+	return multierr.Combine(
+		pc.checkCounter("processor_inserted_spans", insertedSpans, processorAttrs),
+		pc.checkCounter("processor_modified_spans", modifiedSpans, processorAttrs))
+}
+
+func (pc *prometheusChecker) checkProcessorMetricsMutations(processor component.ID, insertedMetricPoints, modifiedMetricPoints int64) error {
+	processorAttrs := attributesForProcessorMetrics(processor)
+	return multierr.Combine(
+		pc.checkCounter("processor_inserted_metric_points", insertedMetricPoints, processorAttrs),
+		pc.checkCounter("processor_modified_metric_points", modifiedMetricPoints, processorAttrs))
+}
+
+func (pc *prometheusChecker) checkProcessorLogsMutations(processor component.ID, insertedLogRecords, modifiedLogRecords int64) error {
+	processorAttrs := attributesForProcessorMetrics(processor)
+	return multierr.Combine(
+		pc.checkCounter("processor_inserted_log_records", insertedLogRecords, processorAttrs),
+		pc.checkCounter("processor_modified_log_records", modifiedLogRecords, processorAttrs))
+}
+
 func (pc *prometheusChecker) checkExporterTraces(exporter component.ID, sentSpans, sendFailedSpans int64) error {
 	exporterAttrs := attributesForExporterMetrics(exporter)
 	if sendFailedSpans > 0 {
@@ -108,6 +130,25 @@ func (pc *prometheusChecker) checkExporterMetrics(exporter component.ID, sentMet
 		pc.checkCounter("exporter_sent_metric_points", sentMetricPoints, exporterAttrs))
 }
 
+func (pc *prometheusChecker) checkReceiverDroppedOnShutdown(receiver component.ID, protocol string, dataType component.DataType, droppedItems int64) error {
+	return pc.checkCounter("receiver_"+droppedOnShutdownSuffix(dataType), droppedItems, attributesForReceiverMetrics(receiver, protocol))
+}
+
+func (pc *prometheusChecker) checkExporterDroppedOnShutdown(exporter component.ID, dataType component.DataType, droppedItems int64) error {
+	return pc.checkCounter("exporter_"+droppedOnShutdownSuffix(dataType), droppedItems, attributesForExporterMetrics(exporter))
+}
+
+func droppedOnShutdownSuffix(dataType component.DataType) string {
+	switch dataType {
+	case component.DataTypeMetrics:
+		return "dropped_on_shutdown_metric_points"
+	case component.DataTypeLogs:
+		return "dropped_on_shutdown_log_records"
+	default:
+		return "dropped_on_shutdown_spans"
+	}
+}
+
 func (pc *prometheusChecker) checkCounter(expectedMetric string, value int64, attrs []attribute.KeyValue) error {
 	// Forces a flush for the opencensus view data.
 	_, _ = view.RetrieveData(expectedMetric)
@@ -125,6 +166,27 @@ func (pc *prometheusChecker) checkCounter(expectedMetric string, value int64, at
 	return nil
 }
 
+func (pc *prometheusChecker) checkGauge(expectedMetric string, value float64, attrs []attribute.KeyValue) error {
+	// Forces a flush for the opencensus view data.
+	_, _ = view.RetrieveData(expectedMetric)
+
+	ts, err := pc.getMetric(expectedMetric, io_prometheus_client.MetricType_GAUGE, attrs)
+	if err != nil {
+		return err
+	}
+
+	if math.Abs(value-ts.GetGauge().GetValue()) > 0.0001 {
+		return fmt.Errorf("values for metric '%s' did no match, expected '%f' got '%f'", expectedMetric, value, ts.GetGauge().GetValue())
+	}
+
+	return nil
+}
+
+// prometheusName returns the name of the metric exported by Prometheus.
+func prometheusName(name string) string {
+	return strings.NewReplacer("/", "_", ".", "_", "-", "_").Replace(name)
+}
+
 // getMetric returns the metric time series that matches the given name, type and set of attributes
 // it fetches data from the prometheus endpoint and parse them, ideally OTel Go should provide a MeterRecorder of some kind.
 func (pc *prometheusChecker) getMetric(expectedName string, expectedType io_prometheus_client.MetricType, expectedAttrs []attribute.KeyValue) (*io_prometheus_client.Metric, error) {
@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package obsreporttest // import "go.opentelemetry.io/collector/obsreport/obsreporttest"
+
+import (
+	"fmt"
+	"reflect"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// Spans returns the spans ended so far by the component, to be narrowed down and checked, e.g.:
+//
+//	err := tt.Spans().WithName("receiver/otlp/TraceDataReceived").WithStatus(codes.Error).CheckCount(1)
+func (tts *TestTelemetry) Spans() Spans {
+	return Spans(tts.SpanRecorder.Ended())
+}
+
+// Logs returns the entries logged so far by the component, to be narrowed down and checked, e.g.:
+//
+//	err := tt.Logs().AtLevel(zapcore.WarnLevel).WithMessage("Sender failed").CheckCount(1)
+func (tts *TestTelemetry) Logs() Logs {
+	return Logs(tts.logs.All())
+}
+
+// Spans is a selection of the spans recorded by TestTelemetry.
+type Spans []sdktrace.ReadOnlySpan
+
+// WithName returns the spans with the given name.
+func (s Spans) WithName(name string) Spans {
+	return s.filter(func(span sdktrace.ReadOnlySpan) bool {
+		return span.Name() == name
+	})
+}
+
+// WithStatus returns the spans with the given status code.
+func (s Spans) WithStatus(code codes.Code) Spans {
+	return s.filter(func(span sdktrace.ReadOnlySpan) bool {
+		return span.Status().Code == code
+	})
+}
+
+// WithAttribute returns the spans having the given attribute.
+func (s Spans) WithAttribute(kv attribute.KeyValue) Spans {
+	return s.filter(func(span sdktrace.ReadOnlySpan) bool {
+		for _, attr := range span.Attributes() {
+			if attr == kv {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// CheckCount checks that the selection contains exactly count spans.
+func (s Spans) CheckCount(count int) error {
+	if len(s) != count {
+		names := make([]string, 0, len(s))
+		for _, span := range s {
+			names = append(names, span.Name())
+		}
+		return fmt.Errorf("expected %d span(s), got %d: %v", count, len(s), names)
+	}
+	return nil
+}
+
+func (s Spans) filter(keep func(sdktrace.ReadOnlySpan) bool) Spans {
+	var res Spans
+	for _, span := range s {
+		if keep(span) {
+			res = append(res, span)
+		}
+	}
+	return res
+}
+
+// Logs is a selection of the log entries recorded by TestTelemetry.
+type Logs []observer.LoggedEntry
+
+// AtLevel returns the entries logged at the given level.
+func (l Logs) AtLevel(level zapcore.Level) Logs {
+	return l.filter(func(entry observer.LoggedEntry) bool {
+		return entry.Level == level
+	})
+}
+
+// WithMessage returns the entries with the given message.
+func (l Logs) WithMessage(msg string) Logs {
+	return l.filter(func(entry observer.LoggedEntry) bool {
+		return entry.Message == msg
+	})
+}
+
+// WithField returns the entries having a field with the given key and value. The value must be
+// of the type zap encodes the field with, e.g. int64 for zap.Int or string for zap.Error.
+func (l Logs) WithField(key string, value any) Logs {
+	return l.filter(func(entry observer.LoggedEntry) bool {
+		v, ok := entry.ContextMap()[key]
+		return ok && reflect.DeepEqual(v, value)
+	})
+}
+
+// CheckCount checks that the selection contains exactly count entries.
+func (l Logs) CheckCount(count int) error {
+	if len(l) != count {
+		msgs := make([]string, 0, len(l))
+		for _, entry := range l {
+			msgs = append(msgs, entry.Message)
+		}
+		return fmt.Errorf("expected %d log entr(ies), got %d: %q", count, len(l), msgs)
+	}
+	return nil
+}
+
+func (l Logs) filter(keep func(observer.LoggedEntry) bool) Logs {
+	var res Logs
+	for _, entry := range l {
+		if keep(entry) {
+			res = append(res, entry)
+		}
+	}
+	return res
+}
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package obsreporttest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+)
+
+func TestSpans(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry(receiver)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	tracer := tt.TracerProvider.Tracer("test")
+	_, span := tracer.Start(context.Background(), "ok")
+	span.SetAttributes(attribute.String("key", "value"))
+	span.End()
+	_, span = tracer.Start(context.Background(), "failed")
+	span.SetStatus(codes.Error, "boom")
+	span.End()
+	_, span = tracer.Start(context.Background(), "not ended")
+	defer span.End()
+
+	assert.NoError(t, tt.Spans().CheckCount(2))
+	assert.NoError(t, tt.Spans().WithName("ok").CheckCount(1))
+	assert.NoError(t, tt.Spans().WithName("not ended").CheckCount(0))
+	assert.NoError(t, tt.Spans().WithStatus(codes.Error).CheckCount(1))
+	assert.NoError(t, tt.Spans().WithName("ok").WithStatus(codes.Error).CheckCount(0))
+	assert.NoError(t, tt.Spans().WithAttribute(attribute.String("key", "value")).CheckCount(1))
+	assert.NoError(t, tt.Spans().WithAttribute(attribute.String("key", "other")).CheckCount(0))
+	assert.EqualError(t, tt.Spans().WithStatus(codes.Error).CheckCount(2), "expected 2 span(s), got 1: [failed]")
+}
+
+func TestSpansReceiver(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry(receiver)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	rec, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             receiver,
+		Transport:              transport,
+		ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+	})
+	require.NoError(t, err)
+	ctx := rec.StartTracesOp(context.Background())
+	rec.EndTracesOp(ctx, format, 7, errors.New("refused"))
+
+	assert.NoError(t, tt.Spans().WithStatus(codes.Error).CheckCount(1))
+}
+
+func TestLogs(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry(receiver)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	tt.Logger.Debug("starting")
+	tt.Logger.Warn("retrying", zap.Int("attempt", 1))
+	tt.Logger.Warn("retrying", zap.Int("attempt", 2))
+	tt.Logger.Error("failed", zap.Error(errors.New("boom")))
+
+	assert.NoError(t, tt.Logs().CheckCount(4))
+	assert.NoError(t, tt.Logs().AtLevel(zapcore.DebugLevel).WithMessage("starting").CheckCount(1))
+	assert.NoError(t, tt.Logs().AtLevel(zapcore.WarnLevel).CheckCount(2))
+	assert.NoError(t, tt.Logs().WithMessage("retrying").WithField("attempt", int64(2)).CheckCount(1))
+	assert.NoError(t, tt.Logs().WithField("error", "boom").CheckCount(1))
+	assert.NoError(t, tt.Logs().AtLevel(zapcore.InfoLevel).CheckCount(0))
+	assert.EqualError(t, tt.Logs().AtLevel(zapcore.ErrorLevel).CheckCount(0), `expected 0 log entr(ies), got 1: ["failed"]`)
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Callbacks are invoked on the lifecycle events of the Collector, e.g. by the programs embedding it.
+// They are called synchronously from the goroutine running the Collector, so they must not block.
+// Any callback may be nil.
+type Callbacks struct {
+	// OnStarting is called when the collector starts loading the configuration and starting
+	// the components, including when the configuration is reloaded.
+	OnStarting func()
+	// OnRunning is called once all the components are started.
+	OnRunning func()
+	// OnClosing is called when the collector starts shutting down the components, including
+	// when the configuration is reloaded.
+	OnClosing func()
+	// OnClosed is called once the collector is shut down, or failed to start.
+	OnClosed func()
+	// OnFatal is called with the fatal error reported by a component, before the collector shuts down.
+	OnFatal func(err error)
+}
+
+func (cb Callbacks) notify(state State) {
+	var f func()
+	switch state {
+	case StateStarting:
+		f = cb.OnStarting
+	case StateRunning:
+		f = cb.OnRunning
+	case StateClosing:
+		f = cb.OnClosing
+	case StateClosed:
+		f = cb.OnClosed
+	}
+	if f != nil {
+		f()
+	}
+}
+
+// Subscribe registers f to be called with the new state of the collector on each state transition,
+// until the returned function is called. Like the Callbacks, f is called synchronously from the
+// goroutine running the collector, so it must not block.
+// The subscribers are called in the order they subscribed.
+func (col *Collector) Subscribe(f func(State)) (unsubscribe func()) {
+	return col.stateSubscribers.add(f)
+}
+
+// SubscribeComponentStatus registers f to be called with the status changes of the component instances,
+// e.g. when they start, fail to start or report a fatal error, until the returned function is called.
+// The subscribers are called in the order they subscribed, one event at a time, so f must not block.
+func (col *Collector) SubscribeComponentStatus(f func(component.StatusEvent)) (unsubscribe func()) {
+	return col.statusSubscribers.add(f)
+}
+
+// subscriptions holds the subscribers to the events of type E, in the order they subscribed.
+type subscriptions[E any] struct {
+	mu     sync.Mutex
+	nextID int
+	subs   []subscription[E]
+
+	// notifyMu serializes the notifications, the events being reported concurrently.
+	notifyMu sync.Mutex
+}
+
+type subscription[E any] struct {
+	id int
+	f  func(E)
+}
+
+// add registers f, returning the function removing it.
+func (s *subscriptions[E]) add(f func(E)) (remove func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := s.nextID
+	s.nextID++
+	s.subs = append(s.subs, subscription[E]{id: id, f: f})
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for i, sub := range s.subs {
+			if sub.id == id {
+				s.subs = append(s.subs[:i:i], s.subs[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// notify calls the subscribers with the event, in the order they subscribed. The subscribers may be
+// removed while notified.
+func (s *subscriptions[E]) notify(event E) {
+	s.notifyMu.Lock()
+	defer s.notifyMu.Unlock()
+	s.mu.Lock()
+	subs := s.subs
+	s.mu.Unlock()
+	for _, sub := range subs {
+		sub.f(event)
+	}
+}
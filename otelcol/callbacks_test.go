@@ -0,0 +1,216 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// eventRecorder records the lifecycle events of a collector.
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *eventRecorder) record(event string) func() {
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.events = append(r.events, event)
+	}
+}
+
+func (r *eventRecorder) callbacks() Callbacks {
+	return Callbacks{
+		OnStarting: r.record("starting"),
+		OnRunning:  r.record("running"),
+		OnClosing:  r.record("closing"),
+		OnClosed:   r.record("closed"),
+		OnFatal: func(err error) {
+			r.record("fatal: " + err.Error())()
+		},
+	}
+}
+
+func (r *eventRecorder) get() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.events...)
+}
+
+func TestCollectorCallbacks(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	provider, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-nop.yaml")}))
+	require.NoError(t, err)
+
+	rec := &eventRecorder{}
+	watcher := make(chan error, 1)
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo:      component.NewDefaultBuildInfo(),
+		Factories:      factories,
+		ConfigProvider: &mockCfgProvider{ConfigProvider: provider, watcher: watcher},
+		Callbacks:      rec.callbacks(),
+	})
+	require.NoError(t, err)
+
+	wg := startCollector(context.Background(), t, col)
+	assert.Eventually(t, func() bool {
+		return len(rec.get()) == 2
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"starting", "running"}, rec.get())
+
+	// the configuration reload restarts the components
+	watcher <- nil
+	assert.Eventually(t, func() bool {
+		return len(rec.get()) == 5
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"starting", "running", "closing", "starting", "running"}, rec.get())
+
+	col.asyncErrorChannel <- errors.New("component failure")
+	wg.Wait()
+	assert.Equal(t, []string{"starting", "running", "closing", "starting", "running", "fatal: component failure", "closing", "closed"}, rec.get())
+}
+
+func TestCollectorCallbacksOnStartUpError(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	cfgProvider, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-invalid.yaml")}))
+	require.NoError(t, err)
+
+	rec := &eventRecorder{}
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo:      component.NewDefaultBuildInfo(),
+		Factories:      factories,
+		ConfigProvider: cfgProvider,
+		Callbacks:      rec.callbacks(),
+	})
+	require.NoError(t, err)
+
+	require.Error(t, col.Run(context.Background()))
+	assert.Equal(t, []string{"starting", "closed"}, rec.get())
+}
+
+func TestCollectorSubscribe(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	cfgProvider, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-nop.yaml")}))
+	require.NoError(t, err)
+
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo:      component.NewDefaultBuildInfo(),
+		Factories:      factories,
+		ConfigProvider: cfgProvider,
+	})
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var all, untilRunning []State
+	col.Subscribe(func(s State) {
+		mu.Lock()
+		defer mu.Unlock()
+		all = append(all, s)
+	})
+	var unsubscribe func()
+	unsubscribe = col.Subscribe(func(s State) {
+		mu.Lock()
+		defer mu.Unlock()
+		untilRunning = append(untilRunning, s)
+		if s == StateRunning {
+			unsubscribe()
+		}
+	})
+
+	wg := startCollector(context.Background(), t, col)
+	assert.Eventually(t, func() bool {
+		return StateRunning == col.GetState()
+	}, 2*time.Second, 10*time.Millisecond)
+	col.Shutdown()
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []State{StateStarting, StateRunning, StateClosing, StateClosed}, all)
+	assert.Equal(t, []State{StateStarting, StateRunning}, untilRunning)
+}
+
+func TestCollectorSubscribeOrder(t *testing.T) {
+	col := &Collector{}
+	var calls []string
+	col.Subscribe(func(State) { calls = append(calls, "first") })
+	unsubscribe := col.Subscribe(func(State) { calls = append(calls, "second") })
+	col.Subscribe(func(State) { calls = append(calls, "third") })
+	col.Subscribe(func(State) { calls = append(calls, "fourth") })
+
+	col.stateSubscribers.notify(StateStarting)
+	assert.Equal(t, []string{"first", "second", "third", "fourth"}, calls)
+
+	calls = nil
+	unsubscribe()
+	col.stateSubscribers.notify(StateRunning)
+	assert.Equal(t, []string{"first", "third", "fourth"}, calls)
+}
+
+func TestCollectorSubscribeComponentStatus(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	cfgProvider, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-nop.yaml")}))
+	require.NoError(t, err)
+
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo:      component.NewDefaultBuildInfo(),
+		Factories:      factories,
+		ConfigProvider: cfgProvider,
+	})
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	statuses := map[string][]component.Status{}
+	col.SubscribeComponentStatus(func(ev component.StatusEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		statuses[ev.InstanceID.String()] = append(statuses[ev.InstanceID.String()], ev.Status)
+	})
+
+	wg := startCollector(context.Background(), t, col)
+	assert.Eventually(t, func() bool {
+		return StateRunning == col.GetState()
+	}, 2*time.Second, 10*time.Millisecond)
+	col.Shutdown()
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	lifecycle := []component.Status{component.StatusStarting, component.StatusOK, component.StatusStopping, component.StatusStopped}
+	for _, instanceID := range []string{
+		"extension/nop[]",
+		"receiver/nop[traces]",
+		"receiver/nop[metrics]",
+		"receiver/nop[logs]",
+		"processor/nop[traces]",
+		"processor/nop[metrics]",
+		"processor/nop[logs]",
+		"exporter/nop[traces]",
+		"exporter/nop[metrics]",
+		"exporter/nop[logs]",
+		"connector/nop/con[logs,traces]",
+	} {
+		assert.Equal(t, lifecycle, statuses[instanceID], instanceID)
+	}
+	assert.Len(t, statuses, 11)
+}
@@ -11,6 +11,8 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"sort"
+	"sync"
 	"sync/atomic"
 	"syscall"
 
@@ -22,6 +24,9 @@ import (
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
+	"go.opentelemetry.io/collector/otelcol/internal/controlserver"
 	"go.opentelemetry.io/collector/otelcol/internal/grpclog"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/receiver"
@@ -75,6 +80,22 @@ type CollectorSettings struct {
 
 	// SkipSettingGRPCLogger avoids setting the grpc logger
 	SkipSettingGRPCLogger bool
+
+	// ControlEndpoint, if not empty, is the loopback address, or the unix socket path prefixed with "unix:",
+	// on which the collector serves its control API, allowing runtime operations such as toggling
+	// feature gates, reloading the configuration, pausing the ingestion or flushing the pipelines.
+	ControlEndpoint string
+
+	// ControlToken is the bearer token every control API request must present.
+	// It is required when ControlEndpoint is set. It also enables the control actions of the zPages.
+	ControlToken string
+
+	// Manifest, if not nil, lists the Go modules the components are built from. It is reported
+	// by the components command, see ParseManifest.
+	Manifest *Manifest
+
+	// Callbacks are invoked on the lifecycle events of the collector, see Callbacks.
+	Callbacks Callbacks
 }
 
 // (Internal note) Collector Lifecycle:
@@ -92,8 +113,19 @@ type CollectorSettings struct {
 type Collector struct {
 	set CollectorSettings
 
-	service *service.Service
-	state   *atomic.Int32
+	// service is the running service, only set by the goroutine of Run. It is read by the other goroutines,
+	// e.g. the handlers of the control API, with runningService.
+	service   *service.Service
+	serviceMu sync.RWMutex
+	state     *atomic.Int32
+
+	registry      *featuregate.Registry
+	controlServer *controlserver.Server
+	// flagFeatureGates are the raw values of the --feature-gates flag.
+	flagFeatureGates []string
+	// configFeatureGates are the values of the gates set by the configuration before it set them, by ID,
+	// restored once the gates are removed from the configuration.
+	configFeatureGates map[string]gateValue
 
 	// shutdownChan is used to terminate the collector.
 	shutdownChan chan struct{}
@@ -101,6 +133,21 @@ type Collector struct {
 	signalsChannel chan os.Signal
 	// asyncErrorChannel is used to signal a fatal error from any component.
 	asyncErrorChannel chan error
+	// reloadChan is used to request a reload of the configuration, the result is sent on the given channel.
+	reloadChan chan chan error
+	// restartChan is used to request a restart of the service when the internal metrics are switched
+	// between OpenCensus and OpenTelemetry at runtime.
+	restartChan chan struct{}
+	// useOtelForInternalMetrics is the value of the obsreportconfig.UseOtelForInternalMetricsfeatureGate
+	// used by the running service.
+	useOtelForInternalMetrics bool
+	// ingestionPaused is kept across the reloads of the configuration.
+	ingestionPaused atomic.Bool
+	// reloadMode is the handling of the configuration changes by the running service.
+	reloadMode service.ReloadMode
+
+	stateSubscribers  subscriptions[State]
+	statusSubscribers subscriptions[component.StatusEvent]
 }
 
 // NewCollector creates and returns a new instance of Collector.
@@ -109,17 +156,35 @@ func NewCollector(set CollectorSettings) (*Collector, error) {
 		return nil, errors.New("invalid nil config provider")
 	}
 
+	// Gates past their removal version must not be used by this build anymore.
+	featuregate.GlobalRegistry().SetCurrentVersion(set.BuildInfo.Version)
+
 	state := &atomic.Int32{}
 	state.Store(int32(StateStarting))
-	return &Collector{
+	col := &Collector{
 		set:          set,
 		state:        state,
+		registry:     featuregate.GlobalRegistry(),
 		shutdownChan: make(chan struct{}),
 		// Per signal.Notify documentation, a size of the channel equaled with
 		// the number of signals getting notified on is recommended.
 		signalsChannel:    make(chan os.Signal, 3),
 		asyncErrorChannel: make(chan error),
-	}, nil
+		reloadChan:        make(chan chan error),
+		restartChan:       make(chan struct{}, 1),
+	}
+
+	// The telemetry of the service and the components is set up with the value of the gate,
+	// so the service is restarted to switch the internal metrics.
+	if err := featuregate.GlobalRegistry().RegisterCallback(obsreportconfig.UseOtelForInternalMetricsfeatureGate.ID(), func(bool) {
+		select {
+		case col.restartChan <- struct{}{}:
+		default:
+		}
+	}); err != nil {
+		return nil, err
+	}
+	return col, nil
 }
 
 // GetState returns current state of the collector server.
@@ -139,11 +204,221 @@ func (col *Collector) Shutdown() {
 	}
 }
 
+// SetFeatureGate enables or disables the feature gate identified by id while the collector is running.
+// Only the gates declared with featuregate.WithRegisterRuntimeToggle can be changed, see
+// featuregate.Registry.SetAtRuntime for the other guards.
+// Callbacks registered for the gate are invoked before SetFeatureGate returns.
+func (col *Collector) SetFeatureGate(id string, enabled bool) error {
+	if err := col.registry.SetAtRuntime(id, enabled); err != nil {
+		return err
+	}
+	if srv := col.runningService(); srv != nil {
+		srv.Logger().Info("Feature gate changed at runtime", zap.String("id", id), zap.Bool("enabled", enabled))
+	}
+	return nil
+}
+
+// runningService returns the running service, nil if there is none. It is safe to call from any goroutine.
+func (col *Collector) runningService() *service.Service {
+	col.serviceMu.RLock()
+	defer col.serviceMu.RUnlock()
+	return col.service
+}
+
+// setService sets the running service, it must only be called by the goroutine of Run.
+// The warnings of the feature gates are logged by the running service, and kept by the registry
+// while there is none.
+func (col *Collector) setService(srv *service.Service) {
+	col.serviceMu.Lock()
+	col.service = srv
+	col.serviceMu.Unlock()
+
+	if srv == nil {
+		col.registry.SetWarningHandler(nil)
+		return
+	}
+	logger := srv.Logger()
+	col.registry.SetWarningHandler(func(w featuregate.Warning) {
+		logFeatureGateWarning(logger, w)
+	})
+}
+
+// Flush sends immediately the data pending in the processors and the exporters of the running service.
+func (col *Collector) Flush(ctx context.Context) error {
+	srv := col.runningService()
+	if srv == nil {
+		return errors.New("collector is not running")
+	}
+	return srv.Flush(ctx)
+}
+
+// Reload loads the configuration again and restarts the service with it, as on SIGHUP.
+// It returns once the service is restarted, the collector shuts down if the reload fails.
+func (col *Collector) Reload(ctx context.Context) error {
+	errCh := make(chan error, 1)
+	select {
+	case col.reloadChan <- errCh:
+	case <-col.shutdownChan:
+		return errors.New("collector is shutting down")
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// PauseIngestion rejects the data received by the receivers with a retryable error until ResumeIngestion
+// is called, including after the configuration is reloaded. The data already received keeps flowing.
+func (col *Collector) PauseIngestion() error {
+	srv := col.runningService()
+	if srv == nil {
+		return errors.New("collector is not running")
+	}
+	col.ingestionPaused.Store(true)
+	srv.PauseIngestion()
+	return nil
+}
+
+// ResumeIngestion accepts again the data received by the receivers.
+func (col *Collector) ResumeIngestion() error {
+	srv := col.runningService()
+	if srv == nil {
+		return errors.New("collector is not running")
+	}
+	col.ingestionPaused.Store(false)
+	srv.ResumeIngestion()
+	return nil
+}
+
+// IngestionPaused returns whether the ingestion of the running service is paused.
+func (col *Collector) IngestionPaused() (bool, error) {
+	srv := col.runningService()
+	if srv == nil {
+		return false, errors.New("collector is not running")
+	}
+	return srv.IngestionPaused(), nil
+}
+
+// Drain pauses the ingestion and returns once the data already received is sent, see service.Service.Drain.
+func (col *Collector) Drain(ctx context.Context) error {
+	srv := col.runningService()
+	if srv == nil {
+		return errors.New("collector is not running")
+	}
+	col.ingestionPaused.Store(true)
+	return srv.Drain(ctx)
+}
+
+// ScrapableReceivers returns the IDs of the running receivers able to scrape on demand.
+func (col *Collector) ScrapableReceivers() []component.ID {
+	srv := col.runningService()
+	if srv == nil {
+		return nil
+	}
+	return srv.ScrapableReceivers()
+}
+
+// ScrapeNow triggers a scrape of the receiver with the given ID, returning once the scraped data
+// is passed to the pipelines.
+func (col *Collector) ScrapeNow(ctx context.Context, id component.ID) error {
+	srv := col.runningService()
+	if srv == nil {
+		return errors.New("collector is not running")
+	}
+	return srv.ScrapeNow(ctx, id)
+}
+
+// startControlServer starts the control API if an endpoint was configured.
+func (col *Collector) startControlServer() error {
+	if col.set.ControlEndpoint == "" {
+		return nil
+	}
+	srv, err := controlserver.New(col.set.ControlEndpoint, col.set.ControlToken)
+	if err != nil {
+		return err
+	}
+	srv.Handle(controlserver.FeatureGatesPath, controlserver.FeatureGatesHandler(col.registry, col.SetFeatureGate))
+	srv.Handle(controlserver.FeatureGatesPath+"/", controlserver.FeatureGatesHandler(col.registry, col.SetFeatureGate))
+	srv.Handle(controlserver.FlushPath, controlserver.FlushHandler(col.Flush))
+	srv.Handle(controlserver.ReloadPath, controlserver.ReloadHandler(col.Reload))
+	srv.Handle(controlserver.IngestionPath, controlserver.IngestionHandler(col))
+	srv.Handle(controlserver.IngestionPath+"/", controlserver.IngestionHandler(col))
+	scrapeHandler := controlserver.ScrapeHandler(col.scrapableReceiverNames, col.scrapeNamedReceiver)
+	srv.Handle(controlserver.ScrapePath, scrapeHandler)
+	srv.Handle(controlserver.ScrapePath+"/", scrapeHandler)
+	if err = srv.Start(col.service.Logger()); err != nil {
+		return err
+	}
+	col.controlServer = srv
+	return nil
+}
+
+func (col *Collector) scrapableReceiverNames() []string {
+	ids := col.ScrapableReceivers()
+	names := make([]string, 0, len(ids))
+	for _, id := range ids {
+		names = append(names, id.String())
+	}
+	return names
+}
+
+func (col *Collector) scrapeNamedReceiver(ctx context.Context, name string) error {
+	var id component.ID
+	if err := id.UnmarshalText([]byte(name)); err != nil {
+		return err
+	}
+	return col.ScrapeNow(ctx, id)
+}
+
 // setupConfigurationComponents loads the config and starts the components. If all the steps succeeds it
-// sets the col.service with the service currently running.
+// sets the col.service with the service currently running, it is left nil otherwise.
 func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 	col.setCollectorState(StateStarting)
 
+	conf, cfg, err := col.loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err = col.applyFeatureGates(cfg.Service.FeatureGates); err != nil {
+		return newClassifiedError(errorClassConfig, fmt.Errorf("invalid service::feature_gates configuration: %w", err))
+	}
+
+	if err = col.registry.Validate(); err != nil {
+		return newClassifiedError(errorClassConfig, fmt.Errorf("invalid combination of feature gates: %w", err))
+	}
+
+	useOtel := obsreportconfig.UseOtelForInternalMetricsfeatureGate.IsEnabled()
+	srv, err := service.New(ctx, col.serviceSettings(conf, cfg), cfg.Service)
+	if err != nil {
+		return newClassifiedError(errorClassConfig, err)
+	}
+	col.reloadMode = cfg.Service.Reload
+	col.useOtelForInternalMetrics = useOtel
+
+	if !col.set.SkipSettingGRPCLogger {
+		grpclog.SetLogger(srv.Logger(), cfg.Service.Telemetry.Logs.Level)
+	}
+
+	logDeprecations(srv.Logger(), cfg, col.set.Factories)
+	logUnusedComponents(srv.Logger(), cfg)
+
+	if err = srv.Start(ctx); err != nil {
+		return multierr.Combine(newClassifiedError(errorClassComponentStart, err), srv.Shutdown(ctx))
+	}
+	col.setService(srv)
+	col.setCollectorState(StateRunning)
+
+	return nil
+}
+
+// loadConfig resolves and validates the configuration. The resolved configuration is nil if the
+// ConfigProvider is not a ConfmapProvider.
+func (col *Collector) loadConfig(ctx context.Context) (*confmap.Conf, *Config, error) {
 	var conf *confmap.Conf
 
 	if cp, ok := col.set.ConfigProvider.(ConfmapProvider); ok {
@@ -151,20 +426,23 @@ func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 		conf, err = cp.GetConfmap(ctx)
 
 		if err != nil {
-			return fmt.Errorf("failed to resolve config: %w", err)
+			return nil, nil, newClassifiedError(errorClassConfig, fmt.Errorf("failed to resolve config: %w", err))
 		}
 	}
 
 	cfg, err := col.set.ConfigProvider.Get(ctx, col.set.Factories)
 	if err != nil {
-		return fmt.Errorf("failed to get config: %w", err)
+		return nil, nil, newClassifiedError(errorClassConfig, fmt.Errorf("failed to get config: %w", err))
 	}
 
 	if err = cfg.Validate(); err != nil {
-		return fmt.Errorf("invalid configuration: %w", err)
+		return nil, nil, newClassifiedError(errorClassConfig, fmt.Errorf("invalid configuration: %w", err))
 	}
+	return conf, cfg, nil
+}
 
-	col.service, err = service.New(ctx, service.Settings{
+func (col *Collector) serviceSettings(conf *confmap.Conf, cfg *Config) service.Settings {
+	return service.Settings{
 		BuildInfo:         col.set.BuildInfo,
 		CollectorConf:     conf,
 		Receivers:         receiver.NewBuilder(cfg.Receivers, col.set.Factories.Receivers),
@@ -174,28 +452,97 @@ func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 		Extensions:        extension.NewBuilder(cfg.Extensions, col.set.Factories.Extensions),
 		AsyncErrorChannel: col.asyncErrorChannel,
 		LoggingOptions:    col.set.LoggingOptions,
-	}, cfg.Service)
-	if err != nil {
-		return err
+		ControlToken:      col.set.ControlToken,
+		SetFeatureGate:    col.SetFeatureGate,
+		IngestionPaused:   col.ingestionPaused.Load(),
+
+		ReportComponentStatus: col.statusSubscribers.notify,
 	}
+}
 
-	if !col.set.SkipSettingGRPCLogger {
-		grpclog.SetLogger(col.service.Logger(), cfg.Service.Telemetry.Logs.Level)
+// gateValue is the value of a feature gate and where it comes from.
+type gateValue struct {
+	enabled bool
+	source  featuregate.Source
+}
+
+// applyFeatureGates applies the feature gates from the configuration, then applies again the ones
+// from the --feature-gates flag, so the command line takes precedence over the configuration.
+// The gates removed from the configuration since it was last applied get back the value they had
+// before, unless they were changed since then, e.g. at runtime.
+func (col *Collector) applyFeatureGates(gates map[string]bool) error {
+	if col.configFeatureGates == nil {
+		col.configFeatureGates = map[string]gateValue{}
 	}
+	current := map[string]*featuregate.Gate{}
+	col.registry.VisitAll(func(g *featuregate.Gate) {
+		current[g.ID()] = g
+	})
 
-	if err = col.service.Start(ctx); err != nil {
-		return multierr.Combine(err, col.service.Shutdown(ctx))
+	var errs error
+	var removed []string
+	for id := range col.configFeatureGates {
+		if _, ok := gates[id]; !ok {
+			removed = append(removed, id)
+		}
+	}
+	sort.Strings(removed)
+	for _, id := range removed {
+		prev := col.configFeatureGates[id]
+		delete(col.configFeatureGates, id)
+		if g, ok := current[id]; ok && g.Source() == featuregate.SourceConfig {
+			errs = multierr.Append(errs, col.registry.SetFromSource(id, prev.enabled, prev.source))
+		}
+	}
+	if len(gates) == 0 && len(removed) == 0 {
+		return errs
 	}
-	col.setCollectorState(StateRunning)
 
-	return nil
+	ids := make([]string, 0, len(gates))
+	for id := range gates {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if _, ok := col.configFeatureGates[id]; !ok {
+			if g, ok := current[id]; ok {
+				col.configFeatureGates[id] = gateValue{enabled: g.IsEnabled(), source: g.Source()}
+			}
+		}
+		errs = multierr.Append(errs, col.registry.SetFromSource(id, gates[id], featuregate.SourceConfig))
+	}
+	if errs != nil {
+		return errs
+	}
+
+	flagValue := featuregate.NewFlag(col.registry)
+	for _, v := range col.flagFeatureGates {
+		errs = multierr.Append(errs, flagValue.Set(v))
+	}
+	return errs
 }
 
 func (col *Collector) reloadConfiguration(ctx context.Context) error {
+	if col.reloadMode == service.ReloadChangedComponents {
+		err := col.reloadChangedComponents(ctx)
+		if err == nil {
+			return nil
+		}
+		col.service.Logger().Warn("Failed to reload the changed components", zap.Error(err))
+	}
+
 	col.service.Logger().Warn("Config updated, restart service")
+	return col.restartService(ctx)
+}
+
+// restartService shuts down the running service, and starts a new one with the configuration loaded again.
+func (col *Collector) restartService(ctx context.Context) error {
 	col.setCollectorState(StateClosing)
 
-	if err := col.service.Shutdown(ctx); err != nil {
+	// The retiring service is not running anymore, whether its shutdown fails or not.
+	retiring := col.service
+	col.setService(nil)
+	if err := retiring.Shutdown(ctx); err != nil {
 		return fmt.Errorf("failed to shutdown the retiring config: %w", err)
 	}
 
@@ -206,13 +553,29 @@ func (col *Collector) reloadConfiguration(ctx context.Context) error {
 	return nil
 }
 
+// reloadChangedComponents only restarts the components of the pipelines which changed, see service.Service.Reload.
+// The service must be restarted if it fails.
+func (col *Collector) reloadChangedComponents(ctx context.Context) error {
+	conf, cfg, err := col.loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+	col.service.Logger().Info("Config updated, reload the changed components")
+	if err = col.service.Reload(ctx, col.serviceSettings(conf, cfg), cfg.Service); err != nil {
+		return err
+	}
+	logDeprecations(col.service.Logger(), cfg, col.set.Factories)
+	logUnusedComponents(col.service.Logger(), cfg)
+	return nil
+}
+
 func (col *Collector) DryRun(ctx context.Context) error {
 	cfg, err := col.set.ConfigProvider.Get(ctx, col.set.Factories)
 	if err != nil {
-		return fmt.Errorf("failed to get config: %w", err)
+		return newClassifiedError(errorClassConfig, fmt.Errorf("failed to get config: %w", err))
 	}
 
-	return cfg.Validate()
+	return newClassifiedError(errorClassConfig, cfg.Validate())
 }
 
 // Run starts the collector according to the given configuration, and waits for it to complete.
@@ -223,6 +586,10 @@ func (col *Collector) Run(ctx context.Context) error {
 		return err
 	}
 
+	if err := col.startControlServer(); err != nil {
+		return multierr.Combine(err, col.shutdown(ctx))
+	}
+
 	// Always notify with SIGHUP for configuration reloading.
 	signal.Notify(col.signalsChannel, syscall.SIGHUP)
 	defer signal.Stop(col.signalsChannel)
@@ -241,10 +608,29 @@ LOOP:
 				break LOOP
 			}
 			if err = col.reloadConfiguration(ctx); err != nil {
-				return err
+				return multierr.Combine(err, col.shutdown(ctx))
+			}
+		case errCh := <-col.reloadChan:
+			col.service.Logger().Info("Received reload request")
+			err := col.reloadConfiguration(ctx)
+			errCh <- err
+			if err != nil {
+				return multierr.Combine(err, col.shutdown(ctx))
+			}
+		case <-col.restartChan:
+			useOtel := obsreportconfig.UseOtelForInternalMetricsfeatureGate.IsEnabled()
+			if useOtel == col.useOtelForInternalMetrics {
+				continue
+			}
+			col.service.Logger().Info("Internal metrics switched at runtime, restart service", zap.Bool("use_otel", useOtel))
+			if err := col.restartService(ctx); err != nil {
+				return multierr.Combine(err, col.shutdown(ctx))
 			}
 		case err := <-col.asyncErrorChannel:
 			col.service.Logger().Error("Asynchronous error received, terminating process", zap.Error(err))
+			if col.set.Callbacks.OnFatal != nil {
+				col.set.Callbacks.OnFatal(err)
+			}
 			break LOOP
 		case s := <-col.signalsChannel:
 			col.service.Logger().Info("Received signal from OS", zap.String("signal", s.String()))
@@ -252,7 +638,7 @@ LOOP:
 				break LOOP
 			}
 			if err := col.reloadConfiguration(ctx); err != nil {
-				return err
+				return multierr.Combine(err, col.shutdown(ctx))
 			}
 		case <-col.shutdownChan:
 			col.service.Logger().Info("Received shutdown request")
@@ -272,13 +658,22 @@ func (col *Collector) shutdown(ctx context.Context) error {
 	// Accumulate errors and proceed with shutting down remaining components.
 	var errs error
 
+	if col.controlServer != nil {
+		if err := col.controlServer.Shutdown(ctx); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("failed to shutdown control server: %w", err))
+		}
+	}
+
 	if err := col.set.ConfigProvider.Shutdown(ctx); err != nil {
 		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown config provider: %w", err))
 	}
 
-	// shutdown service
-	if err := col.service.Shutdown(ctx); err != nil {
-		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown service after error: %w", err))
+	// shutdown service, unless it already failed to restart
+	if srv := col.service; srv != nil {
+		col.setService(nil)
+		if err := srv.Shutdown(ctx); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("failed to shutdown service after error: %w", err))
+		}
 	}
 
 	col.setCollectorState(StateClosed)
@@ -286,7 +681,9 @@ func (col *Collector) shutdown(ctx context.Context) error {
 	return errs
 }
 
-// setCollectorState provides current state of the collector
+// setCollectorState provides current state of the collector, and notifies the callbacks and the subscribers.
 func (col *Collector) setCollectorState(state State) {
 	col.state.Store(int32(state))
+	col.set.Callbacks.notify(state)
+	col.stateSubscribers.notify(state)
 }
@@ -7,18 +7,28 @@ package otelcol
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net"
+	"os"
 	"path/filepath"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/converter/expandconverter"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
+	"go.opentelemetry.io/collector/internal/testutil"
 )
 
 func TestStateString(t *testing.T) {
@@ -329,6 +339,495 @@ func TestCollectorShutdownBeforeRun(t *testing.T) {
 	assert.Equal(t, StateClosed, col.GetState())
 }
 
+func TestCollectorSetFeatureGate(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	cfgProvider, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-nop.yaml")}))
+	require.NoError(t, err)
+
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo:       component.NewDefaultBuildInfo(),
+		Factories:       factories,
+		ConfigProvider:  cfgProvider,
+		ControlEndpoint: "localhost:0",
+		ControlToken:    "secret",
+	})
+	require.NoError(t, err)
+	col.registry = featuregate.NewRegistry()
+	gate := col.registry.MustRegister("test.gate", featuregate.StageAlpha, featuregate.WithRegisterRuntimeToggle())
+	static := col.registry.MustRegister("test.static", featuregate.StageAlpha)
+	var toggled []bool
+	require.NoError(t, col.registry.RegisterCallback(gate.ID(), func(enabled bool) {
+		toggled = append(toggled, enabled)
+	}))
+
+	wg := startCollector(context.Background(), t, col)
+	assert.Eventually(t, func() bool {
+		return StateRunning == col.GetState()
+	}, 2*time.Second, 200*time.Millisecond)
+
+	require.NoError(t, col.SetFeatureGate(gate.ID(), true))
+	assert.True(t, gate.IsEnabled())
+	assert.Equal(t, []bool{true}, toggled)
+	assert.Error(t, col.SetFeatureGate("unknown.gate", true))
+	assert.Error(t, col.SetFeatureGate(static.ID(), true))
+	assert.False(t, static.IsEnabled())
+
+	// the zPages of the service toggle the gates of the collector like its control API
+	set := col.serviceSettings(confmap.New(), &Config{})
+	require.NoError(t, set.SetFeatureGate(gate.ID(), false))
+	assert.False(t, gate.IsEnabled())
+	assert.Equal(t, []bool{true, false}, toggled)
+
+	col.Shutdown()
+	wg.Wait()
+	assert.Equal(t, StateClosed, col.GetState())
+}
+
+func TestCollectorControlDuringReload(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	cfgProvider, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-nop.yaml")}))
+	require.NoError(t, err)
+
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo:      component.NewDefaultBuildInfo(),
+		Factories:      factories,
+		ConfigProvider: cfgProvider,
+	})
+	require.NoError(t, err)
+	col.registry = featuregate.NewRegistry()
+	gate := col.registry.MustRegister("test.gate", featuregate.StageAlpha, featuregate.WithRegisterRuntimeToggle())
+
+	wg := startCollector(context.Background(), t, col)
+	assert.Eventually(t, func() bool {
+		return StateRunning == col.GetState()
+	}, 2*time.Second, 200*time.Millisecond)
+
+	// The control operations read the running service while it is replaced by the reloads.
+	done := make(chan struct{})
+	controlDone := make(chan struct{})
+	go func() {
+		defer close(controlDone)
+		for enabled := true; ; enabled = !enabled {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			assert.NoError(t, col.SetFeatureGate(gate.ID(), enabled))
+			// fails while the service is restarted
+			_ = col.Flush(context.Background())
+			for _, id := range col.ScrapableReceivers() {
+				_ = col.ScrapeNow(context.Background(), id)
+			}
+			if col.PauseIngestion() == nil {
+				_, _ = col.IngestionPaused()
+				_ = col.ResumeIngestion()
+			}
+		}
+	}()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, col.Reload(context.Background()))
+	}
+	close(done)
+	<-controlDone
+
+	col.Shutdown()
+	wg.Wait()
+	assert.Equal(t, StateClosed, col.GetState())
+}
+
+func TestCollectorSwitchInternalMetricsAtRuntime(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	cfgProvider, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-nop.yaml")}))
+	require.NoError(t, err)
+
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo:      component.NewDefaultBuildInfo(),
+		Factories:      factories,
+		ConfigProvider: cfgProvider,
+	})
+	require.NoError(t, err)
+
+	gateID := obsreportconfig.UseOtelForInternalMetricsfeatureGate.ID()
+	initial := obsreportconfig.UseOtelForInternalMetricsfeatureGate.IsEnabled()
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(gateID, initial))
+	})
+
+	wg := startCollector(context.Background(), t, col)
+	assert.Eventually(t, func() bool {
+		return StateRunning == col.GetState()
+	}, 2*time.Second, 200*time.Millisecond)
+	srv := col.runningService()
+
+	// The service is restarted with the internal metrics switched.
+	require.NoError(t, col.SetFeatureGate(gateID, !initial))
+	assert.Eventually(t, func() bool {
+		running := col.runningService()
+		return running != nil && running != srv && StateRunning == col.GetState()
+	}, 2*time.Second, 200*time.Millisecond)
+
+	col.Shutdown()
+	wg.Wait()
+	assert.Equal(t, StateClosed, col.GetState())
+}
+
+func TestCollectorFlush(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	cfgProvider, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-nop.yaml")}))
+	require.NoError(t, err)
+
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo:      component.NewDefaultBuildInfo(),
+		Factories:      factories,
+		ConfigProvider: cfgProvider,
+	})
+	require.NoError(t, err)
+	assert.Error(t, col.Flush(context.Background()))
+
+	wg := startCollector(context.Background(), t, col)
+	assert.Eventually(t, func() bool {
+		return StateRunning == col.GetState()
+	}, 2*time.Second, 200*time.Millisecond)
+	assert.NoError(t, col.Flush(context.Background()))
+
+	col.Shutdown()
+	wg.Wait()
+	assert.Equal(t, StateClosed, col.GetState())
+}
+
+func TestCollectorReloadAndIngestion(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	cfgProvider, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-nop.yaml")}))
+	require.NoError(t, err)
+
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo:      component.NewDefaultBuildInfo(),
+		Factories:      factories,
+		ConfigProvider: cfgProvider,
+	})
+	require.NoError(t, err)
+	assert.Error(t, col.PauseIngestion())
+	assert.Error(t, col.Drain(context.Background()))
+
+	wg := startCollector(context.Background(), t, col)
+	assert.Eventually(t, func() bool {
+		return StateRunning == col.GetState()
+	}, 2*time.Second, 200*time.Millisecond)
+
+	require.NoError(t, col.PauseIngestion())
+	paused, err := col.IngestionPaused()
+	require.NoError(t, err)
+	assert.True(t, paused)
+
+	// The ingestion stays paused once the configuration is reloaded.
+	require.NoError(t, col.Reload(context.Background()))
+	assert.Equal(t, StateRunning, col.GetState())
+	paused, err = col.IngestionPaused()
+	require.NoError(t, err)
+	assert.True(t, paused)
+
+	require.NoError(t, col.ResumeIngestion())
+	paused, err = col.IngestionPaused()
+	require.NoError(t, err)
+	assert.False(t, paused)
+	assert.NoError(t, col.Drain(context.Background()))
+
+	col.Shutdown()
+	wg.Wait()
+	assert.Equal(t, StateClosed, col.GetState())
+	assert.Error(t, col.Reload(context.Background()))
+}
+
+type failingReloadProvider struct {
+	ConfigProvider
+	fail atomic.Bool
+}
+
+func (p *failingReloadProvider) Get(ctx context.Context, factories Factories) (*Config, error) {
+	if p.fail.Load() {
+		return nil, errors.New("reload failed")
+	}
+	return p.ConfigProvider.Get(ctx, factories)
+}
+
+func TestCollectorReloadFailure(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	cfgProvider, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-nop.yaml")}))
+	require.NoError(t, err)
+	provider := &failingReloadProvider{ConfigProvider: cfgProvider}
+	addr := testutil.GetAvailableLocalAddress(t)
+
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo:       component.NewDefaultBuildInfo(),
+		Factories:       factories,
+		ConfigProvider:  provider,
+		ControlEndpoint: addr,
+		ControlToken:    "secret",
+	})
+	require.NoError(t, err)
+
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- col.Run(context.Background())
+	}()
+	assert.Eventually(t, func() bool {
+		conn, dialErr := net.Dial("tcp", addr)
+		if dialErr != nil {
+			return false
+		}
+		return conn.Close() == nil
+	}, 2*time.Second, 200*time.Millisecond)
+
+	provider.fail.Store(true)
+	assert.Error(t, col.Reload(context.Background()))
+	assert.ErrorContains(t, <-runErr, "reload failed")
+	assert.Equal(t, StateClosed, col.GetState())
+	assert.Error(t, col.Flush(context.Background()))
+
+	// The control server is shut down with the collector.
+	_, err = net.Dial("tcp", addr)
+	assert.Error(t, err)
+}
+
+func TestCollectorReloadChangedComponents(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	const cfgTemplate = `
+receivers:
+  nop:
+processors:
+  nop:
+exporters:
+  nop:
+extensions:
+  nop:
+service:
+  reload: changed_components
+  telemetry:
+    metrics:
+      level: %s
+  extensions: [nop]
+  pipelines:
+    traces:
+      receivers: [nop]
+      processors: [%s]
+      exporters: [nop]
+`
+	cfgFile := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(cfgFile, []byte(fmt.Sprintf(cfgTemplate, "none", "nop")), 0600))
+	cfgProvider, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{cfgFile}))
+	require.NoError(t, err)
+
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo:      component.NewDefaultBuildInfo(),
+		Factories:      factories,
+		ConfigProvider: cfgProvider,
+	})
+	require.NoError(t, err)
+
+	wg := startCollector(context.Background(), t, col)
+	assert.Eventually(t, func() bool {
+		return StateRunning == col.GetState()
+	}, 2*time.Second, 200*time.Millisecond)
+
+	// Only the pipelines changed, the service keeps running.
+	srv := col.service
+	require.NoError(t, os.WriteFile(cfgFile, []byte(fmt.Sprintf(cfgTemplate, "none", "")), 0600))
+	require.NoError(t, col.Reload(context.Background()))
+	assert.Same(t, srv, col.service)
+	assert.Equal(t, StateRunning, col.GetState())
+
+	// The telemetry changed, the service is restarted.
+	require.NoError(t, os.WriteFile(cfgFile, []byte(fmt.Sprintf(cfgTemplate, "basic", "")), 0600))
+	require.NoError(t, col.Reload(context.Background()))
+	assert.NotSame(t, srv, col.service)
+	assert.Equal(t, StateRunning, col.GetState())
+
+	col.Shutdown()
+	wg.Wait()
+	assert.Equal(t, StateClosed, col.GetState())
+}
+
+func TestCollectorFeatureGatesFromConfig(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	cfgProvider, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-featuregates.yaml")}))
+	require.NoError(t, err)
+
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo:      component.NewDefaultBuildInfo(),
+		Factories:      factories,
+		ConfigProvider: cfgProvider,
+	})
+	require.NoError(t, err)
+	col.registry = featuregate.NewRegistry()
+	configGate := col.registry.MustRegister("test.config", featuregate.StageAlpha)
+	flagGate := col.registry.MustRegister("test.flag", featuregate.StageAlpha)
+	// The flag was already applied when parsing the command line.
+	col.flagFeatureGates = []string{"+test.flag"}
+	require.NoError(t, col.registry.Set(flagGate.ID(), true))
+
+	wg := startCollector(context.Background(), t, col)
+	assert.Eventually(t, func() bool {
+		return StateRunning == col.GetState()
+	}, 2*time.Second, 200*time.Millisecond)
+
+	assert.True(t, configGate.IsEnabled())
+	// The command line takes precedence over the configuration.
+	assert.True(t, flagGate.IsEnabled())
+
+	col.Shutdown()
+	wg.Wait()
+	assert.Equal(t, StateClosed, col.GetState())
+}
+
+func TestCollectorFeatureGateWarnings(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	cfgProvider, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-nop.yaml")}))
+	require.NoError(t, err)
+
+	core, logs := observer.New(zapcore.WarnLevel)
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo:      component.NewDefaultBuildInfo(),
+		Factories:      factories,
+		ConfigProvider: cfgProvider,
+		LoggingOptions: []zap.Option{zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+			return zapcore.NewTee(c, core)
+		})},
+	})
+	require.NoError(t, err)
+	col.registry = featuregate.NewRegistry()
+	stable := col.registry.MustRegister("test.stable", featuregate.StageStable, featuregate.WithRegisterToVersion("v0.90.0"))
+	// The flag was already applied when parsing the command line, before the logger is created.
+	require.NoError(t, col.registry.Set(stable.ID(), true))
+
+	wg := startCollector(context.Background(), t, col)
+	assert.Eventually(t, func() bool {
+		return StateRunning == col.GetState()
+	}, 2*time.Second, 200*time.Millisecond)
+
+	warnings := logs.FilterField(zap.String("id", stable.ID())).AllUntimed()
+	require.Len(t, warnings, 1)
+	assert.Equal(t, "Feature gate scheduled for removal is explicitly set. Will be removed in future releases.", warnings[0].Message)
+
+	col.Shutdown()
+	wg.Wait()
+	assert.Equal(t, StateClosed, col.GetState())
+}
+
+func TestCollectorFeatureGatesRemovedFromConfig(t *testing.T) {
+	col := &Collector{registry: featuregate.NewRegistry()}
+	alpha := col.registry.MustRegister("test.alpha", featuregate.StageAlpha)
+	beta := col.registry.MustRegister("test.beta", featuregate.StageBeta)
+	runtime := col.registry.MustRegister("test.runtime", featuregate.StageAlpha, featuregate.WithRegisterRuntimeToggle())
+	flag := col.registry.MustRegister("test.flag", featuregate.StageAlpha)
+	// The flag was already applied when parsing the command line.
+	col.flagFeatureGates = []string{"+test.flag"}
+	require.NoError(t, col.registry.SetFromSource(flag.ID(), true, featuregate.SourceFlag))
+
+	require.NoError(t, col.applyFeatureGates(map[string]bool{
+		alpha.ID():   true,
+		beta.ID():    false,
+		runtime.ID(): true,
+		flag.ID():    false,
+	}))
+	assert.True(t, alpha.IsEnabled())
+	assert.False(t, beta.IsEnabled())
+	assert.True(t, runtime.IsEnabled())
+	assert.True(t, flag.IsEnabled())
+	require.NoError(t, col.registry.SetAtRuntime(runtime.ID(), false))
+
+	// The configuration reloaded without the gates restores the values they had before.
+	require.NoError(t, col.applyFeatureGates(map[string]bool{alpha.ID(): true}))
+	assert.True(t, alpha.IsEnabled())
+	assert.True(t, beta.IsEnabled())
+	assert.Equal(t, featuregate.SourceDefault, beta.Source())
+	// The gates changed since then keep their value.
+	assert.False(t, runtime.IsEnabled())
+	assert.True(t, flag.IsEnabled())
+	assert.Equal(t, featuregate.SourceFlag, flag.Source())
+
+	require.NoError(t, col.applyFeatureGates(nil))
+	assert.False(t, alpha.IsEnabled())
+	assert.Equal(t, featuregate.SourceDefault, alpha.Source())
+	assert.Empty(t, col.configFeatureGates)
+}
+
+func TestCollectorInvalidFeatureGatesFromConfig(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	cfgProvider, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-invalid-featuregates.yaml")}))
+	require.NoError(t, err)
+
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo:      component.NewDefaultBuildInfo(),
+		Factories:      factories,
+		ConfigProvider: cfgProvider,
+	})
+	require.NoError(t, err)
+	col.registry = featuregate.NewRegistry()
+	assert.ErrorContains(t, col.Run(context.Background()), "invalid service::feature_gates configuration")
+}
+
+func TestCollectorFeatureGatesRequires(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	cfgProvider, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-featuregates.yaml")}))
+	require.NoError(t, err)
+
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo:      component.NewDefaultBuildInfo(),
+		Factories:      factories,
+		ConfigProvider: cfgProvider,
+	})
+	require.NoError(t, err)
+	col.registry = featuregate.NewRegistry()
+	col.registry.MustRegister("test.base", featuregate.StageAlpha)
+	col.registry.MustRegister("test.config", featuregate.StageAlpha, featuregate.WithRegisterRequires("test.base"))
+	col.registry.MustRegister("test.flag", featuregate.StageAlpha)
+	err = col.Run(context.Background())
+	assert.ErrorContains(t, err, "invalid combination of feature gates")
+	assert.ErrorContains(t, err, `feature gate "test.config" requires feature gate "test.base" to be enabled`)
+}
+
+func TestCollectorInvalidControlEndpoint(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	cfgProvider, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-nop.yaml")}))
+	require.NoError(t, err)
+
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo:       component.NewDefaultBuildInfo(),
+		Factories:       factories,
+		ConfigProvider:  cfgProvider,
+		ControlEndpoint: "0.0.0.0:0",
+		ControlToken:    "secret",
+	})
+	require.NoError(t, err)
+	assert.Error(t, col.Run(context.Background()))
+	assert.Equal(t, StateClosed, col.GetState())
+}
+
 func TestCollectorClosedStateOnStartUpError(t *testing.T) {
 	factories, err := nopFactories()
 	require.NoError(t, err)
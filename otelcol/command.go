@@ -6,8 +6,11 @@ package otelcol // import "go.opentelemetry.io/collector/otelcol"
 import (
 	"errors"
 	"flag"
+	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
+	"go.uber.org/multierr"
 
 	"go.opentelemetry.io/collector/featuregate"
 )
@@ -20,31 +23,55 @@ func NewCommand(set CollectorSettings) *cobra.Command {
 		Version:      set.BuildInfo.Version,
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			col, err := newCollectorWithFlags(set, flagSet)
-			if err != nil {
-				return err
+			err := runCollectorWithFlags(cmd, set, flagSet)
+			if dest := getFailureReportFlag(flagSet); err != nil && dest != "" {
+				cfv := flagSet.Lookup(configFlag).Value.(*configFlagValue)
+				if reportErr := writeFailureReport(dest, err, cfv.values); reportErr != nil {
+					err = multierr.Append(err, fmt.Errorf("failed to write the failure report: %w", reportErr))
+				}
 			}
-			return col.Run(cmd.Context())
+			return err
 		},
 	}
+	rootCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return newClassifiedError(errorClassUsage, err)
+	})
 	rootCmd.AddCommand(newComponentsCommand(set))
+	rootCmd.AddCommand(newFeatureGateCommand(featuregate.GlobalRegistry()))
 	rootCmd.AddCommand(newValidateSubCommand(set, flagSet))
 	rootCmd.Flags().AddGoFlagSet(flagSet)
 	return rootCmd
 }
 
+func runCollectorWithFlags(cmd *cobra.Command, set CollectorSettings, flags *flag.FlagSet) error {
+	col, err := newCollectorWithFlags(set, flags)
+	if err != nil {
+		return err
+	}
+	return col.Run(cmd.Context())
+}
+
 func newCollectorWithFlags(set CollectorSettings, flags *flag.FlagSet) (*Collector, error) {
 	if set.ConfigProvider == nil {
 		configFlags := getConfigFlag(flags)
 		if len(configFlags) == 0 {
-			return nil, errors.New("at least one config flag must be provided")
+			return nil, newClassifiedError(errorClassUsage, errors.New("at least one config flag must be provided"))
 		}
 
 		var err error
 		set.ConfigProvider, err = NewConfigProvider(newDefaultConfigProviderSettings(configFlags))
 		if err != nil {
-			return nil, err
+			return nil, newClassifiedError(errorClassConfig, err)
 		}
 	}
-	return NewCollector(set)
+	if set.ControlEndpoint == "" {
+		set.ControlEndpoint = getControlEndpointFlag(flags)
+		set.ControlToken = os.Getenv(controlTokenEnv)
+	}
+	col, err := NewCollector(set)
+	if err != nil {
+		return nil, err
+	}
+	col.flagFeatureGates = getFeatureGatesFlag(flags)
+	return col, nil
 }
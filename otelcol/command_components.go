@@ -4,17 +4,54 @@
 package otelcol // import "go.opentelemetry.io/collector/otelcol"
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
 )
 
 type componentWithStability struct {
-	Name      component.Type
-	Stability map[string]string
+	Name        component.Type
+	Stability   map[string]string
+	Deprecation *deprecationOutput `yaml:",omitempty"`
+	Module      *moduleOutput      `yaml:",omitempty"`
+}
+
+type deprecationOutput struct {
+	Since          string `yaml:",omitempty"`
+	RemovalVersion string `yaml:",omitempty"`
+	Replacement    string `yaml:",omitempty"`
+}
+
+func newDeprecationOutput(f component.Factory) *deprecationOutput {
+	d := component.FactoryDeprecation(f)
+	if d == nil {
+		return nil
+	}
+	return &deprecationOutput{Since: d.Since, RemovalVersion: d.RemovalVersion, Replacement: d.Replacement}
+}
+
+type moduleOutput struct {
+	Path    string
+	Version string
+	Sum     string `yaml:",omitempty"`
+}
+
+func newModuleOutput(m *Manifest, kind component.Kind, f component.Factory) *moduleOutput {
+	c := m.lookup(kind, f)
+	if c == nil {
+		return nil
+	}
+	return &moduleOutput{Path: c.Module, Version: c.Version, Sum: c.Sum}
 }
 
 type componentsOutput struct {
@@ -26,70 +63,105 @@ type componentsOutput struct {
 	Extensions []componentWithStability
 }
 
+// connectorStability returns the stability levels of the connector for each pair of signals.
+func connectorStability(f connector.Factory) map[string]string {
+	return map[string]string{
+		"logs-to-logs":    f.LogsToLogsStability().String(),
+		"logs-to-metrics": f.LogsToMetricsStability().String(),
+		"logs-to-traces":  f.LogsToTracesStability().String(),
+
+		"metrics-to-logs":    f.MetricsToLogsStability().String(),
+		"metrics-to-metrics": f.MetricsToMetricsStability().String(),
+		"metrics-to-traces":  f.MetricsToTracesStability().String(),
+
+		"traces-to-logs":    f.TracesToLogsStability().String(),
+		"traces-to-metrics": f.TracesToMetricsStability().String(),
+		"traces-to-traces":  f.TracesToTracesStability().String(),
+	}
+}
+
+func extensionStability(f extension.Factory) map[string]string {
+	return map[string]string{
+		"extension": f.ExtensionStability().String(),
+	}
+}
+
+func processorStability(f processor.Factory) map[string]string {
+	return map[string]string{
+		"logs":    f.LogsProcessorStability().String(),
+		"metrics": f.MetricsProcessorStability().String(),
+		"traces":  f.TracesProcessorStability().String(),
+	}
+}
+
+func receiverStability(f receiver.Factory) map[string]string {
+	return map[string]string{
+		"logs":    f.LogsReceiverStability().String(),
+		"metrics": f.MetricsReceiverStability().String(),
+		"traces":  f.TracesReceiverStability().String(),
+	}
+}
+
+func exporterStability(f exporter.Factory) map[string]string {
+	return map[string]string{
+		"logs":    f.LogsExporterStability().String(),
+		"metrics": f.MetricsExporterStability().String(),
+		"traces":  f.TracesExporterStability().String(),
+	}
+}
+
 // newComponentsCommand constructs a new components command using the given CollectorSettings.
 func newComponentsCommand(set CollectorSettings) *cobra.Command {
-	return &cobra.Command{
+	var manifest bool
+	cmd := &cobra.Command{
 		Use:   "components",
 		Short: "Outputs available components in this collector distribution",
-		Long:  "Outputs available components in this collector distribution including their stability levels. The output format is not stable and can change between releases.",
+		Long:  "Outputs available components in this collector distribution including their stability levels and, if the distribution embeds a manifest, the Go modules they are built from. The output format is not stable and can change between releases.",
 		Args:  cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if manifest {
+				return outputManifest(cmd, set)
+			}
 
 			components := componentsOutput{}
-			for con := range set.Factories.Connectors {
+			for con, f := range set.Factories.Connectors {
 				components.Connectors = append(components.Connectors, componentWithStability{
-					Name: con,
-					Stability: map[string]string{
-						"logs-to-logs":    set.Factories.Connectors[con].LogsToLogsStability().String(),
-						"logs-to-metrics": set.Factories.Connectors[con].LogsToMetricsStability().String(),
-						"logs-to-traces":  set.Factories.Connectors[con].LogsToTracesStability().String(),
-
-						"metrics-to-logs":    set.Factories.Connectors[con].MetricsToLogsStability().String(),
-						"metrics-to-metrics": set.Factories.Connectors[con].MetricsToMetricsStability().String(),
-						"metrics-to-traces":  set.Factories.Connectors[con].MetricsToTracesStability().String(),
-
-						"traces-to-logs":    set.Factories.Connectors[con].TracesToLogsStability().String(),
-						"traces-to-metrics": set.Factories.Connectors[con].TracesToMetricsStability().String(),
-						"traces-to-traces":  set.Factories.Connectors[con].TracesToTracesStability().String(),
-					},
+					Name:        con,
+					Stability:   connectorStability(f),
+					Deprecation: newDeprecationOutput(f),
+					Module:      newModuleOutput(set.Manifest, component.KindConnector, f),
 				})
 			}
-			for ext := range set.Factories.Extensions {
+			for ext, f := range set.Factories.Extensions {
 				components.Extensions = append(components.Extensions, componentWithStability{
-					Name: ext,
-					Stability: map[string]string{
-						"extension": set.Factories.Extensions[ext].ExtensionStability().String(),
-					},
+					Name:        ext,
+					Stability:   extensionStability(f),
+					Deprecation: newDeprecationOutput(f),
+					Module:      newModuleOutput(set.Manifest, component.KindExtension, f),
 				})
 			}
-			for prs := range set.Factories.Processors {
+			for prs, f := range set.Factories.Processors {
 				components.Processors = append(components.Processors, componentWithStability{
-					Name: prs,
-					Stability: map[string]string{
-						"logs":    set.Factories.Processors[prs].LogsProcessorStability().String(),
-						"metrics": set.Factories.Processors[prs].MetricsProcessorStability().String(),
-						"traces":  set.Factories.Processors[prs].TracesProcessorStability().String(),
-					},
+					Name:        prs,
+					Stability:   processorStability(f),
+					Deprecation: newDeprecationOutput(f),
+					Module:      newModuleOutput(set.Manifest, component.KindProcessor, f),
 				})
 			}
-			for rcv := range set.Factories.Receivers {
+			for rcv, f := range set.Factories.Receivers {
 				components.Receivers = append(components.Receivers, componentWithStability{
-					Name: rcv,
-					Stability: map[string]string{
-						"logs":    set.Factories.Receivers[rcv].LogsReceiverStability().String(),
-						"metrics": set.Factories.Receivers[rcv].MetricsReceiverStability().String(),
-						"traces":  set.Factories.Receivers[rcv].TracesReceiverStability().String(),
-					},
+					Name:        rcv,
+					Stability:   receiverStability(f),
+					Deprecation: newDeprecationOutput(f),
+					Module:      newModuleOutput(set.Manifest, component.KindReceiver, f),
 				})
 			}
-			for exp := range set.Factories.Exporters {
+			for exp, f := range set.Factories.Exporters {
 				components.Exporters = append(components.Exporters, componentWithStability{
-					Name: exp,
-					Stability: map[string]string{
-						"logs":    set.Factories.Exporters[exp].LogsExporterStability().String(),
-						"metrics": set.Factories.Exporters[exp].MetricsExporterStability().String(),
-						"traces":  set.Factories.Exporters[exp].TracesExporterStability().String(),
-					},
+					Name:        exp,
+					Stability:   exporterStability(f),
+					Deprecation: newDeprecationOutput(f),
+					Module:      newModuleOutput(set.Manifest, component.KindExporter, f),
 				})
 			}
 			components.BuildInfo = set.BuildInfo
@@ -101,4 +173,20 @@ func newComponentsCommand(set CollectorSettings) *cobra.Command {
 			return nil
 		},
 	}
+	cmd.Flags().BoolVar(&manifest, "manifest", false, "Outputs the JSON manifest embedded in the distribution, completed with the stability levels of the components")
+	return cmd
+}
+
+// outputManifest writes the manifest of the distribution, with the stability levels of its components.
+func outputManifest(cmd *cobra.Command, set CollectorSettings) error {
+	if set.Manifest == nil {
+		return errors.New("the distribution does not embed a components manifest")
+	}
+	set.Manifest.setStability(set.Factories)
+	data, err := json.MarshalIndent(set.Manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	return nil
 }
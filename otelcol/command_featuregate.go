@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+type featureGateOutput struct {
+	ID           string
+	Enabled      bool
+	Stage        string
+	Owner        string `yaml:",omitempty"`
+	Description  string `yaml:",omitempty"`
+	ReferenceURL string `yaml:",omitempty"`
+	FromVersion  string `yaml:",omitempty"`
+	ToVersion    string `yaml:",omitempty"`
+}
+
+// newFeatureGateCommand constructs a new featuregate command reporting the gates of the given Registry.
+func newFeatureGateCommand(reg *featuregate.Registry) *cobra.Command {
+	return &cobra.Command{
+		Use:   "featuregate",
+		Short: "Outputs the feature gates registered in this collector distribution",
+		Long:  "Outputs the feature gates registered in this collector distribution including their stage, owner and effective value. The output format is not stable and can change between releases.",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var gates []featureGateOutput
+			reg.VisitAll(func(g *featuregate.Gate) {
+				gates = append(gates, featureGateOutput{
+					ID:           g.ID(),
+					Enabled:      g.IsEnabled(),
+					Stage:        g.Stage().String(),
+					Owner:        g.Owner(),
+					Description:  g.Description(),
+					ReferenceURL: g.ReferenceURL(),
+					FromVersion:  g.FromVersion(),
+					ToVersion:    g.ToVersion(),
+				})
+			})
+			yamlData, err := yaml.Marshal(gates)
+			if err != nil {
+				return err
+			}
+			fmt.Fprint(cmd.OutOrStdout(), string(yamlData))
+			return nil
+		},
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+func TestNewFeatureGateCommand(t *testing.T) {
+	reg := featuregate.NewRegistry()
+	reg.MustRegister("receiver.foo.bar", featuregate.StageBeta,
+		featuregate.WithRegisterOwner("receiver/foo"),
+		featuregate.WithRegisterDescription("test gate"),
+		featuregate.WithRegisterFromVersion("v0.80.0"))
+	reg.MustRegister("alpha", featuregate.StageAlpha)
+
+	cmd := newFeatureGateCommand(reg)
+	cmd.SetArgs([]string{})
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, `- id: alpha
+  enabled: false
+  stage: Alpha
+- id: receiver.foo.bar
+  enabled: true
+  stage: Beta
+  owner: receiver/foo
+  description: test gate
+  fromversion: v0.80.0
+`, b.String())
+}
@@ -6,8 +6,13 @@ package otelcol // import "go.opentelemetry.io/collector/otelcol"
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
+
+	"go.uber.org/multierr"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/service"
 )
 
@@ -48,39 +53,29 @@ func (cfg *Config) Validate() error {
 		return errMissingReceivers
 	}
 
-	// Validate the receiver configuration.
-	for recvID, recvCfg := range cfg.Receivers {
-		if err := component.ValidateConfig(recvCfg); err != nil {
-			return fmt.Errorf("receivers::%s: %w", recvID, err)
-		}
-	}
-
 	// Currently, there is no default exporter enabled.
 	// The configuration must specify at least one exporter to be valid.
 	if len(cfg.Exporters) == 0 {
 		return errMissingExporters
 	}
 
-	// Validate the exporter configuration.
-	for expID, expCfg := range cfg.Exporters {
-		if err := component.ValidateConfig(expCfg); err != nil {
-			return fmt.Errorf("exporters::%s: %w", expID, err)
-		}
+	// Validate the configuration of all the components, so that all the errors are reported at once,
+	// one component per line.
+	var errs []error
+	errs = append(errs, validateComponents("receivers", component.KindReceiver, cfg.Receivers)...)
+	errs = append(errs, validateComponents("exporters", component.KindExporter, cfg.Exporters)...)
+	errs = append(errs, validateComponents("processors", component.KindProcessor, cfg.Processors)...)
+	errs = append(errs, validateComponents("connectors", component.KindConnector, cfg.Connectors)...)
+	errs = append(errs, validateComponents("extensions", component.KindExtension, cfg.Extensions)...)
+	if len(errs) == 1 {
+		return errs[0]
 	}
-
-	// Validate the processor configuration.
-	for procID, procCfg := range cfg.Processors {
-		if err := component.ValidateConfig(procCfg); err != nil {
-			return fmt.Errorf("processors::%s: %w", procID, err)
-		}
+	if len(errs) > 1 {
+		return errors.Join(errs...)
 	}
 
 	// Validate the connector configuration.
-	for connID, connCfg := range cfg.Connectors {
-		if err := component.ValidateConfig(connCfg); err != nil {
-			return fmt.Errorf("connectors::%s: %w", connID, err)
-		}
-
+	for connID := range cfg.Connectors {
 		if _, ok := cfg.Exporters[connID]; ok {
 			return fmt.Errorf("connectors::%s: ambiguous ID: Found both %q exporter and %q connector. "+
 				"Change one of the components' IDs to eliminate ambiguity (e.g. rename %q connector to %q)",
@@ -93,13 +88,6 @@ func (cfg *Config) Validate() error {
 		}
 	}
 
-	// Validate the extension configuration.
-	for extID, extCfg := range cfg.Extensions {
-		if err := component.ValidateConfig(extCfg); err != nil {
-			return fmt.Errorf("extensions::%s: %w", extID, err)
-		}
-	}
-
 	if err := cfg.Service.Validate(); err != nil {
 		return err
 	}
@@ -147,5 +135,74 @@ func (cfg *Config) Validate() error {
 			return fmt.Errorf("service::pipelines::%s: references exporter %q which is not configured", pipelineID, ref)
 		}
 	}
-	return nil
+
+	// Check that all the configured components are used, if the service requires it.
+	return validateUnusedComponents(cfg)
+}
+
+// validateComponents validates the configuration of the components of a section, in the order of their IDs.
+func validateComponents(section string, kind component.Kind, cfgs map[component.ID]component.Config) []error {
+	ids := make([]component.ID, 0, len(cfgs))
+	for id := range cfgs {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+
+	var errs []error
+	for _, id := range ids {
+		if err := component.ValidateConfig(cfgs[id]); err != nil {
+			path := section + confmap.KeyDelimiter + id.String()
+			errs = append(errs, &componentConfigError{kind: kind, id: id, path: path, err: component.NewValidationError(path, err)})
+		}
+	}
+	return errs
+}
+
+// componentConfigError groups the validation errors of the configuration of a component.
+// Each of its errors is a component.ValidationError with the full path of the invalid value.
+type componentConfigError struct {
+	kind component.Kind
+	id   component.ID
+	path string
+	err  error
+}
+
+// Error renders the errors relative to the component, e.g.:
+//
+//	receivers::otlp: 2 errors:
+//	  - protocols::grpc::endpoint: invalid port
+//	  - protocols::http::endpoint: invalid port
+func (e *componentConfigError) Error() string {
+	errs := multierr.Errors(e.err)
+	if len(errs) == 1 {
+		path, msg := e.relativeError(errs[0])
+		if path == "" {
+			return e.path + ": " + msg
+		}
+		return e.path + confmap.KeyDelimiter + path + ": " + msg
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %d errors:", e.path, len(errs))
+	for _, err := range errs {
+		b.WriteString("\n  - ")
+		if path, msg := e.relativeError(err); path == "" {
+			b.WriteString(msg)
+		} else {
+			b.WriteString(path + ": " + msg)
+		}
+	}
+	return b.String()
+}
+
+// relativeError returns the path of err relative to the component, and its message.
+func (e *componentConfigError) relativeError(err error) (string, string) {
+	ve, ok := err.(*component.ValidationError) //nolint:errorlint
+	if !ok || !strings.HasPrefix(ve.Path, e.path) {
+		return "", err.Error()
+	}
+	return strings.TrimPrefix(strings.TrimPrefix(ve.Path, e.path), confmap.KeyDelimiter), ve.Err.Error()
+}
+
+func (e *componentConfigError) Unwrap() error {
+	return e.err
 }
@@ -9,6 +9,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
 	"go.uber.org/zap/zapcore"
 
 	"go.opentelemetry.io/collector/component"
@@ -226,11 +228,44 @@ func TestConfigValidate(t *testing.T) {
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
 			cfg := test.cfgFn()
-			assert.Equal(t, test.expected, cfg.Validate())
+			err := cfg.Validate()
+			if test.expected == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, test.expected.Error())
 		})
 	}
 }
 
+type nestedConfig struct {
+	Protocols map[string]*errConfig `mapstructure:"protocols"`
+}
+
+func TestConfigValidateErrorPaths(t *testing.T) {
+	cfg := generateConfig()
+	cfg.Receivers[component.NewID("nop")] = &nestedConfig{Protocols: map[string]*errConfig{
+		"grpc": {validateErr: errors.New("invalid grpc endpoint")},
+	}}
+	cfg.Receivers[component.NewIDWithName("nop", "2")] = &errConfig{validateErr: multierr.Combine(
+		errors.New("first error"),
+		&component.ValidationError{Path: "endpoint", Err: errors.New("second error")},
+	)}
+	cfg.Exporters[component.NewID("nop")] = &errConfig{validateErr: errInvalidExpConfig}
+
+	err := cfg.Validate()
+	assert.EqualError(t, err, `receivers::nop::protocols::grpc: invalid grpc endpoint
+receivers::nop/2: 2 errors:
+  - first error
+  - endpoint: second error
+exporters::nop: invalid exporter config`)
+	assert.ErrorIs(t, err, errInvalidExpConfig)
+
+	var ve *component.ValidationError
+	require.ErrorAs(t, err, &ve)
+	assert.Equal(t, "receivers::nop::protocols::grpc", ve.Path)
+}
+
 func generateConfig() *Config {
 	return &Config{
 		Receivers: map[component.ID]component.Config{
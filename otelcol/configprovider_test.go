@@ -16,6 +16,7 @@ import (
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/provider/fileprovider"
 	"go.opentelemetry.io/collector/confmap/provider/yamlprovider"
+	"go.opentelemetry.io/collector/featuregate"
 )
 
 func newConfig(yamlBytes []byte, factories Factories) (*Config, error) {
@@ -127,3 +128,31 @@ func TestGetConfmap(t *testing.T) {
 
 	assert.EqualValues(t, yamlMap, cmap.ToStringMap())
 }
+
+func TestConfigProviderStdin(t *testing.T) {
+	stdin, err := os.Open(filepath.Join("testdata", "otelcol-nop.yaml"))
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, stdin.Close()) })
+	oldStdin := os.Stdin
+	os.Stdin = stdin
+	t.Cleanup(func() { os.Stdin = oldStdin })
+
+	flgs := flags(featuregate.NewRegistry())
+	require.NoError(t, flgs.Parse([]string{"--config=stdin:", "--set=service.telemetry.metrics.address=localhost:9999"}))
+
+	cp, err := NewConfigProvider(newDefaultConfigProviderSettings(getConfigFlag(flgs)))
+	require.NoError(t, err)
+
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	cfg, err := cp.Get(context.Background(), factories)
+	require.NoError(t, err)
+	assert.Equal(t, "localhost:9999", cfg.Service.Telemetry.Metrics.Address)
+	assert.Len(t, cfg.Service.Pipelines, 3)
+
+	// The standard input can only be read once, the configuration is retrieved again from the read content.
+	cfg, err = cp.Get(context.Background(), factories)
+	require.NoError(t, err)
+	assert.Len(t, cfg.Service.Pipelines, 3)
+}
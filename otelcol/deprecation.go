@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+// logDeprecations warns about the deprecated components and configuration fields used in the configuration,
+// so that they can be replaced before they are removed.
+func logDeprecations(logger *zap.Logger, cfg *Config, factories Factories) {
+	logSectionDeprecations(logger, "receivers", cfg.Receivers, func(t component.Type) component.Factory {
+		return factories.Receivers[t]
+	})
+	logSectionDeprecations(logger, "processors", cfg.Processors, func(t component.Type) component.Factory {
+		return factories.Processors[t]
+	})
+	logSectionDeprecations(logger, "exporters", cfg.Exporters, func(t component.Type) component.Factory {
+		return factories.Exporters[t]
+	})
+	logSectionDeprecations(logger, "connectors", cfg.Connectors, func(t component.Type) component.Factory {
+		return factories.Connectors[t]
+	})
+	logSectionDeprecations(logger, "extensions", cfg.Extensions, func(t component.Type) component.Factory {
+		return factories.Extensions[t]
+	})
+}
+
+func logSectionDeprecations(logger *zap.Logger, section string, cfgs map[component.ID]component.Config, factory func(component.Type) component.Factory) {
+	ids := make([]component.ID, 0, len(cfgs))
+	for id := range cfgs {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+
+	for _, id := range ids {
+		path := section + confmap.KeyDelimiter + id.String()
+		var defaultCfg component.Config
+		if f := factory(id.Type()); f != nil {
+			defaultCfg = f.CreateDefaultConfig()
+			if d := component.FactoryDeprecation(f); d != nil {
+				logger.Warn("Deprecated component. Will be removed in future releases.",
+					zap.String("path", path),
+					zap.String("since", d.Since),
+					zap.String("removal_version", d.RemovalVersion),
+					zap.String("replacement", d.Replacement))
+			}
+		}
+		for _, field := range component.DeprecatedFields(cfgs[id], defaultCfg) {
+			logger.Warn("Deprecated configuration field. Will be removed in future releases.",
+				zap.String("path", path+confmap.KeyDelimiter+field.Path),
+				zap.String("replacement", field.Replacement))
+		}
+	}
+}
+
+// logFeatureGateWarning warns about the explicit use of a feature gate scheduled for removal, or a
+// feature gate still registered past its removal version, see featuregate.Registry.SetWarningHandler.
+func logFeatureGateWarning(logger *zap.Logger, w featuregate.Warning) {
+	if w.Removed {
+		logger.Warn("Feature gate past its removal version is still registered.",
+			zap.String("id", w.ID),
+			zap.String("removal_version", w.ToVersion))
+		return
+	}
+	logger.Warn("Feature gate scheduled for removal is explicitly set. Will be removed in future releases.",
+		zap.String("id", w.ID),
+		zap.String("stage", strings.ToLower(w.Stage.String())),
+		zap.String("removal_version", w.ToVersion))
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+type deprecatedFieldConfig struct {
+	Port int `mapstructure:"port" deprecated:"use endpoint instead"`
+}
+
+func deprecatedReceiverFactory() receiver.Factory {
+	return receiver.NewFactory("deprecated",
+		func() component.Config { return &deprecatedFieldConfig{Port: 8080} },
+		receiver.WithDeprecation(component.Deprecation{Since: "v0.85.0", RemovalVersion: "v0.90.0", Replacement: "the otlp receiver"}))
+}
+
+func TestLogDeprecations(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+	factories.Receivers["deprecated"] = deprecatedReceiverFactory()
+
+	cfg := generateConfig()
+	cfg.Receivers[component.NewIDWithName("deprecated", "1")] = &deprecatedFieldConfig{Port: 4317}
+	// The default value set by the factory is not reported.
+	cfg.Receivers[component.NewIDWithName("deprecated", "2")] = &deprecatedFieldConfig{Port: 8080}
+
+	core, logs := observer.New(zapcore.WarnLevel)
+	logDeprecations(zap.New(core), cfg, factories)
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 3)
+	assert.Equal(t, "Deprecated component. Will be removed in future releases.", entries[0].Message)
+	assert.Equal(t, map[string]any{
+		"path":            "receivers::deprecated/1",
+		"since":           "v0.85.0",
+		"removal_version": "v0.90.0",
+		"replacement":     "the otlp receiver",
+	}, entries[0].ContextMap())
+	assert.Equal(t, "Deprecated configuration field. Will be removed in future releases.", entries[1].Message)
+	assert.Equal(t, map[string]any{
+		"path":        "receivers::deprecated/1::port",
+		"replacement": "use endpoint instead",
+	}, entries[1].ContextMap())
+	assert.Equal(t, "receivers::deprecated/2", entries[2].ContextMap()["path"])
+}
+
+func TestLogFeatureGateWarning(t *testing.T) {
+	core, logs := observer.New(zapcore.WarnLevel)
+	logFeatureGateWarning(zap.New(core), featuregate.Warning{ID: "test.gate", Stage: featuregate.StageStable, ToVersion: "v0.90.0"})
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "Feature gate scheduled for removal is explicitly set. Will be removed in future releases.", entries[0].Message)
+	assert.Equal(t, map[string]any{
+		"id":              "test.gate",
+		"stage":           "stable",
+		"removal_version": "v0.90.0",
+	}, entries[0].ContextMap())
+
+	logs.TakeAll()
+	logFeatureGateWarning(zap.New(core), featuregate.Warning{ID: "test.gate", Stage: featuregate.StageStable, ToVersion: "v0.80.0", Removed: true})
+	entries = logs.AllUntimed()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "Feature gate past its removal version is still registered.", entries[0].Message)
+	assert.Equal(t, map[string]any{
+		"id":              "test.gate",
+		"removal_version": "v0.80.0",
+	}, entries[0].ContextMap())
+}
+
+func TestComponentsCommandDeprecation(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+	factories.Receivers = map[component.Type]receiver.Factory{"deprecated": deprecatedReceiverFactory()}
+
+	cmd := newComponentsCommand(CollectorSettings{BuildInfo: component.NewDefaultBuildInfo(), Factories: factories})
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, b.String(), `receivers:
+    - name: deprecated
+      stability:
+        logs: Undefined
+        metrics: Undefined
+        traces: Undefined
+      deprecation:
+        since: v0.85.0
+        removalversion: v0.90.0
+        replacement: the otlp receiver
+`)
+}
@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Exit codes of the collector process, telling apart the causes of a failure, see ExitCode.
+const (
+	// ExitCodeFailure is the exit code of the failures not covered by another exit code.
+	ExitCodeFailure = 1
+	// ExitCodeUsage is the exit code of an invalid command line, e.g. an unknown flag.
+	ExitCodeUsage = 2
+	// ExitCodeInvalidConfig is the exit code of a configuration that cannot be resolved, or is invalid.
+	ExitCodeInvalidConfig = 3
+	// ExitCodeAddressInUse is the exit code of a failure to listen on an address already in use.
+	ExitCodeAddressInUse = 4
+	// ExitCodeComponentStart is the exit code of a failure of a component to start.
+	ExitCodeComponentStart = 5
+)
+
+// errorClass is the class of the error causing the collector to fail, reported in the failure report.
+type errorClass string
+
+const (
+	errorClassUnknown        errorClass = "unknown"
+	errorClassUsage          errorClass = "usage"
+	errorClassConfig         errorClass = "config"
+	errorClassAddressInUse   errorClass = "address_in_use"
+	errorClassComponentStart errorClass = "component_start"
+)
+
+func (c errorClass) exitCode() int {
+	switch c {
+	case errorClassUsage:
+		return ExitCodeUsage
+	case errorClassConfig:
+		return ExitCodeInvalidConfig
+	case errorClassAddressInUse:
+		return ExitCodeAddressInUse
+	case errorClassComponentStart:
+		return ExitCodeComponentStart
+	}
+	return ExitCodeFailure
+}
+
+// classifiedError sets the class of the errors returned by the collector, when it is known at the origin.
+type classifiedError struct {
+	class errorClass
+	err   error
+}
+
+func newClassifiedError(class errorClass, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &classifiedError{class: class, err: err}
+}
+
+func (e *classifiedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *classifiedError) Unwrap() error {
+	return e.err
+}
+
+// classify returns the class of the error returned by the collector.
+func classify(err error) errorClass {
+	// The address may be in use either for a component, or for the own telemetry of the collector.
+	if errors.Is(err, syscall.EADDRINUSE) {
+		return errorClassAddressInUse
+	}
+	var startErr *component.StartError
+	if errors.As(err, &startErr) {
+		return errorClassComponentStart
+	}
+	var ce *classifiedError
+	if errors.As(err, &ce) {
+		return ce.class
+	}
+	return errorClassUnknown
+}
+
+// ExitCode returns the exit code of the collector process for the error returned by the command
+// built with NewCommand, or 0 if err is nil.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	return classify(err).exitCode()
+}
+
+// failureReport is the machine-readable report of the error causing the collector to fail.
+type failureReport struct {
+	ExitCode      int      `json:"exit_code"`
+	ErrorClass    string   `json:"error_class"`
+	Error         string   `json:"error"`
+	ComponentKind string   `json:"component_kind,omitempty"`
+	ComponentID   string   `json:"component_id,omitempty"`
+	ConfigPaths   []string `json:"config_paths,omitempty"`
+}
+
+func newFailureReport(err error, configPaths []string) failureReport {
+	class := classify(err)
+	report := failureReport{
+		ExitCode:    class.exitCode(),
+		ErrorClass:  string(class),
+		Error:       err.Error(),
+		ConfigPaths: configPaths,
+	}
+	var startErr *component.StartError
+	var cfgErr *componentConfigError
+	switch {
+	case errors.As(err, &startErr):
+		report.ComponentKind = startErr.Kind.String()
+		report.ComponentID = startErr.ID.String()
+	case errors.As(err, &cfgErr):
+		report.ComponentKind = cfgErr.kind.String()
+		report.ComponentID = cfgErr.id.String()
+	}
+	return report
+}
+
+// writeFailureReport writes the failure report of runErr as JSON to dest, either the path of a file,
+// or "fd:<n>" for an open file descriptor.
+func writeFailureReport(dest string, runErr error, configPaths []string) error {
+	data, err := json.Marshal(newFailureReport(runErr, configPaths))
+	if err != nil {
+		return err
+	}
+
+	var f *os.File
+	if fd, ok := strings.CutPrefix(dest, "fd:"); ok {
+		n, convErr := strconv.ParseUint(fd, 10, 32)
+		if convErr != nil {
+			return fmt.Errorf("invalid file descriptor %q: %w", fd, convErr)
+		}
+		f = os.NewFile(uintptr(n), dest)
+	} else if f, err = os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600); err != nil {
+		return err
+	}
+	_, err = f.Write(append(data, '\n'))
+	return errors.Join(err, f.Close())
+}
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+func TestExitCode(t *testing.T) {
+	startErr := &component.StartError{Kind: component.KindReceiver, ID: component.NewID("otlp"), Err: errors.New("failed")}
+	tests := []struct {
+		name     string
+		err      error
+		exitCode int
+	}{
+		{name: "no_error", err: nil, exitCode: 0},
+		{name: "unknown", err: errors.New("failed"), exitCode: ExitCodeFailure},
+		{name: "usage", err: newClassifiedError(errorClassUsage, errors.New("unknown flag")), exitCode: ExitCodeUsage},
+		{name: "config", err: fmt.Errorf("wrapped: %w", newClassifiedError(errorClassConfig, errors.New("invalid"))), exitCode: ExitCodeInvalidConfig},
+		{name: "component_start", err: newClassifiedError(errorClassComponentStart, startErr), exitCode: ExitCodeComponentStart},
+		{name: "component_start_with_shutdown_error", err: multierr.Combine(startErr, errors.New("shutdown failed")), exitCode: ExitCodeComponentStart},
+		{
+			name:     "address_in_use",
+			err:      &component.StartError{Kind: component.KindReceiver, ID: component.NewID("otlp"), Err: fmt.Errorf("listen: %w", syscall.EADDRINUSE)},
+			exitCode: ExitCodeAddressInUse,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.exitCode, ExitCode(tt.err))
+		})
+	}
+}
+
+func TestNewFailureReport(t *testing.T) {
+	startErr := &component.StartError{Kind: component.KindExporter, ID: component.NewIDWithName("otlp", "backend"), Err: errors.New("failed")}
+	assert.Equal(t, failureReport{
+		ExitCode:      ExitCodeComponentStart,
+		ErrorClass:    "component_start",
+		Error:         `failed to start exporter "otlp/backend": failed`,
+		ComponentKind: "exporter",
+		ComponentID:   "otlp/backend",
+		ConfigPaths:   []string{"config.yaml"},
+	}, newFailureReport(newClassifiedError(errorClassComponentStart, startErr), []string{"config.yaml"}))
+
+	cfgErr := &componentConfigError{kind: component.KindReceiver, id: component.NewID("otlp"), path: "receivers::otlp", err: errors.New("invalid port")}
+	assert.Equal(t, failureReport{
+		ExitCode:      ExitCodeInvalidConfig,
+		ErrorClass:    "config",
+		Error:         "invalid configuration: receivers::otlp: invalid port",
+		ComponentKind: "receiver",
+		ComponentID:   "otlp",
+	}, newFailureReport(newClassifiedError(errorClassConfig, fmt.Errorf("invalid configuration: %w", cfgErr)), nil))
+}
+
+func TestNewCommandFailureReport(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	configPath := filepath.Join("testdata", "otelcol-invalid.yaml")
+	reportPath := filepath.Join(t.TempDir(), "report.json")
+	cmd := NewCommand(CollectorSettings{Factories: factories})
+	cmd.SetArgs([]string{"--config", configPath, "--failure-report", reportPath})
+	err = cmd.Execute()
+	require.Error(t, err)
+	assert.Equal(t, ExitCodeInvalidConfig, ExitCode(err))
+
+	data, err := os.ReadFile(reportPath)
+	require.NoError(t, err)
+	var report failureReport
+	require.NoError(t, json.Unmarshal(data, &report))
+	assert.Equal(t, ExitCodeInvalidConfig, report.ExitCode)
+	assert.Equal(t, "config", report.ErrorClass)
+	assert.Contains(t, report.Error, `references processor "invalid" which is not configured`)
+	assert.Equal(t, []string{configPath}, report.ConfigPaths)
+}
+
+func TestNewCommandFailureReportInvalidDestination(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	cmd := NewCommand(CollectorSettings{Factories: factories})
+	cmd.SetArgs([]string{"--failure-report", "fd:stderr"})
+	err = cmd.Execute()
+	assert.ErrorContains(t, err, `failed to write the failure report: invalid file descriptor "stderr"`)
+	// The exit code is the one of the failure being reported.
+	assert.Equal(t, ExitCodeUsage, ExitCode(err))
+}
+
+func TestNewCommandUsageError(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	cmd := NewCommand(CollectorSettings{Factories: factories})
+	cmd.SetArgs([]string{"--unknown-flag"})
+	assert.Equal(t, ExitCodeUsage, ExitCode(cmd.Execute()))
+}
@@ -12,8 +12,14 @@ import (
 )
 
 const (
-	configFlag       = "config"
-	featureGatesFlag = "feature-gates"
+	configFlag          = "config"
+	featureGatesFlag    = "feature-gates"
+	controlEndpointFlag = "control-endpoint"
+	failureReportFlag   = "failure-report"
+
+	// controlTokenEnv is the environment variable holding the control API token.
+	// It is deliberately not a flag so the token does not show in process listings.
+	controlTokenEnv = "OTELCOL_CONTROL_TOKEN"
 )
 
 type configFlagValue struct {
@@ -30,6 +36,18 @@ func (s *configFlagValue) String() string {
 	return "[" + strings.Join(s.values, ", ") + "]"
 }
 
+// featureGatesFlagValue applies the feature gates to the registry and remembers the raw values,
+// so they can be applied again after the gates from the configuration to take precedence.
+type featureGatesFlagValue struct {
+	flag.Value
+	values []string
+}
+
+func (f *featureGatesFlagValue) Set(s string) error {
+	f.values = append(f.values, s)
+	return f.Value.Set(s)
+}
+
 func flags(reg *featuregate.Registry) *flag.FlagSet {
 	flagSet := new(flag.FlagSet)
 
@@ -50,9 +68,16 @@ func flags(reg *featuregate.Registry) *flag.FlagSet {
 			return nil
 		})
 
-	flagSet.Var(featuregate.NewFlag(reg), featureGatesFlag,
+	flagSet.Var(&featureGatesFlagValue{Value: featuregate.NewFlag(reg)}, featureGatesFlag,
 		"Comma-delimited list of feature gate identifiers. Prefix with '-' to disable the feature. '+' or no prefix will enable the feature.")
 
+	flagSet.String(controlEndpointFlag, "",
+		"Loopback address or unix socket on which to serve the control API, e.g. `localhost:55690` or `unix:/var/run/otelcol.sock`. "+
+			"Requests must present the token from the "+controlTokenEnv+" environment variable as a bearer token.")
+
+	flagSet.String(failureReportFlag, "",
+		"File to write a JSON report of the error to when the collector fails, or `fd:<n>` for an open file descriptor, e.g. `fd:3`.")
+
 	return flagSet
 }
 
@@ -60,3 +85,15 @@ func getConfigFlag(flagSet *flag.FlagSet) []string {
 	cfv := flagSet.Lookup(configFlag).Value.(*configFlagValue)
 	return append(cfv.values, cfv.sets...)
 }
+
+func getControlEndpointFlag(flagSet *flag.FlagSet) string {
+	return flagSet.Lookup(controlEndpointFlag).Value.String()
+}
+
+func getFailureReportFlag(flagSet *flag.FlagSet) string {
+	return flagSet.Lookup(failureReportFlag).Value.String()
+}
+
+func getFeatureGatesFlag(flagSet *flag.FlagSet) []string {
+	return flagSet.Lookup(featureGatesFlag).Value.(*featureGatesFlagValue).values
+}
@@ -69,3 +69,12 @@ func TestSetFlag(t *testing.T) {
 		})
 	}
 }
+
+func TestFeatureGatesFlagValues(t *testing.T) {
+	reg := featuregate.NewRegistry()
+	reg.MustRegister("foo", featuregate.StageAlpha)
+	reg.MustRegister("bar", featuregate.StageBeta)
+	flgs := flags(reg)
+	require.NoError(t, flgs.Parse([]string{"--feature-gates=foo", "--feature-gates=-bar"}))
+	assert.Equal(t, []string{"foo", "-bar"}, getFeatureGatesFlag(flgs))
+}
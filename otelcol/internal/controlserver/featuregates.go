@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controlserver // import "go.opentelemetry.io/collector/otelcol/internal/controlserver"
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+// FeatureGatesPath is the path under which the feature gates are served.
+const FeatureGatesPath = "/featuregates"
+
+type featureGate struct {
+	ID               string `json:"id"`
+	Stage            string `json:"stage"`
+	Enabled          bool   `json:"enabled"`
+	RuntimeTogglable bool   `json:"runtime_togglable"`
+	Description      string `json:"description,omitempty"`
+}
+
+type setFeatureGateRequest struct {
+	Enabled bool `json:"enabled"`
+}
+
+// FeatureGatesHandler returns a handler listing the gates of the registry on GET requests
+// to FeatureGatesPath, and setting a gate on POST requests to FeatureGatesPath/<id>.
+// Setting a gate goes through setFn so the caller can log or otherwise react to the change.
+func FeatureGatesHandler(reg *featuregate.Registry, setFn func(id string, enabled bool) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, FeatureGatesPath), "/")
+		switch {
+		case r.Method == http.MethodGet && id == "":
+			var gates []featureGate
+			reg.VisitAll(func(g *featuregate.Gate) {
+				gates = append(gates, featureGate{
+					ID:               g.ID(),
+					Stage:            g.Stage().String(),
+					Enabled:          g.IsEnabled(),
+					RuntimeTogglable: g.IsRuntimeTogglable(),
+					Description:      g.Description(),
+				})
+			})
+			writeJSON(w, gates)
+		case r.Method == http.MethodPost && id != "":
+			var req setFeatureGateRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if err := setFn(id, req.Enabled); err != nil {
+				http.Error(w, err.Error(), http.StatusConflict)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controlserver // import "go.opentelemetry.io/collector/otelcol/internal/controlserver"
+
+import (
+	"context"
+	"net/http"
+)
+
+// FlushPath is the path under which the pipelines are flushed.
+const FlushPath = "/flush"
+
+// FlushHandler returns a handler calling flushFn on POST requests to FlushPath,
+// returning once the pending data of the processors and the exporters is sent.
+func FlushHandler(flushFn func(ctx context.Context) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if err := flushFn(r.Context()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
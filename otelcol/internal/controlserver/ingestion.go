@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controlserver // import "go.opentelemetry.io/collector/otelcol/internal/controlserver"
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// IngestionPath is the path under which the ingestion of the receivers is controlled.
+const IngestionPath = "/ingestion"
+
+// IngestionController pauses, resumes and drains the ingestion of the receivers.
+type IngestionController interface {
+	PauseIngestion() error
+	ResumeIngestion() error
+	// Drain pauses the ingestion and returns once the data already received is sent.
+	Drain(ctx context.Context) error
+	IngestionPaused() (bool, error)
+}
+
+type ingestionStatus struct {
+	Paused bool `json:"paused"`
+}
+
+// IngestionHandler returns a handler reporting whether the ingestion is paused on GET requests to
+// IngestionPath, and pausing, resuming or draining the ingestion on POST requests to
+// IngestionPath/pause, IngestionPath/resume and IngestionPath/drain.
+func IngestionHandler(ctl IngestionController) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		action := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, IngestionPath), "/")
+		if r.Method == http.MethodGet && action == "" {
+			paused, err := ctl.IngestionPaused()
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusConflict)
+				return
+			}
+			writeJSON(w, ingestionStatus{Paused: paused})
+			return
+		}
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var err error
+		switch action {
+		case "pause":
+			err = ctl.PauseIngestion()
+		case "resume":
+			err = ctl.ResumeIngestion()
+		case "drain":
+			err = ctl.Drain(r.Context())
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
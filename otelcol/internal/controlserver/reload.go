@@ -0,0 +1,28 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controlserver // import "go.opentelemetry.io/collector/otelcol/internal/controlserver"
+
+import (
+	"context"
+	"net/http"
+)
+
+// ReloadPath is the path under which the configuration is reloaded.
+const ReloadPath = "/reload"
+
+// ReloadHandler returns a handler calling reloadFn on POST requests to ReloadPath,
+// returning once the service is restarted with the configuration loaded again.
+func ReloadHandler(reloadFn func(ctx context.Context) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if err := reloadFn(r.Context()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controlserver // import "go.opentelemetry.io/collector/otelcol/internal/controlserver"
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// ScrapePath is the path under which the receivers scrape on demand.
+const ScrapePath = "/scrape"
+
+// ScrapeHandler returns a handler listing the receivers able to scrape on demand on GET requests
+// to ScrapePath, and calling scrapeFn on POST requests to ScrapePath/<receiver id>, returning once
+// the scraped data is passed to the pipelines.
+func ScrapeHandler(receiversFn func() []string, scrapeFn func(ctx context.Context, id string) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, ScrapePath), "/")
+		switch {
+		case r.Method == http.MethodGet && id == "":
+			receivers := receiversFn()
+			if receivers == nil {
+				receivers = []string{}
+			}
+			writeJSON(w, receivers)
+		case r.Method == http.MethodPost && id != "":
+			if !contains(receiversFn(), id) {
+				http.Error(w, "receiver "+id+" does not scrape on demand", http.StatusNotFound)
+				return
+			}
+			if err := scrapeFn(r.Context(), id); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
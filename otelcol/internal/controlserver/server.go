@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package controlserver implements the local, authenticated control API of the Collector.
+package controlserver // import "go.opentelemetry.io/collector/otelcol/internal/controlserver"
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// unixPrefix is the prefix of the endpoints of the control API listening on a unix socket.
+const unixPrefix = "unix:"
+
+var (
+	errMissingToken    = errors.New("control API requires a non-empty token")
+	errNonLocalAddress = errors.New("control API can only listen on a loopback address")
+)
+
+// Server serves the control API on a local endpoint. Every request must carry
+// the configured token as a bearer token in the Authorization header.
+type Server struct {
+	network  string
+	endpoint string
+	token    string
+	mux      *http.ServeMux
+	server   *http.Server
+	listener net.Listener
+}
+
+// New returns a new Server listening on endpoint once started. The endpoint must either
+// resolve to a loopback address, or be the path of a unix socket prefixed with "unix:",
+// e.g. "unix:/var/run/otelcol.sock". The control API is never exposed to the network.
+func New(endpoint string, token string) (*Server, error) {
+	if token == "" {
+		return nil, errMissingToken
+	}
+	if path, ok := strings.CutPrefix(endpoint, unixPrefix); ok {
+		// Accept the "unix:///path" form of the URLs as well.
+		path = strings.TrimPrefix(path, "//")
+		if path == "" {
+			return nil, fmt.Errorf("invalid control endpoint %q: empty unix socket path", endpoint)
+		}
+		return &Server{
+			network:  "unix",
+			endpoint: path,
+			token:    token,
+			mux:      http.NewServeMux(),
+		}, nil
+	}
+	host, _, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid control endpoint %q: %w", endpoint, err)
+	}
+	if host != "localhost" {
+		if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+			return nil, errNonLocalAddress
+		}
+	}
+	return &Server{
+		network:  "tcp",
+		endpoint: endpoint,
+		token:    token,
+		mux:      http.NewServeMux(),
+	}, nil
+}
+
+// Handle registers the handler for the given pattern.
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+// Start starts serving the control API in a separate goroutine.
+func (s *Server) Start(logger *zap.Logger) error {
+	if s.network == "unix" {
+		removeStaleSocket(s.endpoint)
+	}
+	ln, err := net.Listen(s.network, s.endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to listen on control endpoint %q: %w", s.endpoint, err)
+	}
+	if s.network == "unix" {
+		// Only the user running the collector can connect to the socket.
+		if err = os.Chmod(s.endpoint, 0600); err != nil {
+			_ = ln.Close()
+			return fmt.Errorf("failed to restrict the permissions of control socket %q: %w", s.endpoint, err)
+		}
+	}
+	s.listener = ln
+	s.server = &http.Server{Handler: s.authenticate(s.mux)} // #nosec G112
+	logger.Info("Starting control API", zap.String("endpoint", ln.Addr().String()))
+	go func() {
+		if errServe := s.server.Serve(ln); errServe != nil && !errors.Is(errServe, http.ErrServerClosed) {
+			logger.Error("Control API server failed", zap.Error(errServe))
+		}
+	}()
+	return nil
+}
+
+// removeStaleSocket removes the unix socket left by a collector that did not shut down properly,
+// the socket is kept if something still listens on it.
+func removeStaleSocket(path string) {
+	fi, err := os.Stat(path)
+	if err != nil || fi.Mode()&os.ModeSocket == 0 {
+		return
+	}
+	conn, err := net.Dial("unix", path)
+	if err == nil {
+		_ = conn.Close()
+		return
+	}
+	_ = os.Remove(path)
+}
+
+// Addr returns the address the server is listening on, or nil if not started.
+func (s *Server) Addr() net.Addr {
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
+}
+
+// Shutdown stops the server.
+func (s *Server) Shutdown(ctx context.Context) error {
+	if s.server == nil {
+		return nil
+	}
+	return s.server.Shutdown(ctx)
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
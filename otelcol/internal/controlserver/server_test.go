@@ -0,0 +1,283 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controlserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+func TestNew(t *testing.T) {
+	_, err := New("localhost:0", "")
+	assert.ErrorIs(t, err, errMissingToken)
+	_, err = New("0.0.0.0:0", "token")
+	assert.ErrorIs(t, err, errNonLocalAddress)
+	_, err = New("example.com:55690", "token")
+	assert.ErrorIs(t, err, errNonLocalAddress)
+	_, err = New("localhost", "token")
+	assert.Error(t, err)
+	_, err = New("[::1]:0", "token")
+	assert.NoError(t, err)
+}
+
+func startServer(t *testing.T, reg *featuregate.Registry) string {
+	srv, err := New("127.0.0.1:0", "secret")
+	require.NoError(t, err)
+	srv.Handle(FeatureGatesPath, FeatureGatesHandler(reg, reg.SetAtRuntime))
+	srv.Handle(FeatureGatesPath+"/", FeatureGatesHandler(reg, reg.SetAtRuntime))
+	require.NoError(t, srv.Start(zap.NewNop()))
+	t.Cleanup(func() { assert.NoError(t, srv.Shutdown(context.Background())) })
+	return "http://" + srv.Addr().String()
+}
+
+func doRequest(t *testing.T, method, url, token, body string) *http.Response {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	return resp
+}
+
+func TestFeatureGatesHandler(t *testing.T) {
+	reg := featuregate.NewRegistry()
+	alpha := reg.MustRegister("alpha", featuregate.StageAlpha, featuregate.WithRegisterDescription("alpha gate"), featuregate.WithRegisterRuntimeToggle())
+	reg.MustRegister("beta", featuregate.StageBeta)
+	reg.MustRegister("stable", featuregate.StageStable, featuregate.WithRegisterToVersion("v0.100.0"))
+	endpoint := startServer(t, reg)
+
+	assert.Equal(t, http.StatusUnauthorized, doRequest(t, http.MethodGet, endpoint+FeatureGatesPath, "", "").StatusCode)
+	assert.Equal(t, http.StatusUnauthorized, doRequest(t, http.MethodGet, endpoint+FeatureGatesPath, "wrong", "").StatusCode)
+
+	resp := doRequest(t, http.MethodGet, endpoint+FeatureGatesPath, "secret", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var gates []featureGate
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&gates))
+	assert.Equal(t, []featureGate{
+		{ID: "alpha", Stage: "Alpha", Enabled: false, RuntimeTogglable: true, Description: "alpha gate"},
+		{ID: "beta", Stage: "Beta", Enabled: true},
+		{ID: "stable", Stage: "Stable", Enabled: true},
+	}, gates)
+
+	resp = doRequest(t, http.MethodPost, endpoint+FeatureGatesPath+"/alpha", "secret", `{"enabled":true}`)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.True(t, alpha.IsEnabled())
+
+	resp = doRequest(t, http.MethodPost, endpoint+FeatureGatesPath+"/beta", "secret", `{"enabled":false}`)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp = doRequest(t, http.MethodPost, endpoint+FeatureGatesPath+"/stable", "secret", `{"enabled":false}`)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp = doRequest(t, http.MethodPost, endpoint+FeatureGatesPath+"/alpha", "secret", `{`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = doRequest(t, http.MethodDelete, endpoint+FeatureGatesPath+"/alpha", "secret", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestFlushHandler(t *testing.T) {
+	var flushErr error
+	flushed := 0
+	srv, err := New("127.0.0.1:0", "secret")
+	require.NoError(t, err)
+	srv.Handle(FlushPath, FlushHandler(func(context.Context) error {
+		flushed++
+		return flushErr
+	}))
+	require.NoError(t, srv.Start(zap.NewNop()))
+	t.Cleanup(func() { assert.NoError(t, srv.Shutdown(context.Background())) })
+	endpoint := "http://" + srv.Addr().String()
+
+	assert.Equal(t, http.StatusUnauthorized, doRequest(t, http.MethodPost, endpoint+FlushPath, "", "").StatusCode)
+	assert.Equal(t, http.StatusMethodNotAllowed, doRequest(t, http.MethodGet, endpoint+FlushPath, "secret", "").StatusCode)
+	assert.Equal(t, 0, flushed)
+
+	assert.Equal(t, http.StatusNoContent, doRequest(t, http.MethodPost, endpoint+FlushPath, "secret", "").StatusCode)
+	assert.Equal(t, 1, flushed)
+
+	flushErr = errors.New("flush failed")
+	assert.Equal(t, http.StatusInternalServerError, doRequest(t, http.MethodPost, endpoint+FlushPath, "secret", "").StatusCode)
+	assert.Equal(t, 2, flushed)
+}
+
+func TestScrapeHandler(t *testing.T) {
+	var scrapeErr error
+	var scraped []string
+	srv, err := New("127.0.0.1:0", "secret")
+	require.NoError(t, err)
+	handler := ScrapeHandler(func() []string { return []string{"hostmetrics"} }, func(_ context.Context, id string) error {
+		scraped = append(scraped, id)
+		return scrapeErr
+	})
+	srv.Handle(ScrapePath, handler)
+	srv.Handle(ScrapePath+"/", handler)
+	require.NoError(t, srv.Start(zap.NewNop()))
+	t.Cleanup(func() { assert.NoError(t, srv.Shutdown(context.Background())) })
+	endpoint := "http://" + srv.Addr().String()
+
+	resp := doRequest(t, http.MethodGet, endpoint+ScrapePath, "secret", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var receivers []string
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&receivers))
+	assert.Equal(t, []string{"hostmetrics"}, receivers)
+
+	assert.Equal(t, http.StatusUnauthorized, doRequest(t, http.MethodPost, endpoint+ScrapePath+"/hostmetrics", "", "").StatusCode)
+	assert.Equal(t, http.StatusMethodNotAllowed, doRequest(t, http.MethodPost, endpoint+ScrapePath, "secret", "").StatusCode)
+	assert.Equal(t, http.StatusNotFound, doRequest(t, http.MethodPost, endpoint+ScrapePath+"/otlp", "secret", "").StatusCode)
+	assert.Empty(t, scraped)
+
+	assert.Equal(t, http.StatusNoContent, doRequest(t, http.MethodPost, endpoint+ScrapePath+"/hostmetrics", "secret", "").StatusCode)
+	assert.Equal(t, []string{"hostmetrics"}, scraped)
+
+	scrapeErr = errors.New("scrape failed")
+	assert.Equal(t, http.StatusInternalServerError, doRequest(t, http.MethodPost, endpoint+ScrapePath+"/hostmetrics", "secret", "").StatusCode)
+}
+
+func TestNewUnix(t *testing.T) {
+	_, err := New("unix:", "token")
+	assert.Error(t, err)
+	srv, err := New("unix:///tmp/otelcol.sock", "token")
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/otelcol.sock", srv.endpoint)
+	srv, err = New("unix:/tmp/otelcol.sock", "token")
+	require.NoError(t, err)
+	assert.Equal(t, "unix", srv.network)
+	assert.Equal(t, "/tmp/otelcol.sock", srv.endpoint)
+}
+
+func TestServerUnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "otelcol.sock")
+	// A socket left by a previous run is removed.
+	stale, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+
+	srv, err := New("unix:"+path, "secret")
+	require.NoError(t, err)
+	srv.Handle(FlushPath, FlushHandler(func(context.Context) error { return nil }))
+	require.NoError(t, srv.Start(zap.NewNop()))
+	t.Cleanup(func() { assert.NoError(t, srv.Shutdown(context.Background())) })
+
+	fi, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+
+	// A socket in use is kept.
+	other, err := New("unix:"+path, "secret")
+	require.NoError(t, err)
+	assert.Error(t, other.Start(zap.NewNop()))
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", path)
+		},
+	}}
+	req, err := http.NewRequest(http.MethodPost, "http://localhost"+FlushPath, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
+
+func TestReloadHandler(t *testing.T) {
+	var reloadErr error
+	reloaded := 0
+	srv, err := New("127.0.0.1:0", "secret")
+	require.NoError(t, err)
+	srv.Handle(ReloadPath, ReloadHandler(func(context.Context) error {
+		reloaded++
+		return reloadErr
+	}))
+	require.NoError(t, srv.Start(zap.NewNop()))
+	t.Cleanup(func() { assert.NoError(t, srv.Shutdown(context.Background())) })
+	endpoint := "http://" + srv.Addr().String()
+
+	assert.Equal(t, http.StatusUnauthorized, doRequest(t, http.MethodPost, endpoint+ReloadPath, "", "").StatusCode)
+	assert.Equal(t, http.StatusMethodNotAllowed, doRequest(t, http.MethodGet, endpoint+ReloadPath, "secret", "").StatusCode)
+	assert.Equal(t, http.StatusNoContent, doRequest(t, http.MethodPost, endpoint+ReloadPath, "secret", "").StatusCode)
+	assert.Equal(t, 1, reloaded)
+	reloadErr = errors.New("reload failed")
+	assert.Equal(t, http.StatusInternalServerError, doRequest(t, http.MethodPost, endpoint+ReloadPath, "secret", "").StatusCode)
+	assert.Equal(t, 2, reloaded)
+}
+
+type fakeIngestion struct {
+	paused   bool
+	drained  int
+	drainErr error
+}
+
+func (f *fakeIngestion) PauseIngestion() error {
+	f.paused = true
+	return nil
+}
+
+func (f *fakeIngestion) ResumeIngestion() error {
+	f.paused = false
+	return nil
+}
+
+func (f *fakeIngestion) Drain(context.Context) error {
+	f.paused = true
+	f.drained++
+	return f.drainErr
+}
+
+func (f *fakeIngestion) IngestionPaused() (bool, error) {
+	return f.paused, nil
+}
+
+func TestIngestionHandler(t *testing.T) {
+	ctl := &fakeIngestion{}
+	srv, err := New("127.0.0.1:0", "secret")
+	require.NoError(t, err)
+	srv.Handle(IngestionPath, IngestionHandler(ctl))
+	srv.Handle(IngestionPath+"/", IngestionHandler(ctl))
+	require.NoError(t, srv.Start(zap.NewNop()))
+	t.Cleanup(func() { assert.NoError(t, srv.Shutdown(context.Background())) })
+	endpoint := "http://" + srv.Addr().String()
+
+	status := func() ingestionStatus {
+		resp := doRequest(t, http.MethodGet, endpoint+IngestionPath, "secret", "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var st ingestionStatus
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&st))
+		return st
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, doRequest(t, http.MethodPost, endpoint+IngestionPath+"/pause", "", "").StatusCode)
+	assert.False(t, status().Paused)
+	assert.Equal(t, http.StatusNoContent, doRequest(t, http.MethodPost, endpoint+IngestionPath+"/pause", "secret", "").StatusCode)
+	assert.True(t, status().Paused)
+	assert.Equal(t, http.StatusNoContent, doRequest(t, http.MethodPost, endpoint+IngestionPath+"/resume", "secret", "").StatusCode)
+	assert.False(t, status().Paused)
+	assert.Equal(t, http.StatusNoContent, doRequest(t, http.MethodPost, endpoint+IngestionPath+"/drain", "secret", "").StatusCode)
+	assert.True(t, status().Paused)
+	assert.Equal(t, 1, ctl.drained)
+
+	ctl.drainErr = errors.New("drain timed out")
+	assert.Equal(t, http.StatusInternalServerError, doRequest(t, http.MethodPost, endpoint+IngestionPath+"/drain", "secret", "").StatusCode)
+	assert.Equal(t, http.StatusNotFound, doRequest(t, http.MethodPost, endpoint+IngestionPath+"/unknown", "secret", "").StatusCode)
+	assert.Equal(t, http.StatusMethodNotAllowed, doRequest(t, http.MethodGet, endpoint+IngestionPath+"/pause", "secret", "").StatusCode)
+}
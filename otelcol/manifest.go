@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Manifest lists the Go modules the components of a distribution are built from. It is generated
+// by the OpenTelemetry Collector Builder and embedded in the binaries it builds.
+type Manifest struct {
+	// Profile is the build profile of the distribution, "full" or "lite".
+	Profile string `json:"profile"`
+	// BuildTags are the comma-separated build tags the distribution is compiled with.
+	BuildTags string `json:"build_tags,omitempty"`
+	// Components are the modules of the components of the distribution.
+	Components []ManifestComponent `json:"components"`
+}
+
+// ManifestComponent describes the Go module a component of the distribution is built from.
+type ManifestComponent struct {
+	// Kind is the kind of the component, e.g. "receiver".
+	Kind string `json:"kind"`
+	// Import is the import path of the package of the component factory.
+	Import string `json:"import"`
+	// Module is the path of the Go module of the component.
+	Module string `json:"module"`
+	// Version is the version of the Go module of the component.
+	Version string `json:"version"`
+	// Sum is the checksum of the source of the Go module, as recorded in go.sum, e.g. "h1:...".
+	// It is empty if the module is replaced by a local directory.
+	Sum string `json:"sum,omitempty"`
+	// Stability is the stability level of the component for each signal, e.g. "traces": "Beta",
+	// or for each pair of signals for the connectors, as reported by the component factory.
+	// It is empty in the manifest embedded in the binary, and set from the factories of the distribution.
+	Stability map[string]string `json:"stability,omitempty"`
+}
+
+// ParseManifest parses a JSON manifest generated by the OpenTelemetry Collector Builder.
+func ParseManifest(data []byte) (*Manifest, error) {
+	m := &Manifest{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("invalid components manifest: %w", err)
+	}
+	return m, nil
+}
+
+// lookup returns the manifest entry of the component created by the factory, found by matching
+// the package of its default configuration with the import paths of the entries of the same kind.
+func (m *Manifest) lookup(kind component.Kind, f component.Factory) *ManifestComponent {
+	if m == nil {
+		return nil
+	}
+	cfgType := reflect.TypeOf(f.CreateDefaultConfig())
+	for cfgType != nil && cfgType.Kind() == reflect.Ptr {
+		cfgType = cfgType.Elem()
+	}
+	if cfgType == nil {
+		return nil
+	}
+	pkgPath := cfgType.PkgPath()
+
+	var found *ManifestComponent
+	for i, c := range m.Components {
+		if c.Kind != kind.String() || (pkgPath != c.Import && !strings.HasPrefix(pkgPath, c.Import+"/")) {
+			continue
+		}
+		if found == nil || len(c.Import) > len(found.Import) {
+			found = &m.Components[i]
+		}
+	}
+	return found
+}
+
+// setStability sets the stability levels of the components of the manifest from their factories.
+func (m *Manifest) setStability(factories Factories) {
+	for _, f := range factories.Connectors {
+		if c := m.lookup(component.KindConnector, f); c != nil {
+			c.Stability = connectorStability(f)
+		}
+	}
+	for _, f := range factories.Extensions {
+		if c := m.lookup(component.KindExtension, f); c != nil {
+			c.Stability = extensionStability(f)
+		}
+	}
+	for _, f := range factories.Processors {
+		if c := m.lookup(component.KindProcessor, f); c != nil {
+			c.Stability = processorStability(f)
+		}
+	}
+	for _, f := range factories.Receivers {
+		if c := m.lookup(component.KindReceiver, f); c != nil {
+			c.Stability = receiverStability(f)
+		}
+	}
+	for _, f := range factories.Exporters {
+		if c := m.lookup(component.KindExporter, f); c != nil {
+			c.Stability = exporterStability(f)
+		}
+	}
+}
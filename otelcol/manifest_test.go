@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+const testManifest = `{
+  "profile": "lite",
+  "build_tags": "otelcol_noopencensus,otelcol_noprometheus,otelcol_nozpages,otelcol_nojson",
+  "components": [
+    {"kind": "receiver", "import": "go.opentelemetry.io/collector/receiver", "module": "go.opentelemetry.io/collector/receiver", "version": "v0.85.0"},
+    {"kind": "receiver", "import": "go.opentelemetry.io/collector/receiver/receivertest", "module": "go.opentelemetry.io/collector/receiver", "version": "v0.85.0", "sum": "h1:abc=", "stability": {"traces": "Stable"}},
+    {"kind": "processor", "import": "go.opentelemetry.io/collector/exporter/exportertest", "module": "go.opentelemetry.io/collector/exporter", "version": "v0.85.0"}
+  ]
+}`
+
+func TestParseManifest(t *testing.T) {
+	m, err := ParseManifest([]byte(testManifest))
+	require.NoError(t, err)
+	assert.Equal(t, "lite", m.Profile)
+	assert.Equal(t, "otelcol_noopencensus,otelcol_noprometheus,otelcol_nozpages,otelcol_nojson", m.BuildTags)
+	require.Len(t, m.Components, 3)
+	assert.Equal(t, ManifestComponent{
+		Kind:      "receiver",
+		Import:    "go.opentelemetry.io/collector/receiver/receivertest",
+		Module:    "go.opentelemetry.io/collector/receiver",
+		Version:   "v0.85.0",
+		Sum:       "h1:abc=",
+		Stability: map[string]string{"traces": "Stable"},
+	}, m.Components[1])
+
+	_, err = ParseManifest([]byte("{"))
+	assert.ErrorContains(t, err, "invalid components manifest")
+}
+
+func TestManifestLookup(t *testing.T) {
+	m, err := ParseManifest([]byte(testManifest))
+	require.NoError(t, err)
+
+	// The most specific import path is used.
+	assert.Equal(t, &m.Components[1], m.lookup(component.KindReceiver, receivertest.NewNopFactory()))
+	// The kind must match.
+	assert.Nil(t, m.lookup(component.KindExporter, exportertest.NewNopFactory()))
+
+	var nilManifest *Manifest
+	assert.Nil(t, nilManifest.lookup(component.KindReceiver, receivertest.NewNopFactory()))
+}
+
+func TestComponentsCommandManifest(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+	m, err := ParseManifest([]byte(testManifest))
+	require.NoError(t, err)
+
+	cmd := NewCommand(CollectorSettings{BuildInfo: component.NewDefaultBuildInfo(), Factories: factories, Manifest: m})
+	cmd.SetArgs([]string{"components"})
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	require.NoError(t, cmd.Execute())
+
+	var out componentsOutput
+	require.NoError(t, yaml.Unmarshal(b.Bytes(), &out))
+	require.Len(t, out.Receivers, 1)
+	assert.Equal(t, &moduleOutput{Path: "go.opentelemetry.io/collector/receiver", Version: "v0.85.0", Sum: "h1:abc="}, out.Receivers[0].Module)
+	require.Len(t, out.Exporters, 1)
+	assert.Nil(t, out.Exporters[0].Module)
+}
+
+func TestManifestSetStability(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+	m, err := ParseManifest([]byte(testManifest))
+	require.NoError(t, err)
+
+	m.setStability(factories)
+	assert.Nil(t, m.Components[0].Stability)
+	assert.Equal(t, map[string]string{
+		"logs":    component.StabilityLevelStable.String(),
+		"metrics": component.StabilityLevelStable.String(),
+		"traces":  component.StabilityLevelStable.String(),
+	}, m.Components[1].Stability)
+	// The exporter is not a processor.
+	assert.Nil(t, m.Components[2].Stability)
+}
+
+func TestComponentsCommandManifestJSON(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+	m, err := ParseManifest([]byte(testManifest))
+	require.NoError(t, err)
+
+	cmd := NewCommand(CollectorSettings{BuildInfo: component.NewDefaultBuildInfo(), Factories: factories, Manifest: m})
+	cmd.SetArgs([]string{"components", "--manifest"})
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	require.NoError(t, cmd.Execute())
+
+	out, err := ParseManifest(b.Bytes())
+	require.NoError(t, err)
+	require.Len(t, out.Components, 3)
+	assert.Equal(t, component.StabilityLevelStable.String(), out.Components[1].Stability["traces"])
+
+	cmd = NewCommand(CollectorSettings{BuildInfo: component.NewDefaultBuildInfo(), Factories: factories})
+	cmd.SetArgs([]string{"components", "--manifest"})
+	cmd.SetOut(bytes.NewBufferString(""))
+	cmd.SetErr(bytes.NewBufferString(""))
+	assert.EqualError(t, cmd.Execute(), "the distribution does not embed a components manifest")
+}
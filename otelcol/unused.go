@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/service"
+)
+
+// unusedComponents returns the configuration paths of the configured components which are not referenced
+// by service::pipelines or service::extensions, e.g. `processors::batch/logs`, in the order of the sections.
+func unusedComponents(cfg *Config) []string {
+	used := map[component.ID]bool{}
+	usedProcessors := map[component.ID]bool{}
+	for _, pipeline := range cfg.Service.Pipelines {
+		for _, id := range pipeline.Receivers {
+			used[id] = true
+		}
+		for _, id := range pipeline.Processors {
+			usedProcessors[id] = true
+		}
+		for _, id := range pipeline.Exporters {
+			used[id] = true
+		}
+	}
+	usedExtensions := map[component.ID]bool{}
+	for _, id := range cfg.Service.Extensions {
+		usedExtensions[id] = true
+	}
+
+	var paths []string
+	paths = append(paths, unusedSectionComponents("receivers", cfg.Receivers, used)...)
+	paths = append(paths, unusedSectionComponents("exporters", cfg.Exporters, used)...)
+	paths = append(paths, unusedSectionComponents("processors", cfg.Processors, usedProcessors)...)
+	paths = append(paths, unusedSectionComponents("connectors", cfg.Connectors, used)...)
+	paths = append(paths, unusedSectionComponents("extensions", cfg.Extensions, usedExtensions)...)
+	return paths
+}
+
+func unusedSectionComponents(section string, cfgs map[component.ID]component.Config, used map[component.ID]bool) []string {
+	var paths []string
+	for id := range cfgs {
+		if !used[id] {
+			paths = append(paths, section+confmap.KeyDelimiter+id.String())
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// validateUnusedComponents returns an error listing the unused components when the service is configured to fail
+// on them.
+func validateUnusedComponents(cfg *Config) error {
+	if cfg.Service.UnusedComponents != service.UnusedComponentsError {
+		return nil
+	}
+	var errs []error
+	for _, path := range unusedComponents(cfg) {
+		errs = append(errs, fmt.Errorf("%s: component is not used by service::pipelines or service::extensions", path))
+	}
+	return errors.Join(errs...)
+}
+
+// logUnusedComponents warns about the unused components when the service is configured to warn about them.
+func logUnusedComponents(logger *zap.Logger, cfg *Config) {
+	if cfg.Service.UnusedComponents != service.UnusedComponentsWarn {
+		return
+	}
+	for _, path := range unusedComponents(cfg) {
+		logger.Warn("Component is not used by service::pipelines or service::extensions.", zap.String("path", path))
+	}
+}
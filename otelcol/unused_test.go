@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/service"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+func configWithUnusedComponents(mode service.UnusedComponentsMode) *Config {
+	cfg := generateConfig()
+	cfg.Processors[component.NewIDWithName("nop", "logs")] = &errConfig{}
+	cfg.Extensions[component.NewIDWithName("nop", "2")] = &errConfig{}
+	cfg.Service.UnusedComponents = mode
+	return cfg
+}
+
+func TestUnusedComponents(t *testing.T) {
+	cfg := configWithUnusedComponents(service.UnusedComponentsError)
+	assert.Equal(t, []string{"processors::nop/logs", "connectors::nop/conn", "extensions::nop/2"}, unusedComponents(cfg))
+
+	cfg.Service.Pipelines[component.NewID("logs")] = &pipelines.PipelineConfig{
+		Receivers:  []component.ID{component.NewIDWithName("nop", "conn")},
+		Processors: []component.ID{component.NewIDWithName("nop", "logs")},
+		Exporters:  []component.ID{component.NewID("nop")},
+	}
+	cfg.Service.Extensions = append(cfg.Service.Extensions, component.NewIDWithName("nop", "2"))
+	assert.Empty(t, unusedComponents(cfg))
+}
+
+func TestConfigValidateUnusedComponents(t *testing.T) {
+	assert.NoError(t, configWithUnusedComponents("").Validate())
+	assert.NoError(t, configWithUnusedComponents(service.UnusedComponentsIgnore).Validate())
+	assert.NoError(t, configWithUnusedComponents(service.UnusedComponentsWarn).Validate())
+	assert.EqualError(t, configWithUnusedComponents(service.UnusedComponentsError).Validate(),
+		`processors::nop/logs: component is not used by service::pipelines or service::extensions
+connectors::nop/conn: component is not used by service::pipelines or service::extensions
+extensions::nop/2: component is not used by service::pipelines or service::extensions`)
+}
+
+func TestLogUnusedComponents(t *testing.T) {
+	core, logs := observer.New(zapcore.WarnLevel)
+	logUnusedComponents(zap.New(core), configWithUnusedComponents(service.UnusedComponentsIgnore))
+	assert.Zero(t, logs.Len())
+
+	logUnusedComponents(zap.New(core), configWithUnusedComponents(service.UnusedComponentsWarn))
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 3)
+	for i, path := range []string{"processors::nop/logs", "connectors::nop/conn", "extensions::nop/2"} {
+		assert.Equal(t, "Component is not used by service::pipelines or service::extensions.", entries[i].Message)
+		assert.Equal(t, map[string]any{"path": path}, entries[i].ContextMap())
+	}
+}
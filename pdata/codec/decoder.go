@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package codec // import "go.opentelemetry.io/collector/pdata/codec"
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Decoder reads the pdata payloads written by an Encoder with the same options to a stream.
+// The Decode methods return io.EOF at the end of the stream, and io.ErrUnexpectedEOF if it ends with a truncated
+// payload. A Decoder is not safe for concurrent use.
+type Decoder struct {
+	settings
+	r io.Reader
+	// rc is the decompressed reader of r, opened on the first read.
+	rc  io.ReadCloser
+	br  *bufio.Reader
+	buf []byte
+
+	logsUnmarshaler    plog.Unmarshaler
+	metricsUnmarshaler pmetric.Unmarshaler
+	tracesUnmarshaler  ptrace.Unmarshaler
+}
+
+// NewDecoder returns a Decoder reading the payloads from r. Closing the Decoder does not close r.
+func NewDecoder(r io.Reader, opts ...Option) (*Decoder, error) {
+	set, err := newSettings(opts)
+	if err != nil {
+		return nil, err
+	}
+	d := &Decoder{settings: set, r: r}
+	if set.format == FormatProto {
+		d.logsUnmarshaler = &plog.ProtoUnmarshaler{}
+		d.metricsUnmarshaler = &pmetric.ProtoUnmarshaler{}
+		d.tracesUnmarshaler = &ptrace.ProtoUnmarshaler{}
+	} else {
+		d.logsUnmarshaler = &plog.JSONUnmarshaler{}
+		d.metricsUnmarshaler = &pmetric.JSONUnmarshaler{}
+		d.tracesUnmarshaler = &ptrace.JSONUnmarshaler{}
+	}
+	return d, nil
+}
+
+// DecodeLogs reads the next payload as logs.
+func (d *Decoder) DecodeLogs() (plog.Logs, error) {
+	buf, err := d.readRecord()
+	if err != nil {
+		return plog.Logs{}, err
+	}
+	return d.logsUnmarshaler.UnmarshalLogs(buf)
+}
+
+// DecodeMetrics reads the next payload as metrics.
+func (d *Decoder) DecodeMetrics() (pmetric.Metrics, error) {
+	buf, err := d.readRecord()
+	if err != nil {
+		return pmetric.Metrics{}, err
+	}
+	return d.metricsUnmarshaler.UnmarshalMetrics(buf)
+}
+
+// DecodeTraces reads the next payload as traces.
+func (d *Decoder) DecodeTraces() (ptrace.Traces, error) {
+	buf, err := d.readRecord()
+	if err != nil {
+		return ptrace.Traces{}, err
+	}
+	return d.tracesUnmarshaler.UnmarshalTraces(buf)
+}
+
+// Close releases the resources of the decompression. The Decoder cannot be used after.
+func (d *Decoder) Close() error {
+	if d.rc == nil {
+		return nil
+	}
+	return d.rc.Close()
+}
+
+func (d *Decoder) readRecord() ([]byte, error) {
+	if d.br == nil {
+		rc, err := d.newDecompressReader(d.r)
+		if err != nil {
+			// An empty compressed stream has no header.
+			return nil, err
+		}
+		d.rc = rc
+		d.br = bufio.NewReader(rc)
+	}
+	if d.format == FormatProto {
+		return d.readProto()
+	}
+	return d.readLine()
+}
+
+// readLine returns the next non-empty line. The last line does not need to end with a newline.
+func (d *Decoder) readLine() ([]byte, error) {
+	d.buf = d.buf[:0]
+	for {
+		line, err := d.br.ReadSlice('\n')
+		d.buf = append(d.buf, line...)
+		if len(d.buf) > d.maxRecordSize+1 {
+			return nil, d.errTooLarge(len(d.buf))
+		}
+		switch {
+		case errors.Is(err, bufio.ErrBufferFull):
+			continue
+		case errors.Is(err, io.EOF) && len(bytes.TrimSpace(d.buf)) > 0:
+			return bytes.TrimSpace(d.buf), nil
+		case err != nil:
+			return nil, err
+		}
+		if record := bytes.TrimSpace(d.buf); len(record) > 0 {
+			return record, nil
+		}
+		d.buf = d.buf[:0]
+	}
+}
+
+// readProto returns the next payload prefixed by its size.
+func (d *Decoder) readProto() ([]byte, error) {
+	var prefix [4]byte
+	if _, err := io.ReadFull(d.br, prefix[:]); err != nil {
+		return nil, err
+	}
+	size := int(binary.BigEndian.Uint32(prefix[:]))
+	if size > d.maxRecordSize {
+		return nil, d.errTooLarge(size)
+	}
+	if cap(d.buf) < size {
+		d.buf = make([]byte, size)
+	}
+	d.buf = d.buf[:size]
+	if _, err := io.ReadFull(d.br, d.buf); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return d.buf, nil
+}
+
+func (d *Decoder) errTooLarge(size int) error {
+	return fmt.Errorf("the payload of at least %d bytes exceeds the max record size of %d bytes", size, d.maxRecordSize)
+}
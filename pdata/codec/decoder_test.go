@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package codec
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestDecodeJSONLines(t *testing.T) {
+	first, err := (&plog.JSONMarshaler{}).MarshalLogs(testLogs("first"))
+	require.NoError(t, err)
+	second, err := (&plog.JSONMarshaler{}).MarshalLogs(testLogs("second"))
+	require.NoError(t, err)
+
+	// Blank lines and carriage returns are skipped, and the last line may not end with a newline.
+	input := "\n" + string(first) + "\r\n\n" + string(second)
+	dec, err := NewDecoder(strings.NewReader(input))
+	require.NoError(t, err)
+	ld, err := dec.DecodeLogs()
+	require.NoError(t, err)
+	assert.Equal(t, testLogs("first"), ld)
+	ld, err = dec.DecodeLogs()
+	require.NoError(t, err)
+	assert.Equal(t, testLogs("second"), ld)
+	_, err = dec.DecodeLogs()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestDecodeLongLine(t *testing.T) {
+	ld := testLogs(strings.Repeat("x", 10000))
+	buf := &bytes.Buffer{}
+	enc, err := NewEncoder(buf)
+	require.NoError(t, err)
+	require.NoError(t, enc.EncodeLogs(ld))
+	require.NoError(t, enc.Close())
+
+	dec, err := NewDecoder(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	got, err := dec.DecodeLogs()
+	require.NoError(t, err)
+	assert.Equal(t, ld, got)
+
+	dec, err = NewDecoder(bytes.NewReader(buf.Bytes()), WithMaxRecordSize(5000))
+	require.NoError(t, err)
+	_, err = dec.DecodeLogs()
+	assert.ErrorContains(t, err, "exceeds the max record size of 5000 bytes")
+}
+
+func TestDecodeTruncatedProto(t *testing.T) {
+	buf := &bytes.Buffer{}
+	enc, err := NewEncoder(buf, WithFormat(FormatProto))
+	require.NoError(t, err)
+	require.NoError(t, enc.EncodeLogs(testLogs("first")))
+	require.NoError(t, enc.EncodeLogs(testLogs("truncated")))
+	require.NoError(t, enc.Close())
+
+	for _, cut := range []int{2, 10} {
+		dec, err := NewDecoder(bytes.NewReader(buf.Bytes()[:buf.Len()-cut]), WithFormat(FormatProto))
+		require.NoError(t, err)
+		ld, err := dec.DecodeLogs()
+		require.NoError(t, err)
+		assert.Equal(t, testLogs("first"), ld)
+		_, err = dec.DecodeLogs()
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	}
+}
+
+func TestDecodeProtoMaxRecordSize(t *testing.T) {
+	dec, err := NewDecoder(bytes.NewReader([]byte{0xff, 0xff, 0xff, 0xff}), WithFormat(FormatProto))
+	require.NoError(t, err)
+	_, err = dec.DecodeLogs()
+	assert.ErrorContains(t, err, "exceeds the max record size")
+}
+
+func TestDecodeEmptyCompressedStream(t *testing.T) {
+	dec, err := NewDecoder(&bytes.Buffer{}, WithCompression(configcompression.Gzip))
+	require.NoError(t, err)
+	_, err = dec.DecodeMetrics()
+	assert.ErrorIs(t, err, io.EOF)
+	assert.NoError(t, dec.Close())
+}
+
+func TestDecodeInvalidPayload(t *testing.T) {
+	dec, err := NewDecoder(strings.NewReader("{\"resourceSpans\": 5}\n"))
+	require.NoError(t, err)
+	_, err = dec.DecodeTraces()
+	assert.Error(t, err)
+}
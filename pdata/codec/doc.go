@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package codec writes pdata to streams, e.g. files, and reads it back, for debugging, auditing or replaying
+// real payloads in tests. The payloads are written as OTLP JSON lines or as length-prefixed OTLP protobuf,
+// optionally compressed, and the written files can be rotated when they reach a given size.
+//
+// This API is at the early stage of development and may change without backward compatibility.
+package codec // import "go.opentelemetry.io/collector/pdata/codec"
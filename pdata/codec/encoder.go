@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package codec // import "go.opentelemetry.io/collector/pdata/codec"
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var errEncoderClosed = errors.New("encoder is closed")
+
+// OpenFunc opens the stream written after the given number of rotations, 0 for the first stream.
+// It can be used as a hook to name, archive or upload the rotated files.
+type OpenFunc func(index int) (io.WriteCloser, error)
+
+// Encoder writes pdata payloads to a stream, or to a sequence of streams rotated when they reach a given size.
+// An Encoder is not safe for concurrent use.
+type Encoder struct {
+	settings
+	maxBytes int64
+	open     OpenFunc
+
+	// index is the index of the next stream to open.
+	index int
+	// stream is the current stream, nil until the first payload is written to it.
+	stream io.WriteCloser
+	// w is the compressed writer of the stream, or the stream itself.
+	w       io.Writer
+	cw      compressWriter
+	written int64
+	buf     []byte
+	closed  bool
+
+	logsMarshaler    plog.Marshaler
+	metricsMarshaler pmetric.Marshaler
+	tracesMarshaler  ptrace.Marshaler
+}
+
+// NewEncoder returns an Encoder writing the payloads to w. Closing the Encoder does not close w.
+func NewEncoder(w io.Writer, opts ...Option) (*Encoder, error) {
+	return NewRotatingEncoder(0, func(int) (io.WriteCloser, error) {
+		return nopWriteCloser{Writer: w}, nil
+	}, opts...)
+}
+
+// NewRotatingEncoder returns an Encoder writing the payloads to the streams returned by open, closing the current
+// stream and opening the next one before writing a payload which would make it exceed maxBytes, 0 meaning no limit.
+// The size is counted before compression, and each stream is compressed separately so it can be decoded on its own.
+// A payload larger than maxBytes is written alone to a stream.
+func NewRotatingEncoder(maxBytes int64, open OpenFunc, opts ...Option) (*Encoder, error) {
+	set, err := newSettings(opts)
+	if err != nil {
+		return nil, err
+	}
+	if maxBytes < 0 {
+		return nil, fmt.Errorf("the max bytes must not be negative, got %d", maxBytes)
+	}
+	e := &Encoder{settings: set, maxBytes: maxBytes, open: open}
+	if set.format == FormatProto {
+		e.logsMarshaler = &plog.ProtoMarshaler{}
+		e.metricsMarshaler = &pmetric.ProtoMarshaler{}
+		e.tracesMarshaler = &ptrace.ProtoMarshaler{}
+	} else {
+		e.logsMarshaler = &plog.JSONMarshaler{}
+		e.metricsMarshaler = &pmetric.JSONMarshaler{}
+		e.tracesMarshaler = &ptrace.JSONMarshaler{}
+	}
+	return e, nil
+}
+
+// EncodeLogs writes the logs as a payload.
+func (e *Encoder) EncodeLogs(ld plog.Logs) error {
+	buf, err := e.logsMarshaler.MarshalLogs(ld)
+	if err != nil {
+		return err
+	}
+	return e.write(buf)
+}
+
+// EncodeMetrics writes the metrics as a payload.
+func (e *Encoder) EncodeMetrics(md pmetric.Metrics) error {
+	buf, err := e.metricsMarshaler.MarshalMetrics(md)
+	if err != nil {
+		return err
+	}
+	return e.write(buf)
+}
+
+// EncodeTraces writes the traces as a payload.
+func (e *Encoder) EncodeTraces(td ptrace.Traces) error {
+	buf, err := e.tracesMarshaler.MarshalTraces(td)
+	if err != nil {
+		return err
+	}
+	return e.write(buf)
+}
+
+func (e *Encoder) write(payload []byte) error {
+	if e.closed {
+		return errEncoderClosed
+	}
+	if len(payload) > e.maxRecordSize {
+		return fmt.Errorf("the payload of %d bytes exceeds the max record size of %d bytes", len(payload), e.maxRecordSize)
+	}
+
+	e.buf = e.buf[:0]
+	if e.format == FormatProto {
+		e.buf = binary.BigEndian.AppendUint32(e.buf, uint32(len(payload)))
+		e.buf = append(e.buf, payload...)
+	} else {
+		e.buf = append(e.buf, payload...)
+		e.buf = append(e.buf, '\n')
+	}
+
+	if e.stream != nil && e.maxBytes > 0 && e.written > 0 && e.written+int64(len(e.buf)) > e.maxBytes {
+		if err := e.closeStream(); err != nil {
+			return err
+		}
+	}
+	if e.stream == nil {
+		if err := e.openStream(); err != nil {
+			return err
+		}
+	}
+	if _, err := e.w.Write(e.buf); err != nil {
+		return err
+	}
+	e.written += int64(len(e.buf))
+	return nil
+}
+
+func (e *Encoder) openStream() error {
+	stream, err := e.open(e.index)
+	if err != nil {
+		return fmt.Errorf("failed to open stream %d: %w", e.index, err)
+	}
+	e.index++
+	e.stream = stream
+	e.w = stream
+	e.written = 0
+	if e.cw = e.newCompressWriter(stream); e.cw != nil {
+		e.w = e.cw
+	}
+	return nil
+}
+
+func (e *Encoder) closeStream() error {
+	var err error
+	if e.cw != nil {
+		err = e.cw.Close()
+	}
+	err = multierr.Append(err, e.stream.Close())
+	e.stream, e.w, e.cw = nil, nil, nil
+	return err
+}
+
+// Flush writes the compressed data pending in the current stream, so the payloads written so far can be
+// decoded, e.g. if the process crashes. It does nothing if the stream is not compressed.
+func (e *Encoder) Flush() error {
+	if e.cw == nil {
+		return nil
+	}
+	return e.cw.Flush()
+}
+
+// Close completes and closes the current stream. The Encoder cannot be used after.
+func (e *Encoder) Close() error {
+	if e.closed {
+		return nil
+	}
+	e.closed = true
+	if e.stream == nil {
+		return nil
+	}
+	return e.closeStream()
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
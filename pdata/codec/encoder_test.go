@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package codec
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func testLogs(body string) plog.Logs {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "codec")
+	lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Body().SetStr(body)
+	lr.SetTimestamp(pcommon.Timestamp(1_000_000))
+	lr.SetTraceID(pcommon.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	lr.SetSeverityNumber(plog.SeverityNumberWarn)
+	return ld
+}
+
+func testMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("requests")
+	dp := m.SetEmptySum().DataPoints().AppendEmpty()
+	dp.SetIntValue(42)
+	dp.Attributes().PutStr("method", "GET")
+	return md
+}
+
+func testTraces() ptrace.Traces {
+	td := ptrace.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("operation")
+	span.SetTraceID(pcommon.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	span.SetSpanID(pcommon.SpanID{1, 2, 3, 4, 5, 6, 7, 8})
+	span.Status().SetCode(ptrace.StatusCodeError)
+	return td
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, format := range []Format{FormatJSON, FormatProto} {
+		for _, compression := range []configcompression.CompressionType{"", configcompression.Gzip, configcompression.Zlib, configcompression.Deflate} {
+			t.Run(string(format)+"/"+string(compression), func(t *testing.T) {
+				opts := []Option{WithFormat(format), WithCompression(compression)}
+				buf := &bytes.Buffer{}
+				enc, err := NewEncoder(buf, opts...)
+				require.NoError(t, err)
+				require.NoError(t, enc.EncodeLogs(testLogs("first")))
+				require.NoError(t, enc.EncodeMetrics(testMetrics()))
+				require.NoError(t, enc.EncodeTraces(testTraces()))
+				require.NoError(t, enc.EncodeLogs(testLogs("second")))
+				require.NoError(t, enc.Close())
+				assert.ErrorIs(t, enc.EncodeLogs(testLogs("closed")), errEncoderClosed)
+
+				dec, err := NewDecoder(buf, opts...)
+				require.NoError(t, err)
+				ld, err := dec.DecodeLogs()
+				require.NoError(t, err)
+				assert.Equal(t, testLogs("first"), ld)
+				md, err := dec.DecodeMetrics()
+				require.NoError(t, err)
+				assert.Equal(t, testMetrics(), md)
+				td, err := dec.DecodeTraces()
+				require.NoError(t, err)
+				assert.Equal(t, testTraces(), td)
+				ld, err = dec.DecodeLogs()
+				require.NoError(t, err)
+				assert.Equal(t, testLogs("second"), ld)
+				_, err = dec.DecodeLogs()
+				assert.ErrorIs(t, err, io.EOF)
+				assert.NoError(t, dec.Close())
+			})
+		}
+	}
+}
+
+func TestFlush(t *testing.T) {
+	buf := &bytes.Buffer{}
+	enc, err := NewEncoder(buf, WithCompression(configcompression.Gzip))
+	require.NoError(t, err)
+	require.NoError(t, enc.EncodeLogs(testLogs("flushed")))
+	require.NoError(t, enc.Flush())
+
+	// The stream is not complete, but the flushed payloads can be decoded.
+	dec, err := NewDecoder(bytes.NewReader(buf.Bytes()), WithCompression(configcompression.Gzip))
+	require.NoError(t, err)
+	ld, err := dec.DecodeLogs()
+	require.NoError(t, err)
+	assert.Equal(t, testLogs("flushed"), ld)
+	require.NoError(t, enc.Close())
+}
+
+type memoryStream struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (s *memoryStream) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestRotatingEncoder(t *testing.T) {
+	payload, err := (&plog.ProtoMarshaler{}).MarshalLogs(testLogs("rotated"))
+	require.NoError(t, err)
+	recordSize := int64(len(payload) + 4)
+
+	var streams []*memoryStream
+	enc, err := NewRotatingEncoder(2*recordSize, func(index int) (io.WriteCloser, error) {
+		assert.Equal(t, len(streams), index)
+		s := &memoryStream{}
+		streams = append(streams, s)
+		return s, nil
+	}, WithFormat(FormatProto), WithCompression(configcompression.Gzip))
+	require.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		require.NoError(t, enc.EncodeLogs(testLogs("rotated")))
+	}
+	require.NoError(t, enc.Close())
+
+	require.Len(t, streams, 3)
+	for i, s := range streams {
+		assert.True(t, s.closed)
+		dec, err := NewDecoder(&s.Buffer, WithFormat(FormatProto), WithCompression(configcompression.Gzip))
+		require.NoError(t, err)
+		count := 0
+		for ; ; count++ {
+			ld, err := dec.DecodeLogs()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testLogs("rotated"), ld)
+		}
+		if i < 2 {
+			assert.Equal(t, 2, count)
+		} else {
+			assert.Equal(t, 1, count)
+		}
+	}
+}
+
+func TestRotatingEncoderOversizedPayload(t *testing.T) {
+	var streams []*memoryStream
+	enc, err := NewRotatingEncoder(1, func(int) (io.WriteCloser, error) {
+		s := &memoryStream{}
+		streams = append(streams, s)
+		return s, nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, enc.EncodeTraces(testTraces()))
+	require.NoError(t, enc.EncodeTraces(testTraces()))
+	require.NoError(t, enc.Close())
+	require.Len(t, streams, 2)
+	assert.Equal(t, 1, bytes.Count(streams[0].Bytes(), []byte("\n")))
+	assert.Equal(t, 1, bytes.Count(streams[1].Bytes(), []byte("\n")))
+}
+
+func TestRotatingEncoderOpenError(t *testing.T) {
+	enc, err := NewRotatingEncoder(0, func(int) (io.WriteCloser, error) {
+		return nil, errors.New("no space left")
+	})
+	require.NoError(t, err)
+	assert.EqualError(t, enc.EncodeMetrics(testMetrics()), "failed to open stream 0: no space left")
+	assert.NoError(t, enc.Close())
+}
+
+func TestEncoderMaxRecordSize(t *testing.T) {
+	buf := &bytes.Buffer{}
+	enc, err := NewEncoder(buf, WithMaxRecordSize(10))
+	require.NoError(t, err)
+	assert.ErrorContains(t, enc.EncodeLogs(testLogs("large")), "exceeds the max record size of 10 bytes")
+	assert.Zero(t, buf.Len())
+}
+
+func TestInvalidOptions(t *testing.T) {
+	_, err := NewEncoder(io.Discard, WithFormat("xml"))
+	assert.EqualError(t, err, `unsupported format "xml"`)
+	_, err = NewDecoder(&bytes.Buffer{}, WithCompression(configcompression.Zstd))
+	assert.EqualError(t, err, `unsupported compression "zstd"`)
+	_, err = NewDecoder(&bytes.Buffer{}, WithMaxRecordSize(0))
+	assert.EqualError(t, err, "the max record size must be positive, got 0")
+	_, err = NewRotatingEncoder(-1, FileSequence("logs.jsonl"))
+	assert.EqualError(t, err, "the max bytes must not be negative, got -1")
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package codec // import "go.opentelemetry.io/collector/pdata/codec"
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+
+	"go.opentelemetry.io/collector/config/configcompression"
+)
+
+// Format is the encoding of the payloads in a stream.
+type Format string
+
+const (
+	// FormatJSON writes every payload as OTLP JSON on its own line.
+	FormatJSON Format = "json"
+	// FormatProto writes every payload as OTLP protobuf prefixed by its size, a 4 bytes big-endian integer,
+	// as the file exporter of the contrib repository does.
+	FormatProto Format = "proto"
+)
+
+// DefaultMaxRecordSize is the default maximum size of an encoded payload.
+const DefaultMaxRecordSize = 64 << 20
+
+// Option configures an Encoder or a Decoder.
+type Option func(*settings)
+
+type settings struct {
+	format        Format
+	compression   configcompression.CompressionType
+	maxRecordSize int
+}
+
+// WithFormat sets the encoding of the payloads, FormatJSON by default.
+func WithFormat(format Format) Option {
+	return func(s *settings) {
+		s.format = format
+	}
+}
+
+// WithCompression sets the compression of the streams, none by default. The gzip, zlib and deflate
+// compressions are supported.
+func WithCompression(compression configcompression.CompressionType) Option {
+	return func(s *settings) {
+		s.compression = compression
+	}
+}
+
+// WithMaxRecordSize sets the maximum size in bytes of an encoded payload, DefaultMaxRecordSize by default.
+// Larger payloads fail to be encoded or decoded, which bounds the memory used to read corrupted streams.
+func WithMaxRecordSize(size int) Option {
+	return func(s *settings) {
+		s.maxRecordSize = size
+	}
+}
+
+func newSettings(opts []Option) (settings, error) {
+	s := settings{format: FormatJSON, maxRecordSize: DefaultMaxRecordSize}
+	for _, opt := range opts {
+		opt(&s)
+	}
+	if s.format != FormatJSON && s.format != FormatProto {
+		return s, fmt.Errorf("unsupported format %q", s.format)
+	}
+	switch s.compression {
+	case "", "none", configcompression.Gzip, configcompression.Zlib, configcompression.Deflate:
+	default:
+		return s, fmt.Errorf("unsupported compression %q", s.compression)
+	}
+	if s.maxRecordSize <= 0 {
+		return s, fmt.Errorf("the max record size must be positive, got %d", s.maxRecordSize)
+	}
+	return s, nil
+}
+
+// compressWriter is a writer compressing the data.
+type compressWriter interface {
+	io.WriteCloser
+	Flush() error
+}
+
+func (s settings) newCompressWriter(w io.Writer) compressWriter {
+	switch s.compression {
+	case configcompression.Gzip:
+		return gzip.NewWriter(w)
+	case configcompression.Zlib:
+		return zlib.NewWriter(w)
+	case configcompression.Deflate:
+		// The level is always valid.
+		fw, _ := flate.NewWriter(w, flate.DefaultCompression)
+		return fw
+	}
+	return nil
+}
+
+func (s settings) newDecompressReader(r io.Reader) (io.ReadCloser, error) {
+	switch s.compression {
+	case configcompression.Gzip:
+		return gzip.NewReader(r)
+	case configcompression.Zlib:
+		return zlib.NewReader(r)
+	case configcompression.Deflate:
+		return flate.NewReader(r), nil
+	}
+	return io.NopCloser(r), nil
+}
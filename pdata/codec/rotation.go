@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package codec // import "go.opentelemetry.io/collector/pdata/codec"
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// FileSequence returns an OpenFunc creating the files named by FileSequenceName for the given path.
+// The existing files are truncated.
+func FileSequence(path string) OpenFunc {
+	return func(index int) (io.WriteCloser, error) {
+		return os.OpenFile(FileSequenceName(path, index), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	}
+}
+
+// FileSequenceName returns the name of the file written after the given number of rotations by the Encoder
+// of FileSequence(path): path itself for index 0, then path with the index inserted before its extension,
+// e.g. "logs.jsonl", "logs-1.jsonl", "logs-2.jsonl".
+func FileSequenceName(path string, index int) string {
+	if index == 0 {
+		return path
+	}
+	ext := filepath.Ext(path)
+	return path[:len(path)-len(ext)] + "-" + strconv.Itoa(index) + ext
+}
@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package codec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/configcompression"
+)
+
+func TestFileSequenceName(t *testing.T) {
+	assert.Equal(t, "logs.jsonl", FileSequenceName("logs.jsonl", 0))
+	assert.Equal(t, "logs-1.jsonl", FileSequenceName("logs.jsonl", 1))
+	assert.Equal(t, filepath.Join("dir", "traces-12"), FileSequenceName(filepath.Join("dir", "traces"), 12))
+}
+
+func TestFileSequence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.jsonl.gz")
+	enc, err := NewRotatingEncoder(1, FileSequence(path), WithCompression(configcompression.Gzip))
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, enc.EncodeMetrics(testMetrics()))
+	}
+	require.NoError(t, enc.Close())
+
+	for i := 0; i < 3; i++ {
+		f, err := os.Open(FileSequenceName(path, i))
+		require.NoError(t, err)
+		dec, err := NewDecoder(f, WithCompression(configcompression.Gzip))
+		require.NoError(t, err)
+		md, err := dec.DecodeMetrics()
+		require.NoError(t, err)
+		assert.Equal(t, testMetrics(), md)
+		require.NoError(t, dec.Close())
+		require.NoError(t, f.Close())
+	}
+	_, err = os.Stat(FileSequenceName(path, 3))
+	assert.True(t, os.IsNotExist(err))
+}
@@ -231,6 +231,8 @@ type baseField interface {
 	GenerateSetWithTestValue(ms baseStruct) string
 
 	GenerateCopyToValue(ms baseStruct) string
+
+	GenerateEqual(ms baseStruct) string
 }
 
 type sliceField struct {
@@ -269,6 +271,10 @@ func (sf *sliceField) GenerateCopyToValue(_ baseStruct) string {
 	return "\tms." + sf.fieldName + "().CopyTo(dest." + sf.fieldName + "())"
 }
 
+func (sf *sliceField) GenerateEqual(_ baseStruct) string {
+	return generateNotEqual("!ms." + sf.fieldName + "().Equal(val." + sf.fieldName + "(), opts...)")
+}
+
 func (sf *sliceField) templateFields(ms baseStruct) map[string]any {
 	return map[string]any{
 		"structName": ms.getName(),
@@ -324,6 +330,10 @@ func (mf *messageValueField) GenerateCopyToValue(_ baseStruct) string {
 	return "\tms." + mf.fieldName + "().CopyTo(dest." + mf.fieldName + "())"
 }
 
+func (mf *messageValueField) GenerateEqual(_ baseStruct) string {
+	return generateNotEqual("!ms." + mf.fieldName + "().Equal(val." + mf.fieldName + "(), opts...)")
+}
+
 func (mf *messageValueField) templateFields(ms baseStruct) map[string]any {
 	return map[string]any{
 		"isCommon":       usedByOtherDataTypes(mf.returnMessage.getPackageName()),
@@ -376,6 +386,10 @@ func (pf *primitiveField) GenerateCopyToValue(_ baseStruct) string {
 	return "\tdest.Set" + pf.fieldName + "(ms." + pf.fieldName + "())"
 }
 
+func (pf *primitiveField) GenerateEqual(_ baseStruct) string {
+	return generateNotEqual("ms." + pf.fieldName + "() != val." + pf.fieldName + "()")
+}
+
 func (pf *primitiveField) templateFields(ms baseStruct) map[string]any {
 	return map[string]any{
 		"structName":     ms.getName(),
@@ -436,6 +450,10 @@ func (ptf *primitiveTypedField) GenerateCopyToValue(_ baseStruct) string {
 	return "\tdest.Set" + ptf.fieldName + "(ms." + ptf.fieldName + "())"
 }
 
+func (ptf *primitiveTypedField) GenerateEqual(_ baseStruct) string {
+	return generateNotEqual("ms." + ptf.fieldName + "() != val." + ptf.fieldName + "()")
+}
+
 func (ptf *primitiveTypedField) templateFields(ms baseStruct) map[string]any {
 	return map[string]any{
 		"structName": ms.getName(),
@@ -498,6 +516,10 @@ func (psf *primitiveSliceField) GenerateCopyToValue(_ baseStruct) string {
 	return "\tms." + psf.fieldName + "().CopyTo(dest." + psf.fieldName + "())"
 }
 
+func (psf *primitiveSliceField) GenerateEqual(_ baseStruct) string {
+	return generateNotEqual("!ms." + psf.fieldName + "().Equal(val." + psf.fieldName + "())")
+}
+
 func (psf *primitiveSliceField) templateFields(ms baseStruct) map[string]any {
 	return map[string]any{
 		"structName": ms.getName(),
@@ -567,6 +589,17 @@ func (of *oneOfField) GenerateCopyToValue(ms baseStruct) string {
 	return sb.String()
 }
 
+func (of *oneOfField) GenerateEqual(ms baseStruct) string {
+	sb := &bytes.Buffer{}
+	sb.WriteString(generateNotEqual("ms."+of.typeFuncName()+"() != val."+of.typeFuncName()+"()") + "\n")
+	sb.WriteString("\tswitch ms." + of.typeFuncName() + "() {\n")
+	for _, v := range of.values {
+		v.GenerateEqual(ms, of, sb)
+	}
+	sb.WriteString("\t}")
+	return sb.String()
+}
+
 func (of *oneOfField) templateFields(ms baseStruct) map[string]any {
 	return map[string]any{
 		"baseStruct":           ms,
@@ -589,6 +622,7 @@ type oneOfValue interface {
 	GenerateTests(ms baseStruct, of *oneOfField) string
 	GenerateSetWithTestValue(of *oneOfField) string
 	GenerateCopyToValue(ms baseStruct, of *oneOfField, sb *bytes.Buffer)
+	GenerateEqual(ms baseStruct, of *oneOfField, sb *bytes.Buffer)
 	GenerateTypeSwitchCase(of *oneOfField) string
 }
 
@@ -636,6 +670,11 @@ func (opv *oneOfPrimitiveValue) GenerateCopyToValue(_ baseStruct, of *oneOfField
 	sb.WriteString("\tdest.Set" + opv.accessorFieldName(of) + "(ms." + opv.accessorFieldName(of) + "())\n")
 }
 
+func (opv *oneOfPrimitiveValue) GenerateEqual(_ baseStruct, of *oneOfField, sb *bytes.Buffer) {
+	sb.WriteString("\tcase " + of.typeName + opv.fieldName + ":\n")
+	sb.WriteString(generateNotEqual("ms."+opv.accessorFieldName(of)+"() != val."+opv.accessorFieldName(of)+"()") + "\n")
+}
+
 func (opv *oneOfPrimitiveValue) GenerateTypeSwitchCase(of *oneOfField) string {
 	return "\tcase *" + of.originTypePrefix + opv.originFieldName + ":\n" +
 		"\t\treturn " + of.typeName + opv.fieldName
@@ -699,6 +738,11 @@ func (omv *oneOfMessageValue) GenerateCopyToValue(ms baseStruct, of *oneOfField,
 	sb.WriteString("\n")
 }
 
+func (omv *oneOfMessageValue) GenerateEqual(_ baseStruct, of *oneOfField, sb *bytes.Buffer) {
+	sb.WriteString("\tcase " + of.typeName + omv.fieldName + ":\n")
+	sb.WriteString(generateNotEqual("!ms."+omv.fieldName+"().Equal(val."+omv.fieldName+"(), opts...)") + "\n")
+}
+
 func (omv *oneOfMessageValue) GenerateTypeSwitchCase(of *oneOfField) string {
 	return "\tcase *" + of.originTypePrefix + omv.fieldName + ":\n" +
 		"\t\treturn " + of.typeName + omv.fieldName
@@ -757,6 +801,10 @@ func (opv *optionalPrimitiveValue) GenerateCopyToValue(_ baseStruct) string {
 		"}\n"
 }
 
+func (opv *optionalPrimitiveValue) GenerateEqual(_ baseStruct) string {
+	return generateNotEqual("ms.Has" + opv.fieldName + "() != val.Has" + opv.fieldName + "() || ms." + opv.fieldName + "() != val." + opv.fieldName + "()")
+}
+
 func (opv *optionalPrimitiveValue) templateFields(ms baseStruct) map[string]any {
 	return map[string]any{
 		"structName":       ms.getName(),
@@ -778,3 +826,16 @@ func origAccessor(bs baseStruct) string {
 	}
 	return "orig"
 }
+
+// generateNotEqual generates the statement returning false from an Equal method if cond is true.
+func generateNotEqual(cond string) string {
+	return "\tif " + cond + " {\n\t\treturn false\n\t}"
+}
+
+// compareOption returns the name of the pcommon.CompareOption type in the package of the struct.
+func compareOption(bs baseStruct) string {
+	if bs.getPackageName() == "pcommon" {
+		return "CompareOption"
+	}
+	return "pcommon.CompareOption"
+}
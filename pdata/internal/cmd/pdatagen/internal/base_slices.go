@@ -141,6 +141,29 @@ func (es {{ .structName }}) CopyTo(dest {{ .structName }}) {
 	{{- end }}
 }
 
+// Equal checks equality with another {{ .structName }}, all the elements being deeply equal.
+// The elements are compared in order, unless the IgnoreSliceOrder option is provided.
+func (es {{ .structName }}) Equal(val {{ .structName }}, opts ...{{ .compareOption }}) bool {
+	if es.Len() != val.Len() {
+		return false
+	}
+	var cfg internal.CompareConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.IgnoreSliceOrder {
+		return internal.EqualUnordered(es.Len(), func(i, j int) bool {
+			return es.At(i).Equal(val.At(j), opts...)
+		})
+	}
+	for i := range *es.orig {
+		if !es.At(i).Equal(val.At(i), opts...) {
+			return false
+		}
+	}
+	return true
+}
+
 {{ if eq .type "sliceOfPtrs" -}}
 // Sort sorts the {{ .elementName }} elements within {{ .structName }} given the
 // provided less function so that two instances of {{ .structName }}
@@ -243,6 +266,26 @@ func Test{{ .structName }}_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func Test{{ .structName }}_Equal(t *testing.T) {
+	es := generateTest{{ .structName }}()
+	assert.True(t, es.Equal(generateTest{{ .structName }}()))
+	assert.False(t, es.Equal(New{{ .structName }}()))
+
+	other := generateTest{{ .structName }}()
+	New{{ .elementName }}().CopyTo(other.At(0))
+	assert.False(t, es.Equal(other))
+
+	// Test Equal ignoring the order of the elements
+	reordered := New{{ .structName }}()
+	for i := 1; i < other.Len(); i++ {
+		other.At(i).CopyTo(reordered.AppendEmpty())
+	}
+	other.At(0).CopyTo(reordered.AppendEmpty())
+	assert.False(t, other.Equal(reordered))
+	assert.True(t, other.Equal(reordered, pcommon.IgnoreSliceOrder()))
+	assert.False(t, es.Equal(reordered, pcommon.IgnoreSliceOrder()))
+}
+
 {{ if eq .type "sliceOfPtrs" -}}
 func Test{{ .structName }}_Sort(t *testing.T) {
 	es := generateTest{{ .structName }}()
@@ -319,6 +362,7 @@ func (ss *sliceOfPtrs) generateTestValueHelpers(sb *bytes.Buffer) {
 func (ss *sliceOfPtrs) templateFields() map[string]any {
 	return map[string]any{
 		"type":               "sliceOfPtrs",
+		"compareOption":      compareOption(ss),
 		"structName":         ss.structName,
 		"elementName":        ss.element.structName,
 		"originName":         ss.element.originFullName,
@@ -371,6 +415,7 @@ func (ss *sliceOfValues) generateTestValueHelpers(sb *bytes.Buffer) {
 func (ss *sliceOfValues) templateFields() map[string]any {
 	return map[string]any{
 		"type":               "sliceOfValues",
+		"compareOption":      compareOption(ss),
 		"structName":         ss.structName,
 		"elementName":        ss.element.structName,
 		"originName":         ss.element.originFullName,
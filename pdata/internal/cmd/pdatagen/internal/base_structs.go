@@ -62,6 +62,14 @@ func (ms {{ .structName }}) CopyTo(dest {{ .structName }}) {
 {{- range .fields }}
 {{ .GenerateCopyToValue $.messageStruct }}
 {{- end }}
+}
+
+// Equal checks equality with another {{ .structName }}, all the fields being deeply equal.
+func (ms {{ .structName }}) Equal(val {{ .structName }}, opts ...{{ .compareOption }}) bool {
+{{- range .fields }}
+{{ .GenerateEqual $.messageStruct }}
+{{- end }}
+	return true
 }`
 
 const messageValueTestTemplate = `
@@ -83,6 +91,14 @@ func Test{{ .structName }}_CopyTo(t *testing.T) {
 	assert.Equal(t, orig, ms)
 }
 
+func Test{{ .structName }}_Equal(t *testing.T) {
+	ms := {{ .generateTestData }}
+	assert.True(t, ms.Equal(ms))
+	assert.True(t, ms.Equal({{ .generateTestData }}))
+	assert.False(t, ms.Equal(New{{ .structName }}()))
+	assert.False(t, New{{ .structName }}().Equal(ms))
+}
+
 {{ range .fields }}
 {{ .GenerateAccessorsTest $.messageStruct }}
 {{ end }}`
@@ -190,9 +206,10 @@ func (ms *messageValueStruct) templateFields() map[string]any {
 			}
 			return "generateTest" + ms.structName + "()"
 		}(),
-		"description":  ms.description,
-		"isCommon":     usedByOtherDataTypes(ms.packageName),
-		"origAccessor": origAccessor(ms),
+		"description":   ms.description,
+		"isCommon":      usedByOtherDataTypes(ms.packageName),
+		"origAccessor":  origAccessor(ms),
+		"compareOption": compareOption(ms),
 	}
 }
 
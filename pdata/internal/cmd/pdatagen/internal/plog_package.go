@@ -122,6 +122,12 @@ var logRecord = &messageValueStruct{
 		bodyField,
 		attributes,
 		droppedAttributesCount,
+		&primitiveField{
+			fieldName:  "EventName",
+			returnType: "string",
+			defaultVal: `""`,
+			testVal:    `"user.login"`,
+		},
 	},
 }
 
@@ -11,6 +11,7 @@ var plogotlp = &Package{
 	path: filepath.Join("plog", "plogotlp"),
 	imports: []string{
 		`otlpcollectorlog "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/logs/v1"`,
+		`"go.opentelemetry.io/collector/pdata/pcommon"`,
 	},
 	testImports: []string{
 		`"testing"`,
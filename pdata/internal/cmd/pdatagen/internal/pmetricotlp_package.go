@@ -12,6 +12,7 @@ var pmetricotlp = &Package{
 	path: filepath.Join("pmetric", "pmetricotlp"),
 	imports: []string{
 		`otlpcollectormetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/metrics/v1"`,
+		`"go.opentelemetry.io/collector/pdata/pcommon"`,
 	},
 	testImports: []string{
 		`"testing"`,
@@ -89,6 +89,19 @@ func (ms {{ .structName }}) CopyTo(dest {{ .structName }}) {
 	*dest.getOrig() = copy{{ .structName }}(*dest.getOrig(), *ms.getOrig())
 }
 
+// Equal checks equality with another {{ .structName }}, all the items being equal in the same order.
+func (ms {{ .structName }}) Equal(val {{ .structName }}) bool {
+	if ms.Len() != val.Len() {
+		return false
+	}
+	for i := range *ms.getOrig() {
+		if ms.At(i) != val.At(i) {
+			return false
+		}
+	}
+	return true
+}
+
 func copy{{ .structName }}(dst, src []{{ .itemType }}) []{{ .itemType }} {
 	dst = dst[:0]
 	return append(dst, src...)
@@ -133,6 +146,18 @@ func Test{{ .structName }}Append(t *testing.T) {
 	assert.Equal(t, {{ .itemType }}(5), ms.At(4))
 }
 
+func Test{{ .structName }}Equal(t *testing.T) {
+	ms := New{{ .structName }}()
+	ms2 := New{{ .structName }}()
+	assert.True(t, ms.Equal(ms2))
+	ms.Append(1, 2, 3)
+	assert.False(t, ms.Equal(ms2))
+	ms2.Append(1, 2, 3)
+	assert.True(t, ms.Equal(ms2))
+	ms2.SetAt(0, 3)
+	assert.False(t, ms.Equal(ms2))
+}
+
 func Test{{ .structName }}EnsureCapacity(t *testing.T) {
 	ms := New{{ .structName }}()
 	ms.EnsureCapacity(4)
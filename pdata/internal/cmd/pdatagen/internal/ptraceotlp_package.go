@@ -12,6 +12,7 @@ var ptraceotlp = &Package{
 	path: filepath.Join("ptrace", "ptraceotlp"),
 	imports: []string{
 		`otlpcollectortrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/trace/v1"`,
+		`"go.opentelemetry.io/collector/pdata/pcommon"`,
 	},
 	testImports: []string{
 		`"testing"`,
@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/pdata/internal"
+
+// CompareConfig is the configuration of the Equal methods, built from the pcommon.CompareOption.
+type CompareConfig struct {
+	IgnoreMapOrder   bool
+	IgnoreSliceOrder bool
+}
+
+// EqualUnordered returns true if each of the n elements of a slice is equal to a distinct element
+// of another slice of the same length, equal(i, j) comparing the i-th and j-th elements.
+func EqualUnordered(n int, equal func(i, j int) bool) bool {
+	matched := make([]bool, n)
+	for i := 0; i < n; i++ {
+		found := false
+		for j := 0; j < n; j++ {
+			if !matched[j] && equal(i, j) {
+				matched[j] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -426,6 +426,17 @@ type LogRecord struct {
 	//   - the field is not present,
 	//   - the field contains an invalid value.
 	SpanId go_opentelemetry_io_collector_pdata_internal_data.SpanID `protobuf:"bytes,10,opt,name=span_id,json=spanId,proto3,customtype=go.opentelemetry.io/collector/pdata/internal/data.SpanID" json:"span_id"`
+	// A unique identifier of event category/type.
+	// All events with the same event_name are expected to conform to the same
+	// schema for both their attributes and their body.
+	//
+	// Recommended to be fully qualified and short (no longer than 256 characters).
+	//
+	// Presence of event_name on the log record identifies this record
+	// as an event.
+	//
+	// [Optional].
+	EventName string `protobuf:"bytes,12,opt,name=event_name,json=eventName,proto3" json:"event_name,omitempty"`
 }
 
 func (m *LogRecord) Reset()         { *m = LogRecord{} }
@@ -517,6 +528,13 @@ func (m *LogRecord) GetFlags() uint32 {
 	return 0
 }
 
+func (m *LogRecord) GetEventName() string {
+	if m != nil {
+		return m.EventName
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterEnum("opentelemetry.proto.logs.v1.SeverityNumber", SeverityNumber_name, SeverityNumber_value)
 	proto.RegisterEnum("opentelemetry.proto.logs.v1.LogRecordFlags", LogRecordFlags_name, LogRecordFlags_value)
@@ -531,67 +549,68 @@ func init() {
 }
 
 var fileDescriptor_d1c030a3ec7e961e = []byte{
-	// 951 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x96, 0xcf, 0x6e, 0xea, 0x46,
-	0x14, 0xc6, 0x31, 0xe1, 0xef, 0x84, 0x70, 0xa7, 0x73, 0x49, 0xae, 0x9b, 0xa8, 0x84, 0xa6, 0x55,
-	0x4a, 0x53, 0x09, 0x14, 0xa0, 0xd2, 0xed, 0xae, 0x26, 0x98, 0x88, 0x86, 0x40, 0x34, 0x40, 0xaa,
-	0xdc, 0x56, 0xb2, 0x0c, 0x9e, 0x52, 0x4b, 0xc6, 0x63, 0xd9, 0x03, 0x4a, 0xf6, 0x7d, 0x80, 0x3e,
-	0x41, 0xb7, 0x95, 0xfa, 0x1a, 0xed, 0xe2, 0x2e, 0xef, 0xb2, 0xea, 0x22, 0xaa, 0x92, 0x4d, 0xdf,
-	0xa2, 0xd5, 0x0c, 0x86, 0x90, 0xd4, 0x4e, 0x6e, 0x56, 0x99, 0x39, 0xbf, 0xef, 0x7c, 0xe7, 0x8c,
-	0x4f, 0x3c, 0x18, 0xec, 0x53, 0x87, 0xd8, 0x8c, 0x58, 0x64, 0x42, 0x98, 0x7b, 0x55, 0x76, 0x5c,
-	0xca, 0x68, 0xd9, 0xa2, 0x63, 0xaf, 0x3c, 0x3b, 0x14, 0x7f, 0x4b, 0x22, 0x84, 0x76, 0xee, 0xe9,
-	0xe6, 0xc1, 0x92, 0xe0, 0xb3, 0xc3, 0xed, 0xdc, 0x98, 0x8e, 0xe9, 0x3c, 0x95, 0xaf, 0xe6, 0x74,
-	0xfb, 0x20, 0xc8, 0x7a, 0x44, 0x27, 0x13, 0x6a, 0x73, 0xf3, 0xf9, 0xca, 0xd7, 0x96, 0x82, 0xb4,
-	0x2e, 0xf1, 0xe8, 0xd4, 0x1d, 0x11, 0xae, 0x5e, 0xac, 0xe7, 0xfa, 0xbd, 0x37, 0x20, 0xd5, 0xa6,
-	0x63, 0xaf, 0xa1, 0x33, 0x1d, 0x75, 0xc0, 0xc6, 0x82, 0x6a, 0xbc, 0x23, 0x59, 0x2a, 0xac, 0x15,
-	0xd7, 0x2b, 0x9f, 0x97, 0x1e, 0x69, 0xb9, 0x84, 0xfd, 0x0c, 0xee, 0x82, 0x33, 0xee, 0xca, 0x6e,
-	0xef, 0x97, 0x28, 0xc8, 0xac, 0x62, 0xf4, 0x1d, 0xd8, 0x34, 0x88, 0xe3, 0x92, 0x91, 0xce, 0x88,
-	0xa1, 0x79, 0x23, 0xea, 0xf8, 0x85, 0xfe, 0x49, 0x8a, 0x4a, 0xfb, 0x8f, 0x56, 0xea, 0x71, 0xbd,
-	0x28, 0xf3, 0xf2, 0xce, 0x65, 0x19, 0x44, 0x27, 0x20, 0xb5, 0xa8, 0x2e, 0x4b, 0x05, 0x29, 0xb4,
-	0xf1, 0xe5, 0x03, 0x58, 0x69, 0xbe, 0x1e, 0x7b, 0x7b, 0xbd, 0x1b, 0xc1, 0x4b, 0x03, 0xa4, 0x02,
-	0xb0, 0xd2, 0x5e, 0xf4, 0x59, 0xdd, 0xa5, 0xbd, 0x65, 0x4f, 0x1f, 0x71, 0x9b, 0x1f, 0xc9, 0x44,
-	0xd7, 0xa6, 0xae, 0x25, 0xaf, 0x15, 0xa4, 0x62, 0x9a, 0x63, 0x1e, 0x19, 0xb8, 0xd6, 0xde, 0x1f,
-	0x12, 0x48, 0xdf, 0x1d, 0xa0, 0x0b, 0xe2, 0x22, 0xd3, 0xef, 0xbe, 0x1a, 0x58, 0xce, 0x1f, 0xf6,
-	0xec, 0xb0, 0xd4, 0xb2, 0x3d, 0xe6, 0x4e, 0x27, 0xc4, 0x66, 0x3a, 0x33, 0xa9, 0x2d, 0x7c, 0xfc,
-	0x73, 0xcc, 0x7d, 0xd0, 0x31, 0x58, 0xb7, 0xe8, 0x58, 0x73, 0xc9, 0x88, 0xba, 0xc6, 0xfb, 0x9d,
-	0xa2, 0x4d, 0xc7, 0x58, 0xc8, 0x31, 0xb0, 0x16, 0xcb, 0x27, 0x8f, 0xf1, 0x53, 0x1c, 0xa4, 0x97,
-	0x89, 0xe8, 0x53, 0x90, 0x65, 0xe6, 0x84, 0x68, 0x53, 0xdb, 0xbc, 0xd4, 0x6c, 0xdd, 0xa6, 0xe2,
-	0x3c, 0x09, 0x9c, 0xe1, 0xd1, 0x81, 0x6d, 0x5e, 0x76, 0x74, 0x9b, 0xa2, 0x2f, 0xc1, 0x2b, 0x3a,
-	0xf4, 0x88, 0x3b, 0x23, 0x86, 0xf6, 0x40, 0xbe, 0x2e, 0xe4, 0xb9, 0x05, 0xee, 0xaf, 0xa6, 0xf5,
-	0xc1, 0x0b, 0x8f, 0xcc, 0x88, 0x6b, 0xb2, 0x2b, 0xcd, 0x9e, 0x4e, 0x86, 0xc4, 0x95, 0xa3, 0x05,
-	0xa9, 0x98, 0xad, 0x7c, 0xf1, 0xf8, 0x70, 0xfc, 0x9c, 0x8e, 0x48, 0xc1, 0x59, 0xef, 0xde, 0x1e,
-	0x7d, 0x02, 0x36, 0x96, 0xae, 0x8c, 0x5c, 0x32, 0xff, 0x88, 0x99, 0x45, 0xb0, 0x4f, 0x2e, 0x19,
-	0x52, 0x40, 0x6c, 0x48, 0x8d, 0x2b, 0x39, 0x2e, 0xa6, 0xf3, 0xd9, 0x13, 0xd3, 0x51, 0xec, 0xab,
-	0x73, 0xdd, 0x9a, 0x2e, 0x26, 0x22, 0x52, 0xd1, 0x29, 0x00, 0x3a, 0x63, 0xae, 0x39, 0x9c, 0x32,
-	0xe2, 0xc9, 0x09, 0x31, 0x8f, 0xa7, 0x8c, 0x4e, 0xc8, 0x3d, 0xa3, 0x15, 0x03, 0xf4, 0x1a, 0xc8,
-	0x86, 0x4b, 0x1d, 0x87, 0x18, 0xda, 0x5d, 0x54, 0x1b, 0xd1, 0xa9, 0xcd, 0xe4, 0x64, 0x41, 0x2a,
-	0x6e, 0xe0, 0x2d, 0x9f, 0x2b, 0x4b, 0x7c, 0xc4, 0x29, 0xca, 0x81, 0xf8, 0x0f, 0x96, 0x3e, 0xf6,
-	0xe4, 0x54, 0x41, 0x2a, 0x26, 0xf1, 0x7c, 0x83, 0xbe, 0x07, 0x29, 0xe6, 0xea, 0x23, 0xa2, 0x99,
-	0x86, 0x9c, 0x2e, 0x48, 0xc5, 0x4c, 0x5d, 0xe1, 0x35, 0xff, 0xba, 0xde, 0xfd, 0x6a, 0x4c, 0x1f,
-	0xb4, 0x69, 0xf2, 0x1b, 0xc8, 0xb2, 0xc8, 0x88, 0x51, 0xb7, 0xec, 0x18, 0x3a, 0xd3, 0xcb, 0xa6,
-	0xcd, 0x88, 0x6b, 0xeb, 0x56, 0x99, 0xef, 0x4a, 0x7d, 0xee, 0xd4, 0x6a, 0xe0, 0xa4, 0xb0, 0x6c,
-	0x19, 0xe8, 0x02, 0x24, 0x3d, 0x47, 0xb7, 0xb9, 0x39, 0x10, 0xe6, 0x5f, 0xfb, 0xe6, 0xaf, 0x9f,
-	0x6f, 0xde, 0x73, 0x74, 0xbb, 0xd5, 0xc0, 0x09, 0x6e, 0xd8, 0x32, 0xbe, 0x89, 0xa5, 0x62, 0x30,
-	0x7e, 0xf0, 0x7b, 0x1c, 0x64, 0xef, 0x0f, 0x1a, 0xed, 0x82, 0x9d, 0x9e, 0x7a, 0xae, 0xe2, 0x56,
-	0xff, 0x42, 0xeb, 0x0c, 0x4e, 0xeb, 0x2a, 0xd6, 0x06, 0x9d, 0xde, 0x99, 0x7a, 0xd4, 0x6a, 0xb6,
-	0xd4, 0x06, 0x8c, 0xa0, 0x0f, 0xc1, 0xe6, 0x43, 0x41, 0x1f, 0x2b, 0x47, 0x2a, 0x94, 0xd0, 0x36,
-	0xd8, 0x0a, 0x44, 0x15, 0x18, 0x0d, 0x65, 0x55, 0xb8, 0x16, 0xca, 0x6a, 0x30, 0x16, 0x54, 0xae,
-	0xa1, 0xd6, 0x07, 0xc7, 0x30, 0x1e, 0x94, 0x26, 0x50, 0x05, 0x26, 0x42, 0x59, 0x15, 0x26, 0x43,
-	0x59, 0x0d, 0xa6, 0x90, 0x0c, 0x72, 0x0f, 0x59, 0xab, 0xd3, 0xec, 0xc2, 0x74, 0x50, 0x23, 0x9c,
-	0x54, 0x20, 0x08, 0x43, 0x55, 0xb8, 0x1e, 0x86, 0x6a, 0x30, 0x13, 0x54, 0xea, 0x5b, 0x05, 0x77,
-	0xe0, 0x46, 0x50, 0x12, 0x27, 0x15, 0x98, 0x0d, 0x43, 0x55, 0xf8, 0x22, 0x0c, 0xd5, 0x20, 0x0c,
-	0x42, 0x2a, 0xc6, 0x5d, 0x0c, 0x3f, 0x08, 0x7a, 0x18, 0x02, 0x55, 0x20, 0x0a, 0x65, 0x55, 0xf8,
-	0x32, 0x94, 0xd5, 0x60, 0x2e, 0xa8, 0x5c, 0x53, 0xe9, 0x2b, 0x6d, 0xb8, 0x19, 0x94, 0x26, 0x50,
-	0x05, 0x6e, 0x85, 0xb2, 0x2a, 0x7c, 0x15, 0xca, 0x6a, 0x50, 0x3e, 0xb8, 0x00, 0xd9, 0xe5, 0x5d,
-	0xda, 0x14, 0xaf, 0xe5, 0x2e, 0xd8, 0x69, 0x77, 0x8f, 0x35, 0xac, 0x1e, 0x75, 0x71, 0x43, 0x6b,
-	0xb6, 0x95, 0xe3, 0x9e, 0xd6, 0xe8, 0x6a, 0x9d, 0x6e, 0x5f, 0x1b, 0xf4, 0x54, 0x18, 0x41, 0xfb,
-	0xe0, 0xe3, 0xff, 0x09, 0xc4, 0xbf, 0x9c, 0xbf, 0x3e, 0x55, 0x7a, 0x27, 0xf0, 0x5f, 0xa9, 0xfe,
-	0xab, 0xf4, 0xf6, 0x26, 0x2f, 0xbd, 0xbb, 0xc9, 0x4b, 0x7f, 0xdf, 0xe4, 0xa5, 0x9f, 0x6f, 0xf3,
-	0x91, 0x77, 0xb7, 0xf9, 0xc8, 0x9f, 0xb7, 0xf9, 0x08, 0xc8, 0x9b, 0xf4, 0xb1, 0x0b, 0xb4, 0xce,
-	0xef, 0x77, 0xef, 0x8c, 0x87, 0xce, 0xa4, 0x37, 0xf5, 0x67, 0xbf, 0xb0, 0xf3, 0xef, 0x90, 0x31,
-	0xb1, 0x17, 0x5f, 0x44, 0xbf, 0x45, 0x77, 0xba, 0x0e, 0xb1, 0xfb, 0x4b, 0x07, 0xe1, 0xcd, 0x7f,
-	0x7e, 0xbc, 0xd2, 0xf9, 0xe1, 0x30, 0x21, 0xf4, 0xd5, 0xff, 0x02, 0x00, 0x00, 0xff, 0xff, 0x48,
-	0x86, 0x67, 0x14, 0x55, 0x09, 0x00, 0x00,
+	// 968 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x96, 0xc1, 0x6e, 0xe2, 0x46,
+	0x18, 0xc7, 0x71, 0x12, 0x02, 0x4c, 0x08, 0x3b, 0x9d, 0x4d, 0xb2, 0x6e, 0xa2, 0x12, 0x9a, 0x56,
+	0x29, 0x4d, 0x25, 0x50, 0x80, 0x4a, 0xdb, 0x5b, 0x4d, 0x70, 0x22, 0x1a, 0x02, 0xd1, 0x00, 0xa9,
+	0xb2, 0xad, 0x64, 0x19, 0x3c, 0xa5, 0x96, 0xcc, 0x8c, 0x65, 0x0f, 0x28, 0x79, 0x8b, 0x3e, 0x41,
+	0x2f, 0x3d, 0x54, 0xea, 0x6b, 0xb4, 0x87, 0x3d, 0xee, 0xb1, 0xea, 0x61, 0x55, 0x25, 0x97, 0xbe,
+	0x45, 0xab, 0x19, 0x1b, 0x42, 0x52, 0x3b, 0xbb, 0x39, 0x31, 0xf3, 0xfd, 0xfe, 0xdf, 0xff, 0xfb,
+	0xc6, 0x33, 0x1e, 0x0c, 0xf6, 0x99, 0x4b, 0x28, 0x27, 0x0e, 0x19, 0x13, 0xee, 0x5d, 0x97, 0x5d,
+	0x8f, 0x71, 0x56, 0x76, 0xd8, 0xc8, 0x2f, 0x4f, 0x0f, 0xe5, 0x6f, 0x49, 0x86, 0xd0, 0xce, 0x3d,
+	0x5d, 0x10, 0x2c, 0x49, 0x3e, 0x3d, 0xdc, 0xde, 0x18, 0xb1, 0x11, 0x0b, 0x52, 0xc5, 0x28, 0xa0,
+	0xdb, 0x07, 0x51, 0xd6, 0x43, 0x36, 0x1e, 0x33, 0x2a, 0xcc, 0x83, 0x51, 0xa8, 0x2d, 0x45, 0x69,
+	0x3d, 0xe2, 0xb3, 0x89, 0x37, 0x24, 0x42, 0x3d, 0x1b, 0x07, 0xfa, 0xbd, 0x57, 0x20, 0xdd, 0x62,
+	0x23, 0xbf, 0x61, 0x72, 0x13, 0xb5, 0xc1, 0xfa, 0x8c, 0x1a, 0xa2, 0x23, 0x55, 0x29, 0x2c, 0x17,
+	0xd7, 0x2a, 0x9f, 0x97, 0x1e, 0x69, 0xb9, 0x84, 0xc3, 0x0c, 0xe1, 0x82, 0xb3, 0xde, 0xc2, 0x6c,
+	0xef, 0xe7, 0x25, 0x90, 0x5d, 0xc4, 0xe8, 0x3b, 0xb0, 0x69, 0x11, 0xd7, 0x23, 0x43, 0x93, 0x13,
+	0xcb, 0xf0, 0x87, 0xcc, 0x0d, 0x0b, 0xfd, 0x93, 0x92, 0x95, 0xf6, 0x1f, 0xad, 0xd4, 0x15, 0x7a,
+	0x59, 0xe6, 0xf9, 0x9d, 0xcb, 0x3c, 0x88, 0x4e, 0x41, 0x7a, 0x56, 0x5d, 0x55, 0x0a, 0x4a, 0x6c,
+	0xe3, 0xf3, 0x07, 0xb0, 0xd0, 0x7c, 0x7d, 0xe5, 0xf5, 0xdb, 0xdd, 0x04, 0x9e, 0x1b, 0x20, 0x1d,
+	0x80, 0x85, 0xf6, 0x96, 0x9e, 0xd4, 0x5d, 0xc6, 0x9f, 0xf7, 0xf4, 0x91, 0xb0, 0xf9, 0x91, 0x8c,
+	0x4d, 0x63, 0xe2, 0x39, 0xea, 0x72, 0x41, 0x29, 0x66, 0x70, 0x26, 0x88, 0xf4, 0x3d, 0x67, 0xef,
+	0x0f, 0x05, 0x64, 0xee, 0x16, 0xd0, 0x01, 0x49, 0x99, 0x19, 0x76, 0x5f, 0x8d, 0x2c, 0x17, 0x6e,
+	0xf6, 0xf4, 0xb0, 0xd4, 0xa4, 0x3e, 0xf7, 0x26, 0x63, 0x42, 0xb9, 0xc9, 0x6d, 0x46, 0xa5, 0x4f,
+	0xb8, 0x8e, 0xc0, 0x07, 0x9d, 0x80, 0x35, 0x87, 0x8d, 0x0c, 0x8f, 0x0c, 0x99, 0x67, 0xbd, 0xdf,
+	0x2a, 0x5a, 0x6c, 0x84, 0xa5, 0x1c, 0x03, 0x67, 0x36, 0x7c, 0xe7, 0x32, 0x7e, 0x49, 0x82, 0xcc,
+	0x3c, 0x11, 0x7d, 0x0a, 0x72, 0xdc, 0x1e, 0x13, 0x63, 0x42, 0xed, 0x2b, 0x83, 0x9a, 0x94, 0xc9,
+	0xf5, 0xac, 0xe2, 0xac, 0x88, 0xf6, 0xa9, 0x7d, 0xd5, 0x36, 0x29, 0x43, 0x5f, 0x82, 0x17, 0x6c,
+	0xe0, 0x13, 0x6f, 0x4a, 0x2c, 0xe3, 0x81, 0x7c, 0x4d, 0xca, 0x37, 0x66, 0xb8, 0xb7, 0x98, 0xd6,
+	0x03, 0xcf, 0x7c, 0x32, 0x25, 0x9e, 0xcd, 0xaf, 0x0d, 0x3a, 0x19, 0x0f, 0x88, 0xa7, 0x2e, 0x15,
+	0x94, 0x62, 0xae, 0xf2, 0xc5, 0xe3, 0x9b, 0x13, 0xe6, 0xb4, 0x65, 0x0a, 0xce, 0xf9, 0xf7, 0xe6,
+	0xe8, 0x13, 0xb0, 0x3e, 0x77, 0xe5, 0xe4, 0x8a, 0x87, 0x4b, 0xcc, 0xce, 0x82, 0x3d, 0x72, 0xc5,
+	0x91, 0x06, 0x56, 0x06, 0xcc, 0xba, 0x56, 0x93, 0x72, 0x77, 0x3e, 0x7b, 0xc7, 0xee, 0x68, 0xf4,
+	0xfa, 0xc2, 0x74, 0x26, 0xb3, 0x1d, 0x91, 0xa9, 0xe8, 0x0c, 0x00, 0x93, 0x73, 0xcf, 0x1e, 0x4c,
+	0x38, 0xf1, 0xd5, 0xd5, 0xc2, 0xf2, 0x7b, 0x18, 0x9d, 0x92, 0x7b, 0x46, 0x0b, 0x06, 0xe8, 0x25,
+	0x50, 0x2d, 0x8f, 0xb9, 0x2e, 0xb1, 0x8c, 0xbb, 0xa8, 0x31, 0x64, 0x13, 0xca, 0xd5, 0x54, 0x41,
+	0x29, 0xae, 0xe3, 0xad, 0x90, 0x6b, 0x73, 0x7c, 0x24, 0x28, 0xda, 0x00, 0xc9, 0x1f, 0x1c, 0x73,
+	0xe4, 0xab, 0xe9, 0x82, 0x52, 0x4c, 0xe1, 0x60, 0x82, 0xbe, 0x07, 0x69, 0xee, 0x99, 0x43, 0x62,
+	0xd8, 0x96, 0x9a, 0x29, 0x28, 0xc5, 0x6c, 0x5d, 0x13, 0x35, 0xff, 0x7a, 0xbb, 0xfb, 0xd5, 0x88,
+	0x3d, 0x68, 0xd3, 0x16, 0x37, 0x90, 0xe3, 0x90, 0x21, 0x67, 0x5e, 0xd9, 0xb5, 0x4c, 0x6e, 0x96,
+	0x6d, 0xca, 0x89, 0x47, 0x4d, 0xa7, 0x2c, 0x66, 0xa5, 0x9e, 0x70, 0x6a, 0x36, 0x70, 0x4a, 0x5a,
+	0x36, 0x2d, 0x74, 0x09, 0x52, 0xbe, 0x6b, 0x52, 0x61, 0x0e, 0xa4, 0xf9, 0xd7, 0xa1, 0xf9, 0xcb,
+	0xa7, 0x9b, 0x77, 0x5d, 0x93, 0x36, 0x1b, 0x78, 0x55, 0x18, 0x36, 0x2d, 0x71, 0x3e, 0xc9, 0x94,
+	0x50, 0x6e, 0x50, 0x73, 0x4c, 0xd4, 0x6c, 0x70, 0x3e, 0x65, 0xa4, 0x6d, 0x8e, 0xc9, 0x37, 0x2b,
+	0xe9, 0x15, 0x98, 0x3c, 0xf8, 0x3d, 0x09, 0x72, 0xf7, 0xcf, 0x01, 0xda, 0x05, 0x3b, 0x5d, 0xfd,
+	0x42, 0xc7, 0xcd, 0xde, 0xa5, 0xd1, 0xee, 0x9f, 0xd5, 0x75, 0x6c, 0xf4, 0xdb, 0xdd, 0x73, 0xfd,
+	0xa8, 0x79, 0xdc, 0xd4, 0x1b, 0x30, 0x81, 0x3e, 0x04, 0x9b, 0x0f, 0x05, 0x3d, 0xac, 0x1d, 0xe9,
+	0x50, 0x41, 0xdb, 0x60, 0x2b, 0x12, 0x55, 0xe0, 0x52, 0x2c, 0xab, 0xc2, 0xe5, 0x58, 0x56, 0x83,
+	0x2b, 0x51, 0xe5, 0x1a, 0x7a, 0xbd, 0x7f, 0x02, 0x93, 0x51, 0x69, 0x12, 0x55, 0xe0, 0x6a, 0x2c,
+	0xab, 0xc2, 0x54, 0x2c, 0xab, 0xc1, 0x34, 0x52, 0xc1, 0xc6, 0x43, 0xd6, 0x6c, 0x1f, 0x77, 0x60,
+	0x26, 0xaa, 0x11, 0x41, 0x2a, 0x10, 0xc4, 0xa1, 0x2a, 0x5c, 0x8b, 0x43, 0x35, 0x98, 0x8d, 0x2a,
+	0xf5, 0xad, 0x86, 0xdb, 0x70, 0x3d, 0x2a, 0x49, 0x90, 0x0a, 0xcc, 0xc5, 0xa1, 0x2a, 0x7c, 0x16,
+	0x87, 0x6a, 0x10, 0x46, 0x21, 0x1d, 0xe3, 0x0e, 0x86, 0x1f, 0x44, 0x3d, 0x0c, 0x89, 0x2a, 0x10,
+	0xc5, 0xb2, 0x2a, 0x7c, 0x1e, 0xcb, 0x6a, 0x70, 0x23, 0xaa, 0xdc, 0xb1, 0xd6, 0xd3, 0x5a, 0x70,
+	0x33, 0x2a, 0x4d, 0xa2, 0x0a, 0xdc, 0x8a, 0x65, 0x55, 0xf8, 0x22, 0x96, 0xd5, 0xa0, 0x7a, 0x70,
+	0x09, 0x72, 0xf3, 0xab, 0xf6, 0x58, 0xbe, 0xb5, 0xbb, 0x60, 0xa7, 0xd5, 0x39, 0x31, 0xb0, 0x7e,
+	0xd4, 0xc1, 0x0d, 0xe3, 0xb8, 0xa5, 0x9d, 0x74, 0x8d, 0x46, 0xc7, 0x68, 0x77, 0x7a, 0x46, 0xbf,
+	0xab, 0xc3, 0x04, 0xda, 0x07, 0x1f, 0xff, 0x4f, 0x20, 0x8f, 0x5c, 0x38, 0x3e, 0xd3, 0xba, 0xa7,
+	0xf0, 0x5f, 0xa5, 0xfe, 0xab, 0xf2, 0xfa, 0x26, 0xaf, 0xbc, 0xb9, 0xc9, 0x2b, 0x7f, 0xdf, 0xe4,
+	0x95, 0x9f, 0x6e, 0xf3, 0x89, 0x37, 0xb7, 0xf9, 0xc4, 0x9f, 0xb7, 0xf9, 0x04, 0xc8, 0xdb, 0xec,
+	0xb1, 0xfb, 0xb5, 0x2e, 0xae, 0x7f, 0xff, 0x5c, 0x84, 0xce, 0x95, 0x57, 0xf5, 0x27, 0xbf, 0xcf,
+	0xc1, 0x67, 0xca, 0x88, 0xd0, 0xd9, 0x07, 0xd3, 0x6f, 0x4b, 0x3b, 0x1d, 0x97, 0xd0, 0xde, 0xdc,
+	0x41, 0x7a, 0x8b, 0x7f, 0x27, 0xbf, 0x74, 0x71, 0x38, 0x58, 0x95, 0xfa, 0xea, 0x7f, 0x03, 0x00,
+	0xc9, 0xbc, 0x36, 0x44, 0x74, 0x09, 0x00, 0x00,
 }
 
 func (m *LogsData) Marshal() (dAtA []byte, err error) {
@@ -775,6 +794,13 @@ func (m *LogRecord) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.EventName) > 0 {
+		i -= len(m.EventName)
+		copy(dAtA[i:], m.EventName)
+		i = encodeVarintLogs(dAtA, i, uint64(len(m.EventName)))
+		i--
+		dAtA[i] = 0x62
+	}
 	if m.ObservedTimeUnixNano != 0 {
 		i -= 8
 		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.ObservedTimeUnixNano))
@@ -968,6 +994,10 @@ func (m *LogRecord) Size() (n int) {
 	if m.ObservedTimeUnixNano != 0 {
 		n += 9
 	}
+	l = len(m.EventName)
+	if l > 0 {
+		n += 1 + l + sovLogs(uint64(l))
+	}
 	return n
 }
 
@@ -1655,6 +1685,38 @@ func (m *LogRecord) Unmarshal(dAtA []byte) error {
 			}
 			m.ObservedTimeUnixNano = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
 			iNdEx += 8
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EventName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipLogs(dAtA[iNdEx:])
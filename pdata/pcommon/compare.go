@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pcommon // import "go.opentelemetry.io/collector/pdata/pcommon"
+
+import (
+	"go.opentelemetry.io/collector/pdata/internal"
+)
+
+// CompareOption customizes the comparison made by the Equal methods of the pdata types.
+type CompareOption func(*internal.CompareConfig)
+
+// IgnoreMapOrder makes the maps, e.g. the attributes, equal when they have the same entries in any order.
+func IgnoreMapOrder() CompareOption {
+	return func(cfg *internal.CompareConfig) {
+		cfg.IgnoreMapOrder = true
+	}
+}
+
+// IgnoreSliceOrder makes the slices of messages, e.g. the resources, scopes, spans or data points, equal
+// when they have the same elements in any order. The order of the Slice values and of the primitive
+// slices, e.g. the ByteSlice, is always compared.
+func IgnoreSliceOrder() CompareOption {
+	return func(cfg *internal.CompareConfig) {
+		cfg.IgnoreSliceOrder = true
+	}
+}
+
+func newCompareConfig(opts []CompareOption) internal.CompareConfig {
+	var cfg internal.CompareConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
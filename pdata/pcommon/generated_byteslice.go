@@ -90,6 +90,19 @@ func (ms ByteSlice) CopyTo(dest ByteSlice) {
 	*dest.getOrig() = copyByteSlice(*dest.getOrig(), *ms.getOrig())
 }
 
+// Equal checks equality with another ByteSlice, all the items being equal in the same order.
+func (ms ByteSlice) Equal(val ByteSlice) bool {
+	if ms.Len() != val.Len() {
+		return false
+	}
+	for i := range *ms.getOrig() {
+		if ms.At(i) != val.At(i) {
+			return false
+		}
+	}
+	return true
+}
+
 func copyByteSlice(dst, src []byte) []byte {
 	dst = dst[:0]
 	return append(dst, src...)
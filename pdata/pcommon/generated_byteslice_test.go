@@ -51,6 +51,18 @@ func TestByteSliceAppend(t *testing.T) {
 	assert.Equal(t, byte(5), ms.At(4))
 }
 
+func TestByteSliceEqual(t *testing.T) {
+	ms := NewByteSlice()
+	ms2 := NewByteSlice()
+	assert.True(t, ms.Equal(ms2))
+	ms.Append(1, 2, 3)
+	assert.False(t, ms.Equal(ms2))
+	ms2.Append(1, 2, 3)
+	assert.True(t, ms.Equal(ms2))
+	ms2.SetAt(0, 3)
+	assert.False(t, ms.Equal(ms2))
+}
+
 func TestByteSliceEnsureCapacity(t *testing.T) {
 	ms := NewByteSlice()
 	ms.EnsureCapacity(4)
@@ -90,6 +90,19 @@ func (ms Float64Slice) CopyTo(dest Float64Slice) {
 	*dest.getOrig() = copyFloat64Slice(*dest.getOrig(), *ms.getOrig())
 }
 
+// Equal checks equality with another Float64Slice, all the items being equal in the same order.
+func (ms Float64Slice) Equal(val Float64Slice) bool {
+	if ms.Len() != val.Len() {
+		return false
+	}
+	for i := range *ms.getOrig() {
+		if ms.At(i) != val.At(i) {
+			return false
+		}
+	}
+	return true
+}
+
 func copyFloat64Slice(dst, src []float64) []float64 {
 	dst = dst[:0]
 	return append(dst, src...)
@@ -51,6 +51,18 @@ func TestFloat64SliceAppend(t *testing.T) {
 	assert.Equal(t, float64(5), ms.At(4))
 }
 
+func TestFloat64SliceEqual(t *testing.T) {
+	ms := NewFloat64Slice()
+	ms2 := NewFloat64Slice()
+	assert.True(t, ms.Equal(ms2))
+	ms.Append(1, 2, 3)
+	assert.False(t, ms.Equal(ms2))
+	ms2.Append(1, 2, 3)
+	assert.True(t, ms.Equal(ms2))
+	ms2.SetAt(0, 3)
+	assert.False(t, ms.Equal(ms2))
+}
+
 func TestFloat64SliceEnsureCapacity(t *testing.T) {
 	ms := NewFloat64Slice()
 	ms.EnsureCapacity(4)
@@ -85,3 +85,20 @@ func (ms InstrumentationScope) CopyTo(dest InstrumentationScope) {
 	ms.Attributes().CopyTo(dest.Attributes())
 	dest.SetDroppedAttributesCount(ms.DroppedAttributesCount())
 }
+
+// Equal checks equality with another InstrumentationScope, all the fields being deeply equal.
+func (ms InstrumentationScope) Equal(val InstrumentationScope, opts ...CompareOption) bool {
+	if ms.Name() != val.Name() {
+		return false
+	}
+	if ms.Version() != val.Version() {
+		return false
+	}
+	if !ms.Attributes().Equal(val.Attributes(), opts...) {
+		return false
+	}
+	if ms.DroppedAttributesCount() != val.DroppedAttributesCount() {
+		return false
+	}
+	return true
+}
@@ -32,6 +32,14 @@ func TestInstrumentationScope_CopyTo(t *testing.T) {
 	assert.Equal(t, orig, ms)
 }
 
+func TestInstrumentationScope_Equal(t *testing.T) {
+	ms := InstrumentationScope(internal.GenerateTestInstrumentationScope())
+	assert.True(t, ms.Equal(ms))
+	assert.True(t, ms.Equal(InstrumentationScope(internal.GenerateTestInstrumentationScope())))
+	assert.False(t, ms.Equal(NewInstrumentationScope()))
+	assert.False(t, NewInstrumentationScope().Equal(ms))
+}
+
 func TestInstrumentationScope_Name(t *testing.T) {
 	ms := NewInstrumentationScope()
 	assert.Equal(t, "", ms.Name())
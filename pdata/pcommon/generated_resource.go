@@ -63,3 +63,14 @@ func (ms Resource) CopyTo(dest Resource) {
 	ms.Attributes().CopyTo(dest.Attributes())
 	dest.SetDroppedAttributesCount(ms.DroppedAttributesCount())
 }
+
+// Equal checks equality with another Resource, all the fields being deeply equal.
+func (ms Resource) Equal(val Resource, opts ...CompareOption) bool {
+	if !ms.Attributes().Equal(val.Attributes(), opts...) {
+		return false
+	}
+	if ms.DroppedAttributesCount() != val.DroppedAttributesCount() {
+		return false
+	}
+	return true
+}
@@ -32,6 +32,14 @@ func TestResource_CopyTo(t *testing.T) {
 	assert.Equal(t, orig, ms)
 }
 
+func TestResource_Equal(t *testing.T) {
+	ms := Resource(internal.GenerateTestResource())
+	assert.True(t, ms.Equal(ms))
+	assert.True(t, ms.Equal(Resource(internal.GenerateTestResource())))
+	assert.False(t, ms.Equal(NewResource()))
+	assert.False(t, NewResource().Equal(ms))
+}
+
 func TestResource_Attributes(t *testing.T) {
 	ms := NewResource()
 	assert.Equal(t, NewMap(), ms.Attributes())
@@ -90,6 +90,19 @@ func (ms UInt64Slice) CopyTo(dest UInt64Slice) {
 	*dest.getOrig() = copyUInt64Slice(*dest.getOrig(), *ms.getOrig())
 }
 
+// Equal checks equality with another UInt64Slice, all the items being equal in the same order.
+func (ms UInt64Slice) Equal(val UInt64Slice) bool {
+	if ms.Len() != val.Len() {
+		return false
+	}
+	for i := range *ms.getOrig() {
+		if ms.At(i) != val.At(i) {
+			return false
+		}
+	}
+	return true
+}
+
 func copyUInt64Slice(dst, src []uint64) []uint64 {
 	dst = dst[:0]
 	return append(dst, src...)
@@ -51,6 +51,18 @@ func TestUInt64SliceAppend(t *testing.T) {
 	assert.Equal(t, uint64(5), ms.At(4))
 }
 
+func TestUInt64SliceEqual(t *testing.T) {
+	ms := NewUInt64Slice()
+	ms2 := NewUInt64Slice()
+	assert.True(t, ms.Equal(ms2))
+	ms.Append(1, 2, 3)
+	assert.False(t, ms.Equal(ms2))
+	ms2.Append(1, 2, 3)
+	assert.True(t, ms.Equal(ms2))
+	ms2.SetAt(0, 3)
+	assert.False(t, ms.Equal(ms2))
+}
+
 func TestUInt64SliceEnsureCapacity(t *testing.T) {
 	ms := NewUInt64Slice()
 	ms.EnsureCapacity(4)
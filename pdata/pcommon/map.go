@@ -4,6 +4,8 @@
 package pcommon // import "go.opentelemetry.io/collector/pdata/pcommon"
 
 import (
+	"sort"
+
 	"go.uber.org/multierr"
 
 	"go.opentelemetry.io/collector/pdata/internal"
@@ -93,6 +95,86 @@ func (m Map) RemoveIf(f func(string, Value) bool) {
 	*m.getOrig() = (*m.getOrig())[:newLen]
 }
 
+// RetainKeys removes the entries whose key is not one of the given keys.
+func (m Map) RetainKeys(keys ...string) {
+	contains := newKeyMatcher(keys)
+	m.RemoveIf(func(k string, _ Value) bool {
+		return !contains(k)
+	})
+}
+
+// RemoveKeys removes the entries whose key is one of the given keys.
+func (m Map) RemoveKeys(keys ...string) {
+	contains := newKeyMatcher(keys)
+	m.RemoveIf(func(k string, _ Value) bool {
+		return contains(k)
+	})
+}
+
+// newKeyMatcher returns a function reporting whether a key is one of the given keys,
+// only indexing the keys if there are enough of them to make it faster than a linear search.
+func newKeyMatcher(keys []string) func(string) bool {
+	if len(keys) <= 8 {
+		return func(k string) bool {
+			for _, key := range keys {
+				if key == k {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	set := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		set[key] = struct{}{}
+	}
+	return func(k string) bool {
+		_, ok := set[k]
+		return ok
+	}
+}
+
+// Sort sorts the entries of this Map by key, keeping the order of the entries with the same key, so that
+// they can be looked up with GetSorted in O(log n). It is worth it for maps with dozens of entries looked up
+// many times, e.g. the attributes of the records read by several processors. The entries added afterwards by
+// the Put functions are appended at the end, so the Map needs to be sorted again before using GetSorted.
+func (m Map) Sort() {
+	if !m.IsSorted() {
+		sort.Stable(keyValues(*m.getOrig()))
+	}
+}
+
+// IsSorted returns whether the entries of this Map are sorted by key.
+func (m Map) IsSorted() bool {
+	return sort.IsSorted(keyValues(*m.getOrig()))
+}
+
+// GetSorted is like Get, with a binary search of the key in the entries of this Map,
+// which must be sorted with Sort. The result is undefined if the Map isn't sorted.
+func (m Map) GetSorted(key string) (Value, bool) {
+	orig := *m.getOrig()
+	lo, hi := 0, len(orig)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if orig[mid].Key < key {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	if lo < len(orig) && orig[lo].Key == key {
+		return newValue(&orig[lo].Value), true
+	}
+	return newValue(nil), false
+}
+
+// keyValues sorts the entries of a Map by key.
+type keyValues []otlpcommon.KeyValue
+
+func (kvs keyValues) Len() int           { return len(kvs) }
+func (kvs keyValues) Less(i, j int) bool { return kvs[i].Key < kvs[j].Key }
+func (kvs keyValues) Swap(i, j int)      { kvs[i], kvs[j] = kvs[j], kvs[i] }
+
 // PutEmpty inserts or updates an empty value to the map under given key
 // and return the updated/inserted value.
 func (m Map) PutEmpty(k string) Value {
@@ -205,6 +287,32 @@ func (m Map) Range(f func(k string, v Value) bool) {
 	}
 }
 
+// Equal checks equality with another Map: both maps have the same entries, the values being
+// compared with Value.Equal. The entries are compared in order, unless the IgnoreMapOrder option is provided.
+func (m Map) Equal(val Map, opts ...CompareOption) bool {
+	if m.Len() != val.Len() {
+		return false
+	}
+	cfg := newCompareConfig(opts)
+	if !cfg.IgnoreMapOrder {
+		for i := range *m.getOrig() {
+			akv, vkv := &(*m.getOrig())[i], &(*val.getOrig())[i]
+			if akv.Key != vkv.Key || !newValue(&akv.Value).Equal(newValue(&vkv.Value), opts...) {
+				return false
+			}
+		}
+		return true
+	}
+	for i := range *m.getOrig() {
+		akv := &(*m.getOrig())[i]
+		v, ok := val.Get(akv.Key)
+		if !ok || !newValue(&akv.Value).Equal(v, opts...) {
+			return false
+		}
+	}
+	return true
+}
+
 // CopyTo copies all elements from the current map overriding the destination.
 func (m Map) CopyTo(dest Map) {
 	newLen := len(*m.getOrig())
@@ -259,3 +367,78 @@ func (m Map) FromRaw(rawMap map[string]any) error {
 	*m.getOrig() = origs
 	return errs
 }
+
+// MergePolicy defines how Map.Merge resolves the keys present in both maps.
+type MergePolicy int32
+
+const (
+	// MergePolicyOverride replaces the existing values by the merged ones.
+	MergePolicyOverride MergePolicy = iota
+	// MergePolicyKeep keeps the existing values, only inserting the missing keys.
+	MergePolicyKeep
+)
+
+// Merge copies the entries of src to this Map, resolving the keys present in both maps with the given policy.
+func (m Map) Merge(src Map, policy MergePolicy) {
+	srcOrig := *src.getOrig()
+	if len(srcOrig) == 0 || m.getOrig() == src.getOrig() {
+		return
+	}
+	index := make(map[string]int, len(*m.getOrig()))
+	for i := range *m.getOrig() {
+		index[(*m.getOrig())[i].Key] = i
+	}
+	for i := range srcOrig {
+		skv := &srcOrig[i]
+		if j, existing := index[skv.Key]; existing {
+			if policy == MergePolicyOverride {
+				newValue(&skv.Value).CopyTo(newValue(&(*m.getOrig())[j].Value))
+			}
+			continue
+		}
+		*m.getOrig() = append(*m.getOrig(), otlpcommon.KeyValue{Key: skv.Key})
+		j := len(*m.getOrig()) - 1
+		newValue(&skv.Value).CopyTo(newValue(&(*m.getOrig())[j].Value))
+		index[skv.Key] = j
+	}
+}
+
+// MapDiff holds the keys differing between two maps, see Map.Diff.
+type MapDiff struct {
+	// Added are the keys only present in the other map.
+	Added []string
+	// Removed are the keys only present in this map.
+	Removed []string
+	// Changed are the keys present in both maps with different values.
+	Changed []string
+}
+
+// Diff returns the keys added, removed or changed by other compared to this Map.
+// Values are compared with Value.Equal, the order of the entries of the nested maps being ignored.
+func (m Map) Diff(other Map) MapDiff {
+	var diff MapDiff
+	index := make(map[string]int, len(*other.getOrig()))
+	for i := range *other.getOrig() {
+		index[(*other.getOrig())[i].Key] = i
+	}
+	for i := range *m.getOrig() {
+		akv := &(*m.getOrig())[i]
+		j, existing := index[akv.Key]
+		if !existing {
+			diff.Removed = append(diff.Removed, akv.Key)
+			continue
+		}
+		delete(index, akv.Key)
+		if !newValue(&akv.Value).Equal(newValue(&(*other.getOrig())[j].Value), IgnoreMapOrder()) {
+			diff.Changed = append(diff.Changed, akv.Key)
+		}
+	}
+	for i := range *other.getOrig() {
+		okv := &(*other.getOrig())[i]
+		if _, added := index[okv.Key]; added {
+			diff.Added = append(diff.Added, okv.Key)
+			delete(index, okv.Key)
+		}
+	}
+	return diff
+}
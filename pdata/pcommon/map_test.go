@@ -4,6 +4,8 @@
 package pcommon
 
 import (
+	"fmt"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -444,6 +446,228 @@ func TestMap_RemoveIf(t *testing.T) {
 	assert.True(t, exists)
 }
 
+func TestMap_RetainKeys(t *testing.T) {
+	am := NewMap()
+	assert.NoError(t, am.FromRaw(map[string]any{"k1": "v1", "k2": int64(2), "k3": true, "k4": 4.5}))
+
+	am.RetainKeys("k1", "k3", "missing")
+	assert.Equal(t, map[string]any{"k1": "v1", "k3": true}, am.AsRaw())
+
+	// More keys than the linear search threshold.
+	am.RetainKeys("a", "b", "c", "d", "e", "f", "g", "h", "i", "k3")
+	assert.Equal(t, map[string]any{"k3": true}, am.AsRaw())
+
+	am.RetainKeys()
+	assert.Equal(t, 0, am.Len())
+}
+
+func TestMap_RemoveKeys(t *testing.T) {
+	am := NewMap()
+	assert.NoError(t, am.FromRaw(map[string]any{"k1": "v1", "k2": int64(2), "k3": true, "k4": 4.5}))
+
+	am.RemoveKeys("k1", "k3", "missing")
+	assert.Equal(t, map[string]any{"k2": int64(2), "k4": 4.5}, am.AsRaw())
+
+	am.RemoveKeys("a", "b", "c", "d", "e", "f", "g", "h", "i", "k4")
+	assert.Equal(t, map[string]any{"k2": int64(2)}, am.AsRaw())
+
+	am.RemoveKeys()
+	assert.Equal(t, 1, am.Len())
+}
+
+func TestMap_Merge(t *testing.T) {
+	src := NewMap()
+	assert.NoError(t, src.FromRaw(map[string]any{"k2": "new", "k3": map[string]any{"nested": int64(3)}}))
+
+	tests := []struct {
+		name     string
+		policy   MergePolicy
+		expected map[string]any
+	}{
+		{
+			name:     "override",
+			policy:   MergePolicyOverride,
+			expected: map[string]any{"k1": "v1", "k2": "new", "k3": map[string]any{"nested": int64(3)}},
+		},
+		{
+			name:     "keep",
+			policy:   MergePolicyKeep,
+			expected: map[string]any{"k1": "v1", "k2": "old", "k3": map[string]any{"nested": int64(3)}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			am := NewMap()
+			am.PutStr("k1", "v1")
+			am.PutStr("k2", "old")
+			am.Merge(src, tt.policy)
+			assert.Equal(t, tt.expected, am.AsRaw())
+
+			// The merged values are copies.
+			v, _ := am.Get("k3")
+			v.Map().PutStr("other", "value")
+			assert.Equal(t, map[string]any{"k2": "new", "k3": map[string]any{"nested": int64(3)}}, src.AsRaw())
+		})
+	}
+}
+
+func TestMap_MergeItself(t *testing.T) {
+	am := NewMap()
+	am.PutStr("k1", "v1")
+	am.Merge(am, MergePolicyOverride)
+	am.Merge(NewMap(), MergePolicyOverride)
+	assert.Equal(t, map[string]any{"k1": "v1"}, am.AsRaw())
+}
+
+func TestMap_Diff(t *testing.T) {
+	am := NewMap()
+	assert.NoError(t, am.FromRaw(map[string]any{
+		"same":     "v",
+		"removed":  int64(1),
+		"changed":  "old",
+		"type":     int64(1),
+		"map":      map[string]any{"a": int64(1), "b": []any{"x", 1.5}},
+		"mapdiff":  map[string]any{"a": int64(1)},
+		"bytes":    []byte{1, 2},
+		"slicelen": []any{true},
+	}))
+	other := NewMap()
+	assert.NoError(t, other.FromRaw(map[string]any{
+		"same":     "v",
+		"added":    true,
+		"changed":  "new",
+		"type":     "1",
+		"map":      map[string]any{"b": []any{"x", 1.5}, "a": int64(1)},
+		"mapdiff":  map[string]any{"a": int64(2)},
+		"bytes":    []byte{1, 2},
+		"slicelen": []any{true, false},
+	}))
+
+	diff := am.Diff(other)
+	assert.Equal(t, []string{"added"}, diff.Added)
+	assert.Equal(t, []string{"removed"}, diff.Removed)
+	assert.ElementsMatch(t, []string{"changed", "type", "mapdiff", "slicelen"}, diff.Changed)
+
+	assert.Equal(t, MapDiff{}, am.Diff(am))
+	assert.Equal(t, MapDiff{Removed: []string{"k"}}, generateTestIntMap(t).Diff(NewMap()))
+}
+
+func TestMap_Equal(t *testing.T) {
+	am := NewMap()
+	assert.NoError(t, am.FromRaw(map[string]any{"a": "v", "b": map[string]any{"c": int64(1), "d": 1.5}}))
+	other := NewMap()
+	am.CopyTo(other)
+	assert.True(t, am.Equal(other))
+	assert.True(t, am.Equal(am))
+
+	other.PutStr("a", "other")
+	assert.False(t, am.Equal(other))
+	other.Remove("a")
+	assert.False(t, am.Equal(other))
+
+	// The same entries in a different order are only equal when ignoring the order.
+	other.PutStr("a", "v")
+	assert.False(t, am.Equal(other))
+	assert.True(t, am.Equal(other, IgnoreMapOrder()))
+
+	// The option applies to the nested maps.
+	nested, _ := other.Get("b")
+	nested.Map().Remove("c")
+	nested.Map().PutInt("c", 1)
+	assert.True(t, am.Equal(other, IgnoreMapOrder()))
+	nested.Map().PutInt("c", 2)
+	assert.False(t, am.Equal(other, IgnoreMapOrder()))
+	assert.False(t, am.Equal(NewMap(), IgnoreMapOrder()))
+}
+
+func TestMap_Sort(t *testing.T) {
+	am := NewMap()
+	assert.True(t, am.IsSorted())
+	am.Sort()
+	_, ok := am.GetSorted("a")
+	assert.False(t, ok)
+
+	am.PutStr("c", "v")
+	am.PutInt("a", 1)
+	am.PutBool("b", true)
+	// A duplicate key, as it can be received.
+	*am.getOrig() = append(*am.getOrig(), otlpcommon.KeyValue{Key: "a", Value: otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_IntValue{IntValue: 2}}})
+	assert.False(t, am.IsSorted())
+
+	am.Sort()
+	assert.True(t, am.IsSorted())
+	var keys []string
+	am.Range(func(k string, _ Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	assert.Equal(t, []string{"a", "a", "b", "c"}, keys)
+
+	// GetSorted returns the same value as Get, the first one for a duplicate key.
+	for _, k := range []string{"a", "b", "c"} {
+		v, ok := am.GetSorted(k)
+		assert.True(t, ok)
+		expected, _ := am.Get(k)
+		assert.Equal(t, expected, v)
+	}
+	v, _ := am.GetSorted("a")
+	assert.Equal(t, int64(1), v.Int())
+	for _, k := range []string{"", "0", "aa", "d"} {
+		_, ok = am.GetSorted(k)
+		assert.False(t, ok)
+	}
+
+	// The values can be modified in place.
+	v, _ = am.GetSorted("c")
+	v.SetStr("modified")
+	v, _ = am.Get("c")
+	assert.Equal(t, "modified", v.Str())
+
+	am.PutStr("0", "v")
+	assert.False(t, am.IsSorted())
+}
+
+func BenchmarkMap_Get(b *testing.B) {
+	for _, sorted := range []bool{false, true} {
+		b.Run(fmt.Sprintf("sorted=%v", sorted), func(b *testing.B) {
+			am := NewMap()
+			keys := make([]string, 50)
+			for i := range keys {
+				keys[i] = "attribute." + strconv.Itoa(i)
+				am.PutStr(keys[i], "v")
+			}
+			get := am.Get
+			if sorted {
+				am.Sort()
+				get = am.GetSorted
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				if _, ok := get(keys[n%len(keys)]); !ok {
+					b.Fatal("missing attribute")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkMap_Merge(b *testing.B) {
+	src := NewMap()
+	dest := NewMap()
+	for i := 0; i < 50; i++ {
+		src.PutInt(strconv.Itoa(i), int64(i))
+		dest.PutInt(strconv.Itoa(i+25), int64(i))
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		am := NewMap()
+		dest.CopyTo(am)
+		am.Merge(src, MergePolicyOverride)
+	}
+}
+
 func generateTestEmptyMap(t *testing.T) Map {
 	m := NewMap()
 	assert.NoError(t, m.FromRaw(map[string]any{"k": map[string]any(nil)}))
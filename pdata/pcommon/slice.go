@@ -68,6 +68,20 @@ func (es Slice) CopyTo(dest Slice) {
 	}
 }
 
+// Equal checks equality with another Slice: both slices have the same values in the same order,
+// compared with Value.Equal.
+func (es Slice) Equal(val Slice, opts ...CompareOption) bool {
+	if es.Len() != val.Len() {
+		return false
+	}
+	for i := range *es.getOrig() {
+		if !es.At(i).Equal(val.At(i), opts...) {
+			return false
+		}
+	}
+	return true
+}
+
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
 // 1. If the newCap <= cap then no change in capacity.
 // 2. If the newCap > cap then the slice capacity will be expanded to equal newCap.
@@ -45,6 +45,25 @@ func TestSlice_CopyTo(t *testing.T) {
 	assert.Equal(t, Slice(internal.GenerateTestSlice()), dest)
 }
 
+func TestSlice_Equal(t *testing.T) {
+	es := NewSlice()
+	assert.NoError(t, es.FromRaw([]any{"a", int64(1), map[string]any{"b": true, "c": 1.5}}))
+	other := NewSlice()
+	es.CopyTo(other)
+	assert.True(t, es.Equal(other))
+
+	other.At(1).SetInt(2)
+	assert.False(t, es.Equal(other))
+	assert.False(t, es.Equal(NewSlice()))
+
+	// The order of the values is always compared, the options apply to the nested maps.
+	assert.NoError(t, other.FromRaw([]any{"a", int64(1), map[string]any{"c": 1.5, "b": true}}))
+	assert.False(t, es.Equal(other))
+	assert.True(t, es.Equal(other, IgnoreMapOrder()))
+	assert.NoError(t, other.FromRaw([]any{int64(1), "a", map[string]any{"b": true, "c": 1.5}}))
+	assert.False(t, es.Equal(other, IgnoreMapOrder(), IgnoreSliceOrder()))
+}
+
 func TestSlice_EnsureCapacity(t *testing.T) {
 	es := Slice(internal.GenerateTestSlice())
 	// Test ensure smaller capacity.
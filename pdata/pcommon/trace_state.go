@@ -39,3 +39,9 @@ func (ms TraceState) MoveTo(dest TraceState) {
 func (ms TraceState) CopyTo(dest TraceState) {
 	*dest.getOrig() = *ms.getOrig()
 }
+
+// Equal checks equality with another TraceState. The options, accepted for consistency with
+// the other Equal methods, have no effect.
+func (ms TraceState) Equal(val TraceState, _ ...CompareOption) bool {
+	return *ms.getOrig() == *val.getOrig()
+}
@@ -35,3 +35,9 @@ func TestTraceState_FromRaw_AsRaw(t *testing.T) {
 	ms.FromRaw("congo=t61rcWkgMzE")
 	assert.Equal(t, "congo=t61rcWkgMzE", ms.AsRaw())
 }
+
+func TestTraceState_Equal(t *testing.T) {
+	ms := TraceState(internal.GenerateTestTraceState())
+	assert.True(t, ms.Equal(TraceState(internal.GenerateTestTraceState())))
+	assert.False(t, ms.Equal(NewTraceState()))
+}
@@ -347,6 +347,30 @@ func (v Value) CopyTo(dest Value) {
 	}
 }
 
+// Equal checks equality with another Value: both values have the same type and are deeply equal.
+func (v Value) Equal(c Value, opts ...CompareOption) bool {
+	if v.Type() != c.Type() {
+		return false
+	}
+	switch v.Type() {
+	case ValueTypeStr:
+		return v.Str() == c.Str()
+	case ValueTypeInt:
+		return v.Int() == c.Int()
+	case ValueTypeDouble:
+		return v.Double() == c.Double()
+	case ValueTypeBool:
+		return v.Bool() == c.Bool()
+	case ValueTypeBytes:
+		return v.Bytes().Equal(c.Bytes())
+	case ValueTypeMap:
+		return v.Map().Equal(c.Map(), opts...)
+	case ValueTypeSlice:
+		return v.Slice().Equal(c.Slice(), opts...)
+	}
+	return true
+}
+
 // AsString converts an OTLP Value object of any type to its equivalent string
 // representation. This differs from Str which only returns a non-empty value
 // if the ValueType is ValueTypeStr.
@@ -223,6 +223,38 @@ func TestValue_CopyTo(t *testing.T) {
 	assert.EqualValues(t, nil, destVal.Value)
 }
 
+func TestValue_Equal(t *testing.T) {
+	values := []Value{
+		NewValueEmpty(),
+		NewValueStr("a"),
+		NewValueInt(1),
+		NewValueDouble(1),
+		NewValueBool(true),
+		NewValueBytes(),
+		NewValueMap(),
+		NewValueSlice(),
+	}
+	values[5].Bytes().FromRaw([]byte{1})
+	values[6].Map().PutStr("k", "v")
+	values[7].Slice().AppendEmpty().SetStr("v")
+	for i, v := range values {
+		cp := NewValueEmpty()
+		v.CopyTo(cp)
+		assert.True(t, v.Equal(cp))
+		for j, other := range values {
+			assert.Equal(t, i == j, v.Equal(other))
+		}
+	}
+
+	assert.False(t, NewValueStr("a").Equal(NewValueStr("b")))
+	assert.False(t, NewValueInt(1).Equal(NewValueInt(2)))
+	assert.False(t, NewValueDouble(1).Equal(NewValueDouble(2)))
+	assert.False(t, NewValueBool(true).Equal(NewValueBool(false)))
+	assert.False(t, values[5].Equal(NewValueBytes()))
+	assert.False(t, values[6].Equal(NewValueMap()))
+	assert.False(t, values[7].Equal(NewValueSlice()))
+}
+
 func TestSliceWithNilValues(t *testing.T) {
 	origWithNil := []otlpcommon.AnyValue{
 		{},
@@ -133,6 +133,16 @@ func (ms LogRecord) SetDroppedAttributesCount(v uint32) {
 	ms.orig.DroppedAttributesCount = v
 }
 
+// EventName returns the eventname associated with this LogRecord.
+func (ms LogRecord) EventName() string {
+	return ms.orig.EventName
+}
+
+// SetEventName replaces the eventname associated with this LogRecord.
+func (ms LogRecord) SetEventName(v string) {
+	ms.orig.EventName = v
+}
+
 // CopyTo copies all properties from the current struct overriding the destination.
 func (ms LogRecord) CopyTo(dest LogRecord) {
 	dest.SetObservedTimestamp(ms.ObservedTimestamp())
@@ -145,4 +155,43 @@ func (ms LogRecord) CopyTo(dest LogRecord) {
 	ms.Body().CopyTo(dest.Body())
 	ms.Attributes().CopyTo(dest.Attributes())
 	dest.SetDroppedAttributesCount(ms.DroppedAttributesCount())
+	dest.SetEventName(ms.EventName())
+}
+
+// Equal checks equality with another LogRecord, all the fields being deeply equal.
+func (ms LogRecord) Equal(val LogRecord, opts ...pcommon.CompareOption) bool {
+	if ms.ObservedTimestamp() != val.ObservedTimestamp() {
+		return false
+	}
+	if ms.Timestamp() != val.Timestamp() {
+		return false
+	}
+	if ms.TraceID() != val.TraceID() {
+		return false
+	}
+	if ms.SpanID() != val.SpanID() {
+		return false
+	}
+	if ms.Flags() != val.Flags() {
+		return false
+	}
+	if ms.SeverityText() != val.SeverityText() {
+		return false
+	}
+	if ms.SeverityNumber() != val.SeverityNumber() {
+		return false
+	}
+	if !ms.Body().Equal(val.Body(), opts...) {
+		return false
+	}
+	if !ms.Attributes().Equal(val.Attributes(), opts...) {
+		return false
+	}
+	if ms.DroppedAttributesCount() != val.DroppedAttributesCount() {
+		return false
+	}
+	if ms.EventName() != val.EventName() {
+		return false
+	}
+	return true
 }
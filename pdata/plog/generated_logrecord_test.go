@@ -35,6 +35,14 @@ func TestLogRecord_CopyTo(t *testing.T) {
 	assert.Equal(t, orig, ms)
 }
 
+func TestLogRecord_Equal(t *testing.T) {
+	ms := generateTestLogRecord()
+	assert.True(t, ms.Equal(ms))
+	assert.True(t, ms.Equal(generateTestLogRecord()))
+	assert.False(t, ms.Equal(NewLogRecord()))
+	assert.False(t, NewLogRecord().Equal(ms))
+}
+
 func TestLogRecord_ObservedTimestamp(t *testing.T) {
 	ms := NewLogRecord()
 	assert.Equal(t, pcommon.Timestamp(0), ms.ObservedTimestamp())
@@ -110,6 +118,13 @@ func TestLogRecord_DroppedAttributesCount(t *testing.T) {
 	assert.Equal(t, uint32(17), ms.DroppedAttributesCount())
 }
 
+func TestLogRecord_EventName(t *testing.T) {
+	ms := NewLogRecord()
+	assert.Equal(t, "", ms.EventName())
+	ms.SetEventName("user.login")
+	assert.Equal(t, "user.login", ms.EventName())
+}
+
 func generateTestLogRecord() LogRecord {
 	tv := NewLogRecord()
 	fillTestLogRecord(tv)
@@ -127,4 +142,5 @@ func fillTestLogRecord(tv LogRecord) {
 	internal.FillTestValue(internal.NewValue(&tv.orig.Body))
 	internal.FillTestMap(internal.NewMap(&tv.orig.Attributes))
 	tv.orig.DroppedAttributesCount = uint32(17)
+	tv.orig.EventName = "user.login"
 }
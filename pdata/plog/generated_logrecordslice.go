@@ -9,7 +9,9 @@ package plog
 import (
 	"sort"
 
+	"go.opentelemetry.io/collector/pdata/internal"
 	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 // LogRecordSlice logically represents a slice of LogRecord.
@@ -135,6 +137,29 @@ func (es LogRecordSlice) CopyTo(dest LogRecordSlice) {
 	*dest.orig = wrappers
 }
 
+// Equal checks equality with another LogRecordSlice, all the elements being deeply equal.
+// The elements are compared in order, unless the IgnoreSliceOrder option is provided.
+func (es LogRecordSlice) Equal(val LogRecordSlice, opts ...pcommon.CompareOption) bool {
+	if es.Len() != val.Len() {
+		return false
+	}
+	var cfg internal.CompareConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.IgnoreSliceOrder {
+		return internal.EqualUnordered(es.Len(), func(i, j int) bool {
+			return es.At(i).Equal(val.At(j), opts...)
+		})
+	}
+	for i := range *es.orig {
+		if !es.At(i).Equal(val.At(i), opts...) {
+			return false
+		}
+	}
+	return true
+}
+
 // Sort sorts the LogRecord elements within LogRecordSlice given the
 // provided less function so that two instances of LogRecordSlice
 // can be compared.
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 func TestLogRecordSlice(t *testing.T) {
@@ -108,6 +109,26 @@ func TestLogRecordSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestLogRecordSlice_Equal(t *testing.T) {
+	es := generateTestLogRecordSlice()
+	assert.True(t, es.Equal(generateTestLogRecordSlice()))
+	assert.False(t, es.Equal(NewLogRecordSlice()))
+
+	other := generateTestLogRecordSlice()
+	NewLogRecord().CopyTo(other.At(0))
+	assert.False(t, es.Equal(other))
+
+	// Test Equal ignoring the order of the elements
+	reordered := NewLogRecordSlice()
+	for i := 1; i < other.Len(); i++ {
+		other.At(i).CopyTo(reordered.AppendEmpty())
+	}
+	other.At(0).CopyTo(reordered.AppendEmpty())
+	assert.False(t, other.Equal(reordered))
+	assert.True(t, other.Equal(reordered, pcommon.IgnoreSliceOrder()))
+	assert.False(t, es.Equal(reordered, pcommon.IgnoreSliceOrder()))
+}
+
 func TestLogRecordSlice_Sort(t *testing.T) {
 	es := generateTestLogRecordSlice()
 	es.Sort(func(a, b LogRecord) bool {
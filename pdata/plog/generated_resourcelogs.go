@@ -68,3 +68,17 @@ func (ms ResourceLogs) CopyTo(dest ResourceLogs) {
 	dest.SetSchemaUrl(ms.SchemaUrl())
 	ms.ScopeLogs().CopyTo(dest.ScopeLogs())
 }
+
+// Equal checks equality with another ResourceLogs, all the fields being deeply equal.
+func (ms ResourceLogs) Equal(val ResourceLogs, opts ...pcommon.CompareOption) bool {
+	if !ms.Resource().Equal(val.Resource(), opts...) {
+		return false
+	}
+	if ms.SchemaUrl() != val.SchemaUrl() {
+		return false
+	}
+	if !ms.ScopeLogs().Equal(val.ScopeLogs(), opts...) {
+		return false
+	}
+	return true
+}
@@ -33,6 +33,14 @@ func TestResourceLogs_CopyTo(t *testing.T) {
 	assert.Equal(t, orig, ms)
 }
 
+func TestResourceLogs_Equal(t *testing.T) {
+	ms := generateTestResourceLogs()
+	assert.True(t, ms.Equal(ms))
+	assert.True(t, ms.Equal(generateTestResourceLogs()))
+	assert.False(t, ms.Equal(NewResourceLogs()))
+	assert.False(t, NewResourceLogs().Equal(ms))
+}
+
 func TestResourceLogs_Resource(t *testing.T) {
 	ms := NewResourceLogs()
 	internal.FillTestResource(internal.Resource(ms.Resource()))
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 func TestResourceLogsSlice(t *testing.T) {
@@ -108,6 +109,26 @@ func TestResourceLogsSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestResourceLogsSlice_Equal(t *testing.T) {
+	es := generateTestResourceLogsSlice()
+	assert.True(t, es.Equal(generateTestResourceLogsSlice()))
+	assert.False(t, es.Equal(NewResourceLogsSlice()))
+
+	other := generateTestResourceLogsSlice()
+	NewResourceLogs().CopyTo(other.At(0))
+	assert.False(t, es.Equal(other))
+
+	// Test Equal ignoring the order of the elements
+	reordered := NewResourceLogsSlice()
+	for i := 1; i < other.Len(); i++ {
+		other.At(i).CopyTo(reordered.AppendEmpty())
+	}
+	other.At(0).CopyTo(reordered.AppendEmpty())
+	assert.False(t, other.Equal(reordered))
+	assert.True(t, other.Equal(reordered, pcommon.IgnoreSliceOrder()))
+	assert.False(t, es.Equal(reordered, pcommon.IgnoreSliceOrder()))
+}
+
 func TestResourceLogsSlice_Sort(t *testing.T) {
 	es := generateTestResourceLogsSlice()
 	es.Sort(func(a, b ResourceLogs) bool {
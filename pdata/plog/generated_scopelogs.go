@@ -68,3 +68,17 @@ func (ms ScopeLogs) CopyTo(dest ScopeLogs) {
 	dest.SetSchemaUrl(ms.SchemaUrl())
 	ms.LogRecords().CopyTo(dest.LogRecords())
 }
+
+// Equal checks equality with another ScopeLogs, all the fields being deeply equal.
+func (ms ScopeLogs) Equal(val ScopeLogs, opts ...pcommon.CompareOption) bool {
+	if !ms.Scope().Equal(val.Scope(), opts...) {
+		return false
+	}
+	if ms.SchemaUrl() != val.SchemaUrl() {
+		return false
+	}
+	if !ms.LogRecords().Equal(val.LogRecords(), opts...) {
+		return false
+	}
+	return true
+}
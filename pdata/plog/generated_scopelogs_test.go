@@ -33,6 +33,14 @@ func TestScopeLogs_CopyTo(t *testing.T) {
 	assert.Equal(t, orig, ms)
 }
 
+func TestScopeLogs_Equal(t *testing.T) {
+	ms := generateTestScopeLogs()
+	assert.True(t, ms.Equal(ms))
+	assert.True(t, ms.Equal(generateTestScopeLogs()))
+	assert.False(t, ms.Equal(NewScopeLogs()))
+	assert.False(t, NewScopeLogs().Equal(ms))
+}
+
 func TestScopeLogs_Scope(t *testing.T) {
 	ms := NewScopeLogs()
 	internal.FillTestInstrumentationScope(internal.InstrumentationScope(ms.Scope()))
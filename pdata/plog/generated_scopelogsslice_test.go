@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 func TestScopeLogsSlice(t *testing.T) {
@@ -108,6 +109,26 @@ func TestScopeLogsSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestScopeLogsSlice_Equal(t *testing.T) {
+	es := generateTestScopeLogsSlice()
+	assert.True(t, es.Equal(generateTestScopeLogsSlice()))
+	assert.False(t, es.Equal(NewScopeLogsSlice()))
+
+	other := generateTestScopeLogsSlice()
+	NewScopeLogs().CopyTo(other.At(0))
+	assert.False(t, es.Equal(other))
+
+	// Test Equal ignoring the order of the elements
+	reordered := NewScopeLogsSlice()
+	for i := 1; i < other.Len(); i++ {
+		other.At(i).CopyTo(reordered.AppendEmpty())
+	}
+	other.At(0).CopyTo(reordered.AppendEmpty())
+	assert.False(t, other.Equal(reordered))
+	assert.True(t, other.Equal(reordered, pcommon.IgnoreSliceOrder()))
+	assert.False(t, es.Equal(reordered, pcommon.IgnoreSliceOrder()))
+}
+
 func TestScopeLogsSlice_Sort(t *testing.T) {
 	es := generateTestScopeLogsSlice()
 	es.Sort(func(a, b ScopeLogs) bool {
@@ -115,6 +115,8 @@ func (ms LogRecord) unmarshalJsoniter(iter *jsoniter.Iterator) {
 			ms.orig.DroppedAttributesCount = json.ReadUint32(iter)
 		case "flags":
 			ms.orig.Flags = json.ReadUint32(iter)
+		case "eventName", "event_name":
+			ms.orig.EventName = iter.ReadString()
 		case "traceId", "trace_id":
 			if err := ms.orig.TraceId.UnmarshalJSON([]byte(iter.ReadString())); err != nil {
 				iter.ReportError("readLog.traceId", fmt.Sprintf("parse trace_id:%v", err))
@@ -6,6 +6,7 @@ package plog // import "go.opentelemetry.io/collector/pdata/plog"
 import (
 	"go.opentelemetry.io/collector/pdata/internal"
 	otlpcollectorlog "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/logs/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 // Logs is the top-level struct that is propagated through the logs pipeline.
@@ -30,6 +31,13 @@ func (ms Logs) CopyTo(dest Logs) {
 	ms.ResourceLogs().CopyTo(dest.ResourceLogs())
 }
 
+// Equal checks equality with another Logs, all the resources being deeply equal.
+// Use pcommon.IgnoreSliceOrder and pcommon.IgnoreMapOrder to ignore the order of the resources,
+// scopes, records and attributes.
+func (ms Logs) Equal(val Logs, opts ...pcommon.CompareOption) bool {
+	return ms.ResourceLogs().Equal(val.ResourceLogs(), opts...)
+}
+
 // LogRecordCount calculates the total number of log records.
 func (ms Logs) LogRecordCount() int {
 	logCount := 0
@@ -45,6 +53,26 @@ func (ms Logs) LogRecordCount() int {
 	return logCount
 }
 
+// RangeLogRecords calls f sequentially for each log record, with the resource and the scope it belongs to.
+// If f returns false, RangeLogRecords stops the iteration. The log records are not copied: f may modify them,
+// but must not add or remove resources, scopes or log records while iterating.
+func (ms Logs) RangeLogRecords(f func(rl ResourceLogs, sl ScopeLogs, lr LogRecord) bool) {
+	rls := ms.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			lrs := sl.LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				if !f(rl, sl, lrs.At(k)) {
+					return
+				}
+			}
+		}
+	}
+}
+
 // ResourceLogs returns the ResourceLogsSlice associated with this Logs.
 func (ms Logs) ResourceLogs() ResourceLogsSlice {
 	return newResourceLogsSlice(&ms.getOrig().ResourceLogs)
@@ -13,6 +13,7 @@ import (
 
 	otlpcollectorlog "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/logs/v1"
 	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 func TestLogRecordCount(t *testing.T) {
@@ -64,6 +65,45 @@ func TestLogRecordCountWithEmpty(t *testing.T) {
 	}).LogRecordCount())
 }
 
+func TestRangeLogRecords(t *testing.T) {
+	ld := NewLogs()
+	ld.RangeLogRecords(func(ResourceLogs, ScopeLogs, LogRecord) bool {
+		t.Fatal("no log records to iterate")
+		return true
+	})
+
+	rs := ld.ResourceLogs().AppendEmpty()
+	rs.Resource().Attributes().PutStr("resource", "r0")
+	rs.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("a")
+	// An empty scope is skipped.
+	rs.ScopeLogs().AppendEmpty()
+	recs := rs.ScopeLogs().AppendEmpty().LogRecords()
+	recs.AppendEmpty().Body().SetStr("b")
+	recs.AppendEmpty().Body().SetStr("c")
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("d")
+
+	var names []string
+	ld.RangeLogRecords(func(r ResourceLogs, s ScopeLogs, rec LogRecord) bool {
+		names = append(names, rec.Body().Str())
+		if rec.Body().Str() == "b" {
+			assert.Equal(t, rs, r)
+			assert.Equal(t, rs.ScopeLogs().At(2), s)
+			// The log records are not copied.
+			rec.Body().SetStr("modified")
+		}
+		return true
+	})
+	assert.Equal(t, []string{"a", "b", "c", "d"}, names)
+	assert.Equal(t, "modified", recs.At(0).Body().Str())
+
+	names = nil
+	ld.RangeLogRecords(func(_ ResourceLogs, _ ScopeLogs, rec LogRecord) bool {
+		names = append(names, rec.Body().Str())
+		return len(names) < 2
+	})
+	assert.Equal(t, []string{"a", "modified"}, names)
+}
+
 func TestToFromLogOtlp(t *testing.T) {
 	otlp := &otlpcollectorlog.ExportLogsServiceRequest{}
 	logs := newLogs(otlp)
@@ -112,3 +152,22 @@ func TestLogsCopyTo(t *testing.T) {
 	logs.CopyTo(logsCopy)
 	assert.EqualValues(t, logs, logsCopy)
 }
+
+func TestLogsEqual(t *testing.T) {
+	logs := NewLogs()
+	fillTestResourceLogsSlice(logs.ResourceLogs())
+	other := NewLogs()
+	logs.CopyTo(other)
+	assert.True(t, logs.Equal(other))
+
+	other.ResourceLogs().AppendEmpty()
+	assert.False(t, logs.Equal(other))
+
+	reordered := NewLogs()
+	other.ResourceLogs().At(other.ResourceLogs().Len() - 1).CopyTo(reordered.ResourceLogs().AppendEmpty())
+	for i := 0; i < other.ResourceLogs().Len()-1; i++ {
+		other.ResourceLogs().At(i).CopyTo(reordered.ResourceLogs().AppendEmpty())
+	}
+	assert.False(t, other.Equal(reordered))
+	assert.True(t, other.Equal(reordered, pcommon.IgnoreSliceOrder()))
+}
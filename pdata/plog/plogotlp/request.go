@@ -5,6 +5,7 @@ package plogotlp // import "go.opentelemetry.io/collector/pdata/plog/plogotlp"
 
 import (
 	"bytes"
+	"sync"
 
 	"go.opentelemetry.io/collector/pdata/internal"
 	otlpcollectorlog "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/logs/v1"
@@ -69,3 +70,29 @@ func (ms ExportRequest) UnmarshalJSON(data []byte) error {
 func (ms ExportRequest) Logs() plog.Logs {
 	return plog.Logs(internal.NewLogs(ms.orig))
 }
+
+var requestPool = sync.Pool{
+	New: func() any {
+		return &otlpcollectorlog.ExportLogsServiceRequest{}
+	},
+}
+
+// AcquireExportRequest returns an empty ExportRequest from a pool, reusing the memory of the requests
+// given back with ReleaseExportRequest instead of allocating a new request for every received request.
+func AcquireExportRequest() ExportRequest {
+	return ExportRequest{orig: requestPool.Get().(*otlpcollectorlog.ExportLogsServiceRequest)}
+}
+
+// ReleaseExportRequest gives back to the pool of AcquireExportRequest a request that is not used anymore.
+// The request, and the plog.Logs returned by its Logs method, must not be used once released:
+// the data still used afterwards, e.g. passed to a consumer that may keep it, must be moved out of
+// the request before, with ResourceLogsSlice.MoveAndAppendTo.
+func ReleaseExportRequest(req ExportRequest) {
+	// Keep the capacity of the slice, the elements are cleared to not retain the moved data.
+	rs := req.orig.ResourceLogs
+	for i := range rs {
+		rs[i] = nil
+	}
+	*req.orig = otlpcollectorlog.ExportLogsServiceRequest{ResourceLogs: rs[:0]}
+	requestPool.Put(req.orig)
+}
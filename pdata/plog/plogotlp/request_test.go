@@ -9,6 +9,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/plog"
 )
 
 var _ json.Unmarshaler = ExportRequest{}
@@ -53,3 +56,26 @@ func TestRequestJSON(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, strings.Join(strings.Fields(string(logsRequestJSON)), ""), string(got))
 }
+
+func TestAcquireReleaseExportRequest(t *testing.T) {
+	req := AcquireExportRequest()
+	assert.Equal(t, 0, req.Logs().LogRecordCount())
+	req.Logs().ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("test_log")
+	data, err := req.MarshalProto()
+	require.NoError(t, err)
+
+	// The data moved out of the request is not changed once the request is released.
+	moved := plog.NewLogs()
+	req.Logs().ResourceLogs().MoveAndAppendTo(moved.ResourceLogs())
+	ReleaseExportRequest(req)
+	assert.Equal(t, 1, moved.LogRecordCount())
+
+	for i := 0; i < 10; i++ {
+		req = AcquireExportRequest()
+		assert.Equal(t, 0, req.Logs().LogRecordCount())
+		require.NoError(t, req.UnmarshalProto(data))
+		assert.Equal(t, 1, req.Logs().LogRecordCount())
+		ReleaseExportRequest(req)
+	}
+	assert.Equal(t, 1, moved.LogRecordCount())
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
+
+import (
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// AppendDouble appends an exemplar with a double value, recorded at the given timestamp in the
+// span identified by traceID and spanID, and returns it.
+func (es ExemplarSlice) AppendDouble(timestamp pcommon.Timestamp, value float64, traceID pcommon.TraceID, spanID pcommon.SpanID) Exemplar {
+	e := es.appendTraced(timestamp, traceID, spanID)
+	e.SetDoubleValue(value)
+	return e
+}
+
+// AppendInt appends an exemplar with an int value, recorded at the given timestamp in the
+// span identified by traceID and spanID, and returns it.
+func (es ExemplarSlice) AppendInt(timestamp pcommon.Timestamp, value int64, traceID pcommon.TraceID, spanID pcommon.SpanID) Exemplar {
+	e := es.appendTraced(timestamp, traceID, spanID)
+	e.SetIntValue(value)
+	return e
+}
+
+func (es ExemplarSlice) appendTraced(timestamp pcommon.Timestamp, traceID pcommon.TraceID, spanID pcommon.SpanID) Exemplar {
+	e := es.AppendEmpty()
+	e.SetTimestamp(timestamp)
+	e.SetTraceID(traceID)
+	e.SetSpanID(spanID)
+	return e
+}
+
+// RemoveOlderThan removes the exemplars recorded before the given timestamp.
+func (es ExemplarSlice) RemoveOlderThan(timestamp pcommon.Timestamp) {
+	es.RemoveIf(func(e Exemplar) bool {
+		return e.Timestamp() < timestamp
+	})
+}
+
+// KeepMostRecent removes the exemplars beyond the limit most recently recorded ones.
+// The order of the kept exemplars is unchanged.
+func (es ExemplarSlice) KeepMostRecent(limit int) {
+	if limit < 0 {
+		limit = 0
+	}
+	if es.Len() <= limit {
+		return
+	}
+	indexes := make([]int, es.Len())
+	for i := range indexes {
+		indexes[i] = i
+	}
+	// The most recent first, the exemplars recorded at the same time in their order.
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return es.At(indexes[i]).Timestamp() > es.At(indexes[j]).Timestamp()
+	})
+	keep := make([]bool, es.Len())
+	for _, i := range indexes[:limit] {
+		keep[i] = true
+	}
+	i := 0
+	es.RemoveIf(func(Exemplar) bool {
+		removed := !keep[i]
+		i++
+		return removed
+	})
+}
+
+// CopyInRangeTo appends to dest a copy of the exemplars recorded after start, up to and including end,
+// e.g. the exemplars of a cumulative point belonging to the delta point covering (start, end] when the
+// temporality is converted. A zero start or end leaves the range open on that side.
+func (es ExemplarSlice) CopyInRangeTo(dest ExemplarSlice, start, end pcommon.Timestamp) {
+	n := es.Len()
+	for i := 0; i < n; i++ {
+		e := es.At(i)
+		if (start != 0 && e.Timestamp() <= start) || (end != 0 && e.Timestamp() > end) {
+			continue
+		}
+		e.CopyTo(dest.AppendEmpty())
+	}
+}
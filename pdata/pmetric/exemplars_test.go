@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func timestamps(es ExemplarSlice) []pcommon.Timestamp {
+	var ts []pcommon.Timestamp
+	for i := 0; i < es.Len(); i++ {
+		ts = append(ts, es.At(i).Timestamp())
+	}
+	return ts
+}
+
+func newExemplarsAt(ts ...pcommon.Timestamp) ExemplarSlice {
+	es := NewExemplarSlice()
+	for i, t := range ts {
+		es.AppendInt(t, int64(i), pcommon.TraceID{1}, pcommon.SpanID{byte(i)})
+	}
+	return es
+}
+
+func TestExemplarSliceAppend(t *testing.T) {
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	spanID := pcommon.SpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	es := NewExemplarSlice()
+
+	e := es.AppendDouble(10, 1.5, traceID, spanID)
+	assert.Equal(t, ExemplarValueTypeDouble, e.ValueType())
+	assert.Equal(t, 1.5, e.DoubleValue())
+	assert.Equal(t, pcommon.Timestamp(10), e.Timestamp())
+	assert.Equal(t, traceID, e.TraceID())
+	assert.Equal(t, spanID, e.SpanID())
+
+	e = es.AppendInt(20, 3, traceID, spanID)
+	assert.Equal(t, ExemplarValueTypeInt, e.ValueType())
+	assert.Equal(t, int64(3), e.IntValue())
+	assert.Equal(t, 2, es.Len())
+}
+
+func TestExemplarSliceRemoveOlderThan(t *testing.T) {
+	es := newExemplarsAt(30, 10, 20, 40)
+	es.RemoveOlderThan(20)
+	assert.Equal(t, []pcommon.Timestamp{30, 20, 40}, timestamps(es))
+}
+
+func TestExemplarSliceKeepMostRecent(t *testing.T) {
+	es := newExemplarsAt(30, 10, 20, 40, 20)
+	es.KeepMostRecent(5)
+	assert.Equal(t, 5, es.Len())
+
+	es.KeepMostRecent(3)
+	assert.Equal(t, []pcommon.Timestamp{30, 20, 40}, timestamps(es))
+	// The first of the exemplars recorded at the same time is kept.
+	assert.Equal(t, int64(2), es.At(1).IntValue())
+
+	es.KeepMostRecent(-1)
+	assert.Equal(t, 0, es.Len())
+}
+
+func TestExemplarSliceCopyInRangeTo(t *testing.T) {
+	es := newExemplarsAt(10, 20, 30, 40)
+
+	dest := newExemplarsAt(5)
+	es.CopyInRangeTo(dest, 10, 30)
+	assert.Equal(t, []pcommon.Timestamp{5, 20, 30}, timestamps(dest))
+	assert.True(t, es.At(1).Equal(dest.At(1)))
+
+	dest = NewExemplarSlice()
+	es.CopyInRangeTo(dest, 0, 20)
+	assert.Equal(t, []pcommon.Timestamp{10, 20}, timestamps(dest))
+
+	dest = NewExemplarSlice()
+	es.CopyInRangeTo(dest, 20, 0)
+	assert.Equal(t, []pcommon.Timestamp{30, 40}, timestamps(dest))
+
+	// Copying to the same slice appends the copies once.
+	es.CopyInRangeTo(es, 30, 0)
+	assert.Equal(t, []pcommon.Timestamp{10, 20, 30, 40, 40}, timestamps(es))
+}
@@ -131,3 +131,33 @@ func (ms Exemplar) CopyTo(dest Exemplar) {
 	dest.SetTraceID(ms.TraceID())
 	dest.SetSpanID(ms.SpanID())
 }
+
+// Equal checks equality with another Exemplar, all the fields being deeply equal.
+func (ms Exemplar) Equal(val Exemplar, opts ...pcommon.CompareOption) bool {
+	if ms.Timestamp() != val.Timestamp() {
+		return false
+	}
+	if ms.ValueType() != val.ValueType() {
+		return false
+	}
+	switch ms.ValueType() {
+	case ExemplarValueTypeDouble:
+		if ms.DoubleValue() != val.DoubleValue() {
+			return false
+		}
+	case ExemplarValueTypeInt:
+		if ms.IntValue() != val.IntValue() {
+			return false
+		}
+	}
+	if !ms.FilteredAttributes().Equal(val.FilteredAttributes(), opts...) {
+		return false
+	}
+	if ms.TraceID() != val.TraceID() {
+		return false
+	}
+	if ms.SpanID() != val.SpanID() {
+		return false
+	}
+	return true
+}
@@ -35,6 +35,14 @@ func TestExemplar_CopyTo(t *testing.T) {
 	assert.Equal(t, orig, ms)
 }
 
+func TestExemplar_Equal(t *testing.T) {
+	ms := generateTestExemplar()
+	assert.True(t, ms.Equal(ms))
+	assert.True(t, ms.Equal(generateTestExemplar()))
+	assert.False(t, ms.Equal(NewExemplar()))
+	assert.False(t, NewExemplar().Equal(ms))
+}
+
 func TestExemplar_Timestamp(t *testing.T) {
 	ms := NewExemplar()
 	assert.Equal(t, pcommon.Timestamp(0), ms.Timestamp())
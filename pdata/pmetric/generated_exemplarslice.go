@@ -7,7 +7,9 @@
 package pmetric
 
 import (
+	"go.opentelemetry.io/collector/pdata/internal"
 	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 // ExemplarSlice logically represents a slice of Exemplar.
@@ -126,3 +128,26 @@ func (es ExemplarSlice) CopyTo(dest ExemplarSlice) {
 		newExemplar(&(*es.orig)[i]).CopyTo(newExemplar(&(*dest.orig)[i]))
 	}
 }
+
+// Equal checks equality with another ExemplarSlice, all the elements being deeply equal.
+// The elements are compared in order, unless the IgnoreSliceOrder option is provided.
+func (es ExemplarSlice) Equal(val ExemplarSlice, opts ...pcommon.CompareOption) bool {
+	if es.Len() != val.Len() {
+		return false
+	}
+	var cfg internal.CompareConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.IgnoreSliceOrder {
+		return internal.EqualUnordered(es.Len(), func(i, j int) bool {
+			return es.At(i).Equal(val.At(j), opts...)
+		})
+	}
+	for i := range *es.orig {
+		if !es.At(i).Equal(val.At(i), opts...) {
+			return false
+		}
+	}
+	return true
+}
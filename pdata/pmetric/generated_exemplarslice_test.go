@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 func TestExemplarSlice(t *testing.T) {
@@ -107,6 +108,26 @@ func TestExemplarSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestExemplarSlice_Equal(t *testing.T) {
+	es := generateTestExemplarSlice()
+	assert.True(t, es.Equal(generateTestExemplarSlice()))
+	assert.False(t, es.Equal(NewExemplarSlice()))
+
+	other := generateTestExemplarSlice()
+	NewExemplar().CopyTo(other.At(0))
+	assert.False(t, es.Equal(other))
+
+	// Test Equal ignoring the order of the elements
+	reordered := NewExemplarSlice()
+	for i := 1; i < other.Len(); i++ {
+		other.At(i).CopyTo(reordered.AppendEmpty())
+	}
+	other.At(0).CopyTo(reordered.AppendEmpty())
+	assert.False(t, other.Equal(reordered))
+	assert.True(t, other.Equal(reordered, pcommon.IgnoreSliceOrder()))
+	assert.False(t, es.Equal(reordered, pcommon.IgnoreSliceOrder()))
+}
+
 func generateTestExemplarSlice() ExemplarSlice {
 	es := NewExemplarSlice()
 	fillTestExemplarSlice(es)
@@ -8,6 +8,7 @@ package pmetric
 
 import (
 	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 // ExponentialHistogram represents the type of a metric that is calculated by aggregating
@@ -61,3 +62,14 @@ func (ms ExponentialHistogram) CopyTo(dest ExponentialHistogram) {
 	dest.SetAggregationTemporality(ms.AggregationTemporality())
 	ms.DataPoints().CopyTo(dest.DataPoints())
 }
+
+// Equal checks equality with another ExponentialHistogram, all the fields being deeply equal.
+func (ms ExponentialHistogram) Equal(val ExponentialHistogram, opts ...pcommon.CompareOption) bool {
+	if ms.AggregationTemporality() != val.AggregationTemporality() {
+		return false
+	}
+	if !ms.DataPoints().Equal(val.DataPoints(), opts...) {
+		return false
+	}
+	return true
+}
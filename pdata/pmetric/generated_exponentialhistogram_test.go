@@ -32,6 +32,14 @@ func TestExponentialHistogram_CopyTo(t *testing.T) {
 	assert.Equal(t, orig, ms)
 }
 
+func TestExponentialHistogram_Equal(t *testing.T) {
+	ms := generateTestExponentialHistogram()
+	assert.True(t, ms.Equal(ms))
+	assert.True(t, ms.Equal(generateTestExponentialHistogram()))
+	assert.False(t, ms.Equal(NewExponentialHistogram()))
+	assert.False(t, NewExponentialHistogram().Equal(ms))
+}
+
 func TestExponentialHistogram_AggregationTemporality(t *testing.T) {
 	ms := NewExponentialHistogram()
 	assert.Equal(t, AggregationTemporality(otlpmetrics.AggregationTemporality(0)), ms.AggregationTemporality())
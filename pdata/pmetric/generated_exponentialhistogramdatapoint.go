@@ -213,3 +213,47 @@ func (ms ExponentialHistogramDataPoint) CopyTo(dest ExponentialHistogramDataPoin
 	}
 
 }
+
+// Equal checks equality with another ExponentialHistogramDataPoint, all the fields being deeply equal.
+func (ms ExponentialHistogramDataPoint) Equal(val ExponentialHistogramDataPoint, opts ...pcommon.CompareOption) bool {
+	if !ms.Attributes().Equal(val.Attributes(), opts...) {
+		return false
+	}
+	if ms.StartTimestamp() != val.StartTimestamp() {
+		return false
+	}
+	if ms.Timestamp() != val.Timestamp() {
+		return false
+	}
+	if ms.Count() != val.Count() {
+		return false
+	}
+	if ms.HasSum() != val.HasSum() || ms.Sum() != val.Sum() {
+		return false
+	}
+	if ms.Scale() != val.Scale() {
+		return false
+	}
+	if ms.ZeroCount() != val.ZeroCount() {
+		return false
+	}
+	if !ms.Positive().Equal(val.Positive(), opts...) {
+		return false
+	}
+	if !ms.Negative().Equal(val.Negative(), opts...) {
+		return false
+	}
+	if !ms.Exemplars().Equal(val.Exemplars(), opts...) {
+		return false
+	}
+	if ms.Flags() != val.Flags() {
+		return false
+	}
+	if ms.HasMin() != val.HasMin() || ms.Min() != val.Min() {
+		return false
+	}
+	if ms.HasMax() != val.HasMax() || ms.Max() != val.Max() {
+		return false
+	}
+	return true
+}
@@ -34,6 +34,14 @@ func TestExponentialHistogramDataPoint_CopyTo(t *testing.T) {
 	assert.Equal(t, orig, ms)
 }
 
+func TestExponentialHistogramDataPoint_Equal(t *testing.T) {
+	ms := generateTestExponentialHistogramDataPoint()
+	assert.True(t, ms.Equal(ms))
+	assert.True(t, ms.Equal(generateTestExponentialHistogramDataPoint()))
+	assert.False(t, ms.Equal(NewExponentialHistogramDataPoint()))
+	assert.False(t, NewExponentialHistogramDataPoint().Equal(ms))
+}
+
 func TestExponentialHistogramDataPoint_Attributes(t *testing.T) {
 	ms := NewExponentialHistogramDataPoint()
 	assert.Equal(t, pcommon.NewMap(), ms.Attributes())
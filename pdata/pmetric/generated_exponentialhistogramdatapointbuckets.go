@@ -62,3 +62,14 @@ func (ms ExponentialHistogramDataPointBuckets) CopyTo(dest ExponentialHistogramD
 	dest.SetOffset(ms.Offset())
 	ms.BucketCounts().CopyTo(dest.BucketCounts())
 }
+
+// Equal checks equality with another ExponentialHistogramDataPointBuckets, all the fields being deeply equal.
+func (ms ExponentialHistogramDataPointBuckets) Equal(val ExponentialHistogramDataPointBuckets, opts ...pcommon.CompareOption) bool {
+	if ms.Offset() != val.Offset() {
+		return false
+	}
+	if !ms.BucketCounts().Equal(val.BucketCounts()) {
+		return false
+	}
+	return true
+}
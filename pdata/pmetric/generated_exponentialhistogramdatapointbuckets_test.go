@@ -30,6 +30,14 @@ func TestExponentialHistogramDataPointBuckets_CopyTo(t *testing.T) {
 	assert.Equal(t, orig, ms)
 }
 
+func TestExponentialHistogramDataPointBuckets_Equal(t *testing.T) {
+	ms := generateTestExponentialHistogramDataPointBuckets()
+	assert.True(t, ms.Equal(ms))
+	assert.True(t, ms.Equal(generateTestExponentialHistogramDataPointBuckets()))
+	assert.False(t, ms.Equal(NewExponentialHistogramDataPointBuckets()))
+	assert.False(t, NewExponentialHistogramDataPointBuckets().Equal(ms))
+}
+
 func TestExponentialHistogramDataPointBuckets_Offset(t *testing.T) {
 	ms := NewExponentialHistogramDataPointBuckets()
 	assert.Equal(t, int32(0), ms.Offset())
@@ -8,6 +8,7 @@ package pmetric
 
 import (
 	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 // Gauge represents the type of a numeric metric that always exports the "current value" for every data point.
@@ -49,3 +50,11 @@ func (ms Gauge) DataPoints() NumberDataPointSlice {
 func (ms Gauge) CopyTo(dest Gauge) {
 	ms.DataPoints().CopyTo(dest.DataPoints())
 }
+
+// Equal checks equality with another Gauge, all the fields being deeply equal.
+func (ms Gauge) Equal(val Gauge, opts ...pcommon.CompareOption) bool {
+	if !ms.DataPoints().Equal(val.DataPoints(), opts...) {
+		return false
+	}
+	return true
+}
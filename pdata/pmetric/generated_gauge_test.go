@@ -30,6 +30,14 @@ func TestGauge_CopyTo(t *testing.T) {
 	assert.Equal(t, orig, ms)
 }
 
+func TestGauge_Equal(t *testing.T) {
+	ms := generateTestGauge()
+	assert.True(t, ms.Equal(ms))
+	assert.True(t, ms.Equal(generateTestGauge()))
+	assert.False(t, ms.Equal(NewGauge()))
+	assert.False(t, NewGauge().Equal(ms))
+}
+
 func TestGauge_DataPoints(t *testing.T) {
 	ms := NewGauge()
 	assert.Equal(t, NewNumberDataPointSlice(), ms.DataPoints())
@@ -8,6 +8,7 @@ package pmetric
 
 import (
 	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 // Histogram represents the type of a metric that is calculated by aggregating as a Histogram of all reported measurements over a time interval.
@@ -60,3 +61,14 @@ func (ms Histogram) CopyTo(dest Histogram) {
 	dest.SetAggregationTemporality(ms.AggregationTemporality())
 	ms.DataPoints().CopyTo(dest.DataPoints())
 }
+
+// Equal checks equality with another Histogram, all the fields being deeply equal.
+func (ms Histogram) Equal(val Histogram, opts ...pcommon.CompareOption) bool {
+	if ms.AggregationTemporality() != val.AggregationTemporality() {
+		return false
+	}
+	if !ms.DataPoints().Equal(val.DataPoints(), opts...) {
+		return false
+	}
+	return true
+}
@@ -32,6 +32,14 @@ func TestHistogram_CopyTo(t *testing.T) {
 	assert.Equal(t, orig, ms)
 }
 
+func TestHistogram_Equal(t *testing.T) {
+	ms := generateTestHistogram()
+	assert.True(t, ms.Equal(ms))
+	assert.True(t, ms.Equal(generateTestHistogram()))
+	assert.False(t, ms.Equal(NewHistogram()))
+	assert.False(t, NewHistogram().Equal(ms))
+}
+
 func TestHistogram_AggregationTemporality(t *testing.T) {
 	ms := NewHistogram()
 	assert.Equal(t, AggregationTemporality(otlpmetrics.AggregationTemporality(0)), ms.AggregationTemporality())
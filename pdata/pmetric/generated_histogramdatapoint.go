@@ -188,3 +188,41 @@ func (ms HistogramDataPoint) CopyTo(dest HistogramDataPoint) {
 	}
 
 }
+
+// Equal checks equality with another HistogramDataPoint, all the fields being deeply equal.
+func (ms HistogramDataPoint) Equal(val HistogramDataPoint, opts ...pcommon.CompareOption) bool {
+	if !ms.Attributes().Equal(val.Attributes(), opts...) {
+		return false
+	}
+	if ms.StartTimestamp() != val.StartTimestamp() {
+		return false
+	}
+	if ms.Timestamp() != val.Timestamp() {
+		return false
+	}
+	if ms.Count() != val.Count() {
+		return false
+	}
+	if ms.HasSum() != val.HasSum() || ms.Sum() != val.Sum() {
+		return false
+	}
+	if !ms.BucketCounts().Equal(val.BucketCounts()) {
+		return false
+	}
+	if !ms.ExplicitBounds().Equal(val.ExplicitBounds()) {
+		return false
+	}
+	if !ms.Exemplars().Equal(val.Exemplars(), opts...) {
+		return false
+	}
+	if ms.Flags() != val.Flags() {
+		return false
+	}
+	if ms.HasMin() != val.HasMin() || ms.Min() != val.Min() {
+		return false
+	}
+	if ms.HasMax() != val.HasMax() || ms.Max() != val.Max() {
+		return false
+	}
+	return true
+}
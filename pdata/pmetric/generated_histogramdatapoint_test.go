@@ -34,6 +34,14 @@ func TestHistogramDataPoint_CopyTo(t *testing.T) {
 	assert.Equal(t, orig, ms)
 }
 
+func TestHistogramDataPoint_Equal(t *testing.T) {
+	ms := generateTestHistogramDataPoint()
+	assert.True(t, ms.Equal(ms))
+	assert.True(t, ms.Equal(generateTestHistogramDataPoint()))
+	assert.False(t, ms.Equal(NewHistogramDataPoint()))
+	assert.False(t, NewHistogramDataPoint().Equal(ms))
+}
+
 func TestHistogramDataPoint_Attributes(t *testing.T) {
 	ms := NewHistogramDataPoint()
 	assert.Equal(t, pcommon.NewMap(), ms.Attributes())
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 func TestHistogramDataPointSlice(t *testing.T) {
@@ -108,6 +109,26 @@ func TestHistogramDataPointSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestHistogramDataPointSlice_Equal(t *testing.T) {
+	es := generateTestHistogramDataPointSlice()
+	assert.True(t, es.Equal(generateTestHistogramDataPointSlice()))
+	assert.False(t, es.Equal(NewHistogramDataPointSlice()))
+
+	other := generateTestHistogramDataPointSlice()
+	NewHistogramDataPoint().CopyTo(other.At(0))
+	assert.False(t, es.Equal(other))
+
+	// Test Equal ignoring the order of the elements
+	reordered := NewHistogramDataPointSlice()
+	for i := 1; i < other.Len(); i++ {
+		other.At(i).CopyTo(reordered.AppendEmpty())
+	}
+	other.At(0).CopyTo(reordered.AppendEmpty())
+	assert.False(t, other.Equal(reordered))
+	assert.True(t, other.Equal(reordered, pcommon.IgnoreSliceOrder()))
+	assert.False(t, es.Equal(reordered, pcommon.IgnoreSliceOrder()))
+}
+
 func TestHistogramDataPointSlice_Sort(t *testing.T) {
 	es := generateTestHistogramDataPointSlice()
 	es.Sort(func(a, b HistogramDataPoint) bool {
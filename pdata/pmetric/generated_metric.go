@@ -8,6 +8,7 @@ package pmetric
 
 import (
 	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 // Metric represents one metric as a collection of datapoints.
@@ -233,3 +234,42 @@ func (ms Metric) CopyTo(dest Metric) {
 	}
 
 }
+
+// Equal checks equality with another Metric, all the fields being deeply equal.
+func (ms Metric) Equal(val Metric, opts ...pcommon.CompareOption) bool {
+	if ms.Name() != val.Name() {
+		return false
+	}
+	if ms.Description() != val.Description() {
+		return false
+	}
+	if ms.Unit() != val.Unit() {
+		return false
+	}
+	if ms.Type() != val.Type() {
+		return false
+	}
+	switch ms.Type() {
+	case MetricTypeGauge:
+		if !ms.Gauge().Equal(val.Gauge(), opts...) {
+			return false
+		}
+	case MetricTypeSum:
+		if !ms.Sum().Equal(val.Sum(), opts...) {
+			return false
+		}
+	case MetricTypeHistogram:
+		if !ms.Histogram().Equal(val.Histogram(), opts...) {
+			return false
+		}
+	case MetricTypeExponentialHistogram:
+		if !ms.ExponentialHistogram().Equal(val.ExponentialHistogram(), opts...) {
+			return false
+		}
+	case MetricTypeSummary:
+		if !ms.Summary().Equal(val.Summary(), opts...) {
+			return false
+		}
+	}
+	return true
+}
@@ -32,6 +32,14 @@ func TestMetric_CopyTo(t *testing.T) {
 	assert.Equal(t, orig, ms)
 }
 
+func TestMetric_Equal(t *testing.T) {
+	ms := generateTestMetric()
+	assert.True(t, ms.Equal(ms))
+	assert.True(t, ms.Equal(generateTestMetric()))
+	assert.False(t, ms.Equal(NewMetric()))
+	assert.False(t, NewMetric().Equal(ms))
+}
+
 func TestMetric_Name(t *testing.T) {
 	ms := NewMetric()
 	assert.Equal(t, "", ms.Name())
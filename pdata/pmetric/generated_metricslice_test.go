@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 func TestMetricSlice(t *testing.T) {
@@ -108,6 +109,26 @@ func TestMetricSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestMetricSlice_Equal(t *testing.T) {
+	es := generateTestMetricSlice()
+	assert.True(t, es.Equal(generateTestMetricSlice()))
+	assert.False(t, es.Equal(NewMetricSlice()))
+
+	other := generateTestMetricSlice()
+	NewMetric().CopyTo(other.At(0))
+	assert.False(t, es.Equal(other))
+
+	// Test Equal ignoring the order of the elements
+	reordered := NewMetricSlice()
+	for i := 1; i < other.Len(); i++ {
+		other.At(i).CopyTo(reordered.AppendEmpty())
+	}
+	other.At(0).CopyTo(reordered.AppendEmpty())
+	assert.False(t, other.Equal(reordered))
+	assert.True(t, other.Equal(reordered, pcommon.IgnoreSliceOrder()))
+	assert.False(t, es.Equal(reordered, pcommon.IgnoreSliceOrder()))
+}
+
 func TestMetricSlice_Sort(t *testing.T) {
 	es := generateTestMetricSlice()
 	es.Sort(func(a, b Metric) bool {
@@ -133,3 +133,36 @@ func (ms NumberDataPoint) CopyTo(dest NumberDataPoint) {
 	ms.Exemplars().CopyTo(dest.Exemplars())
 	dest.SetFlags(ms.Flags())
 }
+
+// Equal checks equality with another NumberDataPoint, all the fields being deeply equal.
+func (ms NumberDataPoint) Equal(val NumberDataPoint, opts ...pcommon.CompareOption) bool {
+	if !ms.Attributes().Equal(val.Attributes(), opts...) {
+		return false
+	}
+	if ms.StartTimestamp() != val.StartTimestamp() {
+		return false
+	}
+	if ms.Timestamp() != val.Timestamp() {
+		return false
+	}
+	if ms.ValueType() != val.ValueType() {
+		return false
+	}
+	switch ms.ValueType() {
+	case NumberDataPointValueTypeDouble:
+		if ms.DoubleValue() != val.DoubleValue() {
+			return false
+		}
+	case NumberDataPointValueTypeInt:
+		if ms.IntValue() != val.IntValue() {
+			return false
+		}
+	}
+	if !ms.Exemplars().Equal(val.Exemplars(), opts...) {
+		return false
+	}
+	if ms.Flags() != val.Flags() {
+		return false
+	}
+	return true
+}
@@ -34,6 +34,14 @@ func TestNumberDataPoint_CopyTo(t *testing.T) {
 	assert.Equal(t, orig, ms)
 }
 
+func TestNumberDataPoint_Equal(t *testing.T) {
+	ms := generateTestNumberDataPoint()
+	assert.True(t, ms.Equal(ms))
+	assert.True(t, ms.Equal(generateTestNumberDataPoint()))
+	assert.False(t, ms.Equal(NewNumberDataPoint()))
+	assert.False(t, NewNumberDataPoint().Equal(ms))
+}
+
 func TestNumberDataPoint_Attributes(t *testing.T) {
 	ms := NewNumberDataPoint()
 	assert.Equal(t, pcommon.NewMap(), ms.Attributes())
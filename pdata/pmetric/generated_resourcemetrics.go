@@ -68,3 +68,17 @@ func (ms ResourceMetrics) CopyTo(dest ResourceMetrics) {
 	dest.SetSchemaUrl(ms.SchemaUrl())
 	ms.ScopeMetrics().CopyTo(dest.ScopeMetrics())
 }
+
+// Equal checks equality with another ResourceMetrics, all the fields being deeply equal.
+func (ms ResourceMetrics) Equal(val ResourceMetrics, opts ...pcommon.CompareOption) bool {
+	if !ms.Resource().Equal(val.Resource(), opts...) {
+		return false
+	}
+	if ms.SchemaUrl() != val.SchemaUrl() {
+		return false
+	}
+	if !ms.ScopeMetrics().Equal(val.ScopeMetrics(), opts...) {
+		return false
+	}
+	return true
+}
@@ -33,6 +33,14 @@ func TestResourceMetrics_CopyTo(t *testing.T) {
 	assert.Equal(t, orig, ms)
 }
 
+func TestResourceMetrics_Equal(t *testing.T) {
+	ms := generateTestResourceMetrics()
+	assert.True(t, ms.Equal(ms))
+	assert.True(t, ms.Equal(generateTestResourceMetrics()))
+	assert.False(t, ms.Equal(NewResourceMetrics()))
+	assert.False(t, NewResourceMetrics().Equal(ms))
+}
+
 func TestResourceMetrics_Resource(t *testing.T) {
 	ms := NewResourceMetrics()
 	internal.FillTestResource(internal.Resource(ms.Resource()))
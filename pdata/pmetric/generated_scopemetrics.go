@@ -68,3 +68,17 @@ func (ms ScopeMetrics) CopyTo(dest ScopeMetrics) {
 	dest.SetSchemaUrl(ms.SchemaUrl())
 	ms.Metrics().CopyTo(dest.Metrics())
 }
+
+// Equal checks equality with another ScopeMetrics, all the fields being deeply equal.
+func (ms ScopeMetrics) Equal(val ScopeMetrics, opts ...pcommon.CompareOption) bool {
+	if !ms.Scope().Equal(val.Scope(), opts...) {
+		return false
+	}
+	if ms.SchemaUrl() != val.SchemaUrl() {
+		return false
+	}
+	if !ms.Metrics().Equal(val.Metrics(), opts...) {
+		return false
+	}
+	return true
+}
@@ -33,6 +33,14 @@ func TestScopeMetrics_CopyTo(t *testing.T) {
 	assert.Equal(t, orig, ms)
 }
 
+func TestScopeMetrics_Equal(t *testing.T) {
+	ms := generateTestScopeMetrics()
+	assert.True(t, ms.Equal(ms))
+	assert.True(t, ms.Equal(generateTestScopeMetrics()))
+	assert.False(t, ms.Equal(NewScopeMetrics()))
+	assert.False(t, NewScopeMetrics().Equal(ms))
+}
+
 func TestScopeMetrics_Scope(t *testing.T) {
 	ms := NewScopeMetrics()
 	internal.FillTestInstrumentationScope(internal.InstrumentationScope(ms.Scope()))
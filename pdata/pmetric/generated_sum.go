@@ -8,6 +8,7 @@ package pmetric
 
 import (
 	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 // Sum represents the type of a numeric metric that is calculated as a sum of all reported measurements over a time interval.
@@ -71,3 +72,17 @@ func (ms Sum) CopyTo(dest Sum) {
 	dest.SetIsMonotonic(ms.IsMonotonic())
 	ms.DataPoints().CopyTo(dest.DataPoints())
 }
+
+// Equal checks equality with another Sum, all the fields being deeply equal.
+func (ms Sum) Equal(val Sum, opts ...pcommon.CompareOption) bool {
+	if ms.AggregationTemporality() != val.AggregationTemporality() {
+		return false
+	}
+	if ms.IsMonotonic() != val.IsMonotonic() {
+		return false
+	}
+	if !ms.DataPoints().Equal(val.DataPoints(), opts...) {
+		return false
+	}
+	return true
+}
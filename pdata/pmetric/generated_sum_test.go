@@ -32,6 +32,14 @@ func TestSum_CopyTo(t *testing.T) {
 	assert.Equal(t, orig, ms)
 }
 
+func TestSum_Equal(t *testing.T) {
+	ms := generateTestSum()
+	assert.True(t, ms.Equal(ms))
+	assert.True(t, ms.Equal(generateTestSum()))
+	assert.False(t, ms.Equal(NewSum()))
+	assert.False(t, NewSum().Equal(ms))
+}
+
 func TestSum_AggregationTemporality(t *testing.T) {
 	ms := NewSum()
 	assert.Equal(t, AggregationTemporality(otlpmetrics.AggregationTemporality(0)), ms.AggregationTemporality())
@@ -8,6 +8,7 @@ package pmetric
 
 import (
 	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 // Summary represents the type of a metric that is calculated by aggregating as a Summary of all reported double measurements over a time interval.
@@ -49,3 +50,11 @@ func (ms Summary) DataPoints() SummaryDataPointSlice {
 func (ms Summary) CopyTo(dest Summary) {
 	ms.DataPoints().CopyTo(dest.DataPoints())
 }
+
+// Equal checks equality with another Summary, all the fields being deeply equal.
+func (ms Summary) Equal(val Summary, opts ...pcommon.CompareOption) bool {
+	if !ms.DataPoints().Equal(val.DataPoints(), opts...) {
+		return false
+	}
+	return true
+}
@@ -30,6 +30,14 @@ func TestSummary_CopyTo(t *testing.T) {
 	assert.Equal(t, orig, ms)
 }
 
+func TestSummary_Equal(t *testing.T) {
+	ms := generateTestSummary()
+	assert.True(t, ms.Equal(ms))
+	assert.True(t, ms.Equal(generateTestSummary()))
+	assert.False(t, ms.Equal(NewSummary()))
+	assert.False(t, NewSummary().Equal(ms))
+}
+
 func TestSummary_DataPoints(t *testing.T) {
 	ms := NewSummary()
 	assert.Equal(t, NewSummaryDataPointSlice(), ms.DataPoints())
@@ -112,3 +112,29 @@ func (ms SummaryDataPoint) CopyTo(dest SummaryDataPoint) {
 	ms.QuantileValues().CopyTo(dest.QuantileValues())
 	dest.SetFlags(ms.Flags())
 }
+
+// Equal checks equality with another SummaryDataPoint, all the fields being deeply equal.
+func (ms SummaryDataPoint) Equal(val SummaryDataPoint, opts ...pcommon.CompareOption) bool {
+	if !ms.Attributes().Equal(val.Attributes(), opts...) {
+		return false
+	}
+	if ms.StartTimestamp() != val.StartTimestamp() {
+		return false
+	}
+	if ms.Timestamp() != val.Timestamp() {
+		return false
+	}
+	if ms.Count() != val.Count() {
+		return false
+	}
+	if ms.Sum() != val.Sum() {
+		return false
+	}
+	if !ms.QuantileValues().Equal(val.QuantileValues(), opts...) {
+		return false
+	}
+	if ms.Flags() != val.Flags() {
+		return false
+	}
+	return true
+}
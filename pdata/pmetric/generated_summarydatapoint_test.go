@@ -33,6 +33,14 @@ func TestSummaryDataPoint_CopyTo(t *testing.T) {
 	assert.Equal(t, orig, ms)
 }
 
+func TestSummaryDataPoint_Equal(t *testing.T) {
+	ms := generateTestSummaryDataPoint()
+	assert.True(t, ms.Equal(ms))
+	assert.True(t, ms.Equal(generateTestSummaryDataPoint()))
+	assert.False(t, ms.Equal(NewSummaryDataPoint()))
+	assert.False(t, NewSummaryDataPoint().Equal(ms))
+}
+
 func TestSummaryDataPoint_Attributes(t *testing.T) {
 	ms := NewSummaryDataPoint()
 	assert.Equal(t, pcommon.NewMap(), ms.Attributes())
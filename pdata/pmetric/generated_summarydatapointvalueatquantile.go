@@ -8,6 +8,7 @@ package pmetric
 
 import (
 	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 // SummaryDataPointValueAtQuantile is a quantile value within a Summary data point.
@@ -65,3 +66,14 @@ func (ms SummaryDataPointValueAtQuantile) CopyTo(dest SummaryDataPointValueAtQua
 	dest.SetQuantile(ms.Quantile())
 	dest.SetValue(ms.Value())
 }
+
+// Equal checks equality with another SummaryDataPointValueAtQuantile, all the fields being deeply equal.
+func (ms SummaryDataPointValueAtQuantile) Equal(val SummaryDataPointValueAtQuantile, opts ...pcommon.CompareOption) bool {
+	if ms.Quantile() != val.Quantile() {
+		return false
+	}
+	if ms.Value() != val.Value() {
+		return false
+	}
+	return true
+}
@@ -30,6 +30,14 @@ func TestSummaryDataPointValueAtQuantile_CopyTo(t *testing.T) {
 	assert.Equal(t, orig, ms)
 }
 
+func TestSummaryDataPointValueAtQuantile_Equal(t *testing.T) {
+	ms := generateTestSummaryDataPointValueAtQuantile()
+	assert.True(t, ms.Equal(ms))
+	assert.True(t, ms.Equal(generateTestSummaryDataPointValueAtQuantile()))
+	assert.False(t, ms.Equal(NewSummaryDataPointValueAtQuantile()))
+	assert.False(t, NewSummaryDataPointValueAtQuantile().Equal(ms))
+}
+
 func TestSummaryDataPointValueAtQuantile_Quantile(t *testing.T) {
 	ms := NewSummaryDataPointValueAtQuantile()
 	assert.Equal(t, float64(0.0), ms.Quantile())
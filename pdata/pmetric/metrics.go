@@ -6,6 +6,7 @@ package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
 import (
 	"go.opentelemetry.io/collector/pdata/internal"
 	otlpcollectormetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/metrics/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 // Metrics is the top-level struct that is propagated through the metrics pipeline.
@@ -30,6 +31,13 @@ func (ms Metrics) CopyTo(dest Metrics) {
 	ms.ResourceMetrics().CopyTo(dest.ResourceMetrics())
 }
 
+// Equal checks equality with another Metrics, all the resources being deeply equal.
+// Use pcommon.IgnoreSliceOrder and pcommon.IgnoreMapOrder to ignore the order of the resources,
+// scopes, records and attributes.
+func (ms Metrics) Equal(val Metrics, opts ...pcommon.CompareOption) bool {
+	return ms.ResourceMetrics().Equal(val.ResourceMetrics(), opts...)
+}
+
 // ResourceMetrics returns the ResourceMetricsSlice associated with this Metrics.
 func (ms Metrics) ResourceMetrics() ResourceMetricsSlice {
 	return newResourceMetricsSlice(&ms.getOrig().ResourceMetrics)
@@ -50,6 +58,26 @@ func (ms Metrics) MetricCount() int {
 	return metricCount
 }
 
+// RangeMetrics calls f sequentially for each metric, with the resource and the scope it belongs to. If f returns
+// false, RangeMetrics stops the iteration. The metrics are not copied: f may modify them, but must not add or
+// remove resources, scopes or metrics while iterating.
+func (ms Metrics) RangeMetrics(f func(rm ResourceMetrics, sm ScopeMetrics, m Metric) bool) {
+	rms := ms.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			metrics := sm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				if !f(rm, sm, metrics.At(k)) {
+					return
+				}
+			}
+		}
+	}
+}
+
 // DataPointCount calculates the total number of data points.
 func (ms Metrics) DataPointCount() (dataPointCount int) {
 	rms := ms.ResourceMetrics()
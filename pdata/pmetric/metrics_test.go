@@ -82,6 +82,45 @@ func TestMetricCount(t *testing.T) {
 	assert.EqualValues(t, 6, md.MetricCount())
 }
 
+func TestRangeMetrics(t *testing.T) {
+	md := NewMetrics()
+	md.RangeMetrics(func(ResourceMetrics, ScopeMetrics, Metric) bool {
+		t.Fatal("no metrics to iterate")
+		return true
+	})
+
+	rs := md.ResourceMetrics().AppendEmpty()
+	rs.Resource().Attributes().PutStr("resource", "r0")
+	rs.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("a")
+	// An empty scope is skipped.
+	rs.ScopeMetrics().AppendEmpty()
+	recs := rs.ScopeMetrics().AppendEmpty().Metrics()
+	recs.AppendEmpty().SetName("b")
+	recs.AppendEmpty().SetName("c")
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("d")
+
+	var names []string
+	md.RangeMetrics(func(r ResourceMetrics, s ScopeMetrics, rec Metric) bool {
+		names = append(names, rec.Name())
+		if rec.Name() == "b" {
+			assert.Equal(t, rs, r)
+			assert.Equal(t, rs.ScopeMetrics().At(2), s)
+			// The metrics are not copied.
+			rec.SetName("modified")
+		}
+		return true
+	})
+	assert.Equal(t, []string{"a", "b", "c", "d"}, names)
+	assert.Equal(t, "modified", recs.At(0).Name())
+
+	names = nil
+	md.RangeMetrics(func(_ ResourceMetrics, _ ScopeMetrics, rec Metric) bool {
+		names = append(names, rec.Name())
+		return len(names) < 2
+	})
+	assert.Equal(t, []string{"a", "modified"}, names)
+}
+
 func TestMetricCountWithEmpty(t *testing.T) {
 	assert.EqualValues(t, 0, generateMetricsEmptyResource().MetricCount())
 	assert.EqualValues(t, 0, generateMetricsEmptyInstrumentation().MetricCount())
@@ -931,3 +970,22 @@ func generateMetricsEmptyDataPoints() Metrics {
 		},
 	})
 }
+
+func TestMetricsEqual(t *testing.T) {
+	metrics := NewMetrics()
+	fillTestResourceMetricsSlice(metrics.ResourceMetrics())
+	other := NewMetrics()
+	metrics.CopyTo(other)
+	assert.True(t, metrics.Equal(other))
+
+	other.ResourceMetrics().AppendEmpty()
+	assert.False(t, metrics.Equal(other))
+
+	reordered := NewMetrics()
+	other.ResourceMetrics().At(other.ResourceMetrics().Len() - 1).CopyTo(reordered.ResourceMetrics().AppendEmpty())
+	for i := 0; i < other.ResourceMetrics().Len()-1; i++ {
+		other.ResourceMetrics().At(i).CopyTo(reordered.ResourceMetrics().AppendEmpty())
+	}
+	assert.False(t, other.Equal(reordered))
+	assert.True(t, other.Equal(reordered, pcommon.IgnoreSliceOrder()))
+}
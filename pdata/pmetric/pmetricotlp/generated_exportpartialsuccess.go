@@ -8,6 +8,7 @@ package pmetricotlp
 
 import (
 	otlpcollectormetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/metrics/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 // ExportPartialSuccess represents the details of a partially successful export request.
@@ -65,3 +66,14 @@ func (ms ExportPartialSuccess) CopyTo(dest ExportPartialSuccess) {
 	dest.SetRejectedDataPoints(ms.RejectedDataPoints())
 	dest.SetErrorMessage(ms.ErrorMessage())
 }
+
+// Equal checks equality with another ExportPartialSuccess, all the fields being deeply equal.
+func (ms ExportPartialSuccess) Equal(val ExportPartialSuccess, opts ...pcommon.CompareOption) bool {
+	if ms.RejectedDataPoints() != val.RejectedDataPoints() {
+		return false
+	}
+	if ms.ErrorMessage() != val.ErrorMessage() {
+		return false
+	}
+	return true
+}
@@ -5,6 +5,7 @@ package pmetricotlp // import "go.opentelemetry.io/collector/pdata/pmetric/pmetr
 
 import (
 	"bytes"
+	"sync"
 
 	"go.opentelemetry.io/collector/pdata/internal"
 	otlpcollectormetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/metrics/v1"
@@ -64,3 +65,29 @@ func (ms ExportRequest) UnmarshalJSON(data []byte) error {
 func (ms ExportRequest) Metrics() pmetric.Metrics {
 	return pmetric.Metrics(internal.NewMetrics(ms.orig))
 }
+
+var requestPool = sync.Pool{
+	New: func() any {
+		return &otlpcollectormetrics.ExportMetricsServiceRequest{}
+	},
+}
+
+// AcquireExportRequest returns an empty ExportRequest from a pool, reusing the memory of the requests
+// given back with ReleaseExportRequest instead of allocating a new request for every received request.
+func AcquireExportRequest() ExportRequest {
+	return ExportRequest{orig: requestPool.Get().(*otlpcollectormetrics.ExportMetricsServiceRequest)}
+}
+
+// ReleaseExportRequest gives back to the pool of AcquireExportRequest a request that is not used anymore.
+// The request, and the pmetric.Metrics returned by its Metrics method, must not be used once released:
+// the data still used afterwards, e.g. passed to a consumer that may keep it, must be moved out of
+// the request before, with ResourceMetricsSlice.MoveAndAppendTo.
+func ReleaseExportRequest(req ExportRequest) {
+	// Keep the capacity of the slice, the elements are cleared to not retain the moved data.
+	rs := req.orig.ResourceMetrics
+	for i := range rs {
+		rs[i] = nil
+	}
+	*req.orig = otlpcollectormetrics.ExportMetricsServiceRequest{ResourceMetrics: rs[:0]}
+	requestPool.Put(req.orig)
+}
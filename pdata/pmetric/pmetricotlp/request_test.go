@@ -9,6 +9,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
 )
 
 var _ json.Unmarshaler = ExportRequest{}
@@ -49,3 +52,26 @@ func TestRequestJSON(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, strings.Join(strings.Fields(string(metricsRequestJSON)), ""), string(got))
 }
+
+func TestAcquireReleaseExportRequest(t *testing.T) {
+	req := AcquireExportRequest()
+	assert.Equal(t, 0, req.Metrics().MetricCount())
+	req.Metrics().ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("test_metric")
+	data, err := req.MarshalProto()
+	require.NoError(t, err)
+
+	// The data moved out of the request is not changed once the request is released.
+	moved := pmetric.NewMetrics()
+	req.Metrics().ResourceMetrics().MoveAndAppendTo(moved.ResourceMetrics())
+	ReleaseExportRequest(req)
+	assert.Equal(t, 1, moved.MetricCount())
+
+	for i := 0; i < 10; i++ {
+		req = AcquireExportRequest()
+		assert.Equal(t, 0, req.Metrics().MetricCount())
+		require.NoError(t, req.UnmarshalProto(data))
+		assert.Equal(t, 1, req.Metrics().MetricCount())
+		ReleaseExportRequest(req)
+	}
+	assert.Equal(t, 1, moved.MetricCount())
+}
@@ -0,0 +1,7 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pmetricvalidation checks pmetric.Metrics for data violating the OpenTelemetry
+// metrics data model, e.g. decreasing monotonic sums or inconsistent histograms, and
+// reports the violations as structured findings.
+package pmetricvalidation // import "go.opentelemetry.io/collector/pdata/pmetric/pmetricvalidation"
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetricvalidation // import "go.opentelemetry.io/collector/pdata/pmetric/pmetricvalidation"
+
+import (
+	"fmt"
+)
+
+// FindingKind is the kind of violation reported by a Finding.
+type FindingKind int32
+
+const (
+	// FindingMissingTimestamp is reported for a data point without timestamp.
+	FindingMissingTimestamp FindingKind = iota
+	// FindingStartAfterEnd is reported for a data point with a start timestamp after its timestamp.
+	FindingStartAfterEnd
+	// FindingUnspecifiedTemporality is reported for a sum or histogram without aggregation temporality.
+	FindingUnspecifiedTemporality
+	// FindingOutOfOrder is reported for a data point older than the previous data point of the same series.
+	FindingOutOfOrder
+	// FindingOverlappingDelta is reported for a delta data point starting before the end of the previous
+	// data point of the same series.
+	FindingOverlappingDelta
+	// FindingNegativeDelta is reported for a negative delta data point of a monotonic sum.
+	FindingNegativeDelta
+	// FindingMonotonicDecrease is reported for a cumulative data point of a monotonic sum, or for the count
+	// of a cumulative histogram, lower than the previous data point of the same series without reset.
+	FindingMonotonicDecrease
+	// FindingHistogramCount is reported for a histogram data point whose count is not the sum of its buckets.
+	FindingHistogramCount
+	// FindingHistogramBuckets is reported for a histogram data point with inconsistent buckets, e.g. a number
+	// of bucket counts not matching the explicit bounds, or bounds not sorted.
+	FindingHistogramBuckets
+	// FindingMinMax is reported for a histogram data point whose min is greater than its max.
+	FindingMinMax
+	// FindingQuantile is reported for a summary data point with a quantile outside of [0, 1], or quantile
+	// values decreasing with the quantile.
+	FindingQuantile
+)
+
+// String returns the string representation of the FindingKind.
+func (k FindingKind) String() string {
+	switch k {
+	case FindingMissingTimestamp:
+		return "MissingTimestamp"
+	case FindingStartAfterEnd:
+		return "StartAfterEnd"
+	case FindingUnspecifiedTemporality:
+		return "UnspecifiedTemporality"
+	case FindingOutOfOrder:
+		return "OutOfOrder"
+	case FindingOverlappingDelta:
+		return "OverlappingDelta"
+	case FindingNegativeDelta:
+		return "NegativeDelta"
+	case FindingMonotonicDecrease:
+		return "MonotonicDecrease"
+	case FindingHistogramCount:
+		return "HistogramCount"
+	case FindingHistogramBuckets:
+		return "HistogramBuckets"
+	case FindingMinMax:
+		return "MinMax"
+	case FindingQuantile:
+		return "Quantile"
+	}
+	return ""
+}
+
+// Finding is a violation of the metrics data model found in a metric. The indexes locate the metric,
+// and the data point when the violation is specific to a data point, in the validated pmetric.Metrics.
+type Finding struct {
+	Kind          FindingKind
+	ResourceIndex int
+	ScopeIndex    int
+	MetricIndex   int
+	// DataPointIndex is the index of the data point in the metric, -1 if the finding concerns the metric.
+	DataPointIndex int
+	MetricName     string
+	Message        string
+}
+
+// String returns a human-readable description of the Finding.
+func (f Finding) String() string {
+	if f.DataPointIndex < 0 {
+		return fmt.Sprintf("%s: metric %q: %s", f.Kind, f.MetricName, f.Message)
+	}
+	return fmt.Sprintf("%s: metric %q, data point %d: %s", f.Kind, f.MetricName, f.DataPointIndex, f.Message)
+}
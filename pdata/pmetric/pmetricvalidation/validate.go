@@ -0,0 +1,227 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetricvalidation // import "go.opentelemetry.io/collector/pdata/pmetric/pmetricvalidation"
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// Validate checks the metrics for violations of the metrics data model, and returns the findings,
+// nil if the metrics are valid. The data points of a metric are identified as a series by their
+// attributes, the order of the series being checked in the order of the data points.
+func Validate(md pmetric.Metrics) []Finding {
+	v := &validator{}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				m := ms.At(k)
+				v.loc = Finding{ResourceIndex: i, ScopeIndex: j, MetricIndex: k, MetricName: m.Name()}
+				v.series = map[string]seriesState{}
+				v.validateMetric(m)
+			}
+		}
+	}
+	return v.findings
+}
+
+// seriesState is the state of the last data point of a series.
+type seriesState struct {
+	start pcommon.Timestamp
+	end   pcommon.Timestamp
+	value float64
+}
+
+type validator struct {
+	findings []Finding
+	loc      Finding
+	series   map[string]seriesState
+}
+
+func (v *validator) report(kind FindingKind, dataPointIndex int, format string, args ...any) {
+	f := v.loc
+	f.Kind = kind
+	f.DataPointIndex = dataPointIndex
+	f.Message = fmt.Sprintf(format, args...)
+	v.findings = append(v.findings, f)
+}
+
+func (v *validator) validateMetric(m pmetric.Metric) {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			v.validatePoint(i, dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), pmetric.AggregationTemporalityUnspecified, false, numberValue(dp))
+		}
+	case pmetric.MetricTypeSum:
+		sum := m.Sum()
+		v.validateTemporality(sum.AggregationTemporality())
+		dps := sum.DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			value := numberValue(dp)
+			if sum.IsMonotonic() && sum.AggregationTemporality() == pmetric.AggregationTemporalityDelta && value < 0 {
+				v.report(FindingNegativeDelta, i, "negative value %v for a monotonic sum", value)
+			}
+			v.validatePoint(i, dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), sum.AggregationTemporality(), sum.IsMonotonic(), value)
+		}
+	case pmetric.MetricTypeHistogram:
+		hist := m.Histogram()
+		v.validateTemporality(hist.AggregationTemporality())
+		dps := hist.DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			v.validateHistogramPoint(i, dp)
+			v.validatePoint(i, dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), hist.AggregationTemporality(), true, float64(dp.Count()))
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		hist := m.ExponentialHistogram()
+		v.validateTemporality(hist.AggregationTemporality())
+		dps := hist.DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			v.validateExponentialHistogramPoint(i, dp)
+			v.validatePoint(i, dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), hist.AggregationTemporality(), true, float64(dp.Count()))
+		}
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			v.validateSummaryPoint(i, dp)
+			v.validatePoint(i, dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), pmetric.AggregationTemporalityUnspecified, false, float64(dp.Count()))
+		}
+	}
+}
+
+func (v *validator) validateTemporality(temporality pmetric.AggregationTemporality) {
+	if temporality == pmetric.AggregationTemporalityUnspecified {
+		v.report(FindingUnspecifiedTemporality, -1, "aggregation temporality must be delta or cumulative")
+	}
+}
+
+// validatePoint checks the timestamps of the data point, and compares it with the previous data point
+// of its series. The value is only compared for monotonic cumulative series, the series being reset
+// when the start timestamp changes.
+func (v *validator) validatePoint(i int, attrs pcommon.Map, start, end pcommon.Timestamp,
+	temporality pmetric.AggregationTemporality, monotonic bool, value float64) {
+	if end == 0 {
+		v.report(FindingMissingTimestamp, i, "timestamp is not set")
+	}
+	if start > end {
+		v.report(FindingStartAfterEnd, i, "start timestamp %v is after timestamp %v", start, end)
+	}
+
+	key := attributesKey(attrs)
+	prev, ok := v.series[key]
+	v.series[key] = seriesState{start: start, end: end, value: value}
+	if !ok {
+		return
+	}
+	if end < prev.end {
+		v.report(FindingOutOfOrder, i, "timestamp %v is before the timestamp %v of the previous data point", end, prev.end)
+		return
+	}
+	switch temporality {
+	case pmetric.AggregationTemporalityDelta:
+		if start < prev.end {
+			v.report(FindingOverlappingDelta, i, "start timestamp %v is before the timestamp %v of the previous data point", start, prev.end)
+		}
+	case pmetric.AggregationTemporalityCumulative:
+		if monotonic && start == prev.start && value < prev.value {
+			v.report(FindingMonotonicDecrease, i, "value %v is lower than the value %v of the previous data point", value, prev.value)
+		}
+	}
+}
+
+func (v *validator) validateHistogramPoint(i int, dp pmetric.HistogramDataPoint) {
+	bounds := dp.ExplicitBounds()
+	counts := dp.BucketCounts()
+	if counts.Len() > 0 {
+		if counts.Len() != bounds.Len()+1 {
+			v.report(FindingHistogramBuckets, i, "%d bucket counts for %d explicit bounds", counts.Len(), bounds.Len())
+		}
+		var total uint64
+		for j := 0; j < counts.Len(); j++ {
+			total += counts.At(j)
+		}
+		if total != dp.Count() {
+			v.report(FindingHistogramCount, i, "count %d is not the sum %d of the bucket counts", dp.Count(), total)
+		}
+	}
+	for j := 1; j < bounds.Len(); j++ {
+		if bounds.At(j) <= bounds.At(j-1) {
+			v.report(FindingHistogramBuckets, i, "explicit bounds are not strictly increasing")
+			break
+		}
+	}
+	if dp.HasMin() && dp.HasMax() && dp.Min() > dp.Max() {
+		v.report(FindingMinMax, i, "min %v is greater than max %v", dp.Min(), dp.Max())
+	}
+}
+
+func (v *validator) validateExponentialHistogramPoint(i int, dp pmetric.ExponentialHistogramDataPoint) {
+	total := dp.ZeroCount() + bucketsTotal(dp.Positive()) + bucketsTotal(dp.Negative())
+	if total != dp.Count() {
+		v.report(FindingHistogramCount, i, "count %d is not the sum %d of the zero count and the bucket counts", dp.Count(), total)
+	}
+	if dp.HasMin() && dp.HasMax() && dp.Min() > dp.Max() {
+		v.report(FindingMinMax, i, "min %v is greater than max %v", dp.Min(), dp.Max())
+	}
+}
+
+func (v *validator) validateSummaryPoint(i int, dp pmetric.SummaryDataPoint) {
+	qvs := dp.QuantileValues()
+	for j := 0; j < qvs.Len(); j++ {
+		qv := qvs.At(j)
+		if qv.Quantile() < 0 || qv.Quantile() > 1 || math.IsNaN(qv.Quantile()) {
+			v.report(FindingQuantile, i, "quantile %v is not in [0, 1]", qv.Quantile())
+			continue
+		}
+		if j > 0 && qv.Quantile() > qvs.At(j-1).Quantile() && qv.Value() < qvs.At(j-1).Value() {
+			v.report(FindingQuantile, i, "value %v of quantile %v is lower than the value %v of quantile %v",
+				qv.Value(), qv.Quantile(), qvs.At(j-1).Value(), qvs.At(j-1).Quantile())
+		}
+	}
+}
+
+func bucketsTotal(buckets pmetric.ExponentialHistogramDataPointBuckets) uint64 {
+	var total uint64
+	counts := buckets.BucketCounts()
+	for j := 0; j < counts.Len(); j++ {
+		total += counts.At(j)
+	}
+	return total
+}
+
+func numberValue(dp pmetric.NumberDataPoint) float64 {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return float64(dp.IntValue())
+	}
+	return dp.DoubleValue()
+}
+
+// attributesKey returns a key identifying the series of the attributes, independent of their order.
+func attributesKey(attrs pcommon.Map) string {
+	keys := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, _ pcommon.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	sort.Strings(keys)
+	var b strings.Builder
+	for _, k := range keys {
+		val, _ := attrs.Get(k)
+		fmt.Fprintf(&b, "%q=%q;", k, val.AsString())
+	}
+	return b.String()
+}
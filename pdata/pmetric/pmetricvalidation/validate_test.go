@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetricvalidation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func newMetric(md pmetric.Metrics) pmetric.Metric {
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("test")
+	return m
+}
+
+func appendNumber(dps pmetric.NumberDataPointSlice, start, end pcommon.Timestamp, value int64) pmetric.NumberDataPoint {
+	dp := dps.AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(end)
+	dp.SetIntValue(value)
+	return dp
+}
+
+func kinds(findings []Finding) []FindingKind {
+	var ks []FindingKind
+	for _, f := range findings {
+		ks = append(ks, f.Kind)
+	}
+	return ks
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		metric   func(m pmetric.Metric)
+		expected []FindingKind
+	}{
+		{
+			name: "valid_cumulative_sum",
+			metric: func(m pmetric.Metric) {
+				sum := m.SetEmptySum()
+				sum.SetIsMonotonic(true)
+				sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				appendNumber(sum.DataPoints(), 1, 2, 10)
+				appendNumber(sum.DataPoints(), 1, 3, 20)
+				// Reset of the series.
+				appendNumber(sum.DataPoints(), 4, 5, 1)
+				// Another series.
+				appendNumber(sum.DataPoints(), 1, 3, 5).Attributes().PutStr("k", "v")
+			},
+		},
+		{
+			name: "monotonic_decrease",
+			metric: func(m pmetric.Metric) {
+				sum := m.SetEmptySum()
+				sum.SetIsMonotonic(true)
+				sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				appendNumber(sum.DataPoints(), 1, 2, 10)
+				appendNumber(sum.DataPoints(), 1, 3, 5)
+			},
+			expected: []FindingKind{FindingMonotonicDecrease},
+		},
+		{
+			name: "non_monotonic_decrease",
+			metric: func(m pmetric.Metric) {
+				sum := m.SetEmptySum()
+				sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				appendNumber(sum.DataPoints(), 1, 2, 10)
+				appendNumber(sum.DataPoints(), 1, 3, 5)
+			},
+		},
+		{
+			name: "delta_sum",
+			metric: func(m pmetric.Metric) {
+				sum := m.SetEmptySum()
+				sum.SetIsMonotonic(true)
+				sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+				appendNumber(sum.DataPoints(), 1, 3, 10)
+				appendNumber(sum.DataPoints(), 2, 4, 5)
+				appendNumber(sum.DataPoints(), 4, 5, -1)
+			},
+			expected: []FindingKind{FindingOverlappingDelta, FindingNegativeDelta},
+		},
+		{
+			name: "timestamps",
+			metric: func(m pmetric.Metric) {
+				gauge := m.SetEmptyGauge()
+				appendNumber(gauge.DataPoints(), 0, 0, 1)
+				appendNumber(gauge.DataPoints(), 5, 3, 1).Attributes().PutStr("k", "v")
+				appendNumber(gauge.DataPoints(), 0, 10, 1)
+				appendNumber(gauge.DataPoints(), 0, 9, 1)
+			},
+			expected: []FindingKind{FindingMissingTimestamp, FindingStartAfterEnd, FindingOutOfOrder},
+		},
+		{
+			name: "unspecified_temporality",
+			metric: func(m pmetric.Metric) {
+				appendNumber(m.SetEmptySum().DataPoints(), 1, 2, 10)
+			},
+			expected: []FindingKind{FindingUnspecifiedTemporality},
+		},
+		{
+			name: "histogram",
+			metric: func(m pmetric.Metric) {
+				hist := m.SetEmptyHistogram()
+				hist.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				dp := hist.DataPoints().AppendEmpty()
+				dp.SetTimestamp(2)
+				dp.SetCount(6)
+				dp.ExplicitBounds().FromRaw([]float64{1, 2})
+				dp.BucketCounts().FromRaw([]uint64{1, 2, 3})
+				dp.SetMin(0.5)
+				dp.SetMax(3)
+
+				dp = hist.DataPoints().AppendEmpty()
+				dp.SetTimestamp(3)
+				dp.SetCount(5)
+				dp.ExplicitBounds().FromRaw([]float64{2, 1})
+				dp.BucketCounts().FromRaw([]uint64{1, 2})
+				dp.SetMin(3)
+				dp.SetMax(1)
+			},
+			expected: []FindingKind{FindingHistogramBuckets, FindingHistogramCount, FindingHistogramBuckets, FindingMinMax, FindingMonotonicDecrease},
+		},
+		{
+			name: "exponential_histogram",
+			metric: func(m pmetric.Metric) {
+				hist := m.SetEmptyExponentialHistogram()
+				hist.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+				dp := hist.DataPoints().AppendEmpty()
+				dp.SetTimestamp(2)
+				dp.SetCount(6)
+				dp.SetZeroCount(1)
+				dp.Positive().BucketCounts().FromRaw([]uint64{1, 2})
+				dp.Negative().BucketCounts().FromRaw([]uint64{1})
+
+				dp = hist.DataPoints().AppendEmpty()
+				dp.SetStartTimestamp(2)
+				dp.SetTimestamp(3)
+				dp.SetCount(2)
+				dp.SetMin(2)
+				dp.SetMax(1)
+			},
+			expected: []FindingKind{FindingHistogramCount, FindingHistogramCount, FindingMinMax},
+		},
+		{
+			name: "summary",
+			metric: func(m pmetric.Metric) {
+				dp := m.SetEmptySummary().DataPoints().AppendEmpty()
+				dp.SetTimestamp(2)
+				qv := dp.QuantileValues().AppendEmpty()
+				qv.SetQuantile(0.5)
+				qv.SetValue(10)
+				qv = dp.QuantileValues().AppendEmpty()
+				qv.SetQuantile(0.9)
+				qv.SetValue(5)
+				qv = dp.QuantileValues().AppendEmpty()
+				qv.SetQuantile(math.NaN())
+				qv = dp.QuantileValues().AppendEmpty()
+				qv.SetQuantile(1.5)
+			},
+			expected: []FindingKind{FindingQuantile, FindingQuantile, FindingQuantile},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := pmetric.NewMetrics()
+			tt.metric(newMetric(md))
+			assert.Equal(t, tt.expected, kinds(Validate(md)))
+		})
+	}
+}
+
+func TestValidateLocation(t *testing.T) {
+	md := pmetric.NewMetrics()
+	newMetric(md).SetEmptyGauge().DataPoints().AppendEmpty().SetTimestamp(1)
+	sms := md.ResourceMetrics().AppendEmpty().ScopeMetrics()
+	sms.AppendEmpty()
+	ms := sms.AppendEmpty().Metrics()
+	ms.AppendEmpty().SetEmptyGauge()
+	m := ms.AppendEmpty()
+	m.SetName("sum")
+	appendNumber(m.SetEmptySum().DataPoints(), 3, 2, 1)
+
+	findings := Validate(md)
+	assert.Equal(t, []Finding{
+		{Kind: FindingUnspecifiedTemporality, ResourceIndex: 1, ScopeIndex: 1, MetricIndex: 1, DataPointIndex: -1, MetricName: "sum", Message: "aggregation temporality must be delta or cumulative"},
+		{Kind: FindingStartAfterEnd, ResourceIndex: 1, ScopeIndex: 1, MetricIndex: 1, DataPointIndex: 0, MetricName: "sum", Message: "start timestamp 1970-01-01 00:00:00.000000003 +0000 UTC is after timestamp 1970-01-01 00:00:00.000000002 +0000 UTC"},
+	}, findings)
+	assert.Equal(t, `UnspecifiedTemporality: metric "sum": aggregation temporality must be delta or cumulative`, findings[0].String())
+	assert.Equal(t, `StartAfterEnd: metric "sum", data point 0: `+findings[1].Message, findings[1].String())
+
+	assert.Nil(t, Validate(pmetric.NewMetrics()))
+}
+
+func TestFindingKindString(t *testing.T) {
+	assert.Equal(t, "MissingTimestamp", FindingMissingTimestamp.String())
+	assert.Equal(t, "MonotonicDecrease", FindingMonotonicDecrease.String())
+	assert.Equal(t, "Quantile", FindingQuantile.String())
+	assert.Equal(t, "", FindingKind(100).String())
+}
@@ -68,3 +68,17 @@ func (ms ResourceSpans) CopyTo(dest ResourceSpans) {
 	dest.SetSchemaUrl(ms.SchemaUrl())
 	ms.ScopeSpans().CopyTo(dest.ScopeSpans())
 }
+
+// Equal checks equality with another ResourceSpans, all the fields being deeply equal.
+func (ms ResourceSpans) Equal(val ResourceSpans, opts ...pcommon.CompareOption) bool {
+	if !ms.Resource().Equal(val.Resource(), opts...) {
+		return false
+	}
+	if ms.SchemaUrl() != val.SchemaUrl() {
+		return false
+	}
+	if !ms.ScopeSpans().Equal(val.ScopeSpans(), opts...) {
+		return false
+	}
+	return true
+}
@@ -33,6 +33,14 @@ func TestResourceSpans_CopyTo(t *testing.T) {
 	assert.Equal(t, orig, ms)
 }
 
+func TestResourceSpans_Equal(t *testing.T) {
+	ms := generateTestResourceSpans()
+	assert.True(t, ms.Equal(ms))
+	assert.True(t, ms.Equal(generateTestResourceSpans()))
+	assert.False(t, ms.Equal(NewResourceSpans()))
+	assert.False(t, NewResourceSpans().Equal(ms))
+}
+
 func TestResourceSpans_Resource(t *testing.T) {
 	ms := NewResourceSpans()
 	internal.FillTestResource(internal.Resource(ms.Resource()))
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 func TestResourceSpansSlice(t *testing.T) {
@@ -108,6 +109,26 @@ func TestResourceSpansSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestResourceSpansSlice_Equal(t *testing.T) {
+	es := generateTestResourceSpansSlice()
+	assert.True(t, es.Equal(generateTestResourceSpansSlice()))
+	assert.False(t, es.Equal(NewResourceSpansSlice()))
+
+	other := generateTestResourceSpansSlice()
+	NewResourceSpans().CopyTo(other.At(0))
+	assert.False(t, es.Equal(other))
+
+	// Test Equal ignoring the order of the elements
+	reordered := NewResourceSpansSlice()
+	for i := 1; i < other.Len(); i++ {
+		other.At(i).CopyTo(reordered.AppendEmpty())
+	}
+	other.At(0).CopyTo(reordered.AppendEmpty())
+	assert.False(t, other.Equal(reordered))
+	assert.True(t, other.Equal(reordered, pcommon.IgnoreSliceOrder()))
+	assert.False(t, es.Equal(reordered, pcommon.IgnoreSliceOrder()))
+}
+
 func TestResourceSpansSlice_Sort(t *testing.T) {
 	es := generateTestResourceSpansSlice()
 	es.Sort(func(a, b ResourceSpans) bool {
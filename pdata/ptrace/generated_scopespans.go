@@ -68,3 +68,17 @@ func (ms ScopeSpans) CopyTo(dest ScopeSpans) {
 	dest.SetSchemaUrl(ms.SchemaUrl())
 	ms.Spans().CopyTo(dest.Spans())
 }
+
+// Equal checks equality with another ScopeSpans, all the fields being deeply equal.
+func (ms ScopeSpans) Equal(val ScopeSpans, opts ...pcommon.CompareOption) bool {
+	if !ms.Scope().Equal(val.Scope(), opts...) {
+		return false
+	}
+	if ms.SchemaUrl() != val.SchemaUrl() {
+		return false
+	}
+	if !ms.Spans().Equal(val.Spans(), opts...) {
+		return false
+	}
+	return true
+}
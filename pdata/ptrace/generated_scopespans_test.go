@@ -33,6 +33,14 @@ func TestScopeSpans_CopyTo(t *testing.T) {
 	assert.Equal(t, orig, ms)
 }
 
+func TestScopeSpans_Equal(t *testing.T) {
+	ms := generateTestScopeSpans()
+	assert.True(t, ms.Equal(ms))
+	assert.True(t, ms.Equal(generateTestScopeSpans()))
+	assert.False(t, ms.Equal(NewScopeSpans()))
+	assert.False(t, NewScopeSpans().Equal(ms))
+}
+
 func TestScopeSpans_Scope(t *testing.T) {
 	ms := NewScopeSpans()
 	internal.FillTestInstrumentationScope(internal.InstrumentationScope(ms.Scope()))
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 func TestScopeSpansSlice(t *testing.T) {
@@ -108,6 +109,26 @@ func TestScopeSpansSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestScopeSpansSlice_Equal(t *testing.T) {
+	es := generateTestScopeSpansSlice()
+	assert.True(t, es.Equal(generateTestScopeSpansSlice()))
+	assert.False(t, es.Equal(NewScopeSpansSlice()))
+
+	other := generateTestScopeSpansSlice()
+	NewScopeSpans().CopyTo(other.At(0))
+	assert.False(t, es.Equal(other))
+
+	// Test Equal ignoring the order of the elements
+	reordered := NewScopeSpansSlice()
+	for i := 1; i < other.Len(); i++ {
+		other.At(i).CopyTo(reordered.AppendEmpty())
+	}
+	other.At(0).CopyTo(reordered.AppendEmpty())
+	assert.False(t, other.Equal(reordered))
+	assert.True(t, other.Equal(reordered, pcommon.IgnoreSliceOrder()))
+	assert.False(t, es.Equal(reordered, pcommon.IgnoreSliceOrder()))
+}
+
 func TestScopeSpansSlice_Sort(t *testing.T) {
 	es := generateTestScopeSpansSlice()
 	es.Sort(func(a, b ScopeSpans) bool {
@@ -187,3 +187,53 @@ func (ms Span) CopyTo(dest Span) {
 	dest.SetDroppedLinksCount(ms.DroppedLinksCount())
 	ms.Status().CopyTo(dest.Status())
 }
+
+// Equal checks equality with another Span, all the fields being deeply equal.
+func (ms Span) Equal(val Span, opts ...pcommon.CompareOption) bool {
+	if ms.TraceID() != val.TraceID() {
+		return false
+	}
+	if ms.SpanID() != val.SpanID() {
+		return false
+	}
+	if !ms.TraceState().Equal(val.TraceState(), opts...) {
+		return false
+	}
+	if ms.ParentSpanID() != val.ParentSpanID() {
+		return false
+	}
+	if ms.Name() != val.Name() {
+		return false
+	}
+	if ms.Kind() != val.Kind() {
+		return false
+	}
+	if ms.StartTimestamp() != val.StartTimestamp() {
+		return false
+	}
+	if ms.EndTimestamp() != val.EndTimestamp() {
+		return false
+	}
+	if !ms.Attributes().Equal(val.Attributes(), opts...) {
+		return false
+	}
+	if ms.DroppedAttributesCount() != val.DroppedAttributesCount() {
+		return false
+	}
+	if !ms.Events().Equal(val.Events(), opts...) {
+		return false
+	}
+	if ms.DroppedEventsCount() != val.DroppedEventsCount() {
+		return false
+	}
+	if !ms.Links().Equal(val.Links(), opts...) {
+		return false
+	}
+	if ms.DroppedLinksCount() != val.DroppedLinksCount() {
+		return false
+	}
+	if !ms.Status().Equal(val.Status(), opts...) {
+		return false
+	}
+	return true
+}
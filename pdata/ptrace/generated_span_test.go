@@ -35,6 +35,14 @@ func TestSpan_CopyTo(t *testing.T) {
 	assert.Equal(t, orig, ms)
 }
 
+func TestSpan_Equal(t *testing.T) {
+	ms := generateTestSpan()
+	assert.True(t, ms.Equal(ms))
+	assert.True(t, ms.Equal(generateTestSpan()))
+	assert.False(t, ms.Equal(NewSpan()))
+	assert.False(t, NewSpan().Equal(ms))
+}
+
 func TestSpan_TraceID(t *testing.T) {
 	ms := NewSpan()
 	assert.Equal(t, pcommon.TraceID(data.TraceID([16]byte{})), ms.TraceID())
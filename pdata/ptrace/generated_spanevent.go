@@ -85,3 +85,20 @@ func (ms SpanEvent) CopyTo(dest SpanEvent) {
 	ms.Attributes().CopyTo(dest.Attributes())
 	dest.SetDroppedAttributesCount(ms.DroppedAttributesCount())
 }
+
+// Equal checks equality with another SpanEvent, all the fields being deeply equal.
+func (ms SpanEvent) Equal(val SpanEvent, opts ...pcommon.CompareOption) bool {
+	if ms.Timestamp() != val.Timestamp() {
+		return false
+	}
+	if ms.Name() != val.Name() {
+		return false
+	}
+	if !ms.Attributes().Equal(val.Attributes(), opts...) {
+		return false
+	}
+	if ms.DroppedAttributesCount() != val.DroppedAttributesCount() {
+		return false
+	}
+	return true
+}
@@ -33,6 +33,14 @@ func TestSpanEvent_CopyTo(t *testing.T) {
 	assert.Equal(t, orig, ms)
 }
 
+func TestSpanEvent_Equal(t *testing.T) {
+	ms := generateTestSpanEvent()
+	assert.True(t, ms.Equal(ms))
+	assert.True(t, ms.Equal(generateTestSpanEvent()))
+	assert.False(t, ms.Equal(NewSpanEvent()))
+	assert.False(t, NewSpanEvent().Equal(ms))
+}
+
 func TestSpanEvent_Timestamp(t *testing.T) {
 	ms := NewSpanEvent()
 	assert.Equal(t, pcommon.Timestamp(0), ms.Timestamp())
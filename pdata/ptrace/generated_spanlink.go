@@ -93,3 +93,23 @@ func (ms SpanLink) CopyTo(dest SpanLink) {
 	ms.Attributes().CopyTo(dest.Attributes())
 	dest.SetDroppedAttributesCount(ms.DroppedAttributesCount())
 }
+
+// Equal checks equality with another SpanLink, all the fields being deeply equal.
+func (ms SpanLink) Equal(val SpanLink, opts ...pcommon.CompareOption) bool {
+	if ms.TraceID() != val.TraceID() {
+		return false
+	}
+	if ms.SpanID() != val.SpanID() {
+		return false
+	}
+	if !ms.TraceState().Equal(val.TraceState(), opts...) {
+		return false
+	}
+	if !ms.Attributes().Equal(val.Attributes(), opts...) {
+		return false
+	}
+	if ms.DroppedAttributesCount() != val.DroppedAttributesCount() {
+		return false
+	}
+	return true
+}
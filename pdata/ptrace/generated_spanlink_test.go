@@ -34,6 +34,14 @@ func TestSpanLink_CopyTo(t *testing.T) {
 	assert.Equal(t, orig, ms)
 }
 
+func TestSpanLink_Equal(t *testing.T) {
+	ms := generateTestSpanLink()
+	assert.True(t, ms.Equal(ms))
+	assert.True(t, ms.Equal(generateTestSpanLink()))
+	assert.False(t, ms.Equal(NewSpanLink()))
+	assert.False(t, NewSpanLink().Equal(ms))
+}
+
 func TestSpanLink_TraceID(t *testing.T) {
 	ms := NewSpanLink()
 	assert.Equal(t, pcommon.TraceID(data.TraceID([16]byte{})), ms.TraceID())
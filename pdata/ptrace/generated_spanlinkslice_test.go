@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 func TestSpanLinkSlice(t *testing.T) {
@@ -108,6 +109,26 @@ func TestSpanLinkSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestSpanLinkSlice_Equal(t *testing.T) {
+	es := generateTestSpanLinkSlice()
+	assert.True(t, es.Equal(generateTestSpanLinkSlice()))
+	assert.False(t, es.Equal(NewSpanLinkSlice()))
+
+	other := generateTestSpanLinkSlice()
+	NewSpanLink().CopyTo(other.At(0))
+	assert.False(t, es.Equal(other))
+
+	// Test Equal ignoring the order of the elements
+	reordered := NewSpanLinkSlice()
+	for i := 1; i < other.Len(); i++ {
+		other.At(i).CopyTo(reordered.AppendEmpty())
+	}
+	other.At(0).CopyTo(reordered.AppendEmpty())
+	assert.False(t, other.Equal(reordered))
+	assert.True(t, other.Equal(reordered, pcommon.IgnoreSliceOrder()))
+	assert.False(t, es.Equal(reordered, pcommon.IgnoreSliceOrder()))
+}
+
 func TestSpanLinkSlice_Sort(t *testing.T) {
 	es := generateTestSpanLinkSlice()
 	es.Sort(func(a, b SpanLink) bool {
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 func TestSpanSlice(t *testing.T) {
@@ -108,6 +109,26 @@ func TestSpanSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestSpanSlice_Equal(t *testing.T) {
+	es := generateTestSpanSlice()
+	assert.True(t, es.Equal(generateTestSpanSlice()))
+	assert.False(t, es.Equal(NewSpanSlice()))
+
+	other := generateTestSpanSlice()
+	NewSpan().CopyTo(other.At(0))
+	assert.False(t, es.Equal(other))
+
+	// Test Equal ignoring the order of the elements
+	reordered := NewSpanSlice()
+	for i := 1; i < other.Len(); i++ {
+		other.At(i).CopyTo(reordered.AppendEmpty())
+	}
+	other.At(0).CopyTo(reordered.AppendEmpty())
+	assert.False(t, other.Equal(reordered))
+	assert.True(t, other.Equal(reordered, pcommon.IgnoreSliceOrder()))
+	assert.False(t, es.Equal(reordered, pcommon.IgnoreSliceOrder()))
+}
+
 func TestSpanSlice_Sort(t *testing.T) {
 	es := generateTestSpanSlice()
 	es.Sort(func(a, b Span) bool {
@@ -8,6 +8,7 @@ package ptrace
 
 import (
 	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 // Status is an optional final status for this span. Semantically, when Status was not
@@ -66,3 +67,14 @@ func (ms Status) CopyTo(dest Status) {
 	dest.SetCode(ms.Code())
 	dest.SetMessage(ms.Message())
 }
+
+// Equal checks equality with another Status, all the fields being deeply equal.
+func (ms Status) Equal(val Status, opts ...pcommon.CompareOption) bool {
+	if ms.Code() != val.Code() {
+		return false
+	}
+	if ms.Message() != val.Message() {
+		return false
+	}
+	return true
+}
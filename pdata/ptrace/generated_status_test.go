@@ -30,6 +30,14 @@ func TestStatus_CopyTo(t *testing.T) {
 	assert.Equal(t, orig, ms)
 }
 
+func TestStatus_Equal(t *testing.T) {
+	ms := generateTestStatus()
+	assert.True(t, ms.Equal(ms))
+	assert.True(t, ms.Equal(generateTestStatus()))
+	assert.False(t, ms.Equal(NewStatus()))
+	assert.False(t, NewStatus().Equal(ms))
+}
+
 func TestStatus_Code(t *testing.T) {
 	ms := NewStatus()
 	assert.Equal(t, StatusCode(0), ms.Code())
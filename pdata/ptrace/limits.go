@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ptrace // import "go.opentelemetry.io/collector/pdata/ptrace"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// SpanLimits are the limits applied by the OpenTelemetry SDKs to the spans, enforced on the spans
+// received from misconfigured SDKs by Enforce. A zero limit means no limit.
+type SpanLimits struct {
+	// AttributeCountLimit is the maximum number of attributes of a span.
+	AttributeCountLimit int
+	// AttributeValueLengthLimit is the maximum length, in characters, of the string attribute values of the spans,
+	// events and links, including the strings nested in slices and maps. Longer values are truncated.
+	AttributeValueLengthLimit int
+	// EventCountLimit is the maximum number of events of a span.
+	EventCountLimit int
+	// LinkCountLimit is the maximum number of links of a span.
+	LinkCountLimit int
+	// AttributePerEventCountLimit is the maximum number of attributes of a span event.
+	AttributePerEventCountLimit int
+	// AttributePerLinkCountLimit is the maximum number of attributes of a span link.
+	AttributePerLinkCountLimit int
+}
+
+// EnforceTraces enforces the limits on all the spans of td, see Enforce.
+func (sl SpanLimits) EnforceTraces(td Traces) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		sss := rss.At(i).ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			spans := sss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				sl.Enforce(spans.At(k))
+			}
+		}
+	}
+}
+
+// Enforce enforces the limits on the span. The first attributes, events and links within the limits are kept,
+// the number of the removed ones being added to the corresponding dropped count.
+func (sl SpanLimits) Enforce(span Span) {
+	dropped := limitAttributes(span.Attributes(), sl.AttributeCountLimit, sl.AttributeValueLengthLimit)
+	span.SetDroppedAttributesCount(span.DroppedAttributesCount() + dropped)
+
+	events := span.Events()
+	if sl.EventCountLimit > 0 && events.Len() > sl.EventCountLimit {
+		span.SetDroppedEventsCount(span.DroppedEventsCount() + uint32(events.Len()-sl.EventCountLimit))
+		i := 0
+		events.RemoveIf(func(SpanEvent) bool {
+			i++
+			return i > sl.EventCountLimit
+		})
+	}
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		dropped = limitAttributes(event.Attributes(), sl.AttributePerEventCountLimit, sl.AttributeValueLengthLimit)
+		event.SetDroppedAttributesCount(event.DroppedAttributesCount() + dropped)
+	}
+
+	links := span.Links()
+	if sl.LinkCountLimit > 0 && links.Len() > sl.LinkCountLimit {
+		span.SetDroppedLinksCount(span.DroppedLinksCount() + uint32(links.Len()-sl.LinkCountLimit))
+		i := 0
+		links.RemoveIf(func(SpanLink) bool {
+			i++
+			return i > sl.LinkCountLimit
+		})
+	}
+	for i := 0; i < links.Len(); i++ {
+		link := links.At(i)
+		dropped = limitAttributes(link.Attributes(), sl.AttributePerLinkCountLimit, sl.AttributeValueLengthLimit)
+		link.SetDroppedAttributesCount(link.DroppedAttributesCount() + dropped)
+	}
+}
+
+// limitAttributes enforces the limits on the attributes, returning the number of removed attributes.
+func limitAttributes(attrs pcommon.Map, countLimit, lengthLimit int) uint32 {
+	var dropped uint32
+	if countLimit > 0 && attrs.Len() > countLimit {
+		dropped = uint32(attrs.Len() - countLimit)
+		i := 0
+		attrs.RemoveIf(func(string, pcommon.Value) bool {
+			i++
+			return i > countLimit
+		})
+	}
+	if lengthLimit > 0 {
+		attrs.Range(func(_ string, v pcommon.Value) bool {
+			truncateValue(v, lengthLimit)
+			return true
+		})
+	}
+	return dropped
+}
+
+// truncateValue truncates the strings of the value longer than limit characters.
+func truncateValue(v pcommon.Value, limit int) {
+	switch v.Type() {
+	case pcommon.ValueTypeStr:
+		if s, truncated := truncateString(v.Str(), limit); truncated {
+			v.SetStr(s)
+		}
+	case pcommon.ValueTypeSlice:
+		s := v.Slice()
+		for i := 0; i < s.Len(); i++ {
+			truncateValue(s.At(i), limit)
+		}
+	case pcommon.ValueTypeMap:
+		v.Map().Range(func(_ string, mv pcommon.Value) bool {
+			truncateValue(mv, limit)
+			return true
+		})
+	}
+}
+
+// truncateString returns the first limit characters of s, and whether s was longer.
+func truncateString(s string, limit int) (string, bool) {
+	if len(s) <= limit {
+		return s, false
+	}
+	n := 0
+	for i := range s {
+		if n == limit {
+			return s[:i], true
+		}
+		n++
+	}
+	return s, false
+}
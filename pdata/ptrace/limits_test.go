@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ptrace
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func newLimitsTestSpan(t *testing.T) Span {
+	span := NewSpan()
+	span.SetDroppedAttributesCount(1)
+	// The attributes are added in order, so that the attributes kept by the limits are always the same.
+	attrs := span.Attributes()
+	attrs.PutStr("str", "héllo world")
+	attrs.PutInt("int", 1)
+	require.NoError(t, attrs.PutEmptySlice("slice").FromRaw([]any{"abcdef", int64(2)}))
+	attrs.PutEmptyMap("map").PutStr("nested", "abcdef")
+	for i := 0; i < 3; i++ {
+		event := span.Events().AppendEmpty()
+		event.SetName("event" + strconv.Itoa(i))
+		event.Attributes().PutStr("a", "abcdef")
+		event.Attributes().PutStr("b", "b")
+		link := span.Links().AppendEmpty()
+		link.TraceState().FromRaw("link" + strconv.Itoa(i))
+		link.Attributes().PutStr("a", "abcdef")
+		link.Attributes().PutStr("b", "b")
+	}
+	return span
+}
+
+func TestSpanLimits_NoLimit(t *testing.T) {
+	span := newLimitsTestSpan(t)
+	SpanLimits{}.Enforce(span)
+	assert.True(t, newLimitsTestSpan(t).Equal(span, pcommon.IgnoreMapOrder()))
+}
+
+func TestSpanLimits_Enforce(t *testing.T) {
+	span := newLimitsTestSpan(t)
+	SpanLimits{
+		AttributeCountLimit:         2,
+		AttributeValueLengthLimit:   3,
+		EventCountLimit:             2,
+		LinkCountLimit:              1,
+		AttributePerEventCountLimit: 1,
+		AttributePerLinkCountLimit:  3,
+	}.Enforce(span)
+
+	assert.Equal(t, 2, span.Attributes().Len())
+	assert.Equal(t, uint32(3), span.DroppedAttributesCount())
+	span.Attributes().Range(func(_ string, v pcommon.Value) bool {
+		switch v.Type() {
+		case pcommon.ValueTypeStr:
+			assert.Equal(t, "hél", v.Str())
+		case pcommon.ValueTypeSlice:
+			assert.Equal(t, []any{"abc", int64(2)}, v.Slice().AsRaw())
+		case pcommon.ValueTypeMap:
+			assert.Equal(t, map[string]any{"nested": "abc"}, v.Map().AsRaw())
+		}
+		return true
+	})
+
+	require.Equal(t, 2, span.Events().Len())
+	assert.Equal(t, uint32(1), span.DroppedEventsCount())
+	for i := 0; i < span.Events().Len(); i++ {
+		event := span.Events().At(i)
+		assert.Equal(t, "event"+strconv.Itoa(i), event.Name())
+		assert.Equal(t, map[string]any{"a": "abc"}, event.Attributes().AsRaw())
+		assert.Equal(t, uint32(1), event.DroppedAttributesCount())
+	}
+
+	require.Equal(t, 1, span.Links().Len())
+	assert.Equal(t, uint32(2), span.DroppedLinksCount())
+	link := span.Links().At(0)
+	assert.Equal(t, "link0", link.TraceState().AsRaw())
+	assert.Equal(t, map[string]any{"a": "abc", "b": "b"}, link.Attributes().AsRaw())
+	assert.Equal(t, uint32(0), link.DroppedAttributesCount())
+}
+
+func TestSpanLimits_EnforceTraces(t *testing.T) {
+	td := NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	newLimitsTestSpan(t).CopyTo(spans.AppendEmpty())
+	newLimitsTestSpan(t).CopyTo(spans.AppendEmpty())
+
+	SpanLimits{EventCountLimit: 1}.EnforceTraces(td)
+	for i := 0; i < spans.Len(); i++ {
+		assert.Equal(t, 1, spans.At(i).Events().Len())
+		assert.Equal(t, uint32(2), spans.At(i).DroppedEventsCount())
+	}
+}
+
+func TestTruncateString(t *testing.T) {
+	tests := []struct {
+		in        string
+		limit     int
+		out       string
+		truncated bool
+	}{
+		{in: "", limit: 1, out: ""},
+		{in: "abc", limit: 3, out: "abc"},
+		{in: "abcd", limit: 3, out: "abc", truncated: true},
+		{in: "ééé", limit: 3, out: "ééé"},
+		{in: "éééé", limit: 3, out: "ééé", truncated: true},
+	}
+	for _, tt := range tests {
+		out, truncated := truncateString(tt.in, tt.limit)
+		assert.Equal(t, tt.out, out)
+		assert.Equal(t, tt.truncated, truncated)
+	}
+}
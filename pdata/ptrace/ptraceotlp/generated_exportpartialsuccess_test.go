@@ -30,6 +30,14 @@ func TestExportPartialSuccess_CopyTo(t *testing.T) {
 	assert.Equal(t, orig, ms)
 }
 
+func TestExportPartialSuccess_Equal(t *testing.T) {
+	ms := generateTestExportPartialSuccess()
+	assert.True(t, ms.Equal(ms))
+	assert.True(t, ms.Equal(generateTestExportPartialSuccess()))
+	assert.False(t, ms.Equal(NewExportPartialSuccess()))
+	assert.False(t, NewExportPartialSuccess().Equal(ms))
+}
+
 func TestExportPartialSuccess_RejectedSpans(t *testing.T) {
 	ms := NewExportPartialSuccess()
 	assert.Equal(t, int64(0), ms.RejectedSpans())
@@ -5,6 +5,7 @@ package ptraceotlp // import "go.opentelemetry.io/collector/pdata/ptrace/ptraceo
 
 import (
 	"bytes"
+	"sync"
 
 	"go.opentelemetry.io/collector/pdata/internal"
 	otlpcollectortrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/trace/v1"
@@ -69,3 +70,29 @@ func (ms ExportRequest) UnmarshalJSON(data []byte) error {
 func (ms ExportRequest) Traces() ptrace.Traces {
 	return ptrace.Traces(internal.NewTraces(ms.orig))
 }
+
+var requestPool = sync.Pool{
+	New: func() any {
+		return &otlpcollectortrace.ExportTraceServiceRequest{}
+	},
+}
+
+// AcquireExportRequest returns an empty ExportRequest from a pool, reusing the memory of the requests
+// given back with ReleaseExportRequest instead of allocating a new request for every received request.
+func AcquireExportRequest() ExportRequest {
+	return ExportRequest{orig: requestPool.Get().(*otlpcollectortrace.ExportTraceServiceRequest)}
+}
+
+// ReleaseExportRequest gives back to the pool of AcquireExportRequest a request that is not used anymore.
+// The request, and the ptrace.Traces returned by its Traces method, must not be used once released:
+// the data still used afterwards, e.g. passed to a consumer that may keep it, must be moved out of
+// the request before, with ResourceSpansSlice.MoveAndAppendTo.
+func ReleaseExportRequest(req ExportRequest) {
+	// Keep the capacity of the slice, the elements are cleared to not retain the moved data.
+	rs := req.orig.ResourceSpans
+	for i := range rs {
+		rs[i] = nil
+	}
+	*req.orig = otlpcollectortrace.ExportTraceServiceRequest{ResourceSpans: rs[:0]}
+	requestPool.Put(req.orig)
+}
@@ -9,6 +9,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
 var _ json.Unmarshaler = ExportRequest{}
@@ -53,3 +56,26 @@ func TestRequestJSON(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, strings.Join(strings.Fields(string(tracesRequestJSON)), ""), string(got))
 }
+
+func TestAcquireReleaseExportRequest(t *testing.T) {
+	req := AcquireExportRequest()
+	assert.Equal(t, 0, req.Traces().SpanCount())
+	req.Traces().ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("test_span")
+	data, err := req.MarshalProto()
+	require.NoError(t, err)
+
+	// The data moved out of the request is not changed once the request is released.
+	moved := ptrace.NewTraces()
+	req.Traces().ResourceSpans().MoveAndAppendTo(moved.ResourceSpans())
+	ReleaseExportRequest(req)
+	assert.Equal(t, 1, moved.SpanCount())
+
+	for i := 0; i < 10; i++ {
+		req = AcquireExportRequest()
+		assert.Equal(t, 0, req.Traces().SpanCount())
+		require.NoError(t, req.UnmarshalProto(data))
+		assert.Equal(t, 1, req.Traces().SpanCount())
+		ReleaseExportRequest(req)
+	}
+	assert.Equal(t, 1, moved.SpanCount())
+}
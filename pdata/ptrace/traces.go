@@ -6,6 +6,7 @@ package ptrace // import "go.opentelemetry.io/collector/pdata/ptrace"
 import (
 	"go.opentelemetry.io/collector/pdata/internal"
 	otlpcollectortrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/trace/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 // Traces is the top-level struct that is propagated through the traces pipeline.
@@ -30,6 +31,13 @@ func (ms Traces) CopyTo(dest Traces) {
 	ms.ResourceSpans().CopyTo(dest.ResourceSpans())
 }
 
+// Equal checks equality with another Traces, all the resources being deeply equal.
+// Use pcommon.IgnoreSliceOrder and pcommon.IgnoreMapOrder to ignore the order of the resources,
+// scopes, records and attributes.
+func (ms Traces) Equal(val Traces, opts ...pcommon.CompareOption) bool {
+	return ms.ResourceSpans().Equal(val.ResourceSpans(), opts...)
+}
+
 // SpanCount calculates the total number of spans.
 func (ms Traces) SpanCount() int {
 	spanCount := 0
@@ -44,6 +52,26 @@ func (ms Traces) SpanCount() int {
 	return spanCount
 }
 
+// RangeSpans calls f sequentially for each span, with the resource and the scope it belongs to. If f returns
+// false, RangeSpans stops the iteration. The spans are not copied: f may modify them, but must not add or
+// remove resources, scopes or spans while iterating.
+func (ms Traces) RangeSpans(f func(rs ResourceSpans, ss ScopeSpans, span Span) bool) {
+	rss := ms.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		sss := rs.ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			ss := sss.At(j)
+			spans := ss.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				if !f(rs, ss, spans.At(k)) {
+					return
+				}
+			}
+		}
+	}
+}
+
 // ResourceSpans returns the ResourceSpansSlice associated with this Metrics.
 func (ms Traces) ResourceSpans() ResourceSpansSlice {
 	return newResourceSpansSlice(&ms.getOrig().ResourceSpans)
@@ -13,6 +13,7 @@ import (
 
 	otlpcollectortrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/trace/v1"
 	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 func TestSpanCount(t *testing.T) {
@@ -63,6 +64,45 @@ func TestSpanCountWithEmpty(t *testing.T) {
 	}).SpanCount())
 }
 
+func TestRangeSpans(t *testing.T) {
+	td := NewTraces()
+	td.RangeSpans(func(ResourceSpans, ScopeSpans, Span) bool {
+		t.Fatal("no spans to iterate")
+		return true
+	})
+
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("resource", "r0")
+	rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("a")
+	// An empty scope is skipped.
+	rs.ScopeSpans().AppendEmpty()
+	recs := rs.ScopeSpans().AppendEmpty().Spans()
+	recs.AppendEmpty().SetName("b")
+	recs.AppendEmpty().SetName("c")
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("d")
+
+	var names []string
+	td.RangeSpans(func(r ResourceSpans, s ScopeSpans, rec Span) bool {
+		names = append(names, rec.Name())
+		if rec.Name() == "b" {
+			assert.Equal(t, rs, r)
+			assert.Equal(t, rs.ScopeSpans().At(2), s)
+			// The spans are not copied.
+			rec.SetName("modified")
+		}
+		return true
+	})
+	assert.Equal(t, []string{"a", "b", "c", "d"}, names)
+	assert.Equal(t, "modified", recs.At(0).Name())
+
+	names = nil
+	td.RangeSpans(func(_ ResourceSpans, _ ScopeSpans, rec Span) bool {
+		names = append(names, rec.Name())
+		return len(names) < 2
+	})
+	assert.Equal(t, []string{"a", "modified"}, names)
+}
+
 func TestToFromOtlp(t *testing.T) {
 	otlp := &otlpcollectortrace.ExportTraceServiceRequest{}
 	traces := newTraces(otlp)
@@ -113,3 +153,22 @@ func TestTracesCopyTo(t *testing.T) {
 	traces.CopyTo(tracesCopy)
 	assert.EqualValues(t, traces, tracesCopy)
 }
+
+func TestTracesEqual(t *testing.T) {
+	traces := NewTraces()
+	fillTestResourceSpansSlice(traces.ResourceSpans())
+	other := NewTraces()
+	traces.CopyTo(other)
+	assert.True(t, traces.Equal(other))
+
+	other.ResourceSpans().AppendEmpty()
+	assert.False(t, traces.Equal(other))
+
+	reordered := NewTraces()
+	other.ResourceSpans().At(other.ResourceSpans().Len() - 1).CopyTo(reordered.ResourceSpans().AppendEmpty())
+	for i := 0; i < other.ResourceSpans().Len()-1; i++ {
+		other.ResourceSpans().At(i).CopyTo(reordered.ResourceSpans().AppendEmpty())
+	}
+	assert.False(t, other.Equal(reordered))
+	assert.True(t, other.Equal(reordered, pcommon.IgnoreSliceOrder()))
+}
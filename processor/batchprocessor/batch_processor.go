@@ -4,8 +4,8 @@
 package batchprocessor // import "go.opentelemetry.io/collector/processor/batchprocessor"
 
 import (
+	"container/list"
 	"context"
-	"errors"
 	"fmt"
 	"runtime"
 	"sort"
@@ -20,14 +20,23 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/inflight"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
 )
 
-// errTooManyBatchers is returned when the MetadataCardinalityLimit has been reached.
-var errTooManyBatchers = consumererror.NewPermanent(errors.New("too many batcher metadata-value combinations"))
+// MetadataCardinalityLimitError is the error, wrapped in a permanent error, refusing the data of a new
+// combination of metadata values once MetadataCardinalityLimit is reached with the "none" eviction policy.
+type MetadataCardinalityLimitError struct {
+	// Limit is the MetadataCardinalityLimit reached.
+	Limit int
+}
+
+func (e *MetadataCardinalityLimitError) Error() string {
+	return fmt.Sprintf("too many batcher metadata-value combinations, the limit of %d is reached", e.Limit)
+}
 
 // batch_processor is a component that accepts spans and metrics, places them
 // into batches and sends downstream.
@@ -38,6 +47,7 @@ var errTooManyBatchers = consumererror.NewPermanent(errors.New("too many batcher
 // - batch size reaches cfg.SendBatchSize
 // - cfg.Timeout is elapsed since the timestamp when the previous batch was sent out.
 type batchProcessor struct {
+	id               component.ID
 	logger           *zap.Logger
 	timeout          time.Duration
 	sendBatchSize    int
@@ -56,6 +66,18 @@ type batchProcessor struct {
 	// metadataLimit is the limiting size of the batchers map.
 	metadataLimit int
 
+	// metadataEviction defines how the batchers of the metadata
+	// combinations are retired.
+	metadataEviction MetadataEvictionConfig
+
+	// flushSlots bounds the number of batches sent concurrently, nil
+	// when the batches are sent by the shards themselves.
+	flushSlots chan struct{}
+
+	// preserveOrder sends the batches of each shard one at a time, in
+	// order, when flushSlots is not nil.
+	preserveOrder bool
+
 	shutdownC  chan struct{}
 	goroutines sync.WaitGroup
 
@@ -63,11 +85,16 @@ type batchProcessor struct {
 
 	//  batcher will be either *singletonBatcher or *multiBatcher
 	batcher batcher
+
+	// inflight accounts for the bytes held in the batches, nil if the host doesn't account for them.
+	inflight *inflight.Component
 }
 
 type batcher interface {
 	consume(ctx context.Context, data any) error
 	currentMetadataCardinality() int
+	// shardsToFlush returns the shards having data to be sent by Flush.
+	shardsToFlush() []*shard
 }
 
 // shard is a single instance of the batch logic.  When metadata
@@ -88,21 +115,54 @@ type shard struct {
 	// newItem is used to receive data items from producers.
 	newItem chan any
 
+	// flushC receives the requests to send the pending data immediately,
+	// the channel being closed once the data is sent.
+	flushC chan chan struct{}
+
 	// batch is an in-flight data item containing one of the
 	// underlying data types.
 	batch batch
+
+	// inflightBytes is the number of bytes of the batch accounted as held by the processor.
+	inflightBytes int64
+
+	// retireC is closed to stop the shard once its pending data is sent,
+	// when it is evicted by the multiShardBatcher.
+	retireC chan struct{}
+
+	// exports counts the batches of the shard being sent by the flush workers.
+	exports sync.WaitGroup
+
+	// The fields below are guarded by the lock of the multiShardBatcher.
+
+	// key is the metadata combination of the shard.
+	key attribute.Set
+	// lruElem is the element of the shard in the LRU list.
+	lruElem *list.Element
+	// lastUsed is the time the shard last received data.
+	lastUsed time.Time
+	// senders counts the producers sending to newItem, for the shard
+	// to be retired only once they are done.
+	senders sync.WaitGroup
 }
 
 // batch is an interface generalizing the individual signal types.
 type batch interface {
-	// export the current batch
-	export(ctx context.Context, sendBatchMaxSize int, returnBytes bool) (sentBatchSize int, sentBatchBytes int, err error)
+	// split removes the next request to send from the current batch, holding at most
+	// sendBatchMaxSize items, and returns it with its number of items.
+	split(sendBatchMaxSize int) (req any, sentBatchSize int)
+
+	// export sends a request returned by split
+	export(ctx context.Context, req any) error
 
 	// itemCount returns the size of the current batch
 	itemCount() int
 
 	// add item to the current batch
 	add(item any)
+
+	// sizeBytes returns the size in bytes of an item or of a request
+	sizeBytes(item any) int
 }
 
 var _ consumer.Traces = (*batchProcessor)(nil)
@@ -118,6 +178,7 @@ func newBatchProcessor(set processor.CreateSettings, cfg *Config, batchFunc func
 	}
 	sort.Strings(mks)
 	bp := &batchProcessor{
+		id:     set.ID,
 		logger: set.Logger,
 
 		sendBatchSize:    int(cfg.SendBatchSize),
@@ -127,13 +188,22 @@ func newBatchProcessor(set processor.CreateSettings, cfg *Config, batchFunc func
 		shutdownC:        make(chan struct{}, 1),
 		metadataKeys:     mks,
 		metadataLimit:    int(cfg.MetadataCardinalityLimit),
+		metadataEviction: cfg.MetadataEviction,
+		preserveOrder:    cfg.PreserveOrder || len(mks) > 0,
+	}
+	if cfg.NumFlushWorkers > 1 {
+		bp.flushSlots = make(chan struct{}, cfg.NumFlushWorkers)
 	}
+	var mb *multiShardBatcher
 	if len(bp.metadataKeys) == 0 {
 		bp.batcher = &singleShardBatcher{batcher: bp.newShard(nil)}
 	} else {
-		bp.batcher = &multiShardBatcher{
+		mb = &multiShardBatcher{
 			batchProcessor: bp,
+			shards:         map[attribute.Set]*shard{},
+			lru:            list.New(),
 		}
+		bp.batcher = mb
 	}
 
 	bpt, err := newBatchProcessorTelemetry(set, bp.batcher.currentMetadataCardinality, useOtel)
@@ -142,6 +212,11 @@ func newBatchProcessor(set processor.CreateSettings, cfg *Config, batchFunc func
 	}
 	bp.telemetry = bpt
 
+	if mb != nil && bp.metadataEviction.IdleTimeout > 0 {
+		bp.goroutines.Add(1)
+		go mb.evictIdleLoop()
+	}
+
 	return bp, nil
 }
 
@@ -153,8 +228,10 @@ func (bp *batchProcessor) newShard(md map[string][]string) *shard {
 	b := &shard{
 		processor: bp,
 		newItem:   make(chan any, runtime.NumCPU()),
+		flushC:    make(chan chan struct{}),
 		exportCtx: exportCtx,
 		batch:     bp.batchFunc(),
+		retireC:   make(chan struct{}),
 	}
 	b.processor.goroutines.Add(1)
 	go b.start()
@@ -166,7 +243,19 @@ func (bp *batchProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (bp *batchProcessor) Start(context.Context, component.Host) error {
+func (bp *batchProcessor) Start(_ context.Context, host component.Host) error {
+	bp.inflight = inflight.FromHost(host).Component(component.KindProcessor, bp.id)
+	return nil
+}
+
+// Flush sends the pending batches immediately, without waiting for the timeout.
+// It returns once the batches are sent, or ctx is done.
+func (bp *batchProcessor) Flush(ctx context.Context) error {
+	for _, b := range bp.batcher.shardsToFlush() {
+		if err := b.flush(ctx); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -208,11 +297,44 @@ func (b *shard) start() {
 				b.sendItems(triggerTimeout)
 			}
 			return
+		case <-b.retireC:
+			// No more data is sent to an evicted shard once retireC is closed.
+		RETIRED:
+			for {
+				select {
+				case item := <-b.newItem:
+					b.processItem(item)
+				default:
+					break RETIRED
+				}
+			}
+			if b.batch.itemCount() > 0 {
+				b.sendItems(triggerTimeout)
+			}
+			return
 		case item := <-b.newItem:
 			if item == nil {
 				continue
 			}
 			b.processItem(item)
+		case done := <-b.flushC:
+			// Send the data received before the flush, as if the timeout expired.
+		FLUSH:
+			for {
+				select {
+				case item := <-b.newItem:
+					b.processItem(item)
+				default:
+					break FLUSH
+				}
+			}
+			for b.batch.itemCount() > 0 {
+				b.sendItems(triggerTimeout)
+			}
+			b.exports.Wait()
+			b.stopTimer()
+			b.resetTimer()
+			close(done)
 		case <-timerCh:
 			if b.batch.itemCount() > 0 {
 				b.sendItems(triggerTimeout)
@@ -222,7 +344,33 @@ func (b *shard) start() {
 	}
 }
 
+// flush requests the shard to send its pending data, and waits for it to be sent.
+func (b *shard) flush(ctx context.Context) error {
+	done := make(chan struct{})
+	select {
+	case b.flushC <- done:
+	case <-b.retireC:
+		// A retired shard sends its pending data before stopping.
+		return nil
+	case <-b.processor.shutdownC:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func (b *shard) processItem(item any) {
+	if b.processor.inflight != nil {
+		bytes := int64(b.batch.sizeBytes(item))
+		b.inflightBytes += bytes
+		b.processor.inflight.Add(bytes)
+	}
 	b.batch.add(item)
 	sent := false
 	for b.batch.itemCount() > 0 && (!b.hasTimer() || b.batch.itemCount() >= b.processor.sendBatchSize) {
@@ -252,8 +400,40 @@ func (b *shard) resetTimer() {
 	}
 }
 
+// sendItems sends the next request of the batch. With the flush workers, the request is sent by
+// a new goroutine once one of the flush slots is available, after the previous request of the
+// shard was sent when the order is preserved.
 func (b *shard) sendItems(trigger trigger) {
-	sent, bytes, err := b.batch.export(b.exportCtx, b.processor.sendBatchMaxSize, b.processor.telemetry.detailed)
+	req, sent := b.batch.split(b.processor.sendBatchMaxSize)
+	var bytes int
+	if b.processor.telemetry.detailed {
+		bytes = b.batch.sizeBytes(req)
+	}
+	released := b.takeInFlight(sent)
+	slots := b.processor.flushSlots
+	if slots == nil {
+		b.export(trigger, req, sent, bytes, released)
+		return
+	}
+	if b.processor.preserveOrder {
+		b.exports.Wait()
+	}
+	slots <- struct{}{}
+	b.exports.Add(1)
+	b.processor.goroutines.Add(1)
+	go func() {
+		defer b.processor.goroutines.Done()
+		defer b.exports.Done()
+		b.export(trigger, req, sent, bytes, released)
+		<-slots
+	}()
+}
+
+func (b *shard) export(trigger trigger, req any, sent, bytes int, released int64) {
+	err := b.batch.export(b.exportCtx, req)
+	if released != 0 {
+		b.processor.inflight.Add(-released)
+	}
 	if err != nil {
 		b.processor.logger.Warn("Sender failed", zap.Error(err))
 	} else {
@@ -261,6 +441,21 @@ func (b *shard) sendItems(trigger trigger) {
 	}
 }
 
+// takeInFlight returns the bytes accounted for the sent items, to release once they are sent. When the
+// batch was split, the bytes are taken in proportion of the items sent, the remaining items staying in
+// the batch.
+func (b *shard) takeInFlight(sent int) int64 {
+	if b.inflightBytes == 0 {
+		return 0
+	}
+	released := b.inflightBytes
+	if remaining := b.batch.itemCount(); remaining > 0 {
+		released = b.inflightBytes * int64(sent) / int64(sent+remaining)
+	}
+	b.inflightBytes -= released
+	return released
+}
+
 // singleShardBatcher is used when metadataKeys is empty, to avoid the
 // additional lock and map operations used in multiBatcher.
 type singleShardBatcher struct {
@@ -276,15 +471,22 @@ func (sb *singleShardBatcher) currentMetadataCardinality() int {
 	return 1
 }
 
+func (sb *singleShardBatcher) shardsToFlush() []*shard {
+	return []*shard{sb.batcher}
+}
+
 // multiBatcher is used when metadataKeys is not empty.
 type multiShardBatcher struct {
 	*batchProcessor
-	batchers sync.Map
 
-	// Guards the size and the storing logic to ensure no more than limit items are stored.
-	// If we are willing to allow "some" extra items than the limit this can be removed and size can be made atomic.
-	lock sync.Mutex
-	size int
+	// Guards the shards and their LRU order to ensure no more than limit shards are stored.
+	lock   sync.Mutex
+	shards map[attribute.Set]*shard
+	// lru holds the shards, the most recently used in front.
+	lru *list.List
+	// overflow receives the data of the new combinations once the limit is reached with the
+	// overflow policy, created when first needed. It is not counted in the limit, nor evicted.
+	overflow *shard
 }
 
 func (mb *multiShardBatcher) consume(ctx context.Context, data any) error {
@@ -307,31 +509,107 @@ func (mb *multiShardBatcher) consume(ctx context.Context, data any) error {
 	}
 	aset := attribute.NewSet(attrs...)
 
-	b, ok := mb.batchers.Load(aset)
-	if !ok {
-		mb.lock.Lock()
-		if mb.metadataLimit != 0 && mb.size >= mb.metadataLimit {
+	mb.lock.Lock()
+	b, ok := mb.shards[aset]
+	if ok {
+		mb.lru.MoveToFront(b.lruElem)
+	} else {
+		full := mb.metadataLimit != 0 && len(mb.shards) >= mb.metadataLimit
+		switch {
+		case !full:
+		case mb.metadataEviction.Policy == evictionPolicyLRU:
+			mb.evict(mb.lru.Back().Value.(*shard), evictionReasonLRU)
+		case mb.metadataEviction.Policy == evictionPolicyOverflow:
+			if mb.overflow == nil {
+				mb.overflow = mb.newShard(nil)
+			}
+			b = mb.overflow
+		default:
 			mb.lock.Unlock()
-			return errTooManyBatchers
+			return consumererror.NewPermanent(&MetadataCardinalityLimitError{Limit: mb.metadataLimit})
 		}
 
-		// aset.ToSlice() returns the sorted, deduplicated,
-		// and name-downcased list of attributes.
-		var loaded bool
-		b, loaded = mb.batchers.LoadOrStore(aset, mb.newShard(md))
-		if !loaded {
-			mb.size++
+		if b == nil {
+			// aset.ToSlice() returns the sorted, deduplicated,
+			// and name-downcased list of attributes.
+			b = mb.newShard(md)
+			b.key = aset
+			b.lruElem = mb.lru.PushFront(b)
+			mb.shards[aset] = b
 		}
-		mb.lock.Unlock()
 	}
-	b.(*shard).newItem <- data
+	b.lastUsed = time.Now()
+	b.senders.Add(1)
+	mb.lock.Unlock()
+
+	b.newItem <- data
+	b.senders.Done()
 	return nil
 }
 
+// evict removes the shard from the batcher, and retires it once the pending
+// producers are done. It must be called with the lock held.
+func (mb *multiShardBatcher) evict(b *shard, reason string) {
+	delete(mb.shards, b.key)
+	mb.lru.Remove(b.lruElem)
+	mb.telemetry.recordEviction(reason)
+
+	mb.goroutines.Add(1)
+	go func() {
+		defer mb.goroutines.Done()
+		b.senders.Wait()
+		close(b.retireC)
+	}()
+}
+
+// evictIdle evicts the shards that didn't receive data since IdleTimeout.
+func (mb *multiShardBatcher) evictIdle(now time.Time) {
+	mb.lock.Lock()
+	defer mb.lock.Unlock()
+	for e := mb.lru.Back(); e != nil; e = mb.lru.Back() {
+		b := e.Value.(*shard)
+		if now.Sub(b.lastUsed) < mb.metadataEviction.IdleTimeout {
+			return
+		}
+		mb.evict(b, evictionReasonIdle)
+	}
+}
+
+func (mb *multiShardBatcher) evictIdleLoop() {
+	defer mb.goroutines.Done()
+	ticker := time.NewTicker(mb.metadataEviction.IdleTimeout)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-mb.shutdownC:
+			return
+		case now := <-ticker.C:
+			mb.evictIdle(now)
+		}
+	}
+}
+
+// currentMetadataCardinality returns the number of shards, including the overflow shard.
 func (mb *multiShardBatcher) currentMetadataCardinality() int {
 	mb.lock.Lock()
 	defer mb.lock.Unlock()
-	return mb.size
+	if mb.overflow != nil {
+		return len(mb.shards) + 1
+	}
+	return len(mb.shards)
+}
+
+func (mb *multiShardBatcher) shardsToFlush() []*shard {
+	mb.lock.Lock()
+	defer mb.lock.Unlock()
+	shards := make([]*shard, 0, len(mb.shards)+1)
+	for _, b := range mb.shards {
+		shards = append(shards, b)
+	}
+	if mb.overflow != nil {
+		shards = append(shards, mb.overflow)
+	}
+	return shards
 }
 
 // ConsumeTraces implements TracesProcessor
@@ -387,10 +665,9 @@ func (bt *batchTraces) add(item any) {
 	td.ResourceSpans().MoveAndAppendTo(bt.traceData.ResourceSpans())
 }
 
-func (bt *batchTraces) export(ctx context.Context, sendBatchMaxSize int, returnBytes bool) (int, int, error) {
+func (bt *batchTraces) split(sendBatchMaxSize int) (any, int) {
 	var req ptrace.Traces
 	var sent int
-	var bytes int
 	if sendBatchMaxSize > 0 && bt.itemCount() > sendBatchMaxSize {
 		req = splitTraces(sendBatchMaxSize, bt.traceData)
 		bt.spanCount -= sendBatchMaxSize
@@ -401,16 +678,21 @@ func (bt *batchTraces) export(ctx context.Context, sendBatchMaxSize int, returnB
 		bt.traceData = ptrace.NewTraces()
 		bt.spanCount = 0
 	}
-	if returnBytes {
-		bytes = bt.sizer.TracesSize(req)
-	}
-	return sent, bytes, bt.nextConsumer.ConsumeTraces(ctx, req)
+	return req, sent
+}
+
+func (bt *batchTraces) export(ctx context.Context, req any) error {
+	return bt.nextConsumer.ConsumeTraces(ctx, req.(ptrace.Traces))
 }
 
 func (bt *batchTraces) itemCount() int {
 	return bt.spanCount
 }
 
+func (bt *batchTraces) sizeBytes(item any) int {
+	return bt.sizer.TracesSize(item.(ptrace.Traces))
+}
+
 type batchMetrics struct {
 	nextConsumer   consumer.Metrics
 	metricData     pmetric.Metrics
@@ -422,10 +704,9 @@ func newBatchMetrics(nextConsumer consumer.Metrics) *batchMetrics {
 	return &batchMetrics{nextConsumer: nextConsumer, metricData: pmetric.NewMetrics(), sizer: &pmetric.ProtoMarshaler{}}
 }
 
-func (bm *batchMetrics) export(ctx context.Context, sendBatchMaxSize int, returnBytes bool) (int, int, error) {
+func (bm *batchMetrics) split(sendBatchMaxSize int) (any, int) {
 	var req pmetric.Metrics
 	var sent int
-	var bytes int
 	if sendBatchMaxSize > 0 && bm.dataPointCount > sendBatchMaxSize {
 		req = splitMetrics(sendBatchMaxSize, bm.metricData)
 		bm.dataPointCount -= sendBatchMaxSize
@@ -436,16 +717,21 @@ func (bm *batchMetrics) export(ctx context.Context, sendBatchMaxSize int, return
 		bm.metricData = pmetric.NewMetrics()
 		bm.dataPointCount = 0
 	}
-	if returnBytes {
-		bytes = bm.sizer.MetricsSize(req)
-	}
-	return sent, bytes, bm.nextConsumer.ConsumeMetrics(ctx, req)
+	return req, sent
+}
+
+func (bm *batchMetrics) export(ctx context.Context, req any) error {
+	return bm.nextConsumer.ConsumeMetrics(ctx, req.(pmetric.Metrics))
 }
 
 func (bm *batchMetrics) itemCount() int {
 	return bm.dataPointCount
 }
 
+func (bm *batchMetrics) sizeBytes(item any) int {
+	return bm.sizer.MetricsSize(item.(pmetric.Metrics))
+}
+
 func (bm *batchMetrics) add(item any) {
 	md := item.(pmetric.Metrics)
 
@@ -468,10 +754,9 @@ func newBatchLogs(nextConsumer consumer.Logs) *batchLogs {
 	return &batchLogs{nextConsumer: nextConsumer, logData: plog.NewLogs(), sizer: &plog.ProtoMarshaler{}}
 }
 
-func (bl *batchLogs) export(ctx context.Context, sendBatchMaxSize int, returnBytes bool) (int, int, error) {
+func (bl *batchLogs) split(sendBatchMaxSize int) (any, int) {
 	var req plog.Logs
 	var sent int
-	var bytes int
 
 	if sendBatchMaxSize > 0 && bl.logCount > sendBatchMaxSize {
 		req = splitLogs(sendBatchMaxSize, bl.logData)
@@ -483,16 +768,21 @@ func (bl *batchLogs) export(ctx context.Context, sendBatchMaxSize int, returnByt
 		bl.logData = plog.NewLogs()
 		bl.logCount = 0
 	}
-	if returnBytes {
-		bytes = bl.sizer.LogsSize(req)
-	}
-	return sent, bytes, bl.nextConsumer.ConsumeLogs(ctx, req)
+	return req, sent
+}
+
+func (bl *batchLogs) export(ctx context.Context, req any) error {
+	return bl.nextConsumer.ConsumeLogs(ctx, req.(plog.Logs))
 }
 
 func (bl *batchLogs) itemCount() int {
 	return bl.logCount
 }
 
+func (bl *batchLogs) sizeBytes(item any) int {
+	return bl.sizer.LogsSize(item.(plog.Logs))
+}
+
 func (bl *batchLogs) add(item any) {
 	ld := item.(plog.Logs)
 
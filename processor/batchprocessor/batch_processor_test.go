@@ -8,18 +8,23 @@ import (
 	"fmt"
 	"math"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 
 	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/inflight"
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -464,8 +469,8 @@ func TestBatchMetrics_UnevenBatchMaxSize(t *testing.T) {
 
 	batchMetrics.add(md)
 	require.Equal(t, dataPointsPerMetric*metricsCount, batchMetrics.dataPointCount)
-	sent, _, sendErr := batchMetrics.export(ctx, sendBatchMaxSize, false)
-	require.NoError(t, sendErr)
+	req, sent := batchMetrics.split(sendBatchMaxSize)
+	require.NoError(t, batchMetrics.export(ctx, req))
 	require.Equal(t, sendBatchMaxSize, sent)
 	remainingDataPointCount := metricsCount*dataPointsPerMetric - sendBatchMaxSize
 	require.Equal(t, remainingDataPointCount, batchMetrics.dataPointCount)
@@ -1028,10 +1033,176 @@ func TestBatchProcessorMetadataCardinalityLimit(t *testing.T) {
 	assert.Error(t, err)
 	assert.True(t, consumererror.IsPermanent(err))
 	assert.Contains(t, err.Error(), "too many")
+	var limitErr *MetadataCardinalityLimitError
+	require.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, cardLimit, limitErr.Limit)
 
 	require.NoError(t, batcher.Shutdown(context.Background()))
 }
 
+func TestBatchProcessorMetadataOverflow(t *testing.T) {
+	sink := &metadataTracesSink{
+		TracesSink:         &consumertest.TracesSink{},
+		spanCountByToken12: map[string]int{},
+	}
+	cfg := createDefaultConfig().(*Config)
+	cfg.Timeout = time.Hour
+	cfg.MetadataKeys = []string{"token1"}
+	cfg.MetadataCardinalityLimit = 2
+	cfg.MetadataEviction.Policy = evictionPolicyOverflow
+	batcher, err := newBatchTracesProcessor(processortest.NewNopCreateSettings(), sink, cfg, false)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	token1Context := func(token string) context.Context {
+		return client.NewContext(context.Background(), client.Info{
+			Metadata: client.NewMetadata(map[string][]string{"token1": {token}}),
+		})
+	}
+	require.NoError(t, batcher.ConsumeTraces(token1Context("a"), testdata.GenerateTraces(1)))
+	require.NoError(t, batcher.ConsumeTraces(token1Context("b"), testdata.GenerateTraces(2)))
+	assert.Equal(t, 2, batcher.batcher.currentMetadataCardinality())
+
+	// The data of the new combinations is batched together, without metadata, once the limit is reached.
+	require.NoError(t, batcher.ConsumeTraces(token1Context("c"), testdata.GenerateTraces(4)))
+	require.NoError(t, batcher.ConsumeTraces(token1Context("d"), testdata.GenerateTraces(8)))
+	require.NoError(t, batcher.ConsumeTraces(token1Context("a"), testdata.GenerateTraces(16)))
+	assert.Equal(t, 3, batcher.batcher.currentMetadataCardinality())
+
+	require.NoError(t, batcher.Flush(context.Background()))
+	assert.Equal(t, 31, sink.SpanCount())
+	sink.lock.Lock()
+	assert.Equal(t, map[string]int{
+		formatTwo([]string{"a"}, nil): 17,
+		formatTwo([]string{"b"}, nil): 2,
+		formatTwo(nil, nil):           12,
+	}, sink.spanCountByToken12)
+	sink.lock.Unlock()
+
+	require.NoError(t, batcher.Shutdown(context.Background()))
+}
+
+func tokenContext(token string) context.Context {
+	return client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"token": {token}}),
+	})
+}
+
+func metadataEvictions(t *testing.T, reader *sdkmetric.ManualReader, reason string) int64 {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if sum, ok := m.Data.(metricdata.Sum[int64]); ok && m.Name == "processor/batch/metadata_evictions" {
+				for _, dp := range sum.DataPoints {
+					if v, _ := dp.Attributes.Value(evictionReasonKey); v.AsString() == reason {
+						return dp.Value
+					}
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func TestBatchProcessorMetadataEvictionLRU(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Timeout = time.Hour
+	cfg.MetadataKeys = []string{"token"}
+	cfg.MetadataCardinalityLimit = 2
+	cfg.MetadataEviction.Policy = evictionPolicyLRU
+	reader := sdkmetric.NewManualReader()
+	creationSet := processortest.NewNopCreateSettings()
+	creationSet.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	batcher, err := newBatchTracesProcessor(creationSet, sink, cfg, true)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, batcher.ConsumeTraces(tokenContext("a"), testdata.GenerateTraces(1)))
+	require.NoError(t, batcher.ConsumeTraces(tokenContext("b"), testdata.GenerateTraces(2)))
+	// Use "a" again, so that "b" is the least-recently-used combination.
+	require.NoError(t, batcher.ConsumeTraces(tokenContext("a"), testdata.GenerateTraces(1)))
+	require.NoError(t, batcher.ConsumeTraces(tokenContext("c"), testdata.GenerateTraces(4)))
+
+	// The data of "b" is sent when its batcher is retired.
+	assert.Eventually(t, func() bool {
+		return sink.SpanCount() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 2, batcher.batcher.currentMetadataCardinality())
+	assert.Equal(t, int64(1), metadataEvictions(t, reader, evictionReasonLRU))
+
+	require.NoError(t, batcher.Shutdown(context.Background()))
+	assert.Equal(t, 8, sink.SpanCount())
+}
+
+func TestBatchProcessorMetadataEvictionIdle(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Timeout = time.Hour
+	cfg.MetadataKeys = []string{"token"}
+	cfg.MetadataEviction.IdleTimeout = 10 * time.Millisecond
+	reader := sdkmetric.NewManualReader()
+	creationSet := processortest.NewNopCreateSettings()
+	creationSet.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	batcher, err := newBatchTracesProcessor(creationSet, sink, cfg, true)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, batcher.ConsumeTraces(tokenContext("a"), testdata.GenerateTraces(1)))
+	require.NoError(t, batcher.ConsumeTraces(tokenContext("b"), testdata.GenerateTraces(2)))
+
+	assert.Eventually(t, func() bool {
+		return sink.SpanCount() == 3 && batcher.batcher.currentMetadataCardinality() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int64(2), metadataEvictions(t, reader, evictionReasonIdle))
+
+	// A new batcher is created for the data received after the eviction.
+	require.NoError(t, batcher.ConsumeTraces(tokenContext("a"), testdata.GenerateTraces(1)))
+	require.NoError(t, batcher.Shutdown(context.Background()))
+	assert.Equal(t, 4, sink.SpanCount())
+}
+
+func TestBatchProcessorFlush(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Timeout = time.Hour
+	batcher, err := newBatchTracesProcessor(processortest.NewNopCreateSettings(), sink, cfg, false)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraces(3)))
+	require.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	require.NoError(t, batcher.Flush(context.Background()))
+	assert.Equal(t, 5, sink.SpanCount())
+	assert.Len(t, sink.AllTraces(), 1)
+
+	// Nothing is sent when there is no pending data.
+	require.NoError(t, batcher.Flush(context.Background()))
+	assert.Len(t, sink.AllTraces(), 1)
+
+	require.NoError(t, batcher.Shutdown(context.Background()))
+	// Flushing a processor shut down is a no-op.
+	require.NoError(t, batcher.Flush(context.Background()))
+}
+
+func TestBatchProcessorFlushByMetadata(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Timeout = time.Hour
+	cfg.MetadataKeys = []string{"token"}
+	batcher, err := newBatchTracesProcessor(processortest.NewNopCreateSettings(), sink, cfg, false)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, batcher.ConsumeTraces(tokenContext("a"), testdata.GenerateTraces(1)))
+	require.NoError(t, batcher.ConsumeTraces(tokenContext("b"), testdata.GenerateTraces(2)))
+	require.NoError(t, batcher.Flush(context.Background()))
+	assert.Equal(t, 3, sink.SpanCount())
+	assert.Len(t, sink.AllTraces(), 2)
+	require.NoError(t, batcher.Shutdown(context.Background()))
+}
+
 func TestBatchZeroConfig(t *testing.T) {
 	// This is a no-op configuration. No need for a timer, no
 	// minimum, no mxaimum, just a pass through.
@@ -1105,3 +1276,146 @@ func TestBatchSplitOnly(t *testing.T) {
 		require.Equal(t, maxBatch, ld.LogRecordCount())
 	}
 }
+
+type inFlightHost struct {
+	component.Host
+	tracker *inflight.Tracker
+}
+
+func (h inFlightHost) GetInFlightTracker() *inflight.Tracker {
+	return h.tracker
+}
+
+func TestBatchProcessorInFlightBytes(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 1000
+	cfg.SendBatchMaxSize = 1000
+	cfg.Timeout = time.Hour
+	creationSet := processortest.NewNopCreateSettings()
+	batcher, err := newBatchTracesProcessor(creationSet, sink, cfg, false)
+	require.NoError(t, err)
+	tracker := inflight.NewTracker(0)
+	require.NoError(t, batcher.Start(context.Background(), inFlightHost{Host: componenttest.NewNopHost(), tracker: tracker}))
+
+	td := testdata.GenerateTraces(10)
+	size := int64((&ptrace.ProtoMarshaler{}).TracesSize(td))
+	require.NoError(t, batcher.ConsumeTraces(context.Background(), td))
+	processor := tracker.Component(component.KindProcessor, creationSet.ID)
+	assert.Eventually(t, func() bool { return processor.Bytes() == size }, time.Second, 10*time.Millisecond)
+
+	// The held batch is sent on shutdown, releasing its bytes.
+	require.NoError(t, batcher.Shutdown(context.Background()))
+	assert.Zero(t, tracker.Bytes())
+	assert.Equal(t, 10, sink.SpanCount())
+}
+
+func TestBatchProcessorInFlightBytesSplit(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 4
+	cfg.SendBatchMaxSize = 4
+	cfg.Timeout = time.Hour
+	batcher, err := newBatchTracesProcessor(processortest.NewNopCreateSettings(), sink, cfg, false)
+	require.NoError(t, err)
+	tracker := inflight.NewTracker(0)
+	require.NoError(t, batcher.Start(context.Background(), inFlightHost{Host: componenttest.NewNopHost(), tracker: tracker}))
+
+	// 10 spans are sent as 2 batches of 4, the 2 remaining spans staying in the batch.
+	td := testdata.GenerateTraces(10)
+	size := int64((&ptrace.ProtoMarshaler{}).TracesSize(td))
+	require.NoError(t, batcher.ConsumeTraces(context.Background(), td))
+	assert.Eventually(t, func() bool { return sink.SpanCount() == 8 }, time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool { return tracker.Bytes() == size*2/10 }, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, batcher.Shutdown(context.Background()))
+	assert.Zero(t, tracker.Bytes())
+}
+
+// blockingLogsSink blocks the exports until release is closed, recording the number of concurrent exports.
+type blockingLogsSink struct {
+	consumertest.LogsSink
+	release   chan struct{}
+	active    atomic.Int32
+	maxActive atomic.Int32
+}
+
+func (s *blockingLogsSink) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	n := s.active.Add(1)
+	for m := s.maxActive.Load(); n > m && !s.maxActive.CompareAndSwap(m, n); m = s.maxActive.Load() {
+	}
+	<-s.release
+	s.active.Add(-1)
+	return s.LogsSink.ConsumeLogs(ctx, ld)
+}
+
+func TestBatchProcessorFlushWorkers(t *testing.T) {
+	cfg := Config{NumFlushWorkers: 3}
+	require.NoError(t, cfg.Validate())
+	sink := &blockingLogsSink{release: make(chan struct{})}
+	batcher, err := newBatchLogsProcessor(processortest.NewNopCreateSettings(), sink, &cfg, false)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, batcher.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)))
+	}
+	// At most 3 batches are sent concurrently.
+	assert.Eventually(t, func() bool { return sink.active.Load() == 3 }, time.Second, 5*time.Millisecond)
+	assert.Never(t, func() bool { return sink.active.Load() > 3 }, 50*time.Millisecond, 5*time.Millisecond)
+
+	close(sink.release)
+	assert.Eventually(t, func() bool { return sink.LogRecordCount() == 5 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, int32(3), sink.maxActive.Load())
+	require.NoError(t, batcher.Shutdown(context.Background()))
+}
+
+func TestBatchProcessorFlushWorkersPreserveOrder(t *testing.T) {
+	cfg := Config{NumFlushWorkers: 3, PreserveOrder: true}
+	sink := &blockingLogsSink{release: make(chan struct{})}
+	batcher, err := newBatchLogsProcessor(processortest.NewNopCreateSettings(), sink, &cfg, false)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	const requestCount = 5
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 1; i <= requestCount; i++ {
+			assert.NoError(t, batcher.ConsumeLogs(context.Background(), testdata.GenerateLogs(i)))
+		}
+	}()
+	// The batches are sent one at a time.
+	assert.Eventually(t, func() bool { return sink.active.Load() == 1 }, time.Second, 5*time.Millisecond)
+	assert.Never(t, func() bool { return sink.active.Load() > 1 }, 50*time.Millisecond, 5*time.Millisecond)
+
+	close(sink.release)
+	<-done
+	require.NoError(t, batcher.Shutdown(context.Background()))
+	require.Len(t, sink.AllLogs(), requestCount)
+	for i, ld := range sink.AllLogs() {
+		assert.Equal(t, i+1, ld.LogRecordCount())
+	}
+	assert.Equal(t, int32(1), sink.maxActive.Load())
+}
+
+func TestBatchProcessorFlushWorkersFlush(t *testing.T) {
+	sink := &blockingLogsSink{release: make(chan struct{})}
+	cfg := createDefaultConfig().(*Config)
+	cfg.Timeout = time.Hour
+	cfg.NumFlushWorkers = 2
+	batcher, err := newBatchLogsProcessor(processortest.NewNopCreateSettings(), sink, cfg, false)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, batcher.ConsumeLogs(context.Background(), testdata.GenerateLogs(3)))
+
+	// Flush returns once the batches are sent by the flush workers.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, batcher.Flush(ctx), context.DeadlineExceeded)
+
+	close(sink.release)
+	require.NoError(t, batcher.Flush(context.Background()))
+	assert.Equal(t, 3, sink.LogRecordCount())
+	require.NoError(t, batcher.Shutdown(context.Background()))
+}
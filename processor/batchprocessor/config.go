@@ -44,6 +44,51 @@ type Config struct {
 	// batcher instances that will be created through a distinct
 	// combination of MetadataKeys.
 	MetadataCardinalityLimit uint32 `mapstructure:"metadata_cardinality_limit"`
+
+	// MetadataEviction defines how the batcher instances of the
+	// distinct combinations of MetadataKeys are retired.
+	MetadataEviction MetadataEvictionConfig `mapstructure:"metadata_eviction"`
+
+	// NumFlushWorkers is the maximum number of batches sent concurrently
+	// to the next consumer. When this is set to 0 or 1, the batches are
+	// sent one at a time by the batcher, blocking it while they are sent.
+	NumFlushWorkers uint32 `mapstructure:"num_flush_workers"`
+
+	// PreserveOrder sends the batches in the order they were formed when
+	// NumFlushWorkers is greater than 1: a batch is only sent once the
+	// previous batch of the same batcher was sent. The order is always
+	// preserved per batcher when MetadataKeys is not empty.
+	PreserveOrder bool `mapstructure:"preserve_order"`
+}
+
+const (
+	// evictionPolicyNone refuses the data of new metadata combinations once
+	// MetadataCardinalityLimit is reached.
+	evictionPolicyNone = "none"
+	// evictionPolicyLRU flushes and retires the batcher of the least-recently-used
+	// metadata combination to make room for a new one once MetadataCardinalityLimit
+	// is reached.
+	evictionPolicyLRU = "lru"
+	// evictionPolicyOverflow sends the data of new metadata combinations to a
+	// single overflow batcher, without their metadata, once MetadataCardinalityLimit
+	// is reached.
+	evictionPolicyOverflow = "overflow"
+)
+
+// MetadataEvictionConfig defines how the batcher instances of the distinct
+// combinations of MetadataKeys are retired. A retired batcher sends its pending
+// data before stopping, a new one is created if data of the same combination
+// is received later.
+type MetadataEvictionConfig struct {
+	// Policy applied when MetadataCardinalityLimit is reached, either "none"
+	// (the default) to refuse the data of new combinations, "lru" to retire
+	// the batcher of the least-recently-used combination, or "overflow" to
+	// batch the data of new combinations together, without their metadata.
+	Policy string `mapstructure:"policy"`
+
+	// IdleTimeout retires the batchers that didn't receive data for this
+	// duration. When this is set to zero, idle batchers are kept.
+	IdleTimeout time.Duration `mapstructure:"idle_timeout"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -64,5 +109,13 @@ func (cfg *Config) Validate() error {
 	if cfg.Timeout < 0 {
 		return errors.New("timeout must be greater or equal to 0")
 	}
+	switch cfg.MetadataEviction.Policy {
+	case "", evictionPolicyNone, evictionPolicyLRU, evictionPolicyOverflow:
+	default:
+		return fmt.Errorf("metadata_eviction policy must be %q, %q or %q, got %q", evictionPolicyNone, evictionPolicyLRU, evictionPolicyOverflow, cfg.MetadataEviction.Policy)
+	}
+	if cfg.MetadataEviction.IdleTimeout < 0 {
+		return errors.New("metadata_eviction idle_timeout must be greater or equal to 0")
+	}
 	return nil
 }
@@ -35,6 +35,7 @@ func TestUnmarshalConfig(t *testing.T) {
 			SendBatchMaxSize:         uint32(11000),
 			Timeout:                  time.Second * 10,
 			MetadataCardinalityLimit: 1000,
+			NumFlushWorkers:          1,
 		}, cfg)
 }
 
@@ -70,6 +71,22 @@ func TestValidateConfig_InvalidTimeout(t *testing.T) {
 	assert.Error(t, cfg.Validate())
 }
 
+func TestValidateConfig_MetadataEviction(t *testing.T) {
+	cfg := &Config{
+		MetadataEviction: MetadataEvictionConfig{Policy: evictionPolicyLRU, IdleTimeout: time.Minute},
+	}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.MetadataEviction.Policy = evictionPolicyOverflow
+	assert.NoError(t, cfg.Validate())
+
+	cfg.MetadataEviction.Policy = "fifo"
+	assert.EqualError(t, cfg.Validate(), `metadata_eviction policy must be "none", "lru" or "overflow", got "fifo"`)
+
+	cfg.MetadataEviction = MetadataEvictionConfig{IdleTimeout: -time.Second}
+	assert.Error(t, cfg.Validate())
+}
+
 func TestValidateConfig_ValidZero(t *testing.T) {
 	cfg := &Config{}
 	assert.NoError(t, cfg.Validate())
@@ -24,6 +24,9 @@ const (
 	// of metadata configurations the user expects to submit to
 	// the collector.
 	defaultMetadataCardinalityLimit = 1000
+
+	// defaultNumFlushWorkers sends the batches one at a time.
+	defaultNumFlushWorkers = 1
 )
 
 // NewFactory returns a new factory for the Batch processor.
@@ -41,6 +44,7 @@ func createDefaultConfig() component.Config {
 		SendBatchSize:            defaultSendBatchSize,
 		Timeout:                  defaultTimeout,
 		MetadataCardinalityLimit: defaultMetadataCardinalityLimit,
+		NumFlushWorkers:          defaultNumFlushWorkers,
 	}
 }
 
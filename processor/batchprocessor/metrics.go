@@ -10,19 +10,14 @@ import (
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
 	"go.opencensus.io/tag"
-	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
-	"go.uber.org/multierr"
 
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/processor"
-)
-
-const (
-	scopeName = "go.opentelemetry.io/collector/processor/batchprocessor"
+	"go.opentelemetry.io/collector/processor/batchprocessor/internal/metadata"
 )
 
 var (
@@ -31,6 +26,18 @@ var (
 	statTimeoutTriggerSend   = stats.Int64("timeout_trigger_send", "Number of times the batch was sent due to a timeout trigger", stats.UnitDimensionless)
 	statBatchSendSize        = stats.Int64("batch_send_size", "Number of units in the batch", stats.UnitDimensionless)
 	statBatchSendSizeBytes   = stats.Int64("batch_send_size_bytes", "Number of bytes in batch that was sent", stats.UnitBytes)
+	statMetadataEvictions    = stats.Int64("metadata_evictions", "Number of batchers of metadata value combinations retired, by reason", stats.UnitDimensionless)
+
+	evictionReasonTagKey = tag.MustNewKey(evictionReasonKey)
+)
+
+const (
+	// evictionReasonKey is the attribute of the metadata_evictions metric telling why the batcher was retired.
+	evictionReasonKey = "reason"
+	// evictionReasonLRU is used when the batcher was retired to make room for a new metadata combination.
+	evictionReasonLRU = metadata.AttributeReasonLru
+	// evictionReasonIdle is used when the batcher was retired because it didn't receive data since IdleTimeout.
+	evictionReasonIdle = metadata.AttributeReasonIdle
 )
 
 type trigger int
@@ -83,11 +90,20 @@ func metricViews() []*view.View {
 			1000_000, 2000_000, 3000_000, 4000_000, 5000_000, 6000_000, 7000_000, 8000_000, 9000_000),
 	}
 
+	countMetadataEvictionsView := &view.View{
+		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statMetadataEvictions.Name()),
+		Measure:     statMetadataEvictions,
+		Description: statMetadataEvictions.Description(),
+		TagKeys:     []tag.Key{processorTagKey, evictionReasonTagKey},
+		Aggregation: view.Sum(),
+	}
+
 	return []*view.View{
 		countBatchSizeTriggerSendView,
 		countTimeoutTriggerSendView,
 		distributionBatchSendSizeView,
 		distributionBatchSendSizeBytesView,
+		countMetadataEvictionsView,
 	}
 }
 
@@ -98,12 +114,9 @@ type batchProcessorTelemetry struct {
 
 	exportCtx context.Context
 
-	processorAttr            []attribute.KeyValue
-	batchSizeTriggerSend     metric.Int64Counter
-	timeoutTriggerSend       metric.Int64Counter
-	batchSendSize            metric.Int64Histogram
-	batchSendSizeBytes       metric.Int64Histogram
-	batchMetadataCardinality metric.Int64ObservableUpDownCounter
+	processorID      string
+	processorAttr    metric.MeasurementOption
+	telemetryBuilder *metadata.TelemetryBuilder
 }
 
 func newBatchProcessorTelemetry(set processor.CreateSettings, currentMetadataCardinality func() int, useOtel bool) (*batchProcessorTelemetry, error) {
@@ -114,7 +127,8 @@ func newBatchProcessorTelemetry(set processor.CreateSettings, currentMetadataCar
 
 	bpt := &batchProcessorTelemetry{
 		useOtel:       useOtel,
-		processorAttr: []attribute.KeyValue{attribute.String(obsmetrics.ProcessorKey, set.ID.String())},
+		processorID:   set.ID.String(),
+		processorAttr: metadata.ProcessorAttributeSet(set.ID.String()),
 		exportCtx:     exportCtx,
 		level:         set.MetricsLevel,
 		detailed:      set.MetricsLevel == configtelemetry.LevelDetailed,
@@ -136,49 +150,12 @@ func (bpt *batchProcessorTelemetry) createOtelMetrics(mp metric.MeterProvider, c
 		return nil
 	}
 
-	var errors, err error
-	meter := mp.Meter(scopeName)
-
-	bpt.batchSizeTriggerSend, err = meter.Int64Counter(
-		obsreport.BuildProcessorCustomMetricName(typeStr, "batch_size_trigger_send"),
-		metric.WithDescription("Number of times the batch was sent due to a size trigger"),
-		metric.WithUnit("1"),
-	)
-	errors = multierr.Append(errors, err)
-
-	bpt.timeoutTriggerSend, err = meter.Int64Counter(
-		obsreport.BuildProcessorCustomMetricName(typeStr, "timeout_trigger_send"),
-		metric.WithDescription("Number of times the batch was sent due to a timeout trigger"),
-		metric.WithUnit("1"),
-	)
-	errors = multierr.Append(errors, err)
-
-	bpt.batchSendSize, err = meter.Int64Histogram(
-		obsreport.BuildProcessorCustomMetricName(typeStr, "batch_send_size"),
-		metric.WithDescription("Number of units in the batch"),
-		metric.WithUnit("1"),
-	)
-	errors = multierr.Append(errors, err)
-
-	bpt.batchSendSizeBytes, err = meter.Int64Histogram(
-		obsreport.BuildProcessorCustomMetricName(typeStr, "batch_send_size_bytes"),
-		metric.WithDescription("Number of bytes in batch that was sent"),
-		metric.WithUnit("By"),
-	)
-	errors = multierr.Append(errors, err)
-
-	bpt.batchMetadataCardinality, err = meter.Int64ObservableUpDownCounter(
-		obsreport.BuildProcessorCustomMetricName(typeStr, "metadata_cardinality"),
-		metric.WithDescription("Number of distinct metadata value combinations being processed"),
-		metric.WithUnit("1"),
-		metric.WithInt64Callback(func(_ context.Context, obs metric.Int64Observer) error {
-			obs.Observe(int64(currentMetadataCardinality()))
-			return nil
-		}),
-	)
-	errors = multierr.Append(errors, err)
-
-	return errors
+	var err error
+	bpt.telemetryBuilder, err = metadata.NewTelemetryBuilder(mp,
+		metadata.WithProcessorBatchMetadataCardinalityCallback(func() int64 {
+			return int64(currentMetadataCardinality())
+		}, bpt.processorAttr))
+	return err
 }
 
 func (bpt *batchProcessorTelemetry) record(trigger trigger, sent, bytes int64) {
@@ -207,13 +184,21 @@ func (bpt *batchProcessorTelemetry) recordWithOC(trigger trigger, sent, bytes in
 func (bpt *batchProcessorTelemetry) recordWithOtel(trigger trigger, sent, bytes int64) {
 	switch trigger {
 	case triggerBatchSize:
-		bpt.batchSizeTriggerSend.Add(bpt.exportCtx, 1, metric.WithAttributes(bpt.processorAttr...))
+		bpt.telemetryBuilder.ProcessorBatchBatchSizeTriggerSend.Add(bpt.exportCtx, 1, bpt.processorAttr)
 	case triggerTimeout:
-		bpt.timeoutTriggerSend.Add(bpt.exportCtx, 1, metric.WithAttributes(bpt.processorAttr...))
+		bpt.telemetryBuilder.ProcessorBatchTimeoutTriggerSend.Add(bpt.exportCtx, 1, bpt.processorAttr)
 	}
 
-	bpt.batchSendSize.Record(bpt.exportCtx, sent, metric.WithAttributes(bpt.processorAttr...))
+	bpt.telemetryBuilder.ProcessorBatchBatchSendSize.Record(bpt.exportCtx, sent, bpt.processorAttr)
 	if bpt.detailed {
-		bpt.batchSendSizeBytes.Record(bpt.exportCtx, bytes, metric.WithAttributes(bpt.processorAttr...))
+		bpt.telemetryBuilder.ProcessorBatchBatchSendSizeBytes.Record(bpt.exportCtx, bytes, bpt.processorAttr)
+	}
+}
+
+func (bpt *batchProcessorTelemetry) recordEviction(reason string) {
+	if bpt.useOtel {
+		bpt.telemetryBuilder.ProcessorBatchMetadataEvictions.Add(bpt.exportCtx, 1, metadata.ProcessorReasonAttributeSet(bpt.processorID, reason))
+	} else {
+		_ = stats.RecordWithTags(bpt.exportCtx, []tag.Mutator{tag.Upsert(evictionReasonTagKey, reason)}, statMetadataEvictions.M(1))
 	}
 }
@@ -6,6 +6,7 @@
 package memorylimiterprocessor // import "go.opentelemetry.io/collector/processor/memorylimiterprocessor"
 
 import (
+	"fmt"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -33,11 +34,85 @@ type Config struct {
 	// MemorySpikePercentage is the maximum, in percents against the total memory,
 	// spike expected between the measurements of memory usage.
 	MemorySpikePercentage uint32 `mapstructure:"spike_limit_percentage"`
+
+	// GCStrategy is how the memory is reclaimed when the memory usage is above the limits.
+	GCStrategy GCStrategy `mapstructure:"gc_strategy"`
+
+	// MinGOGC is the value of GOGC while the memory usage is above the soft limit, with the
+	// "memory_limit" GC strategy.
+	MinGOGC int `mapstructure:"min_gogc"`
+
+	// ForceGCAboveHardLimit forces a garbage collection when the memory usage is above the hard
+	// limit with the "memory_limit" GC strategy, as a last resort.
+	ForceGCAboveHardLimit bool `mapstructure:"force_gc_above_hard_limit"`
+
+	// Signals are the limits of the data of a signal, "traces", "metrics" or "logs", refused while the
+	// memory usage is above the soft limit of the signal, e.g. to refuse the logs before the traces
+	// and the metrics. The limits of a signal must be lower than the limits of the processor.
+	Signals map[component.DataType]SignalLimits `mapstructure:"signals"`
+}
+
+// SignalLimits defines the limits of the data of a signal.
+type SignalLimits struct {
+	// MemoryLimitMiB is the hard limit of the signal, in MiB.
+	MemoryLimitMiB uint32 `mapstructure:"limit_mib"`
+
+	// MemorySpikeLimitMiB is the difference, in MiB, between the hard and the soft limits of the signal.
+	MemorySpikeLimitMiB uint32 `mapstructure:"spike_limit_mib"`
+
+	// MemoryLimitPercentage is the hard limit of the signal, in % of the total memory.
+	// The fixed memory settings MemoryLimitMiB has a higher precedence.
+	MemoryLimitPercentage uint32 `mapstructure:"limit_percentage"`
+
+	// MemorySpikePercentage is the difference, in % of the total memory, between the hard and the
+	// soft limits of the signal.
+	MemorySpikePercentage uint32 `mapstructure:"spike_limit_percentage"`
 }
 
+// GCStrategy is the strategy used to reclaim memory when the memory usage is above the limits.
+type GCStrategy string
+
+const (
+	// GCStrategyForcedGC forces a garbage collection when the memory usage is above the limits.
+	GCStrategyForcedGC GCStrategy = "forced_gc"
+	// GCStrategyMemoryLimit sets the memory limit of the Go runtime to the hard limit, and lowers
+	// GOGC while the memory usage is above the soft limit, instead of forcing garbage collections.
+	GCStrategyMemoryLimit GCStrategy = "memory_limit"
+)
+
 var _ component.Config = (*Config)(nil)
 
 // Validate checks if the processor configuration is valid
 func (cfg *Config) Validate() error {
+	switch cfg.GCStrategy {
+	case "", GCStrategyForcedGC:
+	case GCStrategyMemoryLimit:
+		if cfg.MinGOGC <= 0 {
+			return fmt.Errorf("min_gogc must be positive, got %d", cfg.MinGOGC)
+		}
+	default:
+		return fmt.Errorf("gc_strategy must be one of %q or %q, got %q", GCStrategyForcedGC, GCStrategyMemoryLimit, cfg.GCStrategy)
+	}
+	for dataType, limits := range cfg.Signals {
+		switch dataType {
+		case component.DataTypeTraces, component.DataTypeMetrics, component.DataTypeLogs:
+		default:
+			return fmt.Errorf("signals must be one of %q, %q or %q, got %q",
+				component.DataTypeTraces, component.DataTypeMetrics, component.DataTypeLogs, dataType)
+		}
+		if limits.MemoryLimitMiB == 0 && limits.MemoryLimitPercentage == 0 {
+			return fmt.Errorf("signals::%s: limit_mib or limit_percentage must be greater than zero", dataType)
+		}
+	}
 	return nil
 }
+
+// limits returns the limits of the processor.
+func (cfg *Config) limits() SignalLimits {
+	return SignalLimits{
+		MemoryLimitMiB:        cfg.MemoryLimitMiB,
+		MemorySpikeLimitMiB:   cfg.MemorySpikeLimitMiB,
+		MemoryLimitPercentage: cfg.MemoryLimitPercentage,
+		MemorySpikePercentage: cfg.MemorySpikePercentage,
+	}
+}
@@ -31,8 +31,87 @@ func TestUnmarshalConfig(t *testing.T) {
 	assert.NoError(t, component.UnmarshalConfig(cm, cfg))
 	assert.Equal(t,
 		&Config{
-			CheckInterval:       5 * time.Second,
-			MemoryLimitMiB:      4000,
-			MemorySpikeLimitMiB: 500,
+			CheckInterval:         5 * time.Second,
+			MemoryLimitMiB:        4000,
+			MemorySpikeLimitMiB:   500,
+			GCStrategy:            GCStrategyForcedGC,
+			MinGOGC:               defaultMinGOGC,
+			ForceGCAboveHardLimit: true,
+			Signals: map[component.DataType]SignalLimits{
+				component.DataTypeLogs: {MemoryLimitMiB: 2000, MemorySpikeLimitMiB: 250},
+			},
 		}, cfg)
 }
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     func(*Config)
+		wantErr string
+	}{
+		{
+			name: "default",
+			cfg:  func(*Config) {},
+		},
+		{
+			name: "memory_limit",
+			cfg: func(cfg *Config) {
+				cfg.GCStrategy = GCStrategyMemoryLimit
+			},
+		},
+		{
+			name: "invalid_min_gogc",
+			cfg: func(cfg *Config) {
+				cfg.GCStrategy = GCStrategyMemoryLimit
+				cfg.MinGOGC = 0
+			},
+			wantErr: "min_gogc must be positive, got 0",
+		},
+		{
+			name: "invalid_gc_strategy",
+			cfg: func(cfg *Config) {
+				cfg.GCStrategy = "none"
+			},
+			wantErr: `gc_strategy must be one of "forced_gc" or "memory_limit", got "none"`,
+		},
+		{
+			name: "signals",
+			cfg: func(cfg *Config) {
+				cfg.Signals = map[component.DataType]SignalLimits{
+					component.DataTypeLogs:    {MemoryLimitMiB: 100},
+					component.DataTypeMetrics: {MemoryLimitPercentage: 50, MemorySpikePercentage: 10},
+				}
+			},
+		},
+		{
+			name: "invalid_signal",
+			cfg: func(cfg *Config) {
+				cfg.Signals = map[component.DataType]SignalLimits{
+					"profiles": {MemoryLimitMiB: 100},
+				}
+			},
+			wantErr: `signals must be one of "traces", "metrics" or "logs", got "profiles"`,
+		},
+		{
+			name: "invalid_signal_limits",
+			cfg: func(cfg *Config) {
+				cfg.Signals = map[component.DataType]SignalLimits{
+					component.DataTypeLogs: {MemorySpikeLimitMiB: 100},
+				}
+			},
+			wantErr: "signals::logs: limit_mib or limit_percentage must be greater than zero",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.cfg(cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -16,6 +16,9 @@ import (
 const (
 	// The value of "type" Attribute Key in configuration.
 	typeStr = "memory_limiter"
+
+	// defaultMinGOGC is the value of GOGC under memory pressure with the "memory_limit" GC strategy.
+	defaultMinGOGC = 25
 )
 
 var processorCapabilities = consumer.Capabilities{MutatesData: false}
@@ -43,7 +46,11 @@ func NewFactory() processor.Factory {
 // CreateDefaultConfig creates the default configuration for processor. Notice
 // that the default configuration is expected to fail for this processor.
 func createDefaultConfig() component.Config {
-	return &Config{}
+	return &Config{
+		GCStrategy:            GCStrategyForcedGC,
+		MinGOGC:               defaultMinGOGC,
+		ForceGCAboveHardLimit: true,
+	}
 }
 
 func (f *factory) createTracesProcessor(
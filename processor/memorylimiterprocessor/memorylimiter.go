@@ -15,6 +15,8 @@ import (
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/inflight"
+	"go.opentelemetry.io/collector/internal/gctuner"
 	"go.opentelemetry.io/collector/internal/iruntime"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -47,6 +49,9 @@ var (
 		"memoryLimitPercentage and memorySpikePercentage must be greater than zero and less than or equal to hundred",
 	)
 
+	errSignalLimitOutOfRange = errors.New(
+		"the memory limit of a signal must be smaller than the memory limit of the processor")
+
 	errShutdownNotStarted = errors.New("no existing monitoring routine is running")
 )
 
@@ -62,10 +67,30 @@ type memoryLimiter struct {
 	// mustRefuse is used to indicate when data should be refused.
 	mustRefuse *atomic.Bool
 
+	// signalUsageCheckers are the limits of the signals configured with their own limits, and
+	// signalMustRefuse indicates when the data of these signals should be refused.
+	signalUsageCheckers map[component.DataType]memUsageChecker
+	signalMustRefuse    map[component.DataType]*atomic.Bool
+
+	// inflight is the accounting of the data held by the components of the collector, used to refuse
+	// data when they hold more than the limit of the service.
+	inflight atomic.Pointer[inflight.Tracker]
+
 	ticker *time.Ticker
 
 	lastGCDone time.Time
 
+	// gcStrategy is how the memory is reclaimed above the limits.
+	gcStrategy GCStrategy
+	// tuner adjusts the settings of the Go runtime with the "memory_limit" GC strategy, nil with the
+	// "forced_gc" strategy or if the settings are tuned by another component, e.g. the memory tuner extension.
+	tuner   *gctuner.Tuner
+	minGOGC int
+	// forceGCAboveHardLimit forces a garbage collection above the hard limit with the "memory_limit" strategy.
+	forceGCAboveHardLimit bool
+	// runtimeMemoryLimit is the memory limit of the Go runtime set by an extension, 0 if none.
+	runtimeMemoryLimit uint64
+
 	// The function to read the mem values is set as a reference to help with
 	// testing different values.
 	readMemStatsFn func(m *runtime.MemStats)
@@ -103,6 +128,25 @@ func newMemoryLimiter(set processor.CreateSettings, cfg *Config) (*memoryLimiter
 		zap.Uint64("limit_mib", usageChecker.memAllocLimit/mibBytes),
 		zap.Uint64("spike_limit_mib", usageChecker.memSpikeLimit/mibBytes),
 		zap.Duration("check_interval", cfg.CheckInterval))
+	checkTotalMemory(usageChecker.memAllocLimit, logger)
+
+	signalUsageCheckers := make(map[component.DataType]memUsageChecker, len(cfg.Signals))
+	signalMustRefuse := make(map[component.DataType]*atomic.Bool, len(cfg.Signals))
+	for dataType, limits := range cfg.Signals {
+		signalUsageChecker, err := getLimitsUsageChecker(limits, logger)
+		if err != nil {
+			return nil, fmt.Errorf("signals::%s: %w", dataType, err)
+		}
+		if signalUsageChecker.memAllocLimit > usageChecker.memAllocLimit {
+			return nil, fmt.Errorf("signals::%s: %w", dataType, errSignalLimitOutOfRange)
+		}
+		logger.Info("Memory limiter configured for signal",
+			zap.String("signal", string(dataType)),
+			zap.Uint64("limit_mib", signalUsageChecker.memAllocLimit/mibBytes),
+			zap.Uint64("spike_limit_mib", signalUsageChecker.memSpikeLimit/mibBytes))
+		signalUsageCheckers[dataType] = *signalUsageChecker
+		signalMustRefuse[dataType] = &atomic.Bool{}
+	}
 
 	obsrep, err := obsreport.NewProcessor(obsreport.ProcessorSettings{
 		ProcessorID:             set.ID,
@@ -113,22 +157,34 @@ func newMemoryLimiter(set processor.CreateSettings, cfg *Config) (*memoryLimiter
 	}
 
 	ml := &memoryLimiter{
-		usageChecker:   *usageChecker,
-		memCheckWait:   cfg.CheckInterval,
-		ticker:         time.NewTicker(cfg.CheckInterval),
-		readMemStatsFn: runtime.ReadMemStats,
-		logger:         logger,
-		mustRefuse:     &atomic.Bool{},
-		obsrep:         obsrep,
+		usageChecker:        *usageChecker,
+		memCheckWait:        cfg.CheckInterval,
+		ticker:              time.NewTicker(cfg.CheckInterval),
+		readMemStatsFn:      runtime.ReadMemStats,
+		logger:              logger,
+		mustRefuse:          &atomic.Bool{},
+		signalUsageCheckers: signalUsageCheckers,
+		signalMustRefuse:    signalMustRefuse,
+		obsrep:              obsrep,
+		gcStrategy:          cfg.GCStrategy,
+	}
+	if cfg.GCStrategy == GCStrategyMemoryLimit {
+		ml.tuner = gctuner.New(logger)
+		ml.minGOGC = cfg.MinGOGC
+		ml.forceGCAboveHardLimit = cfg.ForceGCAboveHardLimit
 	}
 
 	return ml, nil
 }
 
 func getMemUsageChecker(cfg *Config, logger *zap.Logger) (*memUsageChecker, error) {
-	memAllocLimit := uint64(cfg.MemoryLimitMiB) * mibBytes
-	memSpikeLimit := uint64(cfg.MemorySpikeLimitMiB) * mibBytes
-	if cfg.MemoryLimitMiB != 0 {
+	return getLimitsUsageChecker(cfg.limits(), logger)
+}
+
+func getLimitsUsageChecker(limits SignalLimits, logger *zap.Logger) (*memUsageChecker, error) {
+	memAllocLimit := uint64(limits.MemoryLimitMiB) * mibBytes
+	memSpikeLimit := uint64(limits.MemorySpikeLimitMiB) * mibBytes
+	if limits.MemoryLimitMiB != 0 {
 		return newFixedMemUsageChecker(memAllocLimit, memSpikeLimit)
 	}
 	totalMemory, err := getMemoryFn()
@@ -137,9 +193,22 @@ func getMemUsageChecker(cfg *Config, logger *zap.Logger) (*memUsageChecker, erro
 	}
 	logger.Info("Using percentage memory limiter",
 		zap.Uint64("total_memory_mib", totalMemory/mibBytes),
-		zap.Uint32("limit_percentage", cfg.MemoryLimitPercentage),
-		zap.Uint32("spike_limit_percentage", cfg.MemorySpikePercentage))
-	return newPercentageMemUsageChecker(totalMemory, uint64(cfg.MemoryLimitPercentage), uint64(cfg.MemorySpikePercentage))
+		zap.Uint32("limit_percentage", limits.MemoryLimitPercentage),
+		zap.Uint32("spike_limit_percentage", limits.MemorySpikePercentage))
+	return newPercentageMemUsageChecker(totalMemory, uint64(limits.MemoryLimitPercentage), uint64(limits.MemorySpikePercentage))
+}
+
+// checkTotalMemory warns if the hard limit is above the total memory, e.g. the cgroup memory limit of a
+// container: the process would be killed before any data is refused.
+func checkTotalMemory(memAllocLimit uint64, logger *zap.Logger) {
+	totalMemory, err := getMemoryFn()
+	if err != nil || memAllocLimit <= totalMemory {
+		return
+	}
+	logger.Warn("The memory limiter hard limit is greater than the total memory, "+
+		"decrease \"limit_mib\" below the total memory or use \"limit_percentage\"",
+		zap.Uint64("limit_mib", memAllocLimit/mibBytes),
+		zap.Uint64("total_memory_mib", totalMemory/mibBytes))
 }
 
 func (ml *memoryLimiter) start(_ context.Context, host component.Host) error {
@@ -150,10 +219,31 @@ func (ml *memoryLimiter) start(_ context.Context, host component.Host) error {
 			break
 		}
 	}
+	for _, extension := range extensions {
+		if ext, ok := extension.(interface{ GetMemoryLimit() uint64 }); ok {
+			ml.runtimeMemoryLimit = ext.GetMemoryLimit()
+			ml.checkRuntimeMemoryLimit(ml.runtimeMemoryLimit)
+			break
+		}
+	}
+	if tracker := inflight.FromHost(host); tracker != nil {
+		ml.inflight.Store(tracker)
+	}
 	ml.startMonitoring()
 	return nil
 }
 
+// checkRuntimeMemoryLimit warns if the hard limit is above the memory limit of the Go runtime, e.g. set by the
+// memory tuner extension: the garbage collector would run continuously before any data is refused.
+func (ml *memoryLimiter) checkRuntimeMemoryLimit(memoryLimit uint64) {
+	if ml.usageChecker.memAllocLimit > memoryLimit {
+		ml.logger.Warn("The memory limiter hard limit is greater than the memory limit of the Go runtime, "+
+			"decrease \"limit_mib\" or \"limit_percentage\" below the runtime memory limit",
+			zap.Uint64("limit_mib", ml.usageChecker.memAllocLimit/mibBytes),
+			zap.Uint64("runtime_memory_limit_mib", memoryLimit/mibBytes))
+	}
+}
+
 func (ml *memoryLimiter) shutdown(context.Context) error {
 	ml.refCounterLock.Lock()
 	defer ml.refCounterLock.Unlock()
@@ -162,6 +252,9 @@ func (ml *memoryLimiter) shutdown(context.Context) error {
 		return errShutdownNotStarted
 	} else if ml.refCounter == 1 {
 		ml.ticker.Stop()
+		if ml.tuner != nil {
+			ml.tuner.Stop()
+		}
 	}
 	ml.refCounter--
 	return nil
@@ -169,7 +262,7 @@ func (ml *memoryLimiter) shutdown(context.Context) error {
 
 func (ml *memoryLimiter) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
 	numSpans := td.SpanCount()
-	if ml.mustRefuse.Load() {
+	if ml.mustRefuseData(component.DataTypeTraces) {
 		// TODO: actually to be 100% sure that this is "refused" and not "dropped"
 		// 	it is necessary to check the pipeline to see if this is directly connected
 		// 	to a receiver (ie.: a receiver is on the call stack). For now it
@@ -188,7 +281,7 @@ func (ml *memoryLimiter) processTraces(ctx context.Context, td ptrace.Traces) (p
 
 func (ml *memoryLimiter) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
 	numDataPoints := md.DataPointCount()
-	if ml.mustRefuse.Load() {
+	if ml.mustRefuseData(component.DataTypeMetrics) {
 		// TODO: actually to be 100% sure that this is "refused" and not "dropped"
 		// 	it is necessary to check the pipeline to see if this is directly connected
 		// 	to a receiver (ie.: a receiver is on the call stack). For now it
@@ -206,7 +299,7 @@ func (ml *memoryLimiter) processMetrics(ctx context.Context, md pmetric.Metrics)
 
 func (ml *memoryLimiter) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
 	numRecords := ld.LogRecordCount()
-	if ml.mustRefuse.Load() {
+	if ml.mustRefuseData(component.DataTypeLogs) {
 		// TODO: actually to be 100% sure that this is "refused" and not "dropped"
 		// 	it is necessary to check the pipeline to see if this is directly connected
 		// 	to a receiver (ie.: a receiver is on the call stack). For now it
@@ -223,6 +316,17 @@ func (ml *memoryLimiter) processLogs(ctx context.Context, ld plog.Logs) (plog.Lo
 	return ld, nil
 }
 
+// mustRefuseData returns whether the data of the signal must be refused, because the memory usage is above
+// the soft limit of the processor or of the signal, or because the components of the collector hold more data
+// than the in-flight limit of the service.
+func (ml *memoryLimiter) mustRefuseData(dataType component.DataType) bool {
+	if ml.mustRefuse.Load() || ml.inflight.Load().AboveLimit() {
+		return true
+	}
+	signalMustRefuse, ok := ml.signalMustRefuse[dataType]
+	return ok && signalMustRefuse.Load()
+}
+
 func (ml *memoryLimiter) readMemStats() *runtime.MemStats {
 	ms := &runtime.MemStats{}
 	ml.readMemStatsFn(ms)
@@ -247,6 +351,9 @@ func (ml *memoryLimiter) startMonitoring() {
 
 	ml.refCounter++
 	if ml.refCounter == 1 {
+		if ml.tuner != nil {
+			ml.startTuner()
+		}
 		go func() {
 			for range ml.ticker.C {
 				ml.checkMemLimits()
@@ -255,6 +362,21 @@ func (ml *memoryLimiter) startMonitoring() {
 	}
 }
 
+// startTuner sets the memory limit of the Go runtime to the hard limit, unless the settings of the Go runtime
+// are tuned by the memory tuner extension or by another memory limiter, which the processor defers to.
+func (ml *memoryLimiter) startTuner() {
+	if ml.runtimeMemoryLimit != 0 {
+		ml.logger.Info("The memory limit and GOGC of the Go runtime are tuned by an extension, leaving them unchanged")
+		ml.tuner = nil
+		return
+	}
+	// The ballast is part of the heap, so it is added to the hard limit.
+	if _, err := ml.tuner.Start(ml.usageChecker.memAllocLimit + ml.ballastSize); err != nil {
+		ml.logger.Info("Leaving the memory limit and GOGC of the Go runtime unchanged", zap.Error(err))
+		ml.tuner = nil
+	}
+}
+
 func memstatToZapField(ms *runtime.MemStats) zap.Field {
 	return zap.Uint64("cur_mem_mib", ms.Alloc/mibBytes)
 }
@@ -273,8 +395,12 @@ func (ml *memoryLimiter) checkMemLimits() {
 	ml.logger.Debug("Currently used memory.", memstatToZapField(ms))
 
 	if ml.usageChecker.aboveHardLimit(ms) {
-		ml.logger.Warn("Memory usage is above hard limit. Forcing a GC.", memstatToZapField(ms))
-		ms = ml.doGCandReadMemStats()
+		if ml.gcStrategy != GCStrategyMemoryLimit || ml.forceGCAboveHardLimit {
+			ml.logger.Warn("Memory usage is above hard limit. Forcing a GC.", memstatToZapField(ms))
+			ms = ml.doGCandReadMemStats()
+		} else {
+			ml.logger.Warn("Memory usage is above hard limit.", memstatToZapField(ms))
+		}
 	}
 
 	// Remember current state.
@@ -290,8 +416,9 @@ func (ml *memoryLimiter) checkMemLimits() {
 
 	if !wasRefusing && mustRefuse {
 		// We are above soft limit, do a GC if it wasn't done recently and see if
-		// it brings memory usage below the soft limit.
-		if time.Since(ml.lastGCDone) > minGCIntervalWhenSoftLimited {
+		// it brings memory usage below the soft limit. The "memory_limit" strategy
+		// lowers GOGC instead.
+		if ml.gcStrategy != GCStrategyMemoryLimit && time.Since(ml.lastGCDone) > minGCIntervalWhenSoftLimited {
 			ml.logger.Info("Memory usage is above soft limit. Forcing a GC.", memstatToZapField(ms))
 			ms = ml.doGCandReadMemStats()
 			// Check the limit again to see if GC helped.
@@ -303,7 +430,25 @@ func (ml *memoryLimiter) checkMemLimits() {
 		}
 	}
 
+	if ml.tuner != nil {
+		ml.tuner.SetPressure(mustRefuse, ml.minGOGC)
+	}
 	ml.mustRefuse.Store(mustRefuse)
+
+	// The garbage collection is driven by the limits of the processor only, the data of the signals
+	// above their own soft limit is refused until the memory usage goes back below it.
+	for dataType, usageChecker := range ml.signalUsageCheckers {
+		signalMustRefuse := usageChecker.aboveSoftLimit(ms)
+		wasRefusing := ml.signalMustRefuse[dataType].Swap(signalMustRefuse)
+		if wasRefusing && !signalMustRefuse {
+			ml.logger.Info("Memory usage back within the limits of the signal. Resuming accepting its data.",
+				zap.String("signal", string(dataType)), memstatToZapField(ms))
+		}
+		if !wasRefusing && signalMustRefuse {
+			ml.logger.Warn("Memory usage is above the soft limit of the signal. Refusing its data.",
+				zap.String("signal", string(dataType)), memstatToZapField(ms))
+		}
+	}
 }
 
 type memUsageChecker struct {
@@ -5,6 +5,7 @@ package memorylimiterprocessor
 
 import (
 	"context"
+	"math"
 	"runtime"
 	"sync/atomic"
 	"testing"
@@ -13,12 +14,14 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/inflight"
 	"go.opentelemetry.io/collector/internal/iruntime"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -526,3 +529,323 @@ func TestNoDataLoss(t *testing.T) {
 	err = processor.Shutdown(context.Background())
 	require.NoError(t, err)
 }
+
+func TestRuntimeMemoryLimit(t *testing.T) {
+	tests := []struct {
+		name        string
+		memoryLimit uint64
+		expectWarn  bool
+	}{
+		{
+			name:        "above_hard_limit",
+			memoryLimit: 2048 * mibBytes,
+		},
+		{
+			name:        "below_hard_limit",
+			memoryLimit: 512 * mibBytes,
+			expectWarn:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.CheckInterval = 10 * time.Second
+			cfg.MemoryLimitMiB = 1024
+			core, logs := observer.New(zap.WarnLevel)
+			set := processortest.NewNopCreateSettings()
+			set.Logger = zap.New(core)
+			ml, err := newMemoryLimiter(set, cfg)
+			require.NoError(t, err)
+
+			require.NoError(t, ml.start(context.Background(), &memoryTunerHost{memoryLimit: tt.memoryLimit}))
+			assert.Equal(t, tt.expectWarn, logs.Len() == 1)
+			require.NoError(t, ml.shutdown(context.Background()))
+		})
+	}
+}
+
+type memoryTunerHost struct {
+	component.Host
+	memoryLimit uint64
+}
+
+func (h *memoryTunerHost) GetExtensions() map[component.ID]component.Component {
+	ret := make(map[component.ID]component.Component)
+	ret[component.NewID("memory_tuner")] = &memoryTunerExtension{memoryLimit: h.memoryLimit}
+	return ret
+}
+
+type memoryTunerExtension struct {
+	memoryLimit uint64
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+func (mte *memoryTunerExtension) GetMemoryLimit() uint64 {
+	return mte.memoryLimit
+}
+
+type inFlightHost struct {
+	component.Host
+	tracker *inflight.Tracker
+}
+
+func (h inFlightHost) GetInFlightTracker() *inflight.Tracker {
+	return h.tracker
+}
+
+func TestInFlightLimit(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.CheckInterval = 10 * time.Second
+	cfg.MemoryLimitMiB = 1024
+	ml, err := newMemoryLimiter(processortest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	tracker := inflight.NewTracker(100)
+	require.NoError(t, ml.start(context.Background(), inFlightHost{Host: componenttest.NewNopHost(), tracker: tracker}))
+	t.Cleanup(func() {
+		require.NoError(t, ml.shutdown(context.Background()))
+	})
+
+	_, err = ml.processTraces(context.Background(), ptrace.NewTraces())
+	assert.NoError(t, err)
+
+	release := tracker.Component(component.KindExporter, component.NewID("otlp")).Acquire(101)
+	_, err = ml.processTraces(context.Background(), ptrace.NewTraces())
+	assert.ErrorIs(t, err, errDataRefused)
+	_, err = ml.processMetrics(context.Background(), pmetric.NewMetrics())
+	assert.ErrorIs(t, err, errDataRefused)
+	_, err = ml.processLogs(context.Background(), plog.NewLogs())
+	assert.ErrorIs(t, err, errDataRefused)
+
+	release()
+	_, err = ml.processLogs(context.Background(), plog.NewLogs())
+	assert.NoError(t, err)
+}
+
+func TestSignalLimits(t *testing.T) {
+	var currentMemAlloc uint64
+	cfg := createDefaultConfig().(*Config)
+	cfg.CheckInterval = 10 * time.Second
+	cfg.MemoryLimitMiB = 1000
+	cfg.MemorySpikeLimitMiB = 200
+	cfg.Signals = map[component.DataType]SignalLimits{
+		component.DataTypeLogs: {MemoryLimitMiB: 500, MemorySpikeLimitMiB: 100},
+	}
+	ml, err := newMemoryLimiter(processortest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	ml.readMemStatsFn = func(ms *runtime.MemStats) {
+		ms.Alloc = currentMemAlloc
+	}
+
+	// Below the soft limit of the logs.
+	currentMemAlloc = 300 * mibBytes
+	ml.checkMemLimits()
+	_, err = ml.processLogs(context.Background(), plog.NewLogs())
+	assert.NoError(t, err)
+
+	// Above the soft limit of the logs only: the traces and the metrics are still accepted.
+	currentMemAlloc = 450 * mibBytes
+	ml.checkMemLimits()
+	_, err = ml.processLogs(context.Background(), plog.NewLogs())
+	assert.ErrorIs(t, err, errDataRefused)
+	_, err = ml.processTraces(context.Background(), ptrace.NewTraces())
+	assert.NoError(t, err)
+	_, err = ml.processMetrics(context.Background(), pmetric.NewMetrics())
+	assert.NoError(t, err)
+
+	currentMemAlloc = 300 * mibBytes
+	ml.checkMemLimits()
+	_, err = ml.processLogs(context.Background(), plog.NewLogs())
+	assert.NoError(t, err)
+}
+
+func TestSignalLimitsOutOfRange(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.CheckInterval = 10 * time.Second
+	cfg.MemoryLimitMiB = 1000
+	cfg.Signals = map[component.DataType]SignalLimits{
+		component.DataTypeLogs: {MemoryLimitMiB: 2000},
+	}
+	_, err := newMemoryLimiter(processortest.NewNopCreateSettings(), cfg)
+	assert.ErrorIs(t, err, errSignalLimitOutOfRange)
+
+	cfg.Signals = map[component.DataType]SignalLimits{
+		component.DataTypeLogs: {MemoryLimitMiB: 500, MemorySpikeLimitMiB: 500},
+	}
+	_, err = newMemoryLimiter(processortest.NewNopCreateSettings(), cfg)
+	assert.ErrorIs(t, err, errMemSpikeLimitOutOfRange)
+}
+
+func TestTotalMemoryWarning(t *testing.T) {
+	t.Cleanup(func() {
+		getMemoryFn = iruntime.TotalMemory
+	})
+	getMemoryFn = func() (uint64, error) {
+		return 512 * mibBytes, nil
+	}
+	core, logs := observer.New(zap.WarnLevel)
+	set := processortest.NewNopCreateSettings()
+	set.Logger = zap.New(core)
+	cfg := createDefaultConfig().(*Config)
+	cfg.CheckInterval = 10 * time.Second
+	cfg.MemoryLimitMiB = 1024
+	_, err := newMemoryLimiter(set, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, 1, logs.FilterMessageSnippet("greater than the total memory").Len())
+}
+
+func TestMemoryLimitStrategy(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.CheckInterval = 10 * time.Second
+	cfg.MemoryLimitMiB = 1000
+	cfg.MemorySpikeLimitMiB = 200
+	cfg.GCStrategy = GCStrategyMemoryLimit
+	cfg.ForceGCAboveHardLimit = false
+	ml, err := newMemoryLimiter(processortest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	var currentMemAlloc uint64
+	ml.readMemStatsFn = func(ms *runtime.MemStats) {
+		ms.Alloc = currentMemAlloc
+	}
+	memoryLimit := int64(math.MaxInt64)
+	gcPercent := 100
+	ml.tuner.LookupEnv = func(string) (string, bool) { return "", false }
+	ml.tuner.SetMemoryLimit = func(limit int64) int64 {
+		prev := memoryLimit
+		memoryLimit = limit
+		return prev
+	}
+	ml.tuner.SetGCPercent = func(percent int) int {
+		prev := gcPercent
+		gcPercent = percent
+		return prev
+	}
+
+	require.NoError(t, ml.start(context.Background(), &host{ballastSize: 100 * mibBytes}))
+	assert.Equal(t, int64(1100*mibBytes), memoryLimit)
+
+	currentMemAlloc = 800 * mibBytes
+	ml.checkMemLimits()
+	assert.False(t, ml.mustRefuseData(component.DataTypeTraces))
+	assert.Equal(t, 100, gcPercent)
+
+	// Above the soft limit: GOGC is lowered instead of forcing a GC.
+	currentMemAlloc = 900 * mibBytes
+	ml.checkMemLimits()
+	assert.True(t, ml.mustRefuseData(component.DataTypeTraces))
+	assert.Equal(t, defaultMinGOGC, gcPercent)
+	assert.True(t, ml.lastGCDone.IsZero())
+
+	currentMemAlloc = 1200 * mibBytes
+	ml.checkMemLimits()
+	assert.True(t, ml.mustRefuseData(component.DataTypeTraces))
+	assert.True(t, ml.lastGCDone.IsZero())
+
+	currentMemAlloc = 700 * mibBytes
+	ml.checkMemLimits()
+	assert.False(t, ml.mustRefuseData(component.DataTypeTraces))
+	assert.Equal(t, 100, gcPercent)
+
+	// The GC is forced above the hard limit as a last resort, if enabled.
+	ml.forceGCAboveHardLimit = true
+	currentMemAlloc = 1200 * mibBytes
+	ml.checkMemLimits()
+	assert.True(t, ml.mustRefuseData(component.DataTypeTraces))
+	assert.False(t, ml.lastGCDone.IsZero())
+	assert.Equal(t, defaultMinGOGC, gcPercent)
+
+	require.NoError(t, ml.shutdown(context.Background()))
+	assert.Equal(t, int64(math.MaxInt64), memoryLimit)
+	assert.Equal(t, 100, gcPercent)
+}
+
+func TestMemoryLimitStrategyManagedLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		host      component.Host
+		lookupEnv func(string) (string, bool)
+		// expectGCPercent is GOGC above the soft limit, left unchanged if the extension tunes the Go runtime.
+		expectGCPercent int
+	}{
+		{
+			name:            "gomemlimit",
+			host:            componenttest.NewNopHost(),
+			lookupEnv:       func(string) (string, bool) { return "1GiB", true },
+			expectGCPercent: defaultMinGOGC,
+		},
+		{
+			name:            "memory_tuner",
+			host:            &memoryTunerHost{memoryLimit: 2048 * mibBytes},
+			lookupEnv:       func(string) (string, bool) { return "", false },
+			expectGCPercent: 100,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.CheckInterval = 10 * time.Second
+			cfg.MemoryLimitMiB = 1024
+			cfg.GCStrategy = GCStrategyMemoryLimit
+			ml, err := newMemoryLimiter(processortest.NewNopCreateSettings(), cfg)
+			require.NoError(t, err)
+			ml.tuner.LookupEnv = tt.lookupEnv
+			ml.tuner.SetMemoryLimit = func(limit int64) int64 {
+				// a negative limit only reads the memory limit
+				if limit >= 0 {
+					t.Fatal("the memory limit must not be changed")
+				}
+				return 1024 * mibBytes
+			}
+			gcPercent := 100
+			ml.tuner.SetGCPercent = func(percent int) int {
+				prev := gcPercent
+				gcPercent = percent
+				return prev
+			}
+			var currentMemAlloc uint64
+			ml.readMemStatsFn = func(ms *runtime.MemStats) {
+				ms.Alloc = currentMemAlloc
+			}
+
+			require.NoError(t, ml.start(context.Background(), tt.host))
+			currentMemAlloc = 1000 * mibBytes
+			ml.checkMemLimits()
+			assert.True(t, ml.mustRefuseData(component.DataTypeTraces))
+			assert.Equal(t, tt.expectGCPercent, gcPercent)
+			assert.True(t, ml.lastGCDone.IsZero())
+			require.NoError(t, ml.shutdown(context.Background()))
+		})
+	}
+}
+
+func TestMemoryLimitStrategySingleTuner(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.CheckInterval = 10 * time.Second
+	cfg.MemoryLimitMiB = 1024
+	cfg.GCStrategy = GCStrategyMemoryLimit
+	memoryLimit := int64(math.MaxInt64)
+	newLimiter := func() *memoryLimiter {
+		ml, err := newMemoryLimiter(processortest.NewNopCreateSettings(), cfg)
+		require.NoError(t, err)
+		ml.tuner.LookupEnv = func(string) (string, bool) { return "", false }
+		ml.tuner.SetMemoryLimit = func(limit int64) int64 {
+			prev := memoryLimit
+			memoryLimit = limit
+			return prev
+		}
+		return ml
+	}
+
+	first := newLimiter()
+	second := newLimiter()
+	require.NoError(t, first.start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, second.start(context.Background(), componenttest.NewNopHost()))
+	// The settings of the Go runtime are only tuned by the first memory limiter.
+	assert.NotNil(t, first.tuner)
+	assert.Nil(t, second.tuner)
+	assert.Equal(t, int64(1024*mibBytes), memoryLimit)
+	require.NoError(t, second.shutdown(context.Background()))
+	require.NoError(t, first.shutdown(context.Background()))
+	assert.Equal(t, int64(math.MaxInt64), memoryLimit)
+}
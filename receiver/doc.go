@@ -28,6 +28,12 @@
 // retried. In case of OTLP/HTTP for example, this means that HTTP 429 or 503 response
 // is returned.
 //
+// The error may also report that only a part of the data was rejected, which the receiver
+// can check with the consumererror.AsPartialSuccess() helper. If the partial success is
+// not retryable, the receiver should acknowledge the other items and only report the
+// number of items rejected to the sender, if the receiving protocol allows to do that,
+// e.g. with the partial_success field of the OTLP responses.
+//
 // # Acknowledgment and Checkpointing
 //
 // The receivers that receive data via a network protocol that support acknowledgments
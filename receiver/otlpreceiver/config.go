@@ -13,6 +13,7 @@ import (
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
 )
 
 const (
@@ -32,6 +33,14 @@ type HTTPConfig struct {
 
 	// The URL path to receive logs on. If omitted "/v1/logs" will be used.
 	LogsURLPath string `mapstructure:"logs_url_path,omitempty"`
+
+	// GRPCWeb enables the gRPC-Web requests to the OTLP gRPC services, on their gRPC method paths,
+	// e.g. from the browsers. Disabled by default.
+	GRPCWeb bool `mapstructure:"grpc_web"`
+
+	// Encoding is the name of the encoding, e.g. provided by an encoding extension, of the requests whose
+	// content type is neither OTLP protobuf nor JSON. If omitted these requests are rejected.
+	Encoding string `mapstructure:"encoding"`
 }
 
 // Protocols is the configuration for the supported protocols.
@@ -44,6 +53,10 @@ type Protocols struct {
 type Config struct {
 	// Protocols is the configuration for the supported protocols, currently gRPC and HTTP (Proto and JSON).
 	Protocols `mapstructure:"protocols"`
+
+	// AuthAttributes configures the client.AuthData attributes, set by the authenticators of the
+	// protocols, stamped onto the resource attributes of the received data.
+	AuthAttributes receiverhelper.AuthAttributesSettings `mapstructure:"auth_attributes"`
 }
 
 var _ component.Config = (*Config)(nil)
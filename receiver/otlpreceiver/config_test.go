@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
@@ -18,6 +19,7 @@ import (
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
 )
 
 func TestUnmarshalDefaultConfig(t *testing.T) {
@@ -134,6 +136,9 @@ func TestUnmarshalConfig(t *testing.T) {
 					LogsURLPath:    "/log/ingest",
 				},
 			},
+			AuthAttributes: receiverhelper.AuthAttributesSettings{
+				Attributes: map[string]string{client.AuthAttributeTenant: "tenant.id"},
+			},
 		}, cfg)
 
 }
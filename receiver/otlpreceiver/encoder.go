@@ -4,9 +4,6 @@
 package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
 
 import (
-	"bytes"
-
-	"github.com/gogo/protobuf/jsonpb"
 	"github.com/gogo/protobuf/proto"
 	spb "google.golang.org/genproto/googleapis/rpc/status"
 
@@ -20,11 +17,7 @@ const (
 	jsonContentType = "application/json"
 )
 
-var (
-	pbEncoder     = &protoEncoder{}
-	jsEncoder     = &jsonEncoder{}
-	jsonMarshaler = &jsonpb.Marshaler{}
-)
+var pbEncoder = &protoEncoder{}
 
 type encoder interface {
 	unmarshalTracesRequest(buf []byte) (ptraceotlp.ExportRequest, error)
@@ -43,18 +36,27 @@ type encoder interface {
 type protoEncoder struct{}
 
 func (protoEncoder) unmarshalTracesRequest(buf []byte) (ptraceotlp.ExportRequest, error) {
+	if pooledRequestsFeatureGate.IsEnabled() {
+		return unmarshalPooledTraces(buf)
+	}
 	req := ptraceotlp.NewExportRequest()
 	err := req.UnmarshalProto(buf)
 	return req, err
 }
 
 func (protoEncoder) unmarshalMetricsRequest(buf []byte) (pmetricotlp.ExportRequest, error) {
+	if pooledRequestsFeatureGate.IsEnabled() {
+		return unmarshalPooledMetrics(buf)
+	}
 	req := pmetricotlp.NewExportRequest()
 	err := req.UnmarshalProto(buf)
 	return req, err
 }
 
 func (protoEncoder) unmarshalLogsRequest(buf []byte) (plogotlp.ExportRequest, error) {
+	if pooledRequestsFeatureGate.IsEnabled() {
+		return unmarshalPooledLogs(buf)
+	}
 	req := plogotlp.NewExportRequest()
 	err := req.UnmarshalProto(buf)
 	return req, err
@@ -80,44 +82,16 @@ func (protoEncoder) contentType() string {
 	return pbContentType
 }
 
-type jsonEncoder struct{}
-
-func (jsonEncoder) unmarshalTracesRequest(buf []byte) (ptraceotlp.ExportRequest, error) {
-	req := ptraceotlp.NewExportRequest()
-	err := req.UnmarshalJSON(buf)
-	return req, err
-}
-
-func (jsonEncoder) unmarshalMetricsRequest(buf []byte) (pmetricotlp.ExportRequest, error) {
-	req := pmetricotlp.NewExportRequest()
-	err := req.UnmarshalJSON(buf)
-	return req, err
-}
-
-func (jsonEncoder) unmarshalLogsRequest(buf []byte) (plogotlp.ExportRequest, error) {
-	req := plogotlp.NewExportRequest()
-	err := req.UnmarshalJSON(buf)
-	return req, err
-}
-
-func (jsonEncoder) marshalTracesResponse(resp ptraceotlp.ExportResponse) ([]byte, error) {
-	return resp.MarshalJSON()
-}
-
-func (jsonEncoder) marshalMetricsResponse(resp pmetricotlp.ExportResponse) ([]byte, error) {
-	return resp.MarshalJSON()
-}
-
-func (jsonEncoder) marshalLogsResponse(resp plogotlp.ExportResponse) ([]byte, error) {
-	return resp.MarshalJSON()
-}
-
-func (jsonEncoder) marshalStatus(resp *spb.Status) ([]byte, error) {
-	buf := new(bytes.Buffer)
-	err := jsonMarshaler.Marshal(buf, resp)
-	return buf.Bytes(), err
-}
-
-func (jsonEncoder) contentType() string {
-	return jsonContentType
+// encoderFor returns the encoder of the given content type, or nil if the content type is not supported.
+func encoderFor(contentType string) encoder {
+	switch getMimeTypeFromContentType(contentType) {
+	case pbContentType:
+		return pbEncoder
+	case jsonContentType:
+		// jsEncoder is nil if the JSON support is not included in the build.
+		if jsEncoder != nil {
+			return jsEncoder
+		}
+	}
+	return nil
 }
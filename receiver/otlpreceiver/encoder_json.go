@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !otelcol_nojson
+
+package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
+
+import (
+	"bytes"
+
+	"github.com/gogo/protobuf/jsonpb"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+var (
+	jsEncoder     encoder = &jsonEncoder{}
+	jsonMarshaler         = &jsonpb.Marshaler{}
+
+	supportedContentTypes = []string{jsonContentType, pbContentType}
+)
+
+type jsonEncoder struct{}
+
+func (jsonEncoder) unmarshalTracesRequest(buf []byte) (ptraceotlp.ExportRequest, error) {
+	req := ptraceotlp.NewExportRequest()
+	objs := splitJSONObjects(buf)
+	if len(objs) <= 1 {
+		err := req.UnmarshalJSON(buf)
+		return req, err
+	}
+	for _, obj := range objs {
+		next := ptraceotlp.NewExportRequest()
+		if err := next.UnmarshalJSON(obj); err != nil {
+			return req, err
+		}
+		next.Traces().ResourceSpans().MoveAndAppendTo(req.Traces().ResourceSpans())
+	}
+	return req, nil
+}
+
+func (jsonEncoder) unmarshalMetricsRequest(buf []byte) (pmetricotlp.ExportRequest, error) {
+	req := pmetricotlp.NewExportRequest()
+	objs := splitJSONObjects(buf)
+	if len(objs) <= 1 {
+		err := req.UnmarshalJSON(buf)
+		return req, err
+	}
+	for _, obj := range objs {
+		next := pmetricotlp.NewExportRequest()
+		if err := next.UnmarshalJSON(obj); err != nil {
+			return req, err
+		}
+		next.Metrics().ResourceMetrics().MoveAndAppendTo(req.Metrics().ResourceMetrics())
+	}
+	return req, nil
+}
+
+func (jsonEncoder) unmarshalLogsRequest(buf []byte) (plogotlp.ExportRequest, error) {
+	req := plogotlp.NewExportRequest()
+	objs := splitJSONObjects(buf)
+	if len(objs) <= 1 {
+		err := req.UnmarshalJSON(buf)
+		return req, err
+	}
+	for _, obj := range objs {
+		next := plogotlp.NewExportRequest()
+		if err := next.UnmarshalJSON(obj); err != nil {
+			return req, err
+		}
+		next.Logs().ResourceLogs().MoveAndAppendTo(req.Logs().ResourceLogs())
+	}
+	return req, nil
+}
+
+func (jsonEncoder) marshalTracesResponse(resp ptraceotlp.ExportResponse) ([]byte, error) {
+	return resp.MarshalJSON()
+}
+
+func (jsonEncoder) marshalMetricsResponse(resp pmetricotlp.ExportResponse) ([]byte, error) {
+	return resp.MarshalJSON()
+}
+
+func (jsonEncoder) marshalLogsResponse(resp plogotlp.ExportResponse) ([]byte, error) {
+	return resp.MarshalJSON()
+}
+
+func (jsonEncoder) marshalStatus(resp *spb.Status) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	err := jsonMarshaler.Marshal(buf, resp)
+	return buf.Bytes(), err
+}
+
+func (jsonEncoder) contentType() string {
+	return jsonContentType
+}
+
+// splitJSONObjects splits the concatenated top-level JSON objects of buf, e.g. sent by clients appending
+// the requests to the body as they are produced. It returns nil if buf isn't a sequence of JSON objects,
+// in which case buf is unmarshaled as a single request to report the error.
+func splitJSONObjects(buf []byte) [][]byte {
+	var (
+		objs     [][]byte
+		start    int
+		depth    int
+		inString bool
+		escaped  bool
+	)
+	for i, c := range buf {
+		switch {
+		case inString:
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+		case c == '"':
+			if depth == 0 {
+				return nil
+			}
+			inString = true
+		case c == '{' || c == '[':
+			if depth == 0 {
+				if c != '{' {
+					return nil
+				}
+				start = i
+			}
+			depth++
+		case c == '}' || c == ']':
+			depth--
+			if depth < 0 {
+				return nil
+			}
+			if depth == 0 {
+				objs = append(objs, buf[start:i+1])
+			}
+		case depth == 0 && c != ' ' && c != '\t' && c != '\r' && c != '\n':
+			return nil
+		}
+	}
+	if depth != 0 {
+		return nil
+	}
+	return objs
+}
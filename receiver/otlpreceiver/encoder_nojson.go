@@ -0,0 +1,13 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build otelcol_nojson
+
+package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
+
+var (
+	// jsEncoder is nil as the JSON support is not included in builds with the otelcol_nojson tag.
+	jsEncoder encoder
+
+	supportedContentTypes = []string{pbContentType}
+)
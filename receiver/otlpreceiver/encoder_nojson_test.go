@@ -0,0 +1,18 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build otelcol_nojson
+
+package otlpreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncoderForNoJSON(t *testing.T) {
+	assert.Equal(t, pbEncoder, encoderFor("application/x-protobuf"))
+	assert.Nil(t, encoderFor("application/json"))
+	assert.Equal(t, []string{pbContentType}, supportedContentTypes)
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/encoding"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+// registryEncoder unmarshals the requests with the encoding set by HTTPConfig.Encoding, and marshals
+// the responses in OTLP protobuf. The unmarshalers are only set for the signals received.
+type registryEncoder struct {
+	protoEncoder
+	tracesUnmarshaler  ptrace.Unmarshaler
+	metricsUnmarshaler pmetric.Unmarshaler
+	logsUnmarshaler    plog.Unmarshaler
+}
+
+// newRegistryEncoder resolves the unmarshalers of the encoding from the encodings of the host, failing
+// if the encoding is unknown or doesn't support one of the signals received.
+func (r *otlpReceiver) newRegistryEncoder(host component.Host) (*registryEncoder, error) {
+	registry, err := encoding.NewRegistryFromExtensions(host.GetExtensions())
+	if err != nil {
+		return nil, err
+	}
+	name := r.cfg.HTTP.Encoding
+	enc := &registryEncoder{}
+	if r.tracesReceiver != nil {
+		if enc.tracesUnmarshaler, err = registry.TracesUnmarshaler(name); err != nil {
+			return nil, err
+		}
+	}
+	if r.metricsReceiver != nil {
+		if enc.metricsUnmarshaler, err = registry.MetricsUnmarshaler(name); err != nil {
+			return nil, err
+		}
+	}
+	if r.logsReceiver != nil {
+		if enc.logsUnmarshaler, err = registry.LogsUnmarshaler(name); err != nil {
+			return nil, err
+		}
+	}
+	return enc, nil
+}
+
+func (e *registryEncoder) unmarshalTracesRequest(buf []byte) (ptraceotlp.ExportRequest, error) {
+	td, err := e.tracesUnmarshaler.UnmarshalTraces(buf)
+	if err != nil {
+		return ptraceotlp.NewExportRequest(), err
+	}
+	return ptraceotlp.NewExportRequestFromTraces(td), nil
+}
+
+func (e *registryEncoder) unmarshalMetricsRequest(buf []byte) (pmetricotlp.ExportRequest, error) {
+	md, err := e.metricsUnmarshaler.UnmarshalMetrics(buf)
+	if err != nil {
+		return pmetricotlp.NewExportRequest(), err
+	}
+	return pmetricotlp.NewExportRequestFromMetrics(md), nil
+}
+
+func (e *registryEncoder) unmarshalLogsRequest(buf []byte) (plogotlp.ExportRequest, error) {
+	ld, err := e.logsUnmarshaler.UnmarshalLogs(buf)
+	if err != nil {
+		return plogotlp.NewExportRequest(), err
+	}
+	return plogotlp.NewExportRequestFromLogs(ld), nil
+}
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !otelcol_nojson
+
+package otlpreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncoderFor(t *testing.T) {
+	assert.Equal(t, pbEncoder, encoderFor("application/x-protobuf"))
+	assert.Equal(t, jsEncoder, encoderFor("application/json; charset=utf-8"))
+	assert.Nil(t, encoderFor("text/plain"))
+	assert.Equal(t, []string{jsonContentType, pbContentType}, supportedContentTypes)
+}
+
+func TestSplitJSONObjects(t *testing.T) {
+	tests := []struct {
+		name string
+		buf  string
+		objs []string
+	}{
+		{name: "Empty", buf: ""},
+		{name: "Single", buf: `{"a": 1}`, objs: []string{`{"a": 1}`}},
+		{name: "Concatenated", buf: "{\"a\": [{}]}\n {\"b\": 2}", objs: []string{`{"a": [{}]}`, `{"b": 2}`}},
+		{name: "Adjacent", buf: `{}{}`, objs: []string{`{}`, `{}`}},
+		{name: "Strings", buf: `{"a": "}{\"\\"}{"b": "]"}`, objs: []string{`{"a": "}{\"\\"}`, `{"b": "]"}`}},
+		{name: "TopLevelArray", buf: `[{}]`},
+		{name: "TrailingData", buf: `{} x`},
+		{name: "Unbalanced", buf: `{}{`},
+		{name: "TooManyClosed", buf: `{}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var objs []string
+			for _, obj := range splitJSONObjects([]byte(tt.buf)) {
+				objs = append(objs, string(obj))
+			}
+			assert.Equal(t, tt.objs, objs)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/internal/testutil"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func FuzzHTTPTraces(f *testing.F) {
+	endpoint := testutil.GetAvailableLocalAddress(f)
+	cfg := createDefaultConfig().(*Config)
+	cfg.GRPC = nil
+	cfg.HTTP.Endpoint = endpoint
+	receivertest.FuzzReceiver(receivertest.FuzzReceiverParams{
+		F:        f,
+		Factory:  NewFactory(),
+		DataType: component.DataTypeTraces,
+		Config:   cfg,
+		Send:     receivertest.NewHTTPFuzzSendFunc("http://"+endpoint+defaultTracesURLPath, pbContentType),
+	})
+}
+
+func FuzzGRPCTraces(f *testing.F) {
+	endpoint := testutil.GetAvailableLocalAddress(f)
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTP = nil
+	cfg.GRPC.NetAddr.Endpoint = endpoint
+	receivertest.FuzzReceiver(receivertest.FuzzReceiverParams{
+		F:        f,
+		Factory:  NewFactory(),
+		DataType: component.DataTypeTraces,
+		Config:   cfg,
+		Send:     receivertest.NewGRPCFuzzSendFunc(f, endpoint, "/opentelemetry.proto.collector.trace.v1.TraceService/Export"),
+	})
+}
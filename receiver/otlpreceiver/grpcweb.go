@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// Paths of the gRPC-Web requests, the full names of the Export methods of the OTLP gRPC services.
+const (
+	grpcWebTracesPath  = "/opentelemetry.proto.collector.trace.v1.TraceService/Export"
+	grpcWebMetricsPath = "/opentelemetry.proto.collector.metrics.v1.MetricsService/Export"
+	grpcWebLogsPath    = "/opentelemetry.proto.collector.logs.v1.LogsService/Export"
+)
+
+const (
+	grpcWebContentType     = "application/grpc-web"
+	grpcWebTextContentType = "application/grpc-web-text"
+
+	// grpcWebFrameHeaderLen is the length of the header of a gRPC-Web frame: the flags byte and the length of the frame.
+	grpcWebFrameHeaderLen = 5
+	// grpcWebFlagCompressed is set in the flags of the data frames holding a compressed message.
+	grpcWebFlagCompressed = 0x01
+	// grpcWebFlagTrailers is set in the flags of the frame holding the trailers of the response.
+	grpcWebFlagTrailers = 0x80
+)
+
+// grpcWebCORSHeaders are the request headers set by the gRPC-Web clients, allowed in the CORS requests. The
+// Content-Type header must be allowed since the gRPC-Web content types are not allowed in simple requests.
+var grpcWebCORSHeaders = []string{"Content-Type", "X-Grpc-Web", "X-User-Agent", "Grpc-Timeout"}
+
+// grpcWebExportFunc decodes the request message, calls the handler of the gRPC service and encodes the response message.
+type grpcWebExportFunc func(ctx context.Context, msg []byte) ([]byte, error)
+
+// handleGRPCWeb handles a gRPC-Web request to a unary method: the request message is decoded from the single data
+// frame of the request, and the response message and the status are written as a data frame and a trailers frame.
+// Both the binary (application/grpc-web) and the base64 (application/grpc-web-text) encodings are supported.
+func handleGRPCWeb(resp http.ResponseWriter, req *http.Request, export grpcWebExportFunc) {
+	if req.Method != http.MethodPost {
+		handleUnmatchedMethod(resp)
+		return
+	}
+	contentType := getMimeTypeFromContentType(req.Header.Get("Content-Type"))
+	var text bool
+	switch contentType {
+	case grpcWebContentType, grpcWebContentType + "+proto":
+	case grpcWebTextContentType, grpcWebTextContentType + "+proto":
+		text = true
+	default:
+		status := http.StatusUnsupportedMediaType
+		writeResponse(resp, "text/plain", status, []byte(fmt.Sprintf("%v unsupported media type, supported: [%s, %s]",
+			status, grpcWebContentType, grpcWebTextContentType)))
+		return
+	}
+
+	var msg []byte
+	body, err := io.ReadAll(req.Body)
+	if err == nil {
+		err = req.Body.Close()
+	}
+	if err == nil && text {
+		body, err = decodeGRPCWebText(body)
+	}
+	if err == nil {
+		msg, err = readGRPCWebMessage(body, req.Header.Get("Grpc-Encoding"))
+	}
+	var out []byte
+	if err != nil {
+		err = status.Error(codes.InvalidArgument, err.Error())
+	} else {
+		out, err = export(req.Context(), msg)
+	}
+
+	var frames bytes.Buffer
+	if err == nil {
+		writeGRPCWebFrame(&frames, 0, out)
+	}
+	writeGRPCWebFrame(&frames, grpcWebFlagTrailers, grpcWebTrailers(status.Convert(err)))
+	payload := frames.Bytes()
+	if text {
+		payload = []byte(base64.StdEncoding.EncodeToString(payload))
+	}
+	writeResponse(resp, contentType, http.StatusOK, payload)
+}
+
+// decodeGRPCWebText decodes a base64 encoded request body, which may be made of several padded base64 chunks.
+func decodeGRPCWebText(body []byte) ([]byte, error) {
+	if len(body)%4 != 0 {
+		return nil, errors.New("invalid base64 body length")
+	}
+	out := make([]byte, 0, base64.StdEncoding.DecodedLen(len(body)))
+	var block [3]byte
+	for i := 0; i < len(body); i += 4 {
+		n, err := base64.StdEncoding.Decode(block[:], body[i:i+4])
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 body: %w", err)
+		}
+		out = append(out, block[:n]...)
+	}
+	return out, nil
+}
+
+// readGRPCWebMessage returns the message of the single data frame of a request body.
+func readGRPCWebMessage(body []byte, encoding string) ([]byte, error) {
+	if len(body) < grpcWebFrameHeaderLen {
+		return nil, errors.New("missing request message")
+	}
+	flags := body[0]
+	length := binary.BigEndian.Uint32(body[1:grpcWebFrameHeaderLen])
+	if uint64(len(body)-grpcWebFrameHeaderLen) != uint64(length) {
+		return nil, errors.New("the request must hold exactly one message")
+	}
+	msg := body[grpcWebFrameHeaderLen:]
+	if flags&grpcWebFlagCompressed == 0 {
+		return msg, nil
+	}
+	if encoding != "gzip" {
+		return nil, fmt.Errorf("unsupported message encoding %q", encoding)
+	}
+	gr, err := gzip.NewReader(bytes.NewReader(msg))
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(gr)
+}
+
+func writeGRPCWebFrame(w *bytes.Buffer, flags byte, data []byte) {
+	var header [grpcWebFrameHeaderLen]byte
+	header[0] = flags
+	binary.BigEndian.PutUint32(header[1:], uint32(len(data)))
+	w.Write(header[:])
+	w.Write(data)
+}
+
+// grpcWebTrailers encodes the status of the response as the trailers frame of the response.
+func grpcWebTrailers(s *status.Status) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "grpc-status: %d\r\n", s.Code())
+	if s.Message() != "" {
+		fmt.Fprintf(&b, "grpc-message: %s\r\n", url.PathEscape(s.Message()))
+	}
+	return []byte(b.String())
+}
+
+// grpcWebServerSettings returns the HTTP server settings allowing the gRPC-Web request headers in the CORS requests.
+func grpcWebServerSettings(hss *confighttp.HTTPServerSettings) *confighttp.HTTPServerSettings {
+	if hss.CORS == nil || len(hss.CORS.AllowedOrigins) == 0 {
+		return hss
+	}
+	for _, h := range hss.CORS.AllowedHeaders {
+		if h == "*" {
+			return hss
+		}
+	}
+	cors := *hss.CORS
+	cors.AllowedHeaders = append(append([]string{}, hss.CORS.AllowedHeaders...), grpcWebCORSHeaders...)
+	settings := *hss
+	settings.CORS = &cors
+	return &settings
+}
@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/internal/testutil"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+func grpcWebFrame(flags byte, data []byte) []byte {
+	var buf bytes.Buffer
+	writeGRPCWebFrame(&buf, flags, data)
+	return buf.Bytes()
+}
+
+// parseGRPCWebResponse returns the message and the trailers of a gRPC-Web response body.
+func parseGRPCWebResponse(t *testing.T, body []byte) ([]byte, string) {
+	var msg []byte
+	var trailers string
+	for len(body) > 0 {
+		require.GreaterOrEqual(t, len(body), grpcWebFrameHeaderLen)
+		length := int(binary.BigEndian.Uint32(body[1:grpcWebFrameHeaderLen]))
+		require.GreaterOrEqual(t, len(body), grpcWebFrameHeaderLen+length)
+		data := body[grpcWebFrameHeaderLen : grpcWebFrameHeaderLen+length]
+		if body[0]&grpcWebFlagTrailers != 0 {
+			trailers = string(data)
+		} else {
+			msg = data
+		}
+		body = body[grpcWebFrameHeaderLen+length:]
+	}
+	return msg, trailers
+}
+
+func TestGRPCWebTraces(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	sink := &errOrSinkConsumer{TracesSink: new(consumertest.TracesSink)}
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.HTTP.Endpoint = addr
+	cfg.HTTP.GRPCWeb = true
+	cfg.GRPC = nil
+	r := newReceiver(t, factory, cfg, otlpReceiverID, sink, nil)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+	td := testdata.GenerateTraces(2)
+	msg, err := ptraceotlp.NewExportRequestFromTraces(td).MarshalProto()
+	require.NoError(t, err)
+	url := "http://" + addr + grpcWebTracesPath
+
+	send := func(contentType string, body []byte) ([]byte, string) {
+		resp, err := http.Post(url, contentType, bytes.NewReader(body))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, contentType, resp.Header.Get("Content-Type"))
+		respBody, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return respBody, resp.Header.Get("Content-Type")
+	}
+
+	body, _ := send("application/grpc-web+proto", grpcWebFrame(0, msg))
+	respMsg, trailers := parseGRPCWebResponse(t, body)
+	assert.Equal(t, "grpc-status: 0\r\n", trailers)
+	assert.NoError(t, ptraceotlp.NewExportResponse().UnmarshalProto(respMsg))
+	require.Len(t, sink.AllTraces(), 1)
+	assert.Equal(t, td, sink.AllTraces()[0])
+
+	// The text requests may be made of several padded base64 chunks.
+	frame := grpcWebFrame(0, msg)
+	text := base64.StdEncoding.EncodeToString(frame[:4]) + base64.StdEncoding.EncodeToString(frame[4:])
+	body, _ = send("application/grpc-web-text", []byte(text))
+	decoded, err := base64.StdEncoding.DecodeString(string(body))
+	require.NoError(t, err)
+	_, trailers = parseGRPCWebResponse(t, decoded)
+	assert.Equal(t, "grpc-status: 0\r\n", trailers)
+	assert.Len(t, sink.AllTraces(), 2)
+
+	sink.SetConsumeError(errors.New("consumer failed"))
+	body, _ = send("application/grpc-web", grpcWebFrame(0, msg))
+	respMsg, trailers = parseGRPCWebResponse(t, body)
+	assert.Nil(t, respMsg)
+	assert.Equal(t, "grpc-status: 2\r\ngrpc-message: consumer%20failed\r\n", trailers)
+
+	body, _ = send("application/grpc-web", grpcWebFrame(0, []byte{0xff})[:3])
+	_, trailers = parseGRPCWebResponse(t, body)
+	assert.Equal(t, "grpc-status: 3\r\ngrpc-message: missing%20request%20message\r\n", trailers)
+
+	resp, err := http.Post(url, "application/json", bytes.NewReader(msg))
+	require.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+
+	resp, err = http.Get(url)
+	require.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestGRPCWebDisabled(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	r := newHTTPReceiver(t, addr, defaultTracesURLPath, defaultMetricsURLPath, defaultLogsURLPath, consumertest.NewNop(), nil)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+	resp, err := http.Post("http://"+addr+grpcWebTracesPath, "application/grpc-web", bytes.NewReader(grpcWebFrame(0, nil)))
+	require.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestGRPCWebCORS(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.HTTP.Endpoint = addr
+	cfg.HTTP.GRPCWeb = true
+	cfg.HTTP.CORS = &confighttp.CORSSettings{AllowedOrigins: []string{"https://example.com"}}
+	cfg.GRPC = nil
+	r := newReceiver(t, factory, cfg, otlpReceiverID, consumertest.NewNop(), nil)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+	req, err := http.NewRequest(http.MethodOptions, "http://"+addr+grpcWebTracesPath, nil)
+	require.NoError(t, err)
+	req.Header.Set("Origin", "https://example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	req.Header.Set("Access-Control-Request-Headers", "content-type,x-grpc-web,x-user-agent")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	assert.Equal(t, "https://example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "Content-Type, X-Grpc-Web, X-User-Agent", resp.Header.Get("Access-Control-Allow-Headers"))
+	assert.Empty(t, cfg.HTTP.CORS.AllowedHeaders)
+}
+
+func TestGRPCWebServerSettings(t *testing.T) {
+	hss := &confighttp.HTTPServerSettings{}
+	assert.Same(t, hss, grpcWebServerSettings(hss))
+
+	hss.CORS = &confighttp.CORSSettings{AllowedOrigins: []string{"*"}, AllowedHeaders: []string{"*"}}
+	assert.Same(t, hss, grpcWebServerSettings(hss))
+
+	hss.CORS.AllowedHeaders = []string{"X-Tenant"}
+	settings := grpcWebServerSettings(hss)
+	assert.Equal(t, []string{"X-Tenant", "Content-Type", "X-Grpc-Web", "X-User-Agent", "Grpc-Timeout"}, settings.CORS.AllowedHeaders)
+	assert.Equal(t, []string{"X-Tenant"}, hss.CORS.AllowedHeaders)
+}
+
+func TestReadGRPCWebMessage(t *testing.T) {
+	msg, err := readGRPCWebMessage(grpcWebFrame(0, []byte("message")), "")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("message"), msg)
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	_, err = gw.Write([]byte("message"))
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+	msg, err = readGRPCWebMessage(grpcWebFrame(grpcWebFlagCompressed, buf.Bytes()), "gzip")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("message"), msg)
+
+	_, err = readGRPCWebMessage(grpcWebFrame(grpcWebFlagCompressed, buf.Bytes()), "snappy")
+	assert.EqualError(t, err, `unsupported message encoding "snappy"`)
+
+	_, err = readGRPCWebMessage(append(grpcWebFrame(0, []byte("a")), grpcWebFrame(0, []byte("b"))...), "")
+	assert.EqualError(t, err, "the request must hold exactly one message")
+}
+
+func TestDecodeGRPCWebText(t *testing.T) {
+	out, err := decodeGRPCWebText([]byte(base64.StdEncoding.EncodeToString([]byte("ab")) + base64.StdEncoding.EncodeToString([]byte("cde"))))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("abcde"), out)
+
+	_, err = decodeGRPCWebText([]byte("abc"))
+	assert.Error(t, err)
+	_, err = decodeGRPCWebText([]byte("a!c="))
+	assert.Error(t, err)
+}
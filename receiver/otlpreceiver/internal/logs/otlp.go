@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 )
@@ -40,5 +41,13 @@ func (r *Receiver) Export(ctx context.Context, req plogotlp.ExportRequest) (plog
 	err := r.nextConsumer.ConsumeLogs(ctx, ld)
 	r.obsrecv.EndLogsOp(ctx, dataFormatProtobuf, numSpans, err)
 
-	return plogotlp.NewExportResponse(), err
+	resp := plogotlp.NewExportResponse()
+	// The items rejected for good are reported to the client, which must not send them again,
+	// the other items being accepted.
+	if p, ok := consumererror.AsPartialSuccess(err); ok && !p.Retryable() && p.Rejected() < numSpans {
+		resp.PartialSuccess().SetRejectedLogRecords(int64(p.Rejected()))
+		resp.PartialSuccess().SetErrorMessage(err.Error())
+		return resp, nil
+	}
+	return resp, err
 }
@@ -16,6 +16,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/obsreport"
@@ -57,6 +58,22 @@ func TestExport_ErrorConsumer(t *testing.T) {
 	assert.Equal(t, plogotlp.ExportResponse{}, resp)
 }
 
+func TestExport_PartialSuccess(t *testing.T) {
+	req := plogotlp.NewExportRequestFromLogs(testdata.GenerateLogs(3))
+
+	partialErr := consumererror.NewPartialSuccess(consumererror.NewPermanent(errors.New("my error")), 2)
+	client := makeLogsServiceClient(t, consumertest.NewErr(partialErr))
+	resp, err := client.Export(context.Background(), req)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, resp.PartialSuccess().RejectedLogRecords())
+	assert.Equal(t, "Permanent error: my error", resp.PartialSuccess().ErrorMessage())
+
+	// The retryable errors are returned, for the client to send the data again.
+	client = makeLogsServiceClient(t, consumertest.NewErr(consumererror.NewPartialSuccess(errors.New("my error"), 2)))
+	_, err = client.Export(context.Background(), req)
+	assert.EqualError(t, err, "rpc error: code = Unknown desc = my error")
+}
+
 func makeLogsServiceClient(t *testing.T, lc consumer.Logs) plogotlp.GRPCClient {
 	addr := otlpReceiverOnGRPCServer(t, lc)
 	cc, err := grpc.Dial(addr.String(), grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
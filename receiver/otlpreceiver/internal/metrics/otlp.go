@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
 )
@@ -40,5 +41,13 @@ func (r *Receiver) Export(ctx context.Context, req pmetricotlp.ExportRequest) (p
 	err := r.nextConsumer.ConsumeMetrics(ctx, md)
 	r.obsrecv.EndMetricsOp(ctx, dataFormatProtobuf, dataPointCount, err)
 
-	return pmetricotlp.NewExportResponse(), err
+	resp := pmetricotlp.NewExportResponse()
+	// The items rejected for good are reported to the client, which must not send them again,
+	// the other items being accepted.
+	if p, ok := consumererror.AsPartialSuccess(err); ok && !p.Retryable() && p.Rejected() < dataPointCount {
+		resp.PartialSuccess().SetRejectedDataPoints(int64(p.Rejected()))
+		resp.PartialSuccess().SetErrorMessage(err.Error())
+		return resp, nil
+	}
+	return resp, err
 }
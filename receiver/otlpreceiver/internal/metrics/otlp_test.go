@@ -16,6 +16,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/obsreport"
@@ -57,6 +58,22 @@ func TestExport_ErrorConsumer(t *testing.T) {
 	assert.Equal(t, pmetricotlp.ExportResponse{}, resp)
 }
 
+func TestExport_PartialSuccess(t *testing.T) {
+	req := pmetricotlp.NewExportRequestFromMetrics(testdata.GenerateMetrics(3))
+
+	partialErr := consumererror.NewPartialSuccess(consumererror.NewPermanent(errors.New("my error")), 2)
+	client := makeMetricsServiceClient(t, consumertest.NewErr(partialErr))
+	resp, err := client.Export(context.Background(), req)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, resp.PartialSuccess().RejectedDataPoints())
+	assert.Equal(t, "Permanent error: my error", resp.PartialSuccess().ErrorMessage())
+
+	// The retryable errors are returned, for the client to send the data again.
+	client = makeMetricsServiceClient(t, consumertest.NewErr(consumererror.NewPartialSuccess(errors.New("my error"), 2)))
+	_, err = client.Export(context.Background(), req)
+	assert.EqualError(t, err, "rpc error: code = Unknown desc = my error")
+}
+
 func makeMetricsServiceClient(t *testing.T, mc consumer.Metrics) pmetricotlp.GRPCClient {
 	addr := otlpReceiverOnGRPCServer(t, mc)
 
@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
 )
@@ -41,5 +42,13 @@ func (r *Receiver) Export(ctx context.Context, req ptraceotlp.ExportRequest) (pt
 	err := r.nextConsumer.ConsumeTraces(ctx, td)
 	r.obsrecv.EndTracesOp(ctx, dataFormatProtobuf, numSpans, err)
 
-	return ptraceotlp.NewExportResponse(), err
+	resp := ptraceotlp.NewExportResponse()
+	// The items rejected for good are reported to the client, which must not send them again,
+	// the other items being accepted.
+	if p, ok := consumererror.AsPartialSuccess(err); ok && !p.Retryable() && p.Rejected() < numSpans {
+		resp.PartialSuccess().SetRejectedSpans(int64(p.Rejected()))
+		resp.PartialSuccess().SetErrorMessage(err.Error())
+		return resp, nil
+	}
+	return resp, err
 }
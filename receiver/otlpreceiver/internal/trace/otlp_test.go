@@ -16,6 +16,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/obsreport"
@@ -55,6 +56,22 @@ func TestExport_ErrorConsumer(t *testing.T) {
 	assert.Equal(t, ptraceotlp.ExportResponse{}, resp)
 }
 
+func TestExport_PartialSuccess(t *testing.T) {
+	req := ptraceotlp.NewExportRequestFromTraces(testdata.GenerateTraces(3))
+
+	partialErr := consumererror.NewPartialSuccess(consumererror.NewPermanent(errors.New("my error")), 2)
+	client := makeTraceServiceClient(t, consumertest.NewErr(partialErr))
+	resp, err := client.Export(context.Background(), req)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, resp.PartialSuccess().RejectedSpans())
+	assert.Equal(t, "Permanent error: my error", resp.PartialSuccess().ErrorMessage())
+
+	// The retryable errors are returned, for the client to send the data again.
+	client = makeTraceServiceClient(t, consumertest.NewErr(consumererror.NewPartialSuccess(errors.New("my error"), 2)))
+	_, err = client.Export(context.Background(), req)
+	assert.EqualError(t, err, "rpc error: code = Unknown desc = my error")
+}
+
 func makeTraceServiceClient(t *testing.T, tc consumer.Traces) ptraceotlp.GRPCClient {
 	addr := otlpReceiverOnGRPCServer(t, tc)
 	cc, err := grpc.Dial(addr.String(), grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
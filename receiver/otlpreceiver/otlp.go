@@ -9,10 +9,13 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"strings"
 	"sync"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configgrpc"
@@ -26,6 +29,7 @@ import (
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/logs"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metrics"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/trace"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
 )
 
 // otlpReceiver is the type that exposes Trace and Metrics reception.
@@ -40,6 +44,9 @@ type otlpReceiver struct {
 	logsReceiver    *logs.Receiver
 	shutdownWG      sync.WaitGroup
 
+	// httpEncoder unmarshals the HTTP requests of the other content types, nil if no encoding is set.
+	httpEncoder *registryEncoder
+
 	obsrepGRPC *obsreport.Receiver
 	obsrepHTTP *obsreport.Receiver
 
@@ -118,7 +125,7 @@ func (r *otlpReceiver) startHTTPServer(cfg *confighttp.HTTPServerSettings, host
 func (r *otlpReceiver) startProtocolServers(host component.Host) error {
 	var err error
 	if r.cfg.GRPC != nil {
-		r.serverGRPC, err = r.cfg.GRPC.ToServer(host, r.settings.TelemetrySettings)
+		r.serverGRPC, err = r.cfg.GRPC.ToServer(host, r.settings.TelemetrySettings, configgrpc.WithComponentID(r.settings.ID))
 		if err != nil {
 			return err
 		}
@@ -141,7 +148,18 @@ func (r *otlpReceiver) startProtocolServers(host component.Host) error {
 		}
 	}
 	if r.cfg.HTTP != nil {
-		r.serverHTTP, err = r.cfg.HTTP.ToServer(
+		if r.cfg.HTTP.Encoding != "" {
+			r.httpEncoder, err = r.newRegistryEncoder(host)
+			if err != nil {
+				return err
+			}
+		}
+
+		hss := r.cfg.HTTP.HTTPServerSettings
+		if r.cfg.HTTP.GRPCWeb {
+			hss = grpcWebServerSettings(hss)
+		}
+		r.serverHTTP, err = hss.ToServer(
 			host,
 			r.settings.TelemetrySettings,
 			r.httpMux,
@@ -186,6 +204,10 @@ func (r *otlpReceiver) registerTraceConsumer(tc consumer.Traces) error {
 	if tc == nil {
 		return component.ErrNilNextConsumer
 	}
+	tc, err := receiverhelper.NewAuthAttributesTraces(r.cfg.AuthAttributes, tc)
+	if err != nil {
+		return err
+	}
 	r.tracesReceiver = trace.New(tc, r.obsrepGRPC)
 	httpTracesReceiver := trace.New(tc, r.obsrepHTTP)
 	if r.httpMux != nil {
@@ -194,15 +216,28 @@ func (r *otlpReceiver) registerTraceConsumer(tc consumer.Traces) error {
 				handleUnmatchedMethod(resp)
 				return
 			}
-			switch getMimeTypeFromContentType(req.Header.Get("Content-Type")) {
-			case pbContentType:
-				handleTraces(resp, req, httpTracesReceiver, pbEncoder)
-			case jsonContentType:
-				handleTraces(resp, req, httpTracesReceiver, jsEncoder)
-			default:
+			enc := r.httpEncoderFor(req.Header.Get("Content-Type"))
+			if enc == nil {
 				handleUnmatchedContentType(resp)
+				return
 			}
+			handleTraces(resp, req, httpTracesReceiver, enc)
 		})
+		if r.cfg.HTTP.GRPCWeb {
+			r.httpMux.HandleFunc(grpcWebTracesPath, func(resp http.ResponseWriter, req *http.Request) {
+				handleGRPCWeb(resp, req, func(ctx context.Context, msg []byte) ([]byte, error) {
+					otlpReq := ptraceotlp.NewExportRequest()
+					if err := otlpReq.UnmarshalProto(msg); err != nil {
+						return nil, status.Error(codes.InvalidArgument, err.Error())
+					}
+					otlpResp, err := httpTracesReceiver.Export(ctx, otlpReq)
+					if err != nil {
+						return nil, err
+					}
+					return otlpResp.MarshalProto()
+				})
+			})
+		}
 	}
 	return nil
 }
@@ -211,6 +246,10 @@ func (r *otlpReceiver) registerMetricsConsumer(mc consumer.Metrics) error {
 	if mc == nil {
 		return component.ErrNilNextConsumer
 	}
+	mc, err := receiverhelper.NewAuthAttributesMetrics(r.cfg.AuthAttributes, mc)
+	if err != nil {
+		return err
+	}
 	r.metricsReceiver = metrics.New(mc, r.obsrepGRPC)
 	httpMetricsReceiver := metrics.New(mc, r.obsrepHTTP)
 	if r.httpMux != nil {
@@ -219,15 +258,28 @@ func (r *otlpReceiver) registerMetricsConsumer(mc consumer.Metrics) error {
 				handleUnmatchedMethod(resp)
 				return
 			}
-			switch getMimeTypeFromContentType(req.Header.Get("Content-Type")) {
-			case pbContentType:
-				handleMetrics(resp, req, httpMetricsReceiver, pbEncoder)
-			case jsonContentType:
-				handleMetrics(resp, req, httpMetricsReceiver, jsEncoder)
-			default:
+			enc := r.httpEncoderFor(req.Header.Get("Content-Type"))
+			if enc == nil {
 				handleUnmatchedContentType(resp)
+				return
 			}
+			handleMetrics(resp, req, httpMetricsReceiver, enc)
 		})
+		if r.cfg.HTTP.GRPCWeb {
+			r.httpMux.HandleFunc(grpcWebMetricsPath, func(resp http.ResponseWriter, req *http.Request) {
+				handleGRPCWeb(resp, req, func(ctx context.Context, msg []byte) ([]byte, error) {
+					otlpReq := pmetricotlp.NewExportRequest()
+					if err := otlpReq.UnmarshalProto(msg); err != nil {
+						return nil, status.Error(codes.InvalidArgument, err.Error())
+					}
+					otlpResp, err := httpMetricsReceiver.Export(ctx, otlpReq)
+					if err != nil {
+						return nil, err
+					}
+					return otlpResp.MarshalProto()
+				})
+			})
+		}
 	}
 	return nil
 }
@@ -236,6 +288,10 @@ func (r *otlpReceiver) registerLogsConsumer(lc consumer.Logs) error {
 	if lc == nil {
 		return component.ErrNilNextConsumer
 	}
+	lc, err := receiverhelper.NewAuthAttributesLogs(r.cfg.AuthAttributes, lc)
+	if err != nil {
+		return err
+	}
 	r.logsReceiver = logs.New(lc, r.obsrepGRPC)
 	httpLogsReceiver := logs.New(lc, r.obsrepHTTP)
 	if r.httpMux != nil {
@@ -244,15 +300,40 @@ func (r *otlpReceiver) registerLogsConsumer(lc consumer.Logs) error {
 				handleUnmatchedMethod(resp)
 				return
 			}
-			switch getMimeTypeFromContentType(req.Header.Get("Content-Type")) {
-			case pbContentType:
-				handleLogs(resp, req, httpLogsReceiver, pbEncoder)
-			case jsonContentType:
-				handleLogs(resp, req, httpLogsReceiver, jsEncoder)
-			default:
+			enc := r.httpEncoderFor(req.Header.Get("Content-Type"))
+			if enc == nil {
 				handleUnmatchedContentType(resp)
+				return
 			}
+			handleLogs(resp, req, httpLogsReceiver, enc)
 		})
+		if r.cfg.HTTP.GRPCWeb {
+			r.httpMux.HandleFunc(grpcWebLogsPath, func(resp http.ResponseWriter, req *http.Request) {
+				handleGRPCWeb(resp, req, func(ctx context.Context, msg []byte) ([]byte, error) {
+					otlpReq := plogotlp.NewExportRequest()
+					if err := otlpReq.UnmarshalProto(msg); err != nil {
+						return nil, status.Error(codes.InvalidArgument, err.Error())
+					}
+					otlpResp, err := httpLogsReceiver.Export(ctx, otlpReq)
+					if err != nil {
+						return nil, err
+					}
+					return otlpResp.MarshalProto()
+				})
+			})
+		}
+	}
+	return nil
+}
+
+// httpEncoderFor returns the encoder of the content type, or the encoder of the configured encoding
+// if the content type is not supported. It returns nil if neither is available.
+func (r *otlpReceiver) httpEncoderFor(contentType string) encoder {
+	if enc := encoderFor(contentType); enc != nil {
+		return enc
+	}
+	if r.httpEncoder != nil {
+		return r.httpEncoder
 	}
 	return nil
 }
@@ -264,5 +345,5 @@ func handleUnmatchedMethod(resp http.ResponseWriter) {
 
 func handleUnmatchedContentType(resp http.ResponseWriter) {
 	status := http.StatusUnsupportedMediaType
-	writeResponse(resp, "text/plain", status, []byte(fmt.Sprintf("%v unsupported media type, supported: [%s, %s]", status, jsonContentType, pbContentType)))
+	writeResponse(resp, "text/plain", status, []byte(fmt.Sprintf("%v unsupported media type, supported: [%s]", status, strings.Join(supportedContentTypes, ", "))))
 }
@@ -27,8 +27,10 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/confignet"
@@ -36,6 +38,8 @@ import (
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/auth"
+	"go.opentelemetry.io/collector/extension/encoding"
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/internal/testutil"
 	"go.opentelemetry.io/collector/obsreport/obsreporttest"
@@ -197,6 +201,48 @@ func TestJsonHttp(t *testing.T) {
 	}
 }
 
+func TestJsonHttpConcatenated(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	sink := new(consumertest.TracesSink)
+	ocr := newHTTPReceiver(t, addr, defaultTracesURLPath, defaultMetricsURLPath, defaultLogsURLPath, sink, nil)
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()), "Failed to start trace receiver")
+	t.Cleanup(func() { require.NoError(t, ocr.Shutdown(context.Background())) })
+
+	for _, encoding := range []string{"", "gzip", "zstd"} {
+		t.Run("encoding="+encoding, func(t *testing.T) {
+			sink.Reset()
+			payload := append(append(append([]byte{}, traceJSON...), '\n'), traceJSON...)
+			buf := bytes.NewBuffer(payload)
+			var err error
+			switch encoding {
+			case "gzip":
+				buf, err = compressGzip(payload)
+			case "zstd":
+				buf, err = compressZstd(payload)
+			}
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("http://%s%s", addr, defaultTracesURLPath), buf)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Content-Encoding", encoding)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			_, err = io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			// The concatenated requests are received as one request.
+			expected := ptrace.NewTraces()
+			traceOtlp.ResourceSpans().CopyTo(expected.ResourceSpans())
+			traceOtlp.ResourceSpans().At(0).CopyTo(expected.ResourceSpans().AppendEmpty())
+			require.Len(t, sink.AllTraces(), 1)
+			assert.EqualValues(t, expected, sink.AllTraces()[0])
+		})
+	}
+}
+
 func TestHandleInvalidRequests(t *testing.T) {
 	endpoint := testutil.GetAvailableLocalAddress(t)
 	cfg := &Config{
@@ -827,6 +873,126 @@ func TestOTLPReceiverHTTPTracesIngestTest(t *testing.T) {
 	require.NoError(t, tt.CheckReceiverTraces("http", int64(expectedReceivedBatches), int64(expectedIngestionBlockedRPCs)))
 }
 
+type authHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *authHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func TestOTLPReceiverHTTPAuthAttributes(t *testing.T) {
+	authID := component.NewID("tenantauth")
+	server := auth.NewServer(auth.WithServerAuthenticate(func(ctx context.Context, headers map[string][]string) (context.Context, error) {
+		tenant := http.Header(headers).Get("X-Tenant")
+		if tenant == "" {
+			return ctx, errors.New("missing tenant")
+		}
+		return client.NewContextWithAuth(ctx, client.NewAuthData(client.StandardClaims{Tenant: tenant}, nil)), nil
+	}))
+	host := &authHost{Host: componenttest.NewNopHost(), extensions: map[component.ID]component.Component{authID: server}}
+
+	addr := testutil.GetAvailableLocalAddress(t)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.GRPC = nil
+	cfg.HTTP.Endpoint = addr
+	cfg.HTTP.Auth = &configauth.Authentication{AuthenticatorID: authID}
+	cfg.AuthAttributes.Attributes = map[string]string{client.AuthAttributeTenant: "tenant.id"}
+	sink := new(consumertest.TracesSink)
+	r := newReceiver(t, factory, cfg, otlpReceiverID, sink, nil)
+	require.NoError(t, r.Start(context.Background(), host))
+	t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+	td := testdata.GenerateTraces(1)
+	td.ResourceSpans().At(0).Resource().Attributes().PutStr("tenant.id", "impersonated")
+	pbBytes, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, "http://"+addr+defaultTracesURLPath, bytes.NewReader(pbBytes))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", pbContentType)
+	req.Header.Set("X-Tenant", "acme")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	require.Len(t, sink.AllTraces(), 1)
+	tenant, ok := sink.AllTraces()[0].ResourceSpans().At(0).Resource().Attributes().Get("tenant.id")
+	require.True(t, ok)
+	assert.Equal(t, "acme", tenant.Str())
+}
+
+type encodingExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	encodings map[string]encoding.Encoding
+}
+
+func (e *encodingExtension) Encodings() map[string]encoding.Encoding {
+	return e.encodings
+}
+
+func TestOTLPReceiverHTTPEncoding(t *testing.T) {
+	host := &authHost{Host: componenttest.NewNopHost(), extensions: map[component.ID]component.Component{
+		component.NewID("encoding"): &encodingExtension{encodings: map[string]encoding.Encoding{
+			"custom": {TracesUnmarshaler: &ptrace.JSONUnmarshaler{}},
+		}},
+	}}
+	newConfig := func(enc string) *Config {
+		cfg := NewFactory().CreateDefaultConfig().(*Config)
+		cfg.GRPC = nil
+		cfg.HTTP.Endpoint = testutil.GetAvailableLocalAddress(t)
+		cfg.HTTP.Encoding = enc
+		return cfg
+	}
+	send := func(t *testing.T, cfg *Config, contentType string) *http.Response {
+		req, err := http.NewRequest(http.MethodPost, "http://"+cfg.HTTP.Endpoint+defaultTracesURLPath, bytes.NewReader(traceJSON))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", contentType)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		_, err = io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp
+	}
+
+	t.Run("custom", func(t *testing.T) {
+		cfg := newConfig("custom")
+		sink := new(consumertest.TracesSink)
+		r := newReceiver(t, NewFactory(), cfg, otlpReceiverID, sink, nil)
+		require.NoError(t, r.Start(context.Background(), host))
+		t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+		resp := send(t, cfg, "text/plain")
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, pbContentType, resp.Header.Get("Content-Type"))
+		require.Len(t, sink.AllTraces(), 1)
+		assert.EqualValues(t, traceOtlp, sink.AllTraces()[0])
+	})
+
+	t.Run("none", func(t *testing.T) {
+		cfg := newConfig("")
+		r := newReceiver(t, NewFactory(), cfg, otlpReceiverID, consumertest.NewNop(), nil)
+		require.NoError(t, r.Start(context.Background(), host))
+		t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, send(t, cfg, "text/plain").StatusCode)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		r := newReceiver(t, NewFactory(), newConfig("unknown"), otlpReceiverID, consumertest.NewNop(), nil)
+		assert.ErrorIs(t, r.Start(context.Background(), host), encoding.ErrUnknownEncoding)
+	})
+
+	t.Run("unsupported signal", func(t *testing.T) {
+		r := newReceiver(t, NewFactory(), newConfig("custom"), otlpReceiverID, nil, consumertest.NewNop())
+		assert.ErrorIs(t, r.Start(context.Background(), host), encoding.ErrUnsupportedSignal)
+	})
+}
+
 func TestGRPCInvalidTLSCredentials(t *testing.T) {
 	cfg := &Config{
 		Protocols: Protocols{
@@ -973,7 +1139,7 @@ func TestHTTPMaxRequestBodySize_OK(t *testing.T) {
 }
 
 func TestHTTPMaxRequestBodySize_TooLarge(t *testing.T) {
-	testHTTPMaxRequestBodySizeJSON(t, traceJSON, len(traceJSON)-1, 400)
+	testHTTPMaxRequestBodySizeJSON(t, traceJSON, len(traceJSON)-1, 413)
 }
 
 func newGRPCReceiver(t *testing.T, endpoint string, tc consumer.Traces, mc consumer.Metrics) component.Component {
@@ -4,6 +4,7 @@
 package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
 
 import (
+	"errors"
 	"io"
 	"mime"
 	"net/http"
@@ -12,6 +13,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"go.opentelemetry.io/collector/config/configcompression"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/logs"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metrics"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/trace"
@@ -23,12 +25,13 @@ var fallbackMsg = []byte(`{"code": 13, "message": "failed to marshal error messa
 const fallbackContentType = "application/json"
 
 func handleTraces(resp http.ResponseWriter, req *http.Request, tracesReceiver *trace.Receiver, encoder encoder) {
-	body, ok := readAndCloseBody(resp, req, encoder)
+	body, release, ok := readAndCloseBody(resp, req, encoder)
 	if !ok {
 		return
 	}
 
 	otlpReq, err := encoder.unmarshalTracesRequest(body)
+	release()
 	if err != nil {
 		writeError(resp, encoder, err, http.StatusBadRequest)
 		return
@@ -49,12 +52,13 @@ func handleTraces(resp http.ResponseWriter, req *http.Request, tracesReceiver *t
 }
 
 func handleMetrics(resp http.ResponseWriter, req *http.Request, metricsReceiver *metrics.Receiver, encoder encoder) {
-	body, ok := readAndCloseBody(resp, req, encoder)
+	body, release, ok := readAndCloseBody(resp, req, encoder)
 	if !ok {
 		return
 	}
 
 	otlpReq, err := encoder.unmarshalMetricsRequest(body)
+	release()
 	if err != nil {
 		writeError(resp, encoder, err, http.StatusBadRequest)
 		return
@@ -75,12 +79,13 @@ func handleMetrics(resp http.ResponseWriter, req *http.Request, metricsReceiver
 }
 
 func handleLogs(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.Receiver, encoder encoder) {
-	body, ok := readAndCloseBody(resp, req, encoder)
+	body, release, ok := readAndCloseBody(resp, req, encoder)
 	if !ok {
 		return
 	}
 
 	otlpReq, err := encoder.unmarshalLogsRequest(body)
+	release()
 	if err != nil {
 		writeError(resp, encoder, err, http.StatusBadRequest)
 		return
@@ -100,17 +105,39 @@ func handleLogs(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.
 	writeResponse(resp, encoder.contentType(), http.StatusOK, msg)
 }
 
-func readAndCloseBody(resp http.ResponseWriter, req *http.Request, encoder encoder) ([]byte, bool) {
-	body, err := io.ReadAll(req.Body)
+// readAndCloseBody reads the body of the request. The returned function must be called once the body
+// is not used anymore, giving back the buffer of the body to the pool if pooledRequestsFeatureGate is enabled.
+func readAndCloseBody(resp http.ResponseWriter, req *http.Request, encoder encoder) ([]byte, func(), bool) {
+	var (
+		body    []byte
+		release = func() {}
+		err     error
+	)
+	if pooledRequestsFeatureGate.IsEnabled() {
+		body, release, err = readPooledBody(req.Body)
+	} else {
+		body, err = io.ReadAll(req.Body)
+	}
 	if err != nil {
-		writeError(resp, encoder, err, http.StatusBadRequest)
-		return nil, false
+		writeError(resp, encoder, err, readErrorStatusCode(err))
+		return nil, nil, false
 	}
 	if err = req.Body.Close(); err != nil {
+		release()
 		writeError(resp, encoder, err, http.StatusBadRequest)
-		return nil, false
+		return nil, nil, false
+	}
+	return body, release, true
+}
+
+// readErrorStatusCode returns the status code of the response to a request whose body couldn't be read:
+// 413 if the body exceeds the max_request_body_size or the decompression limits, 400 otherwise.
+func readErrorStatusCode(err error) int {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) || errors.Is(err, configcompression.ErrDecompressionLimitExceeded) {
+		return http.StatusRequestEntityTooLarge
 	}
-	return body, true
+	return http.StatusBadRequest
 }
 
 // writeError encodes the HTTP error inside a rpc.Status message as required by the OTLP protocol.
@@ -126,12 +153,8 @@ func writeError(w http.ResponseWriter, encoder encoder, err error, statusCode in
 // by the OTLP protocol.
 func errorHandler(w http.ResponseWriter, r *http.Request, errMsg string, statusCode int) {
 	s := errorMsgToStatus(errMsg, statusCode)
-	switch getMimeTypeFromContentType(r.Header.Get("Content-Type")) {
-	case pbContentType:
-		writeStatusResponse(w, pbEncoder, statusCode, s.Proto())
-		return
-	case jsonContentType:
-		writeStatusResponse(w, jsEncoder, statusCode, s.Proto())
+	if enc := encoderFor(r.Header.Get("Content-Type")); enc != nil {
+		writeStatusResponse(w, enc, statusCode, s.Proto())
 		return
 	}
 	writeResponse(w, fallbackContentType, http.StatusInternalServerError, fallbackMsg)
@@ -155,8 +178,11 @@ func writeResponse(w http.ResponseWriter, contentType string, statusCode int, ms
 }
 
 func errorMsgToStatus(errMsg string, statusCode int) *status.Status {
-	if statusCode == http.StatusBadRequest {
+	switch statusCode {
+	case http.StatusBadRequest:
 		return status.New(codes.InvalidArgument, errMsg)
+	case http.StatusRequestEntityTooLarge:
+		return status.New(codes.ResourceExhausted, errMsg)
 	}
 	return status.New(codes.Unknown, errMsg)
 }
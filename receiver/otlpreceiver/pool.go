@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+// pooledRequestsFeatureGate is the feature gate that controls whether the OTLP receiver reuses the buffers
+// the bodies of the HTTP requests are read into, and the protobuf requests they are unmarshaled into.
+var pooledRequestsFeatureGate = featuregate.GlobalRegistry().MustRegister(
+	"receiver.otlp.pooledRequests",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterDescription("controls whether the OTLP receiver reuses the buffers the bodies of the "+
+		"HTTP requests are read into, and the protobuf requests they are unmarshaled into"),
+	featuregate.WithRegisterFromVersion("v0.86.0"),
+	featuregate.WithRegisterRuntimeToggle())
+
+// maxPooledBodySize is the capacity above which the body buffers are not kept in the pool,
+// so that a few large requests do not keep a lot of memory in use.
+const maxPooledBodySize = 4 << 20
+
+var bodyPool = sync.Pool{
+	New: func() any {
+		return new(bytes.Buffer)
+	},
+}
+
+// readPooledBody reads r into a buffer of the pool. The returned function gives back the buffer
+// to the pool, the body must not be used anymore once called.
+func readPooledBody(r io.Reader) ([]byte, func(), error) {
+	buf := bodyPool.Get().(*bytes.Buffer)
+	release := func() {
+		if buf.Cap() > maxPooledBodySize {
+			return
+		}
+		buf.Reset()
+		bodyPool.Put(buf)
+	}
+	if _, err := buf.ReadFrom(r); err != nil {
+		release()
+		return nil, nil, err
+	}
+	return buf.Bytes(), release, nil
+}
+
+// The unmarshalPooled functions unmarshal the protobuf body into a request of the pool, then move the data
+// out of it before giving it back: the pipeline may keep the data once consumed, e.g. in a sending queue.
+// The unmarshaled data does not reference the body, so that the body buffer can be given back as well.
+
+func unmarshalPooledTraces(buf []byte) (ptraceotlp.ExportRequest, error) {
+	pooled := ptraceotlp.AcquireExportRequest()
+	defer ptraceotlp.ReleaseExportRequest(pooled)
+	if err := pooled.UnmarshalProto(buf); err != nil {
+		return ptraceotlp.ExportRequest{}, err
+	}
+	td := ptrace.NewTraces()
+	pooled.Traces().ResourceSpans().MoveAndAppendTo(td.ResourceSpans())
+	return ptraceotlp.NewExportRequestFromTraces(td), nil
+}
+
+func unmarshalPooledMetrics(buf []byte) (pmetricotlp.ExportRequest, error) {
+	pooled := pmetricotlp.AcquireExportRequest()
+	defer pmetricotlp.ReleaseExportRequest(pooled)
+	if err := pooled.UnmarshalProto(buf); err != nil {
+		return pmetricotlp.ExportRequest{}, err
+	}
+	md := pmetric.NewMetrics()
+	pooled.Metrics().ResourceMetrics().MoveAndAppendTo(md.ResourceMetrics())
+	return pmetricotlp.NewExportRequestFromMetrics(md), nil
+}
+
+func unmarshalPooledLogs(buf []byte) (plogotlp.ExportRequest, error) {
+	pooled := plogotlp.AcquireExportRequest()
+	defer plogotlp.ReleaseExportRequest(pooled)
+	if err := pooled.UnmarshalProto(buf); err != nil {
+		return plogotlp.ExportRequest{}, err
+	}
+	ld := plog.NewLogs()
+	pooled.Logs().ResourceLogs().MoveAndAppendTo(ld.ResourceLogs())
+	return plogotlp.NewExportRequestFromLogs(ld), nil
+}
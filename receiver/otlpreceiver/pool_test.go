@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/internal/testutil"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func enablePooledRequests(t *testing.T) {
+	require.NoError(t, featuregate.GlobalRegistry().Set(pooledRequestsFeatureGate.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(pooledRequestsFeatureGate.ID(), false))
+	})
+}
+
+func TestReadPooledBody(t *testing.T) {
+	body, release, err := readPooledBody(bytes.NewReader([]byte("first")))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("first"), body)
+	release()
+
+	body, release, err = readPooledBody(bytes.NewReader([]byte("second")))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("second"), body)
+	release()
+}
+
+func TestUnmarshalPooled(t *testing.T) {
+	td := testdata.GenerateTraces(2)
+	tracesBytes, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	md := testdata.GenerateMetrics(2)
+	metricsBytes, err := (&pmetric.ProtoMarshaler{}).MarshalMetrics(md)
+	require.NoError(t, err)
+	ld := testdata.GenerateLogs(2)
+	logsBytes, err := (&plog.ProtoMarshaler{}).MarshalLogs(ld)
+	require.NoError(t, err)
+
+	// The unmarshaled data is not changed by the next requests using the same pooled requests.
+	var traces []ptrace.Traces
+	var metrics []pmetric.Metrics
+	var logs []plog.Logs
+	for i := 0; i < 3; i++ {
+		treq, err := unmarshalPooledTraces(tracesBytes)
+		require.NoError(t, err)
+		traces = append(traces, treq.Traces())
+		mreq, err := unmarshalPooledMetrics(metricsBytes)
+		require.NoError(t, err)
+		metrics = append(metrics, mreq.Metrics())
+		lreq, err := unmarshalPooledLogs(logsBytes)
+		require.NoError(t, err)
+		logs = append(logs, lreq.Logs())
+	}
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, td, traces[i])
+		assert.Equal(t, md, metrics[i])
+		assert.Equal(t, ld, logs[i])
+	}
+
+	_, err = unmarshalPooledTraces([]byte{0xff})
+	assert.Error(t, err)
+	_, err = unmarshalPooledMetrics([]byte{0xff})
+	assert.Error(t, err)
+	_, err = unmarshalPooledLogs([]byte{0xff})
+	assert.Error(t, err)
+}
+
+func TestProtoHttpPooledRequests(t *testing.T) {
+	enablePooledRequests(t)
+
+	addr := testutil.GetAvailableLocalAddress(t)
+	sink := new(consumertest.TracesSink)
+	ocr := newHTTPReceiver(t, addr, defaultTracesURLPath, defaultMetricsURLPath, defaultLogsURLPath, sink, consumertest.NewNop())
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, ocr.Shutdown(context.Background())) })
+
+	// The traces kept by the sink are not changed by the next requests reusing the pooled buffers.
+	var want []ptrace.Traces
+	for i := 1; i <= 3; i++ {
+		td := testdata.GenerateTraces(i)
+		want = append(want, td)
+		traceBytes, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+		require.NoError(t, err)
+		req := createHTTPProtobufRequest(t, fmt.Sprintf("http://%s%s", addr, defaultTracesURLPath), "", traceBytes)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	assert.Equal(t, want, sink.AllTraces())
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receiverhelper // import "go.opentelemetry.io/collector/receiver/receiverhelper"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var errEmptyAuthAttribute = errors.New("auth_attributes must not contain empty attribute names")
+
+// AuthAttributesSettings defines configuration for stamping the identity of the authenticated
+// clients, as set in the client.AuthData by the server authenticators, onto the resource
+// attributes of the received data, e.g. to route the data of each tenant downstream.
+// Receivers can embed this struct in their own configuration.
+type AuthAttributesSettings struct {
+	// Attributes maps the names of the client.AuthData attributes, e.g. client.AuthAttributeTenant,
+	// to the resource attributes they are stamped onto. The resource attributes are overwritten,
+	// so that the clients cannot impersonate another identity. Empty by default, stamping nothing.
+	Attributes map[string]string `mapstructure:"attributes"`
+}
+
+// Validate checks if the AuthAttributesSettings configuration is valid.
+func (as *AuthAttributesSettings) Validate() error {
+	for name, key := range as.Attributes {
+		if name == "" || key == "" {
+			return errEmptyAuthAttribute
+		}
+	}
+	return nil
+}
+
+// NewAuthAttributesTraces returns a consumer.Traces stamping the configured client.AuthData attributes
+// onto the resource attributes of the traces before passing them to next. It returns next as is when
+// no attribute is configured.
+func NewAuthAttributesTraces(settings AuthAttributesSettings, next consumer.Traces) (consumer.Traces, error) {
+	if len(settings.Attributes) == 0 {
+		return next, nil
+	}
+	return consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		if ad := client.FromContext(ctx).Auth; ad != nil {
+			rss := td.ResourceSpans()
+			for i := 0; i < rss.Len(); i++ {
+				settings.stamp(ad, rss.At(i).Resource())
+			}
+		}
+		return next.ConsumeTraces(ctx, td)
+	}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+}
+
+// NewAuthAttributesMetrics returns a consumer.Metrics stamping the configured client.AuthData attributes
+// onto the resource attributes of the metrics before passing them to next. It returns next as is when
+// no attribute is configured.
+func NewAuthAttributesMetrics(settings AuthAttributesSettings, next consumer.Metrics) (consumer.Metrics, error) {
+	if len(settings.Attributes) == 0 {
+		return next, nil
+	}
+	return consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		if ad := client.FromContext(ctx).Auth; ad != nil {
+			rms := md.ResourceMetrics()
+			for i := 0; i < rms.Len(); i++ {
+				settings.stamp(ad, rms.At(i).Resource())
+			}
+		}
+		return next.ConsumeMetrics(ctx, md)
+	}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+}
+
+// NewAuthAttributesLogs returns a consumer.Logs stamping the configured client.AuthData attributes
+// onto the resource attributes of the logs before passing them to next. It returns next as is when
+// no attribute is configured.
+func NewAuthAttributesLogs(settings AuthAttributesSettings, next consumer.Logs) (consumer.Logs, error) {
+	if len(settings.Attributes) == 0 {
+		return next, nil
+	}
+	return consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		if ad := client.FromContext(ctx).Auth; ad != nil {
+			rls := ld.ResourceLogs()
+			for i := 0; i < rls.Len(); i++ {
+				settings.stamp(ad, rls.At(i).Resource())
+			}
+		}
+		return next.ConsumeLogs(ctx, ld)
+	}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+}
+
+// stamp copies the configured attributes of ad onto the resource, skipping the attributes
+// missing from ad.
+func (as AuthAttributesSettings) stamp(ad client.AuthData, res pcommon.Resource) {
+	attrs := res.Attributes()
+	for name, key := range as.Attributes {
+		v := ad.GetAttribute(name)
+		if v == nil {
+			continue
+		}
+		if ss, ok := v.([]string); ok {
+			s := attrs.PutEmptySlice(key)
+			s.EnsureCapacity(len(ss))
+			for _, str := range ss {
+				s.AppendEmpty().SetStr(str)
+			}
+			continue
+		}
+		if err := attrs.PutEmpty(key).FromRaw(v); err != nil {
+			attrs.PutStr(key, fmt.Sprint(v))
+		}
+	}
+}
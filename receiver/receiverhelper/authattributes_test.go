@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receiverhelper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func authContext() context.Context {
+	return client.NewContextWithAuth(context.Background(), client.NewAuthData(client.StandardClaims{
+		Subject: "user",
+		Scopes:  []string{"read", "write"},
+		Tenant:  "acme",
+	}, map[string]any{"verified": true}))
+}
+
+var testAuthAttributes = AuthAttributesSettings{
+	Attributes: map[string]string{
+		client.AuthAttributeTenant: "tenant.id",
+		client.AuthAttributeScopes: "auth.scopes",
+		client.AuthAttributeIssuer: "auth.issuer",
+		"verified":                 "auth.verified",
+	},
+}
+
+func assertAuthAttributes(t *testing.T, attrs pcommon.Map) {
+	tenant, ok := attrs.Get("tenant.id")
+	require.True(t, ok)
+	assert.Equal(t, "acme", tenant.Str())
+	scopes, ok := attrs.Get("auth.scopes")
+	require.True(t, ok)
+	assert.Equal(t, []any{"read", "write"}, scopes.Slice().AsRaw())
+	verified, ok := attrs.Get("auth.verified")
+	require.True(t, ok)
+	assert.True(t, verified.Bool())
+	_, ok = attrs.Get("auth.issuer")
+	assert.False(t, ok)
+}
+
+func TestAuthAttributesSettingsValidate(t *testing.T) {
+	assert.NoError(t, (&AuthAttributesSettings{}).Validate())
+	assert.NoError(t, testAuthAttributes.Validate())
+	assert.ErrorIs(t, (&AuthAttributesSettings{Attributes: map[string]string{client.AuthAttributeTenant: ""}}).Validate(), errEmptyAuthAttribute)
+}
+
+func TestNewAuthAttributesNoAttributes(t *testing.T) {
+	tracesSink := new(consumertest.TracesSink)
+	tc, err := NewAuthAttributesTraces(AuthAttributesSettings{}, tracesSink)
+	require.NoError(t, err)
+	assert.Same(t, tracesSink, tc)
+
+	metricsSink := new(consumertest.MetricsSink)
+	mc, err := NewAuthAttributesMetrics(AuthAttributesSettings{}, metricsSink)
+	require.NoError(t, err)
+	assert.Same(t, metricsSink, mc)
+
+	logsSink := new(consumertest.LogsSink)
+	lc, err := NewAuthAttributesLogs(AuthAttributesSettings{}, logsSink)
+	require.NoError(t, err)
+	assert.Same(t, logsSink, lc)
+}
+
+func TestNewAuthAttributesTraces(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	tc, err := NewAuthAttributesTraces(testAuthAttributes, sink)
+	require.NoError(t, err)
+	assert.True(t, tc.Capabilities().MutatesData)
+
+	td := testdata.GenerateTraces(1)
+	td.ResourceSpans().At(0).Resource().Attributes().PutStr("tenant.id", "impersonated")
+	require.NoError(t, tc.ConsumeTraces(authContext(), td))
+	require.Len(t, sink.AllTraces(), 1)
+	assertAuthAttributes(t, sink.AllTraces()[0].ResourceSpans().At(0).Resource().Attributes())
+
+	// Data received without authentication data is left as is.
+	td = testdata.GenerateTraces(1)
+	require.NoError(t, tc.ConsumeTraces(context.Background(), td))
+	_, ok := td.ResourceSpans().At(0).Resource().Attributes().Get("tenant.id")
+	assert.False(t, ok)
+}
+
+func TestNewAuthAttributesMetrics(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	mc, err := NewAuthAttributesMetrics(testAuthAttributes, sink)
+	require.NoError(t, err)
+	assert.True(t, mc.Capabilities().MutatesData)
+
+	md := testdata.GenerateMetrics(1)
+	require.NoError(t, mc.ConsumeMetrics(authContext(), md))
+	require.Len(t, sink.AllMetrics(), 1)
+	assertAuthAttributes(t, sink.AllMetrics()[0].ResourceMetrics().At(0).Resource().Attributes())
+}
+
+func TestNewAuthAttributesLogs(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	lc, err := NewAuthAttributesLogs(testAuthAttributes, sink)
+	require.NoError(t, err)
+	assert.True(t, lc.Capabilities().MutatesData)
+
+	ld := testdata.GenerateLogs(1)
+	require.NoError(t, lc.ConsumeLogs(authContext(), ld))
+	require.Len(t, sink.AllLogs(), 1)
+	assertAuthAttributes(t, sink.AllLogs()[0].ResourceLogs().At(0).Resource().Attributes())
+}
+
+func TestAuthAttributesUnsupportedValue(t *testing.T) {
+	type group struct{ name string }
+	ctx := client.NewContextWithAuth(context.Background(), client.NewAuthData(client.StandardClaims{}, map[string]any{
+		"group": group{name: "dev"},
+	}))
+	sink := new(consumertest.LogsSink)
+	lc, err := NewAuthAttributesLogs(AuthAttributesSettings{Attributes: map[string]string{"group": "auth.group"}}, sink)
+	require.NoError(t, err)
+
+	ld := testdata.GenerateLogs(1)
+	require.NoError(t, lc.ConsumeLogs(ctx, ld))
+	v, ok := ld.ResourceLogs().At(0).Resource().Attributes().Get("auth.group")
+	require.True(t, ok)
+	assert.Equal(t, "{dev}", v.Str())
+}
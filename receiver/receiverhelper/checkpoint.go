@@ -0,0 +1,224 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receiverhelper // import "go.opentelemetry.io/collector/receiver/receiverhelper"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+const checkpointStorageName = "checkpoint"
+
+var (
+	errNoStorageClient      = errors.New("no storage client extension found")
+	errWrongExtensionType   = errors.New("requested extension is not a storage extension")
+	errNegativeFlush        = errors.New("flush_interval must not be negative")
+	errCheckpointerNotStart = errors.New("checkpointer is not started")
+)
+
+// CheckpointSettings defines configuration for persisting receiver checkpoints
+// (offsets, cursors, last seen timestamps) across collector restarts.
+// Receivers can embed this struct in their own configuration.
+type CheckpointSettings struct {
+	// StorageID if not empty, enables persistence of checkpoints using
+	// the component specified as a storage extension. If empty checkpoints
+	// are only kept in memory for the lifetime of the receiver.
+	StorageID *component.ID `mapstructure:"storage"`
+	// FlushInterval is the interval at which pending checkpoints are written
+	// to the storage. A zero value writes every checkpoint as soon as it is set.
+	FlushInterval time.Duration `mapstructure:"flush_interval"`
+}
+
+// NewDefaultCheckpointSettings returns the default settings for CheckpointSettings.
+func NewDefaultCheckpointSettings() CheckpointSettings {
+	return CheckpointSettings{
+		FlushInterval: time.Second,
+	}
+}
+
+// Validate checks if the CheckpointSettings configuration is valid.
+func (cs *CheckpointSettings) Validate() error {
+	if cs.FlushInterval < 0 {
+		return errNegativeFlush
+	}
+	return nil
+}
+
+// Checkpointer persists checkpoints for a single receiver instance. Checkpoints are
+// namespaced by the receiver ID, so multiple instances of the same receiver type
+// sharing a storage extension never observe each other's state.
+//
+// Checkpoints set between two flushes are written in a single storage batch, so a
+// crash never leaves a partially written set of checkpoints behind.
+type Checkpointer struct {
+	id            component.ID
+	storageID     *component.ID
+	flushInterval time.Duration
+	logger        *zap.Logger
+
+	mu      sync.Mutex
+	client  storage.Client
+	cache   map[string][]byte
+	pending map[string][]byte
+
+	// stopChan stops the periodic flush, created by each Start so that the Checkpointer can be restarted.
+	stopChan chan struct{}
+	stopWG   sync.WaitGroup
+}
+
+// NewCheckpointer creates a new Checkpointer for the receiver identified by set.
+func NewCheckpointer(set receiver.CreateSettings, cfg CheckpointSettings) *Checkpointer {
+	return &Checkpointer{
+		id:            set.ID,
+		storageID:     cfg.StorageID,
+		flushInterval: cfg.FlushInterval,
+		logger:        set.Logger,
+		cache:         map[string][]byte{},
+		pending:       map[string][]byte{},
+	}
+}
+
+// Start obtains the storage client and, if configured, starts the periodic flush.
+// It must be called from the receiver's Start method.
+func (c *Checkpointer) Start(ctx context.Context, host component.Host) error {
+	client := storage.NewNopClient()
+	if c.storageID != nil {
+		var err error
+		if client, err = toStorageClient(ctx, *c.storageID, host, c.id); err != nil {
+			return err
+		}
+	}
+
+	stopChan := make(chan struct{})
+	c.mu.Lock()
+	c.client = client
+	c.stopChan = stopChan
+	c.mu.Unlock()
+
+	if c.flushInterval > 0 {
+		c.stopWG.Add(1)
+		go c.flushLoop(stopChan)
+	}
+	return nil
+}
+
+func (c *Checkpointer) flushLoop(stopChan chan struct{}) {
+	defer c.stopWG.Done()
+	ticker := time.NewTicker(c.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.Flush(context.Background()); err != nil {
+				c.logger.Warn("Failed to flush checkpoints", zap.Error(err))
+			}
+		case <-stopChan:
+			return
+		}
+	}
+}
+
+// Get returns the last checkpoint stored under key, or nil if there is none.
+// Checkpoints that were set but not yet flushed are returned as well.
+func (c *Checkpointer) Get(ctx context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.client == nil {
+		return nil, errCheckpointerNotStart
+	}
+	if val, ok := c.cache[key]; ok {
+		return val, nil
+	}
+	val, err := c.client.Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checkpoint %q: %w", key, err)
+	}
+	if val != nil {
+		c.cache[key] = val
+	}
+	return val, nil
+}
+
+// Set records a new checkpoint under key. Depending on the flush interval the value is
+// written immediately or with the next flush.
+func (c *Checkpointer) Set(ctx context.Context, key string, value []byte) error {
+	c.mu.Lock()
+	if c.client == nil {
+		c.mu.Unlock()
+		return errCheckpointerNotStart
+	}
+	c.cache[key] = value
+	c.pending[key] = value
+	c.mu.Unlock()
+
+	if c.flushInterval == 0 {
+		return c.Flush(ctx)
+	}
+	return nil
+}
+
+// Flush writes all pending checkpoints to the storage in a single batch.
+func (c *Checkpointer) Flush(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.client == nil || len(c.pending) == 0 {
+		return nil
+	}
+
+	ops := make([]storage.Operation, 0, len(c.pending))
+	for key, value := range c.pending {
+		ops = append(ops, storage.SetOperation(key, value))
+	}
+	if err := c.client.Batch(ctx, ops...); err != nil {
+		return fmt.Errorf("failed to write checkpoints: %w", err)
+	}
+	c.pending = map[string][]byte{}
+	return nil
+}
+
+// Shutdown flushes pending checkpoints and releases the storage client.
+// It must be called from the receiver's Shutdown method.
+func (c *Checkpointer) Shutdown(ctx context.Context) error {
+	c.mu.Lock()
+	stopChan := c.stopChan
+	c.stopChan = nil
+	c.mu.Unlock()
+	// Not started, or already stopped by another call.
+	if stopChan == nil {
+		return nil
+	}
+
+	close(stopChan)
+	c.stopWG.Wait()
+
+	err := c.Flush(ctx)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	err = multierr.Append(err, c.client.Close(ctx))
+	c.client = nil
+	return err
+}
+
+func toStorageClient(ctx context.Context, storageID component.ID, host component.Host, ownerID component.ID) (storage.Client, error) {
+	ext, found := host.GetExtensions()[storageID]
+	if !found {
+		return nil, errNoStorageClient
+	}
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, errWrongExtensionType
+	}
+	return storageExt.GetClient(ctx, component.KindReceiver, ownerID, checkpointStorageName)
+}
@@ -0,0 +1,268 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receiverhelper
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+type mockHost struct {
+	component.Host
+	ext map[component.ID]component.Component
+}
+
+func (nh *mockHost) GetExtensions() map[component.ID]component.Component {
+	return nh.ext
+}
+
+type mockStorageExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	mu      sync.Mutex
+	clients map[string]*mockStorageClient
+}
+
+func newMockStorageExtension() *mockStorageExtension {
+	return &mockStorageExtension{clients: map[string]*mockStorageClient{}}
+}
+
+func (m *mockStorageExtension) GetClient(_ context.Context, kind component.Kind, id component.ID, name string) (storage.Client, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := kindString(kind) + "/" + id.String() + "/" + name
+	if c, ok := m.clients[key]; ok {
+		return c, nil
+	}
+	c := &mockStorageClient{st: map[string][]byte{}}
+	m.clients[key] = c
+	return c, nil
+}
+
+func kindString(kind component.Kind) string {
+	if kind == component.KindReceiver {
+		return "receiver"
+	}
+	return "other"
+}
+
+type mockStorageClient struct {
+	mu      sync.Mutex
+	st      map[string][]byte
+	batches int
+	err     error
+}
+
+func (m *mockStorageClient) Get(_ context.Context, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.st[key], nil
+}
+
+func (m *mockStorageClient) Set(_ context.Context, key string, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.st[key] = value
+	return nil
+}
+
+func (m *mockStorageClient) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.st, key)
+	return nil
+}
+
+func (m *mockStorageClient) Batch(_ context.Context, ops ...storage.Operation) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return m.err
+	}
+	m.batches++
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			op.Value = m.st[op.Key]
+		case storage.Set:
+			m.st[op.Key] = op.Value
+		case storage.Delete:
+			delete(m.st, op.Key)
+		}
+	}
+	return nil
+}
+
+func (m *mockStorageClient) Close(context.Context) error {
+	return nil
+}
+
+func TestCheckpointSettings_Validate(t *testing.T) {
+	cfg := NewDefaultCheckpointSettings()
+	assert.NoError(t, cfg.Validate())
+
+	cfg.FlushInterval = -time.Second
+	assert.ErrorIs(t, cfg.Validate(), errNegativeFlush)
+}
+
+func TestCheckpointer_NoStorage(t *testing.T) {
+	cp := NewCheckpointer(receivertest.NewNopCreateSettings(), CheckpointSettings{})
+	_, err := cp.Get(context.Background(), "offset")
+	assert.ErrorIs(t, err, errCheckpointerNotStart)
+
+	require.NoError(t, cp.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, cp.Set(context.Background(), "offset", []byte("10")))
+	val, err := cp.Get(context.Background(), "offset")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("10"), val)
+	require.NoError(t, cp.Shutdown(context.Background()))
+}
+
+func TestCheckpointer_MissingExtension(t *testing.T) {
+	storageID := component.NewID("file_storage")
+	cp := NewCheckpointer(receivertest.NewNopCreateSettings(), CheckpointSettings{StorageID: &storageID})
+	assert.ErrorIs(t, cp.Start(context.Background(), componenttest.NewNopHost()), errNoStorageClient)
+
+	host := &mockHost{ext: map[component.ID]component.Component{storageID: nopComponent{}}}
+	assert.ErrorIs(t, cp.Start(context.Background(), host), errWrongExtensionType)
+}
+
+type nopComponent struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+func TestCheckpointer_ResumeAfterRestart(t *testing.T) {
+	storageID := component.NewID("file_storage")
+	ext := newMockStorageExtension()
+	host := &mockHost{ext: map[component.ID]component.Component{storageID: ext}}
+	cfg := CheckpointSettings{StorageID: &storageID, FlushInterval: time.Hour}
+
+	set := receivertest.NewNopCreateSettings()
+	set.ID = component.NewIDWithName("filelog", "a")
+
+	cp := NewCheckpointer(set, cfg)
+	require.NoError(t, cp.Start(context.Background(), host))
+	require.NoError(t, cp.Set(context.Background(), "file1", []byte("100")))
+	require.NoError(t, cp.Set(context.Background(), "file2", []byte("200")))
+	require.NoError(t, cp.Shutdown(context.Background()))
+
+	client := ext.clients["receiver/filelog/a/checkpoint"]
+	require.NotNil(t, client)
+	assert.Equal(t, 1, client.batches)
+
+	// A new instance of the same receiver resumes from the stored checkpoints.
+	cp = NewCheckpointer(set, cfg)
+	require.NoError(t, cp.Start(context.Background(), host))
+	val, err := cp.Get(context.Background(), "file1")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("100"), val)
+
+	// A different instance is isolated.
+	other := receivertest.NewNopCreateSettings()
+	other.ID = component.NewIDWithName("filelog", "b")
+	otherCp := NewCheckpointer(other, cfg)
+	require.NoError(t, otherCp.Start(context.Background(), host))
+	val, err = otherCp.Get(context.Background(), "file1")
+	require.NoError(t, err)
+	assert.Nil(t, val)
+
+	require.NoError(t, cp.Shutdown(context.Background()))
+	require.NoError(t, otherCp.Shutdown(context.Background()))
+	// Shutdown is idempotent.
+	require.NoError(t, cp.Shutdown(context.Background()))
+}
+
+func TestCheckpointer_PeriodicFlush(t *testing.T) {
+	storageID := component.NewID("file_storage")
+	ext := newMockStorageExtension()
+	host := &mockHost{ext: map[component.ID]component.Component{storageID: ext}}
+
+	cp := NewCheckpointer(receivertest.NewNopCreateSettings(), CheckpointSettings{StorageID: &storageID, FlushInterval: 10 * time.Millisecond})
+	require.NoError(t, cp.Start(context.Background(), host))
+	require.NoError(t, cp.Set(context.Background(), "offset", []byte("1")))
+
+	var client *mockStorageClient
+	for _, c := range ext.clients {
+		client = c
+	}
+	assert.Eventually(t, func() bool {
+		val, _ := client.Get(context.Background(), "offset")
+		return string(val) == "1"
+	}, time.Second, 5*time.Millisecond)
+	require.NoError(t, cp.Shutdown(context.Background()))
+}
+
+func TestCheckpointer_RestartAfterShutdown(t *testing.T) {
+	storageID := component.NewID("file_storage")
+	ext := newMockStorageExtension()
+	host := &mockHost{ext: map[component.ID]component.Component{storageID: ext}}
+
+	// A receiver restarted by the collector, e.g. on a configuration reload, restarts its Checkpointer.
+	cp := NewCheckpointer(receivertest.NewNopCreateSettings(), CheckpointSettings{StorageID: &storageID, FlushInterval: 10 * time.Millisecond})
+	require.NoError(t, cp.Start(context.Background(), host))
+	require.NoError(t, cp.Set(context.Background(), "offset", []byte("1")))
+	require.NoError(t, cp.Shutdown(context.Background()))
+
+	require.NoError(t, cp.Start(context.Background(), host))
+	val, err := cp.Get(context.Background(), "offset")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("1"), val)
+	require.NoError(t, cp.Set(context.Background(), "offset", []byte("2")))
+
+	// The periodic flush runs again after the restart.
+	var client *mockStorageClient
+	for _, c := range ext.clients {
+		client = c
+	}
+	assert.Eventually(t, func() bool {
+		val, _ := client.Get(context.Background(), "offset")
+		return string(val) == "2"
+	}, time.Second, 5*time.Millisecond)
+	require.NoError(t, cp.Shutdown(context.Background()))
+}
+
+func TestCheckpointer_ConcurrentShutdown(t *testing.T) {
+	storageID := component.NewID("file_storage")
+	host := &mockHost{ext: map[component.ID]component.Component{storageID: newMockStorageExtension()}}
+
+	cp := NewCheckpointer(receivertest.NewNopCreateSettings(), CheckpointSettings{StorageID: &storageID, FlushInterval: 10 * time.Millisecond})
+	require.NoError(t, cp.Start(context.Background(), host))
+	require.NoError(t, cp.Set(context.Background(), "offset", []byte("1")))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, cp.Shutdown(context.Background()))
+		}()
+	}
+	wg.Wait()
+}
+
+func TestCheckpointer_FlushError(t *testing.T) {
+	storageID := component.NewID("file_storage")
+	ext := newMockStorageExtension()
+	host := &mockHost{ext: map[component.ID]component.Component{storageID: ext}}
+
+	cp := NewCheckpointer(receivertest.NewNopCreateSettings(), CheckpointSettings{StorageID: &storageID})
+	require.NoError(t, cp.Start(context.Background(), host))
+	for _, c := range ext.clients {
+		c.err = errors.New("disk full")
+	}
+	assert.Error(t, cp.Set(context.Background(), "offset", []byte("1")))
+	require.Error(t, cp.Shutdown(context.Background()))
+}
@@ -0,0 +1,5 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package receiverhelper provides utilities for receivers.
+package receiverhelper // import "go.opentelemetry.io/collector/receiver/receiverhelper"
@@ -0,0 +1,259 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receivertest // import "go.opentelemetry.io/collector/receiver/receivertest"
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+// defaultMaxHeapGrowth is the default FuzzReceiverParams.MaxHeapGrowth.
+const defaultMaxHeapGrowth = 256 << 20
+
+// FuzzSendFunc sends a fuzzed payload to the receiver under test. It must return an error only if
+// the receiver failed to handle the payload, e.g. if it closed the connection instead of rejecting
+// the payload, which usually means that it panicked.
+type FuzzSendFunc func(payload []byte) error
+
+type FuzzReceiverParams struct {
+	F *testing.F
+	// Factory that allows to create a receiver.
+	Factory receiver.Factory
+	// DataType to test for.
+	DataType component.DataType
+	// Config of the receiver to use. The receiver must listen on the endpoint used by Send.
+	Config component.Config
+	// Send sends the fuzzed payloads to the receiver, see NewHTTPFuzzSendFunc and NewGRPCFuzzSendFunc.
+	Send FuzzSendFunc
+	// Seeds are added to the seed corpus, in addition to the OTLP payloads returned by OTLPSeedCorpus.
+	Seeds [][]byte
+	// MaxHeapGrowth is the maximum growth of the heap, in bytes, allowed while handling a payload.
+	// Defaults to 256MiB.
+	MaxHeapGrowth uint64
+}
+
+// FuzzReceiver wires the receiver created by the factory to the Go native fuzzing: the receiver is started
+// with a nop consumer, which does not retain the data, and each payload generated by the fuzzing engine is sent to it. The fuzz test fails
+// if the receiver does not handle a payload, or if the heap grows more than MaxHeapGrowth while handling it.
+//
+// It is meant to be called from a fuzz test, e.g.:
+//
+//	func FuzzReceiverHTTP(f *testing.F) {
+//		receivertest.FuzzReceiver(receivertest.FuzzReceiverParams{F: f, ...})
+//	}
+func FuzzReceiver(params FuzzReceiverParams) {
+	f := params.F
+	maxHeapGrowth := params.MaxHeapGrowth
+	if maxHeapGrowth == 0 {
+		maxHeapGrowth = defaultMaxHeapGrowth
+	}
+
+	rcv, err := createWithNop(params)
+	require.NoError(f, err)
+	require.NoError(f, rcv.Start(context.Background(), componenttest.NewNopHost()))
+	f.Cleanup(func() {
+		require.NoError(f, rcv.Shutdown(context.Background()))
+	})
+
+	for _, seed := range OTLPSeedCorpus(params.DataType) {
+		f.Add(seed)
+	}
+	for _, seed := range params.Seeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, payload []byte) {
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		if err := params.Send(payload); err != nil {
+			t.Fatalf("receiver failed to handle the payload: %v", err)
+		}
+		runtime.ReadMemStats(&after)
+		if after.HeapInuse > before.HeapInuse && after.HeapInuse-before.HeapInuse > maxHeapGrowth {
+			t.Fatalf("heap grew by %d bytes while handling a payload of %d bytes", after.HeapInuse-before.HeapInuse, len(payload))
+		}
+	})
+}
+
+func createWithNop(params FuzzReceiverParams) (component.Component, error) {
+	ctx := context.Background()
+	set := NewNopCreateSettings()
+	switch params.DataType {
+	case component.DataTypeTraces:
+		return params.Factory.CreateTracesReceiver(ctx, set, params.Config, consumertest.NewNop())
+	case component.DataTypeMetrics:
+		return params.Factory.CreateMetricsReceiver(ctx, set, params.Config, consumertest.NewNop())
+	case component.DataTypeLogs:
+		return params.Factory.CreateLogsReceiver(ctx, set, params.Config, consumertest.NewNop())
+	}
+	return nil, fmt.Errorf("data type %q is not supported", params.DataType)
+}
+
+// OTLPSeedCorpus returns OTLP export requests of the given data type, encoded both in protobuf and in JSON,
+// to seed the corpus of a fuzz test.
+func OTLPSeedCorpus(dataType component.DataType) [][]byte {
+	var protoReq, jsonReq []byte
+	var err error
+	switch dataType {
+	case component.DataTypeTraces:
+		req := ptraceotlp.NewExportRequestFromTraces(seedTraces())
+		if protoReq, err = req.MarshalProto(); err == nil {
+			jsonReq, err = req.MarshalJSON()
+		}
+	case component.DataTypeMetrics:
+		req := pmetricotlp.NewExportRequestFromMetrics(seedMetrics())
+		if protoReq, err = req.MarshalProto(); err == nil {
+			jsonReq, err = req.MarshalJSON()
+		}
+	case component.DataTypeLogs:
+		req := plogotlp.NewExportRequestFromLogs(seedLogs())
+		if protoReq, err = req.MarshalProto(); err == nil {
+			jsonReq, err = req.MarshalJSON()
+		}
+	default:
+		return nil
+	}
+	if err != nil {
+		// The seed data is static, so marshaling can only fail because of a programming error.
+		panic(err)
+	}
+	return [][]byte{{}, protoReq, jsonReq, protoReq[:len(protoReq)/2]}
+}
+
+func seedTraces() ptrace.Traces {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "fuzz")
+	span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("operation")
+	span.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	span.SetSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	span.Attributes().PutInt("attempt", 1)
+	span.Events().AppendEmpty().SetName("event")
+	return td
+}
+
+func seedMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "fuzz")
+	metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+	gauge := metrics.AppendEmpty()
+	gauge.SetName("gauge")
+	gauge.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(1.5)
+	hist := metrics.AppendEmpty()
+	hist.SetName("histogram")
+	dp := hist.SetEmptyHistogram().DataPoints().AppendEmpty()
+	dp.SetCount(2)
+	dp.ExplicitBounds().FromRaw([]float64{1, 10})
+	dp.BucketCounts().FromRaw([]uint64{1, 1, 0})
+	return md
+}
+
+func seedLogs() plog.Logs {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "fuzz")
+	lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Body().SetStr("message")
+	lr.SetSeverityNumber(plog.SeverityNumberInfo)
+	lr.Attributes().PutBool("fuzz", true)
+	return ld
+}
+
+// NewHTTPFuzzSendFunc returns a FuzzSendFunc posting the payloads to url, with the given content type.
+// Any HTTP response is accepted, as the receiver is expected to reject invalid payloads.
+func NewHTTPFuzzSendFunc(url, contentType string) FuzzSendFunc {
+	client := &http.Client{Timeout: 10 * time.Second}
+	return func(payload []byte) error {
+		resp, err := client.Post(url, contentType, bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(io.Discard, resp.Body)
+		return multierr.Combine(err, resp.Body.Close())
+	}
+}
+
+// NewGRPCFuzzSendFunc returns a FuzzSendFunc invoking the unary method, e.g.
+// "/opentelemetry.proto.collector.trace.v1.TraceService/Export", of the gRPC server at endpoint with
+// the payloads sent as-is. Any gRPC status is accepted, as the receiver is expected to reject invalid
+// payloads, except the ones reporting that the server is unavailable.
+func NewGRPCFuzzSendFunc(tb testing.TB, endpoint, method string) FuzzSendFunc {
+	var once sync.Once
+	var conn *grpc.ClientConn
+	var dialErr error
+	tb.Cleanup(func() {
+		if conn != nil {
+			require.NoError(tb, conn.Close())
+		}
+	})
+	return func(payload []byte) error {
+		once.Do(func() {
+			conn, dialErr = grpc.Dial(endpoint,
+				grpc.WithTransportCredentials(insecure.NewCredentials()),
+				grpc.WithDefaultCallOptions(grpc.ForceCodec(rawCodec{})))
+		})
+		if dialErr != nil {
+			return dialErr
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		var resp []byte
+		err := conn.Invoke(ctx, method, payload, &resp)
+		if st, ok := status.FromError(err); ok && st.Code() != codes.Unavailable && st.Code() != codes.DeadlineExceeded {
+			return nil
+		}
+		return err
+	}
+}
+
+// rawCodec sends and receives the gRPC messages as raw bytes, so that invalid messages can be sent.
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v any) ([]byte, error) {
+	b, ok := v.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected message type %T", v)
+	}
+	return b, nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v any) error {
+	b, ok := v.(*[]byte)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", v)
+	}
+	*b = append((*b)[:0], data...)
+	return nil
+}
+
+func (rawCodec) Name() string {
+	return "raw"
+}
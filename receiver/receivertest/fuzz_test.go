@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receivertest
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/testutil"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+type httpTracesConfig struct {
+	Endpoint string
+}
+
+// httpTracesReceiver accepts OTLP protobuf traces over HTTP.
+type httpTracesReceiver struct {
+	cfg    *httpTracesConfig
+	next   consumer.Traces
+	server *http.Server
+}
+
+func (r *httpTracesReceiver) Start(context.Context, component.Host) error {
+	ln, err := net.Listen("tcp", r.cfg.Endpoint)
+	if err != nil {
+		return err
+	}
+	r.server = &http.Server{Handler: http.HandlerFunc(r.handle)} //nolint:gosec
+	go func() {
+		_ = r.server.Serve(ln)
+	}()
+	return nil
+}
+
+func (r *httpTracesReceiver) handle(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	otlpReq := ptraceotlp.NewExportRequest()
+	if err = otlpReq.UnmarshalProto(body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if err = r.next.ConsumeTraces(req.Context(), otlpReq.Traces()); err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+}
+
+func (r *httpTracesReceiver) Shutdown(ctx context.Context) error {
+	if r.server == nil {
+		return nil
+	}
+	return r.server.Shutdown(ctx)
+}
+
+func newHTTPTracesFactory() receiver.Factory {
+	return receiver.NewFactory("http_traces",
+		func() component.Config { return &httpTracesConfig{} },
+		receiver.WithTraces(func(_ context.Context, _ receiver.CreateSettings, cfg component.Config, next consumer.Traces) (receiver.Traces, error) {
+			return &httpTracesReceiver{cfg: cfg.(*httpTracesConfig), next: next}, nil
+		}, component.StabilityLevelStable))
+}
+
+func FuzzHTTPTracesReceiver(f *testing.F) {
+	endpoint := testutil.GetAvailableLocalAddress(f)
+	FuzzReceiver(FuzzReceiverParams{
+		F:        f,
+		Factory:  newHTTPTracesFactory(),
+		DataType: component.DataTypeTraces,
+		Config:   &httpTracesConfig{Endpoint: endpoint},
+		Send:     NewHTTPFuzzSendFunc("http://"+endpoint+"/v1/traces", "application/x-protobuf"),
+		Seeds:    [][]byte{[]byte("not a protobuf message")},
+	})
+}
+
+func TestOTLPSeedCorpus(t *testing.T) {
+	traces := OTLPSeedCorpus(component.DataTypeTraces)
+	require.Len(t, traces, 4)
+	tracesReq := ptraceotlp.NewExportRequest()
+	require.NoError(t, tracesReq.UnmarshalProto(traces[1]))
+	assert.Equal(t, 1, tracesReq.Traces().SpanCount())
+	require.NoError(t, tracesReq.UnmarshalJSON(traces[2]))
+	assert.Equal(t, 1, tracesReq.Traces().SpanCount())
+
+	metrics := OTLPSeedCorpus(component.DataTypeMetrics)
+	require.Len(t, metrics, 4)
+	metricsReq := pmetricotlp.NewExportRequest()
+	require.NoError(t, metricsReq.UnmarshalProto(metrics[1]))
+	assert.Equal(t, 2, metricsReq.Metrics().DataPointCount())
+
+	logs := OTLPSeedCorpus(component.DataTypeLogs)
+	require.Len(t, logs, 4)
+	logsReq := plogotlp.NewExportRequest()
+	require.NoError(t, logsReq.UnmarshalJSON(logs[2]))
+	assert.Equal(t, 1, logsReq.Logs().LogRecordCount())
+
+	assert.Nil(t, OTLPSeedCorpus("profiles"))
+}
+
+func TestHTTPFuzzSendFuncConnectionError(t *testing.T) {
+	send := NewHTTPFuzzSendFunc("http://"+testutil.GetAvailableLocalAddress(t)+"/v1/traces", "application/x-protobuf")
+	assert.Error(t, send([]byte("payload")))
+}
+
+func TestGRPCFuzzSendFuncUnavailable(t *testing.T) {
+	send := NewGRPCFuzzSendFunc(t, testutil.GetAvailableLocalAddress(t), "/opentelemetry.proto.collector.trace.v1.TraceService/Export")
+	assert.Error(t, send([]byte("payload")))
+}
+
+func TestRawCodec(t *testing.T) {
+	codec := rawCodec{}
+	b, err := codec.Marshal([]byte("payload"))
+	require.NoError(t, err)
+	var resp []byte
+	require.NoError(t, codec.Unmarshal(b, &resp))
+	assert.Equal(t, []byte("payload"), resp)
+
+	_, err = codec.Marshal("payload")
+	assert.Error(t, err)
+	assert.Error(t, codec.Unmarshal(b, resp))
+}
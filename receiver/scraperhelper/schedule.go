@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package scraperhelper // import "go.opentelemetry.io/collector/receiver/scraperhelper"
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// scheduleDescriptors are the shorthands of the common schedules.
+var scheduleDescriptors = map[string]string{
+	"@yearly":  "0 0 1 1 *",
+	"@monthly": "0 0 1 * *",
+	"@weekly":  "0 0 * * 0",
+	"@daily":   "0 0 * * *",
+	"@hourly":  "0 * * * *",
+}
+
+// schedule is a cron expression with the minute, hour, day of month, month and day of week fields.
+// The fields are sets of values, represented as bitsets.
+type schedule struct {
+	minute, hour, dom, month, dow uint64
+	// domAny and dowAny are true if the day of month, respectively the day of week, field starts with "*".
+	// As with cron, a day matches if it matches both fields when either of them is "*", and if it
+	// matches one of them otherwise.
+	domAny, dowAny bool
+}
+
+// scheduleField is the range of the values of a field of a cron expression.
+type scheduleField struct {
+	name     string
+	min, max int
+}
+
+var scheduleFields = [5]scheduleField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	// 7 is also Sunday.
+	{name: "day of week", min: 0, max: 7},
+}
+
+// parseSchedule parses a cron expression of 5 fields, each of them a list of values "5", ranges "1-5",
+// or "*", with an optional step "*/15", or one of the descriptors @yearly, @monthly, @weekly, @daily or @hourly.
+func parseSchedule(expr string) (*schedule, error) {
+	if d, ok := scheduleDescriptors[expr]; ok {
+		expr = d
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != len(scheduleFields) {
+		return nil, fmt.Errorf("expected %d fields, got %d", len(scheduleFields), len(fields))
+	}
+	var bits [5]uint64
+	for i, f := range fields {
+		b, err := parseScheduleField(f, scheduleFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", scheduleFields[i].name, f, err)
+		}
+		bits[i] = b
+	}
+	// Sunday is 0 and 7.
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1
+	}
+	return &schedule{
+		minute: bits[0],
+		hour:   bits[1],
+		dom:    bits[2],
+		month:  bits[3],
+		dow:    bits[4],
+		domAny: strings.HasPrefix(fields[2], "*"),
+		dowAny: strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+func parseScheduleField(field string, sf scheduleField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepStr); err != nil || step <= 0 {
+				return 0, errors.New("the step must be a positive integer")
+			}
+		}
+		low, high := sf.min, sf.max
+		if rng != "*" {
+			lowStr, highStr, isRange := strings.Cut(rng, "-")
+			var err error
+			if low, err = strconv.Atoi(lowStr); err != nil {
+				return 0, fmt.Errorf("%q is not an integer", lowStr)
+			}
+			switch {
+			case isRange:
+				if high, err = strconv.Atoi(highStr); err != nil {
+					return 0, fmt.Errorf("%q is not an integer", highStr)
+				}
+			case !hasStep:
+				// A single value, a value with a step ranging up to the maximum.
+				high = low
+			}
+			if low < sf.min || high > sf.max || low > high {
+				return 0, fmt.Errorf("the values must be in the range %d-%d", sf.min, sf.max)
+			}
+		}
+		for v := low; v <= high; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// next returns the first time after t, at the start of a minute, matching the schedule,
+// or the zero time if none is found within 5 years, e.g. for the 31st of February.
+func (s *schedule) next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (s *schedule) matchDay(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domAny || s.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
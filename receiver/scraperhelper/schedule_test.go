@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package scraperhelper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScheduleErrors(t *testing.T) {
+	for expr, errVal := range map[string]string{
+		"":                "expected 5 fields, got 0",
+		"* * * *":         "expected 5 fields, got 4",
+		"60 * * * *":      `invalid minute "60": the values must be in the range 0-59`,
+		"* 5-2 * * *":     `invalid hour "5-2": the values must be in the range 0-23`,
+		"* * 0 * *":       `invalid day of month "0": the values must be in the range 1-31`,
+		"* * * jan *":     `invalid month "jan": "jan" is not an integer`,
+		"* * * * 1-x":     `invalid day of week "1-x": "x" is not an integer`,
+		"*/0 * * * *":     `invalid minute "*/0": the step must be a positive integer`,
+		"@every 5m":       "expected 5 fields, got 2",
+		"0 0 1 1 * extra": "expected 5 fields, got 6",
+	} {
+		_, err := parseSchedule(expr)
+		assert.EqualError(t, err, errVal, expr)
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	// 2023-09-01 is a Friday.
+	now := time.Date(2023, 9, 1, 10, 2, 30, 0, time.UTC)
+	for _, tc := range []struct {
+		expr string
+		want time.Time
+	}{
+		{expr: "* * * * *", want: time.Date(2023, 9, 1, 10, 3, 0, 0, time.UTC)},
+		{expr: "*/5 * * * *", want: time.Date(2023, 9, 1, 10, 5, 0, 0, time.UTC)},
+		{expr: "2 * * * *", want: time.Date(2023, 9, 1, 11, 2, 0, 0, time.UTC)},
+		{expr: "10/20 * * * *", want: time.Date(2023, 9, 1, 10, 10, 0, 0, time.UTC)},
+		{expr: "0,30 9-17 * * *", want: time.Date(2023, 9, 1, 10, 30, 0, 0, time.UTC)},
+		{expr: "0 8 * * 1-5", want: time.Date(2023, 9, 4, 8, 0, 0, 0, time.UTC)},
+		{expr: "0 0 * * 7", want: time.Date(2023, 9, 3, 0, 0, 0, 0, time.UTC)},
+		// A day matches the day of month or the day of week when both are restricted.
+		{expr: "0 0 15 * 6", want: time.Date(2023, 9, 2, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 29 2 *", want: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{expr: "@daily", want: time.Date(2023, 9, 2, 0, 0, 0, 0, time.UTC)},
+		{expr: "@monthly", want: time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 30 2 *", want: time.Time{}},
+	} {
+		s, err := parseSchedule(tc.expr)
+		require.NoError(t, err, tc.expr)
+		assert.Equal(t, tc.want, s.next(now), tc.expr)
+	}
+}
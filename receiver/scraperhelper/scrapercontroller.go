@@ -6,6 +6,9 @@ package scraperhelper // import "go.opentelemetry.io/collector/receiver/scraperh
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math"
+	"math/rand"
 	"time"
 
 	"go.uber.org/multierr"
@@ -42,11 +45,23 @@ func WithTickerChannel(tickerCh <-chan time.Time) ScraperControllerOption {
 	}
 }
 
+// ScrapeTrigger is implemented by the receivers created by NewScraperControllerReceiver,
+// to scrape the metrics on demand, e.g. from the zPages, in addition to the scheduled scrapes.
+type ScrapeTrigger interface {
+	// ScrapeNow calls the scrapers immediately and waits until the scraped metrics are passed
+	// to the next consumer, or until the context is done.
+	ScrapeNow(ctx context.Context) error
+}
+
+var errControllerNotStarted = errors.New("the scraper controller is not started")
+
 type controller struct {
 	id                 component.ID
 	logger             *zap.Logger
 	collectionInterval time.Duration
 	initialDelay       time.Duration
+	jitter             time.Duration
+	schedule           *schedule
 	timeout            time.Duration
 	nextConsumer       consumer.Metrics
 
@@ -54,6 +69,8 @@ type controller struct {
 	obsScrapers []*obsreport.Scraper
 
 	tickerCh <-chan time.Time
+	// scrapeNowCh receives the on demand scrapes, the channel being closed once the scrape is done.
+	scrapeNowCh chan chan struct{}
 
 	initialized bool
 	done        chan struct{}
@@ -78,6 +95,18 @@ func NewScraperControllerReceiver(
 		return nil, errors.New("collection_interval must be a positive duration")
 	}
 
+	var sched *schedule
+	if cfg.Schedule != "" {
+		var err error
+		if sched, err = parseSchedule(cfg.Schedule); err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %w", cfg.Schedule, err)
+		}
+	}
+	var jitter time.Duration
+	if cfg.InitialDelayJitter > 0 {
+		jitter = time.Duration(rand.Int63n(int64(cfg.InitialDelayJitter)))
+	}
+
 	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
 		ReceiverID:             set.ID,
 		Transport:              "",
@@ -92,8 +121,11 @@ func NewScraperControllerReceiver(
 		logger:             set.Logger,
 		collectionInterval: cfg.CollectionInterval,
 		initialDelay:       cfg.InitialDelay,
+		jitter:             jitter,
+		schedule:           sched,
 		timeout:            cfg.Timeout,
 		nextConsumer:       nextConsumer,
+		scrapeNowCh:        make(chan chan struct{}),
 		done:               make(chan struct{}),
 		terminated:         make(chan struct{}),
 		obsrecv:            obsrecv,
@@ -153,35 +185,103 @@ func (sc *controller) Shutdown(ctx context.Context) error {
 }
 
 // startScraping initiates a ticker that calls Scrape based on the configured
-// collection interval.
+// collection interval, or a timer following the configured schedule.
 func (sc *controller) startScraping() {
 	go func() {
-		if sc.initialDelay > 0 {
-			<-time.After(sc.initialDelay)
-		}
+		defer func() { sc.terminated <- struct{}{} }()
 
-		if sc.tickerCh == nil {
-			ticker := time.NewTicker(sc.collectionInterval)
-			defer ticker.Stop()
+		if sc.schedule == nil {
+			if !sc.wait(sc.initialDelay + sc.jitter) {
+				return
+			}
+			if sc.tickerCh == nil {
+				ticker := time.NewTicker(sc.collectionInterval)
+				defer ticker.Stop()
 
-			sc.tickerCh = ticker.C
+				sc.tickerCh = ticker.C
+			}
+			// Call scrape method on initialision to ensure
+			// that scrapers start from when the component starts
+			// instead of waiting for the full duration to start.
+			sc.scrapeMetricsAndReport()
 		}
-		// Call scrape method on initialision to ensure
-		// that scrapers start from when the component starts
-		// instead of waiting for the full duration to start.
-		sc.scrapeMetricsAndReport()
 		for {
+			tickerCh := sc.tickerCh
+			var timer *time.Timer
+			if tickerCh == nil {
+				timer = time.NewTimer(sc.untilScheduledScrape(time.Now()))
+				tickerCh = timer.C
+			}
 			select {
-			case <-sc.tickerCh:
+			case <-tickerCh:
+				sc.scrapeMetricsAndReport()
+			case scraped := <-sc.scrapeNowCh:
 				sc.scrapeMetricsAndReport()
+				close(scraped)
 			case <-sc.done:
-				sc.terminated <- struct{}{}
+				if timer != nil {
+					timer.Stop()
+				}
 				return
 			}
+			if timer != nil {
+				timer.Stop()
+			}
 		}
 	}()
 }
 
+// wait waits for the given delay, scraping on demand meanwhile, and returns false if the controller is stopped.
+func (sc *controller) wait(delay time.Duration) bool {
+	if delay <= 0 {
+		return true
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+			return true
+		case scraped := <-sc.scrapeNowCh:
+			sc.scrapeMetricsAndReport()
+			close(scraped)
+		case <-sc.done:
+			return false
+		}
+	}
+}
+
+// untilScheduledScrape returns the time until the next scrape of the schedule, shifted by the jitter.
+func (sc *controller) untilScheduledScrape(now time.Time) time.Duration {
+	next := sc.schedule.next(now.Add(-sc.jitter))
+	if next.IsZero() {
+		// The schedule never matches, e.g. "0 0 30 2 *", wait as long as possible.
+		return math.MaxInt64
+	}
+	return next.Add(sc.jitter).Sub(now)
+}
+
+// ScrapeNow implements ScrapeTrigger.
+func (sc *controller) ScrapeNow(ctx context.Context) error {
+	if !sc.initialized {
+		return errControllerNotStarted
+	}
+	scraped := make(chan struct{})
+	select {
+	case sc.scrapeNowCh <- scraped:
+	case <-sc.done:
+		return errControllerNotStarted
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-scraped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // scrapeMetricsAndReport calls the Scrape function for each of the configured
 // Scrapers, records observability information, and passes the scraped metrics
 // to the next component.
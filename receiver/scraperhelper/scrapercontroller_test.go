@@ -435,3 +435,76 @@ func TestScrapeControllerInitialDelay(t *testing.T) {
 
 	assert.NoError(t, r.Shutdown(context.Background()), "Must not error closing down")
 }
+
+func TestScrapeControllerScrapeNow(t *testing.T) {
+	t.Parallel()
+
+	tsm := &testScrapeMetrics{ch: make(chan int, 10)}
+	scp, err := NewScraper("", tsm.scrape)
+	require.NoError(t, err)
+
+	sink := new(consumertest.MetricsSink)
+	r, err := NewScraperControllerReceiver(
+		&ScraperControllerSettings{
+			CollectionInterval: time.Hour,
+			InitialDelay:       time.Hour,
+		},
+		receivertest.NewNopCreateSettings(),
+		sink,
+		AddScraper(scp),
+	)
+	require.NoError(t, err)
+	trigger, ok := r.(ScrapeTrigger)
+	require.True(t, ok)
+	assert.ErrorIs(t, trigger.ScrapeNow(context.Background()), errControllerNotStarted)
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	// The scrapes on demand are not delayed by the initial delay.
+	require.NoError(t, trigger.ScrapeNow(context.Background()))
+	require.NoError(t, trigger.ScrapeNow(context.Background()))
+	assert.Len(t, sink.AllMetrics(), 2)
+
+	require.NoError(t, r.Shutdown(context.Background()))
+	assert.ErrorIs(t, trigger.ScrapeNow(context.Background()), errControllerNotStarted)
+}
+
+func TestScrapeControllerSchedule(t *testing.T) {
+	t.Parallel()
+
+	tsm := &testScrapeMetrics{ch: make(chan int, 10)}
+	scp, err := NewScraper("", tsm.scrape)
+	require.NoError(t, err)
+
+	_, err = NewScraperControllerReceiver(
+		&ScraperControllerSettings{CollectionInterval: time.Minute, Schedule: "* * *"},
+		receivertest.NewNopCreateSettings(),
+		new(consumertest.MetricsSink),
+		AddScraper(scp),
+	)
+	assert.EqualError(t, err, `invalid schedule "* * *": expected 5 fields, got 3`)
+
+	tickerCh := make(chan time.Time)
+	sink := new(consumertest.MetricsSink)
+	r, err := NewScraperControllerReceiver(
+		&ScraperControllerSettings{CollectionInterval: time.Minute, Schedule: "*/5 * * * *", InitialDelayJitter: time.Minute},
+		receivertest.NewNopCreateSettings(),
+		sink,
+		AddScraper(scp),
+		WithTickerChannel(tickerCh),
+	)
+	require.NoError(t, err)
+	sc := r.(*controller)
+	assert.GreaterOrEqual(t, sc.jitter, time.Duration(0))
+	assert.Less(t, sc.jitter, time.Minute)
+
+	sc.jitter = 30 * time.Second
+	now := time.Date(2023, 9, 1, 10, 2, 0, 0, time.UTC)
+	assert.Equal(t, 3*time.Minute+30*time.Second, sc.untilScheduledScrape(now))
+	assert.Equal(t, 5*time.Minute, sc.untilScheduledScrape(now.Add(3*time.Minute+30*time.Second)))
+
+	// With a schedule, the scrapers are not called on start.
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	tickerCh <- time.Now()
+	assert.Equal(t, 1, <-tsm.ch)
+	require.NoError(t, r.Shutdown(context.Background()))
+}
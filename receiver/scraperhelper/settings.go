@@ -28,6 +28,13 @@ type ScraperControllerSettings struct {
 	// InitialDelay sets the initial start delay for the scraper,
 	// any non positive value is assumed to be immediately.
 	InitialDelay time.Duration `mapstructure:"initial_delay"`
+	// InitialDelayJitter is the maximum of a random delay added to the initial delay,
+	// and to every time of the Schedule, so that many collectors do not scrape in lockstep.
+	InitialDelayJitter time.Duration `mapstructure:"initial_delay_jitter"`
+	// Schedule is an optional cron expression, e.g. "*/5 * * * *", scheduling the scrapes
+	// in the local time instead of the CollectionInterval. With a schedule, the scrapers are not
+	// called on start.
+	Schedule string `mapstructure:"schedule"`
 	// Timeout is an optional value used to set scraper's context deadline.
 	Timeout time.Duration `mapstructure:"timeout"`
 }
@@ -49,5 +56,13 @@ func (set *ScraperControllerSettings) Validate() (errs error) {
 	if set.Timeout < 0 {
 		errs = multierr.Append(errs, fmt.Errorf(`"timeout": %w`, errNonPositiveInterval))
 	}
+	if set.InitialDelayJitter < 0 {
+		errs = multierr.Append(errs, fmt.Errorf(`"initial_delay_jitter": %w`, errNonPositiveInterval))
+	}
+	if set.Schedule != "" {
+		if _, err := parseSchedule(set.Schedule); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf(`"schedule": %w`, err))
+		}
+	}
 	return errs
 }
@@ -36,6 +36,29 @@ func TestScrapeControllerSettings(t *testing.T) {
 			},
 			errVal: `"timeout": requires positive value`,
 		},
+		{
+			name: "invalid initial delay jitter",
+			set: ScraperControllerSettings{
+				CollectionInterval: time.Minute,
+				InitialDelayJitter: -1 * time.Second,
+			},
+			errVal: `"initial_delay_jitter": requires positive value`,
+		},
+		{
+			name: "invalid schedule",
+			set: ScraperControllerSettings{
+				CollectionInterval: time.Minute,
+				Schedule:           "61 * * * *",
+			},
+			errVal: `"schedule": invalid minute "61": the values must be in the range 0-59`,
+		},
+		{
+			name: "schedule",
+			set: ScraperControllerSettings{
+				CollectionInterval: time.Minute,
+				Schedule:           "@hourly",
+			},
+		},
 	} {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
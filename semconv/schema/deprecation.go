@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schema // import "go.opentelemetry.io/collector/semconv/schema"
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	version "github.com/hashicorp/go-version"
+)
+
+// AttributeDeprecation describes an attribute key renamed by a version of the semantic conventions.
+type AttributeDeprecation struct {
+	// Key is the deprecated attribute key.
+	Key string
+	// Since is the version of the semantic conventions that renamed the attribute, e.g. "1.17.0".
+	Since string
+	// Replacement is the key replacing the attribute, following the successive renames up to the queried version.
+	Replacement string
+	// Section is the section of the schema file renaming the attribute, e.g. "spans" or "all".
+	// The renaming may only apply to some spans, span events or metrics of the section.
+	Section string
+}
+
+// DeprecatedAttribute returns the deprecation of the attribute key in the version of schemaURL,
+// or nil if the key is not renamed by that version or a previous one.
+func (s *Schema) DeprecatedAttribute(key, schemaURL string) (*AttributeDeprecation, error) {
+	index, err := s.deprecationIndex(schemaURL)
+	if err != nil {
+		return nil, err
+	}
+	if d, ok := index.byKey[key]; ok {
+		return &d, nil
+	}
+	return nil, nil
+}
+
+// DeprecatedAttributes returns the deprecations of all the attribute keys renamed by the version
+// of schemaURL or a previous one, sorted by key.
+func (s *Schema) DeprecatedAttributes(schemaURL string) ([]AttributeDeprecation, error) {
+	index, err := s.deprecationIndex(schemaURL)
+	if err != nil {
+		return nil, err
+	}
+	return append([]AttributeDeprecation(nil), index.sorted...), nil
+}
+
+// deprecationIndex holds the attribute deprecations of a version, computed once and only read afterwards.
+type deprecationIndex struct {
+	once   sync.Once
+	byKey  map[string]AttributeDeprecation
+	sorted []AttributeDeprecation
+}
+
+// deprecationIndex returns the attribute deprecations of the version of schemaURL, computing them on the first lookup.
+func (s *Schema) deprecationIndex(schemaURL string) (*deprecationIndex, error) {
+	if family(schemaURL) != family(s.URL) {
+		return nil, fmt.Errorf("schema URL %q does not belong to the schema family of %q", schemaURL, s.URL)
+	}
+	target, err := urlVersion(schemaURL)
+	if err != nil {
+		return nil, err
+	}
+	latest, err := urlVersion(s.URL)
+	if err != nil {
+		return nil, err
+	}
+	if target.GreaterThan(latest) {
+		return nil, fmt.Errorf("the schema file %q does not describe the changes up to %q", s.URL, schemaURL)
+	}
+
+	s.deprecationsMu.Lock()
+	if s.deprecations == nil {
+		s.deprecations = map[string]*deprecationIndex{}
+	}
+	index, ok := s.deprecations[target.String()]
+	if !ok {
+		index = &deprecationIndex{}
+		s.deprecations[target.String()] = index
+	}
+	s.deprecationsMu.Unlock()
+
+	index.once.Do(func() {
+		index.byKey = s.computeDeprecations(target)
+		index.sorted = make([]AttributeDeprecation, 0, len(index.byKey))
+		for _, d := range index.byKey {
+			index.sorted = append(index.sorted, d)
+		}
+		sort.Slice(index.sorted, func(i, j int) bool { return index.sorted[i].Key < index.sorted[j].Key })
+	})
+	return index, nil
+}
+
+// computeDeprecations returns the attribute keys renamed by the target version or a previous one.
+func (s *Schema) computeDeprecations(target *version.Version) map[string]AttributeDeprecation {
+	deprecations := map[string]AttributeDeprecation{}
+	for _, v := range s.versions {
+		if v.version.GreaterThan(target) {
+			break
+		}
+		for _, op := range versionOperations(v.changes) {
+			for from, to := range op.attributes {
+				// the keys deprecated by previous versions are now replaced by the new key
+				for key, d := range deprecations {
+					if d.Replacement == from {
+						d.Replacement = to
+						deprecations[key] = d
+					}
+				}
+				if _, ok := deprecations[from]; !ok {
+					deprecations[from] = AttributeDeprecation{Key: from, Since: v.version.Original(), Replacement: to, Section: op.section}
+				}
+			}
+		}
+	}
+	return deprecations
+}
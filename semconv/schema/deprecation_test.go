@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const chainedSchema = `file_format: 1.1.0
+schema_url: https://opentelemetry.io/schemas/1.2.0
+versions:
+  1.2.0:
+    spans:
+      changes:
+        - rename_attributes:
+            attribute_map:
+              net.sock.peer.addr: network.peer.address
+  1.1.0:
+    spans:
+      changes:
+        - rename_attributes:
+            attribute_map:
+              net.peer.ip: net.sock.peer.addr
+  1.0.0:
+`
+
+func TestDeprecatedAttribute(t *testing.T) {
+	s := loadSchema(t)
+
+	d, err := s.DeprecatedAttribute("browser.user_agent", schemaURL120)
+	require.NoError(t, err)
+	assert.Equal(t, &AttributeDeprecation{Key: "browser.user_agent", Since: "1.1.0", Replacement: "user_agent.original", Section: "resources"}, d)
+
+	d, err = s.DeprecatedAttribute("http.status_code", schemaURL120)
+	require.NoError(t, err)
+	assert.Equal(t, &AttributeDeprecation{Key: "http.status_code", Since: "1.2.0", Replacement: "http.response.status_code", Section: "spans"}, d)
+
+	// not deprecated yet in 1.1.0
+	d, err = s.DeprecatedAttribute("http.status_code", schemaURL110)
+	require.NoError(t, err)
+	assert.Nil(t, d)
+
+	d, err = s.DeprecatedAttribute("http.response.status_code", schemaURL120)
+	require.NoError(t, err)
+	assert.Nil(t, d)
+
+	_, err = s.DeprecatedAttribute("http.status_code", "https://opentelemetry.io/schemas/1.3.0")
+	assert.ErrorContains(t, err, "does not describe the changes")
+	_, err = s.DeprecatedAttribute("http.status_code", "https://example.com/schemas/1.0.0")
+	assert.ErrorContains(t, err, "does not belong to the schema family")
+	_, err = s.DeprecatedAttribute("http.status_code", "https://opentelemetry.io/schemas/latest")
+	assert.ErrorContains(t, err, "invalid schema URL")
+}
+
+func TestDeprecatedAttributesChained(t *testing.T) {
+	s, err := Parse(strings.NewReader(chainedSchema))
+	require.NoError(t, err)
+
+	deprecations, err := s.DeprecatedAttributes(schemaURL110)
+	require.NoError(t, err)
+	assert.Equal(t, []AttributeDeprecation{
+		{Key: "net.peer.ip", Since: "1.1.0", Replacement: "net.sock.peer.addr", Section: "spans"},
+	}, deprecations)
+
+	deprecations, err = s.DeprecatedAttributes(schemaURL120)
+	require.NoError(t, err)
+	assert.Equal(t, []AttributeDeprecation{
+		{Key: "net.peer.ip", Since: "1.1.0", Replacement: "network.peer.address", Section: "spans"},
+		{Key: "net.sock.peer.addr", Since: "1.2.0", Replacement: "network.peer.address", Section: "spans"},
+	}, deprecations)
+}
+
+func TestDeprecatedAttributesComputedOnce(t *testing.T) {
+	s := loadSchema(t)
+
+	deprecations, err := s.DeprecatedAttributes(schemaURL120)
+	require.NoError(t, err)
+	require.NotEmpty(t, deprecations)
+	index := s.deprecations["1.2.0"]
+	require.NotNil(t, index)
+
+	// the lookups share the deprecations computed by the first one, which are not modified by the callers
+	deprecations[0].Replacement = "modified"
+	again, err := s.DeprecatedAttributes(schemaURL120)
+	require.NoError(t, err)
+	assert.NotEqual(t, "modified", again[0].Replacement)
+	d, err := s.DeprecatedAttribute(again[0].Key, schemaURL120)
+	require.NoError(t, err)
+	d.Replacement = "modified"
+	assert.Same(t, index, s.deprecations["1.2.0"])
+	assert.NotEqual(t, "modified", index.byKey[again[0].Key].Replacement)
+}
@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schema // import "go.opentelemetry.io/collector/semconv/schema"
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"go.uber.org/multierr"
+)
+
+// Provider retrieves the schema files published at the schema URLs, and caches them.
+type Provider struct {
+	client *http.Client
+
+	mu      sync.Mutex
+	schemas map[string]*schemaFetch
+}
+
+// schemaFetch is the retrieval of a schema file, shared by the concurrent lookups of its schema URL.
+type schemaFetch struct {
+	done   chan struct{}
+	schema *Schema
+	err    error
+}
+
+// NewProvider returns a Provider retrieving the schema files with the given client.
+func NewProvider(client *http.Client) *Provider {
+	return &Provider{client: client, schemas: map[string]*schemaFetch{}}
+}
+
+// Add adds a schema file to the cache of the provider, e.g. a schema file embedded in the program,
+// so that it is not retrieved from its schema URL.
+func (p *Provider) Add(s *Schema) {
+	done := make(chan struct{})
+	close(done)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.schemas[s.URL] = &schemaFetch{done: done, schema: s}
+}
+
+// Schema returns the schema file published at schemaURL. The file is retrieved once, the failed
+// retrievals are retried by the next lookups.
+func (p *Provider) Schema(ctx context.Context, schemaURL string) (*Schema, error) {
+	p.mu.Lock()
+	f, ok := p.schemas[schemaURL]
+	if !ok {
+		f = &schemaFetch{done: make(chan struct{})}
+		p.schemas[schemaURL] = f
+	}
+	p.mu.Unlock()
+
+	if !ok {
+		f.schema, f.err = p.fetch(ctx, schemaURL)
+		if f.err != nil {
+			p.mu.Lock()
+			delete(p.schemas, schemaURL)
+			p.mu.Unlock()
+		}
+		close(f.done)
+	}
+	select {
+	case <-f.done:
+		return f.schema, f.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// cachedSchema returns a schema file of the cache describing the changes up to the version of
+// schemaURL, nil if there is none.
+func (p *Provider) cachedSchema(schemaURL string) *Schema {
+	target, err := urlVersion(schemaURL)
+	if err != nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, f := range p.schemas {
+		select {
+		case <-f.done:
+		default:
+			continue
+		}
+		if f.schema == nil || family(f.schema.URL) != family(schemaURL) {
+			continue
+		}
+		if latest, err := urlVersion(f.schema.URL); err == nil && !target.GreaterThan(latest) {
+			return f.schema
+		}
+	}
+	return nil
+}
+
+func (p *Provider) fetch(ctx context.Context, schemaURL string) (*Schema, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, schemaURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve the schema file %q: %w", schemaURL, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, multierr.Append(fmt.Errorf("failed to retrieve the schema file %q: %s", schemaURL, resp.Status), resp.Body.Close())
+	}
+	s, err := Parse(resp.Body)
+	if err = multierr.Append(err, resp.Body.Close()); err != nil {
+		return nil, fmt.Errorf("failed to retrieve the schema file %q: %w", schemaURL, err)
+	}
+	return s, nil
+}
+
+// Transformer returns a Transformer converting the telemetry from the version of sourceURL to the version
+// of targetURL, using the schema file of the newest of the two versions, which describes the changes of all
+// the previous versions.
+func (p *Provider) Transformer(ctx context.Context, sourceURL, targetURL string) (*Transformer, error) {
+	source, err := urlVersion(sourceURL)
+	if err != nil {
+		return nil, err
+	}
+	target, err := urlVersion(targetURL)
+	if err != nil {
+		return nil, err
+	}
+	schemaURL := targetURL
+	if source.GreaterThan(target) {
+		schemaURL = sourceURL
+	}
+	s, err := p.Schema(ctx, schemaURL)
+	if err != nil {
+		return nil, err
+	}
+	return s.Transformer(sourceURL, targetURL)
+}
+
+// DeprecatedAttribute returns the deprecation of the attribute key in the version of schemaURL, e.g. the
+// SchemaURL of a semconv package. It uses a schema file of the cache describing that version if any, e.g.
+// one added with Add, and otherwise the schema file published at schemaURL.
+func (p *Provider) DeprecatedAttribute(ctx context.Context, key, schemaURL string) (*AttributeDeprecation, error) {
+	s := p.cachedSchema(schemaURL)
+	if s == nil {
+		var err error
+		if s, err = p.Schema(ctx, schemaURL); err != nil {
+			return nil, err
+		}
+	}
+	return s.DeprecatedAttribute(key, schemaURL)
+}
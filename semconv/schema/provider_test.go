@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvider(t *testing.T) {
+	file, err := os.ReadFile(filepath.Join("testdata", "schema.yaml"))
+	require.NoError(t, err)
+
+	var fetches atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/schemas/1.2.0" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fetches.Add(1)
+		// the schema URL of the file is rewritten to the one of the test server
+		_, _ = w.Write([]byte(strings.ReplaceAll(string(file), "https://opentelemetry.io", "http://"+r.Host)))
+	}))
+	defer srv.Close()
+
+	p := NewProvider(srv.Client())
+	tr, err := p.Transformer(context.Background(), srv.URL+"/schemas/1.0.0", srv.URL+"/schemas/1.2.0")
+	require.NoError(t, err)
+	assert.Equal(t, srv.URL+"/schemas/1.2.0", tr.TargetURL())
+
+	// the schema file of the newest version is used, and cached
+	tr, err = p.Transformer(context.Background(), srv.URL+"/schemas/1.2.0", srv.URL+"/schemas/1.1.0")
+	require.NoError(t, err)
+	assert.Equal(t, srv.URL+"/schemas/1.1.0", tr.TargetURL())
+	assert.Equal(t, int32(1), fetches.Load())
+
+	_, err = p.Transformer(context.Background(), srv.URL+"/schemas/1.0.0", srv.URL+"/schemas/1.1.0")
+	assert.ErrorContains(t, err, "404 Not Found")
+
+	d, err := p.DeprecatedAttribute(context.Background(), "browser.user_agent", srv.URL+"/schemas/1.2.0")
+	require.NoError(t, err)
+	assert.Equal(t, "user_agent.original", d.Replacement)
+	// the cached schema file of 1.2.0 describes the changes up to 1.1.0
+	d, err = p.DeprecatedAttribute(context.Background(), "browser.user_agent", srv.URL+"/schemas/1.1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "user_agent.original", d.Replacement)
+	assert.Equal(t, int32(1), fetches.Load())
+
+	_, err = p.Transformer(context.Background(), srv.URL+"/schemas/latest", srv.URL+"/schemas/1.1.0")
+	assert.ErrorContains(t, err, "invalid schema URL")
+	_, err = p.Transformer(context.Background(), srv.URL+"/schemas/1.0.0", srv.URL+"/schemas/latest")
+	assert.ErrorContains(t, err, "invalid schema URL")
+}
+
+func TestProviderAdd(t *testing.T) {
+	s := loadSchema(t)
+	// no schema file is retrieved
+	p := NewProvider(&http.Client{Transport: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		t.Fatal("unexpected request")
+		return nil, nil
+	})})
+	p.Add(s)
+
+	got, err := p.Schema(context.Background(), schemaURL120)
+	require.NoError(t, err)
+	assert.Same(t, s, got)
+	d, err := p.DeprecatedAttribute(context.Background(), "browser.user_agent", schemaURL110)
+	require.NoError(t, err)
+	assert.Equal(t, "user_agent.original", d.Replacement)
+}
+
+func TestProviderConcurrentLookups(t *testing.T) {
+	file, err := os.ReadFile(filepath.Join("testdata", "schema.yaml"))
+	require.NoError(t, err)
+
+	var fetches atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fetches.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(strings.ReplaceAll(string(file), "https://opentelemetry.io", "http://"+r.Host)))
+	}))
+	defer srv.Close()
+
+	p := NewProvider(srv.Client())
+	// the failed retrievals are retried
+	_, err = p.Schema(context.Background(), srv.URL+"/schemas/1.2.0")
+	assert.ErrorContains(t, err, "503 Service Unavailable")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d, err := p.DeprecatedAttribute(context.Background(), "http.status_code", srv.URL+"/schemas/1.2.0")
+			assert.NoError(t, err)
+			assert.Equal(t, "http.response.status_code", d.Replacement)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(2), fetches.Load())
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package schema converts telemetry between versions of the semantic conventions, by applying the
+// changes described by the schema files published at the schema URLs, see
+// https://opentelemetry.io/docs/specs/otel/schemas/file_format_v1.1.0/.
+package schema // import "go.opentelemetry.io/collector/semconv/schema"
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	version "github.com/hashicorp/go-version"
+	"gopkg.in/yaml.v3"
+)
+
+// supportedFileFormat is the major version of the file format of the supported schema files.
+const supportedFileFormat = 1
+
+// Schema is a parsed schema file, describing the changes between the versions of a schema family.
+type Schema struct {
+	// URL is the schema URL of the schema file, e.g. "https://opentelemetry.io/schemas/1.18.0".
+	URL string
+
+	// versions are the versions described by the file, sorted in ascending order.
+	versions []schemaVersion
+
+	// deprecations are the attribute deprecations of the versions looked up, by version.
+	deprecationsMu sync.Mutex
+	deprecations   map[string]*deprecationIndex
+}
+
+type schemaVersion struct {
+	version *version.Version
+	changes versionDef
+}
+
+type schemaFile struct {
+	FileFormat string                `yaml:"file_format"`
+	SchemaURL  string                `yaml:"schema_url"`
+	Versions   map[string]versionDef `yaml:"versions"`
+}
+
+type versionDef struct {
+	All        section `yaml:"all"`
+	Resources  section `yaml:"resources"`
+	Spans      section `yaml:"spans"`
+	SpanEvents section `yaml:"span_events"`
+	Metrics    section `yaml:"metrics"`
+	Logs       section `yaml:"logs"`
+}
+
+type section struct {
+	Changes []change `yaml:"changes"`
+}
+
+type change struct {
+	RenameAttributes *renameAttributesChange `yaml:"rename_attributes"`
+	RenameEvents     *renameEventsChange     `yaml:"rename_events"`
+	RenameMetrics    map[string]string       `yaml:"rename_metrics"`
+}
+
+type renameAttributesChange struct {
+	AttributeMap   map[string]string `yaml:"attribute_map"`
+	ApplyToSpans   []string          `yaml:"apply_to_spans"`
+	ApplyToEvents  []string          `yaml:"apply_to_events"`
+	ApplyToMetrics []string          `yaml:"apply_to_metrics"`
+}
+
+type renameEventsChange struct {
+	NameMap map[string]string `yaml:"name_map"`
+}
+
+// Parse parses a schema file in the format 1.x.
+func Parse(r io.Reader) (*Schema, error) {
+	var file schemaFile
+	if err := yaml.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("invalid schema file: %w", err)
+	}
+
+	format, err := version.NewVersion(file.FileFormat)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema file: invalid file_format %q: %w", file.FileFormat, err)
+	}
+	if format.Segments()[0] != supportedFileFormat {
+		return nil, fmt.Errorf("invalid schema file: unsupported file_format %q", file.FileFormat)
+	}
+	if file.SchemaURL == "" {
+		return nil, errors.New("invalid schema file: schema_url is required")
+	}
+	if _, err = urlVersion(file.SchemaURL); err != nil {
+		return nil, fmt.Errorf("invalid schema file: %w", err)
+	}
+
+	s := &Schema{URL: file.SchemaURL}
+	for v, changes := range file.Versions {
+		ver, err := version.NewVersion(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schema file: invalid version %q: %w", v, err)
+		}
+		s.versions = append(s.versions, schemaVersion{version: ver, changes: changes})
+	}
+	sort.Slice(s.versions, func(i, j int) bool { return s.versions[i].version.LessThan(s.versions[j].version) })
+	return s, nil
+}
+
+// urlVersion returns the version of a schema URL, which is its last path element,
+// e.g. "1.18.0" for "https://opentelemetry.io/schemas/1.18.0".
+func urlVersion(schemaURL string) (*version.Version, error) {
+	v, err := version.NewVersion(path.Base(schemaURL))
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema URL %q: the last path element must be a version", schemaURL)
+	}
+	return v, nil
+}
+
+// family returns the schema family of a schema URL, which is the URL without its version.
+func family(schemaURL string) string {
+	return schemaURL[:strings.LastIndex(schemaURL, "/")+1]
+}
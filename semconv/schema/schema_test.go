@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	s := loadSchema(t)
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.2.0", s.URL)
+	require.Len(t, s.versions, 3)
+	for i, v := range []string{"1.0.0", "1.1.0", "1.2.0"} {
+		assert.Equal(t, v, s.versions[i].version.String())
+	}
+	assert.Equal(t, map[string]string{"container.cpu.usage.total": "container.cpu.time"}, s.versions[2].changes.Metrics.Changes[0].RenameMetrics)
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		err  string
+	}{
+		{
+			name: "invalid yaml",
+			file: "file_format: [",
+			err:  "invalid schema file",
+		},
+		{
+			name: "invalid file format",
+			file: "file_format: invalid\nschema_url: https://opentelemetry.io/schemas/1.0.0",
+			err:  `invalid file_format "invalid"`,
+		},
+		{
+			name: "unsupported file format",
+			file: "file_format: 2.0.0\nschema_url: https://opentelemetry.io/schemas/1.0.0",
+			err:  `unsupported file_format "2.0.0"`,
+		},
+		{
+			name: "missing schema url",
+			file: "file_format: 1.1.0",
+			err:  "schema_url is required",
+		},
+		{
+			name: "invalid schema url",
+			file: "file_format: 1.1.0\nschema_url: https://opentelemetry.io/schemas/latest",
+			err:  `invalid schema URL "https://opentelemetry.io/schemas/latest"`,
+		},
+		{
+			name: "invalid version",
+			file: "file_format: 1.1.0\nschema_url: https://opentelemetry.io/schemas/1.0.0\nversions:\n  invalid:\n",
+			err:  `invalid version "invalid"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.file))
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func loadSchema(t *testing.T) *Schema {
+	f, err := os.Open(filepath.Join("testdata", "schema.yaml"))
+	require.NoError(t, err)
+	defer f.Close()
+	s, err := Parse(f)
+	require.NoError(t, err)
+	return s
+}
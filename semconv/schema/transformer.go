@@ -0,0 +1,337 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schema // import "go.opentelemetry.io/collector/semconv/schema"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Transformer converts telemetry from a version of the semantic conventions to another one of the same
+// schema family, by renaming the attributes, the span events and the metrics as described by the schema file.
+//
+// When an attribute is renamed to a name that is already used, the existing attribute is kept.
+type Transformer struct {
+	targetURL  string
+	operations []operation
+}
+
+// operation is a change of the schema file, in the direction of the transformation.
+type operation struct {
+	// section is the section of the schema file the change belongs to, e.g. "spans".
+	section string
+
+	attributes map[string]string
+	events     map[string]string
+	metrics    map[string]string
+
+	// the selectors of the attributes renaming, nil if the renaming applies to everything.
+	applyToSpans   map[string]bool
+	applyToEvents  map[string]bool
+	applyToMetrics map[string]bool
+}
+
+const (
+	sectionAll        = "all"
+	sectionResources  = "resources"
+	sectionSpans      = "spans"
+	sectionSpanEvents = "span_events"
+	sectionMetrics    = "metrics"
+	sectionLogs       = "logs"
+)
+
+// Transformer returns a Transformer converting the telemetry from the version of sourceURL to the version
+// of targetURL. Both URLs must belong to the schema family of the schema file. When upgrading, targetURL must
+// not be newer than the schema file; when downgrading, sourceURL must not be newer than the schema file.
+func (s *Schema) Transformer(sourceURL, targetURL string) (*Transformer, error) {
+	if family(sourceURL) != family(s.URL) || family(targetURL) != family(s.URL) {
+		return nil, fmt.Errorf("schema URLs %q and %q do not belong to the schema family of %q", sourceURL, targetURL, s.URL)
+	}
+	source, err := urlVersion(sourceURL)
+	if err != nil {
+		return nil, err
+	}
+	target, err := urlVersion(targetURL)
+	if err != nil {
+		return nil, err
+	}
+	latest, err := urlVersion(s.URL)
+	if err != nil {
+		return nil, err
+	}
+	if source.GreaterThan(latest) || target.GreaterThan(latest) {
+		return nil, fmt.Errorf("the schema file %q does not describe the changes up to %q and %q", s.URL, sourceURL, targetURL)
+	}
+
+	t := &Transformer{targetURL: targetURL}
+	if target.GreaterThanOrEqual(source) {
+		// upgrade: apply the changes of the versions in (source, target], oldest first
+		for _, v := range s.versions {
+			if v.version.GreaterThan(source) && !v.version.GreaterThan(target) {
+				t.operations = append(t.operations, versionOperations(v.changes)...)
+			}
+		}
+		return t, nil
+	}
+
+	// downgrade: revert the changes of the versions in (target, source], newest first
+	for i := len(s.versions) - 1; i >= 0; i-- {
+		v := s.versions[i]
+		if v.version.GreaterThan(target) && !v.version.GreaterThan(source) {
+			ops := versionOperations(v.changes)
+			for j := len(ops) - 1; j >= 0; j-- {
+				t.operations = append(t.operations, ops[j].reverse())
+			}
+		}
+	}
+	return t, nil
+}
+
+func versionOperations(def versionDef) []operation {
+	var ops []operation
+	for _, sec := range []struct {
+		name string
+		section
+	}{
+		{sectionAll, def.All},
+		{sectionResources, def.Resources},
+		{sectionSpans, def.Spans},
+		{sectionSpanEvents, def.SpanEvents},
+		{sectionMetrics, def.Metrics},
+		{sectionLogs, def.Logs},
+	} {
+		for _, c := range sec.Changes {
+			op := operation{section: sec.name, metrics: c.RenameMetrics}
+			if c.RenameEvents != nil {
+				op.events = c.RenameEvents.NameMap
+			}
+			if c.RenameAttributes != nil {
+				op.attributes = c.RenameAttributes.AttributeMap
+				op.applyToSpans = selector(c.RenameAttributes.ApplyToSpans)
+				op.applyToEvents = selector(c.RenameAttributes.ApplyToEvents)
+				op.applyToMetrics = selector(c.RenameAttributes.ApplyToMetrics)
+			}
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+func selector(names []string) map[string]bool {
+	if len(names) == 0 {
+		return nil
+	}
+	sel := make(map[string]bool, len(names))
+	for _, name := range names {
+		sel[name] = true
+	}
+	return sel
+}
+
+// reverse returns the operation reverting the renames of op. The selectors reference the names of the
+// version the change is defined in, so the renamed events and metrics are selected with their new names.
+func (op operation) reverse() operation {
+	op.attributes = invert(op.attributes)
+	op.events = invert(op.events)
+	op.metrics = invert(op.metrics)
+	return op
+}
+
+func invert(renames map[string]string) map[string]string {
+	if renames == nil {
+		return nil
+	}
+	inverted := make(map[string]string, len(renames))
+	for from, to := range renames {
+		inverted[to] = from
+	}
+	return inverted
+}
+
+// selects returns whether name is selected by sel.
+func selects(sel map[string]bool, name string) bool {
+	return sel == nil || sel[name]
+}
+
+// TargetURL returns the schema URL of the telemetry once transformed.
+func (t *Transformer) TargetURL() string {
+	return t.targetURL
+}
+
+// TransformTraces converts the traces in place, and sets their schema URLs to the target one.
+func (t *Transformer) TransformTraces(td ptrace.Traces) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		rs.SetSchemaUrl(t.targetURL)
+		for _, op := range t.operations {
+			if op.section == sectionAll || op.section == sectionResources {
+				renameAttributes(rs.Resource().Attributes(), op.attributes)
+			}
+		}
+		sss := rs.ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			ss := sss.At(j)
+			ss.SetSchemaUrl(t.targetURL)
+			spans := ss.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				t.transformSpan(spans.At(k))
+			}
+		}
+	}
+}
+
+func (t *Transformer) transformSpan(span ptrace.Span) {
+	for _, op := range t.operations {
+		switch op.section {
+		case sectionAll:
+			renameAttributes(span.Attributes(), op.attributes)
+			events := span.Events()
+			for i := 0; i < events.Len(); i++ {
+				renameAttributes(events.At(i).Attributes(), op.attributes)
+			}
+		case sectionSpans:
+			if selects(op.applyToSpans, span.Name()) {
+				renameAttributes(span.Attributes(), op.attributes)
+			}
+		case sectionSpanEvents:
+			events := span.Events()
+			for i := 0; i < events.Len(); i++ {
+				event := events.At(i)
+				if to, ok := op.events[event.Name()]; ok {
+					event.SetName(to)
+				}
+				if op.attributes != nil && selects(op.applyToSpans, span.Name()) && selects(op.applyToEvents, event.Name()) {
+					renameAttributes(event.Attributes(), op.attributes)
+				}
+			}
+		}
+	}
+}
+
+// TransformMetrics converts the metrics in place, and sets their schema URLs to the target one.
+func (t *Transformer) TransformMetrics(md pmetric.Metrics) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		rm.SetSchemaUrl(t.targetURL)
+		for _, op := range t.operations {
+			if op.section == sectionAll || op.section == sectionResources {
+				renameAttributes(rm.Resource().Attributes(), op.attributes)
+			}
+		}
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			sm.SetSchemaUrl(t.targetURL)
+			metrics := sm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				t.transformMetric(metrics.At(k))
+			}
+		}
+	}
+}
+
+func (t *Transformer) transformMetric(metric pmetric.Metric) {
+	for _, op := range t.operations {
+		switch op.section {
+		case sectionAll:
+			rangeDataPointAttributes(metric, func(attrs pcommon.Map) {
+				renameAttributes(attrs, op.attributes)
+			})
+		case sectionMetrics:
+			if to, ok := op.metrics[metric.Name()]; ok {
+				metric.SetName(to)
+			}
+			if op.attributes != nil && selects(op.applyToMetrics, metric.Name()) {
+				rangeDataPointAttributes(metric, func(attrs pcommon.Map) {
+					renameAttributes(attrs, op.attributes)
+				})
+			}
+		}
+	}
+}
+
+func rangeDataPointAttributes(metric pmetric.Metric, f func(pcommon.Map)) {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := metric.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		dps := metric.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := metric.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).Attributes())
+		}
+	}
+}
+
+// TransformLogs converts the logs in place, and sets their schema URLs to the target one.
+func (t *Transformer) TransformLogs(ld plog.Logs) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		rl.SetSchemaUrl(t.targetURL)
+		for _, op := range t.operations {
+			if op.section == sectionAll || op.section == sectionResources {
+				renameAttributes(rl.Resource().Attributes(), op.attributes)
+			}
+		}
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			sl.SetSchemaUrl(t.targetURL)
+			lrs := sl.LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				for _, op := range t.operations {
+					if op.section == sectionAll || op.section == sectionLogs {
+						renameAttributes(lrs.At(k).Attributes(), op.attributes)
+					}
+				}
+			}
+		}
+	}
+}
+
+// renameAttributes renames the attributes at once, so that chained renames are not applied to each other.
+// When an attribute is renamed to a name that is already used, the existing attribute is kept.
+func renameAttributes(attrs pcommon.Map, renames map[string]string) {
+	if len(renames) == 0 {
+		return
+	}
+	renamed := pcommon.NewMap()
+	attrs.RemoveIf(func(k string, v pcommon.Value) bool {
+		to, ok := renames[k]
+		if ok {
+			v.CopyTo(renamed.PutEmpty(to))
+		}
+		return ok
+	})
+	renamed.Range(func(k string, v pcommon.Value) bool {
+		if _, exists := attrs.Get(k); !exists {
+			v.CopyTo(attrs.PutEmpty(k))
+		}
+		return true
+	})
+}
@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	schemaURL100 = "https://opentelemetry.io/schemas/1.0.0"
+	schemaURL110 = "https://opentelemetry.io/schemas/1.1.0"
+	schemaURL120 = "https://opentelemetry.io/schemas/1.2.0"
+)
+
+func TestTransformerInvalid(t *testing.T) {
+	s := loadSchema(t)
+	_, err := s.Transformer("https://example.com/schemas/1.0.0", schemaURL120)
+	assert.ErrorContains(t, err, "do not belong to the schema family")
+	_, err = s.Transformer("https://opentelemetry.io/schemas/latest", schemaURL120)
+	assert.ErrorContains(t, err, "invalid schema URL")
+	_, err = s.Transformer(schemaURL100, "https://opentelemetry.io/schemas/1.3.0")
+	assert.ErrorContains(t, err, "does not describe the changes")
+}
+
+func TestTransformTraces(t *testing.T) {
+	tr, err := loadSchema(t).Transformer(schemaURL100, schemaURL120)
+	require.NoError(t, err)
+	assert.Equal(t, schemaURL120, tr.TargetURL())
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.SetSchemaUrl(schemaURL100)
+	putAttributes(t, rs.Resource().Attributes(), map[string]any{"browser.user_agent": "agent", "k8s.cluster.name": "cluster"})
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	get := spans.AppendEmpty()
+	get.SetName("HTTP GET")
+	putAttributes(t, get.Attributes(), map[string]any{"http.status_code": 200, "k8s.cluster.name": "cluster"})
+	event := get.Events().AppendEmpty()
+	event.SetName("exception.stacktrace")
+	putAttributes(t, event.Attributes(), map[string]any{"message": "failure"})
+	post := spans.AppendEmpty()
+	post.SetName("HTTP POST")
+	putAttributes(t, post.Attributes(), map[string]any{"http.status_code": 200})
+
+	tr.TransformTraces(td)
+
+	assert.Equal(t, schemaURL120, rs.SchemaUrl())
+	assert.Equal(t, schemaURL120, rs.ScopeSpans().At(0).SchemaUrl())
+	assert.Equal(t, map[string]any{"user_agent.original": "agent", "kubernetes.cluster.name": "cluster"}, rs.Resource().Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"http.response.status_code": int64(200), "kubernetes.cluster.name": "cluster"}, get.Attributes().AsRaw())
+	assert.Equal(t, "exception.stack_trace", event.Name())
+	assert.Equal(t, map[string]any{"exception.message": "failure"}, event.Attributes().AsRaw())
+	// the spans renaming only applies to the "HTTP GET" spans
+	assert.Equal(t, map[string]any{"http.status_code": int64(200)}, post.Attributes().AsRaw())
+
+	// downgrading reverts the changes
+	tr, err = loadSchema(t).Transformer(schemaURL120, schemaURL100)
+	require.NoError(t, err)
+	tr.TransformTraces(td)
+
+	assert.Equal(t, schemaURL100, rs.SchemaUrl())
+	assert.Equal(t, map[string]any{"browser.user_agent": "agent", "k8s.cluster.name": "cluster"}, rs.Resource().Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"http.status_code": int64(200), "k8s.cluster.name": "cluster"}, get.Attributes().AsRaw())
+	assert.Equal(t, "exception.stacktrace", event.Name())
+	assert.Equal(t, map[string]any{"message": "failure"}, event.Attributes().AsRaw())
+}
+
+func TestTransformMetrics(t *testing.T) {
+	tr, err := loadSchema(t).Transformer(schemaURL110, schemaURL120)
+	require.NoError(t, err)
+
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	putAttributes(t, rm.Resource().Attributes(), map[string]any{"browser.user_agent": "agent"})
+	metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+	cpuTime := metrics.AppendEmpty()
+	cpuTime.SetName("container.cpu.usage.total")
+	putAttributes(t, cpuTime.SetEmptySum().DataPoints().AppendEmpty().Attributes(), map[string]any{"k8s.cluster.name": "cluster", "status": "idle"})
+	utilization := metrics.AppendEmpty()
+	utilization.SetName("system.cpu.utilization")
+	putAttributes(t, utilization.SetEmptyGauge().DataPoints().AppendEmpty().Attributes(), map[string]any{"status": "idle"})
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("histogram")
+	putAttributes(t, histogram.SetEmptyHistogram().DataPoints().AppendEmpty().Attributes(), map[string]any{"k8s.cluster.name": "cluster"})
+
+	tr.TransformMetrics(md)
+
+	assert.Equal(t, schemaURL120, rm.SchemaUrl())
+	// the resources changes of 1.1.0 are not applied when upgrading from 1.1.0
+	assert.Equal(t, map[string]any{"browser.user_agent": "agent"}, rm.Resource().Attributes().AsRaw())
+	assert.Equal(t, "container.cpu.time", cpuTime.Name())
+	assert.Equal(t, map[string]any{"kubernetes.cluster.name": "cluster", "status": "idle"}, cpuTime.Sum().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"state": "idle"}, utilization.Gauge().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"kubernetes.cluster.name": "cluster"}, histogram.Histogram().DataPoints().At(0).Attributes().AsRaw())
+}
+
+func TestTransformLogs(t *testing.T) {
+	tr, err := loadSchema(t).Transformer(schemaURL100, schemaURL120)
+	require.NoError(t, err)
+
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	putAttributes(t, rl.Resource().Attributes(), map[string]any{"browser.user_agent": "agent"})
+	sl := rl.ScopeLogs().AppendEmpty()
+	lr := sl.LogRecords().AppendEmpty()
+	putAttributes(t, lr.Attributes(), map[string]any{"process.executable_name": "otelcol", "k8s.cluster.name": "cluster"})
+
+	tr.TransformLogs(ld)
+
+	assert.Equal(t, schemaURL120, rl.SchemaUrl())
+	assert.Equal(t, schemaURL120, sl.SchemaUrl())
+	assert.Equal(t, map[string]any{"user_agent.original": "agent"}, rl.Resource().Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"process.executable.name": "otelcol", "kubernetes.cluster.name": "cluster"}, lr.Attributes().AsRaw())
+}
+
+func TestRenameAttributes(t *testing.T) {
+	attrs := pcommon.NewMap()
+	putAttributes(t, attrs, map[string]any{"a": "a", "b": "b", "c": "c", "d": "d"})
+	// chained renames are applied at once, and existing attributes are kept
+	renameAttributes(attrs, map[string]string{"a": "b", "b": "e", "c": "d"})
+	assert.Equal(t, map[string]any{"b": "a", "e": "b", "d": "d"}, attrs.AsRaw())
+}
+
+func putAttributes(t *testing.T, attrs pcommon.Map, raw map[string]any) {
+	require.NoError(t, attrs.FromRaw(raw))
+}
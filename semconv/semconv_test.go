@@ -6,6 +6,7 @@ package semconv
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	version "github.com/hashicorp/go-version"
@@ -23,7 +24,8 @@ func TestAllSemConvFilesAreCrated(t *testing.T) {
 	assert.NoError(t, err)
 
 	for _, f := range files {
-		if !f.IsDir() {
+		// Only the versioned packages are generated, e.g. the schema package is not
+		if !f.IsDir() || !strings.HasPrefix(f.Name(), "v") {
 			continue
 		}
 
@@ -4,8 +4,12 @@
 package service // import "go.opentelemetry.io/collector/service"
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/inflight"
 	"go.opentelemetry.io/collector/service/extensions"
 	"go.opentelemetry.io/collector/service/pipelines"
 	"go.opentelemetry.io/collector/service/telemetry"
@@ -24,16 +28,142 @@ type Config struct {
 
 	// Pipelines are the set of data pipelines configured for the service.
 	Pipelines pipelines.Config `mapstructure:"pipelines"`
+
+	// FeatureGates enables or disables feature gates by identifier. The gates are applied
+	// before any component is created; gates set with the --feature-gates flag take precedence.
+	FeatureGates map[string]bool `mapstructure:"feature_gates"`
+
+	// InFlight is the configuration of the accounting of the data held by the components across all the pipelines.
+	InFlight inflight.Config `mapstructure:"in_flight"`
+
+	// StartTimeout is the maximum time given to each component of the pipelines to start. The startup
+	// fails, reporting the component, when a component does not start in time. No timeout if zero.
+	StartTimeout time.Duration `mapstructure:"start_timeout"`
+
+	// BackpressureWatermark is the number of requests waiting for a processor, exporter or connector from
+	// which the edge of the pipeline passing the data to the component reports backpressure. No watermark if zero.
+	BackpressureWatermark int `mapstructure:"backpressure_watermark"`
+
+	// UnusedComponents is the handling of the configured components which are not referenced by the pipelines
+	// or the extensions of the service. The unused components are ignored by default.
+	UnusedComponents UnusedComponentsMode `mapstructure:"unused_components"`
+
+	// CrashLoopProtection is the configuration of the protection against the restart loops caused by
+	// a component failing to start.
+	CrashLoopProtection CrashLoopProtectionConfig `mapstructure:"crash_loop_protection"`
+
+	// Reload is the handling of the configuration changes, on SIGHUP or when the configuration providers
+	// report a change. The service is restarted by default.
+	Reload ReloadMode `mapstructure:"reload"`
 }
 
+// CrashLoopProtectionConfig configures the persistence of the component failing to start. When the same
+// component fails to start with the same configuration MaxFailures times in a row, the service starts
+// without that component instead of failing again.
+type CrashLoopProtectionConfig struct {
+	// Storage is the storage extension persisting the failures, the protection is disabled if not set.
+	Storage *component.ID `mapstructure:"storage"`
+
+	// MaxFailures is the number of identical failures after which the component is disabled, 3 if zero.
+	MaxFailures int `mapstructure:"max_failures"`
+}
+
+// UnusedComponentsMode is the handling of the configured components which are not used by the service.
+type UnusedComponentsMode string
+
+const (
+	// UnusedComponentsIgnore ignores the unused components, this is the default.
+	UnusedComponentsIgnore UnusedComponentsMode = "ignore"
+	// UnusedComponentsWarn logs a warning for each unused component.
+	UnusedComponentsWarn UnusedComponentsMode = "warn"
+	// UnusedComponentsError fails the validation of the configuration, reporting each unused component.
+	UnusedComponentsError UnusedComponentsMode = "error"
+)
+
+// ReloadMode is the handling of the configuration changes.
+type ReloadMode string
+
+const (
+	// ReloadRestart shuts down the service and starts it again with the new configuration, this is the default.
+	ReloadRestart ReloadMode = "restart"
+	// ReloadChangedComponents only restarts the components of the pipelines which changed, see Service.Reload,
+	// keeping the other components running. The service is restarted if other parts of the configuration changed.
+	ReloadChangedComponents ReloadMode = "changed_components"
+)
+
 func (cfg *Config) Validate() error {
-	if err := cfg.Pipelines.Validate(); err != nil {
+	var internalIDs []component.ID
+	if p := cfg.Telemetry.Metrics.Pipeline; p != nil {
+		if err := cfg.validateTelemetryPipeline(p); err != nil {
+			return fmt.Errorf("service::telemetry::metrics::pipeline config validation failed: %w", err)
+		}
+		internalIDs = append(internalIDs, p.ID)
+	}
+
+	if err := cfg.Pipelines.ValidateWithInternal(internalIDs...); err != nil {
 		return fmt.Errorf("service::pipelines config validation failed: %w", err)
 	}
 
+	if err := cfg.InFlight.Validate(); err != nil {
+		return fmt.Errorf("service::in_flight config validation failed: %w", err)
+	}
+
+	if cfg.StartTimeout < 0 {
+		return errors.New("service::start_timeout must not be negative")
+	}
+
+	if cfg.BackpressureWatermark < 0 {
+		return errors.New("service::backpressure_watermark must not be negative")
+	}
+
+	switch cfg.UnusedComponents {
+	case "", UnusedComponentsIgnore, UnusedComponentsWarn, UnusedComponentsError:
+	default:
+		return fmt.Errorf("service::unused_components must be one of %q, %q or %q, got %q",
+			UnusedComponentsIgnore, UnusedComponentsWarn, UnusedComponentsError, cfg.UnusedComponents)
+	}
+
+	switch cfg.Reload {
+	case "", ReloadRestart, ReloadChangedComponents:
+	default:
+		return fmt.Errorf("service::reload must be one of %q or %q, got %q", ReloadRestart, ReloadChangedComponents, cfg.Reload)
+	}
+
+	if err := cfg.CrashLoopProtection.validate(cfg.Extensions); err != nil {
+		return fmt.Errorf("service::crash_loop_protection config validation failed: %w", err)
+	}
+
 	if err := cfg.Telemetry.Validate(); err != nil {
 		fmt.Printf("service::telemetry config validation failed: %v\n", err)
 	}
 
 	return nil
 }
+
+func (cfg *Config) validateTelemetryPipeline(p *telemetry.MetricsPipelineConfig) error {
+	if p.ID.Type() != component.DataTypeMetrics {
+		return fmt.Errorf("pipeline %q is not a metrics pipeline", p.ID)
+	}
+	if _, ok := cfg.Pipelines[p.ID]; !ok {
+		return fmt.Errorf("pipeline %q is not configured", p.ID)
+	}
+	if p.Interval < 0 {
+		return errors.New("interval must not be negative")
+	}
+	return nil
+}
+
+func (cfg *CrashLoopProtectionConfig) validate(exts extensions.Config) error {
+	if cfg.MaxFailures < 0 {
+		return errors.New("max_failures must not be negative")
+	}
+	if cfg.Storage == nil {
+		return nil
+	}
+	for _, id := range exts {
+		if id == *cfg.Storage {
+			return nil
+		}
+	}
+	return fmt.Errorf("storage extension %q is not enabled in the service", *cfg.Storage)
+}
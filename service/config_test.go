@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap/zapcore"
@@ -61,6 +62,151 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: fmt.Errorf(`service::pipelines config validation failed: %w`, errors.New(`pipeline "wrongtype": unknown datatype "wrongtype"`)),
 		},
+		{
+			name: "invalid-in-flight-config",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.InFlight.LimitMiB = 100
+				return cfg
+			},
+			expected: fmt.Errorf(`service::in_flight config validation failed: %w`, errors.New(`limit_mib requires the in-flight accounting to be enabled`)),
+		},
+		{
+			name: "negative-start-timeout",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.StartTimeout = -time.Second
+				return cfg
+			},
+			expected: errors.New(`service::start_timeout must not be negative`),
+		},
+		{
+			name: "negative-backpressure-watermark",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.BackpressureWatermark = -1
+				return cfg
+			},
+			expected: errors.New(`service::backpressure_watermark must not be negative`),
+		},
+		{
+			name: "unused-components-warn",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.UnusedComponents = UnusedComponentsWarn
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "invalid-unused-components",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.UnusedComponents = "fail"
+				return cfg
+			},
+			expected: errors.New(`service::unused_components must be one of "ignore", "warn" or "error", got "fail"`),
+		},
+		{
+			name: "reload-changed-components",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Reload = ReloadChangedComponents
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "invalid-reload",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Reload = "hot"
+				return cfg
+			},
+			expected: errors.New(`service::reload must be one of "restart" or "changed_components", got "hot"`),
+		},
+		{
+			name: "crash-loop-protection",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				storage := component.NewID("nop")
+				cfg.CrashLoopProtection.Storage = &storage
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "crash-loop-protection-storage-not-enabled",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				storage := component.NewID("file_storage")
+				cfg.CrashLoopProtection.Storage = &storage
+				return cfg
+			},
+			expected: fmt.Errorf(`service::crash_loop_protection config validation failed: %w`, errors.New(`storage extension "file_storage" is not enabled in the service`)),
+		},
+		{
+			name: "crash-loop-protection-negative-max-failures",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.CrashLoopProtection.MaxFailures = -1
+				return cfg
+			},
+			expected: fmt.Errorf(`service::crash_loop_protection config validation failed: %w`, errors.New(`max_failures must not be negative`)),
+		},
+		{
+			name: "telemetry-pipeline",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Pipelines[component.NewIDWithName("metrics", "internal")] = &pipelines.PipelineConfig{
+					Exporters: []component.ID{component.NewID("nop")},
+				}
+				cfg.Telemetry.Metrics.Pipeline = &telemetry.MetricsPipelineConfig{ID: component.NewIDWithName("metrics", "internal")}
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "telemetry-pipeline-not-configured",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Telemetry.Metrics.Pipeline = &telemetry.MetricsPipelineConfig{ID: component.NewIDWithName("metrics", "internal")}
+				return cfg
+			},
+			expected: fmt.Errorf(`service::telemetry::metrics::pipeline config validation failed: %w`, errors.New(`pipeline "metrics/internal" is not configured`)),
+		},
+		{
+			name: "telemetry-pipeline-not-metrics",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Telemetry.Metrics.Pipeline = &telemetry.MetricsPipelineConfig{ID: component.NewID("traces")}
+				return cfg
+			},
+			expected: fmt.Errorf(`service::telemetry::metrics::pipeline config validation failed: %w`, errors.New(`pipeline "traces" is not a metrics pipeline`)),
+		},
+		{
+			name: "telemetry-pipeline-negative-interval",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Pipelines[component.NewIDWithName("metrics", "internal")] = &pipelines.PipelineConfig{
+					Exporters: []component.ID{component.NewID("nop")},
+				}
+				cfg.Telemetry.Metrics.Pipeline = &telemetry.MetricsPipelineConfig{ID: component.NewIDWithName("metrics", "internal"), Interval: -time.Second}
+				return cfg
+			},
+			expected: fmt.Errorf(`service::telemetry::metrics::pipeline config validation failed: %w`, errors.New(`interval must not be negative`)),
+		},
+		{
+			name: "missing-receivers-not-telemetry-pipeline",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Pipelines[component.NewIDWithName("metrics", "internal")] = &pipelines.PipelineConfig{
+					Exporters: []component.ID{component.NewID("nop")},
+				}
+				return cfg
+			},
+			expected: fmt.Errorf(`service::pipelines config validation failed: %w`, fmt.Errorf(`pipeline "metrics/internal": %w`, errors.New(`must have at least one receiver`))),
+		},
 		{
 			name: "invalid-telemetry-metric-config",
 			cfgFn: func() *Config {
@@ -0,0 +1,268 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package service // import "go.opentelemetry.io/collector/service"
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+const (
+	defaultCrashLoopMaxFailures = 3
+
+	// crashLoopStorageName and crashLoopStorageKey identify the persisted start failure in the storage extension.
+	crashLoopStorageName = "crash_loop_protection"
+	crashLoopStorageKey  = "start_failure"
+)
+
+// startFailure is the persisted state of the last component failing to start.
+type startFailure struct {
+	Kind       string `json:"kind"`
+	ID         string `json:"id"`
+	ConfigHash string `json:"config_hash"`
+	Count      int    `json:"count"`
+	Error      string `json:"error"`
+}
+
+func (f *startFailure) sameComponent(other *startFailure) bool {
+	return f.Kind == other.Kind && f.ID == other.ID && f.ConfigHash == other.ConfigHash
+}
+
+// crashLoopProtection persists the component failing to start, so that the service starts without
+// that component once it failed to start too many times in a row with the same configuration.
+type crashLoopProtection struct {
+	storageID   component.ID
+	maxFailures int
+	conf        *confmap.Conf
+	logger      *zap.Logger
+
+	client storage.Client
+	// last is the failure persisted by the previous runs, if any.
+	last *startFailure
+	// disabled is the component disabled because of the persisted failures, if any.
+	disabled *startFailure
+}
+
+func newCrashLoopProtection(cfg CrashLoopProtectionConfig, conf *confmap.Conf, logger *zap.Logger) *crashLoopProtection {
+	if cfg.Storage == nil {
+		return nil
+	}
+	maxFailures := cfg.MaxFailures
+	if maxFailures == 0 {
+		maxFailures = defaultCrashLoopMaxFailures
+	}
+	return &crashLoopProtection{
+		storageID:   *cfg.Storage,
+		maxFailures: maxFailures,
+		conf:        conf,
+		logger:      logger,
+	}
+}
+
+// load gets the storage client from the started storage extension and reads the persisted failure.
+func (clp *crashLoopProtection) load(ctx context.Context, host component.Host) error {
+	ext, ok := host.GetExtensions()[clp.storageID]
+	if !ok {
+		return fmt.Errorf("storage extension %q not found", clp.storageID)
+	}
+	se, ok := ext.(storage.Extension)
+	if !ok {
+		return fmt.Errorf("extension %q is not a storage extension", clp.storageID)
+	}
+	client, err := se.GetClient(ctx, component.KindExtension, component.NewID("service"), crashLoopStorageName)
+	if err != nil {
+		return fmt.Errorf("failed to get storage client: %w", err)
+	}
+	clp.client = client
+
+	data, err := client.Get(ctx, crashLoopStorageKey)
+	if err != nil {
+		return fmt.Errorf("failed to read the persisted start failure: %w", err)
+	}
+	if data == nil {
+		return nil
+	}
+	last := &startFailure{}
+	if err = json.Unmarshal(data, last); err != nil {
+		clp.logger.Warn("Ignoring the invalid persisted start failure", zap.Error(err))
+		return nil
+	}
+	clp.last = last
+	return nil
+}
+
+// disable returns the component to disable, if the persisted failure reached the maximum number
+// of failures and the configuration of the component did not change since.
+func (clp *crashLoopProtection) disable() (component.Kind, component.ID, bool) {
+	if clp.last == nil || clp.last.Count < clp.maxFailures {
+		return 0, component.ID{}, false
+	}
+	kind, ok := parseKind(clp.last.Kind)
+	if !ok {
+		return 0, component.ID{}, false
+	}
+	id := component.ID{}
+	if err := id.UnmarshalText([]byte(clp.last.ID)); err != nil {
+		return 0, component.ID{}, false
+	}
+	if clp.configHash(kind, id) != clp.last.ConfigHash {
+		return 0, component.ID{}, false
+	}
+	clp.disabled = clp.last
+	return kind, id, true
+}
+
+// recordFailure persists the component failing to start, counting the consecutive identical failures.
+func (clp *crashLoopProtection) recordFailure(ctx context.Context, err error) {
+	var startErr *component.StartError
+	if !errors.As(err, &startErr) {
+		return
+	}
+	failure := &startFailure{
+		Kind:       startErr.Kind.String(),
+		ID:         startErr.ID.String(),
+		ConfigHash: clp.configHash(startErr.Kind, startErr.ID),
+		Count:      1,
+		Error:      startErr.Err.Error(),
+	}
+	if clp.last != nil && clp.last.sameComponent(failure) {
+		failure.Count = clp.last.Count + 1
+	}
+	data, err := json.Marshal(failure)
+	if err == nil {
+		err = clp.client.Set(ctx, crashLoopStorageKey, data)
+	}
+	if err != nil {
+		clp.logger.Error("Failed to persist the start failure", zap.Error(err))
+		return
+	}
+	if failure.Count >= clp.maxFailures {
+		clp.logger.Error("Component failed to start too many times, it will be disabled on the next start",
+			zap.String("kind", failure.Kind), zap.String("id", failure.ID), zap.Int("failures", failure.Count))
+	}
+}
+
+// recordSuccess deletes the persisted failure once the pipelines started. The failure is kept while a component
+// is disabled, so that the component stays disabled until its configuration changes.
+func (clp *crashLoopProtection) recordSuccess(ctx context.Context) {
+	if clp.disabled != nil || clp.last == nil {
+		return
+	}
+	if err := clp.client.Delete(ctx, crashLoopStorageKey); err != nil {
+		clp.logger.Error("Failed to delete the persisted start failure", zap.Error(err))
+	}
+}
+
+func (clp *crashLoopProtection) shutdown(ctx context.Context) error {
+	if clp.client == nil {
+		return nil
+	}
+	return clp.client.Close(ctx)
+}
+
+// configHash is the hash of the configuration of the component, empty if the configuration is not available.
+func (clp *crashLoopProtection) configHash(kind component.Kind, id component.ID) string {
+	if clp.conf == nil {
+		return ""
+	}
+	sub, err := clp.conf.Sub(kind.String() + "s" + confmap.KeyDelimiter + id.String())
+	if err != nil {
+		return ""
+	}
+	m := sub.ToStringMap()
+	data, err := json.Marshal(m)
+	if err != nil {
+		data = []byte(fmt.Sprint(m))
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func parseKind(s string) (component.Kind, bool) {
+	for _, kind := range []component.Kind{component.KindReceiver, component.KindProcessor, component.KindExporter, component.KindConnector} {
+		if kind.String() == s {
+			return kind, true
+		}
+	}
+	return 0, false
+}
+
+// withoutComponent returns the pipelines configuration without the component. The pipelines left without
+// receivers or exporters are removed, as well as the connectors no longer used on both sides.
+func withoutComponent(cfgs pipelines.Config, kind component.Kind, id component.ID, connectors *connector.Builder) pipelines.Config {
+	remove := func(ids []component.ID, match func(component.ID) bool) []component.ID {
+		var out []component.ID
+		for _, cid := range ids {
+			if !match(cid) {
+				out = append(out, cid)
+			}
+		}
+		return out
+	}
+	isComponent := func(cid component.ID) bool { return cid == id }
+	never := func(component.ID) bool { return false }
+
+	out := make(pipelines.Config, len(cfgs))
+	for pipelineID, cfg := range cfgs {
+		receiversMatch, processorsMatch, exportersMatch := never, never, never
+		switch kind {
+		case component.KindReceiver:
+			receiversMatch = isComponent
+		case component.KindProcessor:
+			processorsMatch = isComponent
+		case component.KindExporter:
+			exportersMatch = isComponent
+		case component.KindConnector:
+			receiversMatch, exportersMatch = isComponent, isComponent
+		}
+		out[pipelineID] = &pipelines.PipelineConfig{
+			Receivers:  remove(cfg.Receivers, receiversMatch),
+			Processors: remove(cfg.Processors, processorsMatch),
+			Exporters:  remove(cfg.Exporters, exportersMatch),
+		}
+	}
+
+	isConnector := func(cid component.ID) bool { return connectors != nil && connectors.IsConfigured(cid) }
+	for changed := true; changed; {
+		changed = false
+		for pipelineID, cfg := range out {
+			// The pipelines without receivers from the start, like the telemetry pipeline, are kept.
+			if (len(cfgs[pipelineID].Receivers) > 0 && len(cfg.Receivers) == 0) || len(cfg.Exporters) == 0 {
+				delete(out, pipelineID)
+				changed = true
+			}
+		}
+		asReceiver := map[component.ID]bool{}
+		asExporter := map[component.ID]bool{}
+		for _, cfg := range out {
+			for _, cid := range cfg.Receivers {
+				asReceiver[cid] = true
+			}
+			for _, cid := range cfg.Exporters {
+				asExporter[cid] = true
+			}
+		}
+		for _, cfg := range out {
+			receivers := remove(cfg.Receivers, func(cid component.ID) bool { return isConnector(cid) && !asExporter[cid] })
+			exporters := remove(cfg.Exporters, func(cid component.ID) bool { return isConnector(cid) && !asReceiver[cid] })
+			if len(receivers) != len(cfg.Receivers) || len(exporters) != len(cfg.Exporters) {
+				cfg.Receivers, cfg.Exporters = receivers, exporters
+				changed = true
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,235 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+// memStorage is a storage extension keeping the data in memory, shared by the instances of the test services.
+type memStorage struct {
+	component.StartFunc
+	component.ShutdownFunc
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func (s *memStorage) GetClient(context.Context, component.Kind, component.ID, string) (storage.Client, error) {
+	return &memStorageClient{storage: s}, nil
+}
+
+type memStorageClient struct {
+	storage *memStorage
+}
+
+func (c *memStorageClient) Get(_ context.Context, key string) ([]byte, error) {
+	c.storage.mu.Lock()
+	defer c.storage.mu.Unlock()
+	return c.storage.data[key], nil
+}
+
+func (c *memStorageClient) Set(_ context.Context, key string, value []byte) error {
+	c.storage.mu.Lock()
+	defer c.storage.mu.Unlock()
+	c.storage.data[key] = value
+	return nil
+}
+
+func (c *memStorageClient) Delete(_ context.Context, key string) error {
+	c.storage.mu.Lock()
+	defer c.storage.mu.Unlock()
+	delete(c.storage.data, key)
+	return nil
+}
+
+func (c *memStorageClient) Batch(context.Context, ...storage.Operation) error {
+	return nil
+}
+
+func (c *memStorageClient) Close(context.Context) error {
+	return nil
+}
+
+type panickingReceiver struct {
+	component.ShutdownFunc
+}
+
+func (panickingReceiver) Start(context.Context, component.Host) error {
+	panic("invalid configuration")
+}
+
+func newCrashLoopSettings(s *memStorage, conf *confmap.Conf) Settings {
+	set := newNopSettings()
+	set.CollectorConf = conf
+
+	storageFactory := extension.NewFactory("memstorage",
+		func() component.Config { return &struct{}{} },
+		func(context.Context, extension.CreateSettings, component.Config) (extension.Extension, error) {
+			return s, nil
+		},
+		component.StabilityLevelDevelopment)
+	set.Extensions = extension.NewBuilder(
+		map[component.ID]component.Config{component.NewID("memstorage"): storageFactory.CreateDefaultConfig()},
+		map[component.Type]extension.Factory{"memstorage": storageFactory})
+
+	nopFactory := receivertest.NewNopFactory()
+	panickingFactory := receiver.NewFactory("panicking",
+		func() component.Config { return &struct{}{} },
+		receiver.WithTraces(func(context.Context, receiver.CreateSettings, component.Config, consumer.Traces) (receiver.Traces, error) {
+			return panickingReceiver{}, nil
+		}, component.StabilityLevelDevelopment))
+	set.Receivers = receiver.NewBuilder(
+		map[component.ID]component.Config{
+			component.NewID("nop"):       nopFactory.CreateDefaultConfig(),
+			component.NewID("panicking"): panickingFactory.CreateDefaultConfig(),
+		},
+		map[component.Type]receiver.Factory{"nop": nopFactory, "panicking": panickingFactory})
+	return set
+}
+
+func newCrashLoopConfig() Config {
+	cfg := newNopConfigPipelineConfigs(pipelines.Config{
+		component.NewID("traces"): {
+			Receivers: []component.ID{component.NewID("nop"), component.NewID("panicking")},
+			Exporters: []component.ID{component.NewID("nop")},
+		},
+	})
+	cfg.Telemetry.Metrics.Level = configtelemetry.LevelNone
+	storageID := component.NewID("memstorage")
+	cfg.Extensions = []component.ID{storageID}
+	cfg.CrashLoopProtection = CrashLoopProtectionConfig{Storage: &storageID, MaxFailures: 2}
+	return cfg
+}
+
+func TestServiceCrashLoopProtection(t *testing.T) {
+	s := &memStorage{data: map[string][]byte{}}
+	conf := confmap.NewFromStringMap(map[string]any{
+		"receivers": map[string]any{"panicking": map[string]any{"endpoint": "localhost:4317"}},
+	})
+
+	start := func(conf *confmap.Conf) (*Service, error) {
+		srv, err := New(context.Background(), newCrashLoopSettings(s, conf), newCrashLoopConfig())
+		require.NoError(t, err)
+		err = srv.Start(context.Background())
+		require.NoError(t, srv.Shutdown(context.Background()))
+		return srv, err
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := start(conf)
+		require.Error(t, err)
+		var startErr *component.StartError
+		require.ErrorAs(t, err, &startErr)
+		assert.Equal(t, component.NewID("panicking"), startErr.ID)
+		assert.EqualError(t, startErr.Err, "panic: invalid configuration")
+	}
+
+	// The receiver failed twice with the same configuration, the service starts without it.
+	srv, err := start(conf)
+	require.NoError(t, err)
+	require.NotNil(t, srv.host.disabledComponent)
+	assert.Equal(t, "receiver", srv.host.disabledComponent.Kind)
+	assert.Equal(t, "panicking", srv.host.disabledComponent.ID)
+	assert.Equal(t, 2, srv.host.disabledComponent.Count)
+	assert.Equal(t, "panic: invalid configuration", srv.host.disabledComponent.Error)
+
+	// The receiver stays disabled until its configuration changes.
+	srv, err = start(conf)
+	require.NoError(t, err)
+	assert.NotNil(t, srv.host.disabledComponent)
+
+	changed := confmap.NewFromStringMap(map[string]any{
+		"receivers": map[string]any{"panicking": map[string]any{"endpoint": "localhost:4318"}},
+	})
+	_, err = start(changed)
+	require.Error(t, err)
+	assert.Contains(t, string(s.data[crashLoopStorageKey]), `"count":1`)
+}
+
+func TestServiceCrashLoopProtectionSuccess(t *testing.T) {
+	s := &memStorage{data: map[string][]byte{crashLoopStorageKey: []byte(`{"kind":"receiver","id":"nop","count":1}`)}}
+	set := newCrashLoopSettings(s, confmap.New())
+	cfg := newCrashLoopConfig()
+	cfg.Pipelines[component.NewID("traces")].Receivers = []component.ID{component.NewID("nop")}
+
+	srv, err := New(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(context.Background()))
+	require.NoError(t, srv.Shutdown(context.Background()))
+
+	// The persisted failure is deleted once the pipelines started.
+	assert.Empty(t, s.data)
+}
+
+func TestWithoutComponent(t *testing.T) {
+	conn := component.NewIDWithName("nop", "conn")
+	cfgs := pipelines.Config{
+		component.NewID("traces"): {
+			Receivers:  []component.ID{component.NewID("r1")},
+			Processors: []component.ID{component.NewID("p1")},
+			Exporters:  []component.ID{conn},
+		},
+		component.NewID("metrics"): {
+			Receivers: []component.ID{conn},
+			Exporters: []component.ID{component.NewID("e1")},
+		},
+		component.NewID("logs"): {
+			Receivers:  []component.ID{component.NewID("r1"), component.NewID("r2")},
+			Processors: []component.ID{component.NewID("p1")},
+			Exporters:  []component.ID{component.NewID("e1")},
+		},
+		component.NewIDWithName("metrics", "internal"): {
+			Exporters: []component.ID{component.NewID("e1")},
+		},
+	}
+	connectors := connectortest.NewNopBuilder()
+
+	// The traces pipeline has no receiver left, the connector is then no longer used as exporter.
+	assert.Equal(t, pipelines.Config{
+		component.NewID("logs"): {
+			Receivers:  []component.ID{component.NewID("r2")},
+			Processors: []component.ID{component.NewID("p1")},
+			Exporters:  []component.ID{component.NewID("e1")},
+		},
+		component.NewIDWithName("metrics", "internal"): {
+			Exporters: []component.ID{component.NewID("e1")},
+		},
+	}, withoutComponent(cfgs, component.KindReceiver, component.NewID("r1"), connectors))
+
+	assert.Equal(t, pipelines.Config{
+		component.NewID("traces"): {
+			Receivers: []component.ID{component.NewID("r1")},
+			Exporters: []component.ID{conn},
+		},
+		component.NewID("metrics"): {
+			Receivers: []component.ID{conn},
+			Exporters: []component.ID{component.NewID("e1")},
+		},
+		component.NewID("logs"): {
+			Receivers: []component.ID{component.NewID("r1"), component.NewID("r2")},
+			Exporters: []component.ID{component.NewID("e1")},
+		},
+		component.NewIDWithName("metrics", "internal"): {
+			Exporters: []component.ID{component.NewID("e1")},
+		},
+	}, withoutComponent(cfgs, component.KindProcessor, component.NewID("p1"), connectors))
+
+	assert.Empty(t, withoutComponent(cfgs, component.KindExporter, component.NewID("e1"), connectors))
+}
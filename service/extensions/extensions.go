@@ -22,8 +22,9 @@ const zExtensionName = "zextensionname"
 
 // Extensions is a map of extensions created from extension configs.
 type Extensions struct {
-	telemetry component.TelemetrySettings
-	extMap    map[component.ID]extension.Extension
+	telemetry    component.TelemetrySettings
+	extMap       map[component.ID]extension.Extension
+	reportStatus components.StatusReporter
 }
 
 // Start starts all extensions.
@@ -32,9 +33,13 @@ func (bes *Extensions) Start(ctx context.Context, host component.Host) error {
 	for extID, ext := range bes.extMap {
 		extLogger := components.ExtensionLogger(bes.telemetry.Logger, extID)
 		extLogger.Info("Extension is starting...")
-		if err := ext.Start(ctx, components.NewHostWrapper(host, extLogger)); err != nil {
-			return err
+		instanceID := component.NewInstanceID(extID, component.KindExtension)
+		bes.reportStatus.Report(instanceID, component.StatusStarting, nil)
+		if err := ext.Start(ctx, components.NewHostWrapper(components.HostForInstance(host, instanceID), extLogger)); err != nil {
+			bes.reportStatus.Report(instanceID, component.StatusStartError, err)
+			return &component.StartError{Kind: component.KindExtension, ID: extID, Err: err}
 		}
+		bes.reportStatus.Report(instanceID, component.StatusOK, nil)
 		extLogger.Info("Extension started.")
 	}
 	return nil
@@ -44,8 +49,12 @@ func (bes *Extensions) Start(ctx context.Context, host component.Host) error {
 func (bes *Extensions) Shutdown(ctx context.Context) error {
 	bes.telemetry.Logger.Info("Stopping extensions...")
 	var errs error
-	for _, ext := range bes.extMap {
-		errs = multierr.Append(errs, ext.Shutdown(ctx))
+	for extID, ext := range bes.extMap {
+		instanceID := component.NewInstanceID(extID, component.KindExtension)
+		bes.reportStatus.Report(instanceID, component.StatusStopping, nil)
+		err := ext.Shutdown(ctx)
+		bes.reportStatus.Report(instanceID, component.StatusStopped, err)
+		errs = multierr.Append(errs, err)
 	}
 
 	return errs
@@ -131,6 +140,9 @@ type Settings struct {
 
 	// Extensions builder for extensions.
 	Extensions *extension.Builder
+
+	// ReportStatus reports the status changes of the extensions while started and shut down.
+	ReportStatus components.StatusReporter
 }
 
 // New creates a new Extensions from Config.
@@ -139,12 +151,14 @@ func New(ctx context.Context, set Settings, cfg Config) (*Extensions, error) {
 		set.Extensions = extension.NewBuilder(set.Configs, set.Factories)
 	}
 	exts := &Extensions{
-		telemetry: set.Telemetry,
-		extMap:    make(map[component.ID]extension.Extension),
+		telemetry:    set.Telemetry,
+		extMap:       make(map[component.ID]extension.Extension),
+		reportStatus: set.ReportStatus,
 	}
 	for _, extID := range cfg {
 		extSet := extension.CreateSettings{
 			ID:                extID,
+			InstanceID:        component.NewInstanceID(extID, component.KindExtension),
 			TelemetrySettings: set.Telemetry,
 			BuildInfo:         set.BuildInfo,
 		}
@@ -8,13 +8,17 @@ import (
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/inflight"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/service/extensions"
+	"go.opentelemetry.io/collector/service/internal/components"
 	"go.opentelemetry.io/collector/service/internal/graph"
 )
 
 var _ component.Host = (*serviceHost)(nil)
+var _ inflight.Host = (*serviceHost)(nil)
+var _ components.InstanceHost = (*serviceHost)(nil)
 
 type serviceHost struct {
 	asyncErrorChannel chan error
@@ -28,6 +32,20 @@ type serviceHost struct {
 
 	pipelines         *graph.Graph
 	serviceExtensions *extensions.Extensions
+
+	inflight *inflight.Tracker
+
+	// disabledComponent is the component disabled by the crash loop protection, if any.
+	disabledComponent *startFailure
+
+	// controlToken authenticates the control actions of the zPages, disabled if empty.
+	controlToken string
+
+	// setFeatureGate toggles the feature gates from the zPages, the global registry is used if nil.
+	setFeatureGate func(id string, enabled bool) error
+
+	// reportStatus reports the status changes of the component instances.
+	reportStatus components.StatusReporter
 }
 
 // ReportFatalError is used to report to the host that the receiver encountered
@@ -37,6 +55,12 @@ func (host *serviceHost) ReportFatalError(err error) {
 	host.asyncErrorChannel <- err
 }
 
+// ForInstance returns the host given to the component instance, attributing the fatal errors
+// reported by the instance to it.
+func (host *serviceHost) ForInstance(id component.InstanceID) component.Host {
+	return &instanceHost{serviceHost: host, instanceID: id}
+}
+
 func (host *serviceHost) GetFactory(kind component.Kind, componentType component.Type) component.Factory {
 	switch kind {
 	case component.KindReceiver:
@@ -53,6 +77,11 @@ func (host *serviceHost) GetFactory(kind component.Kind, componentType component
 	return nil
 }
 
+// GetInFlightTracker returns the accounting of the data held by the components, nil if disabled.
+func (host *serviceHost) GetInFlightTracker() *inflight.Tracker {
+	return host.inflight
+}
+
 func (host *serviceHost) GetExtensions() map[component.ID]component.Component {
 	return host.serviceExtensions.GetExtensions()
 }
@@ -66,3 +95,15 @@ func (host *serviceHost) GetExtensions() map[component.ID]component.Component {
 func (host *serviceHost) GetExporters() map[component.DataType]map[component.ID]component.Component {
 	return host.pipelines.GetExporters()
 }
+
+// instanceHost is the host of a component instance.
+type instanceHost struct {
+	*serviceHost
+	instanceID component.InstanceID
+}
+
+// ReportFatalError reports the fatal error of the instance before reporting it to the service.
+func (host *instanceHost) ReportFatalError(err error) {
+	host.reportStatus.Report(host.instanceID, component.StatusFatalError, err)
+	host.serviceHost.ReportFatalError(err)
+}
@@ -23,6 +23,10 @@ func (mts capLogs) Capabilities() consumer.Capabilities {
 	return mts.cap
 }
 
+func (mts capLogs) Backpressure() bool {
+	return consumer.UnderBackpressure(mts.Logs)
+}
+
 func NewMetrics(metrics consumer.Metrics, cap consumer.Capabilities) consumer.Metrics {
 	if metrics.Capabilities() == cap {
 		return metrics
@@ -39,6 +43,10 @@ func (mts capMetrics) Capabilities() consumer.Capabilities {
 	return mts.cap
 }
 
+func (mts capMetrics) Backpressure() bool {
+	return consumer.UnderBackpressure(mts.Metrics)
+}
+
 func NewTraces(traces consumer.Traces, cap consumer.Capabilities) consumer.Traces {
 	if traces.Capabilities() == cap {
 		return traces
@@ -54,3 +62,7 @@ type capTraces struct {
 func (mts capTraces) Capabilities() consumer.Capabilities {
 	return mts.cap
 }
+
+func (mts capTraces) Backpressure() bool {
+	return consumer.UnderBackpressure(mts.Traces)
+}
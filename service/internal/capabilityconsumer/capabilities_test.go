@@ -13,6 +13,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
 func TestLogs(t *testing.T) {
@@ -59,3 +60,15 @@ func TestTraces(t *testing.T) {
 	assert.Len(t, sink.AllTraces(), 1)
 	assert.Equal(t, testdata.GenerateTraces(1), sink.AllTraces()[0])
 }
+
+func TestBackpressure(t *testing.T) {
+	congested := true
+	tc, err := consumer.NewTraces(func(context.Context, ptrace.Traces) error { return nil },
+		consumer.WithBackpressure(func() bool { return congested }))
+	require.NoError(t, err)
+
+	wrap := NewTraces(tc, consumer.Capabilities{MutatesData: true})
+	assert.True(t, consumer.UnderBackpressure(wrap))
+	congested = false
+	assert.False(t, consumer.UnderBackpressure(wrap))
+}
@@ -0,0 +1,32 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package components // import "go.opentelemetry.io/collector/service/internal/components"
+
+import (
+	"go.opentelemetry.io/collector/component"
+)
+
+// InstanceHost is implemented by the hosts giving each component instance its own host, e.g. to
+// attribute the fatal errors reported by the instance.
+type InstanceHost interface {
+	ForInstance(id component.InstanceID) component.Host
+}
+
+// HostForInstance returns the host of the component instance, the given host if it does not implement InstanceHost.
+func HostForInstance(host component.Host, id component.InstanceID) component.Host {
+	if ih, ok := host.(InstanceHost); ok {
+		return ih.ForInstance(id)
+	}
+	return host
+}
+
+// StatusReporter reports the status changes of the component instances. A nil StatusReporter reports nothing.
+type StatusReporter func(component.StatusEvent)
+
+// Report reports the new status of the component instance.
+func (r StatusReporter) Report(id component.InstanceID, status component.Status, err error) {
+	if r != nil {
+		r(component.StatusEvent{InstanceID: id, Status: status, Err: err})
+	}
+}
@@ -0,0 +1,266 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"container/list"
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gonum.org/v1/gonum/graph"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// EdgeTracker tracks the edges of the graphs built with it, across the reloads of the pipelines, so that
+// their state can be reported. The edges removed by a reload are still tracked, with an empty queue.
+// A nil *EdgeTracker is valid and does not track anything.
+type EdgeTracker struct {
+	mu        sync.Mutex
+	edges     map[edgeKey]*Edge
+	observers []func(*Edge)
+}
+
+type edgeKey struct {
+	pipelineID  component.ID
+	kind        component.Kind
+	componentID component.ID
+}
+
+// NewEdgeTracker returns an EdgeTracker without any edge.
+func NewEdgeTracker() *EdgeTracker {
+	return &EdgeTracker{edges: map[edgeKey]*Edge{}}
+}
+
+// Edges returns the edges tracked, sorted by pipeline, kind and ID of the component.
+func (t *EdgeTracker) Edges() []*Edge {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.sortedEdges()
+}
+
+// ObserveEdges calls the function for every edge already tracked, and for every edge tracked later,
+// including the edges replacing the edges of the same pipeline and component after a reload.
+func (t *EdgeTracker) ObserveEdges(observer func(*Edge)) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, e := range t.sortedEdges() {
+		observer(e)
+	}
+	t.observers = append(t.observers, observer)
+}
+
+func (t *EdgeTracker) add(e *Edge) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.edges[edgeKey{pipelineID: e.pipelineID, kind: e.kind, componentID: e.componentID}] = e
+	for _, observer := range t.observers {
+		observer(e)
+	}
+}
+
+func (t *EdgeTracker) sortedEdges() []*Edge {
+	edges := make([]*Edge, 0, len(t.edges))
+	for _, e := range t.edges {
+		edges = append(edges, e)
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].pipelineID != edges[j].pipelineID {
+			return edges[i].pipelineID.String() < edges[j].pipelineID.String()
+		}
+		if edges[i].kind != edges[j].kind {
+			return edges[i].kind < edges[j].kind
+		}
+		return edges[i].componentID.String() < edges[j].componentID.String()
+	})
+	return edges
+}
+
+// Edge passes the data of a pipeline to a processor, exporter or connector, keeping track of the requests
+// the component has not finished consuming yet. The edges whose queue grows are the congested parts of
+// the pipelines.
+type Edge struct {
+	pipelineID  component.ID
+	kind        component.Kind
+	componentID component.ID
+	next        baseConsumer
+	watermark   atomic.Int64
+
+	// downstream are the edges the data goes through after the component, set once the graph is built.
+	downstream []*Edge
+
+	mu sync.Mutex
+	// pending are the times the requests in the queue were passed to the component, oldest first.
+	pending list.List
+}
+
+func newEdge(pipelineID component.ID, kind component.Kind, componentID component.ID, next baseConsumer, watermark int) *Edge {
+	e := &Edge{pipelineID: pipelineID, kind: kind, componentID: componentID, next: next}
+	e.watermark.Store(int64(watermark))
+	return e
+}
+
+// PipelineID returns the ID of the pipeline passing the data.
+func (e *Edge) PipelineID() component.ID {
+	return e.pipelineID
+}
+
+// Kind returns the kind of the component consuming the data.
+func (e *Edge) Kind() component.Kind {
+	return e.kind
+}
+
+// ComponentID returns the ID of the component consuming the data.
+func (e *Edge) ComponentID() component.ID {
+	return e.componentID
+}
+
+// QueueDepth returns the number of requests the component has not finished consuming.
+func (e *Edge) QueueDepth() int64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return int64(e.pending.Len())
+}
+
+// BlockedDuration returns the time spent in the component by the oldest request it has not finished
+// consuming, 0 if the queue is empty.
+func (e *Edge) BlockedDuration() time.Duration {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	oldest := e.pending.Front()
+	if oldest == nil {
+		return 0
+	}
+	return time.Since(oldest.Value.(time.Time))
+}
+
+// Backpressure returns whether the edge is congested: its queue reached the watermark, or the component
+// or any edge downstream of it reports backpressure. It implements consumer.BackpressureReporter.
+func (e *Edge) Backpressure() bool {
+	if watermark := e.watermark.Load(); watermark > 0 && e.QueueDepth() >= watermark {
+		return true
+	}
+	if consumer.UnderBackpressure(e.next) {
+		return true
+	}
+	for _, d := range e.downstream {
+		if d.Backpressure() {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Edge) Capabilities() consumer.Capabilities {
+	return e.next.Capabilities()
+}
+
+func (e *Edge) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	defer e.finish(e.start())
+	return e.next.(consumer.Traces).ConsumeTraces(ctx, td)
+}
+
+func (e *Edge) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	defer e.finish(e.start())
+	return e.next.(consumer.Metrics).ConsumeMetrics(ctx, md)
+}
+
+func (e *Edge) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	defer e.finish(e.start())
+	return e.next.(consumer.Logs).ConsumeLogs(ctx, ld)
+}
+
+func (e *Edge) start() *list.Element {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.pending.PushBack(time.Now())
+}
+
+func (e *Edge) finish(el *list.Element) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.pending.Remove(el)
+}
+
+// edgeNodes are the nodes of an edge of the graph.
+type edgeNodes struct {
+	from, to int64
+}
+
+// newEdge returns the Edge passing the data from the node to the next node when the next node is a processor,
+// exporter or connector, and false for the other nodes.
+func (g *Graph) newEdge(from graph.Node, to graph.Node, next baseConsumer) (*Edge, bool) {
+	var pipelineID component.ID
+	switch n := from.(type) {
+	case *capabilitiesNode:
+		pipelineID = n.pipelineID
+	case *processorNode:
+		pipelineID = n.pipelineID
+	case *fanOutNode:
+		pipelineID = n.pipelineID
+	default:
+		return nil, false
+	}
+	nc, ok := newNodeComponent(to)
+	if !ok {
+		return nil, false
+	}
+	e := newEdge(pipelineID, nc.kind, nc.id, next, g.backpressureWatermark)
+	g.edges[edgeNodes{from: from.ID(), to: to.ID()}] = e
+	return e, true
+}
+
+// keepEdges takes over the edges of the previous graph starting from the node kept by Rebuild, using the
+// watermark of the new graph.
+func (g *Graph) keepEdges(prev *Graph, from int64) {
+	for en, e := range prev.edges {
+		if en.from == from {
+			e.watermark.Store(int64(g.backpressureWatermark))
+			g.edges[en] = e
+		}
+	}
+}
+
+// linkEdges sets the edges downstream of the edges created for the graph, and tracks them. The edges
+// kept from the previous graph are already linked to the edges downstream of them, which are all kept.
+func (g *Graph) linkEdges(tracker *EdgeTracker) {
+	for en, e := range g.edges {
+		if _, ok := g.kept[en.from]; ok {
+			continue
+		}
+		e.downstream = g.edgesFrom(en.to)
+		tracker.add(e)
+	}
+}
+
+// edgesFrom returns the first edges the data goes through after the node.
+func (g *Graph) edgesFrom(id int64) []*Edge {
+	var edges []*Edge
+	nexts := g.componentGraph.From(id)
+	for nexts.Next() {
+		next := nexts.Node().ID()
+		if e, ok := g.edges[edgeNodes{from: id, to: next}]; ok {
+			edges = append(edges, e)
+			continue
+		}
+		edges = append(edges, g.edgesFrom(next)...)
+	}
+	return edges
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+)
+
+func TestEdge(t *testing.T) {
+	release := make(chan struct{})
+	congested := false
+	next, err := consumer.NewTraces(func(context.Context, ptrace.Traces) error {
+		<-release
+		return nil
+	}, consumer.WithBackpressure(func() bool { return congested }))
+	require.NoError(t, err)
+	e := newEdge(reloadTraces, component.KindExporter, reloadExpID, next, 2)
+	assert.Zero(t, e.QueueDepth())
+	assert.Zero(t, e.BlockedDuration())
+	assert.False(t, e.Backpressure())
+
+	done := make(chan struct{})
+	for i := 0; i < 2; i++ {
+		go func() {
+			assert.NoError(t, e.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+			done <- struct{}{}
+		}()
+		assert.Eventually(t, func() bool { return e.QueueDepth() == int64(i+1) }, time.Second, time.Millisecond)
+		// The queue reports backpressure once it reaches the watermark.
+		assert.Equal(t, i == 1, e.Backpressure())
+	}
+	blocked := e.BlockedDuration()
+	assert.Greater(t, blocked, time.Duration(0))
+	assert.Eventually(t, func() bool { return e.BlockedDuration() > blocked }, time.Second, time.Millisecond)
+
+	close(release)
+	<-done
+	<-done
+	assert.Zero(t, e.QueueDepth())
+	assert.Zero(t, e.BlockedDuration())
+	assert.False(t, e.Backpressure())
+
+	// The backpressure reported by the component or by the edges downstream of it is passed on.
+	congested = true
+	assert.True(t, e.Backpressure())
+	congested = false
+	upstream := newEdge(reloadTraces, component.KindProcessor, reloadProcID, consumertest.NewNop(), 0)
+	upstream.downstream = []*Edge{e}
+	assert.False(t, upstream.Backpressure())
+	congested = true
+	assert.True(t, upstream.Backpressure())
+}
+
+func TestGraphEdges(t *testing.T) {
+	tracker := NewEdgeTracker()
+	rcvrCfg := &reloadConfig{}
+	expCfg := testcomponents.ExampleExporterFactory.CreateDefaultConfig()
+	set := reloadSettings(rcvrCfg, expCfg, reloadProcID)
+	set.BackpressureWatermark = 1
+	set.EdgeTracker = tracker
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	require.NoError(t, pg.StartAll(context.Background(), componenttest.NewNopHost()))
+
+	var observed []*Edge
+	tracker.ObserveEdges(func(e *Edge) { observed = append(observed, e) })
+	edges := tracker.Edges()
+	assert.Equal(t, edges, observed)
+	var names []string
+	for _, e := range edges {
+		names = append(names, e.PipelineID().String()+"/"+e.Kind().String()+"/"+e.ComponentID().String())
+	}
+	assert.Equal(t, []string{
+		"metrics/processor/exampleprocessor",
+		"metrics/exporter/exampleexporter",
+		"traces/processor/exampleprocessor",
+		"traces/exporter/exampleexporter",
+	}, names)
+
+	rcvr := receiverComponent(pg.pipelines[reloadTraces]).(*testcomponents.ExampleReceiver)
+	require.NoError(t, rcvr.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.Len(t, exporterComponent(pg.pipelines[reloadTraces]).(*testcomponents.ExampleExporter).Traces, 1)
+
+	// A request waiting for the exporter of the traces pipeline is reported to the receiver of that pipeline only.
+	var tracesNext, metricsNext *switchConsumer
+	for _, n := range pg.pipelines[reloadTraces].receivers {
+		tracesNext = n.(*receiverNode).next
+	}
+	for _, n := range pg.pipelines[reloadMetric].receivers {
+		metricsNext = n.(*receiverNode).next
+	}
+	assert.False(t, consumer.UnderBackpressure(tracesNext))
+	el := edges[3].start()
+	assert.EqualValues(t, 1, edges[3].QueueDepth())
+	assert.True(t, consumer.UnderBackpressure(tracesNext))
+	assert.False(t, consumer.UnderBackpressure(metricsNext))
+	edges[3].finish(el)
+	assert.False(t, consumer.UnderBackpressure(tracesNext))
+
+	// The paused ingestion is reported as backpressure.
+	pg.PauseIngestion()
+	assert.True(t, consumer.UnderBackpressure(tracesNext))
+	pg.ResumeIngestion()
+
+	// The edges of the nodes kept by Rebuild are kept, the other ones are replaced.
+	set = reloadSettings(rcvrCfg, expCfg, reloadNewID)
+	set.EdgeTracker = tracker
+	unchanged := func(component.Kind, component.ID) bool { return true }
+	next, err := Rebuild(context.Background(), set, pg, unchanged)
+	require.NoError(t, err)
+	require.NoError(t, next.StartReplacing(context.Background(), componenttest.NewNopHost(), pg))
+	assert.Len(t, observed, 5)
+	rebuilt := tracker.Edges()
+	require.Len(t, rebuilt, 5)
+	assert.Same(t, edges[0], rebuilt[0])
+	assert.Same(t, edges[1], rebuilt[1])
+	assert.Same(t, edges[2], rebuilt[2])
+	assert.Equal(t, reloadNewID, rebuilt[3].ComponentID())
+	assert.Same(t, edges[3], rebuilt[4])
+	// The watermark of the new graph applies to the edges kept.
+	el = edges[1].start()
+	assert.False(t, edges[1].Backpressure())
+	edges[1].finish(el)
+
+	require.NoError(t, next.ShutdownAll(context.Background()))
+}
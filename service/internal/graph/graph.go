@@ -7,9 +7,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"go.uber.org/multierr"
+	"go.uber.org/zap"
 	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/simple"
 	"gonum.org/v1/gonum/graph/topo"
@@ -19,9 +23,11 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/internal/fanoutconsumer"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/service/internal/capabilityconsumer"
+	"go.opentelemetry.io/collector/service/internal/components"
 	"go.opentelemetry.io/collector/service/pipelines"
 )
 
@@ -37,6 +43,25 @@ type Settings struct {
 
 	// PipelineConfigs is a map of component.ID to PipelineConfig.
 	PipelineConfigs pipelines.Config
+
+	// StartTimeout is the maximum time given to each component to start, no timeout if zero.
+	StartTimeout time.Duration
+
+	// RecoverStartPanics turns the panics of the components while starting into start errors.
+	RecoverStartPanics bool
+
+	// IngestionPaused builds the graph with the ingestion paused, see Graph.PauseIngestion.
+	IngestionPaused bool
+
+	// BackpressureWatermark is the number of requests waiting for a component from which the edge passing
+	// the data to the component reports backpressure, see Edge.Backpressure. No watermark if zero.
+	BackpressureWatermark int
+
+	// EdgeTracker tracks the edges of the graph, see EdgeTracker.Edges. The edges are not tracked if nil.
+	EdgeTracker *EdgeTracker
+
+	// ReportStatus reports the status changes of the component instances while started and shut down.
+	ReportStatus components.StatusReporter
 }
 
 type Graph struct {
@@ -45,13 +70,42 @@ type Graph struct {
 
 	// Keep track of how nodes relate to pipelines, so we can declare edges in the graph.
 	pipelines map[component.ID]*pipelineNodes
+
+	startTimeout time.Duration
+
+	recoverStartPanics bool
+
+	ingestion *ingestionGate
+
+	// kept are the nodes of the previous graph kept by Rebuild, by ID. These nodes are not started again.
+	kept map[int64]graph.Node
+
+	// edges pass the data to the processors, exporters and connectors, by nodes.
+	edges map[edgeNodes]*Edge
+
+	backpressureWatermark int
+
+	reportStatus components.StatusReporter
 }
 
 func Build(ctx context.Context, set Settings) (*Graph, error) {
+	return build(ctx, set, nil, nil)
+}
+
+func build(ctx context.Context, set Settings, prev *Graph, unchanged func(component.Kind, component.ID) bool) (*Graph, error) {
 	pipelines := &Graph{
 		componentGraph: simple.NewDirectedGraph(),
 		pipelines:      make(map[component.ID]*pipelineNodes, len(set.PipelineConfigs)),
+		startTimeout:   set.StartTimeout,
+
+		recoverStartPanics: set.RecoverStartPanics,
+		ingestion:          &ingestionGate{},
+
+		edges:                 make(map[edgeNodes]*Edge),
+		backpressureWatermark: set.BackpressureWatermark,
+		reportStatus:          set.ReportStatus,
 	}
+	pipelines.ingestion.paused.Store(set.IngestionPaused)
 	for pipelineID := range set.PipelineConfigs {
 		pipelines.pipelines[pipelineID] = &pipelineNodes{
 			receivers: make(map[int64]graph.Node),
@@ -61,8 +115,16 @@ func Build(ctx context.Context, set Settings) (*Graph, error) {
 	if err := pipelines.createNodes(set); err != nil {
 		return nil, err
 	}
+	pipelines.logSharedInstances(set.Telemetry.Logger)
 	pipelines.createEdges()
-	return pipelines, pipelines.buildComponents(ctx, set)
+	if prev != nil {
+		pipelines.kept = pipelines.keptNodes(prev, unchanged)
+	}
+	if err := pipelines.buildComponents(ctx, set, prev); err != nil {
+		return pipelines, err
+	}
+	pipelines.linkEdges(set.EdgeTracker)
+	return pipelines, nil
 }
 
 // Creates a node for each instance of a component and adds it to the graph
@@ -82,7 +144,7 @@ func (g *Graph) createNodes(set Settings) error {
 				connectorsAsReceiver[recvID] = append(connectorsAsReceiver[recvID], pipelineID)
 				continue
 			}
-			rcvrNode := g.createReceiver(pipelineID.Type(), recvID)
+			rcvrNode := g.createReceiver(pipelineID, recvID)
 			pipe.receivers[rcvrNode.ID()] = rcvrNode
 		}
 
@@ -100,7 +162,7 @@ func (g *Graph) createNodes(set Settings) error {
 				connectorsAsExporter[exprID] = append(connectorsAsExporter[exprID], pipelineID)
 				continue
 			}
-			expNode := g.createExporter(pipelineID.Type(), exprID)
+			expNode := g.createExporter(pipelineID, exprID)
 			pipe.exporters[expNode.ID()] = expNode
 		}
 	}
@@ -164,12 +226,14 @@ func (g *Graph) createNodes(set Settings) error {
 	return nil
 }
 
-func (g *Graph) createReceiver(pipelineType component.DataType, recvID component.ID) *receiverNode {
-	rcvrNode := newReceiverNode(pipelineType, recvID)
+func (g *Graph) createReceiver(pipelineID, recvID component.ID) *receiverNode {
+	rcvrNode := newReceiverNode(pipelineID.Type(), recvID)
 	if node := g.componentGraph.Node(rcvrNode.ID()); node != nil {
-		return node.(*receiverNode)
+		rcvrNode = node.(*receiverNode)
+	} else {
+		g.componentGraph.AddNode(rcvrNode)
 	}
-	g.componentGraph.AddNode(rcvrNode)
+	rcvrNode.pipelineIDs[pipelineID] = struct{}{}
 	return rcvrNode
 }
 
@@ -179,24 +243,55 @@ func (g *Graph) createProcessor(pipelineID, procID component.ID) *processorNode
 	return procNode
 }
 
-func (g *Graph) createExporter(pipelineType component.DataType, exprID component.ID) *exporterNode {
-	expNode := newExporterNode(pipelineType, exprID)
+func (g *Graph) createExporter(pipelineID, exprID component.ID) *exporterNode {
+	expNode := newExporterNode(pipelineID.Type(), exprID)
 	if node := g.componentGraph.Node(expNode.ID()); node != nil {
-		return node.(*exporterNode)
+		expNode = node.(*exporterNode)
+	} else {
+		g.componentGraph.AddNode(expNode)
 	}
-	g.componentGraph.AddNode(expNode)
+	expNode.pipelineIDs[pipelineID] = struct{}{}
 	return expNode
 }
 
 func (g *Graph) createConnector(exprPipelineID, rcvrPipelineID, connID component.ID) *connectorNode {
 	connNode := newConnectorNode(exprPipelineID.Type(), rcvrPipelineID.Type(), connID)
 	if node := g.componentGraph.Node(connNode.ID()); node != nil {
-		return node.(*connectorNode)
+		connNode = node.(*connectorNode)
+	} else {
+		g.componentGraph.AddNode(connNode)
 	}
-	g.componentGraph.AddNode(connNode)
+	connNode.pipelineIDs[exprPipelineID] = struct{}{}
+	connNode.pipelineIDs[rcvrPipelineID] = struct{}{}
 	return connNode
 }
 
+// logSharedInstances warns about the receivers and exporters used in multiple pipelines of the same
+// data type, as these pipelines share one instance of the component, unlike processors which
+// have one instance per pipeline.
+func (g *Graph) logSharedInstances(logger *zap.Logger) {
+	var instanceIDs []component.InstanceID
+	nodes := g.componentGraph.Nodes()
+	for nodes.Next() {
+		switch n := nodes.Node().(type) {
+		case *receiverNode:
+			instanceIDs = append(instanceIDs, n.instanceID())
+		case *exporterNode:
+			instanceIDs = append(instanceIDs, n.instanceID())
+		}
+	}
+	sort.Slice(instanceIDs, func(i, j int) bool { return instanceIDs[i].String() < instanceIDs[j].String() })
+	for _, instanceID := range instanceIDs {
+		if len(instanceID.PipelineIDs) < 2 {
+			continue
+		}
+		logger.Warn("Component instance shared by multiple pipelines, data is received or exported once for all of them.",
+			zap.String("kind", instanceID.Kind.String()),
+			zap.String("name", instanceID.ID.String()),
+			zap.Stringer("instance", instanceID))
+	}
+}
+
 func (g *Graph) createEdges() {
 	for _, pg := range g.pipelines {
 		for _, receiver := range pg.receivers {
@@ -219,7 +314,7 @@ func (g *Graph) createEdges() {
 	}
 }
 
-func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
+func (g *Graph) buildComponents(ctx context.Context, set Settings, prev *Graph) error {
 	nodes, err := topo.Sort(g.componentGraph)
 	if err != nil {
 		return cycleErr(err, topo.DirectedCyclesIn(g.componentGraph))
@@ -227,9 +322,23 @@ func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
 
 	for i := len(nodes) - 1; i >= 0; i-- {
 		node := nodes[i]
+		prevNode, kept := g.kept[node.ID()]
+		if _, ok := node.(*receiverNode); kept && !ok {
+			keepNode(node, prevNode)
+			g.keepEdges(prev, node.ID())
+			continue
+		}
 		switch n := node.(type) {
 		case *receiverNode:
-			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ReceiverBuilder, g.nextConsumers(n.ID()))
+			nexts := g.nextConsumers(n.ID())
+			for i, next := range nexts {
+				nexts[i] = g.ingestion.wrap(n.pipelineType, next)
+			}
+			if kept {
+				n.keep(prevNode.(*receiverNode), nexts)
+				continue
+			}
+			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ReceiverBuilder, nexts)
 		case *processorNode:
 			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ProcessorBuilder, g.nextConsumers(n.ID())[0])
 		case *exporterNode:
@@ -258,6 +367,8 @@ func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
 			}
 		case *fanOutNode:
 			nexts := g.nextConsumers(n.ID())
+			// The exporters shared by several pipelines record the pipeline the data comes from on their metrics.
+			withPipelineID := obsreportconfig.ExporterPipelineAttributeFeatureGate.IsEnabled()
 			switch n.pipelineID.Type() {
 			case component.DataTypeTraces:
 				consumers := make([]consumer.Traces, 0, len(nexts))
@@ -265,6 +376,9 @@ func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
 					consumers = append(consumers, next.(consumer.Traces))
 				}
 				n.baseConsumer = fanoutconsumer.NewTraces(consumers)
+				if withPipelineID {
+					n.baseConsumer = newPipelineIDTraces(n.pipelineID, n.baseConsumer.(consumer.Traces))
+				}
 			case component.DataTypeMetrics:
 				consumers := make([]consumer.Metrics, 0, len(nexts))
 				for _, next := range nexts {
@@ -272,12 +386,18 @@ func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
 					consumers = append(consumers, next.(consumer.Metrics))
 				}
 				n.baseConsumer = fanoutconsumer.NewMetrics(consumers)
+				if withPipelineID {
+					n.baseConsumer = newPipelineIDMetrics(n.pipelineID, n.baseConsumer.(consumer.Metrics))
+				}
 			case component.DataTypeLogs:
 				consumers := make([]consumer.Logs, 0, len(nexts))
 				for _, next := range nexts {
 					consumers = append(consumers, next.(consumer.Logs))
 				}
 				n.baseConsumer = fanoutconsumer.NewLogs(consumers)
+				if withPipelineID {
+					n.baseConsumer = newPipelineIDLogs(n.pipelineID, n.baseConsumer.(consumer.Logs))
+				}
 			}
 		}
 		if err != nil {
@@ -287,12 +407,17 @@ func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
 	return nil
 }
 
-// Find all nodes
+// Find all nodes, passing the data to the processors, exporters and connectors through an Edge.
 func (g *Graph) nextConsumers(nodeID int64) []baseConsumer {
+	from := g.componentGraph.Node(nodeID)
 	nextNodes := g.componentGraph.From(nodeID)
 	nexts := make([]baseConsumer, 0, nextNodes.Len())
 	for nextNodes.Next() {
-		nexts = append(nexts, nextNodes.Node().(consumerNode).getConsumer())
+		next := nextNodes.Node().(consumerNode).getConsumer()
+		if e, ok := g.newEdge(from, nextNodes.Node(), next); ok {
+			next = e
+		}
+		nexts = append(nexts, next)
 	}
 	return nexts
 }
@@ -322,22 +447,182 @@ func (g *Graph) StartAll(ctx context.Context, host component.Host) error {
 		return err
 	}
 
-	// Start in reverse topological order so that downstream components
-	// are started before upstream components. This ensures that each
-	// component's consumer is ready to consume.
-	for i := len(nodes) - 1; i >= 0; i-- {
-		comp, ok := nodes[i].(component.Component)
-		if !ok {
-			// Skip capabilities/fanout nodes
-			continue
+	// Start each component once the downstream components are started. This ensures
+	// that each component's consumer is ready to consume, while the independent
+	// branches of the graph are started concurrently. No component is started
+	// after a component failed to start.
+	started := make(map[int64]chan struct{}, len(nodes))
+	for _, node := range nodes {
+		started[node.ID()] = make(chan struct{})
+	}
+	// The instances shared by the nodes of a component, e.g. by the nodes of a receiver used
+	// in pipelines of different data types, are started one after the other as before.
+	locks := make(map[nodeComponent]*sync.Mutex)
+	for _, node := range nodes {
+		if nc, ok := newNodeComponent(node); ok && locks[nc] == nil {
+			locks[nc] = &sync.Mutex{}
 		}
-		if compErr := comp.Start(ctx, host); compErr != nil {
-			return compErr
+	}
+	failed := make(chan struct{})
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs error
+	)
+	for _, node := range nodes {
+		wg.Add(1)
+		go func(node graph.Node) {
+			defer wg.Done()
+			for _, next := range graph.NodesOf(g.componentGraph.From(node.ID())) {
+				select {
+				case <-started[next.ID()]:
+				case <-failed:
+					return
+				}
+			}
+			if nc, ok := newNodeComponent(node); ok {
+				locks[nc].Lock()
+				defer locks[nc].Unlock()
+			}
+			if compErr := g.startNode(ctx, host, node); compErr != nil {
+				mu.Lock()
+				if errs == nil {
+					close(failed)
+				}
+				errs = multierr.Append(errs, compErr)
+				mu.Unlock()
+				return
+			}
+			close(started[node.ID()])
+		}(node)
+	}
+	wg.Wait()
+	return errs
+}
+
+// startNode starts the component of the node, failing if it does not start within the start timeout.
+// A component not starting in time keeps starting in the background.
+func (g *Graph) startNode(ctx context.Context, host component.Host, node graph.Node) error {
+	comp, ok := node.(component.Component)
+	if !ok {
+		// Skip capabilities/fanout nodes
+		return nil
+	}
+	if _, kept := g.kept[node.ID()]; kept {
+		// Already started as part of the previous graph.
+		return nil
+	}
+	instanceID, hasInstance := nodeInstanceID(node)
+	if hasInstance {
+		g.reportStatus.Report(instanceID, component.StatusStarting, nil)
+		host = components.HostForInstance(host, instanceID)
+	}
+	err := g.startComponent(ctx, host, comp)
+	if hasInstance {
+		if err != nil {
+			g.reportStatus.Report(instanceID, component.StatusStartError, err)
+		} else {
+			g.reportStatus.Report(instanceID, component.StatusOK, nil)
 		}
 	}
+	if err != nil {
+		return newStartError(node, err)
+	}
 	return nil
 }
 
+// startComponent starts the component, failing if it does not start within the start timeout.
+func (g *Graph) startComponent(ctx context.Context, host component.Host, comp component.Component) error {
+	start := comp.Start
+	if g.recoverStartPanics {
+		start = recoverStart(comp)
+	}
+	if g.startTimeout <= 0 {
+		return start(ctx, host)
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- start(ctx, host)
+	}()
+	timer := time.NewTimer(g.startTimeout)
+	defer timer.Stop()
+	select {
+	case err := <-errCh:
+		return err
+	case <-timer.C:
+		return fmt.Errorf("did not start within %v", g.startTimeout)
+	}
+}
+
+// shutdownNode shuts down the component of the node, reporting the status changes of its instance.
+func (g *Graph) shutdownNode(ctx context.Context, node graph.Node, comp component.Component) error {
+	instanceID, hasInstance := nodeInstanceID(node)
+	if hasInstance {
+		g.reportStatus.Report(instanceID, component.StatusStopping, nil)
+	}
+	err := comp.Shutdown(ctx)
+	if hasInstance {
+		g.reportStatus.Report(instanceID, component.StatusStopped, err)
+	}
+	return err
+}
+
+// nodeInstanceID returns the component instance of the node, false for the capabilities/fanout nodes.
+func nodeInstanceID(node graph.Node) (component.InstanceID, bool) {
+	switch n := node.(type) {
+	case *receiverNode:
+		return n.instanceID(), true
+	case *processorNode:
+		return n.instanceID(), true
+	case *exporterNode:
+		return n.instanceID(), true
+	case *connectorNode:
+		return n.instanceID(), true
+	}
+	return component.InstanceID{}, false
+}
+
+// recoverStart returns the Start function of the component, returning an error instead of panicking.
+func recoverStart(comp component.Component) component.StartFunc {
+	return func(ctx context.Context, host component.Host) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+			}
+		}()
+		return comp.Start(ctx, host)
+	}
+}
+
+// nodeComponent identifies the component of a node.
+type nodeComponent struct {
+	kind component.Kind
+	id   component.ID
+}
+
+func newNodeComponent(node graph.Node) (nodeComponent, bool) {
+	switch n := node.(type) {
+	case *receiverNode:
+		return nodeComponent{kind: component.KindReceiver, id: n.componentID}, true
+	case *processorNode:
+		return nodeComponent{kind: component.KindProcessor, id: n.componentID}, true
+	case *exporterNode:
+		return nodeComponent{kind: component.KindExporter, id: n.componentID}, true
+	case *connectorNode:
+		return nodeComponent{kind: component.KindConnector, id: n.componentID}, true
+	}
+	return nodeComponent{}, false
+}
+
+// newStartError identifies the component of the node that failed to start.
+func newStartError(node graph.Node, err error) error {
+	if nc, ok := newNodeComponent(node); ok {
+		return &component.StartError{Kind: nc.kind, ID: nc.id, Err: err}
+	}
+	return err
+}
+
 func (g *Graph) ShutdownAll(ctx context.Context) error {
 	nodes, err := topo.Sort(g.componentGraph)
 	if err != nil {
@@ -355,11 +640,54 @@ func (g *Graph) ShutdownAll(ctx context.Context) error {
 			// Skip capabilities/fanout nodes
 			continue
 		}
-		errs = multierr.Append(errs, comp.Shutdown(ctx))
+		errs = multierr.Append(errs, g.shutdownNode(ctx, nodes[i], comp))
 	}
 	return errs
 }
 
+// flusher is implemented by the components able to send their pending data on demand,
+// like the batch processor and the exporters created with the exporterhelper.
+type flusher interface {
+	Flush(ctx context.Context) error
+}
+
+// FlushAll flushes the processors and the exporters implementing Flush.
+func (g *Graph) FlushAll(ctx context.Context) error {
+	nodes, err := topo.Sort(g.componentGraph)
+	if err != nil {
+		return err
+	}
+
+	// Flush in topological order so that the data flushed by upstream
+	// components is flushed again by downstream components.
+	var errs error
+	for _, node := range nodes {
+		var comp component.Component
+		switch n := node.(type) {
+		case *processorNode:
+			comp = n.Component
+		case *exporterNode:
+			comp = n.Component
+		}
+		f, ok := comp.(flusher)
+		if !ok {
+			continue
+		}
+		errs = multierr.Append(errs, f.Flush(ctx))
+	}
+	return errs
+}
+
+// MetricsConsumer returns the consumer of the metrics entering the given pipeline, as if they were
+// received by one of its receivers, or nil if the pipeline is not a metrics pipeline of the graph.
+func (g *Graph) MetricsConsumer(pipelineID component.ID) consumer.Metrics {
+	pipe, ok := g.pipelines[pipelineID]
+	if !ok || pipelineID.Type() != component.DataTypeMetrics {
+		return nil
+	}
+	return pipe.capabilitiesNode
+}
+
 // Deprecated: [0.79.0] This function will be removed in the future.
 // Several components in the contrib repository use this function so it cannot be removed
 // before those cases are removed. In most cases, use of this function can be replaced by a
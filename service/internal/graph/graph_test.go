@@ -9,9 +9,13 @@ import (
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 	"gonum.org/v1/gonum/graph/simple"
 
 	"go.opentelemetry.io/collector/component"
@@ -22,7 +26,12 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
 	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processortest"
 	"go.opentelemetry.io/collector/receiver"
@@ -198,6 +207,183 @@ func TestGraphStartStopComponentError(t *testing.T) {
 	assert.EqualError(t, pg.ShutdownAll(context.Background()), "bar")
 }
 
+type blockingNode struct {
+	testNode
+	release chan struct{}
+}
+
+func (n *blockingNode) Start(ctx context.Context, host component.Host) error {
+	<-n.release
+	return n.testNode.Start(ctx, host)
+}
+
+func TestGraphStartConcurrentBranches(t *testing.T) {
+	// e1 blocks its start until e2 starts, which only happens if the branches start concurrently.
+	release := make(chan struct{})
+	e1 := &blockingNode{testNode: testNode{id: component.NewIDWithName("e", "1")}, release: release}
+	e2 := &startFuncNode{testNode: testNode{id: component.NewIDWithName("e", "2")}, start: func() { close(release) }}
+
+	pg := &Graph{componentGraph: simple.NewDirectedGraph()}
+	pg.componentGraph.SetEdge(simple.Edge{F: &testNode{id: component.NewIDWithName("r", "1")}, T: e1})
+	pg.componentGraph.SetEdge(simple.Edge{F: &testNode{id: component.NewIDWithName("r", "2")}, T: e2})
+
+	ctx := &contextWithOrder{Context: context.Background(), order: map[component.ID]int{}}
+	require.NoError(t, pg.StartAll(ctx, componenttest.NewNopHost()))
+	assert.Greater(t, ctx.order[component.NewIDWithName("r", "1")], ctx.order[component.NewIDWithName("e", "1")])
+	assert.Greater(t, ctx.order[component.NewIDWithName("r", "2")], ctx.order[component.NewIDWithName("e", "2")])
+}
+
+type startFuncNode struct {
+	testNode
+	start func()
+}
+
+func (n *startFuncNode) Start(ctx context.Context, host component.Host) error {
+	n.start()
+	return n.testNode.Start(ctx, host)
+}
+
+func TestGraphStartTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	r1 := &testNode{id: component.NewIDWithName("r", "1")}
+	e1 := newExporterNode(component.DataTypeTraces, component.NewIDWithName("e", "1"))
+	e1.Component = &blockingNode{release: release}
+	pg := &Graph{componentGraph: simple.NewDirectedGraph(), startTimeout: 50 * time.Millisecond}
+	pg.componentGraph.SetEdge(simple.Edge{F: r1, T: e1})
+
+	ctx := &contextWithOrder{Context: context.Background(), order: map[component.ID]int{}}
+	err := pg.StartAll(ctx, componenttest.NewNopHost())
+	var startErr *component.StartError
+	require.ErrorAs(t, err, &startErr)
+	assert.Equal(t, component.KindExporter, startErr.Kind)
+	assert.Equal(t, component.NewIDWithName("e", "1"), startErr.ID)
+	assert.Contains(t, err.Error(), "did not start within 50ms")
+
+	// The upstream components are not started.
+	_, ok := ctx.order[r1.id]
+	assert.False(t, ok)
+}
+
+func TestGraphStartRecoverPanics(t *testing.T) {
+	for _, timeout := range []time.Duration{0, time.Minute} {
+		r1 := &testNode{id: component.NewIDWithName("r", "1")}
+		e1 := newExporterNode(component.DataTypeTraces, component.NewIDWithName("e", "1"))
+		e1.Component = &startFuncNode{start: func() { panic("invalid config") }}
+		pg := &Graph{componentGraph: simple.NewDirectedGraph(), startTimeout: timeout, recoverStartPanics: true}
+		pg.componentGraph.SetEdge(simple.Edge{F: r1, T: e1})
+
+		err := pg.StartAll(context.Background(), componenttest.NewNopHost())
+		var startErr *component.StartError
+		require.ErrorAs(t, err, &startErr)
+		assert.Equal(t, component.KindExporter, startErr.Kind)
+		assert.Equal(t, component.NewIDWithName("e", "1"), startErr.ID)
+		assert.EqualError(t, startErr.Err, "panic: invalid config")
+	}
+}
+
+// instanceHost gives each component instance its own host.
+type instanceHost struct {
+	component.Host
+	instanceID component.InstanceID
+}
+
+func (h *instanceHost) ForInstance(id component.InstanceID) component.Host {
+	return &instanceHost{Host: h.Host, instanceID: id}
+}
+
+// hostRecorderNode records the host it is started with.
+type hostRecorderNode struct {
+	testNode
+	host component.Host
+}
+
+func (n *hostRecorderNode) Start(ctx context.Context, host component.Host) error {
+	n.host = host
+	return n.testNode.Start(ctx, host)
+}
+
+func TestGraphReportStatus(t *testing.T) {
+	var events []component.StatusEvent
+	var mu sync.Mutex
+	report := func(ev component.StatusEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, ev)
+	}
+
+	e1 := newExporterNode(component.DataTypeTraces, component.NewIDWithName("e", "1"))
+	e1.pipelineIDs = map[component.ID]struct{}{component.NewID("traces"): {}}
+	recorder := &hostRecorderNode{testNode: testNode{id: component.NewIDWithName("e", "1")}}
+	e1.Component = recorder
+	pg := &Graph{componentGraph: simple.NewDirectedGraph(), reportStatus: report}
+	pg.componentGraph.AddNode(e1)
+
+	require.NoError(t, pg.StartAll(context.Background(), &instanceHost{Host: componenttest.NewNopHost()}))
+	require.NoError(t, pg.ShutdownAll(context.Background()))
+	require.IsType(t, &instanceHost{}, recorder.host)
+	assert.Equal(t, e1.instanceID(), recorder.host.(*instanceHost).instanceID)
+	assert.Equal(t, []component.StatusEvent{
+		{InstanceID: e1.instanceID(), Status: component.StatusStarting},
+		{InstanceID: e1.instanceID(), Status: component.StatusOK},
+		{InstanceID: e1.instanceID(), Status: component.StatusStopping},
+		{InstanceID: e1.instanceID(), Status: component.StatusStopped},
+	}, events)
+
+	events = nil
+	e2 := newExporterNode(component.DataTypeTraces, component.NewIDWithName("e", "2"))
+	e2.Component = &errComponent{}
+	pg = &Graph{componentGraph: simple.NewDirectedGraph(), reportStatus: report}
+	pg.componentGraph.AddNode(e2)
+
+	require.Error(t, pg.StartAll(context.Background(), componenttest.NewNopHost()))
+	require.Error(t, pg.ShutdownAll(context.Background()))
+	assert.Equal(t, []component.StatusEvent{
+		{InstanceID: e2.instanceID(), Status: component.StatusStarting},
+		{InstanceID: e2.instanceID(), Status: component.StatusStartError, Err: errors.New("my error")},
+		{InstanceID: e2.instanceID(), Status: component.StatusStopping},
+		{InstanceID: e2.instanceID(), Status: component.StatusStopped, Err: errors.New("my error")},
+	}, events)
+}
+
+type flushComponent struct {
+	component.StartFunc
+	component.ShutdownFunc
+	id       component.ID
+	flushErr error
+}
+
+func (c *flushComponent) Flush(ctx context.Context) error {
+	if cwo, ok := ctx.(*contextWithOrder); ok {
+		cwo.record(c.id)
+	}
+	return c.flushErr
+}
+
+func TestGraphFlushAll(t *testing.T) {
+	pipelineID := component.NewID("traces")
+	r1 := newReceiverNode(component.DataTypeTraces, component.NewIDWithName("r", "1"))
+	p1 := newProcessorNode(pipelineID, component.NewIDWithName("p", "1"))
+	p1.Component = &flushComponent{id: p1.componentID}
+	p2 := newProcessorNode(pipelineID, component.NewIDWithName("p", "2"))
+	p2.Component = &testNode{id: p2.componentID}
+	e1 := newExporterNode(component.DataTypeTraces, component.NewIDWithName("e", "1"))
+	e1.Component = &flushComponent{id: e1.componentID, flushErr: errors.New("foo")}
+
+	pg := &Graph{componentGraph: simple.NewDirectedGraph()}
+	pg.componentGraph.SetEdge(simple.Edge{F: r1, T: p1})
+	pg.componentGraph.SetEdge(simple.Edge{F: p1, T: p2})
+	pg.componentGraph.SetEdge(simple.Edge{F: p2, T: e1})
+
+	ctx := &contextWithOrder{
+		Context: context.Background(),
+		order:   map[component.ID]int{},
+	}
+	assert.EqualError(t, pg.FlushAll(ctx), "foo")
+	// The processors are flushed before the exporters, the components not implementing Flush are skipped.
+	assert.Equal(t, map[component.ID]int{p1.componentID: 0, e1.componentID: 1}, ctx.order)
+}
+
 func TestConnectorPipelinesGraph(t *testing.T) {
 	tests := []struct {
 		name                string
@@ -2027,7 +2213,11 @@ func TestGraphFailToStartAndShutdown(t *testing.T) {
 			}
 			pipelines, err := Build(context.Background(), set)
 			assert.NoError(t, err)
-			assert.Error(t, pipelines.StartAll(context.Background(), componenttest.NewNopHost()))
+			err = pipelines.StartAll(context.Background(), componenttest.NewNopHost())
+			var startErr *component.StartError
+			require.ErrorAs(t, err, &startErr)
+			assert.Equal(t, component.KindReceiver, startErr.Kind)
+			assert.Equal(t, component.NewID("err"), startErr.ID)
 			assert.Error(t, pipelines.ShutdownAll(context.Background()))
 		})
 
@@ -2273,3 +2463,151 @@ func (e errComponent) Start(context.Context, component.Host) error {
 func (e errComponent) Shutdown(context.Context) error {
 	return errors.New("my error")
 }
+
+func TestGraphInstanceIDs(t *testing.T) {
+	nopID := component.NewID("nop")
+	traces1 := component.NewIDWithName("traces", "1")
+	traces2 := component.NewIDWithName("traces", "2")
+	metrics := component.NewID("metrics")
+
+	core, logs := observer.New(zapcore.WarnLevel)
+	set := Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: receiver.NewBuilder(
+			map[component.ID]component.Config{nopID: receivertest.NewNopFactory().CreateDefaultConfig()},
+			map[component.Type]receiver.Factory{receivertest.NewNopFactory().Type(): receivertest.NewNopFactory()}),
+		ProcessorBuilder: processor.NewBuilder(
+			map[component.ID]component.Config{nopID: processortest.NewNopFactory().CreateDefaultConfig()},
+			map[component.Type]processor.Factory{processortest.NewNopFactory().Type(): processortest.NewNopFactory()}),
+		ExporterBuilder: exporter.NewBuilder(
+			map[component.ID]component.Config{nopID: exportertest.NewNopFactory().CreateDefaultConfig()},
+			map[component.Type]exporter.Factory{exportertest.NewNopFactory().Type(): exportertest.NewNopFactory()}),
+		ConnectorBuilder: connector.NewBuilder(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+		PipelineConfigs: pipelines.Config{
+			traces1: {Receivers: []component.ID{nopID}, Processors: []component.ID{nopID}, Exporters: []component.ID{nopID}},
+			traces2: {Receivers: []component.ID{nopID}, Processors: []component.ID{nopID}, Exporters: []component.ID{nopID}},
+			metrics: {Receivers: []component.ID{nopID}, Processors: []component.ID{nopID}, Exporters: []component.ID{nopID}},
+		},
+	}
+	set.Telemetry.Logger = zap.New(core)
+
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+
+	instances := map[string]struct{}{}
+	nodes := pg.componentGraph.Nodes()
+	for nodes.Next() {
+		switch n := nodes.Node().(type) {
+		case *receiverNode:
+			instances[n.instanceID().String()] = struct{}{}
+		case *processorNode:
+			instances[n.instanceID().String()] = struct{}{}
+		case *exporterNode:
+			instances[n.instanceID().String()] = struct{}{}
+		}
+	}
+	assert.Equal(t, map[string]struct{}{
+		"receiver/nop[traces/1,traces/2]": {},
+		"receiver/nop[metrics]":           {},
+		"processor/nop[traces/1]":         {},
+		"processor/nop[traces/2]":         {},
+		"processor/nop[metrics]":          {},
+		"exporter/nop[traces/1,traces/2]": {},
+		"exporter/nop[metrics]":           {},
+	}, instances)
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 2)
+	assert.Equal(t, zapcore.WarnLevel, entries[0].Level)
+	assert.Equal(t, "exporter/nop[traces/1,traces/2]", entries[0].ContextMap()["instance"])
+	assert.Equal(t, "receiver/nop[traces/1,traces/2]", entries[1].ContextMap()["instance"])
+}
+
+func TestPipelineIDConsumers(t *testing.T) {
+	pipelineID := component.NewIDWithName("traces", "sampled")
+	var got []component.ID
+	record := func(ctx context.Context) error {
+		id, ok := obsreportconfig.PipelineIDFromContext(ctx)
+		require.True(t, ok)
+		got = append(got, id)
+		return nil
+	}
+	tc, err := consumer.NewTraces(func(ctx context.Context, _ ptrace.Traces) error { return record(ctx) },
+		consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+	require.NoError(t, err)
+	mc, err := consumer.NewMetrics(func(ctx context.Context, _ pmetric.Metrics) error { return record(ctx) })
+	require.NoError(t, err)
+	lc, err := consumer.NewLogs(func(ctx context.Context, _ plog.Logs) error { return record(ctx) })
+	require.NoError(t, err)
+
+	traces := newPipelineIDTraces(pipelineID, tc)
+	assert.True(t, traces.Capabilities().MutatesData)
+	require.NoError(t, traces.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	require.NoError(t, newPipelineIDMetrics(pipelineID, mc).ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
+	require.NoError(t, newPipelineIDLogs(pipelineID, lc).ConsumeLogs(context.Background(), plog.NewLogs()))
+	assert.Equal(t, []component.ID{pipelineID, pipelineID, pipelineID}, got)
+}
+
+type recordingTracesExporter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumer.Traces
+}
+
+func TestFanOutNodePipelineID(t *testing.T) {
+	require.NoError(t, featuregate.GlobalRegistry().Set(obsreportconfig.ExporterPipelineAttributeFeatureGate.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(obsreportconfig.ExporterPipelineAttributeFeatureGate.ID(), false))
+	})
+
+	var mu sync.Mutex
+	var got []component.ID
+	recordingFactory := exporter.NewFactory("recording",
+		func() component.Config { return &struct{}{} },
+		exporter.WithTraces(func(context.Context, exporter.CreateSettings, component.Config) (exporter.Traces, error) {
+			tc, err := consumer.NewTraces(func(ctx context.Context, _ ptrace.Traces) error {
+				mu.Lock()
+				defer mu.Unlock()
+				id, _ := obsreportconfig.PipelineIDFromContext(ctx)
+				got = append(got, id)
+				return nil
+			})
+			return recordingTracesExporter{Traces: tc}, err
+		}, component.StabilityLevelDevelopment))
+
+	// The exporter is shared by both pipelines.
+	set := Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: receiver.NewBuilder(
+			map[component.ID]component.Config{
+				component.NewID("examplereceiver"): testcomponents.ExampleReceiverFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]receiver.Factory{
+				testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+			}),
+		ProcessorBuilder: processor.NewBuilder(map[component.ID]component.Config{}, map[component.Type]processor.Factory{}),
+		ExporterBuilder: exporter.NewBuilder(
+			map[component.ID]component.Config{component.NewID("recording"): recordingFactory.CreateDefaultConfig()},
+			map[component.Type]exporter.Factory{"recording": recordingFactory}),
+		ConnectorBuilder: connector.NewBuilder(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+		PipelineConfigs: pipelines.Config{
+			component.NewID("traces"): {
+				Receivers: []component.ID{component.NewID("examplereceiver")},
+				Exporters: []component.ID{component.NewID("recording")},
+			},
+			component.NewIDWithName("traces", "sampled"): {
+				Receivers: []component.ID{component.NewID("examplereceiver")},
+				Exporters: []component.ID{component.NewID("recording")},
+			},
+		},
+	}
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+
+	for _, pipelineID := range []component.ID{component.NewID("traces"), component.NewIDWithName("traces", "sampled")} {
+		require.NoError(t, pg.pipelines[pipelineID].capabilitiesNode.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	}
+	assert.Equal(t, []component.ID{component.NewID("traces"), component.NewIDWithName("traces", "sampled")}, got)
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// errIngestionPaused is returned to the receivers while the ingestion is paused. It is not permanent,
+// so that the receivers report a retryable error to the clients.
+var errIngestionPaused = errors.New("ingestion is paused")
+
+// ingestionGate rejects the data passed by the receivers to the pipelines while paused.
+// The data emitted by the connectors is not rejected, so that the data already in the pipelines is drained.
+type ingestionGate struct {
+	paused atomic.Bool
+}
+
+// wrap returns a consumer passing the data to next unless the ingestion is paused.
+func (ig *ingestionGate) wrap(pipelineType component.DataType, next baseConsumer) baseConsumer {
+	switch pipelineType {
+	case component.DataTypeTraces:
+		tc, _ := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+			if ig.paused.Load() {
+				return errIngestionPaused
+			}
+			return next.(consumer.Traces).ConsumeTraces(ctx, td)
+		}, consumer.WithCapabilities(next.Capabilities()), consumer.WithBackpressure(ig.backpressure(next)))
+		return tc
+	case component.DataTypeMetrics:
+		mc, _ := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+			if ig.paused.Load() {
+				return errIngestionPaused
+			}
+			return next.(consumer.Metrics).ConsumeMetrics(ctx, md)
+		}, consumer.WithCapabilities(next.Capabilities()), consumer.WithBackpressure(ig.backpressure(next)))
+		return mc
+	case component.DataTypeLogs:
+		lc, _ := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+			if ig.paused.Load() {
+				return errIngestionPaused
+			}
+			return next.(consumer.Logs).ConsumeLogs(ctx, ld)
+		}, consumer.WithCapabilities(next.Capabilities()), consumer.WithBackpressure(ig.backpressure(next)))
+		return lc
+	}
+	return next
+}
+
+// backpressure returns the function reporting backpressure while the ingestion is paused, or while next reports it.
+func (ig *ingestionGate) backpressure(next baseConsumer) func() bool {
+	return func() bool {
+		return ig.paused.Load() || consumer.UnderBackpressure(next)
+	}
+}
+
+// PauseIngestion rejects the data received by the receivers until ResumeIngestion is called.
+func (g *Graph) PauseIngestion() {
+	g.ingestion.paused.Store(true)
+}
+
+// ResumeIngestion accepts again the data received by the receivers.
+func (g *Graph) ResumeIngestion() {
+	g.ingestion.paused.Store(false)
+}
+
+// IngestionPaused returns whether the data received by the receivers is rejected.
+func (g *Graph) IngestionPaused() bool {
+	return g.ingestion.paused.Load()
+}
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+func TestGraphPauseIngestion(t *testing.T) {
+	inID := component.NewIDWithName("traces", "in")
+	outID := component.NewIDWithName("traces", "out")
+	set := Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: receiver.NewBuilder(
+			map[component.ID]component.Config{
+				component.NewID("examplereceiver"): testcomponents.ExampleReceiverFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]receiver.Factory{
+				testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+			}),
+		ProcessorBuilder: processor.NewBuilder(map[component.ID]component.Config{}, map[component.Type]processor.Factory{}),
+		ExporterBuilder: exporter.NewBuilder(
+			map[component.ID]component.Config{
+				component.NewID("exampleexporter"): testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]exporter.Factory{
+				testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory,
+			}),
+		ConnectorBuilder: connector.NewBuilder(
+			map[component.ID]component.Config{
+				component.NewID("exampleconnector"): testcomponents.ExampleConnectorFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]connector.Factory{
+				testcomponents.ExampleConnectorFactory.Type(): testcomponents.ExampleConnectorFactory,
+			}),
+		PipelineConfigs: pipelines.Config{
+			inID: {
+				Receivers: []component.ID{component.NewID("examplereceiver")},
+				Exporters: []component.ID{component.NewID("exampleconnector")},
+			},
+			outID: {
+				Receivers: []component.ID{component.NewID("exampleconnector")},
+				Exporters: []component.ID{component.NewID("exampleexporter")},
+			},
+		},
+		IngestionPaused: true,
+	}
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+
+	var rcvr *testcomponents.ExampleReceiver
+	for _, n := range pg.pipelines[inID].receivers {
+		rcvr = n.(*receiverNode).Component.(*testcomponents.ExampleReceiver)
+	}
+	var conn consumer.Traces
+	for _, n := range pg.pipelines[inID].exporters {
+		conn = n.(*connectorNode).Component.(consumer.Traces)
+	}
+	var exp *testcomponents.ExampleExporter
+	for _, n := range pg.pipelines[outID].exporters {
+		exp = n.(*exporterNode).Component.(*testcomponents.ExampleExporter)
+	}
+
+	// The graph is built with the ingestion paused.
+	assert.True(t, pg.IngestionPaused())
+	err = rcvr.ConsumeTraces(context.Background(), ptrace.NewTraces())
+	assert.ErrorIs(t, err, errIngestionPaused)
+	assert.False(t, consumererror.IsPermanent(err))
+	assert.Empty(t, exp.Traces)
+
+	// The data emitted by the connectors is not rejected.
+	require.NoError(t, conn.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.Len(t, exp.Traces, 1)
+
+	pg.ResumeIngestion()
+	assert.False(t, pg.IngestionPaused())
+	require.NoError(t, rcvr.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.Len(t, exp.Traces, 2)
+
+	pg.PauseIngestion()
+	assert.ErrorIs(t, rcvr.ConsumeTraces(context.Background(), ptrace.NewTraces()), errIngestionPaused)
+	assert.Len(t, exp.Traces, 2)
+}
@@ -14,6 +14,10 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/internal/fanoutconsumer"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/service/internal/capabilityconsumer"
@@ -56,7 +60,14 @@ type receiverNode struct {
 	nodeID
 	componentID  component.ID
 	pipelineType component.DataType
+	pipelineIDs  map[component.ID]struct{}
 	component.Component
+
+	// next passes the data received to the pipelines. It is switched to the pipelines
+	// of the new graph when the receiver is kept by Rebuild.
+	next *switchConsumer
+	// pending is the consumer of the pipelines of the new graph, until the new graph is started.
+	pending baseConsumer
 }
 
 func newReceiverNode(pipelineType component.DataType, recvID component.ID) *receiverNode {
@@ -64,42 +75,67 @@ func newReceiverNode(pipelineType component.DataType, recvID component.ID) *rece
 		nodeID:       newNodeID(receiverSeed, string(pipelineType), recvID.String()),
 		componentID:  recvID,
 		pipelineType: pipelineType,
+		pipelineIDs:  make(map[component.ID]struct{}),
 	}
 }
 
+func (n *receiverNode) instanceID() component.InstanceID {
+	return component.InstanceID{ID: n.componentID, Kind: component.KindReceiver, PipelineIDs: n.pipelineIDs}
+}
+
 func (n *receiverNode) buildComponent(ctx context.Context,
 	tel component.TelemetrySettings,
 	info component.BuildInfo,
 	builder *receiver.Builder,
 	nexts []baseConsumer,
 ) error {
-	set := receiver.CreateSettings{ID: n.componentID, TelemetrySettings: tel, BuildInfo: info}
+	set := receiver.CreateSettings{ID: n.componentID, InstanceID: n.instanceID(), TelemetrySettings: tel, BuildInfo: info}
 	set.TelemetrySettings.Logger = components.ReceiverLogger(tel.Logger, n.componentID, n.pipelineType)
+	n.next = newSwitchConsumer(newReceiverFanOut(n.pipelineType, nexts))
 	var err error
 	switch n.pipelineType {
+	case component.DataTypeTraces:
+		n.Component, err = builder.CreateTraces(ctx, set, n.next)
+	case component.DataTypeMetrics:
+		n.Component, err = builder.CreateMetrics(ctx, set, n.next)
+	case component.DataTypeLogs:
+		n.Component, err = builder.CreateLogs(ctx, set, n.next)
+	default:
+		return fmt.Errorf("error creating receiver %q for data type %q is not supported", set.ID, n.pipelineType)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create %q receiver for data type %q: %w", set.ID, n.pipelineType, err)
+	}
+	return nil
+}
+
+// keep takes over the receiver of the node of the previous graph, passing the data to nexts once the new graph is started.
+func (n *receiverNode) keep(prev *receiverNode, nexts []baseConsumer) {
+	n.Component, n.next = prev.Component, prev.next
+	n.pending = newReceiverFanOut(n.pipelineType, nexts)
+}
+
+// newReceiverFanOut returns the consumer passing the data received by a receiver to all the given consumers.
+func newReceiverFanOut(pipelineType component.DataType, nexts []baseConsumer) baseConsumer {
+	switch pipelineType {
 	case component.DataTypeTraces:
 		var consumers []consumer.Traces
 		for _, next := range nexts {
 			consumers = append(consumers, next.(consumer.Traces))
 		}
-		n.Component, err = builder.CreateTraces(ctx, set, fanoutconsumer.NewTraces(consumers))
+		return fanoutconsumer.NewTraces(consumers)
 	case component.DataTypeMetrics:
 		var consumers []consumer.Metrics
 		for _, next := range nexts {
 			consumers = append(consumers, next.(consumer.Metrics))
 		}
-		n.Component, err = builder.CreateMetrics(ctx, set, fanoutconsumer.NewMetrics(consumers))
+		return fanoutconsumer.NewMetrics(consumers)
 	case component.DataTypeLogs:
 		var consumers []consumer.Logs
 		for _, next := range nexts {
 			consumers = append(consumers, next.(consumer.Logs))
 		}
-		n.Component, err = builder.CreateLogs(ctx, set, fanoutconsumer.NewLogs(consumers))
-	default:
-		return fmt.Errorf("error creating receiver %q for data type %q is not supported", set.ID, n.pipelineType)
-	}
-	if err != nil {
-		return fmt.Errorf("failed to create %q receiver for data type %q: %w", set.ID, n.pipelineType, err)
+		return fanoutconsumer.NewLogs(consumers)
 	}
 	return nil
 }
@@ -123,6 +159,10 @@ func newProcessorNode(pipelineID, procID component.ID) *processorNode {
 	}
 }
 
+func (n *processorNode) instanceID() component.InstanceID {
+	return component.NewInstanceID(n.componentID, component.KindProcessor, n.pipelineID)
+}
+
 func (n *processorNode) getConsumer() baseConsumer {
 	return n.Component.(baseConsumer)
 }
@@ -133,7 +173,7 @@ func (n *processorNode) buildComponent(ctx context.Context,
 	builder *processor.Builder,
 	next baseConsumer,
 ) error {
-	set := processor.CreateSettings{ID: n.componentID, TelemetrySettings: tel, BuildInfo: info}
+	set := processor.CreateSettings{ID: n.componentID, InstanceID: n.instanceID(), TelemetrySettings: tel, BuildInfo: info}
 	set.TelemetrySettings.Logger = components.ProcessorLogger(set.TelemetrySettings.Logger, n.componentID, n.pipelineID)
 	var err error
 	switch n.pipelineID.Type() {
@@ -160,6 +200,7 @@ type exporterNode struct {
 	nodeID
 	componentID  component.ID
 	pipelineType component.DataType
+	pipelineIDs  map[component.ID]struct{}
 	component.Component
 }
 
@@ -168,9 +209,14 @@ func newExporterNode(pipelineType component.DataType, exprID component.ID) *expo
 		nodeID:       newNodeID(exporterSeed, string(pipelineType), exprID.String()),
 		componentID:  exprID,
 		pipelineType: pipelineType,
+		pipelineIDs:  make(map[component.ID]struct{}),
 	}
 }
 
+func (n *exporterNode) instanceID() component.InstanceID {
+	return component.InstanceID{ID: n.componentID, Kind: component.KindExporter, PipelineIDs: n.pipelineIDs}
+}
+
 func (n *exporterNode) getConsumer() baseConsumer {
 	return n.Component.(baseConsumer)
 }
@@ -181,7 +227,7 @@ func (n *exporterNode) buildComponent(
 	info component.BuildInfo,
 	builder *exporter.Builder,
 ) error {
-	set := exporter.CreateSettings{ID: n.componentID, TelemetrySettings: tel, BuildInfo: info}
+	set := exporter.CreateSettings{ID: n.componentID, InstanceID: n.instanceID(), TelemetrySettings: tel, BuildInfo: info}
 	set.TelemetrySettings.Logger = components.ExporterLogger(set.TelemetrySettings.Logger, n.componentID, n.pipelineType)
 	var err error
 	switch n.pipelineType {
@@ -209,6 +255,7 @@ type connectorNode struct {
 	componentID      component.ID
 	exprPipelineType component.DataType
 	rcvrPipelineType component.DataType
+	pipelineIDs      map[component.ID]struct{}
 	component.Component
 	baseConsumer
 }
@@ -219,9 +266,14 @@ func newConnectorNode(exprPipelineType, rcvrPipelineType component.DataType, con
 		componentID:      connID,
 		exprPipelineType: exprPipelineType,
 		rcvrPipelineType: rcvrPipelineType,
+		pipelineIDs:      make(map[component.ID]struct{}),
 	}
 }
 
+func (n *connectorNode) instanceID() component.InstanceID {
+	return component.InstanceID{ID: n.componentID, Kind: component.KindConnector, PipelineIDs: n.pipelineIDs}
+}
+
 func (n *connectorNode) getConsumer() baseConsumer {
 	return n.baseConsumer
 }
@@ -233,7 +285,7 @@ func (n *connectorNode) buildComponent(
 	builder *connector.Builder,
 	nexts []baseConsumer,
 ) error {
-	set := connector.CreateSettings{ID: n.componentID, TelemetrySettings: tel, BuildInfo: info}
+	set := connector.CreateSettings{ID: n.componentID, InstanceID: n.instanceID(), TelemetrySettings: tel, BuildInfo: info}
 	set.TelemetrySettings.Logger = components.ConnectorLogger(set.TelemetrySettings.Logger, n.componentID, n.exprPipelineType, n.rcvrPipelineType)
 
 	switch n.rcvrPipelineType {
@@ -365,6 +417,10 @@ func (n *capabilitiesNode) getConsumer() baseConsumer {
 	return n
 }
 
+func (n *capabilitiesNode) Backpressure() bool {
+	return consumer.UnderBackpressure(n.baseConsumer)
+}
+
 var _ consumerNode = &fanOutNode{}
 
 // Each pipeline has one fan-out node before exporters.
@@ -385,3 +441,33 @@ func newFanOutNode(pipelineID component.ID) *fanOutNode {
 func (n *fanOutNode) getConsumer() baseConsumer {
 	return n.baseConsumer
 }
+
+// newPipelineIDTraces returns a consumer passing the data to next with the pipeline ID set in the context.
+func newPipelineIDTraces(pipelineID component.ID, next consumer.Traces) consumer.Traces {
+	tc, _ := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		return next.ConsumeTraces(obsreportconfig.ContextWithPipelineID(ctx, pipelineID), td)
+	}, consumer.WithCapabilities(next.Capabilities()), consumer.WithBackpressure(func() bool {
+		return consumer.UnderBackpressure(next)
+	}))
+	return tc
+}
+
+// newPipelineIDMetrics returns a consumer passing the data to next with the pipeline ID set in the context.
+func newPipelineIDMetrics(pipelineID component.ID, next consumer.Metrics) consumer.Metrics {
+	mc, _ := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		return next.ConsumeMetrics(obsreportconfig.ContextWithPipelineID(ctx, pipelineID), md)
+	}, consumer.WithCapabilities(next.Capabilities()), consumer.WithBackpressure(func() bool {
+		return consumer.UnderBackpressure(next)
+	}))
+	return mc
+}
+
+// newPipelineIDLogs returns a consumer passing the data to next with the pipeline ID set in the context.
+func newPipelineIDLogs(pipelineID component.ID, next consumer.Logs) consumer.Logs {
+	lc, _ := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		return next.ConsumeLogs(obsreportconfig.ContextWithPipelineID(ctx, pipelineID), ld)
+	}, consumer.WithCapabilities(next.Capabilities()), consumer.WithBackpressure(func() bool {
+		return consumer.UnderBackpressure(next)
+	}))
+	return lc
+}
@@ -0,0 +1,260 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"reflect"
+	"sync/atomic"
+
+	"go.uber.org/multierr"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/topo"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Rebuild builds the graph of the given settings like Build, keeping the components of the previous graph
+// instead of creating them again when unchanged reports that their configuration is unchanged, they are
+// used by the same pipelines, and all the components downstream of them are kept as well. The receivers are
+// kept regardless of the components downstream of them: once the new graph is started with StartReplacing,
+// they pass the data they receive to the pipelines of the new graph.
+func Rebuild(ctx context.Context, set Settings, prev *Graph, unchanged func(kind component.Kind, id component.ID) bool) (*Graph, error) {
+	return build(ctx, set, prev, unchanged)
+}
+
+// StartReplacing starts the graph in place of the previous graph it was rebuilt from:
+//   - the receivers of the previous graph which are not kept are shut down first, so that the receivers
+//     replacing them can use the same resources, e.g. listen on the same port;
+//   - the components which are not kept are started;
+//   - the receivers kept pass the data they receive to the pipelines of the new graph;
+//   - the other components of the previous graph which are not kept are flushed, if they implement Flush,
+//     and shut down in topological order, so that the data in flight is passed to the components downstream.
+//
+// The previous graph must not be used anymore, even if StartReplacing fails. The components of the new graph
+// that failed to start are shut down with the rest of the new graph by ShutdownAll.
+func (g *Graph) StartReplacing(ctx context.Context, host component.Host, prev *Graph) error {
+	prevNodes, err := topo.Sort(prev.componentGraph)
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for _, node := range prevNodes {
+		if n, ok := node.(*receiverNode); ok && g.kept[n.ID()] == nil {
+			errs = multierr.Append(errs, prev.shutdownNode(ctx, n, n))
+		}
+	}
+
+	errs = multierr.Append(errs, g.StartAll(ctx, host))
+
+	nodes := g.componentGraph.Nodes()
+	for nodes.Next() {
+		if n, ok := nodes.Node().(*receiverNode); ok && n.pending != nil {
+			n.next.set(n.pending)
+			n.pending = nil
+		}
+	}
+
+	for _, node := range prevNodes {
+		if g.kept[node.ID()] != nil {
+			continue
+		}
+		var comp component.Component
+		switch n := node.(type) {
+		case *processorNode:
+			comp = n.Component
+		case *exporterNode:
+			comp = n.Component
+		case *connectorNode:
+			comp = n.Component
+		default:
+			// Skip capabilities/fanout nodes, and the receivers already shut down.
+			continue
+		}
+		if f, ok := comp.(flusher); ok {
+			errs = multierr.Append(errs, f.Flush(ctx))
+		}
+		errs = multierr.Append(errs, prev.shutdownNode(ctx, node, comp))
+	}
+	return errs
+}
+
+// keptNodes returns the nodes of the previous graph to keep in g, by ID.
+func (g *Graph) keptNodes(prev *Graph, unchanged func(component.Kind, component.ID) bool) map[int64]graph.Node {
+	kept := make(map[int64]graph.Node)
+	nodes := g.componentGraph.Nodes()
+	for nodes.Next() {
+		node := nodes.Node()
+		prevNode := prev.componentGraph.Node(node.ID())
+		if prevNode == nil {
+			continue
+		}
+		if nc, ok := newNodeComponent(node); ok && (!unchanged(nc.kind, nc.id) || !samePipelines(node, prevNode)) {
+			continue
+		}
+		kept[node.ID()] = prevNode
+	}
+
+	// The nodes of a receiver, exporter or connector used in pipelines of different data types may share
+	// one instance of the component, so these nodes are either all kept or all created again.
+	prevShared := sharedNodes(prev.componentGraph)
+	shared := sharedNodes(g.componentGraph)
+
+	// Drop the nodes whose downstream nodes are not all kept, until no more node is dropped.
+	for dropped := true; dropped; {
+		dropped = false
+		for id := range kept {
+			node := g.componentGraph.Node(id)
+			if _, ok := node.(*receiverNode); ok {
+				continue
+			}
+			if !sameNexts(g.componentGraph, prev.componentGraph, id, kept) {
+				delete(kept, id)
+				dropped = true
+			}
+		}
+		for nc, ids := range shared {
+			if allKept(ids, kept) && sameIDs(ids, prevShared[nc]) {
+				continue
+			}
+			for _, id := range ids {
+				if _, ok := kept[id]; ok {
+					delete(kept, id)
+					dropped = true
+				}
+			}
+		}
+	}
+	return kept
+}
+
+// samePipelines returns whether the receiver, exporter or connector of the node is used by the same pipelines
+// in both graphs, the pipelines being part of the identity of the instance of the component.
+func samePipelines(node, prevNode graph.Node) bool {
+	switch n := node.(type) {
+	case *receiverNode:
+		return reflect.DeepEqual(n.pipelineIDs, prevNode.(*receiverNode).pipelineIDs)
+	case *exporterNode:
+		return reflect.DeepEqual(n.pipelineIDs, prevNode.(*exporterNode).pipelineIDs)
+	case *connectorNode:
+		return reflect.DeepEqual(n.pipelineIDs, prevNode.(*connectorNode).pipelineIDs)
+	}
+	return true
+}
+
+// sharedNodes returns the IDs of the nodes of each receiver, exporter and connector.
+func sharedNodes(g graph.Directed) map[nodeComponent][]int64 {
+	shared := make(map[nodeComponent][]int64)
+	nodes := g.Nodes()
+	for nodes.Next() {
+		node := nodes.Node()
+		if _, ok := node.(*processorNode); ok {
+			// Every processor instance is unique to one pipeline.
+			continue
+		}
+		if nc, ok := newNodeComponent(node); ok {
+			shared[nc] = append(shared[nc], node.ID())
+		}
+	}
+	return shared
+}
+
+// sameNexts returns whether the node has the same downstream nodes in both graphs, all of them kept.
+func sameNexts(g, prev graph.Directed, id int64, kept map[int64]graph.Node) bool {
+	nexts := graph.NodesOf(g.From(id))
+	if len(nexts) != prev.From(id).Len() {
+		return false
+	}
+	for _, next := range nexts {
+		if _, ok := kept[next.ID()]; !ok || !prev.HasEdgeFromTo(id, next.ID()) {
+			return false
+		}
+	}
+	return true
+}
+
+func allKept(ids []int64, kept map[int64]graph.Node) bool {
+	for _, id := range ids {
+		if _, ok := kept[id]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func sameIDs(ids, prevIDs []int64) bool {
+	if len(ids) != len(prevIDs) {
+		return false
+	}
+	set := make(map[int64]struct{}, len(ids))
+	for _, id := range ids {
+		set[id] = struct{}{}
+	}
+	for _, id := range prevIDs {
+		if _, ok := set[id]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// keepNode copies the component and the consumer of the node of the previous graph to the node of the new graph.
+func keepNode(node, prevNode graph.Node) {
+	switch n := node.(type) {
+	case *processorNode:
+		*n = *prevNode.(*processorNode)
+	case *exporterNode:
+		*n = *prevNode.(*exporterNode)
+	case *connectorNode:
+		*n = *prevNode.(*connectorNode)
+	case *capabilitiesNode:
+		*n = *prevNode.(*capabilitiesNode)
+	case *fanOutNode:
+		*n = *prevNode.(*fanOutNode)
+	}
+}
+
+// switchConsumer passes the data to a consumer that can be switched while the data flows.
+type switchConsumer struct {
+	current atomic.Pointer[switchTarget]
+}
+
+type switchTarget struct {
+	baseConsumer
+}
+
+func newSwitchConsumer(next baseConsumer) *switchConsumer {
+	sc := &switchConsumer{}
+	sc.set(next)
+	return sc
+}
+
+func (sc *switchConsumer) set(next baseConsumer) {
+	sc.current.Store(&switchTarget{baseConsumer: next})
+}
+
+func (sc *switchConsumer) Capabilities() consumer.Capabilities {
+	return sc.current.Load().Capabilities()
+}
+
+func (sc *switchConsumer) Backpressure() bool {
+	return consumer.UnderBackpressure(sc.current.Load().baseConsumer)
+}
+
+func (sc *switchConsumer) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return sc.current.Load().baseConsumer.(consumer.Traces).ConsumeTraces(ctx, td)
+}
+
+func (sc *switchConsumer) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	return sc.current.Load().baseConsumer.(consumer.Metrics).ConsumeMetrics(ctx, md)
+}
+
+func (sc *switchConsumer) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	return sc.current.Load().baseConsumer.(consumer.Logs).ConsumeLogs(ctx, ld)
+}
@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+var (
+	reloadRcvrID = component.NewID("examplereceiver")
+	reloadProcID = component.NewID("exampleprocessor")
+	reloadNewID  = component.NewIDWithName("exampleprocessor", "new")
+	reloadExpID  = component.NewID("exampleexporter")
+	reloadTraces = component.NewID("traces")
+	reloadMetric = component.NewID("metrics")
+)
+
+// reloadConfig is not zero-sized, so that each configuration gets its own example receiver.
+type reloadConfig struct {
+	_ byte
+}
+
+func reloadSettings(rcvrCfg, expCfg component.Config, tracesProcID component.ID) Settings {
+	return Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: receiver.NewBuilder(
+			map[component.ID]component.Config{reloadRcvrID: rcvrCfg},
+			map[component.Type]receiver.Factory{testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory}),
+		ProcessorBuilder: processor.NewBuilder(
+			map[component.ID]component.Config{
+				reloadProcID: testcomponents.ExampleProcessorFactory.CreateDefaultConfig(),
+				reloadNewID:  testcomponents.ExampleProcessorFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]processor.Factory{testcomponents.ExampleProcessorFactory.Type(): testcomponents.ExampleProcessorFactory}),
+		ExporterBuilder: exporter.NewBuilder(
+			map[component.ID]component.Config{reloadExpID: expCfg},
+			map[component.Type]exporter.Factory{testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory}),
+		ConnectorBuilder: connector.NewBuilder(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+		PipelineConfigs: pipelines.Config{
+			reloadTraces: {Receivers: []component.ID{reloadRcvrID}, Processors: []component.ID{tracesProcID}, Exporters: []component.ID{reloadExpID}},
+			reloadMetric: {Receivers: []component.ID{reloadRcvrID}, Processors: []component.ID{reloadProcID}, Exporters: []component.ID{reloadExpID}},
+		},
+	}
+}
+
+func TestGraphRebuildKeepsUnchangedComponents(t *testing.T) {
+	rcvrCfg := &reloadConfig{}
+	expCfg := testcomponents.ExampleExporterFactory.CreateDefaultConfig()
+	prev, err := Build(context.Background(), reloadSettings(rcvrCfg, expCfg, reloadProcID))
+	require.NoError(t, err)
+	require.NoError(t, prev.StartAll(context.Background(), componenttest.NewNopHost()))
+	prevTraces := prev.pipelines[reloadTraces]
+	prevMetrics := prev.pipelines[reloadMetric]
+
+	// The processor of the traces pipeline is replaced, the other components are unchanged.
+	unchanged := func(component.Kind, component.ID) bool { return true }
+	pg, err := Rebuild(context.Background(), reloadSettings(rcvrCfg, expCfg, reloadNewID), prev, unchanged)
+	require.NoError(t, err)
+	require.NoError(t, pg.StartReplacing(context.Background(), componenttest.NewNopHost(), prev))
+
+	traces := pg.pipelines[reloadTraces]
+	metrics := pg.pipelines[reloadMetric]
+	rcvr := receiverComponent(traces).(*testcomponents.ExampleReceiver)
+	assert.Same(t, receiverComponent(prevTraces), rcvr)
+	assert.False(t, rcvr.Stopped())
+
+	prevProc := prevTraces.processors[0].Component.(*testcomponents.ExampleProcessor)
+	proc := traces.processors[0].Component.(*testcomponents.ExampleProcessor)
+	assert.NotSame(t, prevProc, proc)
+	assert.True(t, prevProc.Stopped())
+	assert.True(t, proc.Started())
+
+	// The components of the metrics pipeline and the exporter of the traces pipeline are kept.
+	assert.Same(t, prevMetrics.processors[0].Component, metrics.processors[0].Component)
+	assert.False(t, metrics.processors[0].Component.(*testcomponents.ExampleProcessor).Stopped())
+	exp := exporterComponent(traces).(*testcomponents.ExampleExporter)
+	assert.Same(t, exporterComponent(prevTraces), exp)
+	assert.False(t, exp.Stopped())
+
+	// The receiver kept passes the data to the new processor.
+	require.NoError(t, rcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	require.NoError(t, rcvr.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1)))
+	assert.Len(t, exp.Traces, 1)
+	assert.Len(t, exporterComponent(metrics).(*testcomponents.ExampleExporter).Metrics, 1)
+
+	require.NoError(t, pg.ShutdownAll(context.Background()))
+	assert.True(t, rcvr.Stopped())
+	assert.True(t, proc.Stopped())
+	assert.True(t, exp.Stopped())
+}
+
+func TestGraphRebuildRestartsChangedComponents(t *testing.T) {
+	rcvrCfg := &reloadConfig{}
+	expCfg := testcomponents.ExampleExporterFactory.CreateDefaultConfig()
+	prev, err := Build(context.Background(), reloadSettings(rcvrCfg, expCfg, reloadProcID))
+	require.NoError(t, err)
+	require.NoError(t, prev.StartAll(context.Background(), componenttest.NewNopHost()))
+	prevTraces := prev.pipelines[reloadTraces]
+
+	// The configuration of the exporter changed: the processors sending data to it are created again.
+	unchanged := func(kind component.Kind, _ component.ID) bool { return kind != component.KindExporter }
+	newExpCfg := testcomponents.ExampleExporterFactory.CreateDefaultConfig()
+	pg, err := Rebuild(context.Background(), reloadSettings(rcvrCfg, newExpCfg, reloadProcID), prev, unchanged)
+	require.NoError(t, err)
+	require.NoError(t, pg.StartReplacing(context.Background(), componenttest.NewNopHost(), prev))
+	traces := pg.pipelines[reloadTraces]
+
+	rcvr := receiverComponent(traces).(*testcomponents.ExampleReceiver)
+	assert.Same(t, receiverComponent(prevTraces), rcvr)
+	assert.False(t, rcvr.Stopped())
+	assert.NotSame(t, prevTraces.processors[0].Component, traces.processors[0].Component)
+	assert.True(t, prevTraces.processors[0].Component.(*testcomponents.ExampleProcessor).Stopped())
+	prevExp := exporterComponent(prevTraces).(*testcomponents.ExampleExporter)
+	exp := exporterComponent(traces).(*testcomponents.ExampleExporter)
+	assert.NotSame(t, prevExp, exp)
+	assert.True(t, prevExp.Stopped())
+	assert.True(t, exp.Started())
+
+	require.NoError(t, rcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Len(t, exp.Traces, 1)
+	assert.Empty(t, prevExp.Traces)
+
+	// The configuration of the receiver changed: it is shut down before the new one is started.
+	unchanged = func(kind component.Kind, _ component.ID) bool { return kind != component.KindReceiver }
+	next, err := Rebuild(context.Background(), reloadSettings(&reloadConfig{}, newExpCfg, reloadProcID), pg, unchanged)
+	require.NoError(t, err)
+	require.NoError(t, next.StartReplacing(context.Background(), componenttest.NewNopHost(), pg))
+	assert.True(t, rcvr.Stopped())
+	assert.NotSame(t, rcvr, receiverComponent(next.pipelines[reloadTraces]))
+	assert.Same(t, exp, exporterComponent(next.pipelines[reloadTraces]))
+	assert.False(t, exp.Stopped())
+
+	require.NoError(t, next.ShutdownAll(context.Background()))
+}
+
+func receiverComponent(pipe *pipelineNodes) component.Component {
+	for _, n := range pipe.receivers {
+		return n.(*receiverNode).Component
+	}
+	return nil
+}
+
+func exporterComponent(pipe *pipelineNodes) component.Component {
+	for _, n := range pipe.exporters {
+		return n.(*exporterNode).Component
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+)
+
+// scrapeTriggers returns the receivers able to scrape on demand, like the receivers created
+// with the scraperhelper, by ID. A receiver used by several pipelines is only returned once.
+func (g *Graph) scrapeTriggers() map[component.ID]scraperhelper.ScrapeTrigger {
+	triggers := map[component.ID]scraperhelper.ScrapeTrigger{}
+	nodes := g.componentGraph.Nodes()
+	for nodes.Next() {
+		n, ok := nodes.Node().(*receiverNode)
+		if !ok {
+			continue
+		}
+		if trigger, ok := n.Component.(scraperhelper.ScrapeTrigger); ok {
+			triggers[n.componentID] = trigger
+		}
+	}
+	return triggers
+}
+
+// ScrapableReceivers returns the IDs, in order, of the receivers able to scrape on demand.
+func (g *Graph) ScrapableReceivers() []component.ID {
+	triggers := g.scrapeTriggers()
+	ids := make([]component.ID, 0, len(triggers))
+	for id := range triggers {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+	return ids
+}
+
+// ScrapeNow makes the given receiver scrape immediately, and waits until the scraped data is passed to the pipelines.
+func (g *Graph) ScrapeNow(ctx context.Context, id component.ID) error {
+	trigger, ok := g.scrapeTriggers()[id]
+	if !ok {
+		return fmt.Errorf("receiver %q does not scrape on demand", id)
+	}
+	return trigger.ScrapeNow(ctx)
+}
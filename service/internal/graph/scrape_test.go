@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/graph/simple"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+type scrapeComponent struct {
+	testNode
+	scrapes int
+}
+
+func (c *scrapeComponent) ScrapeNow(context.Context) error {
+	c.scrapes++
+	return nil
+}
+
+func TestGraphScrapeNow(t *testing.T) {
+	pipelineID := component.NewID("metrics")
+	scraper := &scrapeComponent{testNode: testNode{id: component.NewIDWithName("r", "scraper")}}
+	r1 := newReceiverNode(component.DataTypeMetrics, scraper.id)
+	r1.Component = scraper
+	r2 := newReceiverNode(component.DataTypeMetrics, component.NewIDWithName("r", "push"))
+	r2.Component = &testNode{id: r2.componentID}
+	e1 := newExporterNode(component.DataTypeMetrics, component.NewIDWithName("e", "1"))
+	e1.Component = &scrapeComponent{testNode: testNode{id: e1.componentID}}
+	p1 := newProcessorNode(pipelineID, component.NewIDWithName("p", "1"))
+
+	pg := &Graph{componentGraph: simple.NewDirectedGraph()}
+	pg.componentGraph.SetEdge(simple.Edge{F: r1, T: p1})
+	pg.componentGraph.SetEdge(simple.Edge{F: r2, T: p1})
+	pg.componentGraph.SetEdge(simple.Edge{F: p1, T: e1})
+
+	// Only the receivers scrape on demand.
+	assert.Equal(t, []component.ID{scraper.id}, pg.ScrapableReceivers())
+	assert.NoError(t, pg.ScrapeNow(context.Background(), scraper.id))
+	assert.Equal(t, 1, scraper.scrapes)
+	assert.EqualError(t, pg.ScrapeNow(context.Background(), r2.componentID), `receiver "r/push" does not scrape on demand`)
+	assert.EqualError(t, pg.ScrapeNow(context.Background(), e1.componentID), `receiver "e/1" does not scrape on demand`)
+}
@@ -4,9 +4,13 @@
 package graph // import "go.opentelemetry.io/collector/service/internal/graph"
 
 import (
+	"fmt"
 	"net/http"
 	"sort"
+	"strconv"
+	"time"
 
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/service/internal/zpages"
 )
 
@@ -79,3 +83,73 @@ func (g *Graph) HandleZPages(w http.ResponseWriter, r *http.Request) {
 	}
 	zpages.WriteHTMLPageFooter(w)
 }
+
+// HandleExportersZPages writes the live status of the sending queue and of the retries of each exporter.
+func (g *Graph) HandleExportersZPages(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Exporters"})
+
+	now := time.Now()
+	seen := make(map[int64]struct{})
+	sumData := zpages.SummaryExportersTableData{}
+	for _, p := range g.pipelines {
+		for _, c := range p.exporters {
+			n, ok := c.(*exporterNode)
+			if !ok {
+				continue
+			}
+			if _, ok = seen[n.ID()]; ok {
+				continue
+			}
+			seen[n.ID()] = struct{}{}
+			sumData.Rows = append(sumData.Rows, exporterStatusRow(n, now))
+		}
+	}
+	sort.Slice(sumData.Rows, func(i, j int) bool {
+		if sumData.Rows[i].FullName != sumData.Rows[j].FullName {
+			return sumData.Rows[i].FullName < sumData.Rows[j].FullName
+		}
+		return sumData.Rows[i].InputType < sumData.Rows[j].InputType
+	})
+	zpages.WriteHTMLExportersSummaryTable(w, sumData)
+	zpages.WriteHTMLPageFooter(w)
+}
+
+func exporterStatusRow(n *exporterNode, now time.Time) zpages.SummaryExportersTableRowData {
+	row := zpages.SummaryExportersTableRowData{
+		FullName:  n.componentID.String(),
+		InputType: string(n.pipelineType),
+	}
+	reporter, ok := n.Component.(exporterhelper.StatusReporter)
+	if !ok {
+		// The exporter is not built with the exporterhelper, its status is unknown.
+		row.Queue = "n/a"
+		row.Retry = "n/a"
+		return row
+	}
+
+	st := reporter.ExporterStatus()
+	if st.QueueEnabled {
+		row.Queue = fmt.Sprintf("%d / %d", st.QueueSize, st.QueueCapacity)
+		if st.Persistent {
+			row.Queue += " (persistent)"
+		}
+		row.OldestItemAge = st.OldestItemAge.Truncate(time.Millisecond).String()
+		row.Consumers = strconv.Itoa(st.NumConsumers)
+	} else {
+		row.Queue = "disabled"
+	}
+	switch {
+	case !st.RetryEnabled:
+		row.Retry = "disabled"
+	case st.RetryingRequests == 0:
+		row.Retry = "idle"
+	default:
+		row.Retry = fmt.Sprintf("%d in backoff, next retry in %s", st.RetryingRequests, st.NextRetry.Sub(now).Truncate(time.Millisecond))
+		row.LastRetryError = st.LastRetryError
+	}
+	if st.CircuitBreakerEnabled && st.CircuitBreakerState != exporterhelper.CircuitBreakerClosed {
+		row.Retry += ", circuit breaker " + st.CircuitBreakerState.String()
+	}
+	return row
+}
@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/graph"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/service/internal/zpages"
+)
+
+type statusExporter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	status exporterhelper.Status
+}
+
+func (e *statusExporter) ExporterStatus() exporterhelper.Status {
+	return e.status
+}
+
+func TestExporterStatusRow(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		component component.Component
+		expected  zpages.SummaryExportersTableRowData
+	}{
+		{
+			name: "no_status",
+			component: &struct {
+				component.StartFunc
+				component.ShutdownFunc
+			}{},
+			expected: zpages.SummaryExportersTableRowData{Queue: "n/a", Retry: "n/a"},
+		},
+		{
+			name:      "disabled",
+			component: &statusExporter{},
+			expected:  zpages.SummaryExportersTableRowData{Queue: "disabled", Retry: "disabled"},
+		},
+		{
+			name: "idle",
+			component: &statusExporter{status: exporterhelper.Status{
+				QueueEnabled:  true,
+				QueueCapacity: 1000,
+				NumConsumers:  10,
+				RetryEnabled:  true,
+			}},
+			expected: zpages.SummaryExportersTableRowData{Queue: "0 / 1000", OldestItemAge: "0s", Consumers: "10", Retry: "idle"},
+		},
+		{
+			name: "retrying",
+			component: &statusExporter{status: exporterhelper.Status{
+				QueueEnabled:     true,
+				Persistent:       true,
+				QueueSize:        12,
+				QueueCapacity:    1000,
+				OldestItemAge:    1500 * time.Millisecond,
+				NumConsumers:     10,
+				RetryEnabled:     true,
+				RetryingRequests: 10,
+				NextRetry:        now.Add(2 * time.Second),
+				LastRetryError:   "connection refused",
+			}},
+			expected: zpages.SummaryExportersTableRowData{
+				Queue:          "12 / 1000 (persistent)",
+				OldestItemAge:  "1.5s",
+				Consumers:      "10",
+				Retry:          "10 in backoff, next retry in 2s",
+				LastRetryError: "connection refused",
+			},
+		},
+		{
+			name: "circuit_breaker_open",
+			component: &statusExporter{status: exporterhelper.Status{
+				RetryEnabled:          true,
+				RetryingRequests:      2,
+				NextRetry:             now.Add(30 * time.Second),
+				LastRetryError:        "circuit breaker is open",
+				CircuitBreakerEnabled: true,
+				CircuitBreakerState:   exporterhelper.CircuitBreakerOpen,
+			}},
+			expected: zpages.SummaryExportersTableRowData{
+				Queue:          "disabled",
+				Retry:          "2 in backoff, next retry in 30s, circuit breaker open",
+				LastRetryError: "circuit breaker is open",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := newExporterNode(component.DataTypeTraces, component.NewID("otlp"))
+			n.Component = tt.component
+			tt.expected.FullName = "otlp"
+			tt.expected.InputType = "traces"
+			assert.Equal(t, tt.expected, exporterStatusRow(n, now))
+		})
+	}
+}
+
+func TestHandleExportersZPages(t *testing.T) {
+	exp := newExporterNode(component.DataTypeTraces, component.NewID("otlp"))
+	exp.Component = &statusExporter{status: exporterhelper.Status{QueueEnabled: true, QueueSize: 3, QueueCapacity: 100}}
+	g := &Graph{pipelines: map[component.ID]*pipelineNodes{
+		component.NewID("traces"):                 {exporters: map[int64]graph.Node{exp.ID(): exp}},
+		component.NewIDWithName("traces", "copy"): {exporters: map[int64]graph.Node{exp.ID(): exp}},
+	}}
+
+	rr := httptest.NewRecorder()
+	g.HandleExportersZPages(rr, httptest.NewRequest("GET", "/debug/exporterz", nil))
+	assert.Equal(t, "text/html; charset=utf-8", rr.Header().Get("Content-Type"))
+	// The exporters shared by several pipelines are listed once.
+	assert.Equal(t, 1, strings.Count(rr.Body.String(), "3 / 100"))
+}
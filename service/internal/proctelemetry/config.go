@@ -14,15 +14,11 @@ import (
 	"strings"
 	"time"
 
-	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/bridge/opencensus"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
-	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
 	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
@@ -66,9 +62,11 @@ var (
 	errNoValidSpanExporter   = errors.New("no valid span exporter")
 )
 
-func InitMetricReader(ctx context.Context, reader telemetry.MetricReader, asyncErrorChannel chan error) (sdkmetric.Reader, *http.Server, error) {
+// InitMetricReader initializes the configured metric reader. The resource and the Prometheus settings are used by
+// the Prometheus exporter, to expose the resource attributes as labels and the histograms as native histograms.
+func InitMetricReader(ctx context.Context, reader telemetry.MetricReader, res *resource.Resource, promCfg telemetry.PrometheusConfig, asyncErrorChannel chan error) (sdkmetric.Reader, *http.Server, error) {
 	if reader.Pull != nil {
-		return initPullExporter(reader.Pull.Exporter, asyncErrorChannel)
+		return initPullExporter(reader.Pull.Exporter, res, promCfg, asyncErrorChannel)
 	}
 	if reader.Periodic != nil {
 		opts := []sdkmetric.PeriodicReaderOption{}
@@ -137,21 +135,6 @@ func InitOpenTelemetry(res *resource.Resource, options []sdkmetric.Option, disab
 	), nil
 }
 
-func InitPrometheusServer(registry *prometheus.Registry, address string, asyncErrorChannel chan error) *http.Server {
-	mux := http.NewServeMux()
-	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
-	server := &http.Server{
-		Addr:    address,
-		Handler: mux,
-	}
-	go func() {
-		if serveErr := server.ListenAndServe(); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
-			asyncErrorChannel <- serveErr
-		}
-	}()
-	return server
-}
-
 func batchViews(disableHighCardinality bool) []sdkmetric.View {
 	views := []sdkmetric.View{
 		sdkmetric.NewView(
@@ -195,32 +178,9 @@ func cardinalityFilter(kvs ...attribute.KeyValue) attribute.Filter {
 	}
 }
 
-func initPrometheusExporter(prometheusConfig *telemetry.Prometheus, asyncErrorChannel chan error) (sdkmetric.Reader, *http.Server, error) {
-	promRegistry := prometheus.NewRegistry()
-	if prometheusConfig.Host == nil {
-		return nil, nil, fmt.Errorf("host must be specified")
-	}
-	if prometheusConfig.Port == nil {
-		return nil, nil, fmt.Errorf("port must be specified")
-	}
-	wrappedRegisterer := prometheus.WrapRegistererWithPrefix("otelcol_", promRegistry)
-	exporter, err := otelprom.New(
-		otelprom.WithRegisterer(wrappedRegisterer),
-		// https://github.com/open-telemetry/opentelemetry-collector/issues/8043
-		otelprom.WithoutUnits(),
-		// Disabled for the moment until this becomes stable, and we are ready to break backwards compatibility.
-		otelprom.WithoutScopeInfo(),
-		otelprom.WithProducer(opencensus.NewMetricProducer()))
-	if err != nil {
-		return nil, nil, fmt.Errorf("error creating otel prometheus exporter: %w", err)
-	}
-
-	return exporter, InitPrometheusServer(promRegistry, fmt.Sprintf("%s:%d", *prometheusConfig.Host, *prometheusConfig.Port), asyncErrorChannel), nil
-}
-
-func initPullExporter(exporter telemetry.MetricExporter, asyncErrorChannel chan error) (sdkmetric.Reader, *http.Server, error) {
+func initPullExporter(exporter telemetry.MetricExporter, res *resource.Resource, promCfg telemetry.PrometheusConfig, asyncErrorChannel chan error) (sdkmetric.Reader, *http.Server, error) {
 	if exporter.Prometheus != nil {
-		return initPrometheusExporter(exporter.Prometheus, asyncErrorChannel)
+		return initPrometheusExporter(exporter.Prometheus, res, promCfg, asyncErrorChannel)
 	}
 	return nil, nil, errNoValidMetricExporter
 }
@@ -0,0 +1,26 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build otelcol_noprometheus
+
+package proctelemetry // import "go.opentelemetry.io/collector/service/internal/proctelemetry"
+
+import (
+	"errors"
+	"net/http"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+
+	"go.opentelemetry.io/collector/service/telemetry"
+)
+
+// PrometheusIncluded reports whether the prometheus metric exporter is included in the build,
+// which is not the case in builds with the otelcol_noprometheus tag.
+const PrometheusIncluded = false
+
+var errPrometheusNotIncluded = errors.New("the prometheus metric exporter is not included in this build")
+
+func initPrometheusExporter(*telemetry.Prometheus, *resource.Resource, telemetry.PrometheusConfig, chan error) (sdkmetric.Reader, *http.Server, error) {
+	return nil, nil, errPrometheusNotIncluded
+}
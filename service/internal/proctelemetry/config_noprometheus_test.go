@@ -0,0 +1,28 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build otelcol_noprometheus
+
+package proctelemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/sdk/resource"
+
+	"go.opentelemetry.io/collector/service/telemetry"
+)
+
+func TestMetricReaderPrometheusNotIncluded(t *testing.T) {
+	reader := telemetry.MetricReader{
+		Pull: &telemetry.PullMetricReader{
+			Exporter: telemetry.MetricExporter{
+				Prometheus: &telemetry.Prometheus{},
+			},
+		},
+	}
+	_, _, err := InitMetricReader(context.Background(), reader, resource.Empty(), telemetry.PrometheusConfig{}, make(chan error))
+	assert.ErrorIs(t, err, errPrometheusNotIncluded)
+}
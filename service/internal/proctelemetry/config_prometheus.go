@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !otelcol_noprometheus
+
+package proctelemetry // import "go.opentelemetry.io/collector/service/internal/proctelemetry"
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/bridge/opencensus"
+	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+
+	"go.opentelemetry.io/collector/service/telemetry"
+)
+
+// PrometheusIncluded reports whether the prometheus metric exporter is included in the build,
+// which is not the case in builds with the otelcol_noprometheus tag.
+const PrometheusIncluded = true
+
+func InitPrometheusServer(registry *prometheus.Registry, address string, asyncErrorChannel chan error) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	server := &http.Server{
+		Addr:    address,
+		Handler: mux,
+	}
+	go func() {
+		if serveErr := server.ListenAndServe(); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
+			asyncErrorChannel <- serveErr
+		}
+	}()
+	return server
+}
+
+func initPrometheusExporter(prometheusConfig *telemetry.Prometheus, res *resource.Resource, promCfg telemetry.PrometheusConfig, asyncErrorChannel chan error) (sdkmetric.Reader, *http.Server, error) {
+	promRegistry := prometheus.NewRegistry()
+	if prometheusConfig.Host == nil {
+		return nil, nil, fmt.Errorf("host must be specified")
+	}
+	if prometheusConfig.Port == nil {
+		return nil, nil, fmt.Errorf("port must be specified")
+	}
+	wrappedRegisterer := prometheus.WrapRegistererWithPrefix("otelcol_", promRegistry)
+	opts := []otelprom.Option{
+		// https://github.com/open-telemetry/opentelemetry-collector/issues/8043
+		otelprom.WithoutUnits(),
+		// Disabled for the moment until this becomes stable, and we are ready to break backwards compatibility.
+		otelprom.WithoutScopeInfo(),
+		otelprom.WithProducer(opencensus.NewMetricProducer()),
+	}
+
+	// The resource attributes used as labels are added to every metric but target_info, reported separately
+	// with the other resource attributes.
+	metricsRegisterer := wrappedRegisterer
+	if len(promCfg.ResourceLabels) > 0 || promCfg.DisableTargetInfo {
+		opts = append(opts, otelprom.WithoutTargetInfo())
+		labels, others := splitResourceLabels(res, promCfg.ResourceLabels)
+		metricsRegisterer = prometheus.WrapRegistererWith(labels, wrappedRegisterer)
+		if !promCfg.DisableTargetInfo {
+			targetInfo := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+				Name:        "target_info",
+				Help:        "Target metadata",
+				ConstLabels: others,
+			}, func() float64 { return 1 })
+			if err := wrappedRegisterer.Register(targetInfo); err != nil {
+				return nil, nil, fmt.Errorf("error registering target_info metric: %w", err)
+			}
+		}
+	}
+	if promCfg.NativeHistograms {
+		opts = append(opts, otelprom.WithAggregationSelector(nativeHistogramsAggregationSelector))
+	}
+
+	exporter, err := otelprom.New(append(opts, otelprom.WithRegisterer(metricsRegisterer))...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating otel prometheus exporter: %w", err)
+	}
+	if promCfg.NativeHistograms {
+		// The otel prometheus exporter ignores the exponential histograms, exposed by this collector instead.
+		if err = metricsRegisterer.Register(&nativeHistogramsCollector{reader: exporter}); err != nil {
+			return nil, nil, fmt.Errorf("error registering native histograms collector: %w", err)
+		}
+	}
+
+	return exporter, InitPrometheusServer(promRegistry, fmt.Sprintf("%s:%d", *prometheusConfig.Host, *prometheusConfig.Port), asyncErrorChannel), nil
+}
+
+// splitResourceLabels returns the resource attributes to add as labels to every metric and the other ones,
+// with their keys sanitized to be valid Prometheus label names.
+func splitResourceLabels(res *resource.Resource, resourceLabels []string) (labels, others prometheus.Labels) {
+	labels, others = prometheus.Labels{}, prometheus.Labels{}
+	selected := make(map[attribute.Key]bool, len(resourceLabels))
+	for _, key := range resourceLabels {
+		selected[attribute.Key(key)] = true
+	}
+	for it := res.Iter(); it.Next(); {
+		kv := it.Attribute()
+		if selected[kv.Key] {
+			labels[sanitizePrometheusLabel(string(kv.Key))] = kv.Value.Emit()
+		} else {
+			others[sanitizePrometheusLabel(string(kv.Key))] = kv.Value.Emit()
+		}
+	}
+	return labels, others
+}
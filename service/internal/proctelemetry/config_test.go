@@ -1,6 +1,8 @@
 // Copyright The OpenTelemetry Authors
 // SPDX-License-Identifier: Apache-2.0
 
+//go:build !otelcol_noprometheus
+
 package proctelemetry
 
 import (
@@ -10,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/sdk/resource"
 
 	"go.opentelemetry.io/collector/service/telemetry"
 )
@@ -340,7 +343,7 @@ func TestMetricReader(t *testing.T) {
 	}
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
-			_, _, err := InitMetricReader(context.Background(), tt.reader, make(chan error))
+			_, _, err := InitMetricReader(context.Background(), tt.reader, resource.Empty(), telemetry.PrometheusConfig{}, make(chan error))
 			assert.Equal(t, tt.err, err)
 		})
 	}
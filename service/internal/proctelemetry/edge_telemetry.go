@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package proctelemetry // import "go.opentelemetry.io/collector/service/internal/proctelemetry"
+
+import (
+	"context"
+	"errors"
+
+	"go.opencensus.io/metric"
+	"go.opencensus.io/metric/metricdata"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+
+	"go.opentelemetry.io/collector/service/internal/graph"
+)
+
+const (
+	edgeScopeName = "go.opentelemetry.io/collector/service/edge_telemetry"
+	pipelineKey   = "pipeline"
+)
+
+// RegisterEdgeMetrics registers the gauges reporting, for every edge of the pipelines passing the data to
+// a processor, exporter or connector, the requests waiting for the component, the time spent by the oldest
+// of them, and whether the edge reports backpressure.
+func RegisterEdgeMetrics(ocRegistry *metric.Registry, mp otelmetric.MeterProvider, useOtel bool, tracker *graph.EdgeTracker) error {
+	if useOtel {
+		meter := mp.Meter(edgeScopeName)
+		_, err := meter.Int64ObservableGauge(
+			"pipeline_edge_queue_depth",
+			otelmetric.WithDescription("Requests passed by the pipeline to the component that it has not finished consuming"),
+			otelmetric.WithUnit("{requests}"),
+			otelmetric.WithInt64Callback(func(_ context.Context, o otelmetric.Int64Observer) error {
+				for _, e := range tracker.Edges() {
+					o.Observe(e.QueueDepth(), otelmetric.WithAttributes(edgeAttributes(e)...))
+				}
+				return nil
+			}))
+		// ignore instrument name error as per workaround in https://github.com/open-telemetry/opentelemetry-collector/issues/8346
+		if err != nil && !errors.Is(err, sdkmetric.ErrInstrumentName) {
+			return err
+		}
+		_, err = meter.Float64ObservableGauge(
+			"pipeline_edge_blocked_duration",
+			otelmetric.WithDescription("Time spent in the component by the oldest request passed by the pipeline that it has not finished consuming"),
+			otelmetric.WithUnit("s"),
+			otelmetric.WithFloat64Callback(func(_ context.Context, o otelmetric.Float64Observer) error {
+				for _, e := range tracker.Edges() {
+					o.Observe(e.BlockedDuration().Seconds(), otelmetric.WithAttributes(edgeAttributes(e)...))
+				}
+				return nil
+			}))
+		if err != nil && !errors.Is(err, sdkmetric.ErrInstrumentName) {
+			return err
+		}
+		_, err = meter.Int64ObservableGauge(
+			"pipeline_edge_backpressure",
+			otelmetric.WithDescription("1 if the edge from the pipeline to the component reports backpressure, 0 otherwise"),
+			otelmetric.WithInt64Callback(func(_ context.Context, o otelmetric.Int64Observer) error {
+				for _, e := range tracker.Edges() {
+					o.Observe(backpressureValue(e), otelmetric.WithAttributes(edgeAttributes(e)...))
+				}
+				return nil
+			}))
+		if err != nil && !errors.Is(err, sdkmetric.ErrInstrumentName) {
+			return err
+		}
+		return nil
+	}
+
+	depthGauge, err := ocRegistry.AddInt64DerivedGauge(
+		"pipeline_edge/queue_depth",
+		metric.WithDescription("Requests passed by the pipeline to the component that it has not finished consuming"),
+		metric.WithUnit(metricdata.UnitDimensionless),
+		metric.WithLabelKeys(pipelineKey, kindKey, componentKey))
+	if err != nil {
+		return err
+	}
+	blockedGauge, err := ocRegistry.AddFloat64DerivedGauge(
+		"pipeline_edge/blocked_duration",
+		metric.WithDescription("Time spent in the component by the oldest request passed by the pipeline that it has not finished consuming"),
+		metric.WithUnit("s"),
+		metric.WithLabelKeys(pipelineKey, kindKey, componentKey))
+	if err != nil {
+		return err
+	}
+	backpressureGauge, err := ocRegistry.AddInt64DerivedGauge(
+		"pipeline_edge/backpressure",
+		metric.WithDescription("1 if the edge from the pipeline to the component reports backpressure, 0 otherwise"),
+		metric.WithUnit(metricdata.UnitDimensionless),
+		metric.WithLabelKeys(pipelineKey, kindKey, componentKey))
+	if err != nil {
+		return err
+	}
+	tracker.ObserveEdges(func(e *graph.Edge) {
+		labelValues := []metricdata.LabelValue{
+			metricdata.NewLabelValue(e.PipelineID().String()),
+			metricdata.NewLabelValue(e.Kind().String()),
+			metricdata.NewLabelValue(e.ComponentID().String()),
+		}
+		// The entries only fail to be added for a wrong number of label values.
+		_ = depthGauge.UpsertEntry(e.QueueDepth, labelValues...)
+		_ = blockedGauge.UpsertEntry(func() float64 { return e.BlockedDuration().Seconds() }, labelValues...)
+		_ = backpressureGauge.UpsertEntry(func() int64 { return backpressureValue(e) }, labelValues...)
+	})
+	return nil
+}
+
+func edgeAttributes(e *graph.Edge) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String(pipelineKey, e.PipelineID().String()),
+		attribute.String(kindKey, e.Kind().String()),
+		attribute.String(componentKey, e.ComponentID().String()),
+	}
+}
+
+func backpressureValue(e *graph.Edge) int64 {
+	if e.Backpressure() {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package proctelemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/internal/graph"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+// newEdgeTracker builds a graph with a traces pipeline passing the data of a receiver to an exporter,
+// returning the tracker of its edges.
+func newEdgeTracker(t *testing.T) *graph.EdgeTracker {
+	tracker := graph.NewEdgeTracker()
+	_, err := graph.Build(context.Background(), graph.Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: receiver.NewBuilder(
+			map[component.ID]component.Config{component.NewID("examplereceiver"): testcomponents.ExampleReceiverFactory.CreateDefaultConfig()},
+			map[component.Type]receiver.Factory{testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory}),
+		ProcessorBuilder: processor.NewBuilder(map[component.ID]component.Config{}, map[component.Type]processor.Factory{}),
+		ExporterBuilder: exporter.NewBuilder(
+			map[component.ID]component.Config{component.NewID("exampleexporter"): testcomponents.ExampleExporterFactory.CreateDefaultConfig()},
+			map[component.Type]exporter.Factory{testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory}),
+		ConnectorBuilder: connector.NewBuilder(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+		PipelineConfigs: pipelines.Config{
+			component.NewID("traces"): {
+				Receivers: []component.ID{component.NewID("examplereceiver")},
+				Exporters: []component.ID{component.NewID("exampleexporter")},
+			},
+		},
+		EdgeTracker: tracker,
+	})
+	require.NoError(t, err)
+	return tracker
+}
+
+func TestOtelEdgeTelemetry(t *testing.T) {
+	tel := setupTelemetry(t)
+	require.NoError(t, RegisterEdgeMetrics(nil, tel.MeterProvider, true, newEdgeTracker(t)))
+
+	mp, err := fetchPrometheusMetrics(tel.promHandler)
+	require.NoError(t, err)
+	for _, name := range []string{"pipeline_edge_queue_depth", "pipeline_edge_blocked_duration", "pipeline_edge_backpressure"} {
+		m, ok := mp[name]
+		require.True(t, ok, name)
+		require.Len(t, m.Metric, 1)
+		labels := map[string]string{}
+		for _, l := range m.Metric[0].GetLabel() {
+			labels[l.GetName()] = l.GetValue()
+		}
+		assert.Equal(t, "traces", labels[pipelineKey])
+		assert.Equal(t, "exporter", labels[kindKey])
+		assert.Equal(t, "exampleexporter", labels[componentKey])
+		assert.Zero(t, m.Metric[0].GetGauge().GetValue())
+	}
+}
+
+func TestOCEdgeTelemetry(t *testing.T) {
+	ocRegistry := metric.NewRegistry()
+	require.NoError(t, RegisterEdgeMetrics(ocRegistry, noop.NewMeterProvider(), false, newEdgeTracker(t)))
+
+	for _, name := range []string{"pipeline_edge/queue_depth", "pipeline_edge/blocked_duration", "pipeline_edge/backpressure"} {
+		m := findMetric(ocRegistry.Read(), name)
+		require.NotNil(t, m, name)
+		require.Len(t, m.TimeSeries, 1)
+		ts := m.TimeSeries[0]
+		require.Len(t, ts.LabelValues, 3)
+		assert.Equal(t, "traces/exporter/exampleexporter", ts.LabelValues[0].Value+"/"+ts.LabelValues[1].Value+"/"+ts.LabelValues[2].Value)
+		require.Len(t, ts.Points, 1)
+		assert.Zero(t, ts.Points[0].Value)
+	}
+}
+
+func TestEdgeTelemetryFailToRegister(t *testing.T) {
+	ocRegistry := metric.NewRegistry()
+	_, err := ocRegistry.AddFloat64Gauge("pipeline_edge/queue_depth")
+	require.NoError(t, err)
+	assert.Error(t, RegisterEdgeMetrics(ocRegistry, noop.NewMeterProvider(), false, graph.NewEdgeTracker()))
+}
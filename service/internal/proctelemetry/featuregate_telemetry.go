@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package proctelemetry // import "go.opentelemetry.io/collector/service/internal/proctelemetry"
+
+import (
+	"context"
+	"errors"
+
+	"go.opencensus.io/metric"
+	"go.opencensus.io/metric/metricdata"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+const (
+	featureGateScopeName = "go.opentelemetry.io/collector/service/featuregate_telemetry"
+	featureGateKey       = "feature_gate"
+	stageKey             = "stage"
+)
+
+// RegisterFeatureGateMetrics registers a gauge reporting, for every gate of the Registry,
+// 1 if the gate is enabled and 0 otherwise.
+func RegisterFeatureGateMetrics(ocRegistry *metric.Registry, mp otelmetric.MeterProvider, useOtel bool, reg *featuregate.Registry) error {
+	if useOtel {
+		_, err := mp.Meter(featureGateScopeName).Int64ObservableGauge(
+			"feature_gate_enabled",
+			otelmetric.WithDescription("Whether the feature gate is enabled (1) or disabled (0)"),
+			otelmetric.WithUnit("1"),
+			otelmetric.WithInt64Callback(func(_ context.Context, o otelmetric.Int64Observer) error {
+				reg.VisitAll(func(g *featuregate.Gate) {
+					o.Observe(enabledValue(g), otelmetric.WithAttributes(
+						attribute.String(featureGateKey, g.ID()),
+						attribute.String(stageKey, g.Stage().String())))
+				})
+				return nil
+			}))
+		// ignore instrument name error as per workaround in https://github.com/open-telemetry/opentelemetry-collector/issues/8346
+		if err != nil && !errors.Is(err, sdkmetric.ErrInstrumentName) {
+			return err
+		}
+		return nil
+	}
+
+	gauge, err := ocRegistry.AddInt64DerivedGauge(
+		"feature_gate/enabled",
+		metric.WithDescription("Whether the feature gate is enabled (1) or disabled (0)"),
+		metric.WithLabelKeys(featureGateKey, stageKey))
+	if err != nil {
+		return err
+	}
+	reg.VisitAll(func(g *featuregate.Gate) {
+		if err == nil {
+			err = gauge.UpsertEntry(func() int64 { return enabledValue(g) },
+				metricdata.NewLabelValue(g.ID()),
+				metricdata.NewLabelValue(g.Stage().String()))
+		}
+	})
+	return err
+}
+
+func enabledValue(g *featuregate.Gate) int64 {
+	if g.IsEnabled() {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package proctelemetry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+func newTestFeatureGateRegistry() *featuregate.Registry {
+	reg := featuregate.NewRegistry()
+	reg.MustRegister("alpha", featuregate.StageAlpha)
+	reg.MustRegister("beta", featuregate.StageBeta)
+	return reg
+}
+
+func TestOtelFeatureGateTelemetry(t *testing.T) {
+	tel := setupTelemetry(t)
+	reg := newTestFeatureGateRegistry()
+
+	require.NoError(t, RegisterFeatureGateMetrics(nil, tel.MeterProvider, true, reg))
+
+	mp, err := fetchPrometheusMetrics(tel.promHandler)
+	require.NoError(t, err)
+	metric, ok := mp["feature_gate_enabled"]
+	require.True(t, ok)
+	require.Len(t, metric.Metric, 2)
+	values := map[string]float64{}
+	for _, m := range metric.Metric {
+		for _, l := range m.GetLabel() {
+			if l.GetName() == featureGateKey {
+				values[l.GetValue()] = m.GetGauge().GetValue()
+			}
+		}
+	}
+	assert.Equal(t, map[string]float64{"alpha": 0, "beta": 1}, values)
+}
+
+func TestOCFeatureGateTelemetry(t *testing.T) {
+	ocRegistry := metric.NewRegistry()
+	reg := newTestFeatureGateRegistry()
+
+	require.NoError(t, RegisterFeatureGateMetrics(ocRegistry, noop.NewMeterProvider(), false, reg))
+	require.NoError(t, reg.Set("alpha", true))
+
+	m := findMetric(ocRegistry.Read(), "feature_gate/enabled")
+	require.NotNil(t, m)
+	require.Len(t, m.TimeSeries, 2)
+	for _, ts := range m.TimeSeries {
+		require.Len(t, ts.LabelValues, 2)
+		require.Len(t, ts.Points, 1)
+		assert.Equal(t, int64(1), ts.Points[0].Value, ts.LabelValues[0].Value)
+	}
+}
+
+func TestFeatureGateTelemetryFailToRegister(t *testing.T) {
+	ocRegistry := metric.NewRegistry()
+	_, err := ocRegistry.AddFloat64Gauge("feature_gate/enabled")
+	require.NoError(t, err)
+	assert.Error(t, RegisterFeatureGateMetrics(ocRegistry, noop.NewMeterProvider(), false, newTestFeatureGateRegistry()))
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package proctelemetry // import "go.opentelemetry.io/collector/service/internal/proctelemetry"
+
+import (
+	"context"
+	"errors"
+
+	"go.opencensus.io/metric"
+	"go.opencensus.io/metric/metricdata"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+
+	"go.opentelemetry.io/collector/inflight"
+)
+
+const (
+	inFlightScopeName = "go.opentelemetry.io/collector/service/inflight_telemetry"
+	kindKey           = "kind"
+	componentKey      = "component"
+)
+
+// RegisterInFlightMetrics registers a gauge reporting the bytes held by every component accounted
+// by the Tracker, and a gauge reporting the limit of the Tracker.
+func RegisterInFlightMetrics(ocRegistry *metric.Registry, mp otelmetric.MeterProvider, useOtel bool, tracker *inflight.Tracker) error {
+	if useOtel {
+		meter := mp.Meter(inFlightScopeName)
+		_, err := meter.Int64ObservableGauge(
+			"inflight_bytes",
+			otelmetric.WithDescription("Bytes of data held by the component"),
+			otelmetric.WithUnit("By"),
+			otelmetric.WithInt64Callback(func(_ context.Context, o otelmetric.Int64Observer) error {
+				for _, c := range tracker.Components() {
+					o.Observe(c.Bytes(), otelmetric.WithAttributes(
+						attribute.String(kindKey, c.Kind().String()),
+						attribute.String(componentKey, c.ID().String())))
+				}
+				return nil
+			}))
+		// ignore instrument name error as per workaround in https://github.com/open-telemetry/opentelemetry-collector/issues/8346
+		if err != nil && !errors.Is(err, sdkmetric.ErrInstrumentName) {
+			return err
+		}
+		_, err = meter.Int64ObservableGauge(
+			"inflight_limit_bytes",
+			otelmetric.WithDescription("Maximum bytes of data held by all the components before refusing data, 0 if unlimited"),
+			otelmetric.WithUnit("By"),
+			otelmetric.WithInt64Callback(func(_ context.Context, o otelmetric.Int64Observer) error {
+				o.Observe(tracker.Limit())
+				return nil
+			}))
+		if err != nil && !errors.Is(err, sdkmetric.ErrInstrumentName) {
+			return err
+		}
+		return nil
+	}
+
+	bytesGauge, err := ocRegistry.AddInt64DerivedGauge(
+		"inflight/bytes",
+		metric.WithDescription("Bytes of data held by the component"),
+		metric.WithUnit(metricdata.UnitBytes),
+		metric.WithLabelKeys(kindKey, componentKey))
+	if err != nil {
+		return err
+	}
+	limitGauge, err := ocRegistry.AddInt64DerivedGauge(
+		"inflight/limit_bytes",
+		metric.WithDescription("Maximum bytes of data held by all the components before refusing data, 0 if unlimited"),
+		metric.WithUnit(metricdata.UnitBytes))
+	if err != nil {
+		return err
+	}
+	if err = limitGauge.UpsertEntry(tracker.Limit); err != nil {
+		return err
+	}
+	tracker.ObserveComponents(func(c *inflight.Component) {
+		// The entries only fail to be added for a wrong number of label values.
+		_ = bytesGauge.UpsertEntry(c.Bytes,
+			metricdata.NewLabelValue(c.Kind().String()),
+			metricdata.NewLabelValue(c.ID().String()))
+	})
+	return nil
+}
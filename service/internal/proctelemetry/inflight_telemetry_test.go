@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package proctelemetry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/inflight"
+)
+
+func TestOtelInFlightTelemetry(t *testing.T) {
+	tel := setupTelemetry(t)
+	tracker := inflight.NewTracker(1024)
+	tracker.Component(component.KindExporter, component.NewID("otlp")).Add(100)
+
+	require.NoError(t, RegisterInFlightMetrics(nil, tel.MeterProvider, true, tracker))
+	tracker.Component(component.KindProcessor, component.NewID("batch")).Add(50)
+
+	mp, err := fetchPrometheusMetrics(tel.promHandler)
+	require.NoError(t, err)
+	bytesMetric, ok := mp["inflight_bytes"]
+	require.True(t, ok)
+	values := map[string]float64{}
+	for _, m := range bytesMetric.Metric {
+		labels := map[string]string{}
+		for _, l := range m.GetLabel() {
+			labels[l.GetName()] = l.GetValue()
+		}
+		values[labels[kindKey]+"/"+labels[componentKey]] = m.GetGauge().GetValue()
+	}
+	assert.Equal(t, map[string]float64{"exporter/otlp": 100, "processor/batch": 50}, values)
+
+	limitMetric, ok := mp["inflight_limit_bytes"]
+	require.True(t, ok)
+	require.Len(t, limitMetric.Metric, 1)
+	assert.Equal(t, float64(1024), limitMetric.Metric[0].GetGauge().GetValue())
+}
+
+func TestOCInFlightTelemetry(t *testing.T) {
+	ocRegistry := metric.NewRegistry()
+	tracker := inflight.NewTracker(1024)
+	tracker.Component(component.KindExporter, component.NewID("otlp")).Add(100)
+
+	require.NoError(t, RegisterInFlightMetrics(ocRegistry, noop.NewMeterProvider(), false, tracker))
+	tracker.Component(component.KindProcessor, component.NewID("batch")).Add(50)
+
+	m := findMetric(ocRegistry.Read(), "inflight/bytes")
+	require.NotNil(t, m)
+	require.Len(t, m.TimeSeries, 2)
+	values := map[string]int64{}
+	for _, ts := range m.TimeSeries {
+		require.Len(t, ts.LabelValues, 2)
+		require.Len(t, ts.Points, 1)
+		values[ts.LabelValues[0].Value+"/"+ts.LabelValues[1].Value] = ts.Points[0].Value.(int64)
+	}
+	assert.Equal(t, map[string]int64{"exporter/otlp": 100, "processor/batch": 50}, values)
+
+	m = findMetric(ocRegistry.Read(), "inflight/limit_bytes")
+	require.NotNil(t, m)
+	require.Len(t, m.TimeSeries, 1)
+	assert.Equal(t, int64(1024), m.TimeSeries[0].Points[0].Value)
+}
+
+func TestInFlightTelemetryFailToRegister(t *testing.T) {
+	ocRegistry := metric.NewRegistry()
+	_, err := ocRegistry.AddFloat64Gauge("inflight/bytes")
+	require.NoError(t, err)
+	assert.Error(t, RegisterInFlightMetrics(ocRegistry, noop.NewMeterProvider(), false, inflight.NewTracker(0)))
+}
@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package proctelemetry // import "go.opentelemetry.io/collector/service/internal/proctelemetry"
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"go.opentelemetry.io/collector/service/telemetry"
+)
+
+const (
+	otlpMetricsPath = "/v1/metrics"
+	otlpTracesPath  = "/v1/traces"
+)
+
+// InitOTLPMetricExporter initializes the metric exporter of an OTLP exporter of the collector's own metrics.
+// The given credentials, if any, authenticate the gRPC requests.
+func InitOTLPMetricExporter(ctx context.Context, cfg telemetry.OTLPExporterConfig, creds credentials.PerRPCCredentials) (sdkmetric.Exporter, error) {
+	if cfg.Protocol == telemetry.OTLPProtocolHTTP {
+		u, tlsCfg, err := otlpHTTPEndpoint(cfg, otlpMetricsPath)
+		if err != nil {
+			return nil, err
+		}
+		opts := []otlpmetrichttp.Option{otlpmetrichttp.WithEndpoint(u.Host), otlpmetrichttp.WithURLPath(u.Path)}
+		if tlsCfg == nil {
+			opts = append(opts, otlpmetrichttp.WithInsecure())
+		} else {
+			opts = append(opts, otlpmetrichttp.WithTLSClientConfig(tlsCfg))
+		}
+		if cfg.Compression == "gzip" {
+			opts = append(opts, otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression))
+		}
+		if cfg.Timeout > 0 {
+			opts = append(opts, otlpmetrichttp.WithTimeout(cfg.Timeout))
+		}
+		if len(cfg.Headers) > 0 {
+			opts = append(opts, otlpmetrichttp.WithHeaders(otlpHeaders(cfg)))
+		}
+		return otlpmetrichttp.New(ctx, opts...)
+	}
+
+	tlsCfg, err := cfg.TLSSetting.LoadTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	opts := []otlpmetricgrpc.Option{otlpmetricgrpc.WithEndpoint(cfg.Endpoint)}
+	if tlsCfg == nil {
+		opts = append(opts, otlpmetricgrpc.WithInsecure())
+	} else {
+		opts = append(opts, otlpmetricgrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg)))
+	}
+	if creds != nil {
+		opts = append(opts, otlpmetricgrpc.WithDialOption(grpc.WithPerRPCCredentials(creds)))
+	}
+	if cfg.Compression == "gzip" {
+		opts = append(opts, otlpmetricgrpc.WithCompressor(cfg.Compression))
+	}
+	if cfg.Timeout > 0 {
+		opts = append(opts, otlpmetricgrpc.WithTimeout(cfg.Timeout))
+	}
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlpmetricgrpc.WithHeaders(otlpHeaders(cfg)))
+	}
+	return otlpmetricgrpc.New(ctx, opts...)
+}
+
+// InitOTLPSpanExporter initializes the span exporter of an OTLP exporter of the collector's own spans.
+// The given credentials, if any, authenticate the gRPC requests.
+func InitOTLPSpanExporter(ctx context.Context, cfg telemetry.OTLPExporterConfig, creds credentials.PerRPCCredentials) (sdktrace.SpanExporter, error) {
+	if cfg.Protocol == telemetry.OTLPProtocolHTTP {
+		u, tlsCfg, err := otlpHTTPEndpoint(cfg, otlpTracesPath)
+		if err != nil {
+			return nil, err
+		}
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(u.Host), otlptracehttp.WithURLPath(u.Path)}
+		if tlsCfg == nil {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		} else {
+			opts = append(opts, otlptracehttp.WithTLSClientConfig(tlsCfg))
+		}
+		if cfg.Compression == "gzip" {
+			opts = append(opts, otlptracehttp.WithCompression(otlptracehttp.GzipCompression))
+		}
+		if cfg.Timeout > 0 {
+			opts = append(opts, otlptracehttp.WithTimeout(cfg.Timeout))
+		}
+		if len(cfg.Headers) > 0 {
+			opts = append(opts, otlptracehttp.WithHeaders(otlpHeaders(cfg)))
+		}
+		return otlptracehttp.New(ctx, opts...)
+	}
+
+	tlsCfg, err := cfg.TLSSetting.LoadTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+	if tlsCfg == nil {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	} else {
+		opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg)))
+	}
+	if creds != nil {
+		opts = append(opts, otlptracegrpc.WithDialOption(grpc.WithPerRPCCredentials(creds)))
+	}
+	if cfg.Compression == "gzip" {
+		opts = append(opts, otlptracegrpc.WithCompressor(cfg.Compression))
+	}
+	if cfg.Timeout > 0 {
+		opts = append(opts, otlptracegrpc.WithTimeout(cfg.Timeout))
+	}
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlptracegrpc.WithHeaders(otlpHeaders(cfg)))
+	}
+	return otlptracegrpc.New(ctx, opts...)
+}
+
+// otlpHTTPEndpoint returns the URL the signal is posted to, and the TLS configuration of the https URLs.
+func otlpHTTPEndpoint(cfg telemetry.OTLPExporterConfig, signalPath string) (*url.URL, *tls.Config, error) {
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid endpoint %q: %w", cfg.Endpoint, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, nil, fmt.Errorf("invalid endpoint %q: the scheme must be http or https", cfg.Endpoint)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + signalPath
+	if u.Scheme == "http" {
+		return u, nil, nil
+	}
+	tlsCfg, err := cfg.TLSSetting.LoadTLSConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	if tlsCfg == nil {
+		tlsCfg = &tls.Config{}
+	}
+	return u, tlsCfg, nil
+}
+
+func otlpHeaders(cfg telemetry.OTLPExporterConfig) map[string]string {
+	headers := make(map[string]string, len(cfg.Headers))
+	for k, v := range cfg.Headers {
+		headers[k] = string(v)
+	}
+	return headers
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package proctelemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/service/telemetry"
+)
+
+func TestOTLPHTTPEndpoint(t *testing.T) {
+	u, tlsCfg, err := otlpHTTPEndpoint(telemetry.OTLPExporterConfig{Endpoint: "http://localhost:4318"}, otlpMetricsPath)
+	require.NoError(t, err)
+	assert.Equal(t, "localhost:4318", u.Host)
+	assert.Equal(t, "/v1/metrics", u.Path)
+	assert.Nil(t, tlsCfg)
+
+	u, tlsCfg, err = otlpHTTPEndpoint(telemetry.OTLPExporterConfig{
+		Endpoint:   "https://otlp.example.com/prefix/",
+		TLSSetting: configtls.TLSClientSetting{ServerName: "otlp"},
+	}, otlpTracesPath)
+	require.NoError(t, err)
+	assert.Equal(t, "/prefix/v1/traces", u.Path)
+	require.NotNil(t, tlsCfg)
+	assert.Equal(t, "otlp", tlsCfg.ServerName)
+
+	_, _, err = otlpHTTPEndpoint(telemetry.OTLPExporterConfig{Endpoint: "localhost:4318"}, otlpMetricsPath)
+	assert.ErrorContains(t, err, "the scheme must be http or https")
+}
+
+func TestInitOTLPExporters(t *testing.T) {
+	for _, protocol := range []string{telemetry.OTLPProtocolGRPC, telemetry.OTLPProtocolHTTP} {
+		t.Run(protocol, func(t *testing.T) {
+			cfg := telemetry.OTLPExporterConfig{
+				Protocol:    protocol,
+				Endpoint:    "localhost:4317",
+				TLSSetting:  configtls.TLSClientSetting{Insecure: true},
+				Compression: "gzip",
+			}
+			if protocol == telemetry.OTLPProtocolHTTP {
+				cfg.Endpoint = "http://localhost:4318"
+			}
+			metricExp, err := InitOTLPMetricExporter(context.Background(), cfg, nil)
+			require.NoError(t, err)
+			assert.NoError(t, metricExp.Shutdown(context.Background()))
+			spanExp, err := InitOTLPSpanExporter(context.Background(), cfg, nil)
+			require.NoError(t, err)
+			assert.NoError(t, spanExp.Shutdown(context.Background()))
+
+			// The TLS settings are loaded when creating the exporters.
+			cfg.TLSSetting = configtls.TLSClientSetting{TLSSetting: configtls.TLSSetting{CAFile: "/nonexistent/ca.pem"}}
+			if protocol == telemetry.OTLPProtocolHTTP {
+				cfg.Endpoint = "https://localhost:4318"
+			}
+			_, err = InitOTLPMetricExporter(context.Background(), cfg, nil)
+			assert.Error(t, err)
+			_, err = InitOTLPSpanExporter(context.Background(), cfg, nil)
+			assert.Error(t, err)
+		})
+	}
+}
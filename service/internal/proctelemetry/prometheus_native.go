@@ -0,0 +1,213 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !otelcol_noprometheus
+
+package proctelemetry // import "go.opentelemetry.io/collector/service/internal/proctelemetry"
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+const (
+	// nativeHistogramMaxScale is the highest schema supported by the Prometheus native histograms,
+	// and nativeHistogramMinScale the lowest one.
+	nativeHistogramMaxScale = 8
+	nativeHistogramMinScale = -4
+)
+
+// nativeHistogramsAggregationSelector aggregates the histograms as exponential histograms,
+// unless their buckets are configured by a view.
+func nativeHistogramsAggregationSelector(kind sdkmetric.InstrumentKind) sdkmetric.Aggregation {
+	if kind == sdkmetric.InstrumentKindHistogram {
+		return sdkmetric.AggregationBase2ExponentialHistogram{MaxSize: 160, MaxScale: nativeHistogramMaxScale, NoMinMax: true}
+	}
+	return sdkmetric.DefaultAggregationSelector(kind)
+}
+
+// nativeHistogramsCollector exposes the exponential histograms collected by the reader as Prometheus native
+// histograms, named as the otel prometheus exporter names the histograms.
+type nativeHistogramsCollector struct {
+	reader sdkmetric.Reader
+}
+
+// Describe implements prometheus.Collector. As the otel prometheus exporter, the collector is unchecked.
+func (c *nativeHistogramsCollector) Describe(chan<- *prometheus.Desc) {}
+
+// Collect implements prometheus.Collector.
+func (c *nativeHistogramsCollector) Collect(ch chan<- prometheus.Metric) {
+	// The metrics are cumulative, collecting them again from the reader used by the otel prometheus exporter
+	// does not change them.
+	rm := metricdata.ResourceMetrics{}
+	if err := c.reader.Collect(context.Background(), &rm); err != nil {
+		otel.Handle(err)
+		return
+	}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.ExponentialHistogram[int64]:
+				addNativeHistograms(ch, m, data)
+			case metricdata.ExponentialHistogram[float64]:
+				addNativeHistograms(ch, m, data)
+			}
+		}
+	}
+}
+
+func addNativeHistograms[N int64 | float64](ch chan<- prometheus.Metric, m metricdata.Metrics, data metricdata.ExponentialHistogram[N]) {
+	for _, dp := range data.DataPoints {
+		keys, values := prometheusLabels(dp.Attributes)
+		desc := prometheus.NewDesc(sanitizePrometheusName(m.Name), m.Description, keys, nil)
+		ch <- &nativeHistogram{
+			desc:   desc,
+			labels: prometheus.MakeLabelPairs(desc, values),
+			count:  dp.Count,
+			sum:    float64(dp.Sum),
+			scale:  dp.Scale,
+			zero:   dp.ZeroCount,
+			pos:    dp.PositiveBucket,
+			neg:    dp.NegativeBucket,
+		}
+	}
+}
+
+// nativeHistogram is a prometheus.Metric exposing an exponential histogram data point as a native histogram.
+type nativeHistogram struct {
+	desc   *prometheus.Desc
+	labels []*dto.LabelPair
+	count  uint64
+	sum    float64
+	scale  int32
+	zero   uint64
+	pos    metricdata.ExponentialBucket
+	neg    metricdata.ExponentialBucket
+}
+
+func (h *nativeHistogram) Desc() *prometheus.Desc {
+	return h.desc
+}
+
+func (h *nativeHistogram) Write(out *dto.Metric) error {
+	// The buckets are merged if the scale is lower than supported by Prometheus.
+	schema, scaleDown := h.scale, int32(0)
+	if schema < nativeHistogramMinScale {
+		schema, scaleDown = nativeHistogramMinScale, nativeHistogramMinScale-h.scale
+	}
+	// The zero bucket only counts the zero values, its threshold is set so that the histogram is
+	// detected as a native histogram even without any value.
+	zeroThreshold := prometheus.DefNativeHistogramZeroThreshold
+	his := &dto.Histogram{
+		SampleCount:   &h.count,
+		SampleSum:     &h.sum,
+		Schema:        &schema,
+		ZeroThreshold: &zeroThreshold,
+		ZeroCount:     &h.zero,
+	}
+	his.PositiveSpan, his.PositiveDelta = nativeBuckets(h.pos, scaleDown)
+	his.NegativeSpan, his.NegativeDelta = nativeBuckets(h.neg, scaleDown)
+	out.Histogram = his
+	out.Label = h.labels
+	return nil
+}
+
+// nativeBuckets converts the exponential buckets into the spans and the delta encoded counts of the
+// buckets of a native histogram, merging the buckets 2^scaleDown by 2^scaleDown. The bucket i of an
+// exponential histogram is (base^i, base^(i+1)], the bucket i+1 of a native histogram.
+func nativeBuckets(bucket metricdata.ExponentialBucket, scaleDown int32) ([]*dto.BucketSpan, []int64) {
+	var (
+		spans     []*dto.BucketSpan
+		deltas    []int64
+		counts    []int64
+		lastIndex int32
+	)
+	for i, count := range bucket.Counts {
+		if count == 0 {
+			continue
+		}
+		index := ((bucket.Offset + int32(i)) >> scaleDown) + 1
+		switch {
+		case len(counts) > 0 && index == lastIndex:
+			counts[len(counts)-1] += int64(count)
+			continue
+		case len(spans) == 0:
+			spans = append(spans, &dto.BucketSpan{Offset: int32Ptr(index), Length: uint32Ptr(1)})
+		case index == lastIndex+1:
+			*spans[len(spans)-1].Length++
+		default:
+			spans = append(spans, &dto.BucketSpan{Offset: int32Ptr(index - lastIndex - 1), Length: uint32Ptr(1)})
+		}
+		counts = append(counts, int64(count))
+		lastIndex = index
+	}
+	var prev int64
+	for _, count := range counts {
+		deltas = append(deltas, count-prev)
+		prev = count
+	}
+	return spans, deltas
+}
+
+// prometheusLabels returns the label names and values of the attributes, sanitized as the otel prometheus
+// exporter does.
+func prometheusLabels(attrs attribute.Set) ([]string, []string) {
+	keys := make([]string, 0, attrs.Len())
+	values := make([]string, 0, attrs.Len())
+	index := map[string]int{}
+	for it := attrs.Iter(); it.Next(); {
+		kv := it.Attribute()
+		key := sanitizePrometheusLabel(string(kv.Key))
+		if i, ok := index[key]; ok {
+			// Different attributes with the same sanitized key.
+			values[i] += ";" + kv.Value.Emit()
+			continue
+		}
+		index[key] = len(keys)
+		keys = append(keys, key)
+		values = append(values, kv.Value.Emit())
+	}
+	return keys, values
+}
+
+func sanitizePrometheusLabel(key string) string {
+	key = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return '_'
+	}, key)
+	if key != "" && unicode.IsDigit(rune(key[0])) {
+		key = "key_" + key
+	}
+	return key
+}
+
+func sanitizePrometheusName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' || r == ':' {
+			return r
+		}
+		return '_'
+	}, name)
+	if name != "" && name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func uint32Ptr(i uint32) *uint32 {
+	return &i
+}
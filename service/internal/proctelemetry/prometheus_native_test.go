@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !otelcol_noprometheus
+
+package proctelemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+func TestNativeHistogramsCollector(t *testing.T) {
+	reader := sdkmetric.NewManualReader(sdkmetric.WithAggregationSelector(nativeHistogramsAggregationSelector))
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	hist, err := mp.Meter("test").Float64Histogram("http.server.duration", metric.WithDescription("Duration of the requests"))
+	require.NoError(t, err)
+	for _, v := range []float64{0, 1, 2, 2, 4} {
+		hist.Record(context.Background(), v, metric.WithAttributes(attribute.String("http.method", "POST")))
+	}
+	counter, err := mp.Meter("test").Int64Counter("requests")
+	require.NoError(t, err)
+	counter.Add(context.Background(), 1)
+
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(&nativeHistogramsCollector{reader: reader}))
+	mfs, err := registry.Gather()
+	require.NoError(t, err)
+
+	// Only the exponential histograms are exposed.
+	require.Len(t, mfs, 1)
+	assert.Equal(t, "http_server_duration", mfs[0].GetName())
+	assert.Equal(t, "Duration of the requests", mfs[0].GetHelp())
+	require.Len(t, mfs[0].Metric, 1)
+	m := mfs[0].Metric[0]
+	require.Len(t, m.Label, 1)
+	assert.Equal(t, "http_method", m.Label[0].GetName())
+	assert.Equal(t, "POST", m.Label[0].GetValue())
+
+	h := m.GetHistogram()
+	assert.Equal(t, uint64(5), h.GetSampleCount())
+	assert.Equal(t, 9.0, h.GetSampleSum())
+	assert.Equal(t, uint64(1), h.GetZeroCount())
+	assert.Positive(t, h.GetZeroThreshold())
+	assert.LessOrEqual(t, h.GetSchema(), int32(nativeHistogramMaxScale))
+
+	// The values 1, 2 and 4 are the upper bounds of their buckets.
+	var total int64
+	var count int64
+	for _, d := range h.GetPositiveDelta() {
+		count += d
+		total += count
+	}
+	assert.Equal(t, int64(4), total)
+}
+
+func TestNativeBuckets(t *testing.T) {
+	spans, deltas := nativeBuckets(metricdata.ExponentialBucket{Offset: -1, Counts: []uint64{1, 2, 0, 0, 3, 1}}, 0)
+	assert.Equal(t, []*dto.BucketSpan{
+		{Offset: int32Ptr(0), Length: uint32Ptr(2)},
+		{Offset: int32Ptr(2), Length: uint32Ptr(2)},
+	}, spans)
+	assert.Equal(t, []int64{1, 1, 1, -2}, deltas)
+
+	// Scaling down by one merges the buckets two by two: (0, 1), (2, 3) and (4, 5).
+	spans, deltas = nativeBuckets(metricdata.ExponentialBucket{Offset: 0, Counts: []uint64{1, 2, 0, 0, 3, 1}}, 1)
+	assert.Equal(t, []*dto.BucketSpan{
+		{Offset: int32Ptr(1), Length: uint32Ptr(1)},
+		{Offset: int32Ptr(1), Length: uint32Ptr(1)},
+	}, spans)
+	assert.Equal(t, []int64{3, 1}, deltas)
+
+	spans, deltas = nativeBuckets(metricdata.ExponentialBucket{}, 0)
+	assert.Empty(t, spans)
+	assert.Empty(t, deltas)
+}
+
+func TestSplitResourceLabels(t *testing.T) {
+	res := resource.NewSchemaless(
+		attribute.String("service.name", "otelcol"),
+		attribute.String("service.instance.id", "1234"),
+		attribute.String("host.name", "localhost"),
+	)
+	labels, others := splitResourceLabels(res, []string{"service.instance.id", "missing"})
+	assert.Equal(t, prometheus.Labels{"service_instance_id": "1234"}, labels)
+	assert.Equal(t, prometheus.Labels{"service_name": "otelcol", "host_name": "localhost"}, others)
+}
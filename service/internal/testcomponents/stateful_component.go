@@ -5,29 +5,31 @@ package testcomponents // import "go.opentelemetry.io/collector/service/internal
 
 import (
 	"context"
+	"sync/atomic"
 
 	"go.opentelemetry.io/collector/component"
 )
 
+// componentState is safe for concurrent use, the components being started concurrently.
 type componentState struct {
-	started bool
-	stopped bool
+	started atomic.Bool
+	stopped atomic.Bool
 }
 
 func (cs *componentState) Started() bool {
-	return cs.started
+	return cs.started.Load()
 }
 
 func (cs *componentState) Stopped() bool {
-	return cs.stopped
+	return cs.stopped.Load()
 }
 
 func (cs *componentState) Start(_ context.Context, _ component.Host) error {
-	cs.started = true
+	cs.started.Store(true)
 	return nil
 }
 
 func (cs *componentState) Shutdown(_ context.Context) error {
-	cs.stopped = true
+	cs.stopped.Store(true)
 	return nil
 }
@@ -21,10 +21,22 @@ var (
 	componentHeaderBytes    []byte
 	componentHeaderTemplate = parseTemplate("component_header", componentHeaderBytes)
 
+	//go:embed templates/exporters_table.html
+	exportersTableBytes    []byte
+	exportersTableTemplate = parseTemplate("exporters_table", exportersTableBytes)
+
 	//go:embed templates/extensions_table.html
 	extensionsTableBytes    []byte
 	extensionsTableTemplate = parseTemplate("extensions_table", extensionsTableBytes)
 
+	//go:embed templates/flush_form.html
+	flushFormBytes    []byte
+	flushFormTemplate = parseTemplate("flush_form", flushFormBytes)
+
+	//go:embed templates/scrape_form.html
+	scrapeFormBytes    []byte
+	scrapeFormTemplate = parseTemplate("scrape_form", scrapeFormBytes)
+
 	//go:embed templates/page_header.html
 	headerBytes    []byte
 	headerTemplate = parseTemplate("header", headerBytes)
@@ -44,6 +56,10 @@ var (
 	//go:embed templates/features_table.html
 	featuresTableBytes    []byte
 	featuresTableTemplate = parseTemplate("features_table", featuresTableBytes)
+
+	//go:embed templates/failures_table.html
+	failuresTableBytes    []byte
+	failuresTableTemplate = parseTemplate("failures_table", failuresTableBytes)
 )
 
 func parseTemplate(name string, bytes []byte) *template.Template {
@@ -104,6 +120,62 @@ func WriteHTMLPipelinesSummaryTable(w io.Writer, spd SummaryPipelinesTableData)
 	}
 }
 
+// SummaryExportersTableData contains data for exporters summary table template.
+type SummaryExportersTableData struct {
+	Rows []SummaryExportersTableRowData
+}
+
+// SummaryExportersTableRowData contains data for one row in exporters summary table template.
+type SummaryExportersTableRowData struct {
+	FullName       string
+	InputType      string
+	Queue          string
+	OldestItemAge  string
+	Consumers      string
+	Retry          string
+	LastRetryError string
+}
+
+// WriteHTMLExportersSummaryTable writes the summary table of the sending queue and retries of the exporters.
+// Id does not write the header or footer.
+func WriteHTMLExportersSummaryTable(w io.Writer, sed SummaryExportersTableData) {
+	if err := exportersTableTemplate.Execute(w, sed); err != nil {
+		log.Printf("zpages: executing template: %v", err)
+	}
+}
+
+// FlushFormData contains data for the flush form template.
+type FlushFormData struct {
+	// Enabled is false if no control token is configured.
+	Enabled bool
+	// Result is the outcome of the last flush, if any.
+	Result string
+}
+
+// WriteHTMLFlushForm writes the form to flush the pipelines.
+func WriteHTMLFlushForm(w io.Writer, ffd FlushFormData) {
+	if err := flushFormTemplate.Execute(w, ffd); err != nil {
+		log.Printf("zpages: executing template: %v", err)
+	}
+}
+
+// ScrapeFormData contains data for the scrape form template.
+type ScrapeFormData struct {
+	// Enabled is false if no control token is configured.
+	Enabled bool
+	// Receivers are the receivers able to scrape on demand.
+	Receivers []string
+	// Result is the outcome of the last scrape, if any.
+	Result string
+}
+
+// WriteHTMLScrapeForm writes the forms to make the receivers scrape on demand.
+func WriteHTMLScrapeForm(w io.Writer, sfd ScrapeFormData) {
+	if err := scrapeFormTemplate.Execute(w, sfd); err != nil {
+		log.Printf("zpages: executing template: %v", err)
+	}
+}
+
 // ComponentHeaderData contains data for component header template.
 type ComponentHeaderData struct {
 	Name              string
@@ -153,17 +225,24 @@ func getValue(row [2]string) string {
 // FeatureGateTableData contains data for feature gate table template.
 type FeatureGateTableData struct {
 	Rows []FeatureGateTableRowData
+	// ControlEnabled is false if no control token is configured.
+	ControlEnabled bool
+	// Result is the outcome of the last toggle, if any.
+	Result string
 }
 
 // FeatureGateTableRowData contains data for one row in feature gate table template.
 type FeatureGateTableRowData struct {
-	ID           string
-	Enabled      bool
-	Description  string
-	Stage        string
-	FromVersion  string
-	ToVersion    string
-	ReferenceURL string
+	ID               string
+	Enabled          bool
+	RuntimeTogglable bool
+	Source           string
+	Description      string
+	Stage            string
+	Owner            string
+	FromVersion      string
+	ToVersion        string
+	ReferenceURL     string
 }
 
 // WriteHTMLFeaturesTable writes a table summarizing registered feature gates.
@@ -172,3 +251,27 @@ func WriteHTMLFeaturesTable(w io.Writer, ftd FeatureGateTableData) {
 		log.Printf("zpages: executing template: %v", err)
 	}
 }
+
+// FailuresTableData contains data for the table of the recent failed operations.
+type FailuresTableData struct {
+	Rows []FailuresTableRowData
+}
+
+// FailuresTableRowData contains data for one failed operation in the failures table template.
+type FailuresTableRowData struct {
+	Time     string
+	Kind     string
+	ID       string
+	DataType string
+	Items    int
+	Error    string
+	TraceID  string
+	SpanID   string
+}
+
+// WriteHTMLFailuresTable writes a table of the recent failed operations of the components.
+func WriteHTMLFailuresTable(w io.Writer, ftd FailuresTableData) {
+	if err := failuresTableTemplate.Execute(w, ftd); err != nil {
+		log.Printf("zpages: executing template: %v", err)
+	}
+}
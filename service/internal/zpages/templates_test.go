@@ -59,6 +59,19 @@ func TestNoCrash(t *testing.T) {
 			}},
 		})
 	})
+	assert.NotPanics(t, func() {
+		WriteHTMLExportersSummaryTable(buf, SummaryExportersTableData{
+			Rows: []SummaryExportersTableRowData{{
+				FullName:  "otlp",
+				InputType: "traces",
+				Queue:     "10 / 1000",
+			}},
+		})
+	})
+	assert.NotPanics(t, func() { WriteHTMLFlushForm(buf, FlushFormData{Enabled: true, Result: "Flushed"}) })
+	assert.NotPanics(t, func() {
+		WriteHTMLScrapeForm(buf, ScrapeFormData{Enabled: true, Receivers: []string{"hostmetrics"}, Result: "Scraped"})
+	})
 	assert.NotPanics(t, func() {
 		WriteHTMLExtensionsSummaryTable(buf, SummaryExtensionsTableData{
 			Rows: []SummaryExtensionsTableRowData{{
@@ -74,10 +87,30 @@ func TestNoCrash(t *testing.T) {
 			{
 				ID:          "test",
 				Enabled:     false,
+				Source:      "default",
 				Description: "test gate",
+				Owner:       "receiver.test",
+			},
+			{
+				ID:               "test.runtime",
+				Enabled:          true,
+				RuntimeTogglable: true,
+			},
+		}, ControlEnabled: true, Result: "Disabled test.runtime."})
+	})
+	assert.NotPanics(t, func() {
+		WriteHTMLFailuresTable(buf, FailuresTableData{Rows: []FailuresTableRowData{
+			{
+				Time:     "2023-09-20T10:00:00Z",
+				Kind:     "exporter",
+				ID:       "otlp",
+				DataType: "traces",
+				Items:    10,
+				Error:    "connection refused",
 			},
 		}})
 	})
+	assert.NotPanics(t, func() { WriteHTMLFailuresTable(buf, FailuresTableData{}) })
 	assert.NotPanics(t, func() { WriteHTMLPageFooter(buf) })
 	assert.NotPanics(t, func() { WriteHTMLPageFooter(buf) })
 }
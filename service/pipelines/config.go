@@ -20,6 +20,12 @@ var (
 type Config map[component.ID]*PipelineConfig
 
 func (cfg Config) Validate() error {
+	return cfg.ValidateWithInternal()
+}
+
+// ValidateWithInternal validates the configuration as Validate does, except that the given internal
+// pipelines, fed by the collector itself, e.g. with its own telemetry, are not required to have a receiver.
+func (cfg Config) ValidateWithInternal(internalIDs ...component.ID) error {
 	// Must have at least one pipeline.
 	if len(cfg) == 0 {
 		return errMissingServicePipelines
@@ -32,8 +38,7 @@ func (cfg Config) Validate() error {
 			return fmt.Errorf("pipeline %q: unknown datatype %q", pipelineID, pipelineID.Type())
 		}
 
-		// Validate pipeline has at least one receiver.
-		if err := pipeline.Validate(); err != nil {
+		if err := pipeline.validate(!isInternal(pipelineID, internalIDs)); err != nil {
 			return fmt.Errorf("pipeline %q: %w", pipelineID, err)
 		}
 	}
@@ -41,6 +46,15 @@ func (cfg Config) Validate() error {
 	return nil
 }
 
+func isInternal(pipelineID component.ID, internalIDs []component.ID) bool {
+	for _, id := range internalIDs {
+		if id == pipelineID {
+			return true
+		}
+	}
+	return false
+}
+
 // PipelineConfig defines the configuration of a Pipeline.
 type PipelineConfig struct {
 	Receivers  []component.ID `mapstructure:"receivers"`
@@ -49,8 +63,12 @@ type PipelineConfig struct {
 }
 
 func (cfg *PipelineConfig) Validate() error {
+	return cfg.validate(true)
+}
+
+func (cfg *PipelineConfig) validate(requireReceivers bool) error {
 	// Validate pipeline has at least one receiver.
-	if len(cfg.Receivers) == 0 {
+	if requireReceivers && len(cfg.Receivers) == 0 {
 		return errMissingServicePipelineReceivers
 	}
 
@@ -5,8 +5,11 @@ package service // import "go.opentelemetry.io/collector/service"
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"reflect"
 	"runtime"
+	"time"
 
 	"github.com/google/uuid"
 	"go.opentelemetry.io/otel/attribute"
@@ -21,6 +24,8 @@ import (
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/inflight"
 	"go.opentelemetry.io/collector/internal/obsreportconfig"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/processor"
@@ -32,6 +37,9 @@ import (
 	"go.opentelemetry.io/collector/service/telemetry"
 )
 
+// drainPollInterval is the interval at which Drain checks whether the data in flight is sent.
+const drainPollInterval = 100 * time.Millisecond
+
 // Settings holds configuration for building a new service.
 type Settings struct {
 	// BuildInfo provides collector start information.
@@ -61,6 +69,21 @@ type Settings struct {
 	// LoggingOptions provides a way to change behavior of zap logging.
 	LoggingOptions []zap.Option
 
+	// ControlToken is the token authenticating the control actions of the zPages,
+	// like flushing the pipelines. The control actions are disabled if empty.
+	ControlToken string
+
+	// SetFeatureGate toggles a runtime togglable feature gate from the zPages, like the control API of
+	// the collector. The gate is toggled in the global registry if nil.
+	SetFeatureGate func(id string, enabled bool) error
+
+	// IngestionPaused starts the service with the ingestion paused, see Service.PauseIngestion.
+	IngestionPaused bool
+
+	// ReportComponentStatus is called with the status changes of the component instances, like their
+	// start and their fatal errors. It is called concurrently by the components started concurrently.
+	ReportComponentStatus func(component.StatusEvent)
+
 	// For testing purpose only.
 	useOtel *bool
 }
@@ -73,6 +96,8 @@ type Service struct {
 	host                 *serviceHost
 	telemetryInitializer *telemetryInitializer
 	collectorConf        *confmap.Conf
+	pipelinesSettings    graph.Settings
+	crashLoop            *crashLoopProtection
 }
 
 func New(ctx context.Context, set Settings, cfg Config) (*Service, error) {
@@ -92,10 +117,16 @@ func New(ctx context.Context, set Settings, cfg Config) (*Service, error) {
 			extensions:        set.Extensions,
 			buildInfo:         set.BuildInfo,
 			asyncErrorChannel: set.AsyncErrorChannel,
+			controlToken:      set.ControlToken,
+			setFeatureGate:    set.SetFeatureGate,
+			reportStatus:      set.ReportComponentStatus,
 		},
 		telemetryInitializer: newColTelemetry(useOtel, disableHighCard, extendedConfig),
 		collectorConf:        set.CollectorConf,
 	}
+	if cfg.InFlight.Enabled {
+		srv.host.inflight = inflight.NewTracker(int64(cfg.InFlight.LimitMiB) * 1024 * 1024)
+	}
 	var err error
 	srv.telemetry, err = telemetry.New(ctx, telemetry.Settings{ZapOptions: set.LoggingOptions}, cfg.Telemetry)
 	if err != nil {
@@ -143,6 +174,15 @@ func (srv *Service) Start(ctx context.Context) error {
 	if err := srv.host.serviceExtensions.Start(ctx, srv.host); err != nil {
 		return fmt.Errorf("failed to start extensions: %w", err)
 	}
+	if err := srv.telemetryInitializer.startOTLP(srv.host.GetExtensions()); err != nil {
+		return err
+	}
+
+	if srv.crashLoop != nil {
+		if err := srv.applyCrashLoopProtection(ctx); err != nil {
+			return err
+		}
+	}
 
 	if srv.collectorConf != nil {
 		if err := srv.host.serviceExtensions.NotifyConfig(ctx, srv.collectorConf); err != nil {
@@ -151,8 +191,15 @@ func (srv *Service) Start(ctx context.Context) error {
 	}
 
 	if err := srv.host.pipelines.StartAll(ctx, srv.host); err != nil {
+		if srv.crashLoop != nil {
+			srv.crashLoop.recordFailure(ctx, err)
+		}
 		return fmt.Errorf("cannot start pipelines: %w", err)
 	}
+	if srv.crashLoop != nil {
+		srv.crashLoop.recordSuccess(ctx)
+	}
+	srv.telemetryInitializer.startPipeline(srv.host.pipelines)
 
 	if err := srv.host.serviceExtensions.NotifyPipelineReady(); err != nil {
 		return err
@@ -173,10 +220,20 @@ func (srv *Service) Shutdown(ctx context.Context) error {
 		errs = multierr.Append(errs, fmt.Errorf("failed to notify that pipeline is not ready: %w", err))
 	}
 
+	srv.telemetryInitializer.stopPipeline()
 	if err := srv.host.pipelines.ShutdownAll(ctx); err != nil {
 		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown pipelines: %w", err))
 	}
 
+	if srv.crashLoop != nil {
+		if err := srv.crashLoop.shutdown(ctx); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("failed to close the crash loop protection storage: %w", err))
+		}
+	}
+
+	if err := srv.telemetryInitializer.stopOTLP(); err != nil {
+		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown the telemetry exporters: %w", err))
+	}
 	if err := srv.host.serviceExtensions.Shutdown(ctx); err != nil {
 		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown extensions: %w", err))
 	}
@@ -193,12 +250,170 @@ func (srv *Service) Shutdown(ctx context.Context) error {
 	return errs
 }
 
+// Flush sends immediately the data pending in the processors and the exporters implementing
+// Flush, like the batch processor and the exporters retrying failed requests.
+func (srv *Service) Flush(ctx context.Context) error {
+	srv.telemetrySettings.Logger.Info("Flushing pipelines...")
+	if err := srv.host.pipelines.FlushAll(ctx); err != nil {
+		return fmt.Errorf("failed to flush pipelines: %w", err)
+	}
+	return nil
+}
+
+// ScrapableReceivers returns the IDs of the receivers able to scrape on demand, like the receivers
+// created with the scraperhelper.
+func (srv *Service) ScrapableReceivers() []component.ID {
+	return srv.host.pipelines.ScrapableReceivers()
+}
+
+// ScrapeNow makes the given receiver scrape immediately, in addition to its scheduled scrapes, and
+// waits until the scraped data is passed to the pipelines.
+func (srv *Service) ScrapeNow(ctx context.Context, id component.ID) error {
+	srv.telemetrySettings.Logger.Info("Scraping on demand...", zap.Stringer("receiver", id))
+	return srv.host.pipelines.ScrapeNow(ctx, id)
+}
+
+// PauseIngestion rejects the data received by the receivers with a retryable error until ResumeIngestion
+// is called. The data already received keeps flowing through the pipelines.
+func (srv *Service) PauseIngestion() {
+	srv.telemetrySettings.Logger.Info("Pausing ingestion...")
+	srv.host.pipelines.PauseIngestion()
+}
+
+// ResumeIngestion accepts again the data received by the receivers.
+func (srv *Service) ResumeIngestion() {
+	srv.telemetrySettings.Logger.Info("Resuming ingestion...")
+	srv.host.pipelines.ResumeIngestion()
+}
+
+// IngestionPaused returns whether the ingestion is paused.
+func (srv *Service) IngestionPaused() bool {
+	return srv.host.pipelines.IngestionPaused()
+}
+
+// Drain pauses the ingestion and flushes the pipelines. If the data in flight is accounted, see Config.InFlight,
+// Drain then waits until no data is held by the components anymore, or until ctx is done.
+func (srv *Service) Drain(ctx context.Context) error {
+	srv.PauseIngestion()
+	if err := srv.Flush(ctx); err != nil {
+		return err
+	}
+	if srv.host.inflight == nil {
+		return nil
+	}
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+	for srv.host.inflight.Bytes() > 0 {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to drain the pipelines, %d bytes still in flight: %w", srv.host.inflight.Bytes(), ctx.Err())
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+// Reload applies the changes of the pipelines and of their components, keeping running the components
+// whose configuration is unchanged, used by the same pipelines, and sending the data to components kept as well.
+// The receivers kept pass the data they receive to the new pipelines, the removed components are flushed and shut
+// down once the new ones are started, see ReloadChangedComponents.
+//
+// Reload fails without changing the service if other parts of the configuration changed, as these changes
+// require to restart the service. Once the new components are started, the service must be shut down and
+// created again if Reload fails.
+func (srv *Service) Reload(ctx context.Context, set Settings, cfg Config) error {
+	if srv.host.disabledComponent != nil {
+		return errors.New("a component is disabled by the crash loop protection")
+	}
+	if srv.collectorConf == nil || set.CollectorConf == nil {
+		return errors.New("the resolved configuration is not available")
+	}
+	prevConf, conf := srv.collectorConf.ToStringMap(), set.CollectorConf.ToStringMap()
+	if !reflect.DeepEqual(withoutPipelines(prevConf), withoutPipelines(conf)) {
+		return errors.New("the configuration changed beyond the pipelines and their components")
+	}
+	unchanged := func(kind component.Kind, id component.ID) bool {
+		return reflect.DeepEqual(componentConf(prevConf, kind, id), componentConf(conf, kind, id))
+	}
+
+	pSet := srv.pipelinesSettings
+	pSet.ReceiverBuilder = set.Receivers
+	pSet.ProcessorBuilder = set.Processors
+	pSet.ExporterBuilder = set.Exporters
+	pSet.ConnectorBuilder = set.Connectors
+	pSet.PipelineConfigs = cfg.Pipelines
+	pSet.IngestionPaused = srv.IngestionPaused()
+	pipes, err := graph.Rebuild(ctx, pSet, srv.host.pipelines, unchanged)
+	if err != nil {
+		return fmt.Errorf("failed to build pipelines: %w", err)
+	}
+
+	srv.telemetrySettings.Logger.Info("Reloading the changed components...")
+	srv.telemetryInitializer.stopPipeline()
+	prev := srv.host.pipelines
+	srv.host.receivers = set.Receivers
+	srv.host.processors = set.Processors
+	srv.host.exporters = set.Exporters
+	srv.host.connectors = set.Connectors
+	srv.host.pipelines = pipes
+	srv.pipelinesSettings = pSet
+	srv.collectorConf = set.CollectorConf
+	err = pipes.StartReplacing(ctx, srv.host, prev)
+	srv.telemetryInitializer.startPipeline(pipes)
+	if err != nil {
+		return fmt.Errorf("cannot start pipelines: %w", err)
+	}
+	return srv.host.serviceExtensions.NotifyConfig(ctx, srv.collectorConf)
+}
+
+// pipelineKeys are the sections of the configuration whose changes are applied by Service.Reload.
+var pipelineKeys = []string{"receivers", "processors", "exporters", "connectors"}
+
+// withoutPipelines returns the configuration without the pipelines and their components.
+func withoutPipelines(conf map[string]any) map[string]any {
+	out := make(map[string]any, len(conf))
+	for k, v := range conf {
+		out[k] = v
+	}
+	for _, k := range pipelineKeys {
+		delete(out, k)
+	}
+	if svc, ok := conf["service"].(map[string]any); ok {
+		outSvc := make(map[string]any, len(svc))
+		for k, v := range svc {
+			outSvc[k] = v
+		}
+		delete(outSvc, "pipelines")
+		out["service"] = outSvc
+	}
+	return out
+}
+
+// componentConf returns the configuration of the component, nil if not configured.
+func componentConf(conf map[string]any, kind component.Kind, id component.ID) any {
+	var key string
+	switch kind {
+	case component.KindReceiver:
+		key = "receivers"
+	case component.KindProcessor:
+		key = "processors"
+	case component.KindExporter:
+		key = "exporters"
+	case component.KindConnector:
+		key = "connectors"
+	}
+	section, _ := conf[key].(map[string]any)
+	return section[id.String()]
+}
+
 func (srv *Service) initExtensionsAndPipeline(ctx context.Context, set Settings, cfg Config) error {
 	var err error
 	extensionsSettings := extensions.Settings{
 		Telemetry:  srv.telemetrySettings,
 		BuildInfo:  srv.buildInfo,
 		Extensions: srv.host.extensions,
+
+		ReportStatus: srv.host.reportStatus,
 	}
 	if srv.host.serviceExtensions, err = extensions.New(ctx, extensionsSettings, cfg.Extensions); err != nil {
 		return fmt.Errorf("failed to build extensions: %w", err)
@@ -212,19 +427,76 @@ func (srv *Service) initExtensionsAndPipeline(ctx context.Context, set Settings,
 		ExporterBuilder:  set.Exporters,
 		ConnectorBuilder: set.Connectors,
 		PipelineConfigs:  cfg.Pipelines,
+		StartTimeout:     cfg.StartTimeout,
+
+		// The panics are recorded as start failures by the crash loop protection.
+		RecoverStartPanics: cfg.CrashLoopProtection.Storage != nil,
+
+		IngestionPaused: set.IngestionPaused,
+
+		BackpressureWatermark: cfg.BackpressureWatermark,
+		EdgeTracker:           graph.NewEdgeTracker(),
+
+		ReportStatus: srv.host.reportStatus,
 	}
 
 	if srv.host.pipelines, err = graph.Build(ctx, pSet); err != nil {
 		return fmt.Errorf("failed to build pipelines: %w", err)
 	}
+	srv.pipelinesSettings = pSet
+	srv.crashLoop = newCrashLoopProtection(cfg.CrashLoopProtection, set.CollectorConf, srv.telemetrySettings.Logger)
 
-	if cfg.Telemetry.Metrics.Level != configtelemetry.LevelNone && cfg.Telemetry.Metrics.Address != "" {
+	if cfg.Telemetry.Metrics.Level != configtelemetry.LevelNone && (cfg.Telemetry.Metrics.Address != "" || cfg.Telemetry.Metrics.Pipeline != nil) {
 		// The process telemetry initialization requires the ballast size, which is available after the extensions are initialized.
 		if err = proctelemetry.RegisterProcessMetrics(srv.telemetryInitializer.ocRegistry, srv.telemetryInitializer.mp, obsreportconfig.UseOtelForInternalMetricsfeatureGate.IsEnabled(), getBallastSize(srv.host)); err != nil {
 			return fmt.Errorf("failed to register process metrics: %w", err)
 		}
+		if err = proctelemetry.RegisterFeatureGateMetrics(srv.telemetryInitializer.ocRegistry, srv.telemetryInitializer.mp, obsreportconfig.UseOtelForInternalMetricsfeatureGate.IsEnabled(), featuregate.GlobalRegistry()); err != nil {
+			return fmt.Errorf("failed to register feature gate metrics: %w", err)
+		}
+		if srv.host.inflight != nil {
+			if err = proctelemetry.RegisterInFlightMetrics(srv.telemetryInitializer.ocRegistry, srv.telemetryInitializer.mp, obsreportconfig.UseOtelForInternalMetricsfeatureGate.IsEnabled(), srv.host.inflight); err != nil {
+				return fmt.Errorf("failed to register in-flight metrics: %w", err)
+			}
+		}
+		if err = proctelemetry.RegisterEdgeMetrics(srv.telemetryInitializer.ocRegistry, srv.telemetryInitializer.mp, obsreportconfig.UseOtelForInternalMetricsfeatureGate.IsEnabled(), pSet.EdgeTracker); err != nil {
+			return fmt.Errorf("failed to register pipeline edge metrics: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// applyCrashLoopProtection rebuilds the pipelines without the component which failed to start too many
+// times in a row with the same configuration, starting the service in a degraded mode.
+func (srv *Service) applyCrashLoopProtection(ctx context.Context) error {
+	if err := srv.crashLoop.load(ctx, srv.host); err != nil {
+		return fmt.Errorf("failed to load the crash loop protection state: %w", err)
+	}
+	kind, id, ok := srv.crashLoop.disable()
+	if !ok {
+		return nil
 	}
+	failure := srv.crashLoop.disabled
+	srv.telemetrySettings.Logger.Error("Component failed to start too many times with the same configuration, starting without it",
+		zap.String("kind", failure.Kind),
+		zap.String("id", failure.ID),
+		zap.Int("failures", failure.Count),
+		zap.String("error", failure.Error),
+	)
 
+	// The components of the pipelines are built but not started yet.
+	if err := srv.host.pipelines.ShutdownAll(ctx); err != nil {
+		srv.telemetrySettings.Logger.Warn("Failed to shutdown the pipelines before rebuilding them", zap.Error(err))
+	}
+	pSet := srv.pipelinesSettings
+	pSet.PipelineConfigs = withoutComponent(pSet.PipelineConfigs, kind, id, pSet.ConnectorBuilder)
+	pipes, err := graph.Build(ctx, pSet)
+	if err != nil {
+		return fmt.Errorf("failed to build pipelines without %s %q: %w", failure.Kind, failure.ID, err)
+	}
+	srv.host.pipelines = pipes
+	srv.host.disabledComponent = failure
 	return nil
 }
 
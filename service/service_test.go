@@ -28,6 +28,7 @@ import (
 	"go.opentelemetry.io/collector/extension"
 	"go.opentelemetry.io/collector/extension/extensiontest"
 	"go.opentelemetry.io/collector/extension/zpagesextension"
+	"go.opentelemetry.io/collector/inflight"
 	"go.opentelemetry.io/collector/internal/testutil"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/processor/processortest"
@@ -212,6 +213,60 @@ func TestServiceGetExtensions(t *testing.T) {
 	assert.Contains(t, extMap, component.NewID("nop"))
 }
 
+func TestServiceGetInFlightTracker(t *testing.T) {
+	srv, err := New(context.Background(), newNopSettings(), newNopConfig())
+	require.NoError(t, err)
+	assert.Nil(t, inflight.FromHost(srv.host))
+	assert.NoError(t, srv.Shutdown(context.Background()))
+
+	cfg := newNopConfig()
+	cfg.InFlight = inflight.Config{Enabled: true, LimitMiB: 10}
+	srv, err = New(context.Background(), newNopSettings(), cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Shutdown(context.Background()))
+	})
+	tracker := inflight.FromHost(srv.host)
+	require.NotNil(t, tracker)
+	assert.Equal(t, int64(10*1024*1024), tracker.Limit())
+}
+
+func TestServiceReportComponentStatus(t *testing.T) {
+	var events []component.StatusEvent
+	var mu sync.Mutex
+	set := newNopSettings()
+	set.AsyncErrorChannel = make(chan error, 1)
+	set.ReportComponentStatus = func(ev component.StatusEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, ev)
+	}
+	cfg := newNopConfig()
+	cfg.InFlight = inflight.Config{Enabled: true, LimitMiB: 10}
+	srv, err := New(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(context.Background()))
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Shutdown(context.Background()))
+	})
+
+	mu.Lock()
+	assert.Len(t, events, 20) // the extension and 9 pipeline instances, starting then OK
+	events = nil
+	mu.Unlock()
+
+	// the fatal errors are attributed to the instance reporting them
+	instanceID := component.NewInstanceID(component.NewID("nop"), component.KindReceiver, component.NewID("traces"))
+	fatalErr := errors.New("fatal")
+	srv.host.ForInstance(instanceID).ReportFatalError(fatalErr)
+	assert.Equal(t, fatalErr, <-set.AsyncErrorChannel)
+	assert.Equal(t, []component.StatusEvent{{InstanceID: instanceID, Status: component.StatusFatalError, Err: fatalErr}}, events)
+
+	// the instance host keeps the features of the service host
+	require.NotNil(t, srv.host.inflight)
+	assert.Same(t, srv.host.inflight, inflight.FromHost(srv.host.ForInstance(instanceID)))
+}
+
 func TestServiceGetExporters(t *testing.T) {
 	srv, err := New(context.Background(), newNopSettings(), newNopConfig())
 	require.NoError(t, err)
@@ -231,6 +286,78 @@ func TestServiceGetExporters(t *testing.T) {
 	assert.Contains(t, expMap[component.DataTypeLogs], component.NewID("nop"))
 }
 
+func TestServiceFlush(t *testing.T) {
+	srv, err := New(context.Background(), newNopSettings(), newNopConfig())
+	require.NoError(t, err)
+
+	assert.NoError(t, srv.Start(context.Background()))
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Shutdown(context.Background()))
+	})
+	assert.NoError(t, srv.Flush(context.Background()))
+}
+
+func TestServiceDrain(t *testing.T) {
+	set := newNopSettings()
+	set.IngestionPaused = true
+	cfg := newNopConfig()
+	cfg.InFlight = inflight.Config{Enabled: true, LimitMiB: 10}
+	srv, err := New(context.Background(), set, cfg)
+	require.NoError(t, err)
+
+	assert.NoError(t, srv.Start(context.Background()))
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Shutdown(context.Background()))
+	})
+	assert.True(t, srv.IngestionPaused())
+	srv.ResumeIngestion()
+	assert.False(t, srv.IngestionPaused())
+
+	// The drain waits for the data held by the components.
+	release := inflight.FromHost(srv.host).Component(component.KindExporter, component.NewID("nop")).Acquire(1024)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*drainPollInterval)
+	defer cancel()
+	assert.ErrorIs(t, srv.Drain(ctx), context.DeadlineExceeded)
+	assert.True(t, srv.IngestionPaused())
+
+	release()
+	assert.NoError(t, srv.Drain(context.Background()))
+}
+
+func TestServiceReload(t *testing.T) {
+	set := newNopSettings()
+	set.CollectorConf = confmap.NewFromStringMap(map[string]any{
+		"processors": map[string]any{"nop": nil},
+		"extensions": map[string]any{"nop": nil},
+	})
+	srv, err := New(context.Background(), set, newNopConfig())
+	require.NoError(t, err)
+
+	assert.NoError(t, srv.Start(context.Background()))
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Shutdown(context.Background()))
+	})
+
+	// The configuration of a processor changed.
+	prev := srv.host.pipelines
+	set.CollectorConf = confmap.NewFromStringMap(map[string]any{
+		"processors": map[string]any{"nop": map[string]any{"foo": "bar"}},
+		"extensions": map[string]any{"nop": nil},
+	})
+	require.NoError(t, srv.Reload(context.Background(), set, newNopConfig()))
+	assert.NotSame(t, prev, srv.host.pipelines)
+	assert.Same(t, set.CollectorConf, srv.collectorConf)
+
+	// The configuration of an extension changed, the service must be restarted.
+	prev = srv.host.pipelines
+	set.CollectorConf = confmap.NewFromStringMap(map[string]any{
+		"processors": map[string]any{"nop": map[string]any{"foo": "bar"}},
+		"extensions": map[string]any{"nop": map[string]any{"foo": "bar"}},
+	})
+	assert.EqualError(t, srv.Reload(context.Background(), set, newNopConfig()), "the configuration changed beyond the pipelines and their components")
+	assert.Same(t, prev, srv.host.pipelines)
+}
+
 // TestServiceTelemetryCleanupOnError tests that if newService errors due to an invalid config telemetry is cleaned up
 // and another service with a valid config can be started right after.
 func TestServiceTelemetryCleanupOnError(t *testing.T) {
@@ -474,6 +601,8 @@ func assertZPages(t *testing.T, zpagesAddr string) {
 		"/debug/pipelinez",
 		"/debug/servicez",
 		"/debug/extensionz",
+		"/debug/exporterz",
+		"/debug/flushz",
 	}
 
 	testZPagePathFn := func(t *testing.T, path string) {
@@ -12,13 +12,12 @@ import (
 	"strings"
 	"unicode"
 
-	ocprom "contrib.go.opencensus.io/exporter/prometheus"
-	"github.com/prometheus/client_golang/prometheus"
 	ocmetric "go.opencensus.io/metric"
 	"go.opencensus.io/metric/metricproducer"
 	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/contrib/propagators/b3"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/bridge/opencensus"
 	"go.opentelemetry.io/otel/metric"
 	noopmetric "go.opentelemetry.io/otel/metric/noop"
 	"go.opentelemetry.io/otel/propagation"
@@ -31,7 +30,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
-	"go.opentelemetry.io/collector/internal/obsreportconfig"
+	"go.opentelemetry.io/collector/service/internal/graph"
 	"go.opentelemetry.io/collector/service/internal/proctelemetry"
 	"go.opentelemetry.io/collector/service/telemetry"
 )
@@ -56,6 +55,16 @@ type telemetryInitializer struct {
 	tp         trace.TracerProvider
 	servers    []*http.Server
 
+	// pipelineReader and pipelineExporter route the metrics through a pipeline of the service, if configured.
+	pipelineID       component.ID
+	pipelineReader   sdkmetric.Reader
+	pipelineExporter *pipelineMetricsExporter
+
+	// otlpReaders and otlpSpanProcessors push the metrics and the spans with the OTLP exporters, if configured.
+	otlpReaders        []sdkmetric.Reader
+	otlpSpanProcessors []sdktrace.SpanProcessor
+	authCredentials    []*telemetryAuthCredentials
+
 	useOtel                bool
 	disableHighCardinality bool
 	extendedConfig         bool
@@ -72,7 +81,7 @@ func newColTelemetry(useOtel bool, disableHighCardinality bool, extendedConfig b
 }
 
 func (tel *telemetryInitializer) init(res *resource.Resource, settings component.TelemetrySettings, cfg telemetry.Config, asyncErrorChannel chan error) error {
-	if cfg.Metrics.Level == configtelemetry.LevelNone || (cfg.Metrics.Address == "" && len(cfg.Metrics.Readers) == 0) {
+	if cfg.Metrics.Level == configtelemetry.LevelNone || (cfg.Metrics.Address == "" && len(cfg.Metrics.Readers) == 0 && cfg.Metrics.Pipeline == nil && len(cfg.Metrics.OTLP) == 0) {
 		settings.Logger.Info(
 			"Skipping telemetry setup.",
 			zap.String(zapKeyTelemetryAddress, cfg.Metrics.Address),
@@ -107,14 +116,54 @@ func (tel *telemetryInitializer) initTraces(res *resource.Resource, cfg telemetr
 		}
 		opts = append(opts, sdktrace.WithSpanProcessor(sp))
 	}
+	for _, otlpCfg := range cfg.Traces.OTLP {
+		exp, err := proctelemetry.InitOTLPSpanExporter(context.Background(), otlpCfg, tel.newAuthCredentials(otlpCfg.Auth))
+		if err != nil {
+			return nil, err
+		}
+		sp := sdktrace.NewBatchSpanProcessor(exp)
+		tel.otlpSpanProcessors = append(tel.otlpSpanProcessors, sp)
+		opts = append(opts, sdktrace.WithSpanProcessor(sp))
+	}
 	return proctelemetry.InitTracerProvider(res, opts)
 }
 
 func (tel *telemetryInitializer) initMetrics(res *resource.Resource, logger *zap.Logger, cfg telemetry.Config, asyncErrorChannel chan error) error {
 	// Initialize the ocRegistry, still used by the process metrics.
 	tel.ocRegistry = ocmetric.NewRegistry()
-	if !tel.useOtel && !tel.extendedConfig {
-		return tel.initOpenCensus(res, logger, cfg.Metrics.Address, cfg.Metrics.Level, asyncErrorChannel)
+	if cfg.Metrics.Pipeline != nil {
+		tel.initPipelineReader(*cfg.Metrics.Pipeline)
+	}
+	if err := tel.initOTLPReaders(cfg.Metrics.OTLP); err != nil {
+		return err
+	}
+	if !tel.useOtel && !tel.extendedConfig && openCensusTelemetryIncluded {
+		if prom := cfg.Metrics.Prometheus; prom.NativeHistograms || len(prom.ResourceLabels) > 0 || prom.DisableTargetInfo {
+			logger.Warn("service::telemetry::metrics::prometheus is ignored, the metrics are recorded with OpenCensus")
+		}
+		if err := tel.initOpenCensus(res, logger, cfg.Metrics.Address, cfg.Metrics.Level, asyncErrorChannel); err != nil {
+			return err
+		}
+		pushReaders := tel.pushReaders()
+		if len(pushReaders) == 0 {
+			return nil
+		}
+		// The metrics recorded with OpenTelemetry are only routed through the pipeline and pushed with the OTLP exporters.
+		opts := make([]sdkmetric.Option, 0, len(pushReaders))
+		for _, r := range pushReaders {
+			opts = append(opts, sdkmetric.WithReader(r))
+		}
+		mp, err := proctelemetry.InitOpenTelemetry(res, opts, tel.disableHighCardinality)
+		if err != nil {
+			return err
+		}
+		tel.mp = mp
+		return nil
+	}
+
+	if len(cfg.Metrics.Address) != 0 && !proctelemetry.PrometheusIncluded {
+		logger.Warn("service::telemetry::metrics::address is ignored, the prometheus metric exporter is not included in this build")
+		cfg.Metrics.Address = ""
 	}
 
 	if len(cfg.Metrics.Address) != 0 {
@@ -148,7 +197,7 @@ func (tel *telemetryInitializer) initMetrics(res *resource.Resource, logger *zap
 	opts := []sdkmetric.Option{}
 	for _, reader := range cfg.Metrics.Readers {
 		// https://github.com/open-telemetry/opentelemetry-collector/issues/8045
-		r, server, err := proctelemetry.InitMetricReader(context.Background(), reader, asyncErrorChannel)
+		r, server, err := proctelemetry.InitMetricReader(context.Background(), reader, res, cfg.Metrics.Prometheus, asyncErrorChannel)
 		if err != nil {
 			return err
 		}
@@ -162,6 +211,9 @@ func (tel *telemetryInitializer) initMetrics(res *resource.Resource, logger *zap
 		}
 		opts = append(opts, sdkmetric.WithReader(r))
 	}
+	for _, r := range tel.pushReaders() {
+		opts = append(opts, sdkmetric.WithReader(r))
+	}
 
 	mp, err := proctelemetry.InitOpenTelemetry(res, opts, tel.disableHighCardinality)
 	if err != nil {
@@ -171,47 +223,66 @@ func (tel *telemetryInitializer) initMetrics(res *resource.Resource, logger *zap
 	return nil
 }
 
-func (tel *telemetryInitializer) initOpenCensus(res *resource.Resource, logger *zap.Logger, address string, level configtelemetry.Level, asyncErrorChannel chan error) error {
-	promRegistry := prometheus.NewRegistry()
-	metricproducer.GlobalManager().AddProducer(tel.ocRegistry)
-
-	tel.views = obsreportconfig.AllViews(level)
-	if err := view.Register(tel.views...); err != nil {
-		return err
+// initPipelineReader initializes the reader collecting the metrics routed through the given pipeline,
+// including the ones recorded with OpenCensus.
+func (tel *telemetryInitializer) initPipelineReader(cfg telemetry.MetricsPipelineConfig) {
+	opts := []sdkmetric.PeriodicReaderOption{sdkmetric.WithProducer(opencensus.NewMetricProducer())}
+	if cfg.Interval > 0 {
+		opts = append(opts, sdkmetric.WithInterval(cfg.Interval))
 	}
+	tel.pipelineID = cfg.ID
+	tel.pipelineExporter = &pipelineMetricsExporter{}
+	tel.pipelineReader = sdkmetric.NewPeriodicReader(tel.pipelineExporter, opts...)
+}
 
-	// Until we can use a generic metrics exporter, default to Prometheus.
-	opts := ocprom.Options{
-		Namespace: "otelcol",
-		Registry:  promRegistry,
+// initOTLPReaders initializes the readers pushing the metrics with the given OTLP exporters, including the ones
+// recorded with OpenCensus.
+func (tel *telemetryInitializer) initOTLPReaders(cfgs []telemetry.OTLPMetricsExporterConfig) error {
+	for _, cfg := range cfgs {
+		exp, err := proctelemetry.InitOTLPMetricExporter(context.Background(), cfg.OTLPExporterConfig, tel.newAuthCredentials(cfg.Auth))
+		if err != nil {
+			return err
+		}
+		opts := []sdkmetric.PeriodicReaderOption{sdkmetric.WithProducer(opencensus.NewMetricProducer())}
+		if cfg.Interval > 0 {
+			opts = append(opts, sdkmetric.WithInterval(cfg.Interval))
+		}
+		tel.otlpReaders = append(tel.otlpReaders, sdkmetric.NewPeriodicReader(exp, opts...))
 	}
+	return nil
+}
 
-	opts.ConstLabels = make(map[string]string)
-	for _, keyValue := range res.Attributes() {
-		opts.ConstLabels[sanitizePrometheusKey(string(keyValue.Key))] = keyValue.Value.AsString()
+// pushReaders returns the readers pushing the metrics, i.e. the pipeline and OTLP readers.
+func (tel *telemetryInitializer) pushReaders() []sdkmetric.Reader {
+	readers := append([]sdkmetric.Reader(nil), tel.otlpReaders...)
+	if tel.pipelineReader != nil {
+		readers = append(readers, tel.pipelineReader)
 	}
+	return readers
+}
 
-	pe, err := ocprom.NewExporter(opts)
-	if err != nil {
-		return err
+// startPipeline starts routing the metrics through the pipeline, if configured, once the pipelines are started.
+func (tel *telemetryInitializer) startPipeline(pipelines *graph.Graph) {
+	if tel.pipelineExporter != nil {
+		tel.pipelineExporter.setNext(pipelines.MetricsConsumer(tel.pipelineID))
 	}
+}
 
-	view.RegisterExporter(pe)
-
-	logger.Info(
-		"Serving Prometheus metrics",
-		zap.String(zapKeyTelemetryAddress, address),
-		zap.String(zapKeyTelemetryLevel, level.String()),
-	)
-	tel.servers = append(tel.servers, proctelemetry.InitPrometheusServer(promRegistry, address, asyncErrorChannel))
-	return nil
+// stopPipeline stops routing the metrics through the pipeline, if configured, before the pipelines are shut down.
+func (tel *telemetryInitializer) stopPipeline() {
+	if tel.pipelineExporter != nil {
+		tel.pipelineExporter.setNext(nil)
+	}
 }
 
 func (tel *telemetryInitializer) shutdown() error {
 	metricproducer.GlobalManager().DeleteProducer(tel.ocRegistry)
 	view.Unregister(tel.views...)
 
-	var errs error
+	errs := tel.stopOTLP()
+	if tel.pipelineReader != nil {
+		errs = multierr.Append(errs, tel.pipelineReader.Shutdown(context.Background()))
+	}
 	for _, server := range tel.servers {
 		if server != nil {
 			errs = multierr.Append(errs, server.Close())
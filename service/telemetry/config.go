@@ -4,11 +4,17 @@
 package telemetry // import "go.opentelemetry.io/collector/service/telemetry"
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"go.uber.org/zap/zapcore"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/internal/obsreportconfig"
 )
@@ -77,6 +83,10 @@ type LogsConfig struct {
 	// (default = ["stderr"])
 	ErrorOutputPaths []string `mapstructure:"error_output_paths"`
 
+	// Rotation configures the rotation of the log files of OutputPaths, i.e. the paths other than
+	// "stdout" and "stderr". The log files are not rotated if not set.
+	Rotation *LogsRotationConfig `mapstructure:"rotation"`
+
 	// InitialFields is a collection of fields to add to the root logger.
 	// Example:
 	//
@@ -87,6 +97,30 @@ type LogsConfig struct {
 	InitialFields map[string]any `mapstructure:"initial_fields"`
 }
 
+// LogsRotationConfig defines the rotation of the log files. A log file is rotated once it reaches its maximum
+// size: it is renamed with the current time appended to its name, and a new log file is created.
+type LogsRotationConfig struct {
+	// MaxMegabytes is the maximum size in megabytes of a log file before it gets rotated.
+	// (default = 100)
+	MaxMegabytes int `mapstructure:"max_megabytes"`
+
+	// MaxDays is the maximum number of days to retain the rotated log files, based on the time
+	// in their name. The rotated log files are not removed based on their age if not set.
+	MaxDays int `mapstructure:"max_days"`
+
+	// MaxBackups is the maximum number of rotated log files to retain. All the rotated log files
+	// are retained if not set, unless removed because of MaxDays.
+	MaxBackups int `mapstructure:"max_backups"`
+
+	// LocalTime uses the local time in the names of the rotated log files, instead of UTC.
+	// (default = false)
+	LocalTime bool `mapstructure:"localtime"`
+
+	// Compress compresses the rotated log files with gzip.
+	// (default = false)
+	Compress bool `mapstructure:"compress"`
+}
+
 // LogsSamplingConfig sets a sampling strategy for the logger. Sampling caps the
 // global CPU and I/O load that logging puts on your process while attempting
 // to preserve a representative subset of your logs.
@@ -111,6 +145,43 @@ type MetricsConfig struct {
 	// Readers allow configuration of metric readers to emit metrics to
 	// any number of supported backends.
 	Readers []MetricReader `mapstructure:"readers"`
+
+	// Pipeline routes the metrics through a metrics pipeline of the service, e.g. to batch them and
+	// export them with the otlp exporter. The pipeline needs no receiver, the metrics being fed to its
+	// first processor, or to its exporters if it has none.
+	Pipeline *MetricsPipelineConfig `mapstructure:"pipeline"`
+
+	// Prometheus configures how the metrics are exposed by the Prometheus exporters, i.e. the one serving
+	// on Address and the pull readers. Only used when the metrics are recorded with the OpenTelemetry SDK.
+	Prometheus PrometheusConfig `mapstructure:"prometheus"`
+
+	// OTLP lists the OTLP exporters the metrics are periodically pushed with, including the ones
+	// recorded with OpenCensus.
+	OTLP []OTLPMetricsExporterConfig `mapstructure:"otlp"`
+}
+
+// PrometheusConfig defines how the collector's own metrics are exposed in the Prometheus format.
+type PrometheusConfig struct {
+	// NativeHistograms exposes the histograms without explicitly configured buckets, e.g. the durations
+	// of the HTTP and gRPC requests, as Prometheus native histograms with exponential buckets. The native
+	// histograms are only available to the scrapers negotiating the protobuf format.
+	NativeHistograms bool `mapstructure:"native_histograms"`
+
+	// ResourceLabels lists the resource attributes added as labels to every metric, e.g. "service.instance.id",
+	// the other resource attributes being only reported on the target_info metric.
+	ResourceLabels []string `mapstructure:"resource_labels"`
+
+	// DisableTargetInfo removes the target_info metric reporting the resource attributes.
+	DisableTargetInfo bool `mapstructure:"disable_target_info"`
+}
+
+// MetricsPipelineConfig defines the pipeline the collector's own metrics are routed through.
+type MetricsPipelineConfig struct {
+	// ID is the ID of the metrics pipeline, e.g. "metrics/internal".
+	ID component.ID `mapstructure:"id"`
+
+	// Interval is the interval between two collections of the metrics sent to the pipeline, 60s if not set.
+	Interval time.Duration `mapstructure:"interval"`
 }
 
 // TracesConfig exposes the common Telemetry configuration for collector's internal spans.
@@ -123,13 +194,120 @@ type TracesConfig struct {
 	// Processors allow configuration of span processors to emit spans to
 	// any number of suported backends.
 	Processors []SpanProcessor `mapstructure:"processors"`
+
+	// OTLP lists the OTLP exporters the spans are pushed with, in batches.
+	OTLP []OTLPExporterConfig `mapstructure:"otlp"`
+}
+
+const (
+	// OTLPProtocolGRPC is the protocol of the OTLP exporters sending the telemetry over gRPC.
+	OTLPProtocolGRPC = "grpc"
+	// OTLPProtocolHTTP is the protocol of the OTLP exporters sending the telemetry over HTTP, in protobuf.
+	OTLPProtocolHTTP = "http"
+)
+
+// OTLPExporterConfig defines an OTLP exporter pushing the collector's own telemetry to a backend,
+// with the same TLS and authentication settings as the otlp and otlphttp exporters.
+type OTLPExporterConfig struct {
+	// Protocol is the protocol of the exporter, "grpc" or "http".
+	// (default = "grpc")
+	Protocol string `mapstructure:"protocol"`
+
+	// Endpoint is the host:port of the gRPC server, or the URL of the HTTP server the path of the
+	// signal, e.g. "/v1/metrics", is appended to.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// TLSSetting configures the TLS connection to the gRPC server. The scheme of the endpoint
+	// tells whether TLS is used with the HTTP server.
+	TLSSetting configtls.TLSClientSetting `mapstructure:"tls"`
+
+	// Auth configures the client authenticators of the exporter, only supported over gRPC.
+	// The telemetry exported before the extensions are started is dropped.
+	Auth *configauth.Authentication `mapstructure:"auth"`
+
+	// Headers are added to every export request.
+	Headers map[string]configopaque.String `mapstructure:"headers"`
+
+	// Compression is the compression of the export requests, "gzip" or "none".
+	// (default = "none")
+	Compression string `mapstructure:"compression"`
+
+	// Timeout is the timeout of every export request.
+	// (default = 10s)
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// Validate checks the protocol, endpoint and compression of the exporter.
+func (cfg *OTLPExporterConfig) Validate() error {
+	switch cfg.Protocol {
+	case "", OTLPProtocolGRPC:
+	case OTLPProtocolHTTP:
+		if cfg.Auth != nil {
+			return errors.New("auth is only supported with the grpc protocol")
+		}
+	default:
+		return fmt.Errorf("unsupported protocol %q", cfg.Protocol)
+	}
+	if cfg.Endpoint == "" {
+		return errors.New("endpoint must not be empty")
+	}
+	switch cfg.Compression {
+	case "", "gzip", "none":
+	default:
+		return fmt.Errorf("unsupported compression %q", cfg.Compression)
+	}
+	if cfg.Timeout < 0 {
+		return errors.New("timeout must not be negative")
+	}
+	return nil
+}
+
+// OTLPMetricsExporterConfig defines an OTLP exporter periodically pushing the collector's own metrics.
+type OTLPMetricsExporterConfig struct {
+	OTLPExporterConfig `mapstructure:",squash"`
+
+	// Interval is the interval between two exports of the metrics.
+	// (default = 60s)
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+// Validate checks the exporter and the interval.
+func (cfg *OTLPMetricsExporterConfig) Validate() error {
+	if err := cfg.OTLPExporterConfig.Validate(); err != nil {
+		return err
+	}
+	if cfg.Interval < 0 {
+		return errors.New("interval must not be negative")
+	}
+	return nil
 }
 
 // Validate checks whether the current configuration is valid
 func (c *Config) Validate() error {
 	// Check when service telemetry metric level is not none, the metrics address should not be empty
-	if c.Metrics.Level != configtelemetry.LevelNone && c.Metrics.Address == "" && len(c.Metrics.Readers) == 0 {
-		return fmt.Errorf("collector telemetry metric address or reader should exist when metric level is not none")
+	if c.Metrics.Level != configtelemetry.LevelNone && c.Metrics.Address == "" && len(c.Metrics.Readers) == 0 && c.Metrics.Pipeline == nil && len(c.Metrics.OTLP) == 0 {
+		return fmt.Errorf("collector telemetry metric address, reader, pipeline or otlp exporter should exist when metric level is not none")
+	}
+
+	for i := range c.Metrics.OTLP {
+		if err := c.Metrics.OTLP[i].Validate(); err != nil {
+			return fmt.Errorf("collector telemetry metrics otlp exporter %d: %w", i, err)
+		}
+	}
+	for i := range c.Traces.OTLP {
+		if err := c.Traces.OTLP[i].Validate(); err != nil {
+			return fmt.Errorf("collector telemetry traces otlp exporter %d: %w", i, err)
+		}
+	}
+
+	if r := c.Logs.Rotation; r != nil && (r.MaxMegabytes < 0 || r.MaxDays < 0 || r.MaxBackups < 0) {
+		return fmt.Errorf("collector telemetry logs rotation max_megabytes, max_days and max_backups must not be negative")
+	}
+
+	for _, attr := range c.Metrics.Prometheus.ResourceLabels {
+		if attr == "" {
+			return fmt.Errorf("collector telemetry prometheus resource labels must not be empty")
+		}
 	}
 
 	return nil
@@ -5,10 +5,13 @@ package telemetry
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/featuregate"
@@ -53,6 +56,112 @@ func TestLoadConfig(t *testing.T) {
 			},
 			success: true,
 		},
+		{
+			name: "invalid logs rotation",
+			cfg: &Config{
+				Logs: LogsConfig{
+					Rotation: &LogsRotationConfig{MaxMegabytes: -1},
+				},
+				Metrics: MetricsConfig{
+					Level:   configtelemetry.LevelBasic,
+					Address: "127.0.0.1:3333",
+				},
+			},
+			success: false,
+		},
+		{
+			name: "invalid prometheus resource labels",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:   configtelemetry.LevelBasic,
+					Address: "127.0.0.1:3333",
+					Prometheus: PrometheusConfig{
+						ResourceLabels: []string{"service.instance.id", ""},
+					},
+				},
+			},
+			success: false,
+		},
+		{
+			name: "valid metric telemetry with otlp exporters",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level: configtelemetry.LevelBasic,
+					OTLP: []OTLPMetricsExporterConfig{
+						{OTLPExporterConfig: OTLPExporterConfig{Endpoint: "localhost:4317", Auth: &configauth.Authentication{AuthenticatorID: component.NewID("oauth2client")}}},
+						{OTLPExporterConfig: OTLPExporterConfig{Protocol: OTLPProtocolHTTP, Endpoint: "https://localhost:4318", Compression: "gzip"}, Interval: time.Second},
+					},
+				},
+				Traces: TracesConfig{
+					OTLP: []OTLPExporterConfig{{Endpoint: "localhost:4317"}},
+				},
+			},
+			success: true,
+		},
+		{
+			name: "invalid otlp exporter protocol",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level: configtelemetry.LevelBasic,
+					OTLP: []OTLPMetricsExporterConfig{
+						{OTLPExporterConfig: OTLPExporterConfig{Protocol: "thrift", Endpoint: "localhost:4317"}},
+					},
+				},
+			},
+			success: false,
+		},
+		{
+			name: "otlp exporter without endpoint",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level: configtelemetry.LevelBasic,
+					OTLP:  []OTLPMetricsExporterConfig{{}},
+				},
+			},
+			success: false,
+		},
+		{
+			name: "invalid otlp exporter interval",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level: configtelemetry.LevelBasic,
+					OTLP: []OTLPMetricsExporterConfig{
+						{OTLPExporterConfig: OTLPExporterConfig{Endpoint: "localhost:4317"}, Interval: -time.Second},
+					},
+				},
+			},
+			success: false,
+		},
+		{
+			name: "otlp http exporter with auth",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:   configtelemetry.LevelBasic,
+					Address: "127.0.0.1:3333",
+				},
+				Traces: TracesConfig{
+					OTLP: []OTLPExporterConfig{{
+						Protocol: OTLPProtocolHTTP,
+						Endpoint: "https://localhost:4318",
+						Auth:     &configauth.Authentication{AuthenticatorID: component.NewID("oauth2client")},
+					}},
+				},
+			},
+			success: false,
+		},
+		{
+			name: "invalid otlp exporter compression",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:   configtelemetry.LevelBasic,
+					Address: "127.0.0.1:3333",
+				},
+				Traces: TracesConfig{
+					OTLP: []OTLPExporterConfig{{Endpoint: "localhost:4317", Compression: "zstd"}},
+				},
+			},
+			success: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package telemetry // import "go.opentelemetry.io/collector/service/telemetry"
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"go.uber.org/zap"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// rotatingSinkScheme is the scheme of the zap sinks writing to rotated log files, the path of
+// the log file and the rotation settings being passed in the query of the URLs.
+const rotatingSinkScheme = "otelcol-rotating"
+
+var registerRotatingSinkOnce sync.Once
+
+// rotatedOutputPaths returns the output paths with the log files replaced by rotating sinks,
+// unless the rotation is not configured.
+func rotatedOutputPaths(paths []string, rotation *LogsRotationConfig) []string {
+	if rotation == nil {
+		return paths
+	}
+	registerRotatingSinkOnce.Do(func() {
+		// The registration only fails if the scheme is already registered.
+		_ = zap.RegisterSink(rotatingSinkScheme, newRotatingSink)
+	})
+
+	query := url.Values{}
+	query.Set("max_megabytes", strconv.Itoa(rotation.MaxMegabytes))
+	query.Set("max_days", strconv.Itoa(rotation.MaxDays))
+	query.Set("max_backups", strconv.Itoa(rotation.MaxBackups))
+	query.Set("localtime", strconv.FormatBool(rotation.LocalTime))
+	query.Set("compress", strconv.FormatBool(rotation.Compress))
+
+	out := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if path == "stdout" || path == "stderr" {
+			out = append(out, path)
+			continue
+		}
+		// The URLs with the "file" scheme are handled as the paths without scheme.
+		if u, err := url.Parse(path); err == nil && u.Scheme == "file" {
+			path = u.Path
+		}
+		query.Set("filename", path)
+		sinkURL := url.URL{Scheme: rotatingSinkScheme, RawQuery: query.Encode()}
+		out = append(out, sinkURL.String())
+	}
+	return out
+}
+
+// rotatingSink is a zap.Sink writing to a log file rotated by lumberjack.
+type rotatingSink struct {
+	*lumberjack.Logger
+}
+
+// Sync implements zap.Sink, the writes to lumberjack not being buffered.
+func (rotatingSink) Sync() error {
+	return nil
+}
+
+func newRotatingSink(u *url.URL) (zap.Sink, error) {
+	query := u.Query()
+	var err error
+	intParam := func(name string) int {
+		v, convErr := strconv.Atoi(query.Get(name))
+		if convErr != nil && err == nil {
+			err = fmt.Errorf("invalid %s: %w", name, convErr)
+		}
+		return v
+	}
+	boolParam := func(name string) bool {
+		v, convErr := strconv.ParseBool(query.Get(name))
+		if convErr != nil && err == nil {
+			err = fmt.Errorf("invalid %s: %w", name, convErr)
+		}
+		return v
+	}
+	sink := rotatingSink{Logger: &lumberjack.Logger{
+		Filename:   query.Get("filename"),
+		MaxSize:    intParam("max_megabytes"),
+		MaxAge:     intParam("max_days"),
+		MaxBackups: intParam("max_backups"),
+		LocalTime:  boolParam("localtime"),
+		Compress:   boolParam("compress"),
+	}}
+	if err != nil {
+		return nil, err
+	}
+	return sink, nil
+}
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package telemetry
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestRotatedOutputPaths(t *testing.T) {
+	paths := []string{"stdout", "stderr", "otelcol.log", "file:///var/log/otelcol.log"}
+	assert.Equal(t, paths, rotatedOutputPaths(paths, nil))
+
+	rotated := rotatedOutputPaths(paths, &LogsRotationConfig{MaxMegabytes: 10, Compress: true})
+	require.Len(t, rotated, 4)
+	assert.Equal(t, []string{"stdout", "stderr"}, rotated[:2])
+	assert.True(t, strings.HasPrefix(rotated[2], rotatingSinkScheme+":?"))
+	assert.Contains(t, rotated[2], "filename=otelcol.log")
+	assert.Contains(t, rotated[3], "filename=%2Fvar%2Flog%2Fotelcol.log")
+	assert.Contains(t, rotated[3], "max_megabytes=10")
+	assert.Contains(t, rotated[3], "compress=true")
+}
+
+func TestNewLoggerRotation(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "otelcol.log")
+	logger, err := newLogger(LogsConfig{
+		Level:            zapcore.InfoLevel,
+		Encoding:         "json",
+		OutputPaths:      []string{logFile},
+		ErrorOutputPaths: []string{"stderr"},
+		Rotation:         &LogsRotationConfig{MaxMegabytes: 1, MaxBackups: 1},
+	}, nil)
+	require.NoError(t, err)
+
+	// Writing more than 2MB rotates the log file twice, only one rotated log file being retained.
+	msg := strings.Repeat("x", 1024)
+	for i := 0; i < 2500; i++ {
+		logger.Info(msg)
+	}
+	require.NoError(t, logger.Sync())
+
+	assert.Eventually(t, func() bool {
+		entries, readErr := os.ReadDir(dir)
+		return readErr == nil && len(entries) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	info, err := os.Stat(logFile)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, info.Size(), int64(1024*1024))
+}
@@ -62,7 +62,7 @@ func newLogger(cfg LogsConfig, options []zap.Option) (*zap.Logger, error) {
 		Sampling:          toSamplingConfig(cfg.Sampling),
 		Encoding:          cfg.Encoding,
 		EncoderConfig:     zap.NewProductionEncoderConfig(),
-		OutputPaths:       cfg.OutputPaths,
+		OutputPaths:       rotatedOutputPaths(cfg.OutputPaths, cfg.Rotation),
 		ErrorOutputPaths:  cfg.ErrorOutputPaths,
 		DisableCaller:     cfg.DisableCaller,
 		DisableStacktrace: cfg.DisableStacktrace,
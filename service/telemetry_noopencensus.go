@@ -0,0 +1,23 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build otelcol_noopencensus || otelcol_noprometheus
+
+package service // import "go.opentelemetry.io/collector/service"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/config/configtelemetry"
+)
+
+// openCensusTelemetryIncluded reports whether the own metrics can be exported with OpenCensus: the
+// OpenTelemetry SDK is always used in builds with the otelcol_noopencensus or otelcol_noprometheus tags.
+const openCensusTelemetryIncluded = false
+
+func (tel *telemetryInitializer) initOpenCensus(*resource.Resource, *zap.Logger, string, configtelemetry.Level, chan error) error {
+	return errors.New("the OpenCensus telemetry is not included in this build")
+}
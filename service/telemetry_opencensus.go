@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !otelcol_noopencensus && !otelcol_noprometheus
+
+package service // import "go.opentelemetry.io/collector/service"
+
+import (
+	ocprom "contrib.go.opencensus.io/exporter/prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opencensus.io/metric/metricproducer"
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
+	"go.opentelemetry.io/collector/service/internal/proctelemetry"
+)
+
+// openCensusTelemetryIncluded reports whether the own metrics can be exported with OpenCensus,
+// which is not the case in builds with the otelcol_noopencensus or otelcol_noprometheus tags.
+const openCensusTelemetryIncluded = true
+
+func (tel *telemetryInitializer) initOpenCensus(res *resource.Resource, logger *zap.Logger, address string, level configtelemetry.Level, asyncErrorChannel chan error) error {
+	promRegistry := prometheus.NewRegistry()
+	metricproducer.GlobalManager().AddProducer(tel.ocRegistry)
+
+	tel.views = obsreportconfig.AllViews(level)
+	if err := view.Register(tel.views...); err != nil {
+		return err
+	}
+
+	// The metrics may only be routed through a pipeline of the service.
+	if address == "" {
+		return nil
+	}
+
+	// Until we can use a generic metrics exporter, default to Prometheus.
+	opts := ocprom.Options{
+		Namespace: "otelcol",
+		Registry:  promRegistry,
+	}
+
+	opts.ConstLabels = make(map[string]string)
+	for _, keyValue := range res.Attributes() {
+		opts.ConstLabels[sanitizePrometheusKey(string(keyValue.Key))] = keyValue.Value.AsString()
+	}
+
+	pe, err := ocprom.NewExporter(opts)
+	if err != nil {
+		return err
+	}
+
+	view.RegisterExporter(pe)
+
+	logger.Info(
+		"Serving Prometheus metrics",
+		zap.String(zapKeyTelemetryAddress, address),
+		zap.String(zapKeyTelemetryLevel, level.String()),
+	)
+	tel.servers = append(tel.servers, proctelemetry.InitPrometheusServer(promRegistry, address, asyncErrorChannel))
+	return nil
+}
@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package service // import "go.opentelemetry.io/collector/service"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.uber.org/multierr"
+	"google.golang.org/grpc/credentials"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configauth"
+)
+
+var errTelemetryAuthNotStarted = errors.New("the authenticators of the telemetry exporter are not started yet")
+
+// telemetryAuthCredentials are the credentials.PerRPCCredentials of an OTLP exporter of the collector's own
+// telemetry, see telemetry.OTLPExporterConfig.Auth. The exporters are created before the extensions, so the
+// credentials of the client authenticators are only set once the extensions are started, the export
+// requests failing until then.
+type telemetryAuthCredentials struct {
+	auth *configauth.Authentication
+
+	mu    sync.RWMutex
+	creds []credentials.PerRPCCredentials
+}
+
+var _ credentials.PerRPCCredentials = (*telemetryAuthCredentials)(nil)
+
+// start gets the credentials of the client authenticators from the started extensions.
+func (c *telemetryAuthCredentials) start(extensions map[component.ID]component.Component) error {
+	authenticators, err := c.auth.GetClientAuthenticators(extensions)
+	if err != nil {
+		return err
+	}
+	creds := make([]credentials.PerRPCCredentials, 0, len(authenticators))
+	for _, authenticator := range authenticators {
+		cred, err := authenticator.PerRPCCredentials()
+		if err != nil {
+			return err
+		}
+		creds = append(creds, cred)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.creds = creds
+	return nil
+}
+
+// stop forgets the credentials before the extensions are shut down.
+func (c *telemetryAuthCredentials) stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.creds = nil
+}
+
+// GetRequestMetadata returns the metadata of the credentials, the metadata returned by the last credentials
+// overriding the metadata with the same keys returned by the first ones.
+func (c *telemetryAuthCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.creds == nil {
+		return nil, errTelemetryAuthNotStarted
+	}
+	md := map[string]string{}
+	for _, cred := range c.creds {
+		credMD, err := cred.GetRequestMetadata(ctx, uri...)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range credMD {
+			md[k] = v
+		}
+	}
+	return md, nil
+}
+
+// RequireTransportSecurity returns false: the credentials are not known yet when the connection is created.
+func (c *telemetryAuthCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// startOTLP sets the credentials of the OTLP exporters of the collector's own telemetry once the extensions are started.
+func (tel *telemetryInitializer) startOTLP(extensions map[component.ID]component.Component) error {
+	for _, c := range tel.authCredentials {
+		if err := c.start(extensions); err != nil {
+			return fmt.Errorf("failed to get the authenticators of the telemetry exporters: %w", err)
+		}
+	}
+	return nil
+}
+
+// stopOTLP shuts down the OTLP exporters of the collector's own telemetry, exporting the pending telemetry,
+// before the extensions, and so their authenticators, are shut down.
+func (tel *telemetryInitializer) stopOTLP() error {
+	var errs error
+	for _, r := range tel.otlpReaders {
+		errs = multierr.Append(errs, r.Shutdown(context.Background()))
+	}
+	for _, sp := range tel.otlpSpanProcessors {
+		errs = multierr.Append(errs, sp.Shutdown(context.Background()))
+	}
+	tel.otlpReaders = nil
+	tel.otlpSpanProcessors = nil
+	for _, c := range tel.authCredentials {
+		c.stop()
+	}
+	return errs
+}
+
+// newAuthCredentials returns the credentials of an OTLP exporter with the given authentication settings, nil if not set.
+func (tel *telemetryInitializer) newAuthCredentials(auth *configauth.Authentication) credentials.PerRPCCredentials {
+	if auth == nil {
+		return nil
+	}
+	c := &telemetryAuthCredentials{auth: auth}
+	tel.authCredentials = append(tel.authCredentials, c)
+	return c
+}
@@ -0,0 +1,218 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/extension/auth"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/service/telemetry"
+)
+
+func TestServiceTelemetryOTLP(t *testing.T) {
+	for _, useOtel := range []bool{false, true} {
+		t.Run(map[bool]string{false: "opencensus", true: "opentelemetry"}[useOtel], func(t *testing.T) {
+			backend := newOTLPHTTPBackend(t)
+
+			set := newNopSettings()
+			set.useOtel = &useOtel
+			cfg := newNopConfig()
+			cfg.Telemetry.Metrics.Address = ""
+			cfg.Telemetry.Metrics.OTLP = []telemetry.OTLPMetricsExporterConfig{{
+				OTLPExporterConfig: telemetry.OTLPExporterConfig{
+					Protocol: telemetry.OTLPProtocolHTTP,
+					Endpoint: backend.URL + "/otlp",
+					Headers:  map[string]configopaque.String{"X-Tenant": "collectors"},
+				},
+				Interval: 10 * time.Millisecond,
+			}}
+			require.NoError(t, cfg.Validate())
+
+			srv, err := New(context.Background(), set, cfg)
+			require.NoError(t, err)
+			require.NoError(t, srv.Start(context.Background()))
+			require.Eventually(t, func() bool {
+				return backend.metricsRequests() > 0
+			}, 5*time.Second, 10*time.Millisecond)
+			require.NoError(t, srv.Shutdown(context.Background()))
+
+			backend.mu.Lock()
+			defer backend.mu.Unlock()
+			assert.Equal(t, "collectors", backend.headers.Get("X-Tenant"))
+			serviceName, ok := backend.metrics.Metrics().ResourceMetrics().At(0).Resource().Attributes().Get("service.name")
+			require.True(t, ok)
+			assert.Equal(t, set.BuildInfo.Command, serviceName.Str())
+		})
+	}
+}
+
+func TestTelemetryOTLPTraces(t *testing.T) {
+	backend := newOTLPHTTPBackend(t)
+	tel := newColTelemetry(false, false, false)
+	cfg := telemetry.Config{
+		Metrics: telemetry.MetricsConfig{Address: "localhost:0"},
+		Traces: telemetry.TracesConfig{
+			OTLP: []telemetry.OTLPExporterConfig{{Protocol: telemetry.OTLPProtocolHTTP, Endpoint: backend.URL}},
+		},
+	}
+	require.NoError(t, tel.init(resource.Empty(), componenttest.NewNopTelemetrySettings(), cfg, make(chan error, 1)))
+
+	_, span := tel.tp.Tracer("test").Start(context.Background(), "operation")
+	span.End()
+	// The batches of spans are exported on shutdown.
+	require.NoError(t, tel.shutdown())
+
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	assert.Equal(t, 1, backend.traces.Traces().SpanCount())
+}
+
+func TestTelemetryOTLPAuth(t *testing.T) {
+	receiver := &otlpGRPCBackend{}
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	pmetricotlp.RegisterGRPCServer(srv, receiver)
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	t.Cleanup(srv.Stop)
+
+	authID := component.NewID("token")
+	tel := newColTelemetry(false, false, false)
+	cfg := telemetry.Config{
+		Metrics: telemetry.MetricsConfig{
+			OTLP: []telemetry.OTLPMetricsExporterConfig{{
+				OTLPExporterConfig: telemetry.OTLPExporterConfig{
+					Endpoint:   ln.Addr().String(),
+					TLSSetting: configtls.TLSClientSetting{Insecure: true},
+					Auth:       &configauth.Authentication{AuthenticatorID: authID},
+				},
+				Interval: 10 * time.Millisecond,
+			}},
+		},
+	}
+	require.NoError(t, tel.init(resource.Empty(), componenttest.NewNopTelemetrySettings(), cfg, make(chan error, 1)))
+	t.Cleanup(func() { assert.NoError(t, tel.shutdown()) })
+
+	// The exports fail until the authenticators are started.
+	md, err := tel.authCredentials[0].GetRequestMetadata(context.Background())
+	assert.ErrorIs(t, err, errTelemetryAuthNotStarted)
+	assert.Nil(t, md)
+	assert.Error(t, tel.startOTLP(map[component.ID]component.Component{}))
+
+	extensions := map[component.ID]component.Component{
+		authID: auth.NewClient(auth.WithClientPerRPCCredentials(func() (credentials.PerRPCCredentials, error) {
+			return tokenCredentials("secret"), nil
+		})),
+	}
+	require.NoError(t, tel.startOTLP(extensions))
+	require.Eventually(t, func() bool {
+		return receiver.authorization() == "Bearer secret"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, tel.stopOTLP())
+	_, err = tel.authCredentials[0].GetRequestMetadata(context.Background())
+	assert.ErrorIs(t, err, errTelemetryAuthNotStarted)
+}
+
+// otlpHTTPBackend records the last OTLP/HTTP requests it received.
+type otlpHTTPBackend struct {
+	*httptest.Server
+	mu         sync.Mutex
+	headers    http.Header
+	metricsReq int
+	metrics    pmetricotlp.ExportRequest
+	traces     ptraceotlp.ExportRequest
+}
+
+func newOTLPHTTPBackend(t *testing.T) *otlpHTTPBackend {
+	b := &otlpHTTPBackend{metrics: pmetricotlp.NewExportRequest(), traces: ptraceotlp.NewExportRequest()}
+	b.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.headers = r.Header
+		switch r.URL.Path {
+		case "/otlp/v1/metrics":
+			err = b.metrics.UnmarshalProto(body)
+			b.metricsReq++
+		case "/v1/traces":
+			err = b.traces.UnmarshalProto(body)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	}))
+	t.Cleanup(b.Close)
+	return b
+}
+
+func (b *otlpHTTPBackend) metricsRequests() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.metricsReq
+}
+
+// otlpGRPCBackend records the authorization metadata of the OTLP/gRPC requests it received.
+type otlpGRPCBackend struct {
+	pmetricotlp.UnimplementedGRPCServer
+	mu   sync.Mutex
+	auth string
+}
+
+func (b *otlpGRPCBackend) Export(ctx context.Context, _ pmetricotlp.ExportRequest) (pmetricotlp.ExportResponse, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get("authorization")) > 0 {
+		b.mu.Lock()
+		b.auth = md.Get("authorization")[0]
+		b.mu.Unlock()
+	}
+	return pmetricotlp.NewExportResponse(), nil
+}
+
+func (b *otlpGRPCBackend) authorization() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.auth
+}
+
+type tokenCredentials string
+
+func (c tokenCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(c)}, nil
+}
+
+func (c tokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
@@ -0,0 +1,200 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package service // import "go.opentelemetry.io/collector/service"
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// pipelineMetricsExporter is the sdkmetric.Exporter routing the collector's own metrics through a metrics
+// pipeline of the service, see telemetry.MetricsConfig.Pipeline. The metrics are dropped while the pipeline
+// is not running.
+type pipelineMetricsExporter struct {
+	mu   sync.RWMutex
+	next consumer.Metrics
+}
+
+var _ sdkmetric.Exporter = (*pipelineMetricsExporter)(nil)
+
+// setNext sets the consumer of the pipeline once it is started, or nil before it is shut down.
+// Setting nil waits for the metrics being exported to be consumed.
+func (e *pipelineMetricsExporter) setNext(next consumer.Metrics) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.next = next
+}
+
+func (e *pipelineMetricsExporter) Temporality(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+	return sdkmetric.DefaultTemporalitySelector(kind)
+}
+
+func (e *pipelineMetricsExporter) Aggregation(kind sdkmetric.InstrumentKind) sdkmetric.Aggregation {
+	return sdkmetric.DefaultAggregationSelector(kind)
+}
+
+func (e *pipelineMetricsExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.next == nil {
+		return nil
+	}
+	md := metricsFromSdk(rm)
+	if md.DataPointCount() == 0 {
+		return nil
+	}
+	return e.next.ConsumeMetrics(ctx, md)
+}
+
+func (e *pipelineMetricsExporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+func (e *pipelineMetricsExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+// metricsFromSdk converts the metrics collected by the OpenTelemetry SDK to pdata.
+func metricsFromSdk(rm *metricdata.ResourceMetrics) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rms := md.ResourceMetrics().AppendEmpty()
+	if rm.Resource != nil {
+		rms.SetSchemaUrl(rm.Resource.SchemaURL())
+		attributesFromSdk(rms.Resource().Attributes(), rm.Resource.Iter())
+	}
+	for _, sm := range rm.ScopeMetrics {
+		sms := rms.ScopeMetrics().AppendEmpty()
+		sms.SetSchemaUrl(sm.Scope.SchemaURL)
+		sms.Scope().SetName(sm.Scope.Name)
+		sms.Scope().SetVersion(sm.Scope.Version)
+		for _, m := range sm.Metrics {
+			dest := sms.Metrics().AppendEmpty()
+			dest.SetName(m.Name)
+			dest.SetDescription(m.Description)
+			dest.SetUnit(m.Unit)
+			switch data := m.Data.(type) {
+			case metricdata.Gauge[int64]:
+				numberDataPointsFromSdk(dest.SetEmptyGauge().DataPoints(), data.DataPoints)
+			case metricdata.Gauge[float64]:
+				numberDataPointsFromSdk(dest.SetEmptyGauge().DataPoints(), data.DataPoints)
+			case metricdata.Sum[int64]:
+				sumFromSdk(dest.SetEmptySum(), data.Temporality, data.IsMonotonic)
+				numberDataPointsFromSdk(dest.Sum().DataPoints(), data.DataPoints)
+			case metricdata.Sum[float64]:
+				sumFromSdk(dest.SetEmptySum(), data.Temporality, data.IsMonotonic)
+				numberDataPointsFromSdk(dest.Sum().DataPoints(), data.DataPoints)
+			case metricdata.Histogram[int64]:
+				dest.SetEmptyHistogram().SetAggregationTemporality(temporalityFromSdk(data.Temporality))
+				histogramDataPointsFromSdk(dest.Histogram().DataPoints(), data.DataPoints)
+			case metricdata.Histogram[float64]:
+				dest.SetEmptyHistogram().SetAggregationTemporality(temporalityFromSdk(data.Temporality))
+				histogramDataPointsFromSdk(dest.Histogram().DataPoints(), data.DataPoints)
+			case metricdata.ExponentialHistogram[int64]:
+				dest.SetEmptyExponentialHistogram().SetAggregationTemporality(temporalityFromSdk(data.Temporality))
+				exponentialHistogramDataPointsFromSdk(dest.ExponentialHistogram().DataPoints(), data.DataPoints)
+			case metricdata.ExponentialHistogram[float64]:
+				dest.SetEmptyExponentialHistogram().SetAggregationTemporality(temporalityFromSdk(data.Temporality))
+				exponentialHistogramDataPointsFromSdk(dest.ExponentialHistogram().DataPoints(), data.DataPoints)
+			}
+		}
+	}
+	return md
+}
+
+func sumFromSdk(dest pmetric.Sum, temporality metricdata.Temporality, isMonotonic bool) {
+	dest.SetAggregationTemporality(temporalityFromSdk(temporality))
+	dest.SetIsMonotonic(isMonotonic)
+}
+
+func temporalityFromSdk(temporality metricdata.Temporality) pmetric.AggregationTemporality {
+	switch temporality {
+	case metricdata.CumulativeTemporality:
+		return pmetric.AggregationTemporalityCumulative
+	case metricdata.DeltaTemporality:
+		return pmetric.AggregationTemporalityDelta
+	}
+	return pmetric.AggregationTemporalityUnspecified
+}
+
+func numberDataPointsFromSdk[N int64 | float64](dest pmetric.NumberDataPointSlice, dps []metricdata.DataPoint[N]) {
+	for _, dp := range dps {
+		ndp := dest.AppendEmpty()
+		attributesFromSdk(ndp.Attributes(), dp.Attributes.Iter())
+		ndp.SetStartTimestamp(pcommon.NewTimestampFromTime(dp.StartTime))
+		ndp.SetTimestamp(pcommon.NewTimestampFromTime(dp.Time))
+		switch v := any(dp.Value).(type) {
+		case int64:
+			ndp.SetIntValue(v)
+		case float64:
+			ndp.SetDoubleValue(v)
+		}
+	}
+}
+
+func histogramDataPointsFromSdk[N int64 | float64](dest pmetric.HistogramDataPointSlice, dps []metricdata.HistogramDataPoint[N]) {
+	for _, dp := range dps {
+		hdp := dest.AppendEmpty()
+		attributesFromSdk(hdp.Attributes(), dp.Attributes.Iter())
+		hdp.SetStartTimestamp(pcommon.NewTimestampFromTime(dp.StartTime))
+		hdp.SetTimestamp(pcommon.NewTimestampFromTime(dp.Time))
+		hdp.SetCount(dp.Count)
+		hdp.SetSum(float64(dp.Sum))
+		hdp.ExplicitBounds().FromRaw(dp.Bounds)
+		hdp.BucketCounts().FromRaw(dp.BucketCounts)
+		if v, ok := dp.Min.Value(); ok {
+			hdp.SetMin(float64(v))
+		}
+		if v, ok := dp.Max.Value(); ok {
+			hdp.SetMax(float64(v))
+		}
+	}
+}
+
+func exponentialHistogramDataPointsFromSdk[N int64 | float64](dest pmetric.ExponentialHistogramDataPointSlice, dps []metricdata.ExponentialHistogramDataPoint[N]) {
+	for _, dp := range dps {
+		edp := dest.AppendEmpty()
+		attributesFromSdk(edp.Attributes(), dp.Attributes.Iter())
+		edp.SetStartTimestamp(pcommon.NewTimestampFromTime(dp.StartTime))
+		edp.SetTimestamp(pcommon.NewTimestampFromTime(dp.Time))
+		edp.SetCount(dp.Count)
+		edp.SetSum(float64(dp.Sum))
+		edp.SetScale(dp.Scale)
+		edp.SetZeroCount(dp.ZeroCount)
+		edp.Positive().SetOffset(dp.PositiveBucket.Offset)
+		edp.Positive().BucketCounts().FromRaw(dp.PositiveBucket.Counts)
+		edp.Negative().SetOffset(dp.NegativeBucket.Offset)
+		edp.Negative().BucketCounts().FromRaw(dp.NegativeBucket.Counts)
+		if v, ok := dp.Min.Value(); ok {
+			edp.SetMin(float64(v))
+		}
+		if v, ok := dp.Max.Value(); ok {
+			edp.SetMax(float64(v))
+		}
+	}
+}
+
+func attributesFromSdk(dest pcommon.Map, iter attribute.Iterator) {
+	dest.EnsureCapacity(iter.Len())
+	for iter.Next() {
+		kv := iter.Attribute()
+		switch kv.Value.Type() {
+		case attribute.BOOL:
+			dest.PutBool(string(kv.Key), kv.Value.AsBool())
+		case attribute.INT64:
+			dest.PutInt(string(kv.Key), kv.Value.AsInt64())
+		case attribute.FLOAT64:
+			dest.PutDouble(string(kv.Key), kv.Value.AsFloat64())
+		default:
+			dest.PutStr(string(kv.Key), kv.Value.Emit())
+		}
+	}
+}
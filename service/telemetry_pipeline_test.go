@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/service/pipelines"
+	"go.opentelemetry.io/collector/service/telemetry"
+)
+
+func TestMetricsFromSdk(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	res := resource.NewSchemaless(attribute.String("service.name", "otelcol"))
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader), sdkmetric.WithResource(res))
+	meter := mp.Meter("test", metric.WithInstrumentationVersion("v1"))
+
+	counter, err := meter.Int64Counter("counter", metric.WithUnit("1"), metric.WithDescription("A counter"))
+	require.NoError(t, err)
+	counter.Add(context.Background(), 2, metric.WithAttributes(attribute.String("exporter", "otlp"), attribute.Bool("sampled", true)))
+	upDown, err := meter.Float64UpDownCounter("updown")
+	require.NoError(t, err)
+	upDown.Add(context.Background(), -1.5)
+	histogram, err := meter.Int64Histogram("histogram")
+	require.NoError(t, err)
+	histogram.Record(context.Background(), 3)
+	histogram.Record(context.Background(), 7)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	md := metricsFromSdk(&rm)
+
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	rms := md.ResourceMetrics().At(0)
+	serviceName, ok := rms.Resource().Attributes().Get("service.name")
+	require.True(t, ok)
+	assert.Equal(t, "otelcol", serviceName.Str())
+	require.Equal(t, 1, rms.ScopeMetrics().Len())
+	sms := rms.ScopeMetrics().At(0)
+	assert.Equal(t, "test", sms.Scope().Name())
+	assert.Equal(t, "v1", sms.Scope().Version())
+
+	metrics := map[string]pmetric.Metric{}
+	for i := 0; i < sms.Metrics().Len(); i++ {
+		metrics[sms.Metrics().At(i).Name()] = sms.Metrics().At(i)
+	}
+	require.Len(t, metrics, 3)
+
+	sum := metrics["counter"]
+	assert.Equal(t, "1", sum.Unit())
+	assert.Equal(t, "A counter", sum.Description())
+	require.Equal(t, pmetric.MetricTypeSum, sum.Type())
+	assert.True(t, sum.Sum().IsMonotonic())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, sum.Sum().AggregationTemporality())
+	require.Equal(t, 1, sum.Sum().DataPoints().Len())
+	dp := sum.Sum().DataPoints().At(0)
+	assert.Equal(t, int64(2), dp.IntValue())
+	assert.Equal(t, map[string]any{"exporter": "otlp", "sampled": true}, dp.Attributes().AsRaw())
+	assert.NotZero(t, dp.Timestamp())
+
+	upDownSum := metrics["updown"]
+	require.Equal(t, pmetric.MetricTypeSum, upDownSum.Type())
+	assert.False(t, upDownSum.Sum().IsMonotonic())
+	assert.Equal(t, -1.5, upDownSum.Sum().DataPoints().At(0).DoubleValue())
+
+	hist := metrics["histogram"]
+	require.Equal(t, pmetric.MetricTypeHistogram, hist.Type())
+	hdp := hist.Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(2), hdp.Count())
+	assert.Equal(t, float64(10), hdp.Sum())
+	assert.Equal(t, float64(3), hdp.Min())
+	assert.Equal(t, float64(7), hdp.Max())
+	assert.Equal(t, hdp.ExplicitBounds().Len()+1, hdp.BucketCounts().Len())
+}
+
+func TestPipelineMetricsExporter(t *testing.T) {
+	exp := &pipelineMetricsExporter{}
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	counter, err := mp.Meter("test").Int64Counter("counter")
+	require.NoError(t, err)
+	counter.Add(context.Background(), 1)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	// The metrics are dropped while the pipeline is not running.
+	require.NoError(t, exp.Export(context.Background(), &rm))
+
+	sink := new(consumertest.MetricsSink)
+	exp.setNext(sink)
+	require.NoError(t, exp.Export(context.Background(), &rm))
+	assert.Equal(t, 1, sink.DataPointCount())
+
+	exp.setNext(nil)
+	require.NoError(t, exp.Export(context.Background(), &rm))
+	assert.Equal(t, 1, sink.DataPointCount())
+}
+
+type sinkExporter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	*consumertest.MetricsSink
+}
+
+func TestServiceTelemetryPipeline(t *testing.T) {
+	for _, useOtel := range []bool{false, true} {
+		t.Run(map[bool]string{false: "opencensus", true: "opentelemetry"}[useOtel], func(t *testing.T) {
+			sink := new(consumertest.MetricsSink)
+			sinkFactory := exporter.NewFactory("sink", func() component.Config { return &struct{}{} },
+				exporter.WithMetrics(func(context.Context, exporter.CreateSettings, component.Config) (exporter.Metrics, error) {
+					return &sinkExporter{MetricsSink: sink}, nil
+				}, component.StabilityLevelStable))
+
+			set := newNopSettings()
+			set.useOtel = &useOtel
+			set.Exporters = exporter.NewBuilder(
+				map[component.ID]component.Config{
+					component.NewID("nop"):  &struct{}{},
+					component.NewID("sink"): &struct{}{},
+				},
+				map[component.Type]exporter.Factory{
+					"nop":  set.Exporters.Factory("nop").(exporter.Factory),
+					"sink": sinkFactory,
+				})
+
+			internalID := component.NewIDWithName("metrics", "internal")
+			cfg := newNopConfig()
+			cfg.Pipelines[internalID] = &pipelines.PipelineConfig{
+				Exporters: []component.ID{component.NewID("sink")},
+			}
+			cfg.Telemetry.Metrics.Address = ""
+			cfg.Telemetry.Metrics.Pipeline = &telemetry.MetricsPipelineConfig{ID: internalID, Interval: 10 * time.Millisecond}
+			require.NoError(t, cfg.Validate())
+
+			srv, err := New(context.Background(), set, cfg)
+			require.NoError(t, err)
+			require.NoError(t, srv.Start(context.Background()))
+
+			require.Eventually(t, func() bool {
+				return sink.DataPointCount() > 0
+			}, 5*time.Second, 10*time.Millisecond)
+			require.NoError(t, srv.Shutdown(context.Background()))
+
+			// No metrics are routed through the pipeline once shut down.
+			count := sink.DataPointCount()
+			time.Sleep(50 * time.Millisecond)
+			assert.Equal(t, count, sink.DataPointCount())
+
+			md := sink.AllMetrics()[0]
+			serviceName, ok := md.ResourceMetrics().At(0).Resource().Attributes().Get("service.name")
+			require.True(t, ok)
+			assert.Equal(t, set.BuildInfo.Command, serviceName.Str())
+		})
+	}
+}
+
+var _ consumer.Metrics = (*sinkExporter)(nil)
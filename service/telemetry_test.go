@@ -188,6 +188,60 @@ func TestTelemetryInit(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "UseOpenTelemetryWithResourceLabels",
+			useOtel: true,
+			cfg: &telemetry.Config{
+				Resource: map[string]*string{
+					semconv.AttributeServiceInstanceID: &testInstanceID,
+				},
+				Metrics: telemetry.MetricsConfig{
+					Level:   configtelemetry.LevelDetailed,
+					Address: testutil.GetAvailableLocalAddress(t),
+					Prometheus: telemetry.PrometheusConfig{
+						ResourceLabels: []string{semconv.AttributeServiceInstanceID},
+					},
+				},
+			},
+			expectedMetrics: map[string]metricValue{
+				metricPrefix + ocPrefix + counterName + "_total": {
+					value: 13,
+					labels: map[string]string{
+						"service_instance_id": testInstanceID,
+					},
+				},
+				metricPrefix + otelPrefix + counterName + "_total": {
+					value: 13,
+					labels: map[string]string{
+						"service_instance_id": testInstanceID,
+					},
+				},
+				metricPrefix + grpcPrefix + counterName + "_total": {
+					value: 11,
+					labels: map[string]string{
+						"net_sock_peer_addr":  "",
+						"net_sock_peer_name":  "",
+						"net_sock_peer_port":  "",
+						"service_instance_id": testInstanceID,
+					},
+				},
+				metricPrefix + httpPrefix + counterName + "_total": {
+					value: 10,
+					labels: map[string]string{
+						"net_host_name":       "",
+						"net_host_port":       "",
+						"service_instance_id": testInstanceID,
+					},
+				},
+				metricPrefix + "target_info": {
+					value: 0,
+					labels: map[string]string{
+						"service_name":    "otelcol",
+						"service_version": "latest",
+					},
+				},
+			},
+		},
 		{
 			name:           "UseOTelWithSDKConfiguration",
 			extendedConfig: true,
@@ -1,16 +1,22 @@
 // Copyright The OpenTelemetry Authors
 // SPDX-License-Identifier: Apache-2.0
 
+//go:build !otelcol_nozpages
+
 package service // import "go.opentelemetry.io/collector/service"
 
 import (
+	"crypto/subtle"
 	"net/http"
 	"path"
 	"runtime"
+	"strconv"
+	"strings"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/service/internal/zpages"
 )
 
@@ -20,6 +26,10 @@ const (
 	zPipelinePath  = "pipelinez"
 	zExtensionPath = "extensionz"
 	zFeaturePath   = "featurez"
+	zExporterPath  = "exporterz"
+	zFlushPath     = "flushz"
+	zScrapePath    = "scrapez"
+	zFailurePath   = "failurez"
 )
 
 var (
@@ -41,7 +51,11 @@ func (host *serviceHost) RegisterZPages(mux *http.ServeMux, pathPrefix string) {
 	mux.HandleFunc(path.Join(pathPrefix, zServicePath), host.zPagesRequest)
 	mux.HandleFunc(path.Join(pathPrefix, zPipelinePath), host.pipelines.HandleZPages)
 	mux.HandleFunc(path.Join(pathPrefix, zExtensionPath), host.serviceExtensions.HandleZPages)
-	mux.HandleFunc(path.Join(pathPrefix, zFeaturePath), handleFeaturezRequest)
+	mux.HandleFunc(path.Join(pathPrefix, zFeaturePath), host.handleFeaturezRequest)
+	mux.HandleFunc(path.Join(pathPrefix, zExporterPath), host.pipelines.HandleExportersZPages)
+	mux.HandleFunc(path.Join(pathPrefix, zFlushPath), host.handleFlushzRequest)
+	mux.HandleFunc(path.Join(pathPrefix, zScrapePath), host.handleScrapezRequest)
+	mux.HandleFunc(path.Join(pathPrefix, zFailurePath), handleFailurezRequest)
 }
 
 func (host *serviceHost) zPagesRequest(w http.ResponseWriter, _ *http.Request) {
@@ -49,11 +63,23 @@ func (host *serviceHost) zPagesRequest(w http.ResponseWriter, _ *http.Request) {
 	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Service " + host.buildInfo.Command})
 	zpages.WriteHTMLPropertiesTable(w, zpages.PropertiesTableData{Name: "Build Info", Properties: getBuildInfoProperties(host.buildInfo)})
 	zpages.WriteHTMLPropertiesTable(w, zpages.PropertiesTableData{Name: "Runtime Info", Properties: runtimeInfoVar})
+	if f := host.disabledComponent; f != nil {
+		zpages.WriteHTMLPropertiesTable(w, zpages.PropertiesTableData{Name: "Degraded Mode", Properties: [][2]string{
+			{"Disabled Component", f.Kind + " " + f.ID},
+			{"Failures", strconv.Itoa(f.Count)},
+			{"Error", f.Error},
+		}})
+	}
 	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
 		Name:              "Pipelines",
 		ComponentEndpoint: zPipelinePath,
 		Link:              true,
 	})
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "Exporters",
+		ComponentEndpoint: zExporterPath,
+		Link:              true,
+	})
 	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
 		Name:              "Extensions",
 		ComponentEndpoint: zExtensionPath,
@@ -64,27 +90,178 @@ func (host *serviceHost) zPagesRequest(w http.ResponseWriter, _ *http.Request) {
 		ComponentEndpoint: zFeaturePath,
 		Link:              true,
 	})
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "Flush",
+		ComponentEndpoint: zFlushPath,
+		Link:              true,
+	})
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "Scrape",
+		ComponentEndpoint: zScrapePath,
+		Link:              true,
+	})
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "Failures",
+		ComponentEndpoint: zFailurePath,
+		Link:              true,
+	})
 	zpages.WriteHTMLPageFooter(w)
 }
 
-func handleFeaturezRequest(w http.ResponseWriter, _ *http.Request) {
+// handleFlushzRequest shows the form to flush the pipelines, and flushes them on POST
+// if the control token is given, either in the form or as a bearer token.
+func (host *serviceHost) handleFlushzRequest(w http.ResponseWriter, r *http.Request) {
+	data := zpages.FlushFormData{Enabled: host.controlToken != ""}
+	status := http.StatusOK
+	if r.Method == http.MethodPost {
+		switch {
+		case !data.Enabled:
+			status = http.StatusForbidden
+		case !host.validControlToken(r):
+			status = http.StatusUnauthorized
+			data.Result = "Invalid control token."
+		default:
+			if err := host.pipelines.FlushAll(r.Context()); err != nil {
+				status = http.StatusInternalServerError
+				data.Result = "Failed to flush: " + err.Error()
+			} else {
+				data.Result = "Flushed at " + time.Now().Format(time.RFC3339) + "."
+			}
+		}
+	}
+
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Flush"})
+	zpages.WriteHTMLFlushForm(w, data)
+	zpages.WriteHTMLPageFooter(w)
+}
+
+// handleScrapezRequest lists the receivers able to scrape on demand, and makes the given receiver scrape
+// immediately on POST if the control token is given, either in the form or as a bearer token.
+func (host *serviceHost) handleScrapezRequest(w http.ResponseWriter, r *http.Request) {
+	data := zpages.ScrapeFormData{Enabled: host.controlToken != ""}
+	for _, id := range host.pipelines.ScrapableReceivers() {
+		data.Receivers = append(data.Receivers, id.String())
+	}
+	status := http.StatusOK
+	if r.Method == http.MethodPost {
+		var id component.ID
+		switch {
+		case !data.Enabled:
+			status = http.StatusForbidden
+		case !host.validControlToken(r):
+			status = http.StatusUnauthorized
+			data.Result = "Invalid control token."
+		case id.UnmarshalText([]byte(r.PostFormValue("receiver"))) != nil:
+			status = http.StatusBadRequest
+			data.Result = "Invalid receiver."
+		default:
+			if err := host.pipelines.ScrapeNow(r.Context(), id); err != nil {
+				status = http.StatusInternalServerError
+				data.Result = "Failed to scrape: " + err.Error()
+			} else {
+				data.Result = "Scraped " + id.String() + " at " + time.Now().Format(time.RFC3339) + "."
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Scrape"})
+	zpages.WriteHTMLScrapeForm(w, data)
+	zpages.WriteHTMLPageFooter(w)
+}
+
+func (host *serviceHost) validControlToken(r *http.Request) bool {
+	token := r.PostFormValue("token")
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		token = strings.TrimPrefix(auth, "Bearer ")
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(host.controlToken)) == 1
+}
+
+// handleFeaturezRequest lists the feature gates, and toggles the given runtime togglable gate on POST
+// if the control token is given, either in the form or as a bearer token.
+func (host *serviceHost) handleFeaturezRequest(w http.ResponseWriter, r *http.Request) {
+	controlEnabled := host.controlToken != ""
+	var result string
+	status := http.StatusOK
+	if r.Method == http.MethodPost {
+		id := r.PostFormValue("gate")
+		enabled, parseErr := strconv.ParseBool(r.PostFormValue("enabled"))
+		switch {
+		case !controlEnabled:
+			status = http.StatusForbidden
+		case !host.validControlToken(r):
+			status = http.StatusUnauthorized
+			result = "Invalid control token."
+		case id == "" || parseErr != nil:
+			status = http.StatusBadRequest
+			result = "Invalid feature gate change."
+		default:
+			setFeatureGate := host.setFeatureGate
+			if setFeatureGate == nil {
+				setFeatureGate = featuregate.GlobalRegistry().SetAtRuntime
+			}
+			if err := setFeatureGate(id, enabled); err != nil {
+				status = http.StatusConflict
+				result = "Failed to toggle: " + err.Error()
+			} else {
+				result = "Set " + id + " to " + strconv.FormatBool(enabled) + " at " + time.Now().Format(time.RFC3339) + "."
+			}
+		}
+	}
+
+	data := getFeaturesTableData()
+	data.ControlEnabled = controlEnabled
+	data.Result = result
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
 	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Feature Gates"})
-	zpages.WriteHTMLFeaturesTable(w, getFeaturesTableData())
+	zpages.WriteHTMLFeaturesTable(w, data)
+	zpages.WriteHTMLPageFooter(w)
+}
+
+// handleFailurezRequest shows the last operations in which the components refused, or failed to send, data.
+func handleFailurezRequest(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Recent Failures"})
+	zpages.WriteHTMLFailuresTable(w, getFailuresTableData())
 	zpages.WriteHTMLPageFooter(w)
 }
 
+func getFailuresTableData() zpages.FailuresTableData {
+	data := zpages.FailuresTableData{}
+	for _, f := range obsreport.RecentFailures() {
+		data.Rows = append(data.Rows, zpages.FailuresTableRowData{
+			Time:     f.Time.Format(time.RFC3339Nano),
+			Kind:     f.Kind,
+			ID:       f.ID,
+			DataType: string(f.DataType),
+			Items:    f.Items,
+			Error:    f.Error,
+			TraceID:  f.TraceID,
+			SpanID:   f.SpanID,
+		})
+	}
+	return data
+}
+
 func getFeaturesTableData() zpages.FeatureGateTableData {
 	data := zpages.FeatureGateTableData{}
 	featuregate.GlobalRegistry().VisitAll(func(gate *featuregate.Gate) {
 		data.Rows = append(data.Rows, zpages.FeatureGateTableRowData{
-			ID:           gate.ID(),
-			Enabled:      gate.IsEnabled(),
-			Description:  gate.Description(),
-			Stage:        gate.Stage().String(),
-			FromVersion:  gate.FromVersion(),
-			ToVersion:    gate.ToVersion(),
-			ReferenceURL: gate.ReferenceURL(),
+			ID:               gate.ID(),
+			Enabled:          gate.IsEnabled(),
+			RuntimeTogglable: gate.IsRuntimeTogglable(),
+			Source:           gate.Source().String(),
+			Description:      gate.Description(),
+			Stage:            gate.Stage().String(),
+			Owner:            gate.Owner(),
+			FromVersion:      gate.FromVersion(),
+			ToVersion:        gate.ToVersion(),
+			ReferenceURL:     gate.ReferenceURL(),
 		})
 	})
 	return data
@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !otelcol_nozpages
+
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+func TestFlushzRequest(t *testing.T) {
+	set := newNopSettings()
+	set.ControlToken = "secret"
+	srv, err := New(context.Background(), set, newNopConfig())
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(context.Background()))
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Shutdown(context.Background()))
+	})
+
+	rr := httptest.NewRecorder()
+	srv.host.handleFlushzRequest(rr, httptest.NewRequest(http.MethodGet, "/debug/flushz", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `<form method="post">`)
+
+	post := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/debug/flushz", strings.NewReader(url.Values{"token": {token}}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+		srv.host.handleFlushzRequest(rr, req)
+		return rr
+	}
+	assert.Equal(t, http.StatusUnauthorized, post("wrong").Code)
+	rr = post("secret")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), "Flushed at")
+
+	req := httptest.NewRequest(http.MethodPost, "/debug/flushz", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rr = httptest.NewRecorder()
+	srv.host.handleFlushzRequest(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestFlushzRequestDisabled(t *testing.T) {
+	srv, err := New(context.Background(), newNopSettings(), newNopConfig())
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(context.Background()))
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Shutdown(context.Background()))
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/debug/flushz", nil)
+	req.Header.Set("Authorization", "Bearer ")
+	rr := httptest.NewRecorder()
+	srv.host.handleFlushzRequest(rr, req)
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	assert.Contains(t, rr.Body.String(), "control actions are disabled")
+}
+
+func TestScrapezRequest(t *testing.T) {
+	set := newNopSettings()
+	set.ControlToken = "secret"
+	srv, err := New(context.Background(), set, newNopConfig())
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(context.Background()))
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Shutdown(context.Background()))
+	})
+
+	rr := httptest.NewRecorder()
+	srv.host.handleScrapezRequest(rr, httptest.NewRequest(http.MethodGet, "/debug/scrapez", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), "No receiver scrapes on demand.")
+
+	post := func(token string, receiver string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/debug/scrapez", strings.NewReader(url.Values{"token": {token}, "receiver": {receiver}}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+		srv.host.handleScrapezRequest(rr, req)
+		return rr
+	}
+	assert.Equal(t, http.StatusUnauthorized, post("wrong", "nop").Code)
+	assert.Equal(t, http.StatusBadRequest, post("secret", "").Code)
+	rr = post("secret", "nop")
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Contains(t, rr.Body.String(), "does not scrape on demand")
+}
+
+func TestFeaturezRequest(t *testing.T) {
+	gate := featuregate.GlobalRegistry().MustRegister("service.test.featurez", featuregate.StageAlpha, featuregate.WithRegisterRuntimeToggle())
+	static := featuregate.GlobalRegistry().MustRegister("service.test.featurezStatic", featuregate.StageAlpha)
+	host := &serviceHost{controlToken: "secret"}
+
+	rr := httptest.NewRecorder()
+	host.handleFeaturezRequest(rr, httptest.NewRequest(http.MethodGet, "/debug/featurez", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `<input type="hidden" name="gate" value="service.test.featurez">`)
+	assert.NotContains(t, rr.Body.String(), `<input type="hidden" name="gate" value="service.test.featurezStatic">`)
+
+	post := func(token, id, enabled string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/debug/featurez", strings.NewReader(url.Values{"token": {token}, "gate": {id}, "enabled": {enabled}}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+		host.handleFeaturezRequest(rr, req)
+		return rr
+	}
+	assert.Equal(t, http.StatusUnauthorized, post("wrong", gate.ID(), "true").Code)
+	assert.Equal(t, http.StatusBadRequest, post("secret", gate.ID(), "maybe").Code)
+	assert.Equal(t, http.StatusConflict, post("secret", static.ID(), "true").Code)
+	assert.False(t, static.IsEnabled())
+	rr = post("secret", gate.ID(), "true")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), "Set service.test.featurez to true")
+	assert.True(t, gate.IsEnabled())
+	assert.Equal(t, http.StatusOK, post("secret", gate.ID(), "false").Code)
+	assert.False(t, gate.IsEnabled())
+
+	rr = httptest.NewRecorder()
+	(&serviceHost{}).handleFeaturezRequest(rr, httptest.NewRequest(http.MethodPost, "/debug/featurez", nil))
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+
+	// the gates are toggled through the host, e.g. by the collector logging the change
+	var changes []string
+	host.setFeatureGate = func(id string, enabled bool) error {
+		changes = append(changes, id+"="+strconv.FormatBool(enabled))
+		return featuregate.GlobalRegistry().SetAtRuntime(id, enabled)
+	}
+	assert.Equal(t, http.StatusOK, post("secret", gate.ID(), "true").Code)
+	assert.True(t, gate.IsEnabled())
+	assert.Equal(t, http.StatusConflict, post("secret", static.ID(), "true").Code)
+	assert.Equal(t, []string{"service.test.featurez=true", "service.test.featurezStatic=true"}, changes)
+	require.NoError(t, featuregate.GlobalRegistry().SetAtRuntime(gate.ID(), false))
+}
+
+func TestFailurezRequest(t *testing.T) {
+	set := exportertest.NewNopCreateSettings()
+	exp, err := obsreport.NewExporter(obsreport.ExporterSettings{ExporterID: component.NewID("failing"), ExporterCreateSettings: set})
+	require.NoError(t, err)
+	exp.EndLogsOp(exp.StartLogsOp(context.Background()), 12, errors.New("connection refused"))
+
+	rr := httptest.NewRecorder()
+	handleFailurezRequest(rr, httptest.NewRequest(http.MethodGet, "/debug/failurez", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), "exporter failing")
+	assert.Contains(t, rr.Body.String(), "connection refused")
+}